@@ -0,0 +1,97 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package alert
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/components"
+)
+
+// Severity is the severity of an alert.
+type Severity string
+
+const (
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+// Alert is a structured alert posted to the notifiers.
+type Alert struct {
+	// Rule is the name of the rule raising the alert, e.g. "model_error".
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Title    string   `json:"title"`
+	Message  string   `json:"message,omitempty"`
+	// Component, Type and Name are those of the run info of the callback raising the alert.
+	Component components.Component `json:"component,omitempty"`
+	Type      string               `json:"type,omitempty"`
+	Name      string               `json:"name,omitempty"`
+	// Fields are the details of the alert, e.g. "latency_ms" or "cost".
+	Fields map[string]any `json:"fields,omitempty"`
+	// Key deduplicates the alerts, alerts with the same key being sent at most once per dedup interval.
+	// Optional. Default: the rule, component, type and name of the alert.
+	Key  string    `json:"-"`
+	Time time.Time `json:"time"`
+	// Suppressed is the number of alerts with the same key suppressed since the last one was sent.
+	Suppressed int `json:"suppressed,omitempty"`
+}
+
+func (a *Alert) dedupKey() string {
+	if len(a.Key) > 0 {
+		return a.Key
+	}
+	return strings.Join([]string{a.Rule, string(a.Component), a.Type, a.Name}, "/")
+}
+
+// String formats the alert as a plain text message.
+func (a *Alert) String() string {
+	sb := &strings.Builder{}
+	sb.WriteString(fmt.Sprintf("[%s] %s", strings.ToUpper(string(a.Severity)), a.Title))
+	if source := a.source(); len(source) > 0 {
+		sb.WriteString("\nsource: " + source)
+	}
+	if len(a.Message) > 0 {
+		sb.WriteString("\n" + a.Message)
+	}
+	keys := make([]string, 0, len(a.Fields))
+	for k := range a.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		sb.WriteString(fmt.Sprintf("\n%s: %v", k, a.Fields[k]))
+	}
+	sb.WriteString("\ntime: " + a.Time.Format(time.RFC3339))
+	if a.Suppressed > 0 {
+		sb.WriteString(fmt.Sprintf("\n(%d similar alerts suppressed)", a.Suppressed))
+	}
+	return sb.String()
+}
+
+func (a *Alert) source() string {
+	parts := make([]string, 0, 3)
+	for _, p := range []string{string(a.Component), a.Type, a.Name} {
+		if len(p) > 0 {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, "/")
+}
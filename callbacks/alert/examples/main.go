@@ -0,0 +1,104 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/compose"
+
+	"github.com/cloudwego/eino-ext/callbacks/alert"
+)
+
+func main() {
+	ctx := context.Background()
+
+	var notifiers []alert.Notifier
+	if url := os.Getenv("SLACK_WEBHOOK_URL"); len(url) > 0 {
+		slack, err := alert.NewSlackNotifier(&alert.SlackConfig{WebhookURL: url})
+		if err != nil {
+			log.Fatal(err)
+		}
+		notifiers = append(notifiers, slack)
+	}
+	if url := os.Getenv("LARK_WEBHOOK_URL"); len(url) > 0 {
+		lark, err := alert.NewLarkNotifier(&alert.LarkConfig{WebhookURL: url, Secret: os.Getenv("LARK_SECRET")})
+		if err != nil {
+			log.Fatal(err)
+		}
+		notifiers = append(notifiers, lark)
+	}
+	notifiers = append(notifiers, &logNotifier{})
+
+	cost, err := alert.CostRule(&alert.CostConfig{
+		PromptPrice:     2.5, // per 1M tokens
+		CompletionPrice: 10,
+		CallThreshold:   0.5,
+		WindowThreshold: 50,
+		Window:          time.Hour,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	handler, err := alert.NewHandler(&alert.Config{
+		Notifiers: notifiers,
+		Rules: []alert.Rule{
+			alert.ErrorRule(components.ComponentOfChatModel, compose.ComponentOfLambda),
+			alert.LatencyRule(30*time.Second, components.ComponentOfChatModel),
+			alert.GuardrailBlockRule(),
+			cost,
+		},
+		DedupInterval: 5 * time.Minute,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer handler.Close()
+	callbacks.AppendGlobalHandlers(handler)
+
+	g := compose.NewGraph[string, string]()
+	_ = g.AddLambdaNode("flaky", compose.InvokableLambda(func(ctx context.Context, input string) (string, error) {
+		return "", errors.New("upstream unavailable")
+	}), compose.WithNodeName("flaky"))
+	_ = g.AddEdge(compose.START, "flaky")
+	_ = g.AddEdge("flaky", compose.END)
+	runner, err := g.Compile(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// the second failure is deduplicated
+	for i := 0; i < 2; i++ {
+		if _, err = runner.Invoke(ctx, "input"); err != nil {
+			log.Printf("invoke error: %v", err)
+		}
+	}
+}
+
+type logNotifier struct{}
+
+func (l *logNotifier) Notify(_ context.Context, a *alert.Alert) error {
+	log.Printf("alert:\n%s", a.String())
+	return nil
+}
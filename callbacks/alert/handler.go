@@ -0,0 +1,297 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+type Config struct {
+	// Notifiers post the alerts, e.g. NewWebhookNotifier, NewSlackNotifier or NewLarkNotifier.
+	// Required
+	Notifiers []Notifier
+	// Rules raise the alerts, e.g. ErrorRule, LatencyRule, GuardrailBlockRule or CostRule.
+	// Required
+	Rules []Rule
+	// DedupInterval is the minimal interval between two alerts with the same key, the alerts in between being suppressed.
+	// Optional. Default: 5 minutes
+	DedupInterval time.Duration
+	// QueueSize is the number of alerts buffered for the notifiers, alerts being dropped when the queue is full.
+	// Optional. Default: 100
+	QueueSize int
+	// NotifyTimeout bounds the time to post an alert with a notifier.
+	// Optional. Default: 10 seconds
+	NotifyTimeout time.Duration
+	// OnNotifyError is called when a notifier fails or an alert is dropped.
+	// Optional. Default: logs the error
+	OnNotifyError func(alert *Alert, err error)
+}
+
+const (
+	defaultDedupInterval = 5 * time.Minute
+	defaultQueueSize     = 100
+	defaultNotifyTimeout = 10 * time.Second
+)
+
+// NewHandler creates a callback handler posting alerts to the notifiers when the rules are met.
+// The alerts are posted in background, call Close to flush the pending alerts before exiting.
+func NewHandler(config *Config) (*Handler, error) {
+	if config == nil {
+		return nil, errors.New("config is required")
+	}
+	if len(config.Notifiers) == 0 {
+		return nil, errors.New("notifiers are required")
+	}
+	if len(config.Rules) == 0 {
+		return nil, errors.New("rules are required")
+	}
+
+	h := &Handler{
+		notifiers:     config.Notifiers,
+		rules:         config.Rules,
+		dedupInterval: config.DedupInterval,
+		notifyTimeout: config.NotifyTimeout,
+		onNotifyError: config.OnNotifyError,
+		dedup:         make(map[string]*dedupState),
+		now:           time.Now,
+	}
+	if h.dedupInterval <= 0 {
+		h.dedupInterval = defaultDedupInterval
+	}
+	if h.notifyTimeout <= 0 {
+		h.notifyTimeout = defaultNotifyTimeout
+	}
+	if h.onNotifyError == nil {
+		h.onNotifyError = func(alert *Alert, err error) {
+			log.Printf("alert notify error: %v, alert: %s", err, alert.Title)
+		}
+	}
+	queueSize := config.QueueSize
+	if queueSize <= 0 {
+		queueSize = defaultQueueSize
+	}
+	h.queue = make(chan *Alert, queueSize)
+
+	h.wg.Add(1)
+	go h.run()
+	return h, nil
+}
+
+// Handler is the alerting callback handler.
+type Handler struct {
+	notifiers     []Notifier
+	rules         []Rule
+	dedupInterval time.Duration
+	notifyTimeout time.Duration
+	onNotifyError func(alert *Alert, err error)
+
+	mu     sync.Mutex
+	dedup  map[string]*dedupState
+	closed bool
+
+	queue chan *Alert
+	wg    sync.WaitGroup
+	now   func() time.Time
+}
+
+type dedupState struct {
+	lastSent   time.Time
+	suppressed int
+}
+
+type startTimeKey struct{}
+
+func (h *Handler) Needed(_ context.Context, info *callbacks.RunInfo, timing callbacks.CallbackTiming) bool {
+	if timing == callbacks.TimingOnEndWithStreamOutput {
+		// only the streams of chat models are read, for their latency and token usage
+		return info != nil && info.Component == components.ComponentOfChatModel
+	}
+	return true
+}
+
+func (h *Handler) OnStart(ctx context.Context, _ *callbacks.RunInfo, _ callbacks.CallbackInput) context.Context {
+	return context.WithValue(ctx, startTimeKey{}, h.now())
+}
+
+func (h *Handler) OnEnd(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+	e := h.newEvent(ctx, info)
+	e.Output = output
+	if info != nil && info.Component == components.ComponentOfChatModel {
+		if mo := model.ConvCallbackOutput(output); mo != nil {
+			e.TokenUsage = mo.TokenUsage
+		}
+	}
+	h.evaluate(ctx, e)
+	return ctx
+}
+
+func (h *Handler) OnError(ctx context.Context, info *callbacks.RunInfo, err error) context.Context {
+	e := h.newEvent(ctx, info)
+	e.Err = err
+	h.evaluate(ctx, e)
+	return ctx
+}
+
+func (h *Handler) OnStartWithStreamInput(ctx context.Context, _ *callbacks.RunInfo,
+	input *schema.StreamReader[callbacks.CallbackInput]) context.Context {
+
+	input.Close()
+	return context.WithValue(ctx, startTimeKey{}, h.now())
+}
+
+func (h *Handler) OnEndWithStreamOutput(ctx context.Context, info *callbacks.RunInfo,
+	output *schema.StreamReader[callbacks.CallbackOutput]) context.Context {
+
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				log.Printf("alert handler panic: %v\n%s", p, debug.Stack())
+			}
+		}()
+		defer output.Close()
+
+		var usage *model.TokenUsage
+		for {
+			chunk, err := output.Recv()
+			if err != nil {
+				// errors of the stream are reported by the component
+				break
+			}
+			if mo := model.ConvCallbackOutput(chunk); mo != nil && mo.TokenUsage != nil {
+				usage = mo.TokenUsage
+			}
+		}
+
+		e := h.newEvent(ctx, info)
+		e.TokenUsage = usage
+		h.evaluate(ctx, e)
+	}()
+	return ctx
+}
+
+func (h *Handler) newEvent(ctx context.Context, info *callbacks.RunInfo) *Event {
+	e := &Event{Info: info, Time: h.now()}
+	if start, ok := ctx.Value(startTimeKey{}).(time.Time); ok {
+		e.Latency = e.Time.Sub(start)
+	}
+	return e
+}
+
+func (h *Handler) evaluate(ctx context.Context, e *Event) {
+	for _, rule := range h.rules {
+		alert := rule(ctx, e)
+		if alert == nil {
+			continue
+		}
+		if e.Info != nil {
+			alert.Component, alert.Type, alert.Name = e.Info.Component, e.Info.Type, e.Info.Name
+		}
+		if alert.Time.IsZero() {
+			alert.Time = e.Time
+		}
+		h.send(alert)
+	}
+}
+
+// send enqueues the alert unless an alert with the same key was sent within the dedup interval.
+func (h *Handler) send(alert *Alert) {
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		return
+	}
+	key := alert.dedupKey()
+	state, ok := h.dedup[key]
+	if ok && alert.Time.Sub(state.lastSent) < h.dedupInterval {
+		state.suppressed++
+		h.mu.Unlock()
+		return
+	}
+	if !ok {
+		state = &dedupState{}
+		h.dedup[key] = state
+		h.sweep(alert.Time)
+	}
+	alert.Suppressed = state.suppressed
+	state.lastSent, state.suppressed = alert.Time, 0
+
+	select {
+	case h.queue <- alert:
+		h.mu.Unlock()
+	default:
+		h.mu.Unlock()
+		h.onNotifyError(alert, errors.New("alert queue is full, alert dropped"))
+	}
+}
+
+// sweep removes the expired dedup states, so that the keys do not grow unbounded.
+func (h *Handler) sweep(now time.Time) {
+	if len(h.dedup) < 1024 {
+		return
+	}
+	for k, s := range h.dedup {
+		if now.Sub(s.lastSent) >= h.dedupInterval && s.suppressed == 0 {
+			delete(h.dedup, k)
+		}
+	}
+}
+
+func (h *Handler) run() {
+	defer h.wg.Done()
+	for alert := range h.queue {
+		for _, n := range h.notifiers {
+			if err := h.notify(n, alert); err != nil {
+				h.onNotifyError(alert, err)
+			}
+		}
+	}
+}
+
+func (h *Handler) notify(n Notifier, alert *Alert) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("notifier panic: %v", p)
+		}
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), h.notifyTimeout)
+	defer cancel()
+	return n.Notify(ctx, alert)
+}
+
+// Close stops accepting alerts and waits for the pending alerts to be posted.
+func (h *Handler) Close() {
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		return
+	}
+	h.closed = true
+	close(h.queue)
+	h.mu.Unlock()
+	h.wg.Wait()
+}
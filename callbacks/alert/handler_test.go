@@ -0,0 +1,204 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package alert
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeNotifier struct {
+	mu     sync.Mutex
+	alerts []*Alert
+	err    error
+	block  chan struct{}
+}
+
+func (n *fakeNotifier) Notify(_ context.Context, alert *Alert) error {
+	if n.block != nil {
+		<-n.block
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.alerts = append(n.alerts, alert)
+	return n.err
+}
+
+func (n *fakeNotifier) get() []*Alert {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]*Alert{}, n.alerts...)
+}
+
+type panicNotifier struct{}
+
+func (panicNotifier) Notify(context.Context, *Alert) error {
+	panic("boom")
+}
+
+func TestNewHandler(t *testing.T) {
+	_, err := NewHandler(nil)
+	assert.Error(t, err)
+	_, err = NewHandler(&Config{Rules: []Rule{ErrorRule()}})
+	assert.Error(t, err)
+	_, err = NewHandler(&Config{Notifiers: []Notifier{&fakeNotifier{}}})
+	assert.Error(t, err)
+}
+
+func TestHandler(t *testing.T) {
+	n := &fakeNotifier{}
+	var mu sync.Mutex
+	var notifyErrs []string
+	h, err := NewHandler(&Config{
+		Notifiers: []Notifier{n, panicNotifier{}},
+		Rules: []Rule{
+			ErrorRule(components.ComponentOfChatModel),
+			LatencyRule(time.Second, components.ComponentOfChatModel),
+		},
+		DedupInterval: time.Minute,
+		OnNotifyError: func(alert *Alert, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			notifyErrs = append(notifyErrs, err.Error())
+		},
+	})
+	assert.NoError(t, err)
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	h.now = func() time.Time { return now }
+	info := &callbacks.RunInfo{Name: "gpt", Type: "OpenAI", Component: components.ComponentOfChatModel}
+
+	// latency
+	ctx := h.OnStart(context.Background(), info, &model.CallbackInput{})
+	now = now.Add(2 * time.Second)
+	h.OnEnd(ctx, info, &model.CallbackOutput{})
+
+	// errors, deduplicated
+	for i := 0; i < 3; i++ {
+		h.OnError(context.Background(), info, errors.New("rate limited"))
+		now = now.Add(10 * time.Second)
+	}
+	now = now.Add(time.Minute)
+	h.OnError(context.Background(), info, errors.New("rate limited"))
+
+	// other components
+	h.OnError(context.Background(), &callbacks.RunInfo{Component: components.ComponentOfTool}, errors.New("failed"))
+
+	h.Close()
+	h.OnError(context.Background(), info, errors.New("after close"))
+
+	alerts := n.get()
+	assert.Len(t, alerts, 3)
+	assert.Equal(t, "latency", alerts[0].Rule)
+	assert.Equal(t, int64(2000), alerts[0].Fields["latency_ms"])
+	assert.Equal(t, components.ComponentOfChatModel, alerts[0].Component)
+	assert.Equal(t, "OpenAI", alerts[0].Type)
+	assert.Equal(t, "gpt", alerts[0].Name)
+	assert.Equal(t, "error", alerts[1].Rule)
+	assert.Equal(t, 0, alerts[1].Suppressed)
+	assert.Equal(t, "error", alerts[2].Rule)
+	assert.Equal(t, 2, alerts[2].Suppressed)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"notifier panic: boom", "notifier panic: boom", "notifier panic: boom"}, notifyErrs)
+}
+
+func TestHandlerQueueFull(t *testing.T) {
+	n := &fakeNotifier{block: make(chan struct{})}
+	var dropped int
+	h, err := NewHandler(&Config{
+		Notifiers:     []Notifier{n},
+		Rules:         []Rule{ErrorRule()},
+		QueueSize:     1,
+		OnNotifyError: func(alert *Alert, err error) { dropped++ },
+	})
+	assert.NoError(t, err)
+
+	for _, name := range []string{"a", "b", "c", "d"} {
+		h.OnError(context.Background(), &callbacks.RunInfo{Name: name}, errors.New("failed"))
+	}
+	close(n.block)
+	h.Close()
+
+	// one alert being notified, one queued
+	assert.GreaterOrEqual(t, len(n.get()), 1)
+	assert.Equal(t, 4, len(n.get())+dropped)
+}
+
+func TestHandlerStream(t *testing.T) {
+	n := &fakeNotifier{}
+	cost, err := CostRule(&CostConfig{PromptPrice: 1000, CompletionPrice: 1000, CallThreshold: 1})
+	assert.NoError(t, err)
+	h, err := NewHandler(&Config{
+		Notifiers: []Notifier{n},
+		Rules:     []Rule{cost},
+	})
+	assert.NoError(t, err)
+
+	info := &callbacks.RunInfo{Name: "gpt", Component: components.ComponentOfChatModel}
+	assert.True(t, h.Needed(context.Background(), info, callbacks.TimingOnEndWithStreamOutput))
+	assert.False(t, h.Needed(context.Background(), &callbacks.RunInfo{Component: components.ComponentOfTool}, callbacks.TimingOnEndWithStreamOutput))
+
+	ctx := h.OnStartWithStreamInput(context.Background(), info, schema.StreamReaderFromArray([]callbacks.CallbackInput{&model.CallbackInput{}}))
+	h.OnEndWithStreamOutput(ctx, info, schema.StreamReaderFromArray([]callbacks.CallbackOutput{
+		&model.CallbackOutput{Message: schema.AssistantMessage("hello", nil)},
+		&model.CallbackOutput{Message: schema.AssistantMessage(" world", nil), TokenUsage: &model.TokenUsage{PromptTokens: 1000, CompletionTokens: 500}},
+	}))
+
+	assert.Eventually(t, func() bool { return len(n.get()) == 1 }, time.Second, 10*time.Millisecond)
+	h.Close()
+	assert.Equal(t, "cost", n.get()[0].Rule)
+	assert.InDelta(t, 1.5, n.get()[0].Fields["cost"], 1e-9)
+}
+
+func TestHandlerGraph(t *testing.T) {
+	n := &fakeNotifier{}
+	h, err := NewHandler(&Config{
+		Notifiers: []Notifier{n},
+		Rules:     []Rule{ErrorRule(compose.ComponentOfLambda)},
+	})
+	assert.NoError(t, err)
+
+	g := compose.NewGraph[string, string]()
+	assert.NoError(t, g.AddLambdaNode("node", compose.InvokableLambda(func(ctx context.Context, input string) (string, error) {
+		return "", errors.New("lambda failed")
+	}), compose.WithNodeName("node")))
+	assert.NoError(t, g.AddEdge(compose.START, "node"))
+	assert.NoError(t, g.AddEdge("node", compose.END))
+	runner, err := g.Compile(context.Background())
+	assert.NoError(t, err)
+
+	_, err = runner.Invoke(context.Background(), "input", compose.WithCallbacks(h))
+	assert.Error(t, err)
+	h.Close()
+
+	alerts := n.get()
+	assert.Len(t, alerts, 1)
+	assert.Equal(t, "node", alerts[0].Name)
+	assert.Equal(t, "lambda failed", alerts[0].Message)
+}
@@ -0,0 +1,202 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package alert
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Notifier posts alerts, see NewWebhookNotifier, NewSlackNotifier and NewLarkNotifier.
+type Notifier interface {
+	Notify(ctx context.Context, alert *Alert) error
+}
+
+type WebhookConfig struct {
+	// URL is the webhook url the alerts are posted to as json.
+	// Required
+	URL string
+	// Headers are added to the requests, e.g. for authentication.
+	// Optional. Default: nil
+	Headers map[string]string
+	// HTTPClient sends the requests.
+	// Optional. Default: &http.Client{Timeout: 10 * time.Second}
+	HTTPClient *http.Client
+}
+
+// NewWebhookNotifier creates a notifier posting the alerts as json to a generic webhook.
+func NewWebhookNotifier(config *WebhookConfig) (Notifier, error) {
+	if config == nil || len(config.URL) == 0 {
+		return nil, errors.New("webhook url is required")
+	}
+	return &webhookNotifier{
+		url:     config.URL,
+		headers: config.Headers,
+		cli:     httpClient(config.HTTPClient),
+	}, nil
+}
+
+type webhookNotifier struct {
+	url     string
+	headers map[string]string
+	cli     *http.Client
+}
+
+func (n *webhookNotifier) Notify(ctx context.Context, alert *Alert) error {
+	_, err := post(ctx, n.cli, n.url, n.headers, alert)
+	return err
+}
+
+type SlackConfig struct {
+	// WebhookURL is the url of the slack incoming webhook.
+	// Required
+	WebhookURL string
+	// HTTPClient sends the requests.
+	// Optional. Default: &http.Client{Timeout: 10 * time.Second}
+	HTTPClient *http.Client
+}
+
+// NewSlackNotifier creates a notifier posting the alerts to a slack incoming webhook.
+func NewSlackNotifier(config *SlackConfig) (Notifier, error) {
+	if config == nil || len(config.WebhookURL) == 0 {
+		return nil, errors.New("slack webhook url is required")
+	}
+	return &slackNotifier{
+		url: config.WebhookURL,
+		cli: httpClient(config.HTTPClient),
+	}, nil
+}
+
+type slackNotifier struct {
+	url string
+	cli *http.Client
+}
+
+func (n *slackNotifier) Notify(ctx context.Context, alert *Alert) error {
+	_, err := post(ctx, n.cli, n.url, nil, map[string]any{"text": alert.String()})
+	return err
+}
+
+type LarkConfig struct {
+	// WebhookURL is the url of the lark/feishu custom bot webhook.
+	// Required
+	WebhookURL string
+	// Secret signs the requests, when the signature verification of the bot is enabled.
+	// Optional. Default: ""
+	Secret string
+	// HTTPClient sends the requests.
+	// Optional. Default: &http.Client{Timeout: 10 * time.Second}
+	HTTPClient *http.Client
+}
+
+// NewLarkNotifier creates a notifier posting the alerts to a lark/feishu custom bot.
+func NewLarkNotifier(config *LarkConfig) (Notifier, error) {
+	if config == nil || len(config.WebhookURL) == 0 {
+		return nil, errors.New("lark webhook url is required")
+	}
+	return &larkNotifier{
+		url:    config.WebhookURL,
+		secret: config.Secret,
+		cli:    httpClient(config.HTTPClient),
+		now:    time.Now,
+	}, nil
+}
+
+type larkNotifier struct {
+	url    string
+	secret string
+	cli    *http.Client
+	now    func() time.Time
+}
+
+func (n *larkNotifier) Notify(ctx context.Context, alert *Alert) error {
+	body := map[string]any{
+		"msg_type": "text",
+		"content":  map[string]string{"text": alert.String()},
+	}
+	if len(n.secret) > 0 {
+		timestamp := strconv.FormatInt(n.now().Unix(), 10)
+		body["timestamp"] = timestamp
+		body["sign"] = larkSign(timestamp, n.secret)
+	}
+
+	respBody, err := post(ctx, n.cli, n.url, nil, body)
+	if err != nil {
+		return err
+	}
+	// lark responds 200 with a non-zero code on failures
+	resp := &struct {
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
+	}{}
+	if err = json.Unmarshal(respBody, resp); err == nil && resp.Code != 0 {
+		return fmt.Errorf("lark webhook error, code: %d, msg: %s", resp.Code, resp.Msg)
+	}
+	return nil
+}
+
+func larkSign(timestamp, secret string) string {
+	mac := hmac.New(sha256.New, []byte(timestamp+"\n"+secret))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func httpClient(cli *http.Client) *http.Client {
+	if cli != nil {
+		return cli
+	}
+	return &http.Client{Timeout: 10 * time.Second}
+}
+
+func post(ctx context.Context, cli *http.Client, url string, headers map[string]string, body any) ([]byte, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal alert: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := cli.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to post alert: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(respBody))
+	}
+	return respBody, nil
+}
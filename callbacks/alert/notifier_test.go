@@ -0,0 +1,130 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type captured struct {
+	header http.Header
+	body   map[string]any
+}
+
+func newServer(t *testing.T, status int, resp string) (*httptest.Server, chan *captured) {
+	ch := make(chan *captured, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		body := map[string]any{}
+		assert.NoError(t, json.Unmarshal(b, &body))
+		ch <- &captured{header: r.Header, body: body}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(resp))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, ch
+}
+
+func testAlert() *Alert {
+	return &Alert{
+		Rule:      "error",
+		Severity:  SeverityCritical,
+		Title:     "ChatModel error",
+		Message:   "rate limited",
+		Component: "ChatModel",
+		Type:      "OpenAI",
+		Name:      "gpt",
+		Fields:    map[string]any{"b": 2, "a": 1},
+		Time:      time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
+
+func TestAlertString(t *testing.T) {
+	a := testAlert()
+	a.Suppressed = 3
+	assert.Equal(t, "[CRITICAL] ChatModel error\nsource: ChatModel/OpenAI/gpt\nrate limited\na: 1\nb: 2\ntime: 2025-01-02T03:04:05Z\n(3 similar alerts suppressed)", a.String())
+	assert.Equal(t, "error/ChatModel/OpenAI/gpt", a.dedupKey())
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	_, err := NewWebhookNotifier(&WebhookConfig{})
+	assert.Error(t, err)
+
+	srv, ch := newServer(t, http.StatusOK, "")
+	n, err := NewWebhookNotifier(&WebhookConfig{URL: srv.URL, Headers: map[string]string{"Authorization": "Bearer token"}})
+	assert.NoError(t, err)
+	assert.NoError(t, n.Notify(context.Background(), testAlert()))
+
+	c := <-ch
+	assert.Equal(t, "Bearer token", c.header.Get("Authorization"))
+	assert.Equal(t, "error", c.body["rule"])
+	assert.Equal(t, "critical", c.body["severity"])
+	assert.Equal(t, "gpt", c.body["name"])
+	assert.Equal(t, map[string]any{"a": 1.0, "b": 2.0}, c.body["fields"])
+
+	failing, _ := newServer(t, http.StatusInternalServerError, "oops")
+	n, err = NewWebhookNotifier(&WebhookConfig{URL: failing.URL})
+	assert.NoError(t, err)
+	assert.ErrorContains(t, n.Notify(context.Background(), testAlert()), "unexpected status code: 500")
+}
+
+func TestSlackNotifier(t *testing.T) {
+	_, err := NewSlackNotifier(nil)
+	assert.Error(t, err)
+
+	srv, ch := newServer(t, http.StatusOK, "ok")
+	n, err := NewSlackNotifier(&SlackConfig{WebhookURL: srv.URL})
+	assert.NoError(t, err)
+	assert.NoError(t, n.Notify(context.Background(), testAlert()))
+	assert.Equal(t, map[string]any{"text": testAlert().String()}, (<-ch).body)
+}
+
+func TestLarkNotifier(t *testing.T) {
+	_, err := NewLarkNotifier(&LarkConfig{})
+	assert.Error(t, err)
+
+	srv, ch := newServer(t, http.StatusOK, `{"code":0,"msg":"success"}`)
+	n, err := NewLarkNotifier(&LarkConfig{WebhookURL: srv.URL, Secret: "secret"})
+	assert.NoError(t, err)
+	n.(*larkNotifier).now = func() time.Time { return time.Unix(1700000000, 0) }
+	assert.NoError(t, n.Notify(context.Background(), testAlert()))
+
+	c := <-ch
+	assert.Equal(t, "text", c.body["msg_type"])
+	assert.Equal(t, map[string]any{"text": testAlert().String()}, c.body["content"])
+	assert.Equal(t, "1700000000", c.body["timestamp"])
+	assert.Equal(t, larkSign("1700000000", "secret"), c.body["sign"])
+
+	failing, _ := newServer(t, http.StatusOK, `{"code":19021,"msg":"sign match fail"}`)
+	n, err = NewLarkNotifier(&LarkConfig{WebhookURL: failing.URL})
+	assert.NoError(t, err)
+	assert.EqualError(t, n.Notify(context.Background(), testAlert()), "lark webhook error, code: 19021, msg: sign match fail")
+}
+
+func TestLarkSign(t *testing.T) {
+	// base64 of the HmacSHA256 keyed by "<timestamp>\n<secret>" over an empty message
+	assert.Equal(t, "NVcdmaRhTlOqmXksontxmKEP4AYWAMD5VOczwSqarks=", larkSign("1599360473", "test"))
+}
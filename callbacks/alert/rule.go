@@ -0,0 +1,242 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/model"
+)
+
+// componentOfGuardrails is the component of the callbacks of github.com/cloudwego/eino-ext/flow/guardrails.
+const componentOfGuardrails components.Component = "Guardrails"
+
+// guardrailsBlock is implemented by the blocked errors and the callback outputs of github.com/cloudwego/eino-ext/flow/guardrails,
+// so that the guardrails are recognized without requiring the module.
+type guardrailsBlock interface {
+	GuardrailsBlock() (stage string, categories []string, blocked bool)
+}
+
+func isGuardrailsBlock(err error) bool {
+	var b guardrailsBlock
+	if !errors.As(err, &b) {
+		return false
+	}
+	_, _, blocked := b.GuardrailsBlock()
+	return blocked
+}
+
+// Event is a completed or failed run of a component, evaluated by the rules.
+type Event struct {
+	Info *callbacks.RunInfo
+	// Err is the error of a failed run, nil otherwise.
+	Err error
+	// Output is the output of a completed run, nil for a failed or streamed run.
+	Output callbacks.CallbackOutput
+	// Latency is the duration of the run, until the end of the output stream for streamed runs of chat models.
+	// Zero if the start of the run is unknown.
+	Latency time.Duration
+	// TokenUsage is the token usage of a chat model run, if reported by the model.
+	TokenUsage *model.TokenUsage
+	Time       time.Time
+}
+
+// Rule evaluates an event, returning the alert to send or nil.
+// Rules are called concurrently, and must be safe for concurrent use.
+type Rule func(ctx context.Context, e *Event) *Alert
+
+// ErrorRule alerts on the errors of the given components, e.g. components.ComponentOfChatModel, or of all components if none given.
+// Contents blocked by guardrails are not reported, see GuardrailBlockRule.
+func ErrorRule(cs ...components.Component) Rule {
+	return func(_ context.Context, e *Event) *Alert {
+		if e.Err == nil || isGuardrailsBlock(e.Err) || !matchComponent(e.Info, cs) {
+			return nil
+		}
+		return &Alert{
+			Rule:     "error",
+			Severity: SeverityCritical,
+			Title:    fmt.Sprintf("%s error", componentName(e.Info)),
+			Message:  e.Err.Error(),
+		}
+	}
+}
+
+// LatencyRule alerts when a run of the given components, or of all components if none given, takes longer than threshold.
+func LatencyRule(threshold time.Duration, cs ...components.Component) Rule {
+	return func(_ context.Context, e *Event) *Alert {
+		if e.Err != nil || e.Latency <= threshold || !matchComponent(e.Info, cs) {
+			return nil
+		}
+		return &Alert{
+			Rule:     "latency",
+			Severity: SeverityWarning,
+			Title:    fmt.Sprintf("%s latency over threshold", componentName(e.Info)),
+			Fields: map[string]any{
+				"latency_ms":   e.Latency.Milliseconds(),
+				"threshold_ms": threshold.Milliseconds(),
+			},
+		}
+	}
+}
+
+// GuardrailBlockRule alerts when a content is blocked by the guardrails of github.com/cloudwego/eino-ext/flow/guardrails.
+func GuardrailBlockRule() Rule {
+	return func(_ context.Context, e *Event) *Alert {
+		if e.Info == nil || e.Info.Component != componentOfGuardrails {
+			return nil
+		}
+		output, ok := e.Output.(guardrailsBlock)
+		if !ok {
+			return nil
+		}
+		stage, categories, blocked := output.GuardrailsBlock()
+		if !blocked {
+			return nil
+		}
+		fields := map[string]any{"stage": stage}
+		if len(categories) > 0 {
+			fields["categories"] = strings.Join(categories, ", ")
+		}
+		return &Alert{
+			Rule:     "guardrail_block",
+			Severity: SeverityWarning,
+			Title:    fmt.Sprintf("%s content blocked by guardrails", stage),
+			Fields:   fields,
+			Key:      "guardrail_block/" + stage,
+		}
+	}
+}
+
+type CostConfig struct {
+	// PromptPrice is the price of 1M prompt tokens.
+	// Required
+	PromptPrice float64
+	// CompletionPrice is the price of 1M completion tokens.
+	// Required
+	CompletionPrice float64
+	// CallThreshold alerts when the cost of a single chat model call exceeds it.
+	// Optional. Default: 0, disabled
+	CallThreshold float64
+	// WindowThreshold alerts when the total cost of the chat model calls over Window exceeds it.
+	// Optional. Default: 0, disabled
+	WindowThreshold float64
+	// Window is the sliding window of WindowThreshold.
+	// Optional. Default: 1 hour
+	Window time.Duration
+}
+
+// CostRule alerts on cost spikes of the chat models, computed from the token usage they report.
+func CostRule(config *CostConfig) (Rule, error) {
+	if config == nil {
+		return nil, errors.New("cost config is required")
+	}
+	if config.CallThreshold <= 0 && config.WindowThreshold <= 0 {
+		return nil, errors.New("call threshold or window threshold is required")
+	}
+	window := config.Window
+	if window <= 0 {
+		window = time.Hour
+	}
+
+	type record struct {
+		time time.Time
+		cost float64
+	}
+	var (
+		mu      sync.Mutex
+		records []record
+		total   float64
+	)
+
+	return func(_ context.Context, e *Event) *Alert {
+		if e.TokenUsage == nil {
+			return nil
+		}
+		cost := (float64(e.TokenUsage.PromptTokens)*config.PromptPrice +
+			float64(e.TokenUsage.CompletionTokens)*config.CompletionPrice) / 1e6
+
+		if config.CallThreshold > 0 && cost > config.CallThreshold {
+			return &Alert{
+				Rule:     "cost",
+				Severity: SeverityWarning,
+				Title:    fmt.Sprintf("%s call cost over threshold", componentName(e.Info)),
+				Fields: map[string]any{
+					"cost":              cost,
+					"threshold":         config.CallThreshold,
+					"prompt_tokens":     e.TokenUsage.PromptTokens,
+					"completion_tokens": e.TokenUsage.CompletionTokens,
+				},
+			}
+		}
+		if config.WindowThreshold <= 0 {
+			return nil
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		records = append(records, record{time: e.Time, cost: cost})
+		total += cost
+		expired := 0
+		for expired < len(records) && e.Time.Sub(records[expired].time) > window {
+			total -= records[expired].cost
+			expired++
+		}
+		records = records[expired:]
+		if total <= config.WindowThreshold {
+			return nil
+		}
+		return &Alert{
+			Rule:     "cost_window",
+			Severity: SeverityCritical,
+			Title:    fmt.Sprintf("chat model cost over threshold in the last %v", window),
+			Fields: map[string]any{
+				"cost":      total,
+				"threshold": config.WindowThreshold,
+				"calls":     len(records),
+			},
+			Key: "cost_window",
+		}
+	}, nil
+}
+
+func matchComponent(info *callbacks.RunInfo, cs []components.Component) bool {
+	if len(cs) == 0 {
+		return true
+	}
+	return info != nil && slices.Contains(cs, info.Component)
+}
+
+func componentName(info *callbacks.RunInfo) string {
+	if info == nil {
+		return "component"
+	}
+	if len(info.Component) > 0 {
+		return string(info.Component)
+	}
+	if len(info.Type) > 0 {
+		return info.Type
+	}
+	return info.Name
+}
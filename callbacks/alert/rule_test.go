@@ -0,0 +1,128 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeBlock implements the GuardrailsBlock method of the errors and callback outputs of flow/guardrails.
+type fakeBlock struct {
+	stage      string
+	categories []string
+	blocked    bool
+}
+
+func (f *fakeBlock) GuardrailsBlock() (string, []string, bool) {
+	return f.stage, f.categories, f.blocked
+}
+
+func (f *fakeBlock) Error() string {
+	return f.stage + " content blocked by guardrails"
+}
+
+func TestErrorRule(t *testing.T) {
+	ctx := context.Background()
+	modelInfo := &callbacks.RunInfo{Name: "gpt", Type: "OpenAI", Component: components.ComponentOfChatModel}
+	toolInfo := &callbacks.RunInfo{Name: "search", Component: components.ComponentOfTool}
+
+	rule := ErrorRule(components.ComponentOfChatModel)
+	a := rule(ctx, &Event{Info: modelInfo, Err: errors.New("rate limited")})
+	assert.Equal(t, &Alert{Rule: "error", Severity: SeverityCritical, Title: "ChatModel error", Message: "rate limited"}, a)
+	assert.Nil(t, rule(ctx, &Event{Info: toolInfo, Err: errors.New("failed")}))
+	assert.Nil(t, rule(ctx, &Event{Info: modelInfo}))
+	assert.Nil(t, rule(ctx, &Event{Info: modelInfo, Err: fmt.Errorf("wrapped: %w", &fakeBlock{stage: "input", blocked: true})}))
+
+	assert.NotNil(t, ErrorRule()(ctx, &Event{Info: toolInfo, Err: errors.New("failed")}))
+	assert.NotNil(t, ErrorRule()(ctx, &Event{Err: errors.New("failed")}))
+	assert.Nil(t, rule(ctx, &Event{Err: errors.New("failed")}))
+}
+
+func TestLatencyRule(t *testing.T) {
+	ctx := context.Background()
+	info := &callbacks.RunInfo{Name: "gpt", Component: components.ComponentOfChatModel}
+	rule := LatencyRule(time.Second, components.ComponentOfChatModel)
+
+	assert.Nil(t, rule(ctx, &Event{Info: info, Latency: time.Second}))
+	assert.Nil(t, rule(ctx, &Event{Info: info, Latency: 2 * time.Second, Err: errors.New("failed")}))
+	assert.Nil(t, rule(ctx, &Event{Info: &callbacks.RunInfo{Component: components.ComponentOfTool}, Latency: 2 * time.Second}))
+	a := rule(ctx, &Event{Info: info, Latency: 1500 * time.Millisecond})
+	assert.Equal(t, "latency", a.Rule)
+	assert.Equal(t, map[string]any{"latency_ms": int64(1500), "threshold_ms": int64(1000)}, a.Fields)
+}
+
+func TestGuardrailBlockRule(t *testing.T) {
+	ctx := context.Background()
+	info := &callbacks.RunInfo{Name: "Guardrails", Component: "Guardrails"}
+	rule := GuardrailBlockRule()
+
+	a := rule(ctx, &Event{Info: info, Output: &fakeBlock{stage: "output", categories: []string{"pii", "hate"}, blocked: true}})
+	assert.Equal(t, "guardrail_block", a.Rule)
+	assert.Equal(t, "guardrail_block/output", a.Key)
+	assert.Equal(t, map[string]any{"stage": "output", "categories": "pii, hate"}, a.Fields)
+
+	assert.Nil(t, rule(ctx, &Event{Info: info, Output: &fakeBlock{stage: "output"}}))
+	assert.Nil(t, rule(ctx, &Event{Info: &callbacks.RunInfo{Component: components.ComponentOfChatModel}, Output: "out"}))
+}
+
+func TestCostRule(t *testing.T) {
+	ctx := context.Background()
+	_, err := CostRule(nil)
+	assert.Error(t, err)
+	_, err = CostRule(&CostConfig{PromptPrice: 1})
+	assert.Error(t, err)
+
+	info := &callbacks.RunInfo{Name: "gpt", Component: components.ComponentOfChatModel}
+	rule, err := CostRule(&CostConfig{
+		PromptPrice:     2,
+		CompletionPrice: 8,
+		CallThreshold:   0.1,
+		WindowThreshold: 0.15,
+		Window:          time.Minute,
+	})
+	assert.NoError(t, err)
+
+	now := time.Now()
+	usage := func(prompt, completion int) *model.TokenUsage {
+		return &model.TokenUsage{PromptTokens: prompt, CompletionTokens: completion}
+	}
+
+	assert.Nil(t, rule(ctx, &Event{Info: info}))
+	// 10000*2/1e6 + 20000*8/1e6 = 0.18
+	a := rule(ctx, &Event{Info: info, TokenUsage: usage(10000, 20000), Time: now})
+	assert.Equal(t, "cost", a.Rule)
+	assert.InDelta(t, 0.18, a.Fields["cost"], 1e-9)
+
+	// 0.06 each
+	assert.Nil(t, rule(ctx, &Event{Info: info, TokenUsage: usage(10000, 5000), Time: now.Add(2 * time.Minute)}))
+	assert.Nil(t, rule(ctx, &Event{Info: info, TokenUsage: usage(10000, 5000), Time: now.Add(2*time.Minute + time.Second)}))
+	a = rule(ctx, &Event{Info: info, TokenUsage: usage(10000, 5000), Time: now.Add(2*time.Minute + 2*time.Second)})
+	assert.Equal(t, "cost_window", a.Rule)
+	assert.InDelta(t, 0.18, a.Fields["cost"], 1e-9)
+	assert.Equal(t, 3, a.Fields["calls"])
+
+	assert.Nil(t, rule(ctx, &Event{Info: info, TokenUsage: usage(10000, 5000), Time: now.Add(10 * time.Minute)}))
+}
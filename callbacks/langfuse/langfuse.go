@@ -24,13 +24,15 @@ import (
 	"time"
 
 	"github.com/bytedance/sonic"
-	"github.com/cloudwego/eino-ext/libs/acl/langfuse"
+	"github.com/cloudwego/eino-ext/callbacks/langfuse/internal/langfuse"
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components"
 	"github.com/cloudwego/eino/components/model"
 	"github.com/cloudwego/eino/schema"
 )
 
+//go:generate sh ../../libs/acl/bundle.sh langfuse internal/langfuse
+
 type Config struct {
 	// Host is the Langfuse server URL (Required)
 	// Example: "https://cloud.langfuse.com"
@@ -24,8 +24,8 @@ import (
 	"testing"
 
 	"github.com/bytedance/mockey"
-	"github.com/cloudwego/eino-ext/libs/acl/langfuse"
-	"github.com/cloudwego/eino-ext/libs/acl/langfuse/mock"
+	"github.com/cloudwego/eino-ext/callbacks/langfuse/internal/langfuse"
+	"github.com/cloudwego/eino-ext/callbacks/langfuse/internal/langfuse/mock"
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components"
 	"github.com/cloudwego/eino/components/model"
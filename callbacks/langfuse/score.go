@@ -0,0 +1,122 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package langfuse
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cloudwego/eino-ext/callbacks/langfuse/internal/langfuse"
+)
+
+// FeedbackScoreName is the score name used by CallbackHandler.Feedback
+const FeedbackScoreName = "user-feedback"
+
+// GetTraceInfo returns the langfuse trace id and the id of the current observation(span or generation) of ctx,
+// it can be called in nodes, tools and lambdas to attach scores to the running trace or observation.
+// To score a trace after the graph returns, set a known trace id by SetTrace(ctx, WithID(id)) before running it.
+func GetTraceInfo(ctx context.Context) (traceID, observationID string, ok bool) {
+	state, ok := ctx.Value(langfuseStateKey{}).(*langfuseState)
+	if !ok || state == nil {
+		return "", "", false
+	}
+	return state.traceID, state.observationID, true
+}
+
+type ScoreOption func(*scoreOptions)
+
+// WithScoreID sets the id of the score, scores with the same id are updated instead of duplicated
+func WithScoreID(id string) ScoreOption {
+	return func(o *scoreOptions) {
+		o.ID = id
+	}
+}
+
+// WithScoreObservationID attaches the score to an observation of the trace instead of the whole trace
+func WithScoreObservationID(observationID string) ScoreOption {
+	return func(o *scoreOptions) {
+		o.ObservationID = observationID
+	}
+}
+
+func WithScoreComment(comment string) ScoreOption {
+	return func(o *scoreOptions) {
+		o.Comment = comment
+	}
+}
+
+// WithScoreConfigID validates the score against a score config defined in langfuse
+func WithScoreConfigID(configID string) ScoreOption {
+	return func(o *scoreOptions) {
+		o.ConfigID = configID
+	}
+}
+
+func WithScoreMetadata(metadata map[string]string) ScoreOption {
+	return func(o *scoreOptions) {
+		o.Metadata = metadata
+	}
+}
+
+type scoreOptions struct {
+	ID            string
+	ObservationID string
+	Comment       string
+	ConfigID      string
+	Metadata      map[string]string
+}
+
+// Score submits a numeric score, e.g. a rating from 1 to 5, to the trace
+func (c *CallbackHandler) Score(traceID, name string, value float64, opts ...ScoreOption) (string, error) {
+	return c.createScore(traceID, name, value, langfuse.ScoreDataTypeNumeric, opts)
+}
+
+// CategoricalScore submits a categorical score, e.g. "helpful" or "off-topic", to the trace
+func (c *CallbackHandler) CategoricalScore(traceID, name, value string, opts ...ScoreOption) (string, error) {
+	return c.createScore(traceID, name, value, langfuse.ScoreDataTypeCategorical, opts)
+}
+
+// Feedback submits a thumbs up(positive) or thumbs down user feedback to the trace as a boolean score named FeedbackScoreName,
+// the comment of the user can be attached by WithScoreComment
+func (c *CallbackHandler) Feedback(traceID string, positive bool, opts ...ScoreOption) (string, error) {
+	return c.createScore(traceID, FeedbackScoreName, positive, langfuse.ScoreDataTypeBoolean, opts)
+}
+
+func (c *CallbackHandler) createScore(traceID, name string, value any, dataType langfuse.ScoreDataType, opts []ScoreOption) (string, error) {
+	options := &scoreOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	body := &langfuse.ScoreEventBody{
+		ID:            options.ID,
+		TraceID:       traceID,
+		ObservationID: options.ObservationID,
+		Name:          name,
+		Value:         value,
+		DataType:      dataType,
+		Comment:       options.Comment,
+		ConfigID:      options.ConfigID,
+	}
+	if len(options.Metadata) > 0 {
+		body.MetaData = options.Metadata
+	}
+	sc, ok := c.cli.(langfuse.ScoreClient)
+	if !ok {
+		return "", errors.New("langfuse client does not support scores")
+	}
+	return sc.CreateScore(body)
+}
@@ -0,0 +1,114 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package langfuse
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/eino-ext/callbacks/langfuse/internal/langfuse"
+	"github.com/cloudwego/eino-ext/callbacks/langfuse/internal/langfuse/mock"
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/compose"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTraceInfo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockLangfuse := mock.NewMockLangfuse(ctrl)
+	cbh := &CallbackHandler{cli: mockLangfuse}
+
+	_, _, ok := GetTraceInfo(context.Background())
+	assert.False(t, ok)
+
+	mockLangfuse.EXPECT().CreateTrace(gomock.Any()).DoAndReturn(func(body *langfuse.TraceEventBody) (string, error) {
+		return body.ID, nil
+	}).Times(1)
+	mockLangfuse.EXPECT().CreateSpan(gomock.Any()).Return("span id", nil).Times(1)
+
+	ctx := SetTrace(context.Background(), WithID("trace id"))
+	ctx = cbh.OnStart(ctx, &callbacks.RunInfo{Name: "node", Component: compose.ComponentOfLambda}, "input")
+	traceID, observationID, ok := GetTraceInfo(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "trace id", traceID)
+	assert.Equal(t, "span id", observationID)
+}
+
+type mockScoreLangfuse struct {
+	*mock.MockLangfuse
+	*mock.MockScoreClient
+}
+
+func TestScore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockScoreClient := mock.NewMockScoreClient(ctrl)
+	cbh := &CallbackHandler{cli: &mockScoreLangfuse{MockLangfuse: mock.NewMockLangfuse(ctrl), MockScoreClient: mockScoreClient}}
+
+	var bodies []*langfuse.ScoreEventBody
+	mockScoreClient.EXPECT().CreateScore(gomock.Any()).DoAndReturn(func(body *langfuse.ScoreEventBody) (string, error) {
+		bodies = append(bodies, body)
+		return "score id", nil
+	}).Times(3)
+
+	id, err := cbh.Score("trace id", "rating", 4,
+		WithScoreObservationID("generation id"),
+		WithScoreComment("accurate"),
+		WithScoreConfigID("config id"),
+		WithScoreMetadata(map[string]string{"source": "app"}),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "score id", id)
+	_, err = cbh.CategoricalScore("trace id", "intent", "refund")
+	assert.NoError(t, err)
+	_, err = cbh.Feedback("trace id", false, WithScoreID("feedback id"), WithScoreComment("wrong answer"))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []*langfuse.ScoreEventBody{
+		{
+			TraceID:       "trace id",
+			ObservationID: "generation id",
+			Name:          "rating",
+			Value:         4.0,
+			DataType:      langfuse.ScoreDataTypeNumeric,
+			Comment:       "accurate",
+			ConfigID:      "config id",
+			MetaData:      map[string]string{"source": "app"},
+		},
+		{
+			TraceID:  "trace id",
+			Name:     "intent",
+			Value:    "refund",
+			DataType: langfuse.ScoreDataTypeCategorical,
+		},
+		{
+			ID:       "feedback id",
+			TraceID:  "trace id",
+			Name:     FeedbackScoreName,
+			Value:    false,
+			DataType: langfuse.ScoreDataTypeBoolean,
+			Comment:  "wrong answer",
+		},
+	}, bodies)
+}
+
+func TestScore_Unsupported(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cbh := &CallbackHandler{cli: mock.NewMockLangfuse(ctrl)}
+	_, err := cbh.Feedback("trace id", true)
+	assert.EqualError(t, err, "langfuse client does not support scores")
+}
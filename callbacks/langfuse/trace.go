@@ -21,7 +21,7 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/cloudwego/eino-ext/libs/acl/langfuse"
+	"github.com/cloudwego/eino-ext/callbacks/langfuse/internal/langfuse"
 )
 
 type langfuseTraceOptionKey struct{}
@@ -0,0 +1,80 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/compose"
+
+	"github.com/cloudwego/eino-ext/callbacks/multiplexer"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// replace with the langfuse, opentelemetry and alerting handlers of your application
+	modelTracer := callbacks.NewHandlerBuilder().
+		OnStartFn(func(ctx context.Context, info *callbacks.RunInfo, input callbacks.CallbackInput) context.Context {
+			log.Printf("[model tracer] start %s", info.Name)
+			return ctx
+		}).Build()
+	allTracer := callbacks.NewHandlerBuilder().
+		OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+			log.Printf("[tracer] end %s/%s", info.Component, info.Name)
+			return ctx
+		}).Build()
+	alerter := callbacks.NewHandlerBuilder().
+		OnErrorFn(func(ctx context.Context, info *callbacks.RunInfo, err error) context.Context {
+			time.Sleep(time.Second) // a slow webhook
+			log.Printf("[alerter] %s failed: %v", info.Name, err)
+			return ctx
+		}).Build()
+
+	handler, err := multiplexer.NewHandler(&multiplexer.Config{
+		Routes: []*multiplexer.Route{
+			{Name: "model-tracer", Handler: modelTracer, Filter: multiplexer.Components(components.ComponentOfChatModel)},
+			{Name: "tracer", Handler: allTracer},
+			{Name: "alerter", Handler: alerter, Filter: multiplexer.ErrorsOnly(), Timeout: 100 * time.Millisecond},
+		},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	callbacks.AppendGlobalHandlers(handler)
+
+	g := compose.NewGraph[string, string]()
+	_ = g.AddLambdaNode("echo", compose.InvokableLambda(func(ctx context.Context, input string) (string, error) {
+		return input, nil
+	}), compose.WithNodeName("echo"))
+	_ = g.AddEdge(compose.START, "echo")
+	_ = g.AddEdge("echo", compose.END)
+	runner, err := g.Compile(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	out, err := runner.Invoke(ctx, "hello")
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("output: %s", out)
+}
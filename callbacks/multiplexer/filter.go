@@ -0,0 +1,90 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package multiplexer
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+)
+
+// Filter decides whether a callback event is dispatched to the handler of a route.
+type Filter func(ctx context.Context, info *callbacks.RunInfo, timing callbacks.CallbackTiming) bool
+
+// Components accepts the events of the given components, e.g. components.ComponentOfChatModel.
+func Components(cs ...components.Component) Filter {
+	return func(_ context.Context, info *callbacks.RunInfo, _ callbacks.CallbackTiming) bool {
+		if info == nil {
+			return false
+		}
+		for _, c := range cs {
+			if info.Component == c {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Timings accepts the events of the given timings.
+func Timings(timings ...callbacks.CallbackTiming) Filter {
+	return func(_ context.Context, _ *callbacks.RunInfo, timing callbacks.CallbackTiming) bool {
+		for _, t := range timings {
+			if timing == t {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ErrorsOnly accepts the OnError events.
+func ErrorsOnly() Filter {
+	return Timings(callbacks.TimingOnError)
+}
+
+// And accepts the events accepted by all the filters.
+func And(filters ...Filter) Filter {
+	return func(ctx context.Context, info *callbacks.RunInfo, timing callbacks.CallbackTiming) bool {
+		for _, f := range filters {
+			if !f(ctx, info, timing) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or accepts the events accepted by any of the filters.
+func Or(filters ...Filter) Filter {
+	return func(ctx context.Context, info *callbacks.RunInfo, timing callbacks.CallbackTiming) bool {
+		for _, f := range filters {
+			if f(ctx, info, timing) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not accepts the events rejected by the filter.
+func Not(filter Filter) Filter {
+	return func(ctx context.Context, info *callbacks.RunInfo, timing callbacks.CallbackTiming) bool {
+		return !filter(ctx, info, timing)
+	}
+}
@@ -0,0 +1,254 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package multiplexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/schema"
+)
+
+// Route dispatches the callback events accepted by Filter to Handler.
+type Route struct {
+	// Name identifies the route in the errors reported to Config.OnHandlerError.
+	// Optional. Default: "route[<index>]".
+	Name string
+	// Handler receives the events of the route.
+	// Required.
+	Handler callbacks.Handler
+	// Filter selects the events dispatched to Handler, see Components, Timings and ErrorsOnly.
+	// Optional. Default: all events.
+	Filter Filter
+	// Timeout bounds the time spent in Handler on the request path. When exceeded, the request goes on with the context
+	// passed to Handler, and Handler completes in background, so changes it makes to the context are lost.
+	// Optional. Default: 0, no timeout.
+	Timeout time.Duration
+}
+
+// Config is the config of the multiplexer handler.
+type Config struct {
+	// Routes are the handlers events are fanned out to, in the order eino runs handlers:
+	// OnStart in reverse order, other timings in order.
+	// Required.
+	Routes []*Route
+	// OnHandlerError is called when a handler panics or exceeds its timeout.
+	// Optional. Default: logs the error.
+	OnHandlerError func(ctx context.Context, info *callbacks.RunInfo, err error)
+}
+
+// NewHandler creates a callback handler fanning out events to multiple handlers.
+// Each handler only receives the events accepted by the filter of its route, and is isolated from the others:
+// a panic is recovered and reported, and a slow handler is cut off by the route timeout.
+func NewHandler(config *Config) (callbacks.Handler, error) {
+	if config == nil {
+		return nil, errors.New("config is required")
+	}
+	if len(config.Routes) == 0 {
+		return nil, errors.New("routes are required")
+	}
+
+	routes := make([]*route, 0, len(config.Routes))
+	for i, r := range config.Routes {
+		if r == nil || r.Handler == nil {
+			return nil, fmt.Errorf("handler of route[%d] is required", i)
+		}
+		name := r.Name
+		if len(name) == 0 {
+			name = fmt.Sprintf("route[%d]", i)
+		}
+		tc, _ := r.Handler.(callbacks.TimingChecker)
+		routes = append(routes, &route{
+			name:          name,
+			handler:       r.Handler,
+			timingChecker: tc,
+			filter:        r.Filter,
+			timeout:       r.Timeout,
+		})
+	}
+
+	onHandlerError := config.OnHandlerError
+	if onHandlerError == nil {
+		onHandlerError = func(_ context.Context, info *callbacks.RunInfo, err error) {
+			log.Printf("callback handler error: %v, runinfo: %+v", err, info)
+		}
+	}
+
+	return &handler{
+		routes:         routes,
+		onHandlerError: onHandlerError,
+	}, nil
+}
+
+type route struct {
+	name          string
+	handler       callbacks.Handler
+	timingChecker callbacks.TimingChecker
+	filter        Filter
+	timeout       time.Duration
+}
+
+func (r *route) accept(ctx context.Context, info *callbacks.RunInfo, timing callbacks.CallbackTiming) bool {
+	if r.filter != nil && !r.filter(ctx, info, timing) {
+		return false
+	}
+	return r.timingChecker == nil || r.timingChecker.Needed(ctx, info, timing)
+}
+
+type handler struct {
+	routes         []*route
+	onHandlerError func(ctx context.Context, info *callbacks.RunInfo, err error)
+}
+
+func (h *handler) Needed(ctx context.Context, info *callbacks.RunInfo, timing callbacks.CallbackTiming) bool {
+	for _, r := range h.routes {
+		if r.accept(ctx, info, timing) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *handler) OnStart(ctx context.Context, info *callbacks.RunInfo, input callbacks.CallbackInput) context.Context {
+	routes := h.acceptedRoutes(ctx, info, callbacks.TimingOnStart)
+	for i := len(routes) - 1; i >= 0; i-- {
+		r := routes[i]
+		ctx = h.call(ctx, info, r, func(ctx context.Context) context.Context {
+			return r.handler.OnStart(ctx, info, input)
+		}, nil)
+	}
+	return ctx
+}
+
+func (h *handler) OnEnd(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+	for _, r := range h.acceptedRoutes(ctx, info, callbacks.TimingOnEnd) {
+		r := r
+		ctx = h.call(ctx, info, r, func(ctx context.Context) context.Context {
+			return r.handler.OnEnd(ctx, info, output)
+		}, nil)
+	}
+	return ctx
+}
+
+func (h *handler) OnError(ctx context.Context, info *callbacks.RunInfo, err error) context.Context {
+	for _, r := range h.acceptedRoutes(ctx, info, callbacks.TimingOnError) {
+		r := r
+		ctx = h.call(ctx, info, r, func(ctx context.Context) context.Context {
+			return r.handler.OnError(ctx, info, err)
+		}, nil)
+	}
+	return ctx
+}
+
+func (h *handler) OnStartWithStreamInput(ctx context.Context, info *callbacks.RunInfo,
+	input *schema.StreamReader[callbacks.CallbackInput]) context.Context {
+
+	routes := h.acceptedRoutes(ctx, info, callbacks.TimingOnStartWithStreamInput)
+	if len(routes) == 0 {
+		input.Close()
+		return ctx
+	}
+
+	inputs := input.Copy(len(routes))
+	for i := len(routes) - 1; i >= 0; i-- {
+		r, in := routes[i], inputs[i]
+		ctx = h.call(ctx, info, r, func(ctx context.Context) context.Context {
+			return r.handler.OnStartWithStreamInput(ctx, info, in)
+		}, in.Close)
+	}
+	return ctx
+}
+
+func (h *handler) OnEndWithStreamOutput(ctx context.Context, info *callbacks.RunInfo,
+	output *schema.StreamReader[callbacks.CallbackOutput]) context.Context {
+
+	routes := h.acceptedRoutes(ctx, info, callbacks.TimingOnEndWithStreamOutput)
+	if len(routes) == 0 {
+		output.Close()
+		return ctx
+	}
+
+	outputs := output.Copy(len(routes))
+	for i, r := range routes {
+		r, out := r, outputs[i]
+		ctx = h.call(ctx, info, r, func(ctx context.Context) context.Context {
+			return r.handler.OnEndWithStreamOutput(ctx, info, out)
+		}, out.Close)
+	}
+	return ctx
+}
+
+func (h *handler) acceptedRoutes(ctx context.Context, info *callbacks.RunInfo, timing callbacks.CallbackTiming) []*route {
+	routes := make([]*route, 0, len(h.routes))
+	for _, r := range h.routes {
+		if r.accept(ctx, info, timing) {
+			routes = append(routes, r)
+		}
+	}
+	return routes
+}
+
+// call runs fn of the route, recovering from panics and bounding it by the route timeout.
+// onPanic releases the resources owned by fn, e.g. the stream copy of the route.
+func (h *handler) call(ctx context.Context, info *callbacks.RunInfo, r *route,
+	fn func(ctx context.Context) context.Context, onPanic func()) context.Context {
+
+	if r.timeout <= 0 {
+		return h.safeCall(ctx, info, r, fn, onPanic)
+	}
+
+	done := make(chan context.Context, 1)
+	go func() {
+		done <- h.safeCall(ctx, info, r, fn, onPanic)
+	}()
+
+	timer := time.NewTimer(r.timeout)
+	defer timer.Stop()
+
+	select {
+	case nCtx := <-done:
+		return nCtx
+	case <-timer.C:
+		h.onHandlerError(ctx, info, fmt.Errorf("%s exceeded timeout %v", r.name, r.timeout))
+		return ctx
+	}
+}
+
+func (h *handler) safeCall(ctx context.Context, info *callbacks.RunInfo, r *route,
+	fn func(ctx context.Context) context.Context, onPanic func()) (nCtx context.Context) {
+
+	defer func() {
+		if p := recover(); p != nil {
+			if onPanic != nil {
+				onPanic()
+			}
+			h.onHandlerError(ctx, info, fmt.Errorf("%s panicked: %v\n%s", r.name, p, debug.Stack()))
+			nCtx = ctx
+		}
+	}()
+
+	nCtx = fn(ctx)
+	if nCtx == nil {
+		nCtx = ctx
+	}
+	return nCtx
+}
@@ -0,0 +1,271 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package multiplexer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) add(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *recorder) get() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string{}, r.events...)
+}
+
+func newRecordingHandler(name string, rec *recorder) callbacks.Handler {
+	type key struct{ name string }
+	readStream := func(prefix string, sr interface{ Close() }, recv func() error) {
+		defer sr.Close()
+		n := 0
+		for {
+			if err := recv(); err != nil {
+				break
+			}
+			n++
+		}
+		rec.add(prefix + ":" + strings.Repeat("|", n))
+	}
+	return callbacks.NewHandlerBuilder().
+		OnStartFn(func(ctx context.Context, info *callbacks.RunInfo, input callbacks.CallbackInput) context.Context {
+			rec.add(name + ".start." + info.Name)
+			return context.WithValue(ctx, key{name}, name)
+		}).
+		OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+			v, _ := ctx.Value(key{name}).(string)
+			rec.add(name + ".end." + info.Name + "." + v)
+			return ctx
+		}).
+		OnErrorFn(func(ctx context.Context, info *callbacks.RunInfo, err error) context.Context {
+			rec.add(name + ".error." + info.Name)
+			return ctx
+		}).
+		OnStartWithStreamInputFn(func(ctx context.Context, info *callbacks.RunInfo, input *schema.StreamReader[callbacks.CallbackInput]) context.Context {
+			readStream(name+".start_stream."+info.Name, input, func() error { _, err := input.Recv(); return err })
+			return ctx
+		}).
+		OnEndWithStreamOutputFn(func(ctx context.Context, info *callbacks.RunInfo, output *schema.StreamReader[callbacks.CallbackOutput]) context.Context {
+			readStream(name+".end_stream."+info.Name, output, func() error { _, err := output.Recv(); return err })
+			return ctx
+		}).
+		Build()
+}
+
+func TestNewHandler(t *testing.T) {
+	_, err := NewHandler(nil)
+	assert.Error(t, err)
+	_, err = NewHandler(&Config{})
+	assert.Error(t, err)
+	_, err = NewHandler(&Config{Routes: []*Route{{}}})
+	assert.EqualError(t, err, "handler of route[0] is required")
+}
+
+func TestRouting(t *testing.T) {
+	rec := &recorder{}
+	h, err := NewHandler(&Config{Routes: []*Route{
+		{Handler: newRecordingHandler("all", rec)},
+		{Handler: newRecordingHandler("model", rec), Filter: Components(components.ComponentOfChatModel)},
+		{Handler: newRecordingHandler("err", rec), Filter: ErrorsOnly()},
+	}})
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	model := &callbacks.RunInfo{Name: "m", Component: components.ComponentOfChatModel}
+	tool := &callbacks.RunInfo{Name: "t", Component: components.ComponentOfTool}
+
+	mctx := h.OnStart(ctx, model, "in")
+	h.OnEnd(mctx, model, "out")
+	tctx := h.OnStart(ctx, tool, "in")
+	h.OnError(tctx, tool, errors.New("failed"))
+
+	assert.Equal(t, []string{
+		"model.start.m", "all.start.m",
+		"all.end.m.all", "model.end.m.model",
+		"all.start.t",
+		"all.error.t", "err.error.t",
+	}, rec.get())
+
+	checker := h.(callbacks.TimingChecker)
+	assert.True(t, checker.Needed(ctx, model, callbacks.TimingOnStart))
+
+	rec2 := &recorder{}
+	h2, err := NewHandler(&Config{Routes: []*Route{
+		{Handler: newRecordingHandler("model", rec2), Filter: Components(components.ComponentOfChatModel)},
+	}})
+	assert.NoError(t, err)
+	assert.False(t, h2.(callbacks.TimingChecker).Needed(ctx, tool, callbacks.TimingOnStart))
+	assert.False(t, h2.(callbacks.TimingChecker).Needed(ctx, nil, callbacks.TimingOnStart))
+}
+
+func TestTimingChecker(t *testing.T) {
+	rec := &recorder{}
+	endOnly := callbacks.NewHandlerBuilder().
+		OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+			rec.add("end")
+			return ctx
+		}).Build()
+	h, err := NewHandler(&Config{Routes: []*Route{{Handler: endOnly}}})
+	assert.NoError(t, err)
+
+	info := &callbacks.RunInfo{Name: "n"}
+	assert.False(t, h.(callbacks.TimingChecker).Needed(context.Background(), info, callbacks.TimingOnStart))
+	assert.True(t, h.(callbacks.TimingChecker).Needed(context.Background(), info, callbacks.TimingOnEnd))
+	h.OnStart(context.Background(), info, "in")
+	h.OnEnd(context.Background(), info, "out")
+	assert.Equal(t, []string{"end"}, rec.get())
+}
+
+func TestIsolation(t *testing.T) {
+	var mu sync.Mutex
+	var handlerErrs []string
+	onHandlerError := func(_ context.Context, _ *callbacks.RunInfo, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		handlerErrs = append(handlerErrs, strings.SplitN(err.Error(), "\n", 2)[0])
+	}
+
+	rec := &recorder{}
+	panicking := callbacks.NewHandlerBuilder().
+		OnStartFn(func(ctx context.Context, info *callbacks.RunInfo, input callbacks.CallbackInput) context.Context {
+			panic("boom")
+		}).
+		OnEndWithStreamOutputFn(func(ctx context.Context, info *callbacks.RunInfo, output *schema.StreamReader[callbacks.CallbackOutput]) context.Context {
+			panic("stream boom")
+		}).Build()
+	block := make(chan struct{})
+	slow := callbacks.NewHandlerBuilder().
+		OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+			<-block
+			return ctx
+		}).Build()
+	defer close(block)
+
+	h, err := NewHandler(&Config{
+		Routes: []*Route{
+			{Name: "panicking", Handler: panicking},
+			{Name: "slow", Handler: slow, Timeout: 50 * time.Millisecond},
+			{Handler: newRecordingHandler("ok", rec)},
+		},
+		OnHandlerError: onHandlerError,
+	})
+	assert.NoError(t, err)
+
+	info := &callbacks.RunInfo{Name: "n"}
+	ctx := h.OnStart(context.Background(), info, "in")
+	start := time.Now()
+	h.OnEnd(ctx, info, "out")
+	assert.Less(t, time.Since(start), time.Second)
+
+	sr, sw := schema.Pipe[callbacks.CallbackOutput](2)
+	sw.Send("a", nil)
+	sw.Send("b", nil)
+	sw.Close()
+	h.OnEndWithStreamOutput(ctx, info, sr)
+
+	assert.Equal(t, []string{"ok.start.n", "ok.end.n.ok", "ok.end_stream.n:||"}, rec.get())
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{
+		"panicking panicked: boom",
+		"slow exceeded timeout 50ms",
+		"panicking panicked: stream boom",
+	}, handlerErrs)
+}
+
+func TestStream(t *testing.T) {
+	rec := &recorder{}
+	h, err := NewHandler(&Config{Routes: []*Route{
+		{Handler: newRecordingHandler("a", rec)},
+		{Handler: newRecordingHandler("b", rec), Timeout: time.Second},
+		{Handler: newRecordingHandler("model", rec), Filter: Components(components.ComponentOfChatModel)},
+	}})
+	assert.NoError(t, err)
+
+	info := &callbacks.RunInfo{Name: "n", Component: components.ComponentOfTool}
+	sr := schema.StreamReaderFromArray([]callbacks.CallbackInput{"1", "2", "3"})
+	h.OnStartWithStreamInput(context.Background(), info, sr)
+
+	assert.Equal(t, []string{"b.start_stream.n:|||", "a.start_stream.n:|||"}, rec.get())
+
+	none, err := NewHandler(&Config{Routes: []*Route{
+		{Handler: newRecordingHandler("model", rec), Filter: Components(components.ComponentOfChatModel)},
+	}})
+	assert.NoError(t, err)
+	none.OnEndWithStreamOutput(context.Background(), info, schema.StreamReaderFromArray([]callbacks.CallbackOutput{"1"}))
+	assert.Len(t, rec.get(), 2)
+}
+
+func TestGraph(t *testing.T) {
+	rec := &recorder{}
+	h, err := NewHandler(&Config{Routes: []*Route{
+		{Handler: newRecordingHandler("lambda", rec), Filter: Components(compose.ComponentOfLambda)},
+		{Handler: newRecordingHandler("graph", rec), Filter: Components(compose.ComponentOfGraph)},
+	}})
+	assert.NoError(t, err)
+
+	g := compose.NewGraph[string, string]()
+	assert.NoError(t, g.AddLambdaNode("node", compose.InvokableLambda(func(ctx context.Context, input string) (string, error) {
+		return input + "!", nil
+	}), compose.WithNodeName("node")))
+	assert.NoError(t, g.AddEdge(compose.START, "node"))
+	assert.NoError(t, g.AddEdge("node", compose.END))
+	runner, err := g.Compile(context.Background(), compose.WithGraphName("graph"))
+	assert.NoError(t, err)
+
+	out, err := runner.Invoke(context.Background(), "hi", compose.WithCallbacks(h))
+	assert.NoError(t, err)
+	assert.Equal(t, "hi!", out)
+	assert.Equal(t, []string{
+		"graph.start.graph", "lambda.start.node", "lambda.end.node.lambda", "graph.end.graph.graph",
+	}, rec.get())
+
+	sr, err := runner.Stream(context.Background(), "hi", compose.WithCallbacks(h))
+	assert.NoError(t, err)
+	for {
+		_, err = sr.Recv()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+	}
+	sr.Close()
+	assert.Equal(t, []string{
+		"graph.start_stream.graph:|", "lambda.start.node", "lambda.end.node.lambda", "graph.end_stream.graph:|",
+	}, rec.get()[4:])
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/callbacks/transcript"
+)
+
+// fakeChatModel calls the weather tool, standing for a real chat model.
+type fakeChatModel struct{}
+
+func (fakeChatModel) Generate(_ context.Context, _ []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	return schema.AssistantMessage("", []schema.ToolCall{{
+		ID:       "call_1",
+		Function: schema.FunctionCall{Name: "get_weather", Arguments: `{"city":"Beijing"}`},
+	}}), nil
+}
+
+func (fakeChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	msg, err := fakeChatModel{}.Generate(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return schema.StreamReaderFromArray([]*schema.Message{msg}), nil
+}
+
+type weatherRequest struct {
+	City string `json:"city"`
+}
+
+func main() {
+	ctx := context.Background()
+
+	store, err := transcript.NewFileStore(os.TempDir() + "/transcripts")
+	if err != nil {
+		log.Fatalf("NewFileStore failed, err=%v", err)
+	}
+	handler, err := transcript.NewHandler(&transcript.Config{
+		Store:  store,
+		Format: transcript.FormatHTML,
+		KeyFunc: func(t *transcript.Transcript) string {
+			return t.SessionID + "/" + t.ID + ".html"
+		},
+	})
+	if err != nil {
+		log.Fatalf("NewHandler failed, err=%v", err)
+	}
+	defer handler.Close() // waits for the pending transcripts
+
+	weather, err := utils.InferTool("get_weather", "get the weather of a city",
+		func(ctx context.Context, req *weatherRequest) (string, error) {
+			return "sunny", nil
+		})
+	if err != nil {
+		log.Fatalf("InferTool failed, err=%v", err)
+	}
+	tools, err := compose.NewToolNode(ctx, &compose.ToolsNodeConfig{Tools: []tool.BaseTool{weather}})
+	if err != nil {
+		log.Fatalf("NewToolNode failed, err=%v", err)
+	}
+
+	g := compose.NewGraph[[]*schema.Message, []*schema.Message]()
+	_ = g.AddChatModelNode("model", fakeChatModel{}, compose.WithNodeName("model"))
+	_ = g.AddToolsNode("tools", tools, compose.WithNodeName("tools"))
+	_ = g.AddEdge(compose.START, "model")
+	_ = g.AddEdge("model", "tools")
+	_ = g.AddEdge("tools", compose.END)
+	runner, err := g.Compile(ctx, compose.WithGraphName("weather_agent"))
+	if err != nil {
+		log.Fatalf("Compile failed, err=%v", err)
+	}
+
+	// the transcript is saved to <tmp>/transcripts/session-1/run-1.html
+	ctx = transcript.SetTranscript(ctx, transcript.WithID("run-1"), transcript.WithSessionID("session-1"),
+		transcript.WithMetadata(map[string]string{"user": "alice"}))
+	_, err = runner.Invoke(ctx, []*schema.Message{schema.UserMessage("What is the weather in Beijing?")},
+		compose.WithCallbacks(handler))
+	if err != nil {
+		log.Fatalf("Invoke failed, err=%v", err)
+	}
+}
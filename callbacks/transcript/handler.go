@@ -0,0 +1,480 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transcript
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+)
+
+// Format is the format of the rendered transcripts.
+type Format string
+
+const (
+	FormatMarkdown Format = "markdown"
+	FormatHTML     Format = "html"
+)
+
+type Config struct {
+	// Store saves the rendered transcripts.
+	// Required
+	Store Store
+	// Format is the format of the rendered transcripts.
+	// Optional. Default: FormatMarkdown
+	Format Format
+	// KeyFunc returns the key a transcript is saved with.
+	// Optional. Default: "<date>/<time>_<id>.md", or ".html" for FormatHTML
+	KeyFunc func(t *Transcript) string
+	// RootComponents are the components whose runs are recorded as transcripts, the runs of the other components
+	// being recorded as steps of these runs, and ignored out of them.
+	// Optional. Default: compose.ComponentOfGraph, compose.ComponentOfChain and compose.ComponentOfWorkflow
+	RootComponents []components.Component
+	// MaxContentLength truncates the contents longer than it, in characters.
+	// Optional. Default: 10000, negative for no limit
+	MaxContentLength int
+	// FullModelInput renders all the input messages of the chat models, instead of the messages of the new turn,
+	// those after the last assistant message, the earlier ones being in the transcript already.
+	// Optional. Default: false
+	FullModelInput bool
+	// OnError is called when a transcript fails to be saved.
+	// Optional. Default: logs the error
+	OnError func(t *Transcript, err error)
+}
+
+const defaultMaxContentLength = 10000
+
+// NewHandler creates a callback handler assembling each graph run into a transcript,
+// rendered as Markdown or HTML and saved to the store once the run ends.
+// The transcripts are saved in background, call Close to wait for the pending ones before exiting.
+func NewHandler(config *Config) (*Handler, error) {
+	if config == nil {
+		return nil, errors.New("config is required")
+	}
+	if config.Store == nil {
+		return nil, errors.New("store is required")
+	}
+
+	h := &Handler{
+		store:            config.Store,
+		format:           config.Format,
+		keyFunc:          config.KeyFunc,
+		rootComponents:   config.RootComponents,
+		maxContentLength: config.MaxContentLength,
+		fullModelInput:   config.FullModelInput,
+		onError:          config.OnError,
+		now:              time.Now,
+	}
+	switch h.format {
+	case "":
+		h.format = FormatMarkdown
+	case FormatMarkdown, FormatHTML:
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", h.format)
+	}
+	if h.keyFunc == nil {
+		h.keyFunc = defaultKeyFunc(h.format)
+	}
+	if len(h.rootComponents) == 0 {
+		h.rootComponents = []components.Component{compose.ComponentOfGraph, compose.ComponentOfChain, compose.ComponentOfWorkflow}
+	}
+	if h.maxContentLength == 0 {
+		h.maxContentLength = defaultMaxContentLength
+	}
+	if h.onError == nil {
+		h.onError = func(t *Transcript, err error) {
+			log.Printf("save transcript error: %v, id: %s", err, t.ID)
+		}
+	}
+	return h, nil
+}
+
+func defaultKeyFunc(format Format) func(t *Transcript) string {
+	ext := ".md"
+	if format == FormatHTML {
+		ext = ".html"
+	}
+	return func(t *Transcript) string {
+		return t.Root.Start.Format("2006-01-02/150405") + "_" + t.ID + ext
+	}
+}
+
+// Handler is the transcript callback handler.
+type Handler struct {
+	store            Store
+	format           Format
+	keyFunc          func(t *Transcript) string
+	rootComponents   []components.Component
+	maxContentLength int
+	fullModelInput   bool
+	onError          func(t *Transcript, err error)
+
+	wg  sync.WaitGroup
+	now func() time.Time
+}
+
+// run is the state of a recorded graph run.
+type run struct {
+	mu         sync.Mutex
+	transcript *Transcript
+	// streams counts the output streams of the steps being read
+	streams sync.WaitGroup
+}
+
+type runKey struct{}
+type stepKey struct{}
+
+func (h *Handler) Needed(ctx context.Context, info *callbacks.RunInfo, _ callbacks.CallbackTiming) bool {
+	if _, ok := ctx.Value(runKey{}).(*run); ok {
+		return true
+	}
+	return info != nil && slices.Contains(h.rootComponents, info.Component)
+}
+
+func (h *Handler) OnStart(ctx context.Context, info *callbacks.RunInfo, input callbacks.CallbackInput) context.Context {
+	ctx, r, step := h.startStep(ctx, info)
+	if step == nil {
+		return ctx
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch info.Component {
+	case components.ComponentOfChatModel:
+		if mi := model.ConvCallbackInput(input); mi != nil {
+			step.InputMessages = mi.Messages
+		}
+	case components.ComponentOfTool:
+		if ti := tool.ConvCallbackInput(input); ti != nil {
+			step.Input = ti.ArgumentsInJSON
+		}
+	default:
+		step.Input = formatValue(input)
+	}
+	return ctx
+}
+
+func (h *Handler) OnEnd(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+	r, step := getStep(ctx)
+	if step == nil {
+		return ctx
+	}
+
+	r.mu.Lock()
+	setOutput(info, step, output)
+	step.End = h.now()
+	r.mu.Unlock()
+
+	h.endStep(ctx, r, step)
+	return ctx
+}
+
+func (h *Handler) OnError(ctx context.Context, _ *callbacks.RunInfo, err error) context.Context {
+	r, step := getStep(ctx)
+	if step == nil {
+		return ctx
+	}
+
+	r.mu.Lock()
+	step.Error = err.Error()
+	step.End = h.now()
+	r.mu.Unlock()
+
+	h.endStep(ctx, r, step)
+	return ctx
+}
+
+func (h *Handler) OnStartWithStreamInput(ctx context.Context, info *callbacks.RunInfo,
+	input *schema.StreamReader[callbacks.CallbackInput]) context.Context {
+
+	ctx, r, step := h.startStep(ctx, info)
+	if step == nil {
+		input.Close()
+		return ctx
+	}
+
+	r.streams.Add(1)
+	go func() {
+		defer r.streams.Done()
+		defer input.Close()
+		chunks, _ := readStream(input)
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		step.Input = formatChunks(chunks)
+	}()
+	return ctx
+}
+
+func (h *Handler) OnEndWithStreamOutput(ctx context.Context, info *callbacks.RunInfo,
+	output *schema.StreamReader[callbacks.CallbackOutput]) context.Context {
+
+	r, step := getStep(ctx)
+	if step == nil {
+		output.Close()
+		return ctx
+	}
+
+	r.streams.Add(1)
+	// Close waits for the stream too, the root ending only after it is read
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		defer func() {
+			if p := recover(); p != nil {
+				log.Printf("transcript handler panic: %v\n%s", p, debug.Stack())
+			}
+		}()
+		defer r.streams.Done()
+		defer output.Close()
+
+		chunks, err := readStream(output)
+
+		r.mu.Lock()
+		setStreamOutput(info, step, chunks)
+		if err != nil {
+			step.Error = err.Error()
+		}
+		step.End = h.now()
+		r.mu.Unlock()
+
+		// saving waits for the streams being read, this one included
+		h.endStep(ctx, r, step)
+	}()
+	return ctx
+}
+
+// startStep records the start of a step, starting a new run for root components out of any run.
+func (h *Handler) startStep(ctx context.Context, info *callbacks.RunInfo) (context.Context, *run, *Step) {
+	if info == nil {
+		return ctx, nil, nil
+	}
+	step := &Step{
+		Component: info.Component,
+		Type:      info.Type,
+		Name:      info.Name,
+		Start:     h.now(),
+	}
+
+	r, ok := ctx.Value(runKey{}).(*run)
+	if !ok {
+		if !slices.Contains(h.rootComponents, info.Component) {
+			return ctx, nil, nil
+		}
+		t := &Transcript{Root: step}
+		if opts, found := ctx.Value(transcriptOptionKey{}).(*transcriptOptions); found {
+			t.ID, t.SessionID, t.Metadata = opts.ID, opts.SessionID, opts.Metadata
+		}
+		if len(t.ID) == 0 {
+			t.ID = newID()
+		}
+		r = &run{transcript: t}
+		ctx = context.WithValue(ctx, runKey{}, r)
+	} else if parent, found := ctx.Value(stepKey{}).(*Step); found {
+		r.mu.Lock()
+		parent.Children = append(parent.Children, step)
+		r.mu.Unlock()
+	}
+
+	return context.WithValue(ctx, stepKey{}, step), r, step
+}
+
+// endStep saves the transcript in background when the root step ends.
+func (h *Handler) endStep(ctx context.Context, r *run, step *Step) {
+	if step != r.transcript.Root {
+		return
+	}
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		r.streams.Wait()
+
+		r.mu.Lock()
+		content := h.render(r.transcript)
+		r.mu.Unlock()
+
+		if err := h.store.Save(context.WithoutCancel(ctx), h.keyFunc(r.transcript), content); err != nil {
+			h.onError(r.transcript, err)
+		}
+	}()
+}
+
+func (h *Handler) render(t *Transcript) []byte {
+	opts := &renderOptions{maxContentLength: h.maxContentLength, fullModelInput: h.fullModelInput}
+	if h.format == FormatHTML {
+		return renderHTML(t, opts)
+	}
+	return renderMarkdown(t, opts)
+}
+
+// Close waits for the pending transcripts to be saved.
+func (h *Handler) Close() {
+	h.wg.Wait()
+}
+
+func getStep(ctx context.Context) (*run, *Step) {
+	r, ok := ctx.Value(runKey{}).(*run)
+	if !ok {
+		return nil, nil
+	}
+	step, ok := ctx.Value(stepKey{}).(*Step)
+	if !ok {
+		return nil, nil
+	}
+	return r, step
+}
+
+func setOutput(info *callbacks.RunInfo, step *Step, output callbacks.CallbackOutput) {
+	switch info.Component {
+	case components.ComponentOfChatModel:
+		if mo := model.ConvCallbackOutput(output); mo != nil {
+			step.OutputMessage, step.TokenUsage = mo.Message, mo.TokenUsage
+			if step.TokenUsage == nil {
+				step.TokenUsage = messageTokenUsage(mo.Message)
+			}
+			return
+		}
+	case components.ComponentOfTool:
+		if to := tool.ConvCallbackOutput(output); to != nil {
+			step.Output = to.Response
+			return
+		}
+	}
+	step.Output = formatValue(output)
+}
+
+func setStreamOutput(info *callbacks.RunInfo, step *Step, chunks []callbacks.CallbackOutput) {
+	switch info.Component {
+	case components.ComponentOfChatModel:
+		var msgs []*schema.Message
+		for _, chunk := range chunks {
+			mo := model.ConvCallbackOutput(chunk)
+			if mo == nil {
+				continue
+			}
+			if mo.Message != nil {
+				msgs = append(msgs, mo.Message)
+			}
+			if mo.TokenUsage != nil {
+				step.TokenUsage = mo.TokenUsage
+			}
+		}
+		if len(msgs) > 0 {
+			if msg, err := schema.ConcatMessages(msgs); err == nil {
+				step.OutputMessage = msg
+				if step.TokenUsage == nil {
+					step.TokenUsage = messageTokenUsage(msg)
+				}
+				return
+			}
+		}
+	case components.ComponentOfTool:
+		sb := &strings.Builder{}
+		for _, chunk := range chunks {
+			if to := tool.ConvCallbackOutput(chunk); to != nil {
+				sb.WriteString(to.Response)
+			}
+		}
+		step.Output = sb.String()
+		return
+	}
+	step.Output = formatChunks(chunks)
+}
+
+// messageTokenUsage returns the token usage of the response meta of msg,
+// for the chat models without callbacks, whose callback output only has the message.
+func messageTokenUsage(msg *schema.Message) *model.TokenUsage {
+	if msg == nil || msg.ResponseMeta == nil || msg.ResponseMeta.Usage == nil {
+		return nil
+	}
+	usage := msg.ResponseMeta.Usage
+	return &model.TokenUsage{
+		PromptTokens:     usage.PromptTokens,
+		CompletionTokens: usage.CompletionTokens,
+		TotalTokens:      usage.TotalTokens,
+	}
+}
+
+func readStream[T any](sr *schema.StreamReader[T]) ([]T, error) {
+	var chunks []T
+	for {
+		chunk, err := sr.Recv()
+		if err == io.EOF {
+			return chunks, nil
+		}
+		if err != nil {
+			return chunks, err
+		}
+		chunks = append(chunks, chunk)
+	}
+}
+
+// formatValue formats a callback input or output, strings as is and the others as json.
+func formatValue(v any) string {
+	if v == nil {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	b, err := sonic.ConfigStd.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("%+v", v)
+	}
+	return string(b)
+}
+
+// formatChunks formats the chunks of a stream, concatenating string chunks.
+func formatChunks[T any](chunks []T) string {
+	sb := &strings.Builder{}
+	allStrings := true
+	for _, chunk := range chunks {
+		s, ok := any(chunk).(string)
+		if !ok {
+			allStrings = false
+			break
+		}
+		sb.WriteString(s)
+	}
+	if allStrings {
+		return sb.String()
+	}
+	return formatValue(chunks)
+}
+
+func newID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
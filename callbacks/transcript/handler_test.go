@@ -0,0 +1,248 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transcript
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryStore struct {
+	mu    sync.Mutex
+	files map[string][]byte
+	err   error
+}
+
+func (s *memoryStore) Save(_ context.Context, key string, content []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	if s.files == nil {
+		s.files = map[string][]byte{}
+	}
+	s.files[key] = content
+	return nil
+}
+
+func (s *memoryStore) get() map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ret := map[string]string{}
+	for k, v := range s.files {
+		ret[k] = string(v)
+	}
+	return ret
+}
+
+type fakeChatModel struct{}
+
+func (fakeChatModel) Generate(_ context.Context, input []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	msg := schema.AssistantMessage("", []schema.ToolCall{{
+		ID:       "call_1",
+		Function: schema.FunctionCall{Name: "get_weather", Arguments: `{"city":"Beijing"}`},
+	}})
+	msg.ResponseMeta = &schema.ResponseMeta{Usage: &schema.TokenUsage{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15}}
+	return msg, nil
+}
+
+func (fakeChatModel) Stream(_ context.Context, input []*schema.Message, _ ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return schema.StreamReaderFromArray([]*schema.Message{
+		schema.AssistantMessage("It is ", nil),
+		schema.AssistantMessage("sunny.", nil),
+	}), nil
+}
+
+type fakeTool struct{}
+
+func (fakeTool) Info(context.Context) (*schema.ToolInfo, error) {
+	return &schema.ToolInfo{Name: "get_weather", Desc: "get the weather of a city"}, nil
+}
+
+func (fakeTool) InvokableRun(_ context.Context, argumentsInJSON string, _ ...tool.Option) (string, error) {
+	return `{"weather":"sunny"}`, nil
+}
+
+func newAgentGraph(t *testing.T) compose.Runnable[[]*schema.Message, []*schema.Message] {
+	tn, err := compose.NewToolNode(context.Background(), &compose.ToolsNodeConfig{Tools: []tool.BaseTool{fakeTool{}}})
+	assert.NoError(t, err)
+
+	g := compose.NewGraph[[]*schema.Message, []*schema.Message]()
+	assert.NoError(t, g.AddChatModelNode("model", fakeChatModel{}))
+	assert.NoError(t, g.AddToolsNode("tools", tn))
+	assert.NoError(t, g.AddEdge(compose.START, "model"))
+	assert.NoError(t, g.AddEdge("model", "tools"))
+	assert.NoError(t, g.AddEdge("tools", compose.END))
+	r, err := g.Compile(context.Background(), compose.WithGraphName("agent"))
+	assert.NoError(t, err)
+	return r
+}
+
+func TestNewHandler(t *testing.T) {
+	_, err := NewHandler(nil)
+	assert.Error(t, err)
+	_, err = NewHandler(&Config{})
+	assert.Error(t, err)
+	_, err = NewHandler(&Config{Store: &memoryStore{}, Format: "pdf"})
+	assert.Error(t, err)
+
+	h, err := NewHandler(&Config{Store: &memoryStore{}})
+	assert.NoError(t, err)
+	assert.Equal(t, FormatMarkdown, h.format)
+	assert.Equal(t, defaultMaxContentLength, h.maxContentLength)
+	assert.Len(t, h.rootComponents, 3)
+}
+
+func TestHandlerInvoke(t *testing.T) {
+	store := &memoryStore{}
+	h, err := NewHandler(&Config{
+		Store:   store,
+		KeyFunc: func(t *Transcript) string { return t.ID + ".md" },
+	})
+	assert.NoError(t, err)
+
+	ctx := SetTranscript(context.Background(), WithID("run-1"), WithSessionID("session-1"),
+		WithMetadata(map[string]string{"user": "alice"}))
+	_, err = newAgentGraph(t).Invoke(ctx, []*schema.Message{
+		schema.SystemMessage("You are a weather assistant."),
+		schema.UserMessage("What is the weather in Beijing?"),
+	}, compose.WithCallbacks(h))
+	assert.NoError(t, err)
+	h.Close()
+
+	files := store.get()
+	assert.Len(t, files, 1)
+	content := files["run-1.md"]
+	assert.Contains(t, content, "# Transcript run-1")
+	assert.Contains(t, content, "- Session: session-1")
+	assert.Contains(t, content, "- user: alice")
+	assert.Contains(t, content, "- Status: success")
+	assert.Contains(t, content, "## Graph `agent`")
+	assert.Contains(t, content, "### ChatModel (fakeChatModel)")
+	assert.Contains(t, content, "What is the weather in Beijing?")
+	assert.Contains(t, content, "Tool call `get_weather` (call_1)")
+	assert.Contains(t, content, `{"city":"Beijing"}`)
+	assert.Contains(t, content, "Token usage: prompt 10, completion 5, total 15")
+	assert.Contains(t, content, "### ToolsNode")
+	assert.Contains(t, content, "#### Tool `get_weather` (fakeTool)")
+	assert.Contains(t, content, `{"weather":"sunny"}`)
+	// the steps are in order
+	assert.Less(t, strings.Index(content, "ChatModel"), strings.Index(content, "ToolsNode"))
+}
+
+func TestHandlerStream(t *testing.T) {
+	store := &memoryStore{}
+	h, err := NewHandler(&Config{Store: store, Format: FormatHTML})
+	assert.NoError(t, err)
+
+	g := compose.NewGraph[[]*schema.Message, *schema.Message]()
+	assert.NoError(t, g.AddChatModelNode("model", fakeChatModel{}))
+	assert.NoError(t, g.AddEdge(compose.START, "model"))
+	assert.NoError(t, g.AddEdge("model", compose.END))
+	r, err := g.Compile(context.Background())
+	assert.NoError(t, err)
+
+	sr, err := r.Stream(context.Background(), []*schema.Message{schema.UserMessage("<b>weather</b>?")}, compose.WithCallbacks(h))
+	assert.NoError(t, err)
+	sr.Close()
+	h.Close()
+
+	files := store.get()
+	assert.Len(t, files, 1)
+	for key, content := range files {
+		assert.True(t, strings.HasSuffix(key, ".html"))
+		assert.Contains(t, content, "<!DOCTYPE html>")
+		assert.Contains(t, content, "It is sunny.")
+		assert.Contains(t, content, "&lt;b&gt;weather&lt;/b&gt;?")
+		assert.NotContains(t, content, "<b>weather</b>")
+	}
+}
+
+func TestHandlerError(t *testing.T) {
+	store := &memoryStore{}
+	h, err := NewHandler(&Config{Store: store})
+	assert.NoError(t, err)
+
+	g := compose.NewGraph[string, string]()
+	assert.NoError(t, g.AddLambdaNode("fail", compose.InvokableLambda(func(ctx context.Context, input string) (string, error) {
+		return "", errors.New("lambda failed")
+	})))
+	assert.NoError(t, g.AddEdge(compose.START, "fail"))
+	assert.NoError(t, g.AddEdge("fail", compose.END))
+	r, err := g.Compile(context.Background())
+	assert.NoError(t, err)
+
+	_, err = r.Invoke(context.Background(), "input", compose.WithCallbacks(h))
+	assert.Error(t, err)
+	h.Close()
+
+	files := store.get()
+	assert.Len(t, files, 1)
+	for _, content := range files {
+		assert.Contains(t, content, "- Status: error")
+		assert.Contains(t, content, "lambda failed")
+	}
+}
+
+func TestHandlerSaveError(t *testing.T) {
+	var mu sync.Mutex
+	var saveErr error
+	h, err := NewHandler(&Config{
+		Store: &memoryStore{err: errors.New("store unavailable")},
+		OnError: func(t *Transcript, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			saveErr = err
+		},
+	})
+	assert.NoError(t, err)
+
+	_, err = newAgentGraph(t).Invoke(context.Background(), []*schema.Message{schema.UserMessage("hi")}, compose.WithCallbacks(h))
+	assert.NoError(t, err)
+	h.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.EqualError(t, saveErr, "store unavailable")
+}
+
+func TestHandlerOutOfRun(t *testing.T) {
+	store := &memoryStore{}
+	h, err := NewHandler(&Config{Store: store})
+	assert.NoError(t, err)
+
+	info := &callbacks.RunInfo{Component: components.ComponentOfChatModel, Name: "model"}
+	assert.False(t, h.Needed(context.Background(), info, callbacks.TimingOnStart))
+	ctx := h.OnStart(context.Background(), info, &model.CallbackInput{})
+	h.OnEnd(ctx, info, &model.CallbackOutput{})
+	h.Close()
+	assert.Empty(t, store.get())
+
+	assert.True(t, h.Needed(context.Background(), &callbacks.RunInfo{Component: compose.ComponentOfGraph}, callbacks.TimingOnStart))
+}
@@ -0,0 +1,380 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transcript
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+type renderOptions struct {
+	maxContentLength int
+	fullModelInput   bool
+}
+
+// transcriptView is the transcript prepared for rendering, shared by the formats.
+type transcriptView struct {
+	ID        string
+	SessionID string
+	Metadata  [][2]string
+	Start     string
+	Duration  string
+	Status    string
+	Root      *stepView
+}
+
+type stepView struct {
+	Component string
+	Name      string
+	Type      string
+	Duration  string
+	Error     string
+
+	// InputMessages and OutputMessage are set for chat models,
+	// OmittedMessages being the count of the input messages of the earlier turns.
+	InputMessages   []*messageView
+	OmittedMessages int
+	OutputMessage   *messageView
+	TokenUsage      string
+
+	Input  string
+	Output string
+
+	Children []*stepView
+}
+
+type messageView struct {
+	Role       string
+	Name       string
+	ToolCallID string
+	Reasoning  string
+	Content    string
+	ToolCalls  []*toolCallView
+}
+
+type toolCallView struct {
+	ID        string
+	Name      string
+	Arguments string
+}
+
+func newTranscriptView(t *Transcript, opts *renderOptions) *transcriptView {
+	v := &transcriptView{
+		ID:        t.ID,
+		SessionID: t.SessionID,
+		Start:     t.Root.Start.Format(time.RFC3339Nano),
+		Duration:  formatDuration(t.Root),
+		Status:    "success",
+		Root:      newStepView(t.Root, opts),
+	}
+	if len(t.Root.Error) > 0 {
+		v.Status = "error"
+	}
+	for k, val := range t.Metadata {
+		v.Metadata = append(v.Metadata, [2]string{k, val})
+	}
+	sort.Slice(v.Metadata, func(i, j int) bool {
+		return v.Metadata[i][0] < v.Metadata[j][0]
+	})
+	return v
+}
+
+func newStepView(s *Step, opts *renderOptions) *stepView {
+	v := &stepView{
+		Component: string(s.Component),
+		Name:      s.Name,
+		Type:      s.Type,
+		Duration:  formatDuration(s),
+		Error:     truncate(s.Error, opts.maxContentLength),
+		Input:     truncate(s.Input, opts.maxContentLength),
+		Output:    truncate(s.Output, opts.maxContentLength),
+	}
+	if v.Type == v.Name {
+		v.Type = ""
+	}
+
+	msgs := s.InputMessages
+	if !opts.fullModelInput {
+		// the messages up to the last assistant message are in the transcript already, as the output of a previous step
+		for i := len(msgs) - 1; i >= 0; i-- {
+			if msgs[i] != nil && msgs[i].Role == schema.Assistant {
+				v.OmittedMessages = i + 1
+				msgs = msgs[i+1:]
+				break
+			}
+		}
+	}
+	for _, msg := range msgs {
+		if msg != nil {
+			v.InputMessages = append(v.InputMessages, newMessageView(msg, opts))
+		}
+	}
+	if s.OutputMessage != nil {
+		v.OutputMessage = newMessageView(s.OutputMessage, opts)
+	}
+	if s.TokenUsage != nil {
+		v.TokenUsage = fmt.Sprintf("prompt %d, completion %d, total %d",
+			s.TokenUsage.PromptTokens, s.TokenUsage.CompletionTokens, s.TokenUsage.TotalTokens)
+	}
+
+	for _, child := range s.Children {
+		v.Children = append(v.Children, newStepView(child, opts))
+	}
+	return v
+}
+
+func newMessageView(msg *schema.Message, opts *renderOptions) *messageView {
+	v := &messageView{
+		Role:       string(msg.Role),
+		Name:       msg.Name,
+		ToolCallID: msg.ToolCallID,
+		Reasoning:  truncate(msg.ReasoningContent, opts.maxContentLength),
+		Content:    truncate(msg.Content, opts.maxContentLength),
+	}
+	for _, tc := range msg.ToolCalls {
+		v.ToolCalls = append(v.ToolCalls, &toolCallView{
+			ID:        tc.ID,
+			Name:      tc.Function.Name,
+			Arguments: truncate(tc.Function.Arguments, opts.maxContentLength),
+		})
+	}
+	return v
+}
+
+func formatDuration(s *Step) string {
+	if s.End.IsZero() {
+		return "unfinished"
+	}
+	return s.Duration().Round(time.Millisecond).String()
+}
+
+// truncate truncates content to maxLength characters, no limit if maxLength is negative.
+func truncate(content string, maxLength int) string {
+	if maxLength < 0 {
+		return content
+	}
+	n := utf8.RuneCountInString(content)
+	if n <= maxLength {
+		return content
+	}
+	runes := []rune(content)
+	return fmt.Sprintf("%s\n... (truncated %d chars)", string(runes[:maxLength]), n-maxLength)
+}
+
+func renderMarkdown(t *Transcript, opts *renderOptions) []byte {
+	v := newTranscriptView(t, opts)
+	buf := &bytes.Buffer{}
+
+	fmt.Fprintf(buf, "# Transcript %s\n\n", v.ID)
+	if len(v.SessionID) > 0 {
+		fmt.Fprintf(buf, "- Session: %s\n", v.SessionID)
+	}
+	fmt.Fprintf(buf, "- Start: %s\n", v.Start)
+	fmt.Fprintf(buf, "- Duration: %s\n", v.Duration)
+	fmt.Fprintf(buf, "- Status: %s\n", v.Status)
+	for _, kv := range v.Metadata {
+		fmt.Fprintf(buf, "- %s: %s\n", kv[0], kv[1])
+	}
+	buf.WriteString("\n")
+
+	writeMarkdownStep(buf, v.Root, 2)
+	return buf.Bytes()
+}
+
+func writeMarkdownStep(buf *bytes.Buffer, s *stepView, level int) {
+	buf.WriteString(strings.Repeat("#", min(level, 6)))
+	buf.WriteString(" ")
+	buf.WriteString(s.Component)
+	if len(s.Name) > 0 {
+		fmt.Fprintf(buf, " `%s`", s.Name)
+	}
+	if len(s.Type) > 0 {
+		fmt.Fprintf(buf, " (%s)", s.Type)
+	}
+	fmt.Fprintf(buf, " - %s\n\n", s.Duration)
+
+	if s.OmittedMessages > 0 {
+		fmt.Fprintf(buf, "_%d earlier messages omitted_\n\n", s.OmittedMessages)
+	}
+	for _, msg := range s.InputMessages {
+		writeMarkdownMessage(buf, msg)
+	}
+	if s.OutputMessage != nil {
+		buf.WriteString("**Output**\n\n")
+		writeMarkdownMessage(buf, s.OutputMessage)
+	}
+	if len(s.TokenUsage) > 0 {
+		fmt.Fprintf(buf, "Token usage: %s\n\n", s.TokenUsage)
+	}
+	if len(s.Input) > 0 {
+		buf.WriteString("**Input**\n\n")
+		writeMarkdownCode(buf, s.Input)
+	}
+	if len(s.Output) > 0 {
+		buf.WriteString("**Output**\n\n")
+		writeMarkdownCode(buf, s.Output)
+	}
+	if len(s.Error) > 0 {
+		buf.WriteString("**Error**\n\n")
+		writeMarkdownCode(buf, s.Error)
+	}
+
+	for _, child := range s.Children {
+		writeMarkdownStep(buf, child, level+1)
+	}
+}
+
+func writeMarkdownMessage(buf *bytes.Buffer, msg *messageView) {
+	fmt.Fprintf(buf, "**%s**", msg.Role)
+	if len(msg.Name) > 0 {
+		fmt.Fprintf(buf, " `%s`", msg.Name)
+	}
+	if len(msg.ToolCallID) > 0 {
+		fmt.Fprintf(buf, " (tool call %s)", msg.ToolCallID)
+	}
+	buf.WriteString("\n\n")
+	if len(msg.Reasoning) > 0 {
+		buf.WriteString("_Reasoning_\n\n")
+		writeMarkdownCode(buf, msg.Reasoning)
+	}
+	if len(msg.Content) > 0 {
+		writeMarkdownCode(buf, msg.Content)
+	}
+	for _, tc := range msg.ToolCalls {
+		fmt.Fprintf(buf, "Tool call `%s` (%s)\n\n", tc.Name, tc.ID)
+		writeMarkdownCode(buf, tc.Arguments)
+	}
+}
+
+// writeMarkdownCode writes content as a code block, its fence being longer than any backtick run of content.
+func writeMarkdownCode(buf *bytes.Buffer, content string) {
+	longest, run := 0, 0
+	for _, r := range content {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	fence := strings.Repeat("`", max(3, longest+1))
+	buf.WriteString(fence)
+	buf.WriteString("\n")
+	buf.WriteString(content)
+	if !strings.HasSuffix(content, "\n") {
+		buf.WriteString("\n")
+	}
+	buf.WriteString(fence)
+	buf.WriteString("\n\n")
+}
+
+var htmlTemplate = template.Must(template.New("transcript").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Transcript {{.ID}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+details { margin: 0.5em 0 0.5em 1em; border-left: 2px solid #ddd; padding-left: 1em; }
+summary { cursor: pointer; }
+pre { background: #f6f8fa; padding: 0.5em; white-space: pre-wrap; word-break: break-word; }
+.error { color: #c00; }
+.meta { color: #666; }
+</style>
+</head>
+<body>
+<h1>Transcript {{.ID}}</h1>
+<ul>
+{{- if .SessionID}}
+<li>Session: {{.SessionID}}</li>
+{{- end}}
+<li>Start: {{.Start}}</li>
+<li>Duration: {{.Duration}}</li>
+<li>Status: {{.Status}}</li>
+{{- range .Metadata}}
+<li>{{index . 0}}: {{index . 1}}</li>
+{{- end}}
+</ul>
+{{template "step" .Root}}
+</body>
+</html>
+{{define "step" -}}
+<details open>
+<summary><b>{{.Component}}</b>{{if .Name}} <code>{{.Name}}</code>{{end}}{{if .Type}} ({{.Type}}){{end}} <span class="meta">{{.Duration}}</span></summary>
+{{- if .OmittedMessages}}
+<p class="meta"><i>{{.OmittedMessages}} earlier messages omitted</i></p>
+{{- end}}
+{{- range .InputMessages}}
+{{template "message" .}}
+{{- end}}
+{{- if .OutputMessage}}
+<p><b>Output</b></p>
+{{template "message" .OutputMessage}}
+{{- end}}
+{{- if .TokenUsage}}
+<p class="meta">Token usage: {{.TokenUsage}}</p>
+{{- end}}
+{{- if .Input}}
+<p><b>Input</b></p>
+<pre>{{.Input}}</pre>
+{{- end}}
+{{- if .Output}}
+<p><b>Output</b></p>
+<pre>{{.Output}}</pre>
+{{- end}}
+{{- if .Error}}
+<p class="error"><b>Error</b></p>
+<pre class="error">{{.Error}}</pre>
+{{- end}}
+{{- range .Children}}
+{{template "step" .}}
+{{- end}}
+</details>
+{{- end}}
+{{define "message" -}}
+<div>
+<p><b>{{.Role}}</b>{{if .Name}} <code>{{.Name}}</code>{{end}}{{if .ToolCallID}} (tool call {{.ToolCallID}}){{end}}</p>
+{{- if .Reasoning}}
+<p class="meta"><i>Reasoning</i></p>
+<pre class="meta">{{.Reasoning}}</pre>
+{{- end}}
+{{- if .Content}}
+<pre>{{.Content}}</pre>
+{{- end}}
+{{- range .ToolCalls}}
+<p>Tool call <code>{{.Name}}</code> ({{.ID}})</p>
+<pre>{{.Arguments}}</pre>
+{{- end}}
+</div>
+{{- end}}
+`))
+
+func renderHTML(t *Transcript, opts *renderOptions) []byte {
+	buf := &bytes.Buffer{}
+	if err := htmlTemplate.Execute(buf, newTranscriptView(t, opts)); err != nil {
+		// the template is fixed and the view only has strings, so it fails only on a bug
+		return []byte(fmt.Sprintf("render transcript error: %v", err))
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transcript
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestTranscript() *Transcript {
+	start := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	return &Transcript{
+		ID:       "run-1",
+		Metadata: map[string]string{"b": "2", "a": "1"},
+		Root: &Step{
+			Component: compose.ComponentOfGraph,
+			Name:      "agent",
+			Start:     start,
+			End:       start.Add(1500 * time.Millisecond),
+			Children: []*Step{
+				{
+					Component: components.ComponentOfChatModel,
+					Type:      "OpenAI",
+					Start:     start,
+					End:       start.Add(time.Second),
+					InputMessages: []*schema.Message{
+						schema.UserMessage("first question"),
+						schema.AssistantMessage("first answer", nil),
+						schema.UserMessage("second question with ```code```"),
+					},
+					OutputMessage: schema.AssistantMessage("<script>alert(1)</script>", nil),
+				},
+				{
+					Component: components.ComponentOfTool,
+					Name:      "search",
+					Start:     start.Add(time.Second),
+					Input:     `{"query":"eino"}`,
+					Error:     "timeout",
+				},
+			},
+		},
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	content := string(renderMarkdown(newTestTranscript(), &renderOptions{maxContentLength: -1}))
+
+	assert.True(t, strings.HasPrefix(content, "# Transcript run-1\n\n"))
+	assert.Contains(t, content, "- Start: 2025-01-02T03:04:05Z\n- Duration: 1.5s\n- Status: success\n- a: 1\n- b: 2\n")
+	assert.Contains(t, content, "## Graph `agent` - 1.5s")
+	assert.Contains(t, content, "### ChatModel (OpenAI) - 1s")
+	assert.Contains(t, content, "_2 earlier messages omitted_")
+	assert.NotContains(t, content, "first question")
+	// the fence is longer than the backticks of the content
+	assert.Contains(t, content, "````\nsecond question with ```code```\n````")
+	assert.Contains(t, content, "### Tool `search` - unfinished")
+	assert.Contains(t, content, "**Error**\n\n```\ntimeout\n```")
+
+	content = string(renderMarkdown(newTestTranscript(), &renderOptions{maxContentLength: -1, fullModelInput: true}))
+	assert.Contains(t, content, "first question")
+	assert.NotContains(t, content, "earlier messages omitted")
+}
+
+func TestRenderHTML(t *testing.T) {
+	content := string(renderHTML(newTestTranscript(), &renderOptions{maxContentLength: -1}))
+
+	assert.Contains(t, content, "<title>Transcript run-1</title>")
+	assert.Contains(t, content, "<li>a: 1</li>")
+	assert.Contains(t, content, "<code>agent</code>")
+	assert.Contains(t, content, "2 earlier messages omitted")
+	assert.Contains(t, content, "&lt;script&gt;alert(1)&lt;/script&gt;")
+	assert.NotContains(t, content, "<script>")
+	assert.Contains(t, content, `<pre class="error">timeout</pre>`)
+	assert.Equal(t, 3, strings.Count(content, "<details open>"))
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "hello", truncate("hello", 5))
+	assert.Equal(t, "hello", truncate("hello", -1))
+	assert.Equal(t, "你好\n... (truncated 3 chars)", truncate("你好世界!", 2))
+	assert.Equal(t, "\n... (truncated 5 chars)", truncate("hello", 0))
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transcript
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Store saves the rendered transcripts, e.g. to disk with NewFileStore, or to an object storage.
+type Store interface {
+	Save(ctx context.Context, key string, content []byte) error
+}
+
+// NewFileStore creates a store saving the transcripts as files under dir, the key being the relative path of the file.
+func NewFileStore(dir string) (Store, error) {
+	if len(dir) == 0 {
+		return nil, errors.New("dir is required")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create dir: %w", err)
+	}
+	return &fileStore{dir: dir}, nil
+}
+
+type fileStore struct {
+	dir string
+}
+
+func (s *fileStore) Save(_ context.Context, key string, content []byte) error {
+	path := filepath.Join(s.dir, filepath.FromSlash(key))
+	if rel, err := filepath.Rel(s.dir, path); err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return fmt.Errorf("invalid transcript key: %s", key)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create dir: %w", err)
+	}
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return fmt.Errorf("failed to write transcript: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transcript
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileStore(t *testing.T) {
+	_, err := NewFileStore("")
+	assert.Error(t, err)
+
+	dir := t.TempDir()
+	store, err := NewFileStore(filepath.Join(dir, "transcripts"))
+	assert.NoError(t, err)
+
+	assert.NoError(t, store.Save(context.Background(), "2025-01-02/run-1.md", []byte("content")))
+	b, err := os.ReadFile(filepath.Join(dir, "transcripts", "2025-01-02", "run-1.md"))
+	assert.NoError(t, err)
+	assert.Equal(t, "content", string(b))
+
+	assert.Error(t, store.Save(context.Background(), "../run-1.md", []byte("content")))
+	assert.Error(t, store.Save(context.Background(), "", []byte("content")))
+	_, err = os.Stat(filepath.Join(dir, "run-1.md"))
+	assert.True(t, os.IsNotExist(err))
+}
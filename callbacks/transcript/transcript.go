@@ -0,0 +1,110 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transcript
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+// Transcript is the record of a graph run.
+type Transcript struct {
+	ID        string
+	SessionID string
+	Metadata  map[string]string
+	// Root is the step of the graph run, the steps of the nodes being its children.
+	Root *Step
+}
+
+// Duration returns the duration of the run.
+func (t *Transcript) Duration() time.Duration {
+	return t.Root.Duration()
+}
+
+// Step is the record of a component run.
+type Step struct {
+	Component components.Component
+	Type      string
+	Name      string
+	Start     time.Time
+	End       time.Time
+
+	// InputMessages and OutputMessage are the input and output of a chat model.
+	InputMessages []*schema.Message
+	OutputMessage *schema.Message
+	TokenUsage    *model.TokenUsage
+
+	// Input and Output are the input and output of the other components, the arguments and the result of a tool,
+	// or the json of the input and output of the others.
+	Input  string
+	Output string
+
+	// Error is the error of the run, empty if succeeded.
+	Error string
+
+	Children []*Step
+}
+
+// Duration returns the duration of the step, zero if it did not end.
+func (s *Step) Duration() time.Duration {
+	if s.End.IsZero() {
+		return 0
+	}
+	return s.End.Sub(s.Start)
+}
+
+type transcriptOptionKey struct{}
+
+// SetTranscript sets the options of the transcript of the graph runs with ctx.
+func SetTranscript(ctx context.Context, opts ...Option) context.Context {
+	options := &transcriptOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return context.WithValue(ctx, transcriptOptionKey{}, options)
+}
+
+type Option func(*transcriptOptions)
+
+// WithID sets the id of the transcript, a random id by default.
+func WithID(id string) Option {
+	return func(o *transcriptOptions) {
+		o.ID = id
+	}
+}
+
+func WithSessionID(sessionID string) Option {
+	return func(o *transcriptOptions) {
+		o.SessionID = sessionID
+	}
+}
+
+func WithMetadata(metadata map[string]string) Option {
+	return func(o *transcriptOptions) {
+		o.Metadata = metadata
+	}
+}
+
+type transcriptOptions struct {
+	ID        string
+	SessionID string
+	Metadata  map[string]string
+}
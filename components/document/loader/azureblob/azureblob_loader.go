@@ -0,0 +1,455 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package azureblob can load document from Azure Blob Storage.
+package azureblob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	MetaKeyContainer    = "_azblob_container"
+	MetaKeyBlobName     = "_azblob_name"
+	MetaKeyETag         = "_azblob_etag"
+	MetaKeyLastModified = "_azblob_last_modified"
+	MetaKeySize         = "_azblob_size"
+	MetaKeySource       = "_source"
+)
+
+const defaultConcurrency = 4
+
+// LoaderConfig is the configuration for azure blob loader.
+// The client is created from the first available of: Client, ConnectionString,
+// AccountName + AccountKey, Credential, and finally azidentity.DefaultAzureCredential.
+type LoaderConfig struct {
+	// ServiceURL is the blob service endpoint of the storage account, e.g. https://<account>.blob.core.windows.net/
+	// required unless Client or ConnectionString is set.
+	ServiceURL string
+
+	// Client is the azure blob client used by the loader, optional.
+	Client *azblob.Client
+	// ConnectionString is the storage account connection string, optional.
+	ConnectionString string
+	// AccountName and AccountKey are used for shared key authorization, optional, must be set together.
+	AccountName string
+	AccountKey  string
+	// Credential is the token credential for Microsoft Entra ID authorization, optional.
+	Credential azcore.TokenCredential
+
+	UseBlobNameAsID bool // whether to use blob name as document ID
+
+	Parser parser.Parser // the parser to parse the blob stream into documents, default to parser.TextParser, which directly converts []byte to string
+
+	// The following fields only take effect when the source uri is a prefix, e.g. azblob://container/docs/
+
+	// IncludePatterns are glob patterns (see path.Match) matched against both the blob name and its base name.
+	// Only blobs matching at least one pattern are loaded, empty means all blobs are included.
+	IncludePatterns []string
+	// ExcludePatterns are glob patterns (see path.Match) matched the same way as IncludePatterns.
+	// Blobs matching any of them are skipped, exclusion takes precedence over inclusion.
+	ExcludePatterns []string
+	// MaxBlobs limits the number of blobs loaded under a prefix, 0 means no limit.
+	MaxBlobs int
+	// Concurrency is the number of blobs downloaded concurrently, default 4.
+	Concurrency int
+}
+
+type loader struct {
+	client *azblob.Client
+
+	parser parser.Parser
+
+	useBlobNameAsID bool
+
+	includePatterns []string
+	excludePatterns []string
+	maxBlobs        int
+	concurrency     int
+}
+
+type blobItem struct {
+	name         string
+	eTag         *azcore.ETag
+	lastModified *time.Time
+	size         *int64
+}
+
+// NewAzureBlobLoader creates a new azure blob loader.
+func NewAzureBlobLoader(ctx context.Context, conf *LoaderConfig) (document.Loader, error) {
+	if conf == nil {
+		return nil, errors.New("new azure blob loader, config is nil")
+	}
+
+	for _, pattern := range append(append([]string{}, conf.IncludePatterns...), conf.ExcludePatterns...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("new azure blob loader, invalid glob pattern %q: %w", pattern, err)
+		}
+	}
+
+	if conf.MaxBlobs < 0 {
+		return nil, errors.New("new azure blob loader, max blobs must not be negative")
+	}
+
+	client, err := newClient(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	p := conf.Parser
+	if p == nil {
+		p = &parser.TextParser{}
+	}
+
+	concurrency := conf.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	return &loader{
+		client:          client,
+		parser:          p,
+		useBlobNameAsID: conf.UseBlobNameAsID,
+		includePatterns: conf.IncludePatterns,
+		excludePatterns: conf.ExcludePatterns,
+		maxBlobs:        conf.MaxBlobs,
+		concurrency:     concurrency,
+	}, nil
+}
+
+func newClient(conf *LoaderConfig) (*azblob.Client, error) {
+	if conf.Client != nil {
+		return conf.Client, nil
+	}
+
+	if len(conf.ConnectionString) > 0 {
+		client, err := azblob.NewClientFromConnectionString(conf.ConnectionString, nil)
+		if err != nil {
+			return nil, fmt.Errorf("new azure blob loader, create client from connection string err: %w", err)
+		}
+		return client, nil
+	}
+
+	if len(conf.ServiceURL) == 0 {
+		return nil, errors.New("new azure blob loader, service url is required")
+	}
+
+	var (
+		hasAccountName = len(conf.AccountName) > 0
+		hasAccountKey  = len(conf.AccountKey) > 0
+	)
+
+	if hasAccountName != hasAccountKey {
+		return nil, errors.New("new azure blob loader, account name and account key must be set together")
+	}
+
+	if hasAccountName {
+		cred, err := azblob.NewSharedKeyCredential(conf.AccountName, conf.AccountKey)
+		if err != nil {
+			return nil, fmt.Errorf("new azure blob loader, create shared key credential err: %w", err)
+		}
+
+		client, err := azblob.NewClientWithSharedKeyCredential(conf.ServiceURL, cred, nil)
+		if err != nil {
+			return nil, fmt.Errorf("new azure blob loader, create client err: %w", err)
+		}
+		return client, nil
+	}
+
+	cred := conf.Credential
+	if cred == nil {
+		defaultCred, err := azidentity.NewDefaultAzureCredential(nil)
+		if err != nil {
+			return nil, fmt.Errorf("new azure blob loader, create default credential err: %w", err)
+		}
+		cred = defaultCred
+	}
+
+	client, err := azblob.NewClient(conf.ServiceURL, cred, nil)
+	if err != nil {
+		return nil, fmt.Errorf("new azure blob loader, create client err: %w", err)
+	}
+
+	return client, nil
+}
+
+// Load loads the blob, or all blobs under the prefix, from the given URI.
+func (l *loader) Load(ctx context.Context, src document.Source, opts ...document.LoaderOption) (docs []*schema.Document, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, l.GetType(), components.ComponentOfLoader)
+	ctx = callbacks.OnStart(ctx, &document.LoaderCallbackInput{
+		Source: src,
+	})
+	defer func() {
+		if err != nil {
+			_ = callbacks.OnError(ctx, err)
+		}
+	}()
+
+	// parse document.Source's URI to be container + blob name, or container + prefix.
+	containerName, name, isPrefix, err := uriToContainerAndBlob(src.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	o := document.GetLoaderCommonOptions(&document.LoaderOptions{}, opts...)
+
+	if isPrefix {
+		docs, err = l.loadPrefix(ctx, containerName, name, o.ParserOptions)
+	} else {
+		docs, err = l.loadBlob(ctx, containerName, &blobItem{name: name}, o.ParserOptions)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	_ = callbacks.OnEnd(ctx, &document.LoaderCallbackOutput{
+		Source: src,
+		Docs:   docs,
+	})
+
+	return docs, nil
+}
+
+// loadPrefix lists all blobs under the prefix page by page, and loads the matched ones concurrently.
+func (l *loader) loadPrefix(ctx context.Context, containerName, prefix string, parserOpts []parser.Option) ([]*schema.Document, error) {
+	items, err := l.listBlobs(ctx, containerName, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		results  = make([][]*schema.Document, len(items))
+		sem      = make(chan struct{}, l.concurrency)
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for i := range items {
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			docs, err := l.loadBlob(ctx, containerName, items[i], parserOpts)
+			if err != nil {
+				// only the first error is reported, the others are likely caused by cancellation.
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+			results[i] = docs
+		}(i)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	var docs []*schema.Document
+	for _, r := range results {
+		docs = append(docs, r...)
+	}
+
+	return docs, nil
+}
+
+func (l *loader) listBlobs(ctx context.Context, containerName, prefix string) ([]*blobItem, error) {
+	var items []*blobItem
+
+	pager := l.client.NewListBlobsFlatPager(containerName, &azblob.ListBlobsFlatOptions{
+		Prefix: &prefix,
+	})
+
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("azure blob loader list blobs err, container= %s, prefix= %s: %w", containerName, prefix, err)
+		}
+
+		if page.Segment == nil {
+			continue
+		}
+
+		for _, item := range page.Segment.BlobItems {
+			if item == nil || item.Name == nil {
+				continue
+			}
+
+			name := *item.Name
+			// skip the "directory" placeholder blobs
+			if strings.HasSuffix(name, "/") || !l.match(name) {
+				continue
+			}
+
+			bi := &blobItem{name: name}
+			if item.Properties != nil {
+				bi.eTag = item.Properties.ETag
+				bi.lastModified = item.Properties.LastModified
+				bi.size = item.Properties.ContentLength
+			}
+			items = append(items, bi)
+
+			if l.maxBlobs > 0 && len(items) >= l.maxBlobs {
+				return items, nil
+			}
+		}
+	}
+
+	return items, nil
+}
+
+func (l *loader) match(name string) bool {
+	base := path.Base(name)
+	matchAny := func(patterns []string) bool {
+		for _, pattern := range patterns {
+			// patterns are validated in NewAzureBlobLoader, so the error can be ignored.
+			if ok, _ := path.Match(pattern, name); ok {
+				return true
+			}
+			if ok, _ := path.Match(pattern, base); ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	if matchAny(l.excludePatterns) {
+		return false
+	}
+
+	return len(l.includePatterns) == 0 || matchAny(l.includePatterns)
+}
+
+func (l *loader) loadBlob(ctx context.Context, containerName string, item *blobItem, parserOpts []parser.Option) ([]*schema.Document, error) {
+	resp, err := l.client.DownloadStream(ctx, containerName, item.name, nil)
+	if err != nil {
+		if bloberror.HasCode(err, bloberror.BlobNotFound, bloberror.ContainerNotFound) {
+			return nil, fmt.Errorf("azure blob loader container= %s, blob= %s not found, err: %w", containerName, item.name, err)
+		}
+
+		return nil, fmt.Errorf("azure blob loader download err: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// prefer the attributes returned by download, fallback to those from listing.
+	eTag, lastModified, size := item.eTag, item.lastModified, item.size
+	if resp.ETag != nil {
+		eTag = resp.ETag
+	}
+	if resp.LastModified != nil {
+		lastModified = resp.LastModified
+	}
+	if resp.ContentLength != nil {
+		size = resp.ContentLength
+	}
+
+	uri := fmt.Sprintf("azblob://%s/%s", containerName, item.name)
+	meta := map[string]any{
+		MetaKeyContainer: containerName,
+		MetaKeyBlobName:  item.name,
+		MetaKeySource:    uri,
+	}
+	if eTag != nil {
+		meta[MetaKeyETag] = strings.Trim(string(*eTag), `"`)
+	}
+	if lastModified != nil {
+		meta[MetaKeyLastModified] = *lastModified
+	}
+	if size != nil {
+		meta[MetaKeySize] = *size
+	}
+
+	docs, err := l.parser.Parse(ctx, resp.Body, append([]parser.Option{parser.WithURI(uri), parser.WithExtraMeta(meta)}, parserOpts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("azure blob loader parse err, blob= %s: %w", item.name, err)
+	}
+
+	if l.useBlobNameAsID {
+		for _, doc := range docs {
+			doc.ID = item.name
+		}
+	}
+
+	return docs, nil
+}
+
+func uriToContainerAndBlob(uri string) (containerName string, name string, isPrefix bool, err error) {
+	const (
+		uriPrefix = `azblob://`
+		separator = `/`
+	)
+
+	if len(uri) == 0 {
+		return "", "", false, errors.New("azure blob loader source uri is empty")
+	}
+
+	if !strings.HasPrefix(uri, uriPrefix) {
+		return "", "", false, fmt.Errorf("uri is not azure blob uri, uri: %s", uri)
+	}
+
+	containerAndName := strings.TrimPrefix(uri, uriPrefix)
+	containerEnd := strings.Index(containerAndName, separator)
+	if containerEnd == -1 {
+		return "", "", false, fmt.Errorf("azure blob uri incomplete: %s", uri)
+	}
+
+	containerName = containerAndName[:containerEnd]
+	name = containerAndName[containerEnd+1:]
+
+	if len(name) == 0 || strings.HasSuffix(name, separator) {
+		return containerName, name, true, nil
+	}
+
+	return containerName, name, false, nil
+}
+
+func (l *loader) GetType() string {
+	return "AzureBlobLoader"
+}
+
+func (l *loader) IsCallbacksEnabled() bool {
+	return true
+}
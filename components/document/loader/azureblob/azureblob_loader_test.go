@@ -0,0 +1,240 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package azureblob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"github.com/bytedance/mockey"
+	"github.com/cloudwego/eino/components/document"
+	"github.com/stretchr/testify/assert"
+)
+
+const testConnectionString = "DefaultEndpointsProtocol=https;AccountName=account;AccountKey=a2V5;EndpointSuffix=core.windows.net"
+
+func TestNewAzureBlobLoader(t *testing.T) {
+	mockey.PatchConvey("TestNewAzureBlobLoader", t, func() {
+		ctx := context.Background()
+
+		_, err := NewAzureBlobLoader(ctx, nil)
+		assert.Error(t, err)
+
+		_, err = NewAzureBlobLoader(ctx, &LoaderConfig{})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "service url is required")
+
+		_, err = NewAzureBlobLoader(ctx, &LoaderConfig{
+			ServiceURL:  "https://account.blob.core.windows.net/",
+			AccountName: "account",
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "must be set together")
+
+		_, err = NewAzureBlobLoader(ctx, &LoaderConfig{
+			ConnectionString: testConnectionString,
+			ExcludePatterns:  []string{"[a-"},
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid glob pattern")
+
+		_, err = NewAzureBlobLoader(ctx, &LoaderConfig{
+			ConnectionString: testConnectionString,
+			MaxBlobs:         -1,
+		})
+		assert.Error(t, err)
+
+		l, err := NewAzureBlobLoader(ctx, &LoaderConfig{
+			ServiceURL:  "https://account.blob.core.windows.net/",
+			AccountName: "account",
+			AccountKey:  "a2V5",
+		})
+		assert.NoError(t, err)
+		assert.NotNil(t, l)
+
+		l, err = NewAzureBlobLoader(ctx, &LoaderConfig{
+			ConnectionString: testConnectionString,
+		})
+		assert.NoError(t, err)
+		assert.NotNil(t, l)
+	})
+}
+
+func TestLoader_Load(t *testing.T) {
+	mockey.PatchConvey("TestLoader_Load", t, func() {
+		ctx := context.Background()
+		lastModified := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		l, err := NewAzureBlobLoader(ctx, &LoaderConfig{
+			ConnectionString: testConnectionString,
+			UseBlobNameAsID:  true,
+		})
+		assert.NoError(t, err)
+
+		_, err = l.Load(ctx, document.Source{URI: "container/blob"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not azure blob uri")
+
+		_, err = l.Load(ctx, document.Source{})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "uri is empty")
+
+		_, err = l.Load(ctx, document.Source{URI: "azblob://container"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "incomplete")
+
+		mockey.PatchConvey("download returns error", func() {
+			mockey.Mock((*azblob.Client).DownloadStream).Return(azblob.DownloadStreamResponse{}, errors.New("mock err")).Build()
+
+			_, err = l.Load(ctx, document.Source{URI: "azblob://container/blob"})
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "mock err")
+		})
+
+		mockey.PatchConvey("load single blob", func() {
+			mockey.Mock((*azblob.Client).DownloadStream).Return(azblob.DownloadStreamResponse{
+				DownloadResponse: blob.DownloadResponse{
+					Body:          io.NopCloser(strings.NewReader("hello world!")),
+					ETag:          to.Ptr(azcore.ETag(`"etag"`)),
+					LastModified:  &lastModified,
+					ContentLength: to.Ptr(int64(12)),
+				},
+			}, nil).Build()
+
+			docs, err := l.Load(ctx, document.Source{URI: "azblob://container/blob.txt"})
+			assert.NoError(t, err)
+			assert.Len(t, docs, 1)
+			assert.Equal(t, "hello world!", docs[0].Content)
+			assert.Equal(t, "blob.txt", docs[0].ID)
+			assert.Equal(t, "container", docs[0].MetaData[MetaKeyContainer])
+			assert.Equal(t, "etag", docs[0].MetaData[MetaKeyETag])
+			assert.Equal(t, lastModified, docs[0].MetaData[MetaKeyLastModified])
+			assert.Equal(t, int64(12), docs[0].MetaData[MetaKeySize])
+			assert.Equal(t, "azblob://container/blob.txt", docs[0].MetaData[MetaKeySource])
+		})
+	})
+}
+
+func TestLoader_LoadPrefix(t *testing.T) {
+	mockey.PatchConvey("TestLoader_LoadPrefix", t, func() {
+		ctx := context.Background()
+		lastModified := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		newItem := func(name, eTag string) *container.BlobItem {
+			return &container.BlobItem{
+				Name: to.Ptr(name),
+				Properties: &container.BlobProperties{
+					ETag:          to.Ptr(azcore.ETag(eTag)),
+					LastModified:  &lastModified,
+					ContentLength: to.Ptr(int64(1)),
+				},
+			}
+		}
+
+		pages := []azblob.ListBlobsFlatResponse{
+			{ListBlobsFlatSegmentResponse: container.ListBlobsFlatSegmentResponse{
+				Segment: &container.BlobFlatListSegment{BlobItems: []*container.BlobItem{
+					newItem("docs/", "dir"),
+					newItem("docs/a.md", "etag-a"),
+					newItem("docs/b.txt", "etag-b"),
+				}},
+			}},
+			{ListBlobsFlatSegmentResponse: container.ListBlobsFlatSegmentResponse{
+				Segment: &container.BlobFlatListSegment{BlobItems: []*container.BlobItem{
+					newItem("docs/sub/c.md", "etag-c"),
+					newItem("docs/sub/draft.md", "etag-d"),
+				}},
+			}},
+		}
+
+		mockey.Mock((*azblob.Client).NewListBlobsFlatPager).To(func(_ *azblob.Client, _ string, _ *azblob.ListBlobsFlatOptions) *runtime.Pager[azblob.ListBlobsFlatResponse] {
+			idx := 0
+			return runtime.NewPager(runtime.PagingHandler[azblob.ListBlobsFlatResponse]{
+				More: func(azblob.ListBlobsFlatResponse) bool {
+					return idx < len(pages)
+				},
+				Fetcher: func(context.Context, *azblob.ListBlobsFlatResponse) (azblob.ListBlobsFlatResponse, error) {
+					idx++
+					return pages[idx-1], nil
+				},
+			})
+		}).Build()
+
+		mockey.Mock((*azblob.Client).DownloadStream).To(func(_ *azblob.Client, _ context.Context, _ string, name string, _ *azblob.DownloadStreamOptions) (azblob.DownloadStreamResponse, error) {
+			return azblob.DownloadStreamResponse{
+				DownloadResponse: blob.DownloadResponse{
+					Body: io.NopCloser(strings.NewReader("content of " + name)),
+				},
+			}, nil
+		}).Build()
+
+		mockey.PatchConvey("load all blobs", func() {
+			l, err := NewAzureBlobLoader(ctx, &LoaderConfig{
+				ConnectionString: testConnectionString,
+				UseBlobNameAsID:  true,
+				Concurrency:      2,
+			})
+			assert.NoError(t, err)
+
+			docs, err := l.Load(ctx, document.Source{URI: "azblob://container/docs/"})
+			assert.NoError(t, err)
+			assert.Len(t, docs, 4)
+			assert.Equal(t, "docs/a.md", docs[0].ID)
+			assert.Equal(t, "content of docs/a.md", docs[0].Content)
+			assert.Equal(t, "etag-a", docs[0].MetaData[MetaKeyETag])
+			assert.Equal(t, lastModified, docs[0].MetaData[MetaKeyLastModified])
+			assert.Equal(t, "docs/sub/c.md", docs[2].ID)
+		})
+
+		mockey.PatchConvey("include and exclude patterns", func() {
+			l, err := NewAzureBlobLoader(ctx, &LoaderConfig{
+				ConnectionString: testConnectionString,
+				IncludePatterns:  []string{"*.md"},
+				ExcludePatterns:  []string{"draft*"},
+			})
+			assert.NoError(t, err)
+
+			docs, err := l.Load(ctx, document.Source{URI: "azblob://container/docs/"})
+			assert.NoError(t, err)
+			assert.Len(t, docs, 2)
+			assert.Equal(t, "docs/a.md", docs[0].MetaData[MetaKeyBlobName])
+			assert.Equal(t, "docs/sub/c.md", docs[1].MetaData[MetaKeyBlobName])
+		})
+
+		mockey.PatchConvey("max blobs", func() {
+			l, err := NewAzureBlobLoader(ctx, &LoaderConfig{
+				ConnectionString: testConnectionString,
+				MaxBlobs:         3,
+			})
+			assert.NoError(t, err)
+
+			docs, err := l.Load(ctx, document.Source{URI: "azblob://container/docs/"})
+			assert.NoError(t, err)
+			assert.Len(t, docs, 3)
+		})
+	})
+}
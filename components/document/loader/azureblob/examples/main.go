@@ -0,0 +1,59 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/components/document"
+
+	"github.com/cloudwego/eino-ext/components/document/loader/azureblob"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// without ConnectionString or AccountName/AccountKey, azidentity.DefaultAzureCredential is used.
+	loader, err := azureblob.NewAzureBlobLoader(ctx, &azureblob.LoaderConfig{
+		ServiceURL:       os.Getenv("AZURE_STORAGE_SERVICE_URL"),
+		ConnectionString: os.Getenv("AZURE_STORAGE_CONNECTION_STRING"),
+		UseBlobNameAsID:  true,
+		IncludePatterns:  []string{"*.md", "*.txt"},
+		Concurrency:      8,
+	})
+	if err != nil {
+		log.Fatalf("NewAzureBlobLoader failed, err=%v", err)
+	}
+
+	// load a single blob
+	docs, err := loader.Load(ctx, document.Source{URI: "azblob://my-container/docs/readme.md"})
+	if err != nil {
+		log.Fatalf("load blob failed, err=%v", err)
+	}
+	log.Printf("blob docs: %d", len(docs))
+
+	// load all matched blobs under the prefix
+	docs, err = loader.Load(ctx, document.Source{URI: "azblob://my-container/docs/"})
+	if err != nil {
+		log.Fatalf("load prefix failed, err=%v", err)
+	}
+	for _, doc := range docs {
+		log.Printf("id: %s, etag: %v", doc.ID, doc.MetaData[azureblob.MetaKeyETag])
+	}
+}
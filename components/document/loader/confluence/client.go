@@ -0,0 +1,199 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package confluence
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bytedance/sonic"
+)
+
+// contentExpand are the fields expanded when fetching pages.
+const contentExpand = "body.storage,version,space,ancestors,metadata.labels"
+
+type apiError struct {
+	StatusCode int
+	Message    string `json:"message"`
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("confluence api error, status= %d, message= %s", e.StatusCode, e.Message)
+}
+
+type links struct {
+	Base  string `json:"base"`
+	WebUI string `json:"webui"`
+	Next  string `json:"next"`
+}
+
+type content struct {
+	ID     string `json:"id"`
+	Type   string `json:"type"`
+	Status string `json:"status"`
+	Title  string `json:"title"`
+	Space  *struct {
+		Key  string `json:"key"`
+		Name string `json:"name"`
+	} `json:"space"`
+	Version *struct {
+		Number int       `json:"number"`
+		When   time.Time `json:"when"`
+	} `json:"version"`
+	Ancestors []struct {
+		ID    string `json:"id"`
+		Title string `json:"title"`
+	} `json:"ancestors"`
+	Metadata *struct {
+		Labels *struct {
+			Results []struct {
+				Name string `json:"name"`
+			} `json:"results"`
+		} `json:"labels"`
+	} `json:"metadata"`
+	Body *struct {
+		Storage *struct {
+			Value string `json:"value"`
+		} `json:"storage"`
+	} `json:"body"`
+	Links links `json:"_links"`
+}
+
+type contentList struct {
+	Results []*content `json:"results"`
+	Links   links      `json:"_links"`
+}
+
+type client struct {
+	baseURL    string
+	username   string
+	apiToken   string
+	token      string
+	httpClient *http.Client
+	maxRetries int
+	pageSize   int
+}
+
+func (c *client) getContent(ctx context.Context, id string) (*content, error) {
+	query := url.Values{"expand": {contentExpand}}
+
+	result := &content{}
+	if err := c.get(ctx, "/rest/api/content/"+url.PathEscape(id)+"?"+query.Encode(), result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *client) listSpacePages(ctx context.Context, spaceKey string) ([]*content, error) {
+	query := url.Values{
+		"spaceKey": {spaceKey},
+		"type":     {"page"},
+		"status":   {"current"},
+		"expand":   {contentExpand},
+		"limit":    {strconv.Itoa(c.pageSize)},
+	}
+	return c.list(ctx, "/rest/api/content?"+query.Encode())
+}
+
+func (c *client) search(ctx context.Context, cql string) ([]*content, error) {
+	query := url.Values{
+		"cql":    {cql},
+		"expand": {contentExpand},
+		"limit":  {strconv.Itoa(c.pageSize)},
+	}
+	return c.list(ctx, "/rest/api/content/search?"+query.Encode())
+}
+
+// list fetches all pages of the listing by following the next links,
+// which works for both the offset pagination of Server and the cursor pagination of Cloud.
+func (c *client) list(ctx context.Context, path string) ([]*content, error) {
+	var results []*content
+	for len(path) > 0 {
+		resp := &contentList{}
+		if err := c.get(ctx, path, resp); err != nil {
+			return nil, err
+		}
+
+		results = append(results, resp.Results...)
+		path = resp.Links.Next
+	}
+	return results, nil
+}
+
+// get sends the request, and retries on rate limiting and server errors,
+// honoring the Retry-After header when present.
+func (c *client) get(ctx context.Context, path string, result any) error {
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+		if err != nil {
+			return fmt.Errorf("create request err: %w", err)
+		}
+		req.Header.Set("Accept", "application/json")
+		if len(c.token) > 0 {
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		} else if len(c.username) > 0 {
+			req.SetBasicAuth(c.username, c.apiToken)
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("do request err: %w", err)
+		}
+
+		data, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("read response err: %w", err)
+		}
+
+		if resp.StatusCode == http.StatusOK {
+			if err = sonic.Unmarshal(data, result); err != nil {
+				return fmt.Errorf("decode response err: %w", err)
+			}
+			return nil
+		}
+
+		apiErr := &apiError{}
+		_ = sonic.Unmarshal(data, apiErr)
+		apiErr.StatusCode = resp.StatusCode
+		if len(apiErr.Message) == 0 {
+			apiErr.Message = strings.TrimSpace(string(data))
+		}
+
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+		if !retryable || attempt >= c.maxRetries {
+			return apiErr
+		}
+
+		wait := time.Duration(1<<attempt) * 500 * time.Millisecond
+		if seconds, e := strconv.Atoi(resp.Header.Get("Retry-After")); e == nil {
+			wait = time.Duration(seconds) * time.Second
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
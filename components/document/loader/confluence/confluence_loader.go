@@ -0,0 +1,264 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package confluence can load documents from Confluence Cloud and Server/Data Center.
+package confluence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	MetaKeyPageID       = "_confluence_page_id"
+	MetaKeyTitle        = "_confluence_title"
+	MetaKeySpaceKey     = "_confluence_space_key"
+	MetaKeyVersion      = "_confluence_version"
+	MetaKeyLastModified = "_confluence_last_modified"
+	MetaKeyLabels       = "_confluence_labels"
+	MetaKeyAncestors    = "_confluence_ancestors"
+	MetaKeyAncestorIDs  = "_confluence_ancestor_ids"
+	MetaKeyURL          = "_confluence_url"
+	MetaKeySource       = "_source"
+)
+
+const (
+	defaultMaxRetries = 3
+	defaultPageSize   = 25
+)
+
+// LoaderConfig is the configuration for confluence loader.
+type LoaderConfig struct {
+	// BaseURL is the base url of the confluence site, required.
+	// e.g. https://your-domain.atlassian.net/wiki for Cloud, https://confluence.example.com for Server/Data Center.
+	BaseURL string
+
+	// Username and APIToken are used for basic authentication, optional.
+	// For Cloud, use the account email and an API token, for Server/Data Center, use the username and password.
+	Username string
+	APIToken string
+	// Token is the personal access token of Server/Data Center, sent as bearer token, optional.
+	Token string
+
+	// HTTPClient is the client to send requests, default: http.DefaultClient
+	HTTPClient *http.Client
+	// MaxRetries is the max retry times on rate limiting and server errors, default: 3
+	MaxRetries int
+	// PageSize is the number of pages fetched per request, default: 25
+	PageSize int
+
+	// SkipTitle disables prepending the page title as a level-1 heading to the document content.
+	SkipTitle bool
+}
+
+// Loader loads Confluence pages as markdown documents, one document per page.
+// The document.Source's URI can be:
+//   - confluence://space/<SPACE_KEY>, all current pages in the space
+//   - confluence://page/<PAGE_ID>, a single page
+//   - confluence://cql/<CQL>, the pages matching the path-escaped CQL query, e.g. url.PathEscape(`space = ENG and label = "howto"`)
+type Loader struct {
+	client *client
+	conf   *LoaderConfig
+}
+
+var _ document.Loader = (*Loader)(nil)
+
+// NewLoader creates a new confluence loader.
+func NewLoader(_ context.Context, conf *LoaderConfig) (*Loader, error) {
+	if conf == nil {
+		return nil, errors.New("new confluence loader, config is nil")
+	}
+	if len(conf.BaseURL) == 0 {
+		return nil, errors.New("new confluence loader, base url is required")
+	}
+	if len(conf.Username) > 0 && len(conf.APIToken) == 0 {
+		return nil, errors.New("new confluence loader, api token is required with username")
+	}
+
+	c := &client{
+		baseURL:    strings.TrimRight(conf.BaseURL, "/"),
+		username:   conf.Username,
+		apiToken:   conf.APIToken,
+		token:      conf.Token,
+		httpClient: conf.HTTPClient,
+		maxRetries: conf.MaxRetries,
+		pageSize:   conf.PageSize,
+	}
+	if c.httpClient == nil {
+		c.httpClient = http.DefaultClient
+	}
+	if c.maxRetries <= 0 {
+		c.maxRetries = defaultMaxRetries
+	}
+	if c.pageSize <= 0 {
+		c.pageSize = defaultPageSize
+	}
+
+	return &Loader{client: c, conf: conf}, nil
+}
+
+func (l *Loader) Load(ctx context.Context, src document.Source, opts ...document.LoaderOption) (docs []*schema.Document, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, l.GetType(), components.ComponentOfLoader)
+	ctx = callbacks.OnStart(ctx, &document.LoaderCallbackInput{
+		Source: src,
+	})
+	defer func() {
+		if err != nil {
+			_ = callbacks.OnError(ctx, err)
+		}
+	}()
+
+	kind, value, err := parseURI(src.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	var pages []*content
+	switch kind {
+	case "space":
+		pages, err = l.client.listSpacePages(ctx, value)
+	case "page":
+		var p *content
+		if p, err = l.client.getContent(ctx, value); err == nil {
+			pages = []*content{p}
+		}
+	case "cql":
+		pages, err = l.client.search(ctx, value)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("confluence loader fetch pages of [%s] err: %w", src.URI, err)
+	}
+
+	docs = make([]*schema.Document, 0, len(pages))
+	for _, p := range pages {
+		if p.Type != "page" && p.Type != "blogpost" {
+			continue
+		}
+
+		doc, e := l.toDocument(p)
+		if e != nil {
+			return nil, e
+		}
+		docs = append(docs, doc)
+	}
+
+	_ = callbacks.OnEnd(ctx, &document.LoaderCallbackOutput{
+		Source: src,
+		Docs:   docs,
+	})
+
+	return docs, nil
+}
+
+func (l *Loader) toDocument(p *content) (*schema.Document, error) {
+	var storage string
+	if p.Body != nil && p.Body.Storage != nil {
+		storage = p.Body.Storage.Value
+	}
+
+	md, err := storageToMarkdown(storage)
+	if err != nil {
+		return nil, fmt.Errorf("confluence loader convert page [%s] err: %w", p.ID, err)
+	}
+	if !l.conf.SkipTitle && len(p.Title) > 0 {
+		md = strings.TrimSpace("# " + p.Title + "\n\n" + md)
+	}
+
+	labels := make([]string, 0)
+	if p.Metadata != nil && p.Metadata.Labels != nil {
+		for _, label := range p.Metadata.Labels.Results {
+			labels = append(labels, label.Name)
+		}
+	}
+
+	ancestors := make([]string, 0, len(p.Ancestors))
+	ancestorIDs := make([]string, 0, len(p.Ancestors))
+	for _, a := range p.Ancestors {
+		ancestors = append(ancestors, a.Title)
+		ancestorIDs = append(ancestorIDs, a.ID)
+	}
+
+	webURL := l.client.baseURL + p.Links.WebUI
+
+	meta := map[string]any{
+		MetaKeyPageID:      p.ID,
+		MetaKeyTitle:       p.Title,
+		MetaKeyLabels:      labels,
+		MetaKeyAncestors:   ancestors,
+		MetaKeyAncestorIDs: ancestorIDs,
+		MetaKeyURL:         webURL,
+		MetaKeySource:      webURL,
+	}
+	if p.Space != nil {
+		meta[MetaKeySpaceKey] = p.Space.Key
+	}
+	if p.Version != nil {
+		meta[MetaKeyVersion] = p.Version.Number
+		meta[MetaKeyLastModified] = p.Version.When
+	}
+
+	return &schema.Document{
+		ID:       p.ID,
+		Content:  md,
+		MetaData: meta,
+	}, nil
+}
+
+func parseURI(uri string) (kind string, value string, err error) {
+	const uriPrefix = "confluence://"
+
+	if len(uri) == 0 {
+		return "", "", errors.New("confluence loader source uri is empty")
+	}
+	if !strings.HasPrefix(uri, uriPrefix) {
+		return "", "", fmt.Errorf("uri is not confluence uri, uri: %s", uri)
+	}
+
+	kind, value, found := strings.Cut(strings.TrimPrefix(uri, uriPrefix), "/")
+	if !found || len(value) == 0 {
+		return "", "", fmt.Errorf("confluence uri incomplete: %s", uri)
+	}
+
+	switch kind {
+	case "space", "page":
+		return kind, value, nil
+	case "cql":
+		cql, err := url.PathUnescape(value)
+		if err != nil {
+			return "", "", fmt.Errorf("confluence uri has invalid cql: %w", err)
+		}
+		return kind, cql, nil
+	}
+
+	return "", "", fmt.Errorf("confluence uri has unknown kind %q, expect space, page or cql", kind)
+}
+
+func (l *Loader) GetType() string {
+	return "ConfluenceLoader"
+}
+
+func (l *Loader) IsCallbacksEnabled() bool {
+	return true
+}
@@ -0,0 +1,170 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package confluence
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pageJSON = `{
+	"id": "%s", "type": "page", "status": "current", "title": "%s",
+	"space": {"key": "ENG", "name": "Engineering"},
+	"version": {"number": 3, "when": "2024-01-02T03:04:05.000Z"},
+	"ancestors": [{"id": "1", "title": "Home"}, {"id": "2", "title": "Guides"}],
+	"metadata": {"labels": {"results": [{"prefix": "global", "name": "howto"}]}},
+	"body": {"storage": {"value": "<p>content of %s</p>", "representation": "storage"}},
+	"_links": {"webui": "/spaces/ENG/pages/%s"}
+}`
+
+func pageOf(id, title string) string {
+	return fmt.Sprintf(pageJSON, id, title, title, id)
+}
+
+type fakeConfluence struct {
+	rateLimited atomic.Bool
+	lastQuery   url.Values
+}
+
+func (f *fakeConfluence) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, pass, ok := r.BasicAuth()
+	if !ok || user != "user@example.com" || pass != "token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"statusCode": 401, "message": "unauthorized"}`))
+		return
+	}
+
+	if f.rateLimited.CompareAndSwap(true, false) {
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+		return
+	}
+
+	f.lastQuery = r.URL.Query()
+
+	switch r.URL.Path {
+	case "/wiki/rest/api/content":
+		if r.URL.Query().Get("start") == "" {
+			_, _ = w.Write([]byte(`{"results": [` + pageOf("10", "First") + `], "_links": {"next": "/rest/api/content?spaceKey=ENG&start=1"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"results": [` + pageOf("11", "Second") + `], "_links": {}}`))
+	case "/wiki/rest/api/content/search":
+		_, _ = w.Write([]byte(`{"results": [` + pageOf("12", "Found") + `], "_links": {}}`))
+	case "/wiki/rest/api/content/10":
+		_, _ = w.Write([]byte(pageOf("10", "First")))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"statusCode": 404, "message": "not found"}`))
+	}
+}
+
+func TestNewLoader(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewLoader(ctx, nil)
+	assert.Error(t, err)
+
+	_, err = NewLoader(ctx, &LoaderConfig{})
+	assert.Error(t, err)
+
+	_, err = NewLoader(ctx, &LoaderConfig{BaseURL: "https://example.atlassian.net/wiki", Username: "user"})
+	assert.Error(t, err)
+
+	l, err := NewLoader(ctx, &LoaderConfig{BaseURL: "https://example.atlassian.net/wiki/", Token: "pat"})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.atlassian.net/wiki", l.client.baseURL)
+	assert.Equal(t, defaultPageSize, l.client.pageSize)
+}
+
+func TestLoader_Load(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeConfluence{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	l, err := NewLoader(ctx, &LoaderConfig{
+		BaseURL:  server.URL + "/wiki",
+		Username: "user@example.com",
+		APIToken: "token",
+	})
+	require.NoError(t, err)
+
+	_, err = l.Load(ctx, document.Source{})
+	assert.Error(t, err)
+
+	_, err = l.Load(ctx, document.Source{URI: "https://example.com/space/ENG"})
+	assert.Error(t, err)
+
+	_, err = l.Load(ctx, document.Source{URI: "confluence://folder/ENG"})
+	assert.Error(t, err)
+
+	_, err = l.Load(ctx, document.Source{URI: "confluence://space/"})
+	assert.Error(t, err)
+
+	fake.rateLimited.Store(true)
+	docs, err := l.Load(ctx, document.Source{URI: "confluence://space/ENG"})
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	assert.Equal(t, "10", docs[0].ID)
+	assert.Equal(t, "# First\n\ncontent of First", docs[0].Content)
+	assert.Equal(t, "11", docs[1].ID)
+	assert.Equal(t, "ENG", docs[0].MetaData[MetaKeySpaceKey])
+	assert.Equal(t, 3, docs[0].MetaData[MetaKeyVersion])
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), docs[0].MetaData[MetaKeyLastModified])
+	assert.Equal(t, []string{"howto"}, docs[0].MetaData[MetaKeyLabels])
+	assert.Equal(t, []string{"Home", "Guides"}, docs[0].MetaData[MetaKeyAncestors])
+	assert.Equal(t, []string{"1", "2"}, docs[0].MetaData[MetaKeyAncestorIDs])
+	assert.Equal(t, server.URL+"/wiki/spaces/ENG/pages/10", docs[0].MetaData[MetaKeyURL])
+
+	cql := `space = ENG and label = "howto"`
+	docs, err = l.Load(ctx, document.Source{URI: "confluence://cql/" + url.PathEscape(cql)})
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, "12", docs[0].ID)
+	assert.Equal(t, cql, fake.lastQuery.Get("cql"))
+
+	docs, err = l.Load(ctx, document.Source{URI: "confluence://page/10"})
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, "First", docs[0].MetaData[MetaKeyTitle])
+
+	_, err = l.Load(ctx, document.Source{URI: "confluence://page/404"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+
+	l, err = NewLoader(ctx, &LoaderConfig{
+		BaseURL:  server.URL + "/wiki",
+		Username: "user@example.com",
+		APIToken: "wrong",
+	})
+	require.NoError(t, err)
+
+	_, err = l.Load(ctx, document.Source{URI: "confluence://page/10"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "401")
+}
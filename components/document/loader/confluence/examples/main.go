@@ -0,0 +1,59 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"net/url"
+	"os"
+
+	"github.com/cloudwego/eino/components/document"
+
+	"github.com/cloudwego/eino-ext/components/document/loader/confluence"
+)
+
+func main() {
+	ctx := context.Background()
+
+	loader, err := confluence.NewLoader(ctx, &confluence.LoaderConfig{
+		BaseURL:  os.Getenv("CONFLUENCE_BASE_URL"), // e.g. https://your-domain.atlassian.net/wiki
+		Username: os.Getenv("CONFLUENCE_EMAIL"),
+		APIToken: os.Getenv("CONFLUENCE_API_TOKEN"),
+	})
+	if err != nil {
+		log.Fatalf("NewLoader failed, err=%v", err)
+	}
+
+	// load all pages of a space
+	docs, err := loader.Load(ctx, document.Source{URI: "confluence://space/ENG"})
+	if err != nil {
+		log.Fatalf("load space failed, err=%v", err)
+	}
+	log.Printf("space pages: %d", len(docs))
+
+	// load the pages matching a CQL query
+	cql := `space = ENG and label = "howto" and lastmodified > now("-7d")`
+	docs, err = loader.Load(ctx, document.Source{URI: "confluence://cql/" + url.PathEscape(cql)})
+	if err != nil {
+		log.Fatalf("load cql failed, err=%v", err)
+	}
+	for _, doc := range docs {
+		log.Printf("title: %v, ancestors: %v, labels: %v", doc.MetaData[confluence.MetaKeyTitle],
+			doc.MetaData[confluence.MetaKeyAncestors], doc.MetaData[confluence.MetaKeyLabels])
+	}
+}
@@ -0,0 +1,454 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package confluence
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	spaces     = regexp.MustCompile(`[ \t\r\n\x{00a0}]+`)
+	blankLines = regexp.MustCompile(`\n{3,}`)
+
+	cdata = regexp.MustCompile(`(?s)<!\[CDATA\[(.*?)\]\]>`)
+	// selfClosing matches the self-closing namespaced elements, e.g. <ri:page ri:content-title="x" />
+	selfClosing = regexp.MustCompile(`<([a-zA-Z]+:[\w-]+)([^<>]*?)/>`)
+)
+
+// storageToMarkdown converts the confluence storage format (XHTML with ac:/ri: elements) into markdown.
+func storageToMarkdown(storage string) (string, error) {
+	// the html parser treats CDATA sections as bogus comments and ignores the self-closing syntax of
+	// non-void elements, so both are rewritten into their html equivalents before parsing.
+	storage = cdata.ReplaceAllStringFunc(storage, func(s string) string {
+		return html.EscapeString(cdata.FindStringSubmatch(s)[1])
+	})
+	storage = selfClosing.ReplaceAllString(storage, "<$1$2></$1>")
+
+	nodes, err := html.ParseFragment(strings.NewReader(storage), &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		return "", fmt.Errorf("parse storage format err: %w", err)
+	}
+
+	root := &html.Node{Type: html.ElementNode, Data: "body"}
+	for _, n := range nodes {
+		root.AppendChild(n)
+	}
+
+	md := strings.Join(blocks(root), "\n\n")
+	return strings.TrimSpace(blankLines.ReplaceAllString(md, "\n\n")), nil
+}
+
+// blocks renders the children of n into markdown blocks, consecutive inline nodes are merged into a paragraph.
+func blocks(n *html.Node) []string {
+	var (
+		result    []string
+		paragraph strings.Builder
+	)
+
+	flush := func() {
+		text := cleanInline(paragraph.String())
+		if len(text) > 0 {
+			result = append(result, text)
+		}
+		paragraph.Reset()
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if !isBlock(c) {
+			paragraph.WriteString(inline(c))
+			continue
+		}
+
+		flush()
+		if b := block(c); len(b) > 0 {
+			result = append(result, b...)
+		}
+	}
+	flush()
+
+	return result
+}
+
+func isBlock(n *html.Node) bool {
+	if n.Type != html.ElementNode {
+		return false
+	}
+
+	switch n.Data {
+	case "p", "div", "section", "h1", "h2", "h3", "h4", "h5", "h6", "ul", "ol", "table", "pre", "blockquote", "hr",
+		"ac:structured-macro", "ac:task-list", "ac:layout", "ac:layout-section", "ac:layout-cell", "ac:rich-text-body":
+		return true
+	}
+	return false
+}
+
+func block(n *html.Node) []string {
+	switch n.Data {
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		level := int(n.Data[1] - '0')
+		text := cleanInline(inlineChildren(n))
+		if len(text) == 0 {
+			return nil
+		}
+		return []string{strings.Repeat("#", level) + " " + text}
+	case "ul", "ol":
+		if list := renderList(n); len(list) > 0 {
+			return []string{list}
+		}
+		return nil
+	case "table":
+		if table := renderTable(n); len(table) > 0 {
+			return []string{table}
+		}
+		return nil
+	case "pre":
+		return []string{codeBlock("", textContent(n))}
+	case "blockquote":
+		return []string{quote(strings.Join(blocks(n), "\n\n"))}
+	case "hr":
+		return []string{"---"}
+	case "ac:structured-macro":
+		return macro(n)
+	case "ac:task-list":
+		return []string{renderTasks(n)}
+	}
+
+	return blocks(n)
+}
+
+func macro(n *html.Node) []string {
+	name := attr(n, "ac:name")
+
+	switch name {
+	case "code", "noformat":
+		var language, body string
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != html.ElementNode {
+				continue
+			}
+			switch c.Data {
+			case "ac:parameter":
+				if attr(c, "ac:name") == "language" {
+					language = textContent(c)
+				}
+			case "ac:plain-text-body":
+				body = textContent(c)
+			}
+		}
+		return []string{codeBlock(language, body)}
+	case "info", "note", "tip", "warning", "panel", "expand":
+		body := richTextBody(n)
+		if len(body) == 0 {
+			return nil
+		}
+		return []string{quote(strings.Join(body, "\n\n"))}
+	}
+
+	// other macros, e.g. toc, jira, are dropped except for their rich text body.
+	return richTextBody(n)
+}
+
+func richTextBody(n *html.Node) []string {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.Data == "ac:rich-text-body" {
+			return blocks(c)
+		}
+	}
+	return nil
+}
+
+func renderList(n *html.Node) string {
+	var (
+		items   []string
+		ordered = n.Data == "ol"
+		idx     = 0
+	)
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode || c.Data != "li" {
+			continue
+		}
+		idx++
+
+		marker := "- "
+		if ordered {
+			marker = fmt.Sprintf("%d. ", idx)
+		}
+		indent := strings.Repeat(" ", len(marker))
+
+		content := blocks(c)
+		if len(content) == 0 {
+			items = append(items, strings.TrimSpace(marker))
+			continue
+		}
+
+		lines := strings.Split(strings.Join(content, "\n"), "\n")
+		for i, line := range lines {
+			if i == 0 {
+				lines[i] = marker + line
+			} else if len(line) > 0 {
+				lines[i] = indent + line
+			}
+		}
+		items = append(items, strings.Join(lines, "\n"))
+	}
+
+	return strings.Join(items, "\n")
+}
+
+func renderTasks(n *html.Node) string {
+	var items []string
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode || c.Data != "ac:task" {
+			continue
+		}
+
+		var done bool
+		var body string
+		for t := c.FirstChild; t != nil; t = t.NextSibling {
+			if t.Type != html.ElementNode {
+				continue
+			}
+			switch t.Data {
+			case "ac:task-status":
+				done = strings.TrimSpace(textContent(t)) == "complete"
+			case "ac:task-body":
+				body = cleanInline(inlineChildren(t))
+			}
+		}
+
+		if done {
+			items = append(items, "- [x] "+body)
+		} else {
+			items = append(items, "- [ ] "+body)
+		}
+	}
+	return strings.Join(items, "\n")
+}
+
+func renderTable(n *html.Node) string {
+	var rows [][]string
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != html.ElementNode {
+				continue
+			}
+			switch c.Data {
+			case "thead", "tbody", "tfoot":
+				walk(c)
+			case "tr":
+				var cells []string
+				for cell := c.FirstChild; cell != nil; cell = cell.NextSibling {
+					if cell.Type == html.ElementNode && (cell.Data == "td" || cell.Data == "th") {
+						text := strings.Join(blocks(cell), " ")
+						text = strings.ReplaceAll(strings.ReplaceAll(text, "\n", " "), "|", `\|`)
+						cells = append(cells, text)
+					}
+				}
+				rows = append(rows, cells)
+			}
+		}
+	}
+	walk(n)
+
+	if len(rows) == 0 {
+		return ""
+	}
+
+	width := 0
+	for _, row := range rows {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+
+	lines := make([]string, 0, len(rows)+1)
+	for i, row := range rows {
+		for len(row) < width {
+			row = append(row, "")
+		}
+		lines = append(lines, "| "+strings.Join(row, " | ")+" |")
+
+		// markdown tables require a header row, so the first row is always used as header.
+		if i == 0 {
+			sep := make([]string, width)
+			for j := range sep {
+				sep[j] = "---"
+			}
+			lines = append(lines, "| "+strings.Join(sep, " | ")+" |")
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func inlineChildren(n *html.Node) string {
+	var sb strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		sb.WriteString(inline(c))
+	}
+	return sb.String()
+}
+
+func inline(n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		return spaces.ReplaceAllString(n.Data, " ")
+	case html.ElementNode:
+	default:
+		return ""
+	}
+
+	switch n.Data {
+	case "br":
+		return "\n"
+	case "strong", "b":
+		return wrap(inlineChildren(n), "**")
+	case "em", "i":
+		return wrap(inlineChildren(n), "*")
+	case "s", "del":
+		return wrap(inlineChildren(n), "~~")
+	case "code":
+		return wrap(textContent(n), "`")
+	case "a":
+		text := strings.TrimSpace(inlineChildren(n))
+		href := attr(n, "href")
+		if len(href) == 0 {
+			return text
+		}
+		if len(text) == 0 {
+			text = href
+		}
+		return fmt.Sprintf("[%s](%s)", text, href)
+	case "ac:link":
+		return linkText(n)
+	case "ac:image":
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != html.ElementNode {
+				continue
+			}
+			switch c.Data {
+			case "ri:url":
+				return fmt.Sprintf("![%s](%s)", attr(n, "ac:alt"), attr(c, "ri:value"))
+			case "ri:attachment":
+				return fmt.Sprintf("![%s](%s)", attr(n, "ac:alt"), attr(c, "ri:filename"))
+			}
+		}
+		return ""
+	case "ac:emoticon", "ac:placeholder", "ac:parameter", "script", "style":
+		return ""
+	}
+
+	return inlineChildren(n)
+}
+
+// linkText returns the display text of confluence links, which is the link body or the title of the linked resource.
+func linkText(n *html.Node) string {
+	var target string
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode {
+			continue
+		}
+		switch c.Data {
+		case "ac:plain-text-link-body", "ac:link-body":
+			if text := strings.TrimSpace(textContent(c)); len(text) > 0 {
+				return text
+			}
+		case "ri:page", "ri:blog-post":
+			target = attr(c, "ri:content-title")
+		case "ri:attachment":
+			target = attr(c, "ri:filename")
+		case "ri:user":
+			target = "@user"
+		}
+	}
+	return target
+}
+
+func wrap(s, mark string) string {
+	trimmed := strings.TrimSpace(s)
+	if len(trimmed) == 0 {
+		return s
+	}
+	// keep the surrounding spaces outside the markers, e.g. " **bold** ".
+	leading := s[:strings.Index(s, trimmed)]
+	trailing := s[len(leading)+len(trimmed):]
+	return leading + mark + trimmed + mark + trailing
+}
+
+func cleanInline(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(spaces.ReplaceAllString(line, " "))
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+func codeBlock(language, body string) string {
+	return "```" + strings.TrimSpace(language) + "\n" + strings.Trim(body, "\n") + "\n```"
+}
+
+func quote(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if len(line) == 0 {
+			lines[i] = ">"
+		} else {
+			lines[i] = "> " + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// textContent returns the raw text of n and its descendants.
+func textContent(n *html.Node) string {
+	var sb strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			sb.WriteString(n.Data)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return sb.String()
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		name := a.Key
+		if len(a.Namespace) > 0 {
+			name = a.Namespace + ":" + a.Key
+		}
+		if name == key {
+			return a.Val
+		}
+	}
+	return ""
+}
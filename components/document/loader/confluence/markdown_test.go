@@ -0,0 +1,83 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package confluence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageToMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		storage  string
+		expected string
+	}{
+		{
+			name:     "empty",
+			storage:  "",
+			expected: "",
+		},
+		{
+			name:     "headings and inline styles",
+			storage:  `<h2>Title</h2><p>Hello <strong>bold</strong>, <em>italic</em> and <code>code</code>&nbsp;<a href="https://cloudwego.io">link</a></p>`,
+			expected: "## Title\n\nHello **bold**, *italic* and `code` [link](https://cloudwego.io)",
+		},
+		{
+			name:     "nested lists",
+			storage:  `<ul><li>a<ul><li>a1</li></ul></li><li>b</li></ul><ol><li>one</li><li>two</li></ol>`,
+			expected: "- a\n  - a1\n- b\n\n1. one\n2. two",
+		},
+		{
+			name:     "table",
+			storage:  `<table><tbody><tr><th>k</th><th>v</th></tr><tr><td><p>a|b</p></td><td>1</td></tr></tbody></table>`,
+			expected: "| k | v |\n| --- | --- |\n| a\\|b | 1 |",
+		},
+		{
+			name: "code macro",
+			storage: `<ac:structured-macro ac:name="code" ac:schema-version="1"><ac:parameter ac:name="language">go</ac:parameter>` +
+				`<ac:plain-text-body><![CDATA[if a > b {
+	return <nil>
+}]]></ac:plain-text-body></ac:structured-macro>`,
+			expected: "```go\nif a > b {\n\treturn <nil>\n}\n```",
+		},
+		{
+			name:     "info macro and links",
+			storage:  `<ac:structured-macro ac:name="info"><ac:rich-text-body><p>see <ac:link><ri:page ri:content-title="Guide" /></ac:link> and <ac:link><ri:page ri:content-title="X" /><ac:plain-text-link-body><![CDATA[other]]></ac:plain-text-link-body></ac:link></p></ac:rich-text-body></ac:structured-macro>`,
+			expected: "> see Guide and other",
+		},
+		{
+			name:     "self-closing elements keep siblings",
+			storage:  `<p>smile <ac:emoticon ac:name="smile" /> after</p><ac:structured-macro ac:name="toc" /><p>end</p>`,
+			expected: "smile after\n\nend",
+		},
+		{
+			name:     "tasks and images",
+			storage:  `<ac:task-list><ac:task><ac:task-status>complete</ac:task-status><ac:task-body>done</ac:task-body></ac:task><ac:task><ac:task-status>incomplete</ac:task-status><ac:task-body>todo</ac:task-body></ac:task></ac:task-list><p><ac:image ac:alt="logo"><ri:attachment ri:filename="logo.png" /></ac:image></p>`,
+			expected: "- [x] done\n- [ ] todo\n\n![logo](logo.png)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md, err := storageToMarkdown(tt.storage)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, md)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/cloudwego/eino/components/document"
+
+	"github.com/cloudwego/eino-ext/components/document/loader/feed"
+)
+
+func main() {
+	ctx := context.Background()
+
+	loader, err := feed.NewLoader(ctx, &feed.LoaderConfig{
+		UseEntryIDAsID: true,
+		MaxEntries:     20,
+	})
+	if err != nil {
+		log.Fatalf("feed.NewLoader failed, err=%v", err)
+	}
+
+	src := document.Source{URI: "https://go.dev/blog/feed.atom"}
+
+	// the first load fetches all entries
+	docs, err := loader.Load(ctx, src)
+	if err != nil {
+		log.Fatalf("Load failed, err=%v", err)
+	}
+	for _, doc := range docs {
+		log.Printf("id: %s, published: %v, authors: %v", doc.ID, doc.MetaData[feed.MetaKeyPublished], doc.MetaData[feed.MetaKeyAuthors])
+	}
+
+	// later polls only fetch the entries newer than the cursor
+	cursor := feed.Cursor(docs)
+	for i := 0; i < 3; i++ {
+		time.Sleep(time.Minute)
+
+		docs, err = loader.Load(ctx, src, feed.WithSince(cursor))
+		if err != nil {
+			log.Printf("Load failed, err=%v", err)
+			continue
+		}
+		if len(docs) > 0 {
+			cursor = feed.Cursor(docs)
+		}
+		log.Printf("new entries: %d", len(docs))
+	}
+}
@@ -0,0 +1,303 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package feed can load documents from RSS, Atom and JSON feeds.
+package feed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino-ext/components/document/parser/html"
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+	"github.com/mmcdole/gofeed"
+)
+
+const (
+	MetaKeyFeedTitle  = "_feed_title"
+	MetaKeyFeedLink   = "_feed_link"
+	MetaKeyEntryID    = "_feed_entry_id"
+	MetaKeyEntryTitle = "_feed_entry_title"
+	MetaKeyEntryLink  = "_feed_entry_link"
+	MetaKeyPublished  = "_feed_published"
+	MetaKeyUpdated    = "_feed_updated"
+	MetaKeyAuthors    = "_feed_authors"
+	MetaKeyCategories = "_feed_categories"
+	MetaKeySource     = "_source"
+)
+
+const defaultUserAgent = "eino-feed-loader"
+
+// LoaderConfig is the configuration for feed loader.
+type LoaderConfig struct {
+	// HTTPClient is the client to fetch the feed, optional, default http.DefaultClient.
+	HTTPClient *http.Client
+	// UserAgent is sent when fetching the feed, optional, default eino-feed-loader.
+	// Some feed hosts reject requests without a user agent.
+	UserAgent string
+
+	// Parser is the parser to parse the entry content into documents, optional.
+	// Default to parser/html, which extracts the text of the html content.
+	Parser parser.Parser
+
+	UseEntryIDAsID bool // whether to use the entry guid (or link if guid is missing) as document ID
+
+	// MaxEntries limits the number of entries loaded from a feed, 0 means no limit.
+	MaxEntries int
+
+	// SkipTitle disables prepending the entry title as a level-1 heading to the document content.
+	SkipTitle bool
+}
+
+// Loader loads the entries of RSS, Atom and JSON feeds, one document per entry.
+// The document.Source's URI is the http(s) url of the feed.
+type Loader struct {
+	conf *LoaderConfig
+	fp   *gofeed.Parser
+}
+
+// NewLoader creates a new feed loader.
+func NewLoader(ctx context.Context, conf *LoaderConfig) (*Loader, error) {
+	if conf == nil {
+		return nil, errors.New("new feed loader, config is nil")
+	}
+
+	if conf.MaxEntries < 0 {
+		return nil, errors.New("new feed loader, max entries must not be negative")
+	}
+
+	if conf.HTTPClient == nil {
+		conf.HTTPClient = http.DefaultClient
+	}
+	if conf.UserAgent == "" {
+		conf.UserAgent = defaultUserAgent
+	}
+	if conf.Parser == nil {
+		p, err := html.NewParser(ctx, &html.Config{})
+		if err != nil {
+			return nil, fmt.Errorf("new feed loader, create default parser err: %w", err)
+		}
+
+		conf.Parser = p
+	}
+
+	return &Loader{
+		conf: conf,
+		fp:   gofeed.NewParser(),
+	}, nil
+}
+
+// Load fetches the feed and loads its entries.
+func (l *Loader) Load(ctx context.Context, src document.Source, opts ...document.LoaderOption) (docs []*schema.Document, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, l.GetType(), components.ComponentOfLoader)
+	ctx = callbacks.OnStart(ctx, &document.LoaderCallbackInput{
+		Source: src,
+	})
+	defer func() {
+		if err != nil {
+			_ = callbacks.OnError(ctx, err)
+		}
+	}()
+
+	if !strings.HasPrefix(src.URI, "http://") && !strings.HasPrefix(src.URI, "https://") {
+		return nil, fmt.Errorf("feed loader uri is not http(s) url, uri: %s", src.URI)
+	}
+
+	f, err := l.fetch(ctx, src.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	o := document.GetLoaderCommonOptions(&document.LoaderOptions{}, opts...)
+	implOpts := document.GetLoaderImplSpecificOptions(&options{}, opts...)
+
+	var loaded int
+	for _, item := range f.Items {
+		if l.conf.MaxEntries > 0 && loaded >= l.conf.MaxEntries {
+			break
+		}
+
+		if implOpts.since != nil {
+			// entries without dates can not be compared with the cursor, so they are always loaded.
+			if t := entryTime(item); t != nil && !t.After(*implOpts.since) {
+				continue
+			}
+		}
+
+		entryDocs, err := l.loadEntry(ctx, src.URI, f, item, o.ParserOptions)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, entryDocs...)
+		loaded++
+	}
+
+	_ = callbacks.OnEnd(ctx, &document.LoaderCallbackOutput{
+		Source: src,
+		Docs:   docs,
+	})
+
+	return docs, nil
+}
+
+func (l *Loader) fetch(ctx context.Context, uri string) (*gofeed.Feed, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("feed loader new request err: %w", err)
+	}
+	req.Header.Set("User-Agent", l.conf.UserAgent)
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/feed+json, application/xml;q=0.9, */*;q=0.8")
+
+	resp, err := l.conf.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("feed loader fetch err, uri= %s: %w", uri, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("feed loader fetch err, uri= %s, status code: %d", uri, resp.StatusCode)
+	}
+
+	f, err := l.fp.Parse(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("feed loader parse feed err, uri= %s: %w", uri, err)
+	}
+
+	return f, nil
+}
+
+func (l *Loader) loadEntry(ctx context.Context, feedURI string, f *gofeed.Feed, item *gofeed.Item, parserOpts []parser.Option) ([]*schema.Document, error) {
+	id := item.GUID
+	if id == "" {
+		id = item.Link
+	}
+
+	source := item.Link
+	if source == "" {
+		source = feedURI
+	}
+
+	meta := map[string]any{
+		MetaKeyFeedTitle:  f.Title,
+		MetaKeyEntryTitle: item.Title,
+		MetaKeySource:     source,
+	}
+	if f.Link != "" {
+		meta[MetaKeyFeedLink] = f.Link
+	}
+	if id != "" {
+		meta[MetaKeyEntryID] = id
+	}
+	if item.Link != "" {
+		meta[MetaKeyEntryLink] = item.Link
+	}
+	if item.PublishedParsed != nil {
+		meta[MetaKeyPublished] = item.PublishedParsed.UTC()
+	}
+	if item.UpdatedParsed != nil {
+		meta[MetaKeyUpdated] = item.UpdatedParsed.UTC()
+	}
+	if authors := entryAuthors(f, item); len(authors) > 0 {
+		meta[MetaKeyAuthors] = authors
+	}
+	if len(item.Categories) > 0 {
+		meta[MetaKeyCategories] = item.Categories
+	}
+
+	// the full content is preferred, the description is usually a summary.
+	content := item.Content
+	if strings.TrimSpace(content) == "" {
+		content = item.Description
+	}
+
+	docs, err := l.conf.Parser.Parse(ctx, strings.NewReader(content), append([]parser.Option{parser.WithURI(source), parser.WithExtraMeta(meta)}, parserOpts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("feed loader parse entry err, entry= %s: %w", id, err)
+	}
+
+	for _, doc := range docs {
+		if !l.conf.SkipTitle && len(item.Title) > 0 {
+			doc.Content = strings.TrimSpace("# " + item.Title + "\n\n" + doc.Content)
+		}
+		if l.conf.UseEntryIDAsID {
+			doc.ID = id
+		}
+	}
+
+	return docs, nil
+}
+
+func (l *Loader) GetType() string {
+	return "FeedLoader"
+}
+
+func (l *Loader) IsCallbacksEnabled() bool {
+	return true
+}
+
+// Cursor returns the latest updated or published time of the documents, which can be passed to WithSince
+// on the next load. The zero time is returned if no document carries a time.
+func Cursor(docs []*schema.Document) time.Time {
+	var cursor time.Time
+	for _, doc := range docs {
+		for _, key := range []string{MetaKeyUpdated, MetaKeyPublished} {
+			if t, ok := doc.MetaData[key].(time.Time); ok && t.After(cursor) {
+				cursor = t
+			}
+		}
+	}
+
+	return cursor
+}
+
+// entryTime returns the updated time of the entry, falling back to the published time.
+func entryTime(item *gofeed.Item) *time.Time {
+	if item.UpdatedParsed != nil {
+		return item.UpdatedParsed
+	}
+
+	return item.PublishedParsed
+}
+
+// entryAuthors returns the names of the entry authors, falling back to the feed authors.
+func entryAuthors(f *gofeed.Feed, item *gofeed.Item) []string {
+	persons := item.Authors
+	if len(persons) == 0 {
+		persons = f.Authors
+	}
+
+	var authors []string
+	for _, p := range persons {
+		if p == nil {
+			continue
+		}
+		if name := strings.TrimSpace(p.Name); name != "" {
+			authors = append(authors, name)
+		} else if p.Email != "" {
+			authors = append(authors, p.Email)
+		}
+	}
+
+	return authors
+}
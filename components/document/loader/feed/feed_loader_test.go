@@ -0,0 +1,175 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package feed
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const rssFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:content="http://purl.org/rss/1.0/modules/content/" xmlns:dc="http://purl.org/dc/elements/1.1/">
+<channel>
+  <title>Eino Blog</title>
+  <link>https://example.com/blog</link>
+  <item>
+    <title>Second post</title>
+    <link>https://example.com/blog/2</link>
+    <guid>post-2</guid>
+    <pubDate>Tue, 02 Jan 2024 10:00:00 GMT</pubDate>
+    <dc:creator>Alice</dc:creator>
+    <category>release</category>
+    <description>summary</description>
+    <content:encoded><![CDATA[<p>Hello <b>eino</b></p>]]></content:encoded>
+  </item>
+  <item>
+    <title>First post</title>
+    <link>https://example.com/blog/1</link>
+    <pubDate>Mon, 01 Jan 2024 10:00:00 GMT</pubDate>
+    <description>&lt;p&gt;first summary&lt;/p&gt;</description>
+  </item>
+  <item>
+    <title>Undated</title>
+    <description>no date</description>
+  </item>
+</channel>
+</rss>`
+
+const atomFeed = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Eino Atom</title>
+  <link href="https://example.com/"/>
+  <author><name>Bob</name></author>
+  <entry>
+    <title>Atom entry</title>
+    <link href="https://example.com/atom/1"/>
+    <id>urn:uuid:1</id>
+    <published>2024-03-01T00:00:00Z</published>
+    <updated>2024-03-05T00:00:00Z</updated>
+    <content type="html">&lt;p&gt;atom content&lt;/p&gt;</content>
+  </entry>
+</feed>`
+
+func newFeedServer(t *testing.T) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, defaultUserAgent, r.Header.Get("User-Agent"))
+		switch r.URL.Path {
+		case "/rss":
+			_, _ = w.Write([]byte(rssFeed))
+		case "/atom":
+			_, _ = w.Write([]byte(atomFeed))
+		case "/invalid":
+			_, _ = w.Write([]byte("not a feed"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestLoader_Load(t *testing.T) {
+	ctx := context.Background()
+	srv := newFeedServer(t)
+
+	l, err := NewLoader(ctx, &LoaderConfig{UseEntryIDAsID: true})
+	require.NoError(t, err)
+
+	t.Run("rss", func(t *testing.T) {
+		docs, err := l.Load(ctx, document.Source{URI: srv.URL + "/rss"})
+		require.NoError(t, err)
+		require.Len(t, docs, 3)
+
+		assert.Equal(t, "post-2", docs[0].ID)
+		assert.Equal(t, "# Second post\n\nHello eino", docs[0].Content)
+		assert.Equal(t, "Eino Blog", docs[0].MetaData[MetaKeyFeedTitle])
+		assert.Equal(t, "https://example.com/blog/2", docs[0].MetaData[MetaKeySource])
+		assert.Equal(t, time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC), docs[0].MetaData[MetaKeyPublished])
+		assert.Equal(t, []string{"Alice"}, docs[0].MetaData[MetaKeyAuthors])
+		assert.Equal(t, []string{"release"}, docs[0].MetaData[MetaKeyCategories])
+
+		// the link is used as id when guid is missing, and the description when content is missing.
+		assert.Equal(t, "https://example.com/blog/1", docs[1].ID)
+		assert.Equal(t, "# First post\n\nfirst summary", docs[1].Content)
+
+		assert.Equal(t, srv.URL+"/rss", docs[2].MetaData[MetaKeySource])
+		assert.NotContains(t, docs[2].MetaData, MetaKeyPublished)
+
+		assert.Equal(t, time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC), Cursor(docs))
+	})
+
+	t.Run("atom", func(t *testing.T) {
+		docs, err := l.Load(ctx, document.Source{URI: srv.URL + "/atom"})
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+
+		assert.Equal(t, "urn:uuid:1", docs[0].ID)
+		assert.Equal(t, "# Atom entry\n\natom content", docs[0].Content)
+		assert.Equal(t, []string{"Bob"}, docs[0].MetaData[MetaKeyAuthors])
+		assert.Equal(t, time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), docs[0].MetaData[MetaKeyUpdated])
+		assert.Equal(t, time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), Cursor(docs))
+	})
+
+	t.Run("since", func(t *testing.T) {
+		docs, err := l.Load(ctx, document.Source{URI: srv.URL + "/rss"}, WithSince(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)))
+		require.NoError(t, err)
+		require.Len(t, docs, 2)
+		assert.Equal(t, "post-2", docs[0].ID)
+		assert.Equal(t, "# Undated\n\nno date", docs[1].Content)
+
+		docs, err = l.Load(ctx, document.Source{URI: srv.URL + "/atom"}, WithSince(time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)))
+		require.NoError(t, err)
+		assert.Empty(t, docs)
+	})
+
+	t.Run("max entries and skip title", func(t *testing.T) {
+		l, err := NewLoader(ctx, &LoaderConfig{MaxEntries: 1, SkipTitle: true})
+		require.NoError(t, err)
+
+		docs, err := l.Load(ctx, document.Source{URI: srv.URL + "/rss"})
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Equal(t, "Hello eino", docs[0].Content)
+		assert.Empty(t, docs[0].ID)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := NewLoader(ctx, nil)
+		assert.Error(t, err)
+		_, err = NewLoader(ctx, &LoaderConfig{MaxEntries: -1})
+		assert.Error(t, err)
+
+		_, err = l.Load(ctx, document.Source{URI: "file:///feed.xml"})
+		assert.Error(t, err)
+		_, err = l.Load(ctx, document.Source{URI: srv.URL + "/missing"})
+		assert.ErrorContains(t, err, "404")
+		_, err = l.Load(ctx, document.Source{URI: srv.URL + "/invalid"})
+		assert.ErrorContains(t, err, "parse feed")
+	})
+}
+
+func TestCursor(t *testing.T) {
+	assert.True(t, Cursor(nil).IsZero())
+}
@@ -0,0 +1,36 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package feed
+
+import (
+	"time"
+
+	"github.com/cloudwego/eino/components/document"
+)
+
+type options struct {
+	since *time.Time
+}
+
+// WithSince is a loader option that only loads the entries updated or published after the given time,
+// which is useful for polling a feed incrementally. The max MetaKeyUpdated / MetaKeyPublished of the previous load
+// can be used as the cursor, see Cursor.
+func WithSince(t time.Time) document.LoaderOption {
+	return document.WrapLoaderImplSpecificOptFn(func(o *options) {
+		o.since = &t
+	})
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/components/document"
+	"google.golang.org/api/option"
+
+	"github.com/cloudwego/eino-ext/components/document/loader/gcs"
+)
+
+func main() {
+	ctx := context.Background()
+
+	var opts []option.ClientOption
+	if credFile := os.Getenv("GOOGLE_CREDENTIALS_FILE"); credFile != "" {
+		opts = append(opts, option.WithCredentialsFile(credFile))
+	}
+
+	loader, err := gcs.NewGCSLoader(ctx, &gcs.LoaderConfig{
+		ClientOptions:     opts,
+		UseObjectNameAsID: true,
+		IncludePatterns:   []string{"*.md", "*.txt"},
+		Concurrency:       8,
+	})
+	if err != nil {
+		log.Fatalf("NewGCSLoader failed, err=%v", err)
+	}
+
+	// load a single object
+	docs, err := loader.Load(ctx, document.Source{URI: "gs://my-bucket/docs/readme.md"})
+	if err != nil {
+		log.Fatalf("load object failed, err=%v", err)
+	}
+	log.Printf("object docs: %d", len(docs))
+
+	// load all matched objects under the prefix
+	docs, err = loader.Load(ctx, document.Source{URI: "gs://my-bucket/docs/"})
+	if err != nil {
+		log.Fatalf("load prefix failed, err=%v", err)
+	}
+	for _, doc := range docs {
+		log.Printf("id: %s, generation: %v", doc.ID, doc.MetaData[gcs.MetaKeyGeneration])
+	}
+}
@@ -0,0 +1,410 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package gcs can load document from Google Cloud Storage.
+package gcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+const (
+	MetaKeyBucket       = "_gcs_bucket"
+	MetaKeyObjectName   = "_gcs_object"
+	MetaKeyETag         = "_gcs_etag"
+	MetaKeyGeneration   = "_gcs_generation"
+	MetaKeyLastModified = "_gcs_last_modified"
+	MetaKeySize         = "_gcs_size"
+	MetaKeySource       = "_source"
+)
+
+const defaultConcurrency = 4
+
+// LoaderConfig is the configuration for gcs loader.
+type LoaderConfig struct {
+	// Client is the gcs client used by the loader, optional.
+	// If not set, a client is created with ClientOptions.
+	Client *storage.Client
+	// ClientOptions are used to create the gcs client when Client is not set, optional.
+	// Application Default Credentials are used if no credentials option is provided.
+	ClientOptions []option.ClientOption
+
+	UseObjectNameAsID bool // whether to use object name as document ID
+
+	Parser parser.Parser // the parser to parse the gcs object stream into documents, default to parser.TextParser, which directly converts []byte to string
+
+	// The following fields only take effect when the source uri is a prefix, e.g. gs://bucket/docs/
+
+	// IncludePatterns are glob patterns (see path.Match) matched against both the object name and its base name.
+	// Only objects matching at least one pattern are loaded, empty means all objects are included.
+	IncludePatterns []string
+	// ExcludePatterns are glob patterns (see path.Match) matched the same way as IncludePatterns.
+	// Objects matching any of them are skipped, exclusion takes precedence over inclusion.
+	ExcludePatterns []string
+	// MaxObjects limits the number of objects loaded under a prefix, 0 means no limit.
+	MaxObjects int
+	// Concurrency is the number of objects downloaded concurrently, default 4.
+	Concurrency int
+}
+
+type loader struct {
+	client *storage.Client
+
+	parser parser.Parser
+
+	useObjectNameAsID bool
+
+	includePatterns []string
+	excludePatterns []string
+	maxObjects      int
+	concurrency     int
+}
+
+type object struct {
+	name         string
+	eTag         string
+	generation   int64
+	lastModified time.Time
+	size         int64
+}
+
+// NewGCSLoader creates a new gcs loader.
+func NewGCSLoader(ctx context.Context, conf *LoaderConfig) (document.Loader, error) {
+	if conf == nil {
+		return nil, errors.New("new gcs loader, config is nil")
+	}
+
+	for _, pattern := range append(append([]string{}, conf.IncludePatterns...), conf.ExcludePatterns...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("new gcs loader, invalid glob pattern %q: %w", pattern, err)
+		}
+	}
+
+	if conf.MaxObjects < 0 {
+		return nil, errors.New("new gcs loader, max objects must not be negative")
+	}
+
+	client := conf.Client
+	if client == nil {
+		var err error
+		client, err = storage.NewClient(ctx, conf.ClientOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("new gcs loader, create client err: %w", err)
+		}
+	}
+
+	p := conf.Parser
+	if p == nil {
+		p = &parser.TextParser{}
+	}
+
+	concurrency := conf.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	return &loader{
+		client:            client,
+		parser:            p,
+		useObjectNameAsID: conf.UseObjectNameAsID,
+		includePatterns:   conf.IncludePatterns,
+		excludePatterns:   conf.ExcludePatterns,
+		maxObjects:        conf.MaxObjects,
+		concurrency:       concurrency,
+	}, nil
+}
+
+// Load loads the gcs object, or all objects under the prefix, from the given URI.
+func (l *loader) Load(ctx context.Context, src document.Source, opts ...document.LoaderOption) (docs []*schema.Document, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, l.GetType(), components.ComponentOfLoader)
+	ctx = callbacks.OnStart(ctx, &document.LoaderCallbackInput{
+		Source: src,
+	})
+	defer func() {
+		if err != nil {
+			_ = callbacks.OnError(ctx, err)
+		}
+	}()
+
+	// parse document.Source's URI to be gcs bucket + object name, or bucket + prefix.
+	bucket, name, isPrefix, err := uriToBucketAndObject(src.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	o := document.GetLoaderCommonOptions(&document.LoaderOptions{}, opts...)
+
+	if isPrefix {
+		docs, err = l.loadPrefix(ctx, bucket, name, o.ParserOptions)
+	} else {
+		docs, err = l.loadObject(ctx, bucket, &object{name: name}, o.ParserOptions)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	_ = callbacks.OnEnd(ctx, &document.LoaderCallbackOutput{
+		Source: src,
+		Docs:   docs,
+	})
+
+	return docs, nil
+}
+
+// loadPrefix lists all objects under the prefix, and loads the matched ones concurrently.
+func (l *loader) loadPrefix(ctx context.Context, bucket, prefix string, parserOpts []parser.Option) ([]*schema.Document, error) {
+	objects, err := l.listObjects(ctx, bucket, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		results  = make([][]*schema.Document, len(objects))
+		sem      = make(chan struct{}, l.concurrency)
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for i := range objects {
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			docs, err := l.loadObject(ctx, bucket, objects[i], parserOpts)
+			if err != nil {
+				// only the first error is reported, the others are likely caused by cancellation.
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+			results[i] = docs
+		}(i)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	var docs []*schema.Document
+	for _, r := range results {
+		docs = append(docs, r...)
+	}
+
+	return docs, nil
+}
+
+func (l *loader) listObjects(ctx context.Context, bucket, prefix string) ([]*object, error) {
+	var objects []*object
+
+	it := l.client.Bucket(bucket).Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("gcs loader list objects err, bucket= %s, prefix= %s: %w", bucket, prefix, err)
+		}
+
+		// skip the "directory" placeholder objects
+		if strings.HasSuffix(attrs.Name, "/") || !l.match(attrs.Name) {
+			continue
+		}
+
+		objects = append(objects, &object{
+			name:         attrs.Name,
+			eTag:         attrs.Etag,
+			generation:   attrs.Generation,
+			lastModified: attrs.Updated,
+			size:         attrs.Size,
+		})
+
+		if l.maxObjects > 0 && len(objects) >= l.maxObjects {
+			break
+		}
+	}
+
+	return objects, nil
+}
+
+func (l *loader) match(name string) bool {
+	base := path.Base(name)
+	matchAny := func(patterns []string) bool {
+		for _, pattern := range patterns {
+			// patterns are validated in NewGCSLoader, so the error can be ignored.
+			if ok, _ := path.Match(pattern, name); ok {
+				return true
+			}
+			if ok, _ := path.Match(pattern, base); ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	if matchAny(l.excludePatterns) {
+		return false
+	}
+
+	return len(l.includePatterns) == 0 || matchAny(l.includePatterns)
+}
+
+// newReader opens the object for reading, the returned object carries the attributes known by the reader.
+func (l *loader) newReader(ctx context.Context, bucket, name string) (io.ReadCloser, *object, error) {
+	r, err := l.client.Bucket(bucket).Object(name).NewReader(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return r, &object{
+		name:         name,
+		generation:   r.Attrs.Generation,
+		lastModified: r.Attrs.LastModified,
+		size:         r.Attrs.Size,
+	}, nil
+}
+
+func (l *loader) loadObject(ctx context.Context, bucket string, obj *object, parserOpts []parser.Option) ([]*schema.Document, error) {
+	r, attrs, err := l.newReader(ctx, bucket, obj.name)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return nil, fmt.Errorf("gcs loader bucket= %s, object= %s not found, err: %w", bucket, obj.name, err)
+		}
+
+		return nil, fmt.Errorf("gcs loader new reader err: %w", err)
+	}
+	defer r.Close()
+
+	uri := fmt.Sprintf("gs://%s/%s", bucket, obj.name)
+	meta := map[string]any{
+		MetaKeyBucket:     bucket,
+		MetaKeyObjectName: obj.name,
+		MetaKeySource:     uri,
+	}
+
+	// prefer the attributes returned by the reader, fallback to those from listing.
+	eTag := obj.eTag
+	if attrs.eTag != "" {
+		eTag = attrs.eTag
+	}
+	if eTag != "" {
+		meta[MetaKeyETag] = eTag
+	}
+
+	generation := obj.generation
+	if attrs.generation != 0 {
+		generation = attrs.generation
+	}
+	if generation != 0 {
+		meta[MetaKeyGeneration] = generation
+	}
+
+	lastModified := obj.lastModified
+	if !attrs.lastModified.IsZero() {
+		lastModified = attrs.lastModified
+	}
+	if !lastModified.IsZero() {
+		meta[MetaKeyLastModified] = lastModified
+	}
+
+	size := obj.size
+	if attrs.size != 0 {
+		size = attrs.size
+	}
+	meta[MetaKeySize] = size
+
+	docs, err := l.parser.Parse(ctx, r, append([]parser.Option{parser.WithURI(uri), parser.WithExtraMeta(meta)}, parserOpts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("gcs loader parse err, object= %s: %w", obj.name, err)
+	}
+
+	if l.useObjectNameAsID {
+		for _, doc := range docs {
+			doc.ID = obj.name
+		}
+	}
+
+	return docs, nil
+}
+
+func uriToBucketAndObject(uri string) (bucket string, name string, isPrefix bool, err error) {
+	const (
+		uriPrefix = `gs://`
+		separator = `/`
+	)
+
+	if len(uri) == 0 {
+		return "", "", false, errors.New("gcs loader source uri is empty")
+	}
+
+	if !strings.HasPrefix(uri, uriPrefix) {
+		return "", "", false, fmt.Errorf("uri is not gcs uri, uri: %s", uri)
+	}
+
+	bucketAndName := strings.TrimPrefix(uri, uriPrefix)
+	bucketEnd := strings.Index(bucketAndName, separator)
+	if bucketEnd == -1 {
+		return "", "", false, fmt.Errorf("gcs uri incomplete: %s", uri)
+	}
+
+	bucket = bucketAndName[:bucketEnd]
+	name = bucketAndName[bucketEnd+1:]
+
+	if len(name) == 0 || strings.HasSuffix(name, separator) {
+		return bucket, name, true, nil
+	}
+
+	return bucket, name, false, nil
+}
+
+func (l *loader) GetType() string {
+	return "GCSLoader"
+}
+
+func (l *loader) IsCallbacksEnabled() bool {
+	return true
+}
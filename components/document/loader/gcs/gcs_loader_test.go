@@ -0,0 +1,198 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gcs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/bytedance/mockey"
+	"github.com/cloudwego/eino/components/document"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+func TestNewGCSLoader(t *testing.T) {
+	mockey.PatchConvey("TestNewGCSLoader", t, func() {
+		ctx := context.Background()
+
+		_, err := NewGCSLoader(ctx, nil)
+		assert.Error(t, err)
+
+		_, err = NewGCSLoader(ctx, &LoaderConfig{
+			ClientOptions:   []option.ClientOption{option.WithoutAuthentication()},
+			IncludePatterns: []string{"[a-"},
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid glob pattern")
+
+		_, err = NewGCSLoader(ctx, &LoaderConfig{
+			ClientOptions: []option.ClientOption{option.WithoutAuthentication()},
+			MaxObjects:    -1,
+		})
+		assert.Error(t, err)
+
+		l, err := NewGCSLoader(ctx, &LoaderConfig{
+			ClientOptions: []option.ClientOption{option.WithoutAuthentication()},
+		})
+		assert.NoError(t, err)
+		assert.NotNil(t, l)
+	})
+}
+
+func TestLoader_Load(t *testing.T) {
+	mockey.PatchConvey("TestLoader_Load", t, func() {
+		ctx := context.Background()
+		lastModified := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		l, err := NewGCSLoader(ctx, &LoaderConfig{
+			ClientOptions:     []option.ClientOption{option.WithoutAuthentication()},
+			UseObjectNameAsID: true,
+		})
+		assert.NoError(t, err)
+
+		_, err = l.Load(ctx, document.Source{URI: "bucket/object"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not gcs uri")
+
+		_, err = l.Load(ctx, document.Source{})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "uri is empty")
+
+		_, err = l.Load(ctx, document.Source{URI: "gs://bucket"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "incomplete")
+
+		mockey.PatchConvey("object not exist", func() {
+			mockey.Mock((*loader).newReader).Return(nil, nil, storage.ErrObjectNotExist).Build()
+
+			_, err = l.Load(ctx, document.Source{URI: "gs://bucket/object"})
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "not found")
+		})
+
+		mockey.PatchConvey("new reader returns other error", func() {
+			mockey.Mock((*loader).newReader).Return(nil, nil, errors.New("mock err")).Build()
+
+			_, err = l.Load(ctx, document.Source{URI: "gs://bucket/object"})
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "mock err")
+		})
+
+		mockey.PatchConvey("load single object", func() {
+			mockey.Mock((*loader).newReader).Return(io.NopCloser(strings.NewReader("hello world!")), &object{
+				name:         "object.txt",
+				generation:   1,
+				lastModified: lastModified,
+				size:         12,
+			}, nil).Build()
+
+			docs, err := l.Load(ctx, document.Source{URI: "gs://bucket/object.txt"})
+			assert.NoError(t, err)
+			assert.Len(t, docs, 1)
+			assert.Equal(t, "hello world!", docs[0].Content)
+			assert.Equal(t, "object.txt", docs[0].ID)
+			assert.Equal(t, "bucket", docs[0].MetaData[MetaKeyBucket])
+			assert.Equal(t, int64(1), docs[0].MetaData[MetaKeyGeneration])
+			assert.Equal(t, lastModified, docs[0].MetaData[MetaKeyLastModified])
+			assert.Equal(t, int64(12), docs[0].MetaData[MetaKeySize])
+			assert.Equal(t, "gs://bucket/object.txt", docs[0].MetaData[MetaKeySource])
+		})
+	})
+}
+
+func TestLoader_LoadPrefix(t *testing.T) {
+	mockey.PatchConvey("TestLoader_LoadPrefix", t, func() {
+		ctx := context.Background()
+		lastModified := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		listed := []*storage.ObjectAttrs{
+			{Name: "docs/"},
+			{Name: "docs/a.md", Etag: "etag-a", Generation: 1, Updated: lastModified, Size: 1},
+			{Name: "docs/b.txt", Etag: "etag-b", Generation: 1, Updated: lastModified, Size: 1},
+			{Name: "docs/sub/c.md", Etag: "etag-c", Generation: 1, Updated: lastModified, Size: 1},
+			{Name: "docs/sub/draft.md", Etag: "etag-d", Generation: 1, Updated: lastModified, Size: 1},
+		}
+
+		var idx int
+		mockey.Mock((*storage.ObjectIterator).Next).To(func(_ *storage.ObjectIterator) (*storage.ObjectAttrs, error) {
+			if idx >= len(listed) {
+				return nil, iterator.Done
+			}
+			idx++
+			return listed[idx-1], nil
+		}).Build()
+
+		mockey.Mock((*loader).newReader).To(func(_ *loader, _ context.Context, _, name string) (io.ReadCloser, *object, error) {
+			return io.NopCloser(strings.NewReader("content of " + name)), &object{name: name}, nil
+		}).Build()
+
+		mockey.PatchConvey("load all objects", func() {
+			idx = 0
+			l, err := NewGCSLoader(ctx, &LoaderConfig{
+				ClientOptions:     []option.ClientOption{option.WithoutAuthentication()},
+				UseObjectNameAsID: true,
+				Concurrency:       2,
+			})
+			assert.NoError(t, err)
+
+			docs, err := l.Load(ctx, document.Source{URI: "gs://bucket/docs/"})
+			assert.NoError(t, err)
+			assert.Len(t, docs, 4)
+			assert.Equal(t, "docs/a.md", docs[0].ID)
+			assert.Equal(t, "content of docs/a.md", docs[0].Content)
+			assert.Equal(t, "etag-a", docs[0].MetaData[MetaKeyETag])
+			assert.Equal(t, lastModified, docs[0].MetaData[MetaKeyLastModified])
+			assert.Equal(t, int64(1), docs[0].MetaData[MetaKeySize])
+		})
+
+		mockey.PatchConvey("include and exclude patterns", func() {
+			idx = 0
+			l, err := NewGCSLoader(ctx, &LoaderConfig{
+				ClientOptions:   []option.ClientOption{option.WithoutAuthentication()},
+				IncludePatterns: []string{"*.md"},
+				ExcludePatterns: []string{"draft*"},
+			})
+			assert.NoError(t, err)
+
+			docs, err := l.Load(ctx, document.Source{URI: "gs://bucket/docs/"})
+			assert.NoError(t, err)
+			assert.Len(t, docs, 2)
+			assert.Equal(t, "docs/a.md", docs[0].MetaData[MetaKeyObjectName])
+			assert.Equal(t, "docs/sub/c.md", docs[1].MetaData[MetaKeyObjectName])
+		})
+
+		mockey.PatchConvey("max objects", func() {
+			idx = 0
+			l, err := NewGCSLoader(ctx, &LoaderConfig{
+				ClientOptions: []option.ClientOption{option.WithoutAuthentication()},
+				MaxObjects:    3,
+			})
+			assert.NoError(t, err)
+
+			docs, err := l.Load(ctx, document.Source{URI: "gs://bucket/docs/"})
+			assert.NoError(t, err)
+			assert.Len(t, docs, 3)
+		})
+	})
+}
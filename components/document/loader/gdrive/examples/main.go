@@ -0,0 +1,72 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/cloudwego/eino/components/document"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+
+	"github.com/cloudwego/eino-ext/components/document/loader/gdrive"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// a service account key, the folders and files must be shared with the service account.
+	opts := []option.ClientOption{option.WithScopes(drive.DriveReadonlyScope)}
+	if credFile := os.Getenv("GOOGLE_CREDENTIALS_FILE"); credFile != "" {
+		opts = append(opts, option.WithCredentialsFile(credFile))
+	}
+
+	loader, err := gdrive.NewLoader(ctx, &gdrive.LoaderConfig{
+		ClientOptions: opts,
+		Recursive:     true,
+		UseFileIDAsID: true,
+		ExcludePatterns: []string{
+			"*.zip",
+		},
+		MaxFileSize: 10 << 20,
+	})
+	if err != nil {
+		log.Fatalf("gdrive.NewLoader failed, err=%v", err)
+	}
+
+	// load a folder, including its sub folders
+	docs, err := loader.Load(ctx, document.Source{URI: "gdrive://folder/" + os.Getenv("GDRIVE_FOLDER_ID")})
+	if err != nil {
+		log.Fatalf("load folder failed, err=%v", err)
+	}
+	for _, doc := range docs {
+		log.Printf("id: %s, path: %v, modified: %v", doc.ID, doc.MetaData[gdrive.MetaKeyPath], doc.MetaData[gdrive.MetaKeyModifiedTime])
+	}
+
+	// load the google docs matching a search query, modified in the last week
+	query := "mimeType = '" + gdrive.MIMETypeDocument + "' and name contains 'design'"
+	docs, err = loader.Load(ctx, document.Source{URI: "gdrive://query/" + url.PathEscape(query)},
+		gdrive.WithModifiedAfter(time.Now().Add(-7*24*time.Hour)))
+	if err != nil {
+		log.Fatalf("load query failed, err=%v", err)
+	}
+	log.Printf("query docs: %d", len(docs))
+}
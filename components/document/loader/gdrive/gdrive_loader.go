@@ -0,0 +1,662 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package gdrive can load documents from Google Drive.
+package gdrive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+)
+
+const (
+	MetaKeyFileID         = "_gdrive_file_id"
+	MetaKeyName           = "_gdrive_name"
+	MetaKeyPath           = "_gdrive_path"
+	MetaKeyMIMEType       = "_gdrive_mime_type"
+	MetaKeyExportMIMEType = "_gdrive_export_mime_type"
+	MetaKeyModifiedTime   = "_gdrive_modified_time"
+	MetaKeySize           = "_gdrive_size"
+	MetaKeyMD5Checksum    = "_gdrive_md5_checksum"
+	MetaKeyDriveID        = "_gdrive_drive_id"
+	MetaKeyParents        = "_gdrive_parents"
+	MetaKeyWebViewLink    = "_gdrive_web_view_link"
+	MetaKeySource         = "_source"
+)
+
+const (
+	MIMETypeFolder       = "application/vnd.google-apps.folder"
+	MIMETypeShortcut     = "application/vnd.google-apps.shortcut"
+	MIMETypeDocument     = "application/vnd.google-apps.document"
+	MIMETypeSpreadsheet  = "application/vnd.google-apps.spreadsheet"
+	MIMETypePresentation = "application/vnd.google-apps.presentation"
+
+	googleAppsMIMETypePrefix = "application/vnd.google-apps."
+)
+
+const (
+	fileFields = "id, name, mimeType, size, md5Checksum, modifiedTime, webViewLink, driveId, parents, shortcutDetails"
+	listFields = "nextPageToken, files(" + fileFields + ")"
+
+	defaultPageSize = 100
+)
+
+// LoaderConfig is the configuration for google drive loader.
+type LoaderConfig struct {
+	// Service is the drive service used by the loader, optional.
+	// If not set, a service is created with ClientOptions.
+	Service *drive.Service
+	// ClientOptions are used to create the drive service when Service is not set, optional.
+	// Use option.WithCredentialsFile / option.WithCredentialsJSON for a service account,
+	// or option.WithTokenSource with an oauth2 token source for OAuth user credentials.
+	// Application Default Credentials are used if no credentials option is provided.
+	ClientOptions []option.ClientOption
+
+	// DriveID restricts the query sources to the given shared drive, optional.
+	DriveID string
+	// Recursive loads the files in sub folders as well when the source is a folder.
+	Recursive bool
+	// ExportFormats maps the Google Workspace mime types to the mime types they are exported as, optional.
+	// Default to exporting Docs as text/markdown, Sheets as text/csv (first sheet only) and Slides as text/plain.
+	// Google Workspace files whose mime type is not in the map are skipped.
+	ExportFormats map[string]string
+
+	UseFileIDAsID bool // whether to use the drive file id as document ID
+
+	// Parser is the parser to parse the file content into documents, optional.
+	// Default to parser.ExtParser with parser.TextParser as fallback.
+	// The uri passed to the parser is the file path, with the extension of the export format for the exported files.
+	Parser parser.Parser
+
+	// IncludePatterns are glob patterns (see path.Match) matched against both the file path and its name.
+	// Only files matching at least one pattern are loaded, empty means all files are included.
+	IncludePatterns []string
+	// ExcludePatterns are glob patterns (see path.Match) matched the same way as IncludePatterns.
+	// Files matching any of them are skipped, exclusion takes precedence over inclusion.
+	ExcludePatterns []string
+	// MaxFiles limits the number of files loaded from a folder, drive or query, 0 means no limit.
+	MaxFiles int
+	// MaxFileSize skips the binary files larger than the given size in bytes, 0 means no limit.
+	// Exported Google Workspace files have no size and are never skipped.
+	MaxFileSize int64
+	// PageSize is the number of files requested per list call, default 100.
+	PageSize int64
+}
+
+// Loader loads files from google drive.
+type Loader struct {
+	service *drive.Service
+	conf    *LoaderConfig
+
+	exportFormats map[string]string
+}
+
+type file struct {
+	*drive.File
+	path string
+}
+
+// NewLoader creates a new google drive loader.
+func NewLoader(ctx context.Context, conf *LoaderConfig) (*Loader, error) {
+	if conf == nil {
+		return nil, errors.New("new gdrive loader, config is nil")
+	}
+
+	for _, pattern := range append(append([]string{}, conf.IncludePatterns...), conf.ExcludePatterns...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("new gdrive loader, invalid glob pattern %q: %w", pattern, err)
+		}
+	}
+
+	if conf.MaxFiles < 0 {
+		return nil, errors.New("new gdrive loader, max files must not be negative")
+	}
+
+	service := conf.Service
+	if service == nil {
+		var err error
+		service, err = drive.NewService(ctx, conf.ClientOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("new gdrive loader, create service err: %w", err)
+		}
+	}
+
+	exportFormats := conf.ExportFormats
+	if exportFormats == nil {
+		exportFormats = map[string]string{
+			MIMETypeDocument:     "text/markdown",
+			MIMETypeSpreadsheet:  "text/csv",
+			MIMETypePresentation: "text/plain",
+		}
+	}
+
+	if conf.Parser == nil {
+		p, err := parser.NewExtParser(ctx, &parser.ExtParserConfig{
+			FallbackParser: parser.TextParser{},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("new gdrive loader, create default parser err: %w", err)
+		}
+
+		conf.Parser = p
+	}
+
+	if conf.PageSize <= 0 {
+		conf.PageSize = defaultPageSize
+	}
+
+	return &Loader{
+		service:       service,
+		conf:          conf,
+		exportFormats: exportFormats,
+	}, nil
+}
+
+// Load loads the files of the given source, the source uri can be one of:
+//   - gdrive://file/<file id>, or a file url like https://docs.google.com/document/d/<file id>/edit
+//   - gdrive://folder/<folder id>, or a folder url like https://drive.google.com/drive/folders/<folder id>
+//   - gdrive://drive/<shared drive id>, all files of the shared drive
+//   - gdrive://query/<path escaped query>, files matching the drive search query, e.g. name contains 'report'
+func (l *Loader) Load(ctx context.Context, src document.Source, opts ...document.LoaderOption) (docs []*schema.Document, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, l.GetType(), components.ComponentOfLoader)
+	ctx = callbacks.OnStart(ctx, &document.LoaderCallbackInput{
+		Source: src,
+	})
+	defer func() {
+		if err != nil {
+			_ = callbacks.OnError(ctx, err)
+		}
+	}()
+
+	kind, value, err := parseURI(src.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	o := document.GetLoaderCommonOptions(&document.LoaderOptions{}, opts...)
+	implOpts := document.GetLoaderImplSpecificOptions(&options{}, opts...)
+
+	c := &collector{loader: l, since: implOpts.modifiedAfter, seen: make(map[string]bool)}
+	switch kind {
+	case kindFile:
+		err = c.collectFile(ctx, value)
+	case kindFolder:
+		err = c.collectFolder(ctx, value)
+	case kindDrive:
+		err = c.collectQuery(ctx, "mimeType != '"+MIMETypeFolder+"' and trashed = false", value)
+	case kindQuery:
+		err = c.collectQuery(ctx, "("+value+") and trashed = false", l.conf.DriveID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range c.files {
+		fileDocs, err := l.loadFile(ctx, f, o.ParserOptions)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, fileDocs...)
+	}
+
+	_ = callbacks.OnEnd(ctx, &document.LoaderCallbackOutput{
+		Source: src,
+		Docs:   docs,
+	})
+
+	return docs, nil
+}
+
+// collector collects the files to load for a single Load call.
+type collector struct {
+	loader *Loader
+	since  *time.Time
+
+	files []*file
+	// seen contains the visited file and folder ids, so that shortcuts never lead to duplicates or cycles.
+	seen map[string]bool
+}
+
+var errStopListing = errors.New("stop listing")
+
+func (c *collector) full() bool {
+	return c.loader.conf.MaxFiles > 0 && len(c.files) >= c.loader.conf.MaxFiles
+}
+
+func (c *collector) collectFile(ctx context.Context, id string) error {
+	f, err := c.loader.getFile(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if f.MimeType == MIMETypeShortcut && f.ShortcutDetails != nil {
+		name := f.Name
+		if f, err = c.loader.getFile(ctx, f.ShortcutDetails.TargetId); err != nil {
+			return err
+		}
+		f.Name = name
+	}
+
+	if f.MimeType == MIMETypeFolder {
+		return c.walkFolder(ctx, f, "")
+	}
+
+	if !c.loader.loadable(f) {
+		return fmt.Errorf("gdrive loader file= %s with mime type= %s is not supported", id, f.MimeType)
+	}
+
+	c.add(f, f.Name)
+
+	return nil
+}
+
+func (c *collector) collectFolder(ctx context.Context, id string) error {
+	f, err := c.loader.getFile(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if f.MimeType != MIMETypeFolder {
+		return fmt.Errorf("gdrive loader file= %s is not a folder, mime type= %s", id, f.MimeType)
+	}
+
+	return c.walkFolder(ctx, f, "")
+}
+
+func (c *collector) walkFolder(ctx context.Context, folder *drive.File, dir string) error {
+	if c.seen[folder.Id] {
+		return nil
+	}
+	c.seen[folder.Id] = true
+
+	q := fmt.Sprintf("'%s' in parents and trashed = false", escapeQuery(folder.Id))
+	if c.since != nil {
+		q += fmt.Sprintf(" and (mimeType = '%s' or modifiedTime > '%s')", MIMETypeFolder, c.since.UTC().Format(time.RFC3339))
+	}
+
+	var subFolders []*file
+	err := c.loader.listFiles(ctx, q, folder.DriveId, func(f *drive.File) error {
+		p := path.Join(dir, f.Name)
+
+		if f.MimeType == MIMETypeShortcut && f.ShortcutDetails != nil {
+			if f.ShortcutDetails.TargetMimeType == MIMETypeFolder {
+				if c.loader.conf.Recursive {
+					subFolders = append(subFolders, &file{
+						File: &drive.File{Id: f.ShortcutDetails.TargetId, Name: f.Name, DriveId: f.DriveId},
+						path: p,
+					})
+				}
+				return nil
+			}
+
+			target, err := c.loader.getFile(ctx, f.ShortcutDetails.TargetId)
+			if err != nil {
+				return err
+			}
+			f = target
+		}
+
+		if f.MimeType == MIMETypeFolder {
+			if c.loader.conf.Recursive {
+				subFolders = append(subFolders, &file{File: f, path: p})
+			}
+			return nil
+		}
+
+		c.add(f, p)
+		if c.full() {
+			return errStopListing
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, sub := range subFolders {
+		if c.full() {
+			break
+		}
+
+		folder := sub.File
+		if folder.MimeType == "" {
+			// shortcut target, the drive id of the target is unknown until fetched.
+			if folder, err = c.loader.getFile(ctx, folder.Id); err != nil {
+				return err
+			}
+		}
+
+		if err = c.walkFolder(ctx, folder, sub.path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *collector) collectQuery(ctx context.Context, q, driveID string) error {
+	if c.since != nil {
+		q += fmt.Sprintf(" and modifiedTime > '%s'", c.since.UTC().Format(time.RFC3339))
+	}
+
+	return c.loader.listFiles(ctx, q, driveID, func(f *drive.File) error {
+		if f.MimeType == MIMETypeFolder || f.MimeType == MIMETypeShortcut {
+			return nil
+		}
+
+		c.add(f, f.Name)
+		if c.full() {
+			return errStopListing
+		}
+
+		return nil
+	})
+}
+
+// add appends the file if it is loadable, matched and modified after the cursor.
+func (c *collector) add(f *drive.File, p string) {
+	if c.seen[f.Id] || !c.loader.loadable(f) || !c.loader.match(p) {
+		return
+	}
+
+	if c.since != nil {
+		modified, err := time.Parse(time.RFC3339, f.ModifiedTime)
+		if err == nil && !modified.After(*c.since) {
+			return
+		}
+	}
+
+	c.seen[f.Id] = true
+	c.files = append(c.files, &file{File: f, path: p})
+}
+
+func (l *Loader) getFile(ctx context.Context, id string) (*drive.File, error) {
+	f, err := l.service.Files.Get(id).
+		SupportsAllDrives(true).
+		Fields(fileFields).
+		Context(ctx).
+		Do()
+	if err != nil {
+		if isNotFound(err) {
+			return nil, fmt.Errorf("gdrive loader file= %s not found, err: %w", id, err)
+		}
+
+		return nil, fmt.Errorf("gdrive loader get file err, file= %s: %w", id, err)
+	}
+
+	return f, nil
+}
+
+// listFiles lists the files matching the query page by page, fn can return errStopListing to stop early.
+func (l *Loader) listFiles(ctx context.Context, q, driveID string, fn func(f *drive.File) error) error {
+	call := l.service.Files.List().
+		Q(q).
+		PageSize(l.conf.PageSize).
+		Fields(listFields).
+		SupportsAllDrives(true).
+		IncludeItemsFromAllDrives(true)
+	if driveID != "" {
+		call = call.Corpora("drive").DriveId(driveID)
+	}
+
+	err := call.Pages(ctx, func(list *drive.FileList) error {
+		for _, f := range list.Files {
+			if err := fn(f); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStopListing) {
+		return fmt.Errorf("gdrive loader list files err, q= %s: %w", q, err)
+	}
+
+	return nil
+}
+
+func (l *Loader) loadable(f *drive.File) bool {
+	if strings.HasPrefix(f.MimeType, googleAppsMIMETypePrefix) {
+		_, ok := l.exportFormats[f.MimeType]
+		return ok
+	}
+
+	return l.conf.MaxFileSize <= 0 || f.Size <= l.conf.MaxFileSize
+}
+
+func (l *Loader) match(p string) bool {
+	base := path.Base(p)
+	matchAny := func(patterns []string) bool {
+		for _, pattern := range patterns {
+			// patterns are validated in NewLoader, so the error can be ignored.
+			if ok, _ := path.Match(pattern, p); ok {
+				return true
+			}
+			if ok, _ := path.Match(pattern, base); ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	if matchAny(l.conf.ExcludePatterns) {
+		return false
+	}
+
+	return len(l.conf.IncludePatterns) == 0 || matchAny(l.conf.IncludePatterns)
+}
+
+// open exports the Google Workspace file, or downloads the binary file.
+func (l *Loader) open(ctx context.Context, f *file) (body io.ReadCloser, exportMIMEType string, err error) {
+	var resp *http.Response
+	if strings.HasPrefix(f.MimeType, googleAppsMIMETypePrefix) {
+		exportMIMEType = l.exportFormats[f.MimeType]
+		resp, err = l.service.Files.Export(f.Id, exportMIMEType).Context(ctx).Download()
+	} else {
+		resp, err = l.service.Files.Get(f.Id).SupportsAllDrives(true).Context(ctx).Download()
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	return resp.Body, exportMIMEType, nil
+}
+
+func (l *Loader) loadFile(ctx context.Context, f *file, parserOpts []parser.Option) ([]*schema.Document, error) {
+	body, exportMIMEType, err := l.open(ctx, f)
+	if err != nil {
+		return nil, fmt.Errorf("gdrive loader download err, file= %s: %w", f.Id, err)
+	}
+	defer body.Close()
+
+	source := f.WebViewLink
+	if source == "" {
+		source = "gdrive://file/" + f.Id
+	}
+
+	meta := map[string]any{
+		MetaKeyFileID:   f.Id,
+		MetaKeyName:     f.Name,
+		MetaKeyPath:     f.path,
+		MetaKeyMIMEType: f.MimeType,
+		MetaKeySource:   source,
+	}
+	if exportMIMEType != "" {
+		meta[MetaKeyExportMIMEType] = exportMIMEType
+	}
+	if modified, err := time.Parse(time.RFC3339, f.ModifiedTime); err == nil {
+		meta[MetaKeyModifiedTime] = modified
+	}
+	if f.Size > 0 {
+		meta[MetaKeySize] = f.Size
+	}
+	if f.Md5Checksum != "" {
+		meta[MetaKeyMD5Checksum] = f.Md5Checksum
+	}
+	if f.DriveId != "" {
+		meta[MetaKeyDriveID] = f.DriveId
+	}
+	if len(f.Parents) > 0 {
+		meta[MetaKeyParents] = f.Parents
+	}
+	if f.WebViewLink != "" {
+		meta[MetaKeyWebViewLink] = f.WebViewLink
+	}
+
+	// the parser picks the implementation by the extension of the uri, so exported files carry the export extension.
+	uri := f.path
+	if ext := extensionOf(exportMIMEType); ext != "" && path.Ext(uri) != ext {
+		uri += ext
+	}
+
+	docs, err := l.conf.Parser.Parse(ctx, body, append([]parser.Option{parser.WithURI(uri), parser.WithExtraMeta(meta)}, parserOpts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("gdrive loader parse err, file= %s: %w", f.Id, err)
+	}
+
+	if l.conf.UseFileIDAsID {
+		for _, doc := range docs {
+			doc.ID = f.Id
+		}
+	}
+
+	return docs, nil
+}
+
+func (l *Loader) GetType() string {
+	return "GoogleDriveLoader"
+}
+
+func (l *Loader) IsCallbacksEnabled() bool {
+	return true
+}
+
+var knownExtensions = map[string]string{
+	"text/markdown":             ".md",
+	"text/plain":                ".txt",
+	"text/csv":                  ".csv",
+	"text/tab-separated-values": ".tsv",
+	"text/html":                 ".html",
+	"application/pdf":           ".pdf",
+	"application/rtf":           ".rtf",
+	"application/epub+zip":      ".epub",
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document":   ".docx",
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":         ".xlsx",
+	"application/vnd.openxmlformats-officedocument.presentationml.presentation": ".pptx",
+}
+
+func extensionOf(mimeType string) string {
+	if mimeType == "" {
+		return ""
+	}
+
+	if ext, ok := knownExtensions[mimeType]; ok {
+		return ext
+	}
+
+	if exts, err := mime.ExtensionsByType(mimeType); err == nil && len(exts) > 0 {
+		return exts[0]
+	}
+
+	return ""
+}
+
+type sourceKind int
+
+const (
+	kindFile sourceKind = iota
+	kindFolder
+	kindDrive
+	kindQuery
+)
+
+var (
+	folderURLRegexp = regexp.MustCompile(`/folders/([\w-]+)`)
+	fileURLRegexp   = regexp.MustCompile(`/d/([\w-]+)`)
+)
+
+func parseURI(uri string) (kind sourceKind, value string, err error) {
+	const uriPrefix = `gdrive://`
+
+	if len(uri) == 0 {
+		return 0, "", errors.New("gdrive loader source uri is empty")
+	}
+
+	if strings.HasPrefix(uri, "https://") {
+		if m := folderURLRegexp.FindStringSubmatch(uri); m != nil {
+			return kindFolder, m[1], nil
+		}
+		if m := fileURLRegexp.FindStringSubmatch(uri); m != nil {
+			return kindFile, m[1], nil
+		}
+		return 0, "", fmt.Errorf("unrecognized google drive url: %s", uri)
+	}
+
+	if !strings.HasPrefix(uri, uriPrefix) {
+		return 0, "", fmt.Errorf("uri is not gdrive uri, uri: %s", uri)
+	}
+
+	kindAndValue := strings.TrimPrefix(uri, uriPrefix)
+	kindEnd := strings.Index(kindAndValue, "/")
+	if kindEnd == -1 || kindEnd == len(kindAndValue)-1 {
+		return 0, "", fmt.Errorf("gdrive uri incomplete: %s", uri)
+	}
+
+	value = kindAndValue[kindEnd+1:]
+	switch kindAndValue[:kindEnd] {
+	case "file":
+		return kindFile, value, nil
+	case "folder":
+		return kindFolder, value, nil
+	case "drive":
+		return kindDrive, value, nil
+	case "query":
+		q, err := url.PathUnescape(value)
+		if err != nil {
+			return 0, "", fmt.Errorf("gdrive uri invalid query: %s, err: %w", uri, err)
+		}
+		return kindQuery, q, nil
+	default:
+		return 0, "", fmt.Errorf("gdrive uri unknown kind: %s", uri)
+	}
+}
+
+func escapeQuery(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s)
+}
+
+func isNotFound(err error) bool {
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound
+}
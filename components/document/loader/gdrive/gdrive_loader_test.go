@@ -0,0 +1,347 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gdrive
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+type fakeDrive struct {
+	files    map[string]*drive.File
+	contents map[string]string
+	// exports maps "<file id>:<mime type>" to the exported content.
+	exports map[string]string
+	queries []string
+}
+
+func (fd *fakeDrive) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p := strings.TrimPrefix(r.URL.Path, "/")
+	switch {
+	case p == "files":
+		fd.list(w, r)
+	case strings.HasSuffix(p, "/export"):
+		id := strings.TrimSuffix(strings.TrimPrefix(p, "files/"), "/export")
+		content, ok := fd.exports[id+":"+r.URL.Query().Get("mimeType")]
+		if !ok {
+			http.Error(w, `{"error":{"code":400,"message":"bad export"}}`, http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	case strings.HasPrefix(p, "files/"):
+		id := strings.TrimPrefix(p, "files/")
+		f, ok := fd.files[id]
+		if !ok {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":404,"message":"File not found"}}`))
+			return
+		}
+		if r.URL.Query().Get("alt") == "media" {
+			_, _ = w.Write([]byte(fd.contents[id]))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(f)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// list supports the "'<id>' in parents" and "name contains '<name>'" queries, and pages by one file.
+func (fd *fakeDrive) list(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query().Get("q")
+	fd.queries = append(fd.queries, q)
+
+	var matched []*drive.File
+	for _, id := range sortedIDs(fd.files) {
+		f := fd.files[id]
+		switch {
+		case strings.Contains(q, "in parents"):
+			parent := q[strings.Index(q, "'")+1 : strings.Index(q, "' in parents")]
+			if len(f.Parents) == 0 || f.Parents[0] != parent {
+				continue
+			}
+		case strings.Contains(q, "name contains"):
+			name := q[strings.Index(q, "name contains '")+len("name contains '"):]
+			name = name[:strings.Index(name, "'")]
+			if !strings.Contains(f.Name, name) {
+				continue
+			}
+		case r.URL.Query().Get("driveId") != "":
+			if f.DriveId != r.URL.Query().Get("driveId") || f.MimeType == MIMETypeFolder {
+				continue
+			}
+		}
+		matched = append(matched, f)
+	}
+
+	start := 0
+	if token := r.URL.Query().Get("pageToken"); token != "" {
+		for i, f := range matched {
+			if f.Id == token {
+				start = i
+			}
+		}
+	}
+
+	list := &drive.FileList{}
+	if start < len(matched) {
+		list.Files = matched[start : start+1]
+	}
+	if start+1 < len(matched) {
+		list.NextPageToken = matched[start+1].Id
+	}
+	_ = json.NewEncoder(w).Encode(list)
+}
+
+func sortedIDs(files map[string]*drive.File) []string {
+	ids := make([]string, 0, len(files))
+	for id := range files {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func newFakeDrive() *fakeDrive {
+	return &fakeDrive{
+		files: map[string]*drive.File{
+			"root":   {Id: "root", Name: "root", MimeType: MIMETypeFolder},
+			"a-doc":  {Id: "a-doc", Name: "Design", MimeType: MIMETypeDocument, Parents: []string{"root"}, ModifiedTime: "2025-01-02T00:00:00Z", WebViewLink: "https://docs.google.com/document/d/a-doc/edit"},
+			"b-pdf":  {Id: "b-pdf", Name: "spec.pdf", MimeType: "application/pdf", Parents: []string{"root"}, Size: 2048, ModifiedTime: "2025-01-01T00:00:00Z"},
+			"c-txt":  {Id: "c-txt", Name: "notes.txt", MimeType: "text/plain", Parents: []string{"root"}, Size: 5, Md5Checksum: "md5", ModifiedTime: "2025-01-03T00:00:00Z"},
+			"d-sub":  {Id: "d-sub", Name: "sub", MimeType: MIMETypeFolder, Parents: []string{"root"}},
+			"e-form": {Id: "e-form", Name: "Survey", MimeType: "application/vnd.google-apps.form", Parents: []string{"root"}},
+			"f-link": {Id: "f-link", Name: "notes-link", MimeType: MIMETypeShortcut, Parents: []string{"root"}, ShortcutDetails: &drive.FileShortcutDetails{TargetId: "c-txt", TargetMimeType: "text/plain"}},
+			"g-csv":  {Id: "g-csv", Name: "Budget", MimeType: MIMETypeSpreadsheet, Parents: []string{"d-sub"}, DriveId: "shared", ModifiedTime: "2025-01-04T00:00:00Z"},
+		},
+		contents: map[string]string{
+			"b-pdf": "%PDF",
+			"c-txt": "notes",
+		},
+		exports: map[string]string{
+			"a-doc:text/markdown": "# Design",
+			"g-csv:text/csv":      "a,b\n1,2",
+		},
+	}
+}
+
+func newTestLoader(t *testing.T, fd *fakeDrive, conf *LoaderConfig) *Loader {
+	srv := httptest.NewServer(fd)
+	t.Cleanup(srv.Close)
+
+	ctx := context.Background()
+	service, err := drive.NewService(ctx, option.WithEndpoint(srv.URL+"/"), option.WithHTTPClient(srv.Client()))
+	require.NoError(t, err)
+
+	conf.Service = service
+	l, err := NewLoader(ctx, conf)
+	require.NoError(t, err)
+
+	return l
+}
+
+func TestNewLoader(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewLoader(ctx, nil)
+	assert.Error(t, err)
+
+	_, err = NewLoader(ctx, &LoaderConfig{Service: &drive.Service{}, IncludePatterns: []string{"["}})
+	assert.ErrorContains(t, err, "invalid glob pattern")
+
+	_, err = NewLoader(ctx, &LoaderConfig{Service: &drive.Service{}, MaxFiles: -1})
+	assert.Error(t, err)
+
+	l, err := NewLoader(ctx, &LoaderConfig{Service: &drive.Service{}})
+	require.NoError(t, err)
+	assert.Equal(t, "text/markdown", l.exportFormats[MIMETypeDocument])
+	assert.Equal(t, int64(defaultPageSize), l.conf.PageSize)
+	assert.NotNil(t, l.conf.Parser)
+}
+
+func TestParseURI(t *testing.T) {
+	tests := []struct {
+		uri   string
+		kind  sourceKind
+		value string
+		err   bool
+	}{
+		{uri: "gdrive://file/abc", kind: kindFile, value: "abc"},
+		{uri: "gdrive://folder/abc", kind: kindFolder, value: "abc"},
+		{uri: "gdrive://drive/0AB", kind: kindDrive, value: "0AB"},
+		{uri: "gdrive://query/" + url.PathEscape("name contains 'x'"), kind: kindQuery, value: "name contains 'x'"},
+		{uri: "https://drive.google.com/drive/u/0/folders/abc-1_2", kind: kindFolder, value: "abc-1_2"},
+		{uri: "https://docs.google.com/document/d/abc/edit", kind: kindFile, value: "abc"},
+		{uri: "https://drive.google.com/file/d/abc/view?usp=sharing", kind: kindFile, value: "abc"},
+		{uri: "", err: true},
+		{uri: "s3://bucket/key", err: true},
+		{uri: "gdrive://file/", err: true},
+		{uri: "gdrive://unknown/abc", err: true},
+		{uri: "https://example.com/abc", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			kind, value, err := parseURI(tt.uri)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.kind, kind)
+			assert.Equal(t, tt.value, value)
+		})
+	}
+}
+
+func TestLoader_Load(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("single exported file", func(t *testing.T) {
+		l := newTestLoader(t, newFakeDrive(), &LoaderConfig{UseFileIDAsID: true})
+
+		docs, err := l.Load(ctx, document.Source{URI: "https://docs.google.com/document/d/a-doc/edit"})
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Equal(t, "a-doc", docs[0].ID)
+		assert.Equal(t, "# Design", docs[0].Content)
+		assert.Equal(t, MIMETypeDocument, docs[0].MetaData[MetaKeyMIMEType])
+		assert.Equal(t, "text/markdown", docs[0].MetaData[MetaKeyExportMIMEType])
+		assert.Equal(t, "https://docs.google.com/document/d/a-doc/edit", docs[0].MetaData[MetaKeySource])
+		assert.Equal(t, time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), docs[0].MetaData[MetaKeyModifiedTime])
+	})
+
+	t.Run("single binary file", func(t *testing.T) {
+		l := newTestLoader(t, newFakeDrive(), &LoaderConfig{})
+
+		docs, err := l.Load(ctx, document.Source{URI: "gdrive://file/c-txt"})
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Equal(t, "notes", docs[0].Content)
+		assert.Equal(t, "md5", docs[0].MetaData[MetaKeyMD5Checksum])
+		assert.Equal(t, int64(5), docs[0].MetaData[MetaKeySize])
+		assert.Equal(t, "gdrive://file/c-txt", docs[0].MetaData[MetaKeySource])
+		assert.NotContains(t, docs[0].MetaData, MetaKeyExportMIMEType)
+	})
+
+	t.Run("unsupported and not found file", func(t *testing.T) {
+		l := newTestLoader(t, newFakeDrive(), &LoaderConfig{})
+
+		_, err := l.Load(ctx, document.Source{URI: "gdrive://file/e-form"})
+		assert.ErrorContains(t, err, "not supported")
+
+		_, err = l.Load(ctx, document.Source{URI: "gdrive://file/missing"})
+		assert.ErrorContains(t, err, "not found")
+
+		_, err = l.Load(ctx, document.Source{URI: "gdrive://folder/c-txt"})
+		assert.ErrorContains(t, err, "not a folder")
+	})
+
+	t.Run("folder", func(t *testing.T) {
+		l := newTestLoader(t, newFakeDrive(), &LoaderConfig{UseFileIDAsID: true})
+
+		docs, err := l.Load(ctx, document.Source{URI: "gdrive://folder/root"})
+		require.NoError(t, err)
+
+		// the form is not exportable, the sub folder is not walked, and the shortcut duplicates c-txt.
+		ids := make([]string, 0, len(docs))
+		for _, doc := range docs {
+			ids = append(ids, doc.ID)
+		}
+		assert.Equal(t, []string{"a-doc", "b-pdf", "c-txt"}, ids)
+	})
+
+	t.Run("recursive folder with patterns", func(t *testing.T) {
+		l := newTestLoader(t, newFakeDrive(), &LoaderConfig{
+			Recursive:       true,
+			ExcludePatterns: []string{"*.pdf"},
+			MaxFileSize:     1024,
+		})
+
+		docs, err := l.Load(ctx, document.Source{URI: "gdrive://folder/root"})
+		require.NoError(t, err)
+		require.Len(t, docs, 3)
+		assert.Equal(t, "sub/Budget", docs[2].MetaData[MetaKeyPath])
+		assert.Equal(t, "a,b\n1,2", docs[2].Content)
+		assert.Equal(t, "shared", docs[2].MetaData[MetaKeyDriveID])
+
+		l = newTestLoader(t, newFakeDrive(), &LoaderConfig{
+			Recursive:       true,
+			IncludePatterns: []string{"sub/*"},
+		})
+		docs, err = l.Load(ctx, document.Source{URI: "gdrive://folder/root"})
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Equal(t, "g-csv", docs[0].MetaData[MetaKeyFileID])
+	})
+
+	t.Run("max files", func(t *testing.T) {
+		l := newTestLoader(t, newFakeDrive(), &LoaderConfig{MaxFiles: 2})
+
+		docs, err := l.Load(ctx, document.Source{URI: "gdrive://folder/root"})
+		require.NoError(t, err)
+		assert.Len(t, docs, 2)
+	})
+
+	t.Run("modified after", func(t *testing.T) {
+		fd := newFakeDrive()
+		l := newTestLoader(t, fd, &LoaderConfig{Recursive: true})
+
+		docs, err := l.Load(ctx, document.Source{URI: "gdrive://folder/root"},
+			WithModifiedAfter(time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)))
+		require.NoError(t, err)
+		require.Len(t, docs, 2)
+		assert.Equal(t, "c-txt", docs[0].MetaData[MetaKeyFileID])
+		assert.Equal(t, "g-csv", docs[1].MetaData[MetaKeyFileID])
+		assert.Contains(t, fd.queries[0], "modifiedTime > '2025-01-02T00:00:00Z'")
+	})
+
+	t.Run("query and shared drive", func(t *testing.T) {
+		l := newTestLoader(t, newFakeDrive(), &LoaderConfig{})
+
+		docs, err := l.Load(ctx, document.Source{URI: "gdrive://query/" + url.PathEscape("name contains 'notes'")})
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Equal(t, "notes", docs[0].Content)
+
+		docs, err = l.Load(ctx, document.Source{URI: "gdrive://drive/shared"})
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Equal(t, "g-csv", docs[0].MetaData[MetaKeyFileID])
+	})
+}
+
+func TestExtensionOf(t *testing.T) {
+	assert.Equal(t, ".md", extensionOf("text/markdown"))
+	assert.Equal(t, ".docx", extensionOf("application/vnd.openxmlformats-officedocument.wordprocessingml.document"))
+	assert.Equal(t, "", extensionOf(""))
+	assert.Equal(t, "", extensionOf("application/x-unknown-type"))
+}
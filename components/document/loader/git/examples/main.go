@@ -0,0 +1,65 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/components/document"
+
+	"github.com/cloudwego/eino-ext/components/document/loader/git"
+)
+
+func main() {
+	ctx := context.Background()
+
+	loader, err := git.NewLoader(ctx, &git.LoaderConfig{
+		CloneDir:        os.TempDir(),
+		UsePathAsID:     true,
+		IncludePatterns: []string{"*.md"},
+		ExcludePatterns: []string{"CHANGELOG*"},
+		MaxFileSize:     1 << 20,
+	})
+	if err != nil {
+		log.Fatalf("NewLoader failed, err=%v", err)
+	}
+
+	src := document.Source{URI: "https://github.com/cloudwego/eino.git"}
+
+	// the first load clones the repository and loads all matched files.
+	docs, err := loader.Load(ctx, src)
+	if err != nil {
+		log.Fatalf("load failed, err=%v", err)
+	}
+	if len(docs) == 0 {
+		return
+	}
+
+	lastCommit := docs[0].MetaData[git.MetaKeyCommit].(string)
+	log.Printf("loaded %d docs at commit %s", len(docs), lastCommit)
+
+	// the following loads pull the repository, and only load files changed since the last loaded commit.
+	docs, err = loader.Load(ctx, src, git.WithSinceCommit(lastCommit))
+	if err != nil {
+		log.Fatalf("incremental load failed, err=%v", err)
+	}
+	for _, doc := range docs {
+		log.Printf("changed: %s", doc.ID)
+	}
+}
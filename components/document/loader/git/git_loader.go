@@ -0,0 +1,420 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package git can load documents from the files of a git repository.
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/go-git/go-git/v5/utils/merkletrie"
+)
+
+const (
+	MetaKeyPath       = "_git_path"
+	MetaKeyCommit     = "_git_commit"
+	MetaKeyCommitTime = "_git_commit_time"
+	MetaKeyBlob       = "_git_blob"
+	MetaKeyRepository = "_git_repository"
+	MetaKeyFileName   = "_file_name"
+	MetaKeyExtension  = "_extension"
+	MetaKeySource     = "_source"
+)
+
+// LoaderConfig is the configuration for git loader.
+type LoaderConfig struct {
+	// CloneDir is the directory remote repositories are cloned into, optional.
+	// Each repository is cloned into a sub directory derived from its url on the first load, and pulled on the following loads.
+	// If not set, remote repositories are cloned into memory on every load.
+	CloneDir string
+	// ReferenceName is the branch or tag to load, e.g. refs/heads/main, optional.
+	// Default to the remote HEAD for remote repositories, and the current HEAD for local checkouts.
+	ReferenceName plumbing.ReferenceName
+	// Depth limits the fetched history of remote repositories, 0 means the full history.
+	// Note that WithSinceCommit needs the given revision to be present in the fetched history.
+	Depth int
+	// Auth is the authentication method for remote repositories, optional.
+	Auth transport.AuthMethod
+
+	UsePathAsID bool // whether to use the file path in repository as document ID
+
+	// Parser is the parser to parse the files into documents, optional.
+	// Default to parser.ExtParser with parser.TextParser as fallback.
+	Parser parser.Parser
+
+	// IncludePatterns are glob patterns (see path.Match) matched against both the file path and its base name.
+	// Only files matching at least one pattern are loaded, empty means all files are included.
+	IncludePatterns []string
+	// ExcludePatterns are glob patterns (see path.Match) matched the same way as IncludePatterns.
+	// Files matching any of them are skipped, exclusion takes precedence over inclusion.
+	ExcludePatterns []string
+	// MaxFileSize skips the files larger than the given size in bytes, 0 means no limit.
+	MaxFileSize int64
+}
+
+// Loader loads the files of a git repository as documents.
+// The document.Source's URI is either a remote repository url, e.g. https://github.com/cloudwego/eino.git,
+// or the path of a local checkout. Files are read from the tree of the loaded commit,
+// so uncommitted changes in a local checkout are not loaded.
+type Loader struct {
+	conf *LoaderConfig
+
+	// mu serializes clone and pull of the repositories in CloneDir.
+	mu sync.Mutex
+}
+
+var _ document.Loader = (*Loader)(nil)
+
+// NewLoader creates a new git loader.
+func NewLoader(ctx context.Context, conf *LoaderConfig) (*Loader, error) {
+	if conf == nil {
+		conf = &LoaderConfig{}
+	}
+
+	for _, pattern := range append(append([]string{}, conf.IncludePatterns...), conf.ExcludePatterns...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("new git loader, invalid glob pattern %q: %w", pattern, err)
+		}
+	}
+
+	if conf.Parser == nil {
+		p, err := parser.NewExtParser(ctx, &parser.ExtParserConfig{
+			FallbackParser: parser.TextParser{},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("new git loader, create default parser err: %w", err)
+		}
+
+		conf.Parser = p
+	}
+
+	return &Loader{conf: conf}, nil
+}
+
+func (l *Loader) Load(ctx context.Context, src document.Source, opts ...document.LoaderOption) (docs []*schema.Document, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, l.GetType(), components.ComponentOfLoader)
+	ctx = callbacks.OnStart(ctx, &document.LoaderCallbackInput{
+		Source: src,
+	})
+	defer func() {
+		if err != nil {
+			_ = callbacks.OnError(ctx, err)
+		}
+	}()
+
+	if len(src.URI) == 0 {
+		return nil, errors.New("git loader source uri is empty")
+	}
+
+	o := document.GetLoaderCommonOptions(&document.LoaderOptions{}, opts...)
+	implOpts := document.GetLoaderImplSpecificOptions(&options{}, opts...)
+
+	repo, err := l.openRepository(ctx, src.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := l.resolveCommit(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("git loader get tree of commit %s err: %w", commit.Hash, err)
+	}
+
+	var changed map[string]bool
+	if len(implOpts.sinceRevision) > 0 {
+		changed, err = changedFiles(ctx, repo, implOpts.sinceRevision, tree)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tree.Files().ForEach(func(f *object.File) error {
+		if f.Mode == filemode.Symlink || f.Mode == filemode.Submodule {
+			return nil
+		}
+		if changed != nil && !changed[f.Name] {
+			return nil
+		}
+		if !l.match(f.Name) {
+			return nil
+		}
+		if l.conf.MaxFileSize > 0 && f.Size > l.conf.MaxFileSize {
+			return nil
+		}
+
+		fileDocs, e := l.parseFile(ctx, src.URI, commit, f, o.ParserOptions)
+		if e != nil {
+			return e
+		}
+
+		docs = append(docs, fileDocs...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_ = callbacks.OnEnd(ctx, &document.LoaderCallbackOutput{
+		Source: src,
+		Docs:   docs,
+	})
+
+	return docs, nil
+}
+
+func (l *Loader) parseFile(ctx context.Context, uri string, commit *object.Commit, f *object.File, parserOpts []parser.Option) ([]*schema.Document, error) {
+	reader, err := f.Reader()
+	if err != nil {
+		return nil, fmt.Errorf("git loader read file [%s] err: %w", f.Name, err)
+	}
+	defer reader.Close()
+
+	meta := map[string]any{
+		MetaKeyPath:       f.Name,
+		MetaKeyCommit:     commit.Hash.String(),
+		MetaKeyCommitTime: commit.Committer.When,
+		MetaKeyBlob:       f.Hash.String(),
+		MetaKeyRepository: uri,
+		MetaKeyFileName:   path.Base(f.Name),
+		MetaKeyExtension:  path.Ext(f.Name),
+		MetaKeySource:     strings.TrimSuffix(uri, "/") + "/" + f.Name,
+	}
+
+	docs, err := l.conf.Parser.Parse(ctx, reader, append([]parser.Option{parser.WithURI(f.Name), parser.WithExtraMeta(meta)}, parserOpts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("git loader parse file [%s] err: %w", f.Name, err)
+	}
+
+	if l.conf.UsePathAsID {
+		if len(docs) == 1 {
+			docs[0].ID = f.Name
+		} else {
+			for idx, doc := range docs {
+				doc.ID = fmt.Sprintf("%s_%d", f.Name, idx)
+			}
+		}
+	}
+
+	return docs, nil
+}
+
+func (l *Loader) match(name string) bool {
+	base := path.Base(name)
+	matchAny := func(patterns []string) bool {
+		for _, pattern := range patterns {
+			// patterns are validated in NewLoader, so the error can be ignored.
+			if ok, _ := path.Match(pattern, name); ok {
+				return true
+			}
+			if ok, _ := path.Match(pattern, base); ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	if matchAny(l.conf.ExcludePatterns) {
+		return false
+	}
+
+	return len(l.conf.IncludePatterns) == 0 || matchAny(l.conf.IncludePatterns)
+}
+
+func (l *Loader) openRepository(ctx context.Context, uri string) (*gogit.Repository, error) {
+	if !isRemote(uri) {
+		repo, err := gogit.PlainOpenWithOptions(uri, &gogit.PlainOpenOptions{DetectDotGit: true})
+		if err != nil {
+			return nil, fmt.Errorf("git loader open local repository [%s] err: %w", uri, err)
+		}
+		return repo, nil
+	}
+
+	cloneOpts := &gogit.CloneOptions{
+		URL:           uri,
+		Auth:          l.conf.Auth,
+		ReferenceName: l.conf.ReferenceName,
+		SingleBranch:  len(l.conf.ReferenceName) > 0,
+		Depth:         l.conf.Depth,
+	}
+
+	if len(l.conf.CloneDir) == 0 {
+		repo, err := gogit.CloneContext(ctx, memory.NewStorage(), nil, cloneOpts)
+		if err != nil {
+			return nil, fmt.Errorf("git loader clone [%s] err: %w", uri, err)
+		}
+		return repo, nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	dir := filepath.Join(l.conf.CloneDir, cloneDirName(uri))
+	if _, err := os.Stat(filepath.Join(dir, gogit.GitDirName)); err != nil {
+		repo, err := gogit.PlainCloneContext(ctx, dir, false, cloneOpts)
+		if err != nil {
+			return nil, fmt.Errorf("git loader clone [%s] into [%s] err: %w", uri, dir, err)
+		}
+		return repo, nil
+	}
+
+	repo, err := gogit.PlainOpen(dir)
+	if err != nil {
+		return nil, fmt.Errorf("git loader open cloned repository [%s] err: %w", dir, err)
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("git loader get worktree of [%s] err: %w", dir, err)
+	}
+
+	err = wt.PullContext(ctx, &gogit.PullOptions{
+		RemoteName:    gogit.DefaultRemoteName,
+		ReferenceName: l.conf.ReferenceName,
+		SingleBranch:  len(l.conf.ReferenceName) > 0,
+		Depth:         l.conf.Depth,
+		Auth:          l.conf.Auth,
+	})
+	if err != nil && !errors.Is(err, gogit.NoErrAlreadyUpToDate) {
+		return nil, fmt.Errorf("git loader pull [%s] into [%s] err: %w", uri, dir, err)
+	}
+
+	return repo, nil
+}
+
+func (l *Loader) resolveCommit(repo *gogit.Repository) (*object.Commit, error) {
+	var (
+		ref *plumbing.Reference
+		err error
+	)
+
+	if len(l.conf.ReferenceName) > 0 {
+		ref, err = repo.Reference(l.conf.ReferenceName, true)
+	} else {
+		ref, err = repo.Head()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("git loader resolve reference err: %w", err)
+	}
+
+	hash := ref.Hash()
+	// annotated tags point to tag objects rather than commits.
+	if tag, e := repo.TagObject(hash); e == nil {
+		commit, e := tag.Commit()
+		if e != nil {
+			return nil, fmt.Errorf("git loader get commit of tag %s err: %w", ref.Name(), e)
+		}
+		return commit, nil
+	}
+
+	commit, err := repo.CommitObject(hash)
+	if err != nil {
+		return nil, fmt.Errorf("git loader get commit %s err: %w", hash, err)
+	}
+
+	return commit, nil
+}
+
+// changedFiles returns the paths of files added or modified between the since revision and the given tree.
+func changedFiles(ctx context.Context, repo *gogit.Repository, since string, tree *object.Tree) (map[string]bool, error) {
+	hash, err := repo.ResolveRevision(plumbing.Revision(since))
+	if err != nil {
+		return nil, fmt.Errorf("git loader resolve since revision [%s] err: %w", since, err)
+	}
+
+	sinceCommit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return nil, fmt.Errorf("git loader get since commit %s err: %w", hash, err)
+	}
+
+	sinceTree, err := sinceCommit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("git loader get tree of since commit %s err: %w", hash, err)
+	}
+
+	changes, err := sinceTree.DiffContext(ctx, tree)
+	if err != nil {
+		return nil, fmt.Errorf("git loader diff since commit %s err: %w", hash, err)
+	}
+
+	changed := make(map[string]bool, len(changes))
+	for _, change := range changes {
+		action, err := change.Action()
+		if err != nil {
+			return nil, fmt.Errorf("git loader get change action err: %w", err)
+		}
+		if action == merkletrie.Delete {
+			continue
+		}
+		changed[change.To.Name] = true
+	}
+
+	return changed, nil
+}
+
+// scpLikeURI matches the scp-like syntax of ssh urls, e.g. git@github.com:cloudwego/eino.git
+var scpLikeURI = regexp.MustCompile(`^[\w.-]+@[\w.-]+:`)
+
+func isRemote(uri string) bool {
+	return strings.Contains(uri, "://") || scpLikeURI.MatchString(uri)
+}
+
+var unsafeDirChars = regexp.MustCompile(`[^\w.-]+`)
+
+// cloneDirName derives a readable directory name from the repository url,
+// e.g. https://github.com/cloudwego/eino.git -> github.com_cloudwego_eino
+func cloneDirName(uri string) string {
+	name := uri
+	if idx := strings.Index(name, "://"); idx >= 0 {
+		name = name[idx+len("://"):]
+	}
+	if idx := strings.LastIndex(name, "@"); idx >= 0 {
+		name = name[idx+1:]
+	}
+	name = strings.TrimSuffix(strings.TrimSuffix(name, "/"), ".git")
+
+	return strings.Trim(unsafeDirChars.ReplaceAllString(name, "_"), "_.")
+}
+
+func (l *Loader) GetType() string {
+	return "GitLoader"
+}
+
+func (l *Loader) IsCallbacksEnabled() bool {
+	return true
+}
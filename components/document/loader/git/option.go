@@ -0,0 +1,33 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package git
+
+import "github.com/cloudwego/eino/components/document"
+
+type options struct {
+	sinceRevision string
+}
+
+// WithSinceCommit is a loader option that restricts loading to the files added or modified
+// between the given revision and the loaded commit, which is useful for incremental indexing.
+// The revision can be a commit hash (full or short), a tag or a branch name.
+// The commit hash of the previous load can be read from the MetaKeyCommit metadata.
+func WithSinceCommit(revision string) document.LoaderOption {
+	return document.WrapLoaderImplSpecificOptFn(func(o *options) {
+		o.sinceRevision = revision
+	})
+}
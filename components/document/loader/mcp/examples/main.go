@@ -0,0 +1,86 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	mcpLoader "github.com/cloudwego/eino-ext/components/document/loader/mcp"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// continuous listening receives the notifications of the server while no request is in flight
+	cli, err := client.NewStreamableHttpClient(os.Getenv("MCP_SERVER_URL"), transport.WithContinuousListening())
+	if err != nil {
+		log.Fatalf("NewStreamableHttpClient failed, err=%v", err)
+	}
+	defer cli.Close()
+	if err = cli.Start(ctx); err != nil {
+		log.Fatalf("Start failed, err=%v", err)
+	}
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initRequest.Params.ClientInfo = mcp.Implementation{Name: "example-client", Version: "1.0.0"}
+	if _, err = cli.Initialize(ctx, initRequest); err != nil {
+		log.Fatalf("Initialize failed, err=%v", err)
+	}
+
+	loader, err := mcpLoader.NewLoader(ctx, &mcpLoader.LoaderConfig{
+		Cli: cli,
+		Filter: func(resource mcp.Resource) bool {
+			return resource.MIMEType == "text/markdown"
+		},
+	})
+	if err != nil {
+		log.Fatalf("NewLoader failed, err=%v", err)
+	}
+
+	docs, err := loader.LoadAll(ctx)
+	if err != nil {
+		log.Fatalf("LoadAll failed, err=%v", err)
+	}
+	for _, doc := range docs {
+		log.Printf("name: %v, uri: %s, length: %d", doc.MetaData[mcpLoader.MetaKeyName], doc.ID, len(doc.Content))
+	}
+
+	docs, err = loader.Load(ctx, document.Source{URI: "file:///docs/readme.md"})
+	if err != nil {
+		log.Fatalf("Load failed, err=%v", err)
+	}
+	log.Printf("readme: %s", docs[0].Content)
+
+	err = loader.Watch(ctx, []string{"file:///docs/readme.md"}, func(ctx context.Context, uri string, docs []*schema.Document, err error) {
+		if err != nil {
+			log.Printf("reload %s failed, err=%v", uri, err)
+			return
+		}
+		log.Printf("%s updated: %s", uri, docs[0].Content)
+	})
+	if err != nil {
+		log.Fatalf("Watch failed, err=%v", err)
+	}
+	select {}
+}
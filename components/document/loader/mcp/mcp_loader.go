@@ -0,0 +1,251 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package mcp can load the resources of MCP servers as documents.
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	MetaKeyURI         = "_mcp_uri"
+	MetaKeyName        = "_mcp_name"
+	MetaKeyDescription = "_mcp_description"
+	MetaKeyMIMEType    = "_mcp_mime_type"
+	MetaKeySource      = "_source"
+)
+
+// LoaderConfig is the configuration for mcp loader.
+type LoaderConfig struct {
+	// Cli is the MCP (Model Control Protocol) client, ref: https://github.com/mark3labs/mcp-go
+	// Notice: should Initialize with server before use
+	// Required
+	Cli client.MCPClient
+	// Parser parses the binary resources, e.g. PDF files, the text resources are loaded as they are.
+	// Optional. Default the binary resources fail to load.
+	Parser parser.Parser
+	// Filter selects the resources loaded by LoadAll, e.g. by their mime type.
+	// Optional. Default all resources.
+	Filter func(resource mcp.Resource) bool
+}
+
+// Loader loads the resources of a MCP server, the document.Source's URI being the uri of the resource.
+// Each content of the resource is loaded as a document, most resources having a single content.
+type Loader struct {
+	conf *LoaderConfig
+}
+
+var _ document.Loader = (*Loader)(nil)
+
+// NewLoader creates a new mcp loader.
+func NewLoader(_ context.Context, conf *LoaderConfig) (*Loader, error) {
+	if conf == nil {
+		return nil, errors.New("new mcp loader, config is nil")
+	}
+	if conf.Cli == nil {
+		return nil, errors.New("new mcp loader, cli is required")
+	}
+	return &Loader{conf: conf}, nil
+}
+
+func (l *Loader) Load(ctx context.Context, src document.Source, opts ...document.LoaderOption) (docs []*schema.Document, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, l.GetType(), components.ComponentOfLoader)
+	ctx = callbacks.OnStart(ctx, &document.LoaderCallbackInput{
+		Source: src,
+	})
+	defer func() {
+		if err != nil {
+			_ = callbacks.OnError(ctx, err)
+		}
+	}()
+
+	docs, err = l.load(ctx, src.URI, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = callbacks.OnEnd(ctx, &document.LoaderCallbackOutput{
+		Source: src,
+		Docs:   docs,
+	})
+
+	return docs, nil
+}
+
+// LoadAll loads all the resources of the server selected by Filter, with their name and description as metadata.
+func (l *Loader) LoadAll(ctx context.Context, opts ...document.LoaderOption) ([]*schema.Document, error) {
+	result, err := l.conf.Cli.ListResources(ctx, mcp.ListResourcesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("list mcp resources fail: %w", err)
+	}
+
+	var docs []*schema.Document
+	for _, resource := range result.Resources {
+		if l.conf.Filter != nil && !l.conf.Filter(resource) {
+			continue
+		}
+		rDocs, lErr := l.Load(ctx, document.Source{URI: resource.URI}, opts...)
+		if lErr != nil {
+			return nil, lErr
+		}
+		for _, doc := range rDocs {
+			doc.MetaData[MetaKeyName] = resource.Name
+			if resource.Description != "" {
+				doc.MetaData[MetaKeyDescription] = resource.Description
+			}
+		}
+		docs = append(docs, rDocs...)
+	}
+	return docs, nil
+}
+
+func (l *Loader) load(ctx context.Context, uri string, opts ...document.LoaderOption) ([]*schema.Document, error) {
+	result, err := l.conf.Cli.ReadResource(ctx, mcp.ReadResourceRequest{
+		Params: mcp.ReadResourceParams{URI: uri},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read mcp resource [%s] fail: %w", uri, err)
+	}
+
+	o := document.GetLoaderCommonOptions(&document.LoaderOptions{}, opts...)
+
+	docs := make([]*schema.Document, 0, len(result.Contents))
+	for _, content := range result.Contents {
+		switch c := content.(type) {
+		case mcp.TextResourceContents:
+			docs = append(docs, &schema.Document{
+				ID:       c.URI,
+				Content:  c.Text,
+				MetaData: metaData(uri, c.URI, c.MIMEType),
+			})
+		case mcp.BlobResourceContents:
+			if l.conf.Parser == nil {
+				return nil, fmt.Errorf("mcp resource [%s] is binary, mime type: %s, but parser is not configured", c.URI, c.MIMEType)
+			}
+			data, dErr := base64.StdEncoding.DecodeString(c.Blob)
+			if dErr != nil {
+				return nil, fmt.Errorf("decode mcp resource [%s] fail: %w", c.URI, dErr)
+			}
+			meta := metaData(uri, c.URI, c.MIMEType)
+			pDocs, pErr := l.conf.Parser.Parse(ctx, bytes.NewReader(data),
+				append([]parser.Option{parser.WithURI(c.URI), parser.WithExtraMeta(meta)}, o.ParserOptions...)...)
+			if pErr != nil {
+				return nil, fmt.Errorf("parse mcp resource [%s] fail: %w", c.URI, pErr)
+			}
+			docs = append(docs, pDocs...)
+		default:
+			return nil, fmt.Errorf("unknown mcp resource content type: %T", content)
+		}
+	}
+	return docs, nil
+}
+
+func metaData(source, uri, mimeType string) map[string]any {
+	meta := map[string]any{
+		MetaKeyURI:    uri,
+		MetaKeySource: source,
+	}
+	if mimeType != "" {
+		meta[MetaKeyMIMEType] = mimeType
+	}
+	return meta
+}
+
+// Watch subscribes to the updates of the resources, and loads a resource again each time the server notifies
+// it is updated, calling onUpdate with its documents, or with the error of loading it.
+// The server must support the subscriptions, and the client must receive its notifications, e.g. with the
+// continuous listening of the Streamable HTTP transport. The resources are unsubscribed when ctx is done.
+func (l *Loader) Watch(ctx context.Context, uris []string, onUpdate func(ctx context.Context, uri string, docs []*schema.Document, err error), opts ...document.LoaderOption) error {
+	if len(uris) == 0 {
+		return errors.New("watch mcp resources, uris are required")
+	}
+	if onUpdate == nil {
+		return errors.New("watch mcp resources, onUpdate is required")
+	}
+
+	var (
+		mu      sync.Mutex
+		watched = make(map[string]bool, len(uris))
+	)
+	for _, uri := range uris {
+		watched[uri] = true
+	}
+
+	// the handlers of the client can not be removed, so the handler is a no-op once ctx is done
+	l.conf.Cli.OnNotification(func(notification mcp.JSONRPCNotification) {
+		if notification.Method != mcp.MethodNotificationResourceUpdated || ctx.Err() != nil {
+			return
+		}
+		uri, _ := notification.Params.AdditionalFields["uri"].(string)
+		mu.Lock()
+		ok := watched[uri]
+		mu.Unlock()
+		if !ok {
+			return
+		}
+		// the notifications are handled one by one by the client, loading in the handler would block it
+		go func() {
+			docs, err := l.Load(ctx, document.Source{URI: uri}, opts...)
+			if ctx.Err() != nil {
+				return
+			}
+			onUpdate(ctx, uri, docs, err)
+		}()
+	})
+
+	for i, uri := range uris {
+		if err := l.conf.Cli.Subscribe(ctx, mcp.SubscribeRequest{Params: mcp.SubscribeParams{URI: uri}}); err != nil {
+			mu.Lock()
+			watched = nil
+			mu.Unlock()
+			l.unsubscribe(context.WithoutCancel(ctx), uris[:i])
+			return fmt.Errorf("subscribe mcp resource [%s] fail: %w", uri, err)
+		}
+	}
+
+	go func() {
+		<-ctx.Done()
+		l.unsubscribe(context.WithoutCancel(ctx), uris)
+	}()
+	return nil
+}
+
+func (l *Loader) unsubscribe(ctx context.Context, uris []string) {
+	for _, uri := range uris {
+		_ = l.conf.Cli.Unsubscribe(ctx, mcp.UnsubscribeRequest{Params: mcp.UnsubscribeParams{URI: uri}})
+	}
+}
+
+func (l *Loader) GetType() string {
+	return "MCPLoader"
+}
+
+func (l *Loader) IsCallbacksEnabled() bool {
+	return true
+}
@@ -0,0 +1,237 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mcp
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockMCPClient struct {
+	client.MCPClient
+
+	mu           sync.Mutex
+	resources    map[string][]mcp.ResourceContents
+	handlers     []func(notification mcp.JSONRPCNotification)
+	subscribed   map[string]bool
+	subscribeErr error
+}
+
+func newMockMCPClient() *mockMCPClient {
+	return &mockMCPClient{
+		resources: map[string][]mcp.ResourceContents{
+			"file:///readme.md": {mcp.TextResourceContents{URI: "file:///readme.md", MIMEType: "text/markdown", Text: "# readme"}},
+			"file:///logo.png":  {mcp.BlobResourceContents{URI: "file:///logo.png", MIMEType: "image/png", Blob: base64.StdEncoding.EncodeToString([]byte("png"))}},
+		},
+		subscribed: map[string]bool{},
+	}
+}
+
+func (m *mockMCPClient) ListResources(ctx context.Context, request mcp.ListResourcesRequest) (*mcp.ListResourcesResult, error) {
+	return &mcp.ListResourcesResult{Resources: []mcp.Resource{
+		{URI: "file:///readme.md", Name: "readme", Description: "the readme", MIMEType: "text/markdown"},
+		{URI: "file:///logo.png", Name: "logo", MIMEType: "image/png"},
+	}}, nil
+}
+
+func (m *mockMCPClient) ReadResource(ctx context.Context, request mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	contents, ok := m.resources[request.Params.URI]
+	if !ok {
+		return nil, errors.New("resource not found")
+	}
+	return &mcp.ReadResourceResult{Contents: contents}, nil
+}
+
+func (m *mockMCPClient) Subscribe(ctx context.Context, request mcp.SubscribeRequest) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.subscribeErr != nil {
+		return m.subscribeErr
+	}
+	m.subscribed[request.Params.URI] = true
+	return nil
+}
+
+func (m *mockMCPClient) Unsubscribe(ctx context.Context, request mcp.UnsubscribeRequest) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.subscribed, request.Params.URI)
+	return nil
+}
+
+func (m *mockMCPClient) OnNotification(handler func(notification mcp.JSONRPCNotification)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handlers = append(m.handlers, handler)
+}
+
+func (m *mockMCPClient) update(uri, text string) {
+	m.mu.Lock()
+	m.resources[uri] = []mcp.ResourceContents{mcp.TextResourceContents{URI: uri, MIMEType: "text/markdown", Text: text}}
+	handlers := m.handlers
+	m.mu.Unlock()
+
+	n := mcp.JSONRPCNotification{JSONRPC: mcp.JSONRPC_VERSION}
+	n.Method = mcp.MethodNotificationResourceUpdated
+	n.Params.AdditionalFields = map[string]any{"uri": uri}
+	for _, h := range handlers {
+		h(n)
+	}
+}
+
+type bytesParser struct{}
+
+func (bytesParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	o := parser.GetCommonOptions(&parser.Options{}, opts...)
+	return []*schema.Document{{ID: o.URI, Content: string(data), MetaData: o.ExtraMeta}}, nil
+}
+
+func TestLoad(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewLoader(ctx, nil)
+	assert.EqualError(t, err, "new mcp loader, config is nil")
+	_, err = NewLoader(ctx, &LoaderConfig{})
+	assert.EqualError(t, err, "new mcp loader, cli is required")
+
+	cli := newMockMCPClient()
+	loader, err := NewLoader(ctx, &LoaderConfig{Cli: cli})
+	assert.NoError(t, err)
+
+	docs, err := loader.Load(ctx, document.Source{URI: "file:///readme.md"})
+	assert.NoError(t, err)
+	assert.Equal(t, []*schema.Document{{
+		ID:      "file:///readme.md",
+		Content: "# readme",
+		MetaData: map[string]any{
+			MetaKeyURI:      "file:///readme.md",
+			MetaKeySource:   "file:///readme.md",
+			MetaKeyMIMEType: "text/markdown",
+		},
+	}}, docs)
+
+	_, err = loader.Load(ctx, document.Source{URI: "file:///missing.md"})
+	assert.EqualError(t, err, "read mcp resource [file:///missing.md] fail: resource not found")
+
+	_, err = loader.Load(ctx, document.Source{URI: "file:///logo.png"})
+	assert.EqualError(t, err, "mcp resource [file:///logo.png] is binary, mime type: image/png, but parser is not configured")
+
+	loader, err = NewLoader(ctx, &LoaderConfig{Cli: cli, Parser: bytesParser{}})
+	assert.NoError(t, err)
+	docs, err = loader.Load(ctx, document.Source{URI: "file:///logo.png"})
+	assert.NoError(t, err)
+	if assert.Len(t, docs, 1) {
+		assert.Equal(t, "png", docs[0].Content)
+		assert.Equal(t, "image/png", docs[0].MetaData[MetaKeyMIMEType])
+	}
+}
+
+func TestLoadAll(t *testing.T) {
+	ctx := context.Background()
+	cli := newMockMCPClient()
+
+	loader, err := NewLoader(ctx, &LoaderConfig{Cli: cli, Parser: bytesParser{}})
+	assert.NoError(t, err)
+	docs, err := loader.LoadAll(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, docs, 2) {
+		assert.Equal(t, "readme", docs[0].MetaData[MetaKeyName])
+		assert.Equal(t, "the readme", docs[0].MetaData[MetaKeyDescription])
+		assert.Equal(t, "logo", docs[1].MetaData[MetaKeyName])
+		assert.NotContains(t, docs[1].MetaData, MetaKeyDescription)
+	}
+
+	loader, err = NewLoader(ctx, &LoaderConfig{Cli: cli, Filter: func(resource mcp.Resource) bool {
+		return resource.MIMEType == "text/markdown"
+	}})
+	assert.NoError(t, err)
+	docs, err = loader.LoadAll(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, docs, 1) {
+		assert.Equal(t, "# readme", docs[0].Content)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cli := newMockMCPClient()
+	loader, err := NewLoader(ctx, &LoaderConfig{Cli: cli})
+	assert.NoError(t, err)
+
+	assert.EqualError(t, loader.Watch(ctx, nil, nil), "watch mcp resources, uris are required")
+
+	updates := make(chan []*schema.Document, 1)
+	err = loader.Watch(ctx, []string{"file:///readme.md"}, func(ctx context.Context, uri string, docs []*schema.Document, err error) {
+		assert.NoError(t, err)
+		assert.Equal(t, "file:///readme.md", uri)
+		updates <- docs
+	})
+	assert.NoError(t, err)
+	cli.mu.Lock()
+	assert.True(t, cli.subscribed["file:///readme.md"])
+	cli.mu.Unlock()
+
+	cli.update("file:///readme.md", "# readme v2")
+	select {
+	case docs := <-updates:
+		assert.Equal(t, "# readme v2", docs[0].Content)
+	case <-time.After(time.Second):
+		t.Fatal("no update")
+	}
+
+	// not watched
+	cli.update("file:///other.md", "# other")
+	select {
+	case <-updates:
+		t.Fatal("unexpected update")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	cancel()
+	assert.Eventually(t, func() bool {
+		cli.mu.Lock()
+		defer cli.mu.Unlock()
+		return !cli.subscribed["file:///readme.md"]
+	}, time.Second, 10*time.Millisecond)
+	cli.update("file:///readme.md", "# readme v3")
+	select {
+	case <-updates:
+		t.Fatal("unexpected update")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	cli.subscribeErr = errors.New("method not found")
+	err = loader.Watch(context.Background(), []string{"file:///readme.md"}, func(context.Context, string, []*schema.Document, error) {})
+	assert.EqualError(t, err, "subscribe mcp resource [file:///readme.md] fail: method not found")
+}
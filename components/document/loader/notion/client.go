@@ -0,0 +1,283 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notion
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bytedance/sonic"
+)
+
+const pageSize = 100
+
+type apiError struct {
+	StatusCode int
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("notion api error, status= %d, code= %s, message= %s", e.StatusCode, e.Code, e.Message)
+}
+
+type richText struct {
+	PlainText   string       `json:"plain_text"`
+	Href        *string      `json:"href"`
+	Annotations *annotations `json:"annotations"`
+}
+
+type annotations struct {
+	Bold          bool `json:"bold"`
+	Italic        bool `json:"italic"`
+	Strikethrough bool `json:"strikethrough"`
+	Code          bool `json:"code"`
+}
+
+type parent struct {
+	Type       string `json:"type"`
+	PageID     string `json:"page_id"`
+	DatabaseID string `json:"database_id"`
+	BlockID    string `json:"block_id"`
+}
+
+type page struct {
+	ID             string                    `json:"id"`
+	URL            string                    `json:"url"`
+	CreatedTime    time.Time                 `json:"created_time"`
+	LastEditedTime time.Time                 `json:"last_edited_time"`
+	Archived       bool                      `json:"archived"`
+	InTrash        bool                      `json:"in_trash"`
+	Parent         *parent                   `json:"parent"`
+	Properties     map[string]map[string]any `json:"properties"`
+}
+
+type database struct {
+	ID             string     `json:"id"`
+	URL            string     `json:"url"`
+	Title          []richText `json:"title"`
+	LastEditedTime time.Time  `json:"last_edited_time"`
+}
+
+type fileObject struct {
+	URL string `json:"url"`
+}
+
+// blockContent is the union of the type specific fields of the blocks this loader renders.
+type blockContent struct {
+	RichText        []richText   `json:"rich_text"`
+	Caption         []richText   `json:"caption"`
+	Checked         bool         `json:"checked"`
+	Language        string       `json:"language"`
+	URL             string       `json:"url"`
+	Expression      string       `json:"expression"`
+	Title           string       `json:"title"`
+	Name            string       `json:"name"`
+	HasColumnHeader bool         `json:"has_column_header"`
+	Cells           [][]richText `json:"cells"`
+	External        *fileObject  `json:"external"`
+	File            *fileObject  `json:"file"`
+}
+
+type block struct {
+	ID          string
+	Type        string
+	HasChildren bool
+	Content     *blockContent
+
+	children []*block
+}
+
+func (b *block) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := sonic.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var head struct {
+		ID          string `json:"id"`
+		Type        string `json:"type"`
+		HasChildren bool   `json:"has_children"`
+	}
+	if err := sonic.Unmarshal(data, &head); err != nil {
+		return err
+	}
+
+	b.ID, b.Type, b.HasChildren = head.ID, head.Type, head.HasChildren
+	b.Content = &blockContent{}
+	if content, ok := raw[head.Type]; ok {
+		if err := sonic.Unmarshal(content, b.Content); err != nil {
+			return fmt.Errorf("unmarshal %s block content err: %w", head.Type, err)
+		}
+	}
+
+	return nil
+}
+
+type paginated[T any] struct {
+	Results    []T     `json:"results"`
+	HasMore    bool    `json:"has_more"`
+	NextCursor *string `json:"next_cursor"`
+}
+
+type client struct {
+	baseURL    string
+	token      string
+	version    string
+	httpClient *http.Client
+	maxRetries int
+}
+
+func (c *client) getPage(ctx context.Context, id string) (*page, error) {
+	p := &page{}
+	if err := c.do(ctx, http.MethodGet, "/pages/"+id, nil, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (c *client) getDatabase(ctx context.Context, id string) (*database, error) {
+	d := &database{}
+	if err := c.do(ctx, http.MethodGet, "/databases/"+id, nil, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (c *client) queryDatabase(ctx context.Context, id string, filter any) ([]*page, error) {
+	var (
+		pages  []*page
+		cursor *string
+	)
+
+	for {
+		body := map[string]any{"page_size": pageSize}
+		if filter != nil {
+			body["filter"] = filter
+		}
+		if cursor != nil {
+			body["start_cursor"] = *cursor
+		}
+
+		resp := &paginated[*page]{}
+		if err := c.do(ctx, http.MethodPost, "/databases/"+id+"/query", body, resp); err != nil {
+			return nil, err
+		}
+
+		pages = append(pages, resp.Results...)
+		if !resp.HasMore || resp.NextCursor == nil {
+			return pages, nil
+		}
+		cursor = resp.NextCursor
+	}
+}
+
+func (c *client) listChildren(ctx context.Context, blockID string) ([]*block, error) {
+	var (
+		blocks []*block
+		cursor *string
+	)
+
+	for {
+		query := url.Values{"page_size": {strconv.Itoa(pageSize)}}
+		if cursor != nil {
+			query.Set("start_cursor", *cursor)
+		}
+
+		resp := &paginated[*block]{}
+		if err := c.do(ctx, http.MethodGet, "/blocks/"+blockID+"/children?"+query.Encode(), nil, resp); err != nil {
+			return nil, err
+		}
+
+		blocks = append(blocks, resp.Results...)
+		if !resp.HasMore || resp.NextCursor == nil {
+			return blocks, nil
+		}
+		cursor = resp.NextCursor
+	}
+}
+
+// do sends the request, and retries on rate limiting and server errors,
+// honoring the Retry-After header when present.
+func (c *client) do(ctx context.Context, method, path string, body any, result any) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = sonic.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshal request body err: %w", err)
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(c.baseURL, "/")+path, bytes.NewReader(payload))
+		if err != nil {
+			return fmt.Errorf("create request err: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+c.token)
+		req.Header.Set("Notion-Version", c.version)
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("do request err: %w", err)
+		}
+
+		data, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("read response err: %w", err)
+		}
+
+		if resp.StatusCode == http.StatusOK {
+			if err = sonic.Unmarshal(data, result); err != nil {
+				return fmt.Errorf("decode response err: %w", err)
+			}
+			return nil
+		}
+
+		apiErr := &apiError{}
+		_ = sonic.Unmarshal(data, apiErr)
+		apiErr.StatusCode = resp.StatusCode
+
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+		if !retryable || attempt >= c.maxRetries {
+			return apiErr
+		}
+
+		wait := time.Duration(1<<attempt) * 500 * time.Millisecond
+		if seconds, e := strconv.Atoi(resp.Header.Get("Retry-After")); e == nil {
+			wait = time.Duration(seconds) * time.Second
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
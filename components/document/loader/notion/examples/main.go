@@ -0,0 +1,62 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/cloudwego/eino/components/document"
+
+	"github.com/cloudwego/eino-ext/components/document/loader/notion"
+)
+
+func main() {
+	ctx := context.Background()
+
+	loader, err := notion.NewLoader(ctx, &notion.LoaderConfig{
+		Token:             os.Getenv("NOTION_TOKEN"),
+		IncludeChildPages: true,
+	})
+	if err != nil {
+		log.Fatalf("NewLoader failed, err=%v", err)
+	}
+
+	src := document.Source{URI: "notion://database/" + os.Getenv("NOTION_DATABASE_ID")}
+
+	docs, err := loader.Load(ctx, src)
+	if err != nil {
+		log.Fatalf("load failed, err=%v", err)
+	}
+
+	// remember the latest edit time as the cursor of the next sync
+	var cursor time.Time
+	for _, doc := range docs {
+		log.Printf("title: %v, properties: %v", doc.MetaData[notion.MetaKeyTitle], doc.MetaData[notion.MetaKeyProperties])
+		if edited := doc.MetaData[notion.MetaKeyLastEditedTime].(time.Time); edited.After(cursor) {
+			cursor = edited
+		}
+	}
+
+	docs, err = loader.Load(ctx, src, notion.WithLastEditedAfter(cursor))
+	if err != nil {
+		log.Fatalf("incremental load failed, err=%v", err)
+	}
+	log.Printf("edited pages since %s: %d", cursor, len(docs))
+}
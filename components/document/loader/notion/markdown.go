@@ -0,0 +1,215 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notion
+
+import (
+	"fmt"
+	"strings"
+)
+
+const indentUnit = "  "
+
+// renderMarkdown renders the block tree into markdown.
+func renderMarkdown(blocks []*block) string {
+	sb := &strings.Builder{}
+	renderBlocks(sb, blocks, "")
+	return strings.TrimSpace(sb.String())
+}
+
+func renderBlocks(sb *strings.Builder, blocks []*block, indent string) {
+	for i, b := range blocks {
+		renderBlock(sb, b, indent)
+
+		// consecutive list items are kept tight, other blocks are separated by a blank line.
+		if i+1 < len(blocks) && isListItem(b) && isListItem(blocks[i+1]) {
+			continue
+		}
+		sb.WriteString("\n")
+	}
+}
+
+func isListItem(b *block) bool {
+	switch b.Type {
+	case "bulleted_list_item", "numbered_list_item", "to_do":
+		return true
+	}
+	return false
+}
+
+func renderBlock(sb *strings.Builder, b *block, indent string) {
+	c := b.Content
+	text := renderRichText(c.RichText)
+
+	writeLine := func(line string) {
+		sb.WriteString(indent)
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+
+	// children of list-like blocks are nested, children of container blocks are rendered at the same level.
+	nestedChildren := true
+
+	switch b.Type {
+	case "paragraph":
+		writeLine(text)
+	case "heading_1":
+		writeLine("# " + text)
+	case "heading_2":
+		writeLine("## " + text)
+	case "heading_3":
+		writeLine("### " + text)
+	case "bulleted_list_item", "toggle":
+		writeLine("- " + text)
+	case "numbered_list_item":
+		writeLine("1. " + text)
+	case "to_do":
+		if c.Checked {
+			writeLine("- [x] " + text)
+		} else {
+			writeLine("- [ ] " + text)
+		}
+	case "quote", "callout":
+		for _, line := range strings.Split(text, "\n") {
+			writeLine("> " + line)
+		}
+	case "code":
+		writeLine("```" + c.Language)
+		for _, line := range strings.Split(plainText(c.RichText), "\n") {
+			writeLine(line)
+		}
+		writeLine("```")
+	case "equation":
+		writeLine("$$" + c.Expression + "$$")
+	case "divider":
+		writeLine("---")
+	case "image", "video", "audio", "file", "pdf":
+		link := fileURL(c)
+		caption := renderRichText(c.Caption)
+		if caption == "" {
+			caption = c.Name
+		}
+		if b.Type == "image" {
+			writeLine(fmt.Sprintf("![%s](%s)", caption, link))
+		} else {
+			writeLine(fmt.Sprintf("[%s](%s)", orDefault(caption, b.Type), link))
+		}
+	case "bookmark", "embed", "link_preview":
+		writeLine(fmt.Sprintf("[%s](%s)", orDefault(renderRichText(c.Caption), c.URL), c.URL))
+	case "child_page", "child_database":
+		writeLine(fmt.Sprintf("[%s](%s)", c.Title, pageURL(b.ID)))
+		return
+	case "table":
+		renderTable(sb, b, indent)
+		return
+	default:
+		// column_list, column, synced_block and unsupported blocks only render their children.
+		nestedChildren = false
+		if text != "" {
+			writeLine(text)
+		}
+	}
+
+	if len(b.children) == 0 {
+		return
+	}
+
+	childIndent := indent
+	if nestedChildren {
+		childIndent += indentUnit
+	}
+	if !isListItem(b) && b.Type != "toggle" {
+		sb.WriteString("\n")
+	}
+	renderBlocks(sb, b.children, childIndent)
+}
+
+func renderTable(sb *strings.Builder, b *block, indent string) {
+	for i, row := range b.children {
+		cells := make([]string, 0, len(row.Content.Cells))
+		for _, cell := range row.Content.Cells {
+			cells = append(cells, strings.ReplaceAll(renderRichText(cell), "|", `\|`))
+		}
+
+		sb.WriteString(indent)
+		sb.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+
+		// markdown tables require a header row, so the first row is always used as header.
+		if i == 0 {
+			sep := make([]string, len(cells))
+			for j := range sep {
+				sep[j] = "---"
+			}
+			sb.WriteString(indent)
+			sb.WriteString("| " + strings.Join(sep, " | ") + " |\n")
+		}
+	}
+}
+
+func renderRichText(texts []richText) string {
+	sb := &strings.Builder{}
+	for _, t := range texts {
+		s := t.PlainText
+		if a := t.Annotations; a != nil && strings.TrimSpace(s) != "" {
+			if a.Code {
+				s = "`" + s + "`"
+			}
+			if a.Bold {
+				s = "**" + s + "**"
+			}
+			if a.Italic {
+				s = "*" + s + "*"
+			}
+			if a.Strikethrough {
+				s = "~~" + s + "~~"
+			}
+		}
+		if t.Href != nil && *t.Href != "" {
+			s = fmt.Sprintf("[%s](%s)", s, *t.Href)
+		}
+		sb.WriteString(s)
+	}
+	return sb.String()
+}
+
+func plainText(texts []richText) string {
+	sb := &strings.Builder{}
+	for _, t := range texts {
+		sb.WriteString(t.PlainText)
+	}
+	return sb.String()
+}
+
+func fileURL(c *blockContent) string {
+	if c.External != nil {
+		return c.External.URL
+	}
+	if c.File != nil {
+		return c.File.URL
+	}
+	return ""
+}
+
+func pageURL(id string) string {
+	return "https://www.notion.so/" + strings.ReplaceAll(id, "-", "")
+}
+
+func orDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
@@ -0,0 +1,370 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package notion can load documents from Notion pages and databases.
+package notion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	MetaKeyPageID         = "_notion_page_id"
+	MetaKeyTitle          = "_notion_title"
+	MetaKeyURL            = "_notion_url"
+	MetaKeyCreatedTime    = "_notion_created_time"
+	MetaKeyLastEditedTime = "_notion_last_edited_time"
+	MetaKeyDatabaseID     = "_notion_database_id"
+	MetaKeyProperties     = "_notion_properties"
+	MetaKeySource         = "_source"
+)
+
+const (
+	defaultBaseURL       = "https://api.notion.com/v1"
+	defaultNotionVersion = "2022-06-28"
+	defaultMaxRetries    = 3
+)
+
+// LoaderConfig is the configuration for notion loader.
+type LoaderConfig struct {
+	// Token is the secret of the Notion integration, required.
+	// The pages and databases to load must be shared with the integration.
+	Token string
+	// BaseURL is the Notion API endpoint, default: https://api.notion.com/v1
+	BaseURL string
+	// NotionVersion is the Notion-Version header, default: 2022-06-28
+	NotionVersion string
+	// HTTPClient is the client to send requests, default: http.DefaultClient
+	HTTPClient *http.Client
+	// MaxRetries is the max retry times on rate limiting and server errors, default: 3
+	MaxRetries int
+
+	// IncludeChildPages loads the child pages and child databases of a page recursively, each page as a separate document.
+	IncludeChildPages bool
+	// SkipTitle disables prepending the page title as a level-1 heading to the document content.
+	SkipTitle bool
+}
+
+// Loader loads Notion pages as markdown documents, one document per page.
+// The document.Source's URI can be:
+//   - notion://page/<page_id>, a single page (and its child pages if IncludeChildPages is set)
+//   - notion://database/<database_id>, all pages in the database
+//   - a Notion page or database url, or a bare id, whose type is detected automatically
+type Loader struct {
+	conf   *LoaderConfig
+	client *client
+}
+
+var _ document.Loader = (*Loader)(nil)
+
+// NewLoader creates a new notion loader.
+func NewLoader(_ context.Context, conf *LoaderConfig) (*Loader, error) {
+	if conf == nil {
+		return nil, errors.New("new notion loader, config is nil")
+	}
+	if len(conf.Token) == 0 {
+		return nil, errors.New("new notion loader, token is required")
+	}
+
+	c := &client{
+		baseURL:    conf.BaseURL,
+		token:      conf.Token,
+		version:    conf.NotionVersion,
+		httpClient: conf.HTTPClient,
+		maxRetries: conf.MaxRetries,
+	}
+	if len(c.baseURL) == 0 {
+		c.baseURL = defaultBaseURL
+	}
+	if len(c.version) == 0 {
+		c.version = defaultNotionVersion
+	}
+	if c.httpClient == nil {
+		c.httpClient = http.DefaultClient
+	}
+	if c.maxRetries <= 0 {
+		c.maxRetries = defaultMaxRetries
+	}
+
+	return &Loader{conf: conf, client: c}, nil
+}
+
+func (l *Loader) Load(ctx context.Context, src document.Source, opts ...document.LoaderOption) (docs []*schema.Document, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, l.GetType(), components.ComponentOfLoader)
+	ctx = callbacks.OnStart(ctx, &document.LoaderCallbackInput{
+		Source: src,
+	})
+	defer func() {
+		if err != nil {
+			_ = callbacks.OnError(ctx, err)
+		}
+	}()
+
+	kind, id, err := parseURI(src.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &loadSession{
+		loader:  l,
+		options: document.GetLoaderImplSpecificOptions(&options{}, opts...),
+		visited: make(map[string]bool),
+	}
+
+	switch kind {
+	case kindPage:
+		err = s.loadPage(ctx, id, nil)
+	case kindDatabase:
+		err = s.loadDatabase(ctx, id)
+	default:
+		err = s.loadPage(ctx, id, nil)
+		var apiErr *apiError
+		// the id may belong to a database, which can not be retrieved as a page.
+		if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusBadRequest) {
+			err = s.loadDatabase(ctx, id)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	docs = s.docs
+
+	_ = callbacks.OnEnd(ctx, &document.LoaderCallbackOutput{
+		Source: src,
+		Docs:   docs,
+	})
+
+	return docs, nil
+}
+
+// loadSession holds the state of a single Load call.
+type loadSession struct {
+	loader  *Loader
+	options *options
+	visited map[string]bool // keyed by object kind and normalized id
+	docs    []*schema.Document
+}
+
+// loadPage loads the page by id, p is used directly if it has been fetched, e.g. by database query.
+func (s *loadSession) loadPage(ctx context.Context, id string, p *page) error {
+	key := "page:" + normalizeID(id)
+	if s.visited[key] {
+		return nil
+	}
+	s.visited[key] = true
+
+	if p == nil {
+		var err error
+		p, err = s.loader.client.getPage(ctx, id)
+		if err != nil {
+			return fmt.Errorf("notion loader get page [%s] err: %w", id, err)
+		}
+	}
+
+	if p.Archived || p.InTrash {
+		return nil
+	}
+
+	edited := s.options.editedAfter == nil || p.LastEditedTime.After(*s.options.editedAfter)
+	// unchanged pages are still traversed for their child pages, whose edits do not touch the parent.
+	if !edited && !s.loader.conf.IncludeChildPages {
+		return nil
+	}
+
+	blocks, err := s.fetchBlocks(ctx, p.ID)
+	if err != nil {
+		return fmt.Errorf("notion loader get blocks of page [%s] err: %w", p.ID, err)
+	}
+
+	if edited {
+		s.docs = append(s.docs, s.toDocument(p, blocks))
+	}
+
+	if !s.loader.conf.IncludeChildPages {
+		return nil
+	}
+
+	return s.loadChildPages(ctx, blocks)
+}
+
+func (s *loadSession) loadChildPages(ctx context.Context, blocks []*block) error {
+	for _, b := range blocks {
+		var err error
+		switch b.Type {
+		case "child_page":
+			err = s.loadPage(ctx, b.ID, nil)
+		case "child_database":
+			err = s.loadDatabase(ctx, b.ID)
+		default:
+			err = s.loadChildPages(ctx, b.children)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *loadSession) loadDatabase(ctx context.Context, id string) error {
+	key := "database:" + normalizeID(id)
+	if s.visited[key] {
+		return nil
+	}
+	s.visited[key] = true
+
+	var filter any
+	// when child pages are included, all entries are traversed since their children may have changed.
+	if s.options.editedAfter != nil && !s.loader.conf.IncludeChildPages {
+		filter = map[string]any{
+			"timestamp": "last_edited_time",
+			"last_edited_time": map[string]any{
+				"after": s.options.editedAfter.Format(time.RFC3339),
+			},
+		}
+	}
+
+	pages, err := s.loader.client.queryDatabase(ctx, id, filter)
+	if err != nil {
+		return fmt.Errorf("notion loader query database [%s] err: %w", id, err)
+	}
+
+	for _, p := range pages {
+		if err = s.loadPage(ctx, p.ID, p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fetchBlocks fetches the children of the block recursively, child pages and databases are not expanded.
+func (s *loadSession) fetchBlocks(ctx context.Context, blockID string) ([]*block, error) {
+	blocks, err := s.loader.client.listChildren(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, b := range blocks {
+		if !b.HasChildren || b.Type == "child_page" || b.Type == "child_database" {
+			continue
+		}
+		if b.children, err = s.fetchBlocks(ctx, b.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return blocks, nil
+}
+
+func (s *loadSession) toDocument(p *page, blocks []*block) *schema.Document {
+	title := pageTitle(p)
+
+	content := renderMarkdown(blocks)
+	if !s.loader.conf.SkipTitle && len(title) > 0 {
+		content = strings.TrimSpace("# " + title + "\n\n" + content)
+	}
+
+	meta := map[string]any{
+		MetaKeyPageID:         p.ID,
+		MetaKeyTitle:          title,
+		MetaKeyURL:            p.URL,
+		MetaKeyCreatedTime:    p.CreatedTime,
+		MetaKeyLastEditedTime: p.LastEditedTime,
+		MetaKeyProperties:     propertyValues(p.Properties),
+		MetaKeySource:         p.URL,
+	}
+	if p.Parent != nil && p.Parent.Type == "database_id" {
+		meta[MetaKeyDatabaseID] = p.Parent.DatabaseID
+	}
+
+	return &schema.Document{
+		ID:       p.ID,
+		Content:  content,
+		MetaData: meta,
+	}
+}
+
+type objectKind int
+
+const (
+	kindUnknown objectKind = iota
+	kindPage
+	kindDatabase
+)
+
+// notionID matches the trailing id of notion urls, in either dashed or compact form.
+var notionID = regexp.MustCompile(`([0-9a-fA-F]{8}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{12})$`)
+
+func parseURI(uri string) (objectKind, string, error) {
+	const (
+		pagePrefix     = "notion://page/"
+		databasePrefix = "notion://database/"
+	)
+
+	if len(uri) == 0 {
+		return kindUnknown, "", errors.New("notion loader source uri is empty")
+	}
+
+	kind := kindUnknown
+	switch {
+	case strings.HasPrefix(uri, pagePrefix):
+		kind, uri = kindPage, strings.TrimPrefix(uri, pagePrefix)
+	case strings.HasPrefix(uri, databasePrefix):
+		kind, uri = kindDatabase, strings.TrimPrefix(uri, databasePrefix)
+	}
+
+	// drop the query and fragment of notion urls, e.g. ?v=<view_id>
+	if idx := strings.IndexAny(uri, "?#"); idx >= 0 {
+		uri = uri[:idx]
+	}
+
+	id := notionID.FindString(strings.TrimRight(uri, "/"))
+	if len(id) == 0 {
+		return kindUnknown, "", fmt.Errorf("notion loader can not find page or database id in uri: %s", uri)
+	}
+
+	return kind, dashedID(id), nil
+}
+
+func normalizeID(id string) string {
+	return strings.ToLower(strings.ReplaceAll(id, "-", ""))
+}
+
+// dashedID formats the id in the canonical uuid form used by the Notion API.
+func dashedID(id string) string {
+	id = normalizeID(id)
+	return id[:8] + "-" + id[8:12] + "-" + id[12:16] + "-" + id[16:20] + "-" + id[20:]
+}
+
+func (l *Loader) GetType() string {
+	return "NotionLoader"
+}
+
+func (l *Loader) IsCallbacksEnabled() bool {
+	return true
+}
@@ -0,0 +1,280 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notion
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	rootPageID  = "11111111-1111-1111-1111-111111111111"
+	childPageID = "22222222-2222-2222-2222-222222222222"
+	databaseID  = "33333333-3333-3333-3333-333333333333"
+	entryPageID = "44444444-4444-4444-4444-444444444444"
+)
+
+const rootPage = `{
+	"object": "page", "id": "11111111-1111-1111-1111-111111111111",
+	"url": "https://www.notion.so/Root-11111111111111111111111111111111",
+	"created_time": "2024-01-01T00:00:00.000Z", "last_edited_time": "2024-03-01T00:00:00.000Z",
+	"parent": {"type": "workspace", "workspace": true},
+	"properties": {"title": {"id": "title", "type": "title", "title": [{"plain_text": "Root"}]}}
+}`
+
+const childPage = `{
+	"object": "page", "id": "22222222-2222-2222-2222-222222222222",
+	"url": "https://www.notion.so/Child-22222222222222222222222222222222",
+	"created_time": "2024-01-01T00:00:00.000Z", "last_edited_time": "2024-01-02T00:00:00.000Z",
+	"parent": {"type": "page_id", "page_id": "11111111-1111-1111-1111-111111111111"},
+	"properties": {"title": {"id": "title", "type": "title", "title": [{"plain_text": "Child"}]}}
+}`
+
+const rootBlocksPage1 = `{
+	"object": "list", "has_more": true, "next_cursor": "cursor-1",
+	"results": [
+		{"id": "b1", "type": "heading_1", "has_children": false, "heading_1": {"rich_text": [{"plain_text": "Intro"}]}},
+		{"id": "b2", "type": "paragraph", "has_children": false, "paragraph": {"rich_text": [
+			{"plain_text": "hello "},
+			{"plain_text": "bold", "annotations": {"bold": true}},
+			{"plain_text": " link", "href": "https://cloudwego.io"}
+		]}},
+		{"id": "b3", "type": "bulleted_list_item", "has_children": true, "bulleted_list_item": {"rich_text": [{"plain_text": "item"}]}}
+	]
+}`
+
+const rootBlocksPage2 = `{
+	"object": "list", "has_more": false, "next_cursor": null,
+	"results": [
+		{"id": "b4", "type": "to_do", "has_children": false, "to_do": {"rich_text": [{"plain_text": "done"}], "checked": true}},
+		{"id": "b5", "type": "code", "has_children": false, "code": {"rich_text": [{"plain_text": "fmt.Println(1)"}], "language": "go"}},
+		{"id": "b6", "type": "table", "has_children": true, "table": {"table_width": 2, "has_column_header": true}},
+		{"id": "22222222-2222-2222-2222-222222222222", "type": "child_page", "has_children": true, "child_page": {"title": "Child"}}
+	]
+}`
+
+const nestedBlocks = `{
+	"object": "list", "has_more": false, "next_cursor": null,
+	"results": [
+		{"id": "b31", "type": "bulleted_list_item", "has_children": false, "bulleted_list_item": {"rich_text": [{"plain_text": "nested"}]}}
+	]
+}`
+
+const tableRows = `{
+	"object": "list", "has_more": false, "next_cursor": null,
+	"results": [
+		{"id": "r1", "type": "table_row", "has_children": false, "table_row": {"cells": [[{"plain_text": "k"}], [{"plain_text": "v"}]]}},
+		{"id": "r2", "type": "table_row", "has_children": false, "table_row": {"cells": [[{"plain_text": "a"}], [{"plain_text": "1"}]]}}
+	]
+}`
+
+const childBlocks = `{
+	"object": "list", "has_more": false, "next_cursor": null,
+	"results": [
+		{"id": "c1", "type": "paragraph", "has_children": false, "paragraph": {"rich_text": [{"plain_text": "child content"}]}}
+	]
+}`
+
+const databaseQueryPage1 = `{
+	"object": "list", "has_more": true, "next_cursor": "db-cursor",
+	"results": []
+}`
+
+const databaseQueryPage2 = `{
+	"object": "list", "has_more": false, "next_cursor": null,
+	"results": [{
+		"object": "page", "id": "44444444-4444-4444-4444-444444444444",
+		"url": "https://www.notion.so/Entry-44444444444444444444444444444444",
+		"created_time": "2024-01-01T00:00:00.000Z", "last_edited_time": "2024-02-01T00:00:00.000Z",
+		"parent": {"type": "database_id", "database_id": "33333333-3333-3333-3333-333333333333"},
+		"properties": {
+			"Name": {"id": "title", "type": "title", "title": [{"plain_text": "Entry"}]},
+			"Status": {"id": "s", "type": "status", "status": {"name": "Done"}},
+			"Tags": {"id": "t", "type": "multi_select", "multi_select": [{"name": "a"}, {"name": "b"}]},
+			"Score": {"id": "n", "type": "number", "number": 3},
+			"Due": {"id": "d", "type": "date", "date": {"start": "2024-01-01", "end": "2024-01-31"}},
+			"Empty": {"id": "e", "type": "select", "select": null}
+		}
+	}]
+}`
+
+type fakeNotion struct {
+	rateLimited atomic.Bool
+	queryBodies []string
+}
+
+func (f *fakeNotion) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer token" || r.Header.Get("Notion-Version") == "" {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"object": "error", "status": 401, "code": "unauthorized", "message": "bad token"}`))
+		return
+	}
+
+	if f.rateLimited.CompareAndSwap(true, false) {
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"object": "error", "status": 429, "code": "rate_limited", "message": "slow down"}`))
+		return
+	}
+
+	var resp string
+	switch r.URL.Path {
+	case "/pages/" + rootPageID:
+		resp = rootPage
+	case "/pages/" + childPageID:
+		resp = childPage
+	case "/blocks/" + rootPageID + "/children":
+		resp = rootBlocksPage1
+		if r.URL.Query().Get("start_cursor") == "cursor-1" {
+			resp = rootBlocksPage2
+		}
+	case "/blocks/b3/children":
+		resp = nestedBlocks
+	case "/blocks/b6/children":
+		resp = tableRows
+	case "/blocks/" + childPageID + "/children", "/blocks/" + entryPageID + "/children":
+		resp = childBlocks
+	case "/databases/" + databaseID + "/query":
+		body, _ := io.ReadAll(r.Body)
+		f.queryBodies = append(f.queryBodies, string(body))
+		resp = databaseQueryPage1
+		if strings.Contains(string(body), "db-cursor") {
+			resp = databaseQueryPage2
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"object": "error", "status": 404, "code": "object_not_found", "message": "not found"}`))
+		return
+	}
+
+	_, _ = w.Write([]byte(resp))
+}
+
+func TestNewLoader(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewLoader(ctx, nil)
+	assert.Error(t, err)
+
+	_, err = NewLoader(ctx, &LoaderConfig{})
+	assert.Error(t, err)
+
+	l, err := NewLoader(ctx, &LoaderConfig{Token: "token"})
+	assert.NoError(t, err)
+	assert.Equal(t, defaultBaseURL, l.client.baseURL)
+	assert.Equal(t, defaultNotionVersion, l.client.version)
+}
+
+func TestLoader_LoadPage(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeNotion{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	l, err := NewLoader(ctx, &LoaderConfig{Token: "token", BaseURL: server.URL})
+	require.NoError(t, err)
+
+	_, err = l.Load(ctx, document.Source{})
+	assert.Error(t, err)
+
+	_, err = l.Load(ctx, document.Source{URI: "notion://page/not-an-id"})
+	assert.Error(t, err)
+
+	fake.rateLimited.Store(true)
+	docs, err := l.Load(ctx, document.Source{URI: "notion://page/" + rootPageID})
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+
+	expected := "# Root\n\n" +
+		"# Intro\n\n" +
+		"hello **bold**[ link](https://cloudwego.io)\n\n" +
+		"- item\n" +
+		"  - nested\n\n" +
+		"- [x] done\n\n" +
+		"```go\nfmt.Println(1)\n```\n\n" +
+		"| k | v |\n| --- | --- |\n| a | 1 |\n\n" +
+		"[Child](https://www.notion.so/22222222222222222222222222222222)"
+	assert.Equal(t, expected, docs[0].Content)
+	assert.Equal(t, rootPageID, docs[0].ID)
+	assert.Equal(t, "Root", docs[0].MetaData[MetaKeyTitle])
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), docs[0].MetaData[MetaKeyLastEditedTime])
+
+	l, err = NewLoader(ctx, &LoaderConfig{Token: "token", BaseURL: server.URL, IncludeChildPages: true, SkipTitle: true})
+	require.NoError(t, err)
+
+	docs, err = l.Load(ctx, document.Source{URI: "https://www.notion.so/workspace/Root-11111111111111111111111111111111?pvs=4"})
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	assert.Equal(t, childPageID, docs[1].ID)
+	assert.Equal(t, "child content", docs[1].Content)
+
+	// the root page is not edited after the cursor, but its child pages are still traversed.
+	docs, err = l.Load(ctx, document.Source{URI: "notion://page/" + rootPageID}, WithLastEditedAfter(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)))
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+
+	docs, err = l.Load(ctx, document.Source{URI: "notion://page/" + rootPageID}, WithLastEditedAfter(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)))
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, rootPageID, docs[0].ID)
+
+	l, err = NewLoader(ctx, &LoaderConfig{Token: "bad", BaseURL: server.URL})
+	require.NoError(t, err)
+
+	_, err = l.Load(ctx, document.Source{URI: "notion://page/" + rootPageID})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unauthorized")
+}
+
+func TestLoader_LoadDatabase(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeNotion{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	l, err := NewLoader(ctx, &LoaderConfig{Token: "token", BaseURL: server.URL})
+	require.NoError(t, err)
+
+	// the type of a bare id is detected by falling back to database.
+	docs, err := l.Load(ctx, document.Source{URI: strings.ReplaceAll(databaseID, "-", "")}, WithLastEditedAfter(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, entryPageID, docs[0].ID)
+	assert.Equal(t, "# Entry\n\nchild content", docs[0].Content)
+	assert.Equal(t, databaseID, docs[0].MetaData[MetaKeyDatabaseID])
+	assert.Equal(t, map[string]any{
+		"Name":   "Entry",
+		"Status": "Done",
+		"Tags":   []string{"a", "b"},
+		"Score":  float64(3),
+		"Due":    "2024-01-01/2024-01-31",
+	}, docs[0].MetaData[MetaKeyProperties])
+
+	require.Len(t, fake.queryBodies, 2)
+	assert.Contains(t, fake.queryBodies[0], `"after":"2024-01-01T00:00:00Z"`)
+	assert.Contains(t, fake.queryBodies[1], `"start_cursor":"db-cursor"`)
+}
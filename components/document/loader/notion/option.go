@@ -0,0 +1,35 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notion
+
+import (
+	"time"
+
+	"github.com/cloudwego/eino/components/document"
+)
+
+type options struct {
+	editedAfter *time.Time
+}
+
+// WithLastEditedAfter is a loader option that only loads the pages edited after the given time,
+// which is useful for incremental sync. The max MetaKeyLastEditedTime of the previous load can be used as the cursor.
+func WithLastEditedAfter(t time.Time) document.LoaderOption {
+	return document.WrapLoaderImplSpecificOptFn(func(o *options) {
+		o.editedAfter = &t
+	})
+}
@@ -0,0 +1,149 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notion
+
+import (
+	"fmt"
+	"strings"
+)
+
+// pageTitle returns the plain text of the title property of the page.
+func pageTitle(p *page) string {
+	for _, prop := range p.Properties {
+		if prop["type"] == "title" {
+			return richTextValue(prop["title"])
+		}
+	}
+	return ""
+}
+
+// propertyValues converts the page properties into plain values:
+// text-like properties become strings, multi-value properties become []string,
+// numbers become float64, checkboxes become bool, and dates become ISO 8601 strings,
+// with the end appended after a slash for date ranges. Unsupported or empty properties are omitted.
+func propertyValues(props map[string]map[string]any) map[string]any {
+	values := make(map[string]any, len(props))
+	for name, prop := range props {
+		typ, _ := prop["type"].(string)
+		if v := propertyValue(typ, prop[typ]); v != nil {
+			values[name] = v
+		}
+	}
+	return values
+}
+
+func propertyValue(typ string, v any) any {
+	if v == nil {
+		return nil
+	}
+
+	switch typ {
+	case "title", "rich_text":
+		return richTextValue(v)
+	case "number", "checkbox", "url", "email", "phone_number", "created_time", "last_edited_time":
+		return v
+	case "select", "status":
+		return fieldOf(v, "name")
+	case "multi_select", "files":
+		return collect(v, "name")
+	case "people":
+		return collect(v, "name")
+	case "relation":
+		return collect(v, "id")
+	case "created_by", "last_edited_by":
+		return fieldOf(v, "name")
+	case "date":
+		return dateValue(v)
+	case "unique_id":
+		m, _ := v.(map[string]any)
+		if prefix, _ := m["prefix"].(string); prefix != "" {
+			return fmt.Sprintf("%s-%v", prefix, m["number"])
+		}
+		return m["number"]
+	case "formula", "rollup":
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		subType, _ := m["type"].(string)
+		if subType == "array" {
+			items, _ := m["array"].([]any)
+			values := make([]any, 0, len(items))
+			for _, item := range items {
+				im, _ := item.(map[string]any)
+				itemType, _ := im["type"].(string)
+				if iv := propertyValue(itemType, im[itemType]); iv != nil {
+					values = append(values, iv)
+				}
+			}
+			return values
+		}
+		return propertyValue(subType, m[subType])
+	case "string", "boolean":
+		// the sub types of formula
+		return v
+	}
+
+	return nil
+}
+
+func richTextValue(v any) string {
+	items, _ := v.([]any)
+	sb := &strings.Builder{}
+	for _, item := range items {
+		if s := fieldOf(item, "plain_text"); s != nil {
+			sb.WriteString(s.(string))
+		}
+	}
+	return sb.String()
+}
+
+func dateValue(v any) any {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	start, _ := m["start"].(string)
+	if end, _ := m["end"].(string); end != "" {
+		return start + "/" + end
+	}
+	return start
+}
+
+func fieldOf(v any, field string) any {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil
+	}
+	s, ok := m[field].(string)
+	if !ok {
+		return nil
+	}
+	return s
+}
+
+func collect(v any, field string) []string {
+	items, _ := v.([]any)
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := fieldOf(item, field).(string); ok {
+			values = append(values, s)
+		}
+	}
+	return values
+}
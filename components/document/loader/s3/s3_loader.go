@@ -21,13 +21,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components"
 	"github.com/cloudwego/eino/components/document"
@@ -35,15 +40,47 @@ import (
 	"github.com/cloudwego/eino/schema"
 )
 
+const (
+	MetaKeyBucket       = "_s3_bucket"
+	MetaKeyObjectKey    = "_s3_key"
+	MetaKeyETag         = "_s3_etag"
+	MetaKeyLastModified = "_s3_last_modified"
+	MetaKeySize         = "_s3_size"
+	MetaKeySource       = "_source"
+)
+
+const defaultConcurrency = 4
+
 // LoaderConfig is the configuration for s3 loader.
 type LoaderConfig struct {
 	Region       *string // the region of the AWS bucket
 	AWSAccessKey *string
 	AWSSecretKey *string
 
+	// AssumeRoleARN is the ARN of the IAM role to assume via STS, optional.
+	// The static keys above (or the default credential chain) are used as the source identity.
+	AssumeRoleARN *string
+	// AssumeRoleSessionName is the session name used when assuming the role, optional.
+	AssumeRoleSessionName *string
+	// AssumeRoleExternalID is the external id required by the role's trust policy, optional.
+	AssumeRoleExternalID *string
+
 	UseObjectKeyAsID bool // whether to use object key as document ID
 
 	Parser parser.Parser // the parser to parse the s3 object stream into documents, default to parser.TextParser, which directly converts []byte to string
+
+	// The following fields only take effect when the source uri is a prefix, e.g. s3://bucket/docs/
+
+	// IncludePatterns are glob patterns (see path.Match) matched against both the object key and its base name.
+	// Only objects matching at least one pattern are loaded, empty means all objects are included.
+	IncludePatterns []string
+	// ExcludePatterns are glob patterns (see path.Match) matched the same way as IncludePatterns.
+	// Objects matching any of them are skipped, exclusion takes precedence over inclusion.
+	ExcludePatterns []string
+	// MaxKeys limits the number of objects loaded under a prefix, 0 means no limit.
+	MaxKeys int
+	// Concurrency is the number of objects downloaded concurrently, default 4.
+	Concurrency int
 }
 
 type loader struct {
@@ -52,6 +89,18 @@ type loader struct {
 	parser parser.Parser
 
 	useObjectKeyAsID bool
+
+	includePatterns []string
+	excludePatterns []string
+	maxKeys         int
+	concurrency     int
+}
+
+type object struct {
+	key          string
+	eTag         *string
+	lastModified *time.Time
+	size         *int64
 }
 
 // NewS3Loader creates a new s3 loader.
@@ -88,6 +137,28 @@ func NewS3Loader(ctx context.Context, conf *LoaderConfig) (document.Loader, erro
 		return nil, fmt.Errorf("new s3 loader, load config err: %w", err)
 	}
 
+	if conf.AssumeRoleARN != nil {
+		provider := stscreds.NewAssumeRoleProvider(sts.NewFromConfig(sdkConfig), *conf.AssumeRoleARN, func(o *stscreds.AssumeRoleOptions) {
+			if conf.AssumeRoleSessionName != nil {
+				o.RoleSessionName = *conf.AssumeRoleSessionName
+			}
+			if conf.AssumeRoleExternalID != nil {
+				o.ExternalID = conf.AssumeRoleExternalID
+			}
+		})
+		sdkConfig.Credentials = aws.NewCredentialsCache(provider)
+	}
+
+	for _, pattern := range append(append([]string{}, conf.IncludePatterns...), conf.ExcludePatterns...) {
+		if _, err = path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("new s3 loader, invalid glob pattern %q: %w", pattern, err)
+		}
+	}
+
+	if conf.MaxKeys < 0 {
+		return nil, errors.New("new s3 loader, max keys must not be negative")
+	}
+
 	client := s3.NewFromConfig(sdkConfig)
 
 	p := conf.Parser
@@ -95,10 +166,19 @@ func NewS3Loader(ctx context.Context, conf *LoaderConfig) (document.Loader, erro
 		p = &parser.TextParser{}
 	}
 
+	concurrency := conf.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
 	return &loader{
 		client:           client,
 		parser:           p,
 		useObjectKeyAsID: conf.UseObjectKeyAsID,
+		includePatterns:  conf.IncludePatterns,
+		excludePatterns:  conf.ExcludePatterns,
+		maxKeys:          conf.MaxKeys,
+		concurrency:      concurrency,
 	}, nil
 }
 
@@ -121,48 +201,196 @@ func (l *loader) Load(ctx context.Context, src document.Source, opts ...document
 		return nil, err
 	}
 
-	// prefix is not supported now
+	o := document.GetLoaderCommonOptions(&document.LoaderOptions{}, opts...)
+
 	if isPrefix {
-		err = errors.New("s3 loader does not support batch load with prefix for now")
+		docs, err = l.loadPrefix(ctx, bucket, key, o.ParserOptions)
+	} else {
+		docs, err = l.loadObject(ctx, bucket, &object{key: key}, o.ParserOptions)
+	}
+	if err != nil {
 		return nil, err
 	}
 
+	_ = callbacks.OnEnd(ctx, &document.LoaderCallbackOutput{
+		Source: src,
+		Docs:   docs,
+	})
+
+	return docs, nil
+}
+
+// loadPrefix lists all objects under the prefix page by page, and loads the matched ones concurrently.
+func (l *loader) loadPrefix(ctx context.Context, bucket, prefix string, parserOpts []parser.Option) ([]*schema.Document, error) {
+	objects, err := l.listObjects(ctx, bucket, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		results  = make([][]*schema.Document, len(objects))
+		sem      = make(chan struct{}, l.concurrency)
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for i := range objects {
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			docs, err := l.loadObject(ctx, bucket, objects[i], parserOpts)
+			if err != nil {
+				// only the first error is reported, the others are likely caused by cancellation.
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+			results[i] = docs
+		}(i)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	var docs []*schema.Document
+	for _, r := range results {
+		docs = append(docs, r...)
+	}
+
+	return docs, nil
+}
+
+func (l *loader) listObjects(ctx context.Context, bucket, prefix string) ([]*object, error) {
+	var objects []*object
+
+	paginator := s3.NewListObjectsV2Paginator(l.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("s3 loader list objects err, bucket= %s, prefix= %s: %w", bucket, prefix, err)
+		}
+
+		for _, item := range page.Contents {
+			key := aws.ToString(item.Key)
+			// skip the "directory" placeholder objects
+			if strings.HasSuffix(key, "/") || !l.match(key) {
+				continue
+			}
+
+			objects = append(objects, &object{
+				key:          key,
+				eTag:         item.ETag,
+				lastModified: item.LastModified,
+				size:         item.Size,
+			})
+
+			if l.maxKeys > 0 && len(objects) >= l.maxKeys {
+				return objects, nil
+			}
+		}
+	}
+
+	return objects, nil
+}
+
+func (l *loader) match(key string) bool {
+	base := path.Base(key)
+	matchAny := func(patterns []string) bool {
+		for _, pattern := range patterns {
+			// patterns are validated in NewS3Loader, so the error can be ignored.
+			if ok, _ := path.Match(pattern, key); ok {
+				return true
+			}
+			if ok, _ := path.Match(pattern, base); ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	if matchAny(l.excludePatterns) {
+		return false
+	}
+
+	return len(l.includePatterns) == 0 || matchAny(l.includePatterns)
+}
+
+func (l *loader) loadObject(ctx context.Context, bucket string, obj *object, parserOpts []parser.Option) ([]*schema.Document, error) {
 	// get object from s3
 	resp, err := l.client.GetObject(ctx, &s3.GetObjectInput{
 		Bucket: aws.String(bucket),
-		Key:    aws.String(key),
+		Key:    aws.String(obj.key),
 	})
 	if err != nil {
 		var noKey *types.NoSuchKey
 		if errors.As(err, &noKey) {
-			err = fmt.Errorf("s3 loader bucket= %s, key= %s not found, err: %w", bucket, key, err)
-			return nil, err
+			return nil, fmt.Errorf("s3 loader bucket= %s, key= %s not found, err: %w", bucket, obj.key, err)
 		}
 
-		err = fmt.Errorf("s3 loader get object err: %w", err)
-		return nil, err
+		return nil, fmt.Errorf("s3 loader get object err: %w", err)
 	}
 	defer resp.Body.Close()
 
-	o := document.GetLoaderCommonOptions(&document.LoaderOptions{}, opts...)
+	// prefer the attributes returned by GetObject, fallback to those from listing.
+	eTag, lastModified, size := obj.eTag, obj.lastModified, obj.size
+	if resp.ETag != nil {
+		eTag = resp.ETag
+	}
+	if resp.LastModified != nil {
+		lastModified = resp.LastModified
+	}
+	if resp.ContentLength != nil {
+		size = resp.ContentLength
+	}
+
+	uri := fmt.Sprintf("s3://%s/%s", bucket, obj.key)
+	meta := map[string]any{
+		MetaKeyBucket:    bucket,
+		MetaKeyObjectKey: obj.key,
+		MetaKeySource:    uri,
+	}
+	if eTag != nil {
+		meta[MetaKeyETag] = strings.Trim(*eTag, `"`)
+	}
+	if lastModified != nil {
+		meta[MetaKeyLastModified] = *lastModified
+	}
+	if size != nil {
+		meta[MetaKeySize] = *size
+	}
 
-	docs, err = l.parser.Parse(ctx, resp.Body, append([]parser.Option{parser.WithURI(src.URI)}, o.ParserOptions...)...)
+	docs, err := l.parser.Parse(ctx, resp.Body, append([]parser.Option{parser.WithURI(uri), parser.WithExtraMeta(meta)}, parserOpts...)...)
 	if err != nil {
-		err = fmt.Errorf("s3 loader parse err: %w", err)
-		return nil, err
+		return nil, fmt.Errorf("s3 loader parse err, key= %s: %w", obj.key, err)
 	}
 
 	if l.useObjectKeyAsID {
 		for _, doc := range docs {
-			doc.ID = key
+			doc.ID = obj.key
 		}
 	}
 
-	_ = callbacks.OnEnd(ctx, &document.LoaderCallbackOutput{
-		Source: src,
-		Docs:   docs,
-	})
-
 	return docs, nil
 }
 
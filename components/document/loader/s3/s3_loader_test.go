@@ -20,7 +20,9 @@ import (
 	"context"
 	"errors"
 	"io"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
@@ -60,6 +62,29 @@ func TestNewS3Loader(t *testing.T) {
 
 		assert.NoError(t, err)
 		assert.NotNil(t, l)
+
+		_, err = NewS3Loader(ctx, &LoaderConfig{
+			Region:          aws.String("region"),
+			IncludePatterns: []string{"[a-"},
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid glob pattern")
+
+		_, err = NewS3Loader(ctx, &LoaderConfig{
+			Region:  aws.String("region"),
+			MaxKeys: -1,
+		})
+		assert.Error(t, err)
+
+		l, err = NewS3Loader(ctx, &LoaderConfig{
+			Region:                aws.String("region"),
+			AWSAccessKey:          aws.String("ak"),
+			AWSSecretKey:          aws.String("sk"),
+			AssumeRoleARN:         aws.String("arn:aws:iam::123456789012:role/reader"),
+			AssumeRoleSessionName: aws.String("eino"),
+		})
+		assert.NoError(t, err)
+		assert.NotNil(t, l)
 	})
 }
 
@@ -95,12 +120,6 @@ func TestLoader_Load(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "incomplete")
 
-		_, err = s3Loader.Load(ctx, document.Source{
-			URI: "s3://bucket/prefix/",
-		})
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "does not support batch load with prefix")
-
 		mockey.PatchConvey("get object returns no such key", func() {
 			mockey.Mock((*s3.Client).GetObject).Return(nil, &types.NoSuchKey{}).Build()
 
@@ -138,5 +157,112 @@ func TestLoader_Load(t *testing.T) {
 		assert.Len(t, result, 1)
 		assert.Equal(t, "hello world!", result[0].Content)
 		assert.Equal(t, "key.txt", result[0].ID)
+		assert.Equal(t, "bucket", result[0].MetaData[MetaKeyBucket])
+		assert.Equal(t, "key.txt", result[0].MetaData[MetaKeyObjectKey])
+	})
+}
+
+func TestLoader_LoadPrefix(t *testing.T) {
+	mockey.PatchConvey("TestLoader_LoadPrefix", t, func() {
+		ctx := context.Background()
+		lastModified := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		pages := map[string]*s3.ListObjectsV2Output{
+			"": {
+				Contents: []types.Object{
+					{Key: aws.String("docs/"), ETag: aws.String(`"dir"`)},
+					{Key: aws.String("docs/a.md"), ETag: aws.String(`"etag-a"`), LastModified: &lastModified, Size: aws.Int64(1)},
+					{Key: aws.String("docs/b.txt"), ETag: aws.String(`"etag-b"`), LastModified: &lastModified, Size: aws.Int64(1)},
+				},
+				IsTruncated:           aws.Bool(true),
+				NextContinuationToken: aws.String("page2"),
+			},
+			"page2": {
+				Contents: []types.Object{
+					{Key: aws.String("docs/sub/c.md"), ETag: aws.String(`"etag-c"`), LastModified: &lastModified, Size: aws.Int64(1)},
+					{Key: aws.String("docs/sub/draft.md"), ETag: aws.String(`"etag-d"`), LastModified: &lastModified, Size: aws.Int64(1)},
+				},
+				IsTruncated: aws.Bool(false),
+			},
+		}
+
+		mockey.Mock((*s3.Client).ListObjectsV2).To(func(_ *s3.Client, _ context.Context, input *s3.ListObjectsV2Input, _ ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+			if aws.ToString(input.Prefix) != "docs/" {
+				return nil, errors.New("unexpected prefix")
+			}
+			return pages[aws.ToString(input.ContinuationToken)], nil
+		}).Build()
+
+		mockey.Mock((*s3.Client).GetObject).To(func(_ *s3.Client, _ context.Context, input *s3.GetObjectInput, _ ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+			if aws.ToString(input.Key) == "docs/sub/c.md" {
+				return &s3.GetObjectOutput{
+					Body: io.NopCloser(strings.NewReader("content of " + aws.ToString(input.Key))),
+					ETag: aws.String(`"etag-c-new"`),
+				}, nil
+			}
+			return &s3.GetObjectOutput{
+				Body: io.NopCloser(strings.NewReader("content of " + aws.ToString(input.Key))),
+			}, nil
+		}).Build()
+
+		mockey.PatchConvey("load all objects", func() {
+			l, err := NewS3Loader(ctx, &LoaderConfig{
+				Region:           aws.String("region"),
+				UseObjectKeyAsID: true,
+				Concurrency:      2,
+			})
+			assert.NoError(t, err)
+
+			docs, err := l.Load(ctx, document.Source{URI: "s3://bucket/docs/"})
+			assert.NoError(t, err)
+			assert.Len(t, docs, 4)
+			assert.Equal(t, "docs/a.md", docs[0].ID)
+			assert.Equal(t, "content of docs/a.md", docs[0].Content)
+			assert.Equal(t, "etag-a", docs[0].MetaData[MetaKeyETag])
+			assert.Equal(t, lastModified, docs[0].MetaData[MetaKeyLastModified])
+			assert.Equal(t, int64(1), docs[0].MetaData[MetaKeySize])
+			assert.Equal(t, "s3://bucket/docs/a.md", docs[0].MetaData[MetaKeySource])
+			assert.Equal(t, "docs/sub/c.md", docs[2].ID)
+			assert.Equal(t, "etag-c-new", docs[2].MetaData[MetaKeyETag])
+		})
+
+		mockey.PatchConvey("include and exclude patterns", func() {
+			l, err := NewS3Loader(ctx, &LoaderConfig{
+				Region:          aws.String("region"),
+				IncludePatterns: []string{"*.md"},
+				ExcludePatterns: []string{"draft*"},
+			})
+			assert.NoError(t, err)
+
+			docs, err := l.Load(ctx, document.Source{URI: "s3://bucket/docs/"})
+			assert.NoError(t, err)
+			assert.Len(t, docs, 2)
+			assert.Equal(t, "docs/a.md", docs[0].MetaData[MetaKeyObjectKey])
+			assert.Equal(t, "docs/sub/c.md", docs[1].MetaData[MetaKeyObjectKey])
+		})
+
+		mockey.PatchConvey("max keys", func() {
+			l, err := NewS3Loader(ctx, &LoaderConfig{
+				Region:  aws.String("region"),
+				MaxKeys: 3,
+			})
+			assert.NoError(t, err)
+
+			docs, err := l.Load(ctx, document.Source{URI: "s3://bucket/docs/"})
+			assert.NoError(t, err)
+			assert.Len(t, docs, 3)
+			assert.Equal(t, "docs/sub/c.md", docs[2].MetaData[MetaKeyObjectKey])
+		})
+
+		mockey.PatchConvey("list objects error", func() {
+			l, err := NewS3Loader(ctx, &LoaderConfig{
+				Region: aws.String("region"),
+			})
+			assert.NoError(t, err)
+
+			_, err = l.Load(ctx, document.Source{URI: "s3://bucket/other/"})
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "list objects")
+		})
 	})
 }
@@ -0,0 +1,621 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package url
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino-ext/components/document/parser/html"
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+	xhtml "golang.org/x/net/html"
+)
+
+const (
+	MetaKeyCrawlDepth    = "_crawl_depth"
+	MetaKeyCrawlReferrer = "_crawl_referrer"
+)
+
+const (
+	defaultUserAgent  = "eino-crawler"
+	defaultCrawlDelay = time.Second
+
+	// maxSitemapNesting limits the sitemap index levels followed.
+	maxSitemapNesting = 3
+)
+
+var _ document.Loader = (*Crawler)(nil)
+
+// CrawlerConfig is the config for url Crawler.
+type CrawlerConfig struct {
+	// optional, default: parser/html.
+	Parser parser.Parser
+
+	// optional, default: http.DefaultClient.
+	Client *http.Client
+
+	// UserAgent is sent with every request and selects the robots.txt group, default: eino-crawler.
+	UserAgent string
+
+	// MaxDepth is the max depth of the links followed from the seed pages, 0 means only the seed pages are loaded.
+	// The source page, or every page listed in the source sitemap, is a seed page.
+	MaxDepth int
+	// MaxPages limits the number of pages loaded, 0 means no limit.
+	MaxPages int
+	// AllowedHosts are the hosts links can be followed to besides the host of the source uri, optional.
+	AllowedHosts []string
+	// IncludePatterns are glob patterns (see path.Match) matched against the url path, e.g. /docs/*.
+	// Only the links matching at least one pattern are followed, empty means all links are followed.
+	IncludePatterns []string
+	// ExcludePatterns are glob patterns matched the same way as IncludePatterns.
+	// Links matching any of them are not followed, exclusion takes precedence over inclusion.
+	ExcludePatterns []string
+
+	// IgnoreRobotsTxt disables robots.txt checking.
+	IgnoreRobotsTxt bool
+	// Delay is the min interval between two requests to the same host, default: 1s.
+	// The Crawl-delay of robots.txt takes precedence when it is longer.
+	Delay time.Duration
+}
+
+// NewCrawler creates a new crawler, which loads a site from a seed page or sitemap by following the same-site links.
+func NewCrawler(ctx context.Context, conf *CrawlerConfig) (*Crawler, error) {
+	if conf == nil {
+		conf = &CrawlerConfig{}
+	}
+
+	if conf.MaxDepth < 0 {
+		return nil, errors.New("max depth must not be negative")
+	}
+	if conf.MaxPages < 0 {
+		return nil, errors.New("max pages must not be negative")
+	}
+	for _, pattern := range append(append([]string{}, conf.IncludePatterns...), conf.ExcludePatterns...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid glob pattern %q: %w", pattern, err)
+		}
+	}
+
+	if conf.Parser == nil {
+		p, err := html.NewParser(ctx, &html.Config{
+			Selector: &html.BodySelector,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create default HTML parser: %w", err)
+		}
+
+		conf.Parser = p
+	}
+	if conf.Client == nil {
+		conf.Client = http.DefaultClient
+	}
+	if conf.UserAgent == "" {
+		conf.UserAgent = defaultUserAgent
+	}
+	if conf.Delay == 0 {
+		conf.Delay = defaultCrawlDelay
+	}
+
+	return &Crawler{
+		conf: conf,
+	}, nil
+}
+
+// Crawler is a loader crawling the pages of a site.
+type Crawler struct {
+	conf *CrawlerConfig
+}
+
+// Load crawls the site from the source uri and returns all loaded pages, see Crawl.
+func (c *Crawler) Load(ctx context.Context, src document.Source, opts ...document.LoaderOption) (docs []*schema.Document, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, c.GetType(), components.ComponentOfLoader)
+	ctx = callbacks.OnStart(ctx, &document.LoaderCallbackInput{
+		Source: src,
+	})
+	defer func() {
+		if err != nil {
+			_ = callbacks.OnError(ctx, err)
+		}
+	}()
+
+	sr, err := c.Crawl(ctx, src, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer sr.Close()
+
+	for {
+		doc, err := sr.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+
+	_ = callbacks.OnEnd(ctx, &document.LoaderCallbackOutput{
+		Source: src,
+		Docs:   docs,
+	})
+
+	return docs, nil
+}
+
+// Crawl crawls the site from the source uri, and streams the documents as the pages are fetched.
+// The source uri is either a html page, or a sitemap (urlset or sitemap index) whose pages are all seed pages.
+// Only html pages are loaded, failing to fetch the source is returned as a stream error, while other pages failing
+// to fetch or parse are skipped. Closing the stream reader stops the crawling.
+func (c *Crawler) Crawl(ctx context.Context, src document.Source, opts ...document.LoaderOption) (*schema.StreamReader[*schema.Document], error) {
+	seed, err := url.Parse(src.URI)
+	if err != nil {
+		return nil, fmt.Errorf("invalid uri [%s]: %w", src.URI, err)
+	}
+	if (seed.Scheme != "http" && seed.Scheme != "https") || seed.Host == "" {
+		return nil, fmt.Errorf("invalid uri [%s]: only absolute http(s) uri is supported", src.URI)
+	}
+	seed.Fragment = ""
+
+	o := document.GetLoaderCommonOptions(&document.LoaderOptions{}, opts...)
+
+	hosts := map[string]bool{strings.ToLower(seed.Host): true}
+	for _, host := range c.conf.AllowedHosts {
+		hosts[strings.ToLower(host)] = true
+	}
+
+	sr, sw := schema.Pipe[*schema.Document](1)
+	cr := &crawl{
+		conf:        c.conf,
+		parserOpts:  o.ParserOptions,
+		sw:          sw,
+		hosts:       hosts,
+		visited:     make(map[string]bool),
+		robots:      make(map[string]*robots),
+		lastRequest: make(map[string]time.Time),
+	}
+
+	go func() {
+		defer func() {
+			if e := recover(); e != nil {
+				sw.Send(nil, fmt.Errorf("panic occurred when crawling [%s]: %v", src.URI, e))
+			}
+			sw.Close()
+		}()
+
+		if err := cr.run(ctx, seed); err != nil && !errors.Is(err, errStreamClosed) {
+			sw.Send(nil, err)
+		}
+	}()
+
+	return sr, nil
+}
+
+func (c *Crawler) GetType() string {
+	return "URLCrawler"
+}
+
+func (c *Crawler) IsCallbacksEnabled() bool {
+	return true
+}
+
+var errStreamClosed = errors.New("stream closed")
+
+// crawl is the state of a single crawling.
+type crawl struct {
+	conf       *CrawlerConfig
+	parserOpts []parser.Option
+	sw         *schema.StreamWriter[*schema.Document]
+
+	hosts       map[string]bool
+	visited     map[string]bool
+	robots      map[string]*robots
+	lastRequest map[string]time.Time
+	pages       int
+}
+
+type page struct {
+	url      *url.URL
+	depth    int
+	referrer string
+}
+
+type fetched struct {
+	url         *url.URL
+	contentType string
+	body        []byte
+}
+
+func (cr *crawl) run(ctx context.Context, seed *url.URL) error {
+	res, err := cr.fetch(ctx, seed)
+	if err != nil {
+		return fmt.Errorf("failed to load content from uri [%s]: %w", seed, err)
+	}
+
+	var queue []*page
+	if isSitemap(res) {
+		urls, err := cr.sitemapURLs(ctx, res, 0)
+		if err != nil {
+			return err
+		}
+		for _, u := range urls {
+			if cr.follow(u) {
+				queue = append(queue, &page{url: u})
+			}
+		}
+	} else {
+		cr.visited[seed.String()] = true
+		cr.visited[res.url.String()] = true
+		queue, err = cr.handle(ctx, &page{url: res.url}, res)
+		if err != nil {
+			return err
+		}
+	}
+
+	for len(queue) > 0 {
+		if cr.conf.MaxPages > 0 && cr.pages >= cr.conf.MaxPages {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		p := queue[0]
+		queue = queue[1:]
+
+		res, err := cr.fetch(ctx, p.url)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			continue
+		}
+		// the page may be redirected to a visited one.
+		if res.url.String() != p.url.String() {
+			if cr.visited[res.url.String()] || !cr.hosts[strings.ToLower(res.url.Host)] {
+				continue
+			}
+			cr.visited[res.url.String()] = true
+		}
+
+		links, err := cr.handle(ctx, &page{url: res.url, depth: p.depth, referrer: p.referrer}, res)
+		if err != nil {
+			return err
+		}
+		queue = append(queue, links...)
+	}
+
+	return nil
+}
+
+// handle emits the documents of the html page, and returns the links to follow.
+func (cr *crawl) handle(ctx context.Context, p *page, res *fetched) ([]*page, error) {
+	if !isHTML(res.contentType) {
+		return nil, nil
+	}
+
+	meta := map[string]any{MetaKeyCrawlDepth: p.depth}
+	if p.referrer != "" {
+		meta[MetaKeyCrawlReferrer] = p.referrer
+	}
+
+	docs, err := cr.conf.Parser.Parse(ctx, bytes.NewReader(res.body),
+		append([]parser.Option{parser.WithURI(res.url.String()), parser.WithExtraMeta(meta)}, cr.parserOpts...)...)
+	if err == nil {
+		cr.pages++
+		for _, doc := range docs {
+			if closed := cr.sw.Send(doc, nil); closed {
+				return nil, errStreamClosed
+			}
+		}
+	}
+
+	if p.depth >= cr.conf.MaxDepth {
+		return nil, nil
+	}
+
+	var links []*page
+	for _, u := range extractLinks(res.url, res.body) {
+		if cr.follow(u) {
+			links = append(links, &page{url: u, depth: p.depth + 1, referrer: res.url.String()})
+		}
+	}
+
+	return links, nil
+}
+
+// follow reports whether the url should be crawled, and marks it as visited if so.
+func (cr *crawl) follow(u *url.URL) bool {
+	if cr.visited[u.String()] || !cr.hosts[strings.ToLower(u.Host)] || !cr.match(u.Path) {
+		return false
+	}
+
+	cr.visited[u.String()] = true
+	return true
+}
+
+func (cr *crawl) match(p string) bool {
+	matchAny := func(patterns []string) bool {
+		for _, pattern := range patterns {
+			// patterns are validated in NewCrawler, so the error can be ignored.
+			if ok, _ := path.Match(pattern, p); ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	if matchAny(cr.conf.ExcludePatterns) {
+		return false
+	}
+
+	return len(cr.conf.IncludePatterns) == 0 || matchAny(cr.conf.IncludePatterns)
+}
+
+// fetch gets the url politely: robots.txt is respected and requests to the same host are throttled.
+func (cr *crawl) fetch(ctx context.Context, u *url.URL) (*fetched, error) {
+	delay := cr.conf.Delay
+	if !cr.conf.IgnoreRobotsTxt {
+		rb, err := cr.robotsOf(ctx, u)
+		if err != nil {
+			return nil, err
+		}
+		if !rb.allowed(u.EscapedPath() + querySuffix(u)) {
+			return nil, fmt.Errorf("disallowed by robots.txt")
+		}
+		if rb.crawlDelay > delay {
+			delay = rb.crawlDelay
+		}
+	}
+
+	resp, err := cr.get(ctx, u, delay)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	final := *resp.Request.URL
+	final.Fragment = ""
+
+	return &fetched{
+		url:         &final,
+		contentType: resp.Header.Get("Content-Type"),
+		body:        body,
+	}, nil
+}
+
+// robotsOf returns the robots.txt rules of the url host. Per RFC 9309, a missing robots.txt allows everything,
+// while an unreachable one disallows everything.
+func (cr *crawl) robotsOf(ctx context.Context, u *url.URL) (*robots, error) {
+	key := u.Scheme + "://" + u.Host
+	if rb, ok := cr.robots[key]; ok {
+		return rb, nil
+	}
+
+	rb := disallowAll
+	resp, err := cr.get(ctx, &url.URL{Scheme: u.Scheme, Host: u.Host, Path: "/robots.txt"}, cr.conf.Delay)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	} else {
+		switch {
+		case resp.StatusCode >= 200 && resp.StatusCode < 300:
+			rb = parseRobots(resp.Body, cr.conf.UserAgent)
+		case resp.StatusCode >= 400 && resp.StatusCode < 500:
+			rb = allowAll
+		}
+		_ = resp.Body.Close()
+	}
+
+	cr.robots[key] = rb
+	return rb, nil
+}
+
+func (cr *crawl) get(ctx context.Context, u *url.URL, delay time.Duration) (*http.Response, error) {
+	if last, ok := cr.lastRequest[u.Host]; ok && delay > 0 {
+		if wait := time.Until(last.Add(delay)); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+	defer func() {
+		cr.lastRequest[u.Host] = time.Now()
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", cr.conf.UserAgent)
+
+	return cr.conf.Client.Do(req)
+}
+
+type sitemap struct {
+	XMLName xml.Name
+	URLs    []struct {
+		Loc string `xml:"loc"`
+	} `xml:"url"`
+	Sitemaps []struct {
+		Loc string `xml:"loc"`
+	} `xml:"sitemap"`
+}
+
+func isSitemap(res *fetched) bool {
+	if strings.HasSuffix(res.url.Path, ".xml") || strings.HasSuffix(res.url.Path, ".xml.gz") {
+		return true
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(res.contentType)
+	return mediaType == "application/xml" || mediaType == "text/xml"
+}
+
+// sitemapURLs returns the page urls of the sitemap, the sitemaps of a sitemap index are fetched recursively.
+func (cr *crawl) sitemapURLs(ctx context.Context, res *fetched, nesting int) ([]*url.URL, error) {
+	body := res.body
+	if strings.HasSuffix(res.url.Path, ".gz") || bytes.HasPrefix(body, []byte{0x1f, 0x8b}) {
+		gr, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress sitemap [%s]: %w", res.url, err)
+		}
+		if body, err = io.ReadAll(gr); err != nil {
+			return nil, fmt.Errorf("failed to decompress sitemap [%s]: %w", res.url, err)
+		}
+	}
+
+	var sm sitemap
+	if err := xml.Unmarshal(body, &sm); err != nil {
+		return nil, fmt.Errorf("failed to parse sitemap [%s]: %w", res.url, err)
+	}
+
+	var urls []*url.URL
+	for _, entry := range sm.URLs {
+		if u, err := res.url.Parse(strings.TrimSpace(entry.Loc)); err == nil {
+			u.Fragment = ""
+			urls = append(urls, u)
+		}
+	}
+
+	if nesting >= maxSitemapNesting {
+		return urls, nil
+	}
+
+	for _, entry := range sm.Sitemaps {
+		u, err := res.url.Parse(strings.TrimSpace(entry.Loc))
+		if err != nil || cr.visited[u.String()] {
+			continue
+		}
+		cr.visited[u.String()] = true
+
+		child, err := cr.fetch(ctx, u)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			continue
+		}
+
+		childURLs, err := cr.sitemapURLs(ctx, child, nesting+1)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			continue
+		}
+		urls = append(urls, childURLs...)
+	}
+
+	return urls, nil
+}
+
+// extractLinks returns the absolute http(s) urls of the anchors in the html page, fragments are removed.
+func extractLinks(base *url.URL, body []byte) []*url.URL {
+	var links []*url.URL
+
+	z := xhtml.NewTokenizer(bytes.NewReader(body))
+	for {
+		tt := z.Next()
+		if tt == xhtml.ErrorToken {
+			return links
+		}
+		if tt != xhtml.StartTagToken && tt != xhtml.SelfClosingTagToken {
+			continue
+		}
+
+		name, hasAttr := z.TagName()
+		if !hasAttr || (string(name) != "a" && string(name) != "base") {
+			continue
+		}
+
+		var href string
+		for {
+			key, val, more := z.TagAttr()
+			if string(key) == "href" {
+				href = strings.TrimSpace(string(val))
+			}
+			if !more {
+				break
+			}
+		}
+		if href == "" {
+			continue
+		}
+
+		u, err := base.Parse(href)
+		if err != nil {
+			continue
+		}
+
+		if string(name) == "base" {
+			base = u
+			continue
+		}
+
+		if u.Scheme != "http" && u.Scheme != "https" {
+			continue
+		}
+		u.Fragment = ""
+		u.RawFragment = ""
+		links = append(links, u)
+	}
+}
+
+func isHTML(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	return mediaType == "text/html" || mediaType == "application/xhtml+xml"
+}
+
+func querySuffix(u *url.URL) string {
+	if u.RawQuery == "" {
+		return ""
+	}
+	return "?" + u.RawQuery
+}
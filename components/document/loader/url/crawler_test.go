@@ -0,0 +1,270 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package url
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudwego/eino-ext/components/document/parser/html"
+	"github.com/cloudwego/eino/components/document"
+)
+
+func newSite(t *testing.T, robotsTxt string) (*httptest.Server, func() []string) {
+	var (
+		mu       sync.Mutex
+		requests []string
+	)
+
+	pages := map[string]string{
+		"/":               `<html><head><title>home</title></head><body>home <a href="/a">a</a> <a href="b#frag">b</a> <a href="https://other.example.com/x">x</a> <a href="mailto:a@b.c">mail</a></body></html>`,
+		"/a":              `<html><head><title>a</title></head><body>a <a href="/a/deep">deep</a> <a href="/">home</a></body></html>`,
+		"/b":              `<html><head><title>b</title></head><body>b <a href="/private/secret">secret</a></body></html>`,
+		"/a/deep":         `<html><head><title>deep</title></head><body>deep</body></html>`,
+		"/private/secret": `<html><head><title>secret</title></head><body>secret</body></html>`,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.URL.Path)
+		mu.Unlock()
+
+		assert.Equal(t, "test-bot/1.0", r.Header.Get("User-Agent"))
+
+		switch r.URL.Path {
+		case "/robots.txt":
+			if robotsTxt == "" {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = w.Write([]byte(robotsTxt))
+		case "/sitemap.xml":
+			w.Header().Set("Content-Type", "application/xml")
+			_, _ = fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>
+<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <sitemap><loc>http://%s/pages.xml.gz</loc></sitemap>
+</sitemapindex>`, r.Host)
+		case "/pages.xml.gz":
+			var buf bytes.Buffer
+			gw := gzip.NewWriter(&buf)
+			_, _ = fmt.Fprintf(gw, `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url><loc>http://%s/a</loc></url>
+  <url><loc>http://%s/b</loc></url>
+  <url><loc>http://other.example.com/c</loc></url>
+</urlset>`, r.Host, r.Host)
+			_ = gw.Close()
+			_, _ = w.Write(buf.Bytes())
+		case "/old":
+			http.Redirect(w, r, "/a", http.StatusMovedPermanently)
+		default:
+			content, ok := pages[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte(content))
+		}
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return srv, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string{}, requests...)
+	}
+}
+
+func docTitles(docs []map[string]any) []string {
+	var res []string
+	for _, meta := range docs {
+		res = append(res, fmt.Sprint(meta[html.MetaKeyTitle]))
+	}
+	return res
+}
+
+func TestCrawler(t *testing.T) {
+	ctx := context.Background()
+
+	load := func(t *testing.T, conf *CrawlerConfig, uri string) []map[string]any {
+		conf.UserAgent = "test-bot/1.0"
+		conf.Delay = time.Millisecond
+		crawler, err := NewCrawler(ctx, conf)
+		require.NoError(t, err)
+
+		docs, err := crawler.Load(ctx, document.Source{URI: uri})
+		require.NoError(t, err)
+
+		metas := make([]map[string]any, 0, len(docs))
+		for _, doc := range docs {
+			metas = append(metas, doc.MetaData)
+		}
+		return metas
+	}
+
+	t.Run("depth", func(t *testing.T) {
+		srv, _ := newSite(t, "")
+
+		metas := load(t, &CrawlerConfig{}, srv.URL+"/")
+		assert.Equal(t, []string{"home"}, docTitles(metas))
+
+		metas = load(t, &CrawlerConfig{MaxDepth: 1}, srv.URL+"/")
+		assert.Equal(t, []string{"home", "a", "b"}, docTitles(metas))
+		assert.Equal(t, 1, metas[2][MetaKeyCrawlDepth])
+		assert.Equal(t, srv.URL+"/", metas[2][MetaKeyCrawlReferrer])
+		assert.Equal(t, srv.URL+"/b", metas[2][html.MetaKeySource])
+
+		metas = load(t, &CrawlerConfig{MaxDepth: 2}, srv.URL+"/")
+		assert.Equal(t, []string{"home", "a", "b", "deep", "secret"}, docTitles(metas))
+
+		metas = load(t, &CrawlerConfig{MaxDepth: 2, MaxPages: 2}, srv.URL+"/")
+		assert.Equal(t, []string{"home", "a"}, docTitles(metas))
+
+		metas = load(t, &CrawlerConfig{MaxDepth: 2, ExcludePatterns: []string{"/private/*"}}, srv.URL+"/")
+		assert.Equal(t, []string{"home", "a", "b", "deep"}, docTitles(metas))
+
+		metas = load(t, &CrawlerConfig{MaxDepth: 1}, srv.URL+"/old")
+		assert.Equal(t, []string{"a", "deep", "home"}, docTitles(metas))
+	})
+
+	t.Run("robots.txt", func(t *testing.T) {
+		srv, requests := newSite(t, `
+User-agent: *
+Disallow: /
+
+User-agent: test-bot
+Disallow: /private/
+Disallow: /a$
+`)
+
+		metas := load(t, &CrawlerConfig{MaxDepth: 2}, srv.URL+"/")
+		assert.Equal(t, []string{"home", "b"}, docTitles(metas))
+		assert.NotContains(t, requests(), "/private/secret")
+		assert.Equal(t, 1, strings.Count(strings.Join(requests(), " "), "/robots.txt"))
+
+		metas = load(t, &CrawlerConfig{MaxDepth: 2, IgnoreRobotsTxt: true}, srv.URL+"/")
+		assert.Len(t, metas, 5)
+	})
+
+	t.Run("sitemap", func(t *testing.T) {
+		srv, _ := newSite(t, "")
+
+		metas := load(t, &CrawlerConfig{}, srv.URL+"/sitemap.xml")
+		assert.Equal(t, []string{"a", "b"}, docTitles(metas))
+		assert.Equal(t, 0, metas[1][MetaKeyCrawlDepth])
+
+		metas = load(t, &CrawlerConfig{MaxDepth: 1}, srv.URL+"/sitemap.xml")
+		got := docTitles(metas)
+		sort.Strings(got)
+		assert.Equal(t, []string{"a", "b", "deep", "home", "secret"}, got)
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		srv, requests := newSite(t, "")
+
+		crawler, err := NewCrawler(ctx, &CrawlerConfig{MaxDepth: 2, UserAgent: "test-bot/1.0", Delay: time.Millisecond})
+		require.NoError(t, err)
+
+		sr, err := crawler.Crawl(ctx, document.Source{URI: srv.URL + "/"})
+		require.NoError(t, err)
+
+		doc, err := sr.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, "home", doc.MetaData[html.MetaKeyTitle])
+		sr.Close()
+
+		time.Sleep(50 * time.Millisecond)
+		assert.Less(t, len(requests()), 6)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		srv, _ := newSite(t, "")
+
+		_, err := NewCrawler(ctx, &CrawlerConfig{MaxDepth: -1})
+		assert.Error(t, err)
+		_, err = NewCrawler(ctx, &CrawlerConfig{IncludePatterns: []string{"["}})
+		assert.Error(t, err)
+
+		crawler, err := NewCrawler(ctx, &CrawlerConfig{UserAgent: "test-bot/1.0", Delay: time.Millisecond})
+		require.NoError(t, err)
+
+		_, err = crawler.Load(ctx, document.Source{URI: "file:///etc/hosts"})
+		assert.Error(t, err)
+
+		_, err = crawler.Load(ctx, document.Source{URI: srv.URL + "/missing"})
+		assert.ErrorContains(t, err, "404")
+	})
+}
+
+func TestParseRobots(t *testing.T) {
+	txt := `
+# comment
+User-agent: other
+Disallow: /
+
+User-agent: Test-Bot
+User-agent: another
+Allow: /docs/public
+Disallow: /docs
+Disallow: /*.pdf$
+Disallow:
+Crawl-delay: 2.5
+
+User-agent: *
+Disallow: /tmp
+`
+	rb := parseRobots(strings.NewReader(txt), "test-bot/1.0 (+https://example.com)")
+	assert.Equal(t, 2500*time.Millisecond, rb.crawlDelay)
+
+	tests := map[string]bool{
+		"/":                 true,
+		"/tmp":              true,
+		"/docs":             false,
+		"/docs/a":           false,
+		"/docs/public/a":    true,
+		"/files/a.pdf":      false,
+		"/files/a.pdf?x=1":  true,
+		"/files/a.pdfs":     true,
+		"/other/docs/a.txt": true,
+	}
+	for p, allowed := range tests {
+		assert.Equal(t, allowed, rb.allowed(p), p)
+	}
+
+	rb = parseRobots(strings.NewReader(txt), "unknown")
+	assert.False(t, rb.allowed("/tmp/a"))
+	assert.True(t, rb.allowed("/docs"))
+
+	rb = parseRobots(io.LimitReader(strings.NewReader(""), 0), "unknown")
+	assert.True(t, rb.allowed("/"))
+}
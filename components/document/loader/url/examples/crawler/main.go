@@ -0,0 +1,64 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/cloudwego/eino/components/document"
+
+	"github.com/cloudwego/eino-ext/components/document/loader/url"
+)
+
+func main() {
+	ctx := context.Background()
+
+	crawler, err := url.NewCrawler(ctx, &url.CrawlerConfig{
+		UserAgent:       "my-bot/1.0 (+https://example.com/bot)",
+		MaxDepth:        2,
+		MaxPages:        50,
+		IncludePatterns: []string{"/docs/*", "/docs/*/*"},
+		Delay:           500 * time.Millisecond,
+	})
+	if err != nil {
+		log.Fatalf("NewCrawler failed, err=%v", err)
+	}
+
+	// the source can be a seed page or a sitemap, documents are streamed as pages are fetched.
+	sr, err := crawler.Crawl(ctx, document.Source{URI: "https://example.com/sitemap.xml"})
+	if err != nil {
+		log.Fatalf("Crawl failed, err=%v", err)
+	}
+	defer sr.Close()
+
+	for {
+		doc, err := sr.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			log.Fatalf("Recv failed, err=%v", err)
+		}
+
+		fmt.Printf("depth=%v source=%v len=%d\n", doc.MetaData[url.MetaKeyCrawlDepth], doc.MetaData["_source"], len(doc.Content))
+	}
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/cloudwego/eino/components/document"
+
+	"github.com/cloudwego/eino-ext/components/document/loader/url"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// requires chromium installed locally, or set RemoteURL to a running browser, e.g. ws://127.0.0.1:9222
+	loader, err := url.NewLoader(ctx, &url.LoaderConfig{
+		Render: &url.RenderConfig{
+			WaitSelector: "main article",
+			WaitTime:     500 * time.Millisecond,
+			Timeout:      20 * time.Second,
+		},
+	})
+	if err != nil {
+		log.Fatalf("NewLoader failed, err=%v", err)
+	}
+
+	docs, err := loader.Load(ctx, document.Source{
+		URI: "https://example.com/spa/page",
+	})
+	if err != nil {
+		log.Fatalf("Load failed, err=%v", err)
+	}
+
+	for _, doc := range docs {
+		fmt.Printf("%+v\n", doc)
+	}
+}
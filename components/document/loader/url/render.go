@@ -0,0 +1,113 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package url
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+const defaultRenderTimeout = 30 * time.Second
+
+// RenderConfig is the config to render pages with headless chromium.
+type RenderConfig struct {
+	// WaitSelector is the css selector of the element waited to be visible before the page content is extracted,
+	// e.g. #app .article. optional, default to waiting for the body element to be ready.
+	WaitSelector string
+	// WaitTime is the extra time waited after the page is ready, for the content rendered asynchronously. optional.
+	WaitTime time.Duration
+	// Timeout is the max time to render a page, including launching the browser. optional, default 30s.
+	Timeout time.Duration
+
+	// RemoteURL is the devtools websocket url of a running browser, e.g. ws://127.0.0.1:9222, optional.
+	// If not set, a local headless chromium is launched for every load, which must be installed.
+	RemoteURL string
+	// AllocatorOptions are appended to chromedp.DefaultExecAllocatorOptions when launching the local chromium,
+	// e.g. chromedp.ExecPath or chromedp.ProxyServer. optional.
+	AllocatorOptions []chromedp.ExecAllocatorOption
+}
+
+// render navigates to the request url with headless chromium, and returns the rendered html.
+// The headers of the request, e.g. Authorization and Cookie, are sent with every request of the page.
+func render(ctx context.Context, conf *RenderConfig, req *http.Request) (io.ReadCloser, error) {
+	if req.Method != "" && req.Method != http.MethodGet {
+		return nil, fmt.Errorf("render only supports GET request, method: %s", req.Method)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, conf.Timeout)
+	defer cancel()
+
+	var (
+		allocCtx    context.Context
+		allocCancel context.CancelFunc
+	)
+	if conf.RemoteURL != "" {
+		allocCtx, allocCancel = chromedp.NewRemoteAllocator(ctx, conf.RemoteURL)
+	} else {
+		opts := append(chromedp.DefaultExecAllocatorOptions[:], conf.AllocatorOptions...)
+		if ua := req.UserAgent(); ua != "" {
+			opts = append(opts, chromedp.UserAgent(ua))
+		}
+		allocCtx, allocCancel = chromedp.NewExecAllocator(ctx, opts...)
+	}
+	defer allocCancel()
+
+	taskCtx, taskCancel := chromedp.NewContext(allocCtx)
+	defer taskCancel()
+
+	headers := network.Headers{}
+	for k, v := range req.Header {
+		if k == "User-Agent" {
+			continue
+		}
+		headers[k] = strings.Join(v, ", ")
+	}
+
+	var actions []chromedp.Action
+	if len(headers) > 0 {
+		actions = append(actions, network.Enable(), network.SetExtraHTTPHeaders(headers))
+	}
+	actions = append(actions, chromedp.Navigate(req.URL.String()))
+	if conf.WaitSelector != "" {
+		actions = append(actions, chromedp.WaitVisible(conf.WaitSelector, chromedp.ByQuery))
+	} else {
+		actions = append(actions, chromedp.WaitReady("body", chromedp.ByQuery))
+	}
+	if conf.WaitTime > 0 {
+		actions = append(actions, chromedp.Sleep(conf.WaitTime))
+	}
+
+	var html string
+	actions = append(actions, chromedp.OuterHTML("html", &html, chromedp.ByQuery))
+
+	if err := chromedp.Run(taskCtx, actions...); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("render page timeout after %s: %w", conf.Timeout, err)
+		}
+		return nil, fmt.Errorf("render page err: %w", err)
+	}
+
+	return io.NopCloser(strings.NewReader(html)), nil
+}
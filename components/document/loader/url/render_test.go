@@ -0,0 +1,114 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package url
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/chromedp/chromedp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudwego/eino-ext/components/document/parser/html"
+	"github.com/cloudwego/eino/components/document"
+)
+
+func findChrome() string {
+	for _, name := range []string{"headless-shell", "chromium", "chromium-browser", "google-chrome", "google-chrome-stable"} {
+		if p, err := exec.LookPath(name); err == nil {
+			return p
+		}
+	}
+	return ""
+}
+
+func TestRender(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("default timeout", func(t *testing.T) {
+		loader, err := NewLoader(ctx, &LoaderConfig{Render: &RenderConfig{}})
+		require.NoError(t, err)
+		assert.Equal(t, defaultRenderTimeout, loader.conf.Render.Timeout)
+	})
+
+	t.Run("only get", func(t *testing.T) {
+		loader, err := NewLoader(ctx, &LoaderConfig{
+			Render: &RenderConfig{},
+			RequestBuilder: func(ctx context.Context, src document.Source, opts ...document.LoaderOption) (*http.Request, error) {
+				return http.NewRequestWithContext(ctx, http.MethodPost, src.URI, nil)
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = loader.Load(ctx, document.Source{URI: "http://127.0.0.1/"})
+		assert.ErrorContains(t, err, "only supports GET")
+	})
+
+	chrome := findChrome()
+	if chrome == "" {
+		t.Skip("chromium not found, skip rendering")
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`<html><head><title>spa</title></head><body><div id="app"></div>
+<script>setTimeout(function () {
+  document.title = "rendered";
+  document.getElementById("app").innerHTML = '<p class="content">client side content</p>';
+}, 100);</script></body></html>`))
+	}))
+	defer srv.Close()
+
+	newLoader := func(conf *RenderConfig) *Loader {
+		conf.AllocatorOptions = append(conf.AllocatorOptions, chromedp.ExecPath(chrome), chromedp.NoSandbox)
+		loader, err := NewLoader(ctx, &LoaderConfig{
+			Render: conf,
+			RequestBuilder: func(ctx context.Context, src document.Source, opts ...document.LoaderOption) (*http.Request, error) {
+				req, err := http.NewRequestWithContext(ctx, http.MethodGet, src.URI, nil)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set("Authorization", "Bearer token")
+				return req, nil
+			},
+		})
+		require.NoError(t, err)
+		return loader
+	}
+
+	t.Run("wait selector", func(t *testing.T) {
+		docs, err := newLoader(&RenderConfig{WaitSelector: "#app .content"}).Load(ctx, document.Source{URI: srv.URL + "/"})
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Equal(t, "client side content", docs[0].Content)
+		assert.Equal(t, "rendered", docs[0].MetaData[html.MetaKeyTitle])
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		_, err := newLoader(&RenderConfig{WaitSelector: "#missing", Timeout: 3 * time.Second}).Load(ctx, document.Source{URI: srv.URL + "/"})
+		assert.ErrorContains(t, err, "timeout")
+	})
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package url
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// robots is the parsed robots.txt (see RFC 9309) for a user agent.
+type robots struct {
+	rules      []robotsRule
+	crawlDelay time.Duration
+}
+
+type robotsRule struct {
+	allow   bool
+	pattern string
+	re      *regexp.Regexp
+}
+
+var (
+	allowAll    = &robots{}
+	disallowAll = &robots{rules: []robotsRule{{allow: false, pattern: "/", re: regexp.MustCompile("^/")}}}
+)
+
+// parseRobots parses the robots.txt and keeps the rules of the group matching the user agent,
+// or the rules of the "*" group if no group matches.
+func parseRobots(r io.Reader, userAgent string) *robots {
+	token := strings.ToLower(userAgent)
+	if i := strings.IndexAny(token, "/ "); i >= 0 {
+		token = token[:i]
+	}
+
+	var (
+		matched, wildcard robots
+		hasMatched        bool
+		// agents of the current group, a group starts with one or more user-agent lines.
+		agents     []string
+		inRules    bool
+		scanner    = bufio.NewScanner(r)
+		addToGroup = func(apply func(rb *robots)) {
+			for _, agent := range agents {
+				switch agent {
+				case token:
+					hasMatched = true
+					apply(&matched)
+				case "*":
+					apply(&wildcard)
+				}
+			}
+		}
+	)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if inRules {
+				agents = nil
+				inRules = false
+			}
+			agents = append(agents, strings.ToLower(value))
+		case "allow", "disallow":
+			inRules = true
+			// an empty disallow allows everything, which is the default.
+			if value == "" {
+				continue
+			}
+			rule := robotsRule{allow: key == "allow", pattern: value, re: compileRobotsPattern(value)}
+			addToGroup(func(rb *robots) { rb.rules = append(rb.rules, rule) })
+		case "crawl-delay":
+			inRules = true
+			seconds, err := strconv.ParseFloat(value, 64)
+			if err != nil || seconds < 0 {
+				continue
+			}
+			delay := time.Duration(seconds * float64(time.Second))
+			addToGroup(func(rb *robots) { rb.crawlDelay = delay })
+		}
+	}
+
+	if hasMatched {
+		return &matched
+	}
+
+	return &wildcard
+}
+
+// compileRobotsPattern converts the robots.txt path pattern to a regexp, "*" matches any sequence and a trailing "$" anchors the end.
+func compileRobotsPattern(pattern string) *regexp.Regexp {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+
+	expr := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*")
+	if anchored {
+		expr += "$"
+	}
+
+	return regexp.MustCompile(expr)
+}
+
+// allowed reports whether the path (with query) can be crawled, the longest matching rule wins and allow wins ties.
+func (r *robots) allowed(p string) bool {
+	var (
+		allowed = true
+		longest = -1
+	)
+
+	for _, rule := range r.rules {
+		if !rule.re.MatchString(p) {
+			continue
+		}
+
+		if len(rule.pattern) > longest || (len(rule.pattern) == longest && rule.allow) {
+			longest = len(rule.pattern)
+			allowed = rule.allow
+		}
+	}
+
+	return allowed
+}
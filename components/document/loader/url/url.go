@@ -43,6 +43,10 @@ type LoaderConfig struct {
 
 	// optional, default GET uri.
 	RequestBuilder func(ctx context.Context, source document.Source, opts ...document.LoaderOption) (*http.Request, error)
+
+	// optional, renders the page with headless chromium before parsing if set,
+	// which is required by the client-side rendered pages. Client is not used when rendering.
+	Render *RenderConfig
 }
 
 func defaultRequestBuilder(ctx context.Context, source document.Source, opts ...document.LoaderOption) (*http.Request, error) {
@@ -71,6 +75,9 @@ func NewLoader(ctx context.Context, conf *LoaderConfig) (*Loader, error) {
 	if conf.RequestBuilder == nil {
 		conf.RequestBuilder = defaultRequestBuilder
 	}
+	if conf.Render != nil && conf.Render.Timeout == 0 {
+		conf.Render.Timeout = defaultRenderTimeout
+	}
 
 	return &Loader{
 		conf: conf,
@@ -125,6 +132,10 @@ func (l *Loader) load(ctx context.Context, src document.Source) (io.ReadCloser,
 		return nil, err
 	}
 
+	if l.conf.Render != nil {
+		return render(ctx, l.conf.Render, req)
+	}
+
 	resp, err := l.conf.Client.Do(req)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,212 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package audio provides a parser transcribing audio and video files with a pluggable speech recognition backend.
+package audio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	MetaKeyStart        = "_audio_start"
+	MetaKeyEnd          = "_audio_end"
+	MetaKeyDuration     = "_audio_duration"
+	MetaKeyLanguage     = "_audio_language"
+	MetaKeySpeaker      = "_audio_speaker"
+	MetaKeySegmentIndex = "_segment_index"
+	MetaKeySource       = "_source"
+)
+
+// Transcriber is the speech recognition backend.
+type Transcriber interface {
+	// Transcribe transcribes the audio, whose file name gives the format to the backends needing it.
+	Transcribe(ctx context.Context, audio []byte, fileName string) (*Transcript, error)
+}
+
+// Transcript is the text of the audio.
+type Transcript struct {
+	Text string
+	// Language is the detected or the configured language of the audio, if known.
+	Language string
+	// Duration is the duration of the audio, if known.
+	Duration time.Duration
+	// Segments are the timestamped segments of the transcript, in time order.
+	Segments []Segment
+}
+
+// Segment is a timestamped part of the transcript, usually a sentence.
+type Segment struct {
+	Start time.Duration
+	End   time.Duration
+	Text  string
+	// Speaker is the speaker of the segment, for the backends with speaker diarization.
+	Speaker string
+}
+
+// Config is the configuration for audio parser.
+type Config struct {
+	// Transcriber is the speech recognition backend, e.g. NewWhisperTranscriber, NewWhisperCppTranscriber
+	// or NewVolcengineTranscriber. Required.
+	Transcriber Transcriber
+	// GroupDuration merges the consecutive segments into documents lasting up to this duration,
+	// e.g. one minute, as the segments of a sentence are usually too short to be retrieved on their own.
+	// A segment of another speaker always starts a new document.
+	// Optional. Default 0, one document per segment.
+	GroupDuration time.Duration
+}
+
+// AudioParser reads from io.Reader and transcribes the audio or video file, one document per segment.
+type AudioParser struct {
+	conf *Config
+}
+
+// NewAudioParser creates a new audio parser.
+func NewAudioParser(ctx context.Context, config *Config) (*AudioParser, error) {
+	if config == nil {
+		return nil, errors.New("new audio parser, config is nil")
+	}
+	if config.Transcriber == nil {
+		return nil, errors.New("new audio parser, transcriber is required")
+	}
+
+	return &AudioParser{conf: config}, nil
+}
+
+// Parse transcribes the audio or video file from io.Reader.
+// The file name given to the transcriber is the base of the uri, or guessed from the content.
+func (ap *AudioParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	commonOpts := parser.GetCommonOptions(nil, opts...)
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("audio parser read all from reader failed: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, errors.New("audio parser, audio is empty")
+	}
+
+	transcript, err := ap.conf.Transcriber.Transcribe(ctx, data, fileName(commonOpts.URI, data))
+	if err != nil {
+		return nil, fmt.Errorf("transcribe audio failed: %w", err)
+	}
+
+	segments := transcript.Segments
+	if len(segments) == 0 && strings.TrimSpace(transcript.Text) != "" {
+		segments = []Segment{{End: transcript.Duration, Text: transcript.Text}}
+	}
+
+	docs := make([]*schema.Document, 0, len(segments))
+	for _, group := range groupSegments(segments, ap.conf.GroupDuration) {
+		texts := make([]string, 0, len(group))
+		for _, s := range group {
+			if t := strings.TrimSpace(s.Text); t != "" {
+				texts = append(texts, t)
+			}
+		}
+		if len(texts) == 0 {
+			continue
+		}
+
+		meta := make(map[string]any, len(commonOpts.ExtraMeta)+7)
+		if commonOpts.URI != "" {
+			meta[MetaKeySource] = commonOpts.URI
+		}
+		for k, v := range commonOpts.ExtraMeta {
+			meta[k] = v
+		}
+		meta[MetaKeyStart] = group[0].Start.Seconds()
+		meta[MetaKeyEnd] = group[len(group)-1].End.Seconds()
+		meta[MetaKeySegmentIndex] = len(docs)
+		if transcript.Duration > 0 {
+			meta[MetaKeyDuration] = transcript.Duration.Seconds()
+		}
+		if transcript.Language != "" {
+			meta[MetaKeyLanguage] = transcript.Language
+		}
+		if group[0].Speaker != "" {
+			meta[MetaKeySpeaker] = group[0].Speaker
+		}
+
+		docs = append(docs, &schema.Document{
+			Content:  strings.Join(texts, " "),
+			MetaData: meta,
+		})
+	}
+
+	return docs, nil
+}
+
+// groupSegments merges the consecutive segments of the same speaker lasting up to the duration.
+func groupSegments(segments []Segment, duration time.Duration) [][]Segment {
+	var groups [][]Segment
+	for _, s := range segments {
+		if n := len(groups); n > 0 && duration > 0 {
+			last := groups[n-1]
+			if last[0].Speaker == s.Speaker && s.End-last[0].Start <= duration {
+				groups[n-1] = append(last, s)
+				continue
+			}
+		}
+		groups = append(groups, []Segment{s})
+	}
+	return groups
+}
+
+// fileName returns the base of the uri, or a name with the extension of the detected content type,
+// which the backends use to tell the format of the audio.
+func fileName(uri string, data []byte) string {
+	if uri != "" {
+		if u := strings.SplitN(uri, "?", 2)[0]; path.Ext(u) != "" {
+			return path.Base(strings.ReplaceAll(u, "\\", "/"))
+		}
+	}
+
+	ext := ".mp3"
+	switch {
+	case len(data) >= 4 && string(data[:4]) == "fLaC":
+		ext = ".flac"
+	case len(data) >= 12 && string(data[4:8]) == "ftyp":
+		if string(data[8:11]) == "M4A" {
+			ext = ".m4a"
+		} else {
+			ext = ".mp4"
+		}
+	default:
+		switch http.DetectContentType(data) {
+		case "audio/wave":
+			ext = ".wav"
+		case "application/ogg", "audio/ogg":
+			ext = ".ogg"
+		case "video/webm":
+			ext = ".webm"
+		case "video/avi":
+			ext = ".avi"
+		}
+	}
+
+	return "audio" + ext
+}
@@ -0,0 +1,159 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package audio
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockTranscriber struct {
+	fileName   string
+	transcript *Transcript
+	err        error
+}
+
+func (m *mockTranscriber) Transcribe(ctx context.Context, audio []byte, fileName string) (*Transcript, error) {
+	m.fileName = fileName
+	return m.transcript, m.err
+}
+
+var testTranscript = &Transcript{
+	Text:     "Good morning everyone. Let's start with the roadmap. Thanks, I have two updates.",
+	Language: "english",
+	Duration: 12 * time.Second,
+	Segments: []Segment{
+		{Start: 0, End: 2500 * time.Millisecond, Text: " Good morning everyone.", Speaker: "1"},
+		{Start: 2500 * time.Millisecond, End: 5 * time.Second, Text: " Let's start with the roadmap.", Speaker: "1"},
+		{Start: 5 * time.Second, End: 5 * time.Second, Text: " ", Speaker: "1"},
+		{Start: 6 * time.Second, End: 12 * time.Second, Text: " Thanks, I have two updates.", Speaker: "2"},
+	},
+}
+
+func TestNewAudioParser(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewAudioParser(ctx, nil)
+	assert.EqualError(t, err, "new audio parser, config is nil")
+
+	_, err = NewAudioParser(ctx, &Config{})
+	assert.EqualError(t, err, "new audio parser, transcriber is required")
+}
+
+func TestAudioParser_Parse(t *testing.T) {
+	ctx := context.Background()
+	data, err := os.ReadFile("./examples/testdata/test.wav")
+	assert.NoError(t, err)
+
+	t.Run("segments", func(t *testing.T) {
+		tr := &mockTranscriber{transcript: testTranscript}
+		p, err := NewAudioParser(ctx, &Config{Transcriber: tr})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, bytes.NewReader(data),
+			parser.WithURI("https://example.com/meetings/weekly.mp3?token=abc"), parser.WithExtraMeta(map[string]any{"test": "test"}))
+		assert.NoError(t, err)
+		assert.Equal(t, "weekly.mp3", tr.fileName)
+		assert.Equal(t, 3, len(docs))
+		assert.Equal(t, "Good morning everyone.", docs[0].Content)
+		assert.Equal(t, map[string]any{
+			"test":              "test",
+			MetaKeySource:       "https://example.com/meetings/weekly.mp3?token=abc",
+			MetaKeyStart:        0.0,
+			MetaKeyEnd:          2.5,
+			MetaKeyDuration:     12.0,
+			MetaKeyLanguage:     "english",
+			MetaKeySpeaker:      "1",
+			MetaKeySegmentIndex: 0,
+		}, docs[0].MetaData)
+		assert.Equal(t, "Let's start with the roadmap.", docs[1].Content)
+		assert.Equal(t, 1, docs[1].MetaData[MetaKeySegmentIndex])
+		assert.Equal(t, "Thanks, I have two updates.", docs[2].Content)
+		assert.Equal(t, 6.0, docs[2].MetaData[MetaKeyStart])
+		assert.Equal(t, "2", docs[2].MetaData[MetaKeySpeaker])
+		assert.Equal(t, 2, docs[2].MetaData[MetaKeySegmentIndex])
+	})
+
+	t.Run("group duration", func(t *testing.T) {
+		tr := &mockTranscriber{transcript: testTranscript}
+		p, err := NewAudioParser(ctx, &Config{Transcriber: tr, GroupDuration: time.Minute})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, bytes.NewReader(data))
+		assert.NoError(t, err)
+		assert.Equal(t, "audio.wav", tr.fileName)
+		assert.Equal(t, 2, len(docs))
+		assert.Equal(t, "Good morning everyone. Let's start with the roadmap.", docs[0].Content)
+		assert.Equal(t, 0.0, docs[0].MetaData[MetaKeyStart])
+		assert.Equal(t, 5.0, docs[0].MetaData[MetaKeyEnd])
+		assert.Equal(t, "Thanks, I have two updates.", docs[1].Content)
+		assert.NotContains(t, docs[1].MetaData, MetaKeySource)
+
+		p, err = NewAudioParser(ctx, &Config{Transcriber: tr, GroupDuration: 3 * time.Second})
+		assert.NoError(t, err)
+		docs, err = p.Parse(ctx, bytes.NewReader(data))
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(docs))
+	})
+
+	t.Run("no segments", func(t *testing.T) {
+		tr := &mockTranscriber{transcript: &Transcript{Text: "Hello.", Duration: 1500 * time.Millisecond}}
+		p, err := NewAudioParser(ctx, &Config{Transcriber: tr})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, bytes.NewReader(data))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(docs))
+		assert.Equal(t, "Hello.", docs[0].Content)
+		assert.Equal(t, 1.5, docs[0].MetaData[MetaKeyEnd])
+
+		tr.transcript = &Transcript{}
+		docs, err = p.Parse(ctx, bytes.NewReader(data))
+		assert.NoError(t, err)
+		assert.Empty(t, docs)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		p, err := NewAudioParser(ctx, &Config{Transcriber: &mockTranscriber{err: errors.New("mock error")}})
+		assert.NoError(t, err)
+
+		_, err = p.Parse(ctx, bytes.NewReader(data))
+		assert.EqualError(t, err, "transcribe audio failed: mock error")
+
+		_, err = p.Parse(ctx, strings.NewReader(""))
+		assert.EqualError(t, err, "audio parser, audio is empty")
+	})
+}
+
+func TestFileName(t *testing.T) {
+	assert.Equal(t, "talk.m4a", fileName("/data/talk.m4a", nil))
+	assert.Equal(t, "talk.m4a", fileName(`C:\data\talk.m4a`, nil))
+	assert.Equal(t, "audio.mp3", fileName("https://example.com/stream", []byte("ID3\x03\x00")))
+	assert.Equal(t, "audio.flac", fileName("", []byte("fLaC\x00\x00\x00\x22")))
+	assert.Equal(t, "audio.m4a", fileName("", []byte("\x00\x00\x00\x20ftypM4A \x00\x00\x00\x00")))
+	assert.Equal(t, "audio.mp4", fileName("", []byte("\x00\x00\x00\x20ftypisom\x00\x00\x02\x00")))
+	assert.Equal(t, "audio.ogg", fileName("", []byte("OggS\x00\x02\x00\x00")))
+	assert.Equal(t, "audio.webm", fileName("", []byte("\x1a\x45\xdf\xa3\x9f\x42\x86\x81\x01\x42\xf7\x81\x01\x42\xf2\x81\x04\x42\xf3\x81\x08\x42\x82\x84webm")))
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/cloudwego/eino/components/document/parser"
+
+	"github.com/cloudwego/eino-ext/components/document/parser/audio"
+)
+
+func main() {
+	ctx := context.Background()
+
+	tr, err := audio.NewWhisperTranscriber(&audio.WhisperConfig{
+		APIKey: os.Getenv("OPENAI_API_KEY"),
+	})
+	if err != nil {
+		log.Fatalf("Failed to create transcriber: %v", err)
+	}
+
+	p, err := audio.NewAudioParser(ctx, &audio.Config{
+		Transcriber:   tr,
+		GroupDuration: time.Minute,
+	})
+	if err != nil {
+		log.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := os.Open("./testdata/test.wav")
+	if err != nil {
+		log.Fatalf("Failed to open file: %v", err)
+	}
+	defer file.Close()
+
+	docs, err := p.Parse(ctx, file, parser.WithURI("./testdata/test.wav"))
+	if err != nil {
+		log.Fatalf("Failed to parse file: %v", err)
+	}
+
+	for _, doc := range docs {
+		fmt.Printf("[%.1fs - %.1fs] %s\n", doc.MetaData[audio.MetaKeyStart], doc.MetaData[audio.MetaKeyEnd], doc.Content)
+	}
+}
@@ -0,0 +1,181 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package audio
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultVolcengineBaseURL    = "https://openspeech.bytedance.com"
+	defaultVolcengineResourceID = "volc.bigasr.auc_turbo"
+
+	volcengineStatusOK = "20000000"
+)
+
+// VolcengineConfig is the configuration for the Volcengine (Doubao) speech recognition transcriber.
+type VolcengineConfig struct {
+	// AppKey is the app id of the speech application, in the Volcengine speech console. Required.
+	AppKey string
+	// AccessKey is the access token of the speech application. Required.
+	AccessKey string
+	// BaseURL is the base url of the speech API.
+	// Optional. Default "https://openspeech.bytedance.com".
+	BaseURL string
+	// ResourceID is the resource id of the recognition service.
+	// Optional. Default "volc.bigasr.auc_turbo", the flash recognition of the big model.
+	ResourceID string
+	// HTTPClient is the client sending the requests.
+	// Optional. Default http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// VolcengineTranscriber transcribes the audio with the flash file recognition API of the Volcengine big model ASR,
+// which answers synchronously. The audio must be at most 100 MB and 2 hours, in wav, mp3 or ogg opus.
+type VolcengineTranscriber struct {
+	conf   *VolcengineConfig
+	client *http.Client
+}
+
+// NewVolcengineTranscriber creates a new Volcengine speech recognition transcriber.
+func NewVolcengineTranscriber(config *VolcengineConfig) (*VolcengineTranscriber, error) {
+	if config == nil || config.AppKey == "" || config.AccessKey == "" {
+		return nil, errors.New("new volcengine transcriber, app key and access key are required")
+	}
+
+	client := config.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &VolcengineTranscriber{conf: config, client: client}, nil
+}
+
+type volcengineRequest struct {
+	User struct {
+		UID string `json:"uid"`
+	} `json:"user"`
+	Audio struct {
+		Data string `json:"data"`
+	} `json:"audio"`
+	Request struct {
+		ModelName  string `json:"model_name"`
+		EnableITN  bool   `json:"enable_itn"`
+		EnablePunc bool   `json:"enable_punc"`
+	} `json:"request"`
+}
+
+type volcengineResponse struct {
+	AudioInfo struct {
+		Duration int64 `json:"duration"`
+	} `json:"audio_info"`
+	Result struct {
+		Text       string `json:"text"`
+		Utterances []struct {
+			StartTime int64  `json:"start_time"`
+			EndTime   int64  `json:"end_time"`
+			Text      string `json:"text"`
+			Additions struct {
+				Speaker string `json:"speaker"`
+			} `json:"additions"`
+		} `json:"utterances"`
+	} `json:"result"`
+}
+
+// Transcribe sends the audio to the flash recognition endpoint, whose utterance times are in milliseconds.
+func (vt *VolcengineTranscriber) Transcribe(ctx context.Context, audio []byte, fileName string) (*Transcript, error) {
+	var vr volcengineRequest
+	vr.User.UID = vt.conf.AppKey
+	vr.Audio.Data = base64.StdEncoding.EncodeToString(audio)
+	vr.Request.ModelName = "bigmodel"
+	vr.Request.EnableITN = true
+	vr.Request.EnablePunc = true
+
+	body, err := json.Marshal(vr)
+	if err != nil {
+		return nil, fmt.Errorf("marshal volcengine request failed: %w", err)
+	}
+
+	baseURL := vt.conf.BaseURL
+	if baseURL == "" {
+		baseURL = defaultVolcengineBaseURL
+	}
+	resourceID := vt.conf.ResourceID
+	if resourceID == "" {
+		resourceID = defaultVolcengineResourceID
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		strings.TrimRight(baseURL, "/")+"/api/v3/auc/bigmodel/recognize/flash", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create volcengine request failed: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Api-App-Key", vt.conf.AppKey)
+	req.Header.Set("X-Api-Access-Key", vt.conf.AccessKey)
+	req.Header.Set("X-Api-Resource-Id", resourceID)
+	req.Header.Set("X-Api-Request-Id", uuid.NewString())
+	req.Header.Set("X-Api-Sequence", "-1")
+
+	resp, err := vt.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send volcengine request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read volcengine response failed: %w", err)
+	}
+
+	// the status of the recognition is in the response headers
+	if code := resp.Header.Get("X-Api-Status-Code"); code != volcengineStatusOK {
+		return nil, fmt.Errorf("volcengine request failed: status= %d, code= %s, message= %s, logid= %s",
+			resp.StatusCode, code, resp.Header.Get("X-Api-Message"), resp.Header.Get("X-Tt-Logid"))
+	}
+
+	var vresp volcengineResponse
+	if err = json.Unmarshal(respBody, &vresp); err != nil {
+		return nil, fmt.Errorf("unmarshal volcengine response failed: %w", err)
+	}
+
+	t := &Transcript{
+		Text:     strings.TrimSpace(vresp.Result.Text),
+		Duration: time.Duration(vresp.AudioInfo.Duration) * time.Millisecond,
+	}
+	for _, u := range vresp.Result.Utterances {
+		t.Segments = append(t.Segments, Segment{
+			Start:   time.Duration(u.StartTime) * time.Millisecond,
+			End:     time.Duration(u.EndTime) * time.Millisecond,
+			Text:    strings.TrimSpace(u.Text),
+			Speaker: u.Additions.Speaker,
+		})
+	}
+
+	return t, nil
+}
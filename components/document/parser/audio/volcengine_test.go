@@ -0,0 +1,88 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package audio
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVolcengineTranscriber(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewVolcengineTranscriber(&VolcengineConfig{AppKey: "app"})
+	assert.EqualError(t, err, "new volcengine transcriber, app key and access key are required")
+
+	t.Run("success", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v3/auc/bigmodel/recognize/flash", r.URL.Path)
+			assert.Equal(t, "app", r.Header.Get("X-Api-App-Key"))
+			assert.Equal(t, "token", r.Header.Get("X-Api-Access-Key"))
+			assert.Equal(t, "volc.bigasr.auc_turbo", r.Header.Get("X-Api-Resource-Id"))
+			assert.Equal(t, "-1", r.Header.Get("X-Api-Sequence"))
+			assert.NotEmpty(t, r.Header.Get("X-Api-Request-Id"))
+
+			var req volcengineRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "app", req.User.UID)
+			assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("audio data")), req.Audio.Data)
+			assert.Equal(t, "bigmodel", req.Request.ModelName)
+
+			w.Header().Set("X-Api-Status-Code", "20000000")
+			_, _ = w.Write([]byte(`{"audio_info":{"duration":5200},"result":{"text":"早上好。我们开始吧。",
+				"utterances":[{"start_time":200,"end_time":1500,"text":"早上好。","additions":{"speaker":"1"}},{"start_time":1800,"end_time":5200,"text":"我们开始吧。"}]}}`))
+		}))
+		defer server.Close()
+
+		vt, err := NewVolcengineTranscriber(&VolcengineConfig{AppKey: "app", AccessKey: "token", BaseURL: server.URL})
+		assert.NoError(t, err)
+
+		tr, err := vt.Transcribe(ctx, []byte("audio data"), "audio.wav")
+		assert.NoError(t, err)
+		assert.Equal(t, &Transcript{
+			Text:     "早上好。我们开始吧。",
+			Duration: 5200 * time.Millisecond,
+			Segments: []Segment{
+				{Start: 200 * time.Millisecond, End: 1500 * time.Millisecond, Text: "早上好。", Speaker: "1"},
+				{Start: 1800 * time.Millisecond, End: 5200 * time.Millisecond, Text: "我们开始吧。"},
+			},
+		}, tr)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Api-Status-Code", "45000151")
+			w.Header().Set("X-Api-Message", "invalid audio format")
+			w.Header().Set("X-Tt-Logid", "log-1")
+			_, _ = w.Write([]byte(`{}`))
+		}))
+		defer server.Close()
+
+		vt, err := NewVolcengineTranscriber(&VolcengineConfig{AppKey: "app", AccessKey: "token", BaseURL: server.URL})
+		assert.NoError(t, err)
+
+		_, err = vt.Transcribe(ctx, []byte("audio data"), "audio.wav")
+		assert.EqualError(t, err, "volcengine request failed: status= 200, code= 45000151, message= invalid audio format, logid= log-1")
+	})
+}
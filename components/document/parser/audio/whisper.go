@@ -0,0 +1,180 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package audio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultWhisperBaseURL = "https://api.openai.com/v1"
+	defaultWhisperModel   = "whisper-1"
+)
+
+// WhisperConfig is the configuration for the OpenAI Whisper API transcriber.
+type WhisperConfig struct {
+	// APIKey is the OpenAI API key. Required.
+	APIKey string
+	// BaseURL is the base url of the API, for the OpenAI compatible transcription services.
+	// Optional. Default "https://api.openai.com/v1".
+	BaseURL string
+	// Model is the transcription model, which must support the verbose_json response format.
+	// Optional. Default "whisper-1".
+	Model string
+	// Language is the language of the audio, as an ISO-639-1 code, e.g. "en", improving accuracy and latency.
+	// Optional. Default automatic language detection.
+	Language string
+	// Prompt guides the style of the transcript, or spells the names and terms of the audio.
+	// Optional.
+	Prompt string
+	// HTTPClient is the client sending the requests.
+	// Optional. Default http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// WhisperTranscriber transcribes the audio with the OpenAI audio transcriptions API.
+// The audio must be at most 25 MB, in one of the formats accepted by the API, e.g. mp3, mp4, m4a, wav or webm.
+type WhisperTranscriber struct {
+	conf   *WhisperConfig
+	client *http.Client
+}
+
+// NewWhisperTranscriber creates a new OpenAI Whisper API transcriber.
+func NewWhisperTranscriber(config *WhisperConfig) (*WhisperTranscriber, error) {
+	if config == nil || config.APIKey == "" {
+		return nil, errors.New("new whisper transcriber, api key is required")
+	}
+
+	client := config.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &WhisperTranscriber{conf: config, client: client}, nil
+}
+
+type whisperResponse struct {
+	Text     string  `json:"text"`
+	Language string  `json:"language"`
+	Duration float64 `json:"duration"`
+	Segments []struct {
+		Start float64 `json:"start"`
+		End   float64 `json:"end"`
+		Text  string  `json:"text"`
+	} `json:"segments"`
+	Error *struct {
+		Message string `json:"message"`
+		Type    string `json:"type"`
+	} `json:"error"`
+}
+
+// Transcribe uploads the audio to the transcriptions endpoint, with the segment timestamps of the verbose_json format.
+func (wt *WhisperTranscriber) Transcribe(ctx context.Context, audio []byte, fileName string) (*Transcript, error) {
+	model := wt.conf.Model
+	if model == "" {
+		model = defaultWhisperModel
+	}
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	fw, err := w.CreateFormFile("file", fileName)
+	if err != nil {
+		return nil, fmt.Errorf("create whisper request failed: %w", err)
+	}
+	if _, err = fw.Write(audio); err != nil {
+		return nil, fmt.Errorf("create whisper request failed: %w", err)
+	}
+	fields := [][2]string{
+		{"model", model},
+		{"response_format", "verbose_json"},
+		{"timestamp_granularities[]", "segment"},
+		{"language", wt.conf.Language},
+		{"prompt", wt.conf.Prompt},
+	}
+	for _, f := range fields {
+		if f[1] == "" {
+			continue
+		}
+		if err = w.WriteField(f[0], f[1]); err != nil {
+			return nil, fmt.Errorf("create whisper request failed: %w", err)
+		}
+	}
+	if err = w.Close(); err != nil {
+		return nil, fmt.Errorf("create whisper request failed: %w", err)
+	}
+
+	baseURL := wt.conf.BaseURL
+	if baseURL == "" {
+		baseURL = defaultWhisperBaseURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(baseURL, "/")+"/audio/transcriptions", &body)
+	if err != nil {
+		return nil, fmt.Errorf("create whisper request failed: %w", err)
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+wt.conf.APIKey)
+
+	resp, err := wt.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send whisper request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read whisper response failed: %w", err)
+	}
+
+	var wr whisperResponse
+	if err = json.Unmarshal(respBody, &wr); err != nil {
+		return nil, fmt.Errorf("unmarshal whisper response failed: %w, status= %d", err, resp.StatusCode)
+	}
+	if wr.Error != nil {
+		return nil, fmt.Errorf("whisper request failed: status= %d, type= %s, message= %s", resp.StatusCode, wr.Error.Type, wr.Error.Message)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("whisper request failed: status= %d", resp.StatusCode)
+	}
+
+	t := &Transcript{
+		Text:     strings.TrimSpace(wr.Text),
+		Language: wr.Language,
+		Duration: seconds(wr.Duration),
+	}
+	for _, s := range wr.Segments {
+		t.Segments = append(t.Segments, Segment{
+			Start: seconds(s.Start),
+			End:   seconds(s.End),
+			Text:  strings.TrimSpace(s.Text),
+		})
+	}
+
+	return t, nil
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
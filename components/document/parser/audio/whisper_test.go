@@ -0,0 +1,87 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package audio
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWhisperTranscriber(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewWhisperTranscriber(&WhisperConfig{})
+	assert.EqualError(t, err, "new whisper transcriber, api key is required")
+
+	t.Run("success", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v1/audio/transcriptions", r.URL.Path)
+			assert.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+
+			assert.NoError(t, r.ParseMultipartForm(1<<20))
+			assert.Equal(t, "whisper-1", r.FormValue("model"))
+			assert.Equal(t, "verbose_json", r.FormValue("response_format"))
+			assert.Equal(t, "segment", r.FormValue("timestamp_granularities[]"))
+			assert.Equal(t, "en", r.FormValue("language"))
+			assert.Empty(t, r.MultipartForm.Value["prompt"])
+			f, header, err := r.FormFile("file")
+			assert.NoError(t, err)
+			assert.Equal(t, "weekly.wav", header.Filename)
+			audio, _ := io.ReadAll(f)
+			assert.Equal(t, "audio data", string(audio))
+
+			_, _ = w.Write([]byte(`{"task":"transcribe","language":"english","duration":8.47,"text":"Good morning. Let's start.",
+				"segments":[{"id":0,"start":0.0,"end":3.2,"text":" Good morning."},{"id":1,"start":3.2,"end":8.47,"text":" Let's start."}]}`))
+		}))
+		defer server.Close()
+
+		wt, err := NewWhisperTranscriber(&WhisperConfig{APIKey: "test-key", BaseURL: server.URL + "/v1/", Language: "en"})
+		assert.NoError(t, err)
+
+		tr, err := wt.Transcribe(ctx, []byte("audio data"), "weekly.wav")
+		assert.NoError(t, err)
+		assert.Equal(t, &Transcript{
+			Text:     "Good morning. Let's start.",
+			Language: "english",
+			Duration: 8470 * time.Millisecond,
+			Segments: []Segment{
+				{Start: 0, End: 3200 * time.Millisecond, Text: "Good morning."},
+				{Start: 3200 * time.Millisecond, End: 8470 * time.Millisecond, Text: "Let's start."},
+			},
+		}, tr)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":{"message":"Invalid file format.","type":"invalid_request_error"}}`))
+		}))
+		defer server.Close()
+
+		wt, err := NewWhisperTranscriber(&WhisperConfig{APIKey: "test-key", BaseURL: server.URL})
+		assert.NoError(t, err)
+
+		_, err = wt.Transcribe(ctx, []byte("audio data"), "audio.mp3")
+		assert.EqualError(t, err, "whisper request failed: status= 400, type= invalid_request_error, message= Invalid file format.")
+	})
+}
@@ -0,0 +1,171 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package audio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WhisperCppConfig is the configuration for the whisper.cpp transcriber.
+type WhisperCppConfig struct {
+	// Path is the path of the whisper.cpp command line binary.
+	// Optional. Default "whisper-cli", looked up in PATH.
+	Path string
+	// ModelPath is the path of the ggml model file, e.g. "models/ggml-base.en.bin". Required.
+	ModelPath string
+	// Language is the language of the audio, e.g. "en", or "auto" for automatic detection.
+	// Optional. Default whisper.cpp's default, "en".
+	Language string
+	// Threads is the number of threads of the computation.
+	// Optional. Default whisper.cpp's default.
+	Threads int
+	// FFmpegPath is the path of ffmpeg, converting the audio or video to the 16 kHz wav expected by whisper.cpp.
+	// Optional. Default "", the audio is given to whisper.cpp as is.
+	FFmpegPath string
+	// ExtraArgs are added to the command line, e.g. []string{"--max-len", "60"}.
+	ExtraArgs []string
+}
+
+// WhisperCppTranscriber transcribes the audio locally with the command line of whisper.cpp.
+type WhisperCppTranscriber struct {
+	path   string
+	ffmpeg string
+	conf   *WhisperCppConfig
+}
+
+// NewWhisperCppTranscriber creates a new whisper.cpp transcriber.
+func NewWhisperCppTranscriber(config *WhisperCppConfig) (*WhisperCppTranscriber, error) {
+	if config == nil || config.ModelPath == "" {
+		return nil, errors.New("new whisper.cpp transcriber, model path is required")
+	}
+
+	bin := config.Path
+	if bin == "" {
+		bin = "whisper-cli"
+	}
+	path, err := exec.LookPath(bin)
+	if err != nil {
+		return nil, fmt.Errorf("new whisper.cpp transcriber, whisper.cpp not found: %w", err)
+	}
+
+	var ffmpeg string
+	if config.FFmpegPath != "" {
+		if ffmpeg, err = exec.LookPath(config.FFmpegPath); err != nil {
+			return nil, fmt.Errorf("new whisper.cpp transcriber, ffmpeg not found: %w", err)
+		}
+	}
+
+	return &WhisperCppTranscriber{path: path, ffmpeg: ffmpeg, conf: config}, nil
+}
+
+type whisperCppOutput struct {
+	Result struct {
+		Language string `json:"language"`
+	} `json:"result"`
+	Transcription []struct {
+		Offsets struct {
+			From int64 `json:"from"`
+			To   int64 `json:"to"`
+		} `json:"offsets"`
+		Text string `json:"text"`
+	} `json:"transcription"`
+}
+
+// Transcribe writes the audio to a temporary directory, converted with ffmpeg if configured,
+// and reads the json output of whisper.cpp, whose offsets are in milliseconds.
+func (wt *WhisperCppTranscriber) Transcribe(ctx context.Context, audio []byte, fileName string) (*Transcript, error) {
+	dir, err := os.MkdirTemp("", "eino-whispercpp-")
+	if err != nil {
+		return nil, fmt.Errorf("create temp dir failed: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	input := filepath.Join(dir, "input"+filepath.Ext(fileName))
+	if err = os.WriteFile(input, audio, 0o600); err != nil {
+		return nil, fmt.Errorf("write audio file failed: %w", err)
+	}
+
+	if wt.ffmpeg != "" {
+		wav := filepath.Join(dir, "converted.wav")
+		if err = run(ctx, wt.ffmpeg, "-nostdin", "-loglevel", "error", "-i", input, "-ar", "16000", "-ac", "1", "-c:a", "pcm_s16le", wav); err != nil {
+			return nil, fmt.Errorf("convert audio with ffmpeg failed: %w", err)
+		}
+		input = wav
+	}
+
+	output := filepath.Join(dir, "output")
+	args := []string{"-m", wt.conf.ModelPath, "-f", input, "-oj", "-of", output, "-np"}
+	if wt.conf.Language != "" {
+		args = append(args, "-l", wt.conf.Language)
+	}
+	if wt.conf.Threads > 0 {
+		args = append(args, "-t", strconv.Itoa(wt.conf.Threads))
+	}
+	args = append(args, wt.conf.ExtraArgs...)
+	if err = run(ctx, wt.path, args...); err != nil {
+		return nil, fmt.Errorf("run whisper.cpp failed: %w", err)
+	}
+
+	data, err := os.ReadFile(output + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("read whisper.cpp output failed: %w", err)
+	}
+	var out whisperCppOutput
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("unmarshal whisper.cpp output failed: %w", err)
+	}
+
+	t := &Transcript{Language: out.Result.Language}
+	texts := make([]string, 0, len(out.Transcription))
+	for _, s := range out.Transcription {
+		text := strings.TrimSpace(s.Text)
+		t.Segments = append(t.Segments, Segment{
+			Start: time.Duration(s.Offsets.From) * time.Millisecond,
+			End:   time.Duration(s.Offsets.To) * time.Millisecond,
+			Text:  text,
+		})
+		if text != "" {
+			texts = append(texts, text)
+		}
+	}
+	t.Text = strings.Join(texts, " ")
+	if n := len(t.Segments); n > 0 {
+		t.Duration = t.Segments[n-1].End
+	}
+
+	return t, nil
+}
+
+func run(ctx context.Context, name string, args ...string) error {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%w, stderr= %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package audio
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testWhisperCppOutput = `{"result":{"language":"en"},"transcription":[
+{"timestamps":{"from":"00:00:00,000","to":"00:00:03,200"},"offsets":{"from":0,"to":3200},"text":" Good morning."},
+{"timestamps":{"from":"00:00:03,200","to":"00:00:08,470"},"offsets":{"from":3200,"to":8470},"text":" Let's start."}]}`
+
+func TestWhisperCppTranscriber(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake whisper.cpp is a shell script")
+	}
+
+	_, err := NewWhisperCppTranscriber(&WhisperCppConfig{})
+	assert.EqualError(t, err, "new whisper.cpp transcriber, model path is required")
+	_, err = NewWhisperCppTranscriber(&WhisperCppConfig{ModelPath: "model.bin", Path: filepath.Join(t.TempDir(), "not-exist")})
+	assert.ErrorContains(t, err, "new whisper.cpp transcriber, whisper.cpp not found")
+
+	// the fake whisper.cpp records its arguments and the input, then writes the json output to the -of prefix
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "out.json")
+	argsPath := filepath.Join(dir, "args")
+	inputPath := filepath.Join(dir, "input")
+	assert.NoError(t, os.WriteFile(outPath, []byte(testWhisperCppOutput), 0o644))
+	script := "#!/bin/sh\n" +
+		"echo \"$@\" > " + argsPath + "\n" +
+		"while [ $# -gt 0 ]; do\n" +
+		"  case $1 in -f) cp \"$2\" " + inputPath + ";; -of) cp " + outPath + " \"$2.json\";; esac\n" +
+		"  shift\n" +
+		"done\n"
+	bin := filepath.Join(dir, "whisper-cli")
+	assert.NoError(t, os.WriteFile(bin, []byte(script), 0o755))
+
+	// the fake ffmpeg prefixes the audio, the output file being its last argument
+	ffmpeg := filepath.Join(dir, "ffmpeg")
+	assert.NoError(t, os.WriteFile(ffmpeg, []byte("#!/bin/sh\nfor last; do :; done\n{ printf 'converted '; cat \"$5\"; } > \"$last\"\n"), 0o755))
+
+	wt, err := NewWhisperCppTranscriber(&WhisperCppConfig{Path: bin, ModelPath: "ggml-base.en.bin", Language: "en", Threads: 4})
+	assert.NoError(t, err)
+
+	tr, err := wt.Transcribe(context.Background(), []byte("audio data"), "weekly.wav")
+	assert.NoError(t, err)
+	assert.Equal(t, &Transcript{
+		Text:     "Good morning. Let's start.",
+		Language: "en",
+		Duration: 8470 * time.Millisecond,
+		Segments: []Segment{
+			{Start: 0, End: 3200 * time.Millisecond, Text: "Good morning."},
+			{Start: 3200 * time.Millisecond, End: 8470 * time.Millisecond, Text: "Let's start."},
+		},
+	}, tr)
+
+	args, err := os.ReadFile(argsPath)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(args), "-m ggml-base.en.bin -f "))
+	assert.True(t, strings.HasSuffix(strings.TrimSpace(string(args)), "-np -l en -t 4"))
+	assert.Contains(t, string(args), "input.wav")
+	input, err := os.ReadFile(inputPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "audio data", string(input))
+
+	wt, err = NewWhisperCppTranscriber(&WhisperCppConfig{Path: bin, ModelPath: "ggml-base.en.bin", FFmpegPath: ffmpeg})
+	assert.NoError(t, err)
+	_, err = wt.Transcribe(context.Background(), []byte("video data"), "talk.mp4")
+	assert.NoError(t, err)
+	input, err = os.ReadFile(inputPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "converted video data", string(input))
+
+	failing := filepath.Join(dir, "failing")
+	assert.NoError(t, os.WriteFile(failing, []byte("#!/bin/sh\necho 'failed to read audio file' >&2\nexit 2\n"), 0o755))
+	wt, err = NewWhisperCppTranscriber(&WhisperCppConfig{Path: failing, ModelPath: "ggml-base.en.bin"})
+	assert.NoError(t, err)
+	_, err = wt.Transcribe(context.Background(), []byte("audio data"), "weekly.wav")
+	assert.ErrorContains(t, err, "run whisper.cpp failed: exit status 2, stderr= failed to read audio file")
+}
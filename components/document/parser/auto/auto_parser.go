@@ -0,0 +1,219 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package auto provides a parser routing the content to the parser of its file type, detected from
+// the magic bytes, the content type and the uri extension.
+package auto
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	MetaKeyFileType = "_file_type"
+)
+
+// headSize is the size of the head of the content given to the magic and sniff funcs.
+const headSize = 8 * 1024
+
+// Config is the configuration for auto parser.
+type Config struct {
+	// Parsers map the file type names to their parsers, e.g. {auto.TypePDF: pdfParser, auto.TypeHTML: htmlParser}.
+	// The types without a parser are not detected. Required.
+	Parsers map[string]parser.Parser
+	// Types are the custom file types, checked before the built-in ones. A custom type replaces the built-in one
+	// of the same name, e.g. to add extensions.
+	// Optional.
+	Types []FileType
+	// FallbackParser parses the content whose type is not detected.
+	// Optional. Default parser.TextParser.
+	FallbackParser parser.Parser
+}
+
+// AutoParser detects the file type of the content, and parses it with the parser of the type:
+//  1. the magic bytes of the content, e.g. "%PDF-"
+//  2. the content type given with WithContentType, e.g. the Content-Type header of a http response
+//  3. the extension of the uri given with parser.WithURI
+//  4. the sniffing of the content, e.g. a text beginning with "<html"
+//
+// Only the first bytes of the content are read ahead, the content is streamed to the parser.
+type AutoParser struct {
+	types    []FileType
+	parsers  map[string]parser.Parser
+	fallback parser.Parser
+}
+
+// NewAutoParser creates a new auto parser.
+func NewAutoParser(ctx context.Context, config *Config) (*AutoParser, error) {
+	if config == nil {
+		return nil, errors.New("new auto parser, config is nil")
+	}
+	if len(config.Parsers) == 0 {
+		return nil, errors.New("new auto parser, parsers are required")
+	}
+
+	custom := make(map[string]bool, len(config.Types))
+	for _, t := range config.Types {
+		if t.Name == "" {
+			return nil, errors.New("new auto parser, file type name is required")
+		}
+		custom[t.Name] = true
+	}
+	types := append([]FileType{}, config.Types...)
+	for _, t := range BuiltinTypes {
+		if !custom[t.Name] {
+			types = append(types, t)
+		}
+	}
+
+	parsers := make(map[string]parser.Parser, len(config.Parsers))
+	for name, p := range config.Parsers {
+		found := false
+		for _, t := range types {
+			found = found || t.Name == name
+		}
+		if !found {
+			return nil, fmt.Errorf("new auto parser, unknown file type: %s", name)
+		}
+		if p == nil {
+			return nil, fmt.Errorf("new auto parser, parser of %s is nil", name)
+		}
+		parsers[name] = p
+	}
+
+	fallback := config.FallbackParser
+	if fallback == nil {
+		fallback = parser.TextParser{}
+	}
+
+	return &AutoParser{types: types, parsers: parsers, fallback: fallback}, nil
+}
+
+// Parse detects the file type of the content from io.Reader, and parses it with the parser of the type.
+// The options are passed to the parser, and the detected type is set in the MetaKeyFileType metadata.
+func (ap *AutoParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	commonOpts := parser.GetCommonOptions(nil, opts...)
+	specificOpts := parser.GetImplSpecificOptions(&options{}, opts...)
+
+	br := bufio.NewReaderSize(reader, headSize)
+	head, err := br.Peek(headSize)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return nil, fmt.Errorf("auto parser read head err: %w", err)
+	}
+
+	typeName := ap.detect(head, specificOpts.contentType, commonOpts.URI)
+	p := ap.fallback
+	if typeName != "" {
+		p = ap.parsers[typeName]
+	}
+
+	docs, err := p.Parse(ctx, br, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if typeName != "" {
+		for _, doc := range docs {
+			if doc == nil {
+				continue
+			}
+			if doc.MetaData == nil {
+				doc.MetaData = make(map[string]any, 1)
+			}
+			doc.MetaData[MetaKeyFileType] = typeName
+		}
+	}
+
+	return docs, nil
+}
+
+// DetectType returns the name of the file type detected from the head of the content, the content type and the uri,
+// among the types with a parser. It is empty if no type is detected.
+func (ap *AutoParser) DetectType(head []byte, contentType, uri string) string {
+	return ap.detect(head, contentType, uri)
+}
+
+func (ap *AutoParser) detect(head []byte, contentType, uri string) string {
+	for _, t := range ap.types {
+		if ap.parsers[t.Name] != nil && t.Magic != nil && t.Magic(head) {
+			return t.Name
+		}
+	}
+
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		for _, t := range ap.types {
+			if ap.parsers[t.Name] != nil && matchMIMEType(t.MIMETypes, mediaType) {
+				return t.Name
+			}
+		}
+	}
+
+	if ext := extension(uri); ext != "" {
+		for _, t := range ap.types {
+			if ap.parsers[t.Name] == nil {
+				continue
+			}
+			for _, e := range t.Extensions {
+				if strings.EqualFold(e, ext) {
+					return t.Name
+				}
+			}
+		}
+	}
+
+	for _, t := range ap.types {
+		if ap.parsers[t.Name] != nil && t.Sniff != nil && len(head) > 0 && t.Sniff(head) {
+			return t.Name
+		}
+	}
+
+	return ""
+}
+
+func matchMIMEType(patterns []string, mediaType string) bool {
+	for _, p := range patterns {
+		if strings.EqualFold(p, mediaType) {
+			return true
+		}
+		if family, ok := strings.CutSuffix(p, "/*"); ok && strings.HasPrefix(mediaType, strings.ToLower(family)+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// extension returns the extension of the uri, which is a url or a file path.
+func extension(uri string) string {
+	if uri == "" {
+		return ""
+	}
+	if u, err := url.Parse(uri); err == nil && u.Scheme != "" && len(u.Scheme) > 1 {
+		// a url, not a windows path like C:\docs\a.pdf
+		return path.Ext(u.Path)
+	}
+	return path.Ext(strings.ReplaceAll(uri, `\`, "/"))
+}
@@ -0,0 +1,221 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auto
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockParser returns a document with its name and the length of the content it read.
+type mockParser struct {
+	name string
+	err  error
+}
+
+func (m *mockParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	return []*schema.Document{{Content: m.name + ":" + string(data), MetaData: parser.GetCommonOptions(nil, opts...).ExtraMeta}}, nil
+}
+
+func newTestParser(t *testing.T, types ...FileType) *AutoParser {
+	parsers := map[string]parser.Parser{}
+	for _, name := range []string{TypePDF, TypeDOCX, TypeXLSX, TypeEPUB, TypeEmail, TypeHTML, TypeMarkdown,
+		TypeCSV, TypeJSON, TypeImage, TypeAudio, TypeVideo} {
+		parsers[name] = &mockParser{name: name}
+	}
+	for _, ft := range types {
+		parsers[ft.Name] = &mockParser{name: ft.Name}
+	}
+
+	p, err := NewAutoParser(context.Background(), &Config{Parsers: parsers, Types: types})
+	assert.NoError(t, err)
+	return p
+}
+
+func zipFile(t *testing.T, names ...string) []byte {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, name := range names {
+		method := zip.Deflate
+		if name == "mimetype" {
+			method = zip.Store
+		}
+		f, err := w.CreateHeader(&zip.FileHeader{Name: name, Method: method})
+		assert.NoError(t, err)
+		content := strings.Repeat("<xml>"+name+"</xml>", 50)
+		if name == "mimetype" {
+			content = "application/epub+zip"
+		}
+		_, err = f.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestNewAutoParser(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewAutoParser(ctx, nil)
+	assert.EqualError(t, err, "new auto parser, config is nil")
+
+	_, err = NewAutoParser(ctx, &Config{})
+	assert.EqualError(t, err, "new auto parser, parsers are required")
+
+	_, err = NewAutoParser(ctx, &Config{Parsers: map[string]parser.Parser{"rtf": &mockParser{}}})
+	assert.EqualError(t, err, "new auto parser, unknown file type: rtf")
+
+	_, err = NewAutoParser(ctx, &Config{Parsers: map[string]parser.Parser{TypePDF: nil}})
+	assert.EqualError(t, err, "new auto parser, parser of pdf is nil")
+
+	_, err = NewAutoParser(ctx, &Config{Parsers: map[string]parser.Parser{TypePDF: &mockParser{}}, Types: []FileType{{}}})
+	assert.EqualError(t, err, "new auto parser, file type name is required")
+}
+
+func TestAutoParser_DetectType(t *testing.T) {
+	p := newTestParser(t)
+
+	cases := []struct {
+		name        string
+		head        []byte
+		contentType string
+		uri         string
+		want        string
+	}{
+		{name: "pdf magic beats extension", head: []byte("%PDF-1.7\n%..."), uri: "report.txt", want: TypePDF},
+		{name: "docx", head: zipFile(t, "[Content_Types].xml", "_rels/.rels", "word/document.xml"), uri: "download", want: TypeDOCX},
+		{name: "xlsx", head: zipFile(t, "[Content_Types].xml", "xl/workbook.xml"), want: TypeXLSX},
+		{name: "epub", head: zipFile(t, "mimetype", "META-INF/container.xml"), uri: "book.zip", want: TypeEPUB},
+		{name: "pptx without parser", head: zipFile(t, "[Content_Types].xml", "ppt/presentation.xml"), want: ""},
+		{name: "zip by extension", head: zipFile(t, "a.txt"), uri: "file.docx", want: TypeDOCX},
+		{name: "png", head: []byte("\x89PNG\r\n\x1a\n\x00\x00"), uri: "scan.pdf", want: TypeImage},
+		{name: "webp", head: []byte("RIFF\x00\x00\x00\x00WEBPVP8 "), want: TypeImage},
+		{name: "wav", head: []byte("RIFF\x24\x00\x00\x00WAVEfmt "), want: TypeAudio},
+		{name: "mp3", head: []byte("ID3\x04\x00"), want: TypeAudio},
+		{name: "m4a", head: []byte("\x00\x00\x00\x20ftypM4A \x00\x00"), want: TypeAudio},
+		{name: "mp4", head: []byte("\x00\x00\x00\x20ftypisom\x00\x00"), want: TypeVideo},
+		{name: "content type", head: []byte("Name,Age\nAda,36\n"), contentType: "text/csv; charset=utf-8", uri: "https://example.com/export", want: TypeCSV},
+		{name: "content type family", head: []byte("????"), contentType: "image/heic", want: TypeImage},
+		{name: "content type beats extension", head: []byte("# Title"), contentType: "text/markdown", uri: "readme.txt", want: TypeMarkdown},
+		{name: "unknown content type", head: []byte("# Title"), contentType: "application/octet-stream", uri: "readme.MD", want: TypeMarkdown},
+		{name: "url extension", head: []byte("a,b"), uri: "https://example.com/files/data.CSV?download=1#top", want: TypeCSV},
+		{name: "windows path", head: []byte("a,b"), uri: `C:\exports\data.tsv`, want: TypeCSV},
+		{name: "sniff html", head: []byte("\n  <!DOCTYPE html><html><body>hi</body></html>"), uri: "https://example.com/page", want: TypeHTML},
+		{name: "sniff json", head: []byte("\xef\xbb\xbf[\n  {\"id\": 1}\n]"), want: TypeJSON},
+		{name: "sniff json lines", head: []byte("{\"id\": 1}\n{\"id\": 2}\n"), want: TypeJSON},
+		{name: "sniff email", head: []byte("Return-Path: <a@example.com>\r\nReceived: from mx\r\n\r\nbody"), want: TypeEmail},
+		{name: "extension beats sniff", head: []byte("<html></html>"), uri: "page.md", want: TypeMarkdown},
+		{name: "plain text", head: []byte("just some text"), uri: "notes", want: ""},
+		{name: "empty", head: nil, want: ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.want, p.DetectType(c.head, c.contentType, c.uri))
+		})
+	}
+}
+
+func TestAutoParser_Parse(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("routes the whole content", func(t *testing.T) {
+		p := newTestParser(t)
+
+		content := "%PDF-1.4\n" + strings.Repeat("x", 3*headSize)
+		docs, err := p.Parse(ctx, strings.NewReader(content), parser.WithURI("a.pdf"),
+			parser.WithExtraMeta(map[string]any{"test": "test"}))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(docs))
+		assert.Equal(t, "pdf:"+content, docs[0].Content)
+		assert.Equal(t, map[string]any{"test": "test", MetaKeyFileType: TypePDF}, docs[0].MetaData)
+	})
+
+	t.Run("content type option", func(t *testing.T) {
+		p := newTestParser(t)
+
+		docs, err := p.Parse(ctx, strings.NewReader("<p>hi</p>"), parser.WithURI("https://example.com/a"),
+			WithContentType("application/xhtml+xml; charset=utf-8"))
+		assert.NoError(t, err)
+		assert.Equal(t, "html:<p>hi</p>", docs[0].Content)
+		assert.Equal(t, TypeHTML, docs[0].MetaData[MetaKeyFileType])
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		p := newTestParser(t)
+
+		docs, err := p.Parse(ctx, strings.NewReader("just some text"), parser.WithURI("notes"))
+		assert.NoError(t, err)
+		assert.Equal(t, "just some text", docs[0].Content)
+		assert.NotContains(t, docs[0].MetaData, MetaKeyFileType)
+
+		p, err = NewAutoParser(ctx, &Config{
+			Parsers:        map[string]parser.Parser{TypePDF: &mockParser{name: TypePDF}},
+			FallbackParser: &mockParser{name: "fallback"},
+		})
+		assert.NoError(t, err)
+		docs, err = p.Parse(ctx, strings.NewReader("a,b"), parser.WithURI("data.csv"))
+		assert.NoError(t, err)
+		assert.Equal(t, "fallback:a,b", docs[0].Content)
+	})
+
+	t.Run("custom types", func(t *testing.T) {
+		rtf := FileType{
+			Name:       "rtf",
+			Extensions: []string{".rtf"},
+			MIMETypes:  []string{"application/rtf"},
+			Magic: func(head []byte) bool {
+				return bytes.HasPrefix(head, []byte(`{\rtf1`))
+			},
+		}
+		markdown := FileType{Name: TypeMarkdown, Extensions: []string{".mdown"}}
+		p := newTestParser(t, rtf, markdown)
+
+		docs, err := p.Parse(ctx, strings.NewReader(`{\rtf1\ansi hello}`))
+		assert.NoError(t, err)
+		assert.Equal(t, `rtf:{\rtf1\ansi hello}`, docs[0].Content)
+		assert.Equal(t, "rtf", docs[0].MetaData[MetaKeyFileType])
+
+		assert.Equal(t, TypeMarkdown, p.DetectType([]byte("# Title"), "", "notes.mdown"))
+		// the custom type replaces the built-in one
+		assert.Equal(t, "", p.DetectType([]byte("# Title"), "", "notes.md"))
+	})
+
+	t.Run("parser error", func(t *testing.T) {
+		p, err := NewAutoParser(ctx, &Config{Parsers: map[string]parser.Parser{TypePDF: &mockParser{err: errors.New("mock error")}}})
+		assert.NoError(t, err)
+
+		_, err = p.Parse(ctx, strings.NewReader("%PDF-1.4"))
+		assert.EqualError(t, err, "mock error")
+	})
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+
+	"github.com/cloudwego/eino/components/document/parser"
+
+	"github.com/cloudwego/eino-ext/components/document/parser/auto"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// a custom type, the logs beginning with a timestamp
+	logType := auto.FileType{
+		Name:       "log",
+		Extensions: []string{".log", ".out"},
+		Sniff:      regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}`).Match,
+	}
+
+	p, err := auto.NewAutoParser(ctx, &auto.Config{
+		Parsers: map[string]parser.Parser{
+			// e.g. auto.TypePDF: pdfParser, auto.TypeHTML: htmlParser, auto.TypeCSV: csvParser, ...
+			auto.TypeMarkdown: parser.TextParser{},
+			"log":             parser.TextParser{},
+		},
+		Types: []auto.FileType{logType},
+	})
+	if err != nil {
+		log.Fatalf("Failed to create parser: %v", err)
+	}
+
+	for _, path := range []string{"./testdata/checklist.md", "./testdata/server.out"} {
+		file, err := os.Open(path)
+		if err != nil {
+			log.Fatalf("Failed to open file: %v", err)
+		}
+
+		docs, err := p.Parse(ctx, file, parser.WithURI(path))
+		_ = file.Close()
+		if err != nil {
+			log.Fatalf("Failed to parse file: %v", err)
+		}
+
+		for _, doc := range docs {
+			fmt.Printf("--- %s: %v ---\n", path, doc.MetaData[auto.MetaKeyFileType])
+			fmt.Println(doc.Content)
+		}
+	}
+}
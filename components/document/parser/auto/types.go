@@ -0,0 +1,232 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auto
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// the names of the built-in file types.
+const (
+	TypePDF      = "pdf"
+	TypeDOCX     = "docx"
+	TypeXLSX     = "xlsx"
+	TypePPTX     = "pptx"
+	TypeEPUB     = "epub"
+	TypeEmail    = "email"
+	TypeHTML     = "html"
+	TypeMarkdown = "markdown"
+	TypeCSV      = "csv"
+	TypeJSON     = "json"
+	TypeText     = "text"
+	TypeImage    = "image"
+	TypeAudio    = "audio"
+	TypeVideo    = "video"
+)
+
+// FileType is a kind of file, detected from the content, the content type or the uri extension.
+type FileType struct {
+	// Name is the name of the type, the key of its parser in Config.Parsers.
+	Name string
+	// Extensions are the uri extensions of the type, with the dot, e.g. ".pdf". Matched case-insensitively.
+	Extensions []string
+	// MIMETypes are the content types of the type, e.g. "application/pdf", or "image/*" for a whole family.
+	MIMETypes []string
+	// Magic reports whether the head of the content has the signature of the type, e.g. "%PDF-".
+	// It is checked first, the signatures being reliable. Optional.
+	Magic func(head []byte) bool
+	// Sniff reports whether the head of the content looks like the type, e.g. a text beginning with "<html".
+	// It is checked last, after the content type and the extension, the guess being less reliable. Optional.
+	Sniff func(head []byte) bool
+}
+
+// BuiltinTypes are the file types detected by default, in detection order.
+var BuiltinTypes = []FileType{
+	{
+		Name:       TypePDF,
+		Extensions: []string{".pdf"},
+		MIMETypes:  []string{"application/pdf", "application/x-pdf"},
+		Magic: func(head []byte) bool {
+			// readers accept the header anywhere in the first 1024 bytes
+			return bytes.Contains(head[:min(len(head), 1024)], []byte("%PDF-"))
+		},
+	},
+	{
+		Name:       TypeDOCX,
+		Extensions: []string{".docx", ".docm", ".dotx"},
+		MIMETypes:  []string{"application/vnd.openxmlformats-officedocument.wordprocessingml.document"},
+		Magic:      zipWithEntry("word/"),
+	},
+	{
+		Name:       TypeXLSX,
+		Extensions: []string{".xlsx", ".xlsm", ".xltx"},
+		MIMETypes:  []string{"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+		Magic:      zipWithEntry("xl/"),
+	},
+	{
+		Name:       TypePPTX,
+		Extensions: []string{".pptx", ".pptm", ".potx"},
+		MIMETypes:  []string{"application/vnd.openxmlformats-officedocument.presentationml.presentation"},
+		Magic:      zipWithEntry("ppt/"),
+	},
+	{
+		Name:       TypeEPUB,
+		Extensions: []string{".epub"},
+		MIMETypes:  []string{"application/epub+zip"},
+		Magic: func(head []byte) bool {
+			// the first entry of the container is the uncompressed mimetype file
+			return bytes.HasPrefix(head, []byte("PK\x03\x04")) && len(head) >= 58 &&
+				string(head[30:38]) == "mimetype" && bytes.HasPrefix(head[38:], []byte("application/epub+zip"))
+		},
+	},
+	{
+		Name:       TypeImage,
+		Extensions: []string{".png", ".jpg", ".jpeg", ".gif", ".webp", ".tif", ".tiff", ".bmp"},
+		MIMETypes:  []string{"image/*"},
+		Magic: hasPrefix("\x89PNG\r\n\x1a\n", "\xff\xd8\xff", "GIF87a", "GIF89a", "II*\x00", "MM\x00*",
+			func(head []byte) bool { return riff(head, "WEBP") }),
+	},
+	{
+		Name:       TypeAudio,
+		Extensions: []string{".mp3", ".wav", ".flac", ".ogg", ".oga", ".opus", ".m4a", ".aac", ".amr"},
+		MIMETypes:  []string{"audio/*"},
+		Magic: hasPrefix("ID3", "fLaC", "OggS", "#!AMR",
+			func(head []byte) bool { return riff(head, "WAVE") },
+			func(head []byte) bool { return ftyp(head, "M4A ") },
+			func(head []byte) bool {
+				// an mpeg audio frame, the sync bits followed by a layer
+				return len(head) >= 2 && head[0] == 0xff && head[1]&0xe0 == 0xe0 && head[1]&0x06 != 0
+			}),
+	},
+	{
+		Name:       TypeVideo,
+		Extensions: []string{".mp4", ".m4v", ".mov", ".webm", ".mkv", ".avi"},
+		MIMETypes:  []string{"video/*"},
+		Magic: hasPrefix("\x1a\x45\xdf\xa3",
+			func(head []byte) bool { return riff(head, "AVI ") },
+			func(head []byte) bool { return ftyp(head, "") }),
+	},
+	{
+		Name:       TypeEmail,
+		Extensions: []string{".eml", ".msg"},
+		MIMETypes:  []string{"message/rfc822", "application/vnd.ms-outlook"},
+		Sniff: func(head []byte) bool {
+			return emailHeaderRe.Match(head[:min(len(head), 1024)])
+		},
+	},
+	{
+		Name:       TypeHTML,
+		Extensions: []string{".html", ".htm", ".xhtml"},
+		MIMETypes:  []string{"text/html", "application/xhtml+xml"},
+		Sniff: func(head []byte) bool {
+			return strings.HasPrefix(http.DetectContentType(head), "text/html")
+		},
+	},
+	{
+		Name:       TypeJSON,
+		Extensions: []string{".json", ".jsonl", ".ndjson"},
+		MIMETypes:  []string{"application/json", "application/x-ndjson", "application/jsonl", "application/ld+json"},
+		Sniff: func(head []byte) bool {
+			head = bytes.TrimLeft(bytes.TrimPrefix(head, []byte("\xef\xbb\xbf")), " \t\r\n")
+			if len(head) < 2 || (head[0] != '{' && head[0] != '[') {
+				return false
+			}
+			next := bytes.TrimLeft(head[1:], " \t\r\n")
+			return len(next) > 0 && strings.IndexByte(`"{[]}-0123456789tfn`, next[0]) >= 0
+		},
+	},
+	{
+		Name:       TypeMarkdown,
+		Extensions: []string{".md", ".markdown", ".mdx"},
+		MIMETypes:  []string{"text/markdown", "text/x-markdown"},
+	},
+	{
+		Name:       TypeCSV,
+		Extensions: []string{".csv", ".tsv"},
+		MIMETypes:  []string{"text/csv", "text/tab-separated-values", "application/csv"},
+	},
+	{
+		Name:       TypeText,
+		Extensions: []string{".txt", ".text", ".log"},
+		MIMETypes:  []string{"text/plain"},
+	},
+}
+
+// emailHeaderRe matches the first header of a message, as written by mail servers and clients.
+var emailHeaderRe = regexp.MustCompile(`^(?i)(Return-Path|Received|Delivered-To|From|Message-ID|MIME-Version|Date|X-[A-Za-z-]+): .*\r?\n[A-Za-z-]+: `)
+
+// hasPrefix matches the content beginning with any of the signatures, given as strings or funcs.
+func hasPrefix(signatures ...any) func(head []byte) bool {
+	return func(head []byte) bool {
+		for _, sig := range signatures {
+			switch sig := sig.(type) {
+			case string:
+				if bytes.HasPrefix(head, []byte(sig)) {
+					return true
+				}
+			case func(head []byte) bool:
+				if sig(head) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+func riff(head []byte, format string) bool {
+	return len(head) >= 12 && string(head[:4]) == "RIFF" && string(head[8:12]) == format
+}
+
+// ftyp matches the iso base media files of the brand, any brand if empty.
+func ftyp(head []byte, brand string) bool {
+	if len(head) < 12 || string(head[4:8]) != "ftyp" {
+		return false
+	}
+	return brand == "" || string(head[8:12]) == brand
+}
+
+// zipWithEntry matches the zip archives with an entry under the prefix, e.g. "word/" for docx.
+// The local file headers are searched in the head, the entries of the office files being near the start.
+func zipWithEntry(prefix string) func(head []byte) bool {
+	return func(head []byte) bool {
+		if !bytes.HasPrefix(head, []byte("PK\x03\x04")) {
+			return false
+		}
+		for i := 0; i+30 <= len(head); {
+			j := bytes.Index(head[i:], []byte("PK\x03\x04"))
+			if j < 0 {
+				return false
+			}
+			i += j
+			if i+30 > len(head) {
+				return false
+			}
+			nameLen := int(binary.LittleEndian.Uint16(head[i+26:]))
+			end := min(i+30+nameLen, len(head))
+			if strings.HasPrefix(string(head[i+30:end]), prefix) {
+				return true
+			}
+			i += 4
+		}
+		return false
+	}
+}
@@ -0,0 +1,409 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package csv provides a parser mapping the rows of csv / tsv files to documents.
+package csv
+
+import (
+	"bufio"
+	"context"
+	gocsv "encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	MetaKeyRow       = "_row"
+	MetaKeyRowNumber = "_row_number"
+	MetaKeySource    = "_source"
+)
+
+// HeaderMode decides how the first row is treated.
+type HeaderMode string
+
+const (
+	// HeaderPresent treats the first row as the header, which is the default.
+	HeaderPresent HeaderMode = ""
+	// HeaderAbsent treats the first row as data, the columns are named column_1, column_2, ...
+	HeaderAbsent HeaderMode = "absent"
+	// HeaderInfer guesses whether the first row is a header by comparing it with the following rows.
+	HeaderInfer HeaderMode = "infer"
+)
+
+const (
+	// inferSampleRows is the number of data rows sampled to infer the header.
+	inferSampleRows = 20
+
+	utf8BOM = "\xef\xbb\xbf"
+)
+
+// Config is the configuration for csv parser.
+type Config struct {
+	// Delimiter is the field delimiter, optional.
+	// Default to '\t' if the uri passed by parser.WithURI ends with .tsv, otherwise ','.
+	Delimiter rune
+	// Comment is the comment character, lines beginning with it are ignored, optional.
+	Comment rune
+	// LazyQuotes allows quotes in unquoted fields and non-doubled quotes in quoted fields.
+	LazyQuotes bool
+
+	// Header decides how the first row is treated, default HeaderPresent.
+	Header HeaderMode
+
+	// ContentColumns are the columns rendered into the document content, optional, default all columns.
+	// Ignored if ContentTemplate is set.
+	ContentColumns []string
+	// ContentTemplate is a text/template rendering a row into the document content, optional,
+	// e.g. "{{.name}} is {{.age}} years old". The row is passed as map[string]string, missing columns are empty.
+	// Default to "<column>: <value>" lines of the ContentColumns, with empty values skipped.
+	ContentTemplate string
+	// MetadataColumns are the columns kept in the MetaKeyRow metadata, optional, default all columns.
+	MetadataColumns []string
+	// IDColumn is the column used as the document ID, optional.
+	IDColumn string
+}
+
+// CSVParser parses csv / tsv content into documents, one document per row.
+type CSVParser struct {
+	conf *Config
+	tmpl *template.Template
+}
+
+// NewCSVParser creates a new csv parser.
+func NewCSVParser(ctx context.Context, config *Config) (*CSVParser, error) {
+	if config == nil {
+		config = &Config{}
+	}
+
+	switch config.Header {
+	case HeaderPresent, HeaderAbsent, HeaderInfer:
+	default:
+		return nil, fmt.Errorf("new csv parser, unknown header mode: %s", config.Header)
+	}
+
+	p := &CSVParser{conf: config}
+	if config.ContentTemplate != "" {
+		tmpl, err := template.New("row").Option("missingkey=zero").Parse(config.ContentTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("new csv parser, parse content template err: %w", err)
+		}
+		p.tmpl = tmpl
+	}
+
+	return p, nil
+}
+
+// Parse parses all rows of the csv content from io.Reader.
+// The rows are read one by one, use ParseStream to avoid holding all documents of a large file in memory.
+func (p *CSVParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	var docs []*schema.Document
+	err := p.parse(ctx, reader, opts, func(doc *schema.Document) error {
+		docs = append(docs, doc)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return docs, nil
+}
+
+// ParseStream parses the csv content from io.Reader, and streams the documents as the rows are read.
+// Closing the stream reader stops the parsing.
+func (p *CSVParser) ParseStream(ctx context.Context, reader io.Reader, opts ...parser.Option) *schema.StreamReader[*schema.Document] {
+	sr, sw := schema.Pipe[*schema.Document](1)
+
+	go func() {
+		defer func() {
+			if e := recover(); e != nil {
+				sw.Send(nil, fmt.Errorf("panic occurred when parsing csv: %v", e))
+			}
+			sw.Close()
+		}()
+
+		err := p.parse(ctx, reader, opts, func(doc *schema.Document) error {
+			if closed := sw.Send(doc, nil); closed {
+				return errStreamClosed
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errStreamClosed) {
+			sw.Send(nil, err)
+		}
+	}()
+
+	return sr
+}
+
+var errStreamClosed = errors.New("stream closed")
+
+func (p *CSVParser) parse(ctx context.Context, reader io.Reader, opts []parser.Option, emit func(doc *schema.Document) error) error {
+	commonOpts := parser.GetCommonOptions(nil, opts...)
+
+	r := gocsv.NewReader(skipBOM(reader))
+	r.Comma = p.delimiter(commonOpts.URI)
+	r.Comment = p.conf.Comment
+	r.LazyQuotes = p.conf.LazyQuotes
+	r.FieldsPerRecord = -1
+
+	// the rows read ahead to infer the header, they are processed before the rest.
+	var pending [][]string
+	readRow := func() ([]string, error) {
+		if len(pending) > 0 {
+			row := pending[0]
+			pending = pending[1:]
+			return row, nil
+		}
+		return r.Read()
+	}
+
+	first, err := r.Read()
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("csv parser read err: %w", err)
+	}
+
+	hasHeader := p.conf.Header == HeaderPresent
+	if p.conf.Header == HeaderInfer {
+		for len(pending) < inferSampleRows {
+			row, err := r.Read()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("csv parser read err: %w", err)
+			}
+			pending = append(pending, row)
+		}
+		hasHeader = inferHeader(first, pending)
+	}
+
+	var header []string
+	if hasHeader {
+		header = make([]string, len(first))
+		for i, name := range first {
+			header[i] = strings.TrimSpace(name)
+		}
+	} else {
+		pending = append([][]string{first}, pending...)
+	}
+
+	contentColumns := p.conf.ContentColumns
+	metadataColumns := p.conf.MetadataColumns
+
+	rowNumber := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		row, err := readRow()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("csv parser read err: %w", err)
+		}
+		if isEmptyRow(row) {
+			continue
+		}
+		rowNumber++
+
+		// the header may be shorter than the row, the extra columns are named by their positions.
+		for len(header) < len(row) {
+			header = append(header, "column_"+strconv.Itoa(len(header)+1))
+		}
+
+		values := make(map[string]string, len(header))
+		for i, name := range header {
+			if i < len(row) {
+				values[name] = row[i]
+			} else {
+				values[name] = ""
+			}
+		}
+
+		content, err := p.content(values, header, contentColumns)
+		if err != nil {
+			return fmt.Errorf("csv parser render row %d err: %w", rowNumber, err)
+		}
+
+		meta := make(map[string]any, len(commonOpts.ExtraMeta)+3)
+		if commonOpts.URI != "" {
+			meta[MetaKeySource] = commonOpts.URI
+		}
+		for k, v := range commonOpts.ExtraMeta {
+			meta[k] = v
+		}
+		meta[MetaKeyRowNumber] = rowNumber
+		meta[MetaKeyRow] = rowMeta(values, header, metadataColumns)
+
+		doc := &schema.Document{
+			Content:  content,
+			MetaData: meta,
+		}
+		if p.conf.IDColumn != "" {
+			doc.ID = values[p.conf.IDColumn]
+		}
+
+		if err = emit(doc); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *CSVParser) delimiter(uri string) rune {
+	if p.conf.Delimiter != 0 {
+		return p.conf.Delimiter
+	}
+
+	if strings.EqualFold(filepath.Ext(uri), ".tsv") {
+		return '\t'
+	}
+
+	return ','
+}
+
+func (p *CSVParser) content(values map[string]string, header, columns []string) (string, error) {
+	if p.tmpl != nil {
+		var sb strings.Builder
+		if err := p.tmpl.Execute(&sb, values); err != nil {
+			return "", err
+		}
+		return sb.String(), nil
+	}
+
+	if len(columns) == 0 {
+		columns = header
+	}
+
+	lines := make([]string, 0, len(columns))
+	for _, column := range columns {
+		if v := strings.TrimSpace(values[column]); v != "" {
+			lines = append(lines, column+": "+v)
+		}
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func rowMeta(values map[string]string, header, columns []string) map[string]any {
+	if len(columns) == 0 {
+		columns = header
+	}
+
+	row := make(map[string]any, len(columns))
+	for _, column := range columns {
+		if v, ok := values[column]; ok {
+			row[column] = v
+		}
+	}
+
+	return row
+}
+
+func isEmptyRow(row []string) bool {
+	for _, v := range row {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func skipBOM(reader io.Reader) io.Reader {
+	br := bufio.NewReader(reader)
+	if prefix, err := br.Peek(len(utf8BOM)); err == nil && string(prefix) == utf8BOM {
+		_, _ = br.Discard(len(utf8BOM))
+	}
+	return br
+}
+
+// inferHeader guesses whether the first row is a header, the heuristic is similar to python's csv.Sniffer:
+// a header has unique non-empty cells, and each column votes by whether the first cell differs from the
+// sampled rows in type (number or not) or in length, when the sampled rows are consistent.
+func inferHeader(first []string, samples [][]string) bool {
+	seen := make(map[string]bool, len(first))
+	for _, cell := range first {
+		cell = strings.TrimSpace(cell)
+		if cell == "" || seen[cell] {
+			return false
+		}
+		seen[cell] = true
+	}
+
+	if len(samples) == 0 {
+		// a single row, it is a header only if it looks like one.
+		for _, cell := range first {
+			if isNumber(cell) {
+				return false
+			}
+		}
+		return true
+	}
+
+	votes := 0
+	for col, cell := range first {
+		allNumbers, sameLength := true, true
+		length := -1
+		for _, row := range samples {
+			if col >= len(row) {
+				continue
+			}
+			v := strings.TrimSpace(row[col])
+			if !isNumber(v) {
+				allNumbers = false
+			}
+			if length == -1 {
+				length = len(v)
+			} else if len(v) != length {
+				sameLength = false
+			}
+		}
+
+		switch {
+		case allNumbers:
+			if isNumber(cell) {
+				votes--
+			} else {
+				votes++
+			}
+		case sameLength && length >= 0:
+			if len(strings.TrimSpace(cell)) != length {
+				votes++
+			} else {
+				votes--
+			}
+		}
+	}
+
+	return votes > 0
+}
+
+func isNumber(s string) bool {
+	_, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	return err == nil
+}
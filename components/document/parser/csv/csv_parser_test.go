@@ -0,0 +1,202 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package csv
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVParser_Parse(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("default", func(t *testing.T) {
+		f, err := os.Open("./examples/testdata/test.csv")
+		require.NoError(t, err)
+		defer f.Close()
+
+		p, err := NewCSVParser(ctx, nil)
+		require.NoError(t, err)
+
+		docs, err := p.Parse(ctx, f, parser.WithURI("test.csv"), parser.WithExtraMeta(map[string]any{"test": "test"}))
+		require.NoError(t, err)
+		require.Len(t, docs, 3)
+
+		assert.Equal(t, "id: 1\nname: Alice\nage: 30\ncity: New York, NY", docs[0].Content)
+		assert.Equal(t, map[string]any{"id": "1", "name": "Alice", "age": "30", "city": "New York, NY"}, docs[0].MetaData[MetaKeyRow])
+		assert.Equal(t, 1, docs[0].MetaData[MetaKeyRowNumber])
+		assert.Equal(t, "test.csv", docs[0].MetaData[MetaKeySource])
+		assert.Equal(t, "test", docs[0].MetaData["test"])
+		assert.Empty(t, docs[0].ID)
+
+		// the empty line is skipped
+		assert.Equal(t, 3, docs[2].MetaData[MetaKeyRowNumber])
+	})
+
+	t.Run("columns and id", func(t *testing.T) {
+		f, err := os.Open("./examples/testdata/test.csv")
+		require.NoError(t, err)
+		defer f.Close()
+
+		p, err := NewCSVParser(ctx, &Config{
+			ContentColumns:  []string{"name", "city"},
+			MetadataColumns: []string{"age", "missing"},
+			IDColumn:        "id",
+		})
+		require.NoError(t, err)
+
+		docs, err := p.Parse(ctx, f)
+		require.NoError(t, err)
+		require.Len(t, docs, 3)
+		assert.Equal(t, "2", docs[1].ID)
+		assert.Equal(t, "name: Bob\ncity: London", docs[1].Content)
+		assert.Equal(t, map[string]any{"age": "25"}, docs[1].MetaData[MetaKeyRow])
+		assert.NotContains(t, docs[1].MetaData, MetaKeySource)
+	})
+
+	t.Run("template and tsv", func(t *testing.T) {
+		f, err := os.Open("./examples/testdata/test.tsv")
+		require.NoError(t, err)
+		defer f.Close()
+
+		p, err := NewCSVParser(ctx, &Config{ContentTemplate: "{{.title}} costs ${{.price}}{{.missing}}"})
+		require.NoError(t, err)
+
+		docs, err := p.Parse(ctx, f, parser.WithURI("./examples/testdata/test.tsv"))
+		require.NoError(t, err)
+		require.Len(t, docs, 2)
+		assert.Equal(t, "Keyboard costs $49.9", docs[0].Content)
+		assert.Equal(t, "Mouse costs $19.9", docs[1].Content)
+	})
+
+	t.Run("header modes", func(t *testing.T) {
+		noHeader := "1,Alice,30\n2,Bob,25\n"
+		withHeader := "\xef\xbb\xbfid,name,age\n1,Alice,30\n2,Bob,25\n"
+
+		p, err := NewCSVParser(ctx, &Config{Header: HeaderAbsent})
+		require.NoError(t, err)
+		docs, err := p.Parse(ctx, strings.NewReader(noHeader))
+		require.NoError(t, err)
+		require.Len(t, docs, 2)
+		assert.Equal(t, "column_1: 1\ncolumn_2: Alice\ncolumn_3: 30", docs[0].Content)
+
+		p, err = NewCSVParser(ctx, &Config{Header: HeaderInfer})
+		require.NoError(t, err)
+
+		docs, err = p.Parse(ctx, strings.NewReader(noHeader))
+		require.NoError(t, err)
+		assert.Len(t, docs, 2)
+
+		docs, err = p.Parse(ctx, strings.NewReader(withHeader))
+		require.NoError(t, err)
+		require.Len(t, docs, 2)
+		assert.Equal(t, "id: 1\nname: Alice\nage: 30", docs[0].Content)
+
+		// a short header gets the extra columns named by position
+		p, err = NewCSVParser(ctx, nil)
+		require.NoError(t, err)
+		docs, err = p.Parse(ctx, strings.NewReader("a\n1,2\n"))
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Equal(t, "a: 1\ncolumn_2: 2", docs[0].Content)
+
+		docs, err = p.Parse(ctx, strings.NewReader(""))
+		require.NoError(t, err)
+		assert.Empty(t, docs)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := NewCSVParser(ctx, &Config{Header: "unknown"})
+		assert.Error(t, err)
+
+		_, err = NewCSVParser(ctx, &Config{ContentTemplate: "{{.name"})
+		assert.Error(t, err)
+
+		p, err := NewCSVParser(ctx, nil)
+		require.NoError(t, err)
+		_, err = p.Parse(ctx, strings.NewReader("a,b\n\"1,2\n"))
+		assert.Error(t, err)
+	})
+}
+
+func TestCSVParser_ParseStream(t *testing.T) {
+	ctx := context.Background()
+
+	var sb strings.Builder
+	sb.WriteString("n\n")
+	for i := 0; i < 1000; i++ {
+		sb.WriteString("v\n")
+	}
+
+	p, err := NewCSVParser(ctx, nil)
+	require.NoError(t, err)
+
+	sr := p.ParseStream(ctx, strings.NewReader(sb.String()))
+	count := 0
+	for {
+		_, err := sr.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		count++
+	}
+	assert.Equal(t, 1000, count)
+
+	// closing the stream stops the parsing
+	sr = p.ParseStream(ctx, strings.NewReader(sb.String()))
+	doc, err := sr.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "n: v", doc.Content)
+	sr.Close()
+
+	sr = p.ParseStream(ctx, strings.NewReader("a\n\"b\n"))
+	_, err = sr.Recv()
+	assert.Error(t, err)
+}
+
+func TestInferHeader(t *testing.T) {
+	tests := []struct {
+		name    string
+		first   []string
+		samples [][]string
+		want    bool
+	}{
+		{name: "numeric column", first: []string{"name", "age"}, samples: [][]string{{"a", "1"}, {"bb", "22"}}, want: true},
+		{name: "numeric first row", first: []string{"x", "1"}, samples: [][]string{{"a", "2"}}, want: false},
+		{name: "same length column", first: []string{"code"}, samples: [][]string{{"AB"}, {"CD"}}, want: true},
+		{name: "duplicated cells", first: []string{"a", "a"}, samples: [][]string{{"1", "2"}}, want: false},
+		{name: "empty cell", first: []string{"a", ""}, samples: [][]string{{"1", "2"}}, want: false},
+		{name: "single text row", first: []string{"a", "b"}, want: true},
+		{name: "single numeric row", first: []string{"a", "1"}, want: false},
+		{name: "no signal", first: []string{"foo", "bar"}, samples: [][]string{{"hello", "x"}, {"hi", "yyy"}}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, inferHeader(tt.first, tt.samples))
+		})
+	}
+}
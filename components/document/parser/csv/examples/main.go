@@ -0,0 +1,64 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/components/document/parser"
+
+	"github.com/cloudwego/eino-ext/components/document/parser/csv"
+)
+
+func main() {
+	ctx := context.Background()
+
+	p, err := csv.NewCSVParser(ctx, &csv.Config{
+		ContentTemplate: "{{.name}} lives in {{.city}}.",
+		MetadataColumns: []string{"age", "city"},
+		IDColumn:        "id",
+	})
+	if err != nil {
+		log.Fatalf("NewCSVParser failed, err=%v", err)
+	}
+
+	file, err := os.Open("./testdata/test.csv")
+	if err != nil {
+		log.Fatalf("Failed to open file: %v", err)
+	}
+	defer file.Close()
+
+	// stream the rows, large files are never fully loaded into memory
+	sr := p.ParseStream(ctx, file, parser.WithURI("./testdata/test.csv"))
+	defer sr.Close()
+
+	for {
+		doc, err := sr.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			log.Fatalf("Parse failed, err=%v", err)
+		}
+		fmt.Printf("id: %s, content: %s, row: %v\n", doc.ID, doc.Content, doc.MetaData[csv.MetaKeyRow])
+	}
+}
@@ -0,0 +1,210 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package email provides a parser for email messages, in the MIME (.eml) and Outlook (.msg) formats.
+package email
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	MetaKeySubject     = "_email_subject"
+	MetaKeyFrom        = "_email_from"
+	MetaKeyTo          = "_email_to"
+	MetaKeyCc          = "_email_cc"
+	MetaKeyDate        = "_email_date"
+	MetaKeyMessageID   = "_email_message_id"
+	MetaKeyInReplyTo   = "_email_in_reply_to"
+	MetaKeyAttachments = "_email_attachments"
+	MetaKeySource      = "_source"
+
+	MetaKeyAttachmentName        = "_attachment_name"
+	MetaKeyAttachmentContentType = "_attachment_content_type"
+	MetaKeyAttachmentIndex       = "_attachment_index"
+)
+
+// oleMagic is the signature of the compound file binary format used by the Outlook .msg files.
+var oleMagic = []byte{0xd0, 0xcf, 0x11, 0xe0, 0xa1, 0xb1, 0x1a, 0xe1}
+
+// Config is the configuration for email parser.
+type Config struct {
+	// PreferHTML uses the html body, converted to text, even when the message has a plain text alternative.
+	PreferHTML bool
+	// AttachmentParser parses the attachments into documents following the message document, called with
+	// the attachment file name as URI, so that a parser.ExtParser can choose the parser by the extension.
+	// Optional. When nil, the attachments are only listed in the metadata of the message document.
+	AttachmentParser parser.Parser
+	// AttachmentFilter decides whether the attachment is parsed by AttachmentParser, e.g. to skip the images.
+	// Optional. All attachments are parsed when nil.
+	AttachmentFilter func(name, contentType string) bool
+	// MaxAttachmentSize skips parsing the attachments larger than it, in bytes. 0 means no limit.
+	MaxAttachmentSize int64
+}
+
+// EmailParser reads from io.Reader and parses the email message into documents: the first document is the message body,
+// with the headers as metadata, followed by the documents of the attachments if Config.AttachmentParser is set.
+type EmailParser struct {
+	conf *Config
+}
+
+// NewEmailParser creates a new email parser.
+func NewEmailParser(ctx context.Context, config *Config) (*EmailParser, error) {
+	if config == nil {
+		config = &Config{}
+	}
+
+	return &EmailParser{conf: config}, nil
+}
+
+// message is the format independent content of an email message.
+type message struct {
+	subject     string
+	from        string
+	to          []string
+	cc          []string
+	date        time.Time
+	messageID   string
+	inReplyTo   string
+	body        string
+	attachments []*attachment
+}
+
+type attachment struct {
+	name        string
+	contentType string
+	data        []byte
+}
+
+// Parse parses the email message from io.Reader. The format is detected from the content: Outlook .msg files are
+// recognized by their signature, anything else is read as a MIME message.
+func (ep *EmailParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	commonOpts := parser.GetCommonOptions(nil, opts...)
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("email parser read all from reader failed: %w", err)
+	}
+
+	var msg *message
+	if bytes.HasPrefix(data, oleMagic) {
+		msg, err = parseMSG(data, ep.conf)
+	} else {
+		msg, err = parseEML(data, ep.conf)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	meta := make(map[string]any, len(commonOpts.ExtraMeta)+8)
+	if commonOpts.URI != "" {
+		meta[MetaKeySource] = commonOpts.URI
+	}
+	for k, v := range commonOpts.ExtraMeta {
+		meta[k] = v
+	}
+	if msg.subject != "" {
+		meta[MetaKeySubject] = msg.subject
+	}
+	if msg.from != "" {
+		meta[MetaKeyFrom] = msg.from
+	}
+	if len(msg.to) > 0 {
+		meta[MetaKeyTo] = msg.to
+	}
+	if len(msg.cc) > 0 {
+		meta[MetaKeyCc] = msg.cc
+	}
+	if !msg.date.IsZero() {
+		meta[MetaKeyDate] = msg.date
+	}
+	if msg.messageID != "" {
+		meta[MetaKeyMessageID] = msg.messageID
+	}
+	if msg.inReplyTo != "" {
+		meta[MetaKeyInReplyTo] = msg.inReplyTo
+	}
+
+	msgMeta := copyMeta(meta)
+	if len(msg.attachments) > 0 {
+		names := make([]string, 0, len(msg.attachments))
+		for _, att := range msg.attachments {
+			names = append(names, att.name)
+		}
+		msgMeta[MetaKeyAttachments] = names
+	}
+
+	docs := []*schema.Document{{
+		Content:  msg.body,
+		MetaData: msgMeta,
+	}}
+
+	if ep.conf.AttachmentParser == nil {
+		return docs, nil
+	}
+
+	for i, att := range msg.attachments {
+		if ep.conf.MaxAttachmentSize > 0 && int64(len(att.data)) > ep.conf.MaxAttachmentSize {
+			continue
+		}
+		if ep.conf.AttachmentFilter != nil && !ep.conf.AttachmentFilter(att.name, att.contentType) {
+			continue
+		}
+
+		attMeta := copyMeta(meta)
+		attMeta[MetaKeyAttachmentName] = att.name
+		attMeta[MetaKeyAttachmentIndex] = i
+		if att.contentType != "" {
+			attMeta[MetaKeyAttachmentContentType] = att.contentType
+		}
+
+		attDocs, err := ep.conf.AttachmentParser.Parse(ctx, bytes.NewReader(att.data),
+			parser.WithURI(att.name), parser.WithExtraMeta(attMeta))
+		if err != nil {
+			return nil, fmt.Errorf("parse attachment %s failed: %w", att.name, err)
+		}
+
+		for _, doc := range attDocs {
+			if doc == nil {
+				continue
+			}
+			if doc.MetaData == nil {
+				doc.MetaData = make(map[string]any, len(attMeta))
+			}
+			for k, v := range attMeta {
+				doc.MetaData[k] = v
+			}
+			docs = append(docs, doc)
+		}
+	}
+
+	return docs, nil
+}
+
+func copyMeta(meta map[string]any) map[string]any {
+	res := make(map[string]any, len(meta)+3)
+	for k, v := range meta {
+		res[k] = v
+	}
+	return res
+}
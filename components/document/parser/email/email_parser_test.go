@@ -0,0 +1,156 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package email
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailParser_ParseEML(t *testing.T) {
+	ctx := context.Background()
+
+	data, err := os.ReadFile("./examples/testdata/test.eml")
+	require.NoError(t, err)
+
+	t.Run("default", func(t *testing.T) {
+		p, err := NewEmailParser(ctx, nil)
+		require.NoError(t, err)
+
+		docs, err := p.Parse(ctx, bytes.NewReader(data),
+			parser.WithURI("test.eml"), parser.WithExtraMeta(map[string]any{"test": "test"}))
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+
+		// the plain text alternative, decoded from quoted-printable latin-1
+		assert.Equal(t, "Hello team,\n\nThe build is broken: café machine is down.", docs[0].Content)
+		assert.Equal(t, "Build failed — please help", docs[0].MetaData[MetaKeySubject])
+		assert.Equal(t, "José García <jose@example.com>", docs[0].MetaData[MetaKeyFrom])
+		assert.Equal(t, []string{"Support <support@example.com>", "ops@example.com"}, docs[0].MetaData[MetaKeyTo])
+		assert.Equal(t, []string{"Lead, Team <lead@example.com>"}, docs[0].MetaData[MetaKeyCc])
+		assert.True(t, time.Date(2025, 10, 14, 7, 30, 0, 0, time.UTC).Equal(docs[0].MetaData[MetaKeyDate].(time.Time)))
+		assert.Equal(t, "<ticket-42@example.com>", docs[0].MetaData[MetaKeyMessageID])
+		assert.Equal(t, "<ticket-41@example.com>", docs[0].MetaData[MetaKeyInReplyTo])
+		assert.Equal(t, []string{"notes.txt", "attachment-2.png"}, docs[0].MetaData[MetaKeyAttachments])
+		assert.Equal(t, "test.eml", docs[0].MetaData[MetaKeySource])
+		assert.Equal(t, "test", docs[0].MetaData["test"])
+	})
+
+	t.Run("prefer html", func(t *testing.T) {
+		p, err := NewEmailParser(ctx, &Config{PreferHTML: true})
+		require.NoError(t, err)
+
+		docs, err := p.Parse(ctx, bytes.NewReader(data))
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Equal(t, "Hello team,\n\nThe build is broken:\nsee the log.\n\n- first\n- second", docs[0].Content)
+		assert.NotContains(t, docs[0].MetaData, MetaKeySource)
+	})
+
+	t.Run("attachments", func(t *testing.T) {
+		extParser, err := parser.NewExtParser(ctx, &parser.ExtParserConfig{})
+		require.NoError(t, err)
+
+		p, err := NewEmailParser(ctx, &Config{
+			AttachmentParser: extParser,
+			AttachmentFilter: func(name, contentType string) bool {
+				return !strings.HasPrefix(contentType, "image/")
+			},
+		})
+		require.NoError(t, err)
+
+		docs, err := p.Parse(ctx, bytes.NewReader(data), parser.WithURI("test.eml"))
+		require.NoError(t, err)
+		require.Len(t, docs, 2)
+
+		assert.Equal(t, "Ticket notes\nline two\n", docs[1].Content)
+		assert.Equal(t, "notes.txt", docs[1].MetaData[MetaKeyAttachmentName])
+		assert.Equal(t, "text/plain", docs[1].MetaData[MetaKeyAttachmentContentType])
+		assert.Equal(t, 0, docs[1].MetaData[MetaKeyAttachmentIndex])
+		assert.Equal(t, "Build failed — please help", docs[1].MetaData[MetaKeySubject])
+		assert.Equal(t, "test.eml", docs[1].MetaData[MetaKeySource])
+		assert.NotContains(t, docs[1].MetaData, MetaKeyAttachments)
+	})
+
+	t.Run("max attachment size", func(t *testing.T) {
+		p, err := NewEmailParser(ctx, &Config{AttachmentParser: parser.TextParser{}, MaxAttachmentSize: 10})
+		require.NoError(t, err)
+
+		docs, err := p.Parse(ctx, bytes.NewReader(data))
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+	})
+
+	t.Run("single part html", func(t *testing.T) {
+		p, err := NewEmailParser(ctx, nil)
+		require.NoError(t, err)
+
+		raw := "From: a@example.com\r\nSubject: hi\r\nContent-Type: text/html; charset=utf-8\r\n\r\n" +
+			"<p>one</p><table><tr><td>a</td><td>b</td></tr></table><script>x()</script>"
+		docs, err := p.Parse(ctx, strings.NewReader(raw))
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Equal(t, "one\n\na b", docs[0].Content)
+		assert.Equal(t, "a@example.com", docs[0].MetaData[MetaKeyFrom])
+		assert.NotContains(t, docs[0].MetaData, MetaKeyAttachments)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		p, err := NewEmailParser(ctx, nil)
+		require.NoError(t, err)
+
+		_, err = p.Parse(ctx, strings.NewReader("not an email"))
+		assert.Error(t, err)
+	})
+}
+
+func TestEmailParser_ParseMSG(t *testing.T) {
+	ctx := context.Background()
+
+	data, err := os.ReadFile("./examples/testdata/test.msg")
+	require.NoError(t, err)
+
+	p, err := NewEmailParser(ctx, &Config{AttachmentParser: parser.TextParser{}, AttachmentFilter: func(name, contentType string) bool {
+		return name == "test.doc"
+	}})
+	require.NoError(t, err)
+
+	docs, err := p.Parse(ctx, bytes.NewReader(data), parser.WithURI("test.msg"))
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+
+	assert.True(t, strings.HasPrefix(docs[0].Content, "Test"))
+	assert.Equal(t, "test", docs[0].MetaData[MetaKeySubject])
+	assert.Equal(t, "Lehane, Richard <Richard.Lehane@records.nsw.gov.au>", docs[0].MetaData[MetaKeyFrom])
+	assert.Equal(t, []string{"Lehane, Richard <Richard.Lehane@records.nsw.gov.au>"}, docs[0].MetaData[MetaKeyTo])
+	assert.Equal(t, "<3031EDB65352F345AF0723D0EBAAAE463A3D90@EXCHANGE.records.nsw.gov.au>", docs[0].MetaData[MetaKeyMessageID])
+	assert.False(t, docs[0].MetaData[MetaKeyDate].(time.Time).IsZero())
+	assert.Equal(t, []string{"test.doc", "image001.gif"}, docs[0].MetaData[MetaKeyAttachments])
+	assert.Equal(t, "test.msg", docs[0].MetaData[MetaKeySource])
+
+	assert.Equal(t, "test.doc", docs[1].MetaData[MetaKeyAttachmentName])
+	assert.Equal(t, 0, docs[1].MetaData[MetaKeyAttachmentIndex])
+	assert.NotContains(t, docs[1].MetaData, MetaKeyAttachmentContentType)
+}
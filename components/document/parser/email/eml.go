@@ -0,0 +1,291 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package email
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+var wordDecoder = &mime.WordDecoder{CharsetReader: charsetReader}
+
+// parseEML parses the MIME message, see RFC 5322 and RFC 2045.
+func parseEML(data []byte, conf *Config) (*message, error) {
+	m, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("read email message failed: %w", err)
+	}
+
+	msg := &message{
+		subject:   decodeHeader(m.Header.Get("Subject")),
+		to:        addressList(m.Header, "To"),
+		cc:        addressList(m.Header, "Cc"),
+		messageID: strings.TrimSpace(m.Header.Get("Message-Id")),
+		inReplyTo: strings.TrimSpace(m.Header.Get("In-Reply-To")),
+	}
+	if from := addressList(m.Header, "From"); len(from) > 0 {
+		msg.from = from[0]
+	}
+	if date, err := m.Header.Date(); err == nil {
+		msg.date = date
+	}
+
+	content := &mimeContent{}
+	if err = content.walk(textproto.MIMEHeader(m.Header), m.Body, conf); err != nil {
+		return nil, err
+	}
+
+	msg.body = strings.Join(content.texts, "\n\n")
+	msg.attachments = content.attachments
+	for i, att := range msg.attachments {
+		if att.name != "" {
+			continue
+		}
+		att.name = fmt.Sprintf("attachment-%d", i+1)
+		if att.contentType == "message/rfc822" {
+			att.name += ".eml"
+		} else if exts, _ := mime.ExtensionsByType(att.contentType); len(exts) > 0 {
+			att.name += exts[0]
+		}
+	}
+
+	return msg, nil
+}
+
+// mimeContent collects the text bodies and the attachments of the MIME parts.
+type mimeContent struct {
+	texts       []string
+	hasPlain    bool
+	hasHTML     bool
+	attachments []*attachment
+}
+
+func (c *mimeContent) walk(header textproto.MIMEHeader, body io.Reader, conf *Config) error {
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		return c.walkMultipart(mediaType, params["boundary"], body, conf)
+	}
+
+	disposition, dispParams, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+	name := dispParams["filename"]
+	if name == "" {
+		name = params["name"]
+	}
+	name = decodeHeader(name)
+
+	body = transferDecoder(header, body)
+
+	isText := mediaType == "text/plain" || mediaType == "text/html"
+	if isText && disposition != "attachment" && name == "" {
+		text, err := readText(mediaType, params["charset"], body)
+		if err != nil {
+			return err
+		}
+		if text != "" {
+			c.texts = append(c.texts, text)
+		}
+		c.hasPlain = c.hasPlain || mediaType == "text/plain"
+		c.hasHTML = c.hasHTML || mediaType == "text/html"
+		return nil
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("read email part %s failed: %w", mediaType, err)
+	}
+
+	c.attachments = append(c.attachments, &attachment{
+		name:        name,
+		contentType: mediaType,
+		data:        data,
+	})
+
+	return nil
+}
+
+// walkMultipart walks the parts of the multipart body. Only one of the multipart/alternative parts is kept,
+// the plain text one unless Config.PreferHTML is set.
+func (c *mimeContent) walkMultipart(mediaType, boundary string, body io.Reader, conf *Config) error {
+	if boundary == "" {
+		return fmt.Errorf("email part %s has no boundary", mediaType)
+	}
+
+	var parts []*mimeContent
+	mr := multipart.NewReader(body, boundary)
+	for {
+		part, err := mr.NextRawPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("read email part of %s failed: %w", mediaType, err)
+		}
+
+		pc := &mimeContent{}
+		if err = pc.walk(part.Header, part, conf); err != nil {
+			return err
+		}
+		parts = append(parts, pc)
+	}
+
+	if mediaType != "multipart/alternative" {
+		for _, pc := range parts {
+			c.merge(pc, true)
+		}
+		return nil
+	}
+
+	// the alternatives are in increasing order of preference, see RFC 2046 section 5.1.4.
+	var chosen *mimeContent
+	for _, pc := range parts {
+		if (conf.PreferHTML && pc.hasHTML) || (!conf.PreferHTML && pc.hasPlain && chosen == nil) {
+			chosen = pc
+		}
+	}
+	if chosen == nil {
+		for _, pc := range parts {
+			if len(pc.texts) > 0 {
+				chosen = pc
+			}
+		}
+	}
+
+	for _, pc := range parts {
+		c.merge(pc, pc == chosen)
+	}
+
+	return nil
+}
+
+func (c *mimeContent) merge(o *mimeContent, withText bool) {
+	if withText {
+		c.texts = append(c.texts, o.texts...)
+		c.hasPlain = c.hasPlain || o.hasPlain
+		c.hasHTML = c.hasHTML || o.hasHTML
+	}
+	c.attachments = append(c.attachments, o.attachments...)
+}
+
+// transferDecoder decodes the Content-Transfer-Encoding of the part body.
+func transferDecoder(header textproto.MIMEHeader, body io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(header.Get("Content-Transfer-Encoding"))) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	default:
+		return body
+	}
+}
+
+// readText reads the text part as utf-8, converting the html to text.
+func readText(mediaType, charset string, body io.Reader) (string, error) {
+	r, err := charsetReader(charset, body)
+	if err != nil {
+		// read the unknown charset as is rather than losing the body
+		r = body
+	}
+
+	if mediaType == "text/html" {
+		return htmlToText(r)
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("read email text failed: %w", err)
+	}
+
+	return normalizeText(string(data)), nil
+}
+
+func normalizeText(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(strings.TrimSpace(charset)) {
+	case "", "utf-8", "utf8", "us-ascii", "ascii":
+		return input, nil
+	}
+
+	enc, err := htmlindex.Get(charset)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported charset %s: %w", charset, err)
+	}
+
+	return enc.NewDecoder().Reader(input), nil
+}
+
+// decodeHeader decodes the RFC 2047 encoded words of the header value.
+func decodeHeader(value string) string {
+	decoded, err := wordDecoder.DecodeHeader(value)
+	if err != nil {
+		return strings.TrimSpace(value)
+	}
+	return strings.TrimSpace(decoded)
+}
+
+// addressList returns the addresses of the header formatted as "Name <address>".
+func addressList(header mail.Header, key string) []string {
+	value := header.Get(key)
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+
+	addrs, err := (&mail.AddressParser{WordDecoder: wordDecoder}).ParseList(value)
+	if err != nil {
+		return []string{decodeHeader(value)}
+	}
+
+	res := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		res = append(res, formatAddress(addr.Name, addr.Address))
+	}
+
+	return res
+}
+
+func formatAddress(name, address string) string {
+	switch {
+	case name == "":
+		return address
+	case address == "":
+		return name
+	default:
+		return name + " <" + address + ">"
+	}
+}
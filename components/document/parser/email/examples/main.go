@@ -0,0 +1,68 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document/parser"
+
+	"github.com/cloudwego/eino-ext/components/document/parser/email"
+)
+
+func main() {
+	ctx := context.Background()
+
+	file, err := os.Open("./testdata/test.eml")
+	if err != nil {
+		log.Fatalf("Failed to open file: %v", err)
+	}
+	defer file.Close()
+
+	extParser, err := parser.NewExtParser(ctx, &parser.ExtParserConfig{})
+	if err != nil {
+		log.Fatalf("Failed to create ext parser: %v", err)
+	}
+
+	p, err := email.NewEmailParser(ctx, &email.Config{
+		AttachmentParser: extParser,
+		AttachmentFilter: func(name, contentType string) bool {
+			return !strings.HasPrefix(contentType, "image/")
+		},
+	})
+	if err != nil {
+		log.Fatalf("Failed to create parser: %v", err)
+	}
+
+	docs, err := p.Parse(ctx, file, parser.WithURI("./testdata/test.eml"))
+	if err != nil {
+		log.Fatalf("Failed to parse email: %v", err)
+	}
+
+	fmt.Printf("Subject: %v\nFrom: %v\nTo: %v\nAttachments: %v\n\n", docs[0].MetaData[email.MetaKeySubject],
+		docs[0].MetaData[email.MetaKeyFrom], docs[0].MetaData[email.MetaKeyTo], docs[0].MetaData[email.MetaKeyAttachments])
+	fmt.Println(docs[0].Content)
+
+	for _, doc := range docs[1:] {
+		fmt.Printf("\n--- Attachment %v ---\n", doc.MetaData[email.MetaKeyAttachmentName])
+		fmt.Println(doc.Content)
+	}
+}
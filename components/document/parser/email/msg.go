@@ -0,0 +1,298 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package email
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"strings"
+	"time"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/richardlehane/mscfb"
+	"golang.org/x/net/html/charset"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// The storages and streams of the Outlook .msg file, see [MS-OXMSG].
+const (
+	msgRecipientPrefix  = "__recip_version1.0_#"
+	msgAttachmentPrefix = "__attach_version1.0_#"
+	msgStreamPrefix     = "__substg1.0_"
+	msgPropertiesStream = "__properties_version1.0"
+)
+
+// The properties of the message, recipient and attachment objects, see [MS-OXPROPS].
+const (
+	propSubject                      = 0x0037
+	propClientSubmitTime             = 0x0039
+	propTransportHeaders             = 0x007D
+	propSenderName                   = 0x0C1A
+	propSenderEmailAddress           = 0x0C1F
+	propRecipientType                = 0x0C15
+	propMessageDeliveryTime          = 0x0E06
+	propBody                         = 0x1000
+	propHTML                         = 0x1013
+	propInternetMessageID            = 0x1035
+	propInReplyToID                  = 0x1042
+	propDisplayName                  = 0x3001
+	propEmailAddress                 = 0x3003
+	propAttachDataBinary             = 0x3701
+	propAttachFilename               = 0x3704
+	propAttachLongFilename           = 0x3707
+	propAttachMimeTag                = 0x370E
+	propSMTPAddress                  = 0x39FE
+	propSenderSMTPAddress            = 0x5D01
+	propSentRepresentingEmailAddress = 0x0065
+)
+
+const (
+	propTypeString8 = 0x001E
+	propTypeUnicode = 0x001F
+	propTypeBinary  = 0x0102
+
+	recipientTypeCc  = 2
+	recipientTypeBcc = 3
+)
+
+// msgObject holds the properties of a message, recipient or attachment object.
+type msgObject struct {
+	// streams holds the variable length properties, keyed by the property tag.
+	streams map[uint32][]byte
+	// fixed holds the fixed length properties, keyed by the property id.
+	fixed map[uint16]uint64
+}
+
+func newMSGObject() *msgObject {
+	return &msgObject{streams: map[uint32][]byte{}, fixed: map[uint16]uint64{}}
+}
+
+// parseMSG parses the Outlook .msg file. The body is the plain text body, or the html body when it is preferred
+// or the only one, the rtf body is not supported. Embedded message attachments are skipped.
+func parseMSG(data []byte, conf *Config) (*message, error) {
+	doc, err := mscfb.New(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("open msg failed: %w", err)
+	}
+
+	root := newMSGObject()
+	var (
+		recipients, attachments []*msgObject
+		storages                = map[string]*msgObject{}
+	)
+
+	for {
+		entry, err := doc.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read msg entry failed: %w", err)
+		}
+
+		var (
+			obj          *msgObject
+			headerLength int
+		)
+		switch {
+		case len(entry.Path) == 0:
+			obj, headerLength = root, 32
+		case len(entry.Path) == 1 && strings.HasPrefix(entry.Path[0], msgRecipientPrefix),
+			len(entry.Path) == 1 && strings.HasPrefix(entry.Path[0], msgAttachmentPrefix):
+			storage := entry.Path[0]
+			obj = storages[storage]
+			if obj == nil {
+				obj = newMSGObject()
+				storages[storage] = obj
+				if strings.HasPrefix(storage, msgRecipientPrefix) {
+					recipients = append(recipients, obj)
+				} else {
+					attachments = append(attachments, obj)
+				}
+			}
+			headerLength = 8
+		default:
+			// named property mappings and embedded messages
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(entry.Name, msgStreamPrefix):
+			var tag uint32
+			if _, err = fmt.Sscanf(strings.TrimPrefix(entry.Name, msgStreamPrefix), "%08X", &tag); err != nil {
+				continue
+			}
+			if obj.streams[tag], err = io.ReadAll(entry); err != nil {
+				return nil, fmt.Errorf("read msg stream %s failed: %w", entry.Name, err)
+			}
+		case entry.Name == msgPropertiesStream:
+			b, err := io.ReadAll(entry)
+			if err != nil {
+				return nil, fmt.Errorf("read msg properties failed: %w", err)
+			}
+			obj.readFixed(b, headerLength)
+		}
+	}
+
+	msg := &message{
+		subject:   root.str(propSubject),
+		messageID: root.str(propInternetMessageID),
+		inReplyTo: root.str(propInReplyToID),
+	}
+
+	senderAddr := firstSMTPAddress(root.str(propSenderSMTPAddress), root.str(propSenderEmailAddress),
+		root.str(propSentRepresentingEmailAddress))
+	msg.from = formatAddress(root.str(propSenderName), senderAddr)
+
+	for _, r := range recipients {
+		addr := formatAddress(r.str(propDisplayName), firstSMTPAddress(r.str(propSMTPAddress), r.str(propEmailAddress)))
+		if addr == "" {
+			continue
+		}
+		switch r.fixed[propRecipientType] {
+		case recipientTypeCc:
+			msg.cc = append(msg.cc, addr)
+		case recipientTypeBcc:
+			// bcc is not part of the sent message
+		default:
+			msg.to = append(msg.to, addr)
+		}
+	}
+
+	// the transport headers of received messages hold the original date and ids
+	if headers := root.str(propTransportHeaders); headers != "" {
+		if m, err := mail.ReadMessage(strings.NewReader(strings.TrimRight(headers, "\r\n") + "\r\n\r\n")); err == nil {
+			if date, err := m.Header.Date(); err == nil {
+				msg.date = date
+			}
+			if id := strings.TrimSpace(m.Header.Get("Message-Id")); id != "" && msg.messageID == "" {
+				msg.messageID = id
+			}
+			if id := strings.TrimSpace(m.Header.Get("In-Reply-To")); id != "" && msg.inReplyTo == "" {
+				msg.inReplyTo = id
+			}
+		}
+	}
+	if msg.date.IsZero() {
+		msg.date = root.time(propClientSubmitTime)
+	}
+	if msg.date.IsZero() {
+		msg.date = root.time(propMessageDeliveryTime)
+	}
+
+	body := normalizeText(root.str(propBody))
+	if htmlBody := root.bytes(propHTML); len(htmlBody) > 0 && (conf.PreferHTML || body == "") {
+		r, err := charset.NewReader(bytes.NewReader(htmlBody), "text/html")
+		if err != nil {
+			return nil, fmt.Errorf("read msg html body failed: %w", err)
+		}
+		if body, err = htmlToText(r); err != nil {
+			return nil, err
+		}
+	}
+	msg.body = body
+
+	for i, a := range attachments {
+		data, ok := a.streams[propAttachDataBinary<<16|propTypeBinary]
+		if !ok {
+			continue
+		}
+
+		name := a.str(propAttachLongFilename)
+		if name == "" {
+			name = a.str(propAttachFilename)
+		}
+		if name == "" {
+			name = a.str(propDisplayName)
+		}
+		if name == "" {
+			name = fmt.Sprintf("attachment-%d", i+1)
+		}
+
+		msg.attachments = append(msg.attachments, &attachment{
+			name:        name,
+			contentType: a.str(propAttachMimeTag),
+			data:        data,
+		})
+	}
+
+	return msg, nil
+}
+
+// readFixed reads the fixed length properties of the properties stream, which are 16 bytes entries after the header.
+func (o *msgObject) readFixed(b []byte, headerLength int) {
+	for i := headerLength; i+16 <= len(b); i += 16 {
+		tag := binary.LittleEndian.Uint32(b[i:])
+		o.fixed[uint16(tag>>16)] = binary.LittleEndian.Uint64(b[i+8:])
+	}
+}
+
+// str returns the string property, stored as utf-16 or 8-bit string.
+func (o *msgObject) str(id uint16) string {
+	if b, ok := o.streams[uint32(id)<<16|propTypeUnicode]; ok {
+		u := make([]uint16, 0, len(b)/2)
+		for i := 0; i+1 < len(b); i += 2 {
+			u = append(u, binary.LittleEndian.Uint16(b[i:]))
+		}
+		return strings.TrimSpace(strings.TrimRight(string(utf16.Decode(u)), "\x00"))
+	}
+
+	if b, ok := o.streams[uint32(id)<<16|propTypeString8]; ok {
+		b = bytes.TrimRight(b, "\x00")
+		if !utf8.Valid(b) {
+			if decoded, err := charmap.Windows1252.NewDecoder().Bytes(b); err == nil {
+				b = decoded
+			}
+		}
+		return strings.TrimSpace(string(b))
+	}
+
+	return ""
+}
+
+// bytes returns the binary property, falling back to the string property.
+func (o *msgObject) bytes(id uint16) []byte {
+	if b, ok := o.streams[uint32(id)<<16|propTypeBinary]; ok {
+		return b
+	}
+	return []byte(o.str(id))
+}
+
+// time returns the time property, stored as FILETIME: 100-nanosecond intervals since January 1, 1601 UTC.
+func (o *msgObject) time(id uint16) time.Time {
+	const epochDiff = 116444736000000000 // from 1601-01-01 to 1970-01-01
+	v, ok := o.fixed[id]
+	if !ok || v <= epochDiff {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(v-epochDiff)*100).UTC()
+}
+
+// firstSMTPAddress returns the first address that is not an Exchange X500 address.
+func firstSMTPAddress(addrs ...string) string {
+	for _, addr := range addrs {
+		if addr != "" && !strings.HasPrefix(addr, "/") {
+			return addr
+		}
+	}
+	return ""
+}
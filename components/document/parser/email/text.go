@@ -0,0 +1,110 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package email
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// lineBreak and paragraphBreak mark the breaks of the html layout, which survive the whitespace collapsing.
+const (
+	lineBreak      = "\x00"
+	paragraphBreak = "\x01"
+)
+
+var (
+	spacesRegexp     = regexp.MustCompile(`[ \t\r\n\f\x{00a0}]+`)
+	lineBreakRegexp  = regexp.MustCompile(` ?\x00[ \x00]*`)
+	blankLinesRegexp = regexp.MustCompile(`\n{3,}`)
+)
+
+var paragraphAtoms = map[atom.Atom]bool{
+	atom.Address: true, atom.Article: true, atom.Aside: true, atom.Blockquote: true, atom.Body: true,
+	atom.Div: true, atom.Dl: true, atom.Figure: true, atom.Footer: true, atom.Header: true, atom.Hr: true,
+	atom.Main: true, atom.Nav: true, atom.Ol: true, atom.P: true, atom.Pre: true, atom.Section: true,
+	atom.Table: true, atom.Ul: true, atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true,
+	atom.H5: true, atom.H6: true,
+}
+
+var lineAtoms = map[atom.Atom]bool{
+	atom.Dd: true, atom.Dt: true, atom.Figcaption: true, atom.Li: true, atom.Tr: true,
+}
+
+// htmlToText converts the html body to text: paragraphs are separated by blank lines,
+// list items and table rows are on their own lines, and the list items are prefixed with "-".
+func htmlToText(r io.Reader) (string, error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return "", fmt.Errorf("parse email html failed: %w", err)
+	}
+
+	var sb strings.Builder
+	var walk func(n *html.Node, pre bool)
+	walk = func(n *html.Node, pre bool) {
+		var before, after string
+		switch n.Type {
+		case html.TextNode:
+			if pre {
+				sb.WriteString(strings.ReplaceAll(n.Data, "\n", lineBreak))
+			} else {
+				sb.WriteString(n.Data)
+			}
+			return
+		case html.ElementNode:
+			switch {
+			case n.DataAtom == atom.Head || n.DataAtom == atom.Script || n.DataAtom == atom.Style ||
+				n.DataAtom == atom.Template || n.DataAtom == atom.Noscript:
+				return
+			case n.DataAtom == atom.Br:
+				sb.WriteString(lineBreak)
+				return
+			case n.DataAtom == atom.Td || n.DataAtom == atom.Th:
+				before = " "
+			case n.DataAtom == atom.Li:
+				before, after = lineBreak+"- ", lineBreak
+			case paragraphAtoms[n.DataAtom]:
+				before, after = paragraphBreak, paragraphBreak
+			case lineAtoms[n.DataAtom]:
+				before, after = lineBreak, lineBreak
+			}
+			pre = pre || n.DataAtom == atom.Pre
+		}
+
+		sb.WriteString(before)
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c, pre)
+		}
+		sb.WriteString(after)
+	}
+	walk(doc, false)
+
+	var paragraphs []string
+	for _, p := range strings.Split(spacesRegexp.ReplaceAllString(sb.String(), " "), paragraphBreak) {
+		p = strings.TrimSpace(lineBreakRegexp.ReplaceAllString(p, "\n"))
+		if p != "" {
+			paragraphs = append(paragraphs, p)
+		}
+	}
+
+	return blankLinesRegexp.ReplaceAllString(strings.Join(paragraphs, "\n\n"), "\n\n"), nil
+}
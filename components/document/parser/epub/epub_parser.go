@@ -0,0 +1,438 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package epub provides a parser extracting the chapters of EPUB e-books.
+package epub
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	MetaKeyBookTitle    = "_book_title"
+	MetaKeyAuthors      = "_book_authors"
+	MetaKeyLanguage     = "_book_language"
+	MetaKeyIdentifier   = "_book_identifier"
+	MetaKeyChapterTitle = "_chapter_title"
+	MetaKeyChapterIndex = "_chapter_index"
+	MetaKeyChapterHref  = "_chapter_href"
+	MetaKeySource       = "_source"
+)
+
+// Config is the configuration for EPUB parser.
+type Config struct {
+	// IncludeNonLinear includes the spine items marked as non-linear, which are auxiliary content
+	// such as the cover or footnote pages.
+	IncludeNonLinear bool
+}
+
+// EPUBParser reads from io.Reader and parses the EPUB e-book into documents, one document per chapter in reading order.
+type EPUBParser struct {
+	conf *Config
+}
+
+// NewEPUBParser creates a new EPUB parser.
+func NewEPUBParser(ctx context.Context, config *Config) (*EPUBParser, error) {
+	if config == nil {
+		config = &Config{}
+	}
+
+	return &EPUBParser{conf: config}, nil
+}
+
+// Parse parses the EPUB content from io.Reader. The chapters are the xhtml documents of the spine, titled by
+// the table of contents (EPUB 3 nav or EPUB 2 ncx), falling back to their first heading. Empty chapters are skipped.
+func (ep *EPUBParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) (docs []*schema.Document, err error) {
+	commonOpts := parser.GetCommonOptions(nil, opts...)
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("epub parser read all from reader failed: %w", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("open epub failed: %w", err)
+	}
+
+	book := &epubBook{files: make(map[string]*zip.File, len(zr.File))}
+	for _, f := range zr.File {
+		book.files[f.Name] = f
+	}
+
+	pkg, opfPath, err := book.readPackage()
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := make(map[string]manifestItem, len(pkg.Manifest))
+	for _, item := range pkg.Manifest {
+		manifest[item.ID] = item
+	}
+
+	titles := book.tocTitles(pkg, manifest, opfPath)
+
+	bookMeta := map[string]any{}
+	if title := firstNonEmpty(pkg.Metadata.Titles); title != "" {
+		bookMeta[MetaKeyBookTitle] = title
+	}
+	if authors := nonEmpty(pkg.Metadata.Creators); len(authors) > 0 {
+		bookMeta[MetaKeyAuthors] = authors
+	}
+	if language := firstNonEmpty(pkg.Metadata.Languages); language != "" {
+		bookMeta[MetaKeyLanguage] = language
+	}
+	if identifier := pkg.identifier(); identifier != "" {
+		bookMeta[MetaKeyIdentifier] = identifier
+	}
+
+	for _, ref := range pkg.Spine.ItemRefs {
+		if ref.Linear == "no" && !ep.conf.IncludeNonLinear {
+			continue
+		}
+
+		item, ok := manifest[ref.IDRef]
+		if !ok || (item.MediaType != "application/xhtml+xml" && item.MediaType != "text/html") {
+			continue
+		}
+
+		href := resolveHref(opfPath, item.Href)
+		text, heading, htmlTitle, err := book.readChapter(href)
+		if err != nil {
+			return nil, err
+		}
+		if text == "" {
+			continue
+		}
+
+		title := titles[href]
+		if title == "" {
+			title = heading
+		}
+		if title == "" {
+			title = htmlTitle
+		}
+
+		meta := make(map[string]any, len(commonOpts.ExtraMeta)+len(bookMeta)+4)
+		if commonOpts.URI != "" {
+			meta[MetaKeySource] = commonOpts.URI
+		}
+		for k, v := range commonOpts.ExtraMeta {
+			meta[k] = v
+		}
+		for k, v := range bookMeta {
+			meta[k] = v
+		}
+		meta[MetaKeyChapterIndex] = len(docs) + 1
+		meta[MetaKeyChapterHref] = href
+		if title != "" {
+			meta[MetaKeyChapterTitle] = title
+		}
+
+		docs = append(docs, &schema.Document{
+			Content:  text,
+			MetaData: meta,
+		})
+	}
+
+	return docs, nil
+}
+
+type epubBook struct {
+	files map[string]*zip.File
+}
+
+type opfPackage struct {
+	UniqueIdentifier string `xml:"unique-identifier,attr"`
+	Metadata         struct {
+		Titles      []string `xml:"title"`
+		Creators    []string `xml:"creator"`
+		Languages   []string `xml:"language"`
+		Identifiers []struct {
+			ID    string `xml:"id,attr"`
+			Value string `xml:",chardata"`
+		} `xml:"identifier"`
+	} `xml:"metadata"`
+	Manifest []manifestItem `xml:"manifest>item"`
+	Spine    struct {
+		Toc      string `xml:"toc,attr"`
+		ItemRefs []struct {
+			IDRef  string `xml:"idref,attr"`
+			Linear string `xml:"linear,attr"`
+		} `xml:"itemref"`
+	} `xml:"spine"`
+}
+
+type manifestItem struct {
+	ID         string `xml:"id,attr"`
+	Href       string `xml:"href,attr"`
+	MediaType  string `xml:"media-type,attr"`
+	Properties string `xml:"properties,attr"`
+}
+
+// identifier returns the unique identifier of the book, falling back to the first identifier.
+func (p *opfPackage) identifier() string {
+	for _, id := range p.Metadata.Identifiers {
+		if id.ID == p.UniqueIdentifier && strings.TrimSpace(id.Value) != "" {
+			return strings.TrimSpace(id.Value)
+		}
+	}
+	for _, id := range p.Metadata.Identifiers {
+		if v := strings.TrimSpace(id.Value); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func (b *epubBook) open(name string) (io.ReadCloser, error) {
+	f, ok := b.files[name]
+	if !ok {
+		return nil, fmt.Errorf("epub file %s not found", name)
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("open epub file %s failed: %w", name, err)
+	}
+
+	return rc, nil
+}
+
+func (b *epubBook) decode(name string, v any) error {
+	rc, err := b.open(name)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	if err = xml.NewDecoder(rc).Decode(v); err != nil {
+		return fmt.Errorf("decode epub file %s failed: %w", name, err)
+	}
+
+	return nil
+}
+
+// readPackage reads the package document (opf) located by META-INF/container.xml.
+func (b *epubBook) readPackage() (*opfPackage, string, error) {
+	var container struct {
+		RootFiles []struct {
+			FullPath  string `xml:"full-path,attr"`
+			MediaType string `xml:"media-type,attr"`
+		} `xml:"rootfiles>rootfile"`
+	}
+	if err := b.decode("META-INF/container.xml", &container); err != nil {
+		return nil, "", err
+	}
+
+	var opfPath string
+	for _, rf := range container.RootFiles {
+		if rf.MediaType == "" || rf.MediaType == "application/oebps-package+xml" {
+			opfPath = rf.FullPath
+			break
+		}
+	}
+	if opfPath == "" {
+		return nil, "", fmt.Errorf("epub package document not found in container")
+	}
+
+	pkg := &opfPackage{}
+	if err := b.decode(opfPath, pkg); err != nil {
+		return nil, "", err
+	}
+
+	return pkg, opfPath, nil
+}
+
+func (b *epubBook) readChapter(name string) (text, heading, title string, err error) {
+	rc, err := b.open(name)
+	if err != nil {
+		return "", "", "", err
+	}
+	defer rc.Close()
+
+	text, heading, title, err = chapterText(rc)
+	if err != nil {
+		return "", "", "", fmt.Errorf("parse epub chapter %s failed: %w", name, err)
+	}
+
+	return text, heading, title, nil
+}
+
+// tocTitles maps the chapter paths to their titles in the table of contents, the EPUB 3 nav document is
+// preferred over the EPUB 2 ncx. When a chapter has several entries, the first one wins.
+func (b *epubBook) tocTitles(pkg *opfPackage, manifest map[string]manifestItem, opfPath string) map[string]string {
+	titles := make(map[string]string)
+	add := func(href, title string) {
+		title = strings.TrimSpace(spacesRegexp.ReplaceAllString(title, " "))
+		if _, ok := titles[href]; !ok && title != "" {
+			titles[href] = title
+		}
+	}
+
+	for _, item := range pkg.Manifest {
+		if !hasProperty(item.Properties, "nav") {
+			continue
+		}
+
+		navPath := resolveHref(opfPath, item.Href)
+		rc, err := b.open(navPath)
+		if err != nil {
+			break
+		}
+		doc, err := html.Parse(rc)
+		_ = rc.Close()
+		if err != nil {
+			break
+		}
+
+		if toc := findTocNav(doc); toc != nil {
+			walkAnchors(toc, func(href, text string) {
+				add(resolveHref(navPath, href), text)
+			})
+		}
+		break
+	}
+
+	if item, ok := manifest[pkg.Spine.Toc]; ok && len(titles) == 0 {
+		ncxPath := resolveHref(opfPath, item.Href)
+		var ncx struct {
+			NavPoints []navPoint `xml:"navMap>navPoint"`
+		}
+		if err := b.decode(ncxPath, &ncx); err == nil {
+			var walk func(points []navPoint)
+			walk = func(points []navPoint) {
+				for _, p := range points {
+					add(resolveHref(ncxPath, p.Content.Src), p.Label)
+					walk(p.Children)
+				}
+			}
+			walk(ncx.NavPoints)
+		}
+	}
+
+	return titles
+}
+
+type navPoint struct {
+	Label   string `xml:"navLabel>text"`
+	Content struct {
+		Src string `xml:"src,attr"`
+	} `xml:"content"`
+	Children []navPoint `xml:"navPoint"`
+}
+
+// findTocNav finds the nav element of type toc, falling back to the first nav element.
+func findTocNav(doc *html.Node) *html.Node {
+	var first, toc *html.Node
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if toc != nil {
+			return
+		}
+		if n.Type == html.ElementNode && n.DataAtom == atom.Nav {
+			if first == nil {
+				first = n
+			}
+			for _, a := range n.Attr {
+				if a.Key == "epub:type" && hasProperty(a.Val, "toc") {
+					toc = n
+					return
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	if toc != nil {
+		return toc
+	}
+	return first
+}
+
+func walkAnchors(n *html.Node, fn func(href, text string)) {
+	if n.Type == html.ElementNode && n.DataAtom == atom.A {
+		for _, a := range n.Attr {
+			if a.Key == "href" {
+				fn(a.Val, nodeText(n))
+				break
+			}
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		walkAnchors(c, fn)
+	}
+}
+
+// resolveHref resolves the href relative to the referencing file to the file path in the zip,
+// the fragment is removed and the url escaping is decoded.
+func resolveHref(base, href string) string {
+	if i := strings.IndexByte(href, '#'); i >= 0 {
+		href = href[:i]
+	}
+	if unescaped, err := url.PathUnescape(href); err == nil {
+		href = unescaped
+	}
+	if strings.HasPrefix(href, "/") {
+		return strings.TrimPrefix(path.Clean(href), "/")
+	}
+
+	return path.Join(path.Dir(base), href)
+}
+
+func hasProperty(properties, property string) bool {
+	for _, p := range strings.Fields(properties) {
+		if p == property {
+			return true
+		}
+	}
+	return false
+}
+
+func firstNonEmpty(values []string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func nonEmpty(values []string) []string {
+	var res []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			res = append(res, v)
+		}
+	}
+	return res
+}
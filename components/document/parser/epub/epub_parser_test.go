@@ -0,0 +1,107 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package epub
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEPUBParser_Parse(t *testing.T) {
+	ctx := context.Background()
+
+	data, err := os.ReadFile("./examples/testdata/test.epub")
+	require.NoError(t, err)
+
+	t.Run("default", func(t *testing.T) {
+		p, err := NewEPUBParser(ctx, nil)
+		require.NoError(t, err)
+
+		docs, err := p.Parse(ctx, bytes.NewReader(data),
+			parser.WithURI("test.epub"), parser.WithExtraMeta(map[string]any{"test": "test"}))
+		require.NoError(t, err)
+		require.Len(t, docs, 3)
+
+		// reading order follows the spine, not the file names
+		assert.Equal(t, "# Getting Started\n\nInstall eino with go get.\n\n- first\n\n- second", docs[0].Content)
+		assert.Equal(t, "Getting Started", docs[0].MetaData[MetaKeyChapterTitle])
+		assert.Equal(t, 1, docs[0].MetaData[MetaKeyChapterIndex])
+		assert.Equal(t, "OEBPS/text/chapter 2.xhtml", docs[0].MetaData[MetaKeyChapterHref])
+		assert.Equal(t, "The Eino Book", docs[0].MetaData[MetaKeyBookTitle])
+		assert.Equal(t, []string{"Alice", "Bob"}, docs[0].MetaData[MetaKeyAuthors])
+		assert.Equal(t, "en", docs[0].MetaData[MetaKeyLanguage])
+		assert.Equal(t, "urn:isbn:9780000000001", docs[0].MetaData[MetaKeyIdentifier])
+		assert.Equal(t, "test.epub", docs[0].MetaData[MetaKeySource])
+		assert.Equal(t, "test", docs[0].MetaData["test"])
+
+		assert.Equal(t, "# Components\n\nA component\nhas an interface.\n\nNested & escaped", docs[1].Content)
+		assert.Equal(t, "Components and Graphs", docs[1].MetaData[MetaKeyChapterTitle])
+
+		// not in the table of contents, titled by the first heading
+		assert.Equal(t, "## Appendix A\n\nExtra notes.", docs[2].Content)
+		assert.Equal(t, "Appendix A", docs[2].MetaData[MetaKeyChapterTitle])
+		assert.Equal(t, 3, docs[2].MetaData[MetaKeyChapterIndex])
+	})
+
+	t.Run("include non linear", func(t *testing.T) {
+		p, err := NewEPUBParser(ctx, &Config{IncludeNonLinear: true})
+		require.NoError(t, err)
+
+		docs, err := p.Parse(ctx, bytes.NewReader(data))
+		require.NoError(t, err)
+		require.Len(t, docs, 4)
+		assert.Equal(t, "Cover", docs[0].Content)
+		assert.Equal(t, "Cover", docs[0].MetaData[MetaKeyChapterTitle])
+		assert.NotContains(t, docs[0].MetaData, MetaKeySource)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		p, err := NewEPUBParser(ctx, nil)
+		require.NoError(t, err)
+
+		_, err = p.Parse(ctx, bytes.NewReader([]byte("not an epub")))
+		assert.Error(t, err)
+	})
+}
+
+func TestChapterText(t *testing.T) {
+	text, heading, title, err := chapterText(strings.NewReader(`<html><head><title> Doc
+Title </title></head><body>
+<h2>Sub <em>heading</em></h2>
+<ul><li><p>in paragraph</p></li><li></li><li>plain</li></ul>
+<pre>line 1
+line 2</pre>
+<p>text&nbsp;with   spaces</p>
+</body></html>`))
+	require.NoError(t, err)
+	assert.Equal(t, "Doc Title", title)
+	assert.Equal(t, "Sub heading", heading)
+	assert.Equal(t, "## Sub heading\n\n- in paragraph\n\n- plain\n\nline 1\nline 2\n\ntext with spaces", text)
+}
+
+func TestResolveHref(t *testing.T) {
+	assert.Equal(t, "OEBPS/text/a b.xhtml", resolveHref("OEBPS/content.opf", "text/a%20b.xhtml#frag"))
+	assert.Equal(t, "OEBPS/a.xhtml", resolveHref("OEBPS/text/nav.xhtml", "../a.xhtml"))
+	assert.Equal(t, "a.xhtml", resolveHref("OEBPS/content.opf", "/a.xhtml"))
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/components/document/parser"
+
+	"github.com/cloudwego/eino-ext/components/document/parser/epub"
+)
+
+func main() {
+	ctx := context.Background()
+
+	file, err := os.Open("./testdata/test.epub")
+	if err != nil {
+		log.Fatalf("Failed to open file: %v", err)
+	}
+	defer file.Close()
+
+	p, err := epub.NewEPUBParser(ctx, nil)
+	if err != nil {
+		log.Fatalf("Failed to create parser: %v", err)
+	}
+
+	docs, err := p.Parse(ctx, file, parser.WithURI("./testdata/test.epub"))
+	if err != nil {
+		log.Fatalf("Failed to parse book: %v", err)
+	}
+
+	for _, doc := range docs {
+		fmt.Printf("--- Chapter %v: %v ---\n", doc.MetaData[epub.MetaKeyChapterIndex], doc.MetaData[epub.MetaKeyChapterTitle])
+		fmt.Println(doc.Content)
+		fmt.Println()
+	}
+}
@@ -0,0 +1,148 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package epub
+
+import (
+	"io"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// lineBreak marks the explicit line breaks, which survive the whitespace collapsing.
+const lineBreak = "\x00"
+
+var (
+	spacesRegexp    = regexp.MustCompile(`[ \t\r\n\f\x{00a0}]+`)
+	lineBreakRegexp = regexp.MustCompile(` ?\x00 ?`)
+)
+
+var blockAtoms = map[atom.Atom]bool{
+	atom.Address: true, atom.Article: true, atom.Aside: true, atom.Blockquote: true, atom.Body: true,
+	atom.Dd: true, atom.Div: true, atom.Dl: true, atom.Dt: true, atom.Figcaption: true, atom.Figure: true,
+	atom.Footer: true, atom.Header: true, atom.Hr: true, atom.Li: true, atom.Main: true, atom.Nav: true,
+	atom.Ol: true, atom.P: true, atom.Pre: true, atom.Section: true, atom.Table: true, atom.Tr: true,
+	atom.Ul: true, atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+}
+
+var headingLevels = map[atom.Atom]int{
+	atom.H1: 1, atom.H2: 2, atom.H3: 3, atom.H4: 4, atom.H5: 5, atom.H6: 6,
+}
+
+// chapterText converts the xhtml chapter to text, with the blocks separated by blank lines,
+// headings prefixed with "#" and list items with "-". It also returns the first heading and the document title.
+func chapterText(r io.Reader) (text, heading, title string, err error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	var (
+		blocks []string
+		cur    strings.Builder
+		prefix string
+	)
+	flush := func() string {
+		s := strings.TrimSpace(spacesRegexp.ReplaceAllString(cur.String(), " "))
+		s = strings.Trim(lineBreakRegexp.ReplaceAllString(s, "\n"), "\n")
+		cur.Reset()
+		if s != "" {
+			blocks = append(blocks, prefix+s)
+			prefix = ""
+		}
+		return s
+	}
+
+	var walk func(n *html.Node, pre bool)
+	walk = func(n *html.Node, pre bool) {
+		switch n.Type {
+		case html.TextNode:
+			if pre {
+				cur.WriteString(strings.ReplaceAll(n.Data, "\n", lineBreak))
+			} else {
+				cur.WriteString(n.Data)
+			}
+			return
+		case html.ElementNode:
+			switch n.DataAtom {
+			case atom.Head:
+				if t := findFirst(n, atom.Title); t != nil {
+					title = strings.TrimSpace(spacesRegexp.ReplaceAllString(nodeText(t), " "))
+				}
+				return
+			case atom.Script, atom.Style, atom.Template:
+				return
+			case atom.Br:
+				cur.WriteString(lineBreak)
+				return
+			}
+		}
+
+		block := n.Type == html.ElementNode && blockAtoms[n.DataAtom]
+		if block {
+			flush()
+			if level, ok := headingLevels[n.DataAtom]; ok {
+				prefix = strings.Repeat("#", level) + " "
+			} else if n.DataAtom == atom.Li {
+				prefix = "- "
+			}
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c, pre || n.DataAtom == atom.Pre)
+		}
+
+		if block {
+			s := flush()
+			if _, ok := headingLevels[n.DataAtom]; ok && heading == "" {
+				heading = strings.ReplaceAll(s, "\n", " ")
+			}
+			// a block without content must not leak its prefix to the next one.
+			prefix = ""
+		}
+	}
+	walk(doc, false)
+	flush()
+
+	return strings.Join(blocks, "\n\n"), heading, title, nil
+}
+
+func findFirst(n *html.Node, a atom.Atom) *html.Node {
+	if n.Type == html.ElementNode && n.DataAtom == a {
+		return n
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if found := findFirst(c, a); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func nodeText(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+
+	var sb strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		sb.WriteString(nodeText(c))
+	}
+	return sb.String()
+}
@@ -2,12 +2,12 @@ module github.com/cloudwego/eino-ext/components/document/parser/html
 
 go 1.23.0
 
-
 require (
 	github.com/PuerkitoBio/goquery v1.8.1
 	github.com/cloudwego/eino v0.3.27
 	github.com/microcosm-cc/bluemonday v1.0.27
 	github.com/stretchr/testify v1.9.0
+	golang.org/x/net v0.41.0
 )
 
 require (
@@ -42,7 +42,6 @@ require (
 	github.com/yargevad/filepathx v1.0.0 // indirect
 	golang.org/x/arch v0.11.0 // indirect
 	golang.org/x/exp v0.0.0-20230713183714-613f0c0eb8a1 // indirect
-	golang.org/x/net v0.41.0 // indirect
 	golang.org/x/sys v0.33.0 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
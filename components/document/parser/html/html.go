@@ -19,10 +19,12 @@ package html
 import (
 	"context"
 	"io"
+	"net/url"
 	"strings"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/microcosm-cc/bluemonday"
+	"golang.org/x/net/html"
 
 	"github.com/cloudwego/eino/components/document/parser"
 	"github.com/cloudwego/eino/schema"
@@ -34,6 +36,10 @@ const (
 	MetaKeyLang    = "_language"
 	MetaKeyCharset = "_charset"
 	MetaKeySource  = "_source"
+
+	MetaKeyAuthor        = "_author"
+	MetaKeyPublishedTime = "_published_time"
+	MetaKeySiteName      = "_site_name"
 )
 
 var _ parser.Parser = (*Parser)(nil)
@@ -41,6 +47,11 @@ var _ parser.Parser = (*Parser)(nil)
 type Config struct {
 	// content selector of goquery. eg: body for <body>, #id for <div id="id">
 	Selector *string
+	// Readability extracts the main content of the page, removing the navigation, ads, footers and other boilerplate,
+	// and converts it to markdown, keeping the headings, links, lists and tables.
+	// The author, published time and site name are read from the meta tags and JSON-LD as well.
+	// With Selector, the main content is searched in the selected elements.
+	Readability bool
 }
 
 var (
@@ -93,8 +104,14 @@ func (p *Parser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Opt
 		}
 	}
 
-	sanitized := bluemonday.UGCPolicy().Sanitize(contentSel.Text())
-	content := strings.TrimSpace(sanitized)
+	var content string
+	if p.conf.Readability {
+		articleMeta(doc, meta)
+		content = p.readableContent(doc, option.URI)
+	} else {
+		sanitized := bluemonday.UGCPolicy().Sanitize(contentSel.Text())
+		content = strings.TrimSpace(sanitized)
+	}
 
 	document := &schema.Document{
 		Content:  content,
@@ -106,6 +123,40 @@ func (p *Parser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Opt
 	}, nil
 }
 
+// readableContent returns the main content of the page as markdown, whose relative links are resolved
+// against the <base> of the page, or the uri.
+func (p *Parser) readableContent(doc *goquery.Document, uri string) string {
+	var base *url.URL
+	if u, err := url.Parse(uri); err == nil && u.IsAbs() {
+		base = u
+	}
+	if href, ok := doc.Find("base[href]").First().Attr("href"); ok {
+		if u, err := url.Parse(href); err == nil {
+			if base != nil {
+				u = base.ResolveReference(u)
+			}
+			if u.IsAbs() {
+				base = u
+			}
+		}
+	}
+
+	root := doc.Find("body")
+	if p.conf.Selector != nil {
+		root = doc.Find(*p.conf.Selector)
+	}
+	if root.Length() == 0 {
+		root = doc.Selection
+	}
+
+	var nodes []*html.Node
+	root.Each(func(_ int, s *goquery.Selection) {
+		nodes = append(nodes, extractMainContent(s)...)
+	})
+
+	return (&markdownConverter{base: base}).convert(nodes)
+}
+
 func (p *Parser) getMetaData(ctx context.Context, doc *goquery.Document) (map[string]any, error) {
 	meta := map[string]any{}
 
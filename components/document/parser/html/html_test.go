@@ -19,7 +19,9 @@ package html
 import (
 	"context"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/cloudwego/eino/components/document/parser"
 	"github.com/stretchr/testify/assert"
@@ -83,4 +85,93 @@ func TestHTMLParser(t *testing.T) {
 		assert.Equal(t, "content in xid", docs[0].Content)
 	})
 
+	t.Run("test readability", func(t *testing.T) {
+		p, err := NewParser(ctx, &Config{Readability: true})
+		assert.NoError(t, err)
+		f, err := os.Open("./testdata/article.html")
+		assert.NoError(t, err)
+		defer f.Close()
+
+		docs, err := p.Parse(ctx, f, parser.WithURI("https://blog.example.com/posts/vector-search"))
+		assert.NoError(t, err)
+
+		assert.Equal(t, 1, len(docs))
+		assert.Equal(t, "# Scaling Vector Search in Production\n\n"+
+			"By Ada Chen and Bo Li\n\n"+
+			"Vector search moved from a research topic to a core part of our retrieval stack last year, "+
+			"and the path there taught us a lot about indexing, memory budgets, and failure modes.\n\n"+
+			"This post walks through the three changes that mattered most, with numbers from our "+
+			"[benchmark suite](https://blog.example.com/posts/benchmarks), so that you can skip the mistakes we made.\n\n"+
+			"## Choosing the index\n\n"+
+			"We started with a flat index, which is exact but slow, and moved to HNSW once the corpus passed "+
+			"ten million vectors. The trade-offs are summarized below.\n\n"+
+			"| Index | Recall | Latency |\n| --- | --- | --- |\n| Flat | 1.00 | 120 ms |\n| HNSW | 0.97 | 4 ms |\n\n"+
+			"## Operational lessons\n\n"+
+			"- Build the index offline, then swap it atomically.\n"+
+			"- Track recall continuously, not just latency:\n"+
+			"  1. sample production queries,\n"+
+			"  2. compare with the flat index.\n\n"+
+			"> Measure recall, or you are flying blind.\n\n"+
+			"```\nef_search = 128\nm = 32\n```\n\n"+
+			"Set `ef_search` per query class rather than globally, as described in the "+
+			"[original paper](https://example.org/hnsw.pdf).", docs[0].Content)
+
+		meta := docs[0].MetaData
+		assert.Equal(t, "Scaling Vector Search in Production", meta[MetaKeyTitle])
+		assert.Equal(t, "Lessons learned running vector search at scale.", meta[MetaKeyDesc])
+		assert.Equal(t, "Ada Chen, Bo Li", meta[MetaKeyAuthor])
+		assert.True(t, time.Date(2025, 3, 14, 1, 30, 0, 0, time.UTC).Equal(meta[MetaKeyPublishedTime].(time.Time)))
+		assert.Equal(t, "The Infra Blog", meta[MetaKeySiteName])
+		assert.Equal(t, "en", meta[MetaKeyLang])
+	})
+
+	t.Run("test readability without article", func(t *testing.T) {
+		page := `<html><head>
+			<title>Release notes - Example</title>
+			<meta property="og:description" content="What is new in 2.0">
+			<meta name="author" content="Release Team">
+			<meta name="date" content="2025-01-02">
+			<base href="https://docs.example.com/v2/">
+		</head><body>
+			<div id="menu"><a href="/">Home</a> | <a href="/docs">Docs</a> | <a href="/blog">Blog</a></div>
+			<div id="wrapper">
+				<div class="content">
+					<p>Version 2.0 is the biggest release so far, with a new storage engine, faster queries, and a simpler configuration.</p>
+					<p>Upgrading takes a few minutes, follow the <a href="upgrade.html">upgrade guide</a>, and back up your data first.</p>
+					<p>Thanks to the forty contributors who made it possible, and to everyone who reported bugs.</p>
+				</div>
+				<div class="sidebar"><a href="/a">Link one</a><br><a href="/b">Link two</a></div>
+			</div>
+			<div id="footer">Copyright Example</div>
+		</body></html>`
+
+		p, err := NewParser(ctx, &Config{Readability: true})
+		assert.NoError(t, err)
+		docs, err := p.Parse(ctx, strings.NewReader(page))
+		assert.NoError(t, err)
+
+		assert.Equal(t, "Version 2.0 is the biggest release so far, with a new storage engine, faster queries, and a simpler configuration.\n\n"+
+			"Upgrading takes a few minutes, follow the [upgrade guide](https://docs.example.com/v2/upgrade.html), and back up your data first.\n\n"+
+			"Thanks to the forty contributors who made it possible, and to everyone who reported bugs.", docs[0].Content)
+		meta := docs[0].MetaData
+		assert.Equal(t, "Release notes - Example", meta[MetaKeyTitle])
+		assert.Equal(t, "What is new in 2.0", meta[MetaKeyDesc])
+		assert.Equal(t, "Release Team", meta[MetaKeyAuthor])
+		assert.Equal(t, time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), meta[MetaKeyPublishedTime])
+		assert.NotContains(t, meta, MetaKeySiteName)
+	})
+
+	t.Run("test readability with selector", func(t *testing.T) {
+		sel := "#xid"
+		p, err := NewParser(ctx, &Config{Selector: &sel, Readability: true})
+		assert.NoError(t, err)
+		f, err := os.Open("./testdata/normal.html")
+		assert.NoError(t, err)
+		defer f.Close()
+
+		docs, err := p.Parse(ctx, f)
+		assert.NoError(t, err)
+		assert.Equal(t, "content in xid", docs[0].Content)
+		assert.Equal(t, "Test Document", docs[0].MetaData[MetaKeyTitle])
+	})
 }
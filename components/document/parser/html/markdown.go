@@ -0,0 +1,309 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package html
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// markdownConverter converts html to markdown, keeping the headings, links, lists, quotes, code and tables.
+type markdownConverter struct {
+	// base resolves the relative links, nil to keep them as is.
+	base *url.URL
+}
+
+func (c *markdownConverter) convert(nodes []*html.Node) string {
+	var blocks []string
+	for _, n := range nodes {
+		blocks = append(blocks, c.blocks(n)...)
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
+// blocks renders the children of the node as markdown blocks, the inline children between the block ones
+// being paragraphs.
+func (c *markdownConverter) blocks(n *html.Node) []string {
+	if n.Type != html.ElementNode && n.Type != html.DocumentNode {
+		if text := c.inline(n); text != "" {
+			return []string{text}
+		}
+		return nil
+	}
+
+	var (
+		blocks []string
+		inline strings.Builder
+	)
+	flush := func() {
+		if text := trimLines(inline.String()); text != "" {
+			blocks = append(blocks, text)
+		}
+		inline.Reset()
+	}
+
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != html.ElementNode || !isBlock(child) {
+			inline.WriteString(c.inline(child))
+			continue
+		}
+
+		flush()
+		switch child.DataAtom {
+		case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+			if text := collapseSpaces(c.inline(child)); text != "" {
+				level := int(child.Data[1] - '0')
+				blocks = append(blocks, strings.Repeat("#", level)+" "+text)
+			}
+		case atom.Ul, atom.Ol:
+			if list := c.list(child); list != "" {
+				blocks = append(blocks, list)
+			}
+		case atom.Pre:
+			if code := strings.Trim(textContent(child), "\n"); strings.TrimSpace(code) != "" {
+				blocks = append(blocks, "```\n"+code+"\n```")
+			}
+		case atom.Blockquote:
+			if quote := strings.Join(c.blocks(child), "\n\n"); quote != "" {
+				blocks = append(blocks, "> "+strings.ReplaceAll(quote, "\n", "\n> "))
+			}
+		case atom.Table:
+			if table := c.table(child); table != "" {
+				blocks = append(blocks, table)
+			}
+		case atom.Hr:
+		default:
+			blocks = append(blocks, c.blocks(child)...)
+		}
+	}
+	flush()
+
+	return blocks
+}
+
+// inline renders the node as inline markdown, with the spaces collapsed.
+func (c *markdownConverter) inline(n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		return collapseInline(n.Data)
+	case html.ElementNode, html.DocumentNode:
+	default:
+		return ""
+	}
+
+	switch n.DataAtom {
+	case atom.Br:
+		return "\n"
+	case atom.Img, atom.Script, atom.Style:
+		return ""
+	case atom.Code:
+		if code := strings.TrimSpace(textContent(n)); code != "" {
+			return "`" + code + "`"
+		}
+		return ""
+	}
+
+	var sb strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && isBlock(child) {
+			// a block inside an inline element, e.g. a link wrapping a heading
+			sb.WriteString(" " + strings.Join(c.blocks(child), " ") + " ")
+			continue
+		}
+		sb.WriteString(c.inline(child))
+	}
+	text := sb.String()
+
+	if n.DataAtom == atom.A {
+		label := collapseSpaces(text)
+		href := c.href(n)
+		if label == "" || href == "" {
+			return text
+		}
+		return leadingSpace(text) + "[" + label + "](" + href + ")" + trailingSpace(text)
+	}
+
+	return text
+}
+
+// href returns the absolute url of the link, empty for the anchors and the scripts.
+func (c *markdownConverter) href(n *html.Node) string {
+	href := strings.TrimSpace(attr(n, "href"))
+	if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(strings.ToLower(href), "javascript:") {
+		return ""
+	}
+	if c.base != nil {
+		if u, err := c.base.Parse(href); err == nil {
+			href = u.String()
+		}
+	}
+	return strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29").Replace(href)
+}
+
+// list renders the items of the list, the nested blocks being indented under their item.
+func (c *markdownConverter) list(n *html.Node) string {
+	var items []string
+	number := 1
+	if start, err := strconv.Atoi(attr(n, "start")); err == nil {
+		number = start
+	}
+
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != html.ElementNode || child.DataAtom != atom.Li {
+			continue
+		}
+		content := strings.Join(c.blocks(child), "\n")
+		if content == "" {
+			continue
+		}
+
+		marker := "- "
+		if n.DataAtom == atom.Ol {
+			marker = strconv.Itoa(number) + ". "
+			number++
+		}
+		items = append(items, marker+strings.ReplaceAll(content, "\n", "\n"+strings.Repeat(" ", len(marker))))
+	}
+
+	return strings.Join(items, "\n")
+}
+
+// table renders the table as a markdown table, whose first row is the header.
+func (c *markdownConverter) table(n *html.Node) string {
+	var rows [][]string
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			switch child.DataAtom {
+			case atom.Thead, atom.Tbody, atom.Tfoot:
+				walk(child)
+			case atom.Tr:
+				var row []string
+				for cell := child.FirstChild; cell != nil; cell = cell.NextSibling {
+					if cell.DataAtom != atom.Td && cell.DataAtom != atom.Th {
+						continue
+					}
+					text := collapseSpaces(strings.Join(c.blocks(cell), " "))
+					row = append(row, strings.ReplaceAll(text, "|", `\|`))
+				}
+				if len(row) > 0 {
+					rows = append(rows, row)
+				}
+			}
+		}
+	}
+	walk(n)
+	if len(rows) == 0 {
+		return ""
+	}
+
+	columns := 0
+	for _, row := range rows {
+		columns = max(columns, len(row))
+	}
+
+	var sb strings.Builder
+	for i, row := range rows {
+		for len(row) < columns {
+			row = append(row, "")
+		}
+		sb.WriteString("| " + strings.Join(row, " | ") + " |\n")
+		if i == 0 {
+			sb.WriteString("|" + strings.Repeat(" --- |", columns) + "\n")
+		}
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func isBlock(n *html.Node) bool {
+	switch n.DataAtom {
+	case atom.Address, atom.Article, atom.Aside, atom.Blockquote, atom.Details, atom.Dialog, atom.Dd, atom.Div,
+		atom.Dl, atom.Dt, atom.Fieldset, atom.Figcaption, atom.Figure, atom.Footer, atom.Form,
+		atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Header, atom.Hr, atom.Li, atom.Main, atom.Nav,
+		atom.Ol, atom.P, atom.Pre, atom.Section, atom.Summary, atom.Table, atom.Ul, atom.Body, atom.Html:
+		return true
+	}
+	return false
+}
+
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var sb strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.DataAtom == atom.Br {
+			sb.WriteString("\n")
+			continue
+		}
+		sb.WriteString(textContent(child))
+	}
+	return sb.String()
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// collapseInline collapses the spaces of the text, keeping a leading and a trailing space
+// separating it from the surrounding inline content.
+func collapseInline(s string) string {
+	text := collapseSpaces(s)
+	if text == "" {
+		if s != "" {
+			return " "
+		}
+		return ""
+	}
+	return leadingSpace(s) + text + trailingSpace(s)
+}
+
+func leadingSpace(s string) string {
+	if s != "" && strings.TrimLeft(s, " \t\r\n") != s {
+		return " "
+	}
+	return ""
+}
+
+func trailingSpace(s string) string {
+	if s != "" && strings.TrimRight(s, " \t\r\n") != s {
+		return " "
+	}
+	return ""
+}
+
+// trimLines trims the lines of the text, and collapses the spaces between the inline elements.
+func trimLines(s string) string {
+	lines := strings.Split(s, "\n")
+	kept := lines[:0]
+	for _, l := range lines {
+		if l = collapseSpaces(l); l != "" {
+			kept = append(kept, l)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
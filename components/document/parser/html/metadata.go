@@ -0,0 +1,195 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package html
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// articleMeta sets the title, description, author, published time and site name of the article,
+// from its JSON-LD, then from its Open Graph and other meta tags.
+func articleMeta(doc *goquery.Document, meta map[string]any) {
+	ld := jsonLDArticle(doc)
+
+	title := firstNonEmpty(
+		ldString(ld["headline"]),
+		metaContent(doc, `meta[property="og:title"]`, `meta[name="twitter:title"]`),
+	)
+	if title != "" {
+		meta[MetaKeyTitle] = title
+	}
+
+	if _, ok := meta[MetaKeyDesc]; !ok {
+		desc := firstNonEmpty(
+			ldString(ld["description"]),
+			metaContent(doc, `meta[property="og:description"]`, `meta[name="twitter:description"]`),
+		)
+		if desc != "" {
+			meta[MetaKeyDesc] = desc
+		}
+	}
+
+	author := firstNonEmpty(
+		ldAuthor(ld["author"]),
+		metaContent(doc, `meta[name="author"]`, `meta[property="article:author"]`, `meta[name="byl"]`),
+		collapseSpaces(doc.Find(`[rel="author"], [itemprop="author"] [itemprop="name"], [itemprop="author"]`).First().Text()),
+	)
+	if author != "" && !strings.HasPrefix(author, "http") {
+		meta[MetaKeyAuthor] = author
+	}
+
+	published := firstNonEmpty(
+		ldString(ld["datePublished"]),
+		metaContent(doc, `meta[property="article:published_time"]`, `meta[name="pubdate"]`,
+			`meta[name="publishdate"]`, `meta[itemprop="datePublished"]`, `meta[name="date"]`),
+		doc.Find(`time[itemprop="datePublished"], time[pubdate]`).First().AttrOr("datetime", ""),
+	)
+	if t, ok := parseTime(published); ok {
+		meta[MetaKeyPublishedTime] = t
+	}
+
+	siteName := firstNonEmpty(
+		metaContent(doc, `meta[property="og:site_name"]`, `meta[name="application-name"]`),
+		ldPublisher(ld["publisher"]),
+	)
+	if siteName != "" {
+		meta[MetaKeySiteName] = siteName
+	}
+}
+
+// jsonLDArticle returns the first JSON-LD object with a headline or a published date, e.g. an Article, NewsArticle
+// or BlogPosting, looking into the arrays and the @graph of the scripts.
+func jsonLDArticle(doc *goquery.Document) map[string]any {
+	var found map[string]any
+	var find func(v any)
+	find = func(v any) {
+		if found != nil {
+			return
+		}
+		switch v := v.(type) {
+		case []any:
+			for _, item := range v {
+				find(item)
+			}
+		case map[string]any:
+			if _, ok := v["headline"]; ok {
+				found = v
+				return
+			}
+			if _, ok := v["datePublished"]; ok {
+				found = v
+				return
+			}
+			find(v["@graph"])
+		}
+	}
+
+	doc.Find(`script[type="application/ld+json"]`).EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		var v any
+		if err := json.Unmarshal([]byte(s.Text()), &v); err == nil {
+			find(v)
+		}
+		return found == nil
+	})
+
+	return found
+}
+
+func ldString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case []any:
+		if len(v) > 0 {
+			return ldString(v[0])
+		}
+	}
+	return ""
+}
+
+// ldAuthor returns the names of the authors, which are strings, Person objects or arrays of them.
+func ldAuthor(v any) string {
+	switch v := v.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case map[string]any:
+		return ldString(v["name"])
+	case []any:
+		var names []string
+		for _, item := range v {
+			if name := ldAuthor(item); name != "" {
+				names = append(names, name)
+			}
+		}
+		return strings.Join(names, ", ")
+	}
+	return ""
+}
+
+func ldPublisher(v any) string {
+	if m, ok := v.(map[string]any); ok {
+		return ldString(m["name"])
+	}
+	return ldString(v)
+}
+
+func metaContent(doc *goquery.Document, selectors ...string) string {
+	for _, sel := range selectors {
+		if c := strings.TrimSpace(doc.Find(sel).First().AttrOr("content", "")); c != "" {
+			return c
+		}
+	}
+	return ""
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+var timeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	time.RFC1123Z,
+	time.RFC1123,
+}
+
+func parseTime(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
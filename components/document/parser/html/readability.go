@@ -0,0 +1,265 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package html
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// the class and id patterns of the arc90 readability algorithm, telling boilerplate from content.
+var (
+	unlikelyRe = regexp.MustCompile(`(?i)-ad-|^ads?$|^ad-|advert|banner|breadcrumb|combx|comment|community|consent|cookie|disqus|extra|footer|gdpr|header|legends|menu|modal|nav|newsletter|pager|pagination|popup|promo|related|remark|replies|rss|share|shoutbox|sidebar|skyscraper|social|sponsor|subscribe|tags|toolbar|widget`)
+	maybeRe    = regexp.MustCompile(`(?i)and|article|body|column|content|main|shadow`)
+	positiveRe = regexp.MustCompile(`(?i)article|body|content|entry|hentry|h-entry|main|page|post|text|blog|story`)
+	negativeRe = regexp.MustCompile(`(?i)-ad-|hidden|^hid$|banner|combx|comment|com-|contact|foot|footnote|gdpr|masthead|media|meta|outbrain|promo|related|scroll|share|shoutbox|sidebar|skyscraper|sponsor|shopping|tags|tool|widget`)
+)
+
+// boilerplateSelector matches the elements which are never part of the main content.
+const boilerplateSelector = `script, style, noscript, template, iframe, object, embed, svg, canvas, dialog,
+	nav, aside, button, input, select, textarea,
+	[role=navigation], [role=banner], [role=contentinfo], [role=complementary], [role=dialog], [role=alert],
+	[hidden], [aria-hidden=true]`
+
+// extractMainContent finds the nodes of the main content of the page, scoring the paragraphs and their ancestors
+// as readability does, then removes the boilerplate left inside them, e.g. share buttons or related links.
+// The page is modified.
+func extractMainContent(root *goquery.Selection) []*html.Node {
+	root.Find(boilerplateSelector).Remove()
+	root.Find("header, footer").Each(func(_ int, s *goquery.Selection) {
+		// the header of an article holds its title and byline
+		if s.ParentsFiltered("article, main").Length() == 0 {
+			s.Remove()
+		}
+	})
+	root.Find("*").Each(func(_ int, s *goquery.Selection) {
+		if style, ok := s.Attr("style"); ok && strings.Contains(strings.ReplaceAll(style, " ", ""), "display:none") {
+			s.Remove()
+			return
+		}
+		switch goquery.NodeName(s) {
+		case "html", "body", "article", "main", "a", "table", "tbody", "tr", "td", "th", "pre", "code":
+			return
+		}
+		match := s.AttrOr("class", "") + " " + s.AttrOr("id", "")
+		if unlikelyRe.MatchString(match) && !maybeRe.MatchString(match) {
+			s.Remove()
+		}
+	})
+
+	top := topCandidate(root)
+	if top == nil {
+		return root.Nodes
+	}
+
+	nodes := withSiblings(top)
+	for _, n := range nodes {
+		cleanConditionally(goquery.NewDocumentFromNode(n).Selection)
+	}
+
+	return nodes
+}
+
+type candidate struct {
+	node   *html.Node
+	score  float64
+	scores map[*html.Node]float64
+}
+
+// topCandidate returns the element with the best score, which is propagated from the paragraphs to their ancestors.
+func topCandidate(root *goquery.Selection) *candidate {
+	scores := map[*html.Node]float64{}
+	var order []*html.Node
+	addScore := func(n *html.Node, score float64) {
+		if n == nil || n.Type != html.ElementNode {
+			return
+		}
+		if _, ok := scores[n]; !ok {
+			scores[n] = initialScore(n)
+			order = append(order, n)
+		}
+		scores[n] += score
+	}
+
+	root.Find("p, pre, td, blockquote, div").Each(func(_ int, s *goquery.Selection) {
+		// the divs without block children are paragraphs
+		if goquery.NodeName(s) == "div" && s.Find("p, div, table, ul, ol, pre, blockquote, section, article, h1, h2, h3, h4, h5, h6").Length() > 0 {
+			return
+		}
+		text := collapseSpaces(s.Text())
+		length := utf8.RuneCountInString(text)
+		if length < 25 {
+			return
+		}
+
+		score := 1 + float64(strings.Count(text, ",")+strings.Count(text, "，")) + min(float64(length)/100, 3)
+		// the parent gets the score, the grandparent half of it, and the great-grandparent a sixth
+		n := s.Nodes[0].Parent
+		for _, divider := range []float64{1, 2, 6} {
+			if n == nil {
+				break
+			}
+			addScore(n, score/divider)
+			n = n.Parent
+		}
+	})
+
+	var top *candidate
+	for _, n := range order {
+		score := scores[n] * (1 - linkDensity(goquery.NewDocumentFromNode(n).Selection))
+		scores[n] = score
+		if top == nil || score > top.score {
+			top = &candidate{node: n, score: score}
+		}
+	}
+	if top == nil {
+		return nil
+	}
+
+	top.scores = scores
+	return top
+}
+
+// withSiblings adds to the top candidate its siblings which are content as well,
+// e.g. the paragraphs of an article which are not wrapped in a common element.
+func withSiblings(top *candidate) []*html.Node {
+	parent := top.node.Parent
+	if parent == nil || parent.DataAtom == atom.Html {
+		return []*html.Node{top.node}
+	}
+
+	threshold := max(10, top.score*0.2)
+	var nodes []*html.Node
+	for n := parent.FirstChild; n != nil; n = n.NextSibling {
+		if n.Type != html.ElementNode {
+			continue
+		}
+		if n == top.node {
+			nodes = append(nodes, n)
+			continue
+		}
+
+		bonus := 0.0
+		if classWeight(n) > 0 && classWeight(n) == classWeight(top.node) {
+			bonus = top.score * 0.2
+		}
+		if score, ok := top.scores[n]; ok && score+bonus >= threshold {
+			nodes = append(nodes, n)
+			continue
+		}
+		if n.DataAtom == atom.P {
+			s := goquery.NewDocumentFromNode(n).Selection
+			text := collapseSpaces(s.Text())
+			length := utf8.RuneCountInString(text)
+			density := linkDensity(s)
+			if (length > 80 && density < 0.25) || (length > 0 && density == 0 && strings.Contains(text, ". ")) {
+				nodes = append(nodes, n)
+			}
+		}
+	}
+
+	return nodes
+}
+
+// cleanConditionally removes the containers looking like boilerplate: link lists, widgets and the like.
+func cleanConditionally(root *goquery.Selection) {
+	root.Find("div, section, ul, ol, table, form, h1, h2, h3").Each(func(_ int, s *goquery.Selection) {
+		n := s.Nodes[0]
+		weight := classWeight(n)
+		if weight < 0 {
+			s.Remove()
+			return
+		}
+
+		text := collapseSpaces(s.Text())
+		if strings.Count(text, ",")+strings.Count(text, "，") >= 10 {
+			return
+		}
+		density := linkDensity(s)
+		switch n.DataAtom {
+		case atom.H1, atom.H2, atom.H3:
+			if density > 0.33 {
+				s.Remove()
+			}
+			return
+		case atom.Table:
+			if density > 0.5 {
+				s.Remove()
+			}
+			return
+		}
+
+		length := utf8.RuneCountInString(text)
+		if (weight < 25 && density > 0.2 && length < 200) || density > 0.5 {
+			s.Remove()
+		}
+	})
+}
+
+func initialScore(n *html.Node) float64 {
+	score := float64(classWeight(n))
+	switch n.DataAtom {
+	case atom.Div, atom.Article, atom.Main, atom.Section:
+		score += 5
+	case atom.Pre, atom.Td, atom.Blockquote:
+		score += 3
+	case atom.Address, atom.Ol, atom.Ul, atom.Dl, atom.Dd, atom.Dt, atom.Li, atom.Form:
+		score -= 3
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Th:
+		score -= 5
+	}
+	return score
+}
+
+func classWeight(n *html.Node) int {
+	weight := 0
+	for _, attr := range n.Attr {
+		if (attr.Key != "class" && attr.Key != "id") || attr.Val == "" {
+			continue
+		}
+		if negativeRe.MatchString(attr.Val) {
+			weight -= 25
+		}
+		if positiveRe.MatchString(attr.Val) {
+			weight += 25
+		}
+	}
+	return weight
+}
+
+// linkDensity is the part of the text of the element which is in links.
+func linkDensity(s *goquery.Selection) float64 {
+	length := utf8.RuneCountInString(collapseSpaces(s.Text()))
+	if length == 0 {
+		return 0
+	}
+
+	linkLength := 0
+	s.Find("a").Each(func(_ int, a *goquery.Selection) {
+		linkLength += utf8.RuneCountInString(collapseSpaces(a.Text()))
+	})
+	return float64(linkLength) / float64(length)
+}
+
+func collapseSpaces(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
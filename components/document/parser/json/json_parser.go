@@ -0,0 +1,362 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package json provides a parser mapping the records of json / json lines files to documents with JSONPath.
+package json
+
+import (
+	"bufio"
+	"context"
+	gojson "encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/ohler55/ojg/jp"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	MetaKeyRecord       = "_record"
+	MetaKeyRecordNumber = "_record_number"
+	MetaKeySource       = "_source"
+)
+
+// Config is the configuration of the json parser.
+// The paths are JSONPath expressions, e.g. "$.data.items[*]", "$..author.name" or "$.tags[?(@.primary == true)].name".
+type Config struct {
+	// RecordsPath selects the records in each json value of the input, each record is a document, optional.
+	// A selected array is split into its elements, so "$.items" and "$.items[*]" are the same.
+	// Default "$", the json value itself, or its elements if it is an array.
+	RecordsPath string
+
+	// ContentPaths select the values of the record rendered into the document content, relative to the record, optional.
+	// Strings are kept as is and other values are rendered as json, the values of a path are joined by new lines,
+	// and the paths by blank lines. Ignored if ContentTemplate is set.
+	// Default to the record itself, a string as is, an object as "<key>: <value>" lines.
+	ContentPaths []string
+	// ContentTemplate is a text/template rendering a record into the document content, optional,
+	// e.g. "{{.title}}\n\n{{.body}}". The record is passed as decoded json: map[string]any, []any, string,
+	// int64, float64, bool or nil.
+	ContentTemplate string
+	// MetadataPaths map the metadata keys to the paths of their values, relative to the record, optional.
+	// A path selecting a single value gives the value, several values give a []any, none is skipped.
+	// Default nil, the whole record is kept in the MetaKeyRecord metadata.
+	MetadataPaths map[string]string
+	// IDPath selects the document ID, relative to the record, optional.
+	IDPath string
+}
+
+// JSONParser parses json and json lines content into documents, one document per record.
+type JSONParser struct {
+	conf *Config
+	tmpl *template.Template
+
+	recordsPath  jp.Expr
+	contentPaths []jp.Expr
+	metaKeys     []string
+	metaPaths    []jp.Expr
+	idPath       jp.Expr
+}
+
+// NewJSONParser creates a new json parser.
+func NewJSONParser(ctx context.Context, config *Config) (*JSONParser, error) {
+	if config == nil {
+		config = &Config{}
+	}
+
+	p := &JSONParser{conf: config}
+
+	var err error
+	recordsPath := config.RecordsPath
+	if recordsPath == "" {
+		recordsPath = "$"
+	}
+	if p.recordsPath, err = parsePath("records path", recordsPath); err != nil {
+		return nil, err
+	}
+
+	for _, path := range config.ContentPaths {
+		x, err := parsePath("content path", path)
+		if err != nil {
+			return nil, err
+		}
+		p.contentPaths = append(p.contentPaths, x)
+	}
+
+	for key := range config.MetadataPaths {
+		p.metaKeys = append(p.metaKeys, key)
+	}
+	sort.Strings(p.metaKeys)
+	for _, key := range p.metaKeys {
+		x, err := parsePath("metadata path of "+key, config.MetadataPaths[key])
+		if err != nil {
+			return nil, err
+		}
+		p.metaPaths = append(p.metaPaths, x)
+	}
+
+	if config.IDPath != "" {
+		if p.idPath, err = parsePath("id path", config.IDPath); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.ContentTemplate != "" {
+		tmpl, err := template.New("record").Option("missingkey=zero").Parse(config.ContentTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("new json parser, parse content template err: %w", err)
+		}
+		p.tmpl = tmpl
+	}
+
+	return p, nil
+}
+
+func parsePath(name, path string) (jp.Expr, error) {
+	x, err := jp.ParseString(path)
+	if err != nil {
+		return nil, fmt.Errorf("new json parser, parse %s %q err: %w", name, path, err)
+	}
+	return x, nil
+}
+
+// Parse parses all records of the json content from io.Reader.
+// The content is one json value, or several of them such as json lines, each value being searched for records.
+// The values are read one by one, use ParseStream to avoid holding all documents of a large file in memory.
+func (p *JSONParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	var docs []*schema.Document
+	err := p.parse(ctx, reader, opts, func(doc *schema.Document) error {
+		docs = append(docs, doc)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return docs, nil
+}
+
+// ParseStream parses the json content from io.Reader, and streams the documents as the values are read.
+// Closing the stream reader stops the parsing.
+func (p *JSONParser) ParseStream(ctx context.Context, reader io.Reader, opts ...parser.Option) *schema.StreamReader[*schema.Document] {
+	sr, sw := schema.Pipe[*schema.Document](1)
+
+	go func() {
+		defer func() {
+			if e := recover(); e != nil {
+				sw.Send(nil, fmt.Errorf("panic occurred when parsing json: %v", e))
+			}
+			sw.Close()
+		}()
+
+		err := p.parse(ctx, reader, opts, func(doc *schema.Document) error {
+			if closed := sw.Send(doc, nil); closed {
+				return errStreamClosed
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errStreamClosed) {
+			sw.Send(nil, err)
+		}
+	}()
+
+	return sr
+}
+
+var errStreamClosed = errors.New("stream closed")
+
+func (p *JSONParser) parse(ctx context.Context, reader io.Reader, opts []parser.Option, emit func(doc *schema.Document) error) error {
+	commonOpts := parser.GetCommonOptions(nil, opts...)
+
+	dec := gojson.NewDecoder(skipBOM(reader))
+	dec.UseNumber()
+
+	recordNumber := 0
+	for value := 1; ; value++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var v any
+		err := dec.Decode(&v)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("json parser decode value %d err: %w", value, err)
+		}
+		v = normalize(v)
+
+		for _, record := range p.records(v) {
+			recordNumber++
+
+			content, err := p.content(record)
+			if err != nil {
+				return fmt.Errorf("json parser render record %d err: %w", recordNumber, err)
+			}
+
+			meta := make(map[string]any, len(commonOpts.ExtraMeta)+len(p.metaKeys)+3)
+			if commonOpts.URI != "" {
+				meta[MetaKeySource] = commonOpts.URI
+			}
+			for k, v := range commonOpts.ExtraMeta {
+				meta[k] = v
+			}
+			meta[MetaKeyRecordNumber] = recordNumber
+			if len(p.metaKeys) == 0 {
+				meta[MetaKeyRecord] = record
+			}
+			for i, key := range p.metaKeys {
+				switch values := p.metaPaths[i].Get(record); len(values) {
+				case 0:
+				case 1:
+					meta[key] = values[0]
+				default:
+					meta[key] = values
+				}
+			}
+
+			doc := &schema.Document{
+				Content:  content,
+				MetaData: meta,
+			}
+			if p.idPath != nil {
+				if id, ok := p.idPath.FirstFound(record); ok && id != nil {
+					doc.ID = text(id)
+				}
+			}
+
+			if err = emit(doc); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// records returns the values selected by the records path, the arrays split into their elements.
+func (p *JSONParser) records(v any) []any {
+	var records []any
+	for _, r := range p.recordsPath.Get(v) {
+		if arr, ok := r.([]any); ok {
+			records = append(records, arr...)
+		} else {
+			records = append(records, r)
+		}
+	}
+	return records
+}
+
+func (p *JSONParser) content(record any) (string, error) {
+	if p.tmpl != nil {
+		var sb strings.Builder
+		if err := p.tmpl.Execute(&sb, record); err != nil {
+			return "", err
+		}
+		return sb.String(), nil
+	}
+
+	if len(p.contentPaths) == 0 {
+		obj, ok := record.(map[string]any)
+		if !ok {
+			return text(record), nil
+		}
+
+		keys := make([]string, 0, len(obj))
+		for k := range obj {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		lines := make([]string, 0, len(keys))
+		for _, k := range keys {
+			if v := strings.TrimSpace(text(obj[k])); v != "" && obj[k] != nil {
+				lines = append(lines, k+": "+v)
+			}
+		}
+		return strings.Join(lines, "\n"), nil
+	}
+
+	parts := make([]string, 0, len(p.contentPaths))
+	for _, x := range p.contentPaths {
+		var values []string
+		for _, v := range x.Get(record) {
+			if t := strings.TrimSpace(text(v)); t != "" && v != nil {
+				values = append(values, t)
+			}
+		}
+		if len(values) > 0 {
+			parts = append(parts, strings.Join(values, "\n"))
+		}
+	}
+
+	return strings.Join(parts, "\n\n"), nil
+}
+
+// text renders a string as is, and other values as json.
+func text(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case nil:
+		return ""
+	}
+
+	b, err := gojson.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+// normalize converts the json numbers to int64, keeping the integer ids exact, or to float64.
+func normalize(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			v[k] = normalize(e)
+		}
+	case []any:
+		for i, e := range v {
+			v[i] = normalize(e)
+		}
+	case gojson.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	}
+	return v
+}
+
+const utf8BOM = "\xef\xbb\xbf"
+
+func skipBOM(reader io.Reader) io.Reader {
+	br := bufio.NewReader(reader)
+	if prefix, err := br.Peek(len(utf8BOM)); err == nil && string(prefix) == utf8BOM {
+		_, _ = br.Discard(len(utf8BOM))
+	}
+	return br
+}
@@ -0,0 +1,176 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewJSONParser(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewJSONParser(ctx, &Config{RecordsPath: "$.items[?(@.x"})
+	assert.ErrorContains(t, err, `new json parser, parse records path "$.items[?(@.x" err`)
+
+	_, err = NewJSONParser(ctx, &Config{MetadataPaths: map[string]string{"price": "$.price[["}})
+	assert.ErrorContains(t, err, "new json parser, parse metadata path of price")
+
+	_, err = NewJSONParser(ctx, &Config{ContentTemplate: "{{.name"})
+	assert.ErrorContains(t, err, "new json parser, parse content template err")
+}
+
+func TestJSONParser_Parse(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("records path and field mapping", func(t *testing.T) {
+		f, err := os.Open("./examples/testdata/products.json")
+		assert.NoError(t, err)
+		defer f.Close()
+
+		p, err := NewJSONParser(ctx, &Config{
+			RecordsPath:  "$.data.items",
+			ContentPaths: []string{"$.name", "$.description"},
+			MetadataPaths: map[string]string{
+				"price":       "$.price",
+				"vendor":      "$.vendor.name",
+				"tags":        "$.tags[*].name",
+				"primary_tag": "$.tags[?(@.primary == true)].name",
+				"missing":     "$.missing",
+			},
+			IDPath: "$.id",
+		})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, f, parser.WithURI("products.json"), parser.WithExtraMeta(map[string]any{"test": "test"}))
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(docs))
+
+		assert.Equal(t, "9007199254740993", docs[0].ID)
+		assert.Equal(t, "Trail Runner 2\n\nLightweight running shoe for rough terrain.", docs[0].Content)
+		assert.Equal(t, map[string]any{
+			"test":              "test",
+			MetaKeySource:       "products.json",
+			MetaKeyRecordNumber: 1,
+			"price":             129.9,
+			"vendor":            "Northpeak",
+			"tags":              []any{"running", "outdoor"},
+			"primary_tag":       "running",
+		}, docs[0].MetaData)
+
+		assert.Equal(t, "2", docs[1].ID)
+		assert.Equal(t, int64(89), docs[1].MetaData["price"])
+		// a single value is not wrapped in a slice
+		assert.Equal(t, "casual", docs[1].MetaData["tags"])
+		assert.Equal(t, 2, docs[1].MetaData[MetaKeyRecordNumber])
+	})
+
+	t.Run("default content and record", func(t *testing.T) {
+		p, err := NewJSONParser(ctx, nil)
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, strings.NewReader(`[{"b": {"x": 1}, "a": "text", "c": null, "d": ""}, "plain", 3.5]`))
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(docs))
+		assert.Equal(t, "a: text\nb: {\"x\":1}", docs[0].Content)
+		assert.Equal(t, map[string]any{"a": "text", "b": map[string]any{"x": int64(1)}, "c": nil, "d": ""}, docs[0].MetaData[MetaKeyRecord])
+		assert.Equal(t, "plain", docs[1].Content)
+		assert.Equal(t, "3.5", docs[2].Content)
+		assert.Equal(t, "", docs[0].ID)
+	})
+
+	t.Run("json lines with template", func(t *testing.T) {
+		f, err := os.Open("./examples/testdata/tickets.jsonl")
+		assert.NoError(t, err)
+		defer f.Close()
+
+		p, err := NewJSONParser(ctx, &Config{
+			ContentTemplate: "{{.title}}\n\n{{.body}}",
+			MetadataPaths:   map[string]string{"status": "$.status"},
+			IDPath:          "$.ticket",
+		})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, f, parser.WithURI("tickets.jsonl"))
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(docs))
+		assert.Equal(t, "T-1", docs[0].ID)
+		assert.Equal(t, "Login fails\n\nUsers get a 500 error on the login page.", docs[0].Content)
+		assert.Equal(t, map[string]any{MetaKeySource: "tickets.jsonl", MetaKeyRecordNumber: 1, "status": "open"}, docs[0].MetaData)
+		assert.Equal(t, "T-3", docs[2].ID)
+		assert.Equal(t, "Typo\n\n", docs[2].Content)
+		assert.Equal(t, 3, docs[2].MetaData[MetaKeyRecordNumber])
+	})
+
+	t.Run("records in each value", func(t *testing.T) {
+		p, err := NewJSONParser(ctx, &Config{RecordsPath: "$.hits[*]", ContentPaths: []string{"$.text"}})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, strings.NewReader("\xef\xbb\xbf"+`{"hits":[{"text":"a"},{"text":"b"}]}
+{"hits":[]}
+{"hits":[{"text":"c"}]}`))
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(docs))
+		assert.Equal(t, "c", docs[2].Content)
+		assert.Equal(t, 3, docs[2].MetaData[MetaKeyRecordNumber])
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		p, err := NewJSONParser(ctx, nil)
+		assert.NoError(t, err)
+
+		_, err = p.Parse(ctx, strings.NewReader("{\"a\": 1}\n{\"a\": "))
+		assert.ErrorContains(t, err, "json parser decode value 2 err")
+	})
+}
+
+func TestJSONParser_ParseStream(t *testing.T) {
+	ctx := context.Background()
+
+	f, err := os.Open("./examples/testdata/tickets.jsonl")
+	assert.NoError(t, err)
+	defer f.Close()
+
+	p, err := NewJSONParser(ctx, &Config{ContentPaths: []string{"$.title"}})
+	assert.NoError(t, err)
+
+	sr := p.ParseStream(ctx, f)
+	defer sr.Close()
+
+	var titles []string
+	for {
+		doc, err := sr.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		assert.NoError(t, err)
+		titles = append(titles, doc.Content)
+	}
+	assert.Equal(t, []string{"Login fails", "Slow search", "Typo"}, titles)
+
+	sr = p.ParseStream(ctx, strings.NewReader("[1, 2"))
+	defer sr.Close()
+	_, err = sr.Recv()
+	assert.ErrorContains(t, err, "json parser decode value 1 err")
+}
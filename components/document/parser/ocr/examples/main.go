@@ -0,0 +1,70 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/components/document/parser"
+
+	"github.com/cloudwego/eino-ext/components/document/parser/ocr"
+	"github.com/cloudwego/eino-ext/components/document/parser/pdf"
+)
+
+func main() {
+	ctx := context.Background()
+
+	rec, err := ocr.NewTesseractRecognizer(&ocr.TesseractConfig{
+		Languages: []string{"eng"},
+	})
+	if err != nil {
+		log.Fatalf("Failed to create recognizer: %v", err)
+	}
+
+	p, err := ocr.NewOCRParser(ctx, &ocr.Config{
+		Recognizer: rec,
+		NewPDFParser: func(ctx context.Context, imageParser parser.Parser) (parser.Parser, error) {
+			return pdf.NewPDFParser(ctx, &pdf.Config{ToPages: true, Layout: true, ImageParser: imageParser})
+		},
+	})
+	if err != nil {
+		log.Fatalf("Failed to create parser: %v", err)
+	}
+
+	for _, path := range []string{"../testdata/scanned.png", "../testdata/scanned.pdf"} {
+		file, err := os.Open(path)
+		if err != nil {
+			log.Fatalf("Failed to open file: %v", err)
+		}
+
+		docs, err := p.Parse(ctx, file, parser.WithURI(path))
+		_ = file.Close()
+		if err != nil {
+			log.Fatalf("Failed to parse file: %v", err)
+		}
+
+		for _, doc := range docs {
+			fmt.Printf("--- %v page %v, ocr: %v, confidence: %v ---\n", path,
+				doc.MetaData[ocr.MetaKeyPageNumber], doc.MetaData[ocr.MetaKeyOCR], doc.MetaData[ocr.MetaKeyConfidence])
+			fmt.Println(doc.Content)
+			fmt.Println()
+		}
+	}
+}
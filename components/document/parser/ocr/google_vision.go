@@ -0,0 +1,257 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ocr
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const defaultGoogleVisionBaseURL = "https://vision.googleapis.com/v1"
+
+// GoogleVisionConfig is the configuration for the Google Cloud Vision recognizer.
+type GoogleVisionConfig struct {
+	// APIKey is the Google Cloud API key, with the Cloud Vision API enabled. Required.
+	APIKey string
+	// BaseURL is the base url of the Cloud Vision API.
+	// Optional. Default "https://vision.googleapis.com/v1".
+	BaseURL string
+	// LanguageHints are the languages of the text, as BCP-47 codes, e.g. []string{"en", "zh"}.
+	// Optional. Default automatic language detection.
+	LanguageHints []string
+	// HTTPClient is the client sending the requests.
+	// Optional. Default http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// GoogleVisionRecognizer recognizes the text with the DOCUMENT_TEXT_DETECTION feature of Google Cloud Vision,
+// which is suited to dense text such as scanned documents.
+type GoogleVisionRecognizer struct {
+	conf   *GoogleVisionConfig
+	client *http.Client
+}
+
+// NewGoogleVisionRecognizer creates a new Google Cloud Vision recognizer.
+func NewGoogleVisionRecognizer(config *GoogleVisionConfig) (*GoogleVisionRecognizer, error) {
+	if config == nil || config.APIKey == "" {
+		return nil, errors.New("new google vision recognizer, api key is required")
+	}
+
+	client := config.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &GoogleVisionRecognizer{conf: config, client: client}, nil
+}
+
+type visionRequest struct {
+	Requests []visionImageRequest `json:"requests"`
+}
+
+type visionImageRequest struct {
+	Image struct {
+		Content string `json:"content"`
+	} `json:"image"`
+	Features []struct {
+		Type string `json:"type"`
+	} `json:"features"`
+	ImageContext *struct {
+		LanguageHints []string `json:"languageHints"`
+	} `json:"imageContext,omitempty"`
+}
+
+type visionResponse struct {
+	Responses []struct {
+		FullTextAnnotation *struct {
+			Text  string `json:"text"`
+			Pages []struct {
+				Blocks []struct {
+					Paragraphs []struct {
+						Words []visionWord `json:"words"`
+					} `json:"paragraphs"`
+				} `json:"blocks"`
+			} `json:"pages"`
+		} `json:"fullTextAnnotation"`
+		Error *visionError `json:"error"`
+	} `json:"responses"`
+	Error *visionError `json:"error"`
+}
+
+type visionError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type visionWord struct {
+	BoundingBox struct {
+		Vertices []struct {
+			X int `json:"x"`
+			Y int `json:"y"`
+		} `json:"vertices"`
+	} `json:"boundingBox"`
+	Symbols []struct {
+		Text     string `json:"text"`
+		Property *struct {
+			DetectedBreak *struct {
+				Type string `json:"type"`
+			} `json:"detectedBreak"`
+		} `json:"property"`
+	} `json:"symbols"`
+	Confidence float64 `json:"confidence"`
+}
+
+// Recognize sends the image to the images:annotate endpoint.
+func (gr *GoogleVisionRecognizer) Recognize(ctx context.Context, image []byte) (*Result, error) {
+	req := visionImageRequest{}
+	req.Image.Content = base64.StdEncoding.EncodeToString(image)
+	req.Features = append(req.Features, struct {
+		Type string `json:"type"`
+	}{Type: "DOCUMENT_TEXT_DETECTION"})
+	if len(gr.conf.LanguageHints) > 0 {
+		req.ImageContext = &struct {
+			LanguageHints []string `json:"languageHints"`
+		}{LanguageHints: gr.conf.LanguageHints}
+	}
+
+	body, err := json.Marshal(visionRequest{Requests: []visionImageRequest{req}})
+	if err != nil {
+		return nil, fmt.Errorf("marshal google vision request failed: %w", err)
+	}
+
+	baseURL := gr.conf.BaseURL
+	if baseURL == "" {
+		baseURL = defaultGoogleVisionBaseURL
+	}
+	endpoint := strings.TrimRight(baseURL, "/") + "/images:annotate?key=" + url.QueryEscape(gr.conf.APIKey)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create google vision request failed: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := gr.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("send google vision request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read google vision response failed: %w", err)
+	}
+
+	var vr visionResponse
+	if err = json.Unmarshal(respBody, &vr); err != nil {
+		return nil, fmt.Errorf("unmarshal google vision response failed: %w, status= %d", err, resp.StatusCode)
+	}
+	if vr.Error != nil {
+		return nil, fmt.Errorf("google vision request failed: code= %d, message= %s", vr.Error.Code, vr.Error.Message)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("google vision request failed: status= %d", resp.StatusCode)
+	}
+	if len(vr.Responses) == 0 {
+		return nil, errors.New("google vision response is empty")
+	}
+	if e := vr.Responses[0].Error; e != nil {
+		return nil, fmt.Errorf("google vision request failed: code= %d, message= %s", e.Code, e.Message)
+	}
+
+	annotation := vr.Responses[0].FullTextAnnotation
+	if annotation == nil {
+		// no text found in the image
+		return &Result{}, nil
+	}
+
+	res := &Result{Text: strings.TrimSpace(annotation.Text)}
+	for _, page := range annotation.Pages {
+		for _, block := range page.Blocks {
+			for _, para := range block.Paragraphs {
+				res.Lines = append(res.Lines, visionLines(para.Words)...)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// visionLines assembles the words into lines, which end at the line breaks detected after the symbols.
+func visionLines(words []visionWord) []Line {
+	var (
+		lines []Line
+		sb    strings.Builder
+		count int
+		conf  float64
+		box   = [4]int{math.MaxInt, math.MaxInt, math.MinInt, math.MinInt}
+	)
+	flush := func() {
+		if count == 0 {
+			return
+		}
+		lines = append(lines, Line{
+			Text:       strings.TrimSpace(sb.String()),
+			BBox:       BBox{X: box[0], Y: box[1], Width: box[2] - box[0], Height: box[3] - box[1]},
+			Confidence: conf / float64(count),
+		})
+		sb.Reset()
+		count, conf = 0, 0
+		box = [4]int{math.MaxInt, math.MaxInt, math.MinInt, math.MinInt}
+	}
+
+	for _, w := range words {
+		count++
+		conf += w.Confidence
+		for _, v := range w.BoundingBox.Vertices {
+			box[0], box[1] = min(box[0], v.X), min(box[1], v.Y)
+			box[2], box[3] = max(box[2], v.X), max(box[3], v.Y)
+		}
+
+		lineEnd := false
+		for _, s := range w.Symbols {
+			sb.WriteString(s.Text)
+			if s.Property == nil || s.Property.DetectedBreak == nil {
+				continue
+			}
+			switch s.Property.DetectedBreak.Type {
+			case "SPACE", "SURE_SPACE":
+				sb.WriteString(" ")
+			case "EOL_SURE_SPACE", "LINE_BREAK":
+				lineEnd = true
+			case "HYPHEN":
+				sb.WriteString("-")
+				lineEnd = true
+			}
+		}
+		if lineEnd {
+			flush()
+		}
+	}
+	flush()
+
+	return lines
+}
@@ -0,0 +1,129 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ocr
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testVisionResponse = `{"responses":[{"fullTextAnnotation":{"text":"Invoice 2025-\n001\nTotal: 42.00\n","pages":[{"blocks":[{"paragraphs":[
+{"words":[
+ {"boundingBox":{"vertices":[{"x":32,"y":28},{"x":256,"y":28},{"x":256,"y":80},{"x":32,"y":80}]},"confidence":0.98,
+  "symbols":[{"text":"Invoice","property":{"detectedBreak":{"type":"SPACE"}}}]},
+ {"boundingBox":{"vertices":[{"x":288,"y":30},{"x":400,"y":30},{"x":400,"y":80},{"x":288,"y":80}]},"confidence":0.9,
+  "symbols":[{"text":"2025"},{"text":"","property":{"detectedBreak":{"type":"HYPHEN"}}}]},
+ {"boundingBox":{"vertices":[{"x":32,"y":88},{"x":128,"y":88},{"x":128,"y":140},{"x":32,"y":140}]},"confidence":0.8,
+  "symbols":[{"text":"001","property":{"detectedBreak":{"type":"LINE_BREAK"}}}]}
+]},
+{"words":[
+ {"boundingBox":{"vertices":[{"x":32,"y":150},{"x":200,"y":150},{"x":200,"y":200},{"x":32,"y":200}]},"confidence":0.7,
+  "symbols":[{"text":"Total"},{"text":":","property":{"detectedBreak":{"type":"SPACE"}}}]},
+ {"boundingBox":{"vertices":[{"x":224,"y":150},{"x":360,"y":150},{"x":360,"y":202},{"x":224,"y":202}]},"confidence":0.9,
+  "symbols":[{"text":"42.00","property":{"detectedBreak":{"type":"EOL_SURE_SPACE"}}}]}
+]}]}]}]}}]}`
+
+func TestNewGoogleVisionRecognizer(t *testing.T) {
+	_, err := NewGoogleVisionRecognizer(nil)
+	assert.EqualError(t, err, "new google vision recognizer, api key is required")
+
+	_, err = NewGoogleVisionRecognizer(&GoogleVisionConfig{})
+	assert.EqualError(t, err, "new google vision recognizer, api key is required")
+}
+
+func TestGoogleVisionRecognizer_Recognize(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/v1/images:annotate", r.URL.Path)
+			assert.Equal(t, "test-key", r.URL.Query().Get("key"))
+
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			var req visionRequest
+			assert.NoError(t, json.Unmarshal(body, &req))
+			assert.Equal(t, 1, len(req.Requests))
+			assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("image data")), req.Requests[0].Image.Content)
+			assert.Equal(t, "DOCUMENT_TEXT_DETECTION", req.Requests[0].Features[0].Type)
+			assert.Equal(t, []string{"en"}, req.Requests[0].ImageContext.LanguageHints)
+
+			_, _ = w.Write([]byte(testVisionResponse))
+		}))
+		defer server.Close()
+
+		gr, err := NewGoogleVisionRecognizer(&GoogleVisionConfig{
+			APIKey:        "test-key",
+			BaseURL:       server.URL + "/v1/",
+			LanguageHints: []string{"en"},
+		})
+		assert.NoError(t, err)
+
+		res, err := gr.Recognize(ctx, []byte("image data"))
+		assert.NoError(t, err)
+		assert.Equal(t, "Invoice 2025-\n001\nTotal: 42.00", res.Text)
+		assert.Equal(t, 3, len(res.Lines))
+		assert.Equal(t, "Invoice 2025-", res.Lines[0].Text)
+		assert.Equal(t, BBox{X: 32, Y: 28, Width: 368, Height: 52}, res.Lines[0].BBox)
+		assert.InDelta(t, 0.94, res.Lines[0].Confidence, 1e-9)
+		assert.Equal(t, "001", res.Lines[1].Text)
+		assert.Equal(t, "Total: 42.00", res.Lines[2].Text)
+		assert.Equal(t, BBox{X: 32, Y: 150, Width: 328, Height: 52}, res.Lines[2].BBox)
+	})
+
+	t.Run("no text", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"responses":[{}]}`))
+		}))
+		defer server.Close()
+
+		gr, err := NewGoogleVisionRecognizer(&GoogleVisionConfig{APIKey: "test-key", BaseURL: server.URL})
+		assert.NoError(t, err)
+
+		res, err := gr.Recognize(ctx, []byte("image data"))
+		assert.NoError(t, err)
+		assert.Equal(t, &Result{}, res)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error":{"code":403,"message":"API key not valid."}}`))
+		}))
+		defer server.Close()
+
+		gr, err := NewGoogleVisionRecognizer(&GoogleVisionConfig{APIKey: "test-key", BaseURL: server.URL})
+		assert.NoError(t, err)
+
+		_, err = gr.Recognize(ctx, []byte("image data"))
+		assert.EqualError(t, err, "google vision request failed: code= 403, message= API key not valid.")
+
+		server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"responses":[{"error":{"code":3,"message":"Bad image data."}}]}`))
+		})
+		_, err = gr.Recognize(ctx, []byte("image data"))
+		assert.EqualError(t, err, "google vision request failed: code= 3, message= Bad image data.")
+	})
+}
@@ -0,0 +1,235 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package ocr provides a parser recognizing the text of images and scanned PDF files with a pluggable OCR backend.
+package ocr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	MetaKeyOCR        = "_ocr"
+	MetaKeyLines      = "_ocr_lines"
+	MetaKeyConfidence = "_ocr_confidence"
+	MetaKeyPageNumber = "_page_number" // same key as the PDF parser
+	MetaKeySource     = "_source"
+)
+
+// Recognizer is the OCR backend, recognizing the text of an image.
+type Recognizer interface {
+	Recognize(ctx context.Context, image []byte) (*Result, error)
+}
+
+// Result is the text recognized in an image.
+type Result struct {
+	// Text is the text of the image in reading order, with the lines separated by new lines,
+	// and the paragraphs by blank lines.
+	Text string
+	// Lines are the recognized lines with their bounding boxes.
+	Lines []Line
+}
+
+// Line is a line of text recognized in an image.
+type Line struct {
+	Text string `json:"text"`
+	BBox BBox   `json:"bbox"`
+	// Confidence is the mean confidence of the words of the line, between 0 and 1.
+	Confidence float64 `json:"confidence"`
+	// Image is the 1-based index of the image on the PDF page, 0 for image files.
+	Image int `json:"image,omitempty"`
+}
+
+// BBox is a bounding box in pixels, from the top left corner of the image.
+type BBox struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// Config is the configuration for OCR parser.
+type Config struct {
+	// Recognizer is the OCR backend, e.g. NewTesseractRecognizer or NewGoogleVisionRecognizer. Required.
+	Recognizer Recognizer
+	// MinPageTextLength is the length of text, in characters, under which a PDF page is considered image-only
+	// and its images are recognized. The pages with text are extracted as is.
+	// Optional. Default 0, only the pages without text are recognized.
+	MinPageTextLength int
+	// ForceOCR recognizes the images of all the PDF pages, ignoring their text.
+	ForceOCR bool
+	// NewPDFParser creates the parser splitting the PDF files into one document per page, with the MetaKeyPageNumber
+	// metadata, and calling imageParser with the images embedded in each page and the MetaKeyPageNumber extra meta,
+	// e.g. the PDF parser of components/document/parser/pdf:
+	//
+	//	func(ctx context.Context, imageParser parser.Parser) (parser.Parser, error) {
+	//		return pdf.NewPDFParser(ctx, &pdf.Config{ToPages: true, Layout: true, ImageParser: imageParser})
+	//	}
+	//
+	// Optional. Default nil, the PDF files are not supported.
+	NewPDFParser func(ctx context.Context, imageParser parser.Parser) (parser.Parser, error)
+}
+
+// OCRParser reads from io.Reader and recognizes the text of the image, one document per image,
+// or of the PDF file, one document per page.
+type OCRParser struct {
+	conf *Config
+}
+
+// NewOCRParser creates a new OCR parser.
+func NewOCRParser(ctx context.Context, config *Config) (*OCRParser, error) {
+	if config == nil {
+		return nil, errors.New("new ocr parser, config is nil")
+	}
+	if config.Recognizer == nil {
+		return nil, errors.New("new ocr parser, recognizer is required")
+	}
+
+	return &OCRParser{conf: config}, nil
+}
+
+// Parse parses the image or the PDF file from io.Reader. The format is detected from the content.
+func (op *OCRParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	commonOpts := parser.GetCommonOptions(nil, opts...)
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("ocr parser read all from reader failed: %w", err)
+	}
+
+	meta := make(map[string]any, len(commonOpts.ExtraMeta)+4)
+	if commonOpts.URI != "" {
+		meta[MetaKeySource] = commonOpts.URI
+	}
+	for k, v := range commonOpts.ExtraMeta {
+		meta[k] = v
+	}
+
+	if bytes.HasPrefix(data, []byte("%PDF-")) {
+		if op.conf.NewPDFParser == nil {
+			return nil, errors.New("ocr parser unsupported content type: application/pdf, pdf parser is not configured")
+		}
+		return op.parsePDF(ctx, data, meta)
+	}
+
+	if !isImage(data) {
+		return nil, fmt.Errorf("ocr parser unsupported content type: %s", http.DetectContentType(data))
+	}
+
+	res, err := op.conf.Recognizer.Recognize(ctx, data)
+	if err != nil {
+		return nil, fmt.Errorf("recognize image failed: %w", err)
+	}
+
+	setResultMeta(meta, res.Lines)
+
+	return []*schema.Document{{
+		Content:  res.Text,
+		MetaData: meta,
+	}}, nil
+}
+
+// parsePDF extracts the text of the PDF pages, and recognizes the images of the pages without text.
+func (op *OCRParser) parsePDF(ctx context.Context, data []byte, meta map[string]any) ([]*schema.Document, error) {
+	images := &imageCollector{pages: map[int][][]byte{}}
+	pp, err := op.conf.NewPDFParser(ctx, images)
+	if err != nil {
+		return nil, fmt.Errorf("new pdf parser failed: %w", err)
+	}
+
+	pages, err := pp.Parse(ctx, bytes.NewReader(data), parser.WithExtraMeta(meta))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, page := range pages {
+		number, _ := page.MetaData[MetaKeyPageNumber].(int)
+		textLength := len([]rune(strings.TrimSpace(page.Content)))
+		if (!op.conf.ForceOCR && textLength > 0 && textLength >= op.conf.MinPageTextLength) || len(images.pages[number]) == 0 {
+			page.MetaData[MetaKeyOCR] = false
+			continue
+		}
+
+		var (
+			texts []string
+			lines []Line
+		)
+		for i, img := range images.pages[number] {
+			res, err := op.conf.Recognizer.Recognize(ctx, img)
+			if err != nil {
+				return nil, fmt.Errorf("recognize image failed: %w, page= %d, image= %d", err, number, i+1)
+			}
+			if strings.TrimSpace(res.Text) != "" {
+				texts = append(texts, res.Text)
+			}
+			for _, l := range res.Lines {
+				l.Image = i + 1
+				lines = append(lines, l)
+			}
+		}
+
+		page.Content = strings.Join(texts, "\n\n")
+		setResultMeta(page.MetaData, lines)
+	}
+
+	return pages, nil
+}
+
+func setResultMeta(meta map[string]any, lines []Line) {
+	meta[MetaKeyOCR] = true
+	meta[MetaKeyLines] = lines
+
+	var sum float64
+	for _, l := range lines {
+		sum += l.Confidence
+	}
+	if len(lines) > 0 {
+		meta[MetaKeyConfidence] = sum / float64(len(lines))
+	}
+}
+
+// imageCollector is the image parser of the PDF parser, collecting the images of the pages instead of parsing them.
+type imageCollector struct {
+	pages map[int][][]byte
+}
+
+func (c *imageCollector) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	page, _ := parser.GetCommonOptions(nil, opts...).ExtraMeta[MetaKeyPageNumber].(int)
+	c.pages[page] = append(c.pages[page], data)
+
+	return nil, nil
+}
+
+func isImage(data []byte) bool {
+	if bytes.HasPrefix(data, []byte("II*\x00")) || bytes.HasPrefix(data, []byte("MM\x00*")) { // tiff
+		return true
+	}
+	return strings.HasPrefix(http.DetectContentType(data), "image/")
+}
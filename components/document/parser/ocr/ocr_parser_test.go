@@ -0,0 +1,209 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ocr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockRecognizer struct {
+	images [][]byte
+	err    error
+}
+
+func (m *mockRecognizer) Recognize(ctx context.Context, image []byte) (*Result, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.images = append(m.images, image)
+	return &Result{
+		Text: "Invoice 2025-001\nTotal: 42.00 EUR",
+		Lines: []Line{
+			{Text: "Invoice 2025-001", BBox: BBox{X: 32, Y: 28, Width: 448, Height: 52}, Confidence: 0.9},
+			{Text: "Total: 42.00 EUR", BBox: BBox{X: 32, Y: 88, Width: 448, Height: 52}, Confidence: 0.8},
+		},
+	}, nil
+}
+
+// fakePDFParser splits the PDF files like the PDF parser, into an image-only page with the image,
+// and a typed page without image.
+type fakePDFParser struct {
+	imageParser parser.Parser
+	image       []byte
+}
+
+func newFakePDFParser(t *testing.T) func(ctx context.Context, imageParser parser.Parser) (parser.Parser, error) {
+	image, err := os.ReadFile("./testdata/scanned.png")
+	assert.NoError(t, err)
+	return func(ctx context.Context, imageParser parser.Parser) (parser.Parser, error) {
+		return &fakePDFParser{imageParser: imageParser, image: image}, nil
+	}
+}
+
+func (f *fakePDFParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	extraMeta := parser.GetCommonOptions(nil, opts...).ExtraMeta
+	var docs []*schema.Document
+	for i, content := range []string{"", "A typed page."} {
+		meta := map[string]any{MetaKeyPageNumber: i + 1}
+		for k, v := range extraMeta {
+			meta[k] = v
+		}
+		docs = append(docs, &schema.Document{Content: content, MetaData: meta})
+	}
+
+	_, err := f.imageParser.Parse(ctx, bytes.NewReader(f.image), parser.WithURI("page-1-image-1.png"),
+		parser.WithExtraMeta(map[string]any{MetaKeyPageNumber: 1}))
+	if err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+func TestNewOCRParser(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewOCRParser(ctx, nil)
+	assert.EqualError(t, err, "new ocr parser, config is nil")
+
+	_, err = NewOCRParser(ctx, &Config{})
+	assert.EqualError(t, err, "new ocr parser, recognizer is required")
+
+	p, err := NewOCRParser(ctx, &Config{Recognizer: &mockRecognizer{}})
+	assert.NoError(t, err)
+	assert.NotNil(t, p)
+}
+
+func TestOCRParser_Parse(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("image", func(t *testing.T) {
+		data, err := os.ReadFile("./testdata/scanned.png")
+		assert.NoError(t, err)
+
+		rec := &mockRecognizer{}
+		p, err := NewOCRParser(ctx, &Config{Recognizer: rec})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, bytes.NewReader(data),
+			parser.WithURI("scanned.png"), parser.WithExtraMeta(map[string]any{"test": "test"}))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(docs))
+		assert.Equal(t, "Invoice 2025-001\nTotal: 42.00 EUR", docs[0].Content)
+		assert.Equal(t, [][]byte{data}, rec.images)
+
+		meta := docs[0].MetaData
+		assert.Equal(t, "scanned.png", meta[MetaKeySource])
+		assert.Equal(t, "test", meta["test"])
+		assert.Equal(t, true, meta[MetaKeyOCR])
+		assert.InDelta(t, 0.85, meta[MetaKeyConfidence], 1e-9)
+		lines := meta[MetaKeyLines].([]Line)
+		assert.Equal(t, 2, len(lines))
+		assert.Equal(t, BBox{X: 32, Y: 88, Width: 448, Height: 52}, lines[1].BBox)
+		assert.Equal(t, 0, lines[1].Image)
+	})
+
+	t.Run("scanned pdf", func(t *testing.T) {
+		data, err := os.ReadFile("./testdata/scanned.pdf")
+		assert.NoError(t, err)
+
+		rec := &mockRecognizer{}
+		p, err := NewOCRParser(ctx, &Config{Recognizer: rec, NewPDFParser: newFakePDFParser(t)})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, bytes.NewReader(data), parser.WithURI("scanned.pdf"))
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(docs))
+		assert.Equal(t, 1, len(rec.images))
+		assert.Equal(t, "image/png", http.DetectContentType(rec.images[0]))
+
+		assert.Equal(t, "Invoice 2025-001\nTotal: 42.00 EUR", docs[0].Content)
+		assert.Equal(t, 1, docs[0].MetaData[MetaKeyPageNumber])
+		assert.Equal(t, "scanned.pdf", docs[0].MetaData[MetaKeySource])
+		assert.Equal(t, true, docs[0].MetaData[MetaKeyOCR])
+		lines := docs[0].MetaData[MetaKeyLines].([]Line)
+		assert.Equal(t, 2, len(lines))
+		assert.Equal(t, 1, lines[0].Image)
+
+		assert.Equal(t, "A typed page.", strings.TrimSpace(docs[1].Content))
+		assert.Equal(t, 2, docs[1].MetaData[MetaKeyPageNumber])
+		assert.Equal(t, false, docs[1].MetaData[MetaKeyOCR])
+		assert.NotContains(t, docs[1].MetaData, MetaKeyLines)
+	})
+
+	t.Run("force ocr", func(t *testing.T) {
+		data, err := os.ReadFile("./testdata/scanned.pdf")
+		assert.NoError(t, err)
+
+		rec := &mockRecognizer{}
+		p, err := NewOCRParser(ctx, &Config{Recognizer: rec, ForceOCR: true, NewPDFParser: newFakePDFParser(t)})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, bytes.NewReader(data))
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(docs))
+		assert.Equal(t, 1, len(rec.images))
+		// the typed page has no image to recognize
+		assert.Equal(t, false, docs[1].MetaData[MetaKeyOCR])
+	})
+
+	t.Run("min page text length", func(t *testing.T) {
+		data, err := os.ReadFile("./testdata/scanned.pdf")
+		assert.NoError(t, err)
+
+		rec := &mockRecognizer{}
+		p, err := NewOCRParser(ctx, &Config{Recognizer: rec, MinPageTextLength: 100, NewPDFParser: newFakePDFParser(t)})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, bytes.NewReader(data))
+		assert.NoError(t, err)
+		assert.Equal(t, true, docs[0].MetaData[MetaKeyOCR])
+		assert.Equal(t, false, docs[1].MetaData[MetaKeyOCR])
+	})
+
+	t.Run("recognize error", func(t *testing.T) {
+		data, err := os.ReadFile("./testdata/scanned.png")
+		assert.NoError(t, err)
+
+		p, err := NewOCRParser(ctx, &Config{Recognizer: &mockRecognizer{err: errors.New("mock error")}})
+		assert.NoError(t, err)
+
+		_, err = p.Parse(ctx, bytes.NewReader(data))
+		assert.ErrorContains(t, err, "mock error")
+	})
+
+	t.Run("unsupported content", func(t *testing.T) {
+		p, err := NewOCRParser(ctx, &Config{Recognizer: &mockRecognizer{}})
+		assert.NoError(t, err)
+
+		_, err = p.Parse(ctx, strings.NewReader("plain text"))
+		assert.ErrorContains(t, err, "ocr parser unsupported content type: text/plain")
+
+		_, err = p.Parse(ctx, strings.NewReader("%PDF-1.4"))
+		assert.ErrorContains(t, err, "pdf parser is not configured")
+	})
+}
@@ -0,0 +1,171 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ocr
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// TesseractConfig is the configuration for the Tesseract recognizer.
+type TesseractConfig struct {
+	// Path is the path of the tesseract binary.
+	// Optional. Default "tesseract", looked up in PATH.
+	Path string
+	// Languages are the languages of the text, e.g. []string{"eng", "chi_sim"}, whose trained data must be installed.
+	// Optional. Default tesseract's default, "eng".
+	Languages []string
+	// PageSegMode is the page segmentation mode (--psm), e.g. 6 for a single uniform block of text.
+	// Optional. Default tesseract's default, 3: fully automatic page segmentation.
+	PageSegMode int
+	// ExtraArgs are added to the command line, e.g. []string{"-c", "preserve_interword_spaces=1"}.
+	ExtraArgs []string
+}
+
+// TesseractRecognizer recognizes the text with the local Tesseract OCR engine, running its command line.
+type TesseractRecognizer struct {
+	path string
+	conf *TesseractConfig
+}
+
+// NewTesseractRecognizer creates a new Tesseract recognizer.
+func NewTesseractRecognizer(config *TesseractConfig) (*TesseractRecognizer, error) {
+	if config == nil {
+		config = &TesseractConfig{}
+	}
+
+	bin := config.Path
+	if bin == "" {
+		bin = "tesseract"
+	}
+	path, err := exec.LookPath(bin)
+	if err != nil {
+		return nil, fmt.Errorf("new tesseract recognizer, tesseract not found: %w", err)
+	}
+
+	return &TesseractRecognizer{path: path, conf: config}, nil
+}
+
+// Recognize runs tesseract on the image, read from stdin, with the tsv output giving the word boxes.
+func (tr *TesseractRecognizer) Recognize(ctx context.Context, image []byte) (*Result, error) {
+	args := []string{"stdin", "stdout"}
+	if len(tr.conf.Languages) > 0 {
+		args = append(args, "-l", strings.Join(tr.conf.Languages, "+"))
+	}
+	if tr.conf.PageSegMode > 0 {
+		args = append(args, "--psm", strconv.Itoa(tr.conf.PageSegMode))
+	}
+	args = append(args, tr.conf.ExtraArgs...)
+	args = append(args, "tsv")
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, tr.path, args...)
+	cmd.Stdin = bytes.NewReader(image)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("run tesseract failed: %w, stderr= %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return parseTesseractTSV(stdout.Bytes())
+}
+
+// tsvLine is a line of the tesseract tsv output being assembled from its words.
+type tsvLine struct {
+	key        [3]int // block, paragraph and line numbers
+	words      []string
+	confidence float64
+	x0, y0     int
+	x1, y1     int
+}
+
+// parseTesseractTSV reads the words of the tsv output, whose columns are:
+// level page_num block_num par_num line_num word_num left top width height conf text.
+func parseTesseractTSV(data []byte) (*Result, error) {
+	var (
+		lines []*tsvLine
+		cur   *tsvLine
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for row := 0; scanner.Scan(); row++ {
+		fields := strings.SplitN(scanner.Text(), "\t", 12)
+		if row == 0 || len(fields) < 12 || fields[0] != "5" {
+			// the header, and the page, block, paragraph and line levels
+			continue
+		}
+
+		nums := make([]int, 9)
+		for i := range nums {
+			n, err := strconv.Atoi(fields[i+1])
+			if err != nil {
+				return nil, fmt.Errorf("parse tesseract tsv failed, row= %d: %w", row, err)
+			}
+			nums[i] = n
+		}
+		conf, err := strconv.ParseFloat(fields[10], 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse tesseract tsv failed, row= %d: %w", row, err)
+		}
+		text := strings.TrimSpace(fields[11])
+		if text == "" {
+			continue
+		}
+		// nums: page, block, paragraph, line, word, left, top, width, height
+		key := [3]int{nums[1], nums[2], nums[3]}
+		if cur == nil || cur.key != key {
+			cur = &tsvLine{key: key, x0: nums[5], y0: nums[6], x1: nums[5] + nums[7], y1: nums[6] + nums[8]}
+			lines = append(lines, cur)
+		}
+		cur.words = append(cur.words, text)
+		cur.confidence += max(conf, 0) / 100
+		cur.x0, cur.y0 = min(cur.x0, nums[5]), min(cur.y0, nums[6])
+		cur.x1, cur.y1 = max(cur.x1, nums[5]+nums[7]), max(cur.y1, nums[6]+nums[8])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read tesseract tsv failed: %w", err)
+	}
+
+	res := &Result{}
+	var sb strings.Builder
+	for i, l := range lines {
+		if i > 0 {
+			if l.key[0] != lines[i-1].key[0] || l.key[1] != lines[i-1].key[1] {
+				sb.WriteString("\n\n")
+			} else {
+				sb.WriteString("\n")
+			}
+		}
+		text := strings.Join(l.words, " ")
+		sb.WriteString(text)
+
+		res.Lines = append(res.Lines, Line{
+			Text:       text,
+			BBox:       BBox{X: l.x0, Y: l.y0, Width: l.x1 - l.x0, Height: l.y1 - l.y0},
+			Confidence: l.confidence / float64(len(l.words)),
+		})
+	}
+	res.Text = sb.String()
+
+	return res, nil
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ocr
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testTSV = "level\tpage_num\tblock_num\tpar_num\tline_num\tword_num\tleft\ttop\twidth\theight\tconf\ttext\n" +
+	"1\t1\t0\t0\t0\t0\t0\t0\t560\t160\t-1\t\n" +
+	"2\t1\t1\t0\t0\t0\t32\t28\t448\t112\t-1\t\n" +
+	"3\t1\t1\t1\t0\t0\t32\t28\t448\t112\t-1\t\n" +
+	"4\t1\t1\t1\t1\t0\t32\t28\t448\t52\t-1\t\n" +
+	"5\t1\t1\t1\t1\t1\t32\t28\t224\t52\t96.5\tInvoice\n" +
+	"5\t1\t1\t1\t1\t2\t288\t30\t192\t50\t93.5\t2025-001\n" +
+	"4\t1\t1\t1\t2\t0\t32\t88\t448\t52\t-1\t\n" +
+	"5\t1\t1\t1\t2\t1\t32\t88\t192\t52\t90\tTotal:\n" +
+	"5\t1\t1\t1\t2\t2\t256\t88\t80\t52\t-1\t \n" +
+	"5\t1\t1\t1\t2\t3\t256\t88\t224\t52\t80\t42.00\n" +
+	"5\t1\t2\t1\t1\t1\t32\t200\t64\t20\t70\tEUR\n"
+
+func TestParseTesseractTSV(t *testing.T) {
+	res, err := parseTesseractTSV([]byte(testTSV))
+	assert.NoError(t, err)
+	assert.Equal(t, "Invoice 2025-001\nTotal: 42.00\n\nEUR", res.Text)
+	assert.Equal(t, 3, len(res.Lines))
+	assert.Equal(t, "Invoice 2025-001", res.Lines[0].Text)
+	assert.Equal(t, BBox{X: 32, Y: 28, Width: 448, Height: 52}, res.Lines[0].BBox)
+	assert.InDelta(t, 0.95, res.Lines[0].Confidence, 1e-9)
+	// the empty word and its negative confidence are skipped
+	assert.Equal(t, "Total: 42.00", res.Lines[1].Text)
+	assert.Equal(t, BBox{X: 32, Y: 88, Width: 448, Height: 52}, res.Lines[1].BBox)
+	assert.InDelta(t, 0.85, res.Lines[1].Confidence, 1e-9)
+	assert.Equal(t, "EUR", res.Lines[2].Text)
+	assert.Equal(t, BBox{X: 32, Y: 200, Width: 64, Height: 20}, res.Lines[2].BBox)
+	assert.InDelta(t, 0.7, res.Lines[2].Confidence, 1e-9)
+
+	res, err = parseTesseractTSV([]byte("level\tpage_num\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "", res.Text)
+	assert.Empty(t, res.Lines)
+
+	_, err = parseTesseractTSV([]byte("header\n5\t1\t1\t1\tx\t1\t0\t0\t1\t1\t90\tword\n"))
+	assert.ErrorContains(t, err, "parse tesseract tsv failed")
+}
+
+func TestTesseractRecognizer(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake tesseract is a shell script")
+	}
+
+	_, err := NewTesseractRecognizer(&TesseractConfig{Path: filepath.Join(t.TempDir(), "not-exist")})
+	assert.ErrorContains(t, err, "new tesseract recognizer, tesseract not found")
+
+	dir := t.TempDir()
+	tsvPath := filepath.Join(dir, "out.tsv")
+	argsPath := filepath.Join(dir, "args")
+	stdinPath := filepath.Join(dir, "stdin")
+	assert.NoError(t, os.WriteFile(tsvPath, []byte(testTSV), 0o644))
+
+	script := "#!/bin/sh\n" +
+		"echo \"$@\" > " + argsPath + "\n" +
+		"cat > " + stdinPath + "\n" +
+		"cat " + tsvPath + "\n"
+	bin := filepath.Join(dir, "tesseract")
+	assert.NoError(t, os.WriteFile(bin, []byte(script), 0o755))
+
+	tr, err := NewTesseractRecognizer(&TesseractConfig{
+		Path:        bin,
+		Languages:   []string{"eng", "deu"},
+		PageSegMode: 6,
+		ExtraArgs:   []string{"-c", "preserve_interword_spaces=1"},
+	})
+	assert.NoError(t, err)
+
+	res, err := tr.Recognize(context.Background(), []byte("image data"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Invoice 2025-001\nTotal: 42.00\n\nEUR", res.Text)
+	assert.Equal(t, 3, len(res.Lines))
+
+	args, err := os.ReadFile(argsPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "stdin stdout -l eng+deu --psm 6 -c preserve_interword_spaces=1 tsv", strings.TrimSpace(string(args)))
+	stdin, err := os.ReadFile(stdinPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "image data", string(stdin))
+
+	failing := filepath.Join(dir, "failing")
+	assert.NoError(t, os.WriteFile(failing, []byte("#!/bin/sh\necho 'Error in pixReadStream' >&2\nexit 1\n"), 0o755))
+	tr, err = NewTesseractRecognizer(&TesseractConfig{Path: failing})
+	assert.NoError(t, err)
+	_, err = tr.Recognize(context.Background(), []byte("image data"))
+	assert.ErrorContains(t, err, "stderr= Error in pixReadStream")
+}
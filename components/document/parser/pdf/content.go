@@ -0,0 +1,386 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pdf
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/dslipak/pdf"
+)
+
+// maxFormDepth limits the nesting of form xobjects, which may reference each other.
+const maxFormDepth = 8
+
+// glyph is a character drawn on the page, positioned in the page space: x increasing left to right,
+// y increasing bottom to top, in points.
+type glyph struct {
+	x, y, w, size float64
+	s             string
+}
+
+// placedImage is an image xobject drawn on the page, with the top left corner of its placement.
+type placedImage struct {
+	name string
+	v    pdf.Value
+	x, y float64
+}
+
+// pageContent is the content drawn on a page.
+type pageContent struct {
+	glyphs []glyph
+	images []placedImage
+}
+
+type matrix [3][3]float64
+
+var identity = matrix{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+
+func (m matrix) mul(n matrix) matrix {
+	var r matrix
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			for k := 0; k < 3; k++ {
+				r[i][j] += m[i][k] * n[k][j]
+			}
+		}
+	}
+	return r
+}
+
+func matrixOf(v pdf.Value) matrix {
+	if v.Len() != 6 {
+		return identity
+	}
+	m := identity
+	for i := 0; i < 6; i++ {
+		m[i/2][i%2] = v.Index(i).Float64()
+	}
+	return m
+}
+
+// graphicsState is the part of the graphics state saved by the q operator that is relevant to the text positions.
+type graphicsState struct {
+	ctm       matrix
+	font      *fontInfo
+	fontSize  float64
+	charSpace float64
+	wordSpace float64
+	scale     float64
+	leading   float64
+	rise      float64
+}
+
+// fontInfo holds the encoding and the glyph widths of a font.
+type fontInfo struct {
+	enc          pdf.TextEncoding
+	twoByte      bool
+	widths       map[int]float64
+	ranges       []widthRange
+	defaultWidth float64
+	// unitScale converts the widths to text space, 1/1000 except for Type3 fonts.
+	unitScale float64
+}
+
+type widthRange struct {
+	first, last int
+	width       float64
+}
+
+func newFontInfo(v pdf.Value) *fontInfo {
+	fi := &fontInfo{
+		enc:       pdf.Font{V: v}.Encoder(),
+		widths:    map[int]float64{},
+		unitScale: 0.001,
+	}
+	if fi.enc == nil {
+		fi.enc = rawEncoding{}
+	}
+
+	switch v.Key("Subtype").Name() {
+	case "Type0":
+		// composite fonts are read with 2 bytes codes, which is the case of the common Identity-H encoding
+		fi.twoByte = true
+		desc := v.Key("DescendantFonts").Index(0)
+		fi.defaultWidth = 1000
+		if dw := desc.Key("DW"); dw.Kind() != pdf.Null {
+			fi.defaultWidth = dw.Float64()
+		}
+		w := desc.Key("W")
+		for i := 0; i+1 < w.Len(); {
+			first := int(w.Index(i).Int64())
+			if next := w.Index(i + 1); next.Kind() == pdf.Array {
+				for j := 0; j < next.Len(); j++ {
+					fi.widths[first+j] = next.Index(j).Float64()
+				}
+				i += 2
+				continue
+			}
+			fi.ranges = append(fi.ranges, widthRange{
+				first: first,
+				last:  int(w.Index(i + 1).Int64()),
+				width: w.Index(i + 2).Float64(),
+			})
+			i += 3
+		}
+	default:
+		if fm := v.Key("FontMatrix").Index(0).Float64(); v.Key("Subtype").Name() == "Type3" && fm != 0 {
+			fi.unitScale = fm
+		}
+		first := int(v.Key("FirstChar").Int64())
+		widths := v.Key("Widths")
+		for i := 0; i < widths.Len(); i++ {
+			fi.widths[first+i] = widths.Index(i).Float64()
+		}
+		// the standard 14 fonts may come without widths, guess an average one
+		fi.defaultWidth = 500
+		if mw := v.Key("FontDescriptor").Key("MissingWidth"); mw.Kind() != pdf.Null {
+			fi.defaultWidth = mw.Float64()
+		}
+	}
+
+	return fi
+}
+
+func (fi *fontInfo) width(code int) float64 {
+	if w, ok := fi.widths[code]; ok {
+		return w
+	}
+	for _, r := range fi.ranges {
+		if code >= r.first && code <= r.last {
+			return r.width
+		}
+	}
+	return fi.defaultWidth
+}
+
+// rawEncoding keeps the codes as is, for the fonts without a known encoding.
+type rawEncoding struct{}
+
+func (rawEncoding) Decode(raw string) string {
+	return raw
+}
+
+// contentReader interprets the content streams of a page, collecting the positioned glyphs and the placed images.
+type contentReader struct {
+	content pageContent
+}
+
+// readPageContent reads the content of the page.
+func readPageContent(p pdf.Page) (content *pageContent, err error) {
+	// pdf.Interpret and the pdf.Value accessors panic on malformed content
+	defer func() {
+		if r := recover(); r != nil {
+			content, err = nil, fmt.Errorf("read pdf page content failed: %v", r)
+		}
+	}()
+
+	cr := &contentReader{}
+	res := p.Resources()
+
+	strm := p.V.Key("Contents")
+	if strm.Kind() == pdf.Array {
+		for i := 0; i < strm.Len(); i++ {
+			cr.interpret(strm.Index(i), res, identity, 0)
+		}
+	} else {
+		cr.interpret(strm, res, identity, 0)
+	}
+
+	return &cr.content, nil
+}
+
+func (cr *contentReader) interpret(strm, res pdf.Value, ctm matrix, depth int) {
+	fonts := map[string]*fontInfo{}
+	g := graphicsState{ctm: ctm, scale: 1}
+	var (
+		stack   []graphicsState
+		tm, tlm = identity, identity
+	)
+
+	showText := func(raw string) {
+		if g.font == nil {
+			return
+		}
+		step := 1
+		if g.font.twoByte {
+			step = 2
+		}
+		for i := 0; i+step <= len(raw); i += step {
+			code := int(raw[i])
+			if step == 2 {
+				code = code<<8 | int(raw[i+1])
+			}
+
+			trm := matrix{{g.fontSize * g.scale, 0, 0}, {0, g.fontSize, 0}, {0, g.rise, 1}}.mul(tm).mul(g.ctm)
+			m := tm.mul(g.ctm)
+
+			w0 := g.font.width(code) * g.font.unitScale
+			tx := w0*g.fontSize + g.charSpace
+			if step == 1 && code == ' ' {
+				tx += g.wordSpace
+			}
+			tx *= g.scale
+
+			cr.content.glyphs = append(cr.content.glyphs, glyph{
+				x:    trm[2][0],
+				y:    trm[2][1],
+				w:    w0 * g.fontSize * g.scale * math.Hypot(m[0][0], m[0][1]),
+				size: math.Hypot(trm[1][0], trm[1][1]),
+				s:    g.font.enc.Decode(raw[i : i+step]),
+			})
+
+			tm = matrix{{1, 0, 0}, {0, 1, 0}, {tx, 0, 1}}.mul(tm)
+		}
+	}
+
+	nextLine := func() {
+		tlm = matrix{{1, 0, 0}, {0, 1, 0}, {0, -g.leading, 1}}.mul(tlm)
+		tm = tlm
+	}
+
+	pdf.Interpret(strm, func(stk *pdf.Stack, op string) {
+		args := make([]pdf.Value, stk.Len())
+		for i := len(args) - 1; i >= 0; i-- {
+			args[i] = stk.Pop()
+		}
+
+		switch op {
+		case "q":
+			stack = append(stack, g)
+		case "Q":
+			if n := len(stack) - 1; n >= 0 {
+				g, stack = stack[n], stack[:n]
+			}
+		case "cm":
+			if len(args) == 6 {
+				var m matrix
+				for i := 0; i < 6; i++ {
+					m[i/2][i%2] = args[i].Float64()
+				}
+				m[2][2] = 1
+				g.ctm = m.mul(g.ctm)
+			}
+		case "BT":
+			tm, tlm = identity, identity
+		case "Tf":
+			if len(args) == 2 {
+				name := args[0].Name()
+				fi, ok := fonts[name]
+				if !ok {
+					fi = newFontInfo(res.Key("Font").Key(name))
+					fonts[name] = fi
+				}
+				g.font, g.fontSize = fi, args[1].Float64()
+			}
+		case "Tc":
+			if len(args) == 1 {
+				g.charSpace = args[0].Float64()
+			}
+		case "Tw":
+			if len(args) == 1 {
+				g.wordSpace = args[0].Float64()
+			}
+		case "Tz":
+			if len(args) == 1 {
+				g.scale = args[0].Float64() / 100
+			}
+		case "TL":
+			if len(args) == 1 {
+				g.leading = args[0].Float64()
+			}
+		case "Ts":
+			if len(args) == 1 {
+				g.rise = args[0].Float64()
+			}
+		case "Td", "TD":
+			if len(args) == 2 {
+				if op == "TD" {
+					g.leading = -args[1].Float64()
+				}
+				tlm = matrix{{1, 0, 0}, {0, 1, 0}, {args[0].Float64(), args[1].Float64(), 1}}.mul(tlm)
+				tm = tlm
+			}
+		case "Tm":
+			if len(args) == 6 {
+				var m matrix
+				for i := 0; i < 6; i++ {
+					m[i/2][i%2] = args[i].Float64()
+				}
+				m[2][2] = 1
+				tm, tlm = m, m
+			}
+		case "T*":
+			nextLine()
+		case "Tj":
+			if len(args) == 1 {
+				showText(args[0].RawString())
+			}
+		case "'":
+			if len(args) == 1 {
+				nextLine()
+				showText(args[0].RawString())
+			}
+		case "\"":
+			if len(args) == 3 {
+				g.wordSpace, g.charSpace = args[0].Float64(), args[1].Float64()
+				nextLine()
+				showText(args[2].RawString())
+			}
+		case "TJ":
+			if len(args) == 1 {
+				for i := 0; i < args[0].Len(); i++ {
+					x := args[0].Index(i)
+					if x.Kind() == pdf.String {
+						showText(x.RawString())
+						continue
+					}
+					tx := -x.Float64() / 1000 * g.fontSize * g.scale
+					tm = matrix{{1, 0, 0}, {0, 1, 0}, {tx, 0, 1}}.mul(tm)
+				}
+			}
+		case "Do":
+			if len(args) == 1 {
+				cr.drawXObject(args[0].Name(), res, g.ctm, depth)
+			}
+		}
+	})
+}
+
+func (cr *contentReader) drawXObject(name string, res pdf.Value, ctm matrix, depth int) {
+	xo := res.Key("XObject").Key(name)
+	switch xo.Key("Subtype").Name() {
+	case "Image":
+		// images are drawn in the unit square mapped by the ctm, the top left corner is (0, 1)
+		cr.content.images = append(cr.content.images, placedImage{
+			name: strings.TrimPrefix(name, "/"),
+			v:    xo,
+			x:    ctm[1][0] + ctm[2][0],
+			y:    ctm[1][1] + ctm[2][1],
+		})
+	case "Form":
+		if depth >= maxFormDepth {
+			return
+		}
+		formRes := xo.Key("Resources")
+		if formRes.Kind() == pdf.Null {
+			formRes = res
+		}
+		cr.interpret(xo, formRes, matrixOf(xo.Key("Matrix")).mul(ctm), depth+1)
+	}
+}
@@ -0,0 +1,170 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	"github.com/dslipak/pdf"
+)
+
+// maxImagePixels limits the size of the decoded images.
+const maxImagePixels = 1 << 26
+
+var (
+	jpegStart = []byte{0xff, 0xd8, 0xff}
+	jpegEnd   = []byte{0xff, 0xd9}
+)
+
+// imageExtractor encodes the image xobjects as png or jpeg files.
+type imageExtractor struct {
+	// data is the raw pdf file. The JPEG images are looked up in it, as pdf.Value.Reader doesn't support
+	// the DCTDecode filter, which is why they are not extracted from encrypted files.
+	data []byte
+	// jpegOffsets are the offsets of the streams starting with a jpeg header, scanned on first use.
+	jpegOffsets []int
+}
+
+// extract returns the image file and its extension, or nil if the image format is not supported.
+func (ie *imageExtractor) extract(v pdf.Value) (data []byte, ext string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			data, ext, err = nil, "", fmt.Errorf("extract pdf image failed: %v", r)
+		}
+	}()
+
+	filter := v.Key("Filter")
+	if filter.Kind() == pdf.Array && filter.Len() == 1 {
+		filter = filter.Index(0)
+	}
+
+	switch {
+	case filter.Kind() == pdf.Name && filter.Name() == "DCTDecode":
+		return ie.findJPEG(v), ".jpg", nil
+	case filter.Kind() == pdf.Null, filter.Kind() == pdf.Name && filter.Name() == "FlateDecode":
+		data, err = encodePNG(v)
+		return data, ".png", err
+	default:
+		// CCITTFaxDecode, JBIG2Decode, JPXDecode, ...
+		return nil, "", nil
+	}
+}
+
+// findJPEG looks up the stream of the image in the raw file, by its length and dimensions.
+func (ie *imageExtractor) findJPEG(v pdf.Value) []byte {
+	if ie.jpegOffsets == nil {
+		ie.jpegOffsets = []int{}
+		for i := 0; ; {
+			idx := bytes.Index(ie.data[i:], []byte("stream"))
+			if idx < 0 {
+				break
+			}
+			i += idx + len("stream")
+			start := i
+			if start < len(ie.data) && ie.data[start] == '\r' {
+				start++
+			}
+			if start < len(ie.data) && ie.data[start] == '\n' {
+				start++
+			}
+			if bytes.HasPrefix(ie.data[start:], jpegStart) {
+				ie.jpegOffsets = append(ie.jpegOffsets, start)
+			}
+		}
+	}
+
+	length := int(v.Key("Length").Int64())
+	width, height := int(v.Key("Width").Int64()), int(v.Key("Height").Int64())
+	for _, start := range ie.jpegOffsets {
+		if length <= 0 || start+length > len(ie.data) {
+			continue
+		}
+		candidate := ie.data[start : start+length]
+		if !bytes.HasSuffix(bytes.TrimRight(candidate, "\r\n "), jpegEnd) {
+			continue
+		}
+		conf, err := jpeg.DecodeConfig(bytes.NewReader(candidate))
+		if err == nil && conf.Width == width && conf.Height == height {
+			return candidate
+		}
+	}
+
+	return nil
+}
+
+// encodePNG encodes the 8 bits per component gray, rgb or cmyk images as png.
+func encodePNG(v pdf.Value) ([]byte, error) {
+	width, height := int(v.Key("Width").Int64()), int(v.Key("Height").Int64())
+	if v.Key("BitsPerComponent").Int64() != 8 || v.Key("ImageMask").Bool() ||
+		width <= 0 || height <= 0 || width*height > maxImagePixels {
+		return nil, nil
+	}
+
+	components := 0
+	cs := v.Key("ColorSpace")
+	csName := cs.Name()
+	if cs.Kind() == pdf.Array {
+		csName = cs.Index(0).Name()
+	}
+	switch csName {
+	case "DeviceGray", "CalGray":
+		components = 1
+	case "DeviceRGB", "CalRGB":
+		components = 3
+	case "DeviceCMYK":
+		components = 4
+	case "ICCBased":
+		components = int(cs.Index(1).Key("N").Int64())
+	}
+	if components != 1 && components != 3 && components != 4 {
+		return nil, nil
+	}
+
+	rd := v.Reader()
+	defer rd.Close()
+	pix := make([]byte, width*height*components)
+	if _, err := io.ReadFull(rd, pix); err != nil {
+		return nil, fmt.Errorf("read pdf image failed: %w", err)
+	}
+
+	var img image.Image
+	rect := image.Rect(0, 0, width, height)
+	switch components {
+	case 1:
+		img = &image.Gray{Pix: pix, Stride: width, Rect: rect}
+	case 3:
+		rgba := image.NewNRGBA(rect)
+		for i, j := 0, 0; i < len(pix); i, j = i+3, j+4 {
+			rgba.Pix[j], rgba.Pix[j+1], rgba.Pix[j+2], rgba.Pix[j+3] = pix[i], pix[i+1], pix[i+2], 0xff
+		}
+		img = rgba
+	case 4:
+		img = &image.CMYK{Pix: pix, Stride: width * 4, Rect: rect}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("encode pdf image failed: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
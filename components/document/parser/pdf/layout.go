@@ -0,0 +1,487 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pdf
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// The layout thresholds, relative to the font size unless stated otherwise.
+const (
+	// lineTolerance is the baseline difference of the glyphs on the same line.
+	lineTolerance = 0.5
+	// spaceGap is the gap between glyphs read as a space.
+	spaceGap = 0.15
+	// segmentGap is the gap splitting a line into segments, which are the column lines or the table cells.
+	segmentGap = 1.0
+	// paragraphGap is the baseline distance starting a new paragraph.
+	paragraphGap = 1.6
+	// maxRowGap is the baseline distance of the consecutive table rows.
+	maxRowGap = 2.5
+	// wideBlock is the width, relative to the text area, of the blocks which are expected to span the columns.
+	wideBlock = 0.6
+	// maxTableFill is the ratio of the table area covered by text, which is lower for tables than for column text.
+	maxTableFill = 0.7
+	// minColumnLine is the minimum width of the column text lines.
+	minColumnLine = 8.0
+	// maxRowFill is the ratio of a table row covered by text, above which the row is read as column text.
+	maxRowFill = 0.8
+)
+
+// segment is a run of text on a line, separated from the other runs on the line by a wide gap.
+type segment struct {
+	x0, x1, y, size float64
+	text            string
+	inTable         bool
+}
+
+func (seg *segment) block() *block {
+	return &block{
+		x0:     seg.x0,
+		x1:     seg.x1,
+		y:      seg.y,
+		bottom: seg.y,
+		size:   seg.size,
+		text:   seg.text,
+	}
+}
+
+type textLine struct {
+	y        float64
+	size     float64
+	segments []*segment
+}
+
+// block is a unit of the reading order: a line segment or a table.
+type block struct {
+	x0, x1, y, bottom, size float64
+	text                    string
+	table                   bool
+	// group identifies the column of the band the block is read in.
+	group int
+}
+
+// layoutText returns the text of the glyphs in reading order: the columns are read one after another,
+// and the tables are serialized as markdown.
+func layoutText(glyphs []glyph) string {
+	lines := buildLines(glyphs)
+	if len(lines) == 0 {
+		return ""
+	}
+
+	var segments []*block
+	for _, l := range lines {
+		for _, seg := range l.segments {
+			segments = append(segments, seg.block())
+		}
+	}
+	gutters := findGutters(segments)
+
+	// the tables are detected in each column, so that the text of the other columns is not read as cells
+	var blocks []*block
+	for col := -1; col <= len(gutters); col++ {
+		var colLines []*textLine
+		for _, l := range lines {
+			cl := &textLine{y: l.y, size: l.size}
+			for _, seg := range l.segments {
+				if c := columnOf(seg.x0, seg.x1, gutters); c == col {
+					cl.segments = append(cl.segments, seg)
+				}
+			}
+			if len(cl.segments) > 0 {
+				colLines = append(colLines, cl)
+			}
+		}
+		blocks = append(blocks, detectTables(colLines)...)
+	}
+	for _, l := range lines {
+		for _, seg := range l.segments {
+			if !seg.inTable {
+				blocks = append(blocks, seg.block())
+			}
+		}
+	}
+
+	blocks = orderBlocks(blocks, gutters)
+
+	var sb strings.Builder
+	for i, b := range blocks {
+		if i > 0 {
+			prev := blocks[i-1]
+			switch {
+			case b.group != prev.group || b.table || prev.table || prev.bottom-b.y > paragraphGap*math.Max(b.size, prev.size):
+				sb.WriteString("\n\n")
+			case math.Abs(prev.y-b.y) <= lineTolerance*math.Max(b.size, prev.size):
+				sb.WriteString(" ")
+			default:
+				sb.WriteString("\n")
+			}
+		}
+		sb.WriteString(b.text)
+	}
+
+	return sb.String()
+}
+
+// buildLines groups the glyphs by baseline, and splits the lines into segments at the wide gaps.
+func buildLines(glyphs []glyph) []*textLine {
+	sorted := make([]glyph, 0, len(glyphs))
+	for _, g := range glyphs {
+		if strings.TrimSpace(g.s) != "" && g.size > 0 {
+			sorted = append(sorted, g)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].y != sorted[j].y {
+			return sorted[i].y > sorted[j].y
+		}
+		return sorted[i].x < sorted[j].x
+	})
+
+	var (
+		lines []*textLine
+		cur   []glyph
+	)
+	flush := func() {
+		if len(cur) > 0 {
+			lines = append(lines, splitSegments(cur))
+			cur = nil
+		}
+	}
+	for _, g := range sorted {
+		if len(cur) > 0 && math.Abs(cur[0].y-g.y) > lineTolerance*math.Max(cur[0].size, g.size) {
+			flush()
+		}
+		cur = append(cur, g)
+	}
+	flush()
+
+	return lines
+}
+
+func splitSegments(glyphs []glyph) *textLine {
+	sort.SliceStable(glyphs, func(i, j int) bool {
+		return glyphs[i].x < glyphs[j].x
+	})
+
+	l := &textLine{y: glyphs[0].y}
+	var (
+		seg  *segment
+		sb   strings.Builder
+		last glyph
+	)
+	flush := func() {
+		if seg != nil {
+			seg.text = sb.String()
+			l.segments = append(l.segments, seg)
+			sb.Reset()
+		}
+	}
+	for i, g := range glyphs {
+		l.size = math.Max(l.size, g.size)
+		// skip the glyphs drawn twice with a small offset to simulate bold text
+		if i > 0 && g.s == last.s && math.Abs(g.x-last.x) < 0.1*g.size {
+			continue
+		}
+
+		gap := 0.0
+		if seg != nil {
+			gap = g.x - seg.x1
+		}
+		switch {
+		case seg == nil || gap > segmentGap*g.size:
+			flush()
+			seg = &segment{x0: g.x, x1: g.x + g.w, y: l.y, size: g.size}
+		case gap > spaceGap*g.size:
+			sb.WriteString(" ")
+		}
+
+		sb.WriteString(g.s)
+		seg.x1 = math.Max(seg.x1, g.x+g.w)
+		seg.size = math.Max(seg.size, g.size)
+		last = g
+	}
+	flush()
+
+	return l
+}
+
+type interval struct {
+	x0, x1 float64
+}
+
+// detectTables finds the runs of consecutive lines whose segments are aligned in at least 2 columns,
+// sparse enough not to be column text, and returns them as blocks of markdown tables.
+func detectTables(lines []*textLine) []*block {
+	var tables []*block
+	for i := 0; i < len(lines); {
+		if len(lines[i].segments) < 2 {
+			i++
+			continue
+		}
+
+		cols := make([]interval, 0, len(lines[i].segments))
+		for _, seg := range lines[i].segments {
+			cols = append(cols, interval{seg.x0, seg.x1})
+		}
+
+		j := i + 1
+		for ; j < len(lines); j++ {
+			if len(lines[j].segments) < 2 || lines[j-1].y-lines[j].y > maxRowGap*lines[j].size {
+				break
+			}
+			aligned, ok := alignColumns(cols, lines[j].segments)
+			if !ok || tableFill(lines[j:j+1], aligned) > maxRowFill {
+				break
+			}
+			cols = aligned
+		}
+
+		rows := lines[i:j]
+		if len(rows) < 2 || len(cols) < 2 || tableFill(rows[:1], cols) > maxRowFill || tableFill(rows, cols) > maxTableFill {
+			i++
+			continue
+		}
+
+		tables = append(tables, newTable(rows, cols))
+		i = j
+	}
+
+	return tables
+}
+
+// alignColumns adds the segments to the columns, failing when a segment spans several columns.
+func alignColumns(cols []interval, segments []*segment) ([]interval, bool) {
+	res := append([]interval(nil), cols...)
+	for _, seg := range segments {
+		idx := -1
+		for k, c := range res {
+			if seg.x0 < c.x1 && seg.x1 > c.x0 {
+				if idx >= 0 {
+					return nil, false
+				}
+				idx = k
+			}
+		}
+		if idx >= 0 {
+			res[idx] = interval{math.Min(res[idx].x0, seg.x0), math.Max(res[idx].x1, seg.x1)}
+			continue
+		}
+		res = append(res, interval{seg.x0, seg.x1})
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].x0 < res[j].x0 })
+	for k := 1; k < len(res); k++ {
+		if res[k].x0 < res[k-1].x1 {
+			return nil, false
+		}
+	}
+
+	return res, true
+}
+
+func tableFill(rows []*textLine, cols []interval) float64 {
+	width := cols[len(cols)-1].x1 - cols[0].x0
+	if width <= 0 {
+		return 1
+	}
+	var covered float64
+	for _, row := range rows {
+		for _, seg := range row.segments {
+			covered += seg.x1 - seg.x0
+		}
+	}
+	return covered / (width * float64(len(rows)))
+}
+
+func newTable(rows []*textLine, cols []interval) *block {
+	var sb strings.Builder
+	for r, row := range rows {
+		cells := make([]string, len(cols))
+		for _, seg := range row.segments {
+			seg.inTable = true
+			for k, c := range cols {
+				if seg.x0 < c.x1 && seg.x1 > c.x0 {
+					cells[k] = strings.TrimSpace(cells[k] + " " + strings.ReplaceAll(seg.text, "|", `\|`))
+					break
+				}
+			}
+		}
+
+		if r > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("| " + strings.Join(cells, " | ") + " |")
+		if r == 0 {
+			sb.WriteString("\n|" + strings.Repeat(" --- |", len(cols)))
+		}
+	}
+
+	return &block{
+		x0:     cols[0].x0,
+		x1:     cols[len(cols)-1].x1,
+		y:      rows[0].y,
+		bottom: rows[len(rows)-1].y,
+		size:   rows[0].size,
+		text:   sb.String(),
+		table:  true,
+	}
+}
+
+// orderBlocks sorts the blocks in reading order. The page is divided into columns by the gutters, and into bands
+// by the blocks spanning the gutters: the bands are read top to bottom, and the columns of a band left to right.
+func orderBlocks(blocks []*block, gutters []interval) []*block {
+	sort.SliceStable(blocks, func(i, j int) bool {
+		if blocks[i].y != blocks[j].y {
+			return blocks[i].y > blocks[j].y
+		}
+		return blocks[i].x0 < blocks[j].x0
+	})
+
+	var (
+		res     = make([]*block, 0, len(blocks))
+		columns = make([][]*block, len(gutters)+1)
+		group   int
+	)
+	flush := func() {
+		for _, col := range columns {
+			if len(col) == 0 {
+				continue
+			}
+			group++
+			for _, b := range col {
+				b.group = group
+			}
+			res = append(res, col...)
+		}
+		columns = make([][]*block, len(gutters)+1)
+	}
+
+	for _, b := range blocks {
+		col := columnOf(b.x0, b.x1, gutters)
+		if col < 0 {
+			flush()
+			group++
+			b.group = group
+			res = append(res, b)
+			continue
+		}
+		columns[col] = append(columns[col], b)
+	}
+	flush()
+
+	return res
+}
+
+// columnOf returns the index of the column of the horizontal extent, or -1 if it spans a gutter.
+func columnOf(x0, x1 float64, gutters []interval) int {
+	col := 0
+	for _, g := range gutters {
+		mid := (g.x0 + g.x1) / 2
+		if x0 < mid && x1 > mid {
+			return -1
+		}
+		if (x0+x1)/2 > mid {
+			col++
+		}
+	}
+	return col
+}
+
+// findGutters returns the vertical strips inside the text area, at least as wide as the font size, that are not
+// covered by the narrow blocks, with column text on both sides: at least 3 lines wider than minColumnLine,
+// and blocks spreading over a quarter of the text area height.
+func findGutters(blocks []*block) []interval {
+	if len(blocks) < 6 {
+		return nil
+	}
+
+	minX, maxX := math.Inf(1), math.Inf(-1)
+	minY, maxY := math.Inf(1), math.Inf(-1)
+	sizes := make([]float64, 0, len(blocks))
+	for _, b := range blocks {
+		minX, maxX = math.Min(minX, b.x0), math.Max(maxX, b.x1)
+		minY, maxY = math.Min(minY, b.bottom), math.Max(maxY, b.y)
+		sizes = append(sizes, b.size)
+	}
+	if maxX-minX < 1 {
+		return nil
+	}
+	sort.Float64s(sizes)
+	medianSize := sizes[len(sizes)/2]
+
+	var narrow []*block
+	for _, b := range blocks {
+		if b.x1-b.x0 < wideBlock*(maxX-minX) {
+			narrow = append(narrow, b)
+		}
+	}
+
+	// coverage of the 1pt wide bins, tolerating a few stray blocks in the gutters, such as centered titles
+	coverage := make([]int, int(maxX-minX)+1)
+	for _, b := range narrow {
+		for x := int(b.x0 - minX); x < int(math.Ceil(b.x1-minX)) && x < len(coverage); x++ {
+			coverage[x]++
+		}
+	}
+	tolerance := max(2, len(narrow)/20)
+
+	isColumn := func(side []*block) bool {
+		top, bottom := math.Inf(-1), math.Inf(1)
+		lines := 0
+		for _, b := range side {
+			top, bottom = math.Max(top, b.y), math.Min(bottom, b.bottom)
+			// the lines of column text are wider than most table cells
+			if b.x1-b.x0 >= minColumnLine*medianSize {
+				lines++
+			}
+		}
+		return lines >= 3 && top-bottom >= (maxY-minY)/4
+	}
+
+	var gutters []interval
+	for start := 0; start < len(coverage); {
+		if coverage[start] > tolerance {
+			start++
+			continue
+		}
+		end := start
+		for end < len(coverage) && coverage[end] <= tolerance {
+			end++
+		}
+
+		g := interval{minX + float64(start), minX + float64(end)}
+		if start > 0 && end < len(coverage) && g.x1-g.x0 >= medianSize {
+			var left, right []*block
+			for _, b := range narrow {
+				if b.x1 <= g.x0+1 {
+					left = append(left, b)
+				} else if b.x0 >= g.x1-1 {
+					right = append(right, b)
+				}
+			}
+			if isColumn(left) && isColumn(right) {
+				gutters = append(gutters, g)
+			}
+		}
+		start = end
+	}
+
+	return gutters
+}
@@ -21,22 +21,39 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sort"
 
 	"github.com/cloudwego/eino/components/document/parser"
 	"github.com/cloudwego/eino/schema"
 	"github.com/dslipak/pdf"
 )
 
+const (
+	MetaKeyPageNumber = "_page_number"
+	MetaKeyImageIndex = "_image_index"
+	MetaKeyImageName  = "_image_name"
+)
+
 // Config is the configuration for PDF parser.
 type Config struct {
-	ToPages bool // whether to
+	ToPages bool // whether to split the document into pages
+	// Layout enables the layout-aware extraction: the text is read in reading order across the columns,
+	// and the tables are serialized as markdown. Otherwise the text is read in content stream order.
+	Layout bool
+	// ImageParser parses the images embedded in the pages, e.g. with an OCR or vision parser, into documents
+	// following the document of their page, or of the whole file. It is called with a
+	// "page-{page}-image-{index}.png" (or .jpg) URI. Optional. The images are not extracted when nil.
+	ImageParser parser.Parser
 }
 
 // PDFParser reads from io.Reader and parse its content as plain text.
 // Attention: This is in alpha stage, and may not support all PDF use cases well enough.
-// For example, it will not preserve whitespace and new line for now.
+// For example, it will not preserve whitespace and new line for now, unless Config.Layout is enabled.
 type PDFParser struct {
 	ToPages bool
+
+	layout      bool
+	imageParser parser.Parser
 }
 
 // NewPDFParser creates a new PDF parser.
@@ -44,7 +61,11 @@ func NewPDFParser(ctx context.Context, config *Config) (*PDFParser, error) {
 	if config == nil {
 		config = &Config{}
 	}
-	return &PDFParser{ToPages: config.ToPages}, nil
+	return &PDFParser{
+		ToPages:     config.ToPages,
+		layout:      config.Layout,
+		imageParser: config.ImageParser,
+	}, nil
 }
 
 // Parse parses the PDF content from io.Reader.
@@ -69,8 +90,10 @@ func (pp *PDFParser) Parse(ctx context.Context, reader io.Reader, opts ...parser
 
 	pages := f.NumPage()
 	var (
-		buf     bytes.Buffer
-		toPages = specificOpts.toPages != nil && *specificOpts.toPages
+		buf       bytes.Buffer
+		toPages   = specificOpts.toPages != nil && *specificOpts.toPages
+		imageDocs []*schema.Document
+		images    = &imageExtractor{data: data}
 	)
 	fonts := make(map[string]*pdf.Font)
 	for i := 1; i <= pages; i++ {
@@ -81,18 +104,42 @@ func (pp *PDFParser) Parse(ctx context.Context, reader io.Reader, opts ...parser
 				fonts[name] = &font
 			}
 		}
-		text, err := p.GetPlainText(fonts)
-		if err != nil {
+
+		var content *pageContent
+		if pp.layout || pp.imageParser != nil {
+			// fall back to the plain text of the page if its content can't be interpreted
+			content, _ = readPageContent(p)
+		}
+
+		var text string
+		if pp.layout && content != nil {
+			text = layoutText(content.glyphs)
+		} else if text, err = p.GetPlainText(fonts); err != nil {
 			return nil, fmt.Errorf("read pdf page failed: %w, page= %d", err, i)
 		}
 
+		var pageImageDocs []*schema.Document
+		if pp.imageParser != nil && content != nil {
+			if pageImageDocs, err = pp.parseImages(ctx, images, content.images, i, commonOpts.ExtraMeta); err != nil {
+				return nil, err
+			}
+		}
+
 		if toPages {
+			meta := make(map[string]any, len(commonOpts.ExtraMeta)+1)
+			for k, v := range commonOpts.ExtraMeta {
+				meta[k] = v
+			}
+			meta[MetaKeyPageNumber] = i
+
 			docs = append(docs, &schema.Document{
 				Content:  text,
-				MetaData: commonOpts.ExtraMeta,
+				MetaData: meta,
 			})
+			docs = append(docs, pageImageDocs...)
 		} else {
 			buf.WriteString(text + "\n")
+			imageDocs = append(imageDocs, pageImageDocs...)
 		}
 	}
 
@@ -101,6 +148,58 @@ func (pp *PDFParser) Parse(ctx context.Context, reader io.Reader, opts ...parser
 			Content:  buf.String(),
 			MetaData: commonOpts.ExtraMeta,
 		})
+		docs = append(docs, imageDocs...)
+	}
+
+	return docs, nil
+}
+
+// parseImages parses the images of the page with the image parser, in reading order.
+func (pp *PDFParser) parseImages(ctx context.Context, extractor *imageExtractor, placed []placedImage,
+	page int, extraMeta map[string]any) ([]*schema.Document, error) {
+
+	sort.SliceStable(placed, func(i, j int) bool {
+		if placed[i].y != placed[j].y {
+			return placed[i].y > placed[j].y
+		}
+		return placed[i].x < placed[j].x
+	})
+
+	var docs []*schema.Document
+	index := 0
+	for _, img := range placed {
+		data, ext, err := extractor.extract(img.v)
+		if err != nil || data == nil {
+			// the images in unsupported formats are skipped
+			continue
+		}
+
+		index++
+		name := fmt.Sprintf("page-%d-image-%d%s", page, index, ext)
+		meta := make(map[string]any, len(extraMeta)+3)
+		for k, v := range extraMeta {
+			meta[k] = v
+		}
+		meta[MetaKeyPageNumber] = page
+		meta[MetaKeyImageIndex] = index
+		meta[MetaKeyImageName] = name
+
+		imgDocs, err := pp.imageParser.Parse(ctx, bytes.NewReader(data), parser.WithURI(name), parser.WithExtraMeta(meta))
+		if err != nil {
+			return nil, fmt.Errorf("parse pdf image failed: %w, page= %d, image= %s", err, page, img.name)
+		}
+		for _, doc := range imgDocs {
+			if doc == nil {
+				continue
+			}
+			if doc.MetaData == nil {
+				doc.MetaData = make(map[string]any, len(meta))
+			}
+			for k, v := range meta {
+				doc.MetaData[k] = v
+			}
+			docs = append(docs, doc)
+		}
 	}
 
 	return docs, nil
@@ -17,11 +17,19 @@
 package pdf
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"image/jpeg"
+	"image/png"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -39,8 +47,104 @@ func TestLoader_Load(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, 2, len(docs))
 		assert.True(t, len(docs[0].Content) > 0)
-		assert.Equal(t, map[string]any{"test": "test"}, docs[0].MetaData)
+		assert.Equal(t, map[string]any{"test": "test", MetaKeyPageNumber: 1}, docs[0].MetaData)
 		assert.True(t, len(docs[0].Content) > 0)
-		assert.Equal(t, map[string]any{"test": "test"}, docs[1].MetaData)
+		assert.Equal(t, map[string]any{"test": "test", MetaKeyPageNumber: 2}, docs[1].MetaData)
 	})
+
+	t.Run("TestLoader_Layout", func(t *testing.T) {
+		ctx := context.Background()
+
+		data, err := os.ReadFile("./testdata/test_layout.pdf")
+		assert.NoError(t, err)
+
+		p, err := NewPDFParser(ctx, &Config{ToPages: true, Layout: true})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, bytes.NewReader(data))
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(docs))
+
+		// the columns are interleaved in the content stream, the left one is read first
+		assert.Equal(t, "Layout Aware Extraction\n\n"+
+			"Multi column papers are common in research. The left\n"+
+			"column starts the article and explains why the reading\n"+
+			"order matters for retrieval.\n\n"+
+			"| Name | Size | Unit |\n| --- | --- | --- |\n| alpha | 12 | kb |\n| beta | 7 | mb |\n\n"+
+			"After the table the left column continues with a closing\nremark.\n\n"+
+			"The right column is read after the whole left column. It\n"+
+			"holds the second half of the article, which must not be\n"+
+			"interleaved with the first half.\n\n"+
+			"A new paragraph closes the right column.", docs[0].Content)
+		assert.Equal(t, 1, docs[0].MetaData[MetaKeyPageNumber])
+
+		// a single column page, whose table cells are not read as columns
+		assert.Equal(t, "The second page has a single column of text that is long enough to be wrapped over several lines by the\n"+
+			"generator.\n\n"+
+			"| Quarter | Revenue | Growth |\n| --- | --- | --- |\n| Q1 | 100 | 5% |\n| Q2 | 120 | 20% |\n\n"+
+			"The end.", docs[1].Content)
+		assert.Equal(t, 2, docs[1].MetaData[MetaKeyPageNumber])
+
+		p, err = NewPDFParser(ctx, &Config{Layout: true})
+		assert.NoError(t, err)
+
+		docs, err = p.Parse(ctx, bytes.NewReader(data), parser.WithExtraMeta(map[string]any{"test": "test"}))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(docs))
+		assert.True(t, strings.HasPrefix(docs[0].Content, "Layout Aware Extraction\n\n"))
+		assert.True(t, strings.HasSuffix(docs[0].Content, "The end.\n"))
+		assert.Equal(t, map[string]any{"test": "test"}, docs[0].MetaData)
+	})
+
+	t.Run("TestLoader_Images", func(t *testing.T) {
+		ctx := context.Background()
+
+		data, err := os.ReadFile("./testdata/test_layout.pdf")
+		assert.NoError(t, err)
+
+		var uris []string
+		imageParser := parser.Parser(&mockImageParser{parse: func(data []byte, uri string) string {
+			uris = append(uris, uri)
+			switch filepath.Ext(uri) {
+			case ".jpg":
+				conf, err := jpeg.DecodeConfig(bytes.NewReader(data))
+				assert.NoError(t, err)
+				return fmt.Sprintf("jpeg %dx%d", conf.Width, conf.Height)
+			default:
+				img, err := png.Decode(bytes.NewReader(data))
+				assert.NoError(t, err)
+				return fmt.Sprintf("png %dx%d", img.Bounds().Dx(), img.Bounds().Dy())
+			}
+		}})
+
+		p, err := NewPDFParser(ctx, &Config{ImageParser: imageParser})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, bytes.NewReader(data), WithToPages(true), parser.WithExtraMeta(map[string]any{"test": "test"}))
+		assert.NoError(t, err)
+		assert.Equal(t, 4, len(docs))
+		assert.Equal(t, []string{"page-1-image-1.jpg", "page-1-image-2.png"}, uris)
+
+		// the images follow their page, in reading order
+		assert.Equal(t, 1, docs[0].MetaData[MetaKeyPageNumber])
+		assert.Equal(t, "jpeg 16x8", docs[1].Content)
+		assert.Equal(t, map[string]any{"test": "test", MetaKeyPageNumber: 1, MetaKeyImageIndex: 1,
+			MetaKeyImageName: "page-1-image-1.jpg"}, docs[1].MetaData)
+		assert.Equal(t, "png 4x4", docs[2].Content)
+		assert.Equal(t, 2, docs[2].MetaData[MetaKeyImageIndex])
+		assert.Equal(t, 2, docs[3].MetaData[MetaKeyPageNumber])
+	})
+}
+
+type mockImageParser struct {
+	parse func(data []byte, uri string) string
+}
+
+func (m *mockImageParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	uri := parser.GetCommonOptions(nil, opts...).URI
+	return []*schema.Document{{Content: m.parse(data, uri)}}, nil
 }
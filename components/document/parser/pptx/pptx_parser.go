@@ -0,0 +1,471 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pptx
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	MetaKeySlideNumber = "_slide_number"
+	MetaKeySlideTitle  = "_slide_title"
+	MetaKeyNotes       = "_slide_notes"
+	MetaKeyHidden      = "_slide_hidden"
+	MetaKeySource      = "_source"
+)
+
+const (
+	nsRelationships   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+	relTypeSlide      = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/slide"
+	relTypeNotesSlide = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/notesSlide"
+)
+
+// Config is the configuration for Pptx parser.
+type Config struct {
+	SkipNotes  bool // whether to skip the speaker notes, which are appended to the slide content and kept in metadata by default
+	SkipTables bool // whether to skip the table contents
+	SkipHidden bool // whether to skip the hidden slides
+}
+
+// PptxParser reads from io.Reader and parses the PowerPoint presentation into documents, one document per slide.
+type PptxParser struct {
+	conf *Config
+}
+
+// NewPptxParser creates a new Pptx parser.
+func NewPptxParser(ctx context.Context, config *Config) (*PptxParser, error) {
+	if config == nil {
+		config = &Config{}
+	}
+
+	return &PptxParser{conf: config}, nil
+}
+
+// Parse parses the pptx content from io.Reader. The slide title, text, tables and speaker notes are extracted
+// as plain text, empty slides are skipped.
+func (pp *PptxParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) (docs []*schema.Document, err error) {
+	commonOpts := parser.GetCommonOptions(nil, opts...)
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("pptx parser read all from reader failed: %w", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("open pptx failed: %w", err)
+	}
+
+	pkg := &pptxPackage{files: make(map[string]*zip.File, len(zr.File))}
+	for _, f := range zr.File {
+		pkg.files[f.Name] = f
+	}
+
+	slides, err := pkg.slidePaths()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, slidePath := range slides {
+		s, err := pkg.readSlide(slidePath)
+		if err != nil {
+			return nil, err
+		}
+
+		if s.hidden && pp.conf.SkipHidden {
+			continue
+		}
+
+		var notes string
+		if !pp.conf.SkipNotes {
+			if notes, err = pkg.readNotes(slidePath); err != nil {
+				return nil, err
+			}
+		}
+
+		content := pp.render(s, notes)
+		if content == "" {
+			continue
+		}
+
+		meta := make(map[string]any, len(commonOpts.ExtraMeta)+5)
+		if commonOpts.URI != "" {
+			meta[MetaKeySource] = commonOpts.URI
+		}
+		for k, v := range commonOpts.ExtraMeta {
+			meta[k] = v
+		}
+		meta[MetaKeySlideNumber] = i + 1
+		if s.title != "" {
+			meta[MetaKeySlideTitle] = s.title
+		}
+		if notes != "" {
+			meta[MetaKeyNotes] = notes
+		}
+		if s.hidden {
+			meta[MetaKeyHidden] = true
+		}
+
+		docs = append(docs, &schema.Document{
+			Content:  content,
+			MetaData: meta,
+		})
+	}
+
+	return docs, nil
+}
+
+// render lays out the slide as the title, the text paragraphs, the tables and the notes, separated by blank lines.
+func (pp *PptxParser) render(s *slide, notes string) string {
+	var blocks []string
+	if s.title != "" {
+		blocks = append(blocks, s.title)
+	}
+	if len(s.paragraphs) > 0 {
+		blocks = append(blocks, strings.Join(s.paragraphs, "\n"))
+	}
+	if !pp.conf.SkipTables {
+		for _, t := range s.tables {
+			blocks = append(blocks, renderTable(t))
+		}
+	}
+	if notes != "" {
+		blocks = append(blocks, "Notes:\n"+notes)
+	}
+
+	return strings.Join(blocks, "\n\n")
+}
+
+// renderTable renders the table as markdown, the first row is used as the header.
+func renderTable(rows [][]string) string {
+	width := 0
+	for _, row := range rows {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+
+	var sb strings.Builder
+	for i, row := range rows {
+		sb.WriteString("|")
+		for j := 0; j < width; j++ {
+			cell := ""
+			if j < len(row) {
+				cell = strings.ReplaceAll(row[j], "\n", " ")
+				cell = strings.ReplaceAll(cell, "|", `\|`)
+			}
+			sb.WriteString(" " + cell + " |")
+		}
+		sb.WriteString("\n")
+
+		if i == 0 {
+			sb.WriteString("|" + strings.Repeat(" --- |", width) + "\n")
+		}
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+type pptxPackage struct {
+	files map[string]*zip.File
+}
+
+// xmlNode is a generic xml element, the pptx parts are walked by element local names.
+type xmlNode struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Nodes   []xmlNode  `xml:",any"`
+	Text    string     `xml:",chardata"`
+}
+
+func (n *xmlNode) attr(local string) string {
+	return n.attrNS("", local)
+}
+
+func (n *xmlNode) attrNS(space, local string) string {
+	for _, a := range n.Attrs {
+		if a.Name.Space == space && a.Name.Local == local {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+func (n *xmlNode) child(local string) *xmlNode {
+	for i := range n.Nodes {
+		if n.Nodes[i].XMLName.Local == local {
+			return &n.Nodes[i]
+		}
+	}
+	return nil
+}
+
+type relationship struct {
+	ID     string `xml:"Id,attr"`
+	Type   string `xml:"Type,attr"`
+	Target string `xml:"Target,attr"`
+}
+
+func (pkg *pptxPackage) decode(name string, v any) error {
+	f, ok := pkg.files[name]
+	if !ok {
+		return fmt.Errorf("pptx part %s not found", name)
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("open pptx part %s failed: %w", name, err)
+	}
+	defer rc.Close()
+
+	if err = xml.NewDecoder(rc).Decode(v); err != nil {
+		return fmt.Errorf("decode pptx part %s failed: %w", name, err)
+	}
+
+	return nil
+}
+
+// rels returns the relationships of the part, with the targets resolved to the part names in the package.
+func (pkg *pptxPackage) rels(part string) ([]relationship, error) {
+	name := path.Join(path.Dir(part), "_rels", path.Base(part)+".rels")
+	if _, ok := pkg.files[name]; !ok {
+		return nil, nil
+	}
+
+	var doc struct {
+		Relationships []relationship `xml:"Relationship"`
+	}
+	if err := pkg.decode(name, &doc); err != nil {
+		return nil, err
+	}
+
+	for i, rel := range doc.Relationships {
+		if strings.HasPrefix(rel.Target, "/") {
+			doc.Relationships[i].Target = strings.TrimPrefix(rel.Target, "/")
+		} else {
+			doc.Relationships[i].Target = path.Join(path.Dir(part), rel.Target)
+		}
+	}
+
+	return doc.Relationships, nil
+}
+
+// slidePaths returns the slide part names in presentation order.
+func (pkg *pptxPackage) slidePaths() ([]string, error) {
+	const presentation = "ppt/presentation.xml"
+
+	var pres xmlNode
+	if err := pkg.decode(presentation, &pres); err != nil {
+		return nil, err
+	}
+
+	rels, err := pkg.rels(presentation)
+	if err != nil {
+		return nil, err
+	}
+	targets := make(map[string]string, len(rels))
+	for _, rel := range rels {
+		if rel.Type == relTypeSlide {
+			targets[rel.ID] = rel.Target
+		}
+	}
+
+	var slides []string
+	if list := pres.child("sldIdLst"); list != nil {
+		for _, id := range list.Nodes {
+			if target, ok := targets[id.attrNS(nsRelationships, "id")]; ok {
+				slides = append(slides, target)
+			}
+		}
+	}
+
+	return slides, nil
+}
+
+type slide struct {
+	title      string
+	paragraphs []string
+	tables     [][][]string
+	hidden     bool
+}
+
+func (pkg *pptxPackage) readSlide(name string) (*slide, error) {
+	var root xmlNode
+	if err := pkg.decode(name, &root); err != nil {
+		return nil, err
+	}
+
+	s := &slide{hidden: root.attr("show") == "0"}
+	walkShapes(&root, func(placeholder string, n *xmlNode) {
+		switch n.XMLName.Local {
+		case "tbl":
+			s.tables = append(s.tables, tableRows(n))
+		case "sp":
+			switch placeholder {
+			case "title", "ctrTitle":
+				if s.title == "" {
+					s.title = strings.Join(paragraphs(n), " ")
+					return
+				}
+			case "sldNum", "dt", "ftr", "hdr":
+				return
+			}
+			s.paragraphs = append(s.paragraphs, paragraphs(n)...)
+		}
+	})
+
+	return s, nil
+}
+
+// readNotes returns the speaker notes of the slide, which are the text of the body placeholders of the notes slide.
+func (pkg *pptxPackage) readNotes(slidePath string) (string, error) {
+	rels, err := pkg.rels(slidePath)
+	if err != nil {
+		return "", err
+	}
+
+	for _, rel := range rels {
+		if rel.Type != relTypeNotesSlide {
+			continue
+		}
+
+		var root xmlNode
+		if err = pkg.decode(rel.Target, &root); err != nil {
+			return "", err
+		}
+
+		var notes []string
+		walkShapes(&root, func(placeholder string, n *xmlNode) {
+			if n.XMLName.Local == "sp" && placeholder == "body" {
+				notes = append(notes, paragraphs(n)...)
+			}
+		})
+
+		return strings.Join(notes, "\n"), nil
+	}
+
+	return "", nil
+}
+
+// walkShapes visits the shapes (sp) and tables (tbl) in document order, group shapes are walked recursively.
+func walkShapes(n *xmlNode, visit func(placeholder string, n *xmlNode)) {
+	for i := range n.Nodes {
+		child := &n.Nodes[i]
+		switch child.XMLName.Local {
+		case "sp":
+			visit(placeholderType(child), child)
+		case "tbl":
+			visit("", child)
+		default:
+			walkShapes(child, visit)
+		}
+	}
+}
+
+// placeholderType returns the type of the shape placeholder, a placeholder without type is a body placeholder.
+func placeholderType(sp *xmlNode) string {
+	nvSpPr := sp.child("nvSpPr")
+	if nvSpPr == nil {
+		return ""
+	}
+	nvPr := nvSpPr.child("nvPr")
+	if nvPr == nil {
+		return ""
+	}
+	ph := nvPr.child("ph")
+	if ph == nil {
+		return ""
+	}
+	if t := ph.attr("type"); t != "" {
+		return t
+	}
+	return "body"
+}
+
+// paragraphs returns the non-empty paragraphs of the shape text body.
+func paragraphs(sp *xmlNode) []string {
+	body := sp.child("txBody")
+	if body == nil {
+		return nil
+	}
+
+	var res []string
+	for i := range body.Nodes {
+		if body.Nodes[i].XMLName.Local != "p" {
+			continue
+		}
+		if text := strings.TrimSpace(paragraphText(&body.Nodes[i])); text != "" {
+			res = append(res, text)
+		}
+	}
+
+	return res
+}
+
+func paragraphText(n *xmlNode) string {
+	var sb strings.Builder
+	var walk func(n *xmlNode)
+	walk = func(n *xmlNode) {
+		switch n.XMLName.Local {
+		case "t":
+			sb.WriteString(n.Text)
+			return
+		case "br":
+			sb.WriteString("\n")
+			return
+		}
+		for i := range n.Nodes {
+			walk(&n.Nodes[i])
+		}
+	}
+	walk(n)
+
+	return sb.String()
+}
+
+func tableRows(tbl *xmlNode) [][]string {
+	var rows [][]string
+	for i := range tbl.Nodes {
+		tr := &tbl.Nodes[i]
+		if tr.XMLName.Local != "tr" {
+			continue
+		}
+
+		var row []string
+		for j := range tr.Nodes {
+			tc := &tr.Nodes[j]
+			if tc.XMLName.Local != "tc" {
+				continue
+			}
+			// merged cells are kept as empty cells, so that the columns stay aligned.
+			row = append(row, strings.Join(paragraphs(tc), "\n"))
+		}
+		rows = append(rows, row)
+	}
+
+	return rows
+}
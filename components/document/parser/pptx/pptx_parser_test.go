@@ -0,0 +1,89 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pptx
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPptxParser_Parse(t *testing.T) {
+	ctx := context.Background()
+
+	data, err := os.ReadFile("./examples/testdata/test.pptx")
+	require.NoError(t, err)
+
+	t.Run("default", func(t *testing.T) {
+		p, err := NewPptxParser(ctx, nil)
+		require.NoError(t, err)
+
+		docs, err := p.Parse(ctx, bytes.NewReader(data),
+			parser.WithURI("test.pptx"), parser.WithExtraMeta(map[string]any{"test": "test"}))
+		require.NoError(t, err)
+		require.Len(t, docs, 3)
+
+		assert.Equal(t, "Eino Overview\n\nAn LLM application framework\n\nNotes:\nWelcome everyone", docs[0].Content)
+		assert.Equal(t, 1, docs[0].MetaData[MetaKeySlideNumber])
+		assert.Equal(t, "Eino Overview", docs[0].MetaData[MetaKeySlideTitle])
+		assert.Equal(t, "Welcome everyone", docs[0].MetaData[MetaKeyNotes])
+		assert.Equal(t, "test.pptx", docs[0].MetaData[MetaKeySource])
+		assert.Equal(t, "test", docs[0].MetaData["test"])
+
+		assert.Equal(t, "Components\n\n"+
+			"Loader\nParser\nand splitter\nGrouped text\n\n"+
+			"| Name | Type |\n| --- | --- |\n| pptx | parser |\n\n"+
+			"Notes:\nExplain each component", docs[1].Content)
+		assert.Equal(t, 2, docs[1].MetaData[MetaKeySlideNumber])
+
+		assert.Equal(t, "Backup\n\nHidden content", docs[2].Content)
+		assert.Equal(t, true, docs[2].MetaData[MetaKeyHidden])
+		assert.NotContains(t, docs[2].MetaData, MetaKeyNotes)
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		p, err := NewPptxParser(ctx, &Config{SkipNotes: true, SkipTables: true, SkipHidden: true})
+		require.NoError(t, err)
+
+		docs, err := p.Parse(ctx, bytes.NewReader(data))
+		require.NoError(t, err)
+		require.Len(t, docs, 2)
+
+		assert.Equal(t, "Eino Overview\n\nAn LLM application framework", docs[0].Content)
+		assert.Equal(t, "Components\n\nLoader\nParser\nand splitter\nGrouped text", docs[1].Content)
+		assert.NotContains(t, docs[1].MetaData, MetaKeyNotes)
+		assert.NotContains(t, docs[1].MetaData, MetaKeySource)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		p, err := NewPptxParser(ctx, nil)
+		require.NoError(t, err)
+
+		_, err = p.Parse(ctx, bytes.NewReader([]byte("not a pptx")))
+		assert.Error(t, err)
+	})
+}
+
+func TestRenderTable(t *testing.T) {
+	assert.Equal(t, "| a | b |\n| --- | --- |\n| 1 |  |\n| x\\|y | z w |",
+		renderTable([][]string{{"a", "b"}, {"1"}, {"x|y", "z\nw"}}))
+}
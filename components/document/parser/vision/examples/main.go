@@ -0,0 +1,66 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/components/model"
+
+	"github.com/cloudwego/eino-ext/components/document/parser/vision"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// the multimodal chat model
+	var chatModel model.BaseChatModel
+	// chatModel, err := ark.NewChatModel(ctx, &ark.ChatModelConfig{
+	// 	APIKey: os.Getenv("ARK_API_KEY"),
+	// 	Model:  os.Getenv("ARK_VISION_MODEL"),
+	// })
+	// ...
+
+	p, err := vision.NewVisionParser(ctx, &vision.Config{
+		ChatModel:   chatModel,
+		Concurrency: 4,
+	})
+	if err != nil {
+		log.Fatalf("Failed to create parser: %v", err)
+	}
+
+	file, err := os.Open("./testdata/scanned.png")
+	if err != nil {
+		log.Fatalf("Failed to open file: %v", err)
+	}
+	defer file.Close()
+
+	docs, err := p.Parse(ctx, file, parser.WithURI("./testdata/scanned.png"),
+		vision.WithPrompt("Extract the invoice number and the total amount, as a Markdown table."))
+	if err != nil {
+		log.Fatalf("Failed to parse file: %v", err)
+	}
+
+	for _, doc := range docs {
+		fmt.Println(doc.Content)
+		fmt.Println("token usage:", doc.MetaData[vision.MetaKeyTokenUsage])
+	}
+}
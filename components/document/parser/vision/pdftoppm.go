@@ -0,0 +1,113 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vision
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PdftoppmConfig is the configuration for the pdftoppm page renderer.
+type PdftoppmConfig struct {
+	// Path is the path of the pdftoppm binary, from poppler-utils.
+	// Optional. Default "pdftoppm", looked up in PATH.
+	Path string
+	// DPI is the resolution of the images.
+	// Optional. Default 150, enough for the models which downscale larger images anyway.
+	DPI int
+}
+
+// PdftoppmRenderer renders the PDF pages to PNG images with the pdftoppm command line of poppler.
+type PdftoppmRenderer struct {
+	path string
+	dpi  int
+}
+
+// NewPdftoppmRenderer creates a new pdftoppm page renderer.
+func NewPdftoppmRenderer(config *PdftoppmConfig) (*PdftoppmRenderer, error) {
+	if config == nil {
+		config = &PdftoppmConfig{}
+	}
+
+	bin := config.Path
+	if bin == "" {
+		bin = "pdftoppm"
+	}
+	path, err := exec.LookPath(bin)
+	if err != nil {
+		return nil, fmt.Errorf("new pdftoppm renderer, pdftoppm not found: %w", err)
+	}
+
+	dpi := config.DPI
+	if dpi <= 0 {
+		dpi = 150
+	}
+
+	return &PdftoppmRenderer{path: path, dpi: dpi}, nil
+}
+
+// Render writes the PDF file to a temporary directory, where pdftoppm writes the page-N.png images.
+func (pr *PdftoppmRenderer) Render(ctx context.Context, pdf []byte) ([][]byte, error) {
+	dir, err := os.MkdirTemp("", "eino-pdftoppm-")
+	if err != nil {
+		return nil, fmt.Errorf("create temp dir failed: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	input := filepath.Join(dir, "input.pdf")
+	if err = os.WriteFile(input, pdf, 0o600); err != nil {
+		return nil, fmt.Errorf("write pdf file failed: %w", err)
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, pr.path, "-png", "-r", strconv.Itoa(pr.dpi), input, filepath.Join(dir, "page"))
+	cmd.Stderr = &stderr
+	if err = cmd.Run(); err != nil {
+		return nil, fmt.Errorf("run pdftoppm failed: %w, stderr= %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	// the page numbers are zero padded to the width of the page count, e.g. page-01.png
+	files, err := filepath.Glob(filepath.Join(dir, "page-*.png"))
+	if err != nil {
+		return nil, err
+	}
+	pageNumber := func(file string) int {
+		n, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(filepath.Base(file), "page-"), ".png"))
+		return n
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return pageNumber(files[i]) < pageNumber(files[j])
+	})
+
+	images := make([][]byte, 0, len(files))
+	for _, file := range files {
+		img, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("read page image failed: %w", err)
+		}
+		images = append(images, img)
+	}
+
+	return images, nil
+}
@@ -0,0 +1,280 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package vision provides a parser sending the images of the document pages to a multimodal chat model,
+// which transcribes them to Markdown.
+package vision
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	MetaKeyVision     = "_vision"
+	MetaKeyTokenUsage = "_vision_token_usage"
+	MetaKeyPageNumber = "_page_number" // same key as the PDF parser
+	MetaKeySource     = "_source"
+)
+
+const defaultPrompt = `Convert the document page in the image to Markdown.
+- Transcribe all the text faithfully, in reading order, without summarizing or translating it.
+- Keep the structure: headings as #, lists as -, and tables as Markdown tables.
+- Write form fields and key-value pairs as "label: value", and checkboxes as [x] or [ ].
+- Describe charts, stamps and signatures briefly in square brackets.
+Output only the Markdown, without explanations and without wrapping it in a code block.`
+
+// PageRenderer renders the pages of a PDF file to images.
+type PageRenderer interface {
+	// Render returns one image per page, in page order.
+	Render(ctx context.Context, pdf []byte) ([][]byte, error)
+}
+
+// Config is the configuration for vision parser.
+type Config struct {
+	// ChatModel is the multimodal chat model transcribing the images, e.g. the Ark, OpenAI or Claude chat models
+	// with a vision capable model. Required.
+	ChatModel model.BaseChatModel
+	// Prompt is the instruction sent with each image, e.g. to extract the fields of an invoice.
+	// Optional. Default a prompt transcribing the page to Markdown.
+	Prompt string
+	// ImageDetail is the detail level of the images, for the models supporting it.
+	// Optional. Default the model's default.
+	ImageDetail schema.ImageURLDetail
+	// PageRenderer renders the PDF pages to images, e.g. NewPdftoppmRenderer.
+	// Optional. Default nil, the images embedded in the pages by NewPDFParser are sent instead,
+	// which suits scanned PDF files, and the pages without images are extracted as text.
+	PageRenderer PageRenderer
+	// NewPDFParser creates the parser splitting the PDF files into one document per page, with the MetaKeyPageNumber
+	// metadata, and calling imageParser with the images embedded in each page and the MetaKeyPageNumber extra meta,
+	// e.g. the PDF parser of components/document/parser/pdf:
+	//
+	//	func(ctx context.Context, imageParser parser.Parser) (parser.Parser, error) {
+	//		return pdf.NewPDFParser(ctx, &pdf.Config{ToPages: true, Layout: true, ImageParser: imageParser})
+	//	}
+	//
+	// Optional. Only used without PageRenderer, the PDF files are not supported without both.
+	NewPDFParser func(ctx context.Context, imageParser parser.Parser) (parser.Parser, error)
+	// Concurrency is the number of pages transcribed at the same time.
+	// Optional. Default 1.
+	Concurrency int
+}
+
+// VisionParser reads from io.Reader and transcribes the image, one document per image,
+// or the PDF file, one document per page, with a multimodal chat model.
+type VisionParser struct {
+	conf *Config
+}
+
+// NewVisionParser creates a new vision parser.
+func NewVisionParser(ctx context.Context, config *Config) (*VisionParser, error) {
+	if config == nil {
+		return nil, errors.New("new vision parser, config is nil")
+	}
+	if config.ChatModel == nil {
+		return nil, errors.New("new vision parser, chat model is required")
+	}
+
+	return &VisionParser{conf: config}, nil
+}
+
+// Parse parses the image or the PDF file from io.Reader. The format is detected from the content.
+func (vp *VisionParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	commonOpts := parser.GetCommonOptions(nil, opts...)
+	specificOpts := parser.GetImplSpecificOptions(&options{prompt: vp.conf.Prompt}, opts...)
+	if specificOpts.prompt == "" {
+		specificOpts.prompt = defaultPrompt
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("vision parser read all from reader failed: %w", err)
+	}
+
+	meta := make(map[string]any, len(commonOpts.ExtraMeta)+3)
+	if commonOpts.URI != "" {
+		meta[MetaKeySource] = commonOpts.URI
+	}
+	for k, v := range commonOpts.ExtraMeta {
+		meta[k] = v
+	}
+
+	if bytes.HasPrefix(data, []byte("%PDF-")) {
+		if vp.conf.PageRenderer == nil && vp.conf.NewPDFParser == nil {
+			return nil, errors.New("vision parser unsupported content type: application/pdf, neither page renderer nor pdf parser is configured")
+		}
+		return vp.parsePDF(ctx, data, meta, specificOpts.prompt)
+	}
+
+	if !strings.HasPrefix(http.DetectContentType(data), "image/") {
+		return nil, fmt.Errorf("vision parser unsupported content type: %s", http.DetectContentType(data))
+	}
+
+	doc := &schema.Document{MetaData: meta}
+	if err = vp.transcribe(ctx, doc, [][]byte{data}, specificOpts.prompt); err != nil {
+		return nil, err
+	}
+
+	return []*schema.Document{doc}, nil
+}
+
+// parsePDF transcribes the pages rendered by the page renderer, or the images embedded in the pages.
+func (vp *VisionParser) parsePDF(ctx context.Context, data []byte, meta map[string]any, prompt string) ([]*schema.Document, error) {
+	var (
+		docs   []*schema.Document
+		images [][][]byte
+	)
+
+	if vp.conf.PageRenderer != nil {
+		rendered, err := vp.conf.PageRenderer.Render(ctx, data)
+		if err != nil {
+			return nil, fmt.Errorf("render pdf pages failed: %w", err)
+		}
+		for i, img := range rendered {
+			pageMeta := make(map[string]any, len(meta)+3)
+			for k, v := range meta {
+				pageMeta[k] = v
+			}
+			pageMeta[MetaKeyPageNumber] = i + 1
+			docs = append(docs, &schema.Document{MetaData: pageMeta})
+			images = append(images, [][]byte{img})
+		}
+	} else {
+		collector := &imageCollector{pages: map[int][][]byte{}}
+		pp, err := vp.conf.NewPDFParser(ctx, collector)
+		if err != nil {
+			return nil, fmt.Errorf("new pdf parser failed: %w", err)
+		}
+		docs, err = pp.Parse(ctx, bytes.NewReader(data), parser.WithExtraMeta(meta))
+		if err != nil {
+			return nil, err
+		}
+		for _, doc := range docs {
+			number, _ := doc.MetaData[MetaKeyPageNumber].(int)
+			images = append(images, collector.pages[number])
+		}
+	}
+
+	concurrency := max(vp.conf.Concurrency, 1)
+	sem := make(chan struct{}, concurrency)
+	errs := make([]error, len(docs))
+	var wg sync.WaitGroup
+	for i := range docs {
+		if len(images[i]) == 0 {
+			docs[i].MetaData[MetaKeyVision] = false
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := vp.transcribe(ctx, docs[i], images[i], prompt); err != nil {
+				errs[i] = fmt.Errorf("%w, page= %v", err, docs[i].MetaData[MetaKeyPageNumber])
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return docs, nil
+}
+
+// transcribe sends the prompt and the images to the chat model, and sets its answer as the content of the document.
+func (vp *VisionParser) transcribe(ctx context.Context, doc *schema.Document, images [][]byte, prompt string) error {
+	parts := []schema.ChatMessagePart{{Type: schema.ChatMessagePartTypeText, Text: prompt}}
+	for _, img := range images {
+		mimeType := http.DetectContentType(img)
+		parts = append(parts, schema.ChatMessagePart{
+			Type: schema.ChatMessagePartTypeImageURL,
+			ImageURL: &schema.ChatMessageImageURL{
+				URL:      "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(img),
+				MIMEType: mimeType,
+				Detail:   vp.conf.ImageDetail,
+			},
+		})
+	}
+
+	msg, err := vp.conf.ChatModel.Generate(ctx, []*schema.Message{{
+		Role:         schema.User,
+		MultiContent: parts,
+	}})
+	if err != nil {
+		return fmt.Errorf("transcribe images failed: %w", err)
+	}
+
+	doc.Content = trimCodeFence(msg.Content)
+	doc.MetaData[MetaKeyVision] = true
+	if msg.ResponseMeta != nil && msg.ResponseMeta.Usage != nil {
+		doc.MetaData[MetaKeyTokenUsage] = msg.ResponseMeta.Usage
+	}
+
+	return nil
+}
+
+// trimCodeFence removes the code block the models tend to wrap the Markdown in, despite the prompt.
+func trimCodeFence(s string) string {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "```") || !strings.HasSuffix(s, "```") {
+		return s
+	}
+
+	first := strings.IndexByte(s, '\n')
+	if first < 0 {
+		return s
+	}
+	lang := strings.TrimSpace(s[3:first])
+	if lang != "" && lang != "markdown" && lang != "md" {
+		// the page itself is a code block
+		return s
+	}
+
+	return strings.TrimSpace(s[first+1 : len(s)-3])
+}
+
+// imageCollector is the image parser of the PDF parser, collecting the images of the pages instead of parsing them.
+type imageCollector struct {
+	pages map[int][][]byte
+}
+
+func (c *imageCollector) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	page, _ := parser.GetCommonOptions(nil, opts...).ExtraMeta[MetaKeyPageNumber].(int)
+	c.pages[page] = append(c.pages[page], data)
+
+	return nil, nil
+}
@@ -0,0 +1,283 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vision
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockChatModel struct {
+	mu     sync.Mutex
+	inputs [][]*schema.Message
+	answer string
+	err    error
+}
+
+func (m *mockChatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.inputs = append(m.inputs, input)
+	msg := schema.AssistantMessage(m.answer, nil)
+	msg.ResponseMeta = &schema.ResponseMeta{Usage: &schema.TokenUsage{PromptTokens: 800, CompletionTokens: 20, TotalTokens: 820}}
+	return msg, nil
+}
+
+func (m *mockChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestNewVisionParser(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewVisionParser(ctx, nil)
+	assert.EqualError(t, err, "new vision parser, config is nil")
+
+	_, err = NewVisionParser(ctx, &Config{})
+	assert.EqualError(t, err, "new vision parser, chat model is required")
+}
+
+func TestVisionParser_Parse(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("image", func(t *testing.T) {
+		data, err := os.ReadFile("./examples/testdata/scanned.png")
+		assert.NoError(t, err)
+
+		cm := &mockChatModel{answer: "```markdown\n# Invoice 2025-001\n\nTotal: 42.00 EUR\n```"}
+		p, err := NewVisionParser(ctx, &Config{ChatModel: cm, ImageDetail: schema.ImageURLDetailHigh})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, bytes.NewReader(data),
+			parser.WithURI("scanned.png"), parser.WithExtraMeta(map[string]any{"test": "test"}))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(docs))
+		assert.Equal(t, "# Invoice 2025-001\n\nTotal: 42.00 EUR", docs[0].Content)
+		assert.Equal(t, "scanned.png", docs[0].MetaData[MetaKeySource])
+		assert.Equal(t, "test", docs[0].MetaData["test"])
+		assert.Equal(t, true, docs[0].MetaData[MetaKeyVision])
+		assert.Equal(t, 820, docs[0].MetaData[MetaKeyTokenUsage].(*schema.TokenUsage).TotalTokens)
+
+		assert.Equal(t, 1, len(cm.inputs))
+		parts := cm.inputs[0][0].MultiContent
+		assert.Equal(t, schema.User, cm.inputs[0][0].Role)
+		assert.Equal(t, 2, len(parts))
+		assert.Equal(t, defaultPrompt, parts[0].Text)
+		assert.Equal(t, schema.ChatMessagePartTypeImageURL, parts[1].Type)
+		assert.Equal(t, "data:image/png;base64,"+base64.StdEncoding.EncodeToString(data), parts[1].ImageURL.URL)
+		assert.Equal(t, "image/png", parts[1].ImageURL.MIMEType)
+		assert.Equal(t, schema.ImageURLDetailHigh, parts[1].ImageURL.Detail)
+	})
+
+	t.Run("prompt", func(t *testing.T) {
+		data, err := os.ReadFile("./examples/testdata/scanned.png")
+		assert.NoError(t, err)
+
+		cm := &mockChatModel{answer: "invoice_number: 2025-001"}
+		p, err := NewVisionParser(ctx, &Config{ChatModel: cm, Prompt: "Transcribe the page."})
+		assert.NoError(t, err)
+
+		_, err = p.Parse(ctx, bytes.NewReader(data))
+		assert.NoError(t, err)
+		_, err = p.Parse(ctx, bytes.NewReader(data), WithPrompt("Extract the invoice number."))
+		assert.NoError(t, err)
+		assert.Equal(t, "Transcribe the page.", cm.inputs[0][0].MultiContent[0].Text)
+		assert.Equal(t, "Extract the invoice number.", cm.inputs[1][0].MultiContent[0].Text)
+	})
+
+	t.Run("pdf embedded images", func(t *testing.T) {
+		data, err := os.ReadFile("./examples/testdata/scanned.pdf")
+		assert.NoError(t, err)
+
+		cm := &mockChatModel{answer: "page 1"}
+		p, err := NewVisionParser(ctx, &Config{ChatModel: cm, NewPDFParser: newFakePDFParser(t)})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, bytes.NewReader(data), parser.WithURI("scanned.pdf"))
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(docs))
+		assert.Equal(t, 1, len(cm.inputs))
+		assert.Equal(t, "image/png", cm.inputs[0][0].MultiContent[1].ImageURL.MIMEType)
+
+		assert.Equal(t, "page 1", docs[0].Content)
+		assert.Equal(t, 1, docs[0].MetaData[MetaKeyPageNumber])
+		assert.Equal(t, "scanned.pdf", docs[0].MetaData[MetaKeySource])
+		assert.Equal(t, true, docs[0].MetaData[MetaKeyVision])
+		assert.Equal(t, "A typed page.", strings.TrimSpace(docs[1].Content))
+		assert.Equal(t, 2, docs[1].MetaData[MetaKeyPageNumber])
+		assert.Equal(t, false, docs[1].MetaData[MetaKeyVision])
+	})
+
+	t.Run("pdf page renderer", func(t *testing.T) {
+		data, err := os.ReadFile("./examples/testdata/scanned.pdf")
+		assert.NoError(t, err)
+		img, err := os.ReadFile("./examples/testdata/scanned.png")
+		assert.NoError(t, err)
+
+		cm := &mockChatModel{answer: "page"}
+		p, err := NewVisionParser(ctx, &Config{
+			ChatModel:    cm,
+			PageRenderer: mockRenderer{images: [][]byte{img, img, img}},
+			Concurrency:  2,
+		})
+		assert.NoError(t, err)
+
+		docs, err := p.Parse(ctx, bytes.NewReader(data), parser.WithExtraMeta(map[string]any{"test": "test"}))
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(docs))
+		assert.Equal(t, 3, len(cm.inputs))
+		for i, doc := range docs {
+			assert.Equal(t, "page", doc.Content)
+			assert.Equal(t, map[string]any{
+				"test":            "test",
+				MetaKeyPageNumber: i + 1,
+				MetaKeyVision:     true,
+				MetaKeyTokenUsage: &schema.TokenUsage{PromptTokens: 800, CompletionTokens: 20, TotalTokens: 820},
+			}, doc.MetaData)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		data, err := os.ReadFile("./examples/testdata/scanned.pdf")
+		assert.NoError(t, err)
+
+		p, err := NewVisionParser(ctx, &Config{ChatModel: &mockChatModel{err: errors.New("mock error")}})
+		assert.NoError(t, err)
+		_, err = p.Parse(ctx, bytes.NewReader(data))
+		assert.ErrorContains(t, err, "neither page renderer nor pdf parser is configured")
+
+		p, err = NewVisionParser(ctx, &Config{ChatModel: &mockChatModel{err: errors.New("mock error")}, NewPDFParser: newFakePDFParser(t)})
+		assert.NoError(t, err)
+		_, err = p.Parse(ctx, bytes.NewReader(data))
+		assert.EqualError(t, err, "transcribe images failed: mock error, page= 1")
+
+		p, err = NewVisionParser(ctx, &Config{ChatModel: &mockChatModel{}, PageRenderer: mockRenderer{err: errors.New("mock error")}})
+		assert.NoError(t, err)
+		_, err = p.Parse(ctx, bytes.NewReader(data))
+		assert.EqualError(t, err, "render pdf pages failed: mock error")
+
+		_, err = p.Parse(ctx, strings.NewReader("plain text"))
+		assert.ErrorContains(t, err, "vision parser unsupported content type: text/plain")
+	})
+}
+
+func TestTrimCodeFence(t *testing.T) {
+	assert.Equal(t, "# Title", trimCodeFence("  # Title\n"))
+	assert.Equal(t, "# Title", trimCodeFence("```\n# Title\n```"))
+	assert.Equal(t, "# Title", trimCodeFence("```md\n# Title\n```"))
+	assert.Equal(t, "```go\nfunc main() {}\n```", trimCodeFence("```go\nfunc main() {}\n```"))
+	assert.Equal(t, "```a```", trimCodeFence("```a```"))
+}
+
+// fakePDFParser splits the PDF files like the PDF parser, into an image-only page with the image,
+// and a typed page without image.
+type fakePDFParser struct {
+	imageParser parser.Parser
+	image       []byte
+}
+
+func newFakePDFParser(t *testing.T) func(ctx context.Context, imageParser parser.Parser) (parser.Parser, error) {
+	image, err := os.ReadFile("./examples/testdata/scanned.png")
+	assert.NoError(t, err)
+	return func(ctx context.Context, imageParser parser.Parser) (parser.Parser, error) {
+		return &fakePDFParser{imageParser: imageParser, image: image}, nil
+	}
+}
+
+func (f *fakePDFParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	extraMeta := parser.GetCommonOptions(nil, opts...).ExtraMeta
+	var docs []*schema.Document
+	for i, content := range []string{"", "A typed page."} {
+		meta := map[string]any{MetaKeyPageNumber: i + 1}
+		for k, v := range extraMeta {
+			meta[k] = v
+		}
+		docs = append(docs, &schema.Document{Content: content, MetaData: meta})
+	}
+
+	_, err := f.imageParser.Parse(ctx, bytes.NewReader(f.image), parser.WithURI("page-1-image-1.png"),
+		parser.WithExtraMeta(map[string]any{MetaKeyPageNumber: 1}))
+	if err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+type mockRenderer struct {
+	images [][]byte
+	err    error
+}
+
+func (m mockRenderer) Render(ctx context.Context, pdf []byte) ([][]byte, error) {
+	return m.images, m.err
+}
+
+func TestPdftoppmRenderer(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake pdftoppm is a shell script")
+	}
+
+	_, err := NewPdftoppmRenderer(&PdftoppmConfig{Path: filepath.Join(t.TempDir(), "not-exist")})
+	assert.ErrorContains(t, err, "new pdftoppm renderer, pdftoppm not found")
+
+	// writes 10 pages named like pdftoppm, whose last two arguments are the input file and the output prefix
+	dir := t.TempDir()
+	argsPath := filepath.Join(dir, "args")
+	script := "#!/bin/sh\n" +
+		"echo \"$@\" > " + argsPath + "\n" +
+		"for last; do :; done\n" +
+		"for i in 01 02 03 04 05 06 07 08 09 10; do echo \"image $i\" > \"$last-$i.png\"; done\n"
+	bin := filepath.Join(dir, "pdftoppm")
+	assert.NoError(t, os.WriteFile(bin, []byte(script), 0o755))
+
+	r, err := NewPdftoppmRenderer(&PdftoppmConfig{Path: bin, DPI: 200})
+	assert.NoError(t, err)
+
+	images, err := r.Render(context.Background(), []byte("%PDF-1.4"))
+	assert.NoError(t, err)
+	assert.Equal(t, 10, len(images))
+	assert.Equal(t, "image 01\n", string(images[0]))
+	assert.Equal(t, "image 10\n", string(images[9]))
+
+	args, err := os.ReadFile(argsPath)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(args), "-png -r 200 "))
+
+	failing := filepath.Join(dir, "failing")
+	assert.NoError(t, os.WriteFile(failing, []byte("#!/bin/sh\necho 'Syntax Error: Could not read xref table' >&2\nexit 1\n"), 0o755))
+	r, err = NewPdftoppmRenderer(&PdftoppmConfig{Path: failing})
+	assert.NoError(t, err)
+	_, err = r.Render(context.Background(), []byte("%PDF-1.4"))
+	assert.ErrorContains(t, err, "stderr= Syntax Error: Could not read xref table")
+}
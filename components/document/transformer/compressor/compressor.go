@@ -0,0 +1,401 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package compressor implements the contextual compression of the retrieved documents: given the query, each document
+// is trimmed to its sentences relevant to the query, which reduces the size of the prompt, the kept passages being
+// cited by their offsets in the original content.
+package compressor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	// MetaKeyOriginalContent is the metadata key of the content of the document before the compression.
+	MetaKeyOriginalContent = "_original_content"
+	// MetaKeySpans is the metadata key of the []Span of the passages kept in the compressed content, in order.
+	MetaKeySpans = "_compressed_spans"
+)
+
+const defaultPrompt = `You select the sentences of a document which are relevant to answer a question.
+The sentences of the document are numbered.
+Answer only with a JSON array of the numbers of the relevant sentences, e.g. [2, 5], or [] if none is relevant.`
+
+const (
+	defaultThreshold = 0.5
+	defaultSeparator = "\n...\n"
+)
+
+// ScoreFunc scores the relevance of the sentences to the query, the higher the more relevant,
+// e.g. with a cross-encoder reranking model.
+type ScoreFunc func(ctx context.Context, query string, sentences []string) ([]float64, error)
+
+// Span is a passage of the compressed content, located in the original content.
+type Span struct {
+	// Start and End are the character offsets of the passage in the original content, End being exclusive.
+	Start int `json:"start"`
+	End   int `json:"end"`
+	// Score is the best score of the sentences of the passage, 1 for the sentences selected by the chat model.
+	Score float64 `json:"score"`
+}
+
+type Config struct {
+	// Embedding scores the sentences by the cosine similarity of their embedding to the one of the query.
+	Embedding embedding.Embedder
+	// ChatModel selects the relevant sentences among the numbered sentences of a document.
+	ChatModel model.BaseChatModel
+	// Score scores the sentences, e.g. with a cross-encoder.
+	// Exactly one of Embedding, ChatModel and Score is required.
+	Score ScoreFunc
+	// Threshold is the minimum score of the kept sentences, for Embedding and Score.
+	// Optional. Default 0.5.
+	Threshold *float64
+	// MaxSentences is the maximum number of sentences kept in a document, the best scoring ones.
+	// Optional. Default 0, no limit.
+	MaxSentences int
+	// Window is the number of sentences kept before and after each relevant sentence, for their context.
+	// Optional. Default 0.
+	Window int
+	// Prompt is the system prompt of the ChatModel, which must ask for a JSON array of the numbers of the sentences.
+	// Optional. Default a prompt selecting the sentences relevant to answer the query.
+	Prompt string
+	// Separator joins the passages of the compressed content which aren't adjacent in the original content.
+	// Optional. Default "\n...\n".
+	Separator string
+	// KeepEmpty keeps the documents without relevant sentence, with an empty content.
+	// Optional. Default false, they are dropped.
+	KeepEmpty bool
+	// Concurrency is the number of documents compressed at the same time.
+	// Optional. Default 1.
+	Concurrency int
+}
+
+type options struct {
+	query string
+}
+
+// WithQuery sets the query the documents are compressed for. Required.
+func WithQuery(query string) document.TransformerOption {
+	return document.WrapTransformerImplSpecificOptFn(func(o *options) {
+		o.query = query
+	})
+}
+
+// NewCompressor creates a document transformer keeping the sentences of the documents relevant to the query,
+// set with WithQuery.
+//
+// The compressed documents keep their ID and metadata, their original content being stored at MetaKeyOriginalContent,
+// and the kept passages at MetaKeySpans.
+func NewCompressor(ctx context.Context, config *Config) (document.Transformer, error) {
+	if config == nil {
+		return nil, errors.New("config is required")
+	}
+	scorers := 0
+	for _, set := range []bool{config.Embedding != nil, config.ChatModel != nil, config.Score != nil} {
+		if set {
+			scorers++
+		}
+	}
+	if scorers != 1 {
+		return nil, errors.New("exactly one of embedding, chat model and score is required")
+	}
+	if config.MaxSentences < 0 || config.Window < 0 {
+		return nil, errors.New("max sentences and window must not be negative")
+	}
+
+	threshold := defaultThreshold
+	if config.Threshold != nil {
+		threshold = *config.Threshold
+	}
+	prompt := config.Prompt
+	if prompt == "" {
+		prompt = defaultPrompt
+	}
+	separator := config.Separator
+	if separator == "" {
+		separator = defaultSeparator
+	}
+
+	return &compressor{
+		embedding:    config.Embedding,
+		chatModel:    config.ChatModel,
+		score:        config.Score,
+		threshold:    threshold,
+		maxSentences: config.MaxSentences,
+		window:       config.Window,
+		prompt:       prompt,
+		separator:    separator,
+		keepEmpty:    config.KeepEmpty,
+		concurrency:  max(config.Concurrency, 1),
+	}, nil
+}
+
+type compressor struct {
+	embedding    embedding.Embedder
+	chatModel    model.BaseChatModel
+	score        ScoreFunc
+	threshold    float64
+	maxSentences int
+	window       int
+	prompt       string
+	separator    string
+	keepEmpty    bool
+	concurrency  int
+}
+
+func (c *compressor) Transform(ctx context.Context, src []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	o := document.GetTransformerImplSpecificOptions(&options{}, opts...)
+	if strings.TrimSpace(o.query) == "" {
+		return nil, errors.New("query is required, see WithQuery")
+	}
+
+	var queryVector []float64
+	if c.embedding != nil {
+		vectors, err := c.embedding.EmbedStrings(ctx, []string{o.query})
+		if err != nil {
+			return nil, fmt.Errorf("embed query failed: %w", err)
+		}
+		if len(vectors) != 1 {
+			return nil, fmt.Errorf("embedding returned %d vectors for 1 text", len(vectors))
+		}
+		queryVector = vectors[0]
+	}
+
+	compressed := make([]*schema.Document, len(src))
+	errs := make([]error, len(src))
+	sem := make(chan struct{}, c.concurrency)
+	var wg sync.WaitGroup
+	for i, doc := range src {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, doc *schema.Document) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if compressed[i], errs[i] = c.compress(ctx, o.query, queryVector, doc); errs[i] != nil {
+				errs[i] = fmt.Errorf("%w, document id= %s", errs[i], doc.ID)
+			}
+		}(i, doc)
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	ret := make([]*schema.Document, 0, len(compressed))
+	for _, doc := range compressed {
+		if doc != nil {
+			ret = append(ret, doc)
+		}
+	}
+	return ret, nil
+}
+
+func (c *compressor) GetType() string {
+	return "ContextualCompressor"
+}
+
+// compress returns a copy of the document with its relevant sentences, nil if none is relevant and empty ones are dropped.
+func (c *compressor) compress(ctx context.Context, query string, queryVector []float64, doc *schema.Document) (*schema.Document, error) {
+	sentences := splitSentences(doc.Content)
+	texts := make([]string, len(sentences))
+	for i, s := range sentences {
+		texts[i] = doc.Content[s.start:s.end]
+	}
+
+	var (
+		scores []float64
+		err    error
+	)
+	if len(sentences) > 0 {
+		switch {
+		case c.embedding != nil:
+			scores, err = c.similarities(ctx, queryVector, texts)
+		case c.chatModel != nil:
+			scores, err = c.selections(ctx, query, texts)
+		default:
+			scores, err = c.score(ctx, query, texts)
+			if err == nil && len(scores) != len(texts) {
+				err = fmt.Errorf("score returned %d scores for %d sentences", len(scores), len(texts))
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	relevant := c.relevant(scores)
+	if len(relevant) == 0 && !c.keepEmpty {
+		return nil, nil
+	}
+
+	// the relevant sentences and their window, grouped into the passages of adjacent sentences
+	kept := make([]bool, len(sentences))
+	for _, i := range relevant {
+		for j := max(i-c.window, 0); j <= min(i+c.window, len(sentences)-1); j++ {
+			kept[j] = true
+		}
+	}
+	isRelevant := make(map[int]bool, len(relevant))
+	for _, i := range relevant {
+		isRelevant[i] = true
+	}
+
+	var (
+		passages []string
+		ranges   [][2]int
+		spans    []Span
+	)
+	for i := 0; i < len(sentences); i++ {
+		if !kept[i] {
+			continue
+		}
+		j, score := i, math.Inf(-1)
+		for ; j < len(sentences) && kept[j]; j++ {
+			if isRelevant[j] {
+				score = math.Max(score, scores[j])
+			}
+		}
+		r := [2]int{sentences[i].start, sentences[j-1].end}
+		passages = append(passages, doc.Content[r[0]:r[1]])
+		ranges = append(ranges, r)
+		spans = append(spans, Span{Score: score})
+		i = j - 1
+	}
+	for i, r := range ranges {
+		spans[i].Start = utf8.RuneCountInString(doc.Content[:r[0]])
+		spans[i].End = spans[i].Start + utf8.RuneCountInString(doc.Content[r[0]:r[1]])
+	}
+
+	ret := &schema.Document{
+		ID:       doc.ID,
+		Content:  strings.Join(passages, c.separator),
+		MetaData: make(map[string]any, len(doc.MetaData)+2),
+	}
+	for k, v := range doc.MetaData {
+		ret.MetaData[k] = v
+	}
+	ret.MetaData[MetaKeyOriginalContent] = doc.Content
+	ret.MetaData[MetaKeySpans] = spans
+	return ret, nil
+}
+
+// relevant returns the indexes of the relevant sentences in order, the best scoring ones up to the max sentences.
+func (c *compressor) relevant(scores []float64) []int {
+	threshold := c.threshold
+	if c.chatModel != nil {
+		threshold = 1
+	}
+	var ret []int
+	for i, score := range scores {
+		if score >= threshold {
+			ret = append(ret, i)
+		}
+	}
+	if c.maxSentences > 0 && len(ret) > c.maxSentences {
+		sort.SliceStable(ret, func(i, j int) bool {
+			return scores[ret[i]] > scores[ret[j]]
+		})
+		ret = ret[:c.maxSentences]
+		sort.Ints(ret)
+	}
+	return ret
+}
+
+// similarities returns the cosine similarities of the sentences to the query.
+func (c *compressor) similarities(ctx context.Context, queryVector []float64, sentences []string) ([]float64, error) {
+	vectors, err := c.embedding.EmbedStrings(ctx, sentences)
+	if err != nil {
+		return nil, fmt.Errorf("embed sentences failed: %w", err)
+	}
+	if len(vectors) != len(sentences) {
+		return nil, fmt.Errorf("embedding returned %d vectors for %d sentences", len(vectors), len(sentences))
+	}
+	scores := make([]float64, len(vectors))
+	for i, v := range vectors {
+		scores[i] = cosine(queryVector, v)
+	}
+	return scores, nil
+}
+
+// selections returns 1 for the sentences selected by the chat model, 0 for the others.
+func (c *compressor) selections(ctx context.Context, query string, sentences []string) ([]float64, error) {
+	var sb strings.Builder
+	sb.WriteString("Question: ")
+	sb.WriteString(query)
+	sb.WriteString("\n\nDocument:\n")
+	for i, s := range sentences {
+		// the line breaks are split at, so the sentences are single lines
+		fmt.Fprintf(&sb, "[%d] %s\n", i+1, s)
+	}
+
+	msg, err := c.chatModel.Generate(ctx, []*schema.Message{
+		schema.SystemMessage(c.prompt),
+		schema.UserMessage(sb.String()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("generate selection failed: %w", err)
+	}
+
+	content := msg.Content
+	start, end := strings.Index(content, "["), strings.LastIndex(content, "]")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("no JSON array in selection: %q", content)
+	}
+	var numbers []int
+	if err = json.Unmarshal([]byte(content[start:end+1]), &numbers); err != nil {
+		return nil, fmt.Errorf("unmarshal selection failed: %w", err)
+	}
+
+	scores := make([]float64, len(sentences))
+	for _, n := range numbers {
+		// the numbers out of range are ignored, the model may have made them up
+		if n >= 1 && n <= len(sentences) {
+			scores[n-1] = 1
+		}
+	}
+	return scores, nil
+}
+
+func cosine(a, b []float64) float64 {
+	var dot, na, nb float64
+	for i := range a {
+		if i >= len(b) {
+			break
+		}
+		dot += a[i] * b[i]
+		na += a[i] * a[i]
+		nb += b[i] * b[i]
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(na*nb)
+}
@@ -0,0 +1,229 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package compressor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockEmbedding embeds the texts by the keywords they contain.
+type mockEmbedding struct {
+	err error
+}
+
+var keywords = []string{"revenue", "margin", "ceo"}
+
+func (m *mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	vectors := make([][]float64, 0, len(texts))
+	for _, text := range texts {
+		vec := []float64{0.1, 0, 0, 0}
+		for i, k := range keywords {
+			if strings.Contains(strings.ToLower(text), k) {
+				vec[i+1] = 1
+			}
+		}
+		vectors = append(vectors, vec)
+	}
+	return vectors, nil
+}
+
+type mockChatModel struct {
+	mu     sync.Mutex
+	output string
+	err    error
+	inputs [][]*schema.Message
+}
+
+func (m *mockChatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inputs = append(m.inputs, input)
+	if m.err != nil {
+		return nil, m.err
+	}
+	return schema.AssistantMessage(m.output, nil), nil
+}
+
+func (m *mockChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return nil, errors.New("not implemented")
+}
+
+const report = "ACME Q2 2025 report. Revenue grew by 3%.\nThe margin was stable. The CEO résumé is attached! Revenue forecasts are up."
+
+func TestSplitSentences(t *testing.T) {
+	split := func(text string) []string {
+		var ret []string
+		for _, s := range splitSentences(text) {
+			ret = append(ret, text[s.start:s.end])
+		}
+		return ret
+	}
+	assert.Equal(t, []string{"ACME Q2 2025 report.", "Revenue grew by 3%.", "The margin was stable.", "The CEO résumé is attached!", "Revenue forecasts are up."}, split(report))
+	assert.Equal(t, []string{"Pi is 3.14 exactly?!", `He said "yes."`, "Done"}, split(`  Pi is 3.14 exactly?! He said "yes." Done  `))
+	assert.Equal(t, []string{"第一句。", "第二句！", "third"}, split("第一句。第二句！\n\nthird"))
+	assert.Empty(t, split(" \n "))
+}
+
+func TestNewCompressor(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewCompressor(ctx, nil)
+	assert.EqualError(t, err, "config is required")
+	_, err = NewCompressor(ctx, &Config{})
+	assert.EqualError(t, err, "exactly one of embedding, chat model and score is required")
+	_, err = NewCompressor(ctx, &Config{Embedding: &mockEmbedding{}, ChatModel: &mockChatModel{}})
+	assert.EqualError(t, err, "exactly one of embedding, chat model and score is required")
+	_, err = NewCompressor(ctx, &Config{Embedding: &mockEmbedding{}, Window: -1})
+	assert.EqualError(t, err, "max sentences and window must not be negative")
+
+	c, err := NewCompressor(ctx, &Config{Embedding: &mockEmbedding{}})
+	assert.NoError(t, err)
+	assert.Equal(t, "ContextualCompressor", c.(*compressor).GetType())
+	assert.Equal(t, 0.5, c.(*compressor).threshold)
+	assert.Equal(t, "\n...\n", c.(*compressor).separator)
+}
+
+func TestCompressor_Embedding(t *testing.T) {
+	ctx := context.Background()
+	c, err := NewCompressor(ctx, &Config{Embedding: &mockEmbedding{}})
+	assert.NoError(t, err)
+
+	doc := &schema.Document{ID: "1", Content: report, MetaData: map[string]any{"source": "report.pdf"}}
+	doc.WithScore(0.8)
+	docs, err := c.Transform(ctx, []*schema.Document{doc, {ID: "2", Content: "Nothing about it."}}, WithQuery("How did the revenue evolve?"))
+	assert.NoError(t, err)
+	assert.Len(t, docs, 1)
+
+	compressed := docs[0]
+	assert.Equal(t, "1", compressed.ID)
+	assert.Equal(t, "Revenue grew by 3%.\n...\nRevenue forecasts are up.", compressed.Content)
+	assert.Equal(t, report, compressed.MetaData[MetaKeyOriginalContent])
+	assert.Equal(t, "report.pdf", compressed.MetaData["source"])
+	assert.Equal(t, 0.8, compressed.Score())
+	// the original document is unchanged
+	assert.Equal(t, report, doc.Content)
+
+	spans := compressed.MetaData[MetaKeySpans].([]Span)
+	assert.Len(t, spans, 2)
+	runes := []rune(report)
+	for i, passage := range strings.Split(compressed.Content, "\n...\n") {
+		assert.Equal(t, passage, string(runes[spans[i].Start:spans[i].End]))
+		assert.InDelta(t, 1, spans[i].Score, 1e-9)
+	}
+	// the offsets are in characters, the é being 2 bytes
+	assert.Equal(t, 92, spans[1].Start)
+
+	_, err = c.Transform(ctx, []*schema.Document{doc})
+	assert.EqualError(t, err, "query is required, see WithQuery")
+
+	c, err = NewCompressor(ctx, &Config{Embedding: &mockEmbedding{err: errors.New("rate limited")}})
+	assert.NoError(t, err)
+	_, err = c.Transform(ctx, []*schema.Document{doc}, WithQuery("revenue"))
+	assert.EqualError(t, err, "embed query failed: rate limited")
+}
+
+func TestCompressor_Window(t *testing.T) {
+	ctx := context.Background()
+	c, err := NewCompressor(ctx, &Config{Embedding: &mockEmbedding{}, Window: 1, Separator: " [...] ", KeepEmpty: true, Concurrency: 2})
+	assert.NoError(t, err)
+
+	docs, err := c.Transform(ctx, []*schema.Document{
+		{ID: "1", Content: report},
+		{ID: "2", Content: "Nothing about it."},
+		{ID: "3", Content: ""},
+	}, WithQuery("margin"))
+	assert.NoError(t, err)
+	assert.Len(t, docs, 3)
+	// the adjacent sentences are a single passage, with the original line break
+	assert.Equal(t, "Revenue grew by 3%.\nThe margin was stable. The CEO résumé is attached!", docs[0].Content)
+	assert.Len(t, docs[0].MetaData[MetaKeySpans], 1)
+	assert.Equal(t, "", docs[1].Content)
+	assert.Empty(t, docs[1].MetaData[MetaKeySpans])
+	assert.Equal(t, "", docs[2].Content)
+}
+
+func TestCompressor_ChatModel(t *testing.T) {
+	ctx := context.Background()
+	cm := &mockChatModel{output: "The relevant sentences are:\n```json\n[2, 5, 42]\n```"}
+	c, err := NewCompressor(ctx, &Config{ChatModel: cm, MaxSentences: 1})
+	assert.NoError(t, err)
+
+	docs, err := c.Transform(ctx, []*schema.Document{{ID: "1", Content: report}}, WithQuery("How did the revenue evolve?"))
+	assert.NoError(t, err)
+	assert.Len(t, docs, 1)
+	// the first of the selected sentences, the max sentences being 1
+	assert.Equal(t, "Revenue grew by 3%.", docs[0].Content)
+	assert.Equal(t, []Span{{Start: 21, End: 40, Score: 1}}, docs[0].MetaData[MetaKeySpans])
+
+	assert.Len(t, cm.inputs, 1)
+	assert.Equal(t, defaultPrompt, cm.inputs[0][0].Content)
+	assert.Contains(t, cm.inputs[0][1].Content, "Question: How did the revenue evolve?")
+	assert.Contains(t, cm.inputs[0][1].Content, "[2] Revenue grew by 3%.\n[3] The margin was stable.\n")
+
+	cm.output = "[]"
+	docs, err = c.Transform(ctx, []*schema.Document{{ID: "1", Content: report}}, WithQuery("weather"))
+	assert.NoError(t, err)
+	assert.Empty(t, docs)
+
+	cm.output = "none"
+	_, err = c.Transform(ctx, []*schema.Document{{ID: "1", Content: report}}, WithQuery("weather"))
+	assert.EqualError(t, err, `no JSON array in selection: "none", document id= 1`)
+
+	cm.err = errors.New("timeout")
+	_, err = c.Transform(ctx, []*schema.Document{{ID: "1", Content: report}}, WithQuery("weather"))
+	assert.EqualError(t, err, "generate selection failed: timeout, document id= 1")
+}
+
+func TestCompressor_Score(t *testing.T) {
+	ctx := context.Background()
+	threshold := 2.0
+	c, err := NewCompressor(ctx, &Config{
+		Score: func(ctx context.Context, query string, sentences []string) ([]float64, error) {
+			scores := make([]float64, len(sentences))
+			for i, s := range sentences {
+				scores[i] = float64(strings.Count(strings.ToLower(s), "e"))
+			}
+			return scores, nil
+		},
+		Threshold:    &threshold,
+		MaxSentences: 2,
+	})
+	assert.NoError(t, err)
+
+	docs, err := c.Transform(ctx, []*schema.Document{{ID: "1", Content: report}}, WithQuery("e"))
+	assert.NoError(t, err)
+	// the 2 best scoring sentences, in the original order
+	assert.Equal(t, "Revenue grew by 3%.\n...\nRevenue forecasts are up.", docs[0].Content)
+
+	c, err = NewCompressor(ctx, &Config{Score: func(ctx context.Context, query string, sentences []string) ([]float64, error) {
+		return []float64{1}, nil
+	}})
+	assert.NoError(t, err)
+	_, err = c.Transform(ctx, []*schema.Document{{ID: "1", Content: report}}, WithQuery("e"))
+	assert.EqualError(t, err, "score returned 1 scores for 5 sentences, document id= 1")
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/compressor"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// use Embedding for the similarity to the query, or ChatModel for the extraction by a LLM,
+	// e.g. Embedding: arkEmbedder, Threshold: &threshold
+	threshold := 0.5
+	c, err := compressor.NewCompressor(ctx, &compressor.Config{
+		// a cross-encoder scoring the sentences, here the fraction of the words of the query they contain
+		Score: func(ctx context.Context, query string, sentences []string) ([]float64, error) {
+			words := strings.Fields(strings.ToLower(query))
+			scores := make([]float64, len(sentences))
+			for i, s := range sentences {
+				for _, w := range words {
+					if strings.Contains(strings.ToLower(s), w) {
+						scores[i] += 1 / float64(len(words))
+					}
+				}
+			}
+			return scores, nil
+		},
+		Threshold: &threshold,
+		Window:    1,
+	})
+	if err != nil {
+		log.Fatalf("Failed to create compressor: %v", err)
+	}
+
+	// the documents retrieved for the query
+	docs, err := c.Transform(ctx, []*schema.Document{
+		{ID: "report", Content: "ACME Q2 2025 report. Revenue grew by 3% compared to the previous quarter. The margin was stable. The headcount is 120."},
+		{ID: "faq", Content: "The office is open from 9am to 6pm. Parking is free for visitors."},
+	}, compressor.WithQuery("revenue growth"))
+	if err != nil {
+		log.Fatalf("Failed to compress documents: %v", err)
+	}
+
+	for _, doc := range docs {
+		fmt.Printf("%s: %s\n", doc.ID, doc.Content)
+		for _, span := range doc.MetaData[compressor.MetaKeySpans].([]compressor.Span) {
+			fmt.Printf("  cited from characters [%d, %d), score %.2f\n", span.Start, span.End, span.Score)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package compressor
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// sentence is a sentence of a text, [start, end) being its byte range without the surrounding spaces.
+type sentence struct {
+	start, end int
+}
+
+// splitSentences splits the text after the sentence terminators followed by a space, the CJK terminators, and the line breaks.
+func splitSentences(text string) []sentence {
+	var (
+		ret   []sentence
+		start int
+	)
+	add := func(end int) {
+		s, e := start, end
+		for s < e {
+			r, size := utf8.DecodeRuneInString(text[s:])
+			if !unicode.IsSpace(r) {
+				break
+			}
+			s += size
+		}
+		for e > s {
+			r, size := utf8.DecodeLastRuneInString(text[:e])
+			if !unicode.IsSpace(r) {
+				break
+			}
+			e -= size
+		}
+		if s < e {
+			ret = append(ret, sentence{start: s, end: e})
+		}
+		start = end
+	}
+
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+		switch r {
+		case '\n', '。', '！', '？':
+			add(i)
+		case '.', '!', '?':
+			// the terminators and closing quotes following, e.g. `?!` or `."`
+			for i < len(text) {
+				next, nextSize := utf8.DecodeRuneInString(text[i:])
+				if next != '.' && next != '!' && next != '?' && next != '"' && next != '\'' && next != ')' && next != '”' && next != '’' {
+					break
+				}
+				i += nextSize
+			}
+			if i == len(text) {
+				add(i)
+				break
+			}
+			if next, _ := utf8.DecodeRuneInString(text[i:]); unicode.IsSpace(next) {
+				add(i)
+			}
+		}
+	}
+	add(len(text))
+	return ret
+}
@@ -0,0 +1,323 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package dedup provides a document transformer removing the exact and the near duplicate documents,
+// such as the boilerplate pages of a crawled site, before they are indexed.
+package dedup
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+)
+
+const MetaKeyDuplicateIDs = "_duplicate_ids"
+
+type Method uint8
+
+const (
+	// MethodMinHash compares the Jaccard similarity of the shingle sets of the documents, estimated with MinHash.
+	// It suits the documents sharing most of their content, e.g. the same article with a different footer.
+	MethodMinHash Method = iota
+	// MethodSimHash compares the 64 bits SimHash fingerprints of the documents.
+	// It is cheaper than MinHash, and suits the documents with small edits, e.g. a date or a counter.
+	MethodSimHash
+	// MethodExact only removes the documents with the same normalized content.
+	MethodExact
+)
+
+type Strategy uint8
+
+const (
+	// StrategyDrop keeps the first document of each group of duplicates, and drops the others.
+	StrategyDrop Strategy = iota
+	// StrategyMerge keeps the first document of each group of duplicates, with the IDs of the others
+	// at MetaKeyDuplicateIDs, and the metadata keys of the others it does not have.
+	StrategyMerge
+)
+
+type Config struct {
+	// Method is the similarity measure of the documents. The exact duplicates are always removed.
+	// Optional. Default MethodMinHash.
+	Method Method
+	// Threshold is the similarity from which two documents are duplicates, between 0 and 1:
+	// the Jaccard similarity of the shingles for MethodMinHash,
+	// and the fraction of equal fingerprint bits for MethodSimHash, 0.95 allowing 3 different bits.
+	// Optional. Default 0.8 for MethodMinHash, 0.95 for MethodSimHash.
+	Threshold float64
+	// ShingleSize is the number of consecutive words of the shingles, a CJK character counting as a word.
+	// The documents with fewer words are only compared exactly.
+	// Optional. Default 5.
+	ShingleSize int
+	// NumHashes is the size of the MinHash signatures, the larger the more accurate and the slower.
+	// Optional. Default 128.
+	NumHashes int
+	// Bands is the number of bands of the MinHash signatures for locality sensitive hashing, which must divide NumHashes.
+	// The more bands, the more candidate pairs are compared, and the fewer duplicates are missed.
+	// Optional. Default 32.
+	Bands int
+	// Strategy is what is done with the duplicates.
+	// Optional. Default StrategyDrop.
+	Strategy Strategy
+}
+
+// NewDeduplicator creates a document transformer removing the duplicates among the documents of each Transform call,
+// keeping the first document of each group in the original order.
+func NewDeduplicator(ctx context.Context, config *Config) (document.Transformer, error) {
+	if config == nil {
+		config = &Config{}
+	}
+
+	d := &deduplicator{
+		method:      config.Method,
+		threshold:   config.Threshold,
+		shingleSize: config.ShingleSize,
+		numHashes:   config.NumHashes,
+		bands:       config.Bands,
+		strategy:    config.Strategy,
+	}
+	if d.threshold == 0 {
+		if d.method == MethodSimHash {
+			d.threshold = 0.95
+		} else {
+			d.threshold = 0.8
+		}
+	}
+	if d.shingleSize == 0 {
+		d.shingleSize = 5
+	}
+	if d.numHashes == 0 {
+		d.numHashes = 128
+	}
+	if d.bands == 0 {
+		d.bands = 32
+	}
+
+	if d.method > MethodExact {
+		return nil, fmt.Errorf("unknown method: %d", d.method)
+	}
+	if d.strategy > StrategyMerge {
+		return nil, fmt.Errorf("unknown strategy: %d", d.strategy)
+	}
+	if d.threshold < 0 || d.threshold > 1 {
+		return nil, errors.New("threshold must be between 0 and 1")
+	}
+	if d.shingleSize < 0 {
+		return nil, errors.New("shingle size must be greater than zero")
+	}
+	if d.numHashes < 0 || d.bands < 0 || d.numHashes%d.bands != 0 {
+		return nil, errors.New("bands must divide num hashes")
+	}
+
+	return d, nil
+}
+
+type deduplicator struct {
+	method      Method
+	threshold   float64
+	shingleSize int
+	numHashes   int
+	bands       int
+	strategy    Strategy
+}
+
+func (d *deduplicator) Transform(ctx context.Context, src []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	groups := newUnionFind(len(src))
+
+	tokens := make([][]string, len(src))
+	exact := make(map[string]int, len(src))
+	for i, doc := range src {
+		if doc == nil {
+			continue
+		}
+		tokens[i] = normalize(doc.Content)
+		if len(tokens[i]) == 0 {
+			// nothing to compare, e.g. a page of images
+			continue
+		}
+		h := contentHash(tokens[i])
+		if j, ok := exact[h]; ok {
+			groups.union(j, i)
+			continue
+		}
+		exact[h] = i
+	}
+
+	switch d.method {
+	case MethodMinHash:
+		d.groupMinHash(src, tokens, groups)
+	case MethodSimHash:
+		d.groupSimHash(src, tokens, groups)
+	}
+
+	ret := make([]*schema.Document, 0, len(src))
+	kept := make(map[int]int, len(src))
+	for i, doc := range src {
+		root := groups.find(i)
+		if root == i {
+			kept[i] = len(ret)
+			ret = append(ret, doc)
+			continue
+		}
+		if d.strategy != StrategyMerge {
+			continue
+		}
+
+		k := kept[root]
+		if ret[k] == src[root] {
+			ret[k] = copyDocument(src[root])
+		}
+		ids, _ := ret[k].MetaData[MetaKeyDuplicateIDs].([]string)
+		ret[k].MetaData[MetaKeyDuplicateIDs] = append(ids, doc.ID)
+		for key, v := range doc.MetaData {
+			if _, ok := ret[k].MetaData[key]; !ok {
+				ret[k].MetaData[key] = v
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+func (d *deduplicator) GetType() string {
+	return "Deduplicator"
+}
+
+// groupMinHash groups the documents whose MinHash signatures are similar enough.
+// The candidate pairs are the documents with an identical band of their signatures.
+func (d *deduplicator) groupMinHash(src []*schema.Document, tokens [][]string, groups *unionFind) {
+	rows := d.numHashes / d.bands
+	sigs := make([][]uint64, len(src))
+	buckets := make(map[uint64][]int)
+	for i := range src {
+		if len(tokens[i]) < d.shingleSize || groups.find(i) != i {
+			continue
+		}
+		sigs[i] = minHash(shingles(tokens[i], d.shingleSize), d.numHashes)
+		for b := 0; b < d.bands; b++ {
+			h := fnv.New64a()
+			var buf [8]byte
+			binary.LittleEndian.PutUint64(buf[:], uint64(b))
+			h.Write(buf[:])
+			for _, v := range sigs[i][b*rows : (b+1)*rows] {
+				binary.LittleEndian.PutUint64(buf[:], v)
+				h.Write(buf[:])
+			}
+			key := h.Sum64()
+			buckets[key] = append(buckets[key], i)
+		}
+	}
+
+	d.compareBuckets(buckets, groups, func(i, j int) bool {
+		return minHashSimilarity(sigs[i], sigs[j]) >= d.threshold
+	})
+}
+
+// groupSimHash groups the documents whose SimHash fingerprints have few enough different bits.
+// The fingerprints are cut into one block more than the allowed different bits, so that the candidate pairs,
+// the documents with an identical block, include all the duplicates.
+func (d *deduplicator) groupSimHash(src []*schema.Document, tokens [][]string, groups *unionFind) {
+	maxDistance := int(math.Floor((1-d.threshold)*64 + 1e-9))
+	blocks := min(maxDistance+1, 64)
+	width := 64 / blocks
+
+	fps := make([]uint64, len(src))
+	buckets := make(map[uint64][]int)
+	for i := range src {
+		if len(tokens[i]) < d.shingleSize || groups.find(i) != i {
+			continue
+		}
+		fps[i] = simHash(shingles(tokens[i], d.shingleSize))
+		for b := 0; b < blocks; b++ {
+			lo := b * width
+			hi := lo + width
+			if b == blocks-1 {
+				hi = 64
+			}
+			block := fps[i] >> lo & (1<<(hi-lo) - 1)
+			key := mix(uint64(b)+1) ^ block
+			buckets[key] = append(buckets[key], i)
+		}
+	}
+
+	d.compareBuckets(buckets, groups, func(i, j int) bool {
+		return hammingDistance(fps[i], fps[j]) <= maxDistance
+	})
+}
+
+func (d *deduplicator) compareBuckets(buckets map[uint64][]int, groups *unionFind, similar func(i, j int) bool) {
+	compared := make(map[[2]int]struct{})
+	for _, bucket := range buckets {
+		for x := 0; x < len(bucket); x++ {
+			for y := x + 1; y < len(bucket); y++ {
+				pair := [2]int{bucket[x], bucket[y]}
+				if _, ok := compared[pair]; ok {
+					continue
+				}
+				compared[pair] = struct{}{}
+				if similar(pair[0], pair[1]) {
+					groups.union(pair[0], pair[1])
+				}
+			}
+		}
+	}
+}
+
+func copyDocument(doc *schema.Document) *schema.Document {
+	meta := make(map[string]any, len(doc.MetaData)+1)
+	for k, v := range doc.MetaData {
+		meta[k] = v
+	}
+	return &schema.Document{ID: doc.ID, Content: doc.Content, MetaData: meta}
+}
+
+// unionFind groups the documents, the root of each group being its first document.
+type unionFind struct {
+	parent []int
+}
+
+func newUnionFind(n int) *unionFind {
+	parent := make([]int, n)
+	for i := range parent {
+		parent[i] = i
+	}
+	return &unionFind{parent: parent}
+}
+
+func (u *unionFind) find(i int) int {
+	for u.parent[i] != i {
+		u.parent[i] = u.parent[u.parent[i]]
+		i = u.parent[i]
+	}
+	return i
+}
+
+func (u *unionFind) union(i, j int) {
+	ri, rj := u.find(i), u.find(j)
+	if ri == rj {
+		return
+	}
+	if ri < rj {
+		u.parent[rj] = ri
+	} else {
+		u.parent[ri] = rj
+	}
+}
@@ -0,0 +1,141 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package dedup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+const article = `The city council approved the new budget on Tuesday after a long debate about public transport,
+school funding and the renovation of the central library. The mayor said the plan balances the needs of the
+growing districts with the limits of the tax revenue, while the opposition criticized the cuts to the parks
+department and asked for an independent review of the spending on road works during the next two years.`
+
+func ids(docs []*schema.Document) []string {
+	ret := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		ret = append(ret, doc.ID)
+	}
+	return ret
+}
+
+func TestNewDeduplicator(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewDeduplicator(ctx, nil)
+	assert.NoError(t, err)
+	_, err = NewDeduplicator(ctx, &Config{Threshold: 1.5})
+	assert.EqualError(t, err, "threshold must be between 0 and 1")
+	_, err = NewDeduplicator(ctx, &Config{NumHashes: 100, Bands: 30})
+	assert.EqualError(t, err, "bands must divide num hashes")
+	_, err = NewDeduplicator(ctx, &Config{Method: 5})
+	assert.EqualError(t, err, "unknown method: 5")
+}
+
+func TestDeduplicator_Transform(t *testing.T) {
+	ctx := context.Background()
+
+	src := []*schema.Document{
+		{ID: "a", Content: article, MetaData: map[string]any{"url": "https://example.com/news/1"}},
+		// reformatted copy
+		{ID: "b", Content: strings.ToUpper(strings.ReplaceAll(article, "\n", "  ")), MetaData: map[string]any{"url": "https://example.com/news/1?ref=home"}},
+		// copy with a different footer
+		{ID: "c", Content: article + "\nShare this article. Subscribe to our newsletter.", MetaData: map[string]any{"author": "J. Doe"}},
+		{ID: "d", Content: "The weather will be sunny tomorrow, with temperatures up to twenty five degrees in the afternoon and light wind from the west."},
+		{ID: "e", Content: "  "},
+		{ID: "f", Content: "Read more"},
+		{ID: "g", Content: "read more!"},
+	}
+
+	t.Run("exact", func(t *testing.T) {
+		d, err := NewDeduplicator(ctx, &Config{Method: MethodExact})
+		assert.NoError(t, err)
+		docs, err := d.Transform(ctx, src)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "c", "d", "e", "f"}, ids(docs))
+	})
+
+	t.Run("minhash", func(t *testing.T) {
+		d, err := NewDeduplicator(ctx, &Config{})
+		assert.NoError(t, err)
+		docs, err := d.Transform(ctx, src)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "d", "e", "f"}, ids(docs))
+		assert.Same(t, src[0], docs[0])
+	})
+
+	t.Run("simhash", func(t *testing.T) {
+		d, err := NewDeduplicator(ctx, &Config{Method: MethodSimHash, Threshold: 0.9})
+		assert.NoError(t, err)
+		docs, err := d.Transform(ctx, src)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "d", "e", "f"}, ids(docs))
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		d, err := NewDeduplicator(ctx, &Config{Strategy: StrategyMerge})
+		assert.NoError(t, err)
+		docs, err := d.Transform(ctx, src)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "d", "e", "f"}, ids(docs))
+		assert.Equal(t, map[string]any{
+			"url":               "https://example.com/news/1",
+			"author":            "J. Doe",
+			MetaKeyDuplicateIDs: []string{"b", "c"},
+		}, docs[0].MetaData)
+		assert.Equal(t, []string{"g"}, docs[3].MetaData[MetaKeyDuplicateIDs])
+		// the source documents are not modified
+		assert.Equal(t, map[string]any{"url": "https://example.com/news/1"}, src[0].MetaData)
+	})
+
+	t.Run("distinct", func(t *testing.T) {
+		var docs []*schema.Document
+		for i := 0; i < 50; i++ {
+			docs = append(docs, &schema.Document{
+				ID:      fmt.Sprint(i),
+				Content: fmt.Sprintf("Release notes of version %d: fixed issue %d in the scheduler, improved the startup time by %d percent.", i, i*7, i%13),
+			})
+		}
+
+		d, err := NewDeduplicator(ctx, &Config{Threshold: 0.9})
+		assert.NoError(t, err)
+		ret, err := d.Transform(ctx, docs)
+		assert.NoError(t, err)
+		assert.Equal(t, 50, len(ret))
+	})
+}
+
+func TestSimilarity(t *testing.T) {
+	a := shingles(normalize(article), 5)
+	b := shingles(normalize(article+" Share this article."), 5)
+	c := shingles(normalize("A completely different text about gardening, tomatoes and the best time to plant them in spring."), 5)
+
+	assert.Greater(t, minHashSimilarity(minHash(a, 128), minHash(b, 128)), 0.8)
+	assert.Less(t, minHashSimilarity(minHash(a, 128), minHash(c, 128)), 0.1)
+	assert.LessOrEqual(t, hammingDistance(simHash(a), simHash(b)), 6)
+	assert.Greater(t, hammingDistance(simHash(a), simHash(c)), 10)
+}
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, []string{"hello", "world", "2025", "你", "好"}, normalize("Hello, World! (2025) 你好"))
+	assert.Empty(t, normalize(" \n-- "))
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/dedup"
+)
+
+func main() {
+	ctx := context.Background()
+
+	d, err := dedup.NewDeduplicator(ctx, &dedup.Config{
+		Method:    dedup.MethodMinHash,
+		Threshold: 0.8,
+		Strategy:  dedup.StrategyMerge,
+	})
+	if err != nil {
+		log.Fatalf("Failed to create deduplicator: %v", err)
+	}
+
+	page := "Our office is open from Monday to Friday, 9am to 6pm. For support requests, " +
+		"please use the contact form or write to the support team, we answer within one business day."
+	docs, err := d.Transform(ctx, []*schema.Document{
+		{ID: "contact", Content: page, MetaData: map[string]any{"url": "https://example.com/contact"}},
+		{ID: "contact-en", Content: page + " Follow us.", MetaData: map[string]any{"url": "https://example.com/en/contact"}},
+		{ID: "about", Content: "The company was founded in 2010 by three engineers, and builds tools for small shops."},
+	})
+	if err != nil {
+		log.Fatalf("Failed to deduplicate: %v", err)
+	}
+
+	for _, doc := range docs {
+		fmt.Println(doc.ID, doc.MetaData[dedup.MetaKeyDuplicateIDs])
+	}
+}
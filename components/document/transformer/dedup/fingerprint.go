@@ -0,0 +1,155 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package dedup
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash/fnv"
+	"math"
+	"math/bits"
+	"strings"
+	"unicode"
+)
+
+// normalize lowercases the text and returns its tokens: the words, and each CJK character on its own,
+// the punctuation and the whitespace being ignored, so that the reformatted copies of a page have the same tokens.
+func normalize(text string) []string {
+	var (
+		tokens []string
+		word   strings.Builder
+	)
+	flush := func() {
+		if word.Len() > 0 {
+			tokens = append(tokens, word.String())
+			word.Reset()
+		}
+	}
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r):
+			flush()
+			tokens = append(tokens, string(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word.WriteRune(unicode.ToLower(r))
+		default:
+			flush()
+		}
+	}
+	flush()
+
+	return tokens
+}
+
+// contentHash is the hash of the normalized content, equal for the exact duplicates.
+func contentHash(tokens []string) string {
+	h := sha256.New()
+	for _, t := range tokens {
+		h.Write([]byte(t))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// shingles returns the hashes of the distinct sequences of size consecutive tokens.
+// A text shorter than size is a single shingle.
+func shingles(tokens []string, size int) []uint64 {
+	if len(tokens) == 0 {
+		return nil
+	}
+	n := max(len(tokens)-size+1, 1)
+	seen := make(map[uint64]struct{}, n)
+	ret := make([]uint64, 0, n)
+	for i := 0; i < n; i++ {
+		h := fnv.New64a()
+		for _, t := range tokens[i:min(i+size, len(tokens))] {
+			h.Write([]byte(t))
+			h.Write([]byte{0})
+		}
+		s := h.Sum64()
+		if _, ok := seen[s]; ok {
+			continue
+		}
+		seen[s] = struct{}{}
+		ret = append(ret, s)
+	}
+
+	return ret
+}
+
+// mix is the splitmix64 finalizer, used to derive independent hash functions from the shingle hashes.
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// minHash returns the signature of the shingles: the minimum of each of the numHashes hash functions.
+// The fraction of equal values of two signatures estimates the Jaccard similarity of the shingle sets.
+func minHash(shingles []uint64, numHashes int) []uint64 {
+	sig := make([]uint64, numHashes)
+	for i := range sig {
+		sig[i] = math.MaxUint64
+	}
+	for _, s := range shingles {
+		for i := range sig {
+			if h := mix(s ^ mix(uint64(i)+1)); h < sig[i] {
+				sig[i] = h
+			}
+		}
+	}
+	return sig
+}
+
+func minHashSimilarity(a, b []uint64) float64 {
+	equal := 0
+	for i := range a {
+		if a[i] == b[i] {
+			equal++
+		}
+	}
+	return float64(equal) / float64(len(a))
+}
+
+// simHash returns the 64 bits fingerprint of the shingles, close texts having fingerprints with few different bits.
+func simHash(shingles []uint64) uint64 {
+	var weights [64]int
+	for _, s := range shingles {
+		h := mix(s)
+		for i := 0; i < 64; i++ {
+			if h&(1<<i) != 0 {
+				weights[i]++
+			} else {
+				weights[i]--
+			}
+		}
+	}
+
+	var fp uint64
+	for i, w := range weights {
+		if w > 0 {
+			fp |= 1 << i
+		}
+	}
+	return fp
+}
+
+func hammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
@@ -0,0 +1,264 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package contextual provides a document transformer implementing contextual retrieval:
+// each chunk is prefixed with a short context situating it within its parent document, written by a chat model,
+// so that the chunks such as "the revenue grew by 3%" can be found by the searches about the company and the quarter.
+package contextual
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	MetaKeyContext         = "_chunk_context"
+	MetaKeyOriginalContent = "_original_content"
+	MetaKeyTokenUsage      = "_context_token_usage"
+)
+
+const defaultDocumentPrompt = `<document>
+%s
+</document>`
+
+const defaultChunkPrompt = `Here is the chunk we want to situate within the whole document
+<chunk>
+%s
+</chunk>
+Please give a short succinct context to situate this chunk within the overall document for the purposes of improving search retrieval of the chunk. Answer only with the succinct context and nothing else.`
+
+type Config struct {
+	// ChatModel writes the context of the chunks. Required.
+	ChatModel model.BaseChatModel
+	// Splitter splits the documents passed to Transform into the chunks, each chunk being enriched with its document.
+	// Optional. Default nil, the documents passed to Transform are the chunks, and ParentContent is required.
+	Splitter document.Transformer
+	// ParentContent returns the content of the parent document of a chunk, e.g. from the store of the parsed documents.
+	// Required when Splitter is nil.
+	ParentContent func(ctx context.Context, chunk *schema.Document) (string, error)
+	// DocumentPrompt is the system message holding the parent document, its %s verb being replaced by the content.
+	// It is the same for all the chunks of a document, so that the providers caching the prompt prefixes only
+	// bill the document once.
+	// Optional. Default the document between <document> tags.
+	DocumentPrompt string
+	// ChunkPrompt is the user message asking for the context, its %s verb being replaced by the content of the chunk.
+	// Optional. Default the prompt of Anthropic's contextual retrieval.
+	ChunkPrompt string
+	// CacheControl marks the document message as cacheable, for the providers with explicit prompt caching,
+	// e.g. claude.SetMessageBreakpoint.
+	// Optional. Default nil, the providers with automatic prefix caching, such as OpenAI or DeepSeek, need nothing.
+	CacheControl func(msg *schema.Message) *schema.Message
+	// MaxDocumentLength is the maximum length in characters of the parent document sent to the chat model,
+	// longer documents are truncated, which bounds the cost of each call.
+	// Optional. Default 0, no limit.
+	MaxDocumentLength int
+	// KeepContent keeps the content of the chunks unchanged, the context only being stored at MetaKeyContext,
+	// e.g. to embed a custom combination of both.
+	// Optional. Default false, the context is prepended to the content, and the content is stored at MetaKeyOriginalContent.
+	KeepContent bool
+	// Concurrency is the number of chunks enriched at the same time.
+	// Optional. Default 1.
+	Concurrency int
+}
+
+// NewEnricher creates a document transformer enriching each chunk with the context written by the chat model.
+//
+// The first chunk of each document is enriched before the others, so that they hit the prompt cache
+// of the document it created.
+func NewEnricher(ctx context.Context, config *Config) (document.Transformer, error) {
+	if config == nil {
+		return nil, errors.New("config is required")
+	}
+	if config.ChatModel == nil {
+		return nil, errors.New("chat model is required")
+	}
+	if config.Splitter == nil && config.ParentContent == nil {
+		return nil, errors.New("splitter or parent content is required")
+	}
+	if config.MaxDocumentLength < 0 {
+		return nil, errors.New("max document length must not be negative")
+	}
+
+	documentPrompt := config.DocumentPrompt
+	if documentPrompt == "" {
+		documentPrompt = defaultDocumentPrompt
+	}
+	chunkPrompt := config.ChunkPrompt
+	if chunkPrompt == "" {
+		chunkPrompt = defaultChunkPrompt
+	}
+
+	return &enricher{
+		chatModel:         config.ChatModel,
+		splitter:          config.Splitter,
+		parentContent:     config.ParentContent,
+		documentPrompt:    documentPrompt,
+		chunkPrompt:       chunkPrompt,
+		cacheControl:      config.CacheControl,
+		maxDocumentLength: config.MaxDocumentLength,
+		keepContent:       config.KeepContent,
+		concurrency:       max(config.Concurrency, 1),
+	}, nil
+}
+
+type enricher struct {
+	chatModel         model.BaseChatModel
+	splitter          document.Transformer
+	parentContent     func(ctx context.Context, chunk *schema.Document) (string, error)
+	documentPrompt    string
+	chunkPrompt       string
+	cacheControl      func(msg *schema.Message) *schema.Message
+	maxDocumentLength int
+	keepContent       bool
+	concurrency       int
+}
+
+// item is a chunk to enrich, with the content of its parent document.
+type item struct {
+	chunk  *schema.Document
+	parent string
+}
+
+func (e *enricher) Transform(ctx context.Context, src []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	items, err := e.items(ctx, src, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// the first chunk of each document creates the prompt cache, the others are sent after it
+	var first, rest []int
+	seen := make(map[string]bool)
+	for i, it := range items {
+		if strings.TrimSpace(it.chunk.Content) == "" || strings.TrimSpace(it.chunk.Content) == strings.TrimSpace(it.parent) {
+			// nothing to situate
+			continue
+		}
+		if seen[it.parent] {
+			rest = append(rest, i)
+			continue
+		}
+		seen[it.parent] = true
+		first = append(first, i)
+	}
+
+	ret := make([]*schema.Document, len(items))
+	for i, it := range items {
+		ret[i] = it.chunk
+	}
+	errs := make([]error, len(items))
+	for _, indices := range [][]int{first, rest} {
+		sem := make(chan struct{}, e.concurrency)
+		var wg sync.WaitGroup
+		for _, i := range indices {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(i int) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				if ret[i], errs[i] = e.enrich(ctx, items[i]); errs[i] != nil {
+					errs[i] = fmt.Errorf("%w, chunk id= %s", errs[i], items[i].chunk.ID)
+				}
+			}(i)
+		}
+		wg.Wait()
+
+		if err = errors.Join(errs...); err != nil {
+			return nil, err
+		}
+	}
+
+	return ret, nil
+}
+
+func (e *enricher) GetType() string {
+	return "ContextualEnricher"
+}
+
+// items returns the chunks with the content of their parent document.
+func (e *enricher) items(ctx context.Context, src []*schema.Document, opts ...document.TransformerOption) ([]item, error) {
+	var items []item
+	if e.splitter == nil {
+		for _, chunk := range src {
+			parent, err := e.parentContent(ctx, chunk)
+			if err != nil {
+				return nil, fmt.Errorf("get parent content failed: %w, chunk id= %s", err, chunk.ID)
+			}
+			items = append(items, item{chunk: chunk, parent: parent})
+		}
+		return items, nil
+	}
+
+	for _, doc := range src {
+		chunks, err := e.splitter.Transform(ctx, []*schema.Document{doc}, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("split document failed: %w, document id= %s", err, doc.ID)
+		}
+		for _, chunk := range chunks {
+			items = append(items, item{chunk: chunk, parent: doc.Content})
+		}
+	}
+	return items, nil
+}
+
+// enrich returns a copy of the chunk with the context written by the chat model.
+func (e *enricher) enrich(ctx context.Context, it item) (*schema.Document, error) {
+	parent := it.parent
+	if e.maxDocumentLength > 0 {
+		if runes := []rune(parent); len(runes) > e.maxDocumentLength {
+			parent = string(runes[:e.maxDocumentLength])
+		}
+	}
+
+	documentMsg := schema.SystemMessage(fmt.Sprintf(e.documentPrompt, parent))
+	if e.cacheControl != nil {
+		documentMsg = e.cacheControl(documentMsg)
+	}
+	msg, err := e.chatModel.Generate(ctx, []*schema.Message{
+		documentMsg,
+		schema.UserMessage(fmt.Sprintf(e.chunkPrompt, it.chunk.Content)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("generate chunk context failed: %w", err)
+	}
+	chunkContext := strings.TrimSpace(msg.Content)
+
+	enriched := &schema.Document{
+		ID:       it.chunk.ID,
+		Content:  it.chunk.Content,
+		MetaData: make(map[string]any, len(it.chunk.MetaData)+3),
+	}
+	for k, v := range it.chunk.MetaData {
+		enriched.MetaData[k] = v
+	}
+	enriched.MetaData[MetaKeyContext] = chunkContext
+	if msg.ResponseMeta != nil && msg.ResponseMeta.Usage != nil {
+		enriched.MetaData[MetaKeyTokenUsage] = msg.ResponseMeta.Usage
+	}
+	if !e.keepContent && chunkContext != "" {
+		enriched.MetaData[MetaKeyOriginalContent] = it.chunk.Content
+		enriched.Content = chunkContext + "\n\n" + it.chunk.Content
+	}
+
+	return enriched, nil
+}
@@ -0,0 +1,161 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package contextual
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockChatModel struct {
+	mu     sync.Mutex
+	inputs [][]*schema.Message
+	err    error
+}
+
+func (m *mockChatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.inputs = append(m.inputs, input)
+	msg := schema.AssistantMessage(" This chunk is from the Q2 2025 report of ACME. \n", nil)
+	msg.ResponseMeta = &schema.ResponseMeta{Usage: &schema.TokenUsage{PromptTokens: 900, CompletionTokens: 12, TotalTokens: 912}}
+	return msg, nil
+}
+
+func (m *mockChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return nil, errors.New("not implemented")
+}
+
+// lineSplitter splits the documents into lines.
+type lineSplitter struct{}
+
+func (lineSplitter) Transform(ctx context.Context, src []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	var ret []*schema.Document
+	for _, doc := range src {
+		for _, line := range strings.Split(doc.Content, "\n") {
+			ret = append(ret, &schema.Document{ID: doc.ID, Content: line, MetaData: map[string]any{"_source": doc.MetaData["_source"]}})
+		}
+	}
+	return ret, nil
+}
+
+const report = "ACME Q2 2025 report\nRevenue grew by 3%.\nThe margin was stable."
+
+func TestNewEnricher(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewEnricher(ctx, nil)
+	assert.EqualError(t, err, "config is required")
+	_, err = NewEnricher(ctx, &Config{})
+	assert.EqualError(t, err, "chat model is required")
+	_, err = NewEnricher(ctx, &Config{ChatModel: &mockChatModel{}})
+	assert.EqualError(t, err, "splitter or parent content is required")
+}
+
+func TestEnricher_Transform(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("splitter", func(t *testing.T) {
+		cm := &mockChatModel{}
+		e, err := NewEnricher(ctx, &Config{
+			ChatModel: cm,
+			Splitter:  lineSplitter{},
+			CacheControl: func(msg *schema.Message) *schema.Message {
+				copied := *msg
+				copied.Extra = map[string]any{"cache": true}
+				return &copied
+			},
+			Concurrency: 2,
+		})
+		assert.NoError(t, err)
+
+		docs, err := e.Transform(ctx, []*schema.Document{
+			{ID: "report", Content: report, MetaData: map[string]any{"_source": "report.md"}},
+			{ID: "note", Content: "A single line note.", MetaData: map[string]any{"_source": "note.md"}},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 4, len(docs))
+
+		assert.Equal(t, "This chunk is from the Q2 2025 report of ACME.\n\nRevenue grew by 3%.", docs[1].Content)
+		assert.Equal(t, "report", docs[1].ID)
+		assert.Equal(t, "report.md", docs[1].MetaData["_source"])
+		assert.Equal(t, "This chunk is from the Q2 2025 report of ACME.", docs[1].MetaData[MetaKeyContext])
+		assert.Equal(t, "Revenue grew by 3%.", docs[1].MetaData[MetaKeyOriginalContent])
+		assert.Equal(t, 912, docs[1].MetaData[MetaKeyTokenUsage].(*schema.TokenUsage).TotalTokens)
+
+		// a document made of a single chunk has nothing to situate
+		assert.Equal(t, "A single line note.", docs[3].Content)
+		assert.Nil(t, docs[3].MetaData[MetaKeyContext])
+
+		assert.Equal(t, 3, len(cm.inputs))
+		// the first chunk is sent first, to create the prompt cache
+		assert.Contains(t, cm.inputs[0][1].Content, "<chunk>\nACME Q2 2025 report\n</chunk>")
+		for _, input := range cm.inputs {
+			assert.Equal(t, schema.System, input[0].Role)
+			assert.Equal(t, "<document>\n"+report+"\n</document>", input[0].Content)
+			assert.Equal(t, true, input[0].Extra["cache"])
+			assert.Equal(t, schema.User, input[1].Role)
+		}
+	})
+
+	t.Run("parent content", func(t *testing.T) {
+		cm := &mockChatModel{}
+		e, err := NewEnricher(ctx, &Config{
+			ChatModel: cm,
+			ParentContent: func(ctx context.Context, chunk *schema.Document) (string, error) {
+				if chunk.ID == "missing" {
+					return "", errors.New("not found")
+				}
+				return report, nil
+			},
+			DocumentPrompt:    "Document: %s",
+			ChunkPrompt:       "Situate: %s",
+			MaxDocumentLength: 4,
+			KeepContent:       true,
+		})
+		assert.NoError(t, err)
+
+		docs, err := e.Transform(ctx, []*schema.Document{{ID: "1", Content: "Revenue grew by 3%."}})
+		assert.NoError(t, err)
+		assert.Equal(t, "Revenue grew by 3%.", docs[0].Content)
+		assert.Equal(t, "This chunk is from the Q2 2025 report of ACME.", docs[0].MetaData[MetaKeyContext])
+		assert.Nil(t, docs[0].MetaData[MetaKeyOriginalContent])
+		assert.Equal(t, "Document: ACME", cm.inputs[0][0].Content)
+		assert.Equal(t, "Situate: Revenue grew by 3%.", cm.inputs[0][1].Content)
+
+		_, err = e.Transform(ctx, []*schema.Document{{ID: "missing", Content: "..."}})
+		assert.EqualError(t, err, "get parent content failed: not found, chunk id= missing")
+	})
+
+	t.Run("chat model error", func(t *testing.T) {
+		e, err := NewEnricher(ctx, &Config{ChatModel: &mockChatModel{err: errors.New("mock error")}, Splitter: lineSplitter{}})
+		assert.NoError(t, err)
+
+		_, err = e.Transform(ctx, []*schema.Document{{ID: "report", Content: report}})
+		assert.EqualError(t, err, "generate chunk context failed: mock error, chunk id= report")
+	})
+}
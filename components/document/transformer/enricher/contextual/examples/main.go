@@ -0,0 +1,69 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/enricher/contextual"
+)
+
+func main() {
+	ctx := context.Background()
+
+	var chatModel model.BaseChatModel
+	// chatModel, err := claude.NewChatModel(ctx, &claude.Config{
+	// 	APIKey: os.Getenv("CLAUDE_API_KEY"),
+	// 	Model:  "claude-3-5-haiku-latest",
+	// })
+	// ...
+
+	// the parsed documents, by source, e.g. kept by the indexing pipeline
+	parents := map[string]string{
+		"report.md": "# ACME Q2 2025 report\n\nRevenue grew by 3% compared to the previous quarter.\n\nThe margin was stable.",
+	}
+
+	enricher, err := contextual.NewEnricher(ctx, &contextual.Config{
+		ChatModel: chatModel,
+		ParentContent: func(ctx context.Context, chunk *schema.Document) (string, error) {
+			return parents[chunk.MetaData["_source"].(string)], nil
+		},
+		// with claude, mark the document as cacheable:
+		// CacheControl: claude.SetMessageBreakpoint,
+		Concurrency: 4,
+	})
+	if err != nil {
+		log.Fatalf("Failed to create enricher: %v", err)
+	}
+
+	chunks, err := enricher.Transform(ctx, []*schema.Document{
+		{ID: "report_1", Content: "Revenue grew by 3% compared to the previous quarter.", MetaData: map[string]any{"_source": "report.md"}},
+		{ID: "report_2", Content: "The margin was stable.", MetaData: map[string]any{"_source": "report.md"}},
+	})
+	if err != nil {
+		log.Fatalf("Failed to enrich chunks: %v", err)
+	}
+
+	for _, chunk := range chunks {
+		fmt.Println(chunk.Content)
+		fmt.Println()
+	}
+}
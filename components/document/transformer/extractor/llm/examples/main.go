@@ -0,0 +1,72 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/extractor/llm"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// the chat model, which must support tool calling
+	var chatModel model.ToolCallingChatModel
+	// chatModel, err := openai.NewChatModel(ctx, &openai.ChatModelConfig{
+	// 	APIKey: os.Getenv("OPENAI_API_KEY"),
+	// 	Model:  "gpt-4o-mini",
+	// })
+	// ...
+
+	extractor, err := llm.NewExtractor(ctx, &llm.Config{
+		ChatModel: chatModel,
+		Fields: []*llm.Field{
+			llm.TitleField(),
+			llm.SummaryField(),
+			llm.KeywordsField(),
+			{
+				Name:        "product",
+				Description: "the product the document is about",
+				Enum:        []string{"storage", "compute", "network"},
+			},
+		},
+		Concurrency:      4,
+		MaxContentLength: 8000,
+		MaxTotalTokens:   200000,
+		SkipExisting:     true,
+	})
+	if err != nil {
+		log.Fatalf("Failed to create extractor: %v", err)
+	}
+
+	docs, err := extractor.Transform(ctx, []*schema.Document{
+		{ID: "1", Content: "To create a bucket, open the object storage console and click Create Bucket..."},
+		{ID: "2", Content: "Virtual machines can be resized after they are stopped..."},
+	})
+	if err != nil {
+		log.Fatalf("Failed to extract metadata: %v", err)
+	}
+
+	for _, doc := range docs {
+		fmt.Printf("%s: %v, %v, product= %v\n", doc.ID, doc.MetaData[llm.MetaKeyTitle], doc.MetaData[llm.MetaKeyKeywords], doc.MetaData["product"])
+	}
+}
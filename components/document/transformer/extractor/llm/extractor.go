@@ -0,0 +1,297 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package llm provides a document transformer extracting information such as titles, summaries, keywords
+// or custom fields from each document into its metadata with a chat model.
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+const MetaKeyTokenUsage = "_extract_token_usage"
+
+const (
+	toolName = "save_document_metadata"
+	toolDesc = "Save the information extracted from the document."
+)
+
+const defaultPrompt = `You extract information from documents for a search index.
+Read the document sent by the user, and call the save_document_metadata tool with the information extracted from it.
+Only use information found in the document, and leave out the optional fields the document does not have.`
+
+// Config is the configuration for the llm extractor.
+type Config struct {
+	// ChatModel is the chat model extracting the fields. It must support tool calling,
+	// the fields being requested as the arguments of a tool call, which is how most providers implement structured output.
+	// Required.
+	ChatModel model.ToolCallingChatModel
+	// Fields are the information extracted from each document, e.g. TitleField(), SummaryField(), or custom fields.
+	// Required.
+	Fields []*Field
+	// Prompt is the system prompt of the chat model, e.g. to describe the corpus or the language of the answers.
+	// Optional. Default a prompt asking to call the tool with the information found in the document.
+	Prompt string
+	// Concurrency is the number of documents processed at the same time.
+	// Optional. Default 1.
+	Concurrency int
+	// MaxContentLength is the maximum length in characters of the content sent to the chat model,
+	// longer documents are truncated, which bounds the cost of each call.
+	// Optional. Default 0, no limit.
+	MaxContentLength int
+	// MaxTotalTokens is the token budget of a Transform call. Once the chat model used this many tokens,
+	// the remaining documents are returned without being processed.
+	// As the documents are processed concurrently, the budget can be exceeded by the calls in flight.
+	// Optional. Default 0, no limit.
+	MaxTotalTokens int
+	// SkipExisting skips the documents whose metadata already has all the fields, e.g. when re-indexing a corpus.
+	// Optional. Default false.
+	SkipExisting bool
+	// Filter selects the documents to process, the others are returned unchanged.
+	// Optional. Default all the documents are processed.
+	Filter func(doc *schema.Document) bool
+}
+
+// NewExtractor creates a document transformer which calls the chat model with each document,
+// and stores the values of the fields it extracted in the metadata of the document.
+func NewExtractor(ctx context.Context, config *Config) (document.Transformer, error) {
+	if config == nil {
+		return nil, errors.New("config is required")
+	}
+	if config.ChatModel == nil {
+		return nil, errors.New("chat model is required")
+	}
+	if len(config.Fields) == 0 {
+		return nil, errors.New("at least one field is required")
+	}
+	if config.MaxContentLength < 0 {
+		return nil, errors.New("max content length must not be negative")
+	}
+	if config.MaxTotalTokens < 0 {
+		return nil, errors.New("max total tokens must not be negative")
+	}
+
+	params := make(map[string]*schema.ParameterInfo, len(config.Fields))
+	for _, f := range config.Fields {
+		if err := f.check(); err != nil {
+			return nil, err
+		}
+		if _, ok := params[f.Name]; ok {
+			return nil, fmt.Errorf("duplicate field name: %s", f.Name)
+		}
+		params[f.Name] = f.paramInfo()
+	}
+
+	cm, err := config.ChatModel.WithTools([]*schema.ToolInfo{{
+		Name:        toolName,
+		Desc:        toolDesc,
+		ParamsOneOf: schema.NewParamsOneOfByParams(params),
+	}})
+	if err != nil {
+		return nil, fmt.Errorf("bind extraction tool failed: %w", err)
+	}
+
+	prompt := config.Prompt
+	if prompt == "" {
+		prompt = defaultPrompt
+	}
+
+	return &extractor{
+		chatModel:        cm,
+		fields:           config.Fields,
+		prompt:           prompt,
+		concurrency:      max(config.Concurrency, 1),
+		maxContentLength: config.MaxContentLength,
+		maxTotalTokens:   config.MaxTotalTokens,
+		skipExisting:     config.SkipExisting,
+		filter:           config.Filter,
+	}, nil
+}
+
+type extractor struct {
+	chatModel        model.ToolCallingChatModel
+	fields           []*Field
+	prompt           string
+	concurrency      int
+	maxContentLength int
+	maxTotalTokens   int
+	skipExisting     bool
+	filter           func(doc *schema.Document) bool
+}
+
+// Transform returns copies of the documents with the extracted fields added to the metadata.
+// The documents which are filtered out, skipped or over the token budget are returned unchanged.
+func (e *extractor) Transform(ctx context.Context, src []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	ret := make([]*schema.Document, len(src))
+	errs := make([]error, len(src))
+	sem := make(chan struct{}, e.concurrency)
+	var (
+		wg         sync.WaitGroup
+		usedTokens atomic.Int64
+	)
+	for i, doc := range src {
+		ret[i] = doc
+		if doc == nil || !e.selected(doc) {
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, doc *schema.Document) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if e.maxTotalTokens > 0 && usedTokens.Load() >= int64(e.maxTotalTokens) {
+				return
+			}
+			if err := ctx.Err(); err != nil {
+				errs[i] = err
+				return
+			}
+
+			meta, usage, err := e.extract(ctx, doc)
+			if usage != nil {
+				usedTokens.Add(int64(usage.TotalTokens))
+			}
+			if err != nil {
+				errs[i] = fmt.Errorf("%w, document id= %s", err, doc.ID)
+				return
+			}
+
+			copied := &schema.Document{
+				ID:       doc.ID,
+				Content:  doc.Content,
+				MetaData: make(map[string]any, len(doc.MetaData)+len(meta)),
+			}
+			for k, v := range doc.MetaData {
+				copied.MetaData[k] = v
+			}
+			for k, v := range meta {
+				copied.MetaData[k] = v
+			}
+			ret[i] = copied
+		}(i, doc)
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (e *extractor) GetType() string {
+	return "LLMExtractor"
+}
+
+func (e *extractor) selected(doc *schema.Document) bool {
+	if strings.TrimSpace(doc.Content) == "" {
+		return false
+	}
+	if e.filter != nil && !e.filter(doc) {
+		return false
+	}
+	if !e.skipExisting {
+		return true
+	}
+	for _, f := range e.fields {
+		if _, ok := doc.MetaData[f.metaKey()]; !ok {
+			return true
+		}
+	}
+	return false
+}
+
+// extract calls the chat model with the document, and returns the metadata to add to it.
+func (e *extractor) extract(ctx context.Context, doc *schema.Document) (map[string]any, *schema.TokenUsage, error) {
+	content := doc.Content
+	if e.maxContentLength > 0 {
+		if runes := []rune(content); len(runes) > e.maxContentLength {
+			content = string(runes[:e.maxContentLength])
+		}
+	}
+
+	msg, err := e.chatModel.Generate(ctx, []*schema.Message{
+		schema.SystemMessage(e.prompt),
+		schema.UserMessage(content),
+	}, model.WithToolChoice(schema.ToolChoiceForced))
+	if err != nil {
+		return nil, nil, fmt.Errorf("extract metadata failed: %w", err)
+	}
+
+	var usage *schema.TokenUsage
+	if msg.ResponseMeta != nil {
+		usage = msg.ResponseMeta.Usage
+	}
+
+	args, err := arguments(msg)
+	if err != nil {
+		return nil, usage, err
+	}
+
+	var values map[string]json.RawMessage
+	if err = json.Unmarshal([]byte(args), &values); err != nil {
+		return nil, usage, fmt.Errorf("unmarshal extracted metadata failed: %w, arguments= %s", err, args)
+	}
+
+	meta := make(map[string]any, len(e.fields)+1)
+	for _, f := range e.fields {
+		v, ok, err := f.value(values[f.Name])
+		if err != nil {
+			return nil, usage, fmt.Errorf("invalid value of field %s: %w", f.Name, err)
+		}
+		if ok {
+			meta[f.metaKey()] = v
+		}
+	}
+	if usage != nil {
+		meta[MetaKeyTokenUsage] = usage
+	}
+
+	return meta, usage, nil
+}
+
+// arguments returns the arguments of the tool call, or the content for the models answering with the JSON directly.
+func arguments(msg *schema.Message) (string, error) {
+	for _, tc := range msg.ToolCalls {
+		if tc.Function.Name == toolName {
+			return tc.Function.Arguments, nil
+		}
+	}
+
+	content := strings.TrimSpace(msg.Content)
+	if strings.HasPrefix(content, "```") {
+		content = strings.TrimPrefix(content, "```json")
+		content = strings.Trim(content, "`\n ")
+	}
+	if strings.HasPrefix(content, "{") {
+		return content, nil
+	}
+
+	return "", fmt.Errorf("chat model did not call the extraction tool, content= %s", msg.Content)
+}
@@ -0,0 +1,239 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package llm
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockChatModel struct {
+	mu     sync.Mutex
+	tools  []*schema.ToolInfo
+	inputs [][]*schema.Message
+	opts   []*model.Options
+	answer func(content string) *schema.Message
+}
+
+func (m *mockChatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	m.mu.Lock()
+	m.inputs = append(m.inputs, input)
+	m.opts = append(m.opts, model.GetCommonOptions(nil, opts...))
+	m.mu.Unlock()
+
+	msg := m.answer(input[len(input)-1].Content)
+	if msg == nil {
+		return nil, errors.New("mock error")
+	}
+	return msg, nil
+}
+
+func (m *mockChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockChatModel) WithTools(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	m.tools = tools
+	return m, nil
+}
+
+func toolCall(args string, tokens int) *schema.Message {
+	msg := schema.AssistantMessage("", []schema.ToolCall{{
+		ID:       "call_1",
+		Function: schema.FunctionCall{Name: toolName, Arguments: args},
+	}})
+	msg.ResponseMeta = &schema.ResponseMeta{Usage: &schema.TokenUsage{TotalTokens: tokens}}
+	return msg
+}
+
+func TestNewExtractor(t *testing.T) {
+	ctx := context.Background()
+	cm := &mockChatModel{}
+
+	_, err := NewExtractor(ctx, nil)
+	assert.EqualError(t, err, "config is required")
+	_, err = NewExtractor(ctx, &Config{Fields: []*Field{TitleField()}})
+	assert.EqualError(t, err, "chat model is required")
+	_, err = NewExtractor(ctx, &Config{ChatModel: cm})
+	assert.EqualError(t, err, "at least one field is required")
+	_, err = NewExtractor(ctx, &Config{ChatModel: cm, Fields: []*Field{{Name: "a"}}})
+	assert.EqualError(t, err, "field description is required, field= a")
+	_, err = NewExtractor(ctx, &Config{ChatModel: cm, Fields: []*Field{{Name: "a", Description: "a", Type: schema.Object}}})
+	assert.EqualError(t, err, "unsupported field type: object, field= a")
+	_, err = NewExtractor(ctx, &Config{ChatModel: cm, Fields: []*Field{TitleField(), TitleField()}})
+	assert.EqualError(t, err, "duplicate field name: title")
+
+	_, err = NewExtractor(ctx, &Config{ChatModel: cm, Fields: []*Field{
+		TitleField(),
+		KeywordsField(),
+		{Name: "category", Description: "category of the document", Enum: []string{"faq", "guide"}},
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(cm.tools))
+	assert.Equal(t, toolName, cm.tools[0].Name)
+	js, err := cm.tools[0].ParamsOneOf.ToOpenAPIV3()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"keywords", "title"}, js.Required)
+	assert.Equal(t, "array", js.Properties["keywords"].Value.Type)
+	assert.Equal(t, "string", js.Properties["keywords"].Value.Items.Value.Type)
+	assert.Equal(t, []any{"faq", "guide"}, js.Properties["category"].Value.Enum)
+}
+
+func TestExtractor_Transform(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("fields", func(t *testing.T) {
+		cm := &mockChatModel{answer: func(content string) *schema.Message {
+			return toolCall(`{"title":"Reset your password","summary":"","keywords":["password","account"],"pages":2.0,"public":true,"entities":null}`, 120)
+		}}
+		e, err := NewExtractor(ctx, &Config{
+			ChatModel: cm,
+			Fields: []*Field{
+				TitleField(),
+				SummaryField(),
+				KeywordsField(),
+				EntitiesField(),
+				{Name: "pages", Description: "number of pages", Type: schema.Integer},
+				{Name: "public", Description: "whether the document is public", Type: schema.Boolean, MetaKey: "is_public"},
+			},
+		})
+		assert.NoError(t, err)
+
+		src := []*schema.Document{{ID: "1", Content: "To reset your password...", MetaData: map[string]any{"_source": "faq.md"}}}
+		docs, err := e.Transform(ctx, src)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(docs))
+		assert.Equal(t, "1", docs[0].ID)
+		assert.Equal(t, "To reset your password...", docs[0].Content)
+		assert.Equal(t, map[string]any{
+			"_source":         "faq.md",
+			MetaKeyTitle:      "Reset your password",
+			MetaKeyKeywords:   []string{"password", "account"},
+			"pages":           2,
+			"is_public":       true,
+			MetaKeyTokenUsage: &schema.TokenUsage{TotalTokens: 120},
+		}, docs[0].MetaData)
+		// the source documents are not modified
+		assert.Equal(t, map[string]any{"_source": "faq.md"}, src[0].MetaData)
+
+		assert.Equal(t, 1, len(cm.inputs))
+		assert.Equal(t, schema.System, cm.inputs[0][0].Role)
+		assert.Equal(t, defaultPrompt, cm.inputs[0][0].Content)
+		assert.Equal(t, "To reset your password...", cm.inputs[0][1].Content)
+		assert.Equal(t, schema.ToolChoiceForced, *cm.opts[0].ToolChoice)
+	})
+
+	t.Run("json content", func(t *testing.T) {
+		cm := &mockChatModel{answer: func(content string) *schema.Message {
+			return schema.AssistantMessage("```json\n{\"title\":\"Pricing\"}\n```", nil)
+		}}
+		e, err := NewExtractor(ctx, &Config{ChatModel: cm, Fields: []*Field{TitleField()}})
+		assert.NoError(t, err)
+
+		docs, err := e.Transform(ctx, []*schema.Document{{Content: "Our plans..."}})
+		assert.NoError(t, err)
+		assert.Equal(t, "Pricing", docs[0].MetaData[MetaKeyTitle])
+	})
+
+	t.Run("no tool call", func(t *testing.T) {
+		cm := &mockChatModel{answer: func(content string) *schema.Message {
+			return schema.AssistantMessage("I can't help with that.", nil)
+		}}
+		e, err := NewExtractor(ctx, &Config{ChatModel: cm, Fields: []*Field{TitleField()}})
+		assert.NoError(t, err)
+
+		_, err = e.Transform(ctx, []*schema.Document{{ID: "1", Content: "..."}})
+		assert.EqualError(t, err, "chat model did not call the extraction tool, content= I can't help with that., document id= 1")
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		cm := &mockChatModel{answer: func(content string) *schema.Message {
+			return toolCall(`{"keywords":"a, b"}`, 10)
+		}}
+		e, err := NewExtractor(ctx, &Config{ChatModel: cm, Fields: []*Field{KeywordsField()}})
+		assert.NoError(t, err)
+
+		_, err = e.Transform(ctx, []*schema.Document{{ID: "1", Content: "..."}})
+		assert.ErrorContains(t, err, "invalid value of field keywords")
+	})
+
+	t.Run("chat model error", func(t *testing.T) {
+		cm := &mockChatModel{answer: func(content string) *schema.Message {
+			if content == "b" {
+				return nil
+			}
+			return toolCall(`{"title":"a"}`, 10)
+		}}
+		e, err := NewExtractor(ctx, &Config{ChatModel: cm, Fields: []*Field{TitleField()}, Concurrency: 2})
+		assert.NoError(t, err)
+
+		_, err = e.Transform(ctx, []*schema.Document{{ID: "1", Content: "a"}, {ID: "2", Content: "b"}})
+		assert.EqualError(t, err, "extract metadata failed: mock error, document id= 2")
+	})
+
+	t.Run("selection", func(t *testing.T) {
+		cm := &mockChatModel{answer: func(content string) *schema.Message {
+			return toolCall(`{"title":"`+content+`"}`, 10)
+		}}
+		e, err := NewExtractor(ctx, &Config{
+			ChatModel:        cm,
+			Fields:           []*Field{TitleField()},
+			SkipExisting:     true,
+			MaxContentLength: 3,
+			Filter: func(doc *schema.Document) bool {
+				return doc.MetaData["lang"] != "fr"
+			},
+		})
+		assert.NoError(t, err)
+
+		src := []*schema.Document{
+			{ID: "1", Content: "Guide d'installation", MetaData: map[string]any{"lang": "fr"}},
+			{ID: "2", Content: "Installation guide", MetaData: map[string]any{MetaKeyTitle: "Install"}},
+			{ID: "3", Content: " \n"},
+			{ID: "4", Content: "安装指南"},
+		}
+		docs, err := e.Transform(ctx, src)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, len(docs))
+		for i := 0; i < 3; i++ {
+			assert.Same(t, src[i], docs[i])
+		}
+		assert.Equal(t, "安装指", docs[3].MetaData[MetaKeyTitle])
+		assert.Equal(t, 1, len(cm.inputs))
+	})
+
+	t.Run("token budget", func(t *testing.T) {
+		cm := &mockChatModel{answer: func(content string) *schema.Message {
+			return toolCall(`{"title":"`+content+`"}`, 60)
+		}}
+		e, err := NewExtractor(ctx, &Config{ChatModel: cm, Fields: []*Field{TitleField()}, MaxTotalTokens: 100})
+		assert.NoError(t, err)
+
+		docs, err := e.Transform(ctx, []*schema.Document{{Content: "a"}, {Content: "b"}, {Content: "c"}})
+		assert.NoError(t, err)
+		assert.Equal(t, "a", docs[0].MetaData[MetaKeyTitle])
+		assert.Equal(t, "b", docs[1].MetaData[MetaKeyTitle])
+		assert.Nil(t, docs[2].MetaData)
+		assert.Equal(t, 2, len(cm.inputs))
+	})
+}
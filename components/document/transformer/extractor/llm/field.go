@@ -0,0 +1,201 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package llm
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	MetaKeyTitle    = "_title"
+	MetaKeySummary  = "_summary"
+	MetaKeyKeywords = "_keywords"
+	MetaKeyEntities = "_entities"
+)
+
+// Field is a piece of information the chat model extracts from each document into its metadata.
+type Field struct {
+	// Name is the name of the field in the output schema of the chat model. Required.
+	Name string
+	// Description tells the chat model what to extract. Required.
+	Description string
+	// Type is the type of the value: schema.String, schema.Integer, schema.Number, schema.Boolean or schema.Array.
+	// Optional. Default schema.String.
+	Type schema.DataType
+	// ElemType is the type of the elements, when Type is schema.Array.
+	// Optional. Default schema.String.
+	ElemType schema.DataType
+	// Enum restricts the values of a string field, or of the elements of an array of strings.
+	// Optional.
+	Enum []string
+	// Required forces the chat model to always fill the field.
+	// Optional. Default false, the field is missing from the metadata when the document does not have the information.
+	Required bool
+	// MetaKey is the metadata key the value is stored at.
+	// Optional. Default Name.
+	MetaKey string
+}
+
+// TitleField extracts a short title of the document, stored at MetaKeyTitle.
+func TitleField() *Field {
+	return &Field{
+		Name:        "title",
+		Description: "a short and descriptive title of the document, in the language of the document",
+		Required:    true,
+		MetaKey:     MetaKeyTitle,
+	}
+}
+
+// SummaryField extracts a summary of the document, stored at MetaKeySummary.
+func SummaryField() *Field {
+	return &Field{
+		Name:        "summary",
+		Description: "a summary of the document in 1 to 3 sentences, in the language of the document",
+		Required:    true,
+		MetaKey:     MetaKeySummary,
+	}
+}
+
+// KeywordsField extracts the keywords of the document as []string, stored at MetaKeyKeywords.
+func KeywordsField() *Field {
+	return &Field{
+		Name:        "keywords",
+		Description: "up to 10 keywords of the document, most relevant first",
+		Type:        schema.Array,
+		Required:    true,
+		MetaKey:     MetaKeyKeywords,
+	}
+}
+
+// EntitiesField extracts the named entities of the document as []string, stored at MetaKeyEntities.
+func EntitiesField() *Field {
+	return &Field{
+		Name:        "entities",
+		Description: "the named entities mentioned in the document, such as people, organizations, places and products, without duplicates",
+		Type:        schema.Array,
+		MetaKey:     MetaKeyEntities,
+	}
+}
+
+func (f *Field) check() error {
+	if f.Name == "" {
+		return fmt.Errorf("field name is required")
+	}
+	if f.Description == "" {
+		return fmt.Errorf("field description is required, field= %s", f.Name)
+	}
+	switch f.Type {
+	case "", schema.String, schema.Integer, schema.Number, schema.Boolean:
+	case schema.Array:
+		switch f.ElemType {
+		case "", schema.String, schema.Integer, schema.Number, schema.Boolean:
+		default:
+			return fmt.Errorf("unsupported field element type: %s, field= %s", f.ElemType, f.Name)
+		}
+	default:
+		return fmt.Errorf("unsupported field type: %s, field= %s", f.Type, f.Name)
+	}
+
+	return nil
+}
+
+func (f *Field) metaKey() string {
+	if f.MetaKey != "" {
+		return f.MetaKey
+	}
+	return f.Name
+}
+
+func (f *Field) paramInfo() *schema.ParameterInfo {
+	typ := f.Type
+	if typ == "" {
+		typ = schema.String
+	}
+	if typ != schema.Array {
+		return &schema.ParameterInfo{Type: typ, Desc: f.Description, Enum: f.Enum, Required: f.Required}
+	}
+
+	elemType := f.ElemType
+	if elemType == "" {
+		elemType = schema.String
+	}
+	return &schema.ParameterInfo{
+		Type:     schema.Array,
+		Desc:     f.Description,
+		ElemInfo: &schema.ParameterInfo{Type: elemType, Enum: f.Enum},
+		Required: f.Required,
+	}
+}
+
+// value converts the raw JSON value the chat model returned to the Go type of the field:
+// string, int, float64, bool, or a slice of them for arrays.
+// ok is false when the value is null or empty, so that the field is left out of the metadata.
+func (f *Field) value(raw json.RawMessage) (v any, ok bool, err error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, false, nil
+	}
+
+	switch f.Type {
+	case schema.Array:
+		switch f.ElemType {
+		case schema.Integer:
+			return decodeSlice[int](raw)
+		case schema.Number:
+			return decodeSlice[float64](raw)
+		case schema.Boolean:
+			return decodeSlice[bool](raw)
+		default:
+			return decodeSlice[string](raw)
+		}
+	case schema.Integer:
+		// models occasionally answer 3.0 for an integer
+		var n float64
+		if err = json.Unmarshal(raw, &n); err != nil {
+			return nil, false, err
+		}
+		return int(n), true, nil
+	case schema.Number:
+		return decode[float64](raw)
+	case schema.Boolean:
+		return decode[bool](raw)
+	default:
+		s, ok, err := decode[string](raw)
+		if err != nil || s == "" {
+			return nil, false, err
+		}
+		return s, ok, nil
+	}
+}
+
+func decode[T any](raw json.RawMessage) (T, bool, error) {
+	var v T
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return v, false, err
+	}
+	return v, true, nil
+}
+
+func decodeSlice[T any](raw json.RawMessage) (any, bool, error) {
+	var v []T
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, false, err
+	}
+	return v, len(v) > 0, nil
+}
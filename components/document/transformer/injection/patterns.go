@@ -0,0 +1,79 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package injection
+
+import "regexp"
+
+// Pattern is a heuristic of the detector, a passage matching it scoring Weight.
+type Pattern struct {
+	// Name is reported in the reasons of the flagged passages.
+	Name string
+	// Expr is a regular expression in the syntax of the regexp package, matched case-insensitively.
+	Expr string
+	// Weight is between 0 and 1, the weights of the patterns matched by a passage being combined as
+	// independent probabilities: 1 - (1-w1)(1-w2)...
+	Weight float64
+}
+
+// DefaultPatterns are the heuristics used unless Config.DisableDefaultPatterns is set.
+var DefaultPatterns = []Pattern{
+	{
+		Name:   "ignore_instructions",
+		Expr:   `\b(ignore|disregard|forget|override|bypass)\s+(all\s+|any\s+|the\s+|your\s+|these\s+|of\s+)*(previous|prior|above|earlier|preceding|system|original|existing)\s+(instructions?|prompts?|rules|directions|guidelines|context|messages?)`,
+		Weight: 0.9,
+	},
+	{
+		Name:   "reveal_prompt",
+		Expr:   `\b(reveal|print|show|repeat|output|leak|disclose)\s+(me\s+)?(your|the)\s+(system\s+prompt|initial\s+(prompt|instructions)|hidden\s+instructions|instructions)`,
+		Weight: 0.8,
+	},
+	{
+		Name:   "role_markers",
+		Expr:   `<\|im_start\|>|<\|im_end\|>|<\|system\|>|\[/?INST\]|<</?SYS>>|(?m:^\s*#{0,3}\s*(system|assistant)\s*:)`,
+		Weight: 0.8,
+	},
+	{
+		Name:   "new_instructions",
+		Expr:   `\b(new|updated|real|actual|additional)\s+instructions?\s*:|\bfrom\s+now\s+on,?\s+(you|the\s+assistant)\s+(will|must|shall|are|should)`,
+		Weight: 0.7,
+	},
+	{
+		Name:   "role_play",
+		Expr:   `\byou\s+are\s+now\s+(a|an|the|in)\b|\b(developer|god|jailbreak)\s+mode\b|\bno\s+(longer\s+)?(bound|restricted)\s+by\b|\bwithout\s+any\s+(restrictions|filters|rules)\b`,
+		Weight: 0.6,
+	},
+	{
+		Name:   "exfiltration",
+		Expr:   `\b(send|post|forward|upload|email|exfiltrate)\s+.{0,40}\b(conversation|chat\s+history|api\s+keys?|credentials|passwords?|secrets?|tokens?)\b.{0,20}\bto\b|!\[[^\]]*\]\(https?://[^)\s]*\?[^)\s]*=`,
+		Weight: 0.6,
+	},
+	{
+		Name:   "conceal",
+		Expr:   `\b(do\s+not|don't|never)\s+(tell|inform|mention|reveal|show)\s+(this\s+|it\s+)?(to\s+)?the\s+user\b`,
+		Weight: 0.4,
+	},
+	{
+		Name:   "address_assistant",
+		Expr:   `\b(attention|note|important|message|instructions?)\s*(to|for)\s+(the\s+|any\s+)?(ai|assistant|llm|language\s+model|chatbot|agent)\b`,
+		Weight: 0.4,
+	},
+}
+
+// hiddenChars are zero width and bidirectional control characters, used to hide instructions from human readers
+// or to break the patterns, removed before matching and scored as hiddenCharsWeight.
+var hiddenChars = regexp.MustCompile("[\u200b\u200c\u200d\u2060\ufeff\u202a-\u202e\u2066-\u2069]")
+
+const hiddenCharsWeight = 0.3
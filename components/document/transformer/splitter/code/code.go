@@ -0,0 +1,332 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package code
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/code/internal/provenance"
+)
+
+const (
+	// MetaKeyLanguage is the metadata key of the language of a chunk, absent if the language is unknown.
+	MetaKeyLanguage = "_code_language"
+	// MetaKeySymbols is the metadata key of the symbols defined in a chunk, []string,
+	// methods qualified with their class or receiver type, e.g. Server.Start.
+	MetaKeySymbols = "_code_symbols"
+	// MetaKeyStartLine is the metadata key of the 1-based line of the source the chunk starts at.
+	MetaKeyStartLine = "_code_start_line"
+	// MetaKeyEndLine is the metadata key of the 1-based line of the source the chunk ends at.
+	MetaKeyEndLine = "_code_end_line"
+
+	metaKeySource = "_source"
+)
+
+// IDGenerator generates new IDs for split chunks
+type IDGenerator func(ctx context.Context, originalID string, splitIndex int) string
+
+// defaultIDGenerator keeps the original ID
+func defaultIDGenerator(ctx context.Context, originalID string, _ int) string {
+	return originalID
+}
+
+type Config struct {
+	// ChunkSize is the maximum size of a chunk measured by LenFunc. Required.
+	// Definitions larger than ChunkSize are split into their members, e.g. a class into its methods,
+	// or into lines if they have no members.
+	ChunkSize int
+	// LenFunc is used to calculate string length. Use builtin function len() by default.
+	LenFunc func(string) int
+	// Language of the source code. If empty, it's detected from the extension of the _source metadata
+	// of each document, i.e. the uri the document is loaded from.
+	// Documents of unknown language are split into lines.
+	Language Language
+	// IDGenerator is an optional function to generate new IDs for split chunks.
+	// If nil, the original document ID will be used for all splits.
+	IDGenerator IDGenerator
+}
+
+// NewSplitter creates a source code splitter cutting chunks at the boundaries of functions, classes and types.
+func NewSplitter(ctx context.Context, config *Config) (document.Transformer, error) {
+	if config == nil {
+		return nil, fmt.Errorf("new code splitter, config is nil")
+	}
+	if config.ChunkSize <= 0 {
+		return nil, fmt.Errorf("chunk size must be greater than zero")
+	}
+	if config.Language != "" && languages[config.Language] == nil {
+		return nil, fmt.Errorf("unsupported language: %s", config.Language)
+	}
+
+	lenFunc := config.LenFunc
+	if lenFunc == nil {
+		lenFunc = func(s string) int { return len(s) }
+	}
+	idGenerator := config.IDGenerator
+	if idGenerator == nil {
+		idGenerator = defaultIDGenerator
+	}
+
+	return &splitter{
+		chunkSize:   config.ChunkSize,
+		lenFunc:     lenFunc,
+		language:    config.Language,
+		idGenerator: idGenerator,
+	}, nil
+}
+
+type splitter struct {
+	chunkSize   int
+	lenFunc     func(string) int
+	language    Language
+	idGenerator IDGenerator
+}
+
+// unit is a range of the source, the chunks are made of consecutive units.
+type unit struct {
+	start, end uint32
+	symbols    []string
+}
+
+func (s *splitter) Transform(ctx context.Context, docs []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	ret := make([]*schema.Document, 0, len(docs))
+	for _, doc := range docs {
+		lang := s.language
+		if lang == "" {
+			source, _ := doc.MetaData[metaKeySource].(string)
+			lang = languageOf(source)
+		}
+
+		src := []byte(doc.Content)
+		var units []unit
+		if spec := languages[lang]; spec != nil {
+			var err error
+			units, err = s.parse(ctx, spec, src)
+			if err != nil {
+				return nil, fmt.Errorf("parse %s source of document %s failed: %w", lang, doc.ID, err)
+			}
+		} else {
+			units = s.splitLines(src, unit{start: 0, end: uint32(len(src))})
+		}
+
+		merged := s.merge(src, units)
+		chunks := make([]*schema.Document, 0, len(merged))
+		ranges := make([][2]int, 0, len(merged))
+		for i, chunk := range merged {
+			// keep the indentation of the first line
+			for chunk.start > 0 && (src[chunk.start-1] == ' ' || src[chunk.start-1] == '\t') {
+				chunk.start--
+			}
+			meta := make(map[string]any, len(doc.MetaData)+9)
+			for k, v := range doc.MetaData {
+				meta[k] = v
+			}
+			if lang != "" {
+				meta[MetaKeyLanguage] = string(lang)
+			}
+			if len(chunk.symbols) > 0 {
+				meta[MetaKeySymbols] = chunk.symbols
+			}
+			meta[MetaKeyStartLine] = lineAt(src, chunk.start)
+			meta[MetaKeyEndLine] = lineAt(src, chunk.end-1)
+
+			chunks = append(chunks, &schema.Document{
+				ID:       s.idGenerator(ctx, doc.ID, i),
+				Content:  string(src[chunk.start:chunk.end]),
+				MetaData: meta,
+			})
+			ranges = append(ranges, [2]int{int(chunk.start), int(chunk.end)})
+		}
+		provenance.Annotate(doc.Content, chunks, ranges)
+		ret = append(ret, chunks...)
+	}
+	return ret, nil
+}
+
+func (s *splitter) GetType() string {
+	return "CodeSplitter"
+}
+
+func (s *splitter) parse(ctx context.Context, spec *languageSpec, src []byte) ([]unit, error) {
+	p := sitter.NewParser()
+	defer p.Close()
+	p.SetLanguage(spec.grammar())
+
+	tree, err := p.ParseCtx(ctx, nil, src)
+	if err != nil {
+		return nil, err
+	}
+	defer tree.Close()
+
+	return s.collect(spec, tree.RootNode(), src, ""), nil
+}
+
+// collect makes the units of the children of the node, splitting those larger than the chunk size.
+// The comments right above a definition, e.g. doc comments, belong to the unit of the definition.
+func (s *splitter) collect(spec *languageSpec, node *sitter.Node, src []byte, prefix string) []unit {
+	var units []unit
+	var comments []unit
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+		if spec.comments[child.Type()] {
+			if n := len(comments); n > 0 && lineAt(src, child.StartByte())-lineAt(src, comments[n-1].end-1) > 1 {
+				units = append(units, comments...)
+				comments = comments[:0]
+			}
+			comments = append(comments, unit{start: child.StartByte(), end: child.EndByte()})
+			continue
+		}
+
+		u := unit{start: child.StartByte(), end: child.EndByte()}
+		def := spec.definition(child)
+		if def != nil {
+			for _, name := range spec.symbols(def, src) {
+				u.symbols = append(u.symbols, prefix+name)
+			}
+		}
+		if n := len(comments); n > 0 {
+			if len(u.symbols) > 0 && lineAt(src, u.start)-lineAt(src, comments[n-1].end-1) <= 1 {
+				u.start = comments[0].start
+			} else {
+				units = append(units, comments...)
+			}
+			comments = comments[:0]
+		}
+
+		if s.size(src, u) <= s.chunkSize {
+			units = append(units, u)
+			continue
+		}
+
+		if def != nil && spec.containers[def.Type()] && len(u.symbols) == 1 {
+			if body := def.ChildByFieldName("body"); body != nil {
+				members := s.collect(spec, body, src, u.symbols[0]+".")
+				first, last := -1, -1
+				for j, m := range members {
+					if len(m.symbols) > 0 {
+						if first < 0 {
+							first = j
+						}
+						last = j
+					}
+				}
+				if first >= 0 {
+					// the declaration with the fields before the first member definition, and the rest after the last one
+					units = append(units, s.splitLines(src, unit{start: u.start, end: members[first].start, symbols: u.symbols})...)
+					units = append(units, members[first:last+1]...)
+					units = append(units, s.splitLines(src, unit{start: members[last].end, end: u.end, symbols: u.symbols})...)
+					continue
+				}
+			}
+		}
+		units = append(units, s.splitLines(src, u)...)
+	}
+	return append(units, comments...)
+}
+
+// splitLines splits the unit into parts of whole lines fitting in the chunk size, a line larger than the chunk size
+// being a part by itself. Blank lines at both ends of the parts are dropped.
+func (s *splitter) splitLines(src []byte, u unit) []unit {
+	var parts []unit
+	cur := unit{start: u.start, end: u.start, symbols: u.symbols}
+	for pos := u.start; pos < u.end; {
+		lineEnd := pos
+		for lineEnd < u.end && src[lineEnd] != '\n' {
+			lineEnd++
+		}
+		if lineEnd < u.end {
+			lineEnd++
+		}
+
+		if cur.end > cur.start && s.size(src, unit{start: cur.start, end: lineEnd}) > s.chunkSize {
+			parts = append(parts, cur)
+			cur = unit{start: pos, end: pos, symbols: u.symbols}
+		}
+		cur.end = lineEnd
+		pos = lineEnd
+	}
+	if cur.end > cur.start {
+		parts = append(parts, cur)
+	}
+
+	ret := parts[:0]
+	for _, p := range parts {
+		text := string(src[p.start:p.end])
+		trimmed := strings.TrimLeft(text, "\r\n")
+		p.start += uint32(len(text) - len(trimmed))
+		p.end -= uint32(len(trimmed) - len(strings.TrimRight(trimmed, " \t\r\n")))
+		if p.end > p.start {
+			ret = append(ret, p)
+		}
+	}
+	return ret
+}
+
+// merge joins the consecutive units into chunks up to the chunk size.
+func (s *splitter) merge(src []byte, units []unit) []unit {
+	var chunks []unit
+	for _, u := range units {
+		if n := len(chunks); n > 0 {
+			last := chunks[n-1]
+			joined := unit{start: last.start, end: u.end, symbols: appendSymbols(last.symbols, u.symbols)}
+			if s.size(src, joined) <= s.chunkSize {
+				chunks[n-1] = joined
+				continue
+			}
+		}
+		chunks = append(chunks, unit{start: u.start, end: u.end, symbols: appendSymbols(nil, u.symbols)})
+	}
+	return chunks
+}
+
+func (s *splitter) size(src []byte, u unit) int {
+	return s.lenFunc(string(src[u.start:u.end]))
+}
+
+func appendSymbols(symbols []string, more []string) []string {
+	for _, m := range more {
+		found := false
+		for _, sym := range symbols {
+			if sym == m {
+				found = true
+				break
+			}
+		}
+		if !found {
+			symbols = append(symbols, m)
+		}
+	}
+	return symbols
+}
+
+// lineAt returns the 1-based line of the byte at the offset.
+func lineAt(src []byte, offset uint32) int {
+	line := 1
+	for _, b := range src[:offset] {
+		if b == '\n' {
+			line++
+		}
+	}
+	return line
+}
@@ -0,0 +1,301 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package code
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type chunk struct {
+	content   string
+	symbols   []string
+	startLine int
+	endLine   int
+}
+
+func transform(t *testing.T, config *Config, doc *schema.Document) []chunk {
+	ctx := context.Background()
+	s, err := NewSplitter(ctx, config)
+	require.NoError(t, err)
+	docs, err := s.Transform(ctx, []*schema.Document{doc})
+	require.NoError(t, err)
+
+	ret := make([]chunk, 0, len(docs))
+	for _, d := range docs {
+		symbols, _ := d.MetaData[MetaKeySymbols].([]string)
+		ret = append(ret, chunk{
+			content:   d.Content,
+			symbols:   symbols,
+			startLine: d.MetaData[MetaKeyStartLine].(int),
+			endLine:   d.MetaData[MetaKeyEndLine].(int),
+		})
+	}
+	return ret
+}
+
+func TestNewSplitter(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewSplitter(ctx, nil)
+	assert.ErrorContains(t, err, "config is nil")
+	_, err = NewSplitter(ctx, &Config{})
+	assert.ErrorContains(t, err, "chunk size must be greater than zero")
+	_, err = NewSplitter(ctx, &Config{ChunkSize: 10, Language: "cobol"})
+	assert.ErrorContains(t, err, "unsupported language: cobol")
+
+	s, err := NewSplitter(ctx, &Config{ChunkSize: 10, Language: LanguageGo})
+	require.NoError(t, err)
+	assert.Equal(t, "CodeSplitter", s.(*splitter).GetType())
+}
+
+const goSource = `package server
+
+import "net/http"
+
+// Server serves the api.
+type Server struct {
+	addr string
+}
+
+// Start starts the server,
+// blocking until it stops.
+func (s *Server) Start() error {
+	return http.ListenAndServe(s.addr, nil)
+}
+
+func New(addr string) *Server {
+	return &Server{addr: addr}
+}
+`
+
+func TestGo(t *testing.T) {
+	t.Run("definitions", func(t *testing.T) {
+		chunks := transform(t, &Config{ChunkSize: 150}, &schema.Document{
+			Content:  goSource,
+			MetaData: map[string]any{"_source": "https://example.com/server.go?raw=1"},
+		})
+		require.Len(t, chunks, 3)
+
+		assert.Equal(t, "package server\n\nimport \"net/http\"\n\n// Server serves the api.\ntype Server struct {\n\taddr string\n}", chunks[0].content)
+		assert.Equal(t, []string{"Server"}, chunks[0].symbols)
+		assert.Equal(t, 1, chunks[0].startLine)
+		assert.Equal(t, 8, chunks[0].endLine)
+
+		assert.True(t, strings.HasPrefix(chunks[1].content, "// Start starts the server,\n// blocking"))
+		assert.Equal(t, []string{"Server.Start"}, chunks[1].symbols)
+		assert.Equal(t, 10, chunks[1].startLine)
+		assert.Equal(t, 14, chunks[1].endLine)
+
+		assert.Equal(t, []string{"New"}, chunks[2].symbols)
+		assert.Equal(t, "func New(addr string) *Server {\n\treturn &Server{addr: addr}\n}", chunks[2].content)
+		assert.Equal(t, 16, chunks[2].startLine)
+		assert.Equal(t, 18, chunks[2].endLine)
+	})
+
+	t.Run("merge small definitions", func(t *testing.T) {
+		chunks := transform(t, &Config{ChunkSize: 1000, Language: LanguageGo}, &schema.Document{Content: goSource})
+		require.Len(t, chunks, 1)
+		assert.Equal(t, strings.TrimSpace(goSource), chunks[0].content)
+		assert.Equal(t, []string{"Server", "Server.Start", "New"}, chunks[0].symbols)
+	})
+
+	t.Run("large function split into lines", func(t *testing.T) {
+		var sb strings.Builder
+		sb.WriteString("package p\n\nfunc Long() {\n")
+		for i := 0; i < 20; i++ {
+			fmt.Fprintf(&sb, "\tprintln(%d)\n", i)
+		}
+		sb.WriteString("}\n")
+
+		chunks := transform(t, &Config{ChunkSize: 60, Language: LanguageGo}, &schema.Document{Content: sb.String()})
+		require.Greater(t, len(chunks), 3)
+		assert.True(t, strings.HasPrefix(chunks[0].content, "package p\n\nfunc Long() {"))
+		for _, c := range chunks {
+			assert.LessOrEqual(t, len(c.content), 60)
+			assert.Equal(t, []string{"Long"}, c.symbols)
+		}
+		assert.True(t, strings.HasSuffix(chunks[len(chunks)-1].content, "\tprintln(19)\n}"))
+		assert.Equal(t, 24, chunks[len(chunks)-1].endLine)
+	})
+}
+
+const pythonSource = `import os
+
+
+@cache
+def load(path):
+    return open(path).read()
+
+
+class Store:
+    """A store."""
+
+    def __init__(self, root):
+        self.root = root
+
+    def get(self, key):
+        return load(os.path.join(self.root, key))
+`
+
+func TestPython(t *testing.T) {
+	chunks := transform(t, &Config{ChunkSize: 80}, &schema.Document{
+		Content:  pythonSource,
+		MetaData: map[string]any{"_source": "store.py"},
+	})
+	require.Len(t, chunks, 4)
+	assert.Equal(t, "import os\n\n\n@cache\ndef load(path):\n    return open(path).read()", chunks[0].content)
+	assert.Equal(t, []string{"load"}, chunks[0].symbols)
+	assert.Equal(t, "class Store:\n    \"\"\"A store.\"\"\"", chunks[1].content)
+	assert.Equal(t, []string{"Store"}, chunks[1].symbols)
+	assert.Equal(t, []string{"Store.__init__"}, chunks[2].symbols)
+	assert.Equal(t, []string{"Store.get"}, chunks[3].symbols)
+	assert.Equal(t, 15, chunks[3].startLine)
+	assert.Equal(t, 16, chunks[3].endLine)
+}
+
+const javaSource = `package demo;
+
+import java.util.List;
+
+/**
+ * Greeter greets.
+ */
+public class Greeter {
+    private final String name;
+
+    public Greeter(String name) {
+        this.name = name;
+    }
+
+    // greet returns the greeting.
+    public String greet() {
+        return "Hello, " + name;
+    }
+}
+`
+
+func TestJava(t *testing.T) {
+	chunks := transform(t, &Config{ChunkSize: 100, Language: LanguageJava}, &schema.Document{Content: javaSource})
+	require.Len(t, chunks, 4)
+	assert.Equal(t, "package demo;\n\nimport java.util.List;", chunks[0].content)
+	assert.Empty(t, chunks[0].symbols)
+	assert.Equal(t, "/**\n * Greeter greets.\n */\npublic class Greeter {\n    private final String name;", chunks[1].content)
+	assert.Equal(t, []string{"Greeter"}, chunks[1].symbols)
+	assert.Equal(t, 5, chunks[1].startLine)
+	assert.Equal(t, 9, chunks[1].endLine)
+	assert.Equal(t, []string{"Greeter.Greeter"}, chunks[2].symbols)
+	assert.Equal(t, "    // greet returns the greeting.\n    public String greet() {\n        return \"Hello, \" + name;\n    }\n}", chunks[3].content)
+	assert.Equal(t, []string{"Greeter.greet", "Greeter"}, chunks[3].symbols)
+}
+
+const tsSource = `import { db } from './db';
+
+export interface User {
+  id: string;
+}
+
+export const findUser = async (id: string): Promise<User> => {
+  return db.get(id);
+};
+
+const limit = 10;
+
+export default class Repo {
+  list(): User[] {
+    return db.all(limit);
+  }
+}
+`
+
+func TestTypeScript(t *testing.T) {
+	chunks := transform(t, &Config{ChunkSize: 70}, &schema.Document{
+		Content:  tsSource,
+		MetaData: map[string]any{"_source": "src/user.ts"},
+	})
+	var symbols []string
+	for _, c := range chunks {
+		assert.LessOrEqual(t, len(c.content), 70)
+		symbols = appendSymbols(symbols, c.symbols)
+	}
+	assert.Equal(t, []string{"User", "findUser", "Repo", "Repo.list"}, symbols)
+
+	chunks = transform(t, &Config{ChunkSize: 1000}, &schema.Document{
+		Content:  "function App() {\n  return <div>hi</div>;\n}\n",
+		MetaData: map[string]any{"_source": "App.TSX"},
+	})
+	require.Len(t, chunks, 1)
+	assert.Equal(t, []string{"App"}, chunks[0].symbols)
+}
+
+func TestProvenance(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewSplitter(ctx, &Config{
+		ChunkSize: 40,
+		Language:  LanguagePython,
+		IDGenerator: func(ctx context.Context, originalID string, splitIndex int) string {
+			return fmt.Sprintf("%s_%d", originalID, splitIndex)
+		},
+	})
+	require.NoError(t, err)
+
+	source := "# 数据\ndef 读取():\n    return 1\n\n\ndef write(value):\n    return value\n"
+	docs, err := s.Transform(ctx, []*schema.Document{{ID: "doc", Content: source}})
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+
+	runes := []rune(source)
+	for i, doc := range docs {
+		start, end := doc.MetaData[MetaKeyStartOffset].(int), doc.MetaData[MetaKeyEndOffset].(int)
+		assert.Equal(t, doc.Content, string(runes[start:end]))
+		assert.Equal(t, i, doc.MetaData[MetaKeyChunkIndex])
+	}
+	assert.Equal(t, "doc_1", docs[0].MetaData[MetaKeyNextChunkID])
+	assert.NotContains(t, docs[0].MetaData, MetaKeyPrevChunkID)
+	assert.Equal(t, "doc_0", docs[1].MetaData[MetaKeyPrevChunkID])
+	assert.NotContains(t, docs[1].MetaData, MetaKeyNextChunkID)
+}
+
+func TestUnknownLanguage(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewSplitter(ctx, &Config{ChunkSize: 12})
+	require.NoError(t, err)
+
+	docs, err := s.Transform(ctx, []*schema.Document{{
+		ID:       "doc",
+		Content:  "line one\nline two\n\n\nline three\n",
+		MetaData: map[string]any{"_source": "notes.txt"},
+	}})
+	require.NoError(t, err)
+	require.Len(t, docs, 3)
+	assert.Equal(t, "line one", docs[0].Content)
+	assert.Equal(t, "line two", docs[1].Content)
+	assert.Equal(t, "line three", docs[2].Content)
+	assert.Equal(t, 5, docs[2].MetaData[MetaKeyStartLine])
+	assert.Equal(t, 2, docs[2].MetaData[MetaKeyChunkIndex])
+	assert.Equal(t, 20, docs[2].MetaData[MetaKeyStartOffset])
+	assert.Equal(t, 30, docs[2].MetaData[MetaKeyEndOffset])
+	assert.NotContains(t, docs[0].MetaData, MetaKeyLanguage)
+	assert.NotContains(t, docs[0].MetaData, MetaKeySymbols)
+	assert.Equal(t, "notes.txt", docs[0].MetaData["_source"])
+}
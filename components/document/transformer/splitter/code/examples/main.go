@@ -0,0 +1,62 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/code"
+)
+
+func main() {
+	ctx := context.Background()
+
+	splitter, err := code.NewSplitter(ctx, &code.Config{
+		ChunkSize: 300,
+	})
+	if err != nil {
+		log.Fatalf("NewSplitter of code splitter failed, err=%v", err)
+	}
+
+	file := "./testdata/store.py"
+	data, err := os.ReadFile(file)
+	if err != nil {
+		log.Fatalf("read file failed, err=%v", err)
+	}
+
+	docs, err := splitter.Transform(ctx, []*schema.Document{
+		{
+			Content: string(data),
+			// the language is detected from the extension of the source
+			MetaData: map[string]any{"_source": file},
+		},
+	})
+	if err != nil {
+		log.Fatalf("Transform of code splitter failed, err=%v", err)
+	}
+
+	for idx, doc := range docs {
+		fmt.Printf("====== %02d %v (lines %d-%d) ======\n", idx, doc.MetaData[code.MetaKeySymbols],
+			doc.MetaData[code.MetaKeyStartLine], doc.MetaData[code.MetaKeyEndLine])
+		fmt.Println(doc.Content)
+	}
+}
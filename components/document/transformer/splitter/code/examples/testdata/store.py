@@ -0,0 +1,28 @@
+import json
+import os
+
+
+def load_json(path):
+    """Loads a json file."""
+    with open(path) as f:
+        return json.load(f)
+
+
+class Store:
+    """A key value store persisted in a directory."""
+
+    def __init__(self, root):
+        self.root = root
+        os.makedirs(root, exist_ok=True)
+
+    def get(self, key):
+        """Returns the value of the key, None if absent."""
+        path = os.path.join(self.root, key + ".json")
+        if not os.path.exists(path):
+            return None
+        return load_json(path)
+
+    def put(self, key, value):
+        """Stores the value of the key."""
+        with open(os.path.join(self.root, key + ".json"), "w") as f:
+            json.dump(value, f)
@@ -0,0 +1,227 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package code
+
+import (
+	"path"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/golang"
+	"github.com/smacker/go-tree-sitter/java"
+	"github.com/smacker/go-tree-sitter/javascript"
+	"github.com/smacker/go-tree-sitter/python"
+	"github.com/smacker/go-tree-sitter/typescript/tsx"
+	"github.com/smacker/go-tree-sitter/typescript/typescript"
+)
+
+// Language is the programming language of the source code.
+type Language string
+
+const (
+	LanguageGo         Language = "go"
+	LanguagePython     Language = "python"
+	LanguageJava       Language = "java"
+	LanguageJavaScript Language = "javascript"
+	LanguageTypeScript Language = "typescript"
+	LanguageTSX        Language = "tsx"
+)
+
+// languageSpec describes the syntax nodes of a language the chunks are cut at.
+type languageSpec struct {
+	grammar    func() *sitter.Language
+	extensions []string
+	// definitions are the node types declaring symbols, e.g. functions and classes.
+	definitions map[string]bool
+	// containers are the definitions whose members are split when they are too large, e.g. classes.
+	containers map[string]bool
+	// wrappers are the node types wrapping a definition, mapped to the field of the definition.
+	wrappers map[string]string
+	comments map[string]bool
+}
+
+func set(types ...string) map[string]bool {
+	m := make(map[string]bool, len(types))
+	for _, t := range types {
+		m[t] = true
+	}
+	return m
+}
+
+var jsDefinitions = []string{
+	"function_declaration", "generator_function_declaration", "class_declaration", "class",
+	"method_definition", "lexical_declaration", "variable_declaration",
+}
+
+var tsDefinitions = append([]string{
+	"abstract_class_declaration", "interface_declaration", "type_alias_declaration", "enum_declaration",
+	"module", "internal_module", "abstract_method_signature", "function_signature",
+}, jsDefinitions...)
+
+var tsContainers = set("class_declaration", "class", "abstract_class_declaration", "interface_declaration", "module", "internal_module")
+
+var languages = map[Language]*languageSpec{
+	LanguageGo: {
+		grammar:     golang.GetLanguage,
+		extensions:  []string{".go"},
+		definitions: set("function_declaration", "method_declaration", "type_declaration"),
+		comments:    set("comment"),
+	},
+	LanguagePython: {
+		grammar:     python.GetLanguage,
+		extensions:  []string{".py", ".pyi"},
+		definitions: set("function_definition", "class_definition"),
+		containers:  set("class_definition"),
+		wrappers:    map[string]string{"decorated_definition": "definition"},
+		comments:    set("comment"),
+	},
+	LanguageJava: {
+		grammar:    java.GetLanguage,
+		extensions: []string{".java"},
+		definitions: set("class_declaration", "interface_declaration", "enum_declaration", "record_declaration",
+			"annotation_type_declaration", "method_declaration", "constructor_declaration", "compact_constructor_declaration"),
+		containers: set("class_declaration", "interface_declaration", "enum_declaration", "record_declaration"),
+		comments:   set("line_comment", "block_comment"),
+	},
+	LanguageJavaScript: {
+		grammar:     javascript.GetLanguage,
+		extensions:  []string{".js", ".mjs", ".cjs", ".jsx"},
+		definitions: set(jsDefinitions...),
+		containers:  set("class_declaration", "class"),
+		wrappers:    map[string]string{"export_statement": "declaration"},
+		comments:    set("comment"),
+	},
+	LanguageTypeScript: {
+		grammar:     typescript.GetLanguage,
+		extensions:  []string{".ts", ".mts", ".cts"},
+		definitions: set(tsDefinitions...),
+		containers:  tsContainers,
+		wrappers:    map[string]string{"export_statement": "declaration", "ambient_declaration": ""},
+		comments:    set("comment"),
+	},
+	LanguageTSX: {
+		grammar:     tsx.GetLanguage,
+		extensions:  []string{".tsx"},
+		definitions: set(tsDefinitions...),
+		containers:  tsContainers,
+		wrappers:    map[string]string{"export_statement": "declaration", "ambient_declaration": ""},
+		comments:    set("comment"),
+	},
+}
+
+// languageOf detects the language from the extension of the uri, empty if unknown.
+func languageOf(uri string) Language {
+	if uri == "" {
+		return ""
+	}
+	if i := strings.IndexAny(uri, "?#"); i >= 0 {
+		uri = uri[:i]
+	}
+	ext := strings.ToLower(path.Ext(strings.ReplaceAll(uri, "\\", "/")))
+	for lang, spec := range languages {
+		for _, e := range spec.extensions {
+			if e == ext {
+				return lang
+			}
+		}
+	}
+	return ""
+}
+
+// definition returns the definition node of n, unwrapping export statements and decorators, nil if n is not a definition.
+func (l *languageSpec) definition(n *sitter.Node) *sitter.Node {
+	if field, ok := l.wrappers[n.Type()]; ok {
+		var inner *sitter.Node
+		if field != "" {
+			inner = n.ChildByFieldName(field)
+		}
+		if inner == nil {
+			for i := 0; i < int(n.NamedChildCount()); i++ {
+				if c := n.NamedChild(i); l.definitions[c.Type()] || l.wrappers[c.Type()] != "" {
+					inner = c
+					break
+				}
+			}
+		}
+		if inner == nil {
+			return nil
+		}
+		return l.definition(inner)
+	}
+	if l.definitions[n.Type()] {
+		return n
+	}
+	return nil
+}
+
+// symbols returns the names declared by the definition node, none if it declares nothing worth a symbol,
+// e.g. a javascript variable which is not a function.
+func (l *languageSpec) symbols(def *sitter.Node, src []byte) []string {
+	switch def.Type() {
+	case "method_declaration":
+		// go methods are named after their receiver type
+		name := nameOf(def, src)
+		if recv := def.ChildByFieldName("receiver"); recv != nil && recv.NamedChildCount() > 0 {
+			if typ := recv.NamedChild(0).ChildByFieldName("type"); typ != nil {
+				recvType := strings.TrimLeft(typ.Content(src), "*")
+				if i := strings.IndexByte(recvType, '['); i >= 0 {
+					recvType = recvType[:i]
+				}
+				return []string{recvType + "." + name}
+			}
+		}
+		if name != "" {
+			return []string{name}
+		}
+		return nil
+	case "type_declaration":
+		var names []string
+		for i := 0; i < int(def.NamedChildCount()); i++ {
+			if name := nameOf(def.NamedChild(i), src); name != "" {
+				names = append(names, name)
+			}
+		}
+		return names
+	case "lexical_declaration", "variable_declaration":
+		var names []string
+		for i := 0; i < int(def.NamedChildCount()); i++ {
+			decl := def.NamedChild(i)
+			value := decl.ChildByFieldName("value")
+			if value == nil {
+				continue
+			}
+			switch value.Type() {
+			case "arrow_function", "function_expression", "function", "generator_function", "class":
+				if name := nameOf(decl, src); name != "" {
+					names = append(names, name)
+				}
+			}
+		}
+		return names
+	}
+	if name := nameOf(def, src); name != "" {
+		return []string{name}
+	}
+	return nil
+}
+
+func nameOf(n *sitter.Node, src []byte) string {
+	if name := n.ChildByFieldName("name"); name != nil {
+		return name.Content(src)
+	}
+	return ""
+}
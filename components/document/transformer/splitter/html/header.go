@@ -26,6 +26,8 @@ import (
 
 	"github.com/cloudwego/eino/components/document"
 	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/html/internal/provenance"
 )
 
 // IDGenerator generates new IDs for split chunks
@@ -42,6 +44,16 @@ type HeaderConfig struct {
 	// Header must be in the format of starting with 'h' followed by a number.
 	// Example: {"h1": "Title", "h2": "Section"} will track h1 and h2 headers
 	Headers map[string]string
+	// HeadingPathKey, if set, is the metadata key of the path of the headers a chunk is under,
+	// from the highest level, joined with HeadingPathSeparator, e.g. "Guide > Install > Linux".
+	HeadingPathKey string
+	// HeadingPathSeparator joins the headers of the heading path, " > " by default.
+	HeadingPathSeparator string
+	// MergeSize, if greater than zero, merges the consecutive small sections into chunks up to MergeSize measured by LenFunc,
+	// so that short sections don't end up as tiny chunks. A merged chunk only keeps the headers shared by all its sections.
+	MergeSize int
+	// LenFunc is used to calculate string length for MergeSize. Use builtin function len() by default.
+	LenFunc func(string) int
 	// IDGenerator is an optional function to generate new IDs for split chunks.
 	// If nil, the original document ID will be used for all splits.
 	IDGenerator IDGenerator
@@ -73,19 +85,38 @@ type HeaderConfig struct {
 //	     }
 //	   }
 func NewHeaderSplitter(ctx context.Context, config *HeaderConfig) (document.Transformer, error) {
+	if config.MergeSize < 0 {
+		return nil, fmt.Errorf("merge size must be greater than or equal to zero")
+	}
 	idGenerator := config.IDGenerator
 	if idGenerator == nil {
 		idGenerator = defaultIDGenerator
 	}
+	pathSeparator := config.HeadingPathSeparator
+	if pathSeparator == "" {
+		pathSeparator = " > "
+	}
+	lenFunc := config.LenFunc
+	if lenFunc == nil {
+		lenFunc = func(s string) int { return len(s) }
+	}
 	return &headerSplitter{
-		headers:     config.Headers,
-		idGenerator: idGenerator,
+		headers:        config.Headers,
+		headingPathKey: config.HeadingPathKey,
+		pathSeparator:  pathSeparator,
+		mergeSize:      config.MergeSize,
+		lenFunc:        lenFunc,
+		idGenerator:    idGenerator,
 	}, nil
 }
 
 type headerSplitter struct {
-	headers     map[string]string
-	idGenerator IDGenerator
+	headers        map[string]string
+	headingPathKey string
+	pathSeparator  string
+	mergeSize      int
+	lenFunc        func(string) int
+	idGenerator    IDGenerator
 }
 
 func (h *headerSplitter) Transform(ctx context.Context, docs []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
@@ -95,6 +126,10 @@ func (h *headerSplitter) Transform(ctx context.Context, docs []*schema.Document,
 		if err != nil {
 			return nil, err
 		}
+		if h.mergeSize > 0 {
+			result = h.mergeSections(result)
+		}
+		chunks := make([]*schema.Document, 0, len(result))
 		for i := range result {
 			nDoc := &schema.Document{
 				ID:       h.idGenerator(ctx, doc.ID, i),
@@ -107,8 +142,13 @@ func (h *headerSplitter) Transform(ctx context.Context, docs []*schema.Document,
 			for k, v := range result[i].meta {
 				nDoc.MetaData[k] = v
 			}
-			ret = append(ret, nDoc)
+			if h.headingPathKey != "" && len(result[i].path) > 0 {
+				nDoc.MetaData[h.headingPathKey] = strings.Join(result[i].path, h.pathSeparator)
+			}
+			chunks = append(chunks, nDoc)
 		}
+		provenance.Link(chunks)
+		ret = append(ret, chunks...)
 	}
 	return ret, nil
 }
@@ -120,6 +160,7 @@ func (h *headerSplitter) GetType() string {
 type splitResult struct {
 	chunk string
 	meta  map[string]string
+	path  []string
 }
 
 type metaRecord struct {
@@ -162,6 +203,7 @@ func (h *headerSplitter) dfs(node *html.Node, recordedMetaList []metaRecord, rec
 				*ret = append(*ret, splitResult{
 					chunk: currentText.String(),
 					meta:  deepCopyMap(recordedMetaMap),
+					path:  headingPath(recordedMetaList),
 				})
 				currentText.Reset()
 			}
@@ -208,12 +250,62 @@ func (h *headerSplitter) dfs(node *html.Node, recordedMetaList []metaRecord, rec
 		*ret = append(*ret, splitResult{
 			chunk: currentText.String(),
 			meta:  deepCopyMap(recordedMetaMap),
+			path:  headingPath(recordedMetaList),
 		})
 		currentText.Reset()
 	}
 	return nil
 }
 
+// mergeSections merges the consecutive sections into chunks up to the merge size.
+func (h *headerSplitter) mergeSections(results []splitResult) []splitResult {
+	var ret []splitResult
+	for _, r := range results {
+		if n := len(ret); n > 0 {
+			last := ret[n-1]
+			chunk := last.chunk + "\n" + r.chunk
+			if h.lenFunc(chunk) <= h.mergeSize {
+				ret[n-1] = splitResult{
+					chunk: chunk,
+					meta:  commonMeta(last.meta, r.meta),
+					path:  commonPath(last.path, r.path),
+				}
+				continue
+			}
+		}
+		ret = append(ret, r)
+	}
+	return ret
+}
+
+func headingPath(records []metaRecord) []string {
+	path := make([]string, 0, len(records))
+	for _, r := range records {
+		path = append(path, r.data)
+	}
+	return path
+}
+
+// commonMeta keeps the headers of the same value in both metadata.
+func commonMeta(a, b map[string]string) map[string]string {
+	ret := make(map[string]string)
+	for k, v := range a {
+		if bv, ok := b[k]; ok && bv == v {
+			ret[k] = v
+		}
+	}
+	return ret
+}
+
+// commonPath returns the longest common prefix of the heading paths.
+func commonPath(a, b []string) []string {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return a[:i:i]
+}
+
 func extractText(node *html.Node) (string, error) {
 	sb := strings.Builder{}
 
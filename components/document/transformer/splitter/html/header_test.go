@@ -89,63 +89,117 @@ func TestHTMLHeaderSplitter(t *testing.T) {
 				ID:      "id_part0",
 				Content: "H1 content1",
 				MetaData: map[string]interface{}{
-					"Header1": "H1",
+					MetaKeyChunkIndex:  0,
+					MetaKeyNextChunkID: "id_part1",
+					"Header1":          "H1",
 				},
 			}, {
 				ID:      "id_part1",
 				Content: "H2.1 content",
 				MetaData: map[string]interface{}{
-					"Header1": "H1",
-					"Header2": "H2.1",
+					MetaKeyChunkIndex:  1,
+					MetaKeyPrevChunkID: "id_part0",
+					MetaKeyNextChunkID: "id_part2",
+					"Header1":          "H1",
+					"Header2":          "H2.1",
 				},
 			}, {
 				ID:      "id_part2",
 				Content: "H3.1 content",
 				MetaData: map[string]interface{}{
-					"Header1": "H1",
-					"Header2": "H2.1",
-					"Header3": "H3.1",
+					MetaKeyChunkIndex:  2,
+					MetaKeyPrevChunkID: "id_part1",
+					MetaKeyNextChunkID: "id_part3",
+					"Header1":          "H1",
+					"Header2":          "H2.1",
+					"Header3":          "H3.1",
 				},
 			}, {
 				ID:      "id_part3",
 				Content: "H3.2 content",
 				MetaData: map[string]interface{}{
-					"Header1": "H1",
-					"Header2": "H2.1",
-					"Header3": "H3.2",
+					MetaKeyChunkIndex:  3,
+					MetaKeyPrevChunkID: "id_part2",
+					MetaKeyNextChunkID: "id_part4",
+					"Header1":          "H1",
+					"Header2":          "H2.1",
+					"Header3":          "H3.2",
 				},
 			}, {
 				ID:      "id_part4",
 				Content: "H2.2 content",
 				MetaData: map[string]interface{}{
-					"Header1": "H1",
-					"Header2": "H2.2",
+					MetaKeyChunkIndex:  4,
+					MetaKeyPrevChunkID: "id_part3",
+					MetaKeyNextChunkID: "id_part5",
+					"Header1":          "H1",
+					"Header2":          "H2.2",
 				},
 			}, {
 				ID:      "id_part5",
 				Content: "H2.3 content",
 				MetaData: map[string]interface{}{
-					"Header1": "H1",
-					"Header2": "H2.3",
+					MetaKeyChunkIndex:  5,
+					MetaKeyPrevChunkID: "id_part4",
+					MetaKeyNextChunkID: "id_part6",
+					"Header1":          "H1",
+					"Header2":          "H2.3",
 				},
 			}, {
 				ID:      "id_part6",
 				Content: "H1 content2H1 content3",
 				MetaData: map[string]interface{}{
-					"Header1": "H1",
+					MetaKeyChunkIndex:  6,
+					MetaKeyPrevChunkID: "id_part5",
+					MetaKeyNextChunkID: "id_part7",
+					"Header1":          "H1",
 				},
 			}, {
 				ID:      "id_part7",
 				Content: "H2.4 content",
 				MetaData: map[string]interface{}{
-					"Header2": "H2.4",
+					MetaKeyChunkIndex:  7,
+					MetaKeyPrevChunkID: "id_part6",
+					MetaKeyNextChunkID: "id_part8",
+					"Header2":          "H2.4",
 				},
 			}, {
 				ID:       "id_part8",
 				Content:  "content",
-				MetaData: map[string]interface{}{},
+				MetaData: map[string]interface{}{MetaKeyChunkIndex: 8, MetaKeyPrevChunkID: "id_part7"},
+			},
 			},
+		},
+		{
+			name: "heading path and merge",
+			config: &HeaderConfig{
+				Headers: map[string]string{
+					"h1": "Header1",
+					"h2": "Header2",
+					"h3": "Header3",
+				},
+				HeadingPathKey: "path",
+				MergeSize:      30,
 			},
+			input: []*schema.Document{{
+				Content: commonSuccessHTML,
+			}},
+			want: []*schema.Document{{
+				Content:  "H1 content1\nH2.1 content",
+				MetaData: map[string]interface{}{"Header1": "H1", "path": "H1", MetaKeyChunkIndex: 0},
+			}, {
+				Content:  "H3.1 content\nH3.2 content",
+				MetaData: map[string]interface{}{"Header1": "H1", "Header2": "H2.1", "path": "H1 > H2.1", MetaKeyChunkIndex: 1},
+			}, {
+				Content:  "H2.2 content\nH2.3 content",
+				MetaData: map[string]interface{}{"Header1": "H1", "path": "H1", MetaKeyChunkIndex: 2},
+			}, {
+				Content:  "H1 content2H1 content3",
+				MetaData: map[string]interface{}{"Header1": "H1", "path": "H1", MetaKeyChunkIndex: 3},
+			}, {
+				Content:  "H2.4 content\ncontent",
+				MetaData: map[string]interface{}{MetaKeyChunkIndex: 4},
+			}},
 		},
 	}
 	ctx := context.Background()
@@ -0,0 +1,32 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package html
+
+import "github.com/cloudwego/eino-ext/components/document/transformer/splitter/html/internal/provenance"
+
+//go:generate sh ../../../../../libs/acl/bundle.sh provenance internal/provenance
+
+const (
+	// MetaKeyChunkIndex is the metadata key of the 0-based index of a chunk among the chunks of its document.
+	MetaKeyChunkIndex = provenance.MetaKeyChunkIndex
+	// MetaKeyPrevChunkID is the metadata key of the ID of the previous chunk of the same document,
+	// set only if the chunks have distinct IDs, see HeaderConfig.IDGenerator.
+	MetaKeyPrevChunkID = provenance.MetaKeyPrevChunkID
+	// MetaKeyNextChunkID is the metadata key of the ID of the next chunk of the same document,
+	// set only if the chunks have distinct IDs, see HeaderConfig.IDGenerator.
+	MetaKeyNextChunkID = provenance.MetaKeyNextChunkID
+)
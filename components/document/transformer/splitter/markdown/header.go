@@ -20,9 +20,12 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"unicode"
 
 	"github.com/cloudwego/eino/components/document"
 	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/markdown/internal/provenance"
 )
 
 // IDGenerator generates new IDs for split chunks
@@ -59,6 +62,16 @@ type HeaderConfig struct {
 	Headers map[string]string
 	// TrimHeaders specify if results contain header lines.
 	TrimHeaders bool
+	// HeadingPathKey, if set, is the metadata key of the path of the headers a chunk is under,
+	// from the highest level, joined with HeadingPathSeparator, e.g. "Guide > Install > Linux".
+	HeadingPathKey string
+	// HeadingPathSeparator joins the headers of the heading path, " > " by default.
+	HeadingPathSeparator string
+	// MergeSize, if greater than zero, merges the consecutive small sections into chunks up to MergeSize measured by LenFunc,
+	// so that short sections don't end up as tiny chunks. A merged chunk only keeps the headers shared by all its sections.
+	MergeSize int
+	// LenFunc is used to calculate string length for MergeSize. Use builtin function len() by default.
+	LenFunc func(string) int
 	// IDGenerator is an optional function to generate new IDs for split chunks.
 	// If nil, the original document ID will be used for all splits.
 	IDGenerator IDGenerator
@@ -75,32 +88,60 @@ func NewHeaderSplitter(ctx context.Context, config *HeaderConfig) (document.Tran
 			}
 		}
 	}
+	if config.MergeSize < 0 {
+		return nil, fmt.Errorf("merge size must be greater than or equal to zero")
+	}
 	idGenerator := config.IDGenerator
 	if idGenerator == nil {
 		idGenerator = defaultIDGenerator
 	}
+	pathSeparator := config.HeadingPathSeparator
+	if pathSeparator == "" {
+		pathSeparator = " > "
+	}
+	lenFunc := config.LenFunc
+	if lenFunc == nil {
+		lenFunc = func(s string) int { return len(s) }
+	}
 	return &headerSplitter{
-		headers:     config.Headers,
-		trimHeaders: config.TrimHeaders,
-		idGenerator: idGenerator,
+		headers:        config.Headers,
+		trimHeaders:    config.TrimHeaders,
+		headingPathKey: config.HeadingPathKey,
+		pathSeparator:  pathSeparator,
+		mergeSize:      config.MergeSize,
+		lenFunc:        lenFunc,
+		idGenerator:    idGenerator,
 	}, nil
 }
 
 type headerSplitter struct {
-	headers     map[string]string
-	trimHeaders bool
-	idGenerator IDGenerator
+	headers        map[string]string
+	trimHeaders    bool
+	headingPathKey string
+	pathSeparator  string
+	mergeSize      int
+	lenFunc        func(string) int
+	idGenerator    IDGenerator
 }
 
 type splitResult struct {
 	chunk string
 	meta  map[string]string
+	path  []string
+	// start and end are the byte range of the chunk in the text, -1 if the chunk is empty
+	start int
+	end   int
 }
 
 func (h *headerSplitter) Transform(ctx context.Context, docs []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
 	var ret []*schema.Document
 	for _, doc := range docs {
 		result := h.splitText(ctx, doc.Content)
+		if h.mergeSize > 0 {
+			result = h.mergeSections(result)
+		}
+		chunks := make([]*schema.Document, 0, len(result))
+		ranges := make([][2]int, 0, len(result))
 		for i := range result {
 			nDoc := &schema.Document{
 				ID:       h.idGenerator(ctx, doc.ID, i),
@@ -113,8 +154,15 @@ func (h *headerSplitter) Transform(ctx context.Context, docs []*schema.Document,
 			for k, v := range result[i].meta {
 				nDoc.MetaData[k] = v
 			}
-			ret = append(ret, nDoc)
+			if h.headingPathKey != "" && len(result[i].path) > 0 {
+				nDoc.MetaData[h.headingPathKey] = strings.Join(result[i].path, h.pathSeparator)
+			}
+			chunks = append(chunks, nDoc)
+			ranges = append(ranges, [2]int{result[i].start, result[i].end})
 		}
+		// the range of a chunk is the one of its lines in the text, its content being made of the trimmed lines
+		provenance.Annotate(doc.Content, chunks, ranges)
+		ret = append(ret, chunks...)
 	}
 	return ret, nil
 }
@@ -141,11 +189,27 @@ func (h *headerSplitter) splitText(ctx context.Context, text string) []splitResu
 	var bInCodeBlock bool
 	var openingFence string
 	var ret []splitResult
+	// byte range of the current lines in the text
+	currentStart, currentEnd := -1, -1
+	appendLine := func(line string, start int) {
+		currentLines = append(currentLines, line)
+		if line == "" {
+			return
+		}
+		if currentStart < 0 {
+			currentStart = start
+		}
+		currentEnd = start + len(line)
+	}
 	lines := strings.Split(text, "\n")
+	lineStart := 0
 	for _, line := range lines {
+		rawStart := lineStart
+		lineStart += len(line) + 1
 		if len(line) == 0 {
 			continue
 		}
+		start := rawStart + len(line) - len(strings.TrimLeftFunc(line, unicode.IsSpace))
 		line = strings.TrimSpace(line)
 		if !bInCodeBlock {
 			if strings.HasPrefix(line, codeSep1) && strings.Count(line, codeSep1) == 1 {
@@ -162,7 +226,7 @@ func (h *headerSplitter) splitText(ctx context.Context, text string) []splitResu
 			}
 		}
 		if bInCodeBlock {
-			currentLines = append(currentLines, line)
+			appendLine(line, start)
 			continue
 		}
 		// check if the line starts with headers
@@ -173,12 +237,16 @@ func (h *headerSplitter) splitText(ctx context.Context, text string) []splitResu
 					ret = append(ret, splitResult{
 						chunk: strings.Join(currentLines, "\n"),
 						meta:  deepCopyMap(recordedMetaMap),
+						path:  headingPath(recordedMetaList),
+						start: currentStart,
+						end:   currentEnd,
 					})
 					currentLines = currentLines[:0]
+					currentStart, currentEnd = -1, -1
 				}
 
 				if !h.trimHeaders {
-					currentLines = append(currentLines, line)
+					appendLine(line, start)
 				}
 
 				newLevel := len(header)
@@ -204,16 +272,87 @@ func (h *headerSplitter) splitText(ctx context.Context, text string) []splitResu
 			}
 		}
 		if !bNewHeader {
-			currentLines = append(currentLines, line)
+			appendLine(line, start)
 		}
 	}
 	ret = append(ret, splitResult{
 		chunk: strings.Join(currentLines, "\n"),
 		meta:  deepCopyMap(recordedMetaMap),
+		path:  headingPath(recordedMetaList),
+		start: currentStart,
+		end:   currentEnd,
 	})
 	return ret
 }
 
+// mergeSections merges the consecutive sections into chunks up to the merge size.
+func (h *headerSplitter) mergeSections(results []splitResult) []splitResult {
+	var ret []splitResult
+	for _, r := range results {
+		if n := len(ret); n > 0 {
+			last := ret[n-1]
+			chunk := joinChunks(last.chunk, r.chunk)
+			if h.lenFunc(chunk) <= h.mergeSize {
+				merged := splitResult{
+					chunk: chunk,
+					meta:  commonMeta(last.meta, r.meta),
+					path:  commonPath(last.path, r.path),
+					start: last.start,
+					end:   r.end,
+				}
+				if merged.start < 0 {
+					merged.start = r.start
+				}
+				if r.end < 0 {
+					merged.end = last.end
+				}
+				ret[n-1] = merged
+				continue
+			}
+		}
+		ret = append(ret, r)
+	}
+	return ret
+}
+
+func joinChunks(a, b string) string {
+	if a == "" {
+		return b
+	}
+	if b == "" {
+		return a
+	}
+	return a + "\n\n" + b
+}
+
+func headingPath(records []metaRecord) []string {
+	path := make([]string, 0, len(records))
+	for _, r := range records {
+		path = append(path, r.data)
+	}
+	return path
+}
+
+// commonMeta keeps the headers of the same value in both metadata.
+func commonMeta(a, b map[string]string) map[string]string {
+	ret := make(map[string]string)
+	for k, v := range a {
+		if bv, ok := b[k]; ok && bv == v {
+			ret[k] = v
+		}
+	}
+	return ret
+}
+
+// commonPath returns the longest common prefix of the heading paths.
+func commonPath(a, b []string) []string {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return a[:i:i]
+}
+
 func deepCopyMap(m map[string]string) map[string]string {
 	if m == nil {
 		return nil
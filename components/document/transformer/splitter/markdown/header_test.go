@@ -54,32 +54,106 @@ func TestMarkdownHeaderSplitter(t *testing.T) {
 				ID:      "id_part0",
 				Content: "```code1\ncode2\ncode3\n```",
 				MetaData: map[string]interface{}{
-					"Header1": "Header1",
+					MetaKeyChunkIndex:  0,
+					MetaKeyStartOffset: 12,
+					MetaKeyEndOffset:   36,
+					MetaKeyNextChunkID: "id_part1",
+					"Header1":          "Header1",
 				},
 			}, {
 				ID:      "id_part1",
 				Content: "Content1",
 				MetaData: map[string]interface{}{
-					"Header1": "Header1",
-					"Header2": "Header2",
+					MetaKeyChunkIndex:  1,
+					MetaKeyStartOffset: 50,
+					MetaKeyEndOffset:   58,
+					MetaKeyPrevChunkID: "id_part0",
+					MetaKeyNextChunkID: "id_part2",
+					"Header1":          "Header1",
+					"Header2":          "Header2",
 				},
 			}, {
 				ID:      "id_part2",
 				Content: "Content2",
 				MetaData: map[string]interface{}{
-					"Header1": "Header1",
-					"Header2": "Header2",
-					"Header3": "Header3",
+					MetaKeyChunkIndex:  2,
+					MetaKeyStartOffset: 76,
+					MetaKeyEndOffset:   84,
+					MetaKeyPrevChunkID: "id_part1",
+					MetaKeyNextChunkID: "id_part3",
+					"Header1":          "Header1",
+					"Header2":          "Header2",
+					"Header3":          "Header3",
 				},
 			}, {
 				ID:      "id_part3",
 				Content: "Content3",
 				MetaData: map[string]interface{}{
-					"Header1": "Header1",
-					"Header2": "Header4",
+					MetaKeyChunkIndex:  3,
+					MetaKeyStartOffset: 101,
+					MetaKeyEndOffset:   109,
+					MetaKeyPrevChunkID: "id_part2",
+					"Header1":          "Header1",
+					"Header2":          "Header4",
 				},
 			}},
 		},
+		{
+			name: "heading path",
+			config: &HeaderConfig{
+				Headers: map[string]string{
+					"#":   "Header1",
+					"##":  "Header2",
+					"###": "Header3",
+				},
+				TrimHeaders:    true,
+				HeadingPathKey: "path",
+			},
+			input: []*schema.Document{{
+				Content: "# Guide\nIntro\n## Install\nRun go get\n### Linux\napt\n## Usage\nCall it",
+			}},
+			want: []*schema.Document{{
+				Content:  "Intro",
+				MetaData: map[string]interface{}{"Header1": "Guide", "path": "Guide", MetaKeyChunkIndex: 0, MetaKeyStartOffset: 8, MetaKeyEndOffset: 13},
+			}, {
+				Content:  "Run go get",
+				MetaData: map[string]interface{}{"Header1": "Guide", "Header2": "Install", "path": "Guide > Install", MetaKeyChunkIndex: 1, MetaKeyStartOffset: 25, MetaKeyEndOffset: 35},
+			}, {
+				Content:  "apt",
+				MetaData: map[string]interface{}{"Header1": "Guide", "Header2": "Install", "Header3": "Linux", "path": "Guide > Install > Linux", MetaKeyChunkIndex: 2, MetaKeyStartOffset: 46, MetaKeyEndOffset: 49},
+			}, {
+				Content:  "Call it",
+				MetaData: map[string]interface{}{"Header1": "Guide", "Header2": "Usage", "path": "Guide > Usage", MetaKeyChunkIndex: 3, MetaKeyStartOffset: 59, MetaKeyEndOffset: 66},
+			}},
+		},
+		{
+			name: "merge small sections",
+			config: &HeaderConfig{
+				Headers: map[string]string{
+					"#":   "Header1",
+					"##":  "Header2",
+					"###": "Header3",
+				},
+				TrimHeaders:          true,
+				HeadingPathKey:       "path",
+				HeadingPathSeparator: "/",
+				MergeSize:            20,
+			},
+			input: []*schema.Document{{
+				Content:  "# Guide\nIntro\n## Install\nRun go get\n### Linux\napt\n### Mac\nbrew\n## Usage\nCall it and check the output",
+				MetaData: map[string]interface{}{"source": "guide.md"},
+			}},
+			want: []*schema.Document{{
+				Content:  "Intro\n\nRun go get",
+				MetaData: map[string]interface{}{"source": "guide.md", "Header1": "Guide", "path": "Guide", MetaKeyChunkIndex: 0, MetaKeyStartOffset: 8, MetaKeyEndOffset: 35},
+			}, {
+				Content:  "apt\n\nbrew",
+				MetaData: map[string]interface{}{"source": "guide.md", "Header1": "Guide", "Header2": "Install", "path": "Guide/Install", MetaKeyChunkIndex: 1, MetaKeyStartOffset: 46, MetaKeyEndOffset: 62},
+			}, {
+				Content:  "Call it and check the output",
+				MetaData: map[string]interface{}{"source": "guide.md", "Header1": "Guide", "Header2": "Usage", "path": "Guide/Usage", MetaKeyChunkIndex: 2, MetaKeyStartOffset: 72, MetaKeyEndOffset: 100},
+			}},
+		},
 	}
 	ctx := context.Background()
 	for _, tt := range tests {
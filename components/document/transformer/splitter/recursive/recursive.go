@@ -23,6 +23,8 @@ import (
 
 	"github.com/cloudwego/eino/components/document"
 	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/recursive/internal/provenance"
 )
 
 type KeepType uint8
@@ -105,13 +107,16 @@ func (s *splitter) Transform(ctx context.Context, docs []*schema.Document, opts
 	ret := make([]*schema.Document, 0, len(docs))
 	for _, doc := range docs {
 		splits := s.splitText(ctx, doc.Content, s.separators)
+		chunks := make([]*schema.Document, 0, len(splits))
 		for i, split := range splits {
-			ret = append(ret, &schema.Document{
+			chunks = append(chunks, &schema.Document{
 				ID:       s.idGenerator(ctx, doc.ID, i),
 				Content:  split,
 				MetaData: deepCopyMap(doc.MetaData),
 			})
 		}
+		provenance.Annotate(doc.Content, chunks, provenance.Locate(doc.Content, splits))
+		ret = append(ret, chunks...)
 	}
 	return ret, nil
 }
@@ -25,6 +25,21 @@ import (
 	"github.com/cloudwego/eino/schema"
 )
 
+func chunkMeta(index, start, end int, prevID, nextID string) map[string]any {
+	meta := map[string]any{
+		MetaKeyChunkIndex:  index,
+		MetaKeyStartOffset: start,
+		MetaKeyEndOffset:   end,
+	}
+	if prevID != "" {
+		meta[MetaKeyPrevChunkID] = prevID
+	}
+	if nextID != "" {
+		meta[MetaKeyNextChunkID] = nextID
+	}
+	return meta
+}
+
 func TestRecursiveSplitter(t *testing.T) {
 	type args struct {
 		ctx    context.Context
@@ -53,13 +68,13 @@ func TestRecursiveSplitter(t *testing.T) {
 				input: input,
 			},
 			wantOutput: []*schema.Document{
-				{Content: "1a23"},
-				{Content: "23a45"},
-				{Content: "67890"},
-				{Content: "1"},
-				{Content: "234"},
-				{Content: "5678"},
-				{Content: "90"},
+				{Content: "1a23", MetaData: chunkMeta(0, 0, 4, "", "")},
+				{Content: "23a45", MetaData: chunkMeta(1, 2, 7, "", "")},
+				{Content: "67890", MetaData: chunkMeta(2, 8, 13, "", "")},
+				{Content: "1", MetaData: chunkMeta(3, 14, 15, "", "")},
+				{Content: "234", MetaData: chunkMeta(4, 16, 19, "", "")},
+				{Content: "5678", MetaData: chunkMeta(5, 20, 24, "", "")},
+				{Content: "90", MetaData: chunkMeta(6, 25, 27, "", "")},
 			},
 		},
 		{
@@ -78,13 +93,13 @@ func TestRecursiveSplitter(t *testing.T) {
 				input: input,
 			},
 			wantOutput: []*schema.Document{
-				{ID: "_part0", Content: "1a23"},
-				{ID: "_part1", Content: "a45"},
-				{ID: "_part2", Content: "a67890"},
-				{ID: "_part3", Content: "c1"},
-				{ID: "_part4", Content: "a234"},
-				{ID: "_part5", Content: "b5678"},
-				{ID: "_part6", Content: "a90"},
+				{ID: "_part0", Content: "1a23", MetaData: chunkMeta(0, 0, 4, "", "_part1")},
+				{ID: "_part1", Content: "a45", MetaData: chunkMeta(1, 4, 7, "_part0", "_part2")},
+				{ID: "_part2", Content: "a67890", MetaData: chunkMeta(2, 7, 13, "_part1", "_part3")},
+				{ID: "_part3", Content: "c1", MetaData: chunkMeta(3, 13, 15, "_part2", "_part4")},
+				{ID: "_part4", Content: "a234", MetaData: chunkMeta(4, 15, 19, "_part3", "_part5")},
+				{ID: "_part5", Content: "b5678", MetaData: chunkMeta(5, 19, 24, "_part4", "_part6")},
+				{ID: "_part6", Content: "a90", MetaData: chunkMeta(6, 24, 27, "_part5", "")},
 			},
 		},
 		{
@@ -100,13 +115,13 @@ func TestRecursiveSplitter(t *testing.T) {
 				input: input,
 			},
 			wantOutput: []*schema.Document{
-				{Content: "1a23a"},
-				{Content: "45a"},
-				{Content: "67890c"},
-				{Content: "1a"},
-				{Content: "234b"},
-				{Content: "5678a"},
-				{Content: "90"},
+				{Content: "1a23a", MetaData: chunkMeta(0, 0, 5, "", "")},
+				{Content: "45a", MetaData: chunkMeta(1, 5, 8, "", "")},
+				{Content: "67890c", MetaData: chunkMeta(2, 8, 14, "", "")},
+				{Content: "1a", MetaData: chunkMeta(3, 14, 16, "", "")},
+				{Content: "234b", MetaData: chunkMeta(4, 16, 20, "", "")},
+				{Content: "5678a", MetaData: chunkMeta(5, 20, 25, "", "")},
+				{Content: "90", MetaData: chunkMeta(6, 25, 27, "", "")},
 			},
 		},
 	}
@@ -127,3 +142,38 @@ func TestRecursiveSplitter(t *testing.T) {
 		})
 	}
 }
+
+func TestProvenance(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewSplitter(ctx, &Config{
+		ChunkSize:   12,
+		OverlapSize: 6,
+		Separators:  []string{"。"},
+		KeepType:    KeepTypeEnd,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text := "第一句。第二句话。第三句。第一句。"
+	docs, err := s.Transform(ctx, []*schema.Document{{ID: "doc", Content: text, MetaData: map[string]any{"k": "v"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	runes := []rune(text)
+	for i, doc := range docs {
+		start, end := doc.MetaData[MetaKeyStartOffset].(int), doc.MetaData[MetaKeyEndOffset].(int)
+		if got := string(runes[start:end]); got != doc.Content {
+			t.Errorf("chunk %d at [%d, %d) = %q, want %q", i, start, end, got, doc.Content)
+		}
+		if doc.MetaData["k"] != "v" || doc.MetaData[MetaKeyChunkIndex] != i {
+			t.Errorf("chunk %d metadata = %v", i, doc.MetaData)
+		}
+		if _, ok := doc.MetaData[MetaKeyPrevChunkID]; ok {
+			t.Errorf("chunk %d of the same ID linked: %v", i, doc.MetaData)
+		}
+	}
+	if last := docs[len(docs)-1].MetaData[MetaKeyEndOffset]; last != len(runes) {
+		t.Errorf("last chunk ends at %v, want %d", last, len(runes))
+	}
+}
@@ -0,0 +1,148 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package semantic
+
+import (
+	"math"
+	"sort"
+)
+
+// BreakpointStrategy decides where the text is split from the cosine distances between the adjacent sentences.
+type BreakpointStrategy interface {
+	// Breakpoints returns the indexes i of the distances the text is split at, i.e. between the sentences i and i+1.
+	Breakpoints(distances []float64) []int
+}
+
+// PercentileBreakpoint splits where the distance is greater than the Percentile of all distances.
+type PercentileBreakpoint struct {
+	// Percentile in (0, 1), 0.95 by default.
+	Percentile float64
+}
+
+func (p *PercentileBreakpoint) Breakpoints(distances []float64) []int {
+	percentile := p.Percentile
+	if percentile <= 0 {
+		percentile = 0.95
+	}
+	return above(distances, distances, calPercentile(distances, percentile))
+}
+
+// StandardDeviationBreakpoint splits where the distance is greater than the mean plus Amount standard deviations.
+type StandardDeviationBreakpoint struct {
+	// Amount of standard deviations, 3 by default.
+	Amount float64
+}
+
+func (s *StandardDeviationBreakpoint) Breakpoints(distances []float64) []int {
+	amount := s.Amount
+	if amount <= 0 {
+		amount = 3
+	}
+	mean, std := meanStd(distances)
+	return above(distances, distances, mean+amount*std)
+}
+
+// InterquartileBreakpoint splits where the distance is greater than the mean plus Amount interquartile ranges.
+type InterquartileBreakpoint struct {
+	// Amount of interquartile ranges, 1.5 by default.
+	Amount float64
+}
+
+func (q *InterquartileBreakpoint) Breakpoints(distances []float64) []int {
+	amount := q.Amount
+	if amount <= 0 {
+		amount = 1.5
+	}
+	mean, _ := meanStd(distances)
+	iqr := calPercentile(distances, 0.75) - calPercentile(distances, 0.25)
+	return above(distances, distances, mean+amount*iqr)
+}
+
+// GradientBreakpoint splits where the gradient of the distances is greater than the Percentile of all gradients,
+// which suits the texts of a single topic, e.g. legal or medical documents, whose distances are all close.
+type GradientBreakpoint struct {
+	// Percentile in (0, 1), 0.95 by default.
+	Percentile float64
+}
+
+func (g *GradientBreakpoint) Breakpoints(distances []float64) []int {
+	percentile := g.Percentile
+	if percentile <= 0 {
+		percentile = 0.95
+	}
+	gradients := gradient(distances)
+	return above(distances, gradients, calPercentile(gradients, percentile))
+}
+
+// above returns the indexes of the values greater than the threshold.
+func above(distances, values []float64, threshold float64) []int {
+	var ret []int
+	for i := range distances {
+		if values[i] > threshold {
+			ret = append(ret, i)
+		}
+	}
+	return ret
+}
+
+// calPercentile returns the percentile of the values, linearly interpolated between the closest ranks.
+func calPercentile(values []float64, percentile float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	pos := percentile * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	if lower >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[lower] + (pos-float64(lower))*(sorted[lower+1]-sorted[lower])
+}
+
+func meanStd(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(variance / float64(len(values)))
+}
+
+// gradient returns the gradient of the values, with central differences inside and one-sided ones at the ends.
+func gradient(values []float64) []float64 {
+	n := len(values)
+	ret := make([]float64, n)
+	if n < 2 {
+		return ret
+	}
+	ret[0] = values[1] - values[0]
+	ret[n-1] = values[n-1] - values[n-2]
+	for i := 1; i < n-1; i++ {
+		ret[i] = (values[i+1] - values[i-1]) / 2
+	}
+	return ret
+}
@@ -22,10 +22,13 @@ import (
 	"math"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/cloudwego/eino/components/document"
 	"github.com/cloudwego/eino/components/embedding"
 	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/semantic/internal/provenance"
 )
 
 // IDGenerator generates new IDs for split chunks
@@ -48,7 +51,20 @@ type Config struct {
 	// LenFunc is used to calculate string length. Use builtin function len() by default.
 	LenFunc func(s string) int
 	// Percentile specifies the number of splitting. If the difference between two chunks is greater than X percentile, these two chunks will be split.
+	// 0.9 by default. Ignored if BreakpointStrategy is set.
 	Percentile float64
+	// BreakpointStrategy decides where the text is split from the differences between the adjacent chunks,
+	// e.g. StandardDeviationBreakpoint, InterquartileBreakpoint or GradientBreakpoint.
+	// PercentileBreakpoint of Percentile by default.
+	BreakpointStrategy BreakpointStrategy
+	// MaxChunkSize, if greater than zero, is the hard cap of the chunk size. Larger chunks are split again at their largest
+	// differences, and single sentences still larger than MaxChunkSize are split at the characters.
+	MaxChunkSize int
+	// BatchSize is the maximum number of texts embedded in a call, which some embedding services limit.
+	// All texts of a document are embedded in a call by default.
+	BatchSize int
+	// Concurrency is the maximum number of concurrent embedding calls, 1 by default.
+	Concurrency int
 	// IDGenerator is an optional function to generate new IDs for split chunks.
 	// If nil, the original document ID will be used for all splits.
 	IDGenerator IDGenerator
@@ -66,9 +82,20 @@ func NewSplitter(ctx context.Context, config *Config) (document.Transformer, err
 	if len(seps) == 0 {
 		seps = []string{"\n", ".", "?", "!"}
 	}
-	percentile := config.Percentile
-	if percentile == 0 {
-		percentile = 0.9
+	if config.MaxChunkSize < 0 || config.BatchSize < 0 || config.Concurrency < 0 {
+		return nil, fmt.Errorf("max chunk size, batch size and concurrency should not be negative")
+	}
+	strategy := config.BreakpointStrategy
+	if strategy == nil {
+		percentile := config.Percentile
+		if percentile == 0 {
+			percentile = 0.9
+		}
+		strategy = &PercentileBreakpoint{Percentile: percentile}
+	}
+	concurrency := config.Concurrency
+	if concurrency == 0 {
+		concurrency = 1
 	}
 	idGenerator := config.IDGenerator
 	if idGenerator == nil {
@@ -80,7 +107,10 @@ func NewSplitter(ctx context.Context, config *Config) (document.Transformer, err
 		minChunkSize: config.MinChunkSize,
 		separators:   seps,
 		lenFunc:      lenFunc,
-		percentile:   percentile,
+		strategy:     strategy,
+		maxChunkSize: config.MaxChunkSize,
+		batchSize:    config.BatchSize,
+		concurrency:  concurrency,
 		idGenerator:  idGenerator,
 	}, nil
 }
@@ -91,7 +121,10 @@ type splitter struct {
 	minChunkSize int
 	separators   []string
 	lenFunc      func(s string) int
-	percentile   float64
+	strategy     BreakpointStrategy
+	maxChunkSize int
+	batchSize    int
+	concurrency  int
 	idGenerator  IDGenerator
 }
 
@@ -102,13 +135,16 @@ func (s *splitter) Transform(ctx context.Context, docs []*schema.Document, opts
 		if err != nil {
 			return nil, fmt.Errorf("split document[%s] fail: %w", doc.ID, err)
 		}
+		chunks := make([]*schema.Document, 0, len(splits))
 		for i, split := range splits {
-			ret = append(ret, &schema.Document{
+			chunks = append(chunks, &schema.Document{
 				ID:       s.idGenerator(ctx, doc.ID, i),
 				Content:  split,
 				MetaData: deepCopyMap(doc.MetaData),
 			})
 		}
+		provenance.Annotate(doc.Content, chunks, provenance.Locate(doc.Content, splits))
+		ret = append(ret, chunks...)
 	}
 	return ret, nil
 }
@@ -120,13 +156,8 @@ func (s *splitter) splitText(ctx context.Context, text string, separators []stri
 		texts = splitTexts(texts, separators[i])
 	}
 
-	sentencesLength := make([]int, len(texts))
-	for i := range texts {
-		sentencesLength[i] = s.lenFunc(texts[i])
-	}
-
-	if len(texts) == 1 {
-		return texts, nil
+	if len(texts) <= 1 {
+		return s.capChunk([]string{text}, nil, 0, 1), nil
 	}
 
 	// combine
@@ -143,42 +174,134 @@ func (s *splitter) splitText(ctx context.Context, text string, separators []stri
 	}
 
 	// embedding
-	var vectors [][]float64
-	v, err := s.embedding.EmbedStrings(ctx, combinedSentences)
+	vectors, err := s.embed(ctx, combinedSentences)
 	if err != nil {
 		return nil, err
 	}
-	for i := range v {
-		vectors = append(vectors, v[i])
-	}
 
-	// cosine distances
-	distances := make([]float64, len(texts))
-	for i := 1; i < len(texts); i++ {
-		distances[i] = 1 - cosine(vectors[i-1], vectors[i])
+	// cosine distances, distances[i] is the one between the sentences i and i+1
+	distances := make([]float64, len(texts)-1)
+	for i := range distances {
+		distances[i] = 1 - cosine(vectors[i], vectors[i+1])
 	}
 
-	threshold := calThreshold(distances, s.percentile)
-	var splitIndexes []int
-	for i := 1; i < len(distances); i++ {
-		if distances[i] <= threshold {
-			splitIndexes = append(splitIndexes, i)
-		}
-	}
+	breakpoints := s.strategy.Breakpoints(distances)
+	sort.Ints(breakpoints)
+
 	var ret []string
 	var startIndex int
-	for i := range splitIndexes {
-		chunk := strings.Join(texts[startIndex:splitIndexes[i]], "")
-		if len(chunk) < s.minChunkSize {
+	for _, b := range breakpoints {
+		endIndex := b + 1
+		if endIndex <= startIndex || endIndex >= len(texts) {
 			continue
 		}
-		ret = append(ret, chunk)
-		startIndex = splitIndexes[i]
+		if s.lenFunc(strings.Join(texts[startIndex:endIndex], "")) < s.minChunkSize {
+			continue
+		}
+		ret = append(ret, s.capChunk(texts, distances, startIndex, endIndex)...)
+		startIndex = endIndex
 	}
-	ret = append(ret, strings.Join(texts[startIndex:], ""))
+	ret = append(ret, s.capChunk(texts, distances, startIndex, len(texts))...)
 	return ret, nil
 }
 
+// embed embeds the texts in batches of the batch size.
+func (s *splitter) embed(ctx context.Context, texts []string) ([][]float64, error) {
+	batchSize := s.batchSize
+	if batchSize <= 0 {
+		batchSize = len(texts)
+	}
+
+	vectors := make([][]float64, len(texts))
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	sem := make(chan struct{}, s.concurrency)
+	for start := 0; start < len(texts); start += batchSize {
+		end := min(start+batchSize, len(texts))
+
+		sem <- struct{}{}
+		mu.Lock()
+		failed := firstErr != nil
+		mu.Unlock()
+		if failed {
+			<-sem
+			break
+		}
+
+		wg.Add(1)
+		go func(start, end int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			v, err := s.embedding.EmbedStrings(ctx, texts[start:end])
+			if err == nil && len(v) != end-start {
+				err = fmt.Errorf("embedding returned %d vectors for %d texts", len(v), end-start)
+			}
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+				return
+			}
+			copy(vectors[start:end], v)
+		}(start, end)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return vectors, nil
+}
+
+// capChunk joins the sentences [start, end) into chunks no larger than the max chunk size,
+// splitting at the largest distance between the sentences first.
+func (s *splitter) capChunk(texts []string, distances []float64, start, end int) []string {
+	chunk := strings.Join(texts[start:end], "")
+	if s.maxChunkSize <= 0 || s.lenFunc(chunk) <= s.maxChunkSize {
+		return []string{chunk}
+	}
+	if end-start == 1 {
+		return s.splitRunes(chunk)
+	}
+
+	split := start
+	for i := start + 1; i < end-1; i++ {
+		if distances[i] > distances[split] {
+			split = i
+		}
+	}
+	return append(s.capChunk(texts, distances, start, split+1), s.capChunk(texts, distances, split+1, end)...)
+}
+
+// splitRunes splits the text into the longest prefixes no larger than the max chunk size.
+func (s *splitter) splitRunes(text string) []string {
+	var ret []string
+	runes := []rune(text)
+	for len(runes) > 0 {
+		// at least one rune is taken, even if it alone exceeds the max chunk size
+		lo, hi := 1, len(runes)
+		for lo < hi {
+			mid := (lo + hi + 1) / 2
+			if s.lenFunc(string(runes[:mid])) <= s.maxChunkSize {
+				lo = mid
+			} else {
+				hi = mid - 1
+			}
+		}
+		ret = append(ret, string(runes[:lo]))
+		runes = runes[lo:]
+	}
+	return ret
+}
+
 func (s *splitter) GetType() string {
 	return "SemanticSplitter"
 }
@@ -201,22 +324,15 @@ func dot(x, y []float64) float64 {
 func splitTexts(texts []string, sep string) []string {
 	var ret []string
 	for i := range texts {
-		ret = append(ret, strings.SplitAfter(texts[i], sep)...)
+		for _, t := range strings.SplitAfter(texts[i], sep) {
+			if t != "" {
+				ret = append(ret, t)
+			}
+		}
 	}
 	return ret
 }
 
-func calThreshold(distances []float64, percentile float64) float64 {
-	sorted := make([]float64, len(distances))
-	copy(sorted, distances)
-	sort.Float64s(sorted)
-	idx := int((1 - percentile) * float64(len(sorted)))
-	if idx == 0 {
-		idx = 1
-	}
-	return sorted[idx]
-}
-
 func deepCopyMap(m map[string]interface{}) map[string]interface{} {
 	if m == nil {
 		return nil
@@ -18,13 +18,17 @@ package semantic
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"github.com/cloudwego/eino/components/document"
-	"github.com/cloudwego/eino/components/embedding"
-	"github.com/cloudwego/eino/schema"
 	"math/rand/v2"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
 )
 
 type randomEmbedding struct {
@@ -71,7 +75,7 @@ func TestSemanticSplitter(t *testing.T) {
 			input: []*schema.Document{{
 				Content: "1234567890.1234567890.1234567890.1234567890.1234567890.1234567890",
 			}},
-			outputLen: 4,
+			outputLen: 3,
 		},
 		{
 			name: "corner case: text has not exceeded MinChunkSize",
@@ -116,7 +120,7 @@ func TestSemanticSplitter(t *testing.T) {
 			input: []*schema.Document{{
 				Content: "1234567890.1234567890.1234567890.1234567890.1234567890.1234567890",
 			}},
-			outputLen: 6,
+			outputLen: 5,
 		},
 	}
 	ctx := context.Background()
@@ -138,3 +142,222 @@ func TestSemanticSplitter(t *testing.T) {
 		})
 	}
 }
+
+// topicEmbedding embeds the texts by the counts of the topic letters, so that sentences of the same topic are close.
+type topicEmbedding struct {
+	mu      sync.Mutex
+	batches []int
+	err     error
+}
+
+func (e *topicEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	e.mu.Lock()
+	e.batches = append(e.batches, len(texts))
+	e.mu.Unlock()
+	if e.err != nil {
+		return nil, e.err
+	}
+	var ret [][]float64
+	for _, text := range texts {
+		ret = append(ret, []float64{
+			float64(strings.Count(text, "a")) + 0.01,
+			float64(strings.Count(text, "b")) + 0.01,
+			float64(strings.Count(text, "c")) + 0.01,
+		})
+	}
+	return ret, nil
+}
+
+func TestSemanticSplitterTopics(t *testing.T) {
+	ctx := context.Background()
+	input := []*schema.Document{{Content: "aaa.aab.aaa.bbb.bba.bbb.ccc.cca.ccc."}}
+
+	tests := []struct {
+		name   string
+		config *Config
+		want   []string
+	}{
+		{
+			name: "percentile",
+			config: &Config{
+				Separators:         []string{"."},
+				BreakpointStrategy: &PercentileBreakpoint{Percentile: 0.7},
+			},
+			want: []string{"aaa.aab.aaa.", "bbb.bba.bbb.", "ccc.cca.ccc."},
+		},
+		{
+			name: "standard deviation",
+			config: &Config{
+				Separators:         []string{"."},
+				BreakpointStrategy: &StandardDeviationBreakpoint{Amount: 1},
+			},
+			want: []string{"aaa.aab.aaa.", "bbb.bba.bbb.", "ccc.cca.ccc."},
+		},
+		{
+			name: "interquartile",
+			config: &Config{
+				Separators:         []string{"."},
+				BreakpointStrategy: &InterquartileBreakpoint{Amount: 0.5},
+			},
+			want: []string{"aaa.aab.aaa.", "bbb.bba.bbb.", "ccc.cca.ccc."},
+		},
+		{
+			name: "max chunk size",
+			config: &Config{
+				Separators:         []string{"."},
+				BreakpointStrategy: &PercentileBreakpoint{Percentile: 0.99},
+				MaxChunkSize:       9,
+			},
+			want: []string{"aaa.", "aab.aaa.", "bbb.", "bba.bbb.", "ccc.", "cca.ccc."},
+		},
+		{
+			name: "batches",
+			config: &Config{
+				Separators:         []string{"."},
+				BreakpointStrategy: &PercentileBreakpoint{Percentile: 0.7},
+				BatchSize:          4,
+				Concurrency:        2,
+			},
+			want: []string{"aaa.aab.aaa.", "bbb.bba.bbb.", "ccc.cca.ccc."},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			emb := &topicEmbedding{}
+			tt.config.Embedding = emb
+			s, err := NewSplitter(ctx, tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			docs, err := s.Transform(ctx, input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, doc := range docs {
+				got = append(got, doc.Content)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Transform() got = %q, want %q", got, tt.want)
+			}
+			if tt.config.BatchSize > 0 {
+				total := 0
+				for _, b := range emb.batches {
+					if b > tt.config.BatchSize {
+						t.Errorf("batch of %d texts exceeds batch size", b)
+					}
+					total += b
+				}
+				if len(emb.batches) != 3 || total != 9 {
+					t.Errorf("got batches %v, want 3 batches of 9 texts", emb.batches)
+				}
+			}
+		})
+	}
+}
+
+func TestSemanticSplitterMaxChunkSizeOfSentence(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewSplitter(ctx, &Config{
+		Embedding:    &topicEmbedding{},
+		Separators:   []string{"."},
+		MaxChunkSize: 4,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	docs, err := s.Transform(ctx, []*schema.Document{{Content: "abcdefghij"}, {Content: ""}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, doc := range docs {
+		got = append(got, doc.Content)
+	}
+	if want := []string{"abcd", "efgh", "ij", ""}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Transform() got = %q, want %q", got, want)
+	}
+}
+
+func TestSemanticSplitterErrors(t *testing.T) {
+	ctx := context.Background()
+	if _, err := NewSplitter(ctx, &Config{}); err == nil {
+		t.Error("NewSplitter() without embedding should fail")
+	}
+	if _, err := NewSplitter(ctx, &Config{Embedding: &topicEmbedding{}, BatchSize: -1}); err == nil {
+		t.Error("NewSplitter() with negative batch size should fail")
+	}
+
+	s, err := NewSplitter(ctx, &Config{
+		Embedding:  &topicEmbedding{err: errors.New("quota exceeded")},
+		Separators: []string{"."},
+		BatchSize:  1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.Transform(ctx, []*schema.Document{{ID: "doc", Content: "a.b.c"}})
+	if err == nil || !strings.Contains(err.Error(), "quota exceeded") {
+		t.Errorf("Transform() err = %v, want quota exceeded", err)
+	}
+}
+
+func TestBreakpoints(t *testing.T) {
+	distances := []float64{0.1, 0.1, 0.8, 0.1, 0.12, 0.1, 0.9, 0.1}
+	tests := []struct {
+		name     string
+		strategy BreakpointStrategy
+		want     []int
+	}{
+		{name: "percentile", strategy: &PercentileBreakpoint{Percentile: 0.8}, want: []int{2, 6}},
+		{name: "percentile default", strategy: &PercentileBreakpoint{}, want: []int{6}},
+		{name: "standard deviation", strategy: &StandardDeviationBreakpoint{Amount: 1}, want: []int{2, 6}},
+		{name: "standard deviation default", strategy: &StandardDeviationBreakpoint{}, want: nil},
+		{name: "interquartile", strategy: &InterquartileBreakpoint{}, want: []int{2, 6}},
+		{name: "gradient", strategy: &GradientBreakpoint{Percentile: 0.7}, want: []int{1, 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.strategy.Breakpoints(distances); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Breakpoints() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSemanticSplitterProvenance(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewSplitter(ctx, &Config{
+		Embedding:          &topicEmbedding{},
+		Separators:         []string{"。"},
+		BreakpointStrategy: &PercentileBreakpoint{Percentile: 0.5},
+		IDGenerator: func(ctx context.Context, originalID string, splitIndex int) string {
+			return fmt.Sprintf("%s_%d", originalID, splitIndex)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text := "aa句。aa句。bb句。bb句。"
+	docs, err := s.Transform(ctx, []*schema.Document{{ID: "doc", Content: text}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 2 {
+		t.Fatalf("Transform() got %d chunks, want 2", len(docs))
+	}
+	runes := []rune(text)
+	for i, doc := range docs {
+		start, end := doc.MetaData[MetaKeyStartOffset].(int), doc.MetaData[MetaKeyEndOffset].(int)
+		if got := string(runes[start:end]); got != doc.Content {
+			t.Errorf("chunk %d at [%d, %d) = %q, want %q", i, start, end, got, doc.Content)
+		}
+		if doc.MetaData[MetaKeyChunkIndex] != i {
+			t.Errorf("chunk %d index = %v", i, doc.MetaData[MetaKeyChunkIndex])
+		}
+	}
+	if docs[0].MetaData[MetaKeyNextChunkID] != "doc_1" || docs[1].MetaData[MetaKeyPrevChunkID] != "doc_0" {
+		t.Errorf("chunks not linked: %v, %v", docs[0].MetaData, docs[1].MetaData)
+	}
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/token"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// the tokenizer of the embedding model the chunks are fed to
+	tokenizer, err := token.NewTiktokenForModel("text-embedding-3-small")
+	if err != nil {
+		log.Fatalf("NewTiktokenForModel failed, err=%v", err)
+	}
+
+	splitter, err := token.NewSplitter(ctx, &token.Config{
+		ChunkSize:   256,
+		OverlapSize: 32,
+		Tokenizer:   tokenizer,
+	})
+	if err != nil {
+		log.Fatalf("NewSplitter of token splitter failed, err=%v", err)
+	}
+
+	data, err := os.ReadFile("./testdata/einodoc.md")
+	if err != nil {
+		log.Fatalf("read file failed, err=%v", err)
+	}
+
+	docs, err := splitter.Transform(ctx, []*schema.Document{
+		{
+			Content: string(data),
+		},
+	})
+	if err != nil {
+		log.Fatalf("Transform of token splitter failed, err=%v", err)
+	}
+
+	for idx, doc := range docs {
+		fmt.Printf("====== %02d (%d tokens) ======\n", idx, doc.MetaData[token.MetaKeyTokenCount])
+		fmt.Println(doc.Content)
+	}
+}
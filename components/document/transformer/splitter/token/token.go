@@ -0,0 +1,258 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package token
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/token/internal/provenance"
+)
+
+const (
+	// MetaKeyTokenCount is the metadata key of the token count of a chunk.
+	MetaKeyTokenCount = "_token_count"
+	// MetaKeyStartToken is the metadata key of the number of tokens of the original document content before a chunk.
+	MetaKeyStartToken = "_start_token"
+	// MetaKeyEndToken is the metadata key of the token offset in the original document content where a chunk ends, exclusive.
+	MetaKeyEndToken = "_end_token"
+)
+
+// IDGenerator generates new IDs for split chunks
+type IDGenerator func(ctx context.Context, originalID string, splitIndex int) string
+
+// defaultIDGenerator keeps the original ID
+func defaultIDGenerator(ctx context.Context, originalID string, _ int) string {
+	return originalID
+}
+
+var defaultSeparators = []string{"\n\n", "\n", "。", ". ", "！", "! ", "？", "? ", "；", "; ", "，", ", ", " "}
+
+type Config struct {
+	// ChunkSize is the maximum number of tokens of a chunk. Required.
+	ChunkSize int
+	// OverlapSize is the maximum number of tokens of the end of a chunk repeated at the start of the next one.
+	// It must be less than ChunkSize.
+	OverlapSize int
+	// Tokenizer counts the tokens, it should match the embedding or chat model the chunks are fed to.
+	// Tiktoken of cl100k_base by default.
+	Tokenizer Tokenizer
+	// Separators are the boundaries the text is split at, tried in order until the pieces fit in ChunkSize.
+	// Pieces still too large without any separator are split at the characters.
+	// Paragraphs, lines, sentences, clauses and words, both in Chinese and English, by default.
+	Separators []string
+	// IDGenerator is an optional function to generate new IDs for split chunks.
+	// If nil, the original document ID will be used for all splits.
+	IDGenerator IDGenerator
+}
+
+// NewSplitter creates a splitter measuring the chunk size and overlap in tokens.
+func NewSplitter(ctx context.Context, config *Config) (document.Transformer, error) {
+	if config == nil {
+		return nil, fmt.Errorf("new token splitter, config is nil")
+	}
+	if config.ChunkSize <= 0 {
+		return nil, fmt.Errorf("chunk size must be greater than zero")
+	}
+	if config.OverlapSize < 0 {
+		return nil, fmt.Errorf("overlap must be greater than or equal to zero")
+	}
+	if config.OverlapSize >= config.ChunkSize {
+		return nil, fmt.Errorf("overlap must be less than chunk size")
+	}
+
+	tokenizer := config.Tokenizer
+	if tokenizer == nil {
+		var err error
+		tokenizer, err = NewTiktoken(EncodingCL100kBase)
+		if err != nil {
+			return nil, err
+		}
+	}
+	seps := config.Separators
+	if len(seps) == 0 {
+		seps = defaultSeparators
+	}
+	idGenerator := config.IDGenerator
+	if idGenerator == nil {
+		idGenerator = defaultIDGenerator
+	}
+
+	return &splitter{
+		tokenizer:   tokenizer,
+		chunkSize:   config.ChunkSize,
+		overlap:     config.OverlapSize,
+		separators:  seps,
+		idGenerator: idGenerator,
+	}, nil
+}
+
+type splitter struct {
+	tokenizer   Tokenizer
+	chunkSize   int
+	overlap     int
+	separators  []string
+	idGenerator IDGenerator
+}
+
+type piece struct {
+	text   string
+	tokens int
+}
+
+func (s *splitter) Transform(ctx context.Context, docs []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	ret := make([]*schema.Document, 0, len(docs))
+	for _, doc := range docs {
+		pieces := s.merge(s.split(doc.Content, s.separators, nil))
+		texts := make([]string, 0, len(pieces))
+		chunks := make([]*schema.Document, 0, len(pieces))
+		for i, p := range pieces {
+			meta := make(map[string]any, len(doc.MetaData)+8)
+			for k, v := range doc.MetaData {
+				meta[k] = v
+			}
+			meta[MetaKeyTokenCount] = p.tokens
+			texts = append(texts, p.text)
+			chunks = append(chunks, &schema.Document{
+				ID:       s.idGenerator(ctx, doc.ID, i),
+				Content:  p.text,
+				MetaData: meta,
+			})
+		}
+
+		ranges := provenance.Locate(doc.Content, texts)
+		provenance.Annotate(doc.Content, chunks, ranges)
+		s.addTokenOffsets(doc.Content, chunks, pieces, ranges)
+		ret = append(ret, chunks...)
+	}
+	return ret, nil
+}
+
+// addTokenOffsets sets the token offsets of the chunks in the text. The tokens before a chunk are counted segment by segment
+// between the starts of the chunks rather than over the whole prefix, which may differ by a few tokens at the joints.
+func (s *splitter) addTokenOffsets(text string, chunks []*schema.Document, pieces []piece, ranges [][2]int) {
+	tokens, pos := 0, 0
+	for i, chunk := range chunks {
+		start := ranges[i][0]
+		if start < 0 {
+			continue
+		}
+		tokens += s.tokenizer.CountTokens(text[pos:start])
+		pos = start
+		chunk.MetaData[MetaKeyStartToken] = tokens
+		chunk.MetaData[MetaKeyEndToken] = tokens + pieces[i].tokens
+	}
+}
+
+func (s *splitter) GetType() string {
+	return "TokenSplitter"
+}
+
+// split cuts the text into pieces fitting in the chunk size, the separators are kept at the end of the pieces,
+// so that the pieces joined are the text.
+func (s *splitter) split(text string, separators []string, pieces []piece) []piece {
+	if text == "" {
+		return pieces
+	}
+	if tokens := s.tokenizer.CountTokens(text); tokens <= s.chunkSize {
+		return append(pieces, piece{text: text, tokens: tokens})
+	}
+
+	for i, sep := range separators {
+		if sep == "" || !strings.Contains(text, sep) {
+			continue
+		}
+		for _, part := range strings.SplitAfter(text, sep) {
+			pieces = s.split(part, separators[i+1:], pieces)
+		}
+		return pieces
+	}
+
+	return s.splitRunes(text, pieces)
+}
+
+// splitRunes cuts the text at the characters, each piece being the longest prefix fitting in the chunk size.
+func (s *splitter) splitRunes(text string, pieces []piece) []piece {
+	runes := []rune(text)
+	for len(runes) > 0 {
+		// at least one rune is taken, even if it alone exceeds the chunk size
+		lo, hi := 1, len(runes)
+		tokens := s.tokenizer.CountTokens(string(runes[:1]))
+		for lo < hi {
+			mid := (lo + hi + 1) / 2
+			if n := s.tokenizer.CountTokens(string(runes[:mid])); n <= s.chunkSize {
+				lo, tokens = mid, n
+			} else {
+				hi = mid - 1
+			}
+		}
+		pieces = append(pieces, piece{text: string(runes[:lo]), tokens: tokens})
+		runes = runes[lo:]
+	}
+	return pieces
+}
+
+// merge joins the consecutive pieces into chunks up to the chunk size, starting each chunk with the last pieces
+// of the previous one up to the overlap size.
+func (s *splitter) merge(pieces []piece) []piece {
+	var chunks []piece
+	for start := 0; start < len(pieces); {
+		end, sum := start+1, pieces[start].tokens
+		for end < len(pieces) && sum+pieces[end].tokens <= s.chunkSize {
+			sum += pieces[end].tokens
+			end++
+		}
+
+		// the tokens of the pieces don't add up exactly, the tokens at the joints may merge or not
+		text, tokens := s.join(pieces[start:end])
+		for end-start > 1 && tokens > s.chunkSize {
+			end--
+			text, tokens = s.join(pieces[start:end])
+		}
+		if text != "" {
+			chunks = append(chunks, piece{text: text, tokens: tokens})
+		}
+		if end == len(pieces) {
+			break
+		}
+
+		next, overlap := end, 0
+		for next-1 > start && overlap+pieces[next-1].tokens <= s.overlap &&
+			overlap+pieces[next-1].tokens+pieces[end].tokens <= s.chunkSize {
+			next--
+			overlap += pieces[next].tokens
+		}
+		start = next
+	}
+	return chunks
+}
+
+func (s *splitter) join(pieces []piece) (string, int) {
+	var sb strings.Builder
+	for _, p := range pieces {
+		sb.WriteString(p.text)
+	}
+	text := strings.TrimSpace(sb.String())
+	if text == "" {
+		return "", 0
+	}
+	return text, s.tokenizer.CountTokens(text)
+}
@@ -0,0 +1,227 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package token
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var wordTokenizer = TokenizerFunc(func(text string) int {
+	return len(strings.Fields(text))
+})
+
+var runeTokenizer = TokenizerFunc(utf8.RuneCountInString)
+
+func contents(docs []*schema.Document) []string {
+	ret := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		ret = append(ret, doc.Content)
+	}
+	return ret
+}
+
+func TestNewSplitter(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewSplitter(ctx, nil)
+	assert.ErrorContains(t, err, "config is nil")
+	_, err = NewSplitter(ctx, &Config{})
+	assert.ErrorContains(t, err, "chunk size must be greater than zero")
+	_, err = NewSplitter(ctx, &Config{ChunkSize: 10, OverlapSize: -1})
+	assert.ErrorContains(t, err, "overlap must be greater than or equal to zero")
+	_, err = NewSplitter(ctx, &Config{ChunkSize: 10, OverlapSize: 10})
+	assert.ErrorContains(t, err, "overlap must be less than chunk size")
+
+	s, err := NewSplitter(ctx, &Config{ChunkSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, "TokenSplitter", s.(*splitter).GetType())
+}
+
+func TestTransform(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		config *Config
+		input  string
+		want   []string
+	}{
+		{
+			name:   "fits",
+			config: &Config{ChunkSize: 10, Tokenizer: wordTokenizer},
+			input:  "  a b c  ",
+			want:   []string{"a b c"},
+		},
+		{
+			name:   "overlap",
+			config: &Config{ChunkSize: 3, OverlapSize: 1, Tokenizer: wordTokenizer},
+			input:  "a b c d e f g h",
+			want:   []string{"a b c", "c d e", "e f g", "g h"},
+		},
+		{
+			name:   "no overlap",
+			config: &Config{ChunkSize: 3, Tokenizer: wordTokenizer},
+			input:  "a b c d e f g h",
+			want:   []string{"a b c", "d e f", "g h"},
+		},
+		{
+			name:   "paragraphs first",
+			config: &Config{ChunkSize: 4, Tokenizer: wordTokenizer},
+			input:  "a b\n\nc d e\n\nf g h i j",
+			want:   []string{"a b", "c d e\n\nf", "g h i j"},
+		},
+		{
+			name:   "sentences",
+			config: &Config{ChunkSize: 5, Tokenizer: wordTokenizer},
+			input:  "One two three. Four five. Six seven eight nine.",
+			want:   []string{"One two three. Four five.", "Six seven eight nine."},
+		},
+		{
+			name:   "runes",
+			config: &Config{ChunkSize: 3, Tokenizer: runeTokenizer},
+			input:  "abcdefg",
+			want:   []string{"abc", "def", "g"},
+		},
+		{
+			name:   "custom separators",
+			config: &Config{ChunkSize: 2, Tokenizer: wordTokenizer, Separators: []string{"|"}},
+			input:  "a b|c|d e f",
+			want:   []string{"a b|", "c|", "d e", "f"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewSplitter(ctx, tt.config)
+			require.NoError(t, err)
+			docs, err := s.Transform(ctx, []*schema.Document{{Content: tt.input}})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, contents(docs))
+		})
+	}
+}
+
+func TestTransformMeta(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewSplitter(ctx, &Config{
+		ChunkSize: 2,
+		Tokenizer: wordTokenizer,
+		IDGenerator: func(ctx context.Context, originalID string, splitIndex int) string {
+			return fmt.Sprintf("%s_%d", originalID, splitIndex)
+		},
+	})
+	require.NoError(t, err)
+
+	meta := map[string]any{"k": "v"}
+	docs, err := s.Transform(ctx, []*schema.Document{{ID: "doc", Content: "a b c", MetaData: meta}})
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	assert.Equal(t, "doc_0", docs[0].ID)
+	assert.Equal(t, "doc_1", docs[1].ID)
+	assert.Equal(t, map[string]any{
+		"k": "v", MetaKeyTokenCount: 2, MetaKeyChunkIndex: 0, MetaKeyNextChunkID: "doc_1",
+		MetaKeyStartOffset: 0, MetaKeyEndOffset: 3, MetaKeyStartToken: 0, MetaKeyEndToken: 2,
+	}, docs[0].MetaData)
+	assert.Equal(t, map[string]any{
+		"k": "v", MetaKeyTokenCount: 1, MetaKeyChunkIndex: 1, MetaKeyPrevChunkID: "doc_0",
+		MetaKeyStartOffset: 4, MetaKeyEndOffset: 5, MetaKeyStartToken: 2, MetaKeyEndToken: 3,
+	}, docs[1].MetaData)
+	assert.Equal(t, map[string]any{"k": "v"}, meta)
+}
+
+func TestTiktoken(t *testing.T) {
+	ctx := context.Background()
+
+	tk, err := NewTiktoken(EncodingCL100kBase)
+	require.NoError(t, err)
+	assert.Equal(t, 2, tk.CountTokens("hello world"))
+	assert.Equal(t, 7, tk.CountTokens("<|endoftext|>"))
+
+	o200k, err := NewTiktokenForModel("gpt-4o-mini")
+	require.NoError(t, err)
+	assert.Equal(t, 2, o200k.CountTokens("hello world"))
+
+	_, err = NewTiktoken("unknown")
+	assert.Error(t, err)
+	_, err = NewTiktokenForModel("unknown")
+	assert.Error(t, err)
+
+	var sb strings.Builder
+	for i := 0; i < 50; i++ {
+		fmt.Fprintf(&sb, "Sentence number %d talks about splitting documents by tokens. 这是第%d个中文句子，用来测试分词。\n", i, i)
+		if i%10 == 9 {
+			sb.WriteString("\n")
+		}
+	}
+
+	s, err := NewSplitter(ctx, &Config{ChunkSize: 100, OverlapSize: 40})
+	require.NoError(t, err)
+	docs, err := s.Transform(ctx, []*schema.Document{{Content: sb.String()}})
+	require.NoError(t, err)
+	require.Greater(t, len(docs), 10)
+
+	runes := []rune(sb.String())
+	for i, doc := range docs {
+		start, end := doc.MetaData[MetaKeyStartOffset].(int), doc.MetaData[MetaKeyEndOffset].(int)
+		assert.Equal(t, doc.Content, string(runes[start:end]))
+		startToken := doc.MetaData[MetaKeyStartToken].(int)
+		assert.InDelta(t, tk.CountTokens(string(runes[:start])), startToken, float64(i+1))
+
+		tokens := tk.CountTokens(doc.Content)
+		assert.LessOrEqual(t, tokens, 100)
+		assert.Equal(t, tokens, doc.MetaData[MetaKeyTokenCount])
+		assert.True(t, utf8.ValidString(doc.Content))
+		if i > 0 {
+			// the first line of a chunk is the last one of the previous chunk
+			first := strings.SplitN(doc.Content, "\n", 2)[0]
+			assert.Contains(t, docs[i-1].Content, first)
+		}
+	}
+	assert.Contains(t, docs[len(docs)-1].Content, "Sentence number 49")
+}
+
+func TestBPETokenizer(t *testing.T) {
+	var ranks bytes.Buffer
+	for i := 0; i < 256; i++ {
+		fmt.Fprintf(&ranks, "%s %d\n", base64.StdEncoding.EncodeToString([]byte{byte(i)}), i)
+	}
+	fmt.Fprintf(&ranks, "%s %d\n", base64.StdEncoding.EncodeToString([]byte("ab")), 256)
+
+	tk, err := NewBPETokenizer(&ranks, "")
+	require.NoError(t, err)
+	assert.Equal(t, 2, tk.CountTokens("abab"))
+	assert.Equal(t, 2, tk.CountTokens("abc"))
+	assert.Equal(t, 4, tk.CountTokens("ab 12"))
+
+	_, err = NewBPETokenizer(strings.NewReader(""), PatternQwen)
+	assert.ErrorContains(t, err, "ranks are empty")
+	_, err = NewBPETokenizer(strings.NewReader("YQ== 0 1\n"), "")
+	assert.ErrorContains(t, err, "invalid ranks at line 1")
+	_, err = NewBPETokenizer(strings.NewReader("!!! 0\n"), "")
+	assert.ErrorContains(t, err, "invalid token at line 1")
+	_, err = NewBPETokenizer(strings.NewReader("YQ== x\n"), "")
+	assert.ErrorContains(t, err, "invalid rank at line 1")
+}
@@ -0,0 +1,140 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package token
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkoukk/tiktoken-go"
+	tiktokenloader "github.com/pkoukk/tiktoken-go-loader"
+)
+
+// Tokenizer counts the tokens of a text, it should be the tokenizer of the model the chunks are fed to.
+type Tokenizer interface {
+	CountTokens(text string) int
+}
+
+// TokenizerFunc adapts a function to Tokenizer, e.g. a binding of the Hugging Face tokenizers.
+type TokenizerFunc func(text string) int
+
+func (f TokenizerFunc) CountTokens(text string) int {
+	return f(text)
+}
+
+// Encodings of the OpenAI models.
+const (
+	EncodingO200kBase  = tiktoken.MODEL_O200K_BASE
+	EncodingCL100kBase = tiktoken.MODEL_CL100K_BASE
+	EncodingP50kBase   = tiktoken.MODEL_P50K_BASE
+	EncodingR50kBase   = tiktoken.MODEL_R50K_BASE
+)
+
+// PatternCL100k is the pre-tokenization pattern of cl100k_base, also used by Llama 3.
+const PatternCL100k = `(?i:'s|'t|'re|'ve|'m|'ll|'d)|[^\r\n\p{L}\p{N}]?\p{L}+|\p{N}{1,3}| ?[^\s\p{L}\p{N}]+[\r\n]*|\s*[\r\n]+|\s+(?!\S)|\s+`
+
+// PatternQwen is the pre-tokenization pattern of the Qwen models, which splits every digit.
+const PatternQwen = `(?i:'s|'t|'re|'ve|'m|'ll|'d)|[^\r\n\p{L}\p{N}]?\p{L}+|\p{N}| ?[^\s\p{L}\p{N}]+[\r\n]*|\s*[\r\n]+|\s+(?!\S)|\s+`
+
+var setLoaderOnce sync.Once
+
+// the ranks files are embedded, so that no download happens on the first use of an encoding
+func setOfflineLoader() {
+	setLoaderOnce.Do(func() {
+		tiktoken.SetBpeLoader(tiktokenloader.NewOfflineLoader())
+	})
+}
+
+type tiktokenTokenizer struct {
+	enc *tiktoken.Tiktoken
+}
+
+// CountTokens counts the ordinary tokens, special tokens such as <|endoftext|> in the text are encoded as plain text.
+func (t *tiktokenTokenizer) CountTokens(text string) int {
+	return len(t.enc.EncodeOrdinary(text))
+}
+
+// NewTiktoken creates a tiktoken tokenizer of the encoding, e.g. EncodingCL100kBase.
+func NewTiktoken(encoding string) (Tokenizer, error) {
+	setOfflineLoader()
+	enc, err := tiktoken.GetEncoding(encoding)
+	if err != nil {
+		return nil, fmt.Errorf("get tiktoken encoding %s failed: %w", encoding, err)
+	}
+	return &tiktokenTokenizer{enc: enc}, nil
+}
+
+// NewTiktokenForModel creates a tiktoken tokenizer of the OpenAI model, e.g. gpt-4o or text-embedding-3-small.
+func NewTiktokenForModel(model string) (Tokenizer, error) {
+	setOfflineLoader()
+	enc, err := tiktoken.EncodingForModel(model)
+	if err != nil {
+		return nil, fmt.Errorf("get tiktoken encoding of model %s failed: %w", model, err)
+	}
+	return &tiktokenTokenizer{enc: enc}, nil
+}
+
+// NewBPETokenizer creates a byte pair encoding tokenizer from the ranks in the tiktoken format,
+// one base64 encoded token and its rank per line, as published by other model providers,
+// e.g. qwen.tiktoken of Qwen with PatternQwen, or tokenizer.model of Llama 3 with PatternCL100k.
+// PatternCL100k is used if pattern is empty.
+func NewBPETokenizer(ranks io.Reader, pattern string) (Tokenizer, error) {
+	if pattern == "" {
+		pattern = PatternCL100k
+	}
+
+	mergeableRanks := make(map[string]int)
+	scanner := bufio.NewScanner(ranks)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid ranks at line %d: %q", lineNum, line)
+		}
+		token, err := base64.StdEncoding.DecodeString(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid token at line %d: %w", lineNum, err)
+		}
+		rank, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid rank at line %d: %w", lineNum, err)
+		}
+		mergeableRanks[string(token)] = rank
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read ranks failed: %w", err)
+	}
+	if len(mergeableRanks) == 0 {
+		return nil, fmt.Errorf("ranks are empty")
+	}
+
+	bpe, err := tiktoken.NewCoreBPE(mergeableRanks, map[string]int{}, pattern)
+	if err != nil {
+		return nil, fmt.Errorf("new bpe failed: %w", err)
+	}
+	enc := tiktoken.NewTiktoken(bpe, &tiktoken.Encoding{PatStr: pattern, MergeableRanks: mergeableRanks}, map[string]any{})
+	return &tiktokenTokenizer{enc: enc}, nil
+}
@@ -0,0 +1,40 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package ark
+
+import (
+	"github.com/cloudwego/eino/components/embedding"
+)
+
+const keyOfBatchTokenUsage = "ark-batch-token-usage"
+
+// BatchTokenUsage is the token usage of a single embedding api call,
+// which embeds the texts[Offset:Offset+Count] of the EmbedStrings call.
+type BatchTokenUsage struct {
+	Offset int
+	Count  int
+	Usage  *embedding.TokenUsage
+}
+
+// GetBatchTokenUsage returns the token usage of each embedding api call of an EmbedStrings call,
+// ordered by offset, from the callback output.
+func GetBatchTokenUsage(output *embedding.CallbackOutput) ([]*BatchTokenUsage, bool) {
+	if output == nil || output.Extra == nil {
+		return nil, false
+	}
+	batches, ok := output.Extra[keyOfBatchTokenUsage].([]*BatchTokenUsage)
+	return batches, ok
+}
@@ -18,9 +18,12 @@ package ark
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/sync/errgroup"
@@ -28,16 +31,24 @@ import (
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components"
 	"github.com/cloudwego/eino/components/embedding"
+
 	"github.com/volcengine/volcengine-go-sdk/service/arkruntime"
 	"github.com/volcengine/volcengine-go-sdk/service/arkruntime/model"
+
+	"github.com/cloudwego/eino-ext/components/embedding/ark/internal/vector"
 )
 
+//go:generate sh ../../../libs/acl/bundle.sh vector internal/vector
+
 var (
 	// all default values are from github.com/volcengine/volcengine-go-sdk/service/arkruntime/config.go
 	defaultBaseURL    = "https://ark.cn-beijing.volces.com/api/v3"
 	defaultRegion     = "cn-beijing"
 	defaultRetryTimes = 2
 	defaultTimeout    = 10 * time.Minute
+
+	defaultMaxConcurrentRequests = 5
+	defaultBatchSize             = 256
 )
 
 type EmbeddingConfig struct {
@@ -77,9 +88,30 @@ type EmbeddingConfig struct {
 	// Optional. Default APITypeText
 	APIType *APIType `json:"api_type,omitempty"`
 
-	// MaxConcurrentRequests specifies the maximum number of concurrent multi-modal embedding api calls allowed
+	// MaxConcurrentRequests specifies the maximum number of concurrent embedding api calls allowed,
+	// the texts being embedded in batches with the text api, and one by one with the multi-modal api
 	// Optional. Default: 5
 	MaxConcurrentRequests *int `json:"max_concurrent_requests"`
+
+	// Dimensions specifies the number of dimensions of the output embeddings, for the models supporting it,
+	// e.g. doubao-embedding-vision-250615 and later with the multi-modal api, supporting 1024 or 2048
+	// Optional. Default: the dimensions of the model
+	Dimensions *int `json:"dimensions,omitempty"`
+
+	// BatchSize specifies the maximum number of texts per text embedding api call.
+	// Batches rejected as bad requests are split in halves and retried, the batch size being lowered
+	// for the following calls, down to the maximum batch size accepted by the api
+	// Optional. Default: 256
+	BatchSize *int `json:"batch_size,omitempty"`
+	// TargetDimensions truncates or zero-pads the output embeddings to this dimension on the client,
+	// so that the embeddings of models with different dimensions can share a fixed dimension store schema.
+	// Truncating keeps the meaning only for Matryoshka style models, set Normalize along with it
+	// Optional. Default: the embeddings are returned as is
+	TargetDimensions *int `json:"target_dimensions,omitempty"`
+
+	// Normalize L2-normalizes the output embeddings on the client, after TargetDimensions is applied
+	// Optional. Default: false
+	Normalize bool `json:"normalize,omitempty"`
 }
 
 type APIType string
@@ -104,6 +136,9 @@ const (
 type Embedder struct {
 	client *arkruntime.Client
 	conf   *EmbeddingConfig
+
+	// batchSize is the batch size lowered by the rejected batches, 0 if none
+	batchSize atomic.Int64
 }
 
 func buildClient(config *EmbeddingConfig) *arkruntime.Client {
@@ -122,11 +157,10 @@ func buildClient(config *EmbeddingConfig) *arkruntime.Client {
 	if config.APIType == nil {
 		apiType := APITypeText
 		config.APIType = &apiType
-	} else if *config.APIType == APITypeMultiModal {
-		if config.MaxConcurrentRequests == nil {
-			defaultMaxConcurrentRequests := 5
-			config.MaxConcurrentRequests = &defaultMaxConcurrentRequests
-		}
+	}
+	if config.MaxConcurrentRequests == nil {
+		maxConcurrentRequests := defaultMaxConcurrentRequests
+		config.MaxConcurrentRequests = &maxConcurrentRequests
 	}
 
 	opts := []arkruntime.ConfigOption{
@@ -179,75 +213,213 @@ func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...emb
 		}
 	}()
 
-	var usage *embedding.TokenUsage
-
+	var batches []*BatchTokenUsage
 	if e.conf.APIType == nil || *e.conf.APIType == APITypeText {
-		resp, err := e.client.CreateEmbeddings(ctx, model.EmbeddingRequestStrings{
-			Input:          texts,
-			Model:          conf.Model,
-			EncodingFormat: encodingFormat,
+		embeddings, batches, err = e.embedTexts(ctx, texts, conf.Model)
+	} else {
+		embeddings, batches, err = e.embedMultiModal(ctx, texts, conf.Model)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	embeddings = vector.PostProcess(embeddings, e.conf.TargetDimensions, e.conf.Normalize)
+
+	usage := &embedding.TokenUsage{}
+	for _, b := range batches {
+		usage.PromptTokens += b.Usage.PromptTokens
+		usage.CompletionTokens += b.Usage.CompletionTokens
+		usage.TotalTokens += b.Usage.TotalTokens
+	}
+
+	callbacks.OnEnd(ctx, &embedding.CallbackOutput{
+		Embeddings: embeddings,
+		Config:     conf,
+		TokenUsage: usage,
+		Extra:      map[string]any{keyOfBatchTokenUsage: batches},
+	})
+
+	return embeddings, nil
+}
+
+// embedTexts embeds the texts with the text api, in batches of up to the batch size.
+func (e *Embedder) embedTexts(ctx context.Context, texts []string, modelName string) (
+	[][]float64, []*BatchTokenUsage, error) {
+	embeddings := make([][]float64, len(texts))
+	var batches []*BatchTokenUsage
+
+	mu := sync.Mutex{}
+	eg := errgroup.Group{}
+	eg.SetLimit(e.maxConcurrentRequests())
+	size := e.getBatchSize()
+	for offset := 0; offset < len(texts); offset += size {
+		start, end := offset, offset+size
+		if end > len(texts) {
+			end = len(texts)
+		}
+
+		eg.Go(func() error {
+			vectors, usages, split, err := e.embedBatch(ctx, texts[start:end], start, modelName)
+			if err != nil {
+				return err
+			}
+			// lowered only once the split batches succeeded, a batch rejected down to a single text not being too large
+			if split > 0 {
+				e.lowerBatchSize(split)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			copy(embeddings[start:end], vectors)
+			batches = append(batches, usages...)
+			return nil
 		})
-		if err != nil {
-			return nil, fmt.Errorf("[Ark] CreateEmbeddings error: %w", err)
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, nil, err
+	}
+
+	sort.Slice(batches, func(i, j int) bool {
+		return batches[i].Offset < batches[j].Offset
+	})
+	return embeddings, batches, nil
+}
+
+// embedBatch embeds a batch of texts starting at offset, the batch being split in halves if rejected.
+// split is the size of the smallest batch embedded after splitting the rejected ones, 0 if none was rejected.
+func (e *Embedder) embedBatch(ctx context.Context, texts []string, offset int, modelName string) (
+	embeddings [][]float64, usages []*BatchTokenUsage, split int, err error) {
+	resp, err := e.client.CreateEmbeddings(ctx, model.EmbeddingRequestStrings{
+		Input:          texts,
+		Model:          modelName,
+		EncodingFormat: model.EmbeddingEncodingFormatFloat,
+		Dimensions:     dereferenceOrZero(e.conf.Dimensions),
+	})
+	if err != nil {
+		if len(texts) > 1 && isBadRequest(err) {
+			half := len(texts) / 2
+			left, leftUsages, leftSplit, err := e.embedBatch(ctx, texts[:half], offset, modelName)
+			if err != nil {
+				return nil, nil, 0, err
+			}
+			right, rightUsages, rightSplit, err := e.embedBatch(ctx, texts[half:], offset+half, modelName)
+			if err != nil {
+				return nil, nil, 0, err
+			}
+			split = half
+			for _, s := range []int{leftSplit, rightSplit} {
+				if s > 0 && s < split {
+					split = s
+				}
+			}
+			return append(left, right...), append(leftUsages, rightUsages...), split, nil
 		}
+		return nil, nil, 0, fmt.Errorf("[Ark] CreateEmbeddings error: %w", err)
+	}
+	if len(resp.Data) != len(texts) {
+		return nil, nil, 0, fmt.Errorf("[Ark] CreateEmbeddings error: got %d embeddings for %d texts", len(resp.Data), len(texts))
+	}
 
-		usage = &embedding.TokenUsage{
+	embeddings = make([][]float64, len(resp.Data))
+	for i, d := range resp.Data {
+		embeddings[i] = toFloat64(d.Embedding)
+	}
+	return embeddings, []*BatchTokenUsage{{
+		Offset: offset,
+		Count:  len(texts),
+		Usage: &embedding.TokenUsage{
 			PromptTokens:     resp.Usage.PromptTokens,
 			CompletionTokens: resp.Usage.CompletionTokens,
 			TotalTokens:      resp.Usage.TotalTokens,
-		}
+		},
+	}}, 0, nil
+}
 
-		embeddings = make([][]float64, len(resp.Data))
-		for i, d := range resp.Data {
-			embeddings[i] = toFloat64(d.Embedding)
-		}
-	} else {
-		mu := sync.Mutex{}
-		eg := errgroup.Group{}
-		eg.SetLimit(*e.conf.MaxConcurrentRequests)
-		usage = &embedding.TokenUsage{}
-		embeddings = make([][]float64, len(texts))
-
-		for i := 0; i < len(texts); i++ {
-			idx := i
-			text := texts[idx]
-
-			eg.Go(func() error {
-				res, err := e.client.CreateMultiModalEmbeddings(ctx, model.MultiModalEmbeddingRequest{
-					Input: []model.MultimodalEmbeddingInput{
-						{Type: model.MultiModalEmbeddingInputTypeText, Text: &text},
-					},
-					Model:          conf.Model,
-					EncodingFormat: &encodingFormat,
-				})
-				if err != nil {
-					return fmt.Errorf("[Ark] CreateMultiModalEmbeddings error: %w", err)
-				}
+// embedMultiModal embeds the texts one by one with the multi-modal api.
+func (e *Embedder) embedMultiModal(ctx context.Context, texts []string, modelName string) (
+	[][]float64, []*BatchTokenUsage, error) {
+	encodingFormat := model.EmbeddingEncodingFormatFloat
+	embeddings := make([][]float64, len(texts))
+	batches := make([]*BatchTokenUsage, len(texts))
+
+	eg := errgroup.Group{}
+	eg.SetLimit(e.maxConcurrentRequests())
+	for i := 0; i < len(texts); i++ {
+		idx := i
+		text := texts[idx]
+
+		eg.Go(func() error {
+			res, err := e.client.CreateMultiModalEmbeddings(ctx, model.MultiModalEmbeddingRequest{
+				Input: []model.MultimodalEmbeddingInput{
+					{Type: model.MultiModalEmbeddingInputTypeText, Text: &text},
+				},
+				Model:          modelName,
+				EncodingFormat: &encodingFormat,
+				Dimensions:     e.conf.Dimensions,
+			})
+			if err != nil {
+				return fmt.Errorf("[Ark] CreateMultiModalEmbeddings error: %w", err)
+			}
+
+			embeddings[idx] = toFloat64(res.Data.Embedding)
+			batches[idx] = &BatchTokenUsage{
+				Offset: idx,
+				Count:  1,
+				Usage: &embedding.TokenUsage{
+					PromptTokens:     res.Usage.PromptTokens,
+					CompletionTokens: res.Usage.TotalTokens - res.Usage.PromptTokens,
+					TotalTokens:      res.Usage.TotalTokens,
+				},
+			}
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, nil, err
+	}
+	return embeddings, batches, nil
+}
 
-				mu.Lock()
-				defer mu.Unlock()
+func (e *Embedder) maxConcurrentRequests() int {
+	if e.conf.MaxConcurrentRequests == nil || *e.conf.MaxConcurrentRequests <= 0 {
+		return defaultMaxConcurrentRequests
+	}
+	return *e.conf.MaxConcurrentRequests
+}
 
-				usage.PromptTokens += res.Usage.PromptTokens
-				usage.CompletionTokens += res.Usage.TotalTokens - res.Usage.PromptTokens
-				usage.TotalTokens += res.Usage.TotalTokens
-				embeddings[idx] = toFloat64(res.Data.Embedding)
+func (e *Embedder) getBatchSize() int {
+	if size := e.batchSize.Load(); size > 0 {
+		return int(size)
+	}
+	if e.conf.BatchSize != nil && *e.conf.BatchSize > 0 {
+		return *e.conf.BatchSize
+	}
+	return defaultBatchSize
+}
 
-				return nil
-			})
+// lowerBatchSize lowers the batch size of the following calls to size, if lower.
+func (e *Embedder) lowerBatchSize(size int) {
+	for {
+		cur := e.batchSize.Load()
+		if cur > 0 && cur <= int64(size) {
+			return
 		}
-
-		if err = eg.Wait(); err != nil {
-			return nil, err
+		if e.batchSize.CompareAndSwap(cur, int64(size)) {
+			return
 		}
 	}
+}
 
-	callbacks.OnEnd(ctx, &embedding.CallbackOutput{
-		Embeddings: embeddings,
-		Config:     conf,
-		TokenUsage: usage,
-	})
-
-	return embeddings, nil
+func isBadRequest(err error) bool {
+	var apiErr *model.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.HTTPStatusCode == http.StatusBadRequest
+	}
+	var reqErr *model.RequestError
+	if errors.As(err, &reqErr) {
+		return reqErr.HTTPStatusCode == http.StatusBadRequest
+	}
+	return false
 }
 
 func (e *Embedder) GetType() string {
@@ -18,7 +18,14 @@ package ark
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 
 	. "github.com/bytedance/mockey"
@@ -26,6 +33,7 @@ import (
 	"github.com/volcengine/volcengine-go-sdk/service/arkruntime"
 	"github.com/volcengine/volcengine-go-sdk/service/arkruntime/model"
 
+	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components/embedding"
 )
 
@@ -135,3 +143,136 @@ func TestAPITypeMultiModal(t *testing.T) {
 		})
 	})
 }
+
+func TestEmbedStringsBatch(t *testing.T) {
+	convey.Convey("test EmbedStrings batches", t, func() {
+		ctx := context.Background()
+
+		var mu sync.Mutex
+		var sizes []int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			req := model.EmbeddingRequestStrings{}
+			_ = json.NewDecoder(r.Body).Decode(&req)
+
+			mu.Lock()
+			sizes = append(sizes, len(req.Input))
+			mu.Unlock()
+
+			w.Header().Set("Content-Type", "application/json")
+			if len(req.Input) > 2 || slices.Contains(req.Input, "invalid") {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":{"code":"InvalidParameter","message":"too many inputs","type":"BadRequest"}}`))
+				return
+			}
+
+			resp := model.EmbeddingResponse{Usage: model.Usage{PromptTokens: len(req.Input), TotalTokens: len(req.Input)}}
+			for i, text := range req.Input {
+				idx, _ := strconv.Atoi(strings.TrimPrefix(text, "t"))
+				resp.Data = append(resp.Data, model.Embedding{
+					Embedding: []float32{float32(idx), float32(req.Dimensions)},
+					Index:     i,
+					Object:    "embedding",
+				})
+			}
+			_ = json.NewEncoder(w).Encode(resp)
+		}))
+		defer server.Close()
+
+		batchSize, dimensions, mcr, retryTimes := 4, 1024, 1, 0
+		emb, err := NewEmbedder(ctx, &EmbeddingConfig{
+			APIKey:                "mock",
+			BaseURL:               server.URL,
+			Model:                 "mock",
+			RetryTimes:            &retryTimes,
+			MaxConcurrentRequests: &mcr,
+			Dimensions:            &dimensions,
+			BatchSize:             &batchSize,
+		})
+		convey.So(err, convey.ShouldBeNil)
+
+		var output *embedding.CallbackOutput
+		handler := callbacks.NewHandlerBuilder().OnEndFn(
+			func(ctx context.Context, info *callbacks.RunInfo, out callbacks.CallbackOutput) context.Context {
+				output = embedding.ConvCallbackOutput(out)
+				return ctx
+			}).Build()
+		ctx = callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, handler)
+
+		texts := []string{"t0", "t1", "t2", "t3", "t4"}
+		res, err := emb.EmbedStrings(ctx, texts)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(len(res), convey.ShouldEqual, len(texts))
+		for i := range texts {
+			convey.So(res[i], convey.ShouldResemble, []float64{float64(i), float64(dimensions)})
+		}
+		convey.So(sizes, convey.ShouldResemble, []int{4, 2, 2, 1})
+		convey.So(emb.getBatchSize(), convey.ShouldEqual, 2)
+
+		convey.So(output, convey.ShouldNotBeNil)
+		convey.So(output.TokenUsage.TotalTokens, convey.ShouldEqual, 5)
+		batches, ok := GetBatchTokenUsage(output)
+		convey.So(ok, convey.ShouldBeTrue)
+		convey.So(len(batches), convey.ShouldEqual, 3)
+		convey.So(batches[0].Offset, convey.ShouldEqual, 0)
+		convey.So(batches[0].Count, convey.ShouldEqual, 2)
+		convey.So(batches[1].Offset, convey.ShouldEqual, 2)
+		convey.So(batches[1].Usage.PromptTokens, convey.ShouldEqual, 2)
+		convey.So(batches[2].Offset, convey.ShouldEqual, 4)
+		convey.So(batches[2].Count, convey.ShouldEqual, 1)
+
+		sizes = nil
+		_, err = emb.EmbedStrings(ctx, texts)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(sizes, convey.ShouldResemble, []int{2, 2, 1})
+
+		// a text rejected alone does not lower the batch size
+		emb, err = NewEmbedder(ctx, &EmbeddingConfig{
+			APIKey:                "mock",
+			BaseURL:               server.URL,
+			Model:                 "mock",
+			RetryTimes:            &retryTimes,
+			MaxConcurrentRequests: &mcr,
+			BatchSize:             &batchSize,
+		})
+		convey.So(err, convey.ShouldBeNil)
+		sizes = nil
+		_, err = emb.EmbedStrings(ctx, []string{"invalid", "t1"})
+		convey.So(err, convey.ShouldNotBeNil)
+		convey.So(sizes, convey.ShouldResemble, []int{2, 1})
+		convey.So(emb.getBatchSize(), convey.ShouldEqual, batchSize)
+	})
+}
+
+func TestEmbedStringsPostProcess(t *testing.T) {
+	convey.Convey("test EmbedStrings target dimensions and normalize", t, func() {
+		ctx := context.Background()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(model.EmbeddingResponse{
+				Data: []model.Embedding{{Embedding: []float32{3, 4, 12}, Object: "embedding"}},
+			})
+		}))
+		defer server.Close()
+
+		targetDimensions, retryTimes := 2, 0
+		emb, err := NewEmbedder(ctx, &EmbeddingConfig{
+			APIKey:           "mock",
+			BaseURL:          server.URL,
+			Model:            "mock",
+			RetryTimes:       &retryTimes,
+			TargetDimensions: &targetDimensions,
+			Normalize:        true,
+		})
+		convey.So(err, convey.ShouldBeNil)
+
+		res, err := emb.EmbedStrings(ctx, []string{"t0"})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(res, convey.ShouldResemble, [][]float64{{0.6, 0.8}})
+
+		targetDimensions = 4
+		emb.conf.Normalize = false
+		res, err = emb.EmbedStrings(ctx, []string{"t0"})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(res, convey.ShouldResemble, [][]float64{{3, 4, 12, 0}})
+	})
+}
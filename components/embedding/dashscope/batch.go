@@ -0,0 +1,261 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package dashscope
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/embedding"
+)
+
+const (
+	defaultBatchModel   = "text-embedding-async-v2"
+	defaultPollInterval = 5 * time.Second
+)
+
+// TaskStatus is the status of an async batch embedding task.
+type TaskStatus string
+
+const (
+	TaskStatusPending   TaskStatus = "PENDING"
+	TaskStatusRunning   TaskStatus = "RUNNING"
+	TaskStatusSucceeded TaskStatus = "SUCCEEDED"
+	TaskStatusFailed    TaskStatus = "FAILED"
+	TaskStatusCanceled  TaskStatus = "CANCELED"
+	TaskStatusUnknown   TaskStatus = "UNKNOWN"
+)
+
+// BatchProgress is the progress of an async batch embedding task, reported by WithProgressCallback.
+type BatchProgress struct {
+	TaskID string
+	Status TaskStatus
+	// Total is the number of texts in the input file, 0 until the task starts running
+	Total     int
+	Succeeded int
+	Failed    int
+}
+
+// BatchResult is the result of an async batch embedding task.
+type BatchResult struct {
+	TaskID string
+	// ResultURL is the url of the result file, which expires after 24 hours
+	ResultURL string
+	// Embeddings are indexed by the line number of the texts in the input file, nil for the failed texts
+	Embeddings [][]float64
+	// Failures are the error messages of the failed texts, keyed by the line number in the input file
+	Failures   map[int]string
+	TokenUsage *embedding.TokenUsage
+}
+
+// EmbedBatch embeds the texts of a large corpus with the async batch embedding API, and waits for the result.
+// The inputURL is a publicly accessible url of a text file, with a text per line.
+// It is equivalent to SubmitBatch followed by WaitBatch.
+func (e *Embedder) EmbedBatch(ctx context.Context, inputURL string, opts ...embedding.Option) (result *BatchResult, err error) {
+	defer func() {
+		if err != nil {
+			_ = callbacks.OnError(ctx, err)
+		}
+	}()
+
+	conf := &embedding.Config{
+		Model:          e.batchModel(opts...),
+		EncodingFormat: "float",
+	}
+	ctx = callbacks.OnStart(ctx, &embedding.CallbackInput{
+		Config: conf,
+		Extra:  map[string]any{"input_url": inputURL},
+	})
+
+	taskID, err := e.SubmitBatch(ctx, inputURL, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err = e.WaitBatch(ctx, taskID, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = callbacks.OnEnd(ctx, &embedding.CallbackOutput{
+		Embeddings: result.Embeddings,
+		Config:     conf,
+		TokenUsage: result.TokenUsage,
+		Extra:      map[string]any{"task_id": taskID},
+	})
+
+	return result, nil
+}
+
+// SubmitBatch submits an async batch embedding task of the texts in the file at inputURL, a text per line,
+// and returns the task id to be waited by WaitBatch.
+func (e *Embedder) SubmitBatch(ctx context.Context, inputURL string, opts ...embedding.Option) (string, error) {
+	specOptions := embedding.GetImplSpecificOptions(&options{TextType: e.config.TextType}, opts...)
+
+	req := &textEmbeddingRequest{
+		Model: e.batchModel(opts...),
+		Input: textEmbeddingInput{URL: inputURL},
+	}
+	if specOptions.TextType != nil {
+		req.Parameters.TextType = *specOptions.TextType
+	}
+
+	resp, err := e.native.submitTask(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("submit batch embedding task fail: %w", err)
+	}
+	if resp.Output.TaskID == "" {
+		return "", fmt.Errorf("submit batch embedding task fail: empty task id, request id: %s", resp.RequestID)
+	}
+	return resp.Output.TaskID, nil
+}
+
+// WaitBatch polls the async batch embedding task until it completes, reporting its progress to WithProgressCallback,
+// then downloads and parses its result.
+func (e *Embedder) WaitBatch(ctx context.Context, taskID string, opts ...embedding.Option) (*BatchResult, error) {
+	specOptions := embedding.GetImplSpecificOptions(&options{PollInterval: e.config.PollInterval}, opts...)
+	interval := specOptions.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	for {
+		task, err := e.native.getTask(ctx, taskID)
+		if err != nil {
+			return nil, fmt.Errorf("get batch embedding task fail: %w", err)
+		}
+
+		if specOptions.OnProgress != nil {
+			specOptions.OnProgress(ctx, &BatchProgress{
+				TaskID:    taskID,
+				Status:    task.Output.TaskStatus,
+				Total:     task.Output.TaskMetrics.Total,
+				Succeeded: task.Output.TaskMetrics.Succeeded,
+				Failed:    task.Output.TaskMetrics.Failed,
+			})
+		}
+
+		switch task.Output.TaskStatus {
+		case TaskStatusSucceeded:
+			return e.fetchBatchResult(ctx, task)
+		case TaskStatusFailed, TaskStatusCanceled, TaskStatusUnknown:
+			return nil, fmt.Errorf("batch embedding task %s %s, code: %s, message: %s",
+				taskID, task.Output.TaskStatus, task.Output.Code, task.Output.Message)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+type batchResultLine struct {
+	Output struct {
+		Code      int       `json:"code"`
+		Message   string    `json:"message"`
+		TextIndex int       `json:"text_index"`
+		Embedding []float64 `json:"embedding"`
+	} `json:"output"`
+}
+
+func (e *Embedder) fetchBatchResult(ctx context.Context, task *taskResponse) (*BatchResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, task.Output.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create batch result request fail: %w", err)
+	}
+	resp, err := e.native.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("download batch result fail: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download batch result fail: status code %d", resp.StatusCode)
+	}
+
+	// the result file is usually gzipped, detected by its magic number
+	body := bufio.NewReader(resp.Body)
+	var reader io.Reader = body
+	if magic, _ := body.Peek(2); bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			return nil, fmt.Errorf("read batch result fail: %w", err)
+		}
+		defer gz.Close()
+		reader = gz
+	}
+
+	result := &BatchResult{
+		TaskID:     task.Output.TaskID,
+		ResultURL:  task.Output.URL,
+		Embeddings: make([][]float64, task.Output.TaskMetrics.Total),
+		TokenUsage: &embedding.TokenUsage{
+			PromptTokens: task.Usage.TotalTokens,
+			TotalTokens:  task.Usage.TotalTokens,
+		},
+	}
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		line := &batchResultLine{}
+		if err = json.Unmarshal(scanner.Bytes(), line); err != nil {
+			return nil, fmt.Errorf("unmarshal batch result fail: %w", err)
+		}
+
+		idx := line.Output.TextIndex
+		if idx < 0 {
+			return nil, fmt.Errorf("unexpected text index %d in batch result", idx)
+		}
+		for len(result.Embeddings) <= idx {
+			result.Embeddings = append(result.Embeddings, nil)
+		}
+		if line.Output.Code != http.StatusOK {
+			if result.Failures == nil {
+				result.Failures = make(map[int]string)
+			}
+			result.Failures[idx] = line.Output.Message
+			continue
+		}
+		result.Embeddings[idx] = line.Output.Embedding
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read batch result fail: %w", err)
+	}
+	result.Embeddings = e.postProcess(result.Embeddings)
+
+	return result, nil
+}
+
+func (e *Embedder) batchModel(opts ...embedding.Option) string {
+	model := e.config.BatchModel
+	if model == "" {
+		model = defaultBatchModel
+	}
+	return *embedding.GetCommonOptions(&embedding.Options{Model: &model}, opts...).Model
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package dashscope
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/embedding"
+)
+
+func TestEmbedBatch(t *testing.T) {
+	var polls atomic.Int32
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case textEmbeddingPath:
+			if r.Header.Get("X-DashScope-Async") != "enable" || r.Header.Get("Authorization") != "Bearer mock_key" {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"code":"InvalidApiKey","message":"invalid","request_id":"r0"}`))
+				return
+			}
+			req := &textEmbeddingRequest{}
+			_ = json.NewDecoder(r.Body).Decode(req)
+			if req.Model != "text-embedding-async-v2" || req.Input.URL != "https://mock/input.txt" || req.Parameters.TextType != TextTypeDocument {
+				t.Errorf("unexpected submit request: %+v", req)
+			}
+			_, _ = w.Write([]byte(`{"output":{"task_id":"task-1","task_status":"PENDING"},"request_id":"r1"}`))
+		case taskPath + "task-1":
+			status := []string{"PENDING", "RUNNING", "SUCCEEDED"}[min(int(polls.Add(1))-1, 2)]
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"output": map[string]any{
+					"task_id":      "task-1",
+					"task_status":  status,
+					"url":          server.URL + "/result.txt.gz",
+					"task_metrics": map[string]int{"TOTAL": 3, "SUCCEEDED": 2, "FAILED": 1},
+				},
+				"usage": map[string]int{"total_tokens": 9},
+			})
+		case "/result.txt.gz":
+			gz := gzip.NewWriter(w)
+			_, _ = gz.Write([]byte(`{"output":{"code":200,"text_index":1,"embedding":[0.3,0.4]}}
+{"output":{"code":400,"text_index":2,"message":"empty text"}}
+{"output":{"code":200,"text_index":0,"embedding":[0.1,0.2]}}
+`))
+			_ = gz.Close()
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	textType := TextTypeDocument
+	emb, err := NewEmbedder(ctx, &EmbeddingConfig{
+		APIKey:        "mock_key",
+		NativeBaseURL: server.URL,
+		TextType:      &textType,
+		PollInterval:  time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var progresses []TaskStatus
+	result, err := emb.EmbedBatch(ctx, "https://mock/input.txt",
+		WithProgressCallback(func(ctx context.Context, progress *BatchProgress) {
+			progresses = append(progresses, progress.Status)
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(progresses, []TaskStatus{TaskStatusPending, TaskStatusRunning, TaskStatusSucceeded}) {
+		t.Fatalf("unexpected progresses: %v", progresses)
+	}
+	if !reflect.DeepEqual(result.Embeddings, [][]float64{{0.1, 0.2}, {0.3, 0.4}, nil}) {
+		t.Fatalf("unexpected embeddings: %v", result.Embeddings)
+	}
+	if !reflect.DeepEqual(result.Failures, map[int]string{2: "empty text"}) {
+		t.Fatalf("unexpected failures: %v", result.Failures)
+	}
+	if result.TaskID != "task-1" || result.TokenUsage.TotalTokens != 9 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	t.Run("submit error", func(t *testing.T) {
+		emb, err := NewEmbedder(ctx, &EmbeddingConfig{APIKey: "wrong_key", NativeBaseURL: server.URL})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = emb.EmbedBatch(ctx, "https://mock/input.txt")
+		apiErr := &APIError{}
+		if !errors.As(err, &apiErr) || apiErr.Code != "InvalidApiKey" || apiErr.HTTPStatusCode != http.StatusUnauthorized {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("task failed", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"output":{"task_id":"task-2","task_status":"FAILED","code":"InvalidFile","message":"bad file"}}`))
+		}))
+		defer server.Close()
+
+		emb, err := NewEmbedder(ctx, &EmbeddingConfig{APIKey: "mock_key", NativeBaseURL: server.URL})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = emb.WaitBatch(ctx, "task-2", embedding.WithModel("text-embedding-async-v1"))
+		if err == nil || !bytes.Contains([]byte(err.Error()), []byte("bad file")) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
@@ -18,13 +18,18 @@ package dashscope
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/cloudwego/eino-ext/components/embedding/dashscope/internal/vector"
 	"github.com/cloudwego/eino-ext/libs/acl/openai"
+	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components/embedding"
 )
 
+//go:generate sh ../../../libs/acl/bundle.sh vector internal/vector
+
 const (
 	baseUrl    = "https://dashscope.aliyuncs.com/compatible-mode/v1"
 	dimensions = 1024
@@ -46,15 +51,58 @@ type EmbeddingConfig struct {
 	// DashScope Ref: https://help.aliyun.com/zh/model-studio/developer-reference/text-embedding-synchronous-api?spm=a2c4g.11186623.help-menu-2400256.d_3_3_9_2.532bf440ali5Ry&scm=20140722.H_2712515._.OR_help-T_cn~zh-V_1
 
 	// Model available models: text_embedding_v / text_embedding_v2 / text_embedding_v3
-	// Async embedding models not support.
+	// Async embedding models are only supported by EmbedBatch, see BatchModel.
 	Model string `json:"model"`
 	// Dimensions specify output vector dimension.
 	// Only applicable to text-embedding-v3 model, can only be selected between three values: 1024, 768, and 512.
 	// The default value is 1024.
 	Dimensions *int `json:"dimensions,omitempty"`
+
+	// TextType specifies whether the texts are search queries or the documents searched, for asymmetric retrieval.
+	// It can be overridden per call by WithTextType.
+	// As the OpenAI compatible mode does not support it, the native DashScope API is used if it is set.
+	// Optional. Default: document
+	TextType *TextType `json:"text_type,omitempty"`
+
+	// NativeBaseURL specifies the base url of the native DashScope API, used for TextType and EmbedBatch.
+	// Optional. Default: "https://dashscope.aliyuncs.com/api/v1"
+	NativeBaseURL string `json:"native_base_url,omitempty"`
+
+	// BatchModel specifies the model of EmbedBatch: text-embedding-async-v1 / text-embedding-async-v2
+	// DashScope Ref: https://help.aliyun.com/zh/model-studio/developer-reference/text-embedding-batch-api
+	// Optional. Default: "text-embedding-async-v2"
+	BatchModel string `json:"batch_model,omitempty"`
+
+	// PollInterval specifies the interval of polling the status of the EmbedBatch task.
+	// It can be overridden per call by WithPollInterval.
+	// Optional. Default: 5s
+	PollInterval time.Duration `json:"poll_interval,omitempty"`
+	// TargetDimensions truncates or zero-pads the output embeddings to this dimension on the client,
+	// so that the embeddings of models with different dimensions can share a fixed dimension store schema.
+	// Unlike Dimensions it is applied after the api call, and also works with models not supporting Dimensions
+	// Optional. Default: the embeddings are returned as is
+	TargetDimensions *int `json:"target_dimensions,omitempty"`
+
+	// Normalize L2-normalizes the output embeddings on the client, after TargetDimensions is applied.
+	// Embeddings truncated by TargetDimensions are no longer unit length, set Normalize along with it
+	// Optional. Default: false
+	Normalize bool `json:"normalize,omitempty"`
 }
+
+// TextType is the text type of the texts to embed.
+type TextType string
+
+const (
+	// TextTypeQuery is for the search queries
+	TextTypeQuery TextType = "query"
+	// TextTypeDocument is for the documents searched
+	TextTypeDocument TextType = "document"
+)
+
 type Embedder struct {
-	cli *openai.EmbeddingClient
+	cli    *openai.EmbeddingClient
+	native *nativeClient
+	config *EmbeddingConfig
 }
 
 func NewEmbedder(ctx context.Context, config *EmbeddingConfig) (*Embedder, error) {
@@ -87,15 +135,96 @@ func NewEmbedder(ctx context.Context, config *EmbeddingConfig) (*Embedder, error
 		return nil, err
 	}
 
-	return &Embedder{cli: cli}, nil
+	nativeCli := &nativeClient{
+		baseURL:    config.NativeBaseURL,
+		apiKey:     config.APIKey,
+		httpClient: httpClient,
+	}
+	if nativeCli.baseURL == "" {
+		nativeCli.baseURL = nativeBaseURL
+	}
+
+	return &Embedder{cli: cli, native: nativeCli, config: config}, nil
 }
 
 func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
-	return e.cli.EmbedStrings(ctx, texts, opts...)
+	specOptions := embedding.GetImplSpecificOptions(&options{TextType: e.config.TextType}, opts...)
+	if specOptions.TextType == nil {
+		embeddings, err := e.cli.EmbedStrings(ctx, texts, opts...)
+		if err != nil {
+			return nil, err
+		}
+		// the callbacks of the client report the embeddings before post-processing
+		return e.postProcess(embeddings), nil
+	}
+	return e.embedStringsNative(ctx, texts, *specOptions.TextType, opts...)
+}
+
+// embedStringsNative embeds the texts with the native DashScope API, supporting the text type.
+func (e *Embedder) embedStringsNative(ctx context.Context, texts []string, textType TextType, opts ...embedding.Option) (
+	embeddings [][]float64, err error) {
+
+	defer func() {
+		if err != nil {
+			_ = callbacks.OnError(ctx, err)
+		}
+	}()
+
+	options := embedding.GetCommonOptions(&embedding.Options{Model: &e.config.Model}, opts...)
+	conf := &embedding.Config{
+		Model:          *options.Model,
+		EncodingFormat: string(openai.EmbeddingEncodingFormatFloat),
+	}
+
+	ctx = callbacks.OnStart(ctx, &embedding.CallbackInput{
+		Texts:  texts,
+		Config: conf,
+	})
+
+	dim := dimensions
+	if e.config.Dimensions != nil {
+		dim = *e.config.Dimensions
+	}
+	resp, err := e.native.embedTexts(ctx, &textEmbeddingRequest{
+		Model: conf.Model,
+		Input: textEmbeddingInput{Texts: texts},
+		Parameters: textEmbeddingParameters{
+			TextType:  textType,
+			Dimension: dim,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	embeddings = make([][]float64, len(texts))
+	for _, d := range resp.Output.Embeddings {
+		if d.TextIndex < 0 || d.TextIndex >= len(texts) {
+			return nil, fmt.Errorf("unexpected text index %d of %d texts", d.TextIndex, len(texts))
+		}
+		embeddings[d.TextIndex] = d.Embedding
+	}
+
+	embeddings = e.postProcess(embeddings)
+
+	_ = callbacks.OnEnd(ctx, &embedding.CallbackOutput{
+		Embeddings: embeddings,
+		Config:     conf,
+		TokenUsage: &embedding.TokenUsage{
+			PromptTokens: resp.Usage.TotalTokens,
+			TotalTokens:  resp.Usage.TotalTokens,
+		},
+	})
+
+	return embeddings, nil
 }
 
 const typ = "DashScope"
 
+func (e *Embedder) postProcess(embeddings [][]float64) [][]float64 {
+	return vector.PostProcess(embeddings, e.config.TargetDimensions, e.config.Normalize)
+}
+
 func (e *Embedder) GetType() string {
 	return typ
 }
@@ -18,7 +18,10 @@ package dashscope
 
 import (
 	"context"
+	"encoding/json"
 	"math"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 	"time"
@@ -103,3 +106,89 @@ func TestEmbedding(t *testing.T) {
 		}
 	})
 }
+
+func TestEmbeddingTextType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := &textEmbeddingRequest{}
+		_ = json.NewDecoder(r.Body).Decode(req)
+		if r.URL.Path != textEmbeddingPath || r.Header.Get("X-DashScope-Async") != "" {
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+		if req.Model != "text-embedding-v3" || req.Parameters.TextType != TextTypeQuery || req.Parameters.Dimension != 512 {
+			t.Errorf("unexpected request: %+v", req)
+		}
+		_, _ = w.Write([]byte(`{"output":{"embeddings":[{"text_index":1,"embedding":[0.3,0.4]},{"text_index":0,"embedding":[0.1,0.2]}]},"usage":{"total_tokens":4}}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	dim := 512
+	emb, err := NewEmbedder(ctx, &EmbeddingConfig{
+		APIKey:        "mock_key",
+		Model:         "text-embedding-v3",
+		Dimensions:    &dim,
+		NativeBaseURL: server.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var totalTokens int
+	handler := callbacks.NewHandlerBuilder().
+		OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+			totalTokens = embedding.ConvCallbackOutput(output).TokenUsage.TotalTokens
+			return ctx
+		})
+	ctx = callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, handler.Build())
+
+	result, err := emb.EmbedStrings(ctx, []string{"q1", "q2"}, WithTextType(TextTypeQuery))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(result, [][]float64{{0.1, 0.2}, {0.3, 0.4}}) {
+		t.Fatalf("unexpected result: %v", result)
+	}
+	if totalTokens != 4 {
+		t.Fatalf("unexpected total tokens: %d", totalTokens)
+	}
+}
+
+func TestEmbeddingPostProcess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"output":{"embeddings":[{"text_index":0,"embedding":[3,4,12]}]},"usage":{"total_tokens":1}}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	textType := TextTypeDocument
+	targetDimensions := 2
+	emb, err := NewEmbedder(ctx, &EmbeddingConfig{
+		APIKey:           "mock_key",
+		Model:            "text-embedding-v3",
+		TextType:         &textType,
+		NativeBaseURL:    server.URL,
+		TargetDimensions: &targetDimensions,
+		Normalize:        true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := emb.EmbedStrings(ctx, []string{"t0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(result, [][]float64{{0.6, 0.8}}) {
+		t.Fatalf("unexpected result: %v", result)
+	}
+
+	targetDimensions = 4
+	emb.config.Normalize = false
+	result, err = emb.EmbedStrings(ctx, []string{"t0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(result, [][]float64{{3, 4, 12, 0}}) {
+		t.Fatalf("unexpected result: %v", result)
+	}
+}
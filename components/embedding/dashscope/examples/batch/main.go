@@ -0,0 +1,56 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino-ext/components/embedding/dashscope"
+)
+
+func main() {
+	ctx := context.Background()
+	// see: https://help.aliyun.com/zh/model-studio/developer-reference/text-embedding-batch-api
+	apiKey := os.Getenv("DASHSCOPE_API_KEY")
+	// a publicly accessible text file, with a text per line
+	inputURL := os.Getenv("DASHSCOPE_INPUT_URL")
+
+	textType := dashscope.TextTypeDocument
+	embedder, err := dashscope.NewEmbedder(ctx, &dashscope.EmbeddingConfig{
+		APIKey:     apiKey,
+		BatchModel: "text-embedding-async-v2",
+		TextType:   &textType,
+	})
+	if err != nil {
+		log.Printf("new embedder error: %v\n", err)
+		return
+	}
+
+	result, err := embedder.EmbedBatch(ctx, inputURL,
+		dashscope.WithProgressCallback(func(ctx context.Context, progress *dashscope.BatchProgress) {
+			log.Printf("task %s %s: %d/%d succeeded, %d failed\n",
+				progress.TaskID, progress.Status, progress.Succeeded, progress.Total, progress.Failed)
+		}))
+	if err != nil {
+		log.Printf("batch embedding error: %v\n", err)
+		return
+	}
+
+	log.Printf("embeddings: %d, failures: %v, tokens: %d\n",
+		len(result.Embeddings), result.Failures, result.TokenUsage.TotalTokens)
+}
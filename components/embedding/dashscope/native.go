@@ -0,0 +1,172 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package dashscope
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	nativeBaseURL = "https://dashscope.aliyuncs.com/api/v1"
+
+	textEmbeddingPath = "/services/embeddings/text-embedding/text-embedding"
+	taskPath          = "/tasks/"
+)
+
+// APIError is the error returned by the native DashScope API.
+type APIError struct {
+	HTTPStatusCode int    `json:"-"`
+	Code           string `json:"code"`
+	Message        string `json:"message"`
+	RequestID      string `json:"request_id"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("dashscope api error, status code: %d, code: %s, message: %s, request id: %s",
+		e.HTTPStatusCode, e.Code, e.Message, e.RequestID)
+}
+
+type textEmbeddingRequest struct {
+	Model      string                  `json:"model"`
+	Input      textEmbeddingInput      `json:"input"`
+	Parameters textEmbeddingParameters `json:"parameters"`
+}
+
+type textEmbeddingInput struct {
+	Texts []string `json:"texts,omitempty"`
+	URL   string   `json:"url,omitempty"`
+}
+
+type textEmbeddingParameters struct {
+	TextType  TextType `json:"text_type,omitempty"`
+	Dimension int      `json:"dimension,omitempty"`
+}
+
+type textEmbeddingResponse struct {
+	Output struct {
+		Embeddings []struct {
+			TextIndex int       `json:"text_index"`
+			Embedding []float64 `json:"embedding"`
+		} `json:"embeddings"`
+	} `json:"output"`
+	Usage struct {
+		TotalTokens int `json:"total_tokens"`
+	} `json:"usage"`
+	RequestID string `json:"request_id"`
+}
+
+type taskResponse struct {
+	Output struct {
+		TaskID      string     `json:"task_id"`
+		TaskStatus  TaskStatus `json:"task_status"`
+		URL         string     `json:"url"`
+		Code        string     `json:"code"`
+		Message     string     `json:"message"`
+		TaskMetrics struct {
+			Total     int `json:"TOTAL"`
+			Succeeded int `json:"SUCCEEDED"`
+			Failed    int `json:"FAILED"`
+		} `json:"task_metrics"`
+	} `json:"output"`
+	Usage struct {
+		TotalTokens int `json:"total_tokens"`
+	} `json:"usage"`
+	RequestID string `json:"request_id"`
+}
+
+// nativeClient calls the native DashScope API, for the features not supported by the OpenAI compatible mode.
+type nativeClient struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+func (c *nativeClient) embedTexts(ctx context.Context, req *textEmbeddingRequest) (*textEmbeddingResponse, error) {
+	resp := &textEmbeddingResponse{}
+	if err := c.do(ctx, http.MethodPost, textEmbeddingPath, false, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *nativeClient) submitTask(ctx context.Context, req *textEmbeddingRequest) (*taskResponse, error) {
+	resp := &taskResponse{}
+	if err := c.do(ctx, http.MethodPost, textEmbeddingPath, true, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *nativeClient) getTask(ctx context.Context, taskID string) (*taskResponse, error) {
+	resp := &taskResponse{}
+	if err := c.do(ctx, http.MethodGet, taskPath+taskID, false, nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *nativeClient) do(ctx context.Context, method, path string, async bool, body, result any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshal request fail: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(c.baseURL, "/")+path, reader)
+	if err != nil {
+		return fmt.Errorf("create request fail: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if async {
+		req.Header.Set("X-DashScope-Async", "enable")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send request fail: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response fail: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &APIError{HTTPStatusCode: resp.StatusCode}
+		if err = json.Unmarshal(data, apiErr); err != nil {
+			apiErr.Message = string(data)
+		}
+		return apiErr
+	}
+
+	if err = json.Unmarshal(data, result); err != nil {
+		return fmt.Errorf("unmarshal response fail: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package dashscope
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudwego/eino/components/embedding"
+)
+
+// options is the specific options for the dashscope embedder
+type options struct {
+	// TextType overrides EmbeddingConfig.TextType
+	TextType *TextType
+	// PollInterval overrides EmbeddingConfig.PollInterval
+	PollInterval time.Duration
+	// OnProgress is called with the task progress after each poll of an async batch embedding task
+	OnProgress func(ctx context.Context, progress *BatchProgress)
+}
+
+// WithTextType is the option to set the text type of the texts to embed, e.g. TextTypeQuery for search queries.
+func WithTextType(textType TextType) embedding.Option {
+	return embedding.WrapImplSpecificOptFn(func(opt *options) {
+		opt.TextType = &textType
+	})
+}
+
+// WithPollInterval is the option to set the interval of polling the async batch embedding task status.
+func WithPollInterval(interval time.Duration) embedding.Option {
+	return embedding.WrapImplSpecificOptFn(func(opt *options) {
+		opt.PollInterval = interval
+	})
+}
+
+// WithProgressCallback is the option to receive the progress of the async batch embedding task after each poll.
+func WithProgressCallback(fn func(ctx context.Context, progress *BatchProgress)) embedding.Option {
+	return embedding.WrapImplSpecificOptFn(func(opt *options) {
+		opt.OnProgress = fn
+	})
+}
@@ -22,9 +22,14 @@ import (
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components"
 	"github.com/cloudwego/eino/components/embedding"
+
 	"google.golang.org/genai"
+
+	"github.com/cloudwego/eino-ext/components/embedding/gemini/internal/vector"
 )
 
+//go:generate sh ../../../libs/acl/bundle.sh vector internal/vector
+
 // EmbeddingConfig contains the configuration for the Gemini embedding model.
 type EmbeddingConfig struct {
 	// Client is the Gemimi API client instance
@@ -55,6 +60,15 @@ type EmbeddingConfig struct {
 	// the max sequence length. If this option is set to false, oversized inputs
 	// will lead to an INVALID_ARGUMENT error, similar to other text APIs.
 	AutoTruncate bool `json:"autoTruncate,omitempty"`
+	// Client side dimension of the output embedding, the embedding is truncated
+	// or zero-padded to it. Unlike OutputDimensionality it also applies to models
+	// without reduced dimension support, so that different models fit one schema.
+	TargetDimensions *int
+
+	// L2-normalize the output embedding on the client, after TargetDimensions.
+	// Recommended with OutputDimensionality or TargetDimensions, as truncated
+	// embeddings are not normalized.
+	Normalize bool
 }
 
 type Embedder struct {
@@ -132,6 +146,8 @@ func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...emb
 		}
 	}
 
+	embeddings = vector.PostProcess(embeddings, e.conf.TargetDimensions, e.conf.Normalize)
+
 	callbacks.OnEnd(ctx, &embedding.CallbackOutput{
 		Embeddings: embeddings,
 		Config:     conf,
@@ -69,5 +69,24 @@ func Test_EmbedStrings(t *testing.T) {
 				convey.So(len(result[i]), convey.ShouldEqual, len(expectedResult[i]))
 			}
 		})
+
+		PatchConvey("test target dimensions and normalize", func() {
+			Mock(GetMethod(mockCli.Models, "EmbedContent")).Return(&genai.EmbedContentResponse{
+				Embeddings: []*genai.ContentEmbedding{{Values: []float32{3, 4, 12}}},
+			}, nil).Build()
+
+			targetDimensions := 2
+			embedder.conf.TargetDimensions = &targetDimensions
+			embedder.conf.Normalize = true
+			result, err := embedder.EmbedStrings(ctx, []string{"hello world"})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(result, convey.ShouldResemble, [][]float64{{0.6, 0.8}})
+
+			targetDimensions = 4
+			embedder.conf.Normalize = false
+			result, err = embedder.EmbedStrings(ctx, []string{"hello world"})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(result, convey.ShouldResemble, [][]float64{{3, 4, 12, 0}})
+		})
 	})
 }
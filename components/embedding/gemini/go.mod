@@ -5,6 +5,7 @@ go 1.23.0
 require (
 	github.com/bytedance/mockey v1.2.12
 	github.com/cloudwego/eino v0.3.27
+	github.com/smartystreets/goconvey v1.8.1
 	google.golang.org/genai v1.18.0
 )
 
@@ -42,7 +43,6 @@ require (
 	github.com/sirupsen/logrus v1.9.3 // indirect
 	github.com/slongfield/pyfmt v0.0.0-20220222012616-ea85ff4c361f // indirect
 	github.com/smarty/assertions v1.15.0 // indirect
-	github.com/smartystreets/goconvey v1.8.1 // indirect
 	github.com/twitchyliquid64/golang-asm v0.15.1 // indirect
 	github.com/yargevad/filepathx v1.0.0 // indirect
 	go.opencensus.io v0.24.0 // indirect
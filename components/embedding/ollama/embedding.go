@@ -26,9 +26,14 @@ import (
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components"
 	"github.com/cloudwego/eino/components/embedding"
+
 	"github.com/ollama/ollama/api"
+
+	"github.com/cloudwego/eino-ext/components/embedding/ollama/internal/vector"
 )
 
+//go:generate sh ../../../libs/acl/bundle.sh vector internal/vector
+
 var (
 	defaultBaseUrl = "http://localhost:11434"
 )
@@ -72,6 +77,15 @@ type EmbeddingConfig struct {
 	// Options lists model-specific options.
 	// Optional
 	Options map[string]any `json:"options,omitempty"`
+	// TargetDimensions truncates or zero-pads the output embeddings to this dimension on the client,
+	// so that the embeddings of models with different dimensions can share a fixed dimension store schema.
+	// Truncating keeps the meaning only for Matryoshka style models, set Normalize along with it
+	// Optional. Default: the embeddings are returned as is
+	TargetDimensions *int `json:"target_dimensions,omitempty"`
+
+	// Normalize L2-normalizes the output embeddings on the client, after TargetDimensions is applied
+	// Optional. Default: false
+	Normalize bool `json:"normalize,omitempty"`
 }
 
 var _ embedding.Embedder = (*Embedder)(nil)
@@ -154,6 +168,8 @@ func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...emb
 		}
 	}
 
+	result = vector.PostProcess(result, e.conf.TargetDimensions, e.conf.Normalize)
+
 	extra := map[string]any{
 		TotalDuration:   resp.TotalDuration,
 		LoadDuration:    resp.LoadDuration,
@@ -154,4 +154,32 @@ func TestEmbedding(t *testing.T) {
 
 		assert.Equal(t, len(outEmbeddings[0]), expectedDimensions)
 	})
+
+	t.Run("target dimensions and normalize", func(t *testing.T) {
+		ctx := context.Background()
+		targetDimensions := 2
+		emb, err := NewEmbedder(ctx, &EmbeddingConfig{
+			Model:            model,
+			TargetDimensions: &targetDimensions,
+			Normalize:        true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer mockey.Mock((*api.Client).Embed).Return(&api.EmbedResponse{
+			Model:      model,
+			Embeddings: [][]float32{{3, 4, 12}},
+		}, nil).Build().UnPatch()
+
+		result, err := emb.EmbedStrings(ctx, []string{"hello world"})
+		assert.Nil(t, err)
+		assert.Equal(t, [][]float64{{0.6, 0.8}}, result)
+
+		targetDimensions = 4
+		emb.conf.Normalize = false
+		result, err = emb.EmbedStrings(ctx, []string{"hello world"})
+		assert.Nil(t, err)
+		assert.Equal(t, [][]float64{{3, 4, 12, 0}}, result)
+	})
 }
@@ -25,9 +25,12 @@ import (
 	"github.com/cloudwego/eino/components"
 	"github.com/cloudwego/eino/components/embedding"
 
+	"github.com/cloudwego/eino-ext/components/embedding/openai/internal/vector"
 	"github.com/cloudwego/eino-ext/libs/acl/openai"
 )
 
+//go:generate sh ../../../libs/acl/bundle.sh vector internal/vector
+
 const (
 	EmbeddingEncodingFormatFloat  = openai.EmbeddingEncodingFormatFloat
 	EmbeddingEncodingFormatBase64 = openai.EmbeddingEncodingFormatBase64
@@ -85,12 +88,25 @@ type EmbeddingConfig struct {
 	// User is a unique identifier representing your end-user
 	// Optional. Helps OpenAI monitor and detect abuse
 	User *string `json:"user,omitempty"`
+	// TargetDimensions truncates or zero-pads the output embeddings to this dimension on the client,
+	// so that the embeddings of models with different dimensions can share a fixed dimension store schema.
+	// Unlike Dimensions it is applied after the api call, and also works with models not supporting Dimensions
+	// Optional. Default: the embeddings are returned as is
+	TargetDimensions *int `json:"target_dimensions,omitempty"`
+
+	// Normalize L2-normalizes the output embeddings on the client, after TargetDimensions is applied.
+	// Embeddings truncated by TargetDimensions are no longer unit length, set Normalize along with it
+	// Optional. Default: false
+	Normalize bool `json:"normalize,omitempty"`
 }
 
 var _ embedding.Embedder = (*Embedder)(nil)
 
 type Embedder struct {
 	cli *openai.EmbeddingClient
+
+	targetDimensions *int
+	normalize        bool
 }
 
 func NewEmbedder(ctx context.Context, config *EmbeddingConfig) (*Embedder, error) {
@@ -121,15 +137,22 @@ func NewEmbedder(ctx context.Context, config *EmbeddingConfig) (*Embedder, error
 		return nil, err
 	}
 
-	return &Embedder{
-		cli: cli,
-	}, nil
+	e := &Embedder{cli: cli}
+	if config != nil {
+		e.targetDimensions, e.normalize = config.TargetDimensions, config.Normalize
+	}
+	return e, nil
 }
 
 func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) (
 	embeddings [][]float64, err error) {
 	ctx = callbacks.EnsureRunInfo(ctx, e.GetType(), components.ComponentOfEmbedding)
-	return e.cli.EmbedStrings(ctx, texts, opts...)
+	embeddings, err = e.cli.EmbedStrings(ctx, texts, opts...)
+	if err != nil {
+		return nil, err
+	}
+	// the callbacks of the client report the embeddings before post-processing
+	return vector.PostProcess(embeddings, e.targetDimensions, e.normalize), nil
 }
 
 const typ = "OpenAI"
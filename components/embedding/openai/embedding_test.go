@@ -109,4 +109,39 @@ func TestEmbedding(t *testing.T) {
 			}
 		}
 	})
+	t.Run("target dimensions and normalize", func(t *testing.T) {
+		ctx := context.Background()
+		targetDimensions := 2
+		emb, err := NewEmbedder(ctx, &EmbeddingConfig{
+			APIKey:           "api_key",
+			Model:            "embedding",
+			TargetDimensions: &targetDimensions,
+			Normalize:        true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer mockey.Mock((*openai.Client).CreateEmbeddings).Return(openai.EmbeddingResponse{
+			Data: []openai.Embedding{{Embedding: []float32{3, 4, 12}}},
+		}, nil).Build().UnPatch()
+
+		result, err := emb.EmbedStrings(ctx, []string{"input"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(result, [][]float64{{0.6, 0.8}}) {
+			t.Fatalf("result is unexpected: %v", result)
+		}
+
+		targetDimensions = 4
+		emb.normalize = false
+		result, err = emb.EmbedStrings(ctx, []string{"input"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(result, [][]float64{{3, 4, 12, 0}}) {
+			t.Fatalf("result is unexpected: %v", result)
+		}
+	})
 }
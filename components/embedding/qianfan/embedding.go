@@ -23,8 +23,12 @@ import (
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components"
 	"github.com/cloudwego/eino/components/embedding"
+
+	"github.com/cloudwego/eino-ext/components/embedding/qianfan/internal/vector"
 )
 
+//go:generate sh ../../../libs/acl/bundle.sh vector internal/vector
+
 // GetQianfanSingletonConfig qianfan config is singleton, you should set ak+sk / bear_token before init chat model
 // Set with code: GetQianfanSingletonConfig().AccessKey = "your_access_key"
 // Set with env: os.Setenv("QIANFAN_ACCESS_KEY", "your_iam_ak") or with env file
@@ -37,6 +41,10 @@ type EmbeddingConfig struct {
 	LLMRetryCount         *int
 	LLMRetryTimeout       *float32
 	LLMRetryBackoffFactor *float32
+	// TargetDimensions truncates or zero-pads the embeddings to this dimension on the client.
+	TargetDimensions *int
+	// Normalize L2-normalizes the embeddings on the client, after TargetDimensions.
+	Normalize bool
 }
 
 type Embedder struct {
@@ -91,6 +99,8 @@ func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...emb
 		embeddings[i] = resp.Data[i].Embedding
 	}
 
+	embeddings = vector.PostProcess(embeddings, e.conf.TargetDimensions, e.conf.Normalize)
+
 	callbacks.OnEnd(ctx, &embedding.CallbackOutput{
 		Embeddings: embeddings,
 		Config:     conf,
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package qianfan
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/baidubce/bce-qianfan-sdk/go/qianfan"
+	. "github.com/bytedance/mockey"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func Test_EmbedStrings(t *testing.T) {
+	PatchConvey("test EmbedStrings", t, func() {
+		ctx := context.Background()
+		emb, err := NewEmbedder(ctx, &EmbeddingConfig{
+			Model: "Embedding-V1",
+		})
+		convey.So(err, convey.ShouldBeNil)
+
+		PatchConvey("test embedding error", func() {
+			Mock((*qianfan.Embedding).Do).Return(nil, errors.New("test err")).Build()
+			result, err := emb.EmbedStrings(ctx, []string{"hello world"})
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(result, convey.ShouldBeNil)
+		})
+
+		PatchConvey("test embedding success", func() {
+			Mock((*qianfan.Embedding).Do).Return(&qianfan.EmbeddingResponse{
+				Data: []qianfan.EmbeddingData{
+					{Embedding: []float64{0.1, 0.2}, Index: 0},
+					{Embedding: []float64{0.3, 0.4}, Index: 1},
+				},
+				Usage: qianfan.ModelUsage{PromptTokens: 2, TotalTokens: 2},
+			}, nil).Build()
+			result, err := emb.EmbedStrings(ctx, []string{"hello", "world"})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(result, convey.ShouldResemble, [][]float64{{0.1, 0.2}, {0.3, 0.4}})
+		})
+
+		PatchConvey("test target dimensions and normalize", func() {
+			Mock((*qianfan.Embedding).Do).To(func(ctx context.Context, request *qianfan.EmbeddingRequest) (*qianfan.EmbeddingResponse, error) {
+				return &qianfan.EmbeddingResponse{
+					Data: []qianfan.EmbeddingData{{Embedding: []float64{3, 4, 12}}},
+				}, nil
+			}).Build()
+
+			targetDimensions := 2
+			emb.conf.TargetDimensions = &targetDimensions
+			emb.conf.Normalize = true
+			result, err := emb.EmbedStrings(ctx, []string{"hello world"})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(result, convey.ShouldResemble, [][]float64{{0.6, 0.8}})
+
+			targetDimensions = 4
+			emb.conf.Normalize = false
+			result, err = emb.EmbedStrings(ctx, []string{"hello world"})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(result, convey.ShouldResemble, [][]float64{{3, 4, 12, 0}})
+		})
+	})
+}
@@ -23,17 +23,25 @@ import (
 	"github.com/cloudwego/eino/components"
 	"github.com/cloudwego/eino/components/embedding"
 
+	"github.com/cloudwego/eino-ext/components/embedding/tencentcloud/internal/vector"
+
 	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
 	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/profile"
 	hunyuan "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/hunyuan/v20230901"
 )
 
+//go:generate sh ../../../libs/acl/bundle.sh vector internal/vector
+
 const defaultModel = "hunyuan-embedding"
 
 type EmbeddingConfig struct {
 	SecretID  string
 	SecretKey string
 	Region    string
+	// TargetDimensions truncates or zero-pads the embeddings to this dimension on the client.
+	TargetDimensions *int
+	// Normalize L2-normalizes the embeddings on the client, after TargetDimensions.
+	Normalize bool
 }
 
 var _ embedding.Embedder = (*Embedder)(nil)
@@ -41,6 +49,7 @@ var _ embedding.Embedder = (*Embedder)(nil)
 // Embedder is a Tencent Cloud embedding client
 type Embedder struct {
 	client *hunyuan.Client
+	conf   *EmbeddingConfig
 }
 
 // NewEmbedder creates a new Tencent Cloud embedding client
@@ -58,6 +67,7 @@ func NewEmbedder(ctx context.Context, config *EmbeddingConfig) (*Embedder, error
 
 	return &Embedder{
 		client: client,
+		conf:   config,
 	}, nil
 }
 
@@ -109,6 +119,8 @@ func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...emb
 		totalTokens += int(*rsp.Response.Usage.TotalTokens)
 	}
 
+	embeddings = vector.PostProcess(embeddings, e.conf.TargetDimensions, e.conf.Normalize)
+
 	callbacks.OnEnd(ctx, &embedding.CallbackOutput{
 		Embeddings: embeddings,
 		Config:     conf,
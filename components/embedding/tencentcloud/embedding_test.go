@@ -186,3 +186,53 @@ func TestBatchEmbedding(t *testing.T) {
 		}
 	})
 }
+
+func TestEmbeddingPostProcess(t *testing.T) {
+	mockResponse := hunyuan.NewGetEmbeddingResponse()
+	mockResponse.Response = &hunyuan.GetEmbeddingResponseParams{
+		Data: []*hunyuan.EmbeddingData{
+			{
+				Embedding: common.Float64Ptrs([]float64{3, 4, 12}),
+			},
+		},
+		Usage: &hunyuan.EmbeddingUsage{
+			PromptTokens: common.Int64Ptr(1),
+			TotalTokens:  common.Int64Ptr(1),
+		},
+	}
+
+	t.Run("tencentcloud target dimensions and normalize test", func(t *testing.T) {
+		ctx := context.Background()
+		targetDimensions := 2
+		emb, err := NewEmbedder(ctx, &EmbeddingConfig{
+			SecretID:         "test_id",
+			SecretKey:        "test_key",
+			Region:           "test_region",
+			TargetDimensions: &targetDimensions,
+			Normalize:        true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer mockey.Mock((*hunyuan.Client).GetEmbedding).Return(mockResponse, nil).Build().UnPatch()
+
+		result, err := emb.EmbedStrings(ctx, []string{"input"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(result, [][]float64{{0.6, 0.8}}) {
+			t.Fatalf("result is unexpected: %v", result)
+		}
+
+		targetDimensions = 4
+		emb.conf.Normalize = false
+		result, err = emb.EmbedStrings(ctx, []string{"input"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(result, [][]float64{{3, 4, 12, 0}}) {
+			t.Fatalf("result is unexpected: %v", result)
+		}
+	})
+}
@@ -2,7 +2,6 @@ module github.com/cloudwego/eino-ext/components/embedding/tencentcloud
 
 go 1.23.0
 
-
 require (
 	github.com/bytedance/mockey v1.2.14
 	github.com/cloudwego/eino v0.3.27
@@ -0,0 +1,201 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package es8
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/indices/create"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/indices/exists"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/indices/getmapping"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+)
+
+type Similarity string
+
+const (
+	SimilarityCosine          Similarity = "cosine"
+	SimilarityDotProduct      Similarity = "dot_product"
+	SimilarityL2Norm          Similarity = "l2_norm"
+	SimilarityMaxInnerProduct Similarity = "max_inner_product"
+)
+
+// IndexSpec describes the index created by EnsureIndex.
+type IndexSpec struct {
+	// DenseVectorFields maps the vector fields, i.e. FieldValue.EmbedKey, to their settings.
+	DenseVectorFields map[string]*DenseVectorField
+	// SparseVectorFields are the sparse vector fields, e.g. the output of ELSER.
+	SparseVectorFields []string
+	// TextFields are the fields searched by full text, e.g. the doc content, analyzed with Analyzer.
+	TextFields []string
+	// KeywordFields are the metadata fields matched exactly, used in filters and aggregations.
+	KeywordFields []string
+	// Analyzer of TextFields, e.g. "standard", or "ik_max_word" of the IK plugin for Chinese.
+	// Default is the default analyzer of the index.
+	Analyzer string
+	// SearchAnalyzer of TextFields, e.g. "ik_smart". Default is Analyzer.
+	SearchAnalyzer string
+	// Analysis defines custom analyzers, tokenizers and filters used by Analyzer.
+	Analysis *types.IndexSettingsAnalysis
+	// NumberOfShards and NumberOfReplicas of the index. Default is the cluster default.
+	NumberOfShards   *int
+	NumberOfReplicas *int
+	// Properties are additional mapping properties, they take precedence over the generated ones.
+	Properties map[string]types.Property
+}
+
+type DenseVectorField struct {
+	// Dims must equal the dimensions of the embedding.
+	Dims int
+	// Similarity of the knn search. Default is SimilarityCosine.
+	Similarity Similarity
+	// IndexOptionsType is the type of the knn index, e.g. "hnsw", "int8_hnsw" or "bbq_hnsw".
+	// Default is the default of the cluster version.
+	IndexOptionsType string
+}
+
+// EnsureIndex creates the index with the mapping of IndexerConfig.IndexSpec if it doesn't exist.
+// It is idempotent and safe to call concurrently. When the index exists, the dims of its dense vector
+// fields are checked against the spec, so that a misconfigured embedding fails fast instead of on every bulk.
+// NewIndexer calls it if IndexSpec is set.
+func (i *Indexer) EnsureIndex(ctx context.Context) error {
+	spec := i.config.IndexSpec
+	if spec == nil {
+		return fmt.Errorf("[EnsureIndex] IndexSpec not provided")
+	}
+
+	found, err := exists.NewExistsFunc(i.client)(i.config.Index).IsSuccess(ctx)
+	if err != nil {
+		return fmt.Errorf("[EnsureIndex] check index exists failed, %w", err)
+	}
+	if found {
+		return i.checkMapping(ctx, spec)
+	}
+
+	mappings, err := spec.mappings()
+	if err != nil {
+		return fmt.Errorf("[EnsureIndex] %w", err)
+	}
+	_, err = create.NewCreateFunc(i.client)(i.config.Index).Request(&create.Request{
+		Mappings: mappings,
+		Settings: spec.settings(),
+	}).Do(ctx)
+	if err != nil {
+		var esErr *types.ElasticsearchError
+		if errors.As(err, &esErr) && esErr.ErrorCause.Type == "resource_already_exists_exception" {
+			// created concurrently
+			return i.checkMapping(ctx, spec)
+		}
+		return fmt.Errorf("[EnsureIndex] create index failed, %w", err)
+	}
+
+	return nil
+}
+
+func (i *Indexer) checkMapping(ctx context.Context, spec *IndexSpec) error {
+	resp, err := getmapping.NewGetMappingFunc(i.client)().Index(i.config.Index).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("[EnsureIndex] get mapping failed, %w", err)
+	}
+
+	for _, record := range resp {
+		for name, field := range spec.DenseVectorFields {
+			prop, ok := record.Mappings.Properties[name].(*types.DenseVectorProperty)
+			if !ok || prop.Dims == nil {
+				// missing fields are added dynamically, mapped by the first document
+				continue
+			}
+			if *prop.Dims != field.Dims {
+				return fmt.Errorf("[EnsureIndex] dims of field %s mismatch, index=%d, spec=%d", name, *prop.Dims, field.Dims)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *IndexSpec) mappings() (*types.TypeMapping, error) {
+	props := make(map[string]types.Property)
+
+	for _, name := range s.TextFields {
+		prop := types.NewTextProperty()
+		if s.Analyzer != "" {
+			prop.Analyzer = of(s.Analyzer)
+		}
+		if s.SearchAnalyzer != "" {
+			prop.SearchAnalyzer = of(s.SearchAnalyzer)
+		}
+		props[name] = prop
+	}
+
+	for _, name := range s.KeywordFields {
+		props[name] = types.NewKeywordProperty()
+	}
+
+	for _, name := range s.SparseVectorFields {
+		props[name] = types.NewSparseVectorProperty()
+	}
+
+	names := make([]string, 0, len(s.DenseVectorFields))
+	for name := range s.DenseVectorFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		field := s.DenseVectorFields[name]
+		if field == nil || field.Dims <= 0 {
+			return nil, fmt.Errorf("dims of dense vector field %s not provided", name)
+		}
+		similarity := field.Similarity
+		if similarity == "" {
+			similarity = SimilarityCosine
+		}
+		prop := types.NewDenseVectorProperty()
+		prop.Dims = of(field.Dims)
+		prop.Index = of(true)
+		prop.Similarity = of(string(similarity))
+		if field.IndexOptionsType != "" {
+			prop.IndexOptions = &types.DenseVectorIndexOptions{Type: field.IndexOptionsType}
+		}
+		props[name] = prop
+	}
+
+	for name, prop := range s.Properties {
+		props[name] = prop
+	}
+
+	return &types.TypeMapping{Properties: props}, nil
+}
+
+func (s *IndexSpec) settings() *types.IndexSettings {
+	if s.Analysis == nil && s.NumberOfShards == nil && s.NumberOfReplicas == nil {
+		return nil
+	}
+
+	settings := &types.IndexSettings{Analysis: s.Analysis}
+	if s.NumberOfShards != nil {
+		settings.NumberOfShards = strconv.Itoa(*s.NumberOfShards)
+	}
+	if s.NumberOfReplicas != nil {
+		settings.NumberOfReplicas = strconv.Itoa(*s.NumberOfReplicas)
+	}
+	return settings
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package es8
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+type mockES struct {
+	exists     bool
+	createCode int
+	mapping    string
+	created    map[string]any
+}
+
+func (m *mockES) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Elastic-Product", "Elasticsearch")
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.Method == http.MethodHead:
+		if m.exists {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	case r.Method == http.MethodPut:
+		if m.createCode != 0 {
+			w.WriteHeader(m.createCode)
+			_, _ = w.Write([]byte(`{"error":{"type":"resource_already_exists_exception","reason":"index already exists"},"status":400}`))
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(b, &m.created)
+		_, _ = w.Write([]byte(`{"acknowledged":true,"shards_acknowledged":true,"index":"eino_test"}`))
+	case r.Method == http.MethodGet:
+		_, _ = w.Write([]byte(m.mapping))
+	}
+}
+
+func TestEnsureIndex(t *testing.T) {
+	convey.Convey("test EnsureIndex", t, func() {
+		ctx := context.Background()
+		mock := &mockES{mapping: `{"eino_test":{"mappings":{"properties":{"content_vector":{"type":"dense_vector","dims":1024}}}}}`}
+		server := httptest.NewServer(mock)
+		defer server.Close()
+
+		client, err := elasticsearch.NewClient(elasticsearch.Config{Addresses: []string{server.URL}})
+		convey.So(err, convey.ShouldBeNil)
+
+		shards := 1
+		spec := &IndexSpec{
+			DenseVectorFields: map[string]*DenseVectorField{
+				"content_vector": {Dims: 1024, IndexOptionsType: "int8_hnsw"},
+			},
+			TextFields:     []string{"content"},
+			KeywordFields:  []string{"location"},
+			Analyzer:       "ik_max_word",
+			SearchAnalyzer: "ik_smart",
+			NumberOfShards: &shards,
+		}
+		conf := &IndexerConfig{
+			Client: client,
+			Index:  "eino_test",
+			DocumentToFields: func(ctx context.Context, doc *schema.Document) (map[string]FieldValue, error) {
+				return nil, nil
+			},
+			IndexSpec: spec,
+		}
+
+		convey.Convey("test create index", func() {
+			_, err := NewIndexer(ctx, conf)
+			convey.So(err, convey.ShouldBeNil)
+
+			b, _ := json.Marshal(mock.created)
+			convey.So(string(b), convey.ShouldEqual, `{"mappings":{"properties":{`+
+				`"content":{"analyzer":"ik_max_word","search_analyzer":"ik_smart","type":"text"},`+
+				`"content_vector":{"dims":1024,"index":true,"index_options":{"type":"int8_hnsw"},"similarity":"cosine","type":"dense_vector"},`+
+				`"location":{"type":"keyword"}}},`+
+				`"settings":{"number_of_shards":"1"}}`)
+		})
+
+		convey.Convey("test index exists", func() {
+			mock.exists = true
+			i, err := NewIndexer(ctx, conf)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(mock.created, convey.ShouldBeNil)
+
+			spec.DenseVectorFields["content_vector"].Dims = 768
+			err = i.EnsureIndex(ctx)
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "dims of field content_vector mismatch, index=1024, spec=768")
+		})
+
+		convey.Convey("test index created concurrently", func() {
+			mock.createCode = http.StatusBadRequest
+			_, err := NewIndexer(ctx, conf)
+			convey.So(err, convey.ShouldBeNil)
+		})
+
+		convey.Convey("test dims not provided", func() {
+			spec.DenseVectorFields["content_vector"].Dims = 0
+			_, err := NewIndexer(ctx, conf)
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "dims of dense vector field content_vector not provided")
+		})
+	})
+}
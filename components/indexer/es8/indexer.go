@@ -46,6 +46,10 @@ type IndexerConfig struct {
 	// 1. VectorFields contains fields except doc Content
 	// 2. VectorFields contains doc Content and vector not provided in doc extra (see Document.Vector method)
 	Embedding embedding.Embedder
+	// IndexSpec describes the mapping of Index, e.g. the dims and similarity of the vector fields.
+	// If set, the index is created with the mapping when it doesn't exist, see EnsureIndex.
+	// If not set, Index must be created beforehand.
+	IndexSpec *IndexSpec
 }
 
 type FieldValue struct {
@@ -64,7 +68,7 @@ type Indexer struct {
 	config *IndexerConfig
 }
 
-func NewIndexer(_ context.Context, conf *IndexerConfig) (*Indexer, error) {
+func NewIndexer(ctx context.Context, conf *IndexerConfig) (*Indexer, error) {
 	if conf.Client == nil {
 		return nil, fmt.Errorf("[NewIndexer] es client not provided")
 	}
@@ -77,10 +81,18 @@ func NewIndexer(_ context.Context, conf *IndexerConfig) (*Indexer, error) {
 		conf.BatchSize = defaultBatchSize
 	}
 
-	return &Indexer{
+	i := &Indexer{
 		client: conf.Client,
 		config: conf,
-	}, nil
+	}
+
+	if conf.IndexSpec != nil {
+		if err := i.EnsureIndex(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return i, nil
 }
 
 func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) (ids []string, err error) {
@@ -28,3 +28,7 @@ func iter[T, D any](src []T, fn func(T) D) []D {
 
 	return resp
 }
+
+func of[T any](v T) *T {
+	return &v
+}
@@ -32,6 +32,13 @@ const (
 	defaultDim = 81920
 	
 	defaultIndexField = "vector"
+	defaultIndexType  = AUTOINDEX
+	
+	indexParamMetricType = "metric_type"
+	
+	// binaryBitsPerDim is the bits of a float32 dimension in the binary vector of the default fields
+	binaryBitsPerDim = 32
+	dimProbeText     = "dimension probe"
 	
 	defaultConsistencyLevel = ConsistencyLevelBounded
 	defaultMetricType       = HAMMING
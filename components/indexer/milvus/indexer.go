@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 	
 	"github.com/bytedance/sonic"
@@ -76,6 +77,22 @@ type IndexerConfig struct {
 	// MetricType the metric type for vector
 	// Optional and default type is HAMMING
 	MetricType MetricType
+	// IndexType the index type for vector, the index is created along with the collection
+	// Optional and default type is AUTOINDEX
+	IndexType IndexType
+	// IndexParams the build params of the index, e.g. {"M": "16", "efConstruction": "200"} for HNSW
+	// Optional
+	IndexParams map[string]string
+	
+	// Dim is the dimension of the vectors produced by Embedding
+	// Optional, if set the vector field of the default fields is created with it,
+	// and the vector field of the collection is checked against it in NewIndexer,
+	// failing fast instead of on the first insert.
+	// The default fields store the float32 bytes of the vector as a binary vector of Dim*32 bits
+	Dim int
+	// DetectDim detects Dim by embedding a probe text in NewIndexer when Dim is not set
+	// Optional, and the default value is false
+	DetectDim bool
 	
 	// Embedding vectorization method for values needs to be embedded from schema.Document's content.
 	// Required
@@ -84,25 +101,57 @@ type IndexerConfig struct {
 
 type Indexer struct {
 	config IndexerConfig
+	// defaultConverter is whether the vectors are converted by the default document converter
+	defaultConverter bool
 }
 
 // NewIndexer creates a new indexer.
 func NewIndexer(ctx context.Context, conf *IndexerConfig) (*Indexer, error) {
+	defaultConverter := conf.DocumentConverter == nil
+	defaultFields := conf.Fields == nil
 	// conf check
 	if err := conf.check(); err != nil {
 		return nil, err
 	}
 	
+	if conf.Dim <= 0 && conf.DetectDim {
+		dim, err := detectDim(ctx, conf.Embedding)
+		if err != nil {
+			return nil, err
+		}
+		conf.Dim = dim
+	}
+	if defaultFields && conf.Dim > 0 {
+		conf.Fields = getDefaultFieldsWithDim(int64(conf.Dim) * binaryBitsPerDim)
+	}
+	
+	i := &Indexer{
+		config:           *conf,
+		defaultConverter: defaultConverter,
+	}
+	if err := i.EnsureCollection(ctx); err != nil {
+		return nil, err
+	}
+	
+	// create indexer
+	return i, nil
+}
+
+// EnsureCollection creates the collection with the configured fields, dynamic schema and vector index if it doesn't exist,
+// checks the schema and the vector dimension of the collection, and loads it.
+// It is called by NewIndexer, and is safe to be called again, e.g. after the collection is dropped.
+func (i *Indexer) EnsureCollection(ctx context.Context) error {
+	conf := &i.config
 	// check the collection whether to be created
 	ok, err := conf.Client.HasCollection(ctx, conf.Collection)
 	if err != nil {
 		if errors.Is(err, client.ErrClientNotReady) {
-			return nil, fmt.Errorf("[NewIndexer] milvus client not ready: %w", err)
+			return fmt.Errorf("[NewIndexer] milvus client not ready: %w", err)
 		}
 		if errors.Is(err, client.ErrStatusNil) {
-			return nil, fmt.Errorf("[NewIndexer] milvus client status is nil: %w", err)
+			return fmt.Errorf("[NewIndexer] milvus client status is nil: %w", err)
 		}
-		return nil, fmt.Errorf("[NewIndexer] failed to check collection: %w", err)
+		return fmt.Errorf("[NewIndexer] failed to check collection: %w", err)
 	}
 	if !ok {
 		// create the collection
@@ -116,47 +165,91 @@ func NewIndexer(ctx context.Context, conf *IndexerConfig) (*Indexer, error) {
 			client.WithEnableDynamicSchema(conf.EnableDynamicSchema),
 			client.WithPartitionNum(conf.PartitionNum),
 		); errToCreate != nil {
-			return nil, fmt.Errorf("[NewIndexer] failed to create collection: %w", errToCreate)
+			return fmt.Errorf("[NewIndexer] failed to create collection: %w", errToCreate)
+		}
+		// create the vector index, the collection can't be loaded without it
+		if err := conf.createdDefaultIndex(ctx, false); err != nil {
+			return err
 		}
 	}
 	
 	// load collection info
 	collection, err := conf.Client.DescribeCollection(ctx, conf.Collection)
 	if err != nil {
-		return nil, fmt.Errorf("[NewIndexer] failed to describe collection: %w", err)
+		return fmt.Errorf("[NewIndexer] failed to describe collection: %w", err)
 	}
 	// check collection schema
 	if !conf.checkCollectionSchema(collection.Schema, conf.Fields) {
-		return nil, fmt.Errorf("[NewIndexer] collection schema not match")
+		return fmt.Errorf("[NewIndexer] collection schema not match")
+	}
+	if err := i.checkDim(collection.Schema); err != nil {
+		return err
 	}
 	// check the collection load state
 	if !collection.Loaded {
 		// load collection
 		if err := conf.loadCollection(ctx); err != nil {
-			return nil, err
+			return err
 		}
 	}
 	
 	if conf.PartitionNum == 0 && conf.PartitionName != "" {
 		ok, err = conf.Client.HasPartition(ctx, conf.Collection, conf.PartitionName)
 		if err != nil {
-			return nil, fmt.Errorf("[NewIndexer] failed to check partition: %w", err)
+			return fmt.Errorf("[NewIndexer] failed to check partition: %w", err)
 		}
 		if !ok {
 			err := conf.Client.CreatePartition(ctx, conf.Collection, conf.PartitionName)
 			if err != nil {
-				return nil, fmt.Errorf("[NewIndexer] failed to create partition: %w", err)
+				return fmt.Errorf("[NewIndexer] failed to create partition: %w", err)
 			}
 		}
 		if err = conf.Client.LoadPartitions(ctx, conf.Collection, []string{conf.PartitionName}, false); err != nil {
-			return nil, fmt.Errorf("[NewIndexer] failed to load partition: %w", err)
+			return fmt.Errorf("[NewIndexer] failed to load partition: %w", err)
 		}
 	}
+	return nil
+}
+
+// checkDim checks the dimension of the vector field of the collection against Dim
+func (i *Indexer) checkDim(schema *entity.Schema) error {
+	if i.config.Dim <= 0 {
+		return nil
+	}
+	field := getVectorField(schema.Fields)
+	if field == nil {
+		return fmt.Errorf("[NewIndexer] vector field not found in collection %s", i.config.Collection)
+	}
+	dim, err := strconv.Atoi(field.TypeParams[entity.TypeParamDim])
+	if err != nil {
+		return fmt.Errorf("[NewIndexer] invalid dim of vector field %s: %w", field.Name, err)
+	}
 	
-	// create indexer
-	return &Indexer{
-		config: *conf,
-	}, nil
+	expected := i.config.Dim
+	if field.DataType == entity.FieldTypeBinaryVector {
+		if !i.defaultConverter {
+			// the encoding of the vectors is up to the custom document converter
+			return nil
+		}
+		expected *= binaryBitsPerDim
+	}
+	if dim != expected {
+		return fmt.Errorf("[NewIndexer] dim of vector field %s is %d, but the embedding requires %d, "+
+			"use a collection created for the embedding model", field.Name, dim, expected)
+	}
+	return nil
+}
+
+// detectDim detects the dimension of the embedding by embedding a probe text
+func detectDim(ctx context.Context, emb embedding.Embedder) (int, error) {
+	vectors, err := emb.EmbedStrings(makeEmbeddingCtx(ctx, emb), []string{dimProbeText})
+	if err != nil {
+		return 0, fmt.Errorf("[NewIndexer] failed to detect embedding dim: %w", err)
+	}
+	if len(vectors) != 1 || len(vectors[0]) == 0 {
+		return 0, fmt.Errorf("[NewIndexer] failed to detect embedding dim: empty embedding")
+	}
+	return len(vectors[0]), nil
 }
 
 // Store stores the documents into the indexer.
@@ -283,13 +376,15 @@ func (i *IndexerConfig) getDefaultDocumentConvert() func(ctx context.Context, do
 	}
 }
 
-// createdDefaultIndex creates the default index
+// createdDefaultIndex creates the index of the vector field
 func (i *IndexerConfig) createdDefaultIndex(ctx context.Context, async bool) error {
-	index, err := entity.NewIndexAUTOINDEX(i.MetricType.getMetricType())
-	if err != nil {
-		return fmt.Errorf("[NewIndexer] failed to create index: %w", err)
+	params := make(map[string]string, len(i.IndexParams)+1)
+	for k, v := range i.IndexParams {
+		params[k] = v
 	}
-	if err := i.Client.CreateIndex(ctx, i.Collection, defaultIndexField, index, async); err != nil {
+	params[indexParamMetricType] = string(i.MetricType.getMetricType())
+	index := entity.NewGenericIndex("", i.IndexType.getIndexType(), params)
+	if err := i.Client.CreateIndex(ctx, i.Collection, i.vectorFieldName(), index, async); err != nil {
 		return fmt.Errorf("[NewIndexer] failed to create index: %w", err)
 	}
 	return nil
@@ -314,7 +409,15 @@ func (i *IndexerConfig) checkCollectionSchema(schema *entity.Schema, field []*en
 	return true
 }
 
-// getCollectionDim gets the collection dimension
+// vectorFieldName returns the name of the vector field in Fields
+func (i *IndexerConfig) vectorFieldName() string {
+	if field := getVectorField(i.Fields); field != nil {
+		return field.Name
+	}
+	return defaultIndexField
+}
+
+// loadCollection loads the collection, creating the vector index if missing
 func (i *IndexerConfig) loadCollection(ctx context.Context) error {
 	loadState, err := i.Client.GetLoadState(ctx, i.Collection, nil)
 	if err != nil {
@@ -324,7 +427,7 @@ func (i *IndexerConfig) loadCollection(ctx context.Context) error {
 	case entity.LoadStateNotExist:
 		return fmt.Errorf("[NewIndexer] collection not exist")
 	case entity.LoadStateNotLoad:
-		index, err := i.Client.DescribeIndex(ctx, i.Collection, "vector")
+		index, err := i.Client.DescribeIndex(ctx, i.Collection, i.vectorFieldName())
 		if errors.Is(err, client.ErrClientNotReady) {
 			return fmt.Errorf("[NewIndexer] milvus client not ready: %w", err)
 		}
@@ -385,6 +488,9 @@ func (i *IndexerConfig) check() error {
 	if i.MetricType == "" {
 		i.MetricType = defaultMetricType
 	}
+	if i.IndexType == "" {
+		i.IndexType = defaultIndexType
+	}
 	if i.PartitionNum <= 1 {
 		i.PartitionNum = 0
 	}
@@ -409,3 +409,87 @@ func TestIndexer_Store(t *testing.T) {
 		})
 	})
 }
+
+func TestEnsureCollection(t *testing.T) {
+	PatchConvey("test EnsureCollection", t, func() {
+		ctx := context.Background()
+		Mock(client.NewClient).Return(&client.GrpcClient{}, nil).Build()
+		mockClient, _ := client.NewClient(ctx, client.Config{})
+		mockEmb := &mockEmbedding{}
+		
+		var created *entity.Schema
+		var index entity.Index
+		collection := &entity.Collection{Loaded: true}
+		Mock(GetMethod(mockClient, "HasCollection")).To(func(ctx context.Context, collName string) (bool, error) {
+			return created != nil, nil
+		}).Build()
+		Mock(GetMethod(mockClient, "CreateCollection")).To(func(ctx context.Context, schema *entity.Schema, shardsNum int32, opts ...client.CreateCollectionOption) error {
+			created = schema
+			collection.Schema = schema
+			return nil
+		}).Build()
+		Mock(GetMethod(mockClient, "CreateIndex")).To(func(ctx context.Context, collName string, fieldName string, idx entity.Index, async bool, opts ...client.IndexOption) error {
+			index = idx
+			return nil
+		}).Build()
+		Mock(GetMethod(mockClient, "DescribeCollection")).To(func(ctx context.Context, collName string) (*entity.Collection, error) {
+			return collection, nil
+		}).Build()
+		
+		PatchConvey("test create collection and index with detected dim", func() {
+			i, err := NewIndexer(ctx, &IndexerConfig{
+				Client:      mockClient,
+				Embedding:   mockEmb,
+				MetricType:  JACCARD,
+				IndexType:   BIN_IVF_FLAT,
+				IndexParams: map[string]string{"nlist": "128"},
+				DetectDim:   true,
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(i.config.Dim, convey.ShouldEqual, 3)
+			convey.So(getVectorField(created.Fields).TypeParams[entity.TypeParamDim], convey.ShouldEqual, "96")
+			convey.So(index.Params(), convey.ShouldResemble, map[string]string{
+				"index_type":  "BIN_IVF_FLAT",
+				"metric_type": "JACCARD",
+				"nlist":       "128",
+			})
+			
+			// idempotent
+			convey.So(i.EnsureCollection(ctx), convey.ShouldBeNil)
+		})
+		
+		PatchConvey("test dim mismatch", func() {
+			created = &entity.Schema{}
+			collection.Schema = &entity.Schema{Fields: getDefaultFields()}
+			i, err := NewIndexer(ctx, &IndexerConfig{
+				Client:    mockClient,
+				Embedding: mockEmb,
+				Fields:    getDefaultFields(),
+				Dim:       1024,
+			})
+			convey.So(err, convey.ShouldBeError, fmt.Errorf("[NewIndexer] dim of vector field vector is 81920, "+
+				"but the embedding requires 32768, use a collection created for the embedding model"))
+			convey.So(i, convey.ShouldBeNil)
+		})
+		
+		PatchConvey("test float vector dim", func() {
+			created = &entity.Schema{}
+			fields := []*entity.Field{
+				entity.NewField().WithName("id").WithDataType(entity.FieldTypeVarChar).WithIsPrimaryKey(true),
+				entity.NewField().WithName("embedding").WithDataType(entity.FieldTypeFloatVector).WithDim(3),
+			}
+			collection.Schema = &entity.Schema{Fields: fields}
+			i, err := NewIndexer(ctx, &IndexerConfig{
+				Client:    mockClient,
+				Embedding: mockEmb,
+				Fields:    fields,
+				Dim:       3,
+				DocumentConverter: func(ctx context.Context, docs []*schema.Document, vectors [][]float64) ([]interface{}, error) {
+					return nil, nil
+				},
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(i.config.vectorFieldName(), convey.ShouldEqual, "embedding")
+		})
+	})
+}
@@ -40,6 +40,19 @@ const (
 	SUPERSTRUCTURE = MetricType(entity.SUPERSTRUCTURE)
 )
 
+const (
+	AUTOINDEX    = IndexType(entity.AUTOINDEX)
+	FLAT         = IndexType(entity.Flat)
+	IVF_FLAT     = IndexType(entity.IvfFlat)
+	IVF_SQ8      = IndexType(entity.IvfSQ8)
+	IVF_PQ       = IndexType(entity.IvfPQ)
+	HNSW         = IndexType(entity.HNSW)
+	DISKANN      = IndexType(entity.DISKANN)
+	SCANN        = IndexType(entity.SCANN)
+	BIN_FLAT     = IndexType(entity.BinFlat)
+	BIN_IVF_FLAT = IndexType(entity.BinIvfFlat)
+)
+
 // defaultSchema is the default schema for milvus by eino
 type defaultSchema struct {
 	ID       string `json:"id" milvus:"name:id"`
@@ -49,6 +62,11 @@ type defaultSchema struct {
 }
 
 func getDefaultFields() []*entity.Field {
+	return getDefaultFieldsWithDim(defaultDim)
+}
+
+// getDefaultFieldsWithDim returns the default fields with the binary vector of dim bits
+func getDefaultFieldsWithDim(dim int64) []*entity.Field {
 	return []*entity.Field{
 		entity.NewField().
 			WithName(defaultCollectionID).
@@ -61,7 +79,7 @@ func getDefaultFields() []*entity.Field {
 			WithDescription(defaultCollectionVectorDesc).
 			WithIsPrimaryKey(false).
 			WithDataType(entity.FieldTypeBinaryVector).
-			WithDim(defaultDim),
+			WithDim(dim),
 		entity.NewField().
 			WithName(defaultCollectionContent).
 			WithDescription(defaultCollectionContentDesc).
@@ -89,3 +107,23 @@ type MetricType entity.MetricType
 func (t *MetricType) getMetricType() entity.MetricType {
 	return entity.MetricType(*t)
 }
+
+// IndexType is the index type for vector by eino
+type IndexType entity.IndexType
+
+// getIndexType returns the index type
+func (t *IndexType) getIndexType() entity.IndexType {
+	return entity.IndexType(*t)
+}
+
+// getVectorField returns the first vector field
+func getVectorField(fields []*entity.Field) *entity.Field {
+	for _, f := range fields {
+		switch f.DataType {
+		case entity.FieldTypeFloatVector, entity.FieldTypeBinaryVector,
+			entity.FieldTypeFloat16Vector, entity.FieldTypeBFloat16Vector:
+			return f
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+
+	neo4jindexer "github.com/cloudwego/eino-ext/components/indexer/neo4j"
+)
+
+func main() {
+	ctx := context.Background()
+
+	driver, err := neo4j.NewDriverWithContext(os.Getenv("NEO4J_URI"),
+		neo4j.BasicAuth(os.Getenv("NEO4J_USERNAME"), os.Getenv("NEO4J_PASSWORD"), ""))
+	if err != nil {
+		log.Fatalf("NewDriverWithContext failed, err=%v", err)
+	}
+	defer driver.Close(ctx)
+
+	indexer, err := neo4jindexer.NewIndexer(ctx, &neo4jindexer.IndexerConfig{
+		Driver: driver,
+		// use a chat model to extract the entities and relations, e.g. ChatModel: openaiChatModel,
+		// a fixed graph is extracted for the example.
+		Extract: func(ctx context.Context, doc *schema.Document) (*neo4jindexer.Graph, error) {
+			return &neo4jindexer.Graph{
+				Entities: []*neo4jindexer.Entity{
+					{Name: "Marie Curie", Type: "person", Description: "Physicist and chemist"},
+					{Name: "Radium", Type: "element"},
+					{Name: "Pierre Curie", Type: "person"},
+				},
+				Relations: []*neo4jindexer.Relation{
+					{Source: "Marie Curie", Target: "Radium", Type: "discovered", Description: "in 1898"},
+					{Source: "Marie Curie", Target: "Pierre Curie", Type: "married to"},
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		log.Fatalf("NewIndexer failed, err=%v", err)
+	}
+
+	if err = indexer.EnsureSchema(ctx); err != nil {
+		log.Fatalf("EnsureSchema failed, err=%v", err)
+	}
+
+	ids, err := indexer.Store(ctx, []*schema.Document{{
+		ID:       "curie-1",
+		Content:  "In 1898 Marie Curie and her husband Pierre Curie discovered radium.",
+		MetaData: map[string]any{"source": "curie.md"},
+	}})
+	if err != nil {
+		log.Fatalf("Store failed, err=%v", err)
+	}
+	log.Printf("stored: %v", ids)
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package neo4j
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+// Graph is the knowledge graph extracted from a document.
+type Graph struct {
+	Entities  []*Entity   `json:"entities"`
+	Relations []*Relation `json:"relations"`
+}
+
+// Entity is a node of the knowledge graph, e.g. a person, an organization or a concept.
+// The entities with the same name, ignoring the case and the spaces, are the same node.
+type Entity struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+}
+
+// Relation is an edge of the knowledge graph between the entities named Source and Target, e.g. "works_at".
+type Relation struct {
+	Source      string `json:"source"`
+	Target      string `json:"target"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+}
+
+// ExtractFn extracts the knowledge graph of a document.
+type ExtractFn func(ctx context.Context, doc *schema.Document) (*Graph, error)
+
+const defaultExtractionPrompt = `You extract a knowledge graph from a text.
+Return only a JSON object, without any other text:
+{"entities": [{"name": "...", "type": "...", "description": "..."}], "relations": [{"source": "...", "target": "...", "type": "...", "description": "..."}]}
+
+Rules:
+- The entities are the people, organizations, places, products, concepts and events of the text, named as in the text.
+- The type of an entity is a single word, e.g. person, organization, place, product, concept or event.
+- The source and the target of a relation are the names of entities.
+- The type of a relation is a short verb phrase in snake_case, e.g. works_at or founded.
+- The descriptions are a single sentence based on the text.
+- Only extract the facts stated in the text.`
+
+// llmExtractor extracts the knowledge graph of the documents with a chat model.
+type llmExtractor struct {
+	chatModel   model.BaseChatModel
+	prompt      string
+	entityTypes []string
+}
+
+func (e *llmExtractor) extract(ctx context.Context, doc *schema.Document) (*Graph, error) {
+	prompt := e.prompt
+	if len(e.entityTypes) > 0 {
+		prompt += "\n- Only use these entity types: " + strings.Join(e.entityTypes, ", ") + "."
+	}
+	msg, err := e.chatModel.Generate(makeChatModelCtx(ctx, e.chatModel), []*schema.Message{
+		schema.SystemMessage(prompt),
+		schema.UserMessage(doc.Content),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[extract] generate failed: %w", err)
+	}
+	graph, err := parseGraph(msg.Content)
+	if err != nil {
+		return nil, fmt.Errorf("[extract] invalid graph of document %s: %w", doc.ID, err)
+	}
+	return graph, nil
+}
+
+// parseGraph parses the JSON graph in the output of the model, possibly in a code block or after some text.
+func parseGraph(output string) (*Graph, error) {
+	start := strings.Index(output, "{")
+	end := strings.LastIndex(output, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("no JSON object in output: %q", truncate(output, 200))
+	}
+	graph := &Graph{}
+	if err := json.Unmarshal([]byte(output[start:end+1]), graph); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal graph: %w", err)
+	}
+	return graph, nil
+}
+
+func makeChatModelCtx(ctx context.Context, cm model.BaseChatModel) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfChatModel,
+	}
+
+	if typ, ok := components.GetType(cm); ok {
+		runInfo.Type = typ
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
+
+// entityID is the id of the entity node of a name, ignoring the case and the spaces.
+func entityID(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// relationType normalizes the type of a relation in snake_case, e.g. "Works At" is "works_at".
+func relationType(typ string) string {
+	return strings.ToLower(strings.Join(strings.Fields(strings.ReplaceAll(typ, "-", " ")), "_"))
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
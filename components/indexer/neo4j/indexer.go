@@ -0,0 +1,290 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package neo4j
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+const (
+	defaultEntityLabel   = "Entity"
+	defaultChunkLabel    = "Chunk"
+	defaultFullTextIndex = "entity_name"
+
+	// relMentions links a chunk to the entities it mentions, and relRelatesTo links two entities,
+	// the type of the relation being the type property, as the relationship types can't be parameters of a query.
+	relMentions  = "MENTIONS"
+	relRelatesTo = "RELATES_TO"
+)
+
+var identifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type IndexerConfig struct {
+	// Driver is the neo4j driver, safe for concurrent use.
+	// Required.
+	Driver neo4j.DriverWithContext
+	// Database is the name of the database.
+	// Optional. Default the default database of the server.
+	Database string
+
+	// ChatModel extracts the entities and the relations of the documents.
+	// Required unless Extract is set.
+	ChatModel model.BaseChatModel
+	// ExtractionPrompt is the system prompt of the extraction, which must ask for the JSON format of Graph.
+	// Optional. Default a prompt extracting the entities and the relations stated in the text.
+	ExtractionPrompt string
+	// EntityTypes restricts the types of the extracted entities, e.g. []string{"person", "organization", "product"}.
+	// Optional. Default the model chooses the types.
+	EntityTypes []string
+	// Extract extracts the knowledge graph of a document, instead of the ChatModel.
+	// Optional.
+	Extract ExtractFn
+
+	// EntityLabel is the label of the entity nodes.
+	// Optional. Default "Entity".
+	EntityLabel string
+	// ChunkLabel is the label of the chunk nodes, storing the content and the metadata of the documents.
+	// Optional. Default "Chunk".
+	ChunkLabel string
+	// FullTextIndex is the name of the full-text index on the names of the entities, created by EnsureSchema
+	// and searched by the retriever.
+	// Optional. Default "entity_name".
+	FullTextIndex string
+}
+
+// Indexer stores the documents as chunk nodes, linked to the entity nodes they mention,
+// the entities being linked by the relations extracted from the documents.
+type Indexer struct {
+	config   *IndexerConfig
+	runQuery func(ctx context.Context, query string, params map[string]any) (*neo4j.EagerResult, error)
+}
+
+func NewIndexer(ctx context.Context, config *IndexerConfig) (*Indexer, error) {
+	if config == nil {
+		return nil, fmt.Errorf("[NewIndexer] config not provided")
+	}
+	if config.Driver == nil {
+		return nil, fmt.Errorf("[NewIndexer] neo4j driver not provided")
+	}
+	if config.Extract == nil {
+		if config.ChatModel == nil {
+			return nil, fmt.Errorf("[NewIndexer] chat model or extract function not provided")
+		}
+		prompt := config.ExtractionPrompt
+		if prompt == "" {
+			prompt = defaultExtractionPrompt
+		}
+		e := &llmExtractor{chatModel: config.ChatModel, prompt: prompt, entityTypes: config.EntityTypes}
+		config.Extract = e.extract
+	}
+	if config.EntityLabel == "" {
+		config.EntityLabel = defaultEntityLabel
+	}
+	if config.ChunkLabel == "" {
+		config.ChunkLabel = defaultChunkLabel
+	}
+	if config.FullTextIndex == "" {
+		config.FullTextIndex = defaultFullTextIndex
+	}
+	for _, name := range []string{config.EntityLabel, config.ChunkLabel, config.FullTextIndex} {
+		if !identifierRegexp.MatchString(name) {
+			return nil, fmt.Errorf("[NewIndexer] invalid label or index name: %q", name)
+		}
+	}
+
+	i := &Indexer{config: config}
+	i.runQuery = func(ctx context.Context, query string, params map[string]any) (*neo4j.EagerResult, error) {
+		return neo4j.ExecuteQuery(ctx, config.Driver, query, params, neo4j.EagerResultTransformer,
+			neo4j.ExecuteQueryWithDatabase(config.Database), neo4j.ExecuteQueryWithWritersRouting())
+	}
+	return i, nil
+}
+
+// EnsureSchema creates the uniqueness constraints of the entity and the chunk nodes,
+// and the full-text index on the names of the entities, if they don't exist.
+func (i *Indexer) EnsureSchema(ctx context.Context) error {
+	c := i.config
+	queries := []string{
+		fmt.Sprintf("CREATE CONSTRAINT %s_id IF NOT EXISTS FOR (n:%s) REQUIRE n.id IS UNIQUE",
+			strings.ToLower(c.EntityLabel), c.EntityLabel),
+		fmt.Sprintf("CREATE CONSTRAINT %s_id IF NOT EXISTS FOR (n:%s) REQUIRE n.id IS UNIQUE",
+			strings.ToLower(c.ChunkLabel), c.ChunkLabel),
+		fmt.Sprintf("CREATE FULLTEXT INDEX %s IF NOT EXISTS FOR (n:%s) ON EACH [n.name]",
+			c.FullTextIndex, c.EntityLabel),
+	}
+	for _, q := range queries {
+		if _, err := i.runQuery(ctx, q, nil); err != nil {
+			return fmt.Errorf("[EnsureSchema] failed to run %q: %w", q, err)
+		}
+	}
+	return nil
+}
+
+func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) (ids []string, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{Docs: docs})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	ids = make([]string, 0, len(docs))
+	for _, doc := range docs {
+		if doc.ID == "" {
+			return nil, fmt.Errorf("[Store] doc id not set")
+		}
+		graph, err := i.config.Extract(ctx, doc)
+		if err != nil {
+			return nil, err
+		}
+		params, err := storeParams(doc, graph)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = i.runQuery(ctx, i.storeQuery(), params); err != nil {
+			return nil, fmt.Errorf("[Store] failed to store doc %s: %w", doc.ID, err)
+		}
+		ids = append(ids, doc.ID)
+	}
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{IDs: ids})
+
+	return ids, nil
+}
+
+// storeQuery replaces the chunk of a document and its mentions, merges its entities and relations,
+// in a single statement run in a single transaction.
+func (i *Indexer) storeQuery() string {
+	return strings.NewReplacer("$Chunk", i.config.ChunkLabel, "$Entity", i.config.EntityLabel,
+		"$MENTIONS", relMentions, "$RELATES_TO", relRelatesTo).Replace(`MERGE (c:$Chunk {id: $id})
+SET c.content = $content, c.metadata = $metadata
+WITH c
+CALL {
+  WITH c
+  OPTIONAL MATCH (c)-[m:$MENTIONS]->()
+  DELETE m
+}
+CALL {
+  WITH c
+  UNWIND $entities AS e
+  MERGE (n:$Entity {id: e.id})
+  ON CREATE SET n.name = e.name
+  SET n.type = CASE WHEN e.type <> '' THEN e.type ELSE n.type END,
+      n.description = CASE WHEN e.description <> '' THEN e.description ELSE n.description END
+  MERGE (c)-[:$MENTIONS]->(n)
+}
+CALL {
+  WITH c
+  UNWIND $relations AS r
+  MATCH (s:$Entity {id: r.source})
+  MATCH (t:$Entity {id: r.target})
+  MERGE (s)-[rel:$RELATES_TO {type: r.type}]->(t)
+  SET rel.description = CASE WHEN r.description <> '' THEN r.description ELSE rel.description END,
+      rel.chunk_ids = CASE WHEN c.id IN coalesce(rel.chunk_ids, []) THEN rel.chunk_ids ELSE coalesce(rel.chunk_ids, []) + c.id END
+}
+RETURN c.id AS id`)
+}
+
+// storeParams are the parameters of the store query, the entities being deduplicated,
+// and the entities of the relations being added when the extraction missed them.
+func storeParams(doc *schema.Document, graph *Graph) (map[string]any, error) {
+	metadata := make(map[string]any, len(doc.MetaData))
+	for k, v := range doc.MetaData {
+		// skip the internal metadata, e.g. the score or the vectors
+		if !strings.HasPrefix(k, "_") {
+			metadata[k] = v
+		}
+	}
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("[Store] failed to marshal metadata of doc %s: %w", doc.ID, err)
+	}
+
+	var (
+		entities  []any
+		relations []any
+		seen      = map[string]bool{}
+	)
+	addEntity := func(e *Entity) {
+		id := entityID(e.Name)
+		if id == "" || seen[id] {
+			return
+		}
+		seen[id] = true
+		entities = append(entities, map[string]any{
+			"id":          id,
+			"name":        strings.TrimSpace(e.Name),
+			"type":        strings.ToLower(strings.TrimSpace(e.Type)),
+			"description": strings.TrimSpace(e.Description),
+		})
+	}
+	if graph != nil {
+		for _, e := range graph.Entities {
+			if e != nil {
+				addEntity(e)
+			}
+		}
+		for _, r := range graph.Relations {
+			if r == nil || entityID(r.Source) == "" || entityID(r.Target) == "" || relationType(r.Type) == "" {
+				continue
+			}
+			addEntity(&Entity{Name: r.Source})
+			addEntity(&Entity{Name: r.Target})
+			relations = append(relations, map[string]any{
+				"source":      entityID(r.Source),
+				"target":      entityID(r.Target),
+				"type":        relationType(r.Type),
+				"description": strings.TrimSpace(r.Description),
+			})
+		}
+	}
+	if entities == nil {
+		entities = []any{}
+	}
+	if relations == nil {
+		relations = []any{}
+	}
+
+	return map[string]any{
+		"id":        doc.ID,
+		"content":   doc.Content,
+		"metadata":  string(metadataJSON),
+		"entities":  entities,
+		"relations": relations,
+	}, nil
+}
+
+const typ = "Neo4j"
+
+func (i *Indexer) GetType() string {
+	return typ
+}
+
+func (i *Indexer) IsCallbacksEnabled() bool {
+	return true
+}
@@ -0,0 +1,192 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package neo4j
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeChatModel struct {
+	output string
+	err    error
+	input  []*schema.Message
+}
+
+func (f *fakeChatModel) Generate(_ context.Context, input []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	f.input = input
+	if f.err != nil {
+		return nil, f.err
+	}
+	return schema.AssistantMessage(f.output, nil), nil
+}
+
+func (f *fakeChatModel) Stream(_ context.Context, _ []*schema.Message, _ ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return nil, errors.New("not implemented")
+}
+
+type query struct {
+	cypher string
+	params map[string]any
+}
+
+func newTestIndexer(t *testing.T, config *IndexerConfig) (*Indexer, *[]query) {
+	driver, err := neo4j.NewDriverWithContext("neo4j://localhost:7687", neo4j.NoAuth())
+	assert.NoError(t, err)
+	config.Driver = driver
+	i, err := NewIndexer(context.Background(), config)
+	assert.NoError(t, err)
+	var queries []query
+	i.runQuery = func(_ context.Context, cypher string, params map[string]any) (*neo4j.EagerResult, error) {
+		queries = append(queries, query{cypher: cypher, params: params})
+		if params["id"] == "fail" {
+			return nil, errors.New("connection refused")
+		}
+		return &neo4j.EagerResult{}, nil
+	}
+	return i, &queries
+}
+
+func TestNewIndexer(t *testing.T) {
+	ctx := context.Background()
+	driver, err := neo4j.NewDriverWithContext("neo4j://localhost:7687", neo4j.NoAuth())
+	assert.NoError(t, err)
+
+	_, err = NewIndexer(ctx, nil)
+	assert.EqualError(t, err, "[NewIndexer] config not provided")
+	_, err = NewIndexer(ctx, &IndexerConfig{})
+	assert.EqualError(t, err, "[NewIndexer] neo4j driver not provided")
+	_, err = NewIndexer(ctx, &IndexerConfig{Driver: driver})
+	assert.EqualError(t, err, "[NewIndexer] chat model or extract function not provided")
+	_, err = NewIndexer(ctx, &IndexerConfig{Driver: driver, ChatModel: &fakeChatModel{}, EntityLabel: "Entity`) DETACH DELETE n //"})
+	assert.EqualError(t, err, "[NewIndexer] invalid label or index name: \"Entity`) DETACH DELETE n //\"")
+
+	i, err := NewIndexer(ctx, &IndexerConfig{Driver: driver, ChatModel: &fakeChatModel{}})
+	assert.NoError(t, err)
+	assert.Equal(t, "Neo4j", i.GetType())
+	assert.True(t, i.IsCallbacksEnabled())
+}
+
+func TestEnsureSchema(t *testing.T) {
+	i, queries := newTestIndexer(t, &IndexerConfig{ChatModel: &fakeChatModel{}, ChunkLabel: "Passage"})
+	assert.NoError(t, i.EnsureSchema(context.Background()))
+	assert.Equal(t, []query{
+		{cypher: "CREATE CONSTRAINT entity_id IF NOT EXISTS FOR (n:Entity) REQUIRE n.id IS UNIQUE"},
+		{cypher: "CREATE CONSTRAINT passage_id IF NOT EXISTS FOR (n:Passage) REQUIRE n.id IS UNIQUE"},
+		{cypher: "CREATE FULLTEXT INDEX entity_name IF NOT EXISTS FOR (n:Entity) ON EACH [n.name]"},
+	}, *queries)
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	cm := &fakeChatModel{output: "Here is the graph:\n```json\n" + `{
+		"entities": [
+			{"name": "Marie Curie", "type": "Person", "description": "A physicist and chemist."},
+			{"name": "marie  curie", "type": "person"},
+			{"name": "Radium", "type": "Element", "description": "A radioactive element."},
+			{"name": " "}
+		],
+		"relations": [
+			{"source": "Marie Curie", "target": "Radium", "type": "Discovered", "description": "Marie Curie discovered radium in 1898."},
+			{"source": "Marie Curie", "target": "University of Paris", "type": "works-at"},
+			{"source": "Marie Curie", "target": "", "type": "knows"}
+		]
+	}` + "\n```"}
+	i, queries := newTestIndexer(t, &IndexerConfig{ChatModel: cm, EntityTypes: []string{"person", "element"}})
+
+	ids, err := i.Store(ctx, []*schema.Document{{
+		ID:       "doc-1",
+		Content:  "Marie Curie discovered radium in 1898 at the University of Paris.",
+		MetaData: map[string]any{"source": "curie.md", "_dense_vector": []float64{0.1}},
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"doc-1"}, ids)
+
+	assert.Len(t, cm.input, 2)
+	assert.Contains(t, cm.input[0].Content, "- Only use these entity types: person, element.")
+	assert.Equal(t, "Marie Curie discovered radium in 1898 at the University of Paris.", cm.input[1].Content)
+
+	assert.Len(t, *queries, 1)
+	q := (*queries)[0]
+	assert.Contains(t, q.cypher, "MERGE (c:Chunk {id: $id})")
+	assert.Contains(t, q.cypher, "MERGE (n:Entity {id: e.id})")
+	assert.Contains(t, q.cypher, "MERGE (s)-[rel:RELATES_TO {type: r.type}]->(t)")
+	assert.Equal(t, map[string]any{
+		"id":       "doc-1",
+		"content":  "Marie Curie discovered radium in 1898 at the University of Paris.",
+		"metadata": `{"source":"curie.md"}`,
+		"entities": []any{
+			map[string]any{"id": "marie curie", "name": "Marie Curie", "type": "person", "description": "A physicist and chemist."},
+			map[string]any{"id": "radium", "name": "Radium", "type": "element", "description": "A radioactive element."},
+			map[string]any{"id": "university of paris", "name": "University of Paris", "type": "", "description": ""},
+		},
+		"relations": []any{
+			map[string]any{"source": "marie curie", "target": "radium", "type": "discovered", "description": "Marie Curie discovered radium in 1898."},
+			map[string]any{"source": "marie curie", "target": "university of paris", "type": "works_at", "description": ""},
+		},
+	}, q.params)
+}
+
+func TestStoreErrors(t *testing.T) {
+	ctx := context.Background()
+
+	i, _ := newTestIndexer(t, &IndexerConfig{ChatModel: &fakeChatModel{output: `{"entities": []}`}})
+	_, err := i.Store(ctx, []*schema.Document{{Content: "text"}})
+	assert.EqualError(t, err, "[Store] doc id not set")
+	_, err = i.Store(ctx, []*schema.Document{{ID: "fail", Content: "text"}})
+	assert.EqualError(t, err, "[Store] failed to store doc fail: connection refused")
+
+	i, _ = newTestIndexer(t, &IndexerConfig{ChatModel: &fakeChatModel{output: "I can't find any entity."}})
+	_, err = i.Store(ctx, []*schema.Document{{ID: "doc-1", Content: "text"}})
+	assert.EqualError(t, err, `[extract] invalid graph of document doc-1: no JSON object in output: "I can't find any entity."`)
+
+	i, _ = newTestIndexer(t, &IndexerConfig{ChatModel: &fakeChatModel{err: errors.New("rate limited")}})
+	_, err = i.Store(ctx, []*schema.Document{{ID: "doc-1", Content: "text"}})
+	assert.EqualError(t, err, "[extract] generate failed: rate limited")
+}
+
+func TestStoreWithExtract(t *testing.T) {
+	i, queries := newTestIndexer(t, &IndexerConfig{
+		Extract: func(ctx context.Context, doc *schema.Document) (*Graph, error) {
+			return nil, nil
+		},
+	})
+	_, err := i.Store(context.Background(), []*schema.Document{{ID: "doc-1", Content: "text"}})
+	assert.NoError(t, err)
+	params := (*queries)[0].params
+	assert.Equal(t, []any{}, params["entities"])
+	assert.Equal(t, []any{}, params["relations"])
+	assert.Equal(t, "{}", params["metadata"])
+}
+
+func TestParseGraph(t *testing.T) {
+	graph, err := parseGraph(`{"entities": [{"name": "Go", "type": "language"}], "relations": []}`)
+	assert.NoError(t, err)
+	assert.Equal(t, &Graph{Entities: []*Entity{{Name: "Go", Type: "language"}}, Relations: []*Relation{}}, graph)
+
+	_, err = parseGraph(`{"entities": [`)
+	assert.EqualError(t, err, `no JSON object in output: "{\"entities\": ["`)
+	_, err = parseGraph(`{"entities": 1}`)
+	var typeErr *json.UnmarshalTypeError
+	assert.ErrorAs(t, err, &typeErr)
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"database/sql"
+	"hash/fnv"
+	"log"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/cloudwego/eino-ext/components/indexer/sqlite"
+)
+
+// go run -tags sqlite_fts5 ./examples
+func main() {
+	ctx := context.Background()
+
+	db, err := sql.Open("sqlite3", "file:knowledge.db")
+	if err != nil {
+		log.Fatalf("Failed to open db: %v", err)
+	}
+	defer db.Close()
+
+	idx, err := sqlite.NewIndexer(ctx, &sqlite.IndexerConfig{
+		DB:        db,
+		Embedding: &mockEmbedding{}, // replace with real embedding.
+	})
+	if err != nil {
+		log.Fatalf("Failed to create indexer: %v", err)
+	}
+
+	ids, err := idx.Store(ctx, []*schema.Document{
+		{ID: "1", Content: "SQLite is a self-contained, serverless SQL database engine.", MetaData: map[string]any{"topic": "sqlite"}},
+		{ID: "2", Content: "FTS5 is the full-text search extension of SQLite.", MetaData: map[string]any{"topic": "sqlite"}},
+		{ID: "3", Content: "Eino is a framework for building LLM applications in Go.", MetaData: map[string]any{"topic": "eino"}},
+	})
+	if err != nil {
+		log.Fatalf("Failed to store: %v", err)
+	}
+	log.Printf("stored: %v", ids)
+}
+
+// mockEmbedding hashes the words of the texts into 64 dimensions.
+type mockEmbedding struct{}
+
+func (m *mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	vectors := make([][]float64, 0, len(texts))
+	for _, text := range texts {
+		vec := make([]float64, 64)
+		h := fnv.New32a()
+		for _, c := range []byte(text) {
+			if c == ' ' {
+				vec[h.Sum32()%64]++
+				h.Reset()
+				continue
+			}
+			_, _ = h.Write([]byte{c})
+		}
+		vec[h.Sum32()%64]++
+		vectors = append(vectors, vec)
+	}
+	return vectors, nil
+}
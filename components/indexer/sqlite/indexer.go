@@ -0,0 +1,304 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	defaultTable     = "documents"
+	defaultTokenizer = "unicode61"
+	defaultBatchSize = 10
+
+	// ftsTableSuffix is the suffix of the name of the FTS5 table of the table, e.g. "documents_fts".
+	ftsTableSuffix = "_fts"
+)
+
+var identifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type IndexerConfig struct {
+	// DB is the SQLite database, e.g. sql.Open("sqlite3", "file:knowledge.db").
+	// The driver must be built with FTS5, e.g. with the sqlite_fts5 build tag of github.com/mattn/go-sqlite3,
+	// and the retriever requires the sqlite-vec extension to be loaded for the vector search.
+	// Required.
+	DB *sql.DB
+	// Table is the name of the table of the documents, the full-text index being the Table+"_fts" FTS5 table.
+	// Both are created if they don't exist.
+	// Optional. Default "documents".
+	Table string
+	// Tokenizer is the FTS5 tokenizer of the full-text index, e.g. "porter unicode61" for english stemming,
+	// or "trigram" for languages without spaces between the words. It only applies when the index is created.
+	// see: https://www.sqlite.org/fts5.html#tokenizers
+	// Optional. Default "unicode61".
+	Tokenizer string
+	// Embedding vectorizes the content of the documents, stored as float32 vectors in the format of sqlite-vec.
+	// Optional. Default the documents are only indexed for the full-text search.
+	Embedding embedding.Embedder
+	// BatchSize is the number of the contents embedded at once.
+	// Optional. Default 10.
+	BatchSize int
+}
+
+// Indexer stores the documents in a single SQLite table, with their full-text index and their embeddings,
+// for the hybrid search of the sqlite retriever.
+type Indexer struct {
+	config *IndexerConfig
+}
+
+func NewIndexer(ctx context.Context, config *IndexerConfig) (*Indexer, error) {
+	if config == nil {
+		return nil, fmt.Errorf("[NewIndexer] config not provided")
+	}
+	if config.DB == nil {
+		return nil, fmt.Errorf("[NewIndexer] sqlite db not provided")
+	}
+	if config.Table == "" {
+		config.Table = defaultTable
+	}
+	if !identifierRegexp.MatchString(config.Table) {
+		return nil, fmt.Errorf("[NewIndexer] invalid table name: %q", config.Table)
+	}
+	if config.Tokenizer == "" {
+		config.Tokenizer = defaultTokenizer
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultBatchSize
+	}
+
+	i := &Indexer{config: config}
+	if err := i.createTables(ctx); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+func (i *Indexer) createTables(ctx context.Context) error {
+	table := i.config.Table
+	stmts := []string{
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	id TEXT PRIMARY KEY,
+	content TEXT NOT NULL,
+	metadata TEXT NOT NULL DEFAULT '{}',
+	embedding BLOB
+)`, table),
+		fmt.Sprintf(`CREATE VIRTUAL TABLE IF NOT EXISTS %s%s USING fts5(id UNINDEXED, content, tokenize = '%s')`,
+			table, ftsTableSuffix, strings.ReplaceAll(i.config.Tokenizer, "'", "''")),
+	}
+	for _, stmt := range stmts {
+		if _, err := i.config.DB.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("[NewIndexer] failed to create table: %w", err)
+		}
+	}
+	return nil
+}
+
+// Store inserts the documents, or replaces the ones with the same id, in a single transaction.
+func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) (ids []string, err error) {
+	options := indexer.GetCommonOptions(&indexer.Options{
+		Embedding: i.config.Embedding,
+	}, opts...)
+
+	ctx = callbacks.EnsureRunInfo(ctx, i.GetType(), components.ComponentOfIndexer)
+	ctx = callbacks.OnStart(ctx, &indexer.CallbackInput{Docs: docs})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	for _, doc := range docs {
+		if doc.ID == "" {
+			return nil, fmt.Errorf("[Store] doc id not set")
+		}
+	}
+
+	vectors, err := i.embed(ctx, options.Embedding, docs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = i.insert(ctx, docs, vectors); err != nil {
+		return nil, err
+	}
+
+	ids = make([]string, 0, len(docs))
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+	}
+
+	callbacks.OnEnd(ctx, &indexer.CallbackOutput{IDs: ids})
+
+	return ids, nil
+}
+
+// Delete deletes the documents of the ids, the missing ones being ignored.
+func (i *Indexer) Delete(ctx context.Context, ids ...string) (err error) {
+	tx, err := i.config.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("[Delete] failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	for _, id := range ids {
+		if _, err = tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE id = ?`, i.config.Table), id); err != nil {
+			return fmt.Errorf("[Delete] failed to delete document: %w", err)
+		}
+		if _, err = tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s%s WHERE id = ?`, i.config.Table, ftsTableSuffix), id); err != nil {
+			return fmt.Errorf("[Delete] failed to delete document: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("[Delete] failed to commit: %w", err)
+	}
+	return nil
+}
+
+func (i *Indexer) embed(ctx context.Context, emb embedding.Embedder, docs []*schema.Document) ([][]float64, error) {
+	if emb == nil {
+		return nil, nil
+	}
+
+	vectors := make([][]float64, 0, len(docs))
+	for start := 0; start < len(docs); start += i.config.BatchSize {
+		end := min(start+i.config.BatchSize, len(docs))
+		texts := make([]string, 0, end-start)
+		for _, doc := range docs[start:end] {
+			texts = append(texts, doc.Content)
+		}
+
+		batch, err := emb.EmbedStrings(i.makeEmbeddingCtx(ctx, emb), texts)
+		if err != nil {
+			return nil, fmt.Errorf("[Store] embedding failed, %w", err)
+		}
+		if len(batch) != len(texts) {
+			return nil, fmt.Errorf("[Store] invalid vector length, expected=%d, got=%d", len(texts), len(batch))
+		}
+		vectors = append(vectors, batch...)
+	}
+	return vectors, nil
+}
+
+func (i *Indexer) insert(ctx context.Context, docs []*schema.Document, vectors [][]float64) (err error) {
+	tx, err := i.config.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("[Store] failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	upsert := fmt.Sprintf(`INSERT INTO %s (id, content, metadata, embedding) VALUES (?, ?, ?, ?)
+ON CONFLICT (id) DO UPDATE SET content = excluded.content, metadata = excluded.metadata, embedding = excluded.embedding`,
+		i.config.Table)
+	deleteFTS := fmt.Sprintf(`DELETE FROM %s%s WHERE id = ?`, i.config.Table, ftsTableSuffix)
+	insertFTS := fmt.Sprintf(`INSERT INTO %s%s (id, content) VALUES (?, ?)`, i.config.Table, ftsTableSuffix)
+
+	for idx, doc := range docs {
+		var metadata string
+		if metadata, err = marshalMetadata(doc.MetaData); err != nil {
+			return fmt.Errorf("[Store] failed to marshal metadata of doc %s: %w", doc.ID, err)
+		}
+		var vec []byte
+		if vectors != nil {
+			vec = vector2Bytes(vectors[idx])
+		}
+
+		if _, err = tx.ExecContext(ctx, upsert, doc.ID, doc.Content, metadata, vec); err != nil {
+			return fmt.Errorf("[Store] failed to insert doc %s: %w", doc.ID, err)
+		}
+		if _, err = tx.ExecContext(ctx, deleteFTS, doc.ID); err != nil {
+			return fmt.Errorf("[Store] failed to insert doc %s: %w", doc.ID, err)
+		}
+		if _, err = tx.ExecContext(ctx, insertFTS, doc.ID, doc.Content); err != nil {
+			return fmt.Errorf("[Store] failed to insert doc %s: %w", doc.ID, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("[Store] failed to commit: %w", err)
+	}
+	return nil
+}
+
+// marshalMetadata marshals the metadata to JSON, without the keys starting with "_", which the schema.Document uses
+// for the score, the vectors, etc.
+func marshalMetadata(metadata map[string]any) (string, error) {
+	m := make(map[string]any, len(metadata))
+	for k, v := range metadata {
+		if !strings.HasPrefix(k, "_") {
+			m[k] = v
+		}
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// vector2Bytes converts the vector to the float32 little-endian blob of sqlite-vec.
+func vector2Bytes(vector []float64) []byte {
+	b := make([]byte, 4*len(vector))
+	for idx, v := range vector {
+		binary.LittleEndian.PutUint32(b[4*idx:], math.Float32bits(float32(v)))
+	}
+	return b
+}
+
+func (i *Indexer) makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfEmbedding,
+	}
+
+	if embType, ok := components.GetType(emb); ok {
+		runInfo.Type = embType
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
+
+const typ = "SQLite"
+
+func (i *Indexer) GetType() string {
+	return typ
+}
+
+func (i *Indexer) IsCallbacksEnabled() bool {
+	return true
+}
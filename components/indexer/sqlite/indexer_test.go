@@ -0,0 +1,189 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	// pure go driver built with FTS5, for the tests
+	_ "modernc.org/sqlite"
+)
+
+type mockEmbedding struct {
+	calls int
+	err   error
+}
+
+func (m *mockEmbedding) EmbedStrings(_ context.Context, texts []string, _ ...embedding.Option) ([][]float64, error) {
+	m.calls++
+	if m.err != nil {
+		return nil, m.err
+	}
+	vectors := make([][]float64, 0, len(texts))
+	for _, text := range texts {
+		vectors = append(vectors, []float64{float64(len(text)), 0.5})
+	}
+	return vectors, nil
+}
+
+func openTestDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite", ":memory:")
+	assert.NoError(t, err)
+	// each connection has its own in-memory database
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func search(t *testing.T, db *sql.DB, match string) []string {
+	rows, err := db.Query(`SELECT id FROM documents_fts WHERE documents_fts MATCH ? ORDER BY id`, match)
+	assert.NoError(t, err)
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		assert.NoError(t, rows.Scan(&id))
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestNewIndexer(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+
+	_, err := NewIndexer(ctx, nil)
+	assert.EqualError(t, err, "[NewIndexer] config not provided")
+	_, err = NewIndexer(ctx, &IndexerConfig{})
+	assert.EqualError(t, err, "[NewIndexer] sqlite db not provided")
+	_, err = NewIndexer(ctx, &IndexerConfig{DB: db, Table: "docs; DROP TABLE users"})
+	assert.EqualError(t, err, `[NewIndexer] invalid table name: "docs; DROP TABLE users"`)
+	_, err = NewIndexer(ctx, &IndexerConfig{DB: db, Tokenizer: "unknown"})
+	assert.ErrorContains(t, err, "[NewIndexer] failed to create table")
+
+	i, err := NewIndexer(ctx, &IndexerConfig{DB: db, Tokenizer: "porter unicode61"})
+	assert.NoError(t, err)
+	assert.Equal(t, "documents", i.config.Table)
+	assert.Equal(t, 10, i.config.BatchSize)
+	assert.Equal(t, "SQLite", i.GetType())
+	assert.True(t, i.IsCallbacksEnabled())
+
+	// the tables already exist
+	_, err = NewIndexer(ctx, &IndexerConfig{DB: db})
+	assert.NoError(t, err)
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("store and replace", func(t *testing.T) {
+		db := openTestDB(t)
+		emb := &mockEmbedding{}
+		i, err := NewIndexer(ctx, &IndexerConfig{DB: db, Embedding: emb, BatchSize: 2})
+		assert.NoError(t, err)
+
+		doc := &schema.Document{ID: "1", Content: "sqlite runs everywhere", MetaData: map[string]any{"source": "a.md"}}
+		doc.WithScore(0.9)
+		ids, err := i.Store(ctx, []*schema.Document{
+			doc,
+			{ID: "2", Content: "vectors in a single file"},
+			{ID: "3", Content: "no external services"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1", "2", "3"}, ids)
+		assert.Equal(t, 2, emb.calls)
+
+		var (
+			content, metadata string
+			vec               []byte
+		)
+		assert.NoError(t, db.QueryRow(`SELECT content, metadata, embedding FROM documents WHERE id = '1'`).Scan(&content, &metadata, &vec))
+		assert.Equal(t, "sqlite runs everywhere", content)
+		assert.JSONEq(t, `{"source":"a.md"}`, metadata)
+		assert.Equal(t, vector2Bytes([]float64{22, 0.5}), vec)
+		assert.Equal(t, []string{"1"}, search(t, db, "sqlite"))
+
+		_, err = i.Store(ctx, []*schema.Document{{ID: "1", Content: "replaced content"}})
+		assert.NoError(t, err)
+		assert.NoError(t, db.QueryRow(`SELECT content, metadata FROM documents WHERE id = '1'`).Scan(&content, &metadata))
+		assert.Equal(t, "replaced content", content)
+		assert.Equal(t, "{}", metadata)
+		assert.Empty(t, search(t, db, "sqlite"))
+		assert.Equal(t, []string{"1"}, search(t, db, "replaced"))
+
+		var count int
+		assert.NoError(t, db.QueryRow(`SELECT count(*) FROM documents_fts`).Scan(&count))
+		assert.Equal(t, 3, count)
+	})
+
+	t.Run("full-text only", func(t *testing.T) {
+		db := openTestDB(t)
+		i, err := NewIndexer(ctx, &IndexerConfig{DB: db, Table: "notes"})
+		assert.NoError(t, err)
+		_, err = i.Store(ctx, []*schema.Document{{ID: "1", Content: "offline assistant"}})
+		assert.NoError(t, err)
+
+		var vec []byte
+		assert.NoError(t, db.QueryRow(`SELECT embedding FROM notes WHERE id = '1'`).Scan(&vec))
+		assert.Nil(t, vec)
+
+		// the embedding of the options
+		emb := &mockEmbedding{}
+		_, err = i.Store(ctx, []*schema.Document{{ID: "2", Content: "offline"}}, indexer.WithEmbedding(emb))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, emb.calls)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		db := openTestDB(t)
+		i, err := NewIndexer(ctx, &IndexerConfig{DB: db, Embedding: &mockEmbedding{err: errors.New("quota exceeded")}})
+		assert.NoError(t, err)
+
+		_, err = i.Store(ctx, []*schema.Document{{Content: "no id"}})
+		assert.EqualError(t, err, "[Store] doc id not set")
+		_, err = i.Store(ctx, []*schema.Document{{ID: "1", Content: "text"}})
+		assert.EqualError(t, err, "[Store] embedding failed, quota exceeded")
+		_, err = i.Store(ctx, []*schema.Document{{ID: "1", MetaData: map[string]any{"ch": make(chan int)}}}, indexer.WithEmbedding(&mockEmbedding{}))
+		assert.ErrorContains(t, err, "[Store] failed to marshal metadata of doc 1")
+
+		var count int
+		assert.NoError(t, db.QueryRow(`SELECT count(*) FROM documents`).Scan(&count))
+		assert.Zero(t, count)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	i, err := NewIndexer(ctx, &IndexerConfig{DB: db})
+	assert.NoError(t, err)
+	_, err = i.Store(ctx, []*schema.Document{{ID: "1", Content: "first"}, {ID: "2", Content: "second"}})
+	assert.NoError(t, err)
+
+	assert.NoError(t, i.Delete(ctx, "1", "missing"))
+	var count int
+	assert.NoError(t, db.QueryRow(`SELECT count(*) FROM documents`).Scan(&count))
+	assert.Equal(t, 1, count)
+	assert.Empty(t, search(t, db, "first"))
+	assert.Equal(t, []string{"2"}, search(t, db, "second"))
+}
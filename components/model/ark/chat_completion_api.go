@@ -33,6 +33,8 @@ import (
 	"github.com/cloudwego/eino/components"
 	fmodel "github.com/cloudwego/eino/components/model"
 	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/model/ark/internal/multimodal"
 )
 
 type completionAPIChatModel struct {
@@ -542,133 +544,24 @@ func (cm *completionAPIChatModel) toArkContent(msg *schema.Message) (*model.Chat
 			return nil, fmt.Errorf("user input multi content only support user role, got %s", msg.Role)
 		}
 		parts = make([]*model.ChatCompletionMessageContentPart, 0, len(msg.UserInputMultiContent))
-		var err error
 		for _, part := range msg.UserInputMultiContent {
-			switch part.Type {
-			case schema.ChatMessagePartTypeText:
-				parts = append(parts, &model.ChatCompletionMessageContentPart{
-					Type: model.ChatCompletionMessageContentPartTypeText,
-					Text: part.Text,
-				})
-			case schema.ChatMessagePartTypeImageURL:
-				if part.Image == nil {
-					return nil, fmt.Errorf("image field must not be nil when Type is ChatMessagePartTypeImageURL in user message")
-				}
-				var imageURL string
-				if part.Image.URL != nil && *part.Image.URL != "" {
-					imageURL = *part.Image.URL
-				} else if part.Image.Base64Data != nil && *part.Image.Base64Data != "" {
-					if part.Image.MIMEType == "" {
-						return nil, fmt.Errorf("image part must have MIMEType when using Base64Data")
-					}
-					imageURL, err = ensureDataURL(*part.Image.Base64Data, part.Image.MIMEType)
-					if err != nil {
-						return nil, err
-					}
-				} else {
-					return nil, fmt.Errorf("image part for user input must contain either a URL or Base64Data, but got: %+v", part.Image)
-				}
-				parts = append(parts, &model.ChatCompletionMessageContentPart{
-					Type: model.ChatCompletionMessageContentPartTypeImageURL,
-					ImageURL: &model.ChatMessageImageURL{
-						URL:    imageURL,
-						Detail: model.ImageURLDetail(part.Image.Detail),
-					},
-				})
-			case schema.ChatMessagePartTypeVideoURL:
-				if part.Video == nil {
-					return nil, fmt.Errorf("video field must not be nil when Type is ChatMessagePartTypeVideoURL in user message")
-				}
-				var videoURL string
-				if part.Video.URL != nil && *part.Video.URL != "" {
-					videoURL = *part.Video.URL
-				} else if part.Video.Base64Data != nil && *part.Video.Base64Data != "" {
-					if part.Video.MIMEType == "" {
-						return nil, fmt.Errorf("video part must have MIMEType when using Base64Data")
-					}
-					videoURL, err = ensureDataURL(*part.Video.Base64Data, part.Video.MIMEType)
-					if err != nil {
-						return nil, err
-					}
-				} else {
-					return nil, fmt.Errorf("video part for user input must contain either a URL or Base64Data, but got: %+v", part.Video)
-				}
-				parts = append(parts, &model.ChatCompletionMessageContentPart{
-					Type: model.ChatCompletionMessageContentPartTypeVideoURL,
-					VideoURL: &model.ChatMessageVideoURL{
-						URL: videoURL,
-						FPS: GetInputVideoFPS(part.Video),
-					},
-				})
-			default:
-				return nil, fmt.Errorf("unsupported chat message part type in user message: %s", part.Type)
+			p, err := convInputPart(part)
+			if err != nil {
+				return nil, err
 			}
+			parts = append(parts, toChatContentPart(p, GetInputVideoFPS(part.Video)))
 		}
 	} else if len(msg.AssistantGenMultiContent) > 0 {
 		if msg.Role != schema.Assistant {
 			return nil, fmt.Errorf("assistant gen multi content only support assistant role, got %s", msg.Role)
 		}
 		parts = make([]*model.ChatCompletionMessageContentPart, 0, len(msg.AssistantGenMultiContent))
-		var err error
 		for _, part := range msg.AssistantGenMultiContent {
-			switch part.Type {
-			case schema.ChatMessagePartTypeText:
-				parts = append(parts, &model.ChatCompletionMessageContentPart{
-					Type: model.ChatCompletionMessageContentPartTypeText,
-					Text: part.Text,
-				})
-			case schema.ChatMessagePartTypeImageURL:
-				if part.Image == nil {
-					return nil, fmt.Errorf("image field must not be nil when Type is ChatMessagePartTypeImageURL in assistant message")
-				}
-				var imageURL string
-				if part.Image.URL != nil && *part.Image.URL != "" {
-					imageURL = *part.Image.URL
-				} else if part.Image.Base64Data != nil && *part.Image.Base64Data != "" {
-					if part.Image.MIMEType == "" {
-						return nil, fmt.Errorf("image part must have MIMEType when using Base64Data")
-					}
-					imageURL, err = ensureDataURL(*part.Image.Base64Data, part.Image.MIMEType)
-					if err != nil {
-						return nil, err
-					}
-				} else {
-					return nil, fmt.Errorf("image part for assistant output must contain either a URL or Base64Data, but got: %+v", part.Image)
-				}
-				parts = append(parts, &model.ChatCompletionMessageContentPart{
-					Type: model.ChatCompletionMessageContentPartTypeImageURL,
-					ImageURL: &model.ChatMessageImageURL{
-						URL: imageURL,
-					},
-				})
-			case schema.ChatMessagePartTypeVideoURL:
-				if part.Video == nil {
-					return nil, fmt.Errorf("video field must not be nil when Type is ChatMessagePartTypeVideoURL in assistant message")
-				}
-				var videoURL string
-				if part.Video.URL != nil && *part.Video.URL != "" {
-					videoURL = *part.Video.URL
-				} else if part.Video.Base64Data != nil && *part.Video.Base64Data != "" {
-					if part.Video.MIMEType == "" {
-						return nil, fmt.Errorf("video part must have MIMEType when using Base64Data")
-					}
-					videoURL, err = ensureDataURL(*part.Video.Base64Data, part.Video.MIMEType)
-					if err != nil {
-						return nil, err
-					}
-				} else {
-					return nil, fmt.Errorf("video part for assistant output must contain either a URL or Base64Data, but got: %+v", part.Video)
-				}
-				parts = append(parts, &model.ChatCompletionMessageContentPart{
-					Type: model.ChatCompletionMessageContentPartTypeVideoURL,
-					VideoURL: &model.ChatMessageVideoURL{
-						URL: videoURL,
-						FPS: GetOutputVideoFPS(part.Video),
-					},
-				})
-			default:
-				return nil, fmt.Errorf("unsupported chat message part type in assistant message: %s", part.Type)
+			p, err := convOutputPart(part)
+			if err != nil {
+				return nil, err
 			}
+			parts = append(parts, toChatContentPart(p, GetOutputVideoFPS(part.Video)))
 		}
 	} else if len(msg.MultiContent) > 0 {
 		log.Printf("warning: MultiContent is deprecated, use UserInputMultiContent or AssistantGenMultiContent instead")
@@ -713,6 +606,32 @@ func (cm *completionAPIChatModel) toArkContent(msg *schema.Message) (*model.Chat
 	}, nil
 }
 
+func toChatContentPart(p *multimodal.ArkContentPart, fps *float64) *model.ChatCompletionMessageContentPart {
+	switch {
+	case p.ImageURL != nil:
+		return &model.ChatCompletionMessageContentPart{
+			Type: model.ChatCompletionMessageContentPartTypeImageURL,
+			ImageURL: &model.ChatMessageImageURL{
+				URL:    p.ImageURL.URL,
+				Detail: model.ImageURLDetail(p.ImageURL.Detail),
+			},
+		}
+	case p.VideoURL != nil:
+		return &model.ChatCompletionMessageContentPart{
+			Type: model.ChatCompletionMessageContentPartTypeVideoURL,
+			VideoURL: &model.ChatMessageVideoURL{
+				URL: p.VideoURL.URL,
+				FPS: fps,
+			},
+		}
+	default:
+		return &model.ChatCompletionMessageContentPart{
+			Type: model.ChatCompletionMessageContentPartTypeText,
+			Text: p.Text,
+		}
+	}
+}
+
 func (cm *completionAPIChatModel) toArkToolCalls(toolCalls []schema.ToolCall) []*model.ToolCall {
 	if len(toolCalls) == 0 {
 		return nil
@@ -17,10 +17,15 @@
 package ark
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"image"
+	"image/png"
 	"io"
+	"strings"
 	"testing"
 
 	. "github.com/bytedance/mockey"
@@ -31,6 +36,8 @@ import (
 
 	fmodel "github.com/cloudwego/eino/components/model"
 	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/model/ark/internal/multimodal"
 )
 
 func TestChatCompletionAPIStream(t *testing.T) {
@@ -359,6 +366,49 @@ func TestCompletionAPIChatModel_toArkContent(t *testing.T) {
 				convey.So(err, convey.ShouldBeNil)
 				convey.So(content.ListValue, convey.ShouldHaveLength, 3)
 			})
+			PatchConvey("Converted by the multimodal converter", func() {
+				dataURL := "data:image/png;base64," + base64Data
+				video := &schema.MessageInputVideo{MessagePartCommon: schema.MessagePartCommon{URL: &videoURL}}
+				setInputVideoFPS(video, 2)
+				msg := &schema.Message{
+					Role: schema.User,
+					UserInputMultiContent: []schema.MessageInputPart{
+						{Type: schema.ChatMessagePartTypeImageURL, Image: &schema.MessageInputImage{MessagePartCommon: schema.MessagePartCommon{URL: &dataURL}, Detail: schema.ImageURLDetailLow}},
+						{Type: schema.ChatMessagePartTypeVideoURL, Video: video},
+					},
+				}
+				content, err := cm.toArkContent(msg)
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(content.ListValue[0].ImageURL, convey.ShouldResemble, &model.ChatMessageImageURL{URL: dataURL, Detail: model.ImageURLDetailLow})
+				convey.So(content.ListValue[1].VideoURL.URL, convey.ShouldEqual, videoURL)
+				convey.So(*content.ListValue[1].VideoURL.FPS, convey.ShouldEqual, 2)
+			})
+			PatchConvey("Image over the size limit is downscaled", func() {
+				buf := &bytes.Buffer{}
+				convey.So(png.Encode(buf, image.NewGray(image.Rect(0, 0, 8000, 8))), convey.ShouldBeNil)
+				data := base64.StdEncoding.EncodeToString(buf.Bytes())
+				msg := &schema.Message{
+					Role:                  schema.User,
+					UserInputMultiContent: []schema.MessageInputPart{{Type: schema.ChatMessagePartTypeImageURL, Image: &schema.MessageInputImage{MessagePartCommon: schema.MessagePartCommon{Base64Data: &data, MIMEType: "image/png"}}}},
+				}
+				content, err := cm.toArkContent(msg)
+				convey.So(err, convey.ShouldBeNil)
+				imageURL := content.ListValue[0].ImageURL.URL
+				convey.So(imageURL, convey.ShouldStartWith, "data:image/jpeg;base64,")
+				decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(imageURL, "data:image/jpeg;base64,"))
+				convey.So(err, convey.ShouldBeNil)
+				cfg, _, err := image.DecodeConfig(bytes.NewReader(decoded))
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(cfg.Width, convey.ShouldEqual, 6000)
+			})
+			PatchConvey("Error on unsupported audio", func() {
+				msg := &schema.Message{
+					Role:                  schema.User,
+					UserInputMultiContent: []schema.MessageInputPart{{Type: schema.ChatMessagePartTypeAudioURL, Audio: &schema.MessageInputAudio{MessagePartCommon: schema.MessagePartCommon{Base64Data: &base64Data, MIMEType: "audio/wav"}}}},
+				}
+				_, err := cm.toArkContent(msg)
+				convey.So(errors.Is(err, multimodal.ErrUnsupported), convey.ShouldBeTrue)
+			})
 			PatchConvey("Error on nil image", func() {
 				msg := &schema.Message{UserInputMultiContent: []schema.MessageInputPart{{Type: schema.ChatMessagePartTypeImageURL, Image: nil}}}
 				_, err := cm.toArkContent(msg)
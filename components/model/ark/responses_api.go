@@ -20,7 +20,6 @@ import (
 	"context"
 	"fmt"
 	"runtime/debug"
-	"strings"
 	"time"
 
 	"github.com/bytedance/sonic"
@@ -684,28 +683,15 @@ func (cm *responsesAPIChatModel) toOpenaiMultiModalContent(msg *schema.Message)
 					},
 				})
 			case schema.ChatMessagePartTypeImageURL:
-				if part.Image == nil {
-					return content, fmt.Errorf("image field must not be nil when Type is ChatMessagePartTypeImageURL in user message")
-				} else {
-					var imageURL string
-					var err error
-					if part.Image.URL != nil {
-						imageURL = *part.Image.URL
-					} else if part.Image.Base64Data != nil {
-						if part.Image.MIMEType == "" {
-							return content, fmt.Errorf("image part must have MIMEType when use Base64Data")
-						}
-						imageURL, err = ensureDataURL(*part.Image.Base64Data, part.Image.MIMEType)
-						if err != nil {
-							return content, err
-						}
-					}
-					content.OfInputItemContentList = append(content.OfInputItemContentList, responses.ResponseInputContentUnionParam{
-						OfInputImage: &responses.ResponseInputImageParam{
-							ImageURL: param.NewOpt(imageURL),
-						},
-					})
+				p, err := convInputPart(part)
+				if err != nil {
+					return content, err
 				}
+				content.OfInputItemContentList = append(content.OfInputItemContentList, responses.ResponseInputContentUnionParam{
+					OfInputImage: &responses.ResponseInputImageParam{
+						ImageURL: param.NewOpt(p.ImageURL.URL),
+					},
+				})
 			default:
 				return content, fmt.Errorf("unsupported content type in UserInputMultiContent: %s", part.Type)
 			}
@@ -724,28 +710,15 @@ func (cm *responsesAPIChatModel) toOpenaiMultiModalContent(msg *schema.Message)
 					},
 				})
 			case schema.ChatMessagePartTypeImageURL:
-				if part.Image == nil {
-					return content, fmt.Errorf("image field must not be nil when Type is ChatMessagePartTypeImageURL in assistant message")
-				} else {
-					var imageURL string
-					var err error
-					if part.Image.URL != nil {
-						imageURL = *part.Image.URL
-					} else if part.Image.Base64Data != nil {
-						if part.Image.MIMEType == "" {
-							return content, fmt.Errorf("image part must have MIMEType when use Base64Data")
-						}
-						imageURL, err = ensureDataURL(*part.Image.Base64Data, part.Image.MIMEType)
-						if err != nil {
-							return content, err
-						}
-					}
-					content.OfInputItemContentList = append(content.OfInputItemContentList, responses.ResponseInputContentUnionParam{
-						OfInputImage: &responses.ResponseInputImageParam{
-							ImageURL: param.NewOpt(imageURL),
-						},
-					})
+				p, err := convOutputPart(part)
+				if err != nil {
+					return content, err
 				}
+				content.OfInputItemContentList = append(content.OfInputItemContentList, responses.ResponseInputContentUnionParam{
+					OfInputImage: &responses.ResponseInputImageParam{
+						ImageURL: param.NewOpt(p.ImageURL.URL),
+					},
+				})
 			default:
 				return content, fmt.Errorf("unsupported content type in AssistantGenMultiContent: %s", part.Type)
 			}
@@ -917,13 +890,3 @@ func (cm *responsesAPIChatModel) getOptions(opts []model.Option) (*model.Options
 
 	return options, arkOpts, nil
 }
-
-func ensureDataURL(dataOfBase64, mimeType string) (string, error) {
-	if strings.HasPrefix(dataOfBase64, "data:") {
-		return "", fmt.Errorf("base64Data field must be a raw base64 string, but got a string with prefix 'data:'")
-	}
-	if mimeType == "" {
-		return "", fmt.Errorf("mimeType field is required")
-	}
-	return fmt.Sprintf("data:%s;base64,%s", mimeType, dataOfBase64), nil
-}
@@ -17,9 +17,13 @@
 package ark
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/cloudwego/eino/schema"
 	"github.com/openai/openai-go/packages/param"
+
+	"github.com/cloudwego/eino-ext/components/model/ark/internal/multimodal"
 )
 
 const typ = "Ark"
@@ -92,3 +96,35 @@ func newPanicErr(info any, stack []byte) error {
 		stack: stack,
 	}
 }
+
+//go:generate sh ../../../libs/acl/bundle.sh multimodal internal/multimodal
+
+var partConverter = multimodal.NewConverter(nil)
+
+// convInputPart converts a user input part with the shared multimodal converter, which checks the parts
+// against the api limits and downscales the images over them.
+func convInputPart(part schema.MessageInputPart) (*multimodal.ArkContentPart, error) {
+	p, err := multimodal.FromInputPart(part)
+	if err != nil {
+		return nil, err
+	}
+	return convPart(p)
+}
+
+// convOutputPart converts a model output part like convInputPart, e.g. an image generated by another model.
+func convOutputPart(part schema.MessageOutputPart) (*multimodal.ArkContentPart, error) {
+	p, err := multimodal.FromOutputPart(part)
+	if err != nil {
+		return nil, err
+	}
+	return convPart(p)
+}
+
+func convPart(p *multimodal.Part) (*multimodal.ArkContentPart, error) {
+	// no http client is configured, so the converter never downloads
+	part, err := partConverter.ToArk(context.Background(), p)
+	if err != nil {
+		return nil, fmt.Errorf("convert message part fail: %w", err)
+	}
+	return part, nil
+}
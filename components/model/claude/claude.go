@@ -33,6 +33,8 @@ import (
 	awsConfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 
+	"github.com/cloudwego/eino-ext/components/model/claude/internal/multimodal"
+
 	"github.com/cloudwego/eino/components"
 
 	"github.com/cloudwego/eino/callbacks"
@@ -42,6 +44,10 @@ import (
 
 var _ model.ToolCallingChatModel = (*ChatModel)(nil)
 
+//go:generate sh ../../../libs/acl/bundle.sh multimodal internal/multimodal
+
+var partConverter = multimodal.NewConverter(nil)
+
 // NewChatModel creates a new Claude chat model instance
 //
 // Parameters:
@@ -652,6 +658,35 @@ func (cm *ChatModel) IsCallbacksEnabled() bool {
 	return true
 }
 
+// convInputPart converts a user input part with the shared multimodal converter,
+// which also accepts pdf and text documents and downscales images over the api size limit.
+func convInputPart(part schema.MessageInputPart) (anthropic.ContentBlockParamUnion, error) {
+	p, err := multimodal.FromInputPart(part)
+	if err != nil {
+		return anthropic.ContentBlockParamUnion{}, err
+	}
+	// no http client is configured, so the converter never downloads
+	block, err := partConverter.ToClaude(context.Background(), p)
+	if err != nil {
+		return anthropic.ContentBlockParamUnion{}, fmt.Errorf("convert message part fail: %w", err)
+	}
+
+	switch {
+	case block.Source == nil:
+		return anthropic.NewTextBlock(block.Text), nil
+	case block.Type == "image" && block.Source.Type == "url":
+		return anthropic.NewImageBlock(anthropic.URLImageSourceParam{URL: block.Source.URL}), nil
+	case block.Type == "image":
+		return anthropic.NewImageBlockBase64(block.Source.MediaType, block.Source.Data), nil
+	case block.Source.Type == "url":
+		return anthropic.NewDocumentBlock(anthropic.URLPDFSourceParam{URL: block.Source.URL}), nil
+	case block.Source.Type == "text":
+		return anthropic.NewDocumentBlock(anthropic.PlainTextSourceParam{Data: block.Source.Data}), nil
+	default:
+		return anthropic.NewDocumentBlock(anthropic.Base64PDFSourceParam{Data: block.Source.Data}), nil
+	}
+}
+
 func convSchemaMessage(message *schema.Message) (mp anthropic.MessageParam, err error) {
 	var messageParams []anthropic.ContentBlockParamUnion
 
@@ -680,32 +715,11 @@ func convSchemaMessage(message *schema.Message) (mp anthropic.MessageParam, err
 			return mp, fmt.Errorf("user input multi content only support user role, got %s", message.Role)
 		}
 		for i := range message.UserInputMultiContent {
-			switch message.UserInputMultiContent[i].Type {
-			case schema.ChatMessagePartTypeText:
-				messageParams = append(messageParams, anthropic.NewTextBlock(message.UserInputMultiContent[i].Text))
-			case schema.ChatMessagePartTypeImageURL:
-				if message.UserInputMultiContent[i].Image == nil {
-					return mp, fmt.Errorf("image field must not be nil when Type is ChatMessagePartTypeImageURL in user message")
-				}
-				image := message.UserInputMultiContent[i].Image
-				if image.URL != nil && *image.URL != "" {
-					messageParams = append(messageParams, anthropic.NewImageBlock(anthropic.URLImageSourceParam{
-						URL: *image.URL,
-					}))
-				} else if image.Base64Data != nil && *image.Base64Data != "" {
-					if image.MIMEType == "" {
-						return mp, fmt.Errorf("image part must have MIMEType when use Base64Data")
-					}
-					if strings.HasPrefix(*image.Base64Data, "data:") {
-						return mp, fmt.Errorf("Base64Data should be a raw base64 string, but it has a 'data:' prefix")
-					}
-					messageParams = append(messageParams, anthropic.NewImageBlockBase64(image.MIMEType, *image.Base64Data))
-				} else {
-					return mp, fmt.Errorf("image part must have either a URL or Base64Data")
-				}
-			default:
-				return mp, fmt.Errorf("anthropic message type not supported: %s", message.UserInputMultiContent[i].Type)
+			block, err_ := convInputPart(message.UserInputMultiContent[i])
+			if err_ != nil {
+				return mp, err_
 			}
+			messageParams = append(messageParams, block)
 		}
 	} else if len(message.AssistantGenMultiContent) > 0 {
 		if message.Role != schema.Assistant {
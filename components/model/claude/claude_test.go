@@ -29,6 +29,8 @@ import (
 	orderedmap "github.com/wk8/go-ordered-map/v2"
 
 	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/model/claude/internal/multimodal"
 )
 
 func TestClaude(t *testing.T) {
@@ -579,3 +581,24 @@ func Test_convSchemaMessage_MultiContent(t *testing.T) {
 		assert.ErrorContains(t, err, "image field must not be nil")
 	})
 }
+
+func Test_convInputPart(t *testing.T) {
+	pdfURL := "https://example.com/report.pdf"
+	pdfBase64 := "JVBERi0xLjQ="
+	text := "aGVsbG8="
+
+	block, err := convInputPart(schema.MessageInputPart{Type: schema.ChatMessagePartTypeFileURL, File: &schema.MessageInputFile{MessagePartCommon: schema.MessagePartCommon{URL: &pdfURL}}})
+	assert.NoError(t, err)
+	assert.Equal(t, pdfURL, block.OfDocument.Source.OfURL.URL)
+
+	block, err = convInputPart(schema.MessageInputPart{Type: schema.ChatMessagePartTypeFileURL, File: &schema.MessageInputFile{MessagePartCommon: schema.MessagePartCommon{Base64Data: &pdfBase64, MIMEType: "application/pdf"}}})
+	assert.NoError(t, err)
+	assert.Equal(t, pdfBase64, block.OfDocument.Source.OfBase64.Data)
+
+	block, err = convInputPart(schema.MessageInputPart{Type: schema.ChatMessagePartTypeFileURL, File: &schema.MessageInputFile{MessagePartCommon: schema.MessagePartCommon{Base64Data: &text, MIMEType: "text/plain"}}})
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", block.OfDocument.Source.OfText.Data)
+
+	_, err = convInputPart(schema.MessageInputPart{Type: schema.ChatMessagePartTypeAudioURL, Audio: &schema.MessageInputAudio{MessagePartCommon: schema.MessagePartCommon{Base64Data: &text, MIMEType: "audio/wav"}}})
+	assert.ErrorIs(t, err, multimodal.ErrUnsupported)
+}
@@ -34,10 +34,16 @@ import (
 	"github.com/cloudwego/eino/components"
 	"github.com/cloudwego/eino/components/model"
 	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/model/gemini/internal/multimodal"
 )
 
 var _ model.ToolCallingChatModel = (*ChatModel)(nil)
 
+//go:generate sh ../../../libs/acl/bundle.sh multimodal internal/multimodal
+
+var partConverter = multimodal.NewConverter(nil)
+
 // NewChatModel creates a new Gemini chat model instance
 //
 // Parameters:
@@ -696,80 +702,21 @@ func (cm *ChatModel) convSchemaMessage(message *schema.Message) (*genai.Content,
 func (cm *ChatModel) convInputMedia(contents []schema.MessageInputPart) ([]*genai.Part, error) {
 	result := make([]*genai.Part, 0, len(contents))
 	for _, content := range contents {
-		switch content.Type {
-		case schema.ChatMessagePartTypeText:
-			result = append(result, genai.NewPartFromText(content.Text))
-		case schema.ChatMessagePartTypeImageURL:
-			if content.Image == nil {
-				return nil, fmt.Errorf("image field must not be nil when Type is ChatMessagePartTypeImageURL in user message")
-			}
-			if content.Image.Base64Data != nil {
-				data, err := decodeBase64DataURL(*content.Image.Base64Data)
-				if err != nil {
-					return nil, fmt.Errorf("failed to decode base64 data URL: %w", err)
-				}
-				if content.Image.MIMEType == "" {
-					return nil, fmt.Errorf("MIMEType is required for image parts with Base64Data")
-				}
-				result = append(result, genai.NewPartFromBytes(data, content.Image.MIMEType))
-			} else if content.Image.URL != nil {
-				return nil, fmt.Errorf("gemini: URL is not supported for image parts, please use Base64Data instead")
-			}
-		case schema.ChatMessagePartTypeAudioURL:
-			if content.Audio == nil {
-				return nil, fmt.Errorf("audio field must not be nil when Type is ChatMessagePartTypeAudioURL in user message")
-			}
-			if content.Audio.Base64Data != nil {
-				data, err := decodeBase64DataURL(*content.Audio.Base64Data)
-				if err != nil {
-					return nil, fmt.Errorf("failed to decode base64 data URL: %w", err)
-				}
-				if content.Audio.MIMEType == "" {
-					return nil, fmt.Errorf("MIMEType is required for audio parts with Base64Data")
-				}
-				result = append(result, genai.NewPartFromBytes(data, content.Audio.MIMEType))
-			} else if content.Audio.URL != nil {
-				return nil, fmt.Errorf("gemini: URL is not supported for audio parts, please use Base64Data instead")
-			}
-		case schema.ChatMessagePartTypeVideoURL:
-			if content.Video == nil {
-				return nil, fmt.Errorf("video field must not be nil when Type is ChatMessagePartTypeVideoURL in user message")
-			}
-			if content.Video.Extra != nil {
-				videoMetaData := GetInputVideoMetaData(content.Video)
-				if videoMetaData != nil {
-					result = append(result, &genai.Part{VideoMetadata: videoMetaData})
-				}
-			}
-			if content.Video.Base64Data != nil {
-				data, err := decodeBase64DataURL(*content.Video.Base64Data)
-				if err != nil {
-					return nil, fmt.Errorf("failed to decode base64 data URL: %w", err)
-				}
-				if content.Video.MIMEType == "" {
-					return nil, fmt.Errorf("MIMEType is required for video parts with Base64Data")
-				}
-				result = append(result, genai.NewPartFromBytes(data, content.Video.MIMEType))
-			} else if content.Video.URL != nil {
-				return nil, fmt.Errorf("gemini: URL is not supported for video parts, please use Base64Data instead")
-			}
-		case schema.ChatMessagePartTypeFileURL:
-			if content.File == nil {
-				return nil, fmt.Errorf("file field must not be nil when Type is ChatMessagePartTypeFileURL in user message")
-			}
-			if content.File.Base64Data != nil {
-				data, err := decodeBase64DataURL(*content.File.Base64Data)
-				if err != nil {
-					return nil, fmt.Errorf("failed to decode base64 data URL: %w", err)
-				}
-				if content.File.MIMEType == "" {
-					return nil, fmt.Errorf("MIMEType is required for file parts with Base64Data")
-				}
-				result = append(result, genai.NewPartFromBytes(data, content.File.MIMEType))
-			} else if content.File.URL != nil {
-				return nil, fmt.Errorf("gemini: URL is not supported for file parts, please use Base64Data instead")
+		if content.Type == schema.ChatMessagePartTypeVideoURL && content.Video != nil && content.Video.Extra != nil {
+			videoMetaData := GetInputVideoMetaData(content.Video)
+			if videoMetaData != nil {
+				result = append(result, &genai.Part{VideoMetadata: videoMetaData})
 			}
 		}
+		p, err := multimodal.FromInputPart(content)
+		if err != nil {
+			return nil, err
+		}
+		part, err := convPart(p)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, part)
 	}
 	return result, nil
 }
@@ -777,62 +724,38 @@ func (cm *ChatModel) convInputMedia(contents []schema.MessageInputPart) ([]*gena
 func (cm *ChatModel) convOutputMedia(contents []schema.MessageOutputPart) ([]*genai.Part, error) {
 	result := make([]*genai.Part, 0, len(contents))
 	for _, content := range contents {
-		switch content.Type {
-		case schema.ChatMessagePartTypeText:
-			result = append(result, genai.NewPartFromText(content.Text))
-		case schema.ChatMessagePartTypeImageURL:
-			if content.Image == nil {
-				return nil, fmt.Errorf("image field must not be nil when Type is ChatMessagePartTypeImageURL in assistant message")
-			}
-			if content.Image.Base64Data != nil {
-				data, err := decodeBase64DataURL(*content.Image.Base64Data)
-				if err != nil {
-					return nil, fmt.Errorf("failed to decode base64 data URL: %w", err)
-				}
-				if content.Image.MIMEType == "" {
-					return nil, fmt.Errorf("MIMEType is required for image parts with Base64Data")
-				}
-				result = append(result, genai.NewPartFromBytes(data, content.Image.MIMEType))
-			} else if content.Image.URL != nil {
-				return nil, fmt.Errorf("gemini: URL is not supported for image parts, please use Base64Data instead")
-			}
-		case schema.ChatMessagePartTypeAudioURL:
-			if content.Audio == nil {
-				return nil, fmt.Errorf("audio field must not be nil when Type is ChatMessagePartTypeAudioURL in assistant message")
-			}
-			if content.Audio.Base64Data != nil {
-				data, err := decodeBase64DataURL(*content.Audio.Base64Data)
-				if err != nil {
-					return nil, fmt.Errorf("failed to decode base64 data URL: %w", err)
-				}
-				if content.Audio.MIMEType == "" {
-					return nil, fmt.Errorf("MIMEType is required for audio parts with Base64Data")
-				}
-				result = append(result, genai.NewPartFromBytes(data, content.Audio.MIMEType))
-			} else if content.Audio.URL != nil {
-				return nil, fmt.Errorf("gemini: URL is not supported for audio parts, please use Base64Data instead")
-			}
-		case schema.ChatMessagePartTypeVideoURL:
-			if content.Video == nil {
-				return nil, fmt.Errorf("video field must not be nil when Type is ChatMessagePartTypeVideoURL in assistant message")
-			}
-			if content.Video.Base64Data != nil {
-				data, err := decodeBase64DataURL(*content.Video.Base64Data)
-				if err != nil {
-					return nil, fmt.Errorf("failed to decode base64 data URL: %w", err)
-				}
-				if content.Video.MIMEType == "" {
-					return nil, fmt.Errorf("MIMEType is required for video parts with Base64Data")
-				}
-				result = append(result, genai.NewPartFromBytes(data, content.Video.MIMEType))
-			} else if content.Video.URL != nil {
-				return nil, fmt.Errorf("gemini: URL is not supported for video parts, please use Base64Data instead")
-			}
+		p, err := multimodal.FromOutputPart(content)
+		if err != nil {
+			return nil, err
 		}
+		part, err := convPart(p)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, part)
 	}
 	return result, nil
 }
 
+// convPart converts a part with the shared multimodal converter, which checks the parts against the api limits
+// and downscales the images over them. Remote urls are only accepted for Files API, Cloud Storage and YouTube uris.
+func convPart(p *multimodal.Part) (*genai.Part, error) {
+	// no http client is configured, so the converter never downloads
+	part, err := partConverter.ToGemini(context.Background(), p)
+	if err != nil {
+		return nil, fmt.Errorf("convert message part fail: %w", err)
+	}
+
+	switch {
+	case part.InlineData != nil:
+		return genai.NewPartFromBytes(part.InlineData.Data, part.InlineData.MIMEType), nil
+	case part.FileData != nil:
+		return genai.NewPartFromURI(part.FileData.FileURI, part.FileData.MIMEType), nil
+	default:
+		return genai.NewPartFromText(part.Text), nil
+	}
+}
+
 func (cm *ChatModel) convMedia(contents []schema.ChatMessagePart) ([]*genai.Part, error) {
 	result := make([]*genai.Part, 0, len(contents))
 	for _, content := range contents {
@@ -32,6 +32,8 @@ import (
 	"google.golang.org/genai"
 
 	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/model/gemini/internal/multimodal"
 )
 
 func TestGemini(t *testing.T) {
@@ -417,6 +419,29 @@ func TestChatModel_convMedia(t *testing.T) {
 			assert.Equal(t, decodedData, parts[1].InlineData.Data)
 		})
 
+		t.Run("urls", func(t *testing.T) {
+			fileURI := "gs://bucket/a.pdf"
+			youtube := "https://www.youtube.com/watch?v=1"
+			dataURL := "data:image/png;base64," + base64Data
+			contents := []schema.MessageInputPart{
+				{Type: schema.ChatMessagePartTypeFileURL, File: &schema.MessageInputFile{MessagePartCommon: schema.MessagePartCommon{URL: &fileURI}}},
+				{Type: schema.ChatMessagePartTypeVideoURL, Video: &schema.MessageInputVideo{MessagePartCommon: schema.MessagePartCommon{URL: &youtube, MIMEType: "video/mp4"}}},
+				{Type: schema.ChatMessagePartTypeImageURL, Image: &schema.MessageInputImage{MessagePartCommon: schema.MessagePartCommon{URL: &dataURL}}},
+			}
+			parts, err := cm.convInputMedia(contents)
+			assert.NoError(t, err)
+			assert.Len(t, parts, 3)
+			assert.Equal(t, &genai.FileData{FileURI: fileURI, MIMEType: "application/pdf"}, parts[0].FileData)
+			assert.Equal(t, &genai.FileData{FileURI: youtube, MIMEType: "video/mp4"}, parts[1].FileData)
+			assert.Equal(t, "image/png", parts[2].InlineData.MIMEType)
+
+			url := "https://example.com/image.png"
+			_, err = cm.convInputMedia([]schema.MessageInputPart{
+				{Type: schema.ChatMessagePartTypeImageURL, Image: &schema.MessageInputImage{MessagePartCommon: schema.MessagePartCommon{URL: &url}}},
+			})
+			assert.ErrorIs(t, err, multimodal.ErrUnsupported)
+		})
+
 		t.Run("with video metadata", func(t *testing.T) {
 			videoPart := &schema.MessageInputVideo{MessagePartCommon: schema.MessagePartCommon{Base64Data: &base64Data, MIMEType: "video/mp4"}}
 			setInputVideoMetaData(videoPart, &genai.VideoMetadata{
@@ -0,0 +1,292 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package openaicompat
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/libs/acl/openai"
+)
+
+var _ model.ToolCallingChatModel = (*ChatModel)(nil)
+
+// ErrToolsNotSupported is returned when binding or passing tools to a model without Capabilities.Tools,
+// as tool calling can not be degraded gracefully.
+var ErrToolsNotSupported = errors.New("tools are not supported by the model")
+
+const (
+	ChatCompletionResponseFormatTypeJSONObject = openai.ChatCompletionResponseFormatTypeJSONObject
+	ChatCompletionResponseFormatTypeJSONSchema = openai.ChatCompletionResponseFormatTypeJSONSchema
+	ChatCompletionResponseFormatTypeText       = openai.ChatCompletionResponseFormatTypeText
+)
+
+type ChatCompletionResponseFormat = openai.ChatCompletionResponseFormat
+type ChatCompletionResponseFormatJSONSchema = openai.ChatCompletionResponseFormatJSONSchema
+
+// Capabilities declares the optional features of the OpenAI chat completion API supported by the serving endpoint.
+// The fields of the unsupported features are not sent, or degraded as documented, so that the server does not
+// reject the request. All capabilities are disabled by default, enable the ones supported by your server and model.
+type Capabilities struct {
+	// Tools indicates the endpoint supports tools and tool_choice, e.g. vLLM with --enable-auto-tool-choice.
+	// If disabled, binding tools fails with ErrToolsNotSupported.
+	Tools bool `json:"tools"`
+
+	// ParallelToolCalls indicates the endpoint supports the parallel_tool_calls field.
+	// If disabled, ChatModelConfig.ParallelToolCalls is ignored.
+	ParallelToolCalls bool `json:"parallel_tool_calls"`
+
+	// LogProbs indicates the endpoint supports the logprobs and top_logprobs fields.
+	// If disabled, ChatModelConfig.LogProbs and ChatModelConfig.TopLogProbs are ignored.
+	LogProbs bool `json:"log_probs"`
+
+	// JSONMode indicates the endpoint supports the json_object response format.
+	// If disabled, a JSON response format is replaced by an instruction in a leading system message.
+	JSONMode bool `json:"json_mode"`
+
+	// JSONSchema indicates the endpoint supports the json_schema response format.
+	// If disabled, it is degraded to json_object with JSONMode, or to an instruction embedding the schema otherwise.
+	JSONSchema bool `json:"json_schema"`
+
+	// StreamUsage indicates the endpoint supports stream_options.include_usage.
+	// If disabled, stream_options is not sent and streamed messages carry no token usage.
+	StreamUsage bool `json:"stream_usage"`
+}
+
+type ChatModelConfig struct {
+	// BaseURL is the base url of the OpenAI compatible endpoint
+	// Required. Example: "http://localhost:8000/v1" for vLLM, "https://api.groq.com/openai/v1" for Groq
+	BaseURL string `json:"base_url"`
+
+	// APIKey is the authentication key, sent as a bearer token
+	// Optional. Local servers such as llama.cpp usually do not require it
+	APIKey string `json:"api_key"`
+
+	// Timeout specifies the maximum duration to wait for API responses
+	// If HTTPClient is set, Timeout will not be used.
+	// Optional. Default: no timeout
+	Timeout time.Duration `json:"timeout"`
+
+	// HTTPClient specifies the client to send HTTP requests.
+	// If HTTPClient is set, Timeout will not be used.
+	// Optional. Default &http.Client{Timeout: Timeout}
+	HTTPClient *http.Client `json:"http_client"`
+
+	// Capabilities declares the optional features supported by the endpoint
+	// Optional. Default: all disabled
+	Capabilities Capabilities `json:"capabilities"`
+
+	// The following fields correspond to OpenAI's chat completion API parameters
+	// Ref: https://platform.openai.com/docs/api-reference/chat/create
+
+	// Model specifies the ID of the model to use
+	// Required
+	Model string `json:"model"`
+
+	// MaxTokens limits the maximum number of tokens that can be generated in the chat completion
+	// Optional. Default: model's maximum
+	MaxTokens *int `json:"max_tokens,omitempty"`
+
+	// Temperature specifies what sampling temperature to use
+	// Optional. Default: server's default
+	Temperature *float32 `json:"temperature,omitempty"`
+
+	// TopP controls diversity via nucleus sampling
+	// Optional. Default: server's default
+	TopP *float32 `json:"top_p,omitempty"`
+
+	// Stop sequences where the API will stop generating further tokens
+	// Optional. Example: []string{"\n", "User:"}
+	Stop []string `json:"stop,omitempty"`
+
+	// PresencePenalty prevents repetition by penalizing tokens based on presence
+	// Optional. Default: 0
+	PresencePenalty *float32 `json:"presence_penalty,omitempty"`
+
+	// FrequencyPenalty prevents repetition by penalizing tokens based on frequency
+	// Optional. Default: 0
+	FrequencyPenalty *float32 `json:"frequency_penalty,omitempty"`
+
+	// Seed enables deterministic sampling for consistent outputs
+	// Optional. Set for reproducible results
+	Seed *int `json:"seed,omitempty"`
+
+	// ResponseFormat specifies the format of the model's response, degraded by Capabilities.JSONMode and Capabilities.JSONSchema
+	// Optional. Use for structured outputs
+	ResponseFormat *ChatCompletionResponseFormat `json:"response_format,omitempty"`
+
+	// ParallelToolCalls specifies whether the model may call several tools at once, requires Capabilities.ParallelToolCalls
+	// Optional. Default: server's default
+	ParallelToolCalls *bool `json:"parallel_tool_calls,omitempty"`
+
+	// LogProbs specifies whether to return log probabilities of the output tokens, requires Capabilities.LogProbs
+	// Optional. Default: false
+	LogProbs bool `json:"log_probs"`
+
+	// TopLogProbs specifies the number of most likely tokens to return at each token position, requires Capabilities.LogProbs
+	// Optional. Default: 0
+	TopLogProbs int `json:"top_log_probs"`
+
+	// User unique identifier representing end-user
+	// Optional.
+	User *string `json:"user,omitempty"`
+
+	// ExtraFields are extra body fields of the request, sent as is, e.g. "top_k" or "repetition_penalty" for vLLM.
+	// ExtraFields will override any existing fields with the same key.
+	// Optional.
+	ExtraFields map[string]any `json:"extra_fields,omitempty"`
+}
+
+type ChatModel struct {
+	cli    *openai.Client
+	config *ChatModelConfig
+}
+
+func NewChatModel(ctx context.Context, config *ChatModelConfig) (*ChatModel, error) {
+	if config == nil {
+		return nil, errors.New("config is required")
+	}
+	if config.BaseURL == "" {
+		return nil, errors.New("base url is required")
+	}
+
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: config.Timeout}
+	}
+
+	nConf := &openai.Config{
+		BaseURL:          config.BaseURL,
+		APIKey:           config.APIKey,
+		HTTPClient:       httpClient,
+		Model:            config.Model,
+		MaxTokens:        config.MaxTokens,
+		Temperature:      config.Temperature,
+		TopP:             config.TopP,
+		Stop:             config.Stop,
+		PresencePenalty:  config.PresencePenalty,
+		FrequencyPenalty: config.FrequencyPenalty,
+		Seed:             config.Seed,
+		User:             config.User,
+		ExtraFields:      config.ExtraFields,
+		ResponseFormat:   degradeResponseFormat(config.ResponseFormat, config.Capabilities),
+	}
+	if config.Capabilities.LogProbs {
+		nConf.LogProbs = config.LogProbs
+		nConf.TopLogProbs = config.TopLogProbs
+	}
+
+	cli, err := openai.NewClient(ctx, nConf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChatModel{cli: cli, config: config}, nil
+}
+
+func (cm *ChatModel) Generate(ctx context.Context, in []*schema.Message, opts ...model.Option) (
+	outMsg *schema.Message, err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, cm.GetType(), components.ComponentOfChatModel)
+
+	in, opts, err = cm.prepare(in, opts)
+	if err != nil {
+		return nil, err
+	}
+	return cm.cli.Generate(ctx, in, opts...)
+}
+
+func (cm *ChatModel) Stream(ctx context.Context, in []*schema.Message, opts ...model.Option) (
+	outStream *schema.StreamReader[*schema.Message], err error) {
+	ctx = callbacks.EnsureRunInfo(ctx, cm.GetType(), components.ComponentOfChatModel)
+
+	in, opts, err = cm.prepare(in, opts)
+	if err != nil {
+		return nil, err
+	}
+	return cm.cli.Stream(ctx, in, opts...)
+}
+
+// prepare degrades the input and the options to the capabilities of the endpoint.
+func (cm *ChatModel) prepare(in []*schema.Message, opts []model.Option) ([]*schema.Message, []model.Option, error) {
+	if !cm.config.Capabilities.Tools {
+		if options := model.GetCommonOptions(&model.Options{}, opts...); len(options.Tools) > 0 {
+			return nil, nil, ErrToolsNotSupported
+		}
+	}
+
+	if instruction := responseFormatInstruction(cm.config.ResponseFormat, cm.config.Capabilities); instruction != "" {
+		in = append([]*schema.Message{schema.SystemMessage(instruction)}, in...)
+	}
+
+	specOptions := model.GetImplSpecificOptions(&options{}, opts...)
+	modifier := cm.degradeRequestBody
+	if specOptions.RequestBodyModifier != nil {
+		modifier = func(rawBody []byte) ([]byte, error) {
+			body, err := specOptions.RequestBodyModifier(rawBody)
+			if err != nil {
+				return nil, err
+			}
+			return cm.degradeRequestBody(body)
+		}
+	}
+
+	nOpts := make([]model.Option, 0, len(opts)+1)
+	nOpts = append(nOpts, opts...)
+	nOpts = append(nOpts, openai.WithRequestBodyModifier(modifier))
+	return in, nOpts, nil
+}
+
+func (cm *ChatModel) WithTools(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	if !cm.config.Capabilities.Tools {
+		return nil, ErrToolsNotSupported
+	}
+	cli, err := cm.cli.WithToolsForClient(tools)
+	if err != nil {
+		return nil, err
+	}
+	return &ChatModel{cli: cli, config: cm.config}, nil
+}
+
+func (cm *ChatModel) BindTools(tools []*schema.ToolInfo) error {
+	if !cm.config.Capabilities.Tools {
+		return ErrToolsNotSupported
+	}
+	return cm.cli.BindTools(tools)
+}
+
+func (cm *ChatModel) BindForcedTools(tools []*schema.ToolInfo) error {
+	if !cm.config.Capabilities.Tools {
+		return ErrToolsNotSupported
+	}
+	return cm.cli.BindForcedTools(tools)
+}
+
+const typ = "OpenAICompatible"
+
+func (cm *ChatModel) GetType() string {
+	return typ
+}
+
+func (cm *ChatModel) IsCallbacksEnabled() bool {
+	return cm.cli.IsCallbacksEnabled()
+}
@@ -0,0 +1,241 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package openaicompat
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/eino-contrib/jsonschema"
+	"github.com/stretchr/testify/assert"
+)
+
+type capturingServer struct {
+	*httptest.Server
+	body map[string]any
+}
+
+func newCapturingServer(t *testing.T) *capturingServer {
+	s := &capturingServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		assert.Nil(t, err)
+		s.body = map[string]any{}
+		assert.Nil(t, json.Unmarshal(data, &s.body))
+
+		if s.body["stream"] == true {
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = w.Write([]byte("data: {\"id\":\"1\",\"choices\":[{\"index\":0,\"delta\":{\"role\":\"assistant\",\"content\":\"{}\"}}]}\n\n"))
+			_, _ = w.Write([]byte("data: [DONE]\n\n"))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"1","choices":[{"index":0,"message":{"role":"assistant","content":"{}"},"finish_reason":"stop"}],"usage":{"prompt_tokens":1,"completion_tokens":1,"total_tokens":2}}`))
+	}))
+	return s
+}
+
+func (s *capturingServer) messages() []any {
+	messages, _ := s.body["messages"].([]any)
+	return messages
+}
+
+var weatherTool = &schema.ToolInfo{
+	Name: "get_weather",
+	Desc: "get the weather of a city",
+	ParamsOneOf: schema.NewParamsOneOfByParams(map[string]*schema.ParameterInfo{
+		"city": {Type: schema.String, Required: true},
+	}),
+}
+
+var personFormat = &ChatCompletionResponseFormat{
+	Type: ChatCompletionResponseFormatTypeJSONSchema,
+	JSONSchema: &ChatCompletionResponseFormatJSONSchema{
+		Name: "person",
+		JSONSchema: &jsonschema.Schema{
+			Type:     "object",
+			Required: []string{"name"},
+		},
+	},
+}
+
+func TestNewChatModel(t *testing.T) {
+	_, err := NewChatModel(context.Background(), nil)
+	assert.NotNil(t, err)
+	_, err = NewChatModel(context.Background(), &ChatModelConfig{Model: "m"})
+	assert.NotNil(t, err)
+}
+
+func TestDegradation(t *testing.T) {
+	ctx := context.Background()
+	server := newCapturingServer(t)
+	defer server.Close()
+
+	cm, err := NewChatModel(ctx, &ChatModelConfig{
+		BaseURL:           server.URL,
+		Model:             "llama",
+		ResponseFormat:    personFormat,
+		ParallelToolCalls: of(true),
+		LogProbs:          true,
+		TopLogProbs:       3,
+	})
+	assert.Nil(t, err)
+
+	t.Run("tools not supported", func(t *testing.T) {
+		assert.ErrorIs(t, cm.BindTools([]*schema.ToolInfo{weatherTool}), ErrToolsNotSupported)
+		assert.ErrorIs(t, cm.BindForcedTools([]*schema.ToolInfo{weatherTool}), ErrToolsNotSupported)
+		_, err := cm.WithTools([]*schema.ToolInfo{weatherTool})
+		assert.ErrorIs(t, err, ErrToolsNotSupported)
+		_, err = cm.Generate(ctx, []*schema.Message{schema.UserMessage("hi")}, model.WithTools([]*schema.ToolInfo{weatherTool}))
+		assert.ErrorIs(t, err, ErrToolsNotSupported)
+	})
+
+	t.Run("generate", func(t *testing.T) {
+		msg, err := cm.Generate(ctx, []*schema.Message{schema.UserMessage("who are you")})
+		assert.Nil(t, err)
+		assert.Equal(t, "{}", msg.Content)
+
+		for _, field := range []string{"response_format", "logprobs", "top_logprobs", "parallel_tool_calls", "tools"} {
+			_, ok := server.body[field]
+			assert.False(t, ok, field)
+		}
+		messages := server.messages()
+		assert.Len(t, messages, 2)
+		system := messages[0].(map[string]any)
+		assert.Equal(t, "system", system["role"])
+		assert.Contains(t, system["content"], `"name"`)
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		sr, err := cm.Stream(ctx, []*schema.Message{schema.UserMessage("who are you")})
+		assert.Nil(t, err)
+		msgs, err := readAll(sr)
+		assert.Nil(t, err)
+		assert.Equal(t, "{}", msgs.Content)
+
+		_, ok := server.body["stream_options"]
+		assert.False(t, ok)
+	})
+
+	t.Run("request body modifier", func(t *testing.T) {
+		_, err := cm.Generate(ctx, []*schema.Message{schema.UserMessage("hi")},
+			WithRequestBodyModifier(func(rawBody []byte) ([]byte, error) {
+				body := map[string]any{}
+				_ = json.Unmarshal(rawBody, &body)
+				body["top_k"] = 20
+				body["logprobs"] = true
+				return json.Marshal(body)
+			}))
+		assert.Nil(t, err)
+		assert.Equal(t, float64(20), server.body["top_k"])
+		_, ok := server.body["logprobs"]
+		assert.False(t, ok)
+	})
+}
+
+func TestJSONModeDegradation(t *testing.T) {
+	ctx := context.Background()
+	server := newCapturingServer(t)
+	defer server.Close()
+
+	cm, err := NewChatModel(ctx, &ChatModelConfig{
+		BaseURL:        server.URL,
+		Model:          "llama",
+		ResponseFormat: personFormat,
+		Capabilities:   Capabilities{JSONMode: true},
+	})
+	assert.Nil(t, err)
+
+	_, err = cm.Generate(ctx, []*schema.Message{schema.UserMessage("who are you")})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]any{"type": "json_object"}, server.body["response_format"])
+	messages := server.messages()
+	assert.Len(t, messages, 2)
+	assert.True(t, strings.Contains(messages[0].(map[string]any)["content"].(string), "JSON schema"))
+}
+
+func TestFullCapabilities(t *testing.T) {
+	ctx := context.Background()
+	server := newCapturingServer(t)
+	defer server.Close()
+
+	cm, err := NewChatModel(ctx, &ChatModelConfig{
+		BaseURL:           server.URL,
+		Model:             "gpt",
+		ResponseFormat:    personFormat,
+		ParallelToolCalls: of(false),
+		LogProbs:          true,
+		TopLogProbs:       3,
+		Capabilities: Capabilities{
+			Tools:             true,
+			ParallelToolCalls: true,
+			LogProbs:          true,
+			JSONMode:          true,
+			JSONSchema:        true,
+			StreamUsage:       true,
+		},
+	})
+	assert.Nil(t, err)
+
+	tcm, err := cm.WithTools([]*schema.ToolInfo{weatherTool})
+	assert.Nil(t, err)
+
+	sr, err := tcm.Stream(ctx, []*schema.Message{schema.UserMessage("weather of beijing")})
+	assert.Nil(t, err)
+	_, err = readAll(sr)
+	assert.Nil(t, err)
+
+	assert.Len(t, server.messages(), 1)
+	assert.Equal(t, false, server.body["parallel_tool_calls"])
+	assert.Equal(t, true, server.body["logprobs"])
+	assert.Equal(t, float64(3), server.body["top_logprobs"])
+	assert.Equal(t, "json_schema", server.body["response_format"].(map[string]any)["type"])
+	assert.Len(t, server.body["tools"], 1)
+	assert.NotNil(t, server.body["stream_options"])
+
+	// parallel_tool_calls is only valid along with tools
+	_, err = cm.Generate(ctx, []*schema.Message{schema.UserMessage("hi")})
+	assert.Nil(t, err)
+	_, ok := server.body["parallel_tool_calls"]
+	assert.False(t, ok)
+}
+
+func readAll(sr *schema.StreamReader[*schema.Message]) (*schema.Message, error) {
+	defer sr.Close()
+	var chunks []*schema.Message
+	for {
+		chunk, err := sr.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		chunks = append(chunks, chunk)
+	}
+	return schema.ConcatMessages(chunks)
+}
+
+func of[T any](v T) *T {
+	return &v
+}
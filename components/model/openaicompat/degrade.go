@@ -0,0 +1,126 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package openaicompat
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	jsonObjectInstruction = "Respond with a valid JSON object only, without any other text."
+	jsonSchemaInstruction = "Respond with a valid JSON object only, without any other text, conforming to the following JSON schema:\n%s"
+)
+
+// degradeResponseFormat returns the response format supported by the endpoint, nil if none.
+func degradeResponseFormat(format *ChatCompletionResponseFormat, caps Capabilities) *ChatCompletionResponseFormat {
+	if format == nil {
+		return nil
+	}
+	switch format.Type {
+	case ChatCompletionResponseFormatTypeJSONSchema:
+		if caps.JSONSchema {
+			return format
+		}
+		if caps.JSONMode {
+			return &ChatCompletionResponseFormat{Type: ChatCompletionResponseFormatTypeJSONObject}
+		}
+		return nil
+	case ChatCompletionResponseFormatTypeJSONObject:
+		if caps.JSONMode {
+			return format
+		}
+		return nil
+	default:
+		return format
+	}
+}
+
+// responseFormatInstruction returns the system instruction replacing the response format not supported
+// by the endpoint, empty if not needed. A json_schema format degraded to json_object still gets the schema
+// as an instruction, as json_object alone does not constrain the fields.
+func responseFormatInstruction(format *ChatCompletionResponseFormat, caps Capabilities) string {
+	if format == nil {
+		return ""
+	}
+	switch format.Type {
+	case ChatCompletionResponseFormatTypeJSONSchema:
+		if caps.JSONSchema {
+			return ""
+		}
+		if js := format.JSONSchema; js != nil {
+			var schema any
+			if js.JSONSchema != nil {
+				schema = js.JSONSchema
+			} else if js.Schema != nil {
+				schema = js.Schema
+			}
+			if schema != nil {
+				if data, err := json.Marshal(schema); err == nil {
+					return fmt.Sprintf(jsonSchemaInstruction, data)
+				}
+			}
+		}
+		if caps.JSONMode {
+			return ""
+		}
+		return jsonObjectInstruction
+	case ChatCompletionResponseFormatTypeJSONObject:
+		if caps.JSONMode {
+			return ""
+		}
+		return jsonObjectInstruction
+	default:
+		return ""
+	}
+}
+
+// degradeRequestBody removes the fields not supported by the endpoint from the request body,
+// and sets parallel_tool_calls, which is only valid along with tools.
+func (cm *ChatModel) degradeRequestBody(rawBody []byte) ([]byte, error) {
+	body := map[string]json.RawMessage{}
+	if err := json.Unmarshal(rawBody, &body); err != nil {
+		return nil, fmt.Errorf("unmarshal request body fail: %w", err)
+	}
+
+	caps := cm.config.Capabilities
+	if !caps.StreamUsage {
+		delete(body, "stream_options")
+	}
+	if !caps.LogProbs {
+		delete(body, "logprobs")
+		delete(body, "top_logprobs")
+	}
+	if !caps.Tools {
+		delete(body, "tools")
+		delete(body, "tool_choice")
+	}
+
+	_, hasTools := body["tools"]
+	if !caps.ParallelToolCalls || !hasTools {
+		delete(body, "parallel_tool_calls")
+	} else if cm.config.ParallelToolCalls != nil {
+		if _, ok := body["parallel_tool_calls"]; !ok {
+			body["parallel_tool_calls"] = json.RawMessage(fmt.Sprint(*cm.config.ParallelToolCalls))
+		}
+	}
+
+	newBody, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request body fail: %w", err)
+	}
+	return newBody, nil
+}
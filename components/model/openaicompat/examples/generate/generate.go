@@ -0,0 +1,57 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/model/openaicompat"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// e.g. vllm serve Qwen/Qwen2.5-7B-Instruct --enable-auto-tool-choice --tool-call-parser hermes
+	cm, err := openaicompat.NewChatModel(ctx, &openaicompat.ChatModelConfig{
+		BaseURL: os.Getenv("OPENAI_COMPAT_BASE_URL"), // http://localhost:8000/v1
+		APIKey:  os.Getenv("OPENAI_COMPAT_API_KEY"),
+		Model:   os.Getenv("OPENAI_COMPAT_MODEL"),
+		Capabilities: openaicompat.Capabilities{
+			Tools:       true,
+			JSONMode:    true,
+			StreamUsage: true,
+		},
+		ResponseFormat: &openaicompat.ChatCompletionResponseFormat{
+			Type: openaicompat.ChatCompletionResponseFormatTypeJSONObject,
+		},
+	})
+	if err != nil {
+		log.Fatalf("NewChatModel failed, err=%v", err)
+	}
+
+	resp, err := cm.Generate(ctx, []*schema.Message{
+		schema.UserMessage("List three primary colors as a JSON object with a \"colors\" array."),
+	})
+	if err != nil {
+		log.Fatalf("Generate failed, err=%v", err)
+	}
+
+	log.Printf("output: \n%v", resp)
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package openaicompat
+
+import (
+	"github.com/cloudwego/eino/components/model"
+
+	"github.com/cloudwego/eino-ext/libs/acl/openai"
+)
+
+// RequestBodyModifier modifies the raw json body of the request before sending it.
+type RequestBodyModifier func(rawBody []byte) ([]byte, error)
+
+// options is the specific options for the openaicompat
+type options struct {
+	RequestBodyModifier RequestBodyModifier
+}
+
+// WithRequestBodyModifier is used to modify the request body before sending request, e.g. for server specific fields.
+// The fields not supported by the Capabilities are removed after the modification.
+func WithRequestBodyModifier(modifier RequestBodyModifier) model.Option {
+	return model.WrapImplSpecificOptFn(func(o *options) {
+		o.RequestBodyModifier = modifier
+	})
+}
+
+// WithExtraFields is used to set extra body fields for the request, overriding ChatModelConfig.ExtraFields.
+func WithExtraFields(extraFields map[string]any) model.Option {
+	return openai.WithExtraFields(extraFields)
+}
+
+// WithExtraHeader is used to set extra headers for the request.
+func WithExtraHeader(header map[string]string) model.Option {
+	return openai.WithExtraHeader(header)
+}
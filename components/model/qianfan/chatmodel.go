@@ -18,6 +18,7 @@ package qianfan
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"runtime/debug"
@@ -60,6 +61,14 @@ type ChatModelConfig struct {
 	PresencePenalty     *float64 // 指定存在惩罚，用于控制生成文本的重复程度。取值范围 [-2.0, 2.0]
 	ParallelToolCalls   *bool    // 是否并行调用工具, 默认开启
 	ResponseFormat      *qianfan.ResponseFormat
+
+	// 以下为 ERNIE 系列模型特有参数, see: https://cloud.baidu.com/doc/WENXINWORKSHOP/s/jlil56u11
+	System             *string  // 模型人设，作为首条 system 消息发送，输入的首条消息为 system 消息时不生效
+	EnableSystemMemory *bool    // 是否开启系统记忆，需配合 SystemMemoryID 使用
+	SystemMemoryID     *string  // 系统记忆ID，用于读取对应ID下的系统记忆，可通过 WithSystemMemoryID 覆盖
+	DisableSearch      *bool    // 是否强制关闭实时搜索功能，默认 false，可通过 WithDisableSearch 覆盖
+	EnableCitation     *bool    // 是否开启上角标返回，默认 false
+	Plugins            []string // 调用的插件列表，如 "uuid-zhishiku"、"uuid-chatocr"、"uuid-weatherforecast"，可通过 WithPlugins 覆盖
 }
 
 type ChatModel struct {
@@ -295,6 +304,11 @@ func (cm *ChatModel) BindForcedTools(tools []*schema.ToolInfo) error {
 func (cm *ChatModel) genRequest(input []*schema.Message, isStream bool, opts ...model.Option) (
 	*qianfan.ChatCompletionV2Request, *model.CallbackInput, error) {
 
+	specOptions := model.GetImplSpecificOptions(&options{
+		SystemMemoryID: cm.config.SystemMemoryID,
+		DisableSearch:  cm.config.DisableSearch,
+		Plugins:        cm.config.Plugins,
+	}, opts...)
 	options := model.GetCommonOptions(&model.Options{
 		Temperature: cm.config.Temperature,
 		MaxTokens:   cm.config.MaxCompletionTokens,
@@ -343,14 +357,33 @@ func (cm *ChatModel) genRequest(input []*schema.Message, isStream bool, opts ...
 		ResponseFormat:      cm.config.ResponseFormat,
 	}
 
+	if cm.config.System != nil && (len(input) == 0 || input[0].Role != schema.System) {
+		input = append([]*schema.Message{schema.SystemMessage(*cm.config.System)}, input...)
+	}
 	messages, err := toQianfanMultiModalMessages(input)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	req.SetExtra(map[string]interface{}{
+	extra := map[string]interface{}{
 		"messages": messages,
-	})
+	}
+	if cm.config.EnableSystemMemory != nil {
+		extra["enable_system_memory"] = *cm.config.EnableSystemMemory
+	}
+	if specOptions.SystemMemoryID != nil {
+		extra["system_memory_id"] = *specOptions.SystemMemoryID
+	}
+	if specOptions.DisableSearch != nil {
+		extra["disable_search"] = *specOptions.DisableSearch
+	}
+	if cm.config.EnableCitation != nil {
+		extra["enable_citation"] = *cm.config.EnableCitation
+	}
+	if len(specOptions.Plugins) > 0 {
+		extra["plugins"] = specOptions.Plugins
+	}
+	req.SetExtra(extra)
 
 	if isStream {
 		req.StreamOptions = &qianfan.StreamOptions{IncludeUsage: true}
@@ -620,13 +653,22 @@ func resolveQianfanResponse(resp *qianfan.ChatCompletionV2Response) (*schema.Mes
 		return nil, fmt.Errorf("[resolveQianfanResponse] unexpected message with empty content and tool calls")
 	}
 
+	raw, err := findRawChoice(resp, choice.Index)
+	if err != nil {
+		return nil, err
+	}
+	finishReason := choice.FinishReason
+	if raw != nil && finishReason == "" {
+		finishReason = raw.FinishReason
+	}
+
 	msg := &schema.Message{
 		Content:    choice.Message.Content,
 		Name:       choice.Message.Name,
 		ToolCalls:  toMessageToolCalls(choice.Message.ToolCalls),
 		ToolCallID: choice.Message.ToolCallId,
 		ResponseMeta: &schema.ResponseMeta{
-			FinishReason: choice.FinishReason,
+			FinishReason: finishReason,
 			Usage:        toMessageTokenUsage(resp.Usage),
 		},
 	}
@@ -636,7 +678,7 @@ func resolveQianfanResponse(resp *qianfan.ChatCompletionV2Response) (*schema.Mes
 		msg.Role = schema.User
 	case "assistant":
 		msg.Role = schema.Assistant
-	case "function":
+	case "tool", "function":
 		msg.Role = schema.Tool
 	default:
 		return nil, fmt.Errorf("unsupported role from qianfan: %s", choice.Message.Role)
@@ -647,12 +689,14 @@ func resolveQianfanResponse(resp *qianfan.ChatCompletionV2Response) (*schema.Mes
 
 func toQianfanRole(role string) (string, error) {
 	switch role {
-	case "user", "system":
+	case "user":
 		return "user", nil
+	case "system":
+		return "system", nil
 	case "assistant":
 		return "assistant", nil
 	case "tool":
-		return "function", nil
+		return "tool", nil
 	default:
 		return "", fmt.Errorf("unsupported role: %s", role)
 	}
@@ -730,13 +774,29 @@ func resolveQianfanStreamResponse(resp *qianfan.ChatCompletionV2Response) (
 			continue
 		}
 		found = true
+
+		raw, err := findRawChoice(resp, choice.Index)
+		if err != nil {
+			return nil, false, err
+		}
+		finishReason := choice.FinishReason
+		toolCalls := toMessageToolCalls(choice.Delta.ToolCalls)
+		if raw != nil {
+			if finishReason == "" {
+				finishReason = raw.FinishReason
+			}
+			if len(toolCalls) == 0 {
+				toolCalls = toMessageStreamToolCalls(raw.Delta.ToolCalls)
+			}
+		}
+
 		// delta role assistant see: https://cloud.baidu.com/doc/WENXINWORKSHOP/s/Fm2vrveyu#function-call%E7%A4%BA%E4%BE%8B
 		msg = &schema.Message{
 			Role:      schema.Assistant,
 			Content:   choice.Delta.Content,
-			ToolCalls: toMessageToolCalls(choice.Delta.ToolCalls),
+			ToolCalls: toolCalls,
 			ResponseMeta: &schema.ResponseMeta{
-				FinishReason: choice.FinishReason,
+				FinishReason: finishReason,
 				Usage:        toMessageTokenUsage(resp.Usage),
 			},
 		}
@@ -777,6 +837,69 @@ func toMessageToolCalls(toolCalls []qianfan.ToolCall) []schema.ToolCall {
 	return ret
 }
 
+// rawChoice decodes the choice fields by their json names. The sdk decodes the responses with encoding/json,
+// while tagging the finish reason and the delta tool calls with mapstructure only, so that they get lost.
+type rawChoice struct {
+	Index        int    `json:"index"`
+	FinishReason string `json:"finish_reason"`
+	Delta        struct {
+		ToolCalls []rawToolCall `json:"tool_calls"`
+	} `json:"delta"`
+}
+
+type rawToolCall struct {
+	Index    *int                   `json:"index"`
+	ID       string                 `json:"id"`
+	Type     string                 `json:"type"`
+	Function qianfan.FunctionCallV2 `json:"function"`
+}
+
+// findRawChoice decodes the choice with the index from the response body, nil if not found.
+func findRawChoice(resp *qianfan.ChatCompletionV2Response, index int) (*rawChoice, error) {
+	if len(resp.Body) == 0 {
+		return nil, nil
+	}
+
+	var raw struct {
+		Choices []rawChoice `json:"choices"`
+	}
+	if err := json.Unmarshal(resp.Body, &raw); err != nil {
+		return nil, fmt.Errorf("[findRawChoice] unmarshal response body failed, %w", err)
+	}
+	for i := range raw.Choices {
+		if raw.Choices[i].Index == index {
+			return &raw.Choices[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// toMessageStreamToolCalls keeps the index of the streamed tool calls, by which the chunks are concatenated.
+func toMessageStreamToolCalls(toolCalls []rawToolCall) []schema.ToolCall {
+	if len(toolCalls) == 0 {
+		return nil
+	}
+
+	ret := make([]schema.ToolCall, len(toolCalls))
+	for i, toolCall := range toolCalls {
+		idx := i
+		if toolCall.Index != nil {
+			idx = *toolCall.Index
+		}
+		ret[i] = schema.ToolCall{
+			Index: &idx,
+			ID:    toolCall.ID,
+			Type:  toolCall.Type,
+			Function: schema.FunctionCall{
+				Name:      toolCall.Function.Name,
+				Arguments: toolCall.Function.Arguments,
+			},
+		}
+	}
+
+	return ret
+}
+
 func toMessageTokenUsage(usage *qianfan.ModelUsage) *schema.TokenUsage {
 	if usage == nil {
 		return nil
@@ -18,6 +18,7 @@ package qianfan
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -428,7 +429,7 @@ func TestToQianfanMultiModalMessages(t *testing.T) {
 	msgs, err = toQianfanMultiModalMessages(input5)
 	assert.Nil(t, err)
 	assert.Equal(t, 1, len(msgs))
-	assert.Equal(t, "function", msgs[0].Role)
+	assert.Equal(t, "tool", msgs[0].Role)
 	assert.Equal(t, "id", msgs[0].ToolCallId)
 
 	// 6. test with multiple UserInputMultiContent
@@ -493,3 +494,90 @@ func TestToQianfanMultiModalMessages(t *testing.T) {
 	assert.Equal(t, imageUrl, msgs[0].Content[1].ImageURL.URL)
 
 }
+
+func TestERNIEParams(t *testing.T) {
+	ctx := context.Background()
+	m, err := NewChatModel(ctx, &ChatModelConfig{
+		Model:              "ernie-4.0-8k",
+		System:             of("you are a helpful assistant"),
+		EnableSystemMemory: of(true),
+		SystemMemoryID:     of("sm-1"),
+		DisableSearch:      of(false),
+		EnableCitation:     of(true),
+		Plugins:            []string{"uuid-zhishiku"},
+	})
+	assert.Nil(t, err)
+
+	req, _, err := m.genRequest([]*schema.Message{schema.UserMessage("hi")}, false,
+		WithSystemMemoryID("sm-2"), WithDisableSearch(true), WithPlugins([]string{"uuid-chatocr"}))
+	assert.Nil(t, err)
+
+	extra := req.GetExtra()
+	assert.Equal(t, true, extra["enable_system_memory"])
+	assert.Equal(t, "sm-2", extra["system_memory_id"])
+	assert.Equal(t, true, extra["disable_search"])
+	assert.Equal(t, true, extra["enable_citation"])
+	assert.Equal(t, []string{"uuid-chatocr"}, extra["plugins"])
+
+	messages := extra["messages"].([]chatCompletionV3Message)
+	assert.Equal(t, 2, len(messages))
+	assert.Equal(t, "system", messages[0].Role)
+	assert.Equal(t, "you are a helpful assistant", messages[0].Content[0].Text)
+	assert.Equal(t, "user", messages[1].Role)
+
+	// the system message of the input takes precedence over the persona
+	req, _, err = m.genRequest([]*schema.Message{schema.SystemMessage("sys"), schema.UserMessage("hi")}, false)
+	assert.Nil(t, err)
+	messages = req.GetExtra()["messages"].([]chatCompletionV3Message)
+	assert.Equal(t, 2, len(messages))
+	assert.Equal(t, "sys", messages[0].Content[0].Text)
+	assert.Equal(t, "sm-1", req.GetExtra()["system_memory_id"])
+	assert.Equal(t, []string{"uuid-zhishiku"}, req.GetExtra()["plugins"])
+}
+
+func TestResolveRawResponse(t *testing.T) {
+	t.Run("generate finish reason", func(t *testing.T) {
+		resp := &qianfan.ChatCompletionV2Response{
+			Choices: []qianfan.ChatCompletionV2Choice{
+				{Message: qianfan.ChatCompletionV2Message{Role: "assistant", Content: "hi"}},
+			},
+		}
+		resp.SetResponse([]byte(`{"choices":[{"index":0,"finish_reason":"stop","message":{"role":"assistant","content":"hi"}}]}`), nil)
+
+		msg, err := resolveQianfanResponse(resp)
+		assert.Nil(t, err)
+		assert.Equal(t, "stop", msg.ResponseMeta.FinishReason)
+	})
+
+	t.Run("stream tool calls", func(t *testing.T) {
+		bodies := []string{
+			`{"choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"id":"call_1","type":"function","function":{"name":"get_weather","arguments":""}}]}}]}`,
+			`{"choices":[{"index":0,"delta":{"tool_calls":[{"index":1,"id":"call_2","type":"function","function":{"name":"get_stock","arguments":"{\"name\":"}}]}}]}`,
+			`{"choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"{\"location\":\"beijing\"}"}}]}}]}`,
+			`{"choices":[{"index":0,"delta":{"tool_calls":[{"index":1,"function":{"arguments":"\"baidu\"}"}}]}}]}`,
+			`{"choices":[{"index":0,"delta":{"content":""},"finish_reason":"tool_calls"}]}`,
+		}
+
+		var chunks []*schema.Message
+		for _, body := range bodies {
+			resp := &qianfan.ChatCompletionV2Response{}
+			assert.Nil(t, json.Unmarshal([]byte(body), resp))
+			resp.SetResponse([]byte(body), nil)
+
+			msg, found, err := resolveQianfanStreamResponse(resp)
+			assert.Nil(t, err)
+			assert.True(t, found)
+			chunks = append(chunks, msg)
+		}
+
+		msg, err := schema.ConcatMessages(chunks)
+		assert.Nil(t, err)
+		assert.Equal(t, "tool_calls", msg.ResponseMeta.FinishReason)
+		assert.Equal(t, 2, len(msg.ToolCalls))
+		assert.Equal(t, "call_1", msg.ToolCalls[0].ID)
+		assert.Equal(t, "get_weather", msg.ToolCalls[0].Function.Name)
+		assert.Equal(t, `{"location":"beijing"}`, msg.ToolCalls[0].Function.Arguments)
+		assert.Equal(t, "call_2", msg.ToolCalls[1].ID)
+		assert.Equal(t, `{"name":"baidu"}`, msg.ToolCalls[1].Function.Arguments)
+	})
+}
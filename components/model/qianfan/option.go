@@ -0,0 +1,52 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package qianfan
+
+import (
+	"github.com/cloudwego/eino/components/model"
+)
+
+// options is the specific options for the qianfan
+type options struct {
+	// SystemMemoryID overrides ChatModelConfig.SystemMemoryID
+	SystemMemoryID *string
+	// DisableSearch overrides ChatModelConfig.DisableSearch
+	DisableSearch *bool
+	// Plugins overrides ChatModelConfig.Plugins
+	Plugins []string
+}
+
+// WithSystemMemoryID is the option to set the system memory id of ERNIE models,
+// with ChatModelConfig.EnableSystemMemory enabled.
+func WithSystemMemoryID(id string) model.Option {
+	return model.WrapImplSpecificOptFn(func(opt *options) {
+		opt.SystemMemoryID = &id
+	})
+}
+
+// WithDisableSearch is the option to disable the real-time search of ERNIE models.
+func WithDisableSearch(disableSearch bool) model.Option {
+	return model.WrapImplSpecificOptFn(func(opt *options) {
+		opt.DisableSearch = &disableSearch
+	})
+}
+
+// WithPlugins is the option to set the plugins called by ERNIE models.
+func WithPlugins(plugins []string) model.Option {
+	return model.WrapImplSpecificOptFn(func(opt *options) {
+		opt.Plugins = plugins
+	})
+}
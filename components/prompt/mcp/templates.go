@@ -0,0 +1,97 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cloudwego/eino/components/prompt"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+type TemplatesConfig struct {
+	// Cli is the MCP (Model Control Protocol) client, ref: https://github.com/mark3labs/mcp-go
+	// Notice: should Initialize with server before use
+	// Required
+	Cli client.MCPClient
+	// NameList specifies which prompts to fetch from MCP server
+	// If empty, all available prompts will be fetched
+	NameList []string
+}
+
+// GetPromptTemplates returns the chat templates of the prompts of the MCP server, by prompt name.
+func GetPromptTemplates(ctx context.Context, conf *TemplatesConfig) (map[string]prompt.ChatTemplate, error) {
+	if conf == nil || conf.Cli == nil {
+		return nil, errors.New("mcp cli is required")
+	}
+
+	result, err := conf.Cli.ListPrompts(ctx, mcp.ListPromptsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("list mcp prompts fail: %w", err)
+	}
+
+	nameSet := make(map[string]struct{}, len(conf.NameList))
+	for _, name := range conf.NameList {
+		nameSet[name] = struct{}{}
+	}
+
+	templates := make(map[string]prompt.ChatTemplate, len(result.Prompts))
+	for _, p := range result.Prompts {
+		if len(conf.NameList) > 0 {
+			if _, ok := nameSet[p.Name]; !ok {
+				continue
+			}
+		}
+		templates[p.Name] = &chatTemplate{
+			cli:  conf.Cli,
+			name: p.Name,
+		}
+	}
+	return templates, nil
+}
+
+// WatchPromptTemplates fetches the chat templates again each time the MCP server notifies its prompts changed,
+// calling onChange with them, or with the error of fetching them, until ctx is done.
+// The client must receive the notifications of the server, e.g. with the continuous listening of the
+// Streamable HTTP transport.
+// The templates fetch the prompts when formatting, so they are up to date with the changes of existing prompts.
+func WatchPromptTemplates(ctx context.Context, conf *TemplatesConfig, onChange func(ctx context.Context, templates map[string]prompt.ChatTemplate, err error)) error {
+	if conf == nil || conf.Cli == nil {
+		return errors.New("mcp cli is required")
+	}
+	if onChange == nil {
+		return errors.New("onChange is required")
+	}
+
+	// the handlers of the client can not be removed, so the handler is a no-op once ctx is done
+	conf.Cli.OnNotification(func(notification mcp.JSONRPCNotification) {
+		if notification.Method != mcp.MethodNotificationPromptsListChanged || ctx.Err() != nil {
+			return
+		}
+		// the notifications are handled one by one by the client, listing in the handler would block it
+		go func() {
+			templates, err := GetPromptTemplates(ctx, conf)
+			if ctx.Err() != nil {
+				return
+			}
+			onChange(ctx, templates, err)
+		}()
+	})
+	return nil
+}
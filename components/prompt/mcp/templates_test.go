@@ -0,0 +1,88 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mcp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/prompt"
+	"github.com/cloudwego/eino/schema"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+)
+
+func greetingHandler(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	return &mcp.GetPromptResult{
+		Messages: []mcp.PromptMessage{
+			mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent("hello "+request.Params.Arguments["name"])),
+		},
+	}, nil
+}
+
+func TestPromptTemplates(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	svr := server.NewMCPServer("test", "1.0.0", server.WithPromptCapabilities(true))
+	svr.AddPrompt(mcp.NewPrompt("greeting", mcp.WithArgument("name")), greetingHandler)
+	svr.AddPrompt(mcp.NewPrompt("farewell"), greetingHandler)
+	srv := server.NewTestServer(svr)
+	defer srv.Close()
+
+	cli, err := client.NewSSEMCPClient(srv.URL + "/sse")
+	assert.NoError(t, err)
+	defer cli.Close()
+	assert.NoError(t, cli.Start(ctx))
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	_, err = cli.Initialize(ctx, initRequest)
+	assert.NoError(t, err)
+
+	_, err = GetPromptTemplates(ctx, &TemplatesConfig{})
+	assert.EqualError(t, err, "mcp cli is required")
+
+	templates, err := GetPromptTemplates(ctx, &TemplatesConfig{Cli: cli})
+	assert.NoError(t, err)
+	assert.Len(t, templates, 2)
+
+	templates, err = GetPromptTemplates(ctx, &TemplatesConfig{Cli: cli, NameList: []string{"greeting"}})
+	assert.NoError(t, err)
+	if assert.Contains(t, templates, "greeting") {
+		messages, fErr := templates["greeting"].Format(ctx, map[string]any{"name": "eino"})
+		assert.NoError(t, fErr)
+		assert.Equal(t, []*schema.Message{schema.UserMessage("hello eino")}, messages)
+	}
+
+	changes := make(chan map[string]prompt.ChatTemplate, 1)
+	err = WatchPromptTemplates(ctx, &TemplatesConfig{Cli: cli}, func(ctx context.Context, templates map[string]prompt.ChatTemplate, err error) {
+		assert.NoError(t, err)
+		changes <- templates
+	})
+	assert.NoError(t, err)
+
+	svr.DeletePrompts("farewell")
+	select {
+	case templates = <-changes:
+		assert.Len(t, templates, 1)
+		assert.Contains(t, templates, "greeting")
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change")
+	}
+}
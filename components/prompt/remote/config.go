@@ -0,0 +1,104 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// ConfigGetter reads the raw value of key from a config center, e.g. a nacos or apollo client.
+type ConfigGetter func(ctx context.Context, key string) (string, error)
+
+type ConfigSourceConfig struct {
+	// Get reads the config value holding the prompt, see README for nacos and apollo adapters.
+	// Required
+	Get ConfigGetter
+	// Key maps the prompt name and ref to a config key, e.g. "prompt.qa.production".
+	// When not set, the prompt name is used as key and the prompt document must carry the requested version or label.
+	// Optional
+	Key func(name string, ref Ref) string
+	// TextRole is the role of the message built from a text prompt.
+	// Optional. Default: schema.User
+	TextRole schema.RoleType
+}
+
+// NewConfigSource returns a Source reading prompts from a config center.
+// A value starting with '{' is parsed as a prompt document (see README), any other value is a text prompt.
+func NewConfigSource(config *ConfigSourceConfig) (Source, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is nil")
+	}
+	if config.Get == nil {
+		return nil, fmt.Errorf("get is required")
+	}
+
+	key, checkRef := config.Key, false
+	if key == nil {
+		key, checkRef = func(name string, _ Ref) string { return name }, true
+	}
+	textRole := config.TextRole
+	if textRole == "" {
+		textRole = schema.User
+	}
+
+	return &configSource{
+		get:      config.Get,
+		key:      key,
+		checkRef: checkRef,
+		textRole: textRole,
+	}, nil
+}
+
+type configSource struct {
+	get      ConfigGetter
+	key      func(name string, ref Ref) string
+	checkRef bool
+	textRole schema.RoleType
+}
+
+func (c *configSource) Fetch(ctx context.Context, name string, ref Ref) (*Prompt, error) {
+	value, err := c.get(ctx, c.key(name, ref))
+	if err != nil {
+		return nil, fmt.Errorf("get prompt config fail: %w", err)
+	}
+	if value == "" {
+		return nil, fmt.Errorf("%w: %s", ErrPromptNotFound, name)
+	}
+
+	var p *Prompt
+	if strings.HasPrefix(strings.TrimSpace(value), "{") && json.Valid([]byte(value)) {
+		p, err = parsePromptDocument(name, []byte(value), c.textRole)
+		if err != nil {
+			return nil, fmt.Errorf("parse prompt config fail: %w", err)
+		}
+	} else {
+		p = &Prompt{
+			Name:      name,
+			Templates: []schema.MessagesTemplate{&schema.Message{Role: c.textRole, Content: value}},
+		}
+	}
+
+	if c.checkRef && !matchRef(p, ref) {
+		return nil, fmt.Errorf("%w: %s(%s)", ErrPromptNotFound, name, ref)
+	}
+
+	return p, nil
+}
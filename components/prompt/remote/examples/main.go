@@ -0,0 +1,79 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/prompt/remote"
+)
+
+func main() {
+	ctx := context.Background()
+
+	dir, err := os.MkdirTemp("", "prompts")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = os.WriteFile(filepath.Join(dir, "qa.yaml"), []byte(`
+version: "1"
+type: chat
+prompt:
+  - role: system
+    content: You answer questions about {{topic}}.
+  - type: placeholder
+    name: history
+  - role: user
+    content: "{{question}}"
+`), 0o644)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	source, err := remote.NewFileSource(&remote.FileConfig{Dir: dir})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	provider, err := remote.NewProvider(ctx, &remote.Config{
+		Source: source,
+		TTL:    5 * time.Second,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	msgs, err := provider.Template("qa").Format(ctx, map[string]any{
+		"topic":    "eino",
+		"question": "What is a graph?",
+		"history":  []*schema.Message{schema.UserMessage("hi"), schema.AssistantMessage("Hello, how can I help?", nil)},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, m := range msgs {
+		fmt.Println(m.Role, ":", m.Content)
+	}
+}
@@ -0,0 +1,177 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// fileExtensions are the extensions tried in order when looking up the file of a prompt.
+var fileExtensions = []string{".json", ".yaml", ".yml", ".txt", ".md", ""}
+
+type FileConfig struct {
+	// Dir is the directory holding the prompt files, the prompt "qa" is read from the first existing one of
+	// qa.json, qa.yaml, qa.yml, qa.txt, qa.md and qa.
+	// .json and .yaml files hold a prompt document (see README), other files are text prompts.
+	// Required
+	Dir string
+	// TextRole is the role of the message built from a text prompt.
+	// Optional. Default: schema.User
+	TextRole schema.RoleType
+}
+
+// NewFileSource returns a Source reading prompts from local files.
+// A file is parsed again once its modification time or size changes, so edits are picked up
+// without restarting, as soon as the provider cache entry expires.
+func NewFileSource(config *FileConfig) (Source, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is nil")
+	}
+	if config.Dir == "" {
+		return nil, fmt.Errorf("dir is required")
+	}
+	info, err := os.Stat(config.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("stat prompt dir fail: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", config.Dir)
+	}
+
+	textRole := config.TextRole
+	if textRole == "" {
+		textRole = schema.User
+	}
+
+	return &fileSource{
+		dir:      config.Dir,
+		textRole: textRole,
+		files:    make(map[string]*fileEntry),
+	}, nil
+}
+
+type fileSource struct {
+	dir      string
+	textRole schema.RoleType
+
+	mu    sync.Mutex
+	files map[string]*fileEntry
+}
+
+type fileEntry struct {
+	modTime time.Time
+	size    int64
+	prompt  *Prompt
+}
+
+func (f *fileSource) Fetch(_ context.Context, name string, ref Ref) (*Prompt, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return nil, fmt.Errorf("invalid prompt name: %q", name)
+	}
+
+	path, info, err := f.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := f.load(name, path, info)
+	if err != nil {
+		return nil, err
+	}
+
+	if !matchRef(p, ref) {
+		return nil, fmt.Errorf("%w: %s(%s)", ErrPromptNotFound, name, ref)
+	}
+
+	return p, nil
+}
+
+func (f *fileSource) lookup(name string) (string, fs.FileInfo, error) {
+	for _, ext := range fileExtensions {
+		path := filepath.Join(f.dir, name+ext)
+		info, err := os.Stat(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return "", nil, fmt.Errorf("stat prompt file fail: %w", err)
+		}
+		if info.IsDir() {
+			continue
+		}
+		return path, info, nil
+	}
+	return "", nil, fmt.Errorf("%w: %s", ErrPromptNotFound, name)
+}
+
+func (f *fileSource) load(name, path string, info fs.FileInfo) (*Prompt, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if e, ok := f.files[path]; ok && e.modTime.Equal(info.ModTime()) && e.size == info.Size() {
+		return e.prompt, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read prompt file fail: %w", err)
+	}
+
+	p, err := parsePromptFile(name, filepath.Ext(path), data, f.textRole)
+	if err != nil {
+		return nil, fmt.Errorf("parse prompt file %s fail: %w", path, err)
+	}
+	if p.Version == "" {
+		p.Version = info.ModTime().UTC().Format(time.RFC3339Nano)
+	}
+
+	f.files[path] = &fileEntry{modTime: info.ModTime(), size: info.Size(), prompt: p}
+	return p, nil
+}
+
+func parsePromptFile(name, ext string, data []byte, textRole schema.RoleType) (*Prompt, error) {
+	switch ext {
+	case ".yaml", ".yml":
+		var v any
+		if err := yaml.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+		var err error
+		if data, err = json.Marshal(v); err != nil {
+			return nil, err
+		}
+		fallthrough
+	case ".json":
+		return parsePromptDocument(name, data, textRole)
+	default:
+		return &Prompt{
+			Name:      name,
+			Templates: []schema.MessagesTemplate{&schema.Message{Role: textRole, Content: string(data)}},
+		}, nil
+	}
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package remote
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+const defaultLangfuseHost = "https://cloud.langfuse.com"
+
+type LangfuseConfig struct {
+	// Host is the langfuse server address.
+	// Optional. Default: "https://cloud.langfuse.com"
+	Host string
+	// PublicKey and SecretKey are the api keys of the langfuse project.
+	// Required
+	PublicKey string
+	SecretKey string
+	// TextRole is the role of the message built from a text prompt.
+	// Optional. Default: schema.User
+	TextRole schema.RoleType
+	// Timeout is the http request timeout, used when HTTPClient is not set.
+	// Optional. Default: 10s
+	Timeout time.Duration
+	// HTTPClient is used to request langfuse.
+	// Optional
+	HTTPClient *http.Client
+}
+
+// NewLangfuseSource returns a Source reading prompts from the langfuse prompts api,
+// ref: https://langfuse.com/docs/prompts/get-started
+func NewLangfuseSource(config *LangfuseConfig) (Source, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is nil")
+	}
+	if config.PublicKey == "" || config.SecretKey == "" {
+		return nil, fmt.Errorf("public key and secret key are required")
+	}
+
+	host := config.Host
+	if host == "" {
+		host = defaultLangfuseHost
+	}
+	textRole := config.TextRole
+	if textRole == "" {
+		textRole = schema.User
+	}
+	client := config.HTTPClient
+	if client == nil {
+		timeout := config.Timeout
+		if timeout <= 0 {
+			timeout = 10 * time.Second
+		}
+		client = &http.Client{Timeout: timeout}
+	}
+
+	return &langfuseSource{
+		host:      strings.TrimRight(host, "/"),
+		publicKey: config.PublicKey,
+		secretKey: config.SecretKey,
+		textRole:  textRole,
+		client:    client,
+	}, nil
+}
+
+type langfuseSource struct {
+	host      string
+	publicKey string
+	secretKey string
+	textRole  schema.RoleType
+	client    *http.Client
+}
+
+func (l *langfuseSource) Fetch(ctx context.Context, name string, ref Ref) (*Prompt, error) {
+	query := url.Values{}
+	if ref.Version != "" {
+		query.Set("version", ref.Version)
+	} else if ref.Label != "" {
+		query.Set("label", ref.Label)
+	}
+	u := l.host + "/api/public/v2/prompts/" + url.PathEscape(name)
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create langfuse request fail: %w", err)
+	}
+	req.SetBasicAuth(l.publicKey, l.secretKey)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request langfuse fail: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read langfuse response fail: %w", err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s(%s)", ErrPromptNotFound, name, ref)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("langfuse returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	p, err := parsePromptDocument(name, body, l.textRole)
+	if err != nil {
+		return nil, fmt.Errorf("decode langfuse prompt fail: %w", err)
+	}
+
+	return p, nil
+}
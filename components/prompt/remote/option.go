@@ -0,0 +1,47 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package remote
+
+import (
+	"github.com/cloudwego/eino/components/prompt"
+	"github.com/cloudwego/eino/schema"
+)
+
+type options struct {
+	Ref        Ref
+	FormatType *schema.FormatType
+}
+
+// WithVersion selects an exact version of the prompt.
+func WithVersion(version string) prompt.Option {
+	return prompt.WrapImplSpecificOptFn(func(o *options) {
+		o.Ref.Version = version
+	})
+}
+
+// WithLabel selects the version of the prompt carrying label, e.g. "production" or "staging".
+func WithLabel(label string) prompt.Option {
+	return prompt.WrapImplSpecificOptFn(func(o *options) {
+		o.Ref.Label = label
+	})
+}
+
+// WithFormatType overrides the format type used to render the prompt messages.
+func WithFormatType(formatType schema.FormatType) prompt.Option {
+	return prompt.WrapImplSpecificOptFn(func(o *options) {
+		o.FormatType = &formatType
+	})
+}
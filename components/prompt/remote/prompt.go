@@ -0,0 +1,173 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// ErrPromptNotFound is returned by sources when the prompt, or the requested version of it, does not exist.
+var ErrPromptNotFound = errors.New("prompt not found")
+
+// Ref selects the version of a prompt, an empty Ref selects the version the source considers current.
+type Ref struct {
+	// Version is the exact version of the prompt.
+	Version string
+	// Label selects the version carrying the label, e.g. "production", if the source supports labels.
+	Label string
+}
+
+func (r Ref) String() string {
+	switch {
+	case r.Version != "":
+		return "version=" + r.Version
+	case r.Label != "":
+		return "label=" + r.Label
+	default:
+		return "latest"
+	}
+}
+
+// Prompt is a versioned prompt fetched from a Source.
+type Prompt struct {
+	Name    string
+	Version string
+	// Templates are the message templates of the prompt, formatted with the variables passed to Format.
+	Templates []schema.MessagesTemplate
+	// Config is the free-form config stored along with the prompt, e.g. model parameters.
+	Config map[string]any
+	// Labels are the labels of the version, if the source supports labels.
+	Labels []string
+}
+
+// Source fetches prompts from an external system.
+type Source interface {
+	// Fetch returns the prompt of name at ref, or an error wrapping ErrPromptNotFound if it does not exist.
+	Fetch(ctx context.Context, name string, ref Ref) (*Prompt, error)
+}
+
+// promptDocument is the serialized form of a prompt, shared by the Langfuse prompts api, prompt files
+// and config center entries.
+//
+//	{"name": "qa", "version": 3, "type": "chat", "prompt": [{"role": "system", "content": "..."}, {"type": "placeholder", "name": "history"}]}
+//	{"name": "summary", "type": "text", "prompt": "Summarize {{text}}"}
+type promptDocument struct {
+	Name    string          `json:"name"`
+	Version json.RawMessage `json:"version"`
+	Type    string          `json:"type"`
+	Prompt  json.RawMessage `json:"prompt"`
+	Config  map[string]any  `json:"config"`
+	Labels  []string        `json:"labels"`
+}
+
+type promptMessage struct {
+	Type    string `json:"type"`
+	Role    string `json:"role"`
+	Content string `json:"content"`
+	Name    string `json:"name"`
+}
+
+// toPrompt converts the document to a Prompt, text prompts become a single message of textRole.
+func (d *promptDocument) toPrompt(textRole schema.RoleType) (*Prompt, error) {
+	p := &Prompt{
+		Name:    d.Name,
+		Version: rawToString(d.Version),
+		Config:  d.Config,
+		Labels:  d.Labels,
+	}
+
+	switch d.Type {
+	case "chat":
+		var msgs []*promptMessage
+		if err := json.Unmarshal(d.Prompt, &msgs); err != nil {
+			return nil, fmt.Errorf("decode chat prompt fail: %w", err)
+		}
+		for _, m := range msgs {
+			if m.Type == "placeholder" {
+				p.Templates = append(p.Templates, schema.MessagesPlaceholder(m.Name, true))
+				continue
+			}
+			role, err := toRole(m.Role)
+			if err != nil {
+				return nil, err
+			}
+			p.Templates = append(p.Templates, &schema.Message{Role: role, Content: m.Content})
+		}
+	case "", "text":
+		var text string
+		if err := json.Unmarshal(d.Prompt, &text); err != nil {
+			return nil, fmt.Errorf("decode text prompt fail: %w", err)
+		}
+		p.Templates = []schema.MessagesTemplate{&schema.Message{Role: textRole, Content: text}}
+	default:
+		return nil, fmt.Errorf("unknown prompt type: %s", d.Type)
+	}
+
+	return p, nil
+}
+
+func parsePromptDocument(name string, data []byte, textRole schema.RoleType) (*Prompt, error) {
+	doc := &promptDocument{}
+	if err := json.Unmarshal(data, doc); err != nil {
+		return nil, err
+	}
+	if doc.Name == "" {
+		doc.Name = name
+	}
+	return doc.toPrompt(textRole)
+}
+
+// matchRef reports whether p satisfies ref, for sources that only hold a single version of each prompt.
+func matchRef(p *Prompt, ref Ref) bool {
+	if ref.Version != "" {
+		return ref.Version == p.Version
+	}
+	if ref.Label != "" {
+		return slices.Contains(p.Labels, ref.Label)
+	}
+	return true
+}
+
+func toRole(role string) (schema.RoleType, error) {
+	switch strings.ToLower(role) {
+	case "system", "developer":
+		return schema.System, nil
+	case "user":
+		return schema.User, nil
+	case "assistant":
+		return schema.Assistant, nil
+	default:
+		return "", fmt.Errorf("unsupported prompt message role: %s", role)
+	}
+}
+
+func rawToString(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	return string(raw)
+}
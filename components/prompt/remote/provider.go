@@ -0,0 +1,177 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/components/prompt"
+	"github.com/cloudwego/eino/schema"
+)
+
+const defaultTTL = time.Minute
+
+type Config struct {
+	// Source is where prompts are fetched from, see NewLangfuseSource, NewConfigSource and NewFileSource.
+	// Required
+	Source Source
+	// TTL is how long a fetched prompt is served from cache before being fetched again.
+	// A negative value disables caching.
+	// Optional. Default: 1 minute
+	TTL time.Duration
+	// FormatType is the format type used to render the prompt messages.
+	// Optional. Default: schema.Jinja2, which matches the {{variable}} syntax of langfuse
+	FormatType *schema.FormatType
+	// Label is the label used when neither WithVersion nor WithLabel is given.
+	// Optional
+	Label string
+}
+
+// Provider fetches prompts from a Source and caches them, so prompts can be changed without redeploying.
+// When refreshing an expired prompt fails, the stale prompt keeps being served until the source recovers.
+type Provider struct {
+	source     Source
+	ttl        time.Duration
+	formatType schema.FormatType
+	label      string
+
+	mu      sync.Mutex
+	entries map[cacheKey]*cacheEntry
+}
+
+type cacheKey struct {
+	name string
+	ref  Ref
+}
+
+type cacheEntry struct {
+	mu        sync.Mutex
+	prompt    *Prompt
+	fetchedAt time.Time
+}
+
+func NewProvider(_ context.Context, config *Config) (*Provider, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is nil")
+	}
+	if config.Source == nil {
+		return nil, fmt.Errorf("source is required")
+	}
+
+	ttl := config.TTL
+	if ttl == 0 {
+		ttl = defaultTTL
+	}
+	formatType := schema.Jinja2
+	if config.FormatType != nil {
+		formatType = *config.FormatType
+	}
+
+	return &Provider{
+		source:     config.Source,
+		ttl:        ttl,
+		formatType: formatType,
+		label:      config.Label,
+		entries:    make(map[cacheKey]*cacheEntry),
+	}, nil
+}
+
+// Get returns the prompt of name, from cache if it has not expired.
+func (p *Provider) Get(ctx context.Context, name string, opts ...prompt.Option) (*Prompt, error) {
+	o := prompt.GetImplSpecificOptions(&options{}, opts...)
+	return p.get(ctx, name, p.ref(o.Ref))
+}
+
+// Template returns a chat template rendering the prompt of name, the prompt is resolved on every Format,
+// so the template follows the changes of the source.
+// WithVersion, WithLabel and WithFormatType passed here are the defaults of the template and can be
+// overridden by the options passed to Format.
+func (p *Provider) Template(name string, opts ...prompt.Option) prompt.ChatTemplate {
+	return &chatTemplate{
+		provider: p,
+		name:     name,
+		opts:     opts,
+	}
+}
+
+// Invalidate drops all cached versions of the prompt of name, e.g. from a config center change listener.
+func (p *Provider) Invalidate(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for k := range p.entries {
+		if k.name == name {
+			delete(p.entries, k)
+		}
+	}
+}
+
+func (p *Provider) ref(ref Ref) Ref {
+	if ref.Version == "" && ref.Label == "" {
+		ref.Label = p.label
+	}
+	return ref
+}
+
+func (p *Provider) get(ctx context.Context, name string, ref Ref) (*Prompt, error) {
+	if p.ttl < 0 {
+		return p.fetch(ctx, name, ref)
+	}
+
+	key := cacheKey{name: name, ref: ref}
+	p.mu.Lock()
+	e, ok := p.entries[key]
+	if !ok {
+		e = &cacheEntry{}
+		p.entries[key] = e
+	}
+	p.mu.Unlock()
+
+	// concurrent callers of an expired entry wait for a single fetch
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.prompt != nil && time.Since(e.fetchedAt) < p.ttl {
+		return e.prompt, nil
+	}
+
+	fetched, err := p.fetch(ctx, name, ref)
+	if err != nil {
+		if e.prompt != nil && !errors.Is(err, ErrPromptNotFound) {
+			return e.prompt, nil
+		}
+		return nil, err
+	}
+
+	e.prompt = fetched
+	e.fetchedAt = time.Now()
+	return fetched, nil
+}
+
+func (p *Provider) fetch(ctx context.Context, name string, ref Ref) (*Prompt, error) {
+	fetched, err := p.source.Fetch(ctx, name, ref)
+	if err != nil {
+		return nil, fmt.Errorf("fetch prompt %s(%s) fail: %w", name, ref, err)
+	}
+	if fetched == nil || len(fetched.Templates) == 0 {
+		return nil, fmt.Errorf("prompt %s(%s) is empty", name, ref)
+	}
+	return fetched, nil
+}
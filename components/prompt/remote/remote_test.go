@@ -0,0 +1,285 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSource struct {
+	calls  atomic.Int32
+	prompt *Prompt
+	err    error
+}
+
+func (m *mockSource) Fetch(_ context.Context, name string, _ Ref) (*Prompt, error) {
+	m.calls.Add(1)
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.prompt, nil
+}
+
+func TestProviderCache(t *testing.T) {
+	ctx := context.Background()
+	src := &mockSource{prompt: &Prompt{
+		Name:      "qa",
+		Version:   "1",
+		Templates: []schema.MessagesTemplate{schema.UserMessage("{{question}}")},
+	}}
+
+	p, err := NewProvider(ctx, &Config{Source: src, TTL: 50 * time.Millisecond})
+	require.NoError(t, err)
+
+	got, err := p.Get(ctx, "qa")
+	require.NoError(t, err)
+	assert.Equal(t, "1", got.Version)
+	_, err = p.Get(ctx, "qa")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), src.calls.Load())
+
+	t.Run("different ref is cached separately", func(t *testing.T) {
+		_, err = p.Get(ctx, "qa", WithLabel("staging"))
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), src.calls.Load())
+	})
+
+	t.Run("stale prompt is served when refresh fails", func(t *testing.T) {
+		time.Sleep(60 * time.Millisecond)
+		src.err = errors.New("unavailable")
+		got, err = p.Get(ctx, "qa")
+		require.NoError(t, err)
+		assert.Equal(t, "1", got.Version)
+	})
+
+	t.Run("invalidate", func(t *testing.T) {
+		p.Invalidate("qa")
+		_, err = p.Get(ctx, "qa")
+		assert.Error(t, err)
+
+		src.err = nil
+		src.prompt = &Prompt{Name: "qa", Version: "2", Templates: src.prompt.Templates}
+		got, err = p.Get(ctx, "qa")
+		require.NoError(t, err)
+		assert.Equal(t, "2", got.Version)
+	})
+
+	t.Run("not found is not served stale", func(t *testing.T) {
+		time.Sleep(60 * time.Millisecond)
+		src.err = ErrPromptNotFound
+		_, err = p.Get(ctx, "qa")
+		assert.ErrorIs(t, err, ErrPromptNotFound)
+	})
+}
+
+func TestTemplateFormat(t *testing.T) {
+	ctx := context.Background()
+	src := &mockSource{prompt: &Prompt{
+		Name:    "qa",
+		Version: "3",
+		Templates: []schema.MessagesTemplate{
+			schema.SystemMessage("You answer questions about {{topic}}."),
+			schema.MessagesPlaceholder("history", true),
+			schema.UserMessage("{{question}}"),
+		},
+	}}
+	p, err := NewProvider(ctx, &Config{Source: src})
+	require.NoError(t, err)
+
+	msgs, err := p.Template("qa").Format(ctx, map[string]any{
+		"topic":    "go",
+		"question": "what is a goroutine?",
+		"history":  []*schema.Message{schema.UserMessage("hi"), schema.AssistantMessage("hello", nil)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []*schema.Message{
+		schema.SystemMessage("You answer questions about go."),
+		schema.UserMessage("hi"),
+		schema.AssistantMessage("hello", nil),
+		schema.UserMessage("what is a goroutine?"),
+	}, msgs)
+
+	msgs, err = p.Template("qa").Format(ctx, map[string]any{"topic": "go", "question": "q"})
+	require.NoError(t, err)
+	assert.Len(t, msgs, 2)
+
+	src.err = errors.New("unavailable")
+	_, err = p.Template("other").Format(ctx, nil)
+	assert.Error(t, err)
+}
+
+func TestLangfuseSource(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "pk" || pass != "sk" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/public/v2/prompts/qa":
+			assert.Equal(t, "production", r.URL.Query().Get("label"))
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"name":    "qa",
+				"version": 4,
+				"type":    "chat",
+				"labels":  []string{"production"},
+				"config":  map[string]any{"temperature": 0.2},
+				"prompt": []map[string]any{
+					{"role": "system", "content": "Be brief."},
+					{"type": "placeholder", "name": "history"},
+					{"role": "user", "content": "{{question}}"},
+				},
+			})
+		case "/api/public/v2/prompts/summary":
+			assert.Equal(t, "2", r.URL.Query().Get("version"))
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"name":    "summary",
+				"version": 2,
+				"type":    "text",
+				"prompt":  "Summarize {{text}}",
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	src, err := NewLangfuseSource(&LangfuseConfig{Host: server.URL, PublicKey: "pk", SecretKey: "sk"})
+	require.NoError(t, err)
+
+	p, err := src.Fetch(ctx, "qa", Ref{Label: "production"})
+	require.NoError(t, err)
+	assert.Equal(t, "4", p.Version)
+	assert.Equal(t, map[string]any{"temperature": 0.2}, p.Config)
+	assert.Equal(t, []string{"production"}, p.Labels)
+	require.Len(t, p.Templates, 3)
+	assert.Equal(t, schema.MessagesPlaceholder("history", true), p.Templates[1])
+
+	p, err = src.Fetch(ctx, "summary", Ref{Version: "2"})
+	require.NoError(t, err)
+	assert.Equal(t, []schema.MessagesTemplate{schema.UserMessage("Summarize {{text}}")}, p.Templates)
+
+	_, err = src.Fetch(ctx, "missing", Ref{})
+	assert.ErrorIs(t, err, ErrPromptNotFound)
+
+	_, err = NewLangfuseSource(&LangfuseConfig{})
+	assert.Error(t, err)
+}
+
+func TestFileSource(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "greet.txt"), []byte("Hello {{name}}"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "qa.yaml"), []byte(`
+version: "7"
+type: chat
+labels: [production]
+prompt:
+  - role: system
+    content: Be brief.
+  - role: user
+    content: "{{question}}"
+`), 0o644))
+
+	src, err := NewFileSource(&FileConfig{Dir: dir})
+	require.NoError(t, err)
+
+	p, err := src.Fetch(ctx, "qa", Ref{Label: "production"})
+	require.NoError(t, err)
+	assert.Equal(t, "7", p.Version)
+	assert.Equal(t, []schema.MessagesTemplate{schema.SystemMessage("Be brief."), schema.UserMessage("{{question}}")}, p.Templates)
+
+	_, err = src.Fetch(ctx, "qa", Ref{Version: "6"})
+	assert.ErrorIs(t, err, ErrPromptNotFound)
+	_, err = src.Fetch(ctx, "../qa", Ref{})
+	assert.Error(t, err)
+	_, err = src.Fetch(ctx, "missing", Ref{})
+	assert.ErrorIs(t, err, ErrPromptNotFound)
+
+	p, err = src.Fetch(ctx, "greet", Ref{})
+	require.NoError(t, err)
+	assert.Equal(t, []schema.MessagesTemplate{schema.UserMessage("Hello {{name}}")}, p.Templates)
+
+	t.Run("hot reload", func(t *testing.T) {
+		path := filepath.Join(dir, "greet.txt")
+		require.NoError(t, os.WriteFile(path, []byte("Hi {{name}}!"), 0o644))
+		later := time.Now().Add(time.Minute)
+		require.NoError(t, os.Chtimes(path, later, later))
+
+		provider, err := NewProvider(ctx, &Config{Source: src, TTL: -1})
+		require.NoError(t, err)
+		msgs, err := provider.Template("greet").Format(ctx, map[string]any{"name": "eino"})
+		require.NoError(t, err)
+		assert.Equal(t, []*schema.Message{schema.UserMessage("Hi eino!")}, msgs)
+	})
+}
+
+func TestConfigSource(t *testing.T) {
+	ctx := context.Background()
+	values := map[string]string{
+		"qa":             `{"version": "3", "type": "chat", "prompt": [{"role": "user", "content": "{{question}}"}]}`,
+		"greet":          "Hello {{name}}",
+		"greet.staging":  "Hi {{name}}",
+		"greet.disabled": "",
+	}
+	get := func(_ context.Context, key string) (string, error) {
+		return values[key], nil
+	}
+
+	src, err := NewConfigSource(&ConfigSourceConfig{Get: get})
+	require.NoError(t, err)
+
+	p, err := src.Fetch(ctx, "qa", Ref{Version: "3"})
+	require.NoError(t, err)
+	assert.Equal(t, []schema.MessagesTemplate{schema.UserMessage("{{question}}")}, p.Templates)
+	_, err = src.Fetch(ctx, "qa", Ref{Version: "2"})
+	assert.ErrorIs(t, err, ErrPromptNotFound)
+
+	p, err = src.Fetch(ctx, "greet", Ref{})
+	require.NoError(t, err)
+	assert.Equal(t, []schema.MessagesTemplate{schema.UserMessage("Hello {{name}}")}, p.Templates)
+
+	src, err = NewConfigSource(&ConfigSourceConfig{
+		Get: get,
+		Key: func(name string, ref Ref) string {
+			if ref.Label == "" {
+				return name
+			}
+			return name + "." + ref.Label
+		},
+		TextRole: schema.System,
+	})
+	require.NoError(t, err)
+	p, err = src.Fetch(ctx, "greet", Ref{Label: "staging"})
+	require.NoError(t, err)
+	assert.Equal(t, []schema.MessagesTemplate{schema.SystemMessage("Hi {{name}}")}, p.Templates)
+	_, err = src.Fetch(ctx, "greet", Ref{Label: "disabled"})
+	assert.ErrorIs(t, err, ErrPromptNotFound)
+}
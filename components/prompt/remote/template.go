@@ -0,0 +1,91 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package remote
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/prompt"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	typ = "RemotePrompt"
+
+	// ExtraKeyPromptName and ExtraKeyPromptVersion are the keys of the prompt name and version
+	// in the Extra of the prompt callback input and output.
+	ExtraKeyPromptName    = "prompt_name"
+	ExtraKeyPromptVersion = "prompt_version"
+)
+
+type chatTemplate struct {
+	provider *Provider
+	name     string
+	opts     []prompt.Option
+}
+
+func (c *chatTemplate) Format(ctx context.Context, vs map[string]any, opts ...prompt.Option) (result []*schema.Message, err error) {
+	o := prompt.GetImplSpecificOptions(&options{}, slices.Concat(c.opts, opts)...)
+	formatType := c.provider.formatType
+	if o.FormatType != nil {
+		formatType = *o.FormatType
+	}
+
+	ctx = callbacks.EnsureRunInfo(ctx, c.GetType(), components.ComponentOfPrompt)
+	ctx = callbacks.OnStart(ctx, &prompt.CallbackInput{
+		Variables: vs,
+		Extra:     map[string]any{ExtraKeyPromptName: c.name},
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	p, err := c.provider.get(ctx, c.name, c.provider.ref(o.Ref))
+	if err != nil {
+		return nil, err
+	}
+
+	result = make([]*schema.Message, 0, len(p.Templates))
+	for _, tpl := range p.Templates {
+		msgs, err := tpl.Format(ctx, vs, formatType)
+		if err != nil {
+			return nil, fmt.Errorf("format prompt %s(version=%s) fail: %w", c.name, p.Version, err)
+		}
+		result = append(result, msgs...)
+	}
+
+	callbacks.OnEnd(ctx, &prompt.CallbackOutput{
+		Result:    result,
+		Templates: p.Templates,
+		Extra:     map[string]any{ExtraKeyPromptName: c.name, ExtraKeyPromptVersion: p.Version},
+	})
+
+	return result, nil
+}
+
+func (c *chatTemplate) GetType() string {
+	return typ
+}
+
+func (c *chatTemplate) IsCallbacksEnabled() bool {
+	return true
+}
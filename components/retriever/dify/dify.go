@@ -32,6 +32,7 @@ const (
 	origDocIDKey   = "orig_doc_id"
 	origDocNameKey = "orig_doc_name"
 	keywordsKey    = "keywords"
+	positionKey    = "position"
 )
 
 type RetrievalModel struct {
@@ -83,6 +84,42 @@ func (x *RetrievalModel) copy() *RetrievalModel {
 	}
 }
 
+// merge 以 o 中已设置的字段覆盖 x，返回新的 RetrievalModel
+func (x *RetrievalModel) merge(o *RetrievalModel) *RetrievalModel {
+	if o == nil {
+		return x.copy()
+	}
+	if x == nil {
+		return o.copy()
+	}
+	rm := x.copy()
+	if o.SearchMethod != "" {
+		rm.SearchMethod = o.SearchMethod
+	}
+	if o.RerankingEnable != nil {
+		rm.RerankingEnable = copyPtr(o.RerankingEnable)
+	}
+	if o.RerankingMode != nil {
+		rm.RerankingMode = copyPtr(o.RerankingMode)
+	}
+	if o.RerankingModel != nil {
+		rm.RerankingModel = o.RerankingModel.copy()
+	}
+	if o.Weights != nil {
+		rm.Weights = copyPtr(o.Weights)
+	}
+	if o.TopK != nil {
+		rm.TopK = copyPtr(o.TopK)
+	}
+	if o.ScoreThresholdEnabled != nil {
+		rm.ScoreThresholdEnabled = copyPtr(o.ScoreThresholdEnabled)
+	}
+	if o.ScoreThreshold != nil {
+		rm.ScoreThreshold = copyPtr(o.ScoreThreshold)
+	}
+	return rm
+}
+
 // request Body
 type request struct {
 	Query          string          `json:"query"`
@@ -143,22 +180,33 @@ func (r *Retriever) getAuth() string {
 	return fmt.Sprintf("Bearer %s", r.config.APIKey)
 }
 
-func (r *Retriever) getRequest(query string, option *retriever.Options) *request {
-	// 避免污染原始数据，这里必须copy一次
-	rm := r.config.RetrievalModel.copy()
+func (r *Retriever) getRequest(query string, option *retriever.Options, implOption *implOptions) (*request, error) {
+	// 避免污染原始数据，merge 会 copy 一次
+	rm := r.config.RetrievalModel.merge(implOption.RetrievalModel)
 	if rm != nil {
+		if rm.SearchMethod == "" {
+			return nil, fmt.Errorf("if retrieval_model is set, search_method is required")
+		}
 		// options 配置优先
 		rm.TopK = option.TopK
 		rm.ScoreThreshold = option.ScoreThreshold
+		// 设置了分数阈值但未显式开关时，默认开启服务端阈值过滤
+		if rm.ScoreThreshold != nil && rm.ScoreThresholdEnabled == nil {
+			rm.ScoreThresholdEnabled = ptrOf(true)
+		}
 	}
 	return &request{
 		Query:          query,
 		RetrievalModel: rm,
-	}
+	}, nil
 }
 
-func (r *Retriever) doPost(ctx context.Context, query string, option *retriever.Options) (res *successResponse, err error) {
-	reqData, err := sonic.MarshalString(r.getRequest(query, option))
+func (r *Retriever) doPost(ctx context.Context, query string, option *retriever.Options, implOption *implOptions) (res *successResponse, err error) {
+	reqBody, err := r.getRequest(query, option, implOption)
+	if err != nil {
+		return nil, err
+	}
+	reqData, err := sonic.MarshalString(reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("error marshaling data: %w", err)
 	}
@@ -207,6 +255,7 @@ func (x *Record) toDoc() *schema.Document {
 	doc.WithScore(x.Score)
 	setOrgDocID(doc, x.Segment.DocumentID)
 	setKeywords(doc, x.Segment.Keywords)
+	setPosition(doc, x.Segment.Position)
 	if x.Segment.Document != nil {
 		setOrgDocName(doc, x.Segment.Document.Name)
 	}
@@ -234,6 +283,13 @@ func setKeywords(doc *schema.Document, keywords []string) {
 	doc.MetaData[keywordsKey] = keywords
 }
 
+func setPosition(doc *schema.Document, position int) {
+	if doc == nil {
+		return
+	}
+	doc.MetaData[positionKey] = position
+}
+
 func GetOrgDocID(doc *schema.Document) string {
 	if doc == nil {
 		return ""
@@ -263,3 +319,14 @@ func GetKeywords(doc *schema.Document) []string {
 	}
 	return nil
 }
+
+// GetPosition 返回分段在原始文档中的位置，从 1 开始
+func GetPosition(doc *schema.Document) int {
+	if doc == nil {
+		return 0
+	}
+	if v, ok := doc.MetaData[positionKey]; ok {
+		return v.(int)
+	}
+	return 0
+}
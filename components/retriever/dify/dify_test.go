@@ -32,6 +32,7 @@ func TestRecord_ToDoc(t *testing.T) {
 					ID:         "1",
 					Content:    "test content",
 					DocumentID: "doc1",
+					Position:   3,
 					Document: &Document{
 						ID:             "1",
 						DataSourceType: "markdown",
@@ -53,6 +54,8 @@ func TestRecord_ToDoc(t *testing.T) {
 			convey.So(result.ID, convey.ShouldEqual, expected.ID)
 			convey.So(result.Content, convey.ShouldEqual, expected.Content)
 			convey.So(result.MetaData[origDocIDKey], convey.ShouldEqual, expected.MetaData[origDocIDKey])
+			convey.So(GetOrgDocName(result), convey.ShouldEqual, "test.md")
+			convey.So(GetPosition(result), convey.ShouldEqual, 3)
 		})
 
 		PatchConvey("When record is nil", func() {
@@ -104,6 +107,7 @@ func TestMetadataFunctions(t *testing.T) {
 			convey.So(GetOrgDocID(nilDoc), convey.ShouldEqual, "")
 			convey.So(GetOrgDocName(nilDoc), convey.ShouldEqual, "")
 			convey.So(GetKeywords(nilDoc), convey.ShouldBeNil)
+			convey.So(GetPosition(nilDoc), convey.ShouldEqual, 0)
 		})
 	})
 }
@@ -177,3 +181,51 @@ func TestRetrievalModel_Copy(t *testing.T) {
 		})
 	})
 }
+
+func TestRetrievalModel_Merge(t *testing.T) {
+	PatchConvey("Test RetrievalModel.merge", t, func() {
+		base := &RetrievalModel{
+			SearchMethod:    SearchMethodSemantic,
+			RerankingEnable: ptrOf(false),
+			TopK:            ptrOf(5),
+			Weights:         ptrOf(0.3),
+		}
+
+		PatchConvey("When both are nil", func() {
+			var model *RetrievalModel
+			convey.So(model.merge(nil), convey.ShouldBeNil)
+		})
+
+		PatchConvey("When override is nil", func() {
+			result := base.merge(nil)
+			convey.So(result, convey.ShouldResemble, base)
+			convey.So(result, convey.ShouldNotPointTo, base)
+		})
+
+		PatchConvey("When base is nil", func() {
+			var model *RetrievalModel
+			result := model.merge(&RetrievalModel{SearchMethod: SearchMethodHybrid})
+			convey.So(result.SearchMethod, convey.ShouldEqual, SearchMethodHybrid)
+		})
+
+		PatchConvey("When override sets some fields", func() {
+			result := base.merge(&RetrievalModel{
+				SearchMethod:    SearchMethodHybrid,
+				RerankingEnable: ptrOf(true),
+				RerankingModel: &RerankingModel{
+					RerankingProviderName: "cohere",
+					RerankingModelName:    "rerank-v3.5",
+				},
+				ScoreThreshold: ptrOf(0.5),
+			})
+			convey.So(result.SearchMethod, convey.ShouldEqual, SearchMethodHybrid)
+			convey.So(*result.RerankingEnable, convey.ShouldBeTrue)
+			convey.So(result.RerankingModel.RerankingModelName, convey.ShouldEqual, "rerank-v3.5")
+			convey.So(*result.ScoreThreshold, convey.ShouldEqual, 0.5)
+			convey.So(*result.TopK, convey.ShouldEqual, 5)
+			convey.So(*result.Weights, convey.ShouldEqual, 0.3)
+			convey.So(*base.RerankingEnable, convey.ShouldBeFalse)
+			convey.So(base.RerankingModel, convey.ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package dify
+
+import (
+	"github.com/cloudwego/eino/components/retriever"
+)
+
+type implOptions struct {
+	RetrievalModel *RetrievalModel
+}
+
+func (o *implOptions) retrievalModel() *RetrievalModel {
+	if o.RetrievalModel == nil {
+		o.RetrievalModel = &RetrievalModel{}
+	}
+	return o.RetrievalModel
+}
+
+// WithRetrievalModel 设置本次检索的 retrieval_model，已设置的字段覆盖 RetrieverConfig.RetrievalModel 中的对应字段
+// TopK 与 ScoreThreshold 仍以 retriever.WithTopK、retriever.WithScoreThreshold 为准
+func WithRetrievalModel(rm *RetrievalModel) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *implOptions) {
+		o.RetrievalModel = rm.copy()
+	})
+}
+
+// WithSearchMethod 设置本次检索的检索方式
+func WithSearchMethod(method SearchMethod) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *implOptions) {
+		o.retrievalModel().SearchMethod = method
+	})
+}
+
+// WithRerankingModel 设置本次检索使用的 Rerank 模型，并开启 Rerank
+func WithRerankingModel(providerName, modelName string) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *implOptions) {
+		rm := o.retrievalModel()
+		rm.RerankingEnable = ptrOf(true)
+		rm.RerankingModel = &RerankingModel{
+			RerankingProviderName: providerName,
+			RerankingModelName:    modelName,
+		}
+	})
+}
+
+// WithRerankingEnable 设置本次检索是否开启 Rerank
+func WithRerankingEnable(enable bool) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *implOptions) {
+		o.retrievalModel().RerankingEnable = ptrOf(enable)
+	})
+}
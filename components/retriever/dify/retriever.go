@@ -84,6 +84,7 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		baseOptions.ScoreThreshold = r.config.RetrievalModel.ScoreThreshold
 	}
 	options := retriever.GetCommonOptions(baseOptions, opts...)
+	implOption := retriever.GetImplSpecificOptions(&implOptions{}, opts...)
 
 	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
 	// 开始检索回调
@@ -100,7 +101,7 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 	}()
 
 	// 发送检索请求
-	result, err := r.doPost(ctx, query, options)
+	result, err := r.doPost(ctx, query, options, implOption)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve documents: %w", err)
 	}
@@ -172,6 +172,85 @@ func TestRetrieve(t *testing.T) {
 	})
 }
 
+func TestRetrieveWithRetrievalModelOptions(t *testing.T) {
+	PatchConvey("test Retrieve with retrieval model options", t, func() {
+		ctx := context.Background()
+		r := &Retriever{
+			config: &RetrieverConfig{
+				APIKey:    "test",
+				Endpoint:  "https://api.dify.ai/v1",
+				DatasetID: "test",
+			},
+			client: &http.Client{},
+		}
+
+		var reqBody *request
+		Mock(GetMethod(r.client, "Do")).To(func(req *http.Request) (*http.Response, error) {
+			reqBody = &request{}
+			body, _ := io.ReadAll(req.Body)
+			_ = json.Unmarshal(body, reqBody)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body: io.NopCloser(strings.NewReader(`{"query":{"content":"test query"},"records":[
+					{"score":0.9,"segment":{"id":"1","position":2,"content":"c1","document_id":"d1","document":{"id":"d1","name":"a.md"}}}
+				]}`)),
+			}, nil
+		}).Build()
+
+		PatchConvey("test without retrieval model", func() {
+			docs, err := r.Retrieve(ctx, "test query")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(reqBody.RetrievalModel, convey.ShouldBeNil)
+			convey.So(GetOrgDocName(docs[0]), convey.ShouldEqual, "a.md")
+			convey.So(GetPosition(docs[0]), convey.ShouldEqual, 2)
+		})
+
+		PatchConvey("test per call options", func() {
+			_, err := r.Retrieve(ctx, "test query",
+				WithSearchMethod(SearchMethodHybrid),
+				WithRerankingModel("cohere", "rerank-v3.5"),
+				retriever.WithTopK(3),
+				retriever.WithScoreThreshold(0.5),
+			)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(reqBody.RetrievalModel.SearchMethod, convey.ShouldEqual, SearchMethodHybrid)
+			convey.So(*reqBody.RetrievalModel.RerankingEnable, convey.ShouldBeTrue)
+			convey.So(reqBody.RetrievalModel.RerankingModel, convey.ShouldResemble, &RerankingModel{
+				RerankingProviderName: "cohere",
+				RerankingModelName:    "rerank-v3.5",
+			})
+			convey.So(*reqBody.RetrievalModel.TopK, convey.ShouldEqual, 3)
+			convey.So(*reqBody.RetrievalModel.ScoreThreshold, convey.ShouldEqual, 0.5)
+			convey.So(*reqBody.RetrievalModel.ScoreThresholdEnabled, convey.ShouldBeTrue)
+		})
+
+		PatchConvey("test per call options override config", func() {
+			r.config.RetrievalModel = &RetrievalModel{
+				SearchMethod:          SearchMethodSemantic,
+				TopK:                  ptrOf(10),
+				ScoreThresholdEnabled: ptrOf(false),
+				ScoreThreshold:        ptrOf(0.2),
+			}
+			_, err := r.Retrieve(ctx, "test query", WithRetrievalModel(&RetrievalModel{
+				SearchMethod:    SearchMethodFullText,
+				RerankingEnable: ptrOf(false),
+			}))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(reqBody.RetrievalModel.SearchMethod, convey.ShouldEqual, SearchMethodFullText)
+			convey.So(*reqBody.RetrievalModel.RerankingEnable, convey.ShouldBeFalse)
+			convey.So(*reqBody.RetrievalModel.TopK, convey.ShouldEqual, 10)
+			convey.So(*reqBody.RetrievalModel.ScoreThresholdEnabled, convey.ShouldBeFalse)
+			convey.So(r.config.RetrievalModel.SearchMethod, convey.ShouldEqual, SearchMethodSemantic)
+		})
+
+		PatchConvey("test missing search method", func() {
+			_, err := r.Retrieve(ctx, "test query", WithRerankingEnable(true))
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "search_method is required")
+		})
+	})
+}
+
 func TestNewRetrieverWithRetrievalModel(t *testing.T) {
 	PatchConvey("test NewRetriever with retrieval model", t, func() {
 		ctx := context.Background()
@@ -16,6 +16,8 @@
 
 package es8
 
+import "github.com/cloudwego/eino-ext/components/retriever/es8/internal/vector"
+
 const typ = "ElasticSearch8"
 
 const (
@@ -25,3 +27,15 @@ const (
 func GetType() string {
 	return typ
 }
+
+// ScoreMetric is how the scores of a search mode are computed, see RetrieverConfig.ScoreMetric.
+type ScoreMetric = vector.Metric
+
+const (
+	// ScoreMetricUnit is a score already in [0,1], eg. knn and the dotProduct / l1norm / l2norm scripts.
+	ScoreMetricUnit ScoreMetric = vector.MetricUnit
+	// ScoreMetricShiftedCosine is the cosine similarity shifted by 1 into [0,2], eg. the cosineSimilarity script.
+	ScoreMetricShiftedCosine ScoreMetric = vector.MetricShiftedCosine
+	// ScoreMetricRelevance is a non-negative unbounded relevance score, eg. text and sparse vector queries.
+	ScoreMetricRelevance ScoreMetric = vector.MetricRelevance
+)
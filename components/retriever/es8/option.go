@@ -26,6 +26,9 @@ import (
 type ImplOptions struct {
 	Filters      []types.Query      `json:"filters,omitempty"`
 	SparseVector map[string]float32 `json:"sparse_vector,omitempty"`
+
+	NormalizeScore *bool    `json:"normalize_score,omitempty"`
+	MinScore       *float64 `json:"min_score,omitempty"`
 }
 
 // WithFilters set filters for retrieve query.
@@ -45,3 +48,17 @@ func WithSparseVector(sparse map[string]float32) retriever.Option {
 		o.SparseVector = sparse
 	})
 }
+
+// WithNormalizeScore overrides RetrieverConfig.NormalizeScore for this retrieve.
+func WithNormalizeScore(normalize bool) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *ImplOptions) {
+		o.NormalizeScore = &normalize
+	})
+}
+
+// WithMinScore overrides RetrieverConfig.MinScore for this retrieve.
+func WithMinScore(minScore float64) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *ImplOptions) {
+		o.MinScore = &minScore
+	})
+}
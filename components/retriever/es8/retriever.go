@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/cloudwego/eino-ext/components/retriever/es8/internal/vector"
 	"github.com/cloudwego/eino/components"
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
@@ -31,6 +32,8 @@ import (
 	"github.com/cloudwego/eino/schema"
 )
 
+//go:generate sh ../../../libs/acl/bundle.sh vector internal/vector
+
 type RetrieverConfig struct {
 	Client *elasticsearch.Client `json:"client"`
 
@@ -52,6 +55,20 @@ type RetrieverConfig struct {
 	ResultParser func(ctx context.Context, hit types.Hit) (doc *schema.Document, err error)
 	// Embedding vectorization method, must provide when SearchMode needed
 	Embedding embedding.Embedder
+
+	// NormalizeScore converts the scores of hits to similarities in [0,1] by ScoreMetric before they are set to documents,
+	// so that they can be compared with the scores of other retrievers.
+	// Default is false
+	NormalizeScore bool `json:"normalize_score"`
+	// ScoreMetric how the scores of SearchMode are computed, used by NormalizeScore:
+	// knn of SearchModeApproximate and the dotProduct / l1norm / l2norm scripts of SearchModeDenseVectorSimilarity are ScoreMetricUnit,
+	// the cosineSimilarity script of SearchModeDenseVectorSimilarity is ScoreMetricShiftedCosine,
+	// text and sparse vector queries are ScoreMetricRelevance.
+	// Default is ScoreMetricRelevance
+	ScoreMetric ScoreMetric `json:"score_metric"`
+	// MinScore drops the hits whose score, normalized if NormalizeScore, is lower than it on client side.
+	// Unlike ScoreThreshold, which is sent as min_score of the request, it applies to the normalized scores.
+	MinScore *float64 `json:"min_score"`
 }
 
 type SearchMode interface {
@@ -82,6 +99,11 @@ func NewRetriever(_ context.Context, conf *RetrieverConfig) (*Retriever, error)
 	if conf.Client == nil {
 		return nil, fmt.Errorf("[NewRetriever] es client not provided")
 	}
+
+	if conf.ScoreMetric == "" {
+		conf.ScoreMetric = ScoreMetricRelevance
+	}
+
 	return &Retriever{
 		client: conf.Client,
 		config: conf,
@@ -95,6 +117,10 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		ScoreThreshold: r.config.ScoreThreshold,
 		Embedding:      r.config.Embedding,
 	}, opts...)
+	io := retriever.GetImplSpecificOptions(&ImplOptions{
+		NormalizeScore: &r.config.NormalizeScore,
+		MinScore:       r.config.MinScore,
+	}, opts...)
 
 	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
 	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
@@ -121,7 +147,7 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		return nil, err
 	}
 
-	docs, err = r.parseSearchResult(ctx, resp)
+	docs, err = r.parseSearchResult(ctx, resp, io)
 	if err != nil {
 		return nil, err
 	}
@@ -131,7 +157,7 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 	return docs, nil
 }
 
-func (r *Retriever) parseSearchResult(ctx context.Context, resp *search.Response) (docs []*schema.Document, err error) {
+func (r *Retriever) parseSearchResult(ctx context.Context, resp *search.Response, io *ImplOptions) (docs []*schema.Document, err error) {
 	docs = make([]*schema.Document, 0, len(resp.Hits.Hits))
 
 	for _, hit := range resp.Hits.Hits {
@@ -140,6 +166,18 @@ func (r *Retriever) parseSearchResult(ctx context.Context, resp *search.Response
 			return nil, err
 		}
 
+		score := doc.Score()
+		if hit.Score_ != nil {
+			score = float64(*hit.Score_)
+		}
+		if io.NormalizeScore != nil && *io.NormalizeScore {
+			score = vector.NormalizeScore(r.config.ScoreMetric, score)
+			doc.WithScore(score)
+		}
+		if io.MinScore != nil && score < *io.MinScore {
+			continue
+		}
+
 		docs = append(docs, doc)
 	}
 
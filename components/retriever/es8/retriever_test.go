@@ -92,6 +92,53 @@ func TestNewRetriever(t *testing.T) {
 		assert.Equal(t, "i'm fine, thank you", docs[0].Content)
 	})
 
+	t.Run("normalize_score", func(t *testing.T) {
+		minScore := 0.5
+		r, err := NewRetriever(ctx, &RetrieverConfig{
+			Client:         &elasticsearch.Client{},
+			Index:          "eino_ut",
+			NormalizeScore: true,
+			ScoreMetric:    ScoreMetricShiftedCosine,
+			MinScore:       &minScore,
+			ResultParser: func(ctx context.Context, hit types.Hit) (doc *schema.Document, err error) {
+				return &schema.Document{ID: *hit.Id_}, nil
+			},
+			SearchMode: &mockSearchMode{},
+		})
+		assert.NoError(t, err)
+
+		mockSearch := search.NewSearchFunc(r.client)()
+
+		defer mockey.Mock(mockey.GetMethod(mockSearch, "Index")).
+			Return(mockSearch).Build().Patch().UnPatch()
+
+		defer mockey.Mock(mockey.GetMethod(mockSearch, "Request")).
+			Return(mockSearch).Build().Patch().UnPatch()
+
+		defer mockey.Mock(mockey.GetMethod(mockSearch, "Do")).Return(&search.Response{
+			Hits: types.HitsMetadata{
+				Hits: []types.Hit{
+					{Id_: of("1"), Score_: of(types.Float64(1.6))},
+					{Id_: of("2"), Score_: of(types.Float64(0.4))},
+				},
+			},
+		}, nil).Build().Patch().UnPatch()
+
+		docs, err := r.Retrieve(ctx, "how are you")
+		assert.NoError(t, err)
+		assert.Len(t, docs, 1)
+		assert.Equal(t, "1", docs[0].ID)
+		assert.InDelta(t, 0.8, docs[0].Score(), 1e-9)
+
+		docs, err = r.Retrieve(ctx, "how are you", WithNormalizeScore(false), WithMinScore(0.3))
+		assert.NoError(t, err)
+		assert.Len(t, docs, 2)
+		assert.Equal(t, float64(0), docs[1].Score())
+	})
+}
+
+func of[T any](v T) *T {
+	return &v
 }
 
 type mockSearchMode struct{}
@@ -0,0 +1,170 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package llamacloud
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/bytedance/sonic"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	fileNameKey     = "file_name"
+	fileIDKey       = "file_id"
+	pageLabelKey    = "page_label"
+	startCharIdxKey = "start_char_idx"
+	endCharIdxKey   = "end_char_idx"
+)
+
+// MetadataFilters are the metadata filters of LlamaCloud retrieval.
+type MetadataFilters struct {
+	Filters []*MetadataFilter `json:"filters"`
+	// Condition combines filters, "and" or "or".
+	Condition string `json:"condition,omitempty"`
+}
+
+// MetadataFilter filters nodes by a metadata key.
+type MetadataFilter struct {
+	Key   string `json:"key"`
+	Value any    `json:"value"`
+	// Operator compares the metadata value with Value, e.g. "==", "!=", ">", "<", "in", "nin", "contains".
+	// Default: "=="
+	Operator string `json:"operator,omitempty"`
+}
+
+type retrieveRequest struct {
+	Query                string           `json:"query"`
+	DenseSimilarityTopK  int              `json:"dense_similarity_top_k,omitempty"`
+	SparseSimilarityTopK int              `json:"sparse_similarity_top_k,omitempty"`
+	EnableReranking      *bool            `json:"enable_reranking,omitempty"`
+	RerankTopN           int              `json:"rerank_top_n,omitempty"`
+	Alpha                *float64         `json:"alpha,omitempty"`
+	SearchFilters        *MetadataFilters `json:"search_filters,omitempty"`
+	FilesTopK            int              `json:"files_top_k,omitempty"`
+	RetrievalMode        RetrievalMode    `json:"retrieval_mode,omitempty"`
+}
+
+type retrieveResponse struct {
+	PipelineID     string           `json:"pipeline_id"`
+	RetrievalNodes []*NodeWithScore `json:"retrieval_nodes"`
+}
+
+type errorResponse struct {
+	Detail any `json:"detail"`
+}
+
+// NodeWithScore is a node returned by the LlamaCloud retrieve api.
+type NodeWithScore struct {
+	Node  *TextNode `json:"node"`
+	Score float64   `json:"score"`
+}
+
+// TextNode is the content of a retrieved node.
+type TextNode struct {
+	ID           string         `json:"id_"`
+	Text         string         `json:"text"`
+	Metadata     map[string]any `json:"metadata"`
+	StartCharIdx *int           `json:"start_char_idx"`
+	EndCharIdx   *int           `json:"end_char_idx"`
+}
+
+func (r *Retriever) doPost(ctx context.Context, body *retrieveRequest) (*retrieveResponse, error) {
+	reqData, err := sonic.MarshalString(body)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling data: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.getURL(), strings.NewReader(reqData))
+	if err != nil {
+		return nil, fmt.Errorf("create request failed: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+r.config.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("do request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response failed: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		errResp := &errorResponse{}
+		if err = sonic.Unmarshal(respBody, errResp); err == nil && errResp.Detail != nil {
+			return nil, fmt.Errorf("request failed with status code: %d, detail: %v", resp.StatusCode, errResp.Detail)
+		}
+		return nil, fmt.Errorf("request failed with status code: %d", resp.StatusCode)
+	}
+
+	res := &retrieveResponse{}
+	if err = sonic.Unmarshal(respBody, res); err != nil {
+		return nil, fmt.Errorf("decode response failed: %w", err)
+	}
+
+	return res, nil
+}
+
+func (n *NodeWithScore) toDoc() *schema.Document {
+	doc := &schema.Document{
+		ID:       n.Node.ID,
+		Content:  n.Node.Text,
+		MetaData: make(map[string]any, len(n.Node.Metadata)+3),
+	}
+	for k, v := range n.Node.Metadata {
+		doc.MetaData[k] = v
+	}
+	if n.Node.StartCharIdx != nil {
+		doc.MetaData[startCharIdxKey] = *n.Node.StartCharIdx
+	}
+	if n.Node.EndCharIdx != nil {
+		doc.MetaData[endCharIdxKey] = *n.Node.EndCharIdx
+	}
+	doc.WithScore(n.Score)
+
+	return doc
+}
+
+// GetFileName returns the name of the file the node is parsed from.
+func GetFileName(doc *schema.Document) string {
+	return getString(doc, fileNameKey)
+}
+
+// GetFileID returns the id of the LlamaCloud file the node is parsed from.
+func GetFileID(doc *schema.Document) string {
+	return getString(doc, fileIDKey)
+}
+
+// GetPageLabel returns the page label of the node, only set for paged files such as pdf.
+func GetPageLabel(doc *schema.Document) string {
+	return getString(doc, pageLabelKey)
+}
+
+func getString(doc *schema.Document, key string) string {
+	if doc == nil {
+		return ""
+	}
+	v, _ := doc.MetaData[key].(string)
+	return v
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package llamacloud
+
+import (
+	"github.com/cloudwego/eino/components/retriever"
+)
+
+type implOptions struct {
+	Filters         *MetadataFilters
+	RetrievalMode   RetrievalMode
+	EnableReranking *bool
+	RerankTopN      int
+	Alpha           *float64
+}
+
+// WithFilters overrides the metadata filters applied to retrieval.
+func WithFilters(filters *MetadataFilters) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *implOptions) {
+		o.Filters = filters
+	})
+}
+
+// WithRetrievalMode overrides the retrieval mode.
+func WithRetrievalMode(mode RetrievalMode) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *implOptions) {
+		o.RetrievalMode = mode
+	})
+}
+
+// WithReranking enables reranking and keeps topN chunks after reranking, topN <= 0 keeps the server side default.
+func WithReranking(topN int) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *implOptions) {
+		enable := true
+		o.EnableReranking = &enable
+		if topN > 0 {
+			o.RerankTopN = topN
+		}
+	})
+}
+
+// WithAlpha overrides the weight of dense retrieval in hybrid retrieval.
+func WithAlpha(alpha float64) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *implOptions) {
+		o.Alpha = &alpha
+	})
+}
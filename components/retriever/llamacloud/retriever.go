@@ -0,0 +1,204 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package llamacloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	typ = "LlamaCloud"
+
+	defaultBaseURL = "https://api.cloud.llamaindex.ai"
+)
+
+// RetrievalMode decides how LlamaCloud retrieves from the index.
+type RetrievalMode string
+
+const (
+	// RetrievalModeChunks retrieves the most relevant chunks.
+	RetrievalModeChunks RetrievalMode = "chunks"
+	// RetrievalModeFilesViaMetadata retrieves whole files selected by metadata.
+	RetrievalModeFilesViaMetadata RetrievalMode = "files_via_metadata"
+	// RetrievalModeFilesViaContent retrieves whole files selected by content.
+	RetrievalModeFilesViaContent RetrievalMode = "files_via_content"
+	// RetrievalModeAutoRouted lets LlamaCloud pick one of the modes above.
+	RetrievalModeAutoRouted RetrievalMode = "auto_routed"
+)
+
+// RetrieverConfig is the config of the LlamaCloud retriever.
+type RetrieverConfig struct {
+	// APIKey is the LlamaCloud api key, used as the bearer token.
+	// Required.
+	APIKey string
+	// BaseURL is the address of the LlamaCloud api.
+	// Optional. Default: https://api.cloud.llamaindex.ai
+	BaseURL string
+	// PipelineID is the id of the pipeline backing the managed index.
+	// Required.
+	PipelineID string
+	// ProjectID is the project the pipeline belongs to.
+	// Optional.
+	ProjectID string
+	// TopK is the number of chunks returned by dense retrieval, sent as dense_similarity_top_k.
+	// Optional. Default: server side default
+	TopK int
+	// SparseTopK is the number of chunks returned by sparse retrieval.
+	// Optional. Default: server side default
+	SparseTopK int
+	// Alpha is the weight of dense retrieval in hybrid retrieval, between 0 (sparse only) and 1 (dense only).
+	// Optional. Default: server side default
+	Alpha *float64
+	// EnableReranking enables reranking of retrieved chunks.
+	// Optional. Default: server side default
+	EnableReranking *bool
+	// RerankTopN is the number of chunks kept after reranking.
+	// Optional. Default: server side default
+	RerankTopN int
+	// RetrievalMode decides whether chunks or files are retrieved.
+	// Optional. Default: RetrievalModeChunks
+	RetrievalMode RetrievalMode
+	// FilesTopK is the number of files retrieved in file retrieval modes.
+	// Optional. Default: server side default
+	FilesTopK int
+	// Filters are the metadata filters applied to retrieval.
+	// Optional.
+	Filters *MetadataFilters
+	// Timeout is the http request timeout, not used if HTTPClient is set.
+	// Optional.
+	Timeout time.Duration
+	// HTTPClient is the http client used to send requests.
+	// Optional. Default: &http.Client{Timeout: Timeout}
+	HTTPClient *http.Client
+}
+
+type Retriever struct {
+	config *RetrieverConfig
+	client *http.Client
+}
+
+func NewRetriever(_ context.Context, config *RetrieverConfig) (*Retriever, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+	if config.APIKey == "" {
+		return nil, fmt.Errorf("api_key is required")
+	}
+	if config.PipelineID == "" {
+		return nil, fmt.Errorf("pipeline_id is required")
+	}
+
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: config.Timeout}
+	}
+
+	return &Retriever{
+		config: config,
+		client: httpClient,
+	}, nil
+}
+
+// Retrieve retrieves nodes relevant to query from the LlamaCloud managed index.
+// LlamaCloud has no server side score threshold, so ScoreThreshold is applied on returned nodes.
+func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) (docs []*schema.Document, err error) {
+	baseOptions := &retriever.Options{}
+	if r.config.TopK > 0 {
+		baseOptions.TopK = ptrOf(r.config.TopK)
+	}
+	options := retriever.GetCommonOptions(baseOptions, opts...)
+	implOption := retriever.GetImplSpecificOptions(&implOptions{
+		Filters:         r.config.Filters,
+		RetrievalMode:   r.config.RetrievalMode,
+		EnableReranking: r.config.EnableReranking,
+		RerankTopN:      r.config.RerankTopN,
+		Alpha:           r.config.Alpha,
+	}, opts...)
+
+	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
+	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
+		Query:          query,
+		TopK:           dereferenceOrZero(options.TopK),
+		ScoreThreshold: options.ScoreThreshold,
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	req := &retrieveRequest{
+		Query:                query,
+		DenseSimilarityTopK:  dereferenceOrZero(options.TopK),
+		SparseSimilarityTopK: r.config.SparseTopK,
+		EnableReranking:      implOption.EnableReranking,
+		RerankTopN:           implOption.RerankTopN,
+		Alpha:                implOption.Alpha,
+		SearchFilters:        implOption.Filters,
+		FilesTopK:            r.config.FilesTopK,
+		RetrievalMode:        implOption.RetrievalMode,
+	}
+
+	resp, err := r.doPost(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve documents: %w", err)
+	}
+
+	docs = make([]*schema.Document, 0, len(resp.RetrievalNodes))
+	for _, node := range resp.RetrievalNodes {
+		if node == nil || node.Node == nil {
+			continue
+		}
+		if options.ScoreThreshold != nil && node.Score < *options.ScoreThreshold {
+			continue
+		}
+		docs = append(docs, node.toDoc())
+	}
+
+	callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs})
+
+	return docs, nil
+}
+
+func (r *Retriever) getURL() string {
+	baseURL := r.config.BaseURL
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	u := strings.TrimRight(baseURL, "/") + "/api/v1/pipelines/" + url.PathEscape(r.config.PipelineID) + "/retrieve"
+	if r.config.ProjectID != "" {
+		u += "?" + url.Values{"project_id": {r.config.ProjectID}}.Encode()
+	}
+	return u
+}
+
+func (r *Retriever) GetType() string {
+	return typ
+}
+
+func (r *Retriever) IsCallbacksEnabled() bool {
+	return true
+}
@@ -0,0 +1,163 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package llamacloud
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/stretchr/testify/assert"
+)
+
+const retrieveResp = `{
+	"pipeline_id": "p1",
+	"retrieval_nodes": [
+		{"node": {"id_": "n1", "text": "eino is a framework", "metadata": {"file_name": "eino.pdf", "file_id": "f1", "page_label": "3"}, "start_char_idx": 10, "end_char_idx": 29}, "score": 0.82},
+		{"node": {"id_": "n2", "text": "graph", "metadata": {"file_name": "graph.md"}}, "score": 0.41},
+		{"node": null, "score": 0.9}
+	]
+}`
+
+func TestNewRetriever(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewRetriever(ctx, nil)
+	assert.ErrorContains(t, err, "config is required")
+
+	_, err = NewRetriever(ctx, &RetrieverConfig{PipelineID: "p1"})
+	assert.ErrorContains(t, err, "api_key is required")
+
+	_, err = NewRetriever(ctx, &RetrieverConfig{APIKey: "k"})
+	assert.ErrorContains(t, err, "pipeline_id is required")
+
+	r, err := NewRetriever(ctx, &RetrieverConfig{APIKey: "k", PipelineID: "p1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://api.cloud.llamaindex.ai/api/v1/pipelines/p1/retrieve", r.getURL())
+	assert.Equal(t, "LlamaCloud", r.GetType())
+	assert.True(t, r.IsCallbacksEnabled())
+}
+
+func TestRetrieve(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		reqBody  map[string]any
+		reqQuery string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/pipelines/p1/retrieve", r.URL.Path)
+		assert.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+		reqQuery = r.URL.RawQuery
+		body, _ := io.ReadAll(r.Body)
+		reqBody = map[string]any{}
+		assert.NoError(t, json.Unmarshal(body, &reqBody))
+		_, _ = w.Write([]byte(retrieveResp))
+	}))
+	defer srv.Close()
+
+	t.Run("success", func(t *testing.T) {
+		r, err := NewRetriever(ctx, &RetrieverConfig{
+			APIKey:     "test-key",
+			BaseURL:    srv.URL + "/",
+			PipelineID: "p1",
+			ProjectID:  "proj",
+			TopK:       4,
+			SparseTopK: 8,
+		})
+		assert.NoError(t, err)
+
+		docs, err := r.Retrieve(ctx, "what is eino")
+		assert.NoError(t, err)
+		assert.Len(t, docs, 2)
+
+		assert.Equal(t, "n1", docs[0].ID)
+		assert.Equal(t, "eino is a framework", docs[0].Content)
+		assert.Equal(t, 0.82, docs[0].Score())
+		assert.Equal(t, "eino.pdf", GetFileName(docs[0]))
+		assert.Equal(t, "f1", GetFileID(docs[0]))
+		assert.Equal(t, "3", GetPageLabel(docs[0]))
+		assert.Equal(t, 10, docs[0].MetaData[startCharIdxKey])
+		assert.Equal(t, 29, docs[0].MetaData[endCharIdxKey])
+		assert.Equal(t, "", GetPageLabel(docs[1]))
+
+		assert.Equal(t, "project_id=proj", reqQuery)
+		assert.Equal(t, map[string]any{
+			"query":                   "what is eino",
+			"dense_similarity_top_k":  float64(4),
+			"sparse_similarity_top_k": float64(8),
+		}, reqBody)
+	})
+
+	t.Run("options", func(t *testing.T) {
+		r, err := NewRetriever(ctx, &RetrieverConfig{
+			APIKey:        "test-key",
+			BaseURL:       srv.URL,
+			PipelineID:    "p1",
+			RetrievalMode: RetrievalModeChunks,
+		})
+		assert.NoError(t, err)
+
+		docs, err := r.Retrieve(ctx, "what is eino",
+			retriever.WithTopK(10),
+			retriever.WithScoreThreshold(0.5),
+			WithReranking(3),
+			WithAlpha(0.7),
+			WithRetrievalMode(RetrievalModeAutoRouted),
+			WithFilters(&MetadataFilters{
+				Filters:   []*MetadataFilter{{Key: "file_name", Value: "eino.pdf"}},
+				Condition: "and",
+			}),
+		)
+		assert.NoError(t, err)
+		assert.Len(t, docs, 1)
+		assert.Equal(t, "n1", docs[0].ID)
+
+		assert.Equal(t, "", reqQuery)
+		assert.Equal(t, map[string]any{
+			"query":                  "what is eino",
+			"dense_similarity_top_k": float64(10),
+			"enable_reranking":       true,
+			"rerank_top_n":           float64(3),
+			"alpha":                  0.7,
+			"retrieval_mode":         "auto_routed",
+			"search_filters": map[string]any{
+				"filters":   []any{map[string]any{"key": "file_name", "value": "eino.pdf"}},
+				"condition": "and",
+			},
+		}, reqBody)
+	})
+}
+
+func TestRetrieveError(t *testing.T) {
+	ctx := context.Background()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"detail":"Pipeline not found"}`))
+	}))
+	defer srv.Close()
+
+	r, err := NewRetriever(ctx, &RetrieverConfig{APIKey: "test-key", BaseURL: srv.URL, PipelineID: "p1"})
+	assert.NoError(t, err)
+
+	_, err = r.Retrieve(ctx, "what is eino")
+	assert.ErrorContains(t, err, "status code: 404, detail: Pipeline not found")
+}
@@ -2,7 +2,6 @@ module github.com/cloudwego/eino-ext/components/retriever/milvus
 
 go 1.23.0
 
-
 require (
 	github.com/bytedance/mockey v1.2.12
 	github.com/bytedance/sonic v1.13.2
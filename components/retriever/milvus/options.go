@@ -31,6 +31,14 @@ type ImplOptions struct {
 	// Optional, and the default value is nil
 	// It's means the milvus search extra search options, and refer to client.SearchQueryOptionFunc
 	SearchQueryOptFn func(option *client.SearchQueryOption)
+
+	// NormalizeScore overrides RetrieverConfig.NormalizeScore
+	// Optional, and the default value is RetrieverConfig.NormalizeScore
+	NormalizeScore *bool
+
+	// MinScore overrides RetrieverConfig.MinScore
+	// Optional, and the default value is RetrieverConfig.MinScore
+	MinScore *float64
 }
 
 func WithFilter(filter string) retriever.Option {
@@ -44,3 +52,15 @@ func WithSearchQueryOptFn(f func(option *client.SearchQueryOption)) retriever.Op
 		o.SearchQueryOptFn = f
 	})
 }
+
+func WithNormalizeScore(normalize bool) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *ImplOptions) {
+		o.NormalizeScore = &normalize
+	})
+}
+
+func WithMinScore(minScore float64) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *ImplOptions) {
+		o.MinScore = &minScore
+	})
+}
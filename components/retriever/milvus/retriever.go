@@ -70,6 +70,14 @@ type RetrieverConfig struct {
 	// Embedding is the embedding vectorization method for values needs to be embedded from schema.Document's content.
 	// Required
 	Embedding embedding.Embedder
+	
+	// NormalizeScore converts the scores of the search result to similarities in [0,1] by MetricType,
+	// so that they can be compared with the scores of other retrievers
+	// Optional, and the default value is false
+	NormalizeScore bool
+	// MinScore drops the documents whose score, normalized if NormalizeScore, is lower than it on client side
+	// Optional, and the default value is nil
+	MinScore *float64
 }
 
 type Retriever struct {
@@ -146,6 +154,8 @@ func NewRetriever(ctx context.Context, config *RetrieverConfig) (*Retriever, err
 			ScoreThreshold:    config.ScoreThreshold,
 			Sp:                config.Sp,
 			Embedding:         config.Embedding,
+			NormalizeScore:    config.NormalizeScore,
+			MinScore:          config.MinScore,
 		},
 	}, nil
 }
@@ -159,7 +169,10 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		Embedding:      r.config.Embedding,
 	}, opts...)
 	// get impl specific options
-	io := retriever.GetImplSpecificOptions(&ImplOptions{}, opts...)
+	io := retriever.GetImplSpecificOptions(&ImplOptions{
+		NormalizeScore: &r.config.NormalizeScore,
+		MinScore:       r.config.MinScore,
+	}, opts...)
 	
 	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
 	// callback info on start
@@ -241,7 +254,7 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		if err != nil {
 			return nil, fmt.Errorf("[milvus retriever] failed to convert search result to schema.Document: %w", err)
 		}
-		documents = append(documents, document...)
+		documents = append(documents, r.scoreDocuments(document, result.Scores, io)...)
 	}
 	
 	// callback info on end
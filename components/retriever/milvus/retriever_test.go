@@ -410,6 +410,27 @@ func TestRetriever_Retrieve(t *testing.T) {
 				convey.So(err, convey.ShouldBeNil)
 				convey.So(documents, convey.ShouldNotBeNil)
 			})
+
+			PatchConvey("test search results normalize score", func() {
+				minScore := 0.4
+				r, _ := NewRetriever(ctx, &RetrieverConfig{
+					Client:         mockClient,
+					NormalizeScore: true,
+					MinScore:       &minScore,
+					Embedding:      &mockEmbedding{sizeForCall: []int{1, 1}},
+				})
+				documents, err := r.Retrieve(ctx, "test")
+
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(len(documents), convey.ShouldEqual, 1)
+				convey.So(documents[0].ID, convey.ShouldEqual, "1")
+				convey.So(documents[0].Score(), convey.ShouldEqual, 0.5)
+
+				documents, err = r.Retrieve(ctx, "test", WithNormalizeScore(false), WithMinScore(0))
+				convey.So(err, convey.ShouldBeNil)
+				convey.So(len(documents), convey.ShouldEqual, 2)
+				convey.So(documents[1].Score(), convey.ShouldEqual, 2)
+			})
 		})
 	})
 }
@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/bytedance/sonic"
+	"github.com/cloudwego/eino-ext/components/retriever/milvus/internal/vector"
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components"
 	"github.com/cloudwego/eino/components/embedding"
@@ -34,6 +35,8 @@ import (
 	"github.com/milvus-io/milvus-sdk-go/v2/entity"
 )
 
+//go:generate sh ../../../libs/acl/bundle.sh vector internal/vector
+
 // defaultSearchParam returns the default search param
 func defaultSearchParam(score float64, dim float64) entity.SearchParam {
 	searchParam, _ := entity.NewIndexAUTOINDEXSearchParam(defaultAutoIndexLevel)
@@ -167,6 +170,45 @@ func loadCollection(ctx context.Context, conf *RetrieverConfig) error {
 	}
 }
 
+// scoreDocuments sets the scores of the search result to the documents, normalized by the metric type if required,
+// and drops the documents whose score is lower than the min score
+func (r *Retriever) scoreDocuments(docs []*schema.Document, scores []float32, io *ImplOptions) []*schema.Document {
+	if len(docs) != len(scores) {
+		return docs
+	}
+	normalize := io.NormalizeScore != nil && *io.NormalizeScore
+	result := docs[:0]
+	for i, doc := range docs {
+		score := float64(scores[i])
+		if normalize {
+			score = vector.NormalizeScore(scoreMetric(r.config.MetricType), score)
+		}
+		if io.MinScore != nil && score < *io.MinScore {
+			continue
+		}
+		result = append(result, doc.WithScore(score))
+	}
+	return result
+}
+
+// scoreMetric returns the score metric of the milvus metric type
+func scoreMetric(metricType entity.MetricType) vector.Metric {
+	switch metricType {
+	case entity.L2:
+		return vector.MetricL2
+	case entity.IP:
+		return vector.MetricInnerProduct
+	case entity.COSINE:
+		return vector.MetricCosine
+	case entity.HAMMING:
+		return vector.MetricHamming
+	case entity.JACCARD, entity.TANIMOTO:
+		return vector.MetricJaccard
+	default:
+		return vector.MetricUnit
+	}
+}
+
 // makeEmbeddingCtx makes the embedding context
 func (r *Retriever) makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder) context.Context {
 	runInfo := &callbacks.RunInfo{
@@ -0,0 +1,436 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package neo4j
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+const (
+	defaultEntityLabel   = "Entity"
+	defaultChunkLabel    = "Chunk"
+	defaultFullTextIndex = "entity_name"
+	defaultTopK          = 5
+	defaultMaxEntities   = 5
+	defaultDepth         = 1
+	maxDepth             = 3
+	defaultMaxTriples    = 50
+
+	// the relationships created by the neo4j indexer
+	relMentions  = "MENTIONS"
+	relRelatesTo = "RELATES_TO"
+)
+
+const (
+	// MetaKeyKind is the metadata key of the kind of the documents, KindTriples or KindPassage.
+	MetaKeyKind = "graph_kind"
+	// MetaKeyTriples is the metadata key of the []*Triple of the triples document.
+	MetaKeyTriples = "graph_triples"
+	// MetaKeyEntities is the metadata key of the names of the entities of the query mentioned by a passage.
+	MetaKeyEntities = "graph_entities"
+
+	KindTriples = "triples"
+	KindPassage = "passage"
+)
+
+var identifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type RetrieverConfig struct {
+	// Driver is the neo4j driver, safe for concurrent use.
+	// Required.
+	Driver neo4j.DriverWithContext
+	// Database is the name of the database.
+	// Optional. Default the default database of the server.
+	Database string
+
+	// ChatModel extracts the names of the entities of the query, which are searched in the full-text index.
+	// Optional. Default the query itself is searched in the full-text index.
+	ChatModel model.BaseChatModel
+
+	// EntityLabel, ChunkLabel and FullTextIndex must be the ones of the neo4j indexer.
+	// Optional. Default "Entity", "Chunk" and "entity_name".
+	EntityLabel   string
+	ChunkLabel    string
+	FullTextIndex string
+
+	// MaxEntities is the maximum number of entities matching the query, the starting points of the traversal.
+	// Optional. Default 5.
+	MaxEntities int
+	// Depth is the number of hops of the traversal from the entities of the query, between 1 and 3.
+	// Optional. Default 1.
+	Depth int
+	// MaxTriples is the maximum number of triples returned.
+	// Optional. Default 50.
+	MaxTriples int
+	// TopK is the number of passages returned, the passages mentioning the most entities of the query first.
+	// Optional. Default 5.
+	TopK int
+}
+
+// Entity is an entity of the knowledge graph matching the query.
+type Entity struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Type        string  `json:"type,omitempty"`
+	Description string  `json:"description,omitempty"`
+	Score       float64 `json:"score"`
+}
+
+// Triple is a relation of the knowledge graph, e.g. "Marie Curie" "discovered" "Radium".
+type Triple struct {
+	Source      string `json:"source"`
+	Relation    string `json:"relation"`
+	Target      string `json:"target"`
+	Description string `json:"description,omitempty"`
+}
+
+// String formats the triple for a prompt, e.g. "Marie Curie -[discovered]-> Radium".
+func (t *Triple) String() string {
+	s := fmt.Sprintf("%s -[%s]-> %s", t.Source, t.Relation, t.Target)
+	if t.Description != "" {
+		s += ": " + t.Description
+	}
+	return s
+}
+
+// Result is the knowledge about the query: the entities matching it, the relations around them,
+// and the passages mentioning them.
+type Result struct {
+	Entities []*Entity
+	Triples  []*Triple
+	Passages []*schema.Document
+}
+
+// Retriever answers the queries from the knowledge graph built by the neo4j indexer,
+// with the relations around the entities of the query and the passages mentioning them.
+type Retriever struct {
+	config   *RetrieverConfig
+	runQuery func(ctx context.Context, query string, params map[string]any) (*neo4j.EagerResult, error)
+}
+
+func NewRetriever(ctx context.Context, config *RetrieverConfig) (*Retriever, error) {
+	if config == nil {
+		return nil, fmt.Errorf("[NewRetriever] config not provided")
+	}
+	if config.Driver == nil {
+		return nil, fmt.Errorf("[NewRetriever] neo4j driver not provided")
+	}
+	if config.EntityLabel == "" {
+		config.EntityLabel = defaultEntityLabel
+	}
+	if config.ChunkLabel == "" {
+		config.ChunkLabel = defaultChunkLabel
+	}
+	if config.FullTextIndex == "" {
+		config.FullTextIndex = defaultFullTextIndex
+	}
+	for _, name := range []string{config.EntityLabel, config.ChunkLabel, config.FullTextIndex} {
+		if !identifierRegexp.MatchString(name) {
+			return nil, fmt.Errorf("[NewRetriever] invalid label or index name: %q", name)
+		}
+	}
+	if config.MaxEntities <= 0 {
+		config.MaxEntities = defaultMaxEntities
+	}
+	if config.Depth <= 0 {
+		config.Depth = defaultDepth
+	}
+	if config.Depth > maxDepth {
+		return nil, fmt.Errorf("[NewRetriever] depth must be at most %d", maxDepth)
+	}
+	if config.MaxTriples <= 0 {
+		config.MaxTriples = defaultMaxTriples
+	}
+	if config.TopK <= 0 {
+		config.TopK = defaultTopK
+	}
+
+	r := &Retriever{config: config}
+	r.runQuery = func(ctx context.Context, query string, params map[string]any) (*neo4j.EagerResult, error) {
+		return neo4j.ExecuteQuery(ctx, config.Driver, query, params, neo4j.EagerResultTransformer,
+			neo4j.ExecuteQueryWithDatabase(config.Database), neo4j.ExecuteQueryWithReadersRouting())
+	}
+	return r, nil
+}
+
+// Retrieve returns a document of the triples, with the KindTriples kind, if any, followed by the passages,
+// with the KindPassage kind and the fraction of the entities of the query they mention as score.
+func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) (docs []*schema.Document, err error) {
+	co := retriever.GetCommonOptions(&retriever.Options{TopK: &r.config.TopK}, opts...)
+
+	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
+	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
+		Query: query,
+		TopK:  *co.TopK,
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	result, err := r.Query(ctx, query, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	docs = make([]*schema.Document, 0, len(result.Passages)+1)
+	if len(result.Triples) > 0 {
+		lines := make([]string, 0, len(result.Triples))
+		for _, t := range result.Triples {
+			lines = append(lines, t.String())
+		}
+		docs = append(docs, &schema.Document{
+			ID:      "triples",
+			Content: strings.Join(lines, "\n"),
+			MetaData: map[string]any{
+				MetaKeyKind:    KindTriples,
+				MetaKeyTriples: result.Triples,
+			},
+		})
+	}
+	docs = append(docs, result.Passages...)
+
+	callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs})
+
+	return docs, nil
+}
+
+// Query finds the entities matching the query, the triples around them, and the passages mentioning them.
+func (r *Retriever) Query(ctx context.Context, query string, opts ...retriever.Option) (*Result, error) {
+	co := retriever.GetCommonOptions(&retriever.Options{TopK: &r.config.TopK}, opts...)
+	io := retriever.GetImplSpecificOptions(&implOptions{Depth: &r.config.Depth, MaxTriples: &r.config.MaxTriples}, opts...)
+	if *io.Depth < 1 || *io.Depth > maxDepth {
+		return nil, fmt.Errorf("[Query] depth must be between 1 and %d", maxDepth)
+	}
+
+	search, err := r.fullTextQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{}
+	if search == "" {
+		return result, nil
+	}
+	if result.Entities, err = r.findEntities(ctx, search); err != nil {
+		return nil, err
+	}
+	if len(result.Entities) == 0 {
+		return result, nil
+	}
+	ids := make([]any, 0, len(result.Entities))
+	for _, e := range result.Entities {
+		ids = append(ids, e.ID)
+	}
+	if result.Triples, err = r.findTriples(ctx, ids, *io.Depth, *io.MaxTriples); err != nil {
+		return nil, err
+	}
+	if result.Passages, err = r.findPassages(ctx, ids, *co.TopK); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+const entityPrompt = `Extract the names of the entities of the question: the people, organizations, places, products, concepts and events.
+Return only a JSON array of strings, e.g. ["Marie Curie", "Radium"], or [] if there is none.`
+
+// fullTextQuery is the Lucene query of the full-text index: the names of the entities extracted by the chat model,
+// or the terms of the query.
+func (r *Retriever) fullTextQuery(ctx context.Context, query string) (string, error) {
+	if r.config.ChatModel == nil {
+		return escapeLucene(query), nil
+	}
+	msg, err := r.config.ChatModel.Generate(makeChatModelCtx(ctx, r.config.ChatModel), []*schema.Message{
+		schema.SystemMessage(entityPrompt),
+		schema.UserMessage(query),
+	})
+	if err != nil {
+		return "", fmt.Errorf("[Query] generate failed: %w", err)
+	}
+	content := msg.Content
+	start, end := strings.Index(content, "["), strings.LastIndex(content, "]")
+	if start < 0 || end < start {
+		return "", fmt.Errorf("[Query] no JSON array in output: %q", content)
+	}
+	var names []string
+	if err = json.Unmarshal([]byte(content[start:end+1]), &names); err != nil {
+		return "", fmt.Errorf("[Query] failed to unmarshal entity names: %w", err)
+	}
+	phrases := make([]string, 0, len(names))
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			phrases = append(phrases, `"`+strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(name)+`"`)
+		}
+	}
+	return strings.Join(phrases, " OR "), nil
+}
+
+func (r *Retriever) findEntities(ctx context.Context, search string) ([]*Entity, error) {
+	res, err := r.runQuery(ctx, `CALL db.index.fulltext.queryNodes($index, $search) YIELD node, score
+RETURN node.id AS id, node.name AS name, node.type AS type, node.description AS description, score
+ORDER BY score DESC
+LIMIT $limit`, map[string]any{
+		"index":  r.config.FullTextIndex,
+		"search": search,
+		"limit":  r.config.MaxEntities,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[Query] failed to search entities: %w", err)
+	}
+	entities := make([]*Entity, 0, len(res.Records))
+	for _, rec := range res.Records {
+		entities = append(entities, &Entity{
+			ID:          get[string](rec, "id"),
+			Name:        get[string](rec, "name"),
+			Type:        get[string](rec, "type"),
+			Description: get[string](rec, "description"),
+			Score:       get[float64](rec, "score"),
+		})
+	}
+	return entities, nil
+}
+
+func (r *Retriever) findTriples(ctx context.Context, ids []any, depth, limit int) ([]*Triple, error) {
+	query := fmt.Sprintf(`MATCH (s:%[1]s) WHERE s.id IN $ids
+MATCH p = (s)-[:%[2]s*1..%[3]d]-(:%[1]s)
+UNWIND relationships(p) AS r
+WITH DISTINCT r
+RETURN startNode(r).name AS source, r.type AS relation, endNode(r).name AS target, r.description AS description
+LIMIT $limit`, r.config.EntityLabel, relRelatesTo, depth)
+	res, err := r.runQuery(ctx, query, map[string]any{"ids": ids, "limit": limit})
+	if err != nil {
+		return nil, fmt.Errorf("[Query] failed to traverse relations: %w", err)
+	}
+	triples := make([]*Triple, 0, len(res.Records))
+	for _, rec := range res.Records {
+		triples = append(triples, &Triple{
+			Source:      get[string](rec, "source"),
+			Relation:    get[string](rec, "relation"),
+			Target:      get[string](rec, "target"),
+			Description: get[string](rec, "description"),
+		})
+	}
+	return triples, nil
+}
+
+func (r *Retriever) findPassages(ctx context.Context, ids []any, limit int) ([]*schema.Document, error) {
+	query := fmt.Sprintf(`MATCH (c:%s)-[:%s]->(e:%s) WHERE e.id IN $ids
+WITH c, collect(DISTINCT e.name) AS entities
+RETURN c.id AS id, c.content AS content, c.metadata AS metadata, entities
+ORDER BY size(entities) DESC, c.id
+LIMIT $limit`, r.config.ChunkLabel, relMentions, r.config.EntityLabel)
+	res, err := r.runQuery(ctx, query, map[string]any{"ids": ids, "limit": limit})
+	if err != nil {
+		return nil, fmt.Errorf("[Query] failed to find passages: %w", err)
+	}
+	docs := make([]*schema.Document, 0, len(res.Records))
+	for _, rec := range res.Records {
+		metadata := map[string]any{}
+		if raw := get[string](rec, "metadata"); raw != "" {
+			if err = json.Unmarshal([]byte(raw), &metadata); err != nil {
+				return nil, fmt.Errorf("[Query] invalid metadata of passage %s: %w", get[string](rec, "id"), err)
+			}
+		}
+		var entities []string
+		for _, e := range get[[]any](rec, "entities") {
+			if name, ok := e.(string); ok {
+				entities = append(entities, name)
+			}
+		}
+		metadata[MetaKeyKind] = KindPassage
+		metadata[MetaKeyEntities] = entities
+		doc := &schema.Document{
+			ID:       get[string](rec, "id"),
+			Content:  get[string](rec, "content"),
+			MetaData: metadata,
+		}
+		docs = append(docs, doc.WithScore(float64(len(entities))/float64(len(ids))))
+	}
+	return docs, nil
+}
+
+// get returns the value of the key of the record, the zero value if it's missing or null.
+func get[T any](rec *neo4j.Record, key string) T {
+	var zero T
+	v, ok := rec.Get(key)
+	if !ok {
+		return zero
+	}
+	t, ok := v.(T)
+	if !ok {
+		return zero
+	}
+	return t
+}
+
+// luceneSpecialChars are the characters of the Lucene query syntax.
+const luceneSpecialChars = `+-&|!(){}[]^"~*?:\/`
+
+// escapeLucene escapes the query, so that its terms are matched with any of them, the default operator being OR.
+func escapeLucene(query string) string {
+	terms := strings.Fields(query)
+	for i, term := range terms {
+		// the operators are case-sensitive
+		if term == "AND" || term == "OR" || term == "NOT" {
+			terms[i] = strings.ToLower(term)
+			continue
+		}
+		var sb strings.Builder
+		for _, c := range term {
+			if strings.ContainsRune(luceneSpecialChars, c) {
+				sb.WriteRune('\\')
+			}
+			sb.WriteRune(c)
+		}
+		terms[i] = sb.String()
+	}
+	return strings.Join(terms, " ")
+}
+
+func makeChatModelCtx(ctx context.Context, cm model.BaseChatModel) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfChatModel,
+	}
+
+	if typ, ok := components.GetType(cm); ok {
+		runInfo.Type = typ
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
+
+const typ = "Neo4j"
+
+func (r *Retriever) GetType() string {
+	return typ
+}
+
+func (r *Retriever) IsCallbacksEnabled() bool {
+	return true
+}
@@ -0,0 +1,235 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package neo4j
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeChatModel struct {
+	output string
+	err    error
+}
+
+func (f *fakeChatModel) Generate(_ context.Context, _ []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return schema.AssistantMessage(f.output, nil), nil
+}
+
+func (f *fakeChatModel) Stream(_ context.Context, _ []*schema.Message, _ ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return nil, errors.New("not implemented")
+}
+
+type query struct {
+	cypher string
+	params map[string]any
+}
+
+func record(keys []string, values ...any) *neo4j.Record {
+	return &neo4j.Record{Keys: keys, Values: values}
+}
+
+var (
+	entityKeys  = []string{"id", "name", "type", "description", "score"}
+	tripleKeys  = []string{"source", "relation", "target", "description"}
+	passageKeys = []string{"id", "content", "metadata", "entities"}
+)
+
+// newTestRetriever returns a retriever answering with the results of the graph of the curies.
+func newTestRetriever(t *testing.T, config *RetrieverConfig) (*Retriever, *[]query) {
+	driver, err := neo4j.NewDriverWithContext("neo4j://localhost:7687", neo4j.NoAuth())
+	assert.NoError(t, err)
+	config.Driver = driver
+	r, err := NewRetriever(context.Background(), config)
+	assert.NoError(t, err)
+	var queries []query
+	r.runQuery = func(_ context.Context, cypher string, params map[string]any) (*neo4j.EagerResult, error) {
+		queries = append(queries, query{cypher: cypher, params: params})
+		switch {
+		case params["search"] == "fail":
+			return nil, errors.New("connection refused")
+		case strings.Contains(cypher, "db.index.fulltext.queryNodes"):
+			if params["search"] == "nobody" {
+				return &neo4j.EagerResult{}, nil
+			}
+			return &neo4j.EagerResult{Records: []*neo4j.Record{
+				record(entityKeys, "marie curie", "Marie Curie", "person", "Physicist", 2.5),
+				record(entityKeys, "radium", "Radium", "element", nil, 1.0),
+			}}, nil
+		case strings.Contains(cypher, "RELATES_TO"):
+			return &neo4j.EagerResult{Records: []*neo4j.Record{
+				record(tripleKeys, "Marie Curie", "discovered", "Radium", "in 1898"),
+				record(tripleKeys, "Marie Curie", "married_to", "Pierre Curie", nil),
+			}}, nil
+		case strings.Contains(cypher, "MENTIONS"):
+			return &neo4j.EagerResult{Records: []*neo4j.Record{
+				record(passageKeys, "curie-1", "In 1898 Marie Curie discovered radium.", `{"source":"curie.md"}`, []any{"Marie Curie", "Radium"}),
+				record(passageKeys, "curie-2", "Marie Curie won two Nobel prizes.", nil, []any{"Marie Curie"}),
+			}}, nil
+		}
+		return nil, errors.New("unexpected query")
+	}
+	return r, &queries
+}
+
+func TestNewRetriever(t *testing.T) {
+	ctx := context.Background()
+	driver, err := neo4j.NewDriverWithContext("neo4j://localhost:7687", neo4j.NoAuth())
+	assert.NoError(t, err)
+
+	_, err = NewRetriever(ctx, nil)
+	assert.EqualError(t, err, "[NewRetriever] config not provided")
+	_, err = NewRetriever(ctx, &RetrieverConfig{})
+	assert.EqualError(t, err, "[NewRetriever] neo4j driver not provided")
+	_, err = NewRetriever(ctx, &RetrieverConfig{Driver: driver, EntityLabel: "Entity) DETACH DELETE (n"})
+	assert.ErrorContains(t, err, "invalid label or index name")
+	_, err = NewRetriever(ctx, &RetrieverConfig{Driver: driver, Depth: 4})
+	assert.EqualError(t, err, "[NewRetriever] depth must be at most 3")
+
+	r, err := NewRetriever(ctx, &RetrieverConfig{Driver: driver})
+	assert.NoError(t, err)
+	assert.Equal(t, "Entity", r.config.EntityLabel)
+	assert.Equal(t, "Chunk", r.config.ChunkLabel)
+	assert.Equal(t, "entity_name", r.config.FullTextIndex)
+	assert.Equal(t, 5, r.config.MaxEntities)
+	assert.Equal(t, 1, r.config.Depth)
+	assert.Equal(t, 50, r.config.MaxTriples)
+	assert.Equal(t, 5, r.config.TopK)
+	assert.Equal(t, "Neo4j", r.GetType())
+	assert.True(t, r.IsCallbacksEnabled())
+}
+
+func TestQuery(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("full-text search of the query", func(t *testing.T) {
+		r, queries := newTestRetriever(t, &RetrieverConfig{})
+		result, err := r.Query(ctx, "What did Marie Curie discover?")
+		assert.NoError(t, err)
+
+		assert.Len(t, *queries, 3)
+		assert.Equal(t, `What did Marie Curie discover\?`, (*queries)[0].params["search"])
+		assert.Equal(t, "entity_name", (*queries)[0].params["index"])
+		assert.Equal(t, 5, (*queries)[0].params["limit"])
+		assert.Equal(t, []any{"marie curie", "radium"}, (*queries)[1].params["ids"])
+		assert.Contains(t, (*queries)[1].cypher, "-[:RELATES_TO*1..1]-(:Entity)")
+		assert.Equal(t, 50, (*queries)[1].params["limit"])
+		assert.Contains(t, (*queries)[2].cypher, "MATCH (c:Chunk)-[:MENTIONS]->(e:Entity)")
+		assert.Equal(t, 5, (*queries)[2].params["limit"])
+
+		assert.Equal(t, []*Entity{
+			{ID: "marie curie", Name: "Marie Curie", Type: "person", Description: "Physicist", Score: 2.5},
+			{ID: "radium", Name: "Radium", Type: "element", Score: 1},
+		}, result.Entities)
+		assert.Equal(t, []*Triple{
+			{Source: "Marie Curie", Relation: "discovered", Target: "Radium", Description: "in 1898"},
+			{Source: "Marie Curie", Relation: "married_to", Target: "Pierre Curie"},
+		}, result.Triples)
+
+		assert.Len(t, result.Passages, 2)
+		assert.Equal(t, "curie-1", result.Passages[0].ID)
+		assert.Equal(t, "In 1898 Marie Curie discovered radium.", result.Passages[0].Content)
+		assert.Equal(t, 1.0, result.Passages[0].Score())
+		assert.Equal(t, "curie.md", result.Passages[0].MetaData["source"])
+		assert.Equal(t, KindPassage, result.Passages[0].MetaData[MetaKeyKind])
+		assert.Equal(t, []string{"Marie Curie", "Radium"}, result.Passages[0].MetaData[MetaKeyEntities])
+		assert.Equal(t, 0.5, result.Passages[1].Score())
+	})
+
+	t.Run("options", func(t *testing.T) {
+		r, queries := newTestRetriever(t, &RetrieverConfig{})
+		_, err := r.Query(ctx, "curie", retriever.WithTopK(1), WithDepth(2), WithMaxTriples(10))
+		assert.NoError(t, err)
+		assert.Contains(t, (*queries)[1].cypher, "*1..2]")
+		assert.Equal(t, 10, (*queries)[1].params["limit"])
+		assert.Equal(t, 1, (*queries)[2].params["limit"])
+
+		_, err = r.Query(ctx, "curie", WithDepth(4))
+		assert.EqualError(t, err, "[Query] depth must be between 1 and 3")
+	})
+
+	t.Run("entities extracted by the chat model", func(t *testing.T) {
+		r, queries := newTestRetriever(t, &RetrieverConfig{
+			ChatModel: &fakeChatModel{output: "```json\n[\"Marie Curie\", \"the \\\"Radium\\\" Institute\", \" \"]\n```"},
+		})
+		_, err := r.Query(ctx, "Where did Marie Curie work?")
+		assert.NoError(t, err)
+		assert.Equal(t, `"Marie Curie" OR "the \"Radium\" Institute"`, (*queries)[0].params["search"])
+	})
+
+	t.Run("no entity", func(t *testing.T) {
+		r, queries := newTestRetriever(t, &RetrieverConfig{ChatModel: &fakeChatModel{output: "[]"}})
+		result, err := r.Query(ctx, "hello")
+		assert.NoError(t, err)
+		assert.Empty(t, *queries)
+		assert.Empty(t, result.Entities)
+
+		r, queries = newTestRetriever(t, &RetrieverConfig{})
+		result, err = r.Query(ctx, "nobody")
+		assert.NoError(t, err)
+		assert.Len(t, *queries, 1)
+		assert.Empty(t, result.Triples)
+		assert.Empty(t, result.Passages)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		r, _ := newTestRetriever(t, &RetrieverConfig{ChatModel: &fakeChatModel{err: errors.New("rate limited")}})
+		_, err := r.Query(ctx, "curie")
+		assert.EqualError(t, err, "[Query] generate failed: rate limited")
+
+		r, _ = newTestRetriever(t, &RetrieverConfig{ChatModel: &fakeChatModel{output: "Marie Curie"}})
+		_, err = r.Query(ctx, "curie")
+		assert.ErrorContains(t, err, "no JSON array in output")
+
+		r, _ = newTestRetriever(t, &RetrieverConfig{})
+		_, err = r.Query(ctx, "fail")
+		assert.EqualError(t, err, "[Query] failed to search entities: connection refused")
+	})
+}
+
+func TestRetrieve(t *testing.T) {
+	r, _ := newTestRetriever(t, &RetrieverConfig{})
+	docs, err := r.Retrieve(context.Background(), "What did Marie Curie discover?")
+	assert.NoError(t, err)
+	assert.Len(t, docs, 3)
+
+	assert.Equal(t, "triples", docs[0].ID)
+	assert.Equal(t, "Marie Curie -[discovered]-> Radium: in 1898\nMarie Curie -[married_to]-> Pierre Curie", docs[0].Content)
+	assert.Equal(t, KindTriples, docs[0].MetaData[MetaKeyKind])
+	assert.Len(t, docs[0].MetaData[MetaKeyTriples], 2)
+	assert.Equal(t, "curie-1", docs[1].ID)
+	assert.Equal(t, "curie-2", docs[2].ID)
+
+	r, _ = newTestRetriever(t, &RetrieverConfig{})
+	docs, err = r.Retrieve(context.Background(), "nobody")
+	assert.NoError(t, err)
+	assert.Empty(t, docs)
+}
+
+func TestEscapeLucene(t *testing.T) {
+	assert.Equal(t, `C\+\+ and \(go\) \"x\" a\:b\/c or`, escapeLucene(` C++ AND (go)  "x" a:b/c OR`))
+}
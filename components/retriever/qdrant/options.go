@@ -22,7 +22,9 @@ import (
 )
 
 type implOptions struct {
-	Filter *qdrant.Filter
+	Filter         *qdrant.Filter
+	NormalizeScore bool
+	MinScore       *float64
 }
 
 // WithFilter sets a Qdrant filter for the search query.
@@ -32,3 +34,17 @@ func WithFilter(filter *qdrant.Filter) retriever.Option {
 		o.Filter = filter
 	})
 }
+
+// WithNormalizeScore overrides Config.NormalizeScore for the search query.
+func WithNormalizeScore(normalize bool) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *implOptions) {
+		o.NormalizeScore = normalize
+	})
+}
+
+// WithMinScore overrides Config.MinScore for the search query.
+func WithMinScore(minScore float64) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *implOptions) {
+		o.MinScore = &minScore
+	})
+}
@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/cloudwego/eino-ext/components/retriever/qdrant/internal/vector"
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components"
 	"github.com/cloudwego/eino/components/embedding"
@@ -29,6 +30,8 @@ import (
 	qdrant "github.com/qdrant/go-client/qdrant"
 )
 
+//go:generate sh ../../../libs/acl/bundle.sh vector internal/vector
+
 type Config struct {
 	// Qdrant gRPC client
 	Client *qdrant.Client
@@ -40,6 +43,12 @@ type Config struct {
 	ScoreThreshold *float64
 	// Number of top results to retrieve from Qdrant.
 	TopK int
+	// Distance of the collection vectors, used to normalize scores. Defaults to Cosine.
+	Distance qdrant.Distance
+	// Convert scores to similarities in [0,1] by Distance, so they can be compared with other retrievers.
+	NormalizeScore bool
+	// Optional minimum score, normalized if NormalizeScore, for filtering results on the client side.
+	MinScore *float64
 }
 
 type Retriever struct {
@@ -48,6 +57,9 @@ type Retriever struct {
 	embedding      embedding.Embedder
 	scoreThreshold *float64
 	topK           int
+	metric         vector.Metric
+	normalizeScore bool
+	minScore       *float64
 }
 
 func NewRetriever(ctx context.Context, config *Config) (*Retriever, error) {
@@ -75,6 +87,9 @@ func NewRetriever(ctx context.Context, config *Config) (*Retriever, error) {
 		embedding:      config.Embedding,
 		scoreThreshold: config.ScoreThreshold,
 		topK:           topK,
+		metric:         scoreMetric(config.Distance),
+		normalizeScore: config.NormalizeScore,
+		minScore:       config.MinScore,
 	}, nil
 }
 
@@ -84,7 +99,10 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		ScoreThreshold: r.scoreThreshold,
 		Embedding:      r.embedding,
 	}, opts...)
-	io := retriever.GetImplSpecificOptions(&implOptions{}, opts...)
+	io := retriever.GetImplSpecificOptions(&implOptions{
+		NormalizeScore: r.normalizeScore,
+		MinScore:       r.minScore,
+	}, opts...)
 
 	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
 	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
@@ -147,7 +165,14 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 			doc.MetaData[defaultMetadataKey] = val.GetStructValue().Fields
 		}
 
-		doc.WithScore(float64(pt.Score))
+		score := float64(pt.Score)
+		if io.NormalizeScore {
+			score = vector.NormalizeScore(r.metric, score)
+		}
+		if io.MinScore != nil && score < *io.MinScore {
+			continue
+		}
+		doc.WithScore(score)
 
 		docs = append(docs, doc)
 	}
@@ -171,6 +196,20 @@ func (r *Retriever) makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder
 	return callbacks.ReuseHandlers(ctx, runInfo)
 }
 
+// scoreMetric returns the score metric of the collection distance.
+func scoreMetric(distance qdrant.Distance) vector.Metric {
+	switch distance {
+	case qdrant.Distance_Dot:
+		return vector.MetricInnerProduct
+	case qdrant.Distance_Euclid:
+		return vector.MetricL2
+	case qdrant.Distance_Manhattan:
+		return vector.MetricL1
+	default:
+		return vector.MetricCosine
+	}
+}
+
 func tryMarshalJsonString(input any) string {
 	if input == nil {
 		return ""
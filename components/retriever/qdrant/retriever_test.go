@@ -145,6 +145,53 @@ func TestRetrieverRetrieve(t *testing.T) {
 	})
 }
 
+func TestRetrieverRetrieveNormalizeScore(t *testing.T) {
+	PatchConvey("TestRetrieverRetrieveNormalizeScore", t, func() {
+		ctx := context.Background()
+		mockEmbedding := &mockEmbeddingQdrant{dims: 4}
+		mockClient := &qdrant.Client{}
+
+		Mock((*qdrant.Client).Query).To(func(c *qdrant.Client, ctx context.Context, req *qdrant.QueryPoints) ([]*qdrant.ScoredPoint, error) {
+			return []*qdrant.ScoredPoint{
+				{Id: qdrant.NewID("fba95545-ef38-4880-bf4a-b98174554103"), Score: 1},
+				{Id: qdrant.NewID("7c6b1a3e-2f7d-4c1e-9b1e-3d2f4a5b6c7d"), Score: 3},
+			}, nil
+		}).Build()
+
+		minScore := 0.4
+		retriever, err := NewRetriever(ctx, &Config{
+			Client:         mockClient,
+			Collection:     CollectionName,
+			Embedding:      mockEmbedding,
+			Distance:       qdrant.Distance_Euclid,
+			NormalizeScore: true,
+			MinScore:       &minScore,
+		})
+		So(err, ShouldBeNil)
+
+		Convey("When retrieving with normalized scores", func() {
+			docs, err := retriever.Retrieve(ctx, "test query")
+
+			Convey("Then distances should be converted and filtered", func() {
+				So(err, ShouldBeNil)
+				So(len(docs), ShouldEqual, 1)
+				So(docs[0].ID, ShouldEqual, "fba95545-ef38-4880-bf4a-b98174554103")
+				So(docs[0].Score(), ShouldEqual, 0.5)
+			})
+		})
+
+		Convey("When retrieving with raw scores", func() {
+			docs, err := retriever.Retrieve(ctx, "test query", WithNormalizeScore(false), WithMinScore(2))
+
+			Convey("Then the raw scores should be filtered", func() {
+				So(err, ShouldBeNil)
+				So(len(docs), ShouldEqual, 1)
+				So(docs[0].Score(), ShouldEqual, 3)
+			})
+		})
+	})
+}
+
 func TestRetrieverRetrieveWithError(t *testing.T) {
 	PatchConvey("TestRetrieverRetrieveWithError", t, func() {
 		ctx := context.Background()
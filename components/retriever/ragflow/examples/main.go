@@ -0,0 +1,51 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/components/retriever"
+
+	"github.com/cloudwego/eino-ext/components/retriever/ragflow"
+)
+
+func main() {
+	ctx := context.Background()
+
+	ret, err := ragflow.NewRetriever(ctx, &ragflow.RetrieverConfig{
+		APIKey:     os.Getenv("RAGFLOW_API_KEY"),
+		Endpoint:   os.Getenv("RAGFLOW_ENDPOINT"),
+		DatasetIDs: []string{os.Getenv("RAGFLOW_DATASET_ID")},
+		TopK:       5,
+	})
+	if err != nil {
+		log.Fatalf("NewRetriever of ragflow failed, err=%v", err)
+	}
+
+	docs, err := ret.Retrieve(ctx, "What is eino?", retriever.WithScoreThreshold(0.3))
+	if err != nil {
+		log.Fatalf("Retrieve of ragflow failed, err=%v", err)
+	}
+
+	for _, doc := range docs {
+		fmt.Printf("id: %s, document: %s, score: %.3f\n", doc.ID, ragflow.GetDocumentName(doc), doc.Score())
+		fmt.Printf("content: %s\n\n", doc.Content)
+	}
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package ragflow
+
+import (
+	"github.com/cloudwego/eino/components/retriever"
+)
+
+type implOptions struct {
+	DatasetIDs  []string
+	DocumentIDs []string
+	RerankID    string
+	Keyword     bool
+}
+
+// WithDatasetIDs overrides the datasets to retrieve from.
+func WithDatasetIDs(ids ...string) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *implOptions) {
+		o.DatasetIDs = ids
+	})
+}
+
+// WithDocumentIDs overrides the documents to retrieve from.
+func WithDocumentIDs(ids ...string) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *implOptions) {
+		o.DocumentIDs = ids
+	})
+}
+
+// WithRerankID overrides the rerank model used to rerank chunks.
+func WithRerankID(id string) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *implOptions) {
+		o.RerankID = id
+	})
+}
+
+// WithKeyword enables or disables keyword based matching.
+func WithKeyword(enable bool) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *implOptions) {
+		o.Keyword = enable
+	})
+}
@@ -0,0 +1,196 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package ragflow
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/bytedance/sonic"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	documentIDKey       = "document_id"
+	documentNameKey     = "document_name"
+	datasetIDKey        = "dataset_id"
+	highlightKey        = "highlight"
+	importantKeywordKey = "important_keywords"
+	vectorSimilarityKey = "vector_similarity"
+	termSimilarityKey   = "term_similarity"
+)
+
+type retrievalRequest struct {
+	Question               string   `json:"question"`
+	DatasetIDs             []string `json:"dataset_ids,omitempty"`
+	DocumentIDs            []string `json:"document_ids,omitempty"`
+	Page                   int      `json:"page,omitempty"`
+	PageSize               int      `json:"page_size,omitempty"`
+	SimilarityThreshold    *float64 `json:"similarity_threshold,omitempty"`
+	VectorSimilarityWeight *float64 `json:"vector_similarity_weight,omitempty"`
+	TopK                   int      `json:"top_k,omitempty"`
+	RerankID               string   `json:"rerank_id,omitempty"`
+	Keyword                bool     `json:"keyword,omitempty"`
+	Highlight              bool     `json:"highlight,omitempty"`
+}
+
+type retrievalResponse struct {
+	Code    int            `json:"code"`
+	Message string         `json:"message"`
+	Data    *retrievalData `json:"data"`
+}
+
+type retrievalData struct {
+	Chunks  []*Chunk  `json:"chunks"`
+	DocAggs []*DocAgg `json:"doc_aggs"`
+	Total   int       `json:"total"`
+}
+
+// Chunk is a chunk returned by the RAGFlow retrieval api.
+type Chunk struct {
+	ID                string   `json:"id"`
+	Content           string   `json:"content"`
+	DocumentID        string   `json:"document_id"`
+	DocumentKeyword   string   `json:"document_keyword"`
+	Highlight         string   `json:"highlight"`
+	ImageID           string   `json:"image_id"`
+	ImportantKeywords []string `json:"important_keywords"`
+	KBID              string   `json:"kb_id"`
+	Similarity        float64  `json:"similarity"`
+	TermSimilarity    float64  `json:"term_similarity"`
+	VectorSimilarity  float64  `json:"vector_similarity"`
+}
+
+// DocAgg is the per document hit count returned by the RAGFlow retrieval api.
+type DocAgg struct {
+	Count   int    `json:"count"`
+	DocID   string `json:"doc_id"`
+	DocName string `json:"doc_name"`
+}
+
+func (r *Retriever) doPost(ctx context.Context, body *retrievalRequest) (*retrievalData, error) {
+	reqData, err := sonic.MarshalString(body)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling data: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.getURL(), strings.NewReader(reqData))
+	if err != nil {
+		return nil, fmt.Errorf("create request failed: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+r.config.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("do request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response failed: %w", err)
+	}
+
+	res := &retrievalResponse{}
+	if err = sonic.Unmarshal(respBody, res); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("request failed with status code: %d", resp.StatusCode)
+		}
+		return nil, fmt.Errorf("decode response failed: %w", err)
+	}
+	// ragflow reports errors with a non-zero code, usually along with http status 200
+	if res.Code != 0 {
+		return nil, fmt.Errorf("request failed, code: %d, message: %s", res.Code, res.Message)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request failed with status code: %d", resp.StatusCode)
+	}
+	if res.Data == nil {
+		return &retrievalData{}, nil
+	}
+
+	return res.Data, nil
+}
+
+func (c *Chunk) toDoc(docNames map[string]string) *schema.Document {
+	doc := &schema.Document{
+		ID:      c.ID,
+		Content: c.Content,
+		MetaData: map[string]any{
+			documentIDKey:       c.DocumentID,
+			datasetIDKey:        c.KBID,
+			vectorSimilarityKey: c.VectorSimilarity,
+			termSimilarityKey:   c.TermSimilarity,
+		},
+	}
+	doc.WithScore(c.Similarity)
+
+	name := c.DocumentKeyword
+	if name == "" {
+		name = docNames[c.DocumentID]
+	}
+	if name != "" {
+		doc.MetaData[documentNameKey] = name
+	}
+	if c.Highlight != "" {
+		doc.MetaData[highlightKey] = c.Highlight
+	}
+	if len(c.ImportantKeywords) > 0 {
+		doc.MetaData[importantKeywordKey] = c.ImportantKeywords
+	}
+
+	return doc
+}
+
+// GetDocumentID returns the id of the RAGFlow document the chunk belongs to.
+func GetDocumentID(doc *schema.Document) string {
+	return getString(doc, documentIDKey)
+}
+
+// GetDocumentName returns the name of the RAGFlow document the chunk belongs to.
+func GetDocumentName(doc *schema.Document) string {
+	return getString(doc, documentNameKey)
+}
+
+// GetDatasetID returns the id of the RAGFlow dataset the chunk belongs to.
+func GetDatasetID(doc *schema.Document) string {
+	return getString(doc, datasetIDKey)
+}
+
+// GetHighlight returns the chunk content with matched terms highlighted, only set when highlight is enabled.
+func GetHighlight(doc *schema.Document) string {
+	return getString(doc, highlightKey)
+}
+
+// GetImportantKeywords returns the important keywords of the chunk.
+func GetImportantKeywords(doc *schema.Document) []string {
+	if doc == nil {
+		return nil
+	}
+	v, _ := doc.MetaData[importantKeywordKey].([]string)
+	return v
+}
+
+func getString(doc *schema.Document, key string) string {
+	if doc == nil {
+		return ""
+	}
+	v, _ := doc.MetaData[key].(string)
+	return v
+}
@@ -0,0 +1,197 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package ragflow
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	typ = "RAGFlow"
+
+	defaultTopK                   = 30
+	defaultSimilarityThreshold    = 0.2
+	defaultVectorSimilarityWeight = 0.3
+)
+
+// RetrieverConfig is the config of the RAGFlow retriever.
+type RetrieverConfig struct {
+	// APIKey is the RAGFlow api key, used as the bearer token.
+	// Required.
+	APIKey string
+	// Endpoint is the address of the RAGFlow server, e.g. http://localhost:9380
+	// Required.
+	Endpoint string
+	// DatasetIDs are the datasets to retrieve from.
+	// Either DatasetIDs or DocumentIDs is required.
+	DatasetIDs []string
+	// DocumentIDs limits retrieval to the given documents.
+	// Optional.
+	DocumentIDs []string
+	// TopK is the number of chunks to return, sent as page_size.
+	// Optional. Default: 30
+	TopK int
+	// SimilarityThreshold is the minimum similarity of returned chunks.
+	// Optional. Default: 0.2
+	SimilarityThreshold *float64
+	// VectorSimilarityWeight is the weight of vector cosine similarity, 1 - weight is the weight of term similarity.
+	// Optional. Default: 0.3
+	VectorSimilarityWeight *float64
+	// CandidateTopK is the number of chunks taking part in vector cosine computation, sent as top_k.
+	// Optional. Default: server side default, 1024
+	CandidateTopK int
+	// RerankID is the id of the rerank model configured in RAGFlow.
+	// Optional.
+	RerankID string
+	// Keyword enables keyword based matching.
+	// Optional. Default: false
+	Keyword bool
+	// Highlight returns the matched terms highlighted in metadata.
+	// Optional. Default: false
+	Highlight bool
+	// Timeout is the http request timeout, not used if HTTPClient is set.
+	// Optional.
+	Timeout time.Duration
+	// HTTPClient is the http client used to send requests.
+	// Optional. Default: &http.Client{Timeout: Timeout}
+	HTTPClient *http.Client
+}
+
+type Retriever struct {
+	config *RetrieverConfig
+	client *http.Client
+}
+
+func NewRetriever(_ context.Context, config *RetrieverConfig) (*Retriever, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+	if config.APIKey == "" {
+		return nil, fmt.Errorf("api_key is required")
+	}
+	if config.Endpoint == "" {
+		return nil, fmt.Errorf("endpoint is required")
+	}
+	if len(config.DatasetIDs) == 0 && len(config.DocumentIDs) == 0 {
+		return nil, fmt.Errorf("dataset_ids or document_ids is required")
+	}
+
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: config.Timeout}
+	}
+
+	return &Retriever{
+		config: config,
+		client: httpClient,
+	}, nil
+}
+
+// Retrieve retrieves chunks relevant to query from RAGFlow.
+func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) (docs []*schema.Document, err error) {
+	topK := r.config.TopK
+	if topK <= 0 {
+		topK = defaultTopK
+	}
+	threshold := r.config.SimilarityThreshold
+	if threshold == nil {
+		threshold = ptrOf(defaultSimilarityThreshold)
+	}
+	options := retriever.GetCommonOptions(&retriever.Options{
+		TopK:           &topK,
+		ScoreThreshold: threshold,
+	}, opts...)
+	implOption := retriever.GetImplSpecificOptions(&implOptions{
+		DatasetIDs:  r.config.DatasetIDs,
+		DocumentIDs: r.config.DocumentIDs,
+		RerankID:    r.config.RerankID,
+		Keyword:     r.config.Keyword,
+	}, opts...)
+
+	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
+	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
+		Query:          query,
+		TopK:           dereferenceOrZero(options.TopK),
+		ScoreThreshold: options.ScoreThreshold,
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	weight := r.config.VectorSimilarityWeight
+	if weight == nil {
+		weight = ptrOf(defaultVectorSimilarityWeight)
+	}
+
+	req := &retrievalRequest{
+		Question:               query,
+		DatasetIDs:             implOption.DatasetIDs,
+		DocumentIDs:            implOption.DocumentIDs,
+		Page:                   1,
+		PageSize:               dereferenceOrZero(options.TopK),
+		SimilarityThreshold:    options.ScoreThreshold,
+		VectorSimilarityWeight: weight,
+		TopK:                   r.config.CandidateTopK,
+		RerankID:               implOption.RerankID,
+		Keyword:                implOption.Keyword,
+		Highlight:              r.config.Highlight,
+	}
+
+	data, err := r.doPost(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve documents: %w", err)
+	}
+
+	docNames := make(map[string]string, len(data.DocAggs))
+	for _, agg := range data.DocAggs {
+		docNames[agg.DocID] = agg.DocName
+	}
+
+	docs = make([]*schema.Document, 0, len(data.Chunks))
+	for _, chunk := range data.Chunks {
+		if chunk == nil {
+			continue
+		}
+		docs = append(docs, chunk.toDoc(docNames))
+	}
+
+	callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs})
+
+	return docs, nil
+}
+
+func (r *Retriever) getURL() string {
+	return strings.TrimRight(r.config.Endpoint, "/") + "/api/v1/retrieval"
+}
+
+func (r *Retriever) GetType() string {
+	return typ
+}
+
+func (r *Retriever) IsCallbacksEnabled() bool {
+	return true
+}
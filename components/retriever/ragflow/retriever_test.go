@@ -0,0 +1,163 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package ragflow
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestServer(t *testing.T, resp string, reqBody *map[string]any) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/retrieval", r.URL.Path)
+		assert.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+		body, _ := io.ReadAll(r.Body)
+		*reqBody = map[string]any{}
+		assert.NoError(t, json.Unmarshal(body, reqBody))
+		_, _ = w.Write([]byte(resp))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestNewRetriever(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewRetriever(ctx, nil)
+	assert.ErrorContains(t, err, "config is required")
+
+	_, err = NewRetriever(ctx, &RetrieverConfig{Endpoint: "http://localhost"})
+	assert.ErrorContains(t, err, "api_key is required")
+
+	_, err = NewRetriever(ctx, &RetrieverConfig{APIKey: "k"})
+	assert.ErrorContains(t, err, "endpoint is required")
+
+	_, err = NewRetriever(ctx, &RetrieverConfig{APIKey: "k", Endpoint: "http://localhost"})
+	assert.ErrorContains(t, err, "dataset_ids or document_ids is required")
+
+	r, err := NewRetriever(ctx, &RetrieverConfig{APIKey: "k", Endpoint: "http://localhost", DatasetIDs: []string{"ds"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "RAGFlow", r.GetType())
+	assert.True(t, r.IsCallbacksEnabled())
+}
+
+func TestRetrieve(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		var reqBody map[string]any
+		srv := newTestServer(t, `{"code":0,"data":{"chunks":[
+			{"id":"c1","content":"eino is a framework","document_id":"d1","document_keyword":"eino.md","highlight":"<em>eino</em> is a framework","important_keywords":["eino"],"kb_id":"ds1","similarity":0.9,"term_similarity":0.8,"vector_similarity":0.95},
+			{"id":"c2","content":"graph","document_id":"d2","kb_id":"ds1","similarity":0.5}
+		],"doc_aggs":[{"count":1,"doc_id":"d1","doc_name":"eino.md"},{"count":1,"doc_id":"d2","doc_name":"graph.pdf"}],"total":2}}`, &reqBody)
+
+		r, err := NewRetriever(ctx, &RetrieverConfig{
+			APIKey:     "test-key",
+			Endpoint:   srv.URL + "/",
+			DatasetIDs: []string{"ds1"},
+			Highlight:  true,
+		})
+		assert.NoError(t, err)
+
+		docs, err := r.Retrieve(ctx, "what is eino")
+		assert.NoError(t, err)
+		assert.Len(t, docs, 2)
+
+		assert.Equal(t, "c1", docs[0].ID)
+		assert.Equal(t, "eino is a framework", docs[0].Content)
+		assert.Equal(t, 0.9, docs[0].Score())
+		assert.Equal(t, "d1", GetDocumentID(docs[0]))
+		assert.Equal(t, "eino.md", GetDocumentName(docs[0]))
+		assert.Equal(t, "ds1", GetDatasetID(docs[0]))
+		assert.Equal(t, "<em>eino</em> is a framework", GetHighlight(docs[0]))
+		assert.Equal(t, []string{"eino"}, GetImportantKeywords(docs[0]))
+		assert.Equal(t, 0.95, docs[0].MetaData[vectorSimilarityKey])
+		assert.Equal(t, "graph.pdf", GetDocumentName(docs[1]))
+
+		assert.Equal(t, map[string]any{
+			"question":                 "what is eino",
+			"dataset_ids":              []any{"ds1"},
+			"page":                     float64(1),
+			"page_size":                float64(30),
+			"similarity_threshold":     0.2,
+			"vector_similarity_weight": 0.3,
+			"highlight":                true,
+		}, reqBody)
+	})
+
+	t.Run("options", func(t *testing.T) {
+		var reqBody map[string]any
+		srv := newTestServer(t, `{"code":0,"data":{"chunks":[],"doc_aggs":[],"total":0}}`, &reqBody)
+
+		r, err := NewRetriever(ctx, &RetrieverConfig{
+			APIKey:        "test-key",
+			Endpoint:      srv.URL,
+			DatasetIDs:    []string{"ds1"},
+			CandidateTopK: 512,
+		})
+		assert.NoError(t, err)
+
+		docs, err := r.Retrieve(ctx, "what is eino",
+			retriever.WithTopK(5),
+			retriever.WithScoreThreshold(0.6),
+			WithDatasetIDs("ds2", "ds3"),
+			WithDocumentIDs("d9"),
+			WithRerankID("BAAI/bge-reranker-v2-m3"),
+			WithKeyword(true),
+		)
+		assert.NoError(t, err)
+		assert.Empty(t, docs)
+
+		assert.Equal(t, []any{"ds2", "ds3"}, reqBody["dataset_ids"])
+		assert.Equal(t, []any{"d9"}, reqBody["document_ids"])
+		assert.Equal(t, float64(5), reqBody["page_size"])
+		assert.Equal(t, 0.6, reqBody["similarity_threshold"])
+		assert.Equal(t, float64(512), reqBody["top_k"])
+		assert.Equal(t, "BAAI/bge-reranker-v2-m3", reqBody["rerank_id"])
+		assert.Equal(t, true, reqBody["keyword"])
+	})
+
+	t.Run("error code", func(t *testing.T) {
+		var reqBody map[string]any
+		srv := newTestServer(t, `{"code":102,"message":"You don't own the dataset ds1."}`, &reqBody)
+
+		r, err := NewRetriever(ctx, &RetrieverConfig{APIKey: "test-key", Endpoint: srv.URL, DatasetIDs: []string{"ds1"}})
+		assert.NoError(t, err)
+
+		_, err = r.Retrieve(ctx, "what is eino")
+		assert.ErrorContains(t, err, "code: 102, message: You don't own the dataset ds1.")
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer srv.Close()
+
+		r, err := NewRetriever(ctx, &RetrieverConfig{APIKey: "test-key", Endpoint: srv.URL, DatasetIDs: []string{"ds1"}})
+		assert.NoError(t, err)
+
+		_, err = r.Retrieve(ctx, "what is eino")
+		assert.ErrorContains(t, err, "status code: 502")
+	})
+}
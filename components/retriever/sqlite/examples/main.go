@@ -0,0 +1,99 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/mattn/go-sqlite3"
+
+	"github.com/cloudwego/eino-ext/components/retriever/sqlite"
+)
+
+// Run the example of the sqlite indexer first, then:
+// SQLITE_VEC_PATH=/path/to/vec0 go run -tags sqlite_fts5 ./examples
+// see https://alexgarcia.xyz/sqlite-vec/installation.html for the loadable extension of sqlite-vec.
+func main() {
+	ctx := context.Background()
+
+	// load sqlite-vec, alternatively with github.com/asg017/sqlite-vec-go-bindings/cgo
+	sql.Register("sqlite3_vec", &sqlite3.SQLiteDriver{
+		Extensions: []string{os.Getenv("SQLITE_VEC_PATH")},
+	})
+	db, err := sql.Open("sqlite3_vec", "file:knowledge.db")
+	if err != nil {
+		log.Fatalf("Failed to open db: %v", err)
+	}
+	defer db.Close()
+
+	r, err := sqlite.NewRetriever(ctx, &sqlite.RetrieverConfig{
+		DB:        db,
+		Embedding: &mockEmbedding{}, // replace with real embedding, the same as the indexer.
+		TopK:      2,
+	})
+	if err != nil {
+		log.Fatalf("Failed to create retriever: %v", err)
+	}
+
+	// hybrid search
+	docs, err := r.Retrieve(ctx, "full-text search in SQLite")
+	if err != nil {
+		log.Fatalf("Failed to retrieve: %v", err)
+	}
+	for _, doc := range docs {
+		fmt.Printf("%s (%.4f): %s\n", doc.ID, doc.Score(), doc.Content)
+	}
+
+	// full-text search, with a filter on the metadata
+	docs, err = r.Retrieve(ctx, "framework", retriever.WithTopK(5),
+		sqlite.WithSearchMode(sqlite.SearchModeFullText),
+		sqlite.WithFilter(map[string]any{"topic": "eino"}))
+	if err != nil {
+		log.Fatalf("Failed to retrieve: %v", err)
+	}
+	for _, doc := range docs {
+		fmt.Printf("%s (%.4f): %s\n", doc.ID, doc.Score(), doc.Content)
+	}
+}
+
+// mockEmbedding hashes the words of the texts into 64 dimensions.
+type mockEmbedding struct{}
+
+func (m *mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	vectors := make([][]float64, 0, len(texts))
+	for _, text := range texts {
+		vec := make([]float64, 64)
+		h := fnv.New32a()
+		for _, c := range []byte(text) {
+			if c == ' ' {
+				vec[h.Sum32()%64]++
+				h.Reset()
+				continue
+			}
+			_, _ = h.Write([]byte{c})
+		}
+		vec[h.Sum32()%64]++
+		vectors = append(vectors, vec)
+	}
+	return vectors, nil
+}
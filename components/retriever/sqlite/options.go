@@ -0,0 +1,40 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sqlite
+
+import (
+	"github.com/cloudwego/eino/components/retriever"
+)
+
+type implOptions struct {
+	SearchMode SearchMode
+	Filter     map[string]any
+}
+
+// WithSearchMode overrides the search mode of the config.
+func WithSearchMode(mode SearchMode) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *implOptions) {
+		o.SearchMode = mode
+	})
+}
+
+// WithFilter keeps the documents whose metadata have the values, e.g. map[string]any{"topic": "sqlite"}.
+// A nil value matches a missing key.
+func WithFilter(filter map[string]any) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *implOptions) {
+		o.Filter = filter
+	})
+}
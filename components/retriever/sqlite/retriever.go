@@ -0,0 +1,427 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	defaultTable         = "documents"
+	defaultTopK          = 5
+	defaultRRFK          = 60
+	defaultVectorWeight  = 0.5
+	minHybridCandidates  = 20
+	hybridCandidateRatio = 4
+
+	// ftsTableSuffix is the suffix of the name of the FTS5 table of the table, as in the sqlite indexer.
+	ftsTableSuffix = "_fts"
+)
+
+var identifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// SearchMode is how the documents are searched.
+type SearchMode string
+
+const (
+	// SearchModeHybrid fuses the rankings of the full-text and the vector searches with reciprocal rank fusion.
+	SearchModeHybrid SearchMode = "hybrid"
+	// SearchModeVector ranks the documents by the distance of their embedding to the one of the query.
+	SearchModeVector SearchMode = "vector"
+	// SearchModeFullText ranks the documents matching any term of the query by bm25.
+	SearchModeFullText SearchMode = "fulltext"
+)
+
+// Distance is the sqlite-vec distance of the vector search.
+type Distance string
+
+const (
+	// DistanceCosine is vec_distance_cosine, the score of the documents being 1 - distance.
+	DistanceCosine Distance = "cosine"
+	// DistanceL2 is vec_distance_l2, the score of the documents being 1 / (1 + distance).
+	DistanceL2 Distance = "l2"
+)
+
+type RetrieverConfig struct {
+	// DB is the SQLite database of the sqlite indexer. The driver must be built with FTS5, e.g. with the sqlite_fts5
+	// build tag of github.com/mattn/go-sqlite3, and the vector search requires the sqlite-vec extension to be loaded.
+	// Required.
+	DB *sql.DB
+	// Table is the name of the table of the documents, as in the sqlite indexer.
+	// Optional. Default "documents".
+	Table string
+	// Embedding vectorizes the query for the vector and the hybrid searches.
+	// Optional. Default only the full-text search is available.
+	Embedding embedding.Embedder
+	// SearchMode is how the documents are searched.
+	// Optional. Default SearchModeHybrid with an Embedding, SearchModeFullText otherwise.
+	SearchMode SearchMode
+	// Distance is the distance of the vector search.
+	// Optional. Default DistanceCosine.
+	Distance Distance
+	// TopK limits number of results given.
+	// Optional. Default 5.
+	TopK int
+	// ScoreThreshold drops the documents with a lower score, which depends on the search mode,
+	// see SearchMode and Distance.
+	// Optional.
+	ScoreThreshold *float64
+	// HybridCandidates is the number of documents of each search fused by the hybrid search.
+	// Optional. Default 4 * TopK, at least 20.
+	HybridCandidates int
+	// VectorWeight is the weight of the vector search in the hybrid search, the full-text search weighing 1 - VectorWeight.
+	// Optional. Default 0.5.
+	VectorWeight *float64
+	// RRFK is the k constant of the reciprocal rank fusion, the score of a document being the sum of
+	// weight / (k + rank) of the searches.
+	// Optional. Default 60.
+	RRFK int
+}
+
+// Retriever searches the documents stored by the sqlite indexer, with FTS5, sqlite-vec or both.
+type Retriever struct {
+	config *RetrieverConfig
+}
+
+func NewRetriever(ctx context.Context, config *RetrieverConfig) (*Retriever, error) {
+	if config == nil {
+		return nil, fmt.Errorf("[NewRetriever] config not provided")
+	}
+	if config.DB == nil {
+		return nil, fmt.Errorf("[NewRetriever] sqlite db not provided")
+	}
+	if config.Table == "" {
+		config.Table = defaultTable
+	}
+	if !identifierRegexp.MatchString(config.Table) {
+		return nil, fmt.Errorf("[NewRetriever] invalid table name: %q", config.Table)
+	}
+	if config.SearchMode != "" && !validSearchMode(config.SearchMode) {
+		return nil, fmt.Errorf("[NewRetriever] invalid search mode: %q", config.SearchMode)
+	}
+	if config.Distance == "" {
+		config.Distance = DistanceCosine
+	}
+	if config.Distance != DistanceCosine && config.Distance != DistanceL2 {
+		return nil, fmt.Errorf("[NewRetriever] invalid distance: %q", config.Distance)
+	}
+	if config.TopK <= 0 {
+		config.TopK = defaultTopK
+	}
+	if config.VectorWeight == nil {
+		w := defaultVectorWeight
+		config.VectorWeight = &w
+	}
+	if *config.VectorWeight < 0 || *config.VectorWeight > 1 {
+		return nil, fmt.Errorf("[NewRetriever] vector weight must be between 0 and 1")
+	}
+	if config.RRFK <= 0 {
+		config.RRFK = defaultRRFK
+	}
+
+	return &Retriever{config: config}, nil
+}
+
+func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) (docs []*schema.Document, err error) {
+	co := retriever.GetCommonOptions(&retriever.Options{
+		TopK:           &r.config.TopK,
+		ScoreThreshold: r.config.ScoreThreshold,
+		Embedding:      r.config.Embedding,
+	}, opts...)
+	io := retriever.GetImplSpecificOptions(&implOptions{SearchMode: r.config.SearchMode}, opts...)
+
+	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
+	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
+		Query:          query,
+		TopK:           *co.TopK,
+		ScoreThreshold: co.ScoreThreshold,
+	})
+	defer func() {
+		if err != nil {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	mode := io.SearchMode
+	if mode == "" {
+		mode = SearchModeFullText
+		if co.Embedding != nil {
+			mode = SearchModeHybrid
+		}
+	}
+	if !validSearchMode(mode) {
+		return nil, fmt.Errorf("[Retrieve] invalid search mode: %q", mode)
+	}
+
+	where, args, err := filterClause(io.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	switch mode {
+	case SearchModeFullText:
+		docs, err = r.fullTextSearch(ctx, query, where, args, *co.TopK)
+	case SearchModeVector:
+		docs, err = r.vectorSearch(ctx, co.Embedding, query, where, args, *co.TopK)
+	case SearchModeHybrid:
+		docs, err = r.hybridSearch(ctx, co.Embedding, query, where, args, *co.TopK)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if co.ScoreThreshold != nil {
+		filtered := docs[:0]
+		for _, doc := range docs {
+			if doc.Score() >= *co.ScoreThreshold {
+				filtered = append(filtered, doc)
+			}
+		}
+		docs = filtered
+	}
+
+	callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs})
+
+	return docs, nil
+}
+
+func (r *Retriever) fullTextSearch(ctx context.Context, query, where string, args []any, limit int) ([]*schema.Document, error) {
+	match := matchQuery(query)
+	if match == "" {
+		return nil, nil
+	}
+	fts := r.config.Table + ftsTableSuffix
+	stmt := fmt.Sprintf(`SELECT d.id, d.content, d.metadata, -bm25(%[2]s) AS score
+FROM %[2]s JOIN %[1]s d ON d.id = %[2]s.id
+WHERE %[2]s MATCH ?%[3]s
+ORDER BY score DESC
+LIMIT ?`, r.config.Table, fts, where)
+	docs, err := r.query(ctx, stmt, append(append([]any{match}, args...), limit)...)
+	if err != nil {
+		return nil, fmt.Errorf("[Retrieve] full-text search failed: %w", err)
+	}
+	return docs, nil
+}
+
+func (r *Retriever) vectorSearch(ctx context.Context, emb embedding.Embedder, query, where string, args []any, limit int) ([]*schema.Document, error) {
+	if emb == nil {
+		return nil, fmt.Errorf("[Retrieve] embedding not provided")
+	}
+	vectors, err := emb.EmbedStrings(r.makeEmbeddingCtx(ctx, emb), []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("[Retrieve] embedding failed, %w", err)
+	}
+	if len(vectors) != 1 {
+		return nil, fmt.Errorf("[Retrieve] invalid return length of vector, got=%d, expected=1", len(vectors))
+	}
+	if len(vectors[0]) == 0 {
+		return nil, fmt.Errorf("[Retrieve] empty vector of query")
+	}
+
+	score := "1 - distance"
+	if r.config.Distance == DistanceL2 {
+		score = "1 / (1 + distance)"
+	}
+	stmt := fmt.Sprintf(`SELECT id, content, metadata, %s AS score FROM (
+	SELECT d.id, d.content, d.metadata, vec_distance_%s(d.embedding, ?) AS distance
+	FROM %s d
+	WHERE d.embedding IS NOT NULL%s
+	ORDER BY distance
+	LIMIT ?
+)`, score, r.config.Distance, r.config.Table, where)
+	docs, err := r.query(ctx, stmt, append(append([]any{vector2Bytes(vectors[0])}, args...), limit)...)
+	if err != nil {
+		return nil, fmt.Errorf("[Retrieve] vector search failed: %w", err)
+	}
+	return docs, nil
+}
+
+func (r *Retriever) hybridSearch(ctx context.Context, emb embedding.Embedder, query, where string, args []any, topK int) ([]*schema.Document, error) {
+	candidates := r.config.HybridCandidates
+	if candidates <= 0 {
+		candidates = max(topK*hybridCandidateRatio, minHybridCandidates)
+	}
+
+	vectorDocs, err := r.vectorSearch(ctx, emb, query, where, args, candidates)
+	if err != nil {
+		return nil, err
+	}
+	textDocs, err := r.fullTextSearch(ctx, query, where, args, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	vectorWeight := *r.config.VectorWeight
+	scores := make(map[string]float64, len(vectorDocs)+len(textDocs))
+	fused := make([]*schema.Document, 0, len(vectorDocs)+len(textDocs))
+	for _, ranking := range []struct {
+		docs   []*schema.Document
+		weight float64
+	}{
+		{docs: vectorDocs, weight: vectorWeight},
+		{docs: textDocs, weight: 1 - vectorWeight},
+	} {
+		for rank, doc := range ranking.docs {
+			if _, found := scores[doc.ID]; !found {
+				fused = append(fused, doc)
+			}
+			scores[doc.ID] += ranking.weight / float64(r.config.RRFK+rank+1)
+		}
+	}
+
+	sort.SliceStable(fused, func(i, j int) bool {
+		return scores[fused[i].ID] > scores[fused[j].ID]
+	})
+	fused = fused[:min(topK, len(fused))]
+	for _, doc := range fused {
+		doc.WithScore(scores[doc.ID])
+	}
+	return fused, nil
+}
+
+func (r *Retriever) query(ctx context.Context, stmt string, args ...any) ([]*schema.Document, error) {
+	rows, err := r.config.DB.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var docs []*schema.Document
+	for rows.Next() {
+		var (
+			doc      = &schema.Document{}
+			metadata string
+			score    float64
+		)
+		if err = rows.Scan(&doc.ID, &doc.Content, &metadata, &score); err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal([]byte(metadata), &doc.MetaData); err != nil {
+			return nil, fmt.Errorf("invalid metadata of doc %s: %w", doc.ID, err)
+		}
+		if doc.MetaData == nil {
+			doc.MetaData = map[string]any{}
+		}
+		docs = append(docs, doc.WithScore(score))
+	}
+	return docs, rows.Err()
+}
+
+// matchQuery is the FTS5 query matching any term of the query, the terms being quoted
+// so that the query syntax doesn't apply.
+func matchQuery(query string) string {
+	terms := strings.FieldsFunc(query, func(c rune) bool {
+		return !unicode.IsLetter(c) && !unicode.IsNumber(c)
+	})
+	for i, term := range terms {
+		terms[i] = `"` + term + `"`
+	}
+	return strings.Join(terms, " OR ")
+}
+
+// filterClause returns the conditions on the metadata of the filter, for the d alias of the table.
+func filterClause(filter map[string]any) (string, []any, error) {
+	if len(filter) == 0 {
+		return "", nil, nil
+	}
+	keys := make([]string, 0, len(filter))
+	for k := range filter {
+		if k == "" || strings.ContainsAny(k, `"\`) {
+			return "", nil, fmt.Errorf("[Retrieve] invalid filter key: %q", k)
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var (
+		sb   strings.Builder
+		args []any
+	)
+	for _, k := range keys {
+		path := `$."` + k + `"`
+		switch v := filter[k].(type) {
+		case nil:
+			sb.WriteString(" AND json_extract(d.metadata, ?) IS NULL")
+			args = append(args, path)
+		case bool:
+			// json_extract returns the booleans as integers
+			b := 0
+			if v {
+				b = 1
+			}
+			sb.WriteString(" AND json_extract(d.metadata, ?) = ?")
+			args = append(args, path, b)
+		case string, int, int32, int64, float32, float64:
+			sb.WriteString(" AND json_extract(d.metadata, ?) = ?")
+			args = append(args, path, v)
+		default:
+			return "", nil, fmt.Errorf("[Retrieve] unsupported filter value type of key %s: %T", k, v)
+		}
+	}
+	return sb.String(), args, nil
+}
+
+func validSearchMode(mode SearchMode) bool {
+	return mode == SearchModeHybrid || mode == SearchModeVector || mode == SearchModeFullText
+}
+
+// vector2Bytes converts the vector to the float32 little-endian blob of sqlite-vec.
+func vector2Bytes(vector []float64) []byte {
+	b := make([]byte, 4*len(vector))
+	for idx, v := range vector {
+		binary.LittleEndian.PutUint32(b[4*idx:], math.Float32bits(float32(v)))
+	}
+	return b
+}
+
+func (r *Retriever) makeEmbeddingCtx(ctx context.Context, emb embedding.Embedder) context.Context {
+	runInfo := &callbacks.RunInfo{
+		Component: components.ComponentOfEmbedding,
+	}
+
+	if embType, ok := components.GetType(emb); ok {
+		runInfo.Type = embType
+	}
+
+	runInfo.Name = runInfo.Type + string(runInfo.Component)
+
+	return callbacks.ReuseHandlers(ctx, runInfo)
+}
+
+const typ = "SQLite"
+
+func (r *Retriever) GetType() string {
+	return typ
+}
+
+func (r *Retriever) IsCallbacksEnabled() bool {
+	return true
+}
@@ -0,0 +1,275 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/stretchr/testify/assert"
+	"modernc.org/sqlite"
+)
+
+// the distances of sqlite-vec, which isn't loadable by the pure go driver built with FTS5 of the tests
+func init() {
+	distance := func(f func(a, b []float32) float64) func(*sqlite.FunctionContext, []driver.Value) (driver.Value, error) {
+		return func(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+			a, aok := args[0].([]byte)
+			b, bok := args[1].([]byte)
+			if !aok || !bok || len(a) != len(b) {
+				return nil, errors.New("vector dimension mismatch")
+			}
+			return f(bytes2Vector(a), bytes2Vector(b)), nil
+		}
+	}
+	sqlite.MustRegisterDeterministicScalarFunction("vec_distance_cosine", 2, distance(func(a, b []float32) float64 {
+		var dot, na, nb float64
+		for i := range a {
+			dot += float64(a[i] * b[i])
+			na += float64(a[i] * a[i])
+			nb += float64(b[i] * b[i])
+		}
+		return 1 - dot/math.Sqrt(na*nb)
+	}))
+	sqlite.MustRegisterDeterministicScalarFunction("vec_distance_l2", 2, distance(func(a, b []float32) float64 {
+		var sum float64
+		for i := range a {
+			sum += float64((a[i] - b[i]) * (a[i] - b[i]))
+		}
+		return math.Sqrt(sum)
+	}))
+}
+
+func bytes2Vector(b []byte) []float32 {
+	v := make([]float32, len(b)/4)
+	for i := range v {
+		v[i] = math.Float32frombits(binary.LittleEndian.Uint32(b[4*i:]))
+	}
+	return v
+}
+
+type mockEmbedding struct {
+	vectors map[string][]float64
+	err     error
+}
+
+func (m *mockEmbedding) EmbedStrings(_ context.Context, texts []string, _ ...embedding.Option) ([][]float64, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	vectors := make([][]float64, 0, len(texts))
+	for _, text := range texts {
+		vectors = append(vectors, m.vectors[text])
+	}
+	return vectors, nil
+}
+
+var testDocs = []struct {
+	id, content, metadata string
+	vector                []float64
+}{
+	{"1", "SQLite is a serverless database engine", `{"topic":"sqlite","year":2000}`, []float64{1, 0, 0}},
+	{"2", "FTS5 adds full-text search to SQLite", `{"topic":"sqlite","year":2015}`, []float64{0.8, 0.6, 0}},
+	{"3", "sqlite-vec stores vectors in SQLite", `{"topic":"vector","draft":true}`, []float64{0, 1, 0}},
+	{"4", "Eino builds LLM applications in Go", `{"topic":"eino"}`, nil},
+}
+
+// openTestDB returns a database with the tables of the sqlite indexer, and the test documents.
+func openTestDB(t *testing.T, table string) *sql.DB {
+	db, err := sql.Open("sqlite", ":memory:")
+	assert.NoError(t, err)
+	// each connection has its own in-memory database
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+
+	_, err = db.Exec(fmt.Sprintf(`CREATE TABLE %[1]s (id TEXT PRIMARY KEY, content TEXT NOT NULL, metadata TEXT NOT NULL DEFAULT '{}', embedding BLOB);
+CREATE VIRTUAL TABLE %[1]s_fts USING fts5(id UNINDEXED, content, tokenize = 'unicode61');`, table))
+	assert.NoError(t, err)
+	for _, doc := range testDocs {
+		var vec []byte
+		if doc.vector != nil {
+			vec = vector2Bytes(doc.vector)
+		}
+		_, err = db.Exec(fmt.Sprintf(`INSERT INTO %s VALUES (?, ?, ?, ?)`, table), doc.id, doc.content, doc.metadata, vec)
+		assert.NoError(t, err)
+		_, err = db.Exec(fmt.Sprintf(`INSERT INTO %s_fts (id, content) VALUES (?, ?)`, table), doc.id, doc.content)
+		assert.NoError(t, err)
+	}
+	return db
+}
+
+var testEmbedding = &mockEmbedding{vectors: map[string][]float64{
+	"serverless database": {1, 0, 0},
+	"vectors":             {0, 1, 0},
+	"full-text search":    {0.6, 0.8, 0},
+	"unknown":             {0.1, 1, 0},
+	"sqlite":              {0.6, 0.6, 0.5},
+}}
+
+func ids(t *testing.T, r *Retriever, query string, opts ...retriever.Option) []string {
+	docs, err := r.Retrieve(context.Background(), query, opts...)
+	assert.NoError(t, err)
+	result := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		result = append(result, doc.ID)
+	}
+	return result
+}
+
+func TestNewRetriever(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t, "documents")
+
+	_, err := NewRetriever(ctx, nil)
+	assert.EqualError(t, err, "[NewRetriever] config not provided")
+	_, err = NewRetriever(ctx, &RetrieverConfig{})
+	assert.EqualError(t, err, "[NewRetriever] sqlite db not provided")
+	_, err = NewRetriever(ctx, &RetrieverConfig{DB: db, Table: "a b"})
+	assert.EqualError(t, err, `[NewRetriever] invalid table name: "a b"`)
+	_, err = NewRetriever(ctx, &RetrieverConfig{DB: db, SearchMode: "semantic"})
+	assert.EqualError(t, err, `[NewRetriever] invalid search mode: "semantic"`)
+	_, err = NewRetriever(ctx, &RetrieverConfig{DB: db, Distance: "dot"})
+	assert.EqualError(t, err, `[NewRetriever] invalid distance: "dot"`)
+	w := 1.5
+	_, err = NewRetriever(ctx, &RetrieverConfig{DB: db, VectorWeight: &w})
+	assert.EqualError(t, err, "[NewRetriever] vector weight must be between 0 and 1")
+
+	r, err := NewRetriever(ctx, &RetrieverConfig{DB: db})
+	assert.NoError(t, err)
+	assert.Equal(t, "documents", r.config.Table)
+	assert.Equal(t, DistanceCosine, r.config.Distance)
+	assert.Equal(t, 5, r.config.TopK)
+	assert.Equal(t, 0.5, *r.config.VectorWeight)
+	assert.Equal(t, 60, r.config.RRFK)
+	assert.Equal(t, "SQLite", r.GetType())
+	assert.True(t, r.IsCallbacksEnabled())
+}
+
+func TestFullTextSearch(t *testing.T) {
+	ctx := context.Background()
+	r, err := NewRetriever(ctx, &RetrieverConfig{DB: openTestDB(t, "notes"), Table: "notes"})
+	assert.NoError(t, err)
+
+	docs, err := r.Retrieve(ctx, "full-text search")
+	assert.NoError(t, err)
+	assert.Len(t, docs, 1)
+	assert.Equal(t, "2", docs[0].ID)
+	assert.Equal(t, "FTS5 adds full-text search to SQLite", docs[0].Content)
+	assert.Equal(t, map[string]any{"topic": "sqlite", "year": float64(2015), "_score": docs[0].Score()}, docs[0].MetaData)
+	assert.Greater(t, docs[0].Score(), 0.0)
+
+	// any term, the syntax of FTS5 being escaped
+	assert.ElementsMatch(t, []string{"1", "2", "3", "4"}, ids(t, r, `sqlite AND "go`))
+	assert.Equal(t, []string{"4"}, ids(t, r, "eino NOT"))
+	assert.Len(t, ids(t, r, "sqlite", retriever.WithTopK(2)), 2)
+	assert.Empty(t, ids(t, r, "?!"))
+	assert.Empty(t, ids(t, r, "postgres"))
+}
+
+func TestVectorSearch(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t, "documents")
+	r, err := NewRetriever(ctx, &RetrieverConfig{DB: db, Embedding: testEmbedding, SearchMode: SearchModeVector, TopK: 2})
+	assert.NoError(t, err)
+
+	docs, err := r.Retrieve(ctx, "serverless database")
+	assert.NoError(t, err)
+	assert.Len(t, docs, 2)
+	assert.Equal(t, "1", docs[0].ID)
+	assert.InDelta(t, 1, docs[0].Score(), 1e-6)
+	assert.Equal(t, "2", docs[1].ID)
+	assert.InDelta(t, 0.8, docs[1].Score(), 1e-6)
+
+	threshold := 0.9
+	assert.Equal(t, []string{"1"}, ids(t, r, "serverless database", retriever.WithScoreThreshold(threshold)))
+
+	r, err = NewRetriever(ctx, &RetrieverConfig{DB: db, Embedding: testEmbedding, SearchMode: SearchModeVector, Distance: DistanceL2})
+	assert.NoError(t, err)
+	docs, err = r.Retrieve(ctx, "vectors")
+	assert.NoError(t, err)
+	assert.Equal(t, "3", docs[0].ID)
+	assert.InDelta(t, 1, docs[0].Score(), 1e-6)
+	// the documents without embedding are not searched
+	assert.Len(t, docs, 3)
+}
+
+func TestHybridSearch(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t, "documents")
+	r, err := NewRetriever(ctx, &RetrieverConfig{DB: db, Embedding: testEmbedding})
+	assert.NoError(t, err)
+
+	// 2 is first of both searches, 1 is second of the vector search and matches "search" of the full-text search
+	docs, err := r.Retrieve(ctx, "full-text search")
+	assert.NoError(t, err)
+	assert.Equal(t, "2", docs[0].ID)
+	assert.InDelta(t, 0.5/61+0.5/61, docs[0].Score(), 1e-9)
+	assert.Len(t, docs, 3)
+
+	// the full-text search only finds 3, the vector search ranks it second
+	assert.Equal(t, "3", ids(t, r, "vectors")[0])
+	assert.Equal(t, "3", ids(t, r, "vectors", WithSearchMode(SearchModeFullText))[0])
+
+	w := 1.0
+	r, err = NewRetriever(ctx, &RetrieverConfig{DB: db, Embedding: testEmbedding, VectorWeight: &w, TopK: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"3"}, ids(t, r, "unknown"))
+}
+
+func TestFilter(t *testing.T) {
+	ctx := context.Background()
+	r, err := NewRetriever(ctx, &RetrieverConfig{DB: openTestDB(t, "documents"), Embedding: testEmbedding})
+	assert.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"1", "2"}, ids(t, r, "sqlite", WithFilter(map[string]any{"topic": "sqlite"}), WithSearchMode(SearchModeFullText)))
+	assert.Equal(t, []string{"2"}, ids(t, r, "sqlite", WithFilter(map[string]any{"topic": "sqlite", "year": 2015})))
+	assert.Equal(t, []string{"3"}, ids(t, r, "sqlite", WithFilter(map[string]any{"draft": true}), WithSearchMode(SearchModeVector)))
+	assert.ElementsMatch(t, []string{"1", "2"}, ids(t, r, "sqlite", WithFilter(map[string]any{"draft": nil}), WithSearchMode(SearchModeFullText)))
+
+	_, err = r.Retrieve(ctx, "sqlite", WithFilter(map[string]any{`a"b`: 1}))
+	assert.EqualError(t, err, `[Retrieve] invalid filter key: "a\"b"`)
+	_, err = r.Retrieve(ctx, "sqlite", WithFilter(map[string]any{"tags": []string{"a"}}))
+	assert.EqualError(t, err, "[Retrieve] unsupported filter value type of key tags: []string")
+}
+
+func TestRetrieveErrors(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t, "documents")
+
+	r, err := NewRetriever(ctx, &RetrieverConfig{DB: db, SearchMode: SearchModeVector})
+	assert.NoError(t, err)
+	_, err = r.Retrieve(ctx, "sqlite")
+	assert.EqualError(t, err, "[Retrieve] embedding not provided")
+	_, err = r.Retrieve(ctx, "sqlite", retriever.WithEmbedding(&mockEmbedding{err: errors.New("timeout")}))
+	assert.EqualError(t, err, "[Retrieve] embedding failed, timeout")
+	_, err = r.Retrieve(ctx, "unknown", retriever.WithEmbedding(&mockEmbedding{}))
+	assert.EqualError(t, err, "[Retrieve] empty vector of query")
+	_, err = r.Retrieve(ctx, "sqlite", WithSearchMode("semantic"))
+	assert.EqualError(t, err, `[Retrieve] invalid search mode: "semantic"`)
+
+	r, err = NewRetriever(ctx, &RetrieverConfig{DB: db, Table: "missing"})
+	assert.NoError(t, err)
+	_, err = r.Retrieve(ctx, "sqlite")
+	assert.ErrorContains(t, err, "[Retrieve] full-text search failed")
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/components/retriever"
+
+	knowledge "github.com/cloudwego/eino-ext/components/retriever/volc_knowledge"
+)
+
+func main() {
+	ctx := context.Background()
+
+	ret, err := knowledge.NewRetriever(ctx, &knowledge.Config{
+		AK:        os.Getenv("VOLC_ACCESS_KEY"),
+		SK:        os.Getenv("VOLC_SECRET_KEY"),
+		AccountID: os.Getenv("VOLC_ACCOUNT_ID"),
+		Name:      os.Getenv("VOLC_KNOWLEDGE_NAME"),
+		Project:   "default",
+		Limit:     5,
+	})
+	if err != nil {
+		log.Fatalf("NewRetriever of volc knowledge failed, err=%v", err)
+	}
+
+	docs, err := ret.Retrieve(ctx, "What is eino?",
+		retriever.WithScoreThreshold(0.3),
+		knowledge.WithRerankSwitch(true),
+		knowledge.WithRetrieveCount(25),
+		knowledge.WithDocFilter(knowledge.MustNotFilter("doc_id", "outdated-doc")),
+	)
+	if err != nil {
+		log.Fatalf("Retrieve of volc knowledge failed, err=%v", err)
+	}
+
+	for _, doc := range docs {
+		fmt.Printf("id: %s, doc: %s, score: %.3f\n", doc.ID, knowledge.GetDocName(doc), doc.Score())
+		fmt.Printf("content: %s\n\n", doc.Content)
+	}
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package knowledge
+
+// The helpers below build doc_filter expressions of the search_knowledge api.
+// For more details, please refer to: https://www.volcengine.com/docs/84313/1350012
+
+// MustFilter matches documents whose field equals one of conds.
+func MustFilter(field string, conds ...any) map[string]any {
+	return map[string]any{"op": "must", "field": field, "conds": conds}
+}
+
+// MustNotFilter matches documents whose field equals none of conds.
+func MustNotFilter(field string, conds ...any) map[string]any {
+	return map[string]any{"op": "must_not", "field": field, "conds": conds}
+}
+
+// RangeFilter matches documents whose field falls in the range, nil bounds are omitted.
+func RangeFilter(field string, gte, lte any) map[string]any {
+	f := map[string]any{"op": "range", "field": field}
+	if gte != nil {
+		f["gte"] = gte
+	}
+	if lte != nil {
+		f["lte"] = lte
+	}
+	return f
+}
+
+// AndFilter matches documents matching all filters.
+func AndFilter(filters ...map[string]any) map[string]any {
+	return map[string]any{"op": "and", "conds": filters}
+}
+
+// OrFilter matches documents matching any of filters.
+func OrFilter(filters ...map[string]any) map[string]any {
+	return map[string]any{"op": "or", "conds": filters}
+}
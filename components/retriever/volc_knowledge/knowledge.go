@@ -27,12 +27,15 @@ import (
 	"time"
 
 	"github.com/bytedance/sonic"
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
 	"github.com/cloudwego/eino/components/retriever"
 	"github.com/cloudwego/eino/schema"
 	"github.com/volcengine/volc-sdk-golang/base"
 )
 
 const (
+	typ            = "VolcKnowledge"
 	path           = "/api/knowledge/collection/search_knowledge"
 	defaultBaseURL = "api-knowledgebase.mlp.cn-beijing.volces.com"
 )
@@ -80,7 +83,7 @@ type Config struct {
 	// Optional. Range: [1, 200], Default: 10
 	Limit int32
 
-	// DocFilter specifies filters to apply to the document search
+	// DocFilter specifies filters to apply to the document search, see MustFilter, RangeFilter, AndFilter etc.
 	// Optional. Default: nil
 	DocFilter map[string]any
 
@@ -107,6 +110,7 @@ type Config struct {
 	Messages []*schema.Message
 
 	// RerankSwitch specifies whether to enable reranking of results
+	// When enabled, the rerank score is used as the document score
 	// Optional.
 	RerankSwitch bool
 
@@ -167,30 +171,62 @@ type knowledge struct {
 	credential *base.Credentials
 }
 
-func (k *knowledge) Retrieve(ctx context.Context, query string, opts ...retriever.Option) ([]*schema.Document, error) {
+func (k *knowledge) Retrieve(ctx context.Context, query string, opts ...retriever.Option) (docs []*schema.Document, err error) {
+	baseOptions := &retriever.Options{}
+	if k.cfg.Limit > 0 {
+		baseOptions.TopK = ptrOf(int(k.cfg.Limit))
+	}
+	options := retriever.GetCommonOptions(baseOptions, opts...)
+	implOption := retriever.GetImplSpecificOptions(&implOptions{
+		DocFilter:       k.cfg.DocFilter,
+		Messages:        k.cfg.Messages,
+		RerankSwitch:    k.cfg.RerankSwitch,
+		RerankModel:     k.cfg.RerankModel,
+		RetrieveCount:   k.cfg.RetrieveCount,
+		RerankOnlyChunk: k.cfg.RerankOnlyChunk,
+	}, opts...)
+
+	ctx = callbacks.EnsureRunInfo(ctx, k.GetType(), components.ComponentOfRetriever)
+	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
+		Query:          query,
+		TopK:           dereferenceOrZero(options.TopK),
+		Filter:         tryMarshalJsonString(implOption.DocFilter),
+		ScoreThreshold: options.ScoreThreshold,
+	})
+	defer func() {
+		if err != nil {
+			ctx = callbacks.OnError(ctx, err)
+		}
+	}()
+
+	limit := int32(dereferenceOrZero(options.TopK))
+	if implOption.RerankSwitch && implOption.RetrieveCount > 0 && limit > implOption.RetrieveCount {
+		return nil, fmt.Errorf("retrieve_count(%d) must be greater than or equal to limit(%d)", implOption.RetrieveCount, limit)
+	}
+
 	origReq := &request{
 		Name:       k.cfg.Name,
 		Project:    k.cfg.Project,
 		ResourceID: k.cfg.ResourceID,
 		Query:      query,
-		Limit:      k.cfg.Limit,
+		Limit:      limit,
 		QueryParam: queryParam{
-			DocFilter: k.cfg.DocFilter,
+			DocFilter: implOption.DocFilter,
 		},
 		DenseWeight: k.cfg.DenseWeight,
 		PreProcessing: preProcessing{
 			NeedInstruction:  k.cfg.NeedInstruction,
 			Rewrite:          k.cfg.Rewrite,
 			ReturnTokenUsage: k.cfg.ReturnTokenUsage,
-			Messages:         k.cfg.Messages,
+			Messages:         implOption.Messages,
 		},
 		PostProcessing: postProcessing{
-			RerankSwitch:        k.cfg.RerankSwitch,
-			RetrieveCount:       k.cfg.RetrieveCount,
+			RerankSwitch:        implOption.RerankSwitch,
+			RetrieveCount:       implOption.RetrieveCount,
 			ChunkDiffusionCount: k.cfg.ChunkDiffusionCount,
 			ChunkGroup:          k.cfg.ChunkGroup,
-			RerankModel:         k.cfg.RerankModel,
-			RerankOnlyChunk:     k.cfg.RerankOnlyChunk,
+			RerankModel:         implOption.RerankModel,
+			RerankOnlyChunk:     implOption.RerankOnlyChunk,
 			GetAttachmentLink:   k.cfg.GetAttachmentLink,
 		},
 	}
@@ -226,7 +262,20 @@ func (k *knowledge) Retrieve(ctx context.Context, query string, opts ...retrieve
 	if origResp.Code != 0 {
 		return nil, fmt.Errorf("request fail, code: %d, msg: %s, request id: %s", origResp.Code, origResp.Message, origResp.RequestID)
 	}
-	return origResp.toDocuments(), nil
+
+	docs = origResp.toDocuments(implOption.RerankSwitch, options.ScoreThreshold)
+
+	ctx = callbacks.OnEnd(ctx, &retriever.CallbackOutput{Docs: docs})
+
+	return docs, nil
+}
+
+func (k *knowledge) GetType() string {
+	return typ
+}
+
+func (k *knowledge) IsCallbacksEnabled() bool {
+	return true
 }
 
 func (k *knowledge) prepareRequest(req *http.Request) *http.Request {
@@ -270,19 +319,37 @@ type postProcessing struct {
 	GetAttachmentLink   bool   `json:"get_attachment_link,omitempty"`
 }
 
-func (r *response) toDocuments() []*schema.Document {
+// toDocuments converts results to documents, the rerank score is used as document score if reranked.
+func (r *response) toDocuments(reranked bool, scoreThreshold *float64) []*schema.Document {
+	if r.Data == nil {
+		return []*schema.Document{}
+	}
 	docs := make([]*schema.Document, 0, len(r.Data.ResultList))
 	for _, res := range r.Data.ResultList {
+		score := res.Score
+		if reranked {
+			score = res.RerankScore
+		}
+		if scoreThreshold != nil && score < *scoreThreshold {
+			continue
+		}
+
 		doc := &schema.Document{
 			ID:       res.ID,
 			Content:  res.Content,
 			MetaData: make(map[string]any),
 		}
+		doc.WithScore(score)
 		setDocID(doc, res.DocInfo.DocID)
 		setDocName(doc, res.DocInfo.DocName)
 		setChunkID(doc, res.ChunkID)
 		setAttachments(doc, res.ChunkAttachment)
 		setTableChunks(doc, res.TableChunkFields)
+		setChunkTitle(doc, res.ChunkTitle)
+		setRecallScore(doc, res.Score)
+		if reranked {
+			setRerankScore(doc, res.RerankScore)
+		}
 
 		docs = append(docs, doc)
 	}
@@ -24,6 +24,8 @@ import (
 	"testing"
 
 	"github.com/bytedance/mockey"
+	"github.com/bytedance/sonic"
+	"github.com/cloudwego/eino/components/retriever"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -68,3 +70,105 @@ func TestRetrieve(t *testing.T) {
 		assert.Equal(t, "This is a test document.", docs[0].Content)
 	})
 }
+
+func TestRetrieveWithOptions(t *testing.T) {
+	ctx := context.Background()
+	conf := &Config{
+		AK:            "test-ak",
+		SK:            "test-sk",
+		AccountID:     "test-account-id",
+		Name:          "test-name",
+		Limit:         10,
+		DocFilter:     MustFilter("doc_id", "d0"),
+		RetrieveCount: 20,
+	}
+
+	r, err := NewRetriever(ctx, conf)
+	assert.NoError(t, err)
+
+	mockey.PatchConvey("Test Retrieve with options", t, func() {
+		var reqBody map[string]any
+		mockey.Mock((*http.Client).Do).To(func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			reqBody = map[string]any{}
+			assert.NoError(t, sonic.Unmarshal(body, &reqBody))
+			respBody := `{
+				"code": 0,
+				"data": {
+					"result_list": [
+						{"id": "c1", "content": "first", "score": 0.6, "rerank_score": 0.9, "chunk_title": "t1", "doc_info": {"doc_id": "d1", "doc_name": "a.pdf"}},
+						{"id": "c2", "content": "second", "score": 0.8, "rerank_score": 0.3, "doc_info": {"doc_id": "d2", "doc_name": "b.pdf"}}
+					]
+				}
+			}`
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(respBody)),
+			}, nil
+		}).Build()
+
+		mockey.PatchConvey("without rerank", func() {
+			docs, err := r.Retrieve(ctx, "test query", retriever.WithScoreThreshold(0.7))
+			assert.NoError(t, err)
+			assert.Len(t, docs, 1)
+			assert.Equal(t, "c2", docs[0].ID)
+			assert.Equal(t, 0.8, docs[0].Score())
+			assert.Equal(t, "b.pdf", GetDocName(docs[0]))
+			_, ok := GetRerankScore(docs[0])
+			assert.False(t, ok)
+
+			assert.Equal(t, float64(10), reqBody["limit"])
+			assert.Equal(t, map[string]any{
+				"doc_filter": map[string]any{"op": "must", "field": "doc_id", "conds": []any{"d0"}},
+			}, reqBody["query_param"])
+			assert.Nil(t, reqBody["post_processing"].(map[string]any)["rerank_switch"])
+		})
+
+		mockey.PatchConvey("with rerank", func() {
+			filter := AndFilter(
+				MustNotFilter("doc_id", "d3"),
+				RangeFilter("create_time", 1700000000, nil),
+			)
+			docs, err := r.Retrieve(ctx, "test query",
+				retriever.WithTopK(5),
+				retriever.WithScoreThreshold(0.5),
+				WithDocFilter(filter),
+				WithRerankSwitch(true),
+				WithRerankModel("m3-v2-rerank"),
+				WithRetrieveCount(30),
+				WithRerankOnlyChunk(true),
+			)
+			assert.NoError(t, err)
+			assert.Len(t, docs, 1)
+			assert.Equal(t, "c1", docs[0].ID)
+			assert.Equal(t, 0.9, docs[0].Score())
+			assert.Equal(t, 0.6, GetRecallScore(docs[0]))
+			assert.Equal(t, "t1", GetChunkTitle(docs[0]))
+			score, ok := GetRerankScore(docs[0])
+			assert.True(t, ok)
+			assert.Equal(t, 0.9, score)
+
+			assert.Equal(t, float64(5), reqBody["limit"])
+			assert.Equal(t, map[string]any{
+				"doc_filter": map[string]any{
+					"op": "and",
+					"conds": []any{
+						map[string]any{"op": "must_not", "field": "doc_id", "conds": []any{"d3"}},
+						map[string]any{"op": "range", "field": "create_time", "gte": float64(1700000000)},
+					},
+				},
+			}, reqBody["query_param"])
+			assert.Equal(t, map[string]any{
+				"rerank_switch":     true,
+				"rerank_model":      "m3-v2-rerank",
+				"retrieve_count":    float64(30),
+				"rerank_only_chunk": true,
+			}, reqBody["post_processing"])
+		})
+
+		mockey.PatchConvey("invalid retrieve count", func() {
+			_, err := r.Retrieve(ctx, "test query", WithRerankSwitch(true), retriever.WithTopK(50))
+			assert.ErrorContains(t, err, "retrieve_count(20) must be greater than or equal to limit(50)")
+		})
+	})
+}
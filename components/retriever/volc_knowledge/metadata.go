@@ -24,6 +24,9 @@ const (
 	chunkIDKey     = "chunk_id"
 	attachmentsKey = "attachment"
 	tableChunksKey = "table"
+	chunkTitleKey  = "chunk_title"
+	recallScoreKey = "recall_score"
+	rerankScoreKey = "rerank_score"
 )
 
 func setDocID(doc *schema.Document, id string) {
@@ -46,6 +49,18 @@ func setTableChunks(doc *schema.Document, tableChunks []*TableChunkField) {
 	doc.MetaData[tableChunksKey] = tableChunks
 }
 
+func setChunkTitle(doc *schema.Document, title string) {
+	doc.MetaData[chunkTitleKey] = title
+}
+
+func setRecallScore(doc *schema.Document, score float64) {
+	doc.MetaData[recallScoreKey] = score
+}
+
+func setRerankScore(doc *schema.Document, score float64) {
+	doc.MetaData[rerankScoreKey] = score
+}
+
 func GetDocID(doc *schema.Document) string {
 	if v, ok := doc.MetaData[docIDKey]; ok {
 		return v.(string)
@@ -80,3 +95,26 @@ func GetTableChunks(doc *schema.Document) []*TableChunkField {
 	}
 	return nil
 }
+
+func GetChunkTitle(doc *schema.Document) string {
+	if v, ok := doc.MetaData[chunkTitleKey]; ok {
+		return v.(string)
+	}
+	return ""
+}
+
+// GetRecallScore returns the score of the retrieval stage, before reranking.
+func GetRecallScore(doc *schema.Document) float64 {
+	if v, ok := doc.MetaData[recallScoreKey]; ok {
+		return v.(float64)
+	}
+	return 0
+}
+
+// GetRerankScore returns the rerank score, only set when reranking is enabled.
+func GetRerankScore(doc *schema.Document) (float64, bool) {
+	if v, ok := doc.MetaData[rerankScoreKey]; ok {
+		return v.(float64), true
+	}
+	return 0, false
+}
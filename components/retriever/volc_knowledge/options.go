@@ -0,0 +1,72 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package knowledge
+
+import (
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+)
+
+type implOptions struct {
+	DocFilter       map[string]any
+	Messages        []*schema.Message
+	RerankSwitch    bool
+	RerankModel     string
+	RetrieveCount   int32
+	RerankOnlyChunk bool
+}
+
+// WithDocFilter overrides Config.DocFilter for this call, see MustFilter, RangeFilter, AndFilter etc.
+func WithDocFilter(filter map[string]any) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *implOptions) {
+		o.DocFilter = filter
+	})
+}
+
+// WithMessages overrides Config.Messages, the conversation history used to rewrite the query.
+func WithMessages(messages []*schema.Message) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *implOptions) {
+		o.Messages = messages
+	})
+}
+
+// WithRerankSwitch overrides Config.RerankSwitch for this call.
+func WithRerankSwitch(enable bool) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *implOptions) {
+		o.RerankSwitch = enable
+	})
+}
+
+// WithRerankModel overrides Config.RerankModel, only takes effect when reranking is enabled.
+func WithRerankModel(model string) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *implOptions) {
+		o.RerankModel = model
+	})
+}
+
+// WithRetrieveCount overrides Config.RetrieveCount, the number of documents retrieved for reranking.
+func WithRetrieveCount(count int32) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *implOptions) {
+		o.RetrieveCount = count
+	})
+}
+
+// WithRerankOnlyChunk overrides Config.RerankOnlyChunk for this call.
+func WithRerankOnlyChunk(only bool) retriever.Option {
+	return retriever.WrapImplSpecificOptFn(func(o *implOptions) {
+		o.RerankOnlyChunk = only
+	})
+}
@@ -2,7 +2,6 @@ module github.com/cloudwego/eino-ext/components/retriever/volc_vikingdb
 
 go 1.23.0
 
-
 require (
 	github.com/bytedance/mockey v1.2.13
 	github.com/cloudwego/eino v0.3.27
@@ -23,6 +23,7 @@ import (
 
 	"github.com/volcengine/volc-sdk-golang/service/vikingdb"
 
+	"github.com/cloudwego/eino-ext/components/retriever/volc_vikingdb/internal/vector"
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components"
 	"github.com/cloudwego/eino/components/embedding"
@@ -30,6 +31,8 @@ import (
 	"github.com/cloudwego/eino/schema"
 )
 
+//go:generate sh ../../../libs/acl/bundle.sh vector internal/vector
+
 const (
 	defaultTopK        = 100
 	defaultPartition   = "default"
@@ -59,6 +62,12 @@ type RetrieverConfig struct {
 	ScoreThreshold *float64 `json:"score_threshold,omitempty"`
 	// FilterDSL 标量过滤 filter 表达式 https://www.volcengine.com/docs/84313/1254609
 	FilterDSL map[string]any `json:"filter_dsl,omitempty"`
+
+	// NormalizeScore 按索引的 distance (ip / l2 / cosine) 将分数转换为 [0,1] 区间的相似度, 便于与其他 retriever 的分数比较
+	NormalizeScore bool `json:"normalize_score"`
+	// MinScore 在客户端丢弃分数 (开启 NormalizeScore 时为归一化后的分数) 低于该值的文档
+	// 与 ScoreThreshold 不同, ScoreThreshold 作用于原始分数
+	MinScore *float64 `json:"min_score,omitempty"`
 }
 
 type EmbeddingConfig struct {
@@ -148,6 +157,10 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 		Embedding:      r.config.EmbeddingConfig.Embedding,
 		DSLInfo:        r.config.FilterDSL,
 	}, opts...)
+	io := retriever.GetImplSpecificOptions(&ImplOptions{
+		NormalizeScore: &r.config.NormalizeScore,
+		MinScore:       r.config.MinScore,
+	}, opts...)
 
 	ctx = callbacks.EnsureRunInfo(ctx, r.GetType(), components.ComponentOfRetriever)
 	ctx = callbacks.OnStart(ctx, &retriever.CallbackInput{
@@ -201,6 +214,10 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retrieve
 			return nil, err
 		}
 
+		if !r.applyScoreOptions(doc, io) {
+			continue
+		}
+
 		docs = append(docs, doc.WithDSLInfo(options.DSLInfo))
 	}
 
@@ -323,6 +340,21 @@ func (r *Retriever) data2Document(data *vikingdb.Data) (*schema.Document, error)
 	return doc, nil
 }
 
+// applyScoreOptions normalizes the score of doc if required, and reports whether doc reaches the min score.
+func (r *Retriever) applyScoreOptions(doc *schema.Document, io *ImplOptions) bool {
+	score := doc.Score()
+	if io.NormalizeScore != nil && *io.NormalizeScore {
+		var distance string
+		if r.index != nil && r.index.VectorIndex != nil {
+			distance = r.index.VectorIndex.Distance
+		}
+		score = vector.NormalizeScore(scoreMetric(distance), score)
+		doc.WithScore(score)
+	}
+
+	return io.MinScore == nil || score >= *io.MinScore
+}
+
 func (r *Retriever) GetType() string {
 	return typ
 }
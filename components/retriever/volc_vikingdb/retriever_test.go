@@ -349,3 +349,30 @@ func (m *mockEmbedding) GetType() string {
 func of[T any](v T) *T {
 	return &v
 }
+
+func TestApplyScoreOptions(t *testing.T) {
+	PatchConvey("test applyScoreOptions", t, func() {
+		r := &Retriever{index: &vikingdb.Index{VectorIndex: &vikingdb.VectorIndexParams{Distance: "cosine"}}}
+
+		PatchConvey("test raw score", func() {
+			doc := (&schema.Document{}).WithScore(0.2)
+			convey.So(r.applyScoreOptions(doc, &ImplOptions{}), convey.ShouldBeTrue)
+			convey.So(doc.Score(), convey.ShouldEqual, 0.2)
+			convey.So(r.applyScoreOptions(doc, &ImplOptions{MinScore: ptrOf(0.3)}), convey.ShouldBeFalse)
+		})
+
+		PatchConvey("test normalize score", func() {
+			doc := (&schema.Document{}).WithScore(0.2)
+			ok := r.applyScoreOptions(doc, &ImplOptions{NormalizeScore: ptrOf(true), MinScore: ptrOf(0.3)})
+			convey.So(ok, convey.ShouldBeTrue)
+			convey.So(doc.Score(), convey.ShouldAlmostEqual, 0.6)
+		})
+
+		PatchConvey("test default distance", func() {
+			doc := (&schema.Document{}).WithScore(0)
+			r = &Retriever{index: &vikingdb.Index{}}
+			convey.So(r.applyScoreOptions(doc, &ImplOptions{NormalizeScore: ptrOf(true)}), convey.ShouldBeTrue)
+			convey.So(doc.Score(), convey.ShouldEqual, 0.5)
+		})
+	})
+}
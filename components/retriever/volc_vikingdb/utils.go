@@ -19,6 +19,9 @@ package volc_vikingdb
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino-ext/components/retriever/volc_vikingdb/internal/vector"
 )
 
 func GetType() string {
@@ -65,6 +68,18 @@ func interfaceTof64Slice(raw interface{}) ([]float64, error) {
 	return resp, nil
 }
 
+// scoreMetric returns the score metric of the index distance, vikingdb uses ip if not specified.
+func scoreMetric(distance string) vector.Metric {
+	switch strings.ToLower(distance) {
+	case "l2":
+		return vector.MetricL2
+	case "cosine":
+		return vector.MetricCosine
+	default:
+		return vector.MetricInnerProduct
+	}
+}
+
 func dereferenceOrZero[T any](v *T) T {
 	if v == nil {
 		var t T
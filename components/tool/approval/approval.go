@@ -0,0 +1,480 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package approval wraps the tools whose invocations require the approval of a human before running,
+// e.g. the tools writing to production systems.
+package approval
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+)
+
+// Rule selects the invocations requiring an approval.
+type Rule struct {
+	// ToolName is the pattern of the tool names, in the syntax of path.Match, e.g. "github_create_*".
+	// Optional. Default all tools.
+	ToolName string
+	// Arguments are the patterns of the arguments, by the dotted path of the argument, e.g. "repo" or "issue.labels",
+	// the regular expression matching the value of the argument, the non-string values being encoded as JSON.
+	// All the patterns must match. The keys are matched case-insensitively, as the tools decode the arguments.
+	// Optional.
+	Arguments map[string]string
+	// Match selects the invocations with the arguments decoded, along with ToolName and Arguments.
+	// The top level keys are renamed to the params declared by the tool, whatever their case.
+	// Optional.
+	Match func(ctx context.Context, toolName string, arguments map[string]any) bool
+}
+
+// Request is an invocation waiting for the approval.
+type Request struct {
+	// ID identifies the request, e.g. to correlate the decision of a webhook.
+	ID        string `json:"id"`
+	ToolName  string `json:"tool_name"`
+	ToolDesc  string `json:"tool_desc,omitempty"`
+	Arguments string `json:"arguments"`
+	// Rule is the index of the first rule requiring the approval, -1 without rules.
+	Rule        int       `json:"rule"`
+	RequestedAt time.Time `json:"requested_at"`
+}
+
+// Decision is the decision of the approver.
+type Decision struct {
+	Approved bool `json:"approved"`
+	// Reason is the reason of the decision, given to the model when the invocation is rejected.
+	Reason string `json:"reason,omitempty"`
+	// Approver identifies who decided, for the audit.
+	Approver string `json:"approver,omitempty"`
+	// Arguments replace the arguments of the approved invocation, e.g. narrowed by the approver.
+	// Optional.
+	Arguments string `json:"arguments,omitempty"`
+}
+
+// Approver decides the invocations, e.g. by asking a human on a channel, a webhook or the terminal.
+// The error fails the invocation, as it is neither approved nor rejected.
+type Approver interface {
+	Approve(ctx context.Context, req *Request) (*Decision, error)
+}
+
+// ApproverFunc is an Approver function.
+type ApproverFunc func(ctx context.Context, req *Request) (*Decision, error)
+
+func (f ApproverFunc) Approve(ctx context.Context, req *Request) (*Decision, error) {
+	return f(ctx, req)
+}
+
+// Config is the configuration of the approval.
+type Config struct {
+	// Rules select the invocations requiring an approval, any of them requiring it.
+	// Optional. Default all invocations require an approval.
+	Rules []*Rule
+	// Approver decides the invocations.
+	// Required.
+	Approver Approver
+	// Timeout is the maximum duration of waiting for the decision, the invocation failing after it.
+	// Optional. Default 0, no timeout.
+	Timeout time.Duration
+	// OnDecision is called with each decision, or with the error of the approver, e.g. to keep an audit log.
+	// Optional. Default the decisions are logged with the standard logger.
+	OnDecision func(ctx context.Context, req *Request, decision *Decision, err error)
+	// ReturnError returns the *RejectedError as the error of InvokableRun, instead of telling the model
+	// in the tool result that the invocation is rejected.
+	// Notice: the error of a tool aborts the agent loop.
+	// Optional. Default false.
+	ReturnError bool
+}
+
+// RejectedError is the error of the rejected invocations.
+type RejectedError struct {
+	ToolName string
+	Decision *Decision
+}
+
+func (e *RejectedError) Error() string {
+	if e.Decision.Reason == "" {
+		return fmt.Sprintf("invocation of tool %s is rejected", e.ToolName)
+	}
+	return fmt.Sprintf("invocation of tool %s is rejected: %s", e.ToolName, e.Decision.Reason)
+}
+
+type argumentPattern struct {
+	path []string
+	re   *regexp.Regexp
+}
+
+type rule struct {
+	*Rule
+	arguments []*argumentPattern
+}
+
+// Wrap wraps the tool to require the approval of the invocations selected by the rules before running them.
+func Wrap(ctx context.Context, t tool.InvokableTool, conf *Config) (tool.InvokableTool, error) {
+	if t == nil {
+		return nil, errors.New("tool is nil")
+	}
+	rules, err := conf.validate()
+	if err != nil {
+		return nil, err
+	}
+	info, err := t.Info(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tool info: %w", err)
+	}
+	params, err := declaredParams(info)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get params of tool %s: %w", info.Name, err)
+	}
+	return &approvalTool{tool: t, info: info, conf: conf, rules: rules, params: params}, nil
+}
+
+func declaredParams(info *schema.ToolInfo) (map[string]string, error) {
+	if info.ParamsOneOf == nil {
+		return nil, nil
+	}
+	js, err := info.ParamsOneOf.ToJSONSchema()
+	if err != nil {
+		return nil, err
+	}
+	if js == nil || js.Properties == nil {
+		return nil, nil
+	}
+	params := make(map[string]string, js.Properties.Len())
+	for p := js.Properties.Oldest(); p != nil; p = p.Next() {
+		params[foldKey(p.Key)] = p.Key
+	}
+	return params, nil
+}
+
+// WrapAll wraps the tools whose names match the rules, e.g. the tools of a tool kit, the others being returned as they are.
+// The tools must be invokable.
+func WrapAll(ctx context.Context, tools []tool.BaseTool, conf *Config) ([]tool.BaseTool, error) {
+	if _, err := conf.validate(); err != nil {
+		return nil, err
+	}
+
+	wrapped := make([]tool.BaseTool, 0, len(tools))
+	for _, t := range tools {
+		info, err := t.Info(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tool info: %w", err)
+		}
+		if !conf.mayMatch(info.Name) {
+			wrapped = append(wrapped, t)
+			continue
+		}
+		it, ok := t.(tool.InvokableTool)
+		if !ok {
+			return nil, fmt.Errorf("tool %s requires approval, but is not invokable", info.Name)
+		}
+		w, err := Wrap(ctx, it, conf)
+		if err != nil {
+			return nil, err
+		}
+		wrapped = append(wrapped, w)
+	}
+	return wrapped, nil
+}
+
+func (conf *Config) validate() ([]*rule, error) {
+	if conf == nil {
+		return nil, errors.New("config is nil")
+	}
+	if conf.Approver == nil {
+		return nil, errors.New("approver is required")
+	}
+
+	rules := make([]*rule, 0, len(conf.Rules))
+	for i, r := range conf.Rules {
+		if r == nil {
+			return nil, fmt.Errorf("rule %d is nil", i)
+		}
+		if _, err := path.Match(r.ToolName, ""); err != nil {
+			return nil, fmt.Errorf("invalid tool name pattern of rule %d: %w", i, err)
+		}
+		cr := &rule{Rule: r}
+		for p, expr := range r.Arguments {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid argument pattern of rule %d, argument: %s: %w", i, p, err)
+			}
+			cr.arguments = append(cr.arguments, &argumentPattern{path: strings.Split(p, "."), re: re})
+		}
+		rules = append(rules, cr)
+	}
+	return rules, nil
+}
+
+// mayMatch reports whether the invocations of the tool may require an approval, by its name only.
+func (conf *Config) mayMatch(toolName string) bool {
+	if len(conf.Rules) == 0 {
+		return true
+	}
+	for _, r := range conf.Rules {
+		if r.ToolName == "" {
+			return true
+		}
+		if ok, _ := path.Match(r.ToolName, toolName); ok {
+			return true
+		}
+	}
+	return false
+}
+
+type approvalTool struct {
+	tool  tool.InvokableTool
+	info  *schema.ToolInfo
+	conf  *Config
+	rules []*rule
+	// params are the declared params by their folded names, nil if the tool declares none.
+	params map[string]string
+}
+
+func (a *approvalTool) Info(ctx context.Context) (*schema.ToolInfo, error) {
+	return a.tool.Info(ctx)
+}
+
+func (a *approvalTool) InvokableRun(ctx context.Context, argumentsInJSON string, opts ...tool.Option) (string, error) {
+	idx, err := a.match(ctx, argumentsInJSON)
+	if err != nil {
+		return "", err
+	}
+	if idx == noMatch {
+		return a.tool.InvokableRun(ctx, argumentsInJSON, opts...)
+	}
+
+	req := &Request{
+		ID:          newID(),
+		ToolName:    a.info.Name,
+		ToolDesc:    a.info.Desc,
+		Arguments:   argumentsInJSON,
+		Rule:        idx,
+		RequestedAt: time.Now(),
+	}
+	decision, err := a.approve(ctx, req)
+	if a.conf.OnDecision != nil {
+		a.conf.OnDecision(ctx, req, decision, err)
+	} else {
+		logDecision(req, decision, err)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get approval of tool %s: %w", a.info.Name, err)
+	}
+
+	if !decision.Approved {
+		rErr := &RejectedError{ToolName: a.info.Name, Decision: decision}
+		if a.conf.ReturnError {
+			return "", rErr
+		}
+		return rErr.Error() + ", do not retry it unless asked to", nil
+	}
+
+	if decision.Arguments != "" {
+		argumentsInJSON = decision.Arguments
+	}
+	return a.tool.InvokableRun(ctx, argumentsInJSON, opts...)
+}
+
+func (a *approvalTool) approve(ctx context.Context, req *Request) (*Decision, error) {
+	if a.conf.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, a.conf.Timeout)
+		defer cancel()
+	}
+	decision, err := a.conf.Approver.Approve(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if decision == nil {
+		return nil, errors.New("approver returned no decision")
+	}
+	return decision, nil
+}
+
+const (
+	noMatch  = -2
+	allRules = -1
+)
+
+// match returns the index of the first rule matching the invocation.
+func (a *approvalTool) match(ctx context.Context, argumentsInJSON string) (int, error) {
+	if len(a.rules) == 0 {
+		return allRules, nil
+	}
+
+	var args map[string]any
+	decoded := false
+	for i, r := range a.rules {
+		if r.ToolName != "" {
+			if ok, _ := path.Match(r.ToolName, a.info.Name); !ok {
+				continue
+			}
+		}
+		if len(r.arguments) > 0 || r.Match != nil {
+			if !decoded {
+				decoded = true
+				var err error
+				if args, err = a.decode(argumentsInJSON); err != nil {
+					// the arguments can not be checked reliably, require the approval
+					return i, nil
+				}
+			}
+		}
+		if r.matchArguments(args) && (r.Match == nil || r.Match(ctx, a.info.Name, args)) {
+			return i, nil
+		}
+	}
+	return noMatch, nil
+}
+
+// decode decodes the arguments for the rules. The tools decode the keys case-insensitively, so the duplicate keys
+// whatever their case, and the keys not declared by the tool, could reach the tool without matching the rules: they fail.
+func (a *approvalTool) decode(argumentsInJSON string) (map[string]any, error) {
+	if err := checkDuplicateKeys(json.NewDecoder(strings.NewReader(argumentsInJSON))); err != nil {
+		return nil, err
+	}
+	var args map[string]any
+	if err := json.Unmarshal([]byte(argumentsInJSON), &args); err != nil {
+		return nil, err
+	}
+	if a.params == nil {
+		return args, nil
+	}
+	declared := make(map[string]any, len(args))
+	for k, v := range args {
+		name, ok := a.params[foldKey(k)]
+		if !ok {
+			return nil, fmt.Errorf("unknown argument: %s", k)
+		}
+		declared[name] = v
+	}
+	return declared, nil
+}
+
+func checkDuplicateKeys(dec *json.Decoder) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	switch tok {
+	case json.Delim('{'):
+		seen := make(map[string]bool)
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			k := foldKey(key.(string))
+			if seen[k] {
+				return fmt.Errorf("duplicate argument: %s", key)
+			}
+			seen[k] = true
+			if err = checkDuplicateKeys(dec); err != nil {
+				return err
+			}
+		}
+	case json.Delim('['):
+		for dec.More() {
+			if err = checkDuplicateKeys(dec); err != nil {
+				return err
+			}
+		}
+	default:
+		return nil
+	}
+	_, err = dec.Token()
+	return err
+}
+
+// foldKey folds the case of a key, the keys equal under the case folding of encoding/json folding to the same key.
+func foldKey(key string) string {
+	return strings.ToLower(strings.ToUpper(key))
+}
+
+func (r *rule) matchArguments(args map[string]any) bool {
+	for _, p := range r.arguments {
+		v, ok := lookup(args, p.path)
+		if !ok || !p.re.MatchString(format(v)) {
+			return false
+		}
+	}
+	return true
+}
+
+func lookup(args map[string]any, path []string) (any, bool) {
+	var cur any = args
+	for _, key := range path {
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if cur, ok = get(m, key); !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// get gets the value of a key case-insensitively, the exact key first.
+func get(m map[string]any, key string) (any, bool) {
+	if v, ok := m[key]; ok {
+		return v, true
+	}
+	for k, v := range m {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+func format(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+func logDecision(req *Request, decision *Decision, err error) {
+	switch {
+	case err != nil:
+		log.Printf("[approval] tool: %s, request: %s, arguments: %s, error: %v", req.ToolName, req.ID, req.Arguments, err)
+	case decision.Approved:
+		log.Printf("[approval] tool: %s, request: %s, arguments: %s, approved by: %s", req.ToolName, req.ID, req.Arguments, decision.Approver)
+	default:
+		log.Printf("[approval] tool: %s, request: %s, arguments: %s, rejected by: %s, reason: %s", req.ToolName, req.ID, req.Arguments, decision.Approver, decision.Reason)
+	}
+}
+
+func newID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
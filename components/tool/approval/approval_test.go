@@ -0,0 +1,249 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package approval
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeTool struct {
+	name   string
+	params map[string]*schema.ParameterInfo
+	got    string
+	runs   int
+}
+
+func (f *fakeTool) Info(ctx context.Context) (*schema.ToolInfo, error) {
+	info := &schema.ToolInfo{Name: f.name, Desc: "a fake tool"}
+	if f.params != nil {
+		info.ParamsOneOf = schema.NewParamsOneOfByParams(f.params)
+	}
+	return info, nil
+}
+
+func (f *fakeTool) InvokableRun(ctx context.Context, argumentsInJSON string, opts ...tool.Option) (string, error) {
+	f.runs++
+	f.got = argumentsInJSON
+	return "done", nil
+}
+
+type streamTool struct{}
+
+func (streamTool) Info(ctx context.Context) (*schema.ToolInfo, error) {
+	return &schema.ToolInfo{Name: "stream_deploy"}, nil
+}
+
+func (streamTool) StreamableRun(ctx context.Context, argumentsInJSON string, opts ...tool.Option) (*schema.StreamReader[string], error) {
+	return nil, nil
+}
+
+func TestWrap(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		requests  []*Request
+		decisions []*Decision
+		decide    = &Decision{Approved: true, Approver: "alice"}
+	)
+	conf := &Config{
+		Rules: []*Rule{
+			{ToolName: "github_create_*"},
+			{ToolName: "sql_*", Arguments: map[string]string{"query": `(?i)^\s*(delete|drop|update)`}},
+			{Arguments: map[string]string{"target.env": "^prod"}},
+			{ToolName: "deploy", Match: func(ctx context.Context, toolName string, arguments map[string]any) bool {
+				return arguments["force"] == true
+			}},
+		},
+		Approver: ApproverFunc(func(ctx context.Context, req *Request) (*Decision, error) {
+			requests = append(requests, req)
+			return decide, nil
+		}),
+		OnDecision: func(ctx context.Context, req *Request, decision *Decision, err error) {
+			decisions = append(decisions, decision)
+		},
+	}
+
+	cases := []struct {
+		name, tool, args string
+		rule             int
+	}{
+		{"tool name", "github_create_issue", `{"title": "bug"}`, 0},
+		{"argument", "sql_query", `{"query": " DELETE FROM users"}`, 1},
+		{"argument not matched", "sql_query", `{"query": "SELECT 1"}`, noMatch},
+		{"nested argument", "k8s_apply", `{"target": {"env": "production"}}`, 2},
+		{"nested argument not matched", "k8s_apply", `{"target": {"env": "staging"}}`, noMatch},
+		{"match", "deploy", `{"force": true}`, 3},
+		{"match not matched", "deploy", `{"force": false}`, noMatch},
+		{"invalid arguments", "sql_query", `{query`, 1},
+		{"argument case", "sql_query", `{"QUERY": "drop table users"}`, 1},
+		{"nested argument case", "k8s_apply", `{"Target": {"ENV": "prod"}}`, 2},
+		{"duplicate arguments", "sql_query", `{"query": "SELECT 1", "Query": "DROP TABLE users"}`, 1},
+		{"nested duplicate arguments", "k8s_apply", `{"target": {"env": "staging", "Env": "prod"}}`, 2},
+		{"no rule", "github_get_file", `{}`, noMatch},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			requests, decisions = nil, nil
+			ft := &fakeTool{name: c.tool}
+			wrapped, err := Wrap(ctx, ft, conf)
+			assert.NoError(t, err)
+
+			result, err := wrapped.InvokableRun(ctx, c.args)
+			assert.NoError(t, err)
+			assert.Equal(t, "done", result)
+			assert.Equal(t, 1, ft.runs)
+			if c.rule == noMatch {
+				assert.Empty(t, requests)
+				return
+			}
+			if assert.Len(t, requests, 1) {
+				assert.Equal(t, c.tool, requests[0].ToolName)
+				assert.Equal(t, c.args, requests[0].Arguments)
+				assert.Equal(t, c.rule, requests[0].Rule)
+				assert.Len(t, requests[0].ID, 16)
+			}
+			assert.Equal(t, []*Decision{decide}, decisions)
+		})
+	}
+
+	t.Run("declared params", func(t *testing.T) {
+		var forced []bool
+		conf := &Config{
+			Rules: []*Rule{
+				{ToolName: "delete_repo", Arguments: map[string]string{"repo": "^prod"}},
+				{ToolName: "deploy", Match: func(ctx context.Context, toolName string, arguments map[string]any) bool {
+					forced = append(forced, arguments["force"] == true)
+					return arguments["force"] == true
+				}},
+			},
+			Approver: conf.Approver,
+		}
+		params := map[string]*schema.ParameterInfo{
+			"repo":  {Type: schema.String},
+			"force": {Type: schema.Boolean},
+		}
+		for args, rule := range map[string]int{
+			`{"repo": "dev-main"}`:                  noMatch,
+			`{"REPO": "prod-main"}`:                 0,
+			`{"repo": "dev-main", "Repo": "prod"}`:  0,
+			`{"repo": "dev-main", "owner": "prod"}`: 0,
+			`{"repo": "dev-main", "force": true}`:   noMatch,
+		} {
+			requests = nil
+			wrapped, err := Wrap(ctx, &fakeTool{name: "delete_repo", params: params}, conf)
+			assert.NoError(t, err)
+			_, err = wrapped.InvokableRun(ctx, args)
+			assert.NoError(t, err)
+			if rule == noMatch {
+				assert.Empty(t, requests, args)
+			} else if assert.Len(t, requests, 1, args) {
+				assert.Equal(t, rule, requests[0].Rule, args)
+			}
+		}
+
+		requests = nil
+		wrapped, err := Wrap(ctx, &fakeTool{name: "deploy", params: params}, conf)
+		assert.NoError(t, err)
+		_, err = wrapped.InvokableRun(ctx, `{"Force": true}`)
+		assert.NoError(t, err)
+		assert.Equal(t, []bool{true}, forced)
+		assert.Len(t, requests, 1)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		decide = &Decision{Approved: false, Reason: "not during the freeze"}
+		ft := &fakeTool{name: "github_create_issue"}
+		wrapped, err := Wrap(ctx, ft, conf)
+		assert.NoError(t, err)
+
+		result, err := wrapped.InvokableRun(ctx, `{}`)
+		assert.NoError(t, err)
+		assert.Equal(t, "invocation of tool github_create_issue is rejected: not during the freeze, do not retry it unless asked to", result)
+		assert.Equal(t, 0, ft.runs)
+
+		strict := *conf
+		strict.ReturnError = true
+		wrapped, err = Wrap(ctx, ft, &strict)
+		assert.NoError(t, err)
+		_, err = wrapped.InvokableRun(ctx, `{}`)
+		var rErr *RejectedError
+		assert.ErrorAs(t, err, &rErr)
+		assert.Equal(t, 0, ft.runs)
+	})
+
+	t.Run("modified arguments", func(t *testing.T) {
+		decide = &Decision{Approved: true, Arguments: `{"title": "bug", "labels": ["triage"]}`}
+		ft := &fakeTool{name: "github_create_issue"}
+		wrapped, err := Wrap(ctx, ft, conf)
+		assert.NoError(t, err)
+		_, err = wrapped.InvokableRun(ctx, `{"title": "bug"}`)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"title": "bug", "labels": ["triage"]}`, ft.got)
+	})
+
+	t.Run("approver error", func(t *testing.T) {
+		ft := &fakeTool{name: "deploy"}
+		wrapped, err := Wrap(ctx, ft, &Config{
+			Approver: ApproverFunc(func(ctx context.Context, req *Request) (*Decision, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}),
+			Timeout: 10 * time.Millisecond,
+		})
+		assert.NoError(t, err)
+		_, err = wrapped.InvokableRun(ctx, `{}`)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.Equal(t, 0, ft.runs)
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		ft := &fakeTool{name: "deploy"}
+		_, err := Wrap(ctx, ft, nil)
+		assert.EqualError(t, err, "config is nil")
+		_, err = Wrap(ctx, ft, &Config{})
+		assert.EqualError(t, err, "approver is required")
+		_, err = Wrap(ctx, ft, &Config{Approver: conf.Approver, Rules: []*Rule{{ToolName: "["}}})
+		assert.EqualError(t, err, "invalid tool name pattern of rule 0: syntax error in pattern")
+		_, err = Wrap(ctx, ft, &Config{Approver: conf.Approver, Rules: []*Rule{{Arguments: map[string]string{"a": "("}}}})
+		assert.ErrorContains(t, err, "invalid argument pattern of rule 0, argument: a")
+	})
+}
+
+func TestWrapAll(t *testing.T) {
+	ctx := context.Background()
+	approver := ApproverFunc(func(ctx context.Context, req *Request) (*Decision, error) {
+		return &Decision{Approved: true}, nil
+	})
+
+	read, write := &fakeTool{name: "github_get_file"}, &fakeTool{name: "github_create_issue"}
+	tools, err := WrapAll(ctx, []tool.BaseTool{read, write}, &Config{
+		Rules:    []*Rule{{ToolName: "github_create_*"}},
+		Approver: approver,
+	})
+	assert.NoError(t, err)
+	assert.Same(t, read, tools[0])
+	assert.IsType(t, &approvalTool{}, tools[1])
+
+	_, err = WrapAll(ctx, []tool.BaseTool{streamTool{}}, &Config{Approver: approver})
+	assert.EqualError(t, err, "tool stream_deploy requires approval, but is not invokable")
+}
@@ -0,0 +1,212 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package approval
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// PendingRequest is a request waiting for the decision on the channel of a ChannelApprover.
+type PendingRequest struct {
+	*Request
+	decision chan *Decision
+}
+
+// Decide decides the request, the later decisions being ignored.
+func (p *PendingRequest) Decide(decision *Decision) {
+	select {
+	case p.decision <- decision:
+	default:
+	}
+}
+
+// ChannelApprover sends the requests on a channel, e.g. to a UI or a chat bot deciding them with PendingRequest.Decide.
+type ChannelApprover struct {
+	requests chan *PendingRequest
+}
+
+// NewChannelApprover creates a ChannelApprover, with the buffer size of its channel.
+func NewChannelApprover(size int) *ChannelApprover {
+	return &ChannelApprover{requests: make(chan *PendingRequest, size)}
+}
+
+// Requests returns the channel of the requests.
+func (c *ChannelApprover) Requests() <-chan *PendingRequest {
+	return c.requests
+}
+
+func (c *ChannelApprover) Approve(ctx context.Context, req *Request) (*Decision, error) {
+	p := &PendingRequest{Request: req, decision: make(chan *Decision, 1)}
+	select {
+	case c.requests <- p:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	select {
+	case d := <-p.decision:
+		return d, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// WebhookConfig is the configuration of a WebhookApprover.
+type WebhookConfig struct {
+	// URL receives the requests as JSON with POST, and responds with the decision as JSON,
+	// e.g. {"approved": false, "reason": "not during the freeze", "approver": "alice"},
+	// holding the response until it is decided.
+	// Required.
+	URL string
+	// Headers are added to the requests, e.g. the authorization of the webhook.
+	// Optional.
+	Headers map[string]string
+	// Secret signs the body of the requests with HMAC-SHA256, in the header X-Signature-256 as "sha256=<hex>".
+	// Optional.
+	Secret string
+	// HTTPClient is the http client sending the requests, its timeout must allow for the human to decide.
+	// Optional. Default http.DefaultClient, Config.Timeout bounding the wait.
+	HTTPClient *http.Client
+}
+
+// WebhookApprover asks a webhook for the decisions, e.g. a service notifying the reviewers on a chat.
+type WebhookApprover struct {
+	conf *WebhookConfig
+}
+
+// NewWebhookApprover creates a WebhookApprover.
+func NewWebhookApprover(conf *WebhookConfig) (*WebhookApprover, error) {
+	if conf == nil {
+		return nil, errors.New("config is nil")
+	}
+	if conf.URL == "" {
+		return nil, errors.New("url is required")
+	}
+	if conf.HTTPClient == nil {
+		conf.HTTPClient = http.DefaultClient
+	}
+	return &WebhookApprover{conf: conf}, nil
+}
+
+func (w *WebhookApprover) Approve(ctx context.Context, req *Request) (*Decision, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, w.conf.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	for k, v := range w.conf.Headers {
+		httpReq.Header.Set(k, v)
+	}
+	if w.conf.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(w.conf.Secret))
+		mac.Write(body)
+		httpReq.Header.Set("X-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := w.conf.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("webhook error, status code: %d, message: %s", resp.StatusCode, respBody)
+	}
+	var decision Decision
+	if err = json.Unmarshal(respBody, &decision); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal decision: %w", err)
+	}
+	return &decision, nil
+}
+
+// CLIApprover asks the decisions on the terminal, e.g. for the agents run locally.
+// The invocation is approved by answering "y" or "yes", any other answer rejecting it with the answer as the reason.
+type CLIApprover struct {
+	in  *bufio.Reader
+	out io.Writer
+	mu  sync.Mutex
+	// pending is the answer being read when the previous question was canceled, to be the answer of the next one
+	pending chan cliAnswer
+}
+
+type cliAnswer struct {
+	line string
+	err  error
+}
+
+// NewCLIApprover creates a CLIApprover reading the answers from in, e.g. os.Stdin, and asking on out, e.g. os.Stdout.
+func NewCLIApprover(in io.Reader, out io.Writer) *CLIApprover {
+	return &CLIApprover{in: bufio.NewReader(in), out: out}
+}
+
+func (c *CLIApprover) Approve(ctx context.Context, req *Request) (*Decision, error) {
+	// one question at a time when the tools run concurrently
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, err := fmt.Fprintf(c.out, "Tool %s requests approval with arguments:\n%s\nApprove? [y/N]: ", req.ToolName, req.Arguments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write question: %w", err)
+	}
+
+	if c.pending == nil {
+		c.pending = make(chan cliAnswer, 1)
+		go func(ch chan<- cliAnswer) {
+			line, err := c.in.ReadString('\n')
+			ch <- cliAnswer{line: line, err: err}
+		}(c.pending)
+	}
+
+	var a cliAnswer
+	select {
+	case a = <-c.pending:
+		c.pending = nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if a.err != nil && (a.err != io.EOF || a.line == "") {
+		return nil, fmt.Errorf("failed to read answer: %w", a.err)
+	}
+
+	line := strings.TrimSpace(a.line)
+	switch strings.ToLower(line) {
+	case "y", "yes":
+		return &Decision{Approved: true, Approver: "cli"}, nil
+	case "", "n", "no":
+		return &Decision{Approved: false, Approver: "cli", Reason: "declined by the user"}, nil
+	default:
+		return &Decision{Approved: false, Approver: "cli", Reason: line}, nil
+	}
+}
@@ -0,0 +1,114 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package approval
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChannelApprover(t *testing.T) {
+	ctx := context.Background()
+	approver := NewChannelApprover(0)
+
+	go func() {
+		p := <-approver.Requests()
+		assert.Equal(t, "deploy", p.ToolName)
+		p.Decide(&Decision{Approved: true, Approver: "bob"})
+		p.Decide(&Decision{Approved: false})
+	}()
+	decision, err := approver.Approve(ctx, &Request{ToolName: "deploy"})
+	assert.NoError(t, err)
+	assert.Equal(t, &Decision{Approved: true, Approver: "bob"}, decision)
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = approver.Approve(ctx, &Request{ToolName: "deploy"})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestWebhookApprover(t *testing.T) {
+	ctx := context.Background()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write(body)
+		assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), r.Header.Get("X-Signature-256"))
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+		var req Request
+		assert.NoError(t, json.Unmarshal(body, &req))
+		if req.ToolName == "broken" {
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte("upstream down"))
+			return
+		}
+		_, _ = w.Write([]byte(`{"approved": false, "reason": "not during the freeze", "approver": "alice"}`))
+	}))
+	defer srv.Close()
+
+	_, err := NewWebhookApprover(&WebhookConfig{})
+	assert.EqualError(t, err, "url is required")
+
+	approver, err := NewWebhookApprover(&WebhookConfig{
+		URL:     srv.URL,
+		Headers: map[string]string{"Authorization": "Bearer token"},
+		Secret:  "secret",
+	})
+	assert.NoError(t, err)
+
+	decision, err := approver.Approve(ctx, &Request{ID: "1", ToolName: "deploy", Arguments: `{}`})
+	assert.NoError(t, err)
+	assert.Equal(t, &Decision{Approved: false, Reason: "not during the freeze", Approver: "alice"}, decision)
+
+	_, err = approver.Approve(ctx, &Request{ToolName: "broken"})
+	assert.EqualError(t, err, "webhook error, status code: 502, message: upstream down")
+}
+
+func TestCLIApprover(t *testing.T) {
+	ctx := context.Background()
+	var out bytes.Buffer
+	approver := NewCLIApprover(strings.NewReader("y\nno\nwrong repo\n"), &out)
+
+	decision, err := approver.Approve(ctx, &Request{ToolName: "deploy", Arguments: `{"env": "prod"}`})
+	assert.NoError(t, err)
+	assert.True(t, decision.Approved)
+	assert.Equal(t, "Tool deploy requests approval with arguments:\n{\"env\": \"prod\"}\nApprove? [y/N]: ", out.String())
+
+	decision, err = approver.Approve(ctx, &Request{ToolName: "deploy"})
+	assert.NoError(t, err)
+	assert.Equal(t, &Decision{Approved: false, Approver: "cli", Reason: "declined by the user"}, decision)
+
+	decision, err = approver.Approve(ctx, &Request{ToolName: "deploy"})
+	assert.NoError(t, err)
+	assert.Equal(t, "wrong repo", decision.Reason)
+
+	_, err = approver.Approve(ctx, &Request{ToolName: "deploy"})
+	assert.ErrorIs(t, err, io.EOF)
+}
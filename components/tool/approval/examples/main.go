@@ -0,0 +1,61 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/components/tool/utils"
+
+	"github.com/cloudwego/eino-ext/components/tool/approval"
+)
+
+type queryRequest struct {
+	Query string `json:"query" jsonschema:"description=the SQL statement to run"`
+}
+
+func main() {
+	ctx := context.Background()
+
+	sqlTool, err := utils.InferTool("sql_execute", "run a SQL statement", func(ctx context.Context, req *queryRequest) (string, error) {
+		return "executed: " + req.Query, nil
+	})
+	if err != nil {
+		log.Fatalf("InferTool failed, err=%v", err)
+	}
+
+	wrapped, err := approval.Wrap(ctx, sqlTool, &approval.Config{
+		Rules: []*approval.Rule{
+			// only the statements changing the data require an approval
+			{ToolName: "sql_*", Arguments: map[string]string{"query": `(?i)^\s*(insert|update|delete|drop|alter|truncate)`}},
+		},
+		Approver: approval.NewCLIApprover(os.Stdin, os.Stdout),
+	})
+	if err != nil {
+		log.Fatalf("Wrap failed, err=%v", err)
+	}
+
+	for _, query := range []string{"SELECT * FROM users", "DELETE FROM users WHERE id = 1"} {
+		result, err := wrapped.InvokableRun(ctx, fmt.Sprintf(`{"query": %q}`, query))
+		if err != nil {
+			log.Fatalf("InvokableRun failed, err=%v", err)
+		}
+		fmt.Println(result)
+	}
+}
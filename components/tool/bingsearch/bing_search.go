@@ -30,6 +30,7 @@ import (
 type Region string
 type SafeSearch string
 type TimeRange string
+type AnswerType string
 
 const (
 	// Regions settings
@@ -54,6 +55,10 @@ const (
 	TimeRangeDay   TimeRange = "Day"
 	TimeRangeWeek  TimeRange = "Week"
 	TimeRangeMonth TimeRange = "Month"
+
+	// AnswerType settings
+	AnswerTypeWebPages AnswerType = "webpages"
+	AnswerTypeNews     AnswerType = "news"
 )
 
 // Config represents the Bing search tool configuration.
@@ -82,6 +87,10 @@ type Config struct {
 	// Optional, default: ""
 	TimeRange TimeRange `json:"time_range"`
 
+	// AnswerTypes specifies the answer types to return, webpages and/or news.
+	// Optional, default: nil (decided by Bing)
+	AnswerTypes []AnswerType `json:"answer_types"`
+
 	// Bing client settings
 	// Headers specifies custom HTTP headers to be sent with each request.
 	// Common headers like "User-Agent" can be set here.
@@ -195,14 +204,28 @@ func newBingSearch(config *Config) (*bingSearch, error) {
 }
 
 type SearchRequest struct {
-	Query  string `json:"query" jsonschema:"description=The query to search the web for"`
-	Offset int    `json:"page" jsonschema:"description=The index of the first result to return, default is 0"`
+	Query       string       `json:"query" jsonschema:"description=The query to search the web for"`
+	Offset      int          `json:"page" jsonschema:"description=The index of the first result to return, default is 0"`
+	Market      Region       `json:"market,omitempty" jsonschema:"description=The market code the results come from like en-US or zh-CN. Default is the configured region"`
+	Freshness   TimeRange    `json:"freshness,omitempty" jsonschema:"description=Only return results discovered within this time range: Day or Week or Month or a date range like 2019-02-01..2019-05-30"`
+	SafeSearch  SafeSearch   `json:"safe_search,omitempty" jsonschema:"description=Filter adult content from the results. Default is the configured setting,enum=Off,enum=Moderate,enum=Strict"`
+	AnswerTypes []AnswerType `json:"answer_types,omitempty" jsonschema:"description=The answer types to return. Default is the configured setting,enum=webpages,enum=news"`
+}
+
+type DeepLink struct {
+	Title       string `json:"title" jsonschema:"description=The title of the deep link"`
+	URL         string `json:"url" jsonschema:"description=The link of the deep link"`
+	Description string `json:"description,omitempty" jsonschema:"description=The description of the deep link"`
 }
 
 type SearchResult struct {
-	Title       string `json:"title" jsonschema:"description=The title of the search result"`
-	URL         string `json:"url" jsonschema:"description=The link of the search result"`
-	Description string `json:"description" jsonschema:"description=The description of the search result"`
+	Type        string      `json:"type" jsonschema:"description=The answer type of the search result: webpage or news"`
+	Title       string      `json:"title" jsonschema:"description=The title of the search result"`
+	URL         string      `json:"url" jsonschema:"description=The link of the search result"`
+	Description string      `json:"description" jsonschema:"description=The description of the search result"`
+	Date        string      `json:"date,omitempty" jsonschema:"description=The publish date of the search result or the date it was last crawled"`
+	Source      string      `json:"source,omitempty" jsonschema:"description=The provider of the news"`
+	DeepLinks   []*DeepLink `json:"deep_links,omitempty" jsonschema:"description=The links to the sub pages of the search result"`
 }
 
 type SearchResponse struct {
@@ -211,15 +234,43 @@ type SearchResponse struct {
 
 // Search searches the web for information.
 func (s *bingSearch) Search(ctx context.Context, request *SearchRequest) (response *SearchResponse, err error) {
-	// Search the web for information
-	searchResults, err := s.client.Search(ctx, &bingcore.SearchParams{
+	params := &bingcore.SearchParams{
 		Query:      request.Query,
 		Region:     bingcore.Region(s.config.Region),
 		SafeSearch: bingcore.SafeSearch(s.config.SafeSearch),
 		TimeRange:  bingcore.TimeRange(s.config.TimeRange),
 		Offset:     request.Offset,
 		Count:      s.config.MaxResults,
-	})
+	}
+
+	// Parameters set by the model override the config
+	if request.Market != "" {
+		params.Region = bingcore.Region(request.Market)
+	}
+	if request.Freshness != "" {
+		params.TimeRange = bingcore.TimeRange(request.Freshness)
+	}
+	if request.SafeSearch != "" {
+		params.SafeSearch = bingcore.SafeSearch(request.SafeSearch)
+	}
+
+	answerTypes := s.config.AnswerTypes
+	if len(request.AnswerTypes) > 0 {
+		answerTypes = request.AnswerTypes
+	}
+	for _, t := range answerTypes {
+		switch t {
+		case AnswerTypeWebPages:
+			params.AnswerTypes = append(params.AnswerTypes, bingcore.AnswerTypeWebPages)
+		case AnswerTypeNews:
+			params.AnswerTypes = append(params.AnswerTypes, bingcore.AnswerTypeNews)
+		default:
+			return nil, fmt.Errorf("invalid answer type: %s, must be one of webpages, news", t)
+		}
+	}
+
+	// Search the web for information
+	searchResults, err := s.client.Search(ctx, params)
 	if err != nil {
 		return nil, err
 	}
@@ -227,11 +278,22 @@ func (s *bingSearch) Search(ctx context.Context, request *SearchRequest) (respon
 	// Convert search results to search response
 	results := make([]*SearchResult, 0, len(searchResults))
 	for _, r := range searchResults {
-		results = append(results, &SearchResult{
+		result := &SearchResult{
+			Type:        r.Type,
 			Title:       r.Title,
 			URL:         r.URL,
 			Description: r.Description,
-		})
+			Date:        r.Date,
+			Source:      r.Source,
+		}
+		for _, link := range r.DeepLinks {
+			result.DeepLinks = append(result.DeepLinks, &DeepLink{
+				Title:       link.Title,
+				URL:         link.URL,
+				Description: link.Description,
+			})
+		}
+		results = append(results, result)
 	}
 
 	return &SearchResponse{
@@ -220,7 +220,7 @@ func TestNewTool(t *testing.T) {
 
 			doc, err := info.ParamsOneOf.ToJSONSchema()
 			assert.Nil(t, err)
-			assert.Equal(t, 2, doc.Properties.Len())
+			assert.Equal(t, 6, doc.Properties.Len())
 			for pair := doc.Properties.Oldest(); pair != nil; pair = pair.Next() {
 				assert.NotEqual(t, "", pair.Value.Description)
 			}
@@ -254,6 +254,36 @@ func Test_bingSearch_Search(t *testing.T) {
 			wantResponse: nil,
 			wantErr:      true,
 		},
+		{
+			name: "Test_bingSearch_Invalid_Answer_Type",
+			fields: &Config{
+				APIKey: "api_key_to_test",
+			},
+			args: args{
+				ctx: context.Background(),
+				request: &SearchRequest{
+					Query:       "test",
+					AnswerTypes: []AnswerType{"images"},
+				},
+			},
+			wantResponse: nil,
+			wantErr:      true,
+		},
+		{
+			name: "Test_bingSearch_Invalid_Safe_Search",
+			fields: &Config{
+				APIKey: "api_key_to_test",
+			},
+			args: args{
+				ctx: context.Background(),
+				request: &SearchRequest{
+					Query:      "test",
+					SafeSearch: "None",
+				},
+			},
+			wantResponse: nil,
+			wantErr:      true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
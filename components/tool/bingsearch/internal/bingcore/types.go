@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -67,6 +68,15 @@ const (
 	TimeRangeMonth TimeRange = "Month"
 )
 
+// AnswerType This type represents the Bing answer type to include in the response.
+// Please refer to https://learn.microsoft.com/en-us/bing/search-apis/bing-web-search/reference/query-parameters#responsefilter
+type AnswerType string
+
+const (
+	AnswerTypeWebPages AnswerType = "Webpages"
+	AnswerTypeNews     AnswerType = "News"
+)
+
 // SearchParams This struct represents the search parameters for the Bing Web Search API.
 // The search parameters include the search query, region, safe search setting, time range, offset, and count.
 // The search parameters are used to customize the search results.
@@ -86,8 +96,13 @@ type SearchParams struct {
 
 	// TimeRange specifies the time range for the search results.
 	// The time range filters the search results by the date they were last crawled.
+	// Besides Day, Week and Month, a date range like "2019-02-01..2019-05-30" or a single date like "2019-02-04" is also accepted.
 	TimeRange TimeRange `json:"freshness"`
 
+	// AnswerTypes specifies the answer types to include in the response.
+	// Default is empty, which means Bing decides, and webpages and news are parsed from the response.
+	AnswerTypes []AnswerType `json:"response_filter"`
+
 	// Offset specifies the search result offset.
 	// The search result offset is the number of search results to skip before returning the search results.
 	// Default is 0 and must be greater than 0.
@@ -104,12 +119,13 @@ type SearchParams struct {
 // NextPage NewSearchParams creates a new SearchParams instance.
 func (s *SearchParams) NextPage() *SearchParams {
 	return &SearchParams{
-		Query:      s.Query,
-		Region:     s.Region,
-		SafeSearch: s.SafeSearch,
-		TimeRange:  s.TimeRange,
-		Offset:     s.Offset + 1,
-		Count:      s.Count,
+		Query:       s.Query,
+		Region:      s.Region,
+		SafeSearch:  s.SafeSearch,
+		TimeRange:   s.TimeRange,
+		AnswerTypes: s.AnswerTypes,
+		Offset:      s.Offset + 1,
+		Count:       s.Count,
 	}
 }
 
@@ -134,6 +150,14 @@ func (s *SearchParams) build() url.Values {
 		params.Set("safeSearch", string(s.SafeSearch))
 	}
 
+	if len(s.AnswerTypes) > 0 {
+		types := make([]string, 0, len(s.AnswerTypes))
+		for _, t := range s.AnswerTypes {
+			types = append(types, string(t))
+		}
+		params.Set("responseFilter", strings.Join(types, ","))
+	}
+
 	return params
 }
 
@@ -160,8 +184,18 @@ func (s *SearchParams) validate() error {
 		return fmt.Errorf("search count must be greater than 0")
 	}
 
-	if s.SafeSearch == "" {
+	switch s.SafeSearch {
+	case "":
 		s.SafeSearch = SafeSearchModerate
+	case SafeSearchOff, SafeSearchModerate, SafeSearchStrict:
+	default:
+		return fmt.Errorf("invalid safe search: %s, must be one of Off, Moderate, Strict", s.SafeSearch)
+	}
+
+	for _, t := range s.AnswerTypes {
+		if t != AnswerTypeWebPages && t != AnswerTypeNews {
+			return fmt.Errorf("invalid answer type: %s, must be one of Webpages, News", t)
+		}
 	}
 
 	if s.Count == 0 {
@@ -177,11 +211,27 @@ func (s *SearchParams) validate() error {
 
 // searchResult This struct formats the search results provided by the Bing Web Search API.
 type searchResult struct {
+	Type        string      `json:"type"`
+	Title       string      `json:"title"`
+	URL         string      `json:"url"`
+	Description string      `json:"description"`
+	Date        string      `json:"date,omitempty"`
+	Source      string      `json:"source,omitempty"`
+	DeepLinks   []*deepLink `json:"deep_links,omitempty"`
+}
+
+// deepLink This struct formats the deep links of a webpage, eg. the sub pages of a site.
+type deepLink struct {
 	Title       string `json:"title"`
 	URL         string `json:"url"`
-	Description string `json:"description"`
+	Description string `json:"description,omitempty"`
 }
 
+const (
+	resultTypeWebPage = "webpage"
+	resultTypeNews    = "news"
+)
+
 // bingAnswer This struct formats the answers provided by the Bing Web Search API.
 type bingAnswer struct {
 	Type         string `json:"_type"`
@@ -199,7 +249,13 @@ type bingAnswer struct {
 			DisplayURL       string    `json:"displayUrl"`
 			Snippet          string    `json:"snippet"`
 			DateLastCrawled  time.Time `json:"dateLastCrawled"`
-			SearchTags       []struct {
+			DatePublished    string    `json:"datePublished,omitempty"`
+			DeepLinks        []struct {
+				Name    string `json:"name"`
+				URL     string `json:"url"`
+				Snippet string `json:"snippet,omitempty"`
+			} `json:"deepLinks,omitempty"`
+			SearchTags []struct {
 				Name    string `json:"name"`
 				Content string `json:"content"`
 			} `json:"searchTags,omitempty"`
@@ -208,6 +264,18 @@ type bingAnswer struct {
 			} `json:"about,omitempty"`
 		} `json:"value"`
 	} `json:"webPages"`
+	News struct {
+		ID    string `json:"id"`
+		Value []struct {
+			Name          string `json:"name"`
+			URL           string `json:"url"`
+			Description   string `json:"description"`
+			DatePublished string `json:"datePublished"`
+			Provider      []struct {
+				Name string `json:"name"`
+			} `json:"provider,omitempty"`
+		} `json:"value"`
+	} `json:"news"`
 	RelatedSearches struct {
 		ID    string `json:"id"`
 		Value []struct {
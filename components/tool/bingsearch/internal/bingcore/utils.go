@@ -18,6 +18,7 @@ package bingcore
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/bytedance/sonic"
 )
@@ -32,14 +33,41 @@ func parseSearchResponse(body []byte) ([]*searchResult, error) {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
-	// Convert response to search results
-	results := make([]*searchResult, 0, len(response.WebPages.Value))
+	// Convert response to search results, webpages first and then news
+	results := make([]*searchResult, 0, len(response.WebPages.Value)+len(response.News.Value))
 	for _, resp := range response.WebPages.Value {
-		results = append(results, &searchResult{
+		result := &searchResult{
+			Type:        resultTypeWebPage,
 			Title:       resp.Name,
 			URL:         resp.URL,
 			Description: resp.Snippet,
-		})
+			Date:        resp.DatePublished,
+		}
+		if result.Date == "" && !resp.DateLastCrawled.IsZero() {
+			result.Date = resp.DateLastCrawled.Format(time.RFC3339)
+		}
+		for _, link := range resp.DeepLinks {
+			result.DeepLinks = append(result.DeepLinks, &deepLink{
+				Title:       link.Name,
+				URL:         link.URL,
+				Description: link.Snippet,
+			})
+		}
+		results = append(results, result)
+	}
+
+	for _, resp := range response.News.Value {
+		result := &searchResult{
+			Type:        resultTypeNews,
+			Title:       resp.Name,
+			URL:         resp.URL,
+			Description: resp.Description,
+			Date:        resp.DatePublished,
+		}
+		if len(resp.Provider) > 0 {
+			result.Source = resp.Provider[0].Name
+		}
+		results = append(results, result)
 	}
 	return results, nil
 }
@@ -74,6 +74,40 @@ var response = []byte(`
 }
 `)
 
+var newsResponse = []byte(`
+{
+  "_type": "SearchResponse",
+  "webPages": {
+    "value": [
+      {
+        "name": "Microsoft Edge",
+        "url": "https://www.microsoft.com/edge",
+        "snippet": "Microsoft Edge, now available on ios...",
+        "dateLastCrawled": "2025-01-02T03:04:05Z",
+        "deepLinks": [
+          {
+            "name": "Download",
+            "url": "https://www.microsoft.com/edge/download",
+            "snippet": "Download Microsoft Edge"
+          }
+        ]
+      }
+    ]
+  },
+  "news": {
+    "value": [
+      {
+        "name": "Edge gets a new look",
+        "url": "https://news.example.com/edge",
+        "description": "Microsoft Edge is updated...",
+        "datePublished": "2025-01-03T00:00:00.0000000Z",
+        "provider": [{"name": "Example News"}]
+      }
+    ]
+  }
+}
+`)
+
 func Test_parseSearchResponse(t *testing.T) {
 	type args struct {
 		body []byte
@@ -91,17 +125,49 @@ func Test_parseSearchResponse(t *testing.T) {
 			},
 			want: []*searchResult{
 				{
+					Type:        resultTypeWebPage,
 					Title:       "The Better Web Browser for Windows...",
 					URL:         "https://ww.microsoft.com/en-us/...",
 					Description: "Microsoft Edge, now available on ios...",
 				},
 				{
+					Type:        resultTypeWebPage,
 					Title:       "Microsoft Edge",
 					URL:         "https://ww.microsoft.com/en-us/...",
 					Description: "Microsoft Edge, now available on ios...",
 				},
 			},
 			wantErr: false,
+		}, {
+			name: "Test_parseSearchResponse_DeepLinks_And_News",
+			args: args{
+				body: newsResponse,
+			},
+			want: []*searchResult{
+				{
+					Type:        resultTypeWebPage,
+					Title:       "Microsoft Edge",
+					URL:         "https://www.microsoft.com/edge",
+					Description: "Microsoft Edge, now available on ios...",
+					Date:        "2025-01-02T03:04:05Z",
+					DeepLinks: []*deepLink{
+						{
+							Title:       "Download",
+							URL:         "https://www.microsoft.com/edge/download",
+							Description: "Download Microsoft Edge",
+						},
+					},
+				},
+				{
+					Type:        resultTypeNews,
+					Title:       "Edge gets a new look",
+					URL:         "https://news.example.com/edge",
+					Description: "Microsoft Edge is updated...",
+					Date:        "2025-01-03T00:00:00.0000000Z",
+					Source:      "Example News",
+				},
+			},
+			wantErr: false,
 		}, {
 			name: "Test_parseSearchResponse_JSON_Error",
 			args: args{
@@ -124,3 +190,61 @@ func Test_parseSearchResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestSearchParams_build(t *testing.T) {
+	params := &SearchParams{
+		Query:       "edge",
+		Region:      RegionUS,
+		TimeRange:   "2019-02-01..2019-05-30",
+		AnswerTypes: []AnswerType{AnswerTypeWebPages, AnswerTypeNews},
+	}
+	if err := params.validate(); err != nil {
+		t.Fatalf("validate() error = %v", err)
+	}
+
+	values := params.build()
+	want := map[string]string{
+		"q":              "edge",
+		"mkt":            "en-US",
+		"freshness":      "2019-02-01..2019-05-30",
+		"safeSearch":     "Moderate",
+		"responseFilter": "Webpages,News",
+		"count":          "10",
+	}
+	for k, v := range want {
+		if got := values.Get(k); got != v {
+			t.Errorf("build() %s = %v, want %v", k, got, v)
+		}
+	}
+}
+
+func TestSearchParams_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  *SearchParams
+		wantErr bool
+	}{
+		{
+			name:    "TestSearchParams_validate_Invalid_SafeSearch",
+			params:  &SearchParams{Query: "edge", SafeSearch: "None"},
+			wantErr: true,
+		},
+		{
+			name:    "TestSearchParams_validate_Invalid_AnswerType",
+			params:  &SearchParams{Query: "edge", AnswerTypes: []AnswerType{"Images"}},
+			wantErr: true,
+		},
+		{
+			name:    "TestSearchParams_validate_Valid",
+			params:  &SearchParams{Query: "edge", SafeSearch: SafeSearchStrict, AnswerTypes: []AnswerType{AnswerTypeNews}},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.params.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
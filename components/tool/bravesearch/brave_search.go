@@ -0,0 +1,301 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package bravesearch provides a tool searching the web with the Brave Search API.
+package bravesearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+)
+
+// SafeSearch is the filter of adult content.
+type SafeSearch string
+
+const (
+	SafeSearchOff      SafeSearch = "off"
+	SafeSearchModerate SafeSearch = "moderate"
+	SafeSearchStrict   SafeSearch = "strict"
+)
+
+// Freshness restricts the results to the pages discovered within a time range.
+type Freshness string
+
+const (
+	FreshnessDay   Freshness = "day"
+	FreshnessWeek  Freshness = "week"
+	FreshnessMonth Freshness = "month"
+	FreshnessYear  Freshness = "year"
+)
+
+// freshnessParams maps the freshness to the values of the freshness parameter of the API.
+var freshnessParams = map[Freshness]string{
+	FreshnessDay:   "pd",
+	FreshnessWeek:  "pw",
+	FreshnessMonth: "pm",
+	FreshnessYear:  "py",
+}
+
+const (
+	// maxCount is the maximum number of results of a page allowed by the API.
+	maxCount = 20
+	// maxPage is the maximum page number allowed by the API, whose offset is at most 9.
+	maxPage = 10
+)
+
+// Config is the configuration for the brave search tool.
+type Config struct {
+	// APIKey is the subscription token of the Brave Search API.
+	// Required.
+	APIKey string
+	// BaseURL is the url of the web search endpoint.
+	// Optional. Default "https://api.search.brave.com/res/v1/web/search".
+	BaseURL string
+	// HTTPClient is the http client sending the requests.
+	// Optional. Default a client with Timeout.
+	HTTPClient *http.Client
+	// Timeout is the maximum duration of each request.
+	// Optional. Default 30s.
+	Timeout time.Duration
+
+	// MaxResults is the number of results of a search, between 1 and 20.
+	// Optional. Default 10.
+	MaxResults int
+	// Country is the 2 letter country code the results come from, e.g. "us".
+	// Optional.
+	Country string
+	// SearchLang is the language code of the results, e.g. "en".
+	// Optional.
+	SearchLang string
+	// SafeSearch is the filter of adult content.
+	// Optional. Default SafeSearchModerate.
+	SafeSearch SafeSearch
+	// Freshness is the default time range of the searches, the model can override it per search.
+	// Optional. Default no restriction.
+	Freshness Freshness
+	// ExtraSnippets appends the additional excerpts Brave returns for a page to its snippet, which requires a paid plan.
+	// Optional. Default false.
+	ExtraSnippets bool
+
+	ToolName string // Optional. Default "brave_search".
+	ToolDesc string // Optional. Default "search web for information by brave search".
+}
+
+// NewTool creates a new brave search tool.
+func NewTool(ctx context.Context, conf *Config) (tool.InvokableTool, error) {
+	if err := conf.validate(); err != nil {
+		return nil, err
+	}
+	b := &braveSearch{conf: conf}
+	t, err := utils.InferTool(conf.ToolName, conf.ToolDesc, b.Search)
+	if err != nil {
+		return nil, fmt.Errorf("failed to infer tool: %w", err)
+	}
+	return t, nil
+}
+
+// validate validates the configuration and sets default values if not provided.
+func (conf *Config) validate() error {
+	if conf == nil {
+		return fmt.Errorf("config is nil")
+	}
+	if conf.APIKey == "" {
+		return fmt.Errorf("api key is required")
+	}
+	if conf.BaseURL == "" {
+		conf.BaseURL = "https://api.search.brave.com/res/v1/web/search"
+	}
+	if conf.Timeout <= 0 {
+		conf.Timeout = 30 * time.Second
+	}
+	if conf.HTTPClient == nil {
+		conf.HTTPClient = &http.Client{Timeout: conf.Timeout}
+	}
+	if conf.MaxResults <= 0 {
+		conf.MaxResults = 10
+	}
+	if conf.MaxResults > maxCount {
+		return fmt.Errorf("max results must be at most %d", maxCount)
+	}
+	switch conf.SafeSearch {
+	case "":
+		conf.SafeSearch = SafeSearchModerate
+	case SafeSearchOff, SafeSearchModerate, SafeSearchStrict:
+	default:
+		return fmt.Errorf("unsupported safe search: %s", conf.SafeSearch)
+	}
+	if _, ok := freshnessParams[conf.Freshness]; conf.Freshness != "" && !ok {
+		return fmt.Errorf("unsupported freshness: %s", conf.Freshness)
+	}
+	if conf.ToolName == "" {
+		conf.ToolName = "brave_search"
+	}
+	if conf.ToolDesc == "" {
+		conf.ToolDesc = "search web for information by brave search"
+	}
+	return nil
+}
+
+// SearchRequest is the request of the brave search tool.
+type SearchRequest struct {
+	Query     string    `json:"query" jsonschema:"required,description=The query to search the web for"`
+	Page      int       `json:"page,omitempty" jsonschema:"description=The page number of the results, between 1 and 10, default: 1"`
+	Freshness Freshness `json:"freshness,omitempty" jsonschema:"description=Only return pages discovered within this time range,enum=day,enum=week,enum=month,enum=year"`
+}
+
+// SearchResult is a page found by a search.
+type SearchResult struct {
+	Title         string `json:"title" jsonschema:"description=The title of the search result"`
+	URL           string `json:"url" jsonschema:"description=The link of the search result"`
+	Snippet       string `json:"snippet" jsonschema:"description=The snippet of the search result"`
+	PublishedDate string `json:"published_date,omitempty" jsonschema:"description=The publication date of the search result"`
+}
+
+// SearchResponse is the response of the brave search tool.
+type SearchResponse struct {
+	Query   string          `json:"query" jsonschema:"description=The query of the search"`
+	Results []*SearchResult `json:"results" jsonschema:"description=The results of the search"`
+}
+
+type braveSearch struct {
+	conf *Config
+}
+
+type webSearchResponse struct {
+	Query struct {
+		Original string `json:"original"`
+	} `json:"query"`
+	Web struct {
+		Results []struct {
+			Title         string   `json:"title"`
+			URL           string   `json:"url"`
+			Description   string   `json:"description"`
+			PageAge       string   `json:"page_age"`
+			Age           string   `json:"age"`
+			ExtraSnippets []string `json:"extra_snippets"`
+		} `json:"results"`
+	} `json:"web"`
+}
+
+// Search searches the web with the Brave Search API.
+func (b *braveSearch) Search(ctx context.Context, req *SearchRequest) (*SearchResponse, error) {
+	if strings.TrimSpace(req.Query) == "" {
+		return nil, errors.New("query is required")
+	}
+	if req.Page < 0 || req.Page > maxPage {
+		return nil, fmt.Errorf("page must be between 1 and %d", maxPage)
+	}
+	freshness := b.conf.Freshness
+	if req.Freshness != "" {
+		freshness = req.Freshness
+	}
+	if _, ok := freshnessParams[freshness]; freshness != "" && !ok {
+		return nil, fmt.Errorf("unsupported freshness: %s", freshness)
+	}
+
+	params := url.Values{}
+	params.Set("q", req.Query)
+	params.Set("count", strconv.Itoa(b.conf.MaxResults))
+	params.Set("safesearch", string(b.conf.SafeSearch))
+	params.Set("text_decorations", "false")
+	if req.Page > 1 {
+		params.Set("offset", strconv.Itoa(req.Page-1))
+	}
+	if freshness != "" {
+		params.Set("freshness", freshnessParams[freshness])
+	}
+	if b.conf.Country != "" {
+		params.Set("country", b.conf.Country)
+	}
+	if b.conf.SearchLang != "" {
+		params.Set("search_lang", b.conf.SearchLang)
+	}
+	if b.conf.ExtraSnippets {
+		params.Set("extra_snippets", "true")
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, b.conf.BaseURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.Header.Set("X-Subscription-Token", b.conf.APIKey)
+
+	resp, err := b.conf.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("brave search api error, status code: %d, message: %s", resp.StatusCode, errorMessage(body))
+	}
+
+	var res webSearchResponse
+	if err = json.Unmarshal(body, &res); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	ret := &SearchResponse{
+		Query:   res.Query.Original,
+		Results: make([]*SearchResult, 0, len(res.Web.Results)),
+	}
+	for _, r := range res.Web.Results {
+		snippet := r.Description
+		if len(r.ExtraSnippets) > 0 {
+			snippet = strings.Join(append([]string{snippet}, r.ExtraSnippets...), "\n")
+		}
+		publishedDate := r.PageAge
+		if publishedDate == "" {
+			publishedDate = r.Age
+		}
+		ret.Results = append(ret.Results, &SearchResult{
+			Title:         r.Title,
+			URL:           r.URL,
+			Snippet:       snippet,
+			PublishedDate: publishedDate,
+		})
+	}
+	return ret, nil
+}
+
+// errorMessage returns the detail of an error response, {"error":{"detail":"..."}}, or the body if it has another format.
+func errorMessage(body []byte) string {
+	var e struct {
+		Error struct {
+			Detail string `json:"detail"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &e); err == nil && e.Error.Detail != "" {
+		return e.Error.Detail
+	}
+	return string(body)
+}
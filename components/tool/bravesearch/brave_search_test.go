@@ -0,0 +1,130 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bravesearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTool(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewTool(ctx, nil)
+	assert.EqualError(t, err, "config is nil")
+	_, err = NewTool(ctx, &Config{})
+	assert.EqualError(t, err, "api key is required")
+	_, err = NewTool(ctx, &Config{APIKey: "key", MaxResults: 21})
+	assert.EqualError(t, err, "max results must be at most 20")
+	_, err = NewTool(ctx, &Config{APIKey: "key", Freshness: "hour"})
+	assert.EqualError(t, err, "unsupported freshness: hour")
+	_, err = NewTool(ctx, &Config{APIKey: "key", SafeSearch: "none"})
+	assert.EqualError(t, err, "unsupported safe search: none")
+
+	tl, err := NewTool(ctx, &Config{APIKey: "key"})
+	assert.NoError(t, err)
+	info, err := tl.Info(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "brave_search", info.Name)
+}
+
+func TestSearch(t *testing.T) {
+	ctx := context.Background()
+
+	var query url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "key", r.Header.Get("X-Subscription-Token"))
+		query = r.URL.Query()
+		if query.Get("q") == "quota" {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"type":"ErrorResponse","error":{"code":"RATE_LIMITED","detail":"Request rate limit exceeded for plan.","status":429}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{
+			"type": "search",
+			"query": {"original": "eino framework"},
+			"web": {"results": [
+				{"title": "CloudWeGo Eino", "url": "https://github.com/cloudwego/eino", "description": "The ultimate LLM application development framework in Go.", "page_age": "2025-02-11T08:00:00", "age": "February 11, 2025", "extra_snippets": ["Eino provides components and orchestration."]},
+				{"title": "Eino docs", "url": "https://www.cloudwego.io/docs/eino/", "description": "Eino documentation.", "age": "2 days ago"}
+			]}
+		}`))
+	}))
+	defer srv.Close()
+
+	tl, err := NewTool(ctx, &Config{
+		APIKey:     "key",
+		BaseURL:    srv.URL,
+		MaxResults: 5,
+		Country:    "us",
+		SearchLang: "en",
+		Freshness:  FreshnessYear,
+	})
+	assert.NoError(t, err)
+
+	out, err := tl.InvokableRun(ctx, `{"query":"eino framework","page":2,"freshness":"week"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, url.Values{
+		"q":                {"eino framework"},
+		"count":            {"5"},
+		"offset":           {"1"},
+		"safesearch":       {"moderate"},
+		"text_decorations": {"false"},
+		"freshness":        {"pw"},
+		"country":          {"us"},
+		"search_lang":      {"en"},
+	}, query)
+
+	var resp SearchResponse
+	assert.NoError(t, json.Unmarshal([]byte(out), &resp))
+	assert.Equal(t, SearchResponse{
+		Query: "eino framework",
+		Results: []*SearchResult{
+			{
+				Title:         "CloudWeGo Eino",
+				URL:           "https://github.com/cloudwego/eino",
+				Snippet:       "The ultimate LLM application development framework in Go.\nEino provides components and orchestration.",
+				PublishedDate: "2025-02-11T08:00:00",
+			},
+			{
+				Title:         "Eino docs",
+				URL:           "https://www.cloudwego.io/docs/eino/",
+				Snippet:       "Eino documentation.",
+				PublishedDate: "2 days ago",
+			},
+		},
+	}, resp)
+
+	_, err = tl.InvokableRun(ctx, `{"query":"eino"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "py", query.Get("freshness"))
+	assert.False(t, query.Has("offset"))
+
+	_, err = tl.InvokableRun(ctx, `{"query":"quota"}`)
+	assert.ErrorContains(t, err, "brave search api error, status code: 429, message: Request rate limit exceeded for plan.")
+	_, err = tl.InvokableRun(ctx, `{"query":""}`)
+	assert.ErrorContains(t, err, "query is required")
+	_, err = tl.InvokableRun(ctx, `{"query":"eino","page":11}`)
+	assert.ErrorContains(t, err, "page must be between 1 and 10")
+	_, err = tl.InvokableRun(ctx, `{"query":"eino","freshness":"hour"}`)
+	assert.ErrorContains(t, err, "unsupported freshness: hour")
+}
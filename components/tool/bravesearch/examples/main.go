@@ -0,0 +1,44 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino-ext/components/tool/bravesearch"
+)
+
+func main() {
+	ctx := context.Background()
+
+	searchTool, err := bravesearch.NewTool(ctx, &bravesearch.Config{
+		APIKey:     os.Getenv("BRAVE_API_KEY"),
+		MaxResults: 5,
+		SearchLang: "en",
+	})
+	if err != nil {
+		log.Fatalf("NewTool of brave search failed, err=%v", err)
+	}
+
+	out, err := searchTool.InvokableRun(ctx, `{"query":"cloudwego eino","freshness":"month"}`)
+	if err != nil {
+		log.Fatalf("search failed, err=%v", err)
+	}
+	log.Println(out)
+}
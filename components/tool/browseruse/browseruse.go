@@ -49,14 +49,19 @@ Element Interaction:
 - 'click_element': Click an element by index
 - 'input_text': Input text into a form element
 - 'scroll_down'/'scroll_up': Scroll the page (with optional pixel amount)
+- 'scroll_to_element': Scroll an element into view by index
 Content Extraction:
 - 'extract_content': Extract page content to retrieve specific information from the page, e.g.all company names, a specific description, links with companies in structured format or simply links
+- 'snapshot': Get the accessibility tree of the page, interactive elements being prefixed with their index
+- 'screenshot': Take a screenshot of the viewport, or of the full page
 Tab Management:
 - 'switch_tab': Switch to a specific tab
 - 'open_tab': Open a new tab with a URL
 - 'close_tab': Close the current tab
 Utility:
 - 'wait': Wait for a specified number of seconds
+- 'wait_for_selector': Wait until an element matching a CSS selector is visible (with optional timeout in seconds)
+- 'list_downloads': List the files downloaded by the browser and their state
 `
 
 	extractContentPrompt = `
@@ -78,6 +83,14 @@ type Config struct {
 	DDGSearchTool    duckduckgo.Search
 	ExtractChatModel model.BaseChatModel
 
+	// DownloadDir enables the downloads, saved to this directory. Optional, downloads are denied by default.
+	DownloadDir string
+	// ScreenshotQuality is the JPEG quality of the screenshots, from 1 to 99, which are much smaller than PNG for vision models.
+	// Optional, default 0 for PNG.
+	ScreenshotQuality int
+	// MaxSnapshotLength is the maximum length in bytes of the accessibility tree snapshots. Optional, default 20000.
+	MaxSnapshotLength int
+
 	Logf func(string, ...any)
 }
 
@@ -111,10 +124,11 @@ type ScrollInfo struct {
 }
 
 type ElementInfo struct {
-	Index       int    `json:"index"`
-	Description string `json:"description"`
-	Type        string `json:"type"`
-	XPath       string `json:"xpath"`
+	Index         int               `json:"index"`
+	Description   string            `json:"description"`
+	Type          string            `json:"type"`
+	XPath         string            `json:"xpath"`
+	BackendNodeID cdp.BackendNodeID `json:"-"`
 }
 
 type Tool struct {
@@ -130,6 +144,10 @@ type Tool struct {
 	searchTool      duckduckgo.Search
 	cm              model.BaseChatModel
 	tpl             prompt.ChatTemplate
+
+	downloads         *downloads
+	screenshotQuality int
+	maxSnapshotLength int
 }
 
 func (b *Tool) Info(_ context.Context) (*schema.ToolInfo, error) {
@@ -173,13 +191,18 @@ func NewBrowserUseTool(ctx context.Context, config *Config) (*Tool, error) {
 										string(ActionInputText),
 										string(ActionScrollDown),
 										string(ActionScrollUp),
+										string(ActionScrollToElement),
 										//string(ActionSendKeys),
 										string(ActionWebSearch),
 										string(ActionWait),
+										string(ActionWaitForSelector),
 										string(ActionExtractContent),
+										string(ActionSnapshot),
+										string(ActionScreenshot),
 										string(ActionSwitchTab),
 										string(ActionOpenTab),
 										string(ActionCloseTab),
+										string(ActionListDownloads),
 									},
 									Description: "The browser action to perform",
 								},
@@ -195,7 +218,7 @@ func NewBrowserUseTool(ctx context.Context, config *Config) (*Tool, error) {
 								Key: "index",
 								Value: &jsonschema.Schema{
 									Type:        string(schema.Integer),
-									Description: "Element index for 'click_element', 'input_text' actions",
+									Description: "Element index for 'click_element', 'input_text', 'scroll_to_element' actions",
 								},
 							},
 							orderedmap.Pair[string, *jsonschema.Schema]{
@@ -244,7 +267,21 @@ func NewBrowserUseTool(ctx context.Context, config *Config) (*Tool, error) {
 								Key: "seconds",
 								Value: &jsonschema.Schema{
 									Type:        string(schema.Integer),
-									Description: "Seconds to wait for 'wait' action",
+									Description: "Seconds to wait for 'wait' action, or timeout in seconds for 'wait_for_selector' action (default 10)",
+								},
+							},
+							orderedmap.Pair[string, *jsonschema.Schema]{
+								Key: "selector",
+								Value: &jsonschema.Schema{
+									Type:        string(schema.String),
+									Description: "CSS selector for 'wait_for_selector' action",
+								},
+							},
+							orderedmap.Pair[string, *jsonschema.Schema]{
+								Key: "full_page",
+								Value: &jsonschema.Schema{
+									Type:        string(schema.Boolean),
+									Description: "Capture the full page instead of the viewport for 'screenshot' action",
 								},
 							},
 						),
@@ -256,6 +293,12 @@ func NewBrowserUseTool(ctx context.Context, config *Config) (*Tool, error) {
 		searchTool: config.DDGSearchTool,
 		cm:         config.ExtractChatModel,
 		tpl:        prompt.FromMessages(schema.FString, schema.UserMessage(extractContentPrompt)),
+
+		screenshotQuality: config.ScreenshotQuality,
+		maxSnapshotLength: config.MaxSnapshotLength,
+	}
+	if but.maxSnapshotLength <= 0 {
+		but.maxSnapshotLength = defaultMaxSnapshotLength
 	}
 
 	err := but.initialize(ctx, config)
@@ -324,6 +367,12 @@ func (b *Tool) initialize(ctx context.Context, config *Config) error {
 		return fmt.Errorf("failed to update tab info: %v", err)
 	}
 
+	if config.DownloadDir != "" {
+		if err := b.enableDownloads(b.ctx, config.DownloadDir); err != nil {
+			return fmt.Errorf("failed to enable downloads: %v", err)
+		}
+	}
+
 	return nil
 }
 
@@ -360,6 +409,8 @@ type Param struct {
 	Goal         *string `json:"goal,omitempty"`
 	Keys         *string `json:"keys,omitempty"`
 	Seconds      *int    `json:"seconds,omitempty"`
+	Selector     *string `json:"selector,omitempty"`
+	FullPage     *bool   `json:"full_page,omitempty"`
 }
 
 type Action string
@@ -371,12 +422,17 @@ const (
 	ActionScrollDown   Action = "scroll_down"
 	ActionScrollUp     Action = "scroll_up"
 	//ActionSendKeys       Action = "send_keys"
-	ActionWebSearch      Action = "web_search"
-	ActionWait           Action = "wait"
-	ActionExtractContent Action = "extract_content"
-	ActionSwitchTab      Action = "switch_tab"
-	ActionOpenTab        Action = "open_tab"
-	ActionCloseTab       Action = "close_tab"
+	ActionWebSearch       Action = "web_search"
+	ActionWait            Action = "wait"
+	ActionExtractContent  Action = "extract_content"
+	ActionSwitchTab       Action = "switch_tab"
+	ActionOpenTab         Action = "open_tab"
+	ActionCloseTab        Action = "close_tab"
+	ActionScrollToElement Action = "scroll_to_element"
+	ActionWaitForSelector Action = "wait_for_selector"
+	ActionSnapshot        Action = "snapshot"
+	ActionScreenshot      Action = "screenshot"
+	ActionListDownloads   Action = "list_downloads"
 )
 
 func (b *Tool) Execute(params *Param) (*ToolResult, error) {
@@ -485,6 +541,28 @@ func (b *Tool) Execute(params *Param) (*ToolResult, error) {
 
 		result = &ToolResult{Output: fmt.Sprintf("successfully scrolled %s %d pixels", params.Action, amount)}
 
+	case ActionScrollToElement:
+		if params.Index == nil {
+			return &ToolResult{Error: "index is required for 'scroll_to_element' action"}, nil
+		}
+		index := *params.Index
+		if index < 0 || index >= len(b.elements) {
+			return &ToolResult{Error: fmt.Sprintf("index %d out of range", index)}, nil
+		}
+
+		err := chromedp.Run(b.ctx,
+			chromedp.ScrollIntoView(b.elements[index].XPath, chromedp.BySearch),
+		)
+		if err != nil {
+			return &ToolResult{Error: fmt.Sprintf("failed to scroll to element %d: %v", index, err)}, nil
+		}
+
+		if err := b.updateElements(b.ctx); err != nil {
+			return &ToolResult{Error: fmt.Sprintf("failed to update elements: %v", err)}, nil
+		}
+
+		result = &ToolResult{Output: fmt.Sprintf("successfully scrolled to element %d", index)}
+
 	case ActionWait:
 		var seconds = 3
 		if params.Seconds != nil {
@@ -501,6 +579,73 @@ func (b *Tool) Execute(params *Param) (*ToolResult, error) {
 
 		result = &ToolResult{Output: fmt.Sprintf("successfully waited for %d seconds", seconds)}
 
+	case ActionWaitForSelector:
+		if params.Selector == nil {
+			return &ToolResult{Error: "selector is required for 'wait_for_selector' action"}, nil
+		}
+		selector := *params.Selector
+		var seconds = 10
+		if params.Seconds != nil {
+			seconds = *params.Seconds
+		}
+
+		ctx, cancel := context.WithTimeout(b.ctx, time.Duration(seconds)*time.Second)
+		err := chromedp.Run(ctx, chromedp.WaitVisible(selector, chromedp.ByQuery))
+		cancel()
+		if err != nil {
+			return &ToolResult{Error: fmt.Sprintf("element %s not visible after %d seconds: %v", selector, seconds, err)}, nil
+		}
+
+		if err := b.updateElements(b.ctx); err != nil {
+			return &ToolResult{Error: fmt.Sprintf("failed to update elements: %v", err)}, nil
+		}
+
+		result = &ToolResult{Output: fmt.Sprintf("element %s is visible", selector)}
+
+	case ActionSnapshot:
+		if err := b.updateElements(b.ctx); err != nil {
+			return &ToolResult{Error: fmt.Sprintf("failed to update elements: %v", err)}, nil
+		}
+
+		var url, title string
+		if err := chromedp.Run(b.ctx, chromedp.Location(&url), chromedp.Title(&title)); err != nil {
+			return &ToolResult{Error: fmt.Sprintf("failed to get page info: %v", err)}, nil
+		}
+		snapshot, err := b.snapshot(b.ctx)
+		if err != nil {
+			return &ToolResult{Error: fmt.Sprintf("failed to get accessibility tree: %v", err)}, nil
+		}
+
+		result = &ToolResult{Output: fmt.Sprintf("url: %s\ntitle: %s\n\n%s", url, title, snapshot)}
+
+	case ActionScreenshot:
+		fullPage := params.FullPage != nil && *params.FullPage
+		buf, err := b.screenshot(b.ctx, fullPage)
+		if err != nil {
+			return &ToolResult{Error: fmt.Sprintf("failed to capture screenshot: %v", err)}, nil
+		}
+
+		var url string
+		if err := chromedp.Run(b.ctx, chromedp.Location(&url)); err != nil {
+			return &ToolResult{Error: fmt.Sprintf("failed to get page info: %v", err)}, nil
+		}
+		area := "viewport"
+		if fullPage {
+			area = "full page"
+		}
+
+		result = &ToolResult{
+			Output:      fmt.Sprintf("screenshot of the %s of %s", area, url),
+			Base64Image: base64.StdEncoding.EncodeToString(buf),
+		}
+
+	case ActionListDownloads:
+		if b.downloads == nil {
+			return &ToolResult{Error: "downloads are disabled, set DownloadDir to enable them"}, nil
+		}
+
+		result = &ToolResult{Output: b.downloads.String()}
+
 	case ActionWebSearch:
 		if b.searchTool == nil {
 			return nil, fmt.Errorf("web search fail, no search tool found")
@@ -697,10 +842,11 @@ func (b *Tool) updateElements(ctx context.Context) error {
 		}
 
 		b.elements = append(b.elements, ElementInfo{
-			Index:       i,
-			Description: description,
-			Type:        node.NodeName,
-			XPath:       node.FullXPath(),
+			Index:         i,
+			Description:   description,
+			Type:          node.NodeName,
+			XPath:         node.FullXPath(),
+			BackendNodeID: node.BackendNodeID,
 		})
 	}
 
@@ -728,6 +874,41 @@ func calculateVisible(ctx context.Context, node *cdp.Node) (bool, error) {
 	return isVisible, err
 }
 
+// screenshot captures the viewport or the full page, as JPEG when ScreenshotQuality is set, as PNG otherwise.
+func (b *Tool) screenshot(ctx context.Context, fullPage bool) ([]byte, error) {
+	var buf []byte
+	if fullPage {
+		quality := 100 // png
+		if b.screenshotQuality > 0 && b.screenshotQuality < 100 {
+			quality = b.screenshotQuality
+		}
+		err := chromedp.Run(ctx, chromedp.FullScreenshot(&buf, quality))
+		return buf, err
+	}
+
+	if b.screenshotQuality <= 0 || b.screenshotQuality >= 100 {
+		err := chromedp.Run(ctx, chromedp.CaptureScreenshot(&buf))
+		return buf, err
+	}
+	err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		var err error
+		buf, err = page.CaptureScreenshot().
+			WithFormat(page.CaptureScreenshotFormatJpeg).
+			WithQuality(int64(b.screenshotQuality)).
+			Do(ctx)
+		return err
+	}))
+	return buf, err
+}
+
+// Downloads returns the files downloaded by the browser, when DownloadDir is set.
+func (b *Tool) Downloads() []*DownloadInfo {
+	if b.downloads == nil {
+		return nil
+	}
+	return b.downloads.list()
+}
+
 func (b *Tool) Cleanup() {
 	b.mu.Lock()
 	defer b.mu.Unlock()
@@ -170,6 +170,61 @@ func TestExecute(t *testing.T) {
 		assert.Equal(t, "successfully closed current tab", result.Output)
 	})
 
+	mockey.PatchConvey("scroll to element", t, func() {
+		defer mockey.Mock(chromedp.Run).Return(nil).Build().UnPatch()
+		tool.elements = make([]ElementInfo, 5)
+		index := 4
+		result, err := tool.Execute(&Param{Action: ActionScrollToElement, Index: &index})
+		assert.NoError(t, err)
+		assert.Equal(t, "successfully scrolled to element 4", result.Output)
+		index = 5
+		result, err = tool.Execute(&Param{Action: ActionScrollToElement, Index: &index})
+		assert.NoError(t, err)
+		assert.Equal(t, "index 5 out of range", result.Error)
+	})
+
+	mockey.PatchConvey("wait for selector", t, func() {
+		tool.ctx = context.Background()
+		defer mockey.Mock(chromedp.Run).Return(nil).Build().UnPatch()
+		selector := "#results"
+		result, err := tool.Execute(&Param{Action: ActionWaitForSelector, Selector: &selector})
+		assert.NoError(t, err)
+		assert.Equal(t, "element #results is visible", result.Output)
+		result, err = tool.Execute(&Param{Action: ActionWaitForSelector})
+		assert.NoError(t, err)
+		assert.Equal(t, "selector is required for 'wait_for_selector' action", result.Error)
+	})
+
+	mockey.PatchConvey("snapshot", t, func() {
+		defer mockey.Mock(chromedp.Run).Return(nil).Build().UnPatch()
+		tool.maxSnapshotLength = defaultMaxSnapshotLength
+		result, err := tool.Execute(&Param{Action: ActionSnapshot})
+		assert.NoError(t, err)
+		assert.Equal(t, "url: \ntitle: \n\n(empty page)", result.Output)
+	})
+
+	mockey.PatchConvey("screenshot", t, func() {
+		defer mockey.Mock(chromedp.Run).Return(nil).Build().UnPatch()
+		defer mockey.Mock((*Tool).screenshot).Return([]byte("png"), nil).Build().UnPatch()
+		fullPage := true
+		result, err := tool.Execute(&Param{Action: ActionScreenshot, FullPage: &fullPage})
+		assert.NoError(t, err)
+		assert.Equal(t, "screenshot of the full page of ", result.Output)
+		assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("png")), result.Base64Image)
+	})
+
+	mockey.PatchConvey("list downloads", t, func() {
+		result, err := tool.Execute(&Param{Action: ActionListDownloads})
+		assert.NoError(t, err)
+		assert.Equal(t, "downloads are disabled, set DownloadDir to enable them", result.Error)
+
+		tool.downloads = &downloads{dir: "/tmp", byID: map[string]*DownloadInfo{}}
+		result, err = tool.Execute(&Param{Action: ActionListDownloads})
+		assert.NoError(t, err)
+		assert.Equal(t, "no downloads", result.Output)
+		tool.downloads = nil
+	})
+
 }
 
 func TestUpdateElements(t *testing.T) {
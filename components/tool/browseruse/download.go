@@ -0,0 +1,141 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package browseruse
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/chromedp/cdproto/browser"
+	"github.com/chromedp/chromedp"
+)
+
+const (
+	DownloadStateInProgress = "in_progress"
+	DownloadStateCompleted  = "completed"
+	DownloadStateCanceled   = "canceled"
+)
+
+// DownloadInfo is a file downloaded by the browser.
+type DownloadInfo struct {
+	URL           string `json:"url"`
+	FileName      string `json:"file_name"`
+	Path          string `json:"path"`
+	State         string `json:"state"`
+	ReceivedBytes int64  `json:"received_bytes"`
+	TotalBytes    int64  `json:"total_bytes"`
+}
+
+// downloads tracks the downloads of the browser from its events, which come from the event loop of chromedp,
+// so it has its own lock rather than the one of the tool held while running actions.
+type downloads struct {
+	mu    sync.Mutex
+	dir   string
+	byID  map[string]*DownloadInfo
+	order []string
+}
+
+// enableDownloads saves the downloads to dir, the files being named after their GUID until they complete,
+// and renamed to their suggested name then.
+func (b *Tool) enableDownloads(ctx context.Context, dir string) error {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	b.downloads = &downloads{dir: dir, byID: make(map[string]*DownloadInfo)}
+
+	chromedp.ListenBrowser(ctx, b.downloads.onEvent)
+	return chromedp.Run(ctx, browser.SetDownloadBehavior(browser.SetDownloadBehaviorBehaviorAllowAndName).
+		WithDownloadPath(dir).
+		WithEventsEnabled(true))
+}
+
+func (d *downloads) onEvent(ev any) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	switch e := ev.(type) {
+	case *browser.EventDownloadWillBegin:
+		d.byID[e.GUID] = &DownloadInfo{
+			URL:      e.URL,
+			FileName: e.SuggestedFilename,
+			Path:     filepath.Join(d.dir, e.GUID),
+			State:    DownloadStateInProgress,
+		}
+		d.order = append(d.order, e.GUID)
+	case *browser.EventDownloadProgress:
+		info, ok := d.byID[e.GUID]
+		if !ok {
+			return
+		}
+		info.ReceivedBytes, info.TotalBytes = int64(e.ReceivedBytes), int64(e.TotalBytes)
+		switch e.State {
+		case browser.DownloadProgressStateCompleted:
+			info.State = DownloadStateCompleted
+			info.Path = d.rename(info.Path, info.FileName, e.GUID)
+		case browser.DownloadProgressStateCanceled:
+			info.State = DownloadStateCanceled
+		}
+	}
+}
+
+// rename gives the downloaded file its suggested name, prefixed with the GUID if a file already has it.
+func (d *downloads) rename(path, name, guid string) string {
+	name = filepath.Base(name)
+	if name == "." || name == string(filepath.Separator) || name == "" {
+		return path
+	}
+	target := filepath.Join(d.dir, name)
+	if _, err := os.Stat(target); err == nil {
+		target = filepath.Join(d.dir, guid+"-"+name)
+	}
+	if err := os.Rename(path, target); err != nil {
+		return path
+	}
+	return target
+}
+
+func (d *downloads) list() []*DownloadInfo {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	ret := make([]*DownloadInfo, 0, len(d.order))
+	for _, id := range d.order {
+		info := *d.byID[id]
+		ret = append(ret, &info)
+	}
+	return ret
+}
+
+func (d *downloads) String() string {
+	list := d.list()
+	if len(list) == 0 {
+		return "no downloads"
+	}
+	var s string
+	for i, info := range list {
+		s += fmt.Sprintf("[%d] %s %s, %d/%d bytes, saved to %s, from %s\n",
+			i, info.FileName, info.State, info.ReceivedBytes, info.TotalBytes, info.Path, info.URL)
+	}
+	return s
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package browseruse
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chromedp/cdproto/browser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownloads(t *testing.T) {
+	dir := t.TempDir()
+	d := &downloads{dir: dir, byID: map[string]*DownloadInfo{}}
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "report.pdf"), []byte("old"), 0644))
+
+	for _, guid := range []string{"g1", "g2", "g3"} {
+		d.onEvent(&browser.EventDownloadWillBegin{GUID: guid, URL: "https://example.com/" + guid, SuggestedFilename: "report.pdf"})
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, guid), []byte(guid), 0644))
+	}
+	d.onEvent(&browser.EventDownloadProgress{GUID: "g1", ReceivedBytes: 2, TotalBytes: 2, State: browser.DownloadProgressStateCompleted})
+	d.onEvent(&browser.EventDownloadProgress{GUID: "g2", ReceivedBytes: 1, TotalBytes: 2, State: browser.DownloadProgressStateCanceled})
+	d.onEvent(&browser.EventDownloadProgress{GUID: "g3", ReceivedBytes: 1, TotalBytes: 2, State: browser.DownloadProgressStateInProgress})
+	d.onEvent(&browser.EventDownloadProgress{GUID: "unknown", State: browser.DownloadProgressStateCompleted})
+
+	assert.Equal(t, []*DownloadInfo{
+		{URL: "https://example.com/g1", FileName: "report.pdf", Path: filepath.Join(dir, "g1-report.pdf"), State: DownloadStateCompleted, ReceivedBytes: 2, TotalBytes: 2},
+		{URL: "https://example.com/g2", FileName: "report.pdf", Path: filepath.Join(dir, "g2"), State: DownloadStateCanceled, ReceivedBytes: 1, TotalBytes: 2},
+		{URL: "https://example.com/g3", FileName: "report.pdf", Path: filepath.Join(dir, "g3"), State: DownloadStateInProgress, ReceivedBytes: 1, TotalBytes: 2},
+	}, d.list())
+
+	content, err := os.ReadFile(filepath.Join(dir, "g1-report.pdf"))
+	assert.NoError(t, err)
+	assert.Equal(t, "g1", string(content))
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package browseruse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/chromedp/cdproto/accessibility"
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/chromedp"
+)
+
+const defaultMaxSnapshotLength = 20000
+
+// skippedRoles are the roles which only structure the page, their children being rendered in their place.
+var skippedRoles = map[string]bool{
+	"generic": true, "none": true, "presentation": true, "InlineTextBox": true, "LineBreak": true, "RootWebArea": true,
+}
+
+// snapshotProperties are the states rendered after the name of the nodes.
+var snapshotProperties = map[accessibility.PropertyName]bool{
+	accessibility.PropertyNameChecked:  true,
+	accessibility.PropertyNameSelected: true,
+	accessibility.PropertyNameExpanded: true,
+	accessibility.PropertyNameDisabled: true,
+	accessibility.PropertyNameRequired: true,
+	accessibility.PropertyNameLevel:    true,
+}
+
+// snapshot returns the accessibility tree of the current page as indented lines,
+// the interactive elements being marked with their index.
+func (b *Tool) snapshot(ctx context.Context) (string, error) {
+	var nodes []*accessibility.Node
+	err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		var err error
+		nodes, err = accessibility.GetFullAXTree().Do(ctx)
+		return err
+	}))
+	if err != nil {
+		return "", err
+	}
+
+	indices := make(map[cdp.BackendNodeID]int, len(b.elements))
+	for _, e := range b.elements {
+		if e.BackendNodeID != 0 {
+			indices[e.BackendNodeID] = e.Index
+		}
+	}
+	return renderAXTree(nodes, indices, b.maxSnapshotLength), nil
+}
+
+// renderAXTree renders the nodes from the root, skipping the ignored and structural nodes,
+// and truncates the result to maxLength characters.
+func renderAXTree(nodes []*accessibility.Node, indices map[cdp.BackendNodeID]int, maxLength int) string {
+	if len(nodes) == 0 {
+		return "(empty page)"
+	}
+	byID := make(map[accessibility.NodeID]*accessibility.Node, len(nodes))
+	for _, n := range nodes {
+		byID[n.NodeID] = n
+	}
+
+	var sb strings.Builder
+	var render func(n *accessibility.Node, depth int)
+	render = func(n *accessibility.Node, depth int) {
+		if sb.Len() > maxLength {
+			return
+		}
+		role, name := axString(n.Role), strings.Join(strings.Fields(axString(n.Name)), " ")
+		index, interactive := indices[n.BackendDOMNodeID]
+		skip := n.Ignored || (skippedRoles[role] && !interactive) || (role == "StaticText" && name == "")
+		if !skip {
+			sb.WriteString(strings.Repeat("  ", depth))
+			if interactive {
+				sb.WriteString(fmt.Sprintf("[%d] ", index))
+			}
+			if role == "StaticText" {
+				sb.WriteString(fmt.Sprintf("%q", name))
+			} else {
+				sb.WriteString(role)
+				if name != "" {
+					sb.WriteString(fmt.Sprintf(" %q", name))
+				}
+				if value := axString(n.Value); value != "" {
+					sb.WriteString(fmt.Sprintf(" value=%q", value))
+				}
+				for _, p := range n.Properties {
+					if snapshotProperties[p.Name] {
+						if v := axString(p.Value); v != "" && v != "false" {
+							sb.WriteString(fmt.Sprintf(" %s=%s", p.Name, v))
+						}
+					}
+				}
+			}
+			sb.WriteString("\n")
+			depth++
+		}
+		// a name computed from the text of the children is not repeated
+		if !skip && name != "" && len(n.ChildIDs) == 1 {
+			if c := byID[n.ChildIDs[0]]; c != nil && axString(c.Role) == "StaticText" && len(c.ChildIDs) <= 1 {
+				return
+			}
+		}
+		for _, id := range n.ChildIDs {
+			if c := byID[id]; c != nil {
+				render(c, depth)
+			}
+		}
+	}
+
+	for _, n := range nodes {
+		if n.ParentID == "" {
+			render(n, 0)
+		}
+	}
+
+	out := sb.String()
+	if len(out) > maxLength {
+		out = out[:strings.LastIndex(out[:maxLength], "\n")+1] + "[snapshot truncated, scroll or use extract_content for the rest of the page]\n"
+	}
+	return out
+}
+
+// axString returns the value as a string, whatever its json type.
+func axString(v *accessibility.Value) string {
+	if v == nil || len(v.Value) == 0 {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(v.Value, &s); err == nil {
+		return s
+	}
+	return string(v.Value)
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package browseruse
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chromedp/cdproto/accessibility"
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/stretchr/testify/assert"
+)
+
+func axValue(raw string) *accessibility.Value {
+	return &accessibility.Value{Type: accessibility.ValueTypeString, Value: []byte(raw)}
+}
+
+func TestRenderAXTree(t *testing.T) {
+	nodes := []*accessibility.Node{
+		{NodeID: "1", Role: axValue(`"RootWebArea"`), Name: axValue(`"Example"`), ChildIDs: []accessibility.NodeID{"2", "6"}},
+		{NodeID: "2", ParentID: "1", Role: axValue(`"generic"`), ChildIDs: []accessibility.NodeID{"3", "5", "9"}},
+		{NodeID: "3", ParentID: "2", Role: axValue(`"heading"`), Name: axValue(`"Welcome  home"`), ChildIDs: []accessibility.NodeID{"4"},
+			Properties: []*accessibility.Property{{Name: accessibility.PropertyNameLevel, Value: &accessibility.Value{Type: accessibility.ValueTypeInteger, Value: []byte("1")}}}},
+		{NodeID: "4", ParentID: "3", Role: axValue(`"StaticText"`), Name: axValue(`"Welcome home"`)},
+		{NodeID: "5", ParentID: "2", Role: axValue(`"link"`), Name: axValue(`"Docs"`), BackendDOMNodeID: 42},
+		{NodeID: "9", ParentID: "2", Ignored: true, Role: axValue(`"none"`), ChildIDs: []accessibility.NodeID{"10"}},
+		{NodeID: "10", ParentID: "9", Role: axValue(`"StaticText"`), Name: axValue(`"hidden wrapper text"`)},
+		{NodeID: "6", ParentID: "1", Role: axValue(`"textbox"`), Name: axValue(`"Search"`), Value: axValue(`"eino"`), BackendDOMNodeID: 43,
+			Properties: []*accessibility.Property{
+				{Name: accessibility.PropertyNameRequired, Value: &accessibility.Value{Type: accessibility.ValueTypeBoolean, Value: []byte("true")}},
+				{Name: accessibility.PropertyNameDisabled, Value: &accessibility.Value{Type: accessibility.ValueTypeBoolean, Value: []byte("false")}},
+			}},
+	}
+	indices := map[cdp.BackendNodeID]int{42: 0, 43: 1}
+
+	assert.Equal(t, `heading "Welcome home" level=1
+[0] link "Docs"
+"hidden wrapper text"
+[1] textbox "Search" value="eino" required=true
+`, renderAXTree(nodes, indices, 1000))
+
+	out := renderAXTree(nodes, indices, 40)
+	assert.True(t, strings.HasPrefix(out, "heading \"Welcome home\" level=1\n[snapshot truncated"), out)
+
+	assert.Equal(t, "(empty page)", renderAXTree(nil, nil, 1000))
+}
@@ -0,0 +1,215 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package calculator provides tools for agents to compute instead of guessing the numbers:
+// the evaluation of arithmetic and scientific expressions, and the conversion of values between units.
+package calculator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+)
+
+const (
+	defaultPrecision           = 15
+	defaultMaxExpressionLength = 1000
+)
+
+// Config is the configuration for the calculator tools.
+type Config struct {
+	// Precision is the number of significant digits of the results, between 1 and 17.
+	// The default hides the floating point errors, e.g. 0.1+0.2 is 0.3 instead of 0.30000000000000004.
+	// Optional. Default 15.
+	Precision int
+	// MaxExpressionLength is the maximum number of characters of an expression.
+	// Optional. Default 1000.
+	MaxExpressionLength int
+}
+
+// NewToolKit creates the calculator tools: calculate and convert_unit.
+func NewToolKit(ctx context.Context, conf *Config) ([]tool.BaseTool, error) {
+	if err := conf.validate(); err != nil {
+		return nil, err
+	}
+	c := &calculator{conf: conf}
+
+	var (
+		tools    []tool.BaseTool
+		inferErr error
+	)
+	add := func(t tool.InvokableTool, err error) {
+		if err != nil {
+			inferErr = errors.Join(inferErr, err)
+			return
+		}
+		tools = append(tools, t)
+	}
+
+	add(utils.InferTool("calculate", "Evaluate an arithmetic or scientific expression exactly, instead of computing it yourself. "+
+		"Supports + - * / % ^ and ! for factorial, parentheses, the constants pi, e, tau and phi, "+
+		"and the functions sqrt, cbrt, abs, exp, ln, log, log2, log10, sin, cos, tan, asin, acos, atan, atan2, "+
+		"sinh, cosh, tanh, floor, ceil, round, trunc, min, max, pow, hypot.", c.Calculate))
+	add(utils.InferTool("convert_unit", "Convert a value between units of length, mass, time, temperature, area, volume, "+
+		"speed, data, energy, power, pressure and angle, e.g. from mi to km or from F to C.", c.ConvertUnit))
+	if inferErr != nil {
+		return nil, fmt.Errorf("failed to infer tool: %w", inferErr)
+	}
+	return tools, nil
+}
+
+// validate validates the configuration and sets default values if not provided.
+func (conf *Config) validate() error {
+	if conf == nil {
+		return fmt.Errorf("config is nil")
+	}
+	if conf.Precision == 0 {
+		conf.Precision = defaultPrecision
+	}
+	if conf.Precision < 1 || conf.Precision > 17 {
+		return fmt.Errorf("precision must be between 1 and 17")
+	}
+	if conf.MaxExpressionLength <= 0 {
+		conf.MaxExpressionLength = defaultMaxExpressionLength
+	}
+	return nil
+}
+
+type calculator struct {
+	conf *Config
+}
+
+const (
+	AngleUnitRadians = "radians"
+	AngleUnitDegrees = "degrees"
+)
+
+// CalculateRequest is the request of the calculate tool.
+type CalculateRequest struct {
+	Expression string `json:"expression" jsonschema:"required,description=The expression to evaluate, e.g. (1.5e3 + 2^10) * sqrt(2) / 3"`
+	AngleUnit  string `json:"angle_unit,omitempty" jsonschema:"description=The unit of the angles of the trigonometric functions. Default radians,enum=radians,enum=degrees"`
+}
+
+// CalculateResponse is the result of an expression.
+type CalculateResponse struct {
+	Expression string  `json:"expression"`
+	Result     float64 `json:"result"`
+	// Text is the result without the exponent notation for the usual magnitudes.
+	Text string `json:"text"`
+}
+
+// Calculate evaluates the expression.
+func (c *calculator) Calculate(_ context.Context, req *CalculateRequest) (*CalculateResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request is nil")
+	}
+	if len([]rune(req.Expression)) > c.conf.MaxExpressionLength {
+		return nil, fmt.Errorf("expression is too long, must be at most %d characters", c.conf.MaxExpressionLength)
+	}
+	var degrees bool
+	switch req.AngleUnit {
+	case "", AngleUnitRadians:
+	case AngleUnitDegrees:
+		degrees = true
+	default:
+		return nil, fmt.Errorf("invalid angle unit: %q, must be radians or degrees", req.AngleUnit)
+	}
+
+	v, err := evaluate(req.Expression, degrees)
+	if err != nil {
+		return nil, err
+	}
+	v = c.round(v)
+	return &CalculateResponse{
+		Expression: req.Expression,
+		Result:     v,
+		Text:       formatNumber(v),
+	}, nil
+}
+
+// ConvertUnitRequest is the request of the convert_unit tool.
+type ConvertUnitRequest struct {
+	Value float64 `json:"value" jsonschema:"required,description=The value to convert"`
+	From  string  `json:"from" jsonschema:"required,description=The unit of the value, e.g. km or lb or F or kWh or MiB"`
+	To    string  `json:"to" jsonschema:"required,description=The unit to convert to, of the same dimension as from"`
+}
+
+// ConvertUnitResponse is the converted value.
+type ConvertUnitResponse struct {
+	Value float64 `json:"value"`
+	From  string  `json:"from"`
+	To    string  `json:"to"`
+	// Dimension is the physical dimension of the units, e.g. "length".
+	Dimension string  `json:"dimension"`
+	Result    float64 `json:"result"`
+	// Text is the result without the exponent notation for the usual magnitudes.
+	Text string `json:"text"`
+}
+
+// ConvertUnit converts the value between the units.
+func (c *calculator) ConvertUnit(_ context.Context, req *ConvertUnitRequest) (*ConvertUnitResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request is nil")
+	}
+	from, err := lookupUnit(req.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := lookupUnit(req.To)
+	if err != nil {
+		return nil, err
+	}
+	if from.dimension != to.dimension {
+		return nil, fmt.Errorf("cannot convert %s (%s) to %s (%s)", req.From, from.dimension, req.To, to.dimension)
+	}
+
+	v := c.round(convert(req.Value, from, to))
+	return &ConvertUnitResponse{
+		Value:     req.Value,
+		From:      req.From,
+		To:        req.To,
+		Dimension: from.dimension,
+		Result:    v,
+		Text:      formatNumber(v),
+	}, nil
+}
+
+// maxExactInteger is the largest integer such that all the smaller integers are exact float64.
+const maxExactInteger = 1 << 53
+
+// round rounds the value to the significant digits of the configuration, except the exact integers.
+func (c *calculator) round(v float64) float64 {
+	if v == math.Trunc(v) && math.Abs(v) <= maxExactInteger {
+		return v
+	}
+	r, err := strconv.ParseFloat(strconv.FormatFloat(v, 'g', c.conf.Precision, 64), 64)
+	if err != nil {
+		return v
+	}
+	return r
+}
+
+// formatNumber formats the value in decimal notation, unless it's very large or very small.
+func formatNumber(v float64) string {
+	if abs := math.Abs(v); abs == 0 || (abs >= 1e-6 && abs < 1e21) {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
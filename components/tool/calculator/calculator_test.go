@@ -0,0 +1,136 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package calculator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewToolKit(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewToolKit(ctx, nil)
+	assert.EqualError(t, err, "config is nil")
+	_, err = NewToolKit(ctx, &Config{Precision: 18})
+	assert.EqualError(t, err, "precision must be between 1 and 17")
+
+	tools, err := NewToolKit(ctx, &Config{})
+	assert.NoError(t, err)
+	var names []string
+	for _, tl := range tools {
+		info, err := tl.Info(ctx)
+		assert.NoError(t, err)
+		names = append(names, info.Name)
+	}
+	assert.Equal(t, []string{"calculate", "convert_unit"}, names)
+
+	out, err := tools[0].(tool.InvokableTool).InvokableRun(ctx, `{"expression":"0.1 + 0.2"}`)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"expression":"0.1 + 0.2","result":0.3,"text":"0.3"}`, out)
+	out, err = tools[1].(tool.InvokableTool).InvokableRun(ctx, `{"value":100,"from":"C","to":"F"}`)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"value":100,"from":"C","to":"F","dimension":"temperature","result":212,"text":"212"}`, out)
+}
+
+func TestCalculate(t *testing.T) {
+	ctx := context.Background()
+	c := &calculator{conf: &Config{MaxExpressionLength: 10}}
+	assert.NoError(t, c.conf.validate())
+
+	resp, err := c.Calculate(ctx, &CalculateRequest{Expression: "1 / 3"})
+	assert.NoError(t, err)
+	assert.Equal(t, 0.333333333333333, resp.Result)
+	assert.Equal(t, "0.333333333333333", resp.Text)
+
+	resp, err = c.Calculate(ctx, &CalculateRequest{Expression: "cos(60)", AngleUnit: AngleUnitDegrees})
+	assert.NoError(t, err)
+	assert.Equal(t, 0.5, resp.Result)
+
+	resp, err = c.Calculate(ctx, &CalculateRequest{Expression: "2^53"})
+	assert.NoError(t, err)
+	assert.Equal(t, "9007199254740992", resp.Text)
+	resp, err = c.Calculate(ctx, &CalculateRequest{Expression: "2^70"})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.18059162071741e+21", resp.Text)
+	resp, err = c.Calculate(ctx, &CalculateRequest{Expression: "10^-7"})
+	assert.NoError(t, err)
+	assert.Equal(t, "1e-07", resp.Text)
+
+	_, err = c.Calculate(ctx, &CalculateRequest{Expression: "1 + 2 + 3 + 4"})
+	assert.EqualError(t, err, "expression is too long, must be at most 10 characters")
+	_, err = c.Calculate(ctx, &CalculateRequest{Expression: "1", AngleUnit: "turns"})
+	assert.EqualError(t, err, `invalid angle unit: "turns", must be radians or degrees`)
+
+	c = &calculator{conf: &Config{Precision: 3}}
+	assert.NoError(t, c.conf.validate())
+	resp, err = c.Calculate(ctx, &CalculateRequest{Expression: "pi * 1000"})
+	assert.NoError(t, err)
+	assert.Equal(t, "3140", resp.Text)
+}
+
+func TestConvertUnit(t *testing.T) {
+	ctx := context.Background()
+	c := &calculator{conf: &Config{}}
+	assert.NoError(t, c.conf.validate())
+
+	// the results are rounded to 15 significant digits
+	tests := []struct {
+		value     float64
+		from, to  string
+		dimension string
+		want      float64
+	}{
+		{value: 26.2188, from: "mi", to: "km", dimension: "length", want: 42.1950684672},
+		{value: 6, from: "feet", to: "M", dimension: "length", want: 1.8288},
+		{value: 1, from: "lb", to: "g", dimension: "mass", want: 453.59237},
+		{value: 1.5, from: "hours", to: "min", dimension: "time", want: 90},
+		{value: 98.6, from: "°F", to: "celsius", dimension: "temperature", want: 37},
+		{value: 0, from: "K", to: "C", dimension: "temperature", want: -273.15},
+		{value: 32, from: "F", to: "K", dimension: "temperature", want: 273.15},
+		{value: -40, from: "C", to: "F", dimension: "temperature", want: -40},
+		{value: 1, from: "acre", to: "m²", dimension: "area", want: 4046.8564224},
+		{value: 2, from: "square meters", to: "sq ft", dimension: "area", want: 21.5278208334194},
+		{value: 1, from: "gal", to: "L", dimension: "volume", want: 3.785411784},
+		{value: 100, from: "km/h", to: "mph", dimension: "speed", want: 62.1371192237334},
+		{value: 1, from: "GiB", to: "MB", dimension: "data", want: 1073.741824},
+		{value: 100, from: "Mb", to: "MB", dimension: "data", want: 12.5},
+		{value: 1, from: "kwh", to: "kJ", dimension: "energy", want: 3600},
+		{value: 1, from: "hp", to: "W", dimension: "power", want: 745.69987158227},
+		{value: 1, from: "atm", to: "psi", dimension: "pressure", want: 14.6959487755134},
+		{value: 180, from: "deg", to: "rad", dimension: "angle", want: 3.14159265358979},
+	}
+	for _, tt := range tests {
+		resp, err := c.ConvertUnit(ctx, &ConvertUnitRequest{Value: tt.value, From: tt.from, To: tt.to})
+		assert.NoError(t, err, tt.from)
+		assert.Equal(t, tt.dimension, resp.Dimension, tt.from)
+		assert.InDelta(t, tt.want, resp.Result, 1e-9, tt.from)
+	}
+
+	resp, err := c.ConvertUnit(ctx, &ConvertUnitRequest{Value: 98.6, From: "F", To: "C"})
+	assert.NoError(t, err)
+	assert.Equal(t, "37", resp.Text)
+
+	_, err = c.ConvertUnit(ctx, &ConvertUnitRequest{Value: 1, From: "furlong", To: "m"})
+	assert.EqualError(t, err, `unknown unit: "furlong"`)
+	_, err = c.ConvertUnit(ctx, &ConvertUnitRequest{Value: 1, From: "mb", To: "B"})
+	assert.EqualError(t, err, `ambiguous unit: "mb", mind the case, e.g. MB is megabytes and Mb is megabits`)
+	_, err = c.ConvertUnit(ctx, &ConvertUnitRequest{Value: 1, From: "kg", To: "m"})
+	assert.EqualError(t, err, "cannot convert kg (mass) to m (length)")
+}
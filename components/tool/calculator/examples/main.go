@@ -0,0 +1,49 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/cloudwego/eino/components/tool"
+
+	"github.com/cloudwego/eino-ext/components/tool/calculator"
+)
+
+func main() {
+	ctx := context.Background()
+
+	tools, err := calculator.NewToolKit(ctx, &calculator.Config{})
+	if err != nil {
+		log.Fatalf("NewToolKit failed, err=%v", err)
+	}
+
+	calculate := tools[0].(tool.InvokableTool)
+	out, err := calculate.InvokableRun(ctx, `{"expression":"1200 * (1 + 0.035/12)^(12*30)"}`)
+	if err != nil {
+		log.Fatalf("calculate failed, err=%v", err)
+	}
+	fmt.Println(out)
+
+	convert := tools[1].(tool.InvokableTool)
+	out, err = convert.InvokableRun(ctx, `{"value":26.2188,"from":"mi","to":"km"}`)
+	if err != nil {
+		log.Fatalf("convert_unit failed, err=%v", err)
+	}
+	fmt.Println(out)
+}
@@ -0,0 +1,485 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package calculator
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// maxDepth is the maximum nesting of the parentheses and the unary operators.
+const maxDepth = 100
+
+// trigEpsilon is the magnitude under which the results of sin, cos and tan are zero,
+// so that e.g. sin(pi) is 0 instead of 1.2246467991473532e-16.
+const trigEpsilon = 1e-15
+
+// maxFactorial is the largest integer whose factorial is a finite float64.
+const maxFactorial = 170
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenIdent
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	// pos is the 1-based position of the token in the expression, in characters.
+	pos int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of expression"
+	}
+	return fmt.Sprintf("%q at position %d", t.text, t.pos)
+}
+
+// operatorAliases are the unicode operators, and ** for the power.
+var operatorAliases = map[string]string{
+	"**": "^",
+	"×":  "*",
+	"·":  "*",
+	"÷":  "/",
+	"−":  "-",
+}
+
+func tokenize(expr string) ([]token, error) {
+	runes := []rune(expr)
+	var tokens []token
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r) || r == '.':
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			// exponent, only when followed by digits, e.g. 1e3 or 2.5E-4
+			if i < len(runes) && (runes[i] == 'e' || runes[i] == 'E') {
+				j := i + 1
+				if j < len(runes) && (runes[j] == '+' || runes[j] == '-') {
+					j++
+				}
+				if j < len(runes) && unicode.IsDigit(runes[j]) {
+					for j < len(runes) && unicode.IsDigit(runes[j]) {
+						j++
+					}
+					i = j
+				}
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: string(runes[start:i]), pos: start + 1})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: strings.ToLower(string(runes[start:i])), pos: start + 1})
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: i + 1})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: i + 1})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", pos: i + 1})
+			i++
+		case r == '*' && i+1 < len(runes) && runes[i+1] == '*':
+			tokens = append(tokens, token{kind: tokenOperator, text: "^", pos: i + 1})
+			i += 2
+		case strings.ContainsRune("+-*/%^!", r):
+			tokens = append(tokens, token{kind: tokenOperator, text: string(r), pos: i + 1})
+			i++
+		default:
+			if op, ok := operatorAliases[string(r)]; ok {
+				tokens = append(tokens, token{kind: tokenOperator, text: op, pos: i + 1})
+				i++
+				continue
+			}
+			return nil, fmt.Errorf("unexpected character %q at position %d", r, i+1)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(runes) + 1}), nil
+}
+
+var constants = map[string]float64{
+	"pi":  math.Pi,
+	"π":   math.Pi,
+	"e":   math.E,
+	"tau": 2 * math.Pi,
+	"phi": math.Phi,
+}
+
+type function struct {
+	minArgs, maxArgs int
+	fn               func(args []float64) float64
+	// angleArg is true when the argument is an angle, angleResult when the result is an angle.
+	angleArg, angleResult bool
+}
+
+func unary(fn func(float64) float64) function {
+	return function{minArgs: 1, maxArgs: 1, fn: func(args []float64) float64 { return fn(args[0]) }}
+}
+
+func binary(fn func(float64, float64) float64) function {
+	return function{minArgs: 2, maxArgs: 2, fn: func(args []float64) float64 { return fn(args[0], args[1]) }}
+}
+
+func variadic(fn func(float64, float64) float64) function {
+	return function{minArgs: 1, maxArgs: -1, fn: func(args []float64) float64 {
+		v := args[0]
+		for _, arg := range args[1:] {
+			v = fn(v, arg)
+		}
+		return v
+	}}
+}
+
+var functions = map[string]function{
+	"sqrt":  unary(math.Sqrt),
+	"cbrt":  unary(math.Cbrt),
+	"abs":   unary(math.Abs),
+	"exp":   unary(math.Exp),
+	"ln":    unary(math.Log),
+	"log2":  unary(math.Log2),
+	"log10": unary(math.Log10),
+	// log is the decimal logarithm, or the logarithm in the base of the second argument
+	"log": {minArgs: 1, maxArgs: 2, fn: func(args []float64) float64 {
+		if len(args) == 2 {
+			return math.Log(args[0]) / math.Log(args[1])
+		}
+		return math.Log10(args[0])
+	}},
+	"sin":   {minArgs: 1, maxArgs: 1, fn: func(args []float64) float64 { return math.Sin(args[0]) }, angleArg: true},
+	"cos":   {minArgs: 1, maxArgs: 1, fn: func(args []float64) float64 { return math.Cos(args[0]) }, angleArg: true},
+	"tan":   {minArgs: 1, maxArgs: 1, fn: func(args []float64) float64 { return math.Tan(args[0]) }, angleArg: true},
+	"asin":  {minArgs: 1, maxArgs: 1, fn: func(args []float64) float64 { return math.Asin(args[0]) }, angleResult: true},
+	"acos":  {minArgs: 1, maxArgs: 1, fn: func(args []float64) float64 { return math.Acos(args[0]) }, angleResult: true},
+	"atan":  {minArgs: 1, maxArgs: 1, fn: func(args []float64) float64 { return math.Atan(args[0]) }, angleResult: true},
+	"atan2": {minArgs: 2, maxArgs: 2, fn: func(args []float64) float64 { return math.Atan2(args[0], args[1]) }, angleResult: true},
+	"sinh":  unary(math.Sinh),
+	"cosh":  unary(math.Cosh),
+	"tanh":  unary(math.Tanh),
+	"floor": unary(math.Floor),
+	"ceil":  unary(math.Ceil),
+	"trunc": unary(math.Trunc),
+	// round rounds half away from zero, to the number of decimals of the second argument
+	"round": {minArgs: 1, maxArgs: 2, fn: func(args []float64) float64 {
+		if len(args) == 2 {
+			scale := math.Pow(10, math.Trunc(args[1]))
+			return math.Round(args[0]*scale) / scale
+		}
+		return math.Round(args[0])
+	}},
+	"min":   variadic(math.Min),
+	"max":   variadic(math.Max),
+	"pow":   binary(math.Pow),
+	"hypot": binary(math.Hypot),
+}
+
+// parser evaluates the expression while parsing it, by recursive descent:
+//
+//	expr    = term { ("+" | "-") term }
+//	term    = unary { ("*" | "/" | "%") unary }
+//	unary   = ("+" | "-") unary | power
+//	power   = postfix [ "^" unary ]
+//	postfix = primary { "!" }
+//	primary = number | constant | function "(" [ expr { "," expr } ] ")" | "(" expr ")"
+//
+// The power is right associative and binds tighter than the unary minus, so -2^2 is -4 and 2^3^2 is 512.
+type parser struct {
+	tokens  []token
+	pos     int
+	depth   int
+	degrees bool
+}
+
+// evaluate evaluates the expression, the angles of the trigonometric functions are in degrees if degrees is true.
+func evaluate(expr string, degrees bool) (float64, error) {
+	if strings.TrimSpace(expr) == "" {
+		return 0, fmt.Errorf("expression is empty")
+	}
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return 0, err
+	}
+	p := &parser{tokens: tokens, degrees: degrees}
+	v, err := p.parseExpr()
+	if err != nil {
+		return 0, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return 0, fmt.Errorf("unexpected %s", t)
+	}
+	if math.IsNaN(v) {
+		return 0, fmt.Errorf("result is not a real number")
+	}
+	if math.IsInf(v, 0) {
+		return 0, fmt.Errorf("result is infinite")
+	}
+	return v, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isOperator(ops ...string) bool {
+	t := p.peek()
+	if t.kind != tokenOperator {
+		return false
+	}
+	for _, op := range ops {
+		if t.text == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) enter() error {
+	p.depth++
+	if p.depth > maxDepth {
+		return fmt.Errorf("expression is nested too deeply, must be at most %d levels", maxDepth)
+	}
+	return nil
+}
+
+func (p *parser) leave() {
+	p.depth--
+}
+
+func (p *parser) parseExpr() (float64, error) {
+	v, err := p.parseTerm()
+	if err != nil {
+		return 0, err
+	}
+	for p.isOperator("+", "-") {
+		op := p.next()
+		rhs, err := p.parseTerm()
+		if err != nil {
+			return 0, err
+		}
+		if op.text == "+" {
+			v += rhs
+		} else {
+			v -= rhs
+		}
+	}
+	return v, nil
+}
+
+func (p *parser) parseTerm() (float64, error) {
+	v, err := p.parseUnary()
+	if err != nil {
+		return 0, err
+	}
+	for p.isOperator("*", "/", "%") {
+		op := p.next()
+		rhs, err := p.parseUnary()
+		if err != nil {
+			return 0, err
+		}
+		switch op.text {
+		case "*":
+			v *= rhs
+		case "/":
+			if rhs == 0 {
+				return 0, fmt.Errorf("division by zero at position %d", op.pos)
+			}
+			v /= rhs
+		case "%":
+			if rhs == 0 {
+				return 0, fmt.Errorf("modulo by zero at position %d", op.pos)
+			}
+			v = math.Mod(v, rhs)
+		}
+	}
+	return v, nil
+}
+
+func (p *parser) parseUnary() (float64, error) {
+	if !p.isOperator("+", "-") {
+		return p.parsePower()
+	}
+	if err := p.enter(); err != nil {
+		return 0, err
+	}
+	defer p.leave()
+
+	op := p.next()
+	v, err := p.parseUnary()
+	if err != nil {
+		return 0, err
+	}
+	if op.text == "-" {
+		return -v, nil
+	}
+	return v, nil
+}
+
+func (p *parser) parsePower() (float64, error) {
+	v, err := p.parsePostfix()
+	if err != nil {
+		return 0, err
+	}
+	if !p.isOperator("^") {
+		return v, nil
+	}
+	if err = p.enter(); err != nil {
+		return 0, err
+	}
+	defer p.leave()
+
+	p.next()
+	exp, err := p.parseUnary()
+	if err != nil {
+		return 0, err
+	}
+	return math.Pow(v, exp), nil
+}
+
+func (p *parser) parsePostfix() (float64, error) {
+	v, err := p.parsePrimary()
+	if err != nil {
+		return 0, err
+	}
+	for p.isOperator("!") {
+		op := p.next()
+		if v < 0 || v != math.Trunc(v) {
+			return 0, fmt.Errorf("factorial of %v at position %d, must be a non-negative integer", v, op.pos)
+		}
+		if v > maxFactorial {
+			return 0, fmt.Errorf("factorial of %v at position %d is too large, must be at most %d", v, op.pos, maxFactorial)
+		}
+		f := 1.0
+		for i := 2.0; i <= v; i++ {
+			f *= i
+		}
+		v = f
+	}
+	return v, nil
+}
+
+func (p *parser) parsePrimary() (float64, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber:
+		v, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid number %s", t)
+		}
+		return v, nil
+	case tokenIdent:
+		if p.peek().kind == tokenLParen {
+			return p.parseCall(t)
+		}
+		v, ok := constants[t.text]
+		if !ok {
+			return 0, fmt.Errorf("unknown constant %s", t)
+		}
+		return v, nil
+	case tokenLParen:
+		if err := p.enter(); err != nil {
+			return 0, err
+		}
+		defer p.leave()
+
+		v, err := p.parseExpr()
+		if err != nil {
+			return 0, err
+		}
+		if r := p.next(); r.kind != tokenRParen {
+			return 0, fmt.Errorf("missing closing parenthesis for %s, got %s", t, r)
+		}
+		return v, nil
+	default:
+		return 0, fmt.Errorf("unexpected %s", t)
+	}
+}
+
+func (p *parser) parseCall(name token) (float64, error) {
+	fn, ok := functions[name.text]
+	if !ok {
+		return 0, fmt.Errorf("unknown function %s", name)
+	}
+	if err := p.enter(); err != nil {
+		return 0, err
+	}
+	defer p.leave()
+
+	p.next() // (
+	var args []float64
+	if p.peek().kind != tokenRParen {
+		for {
+			v, err := p.parseExpr()
+			if err != nil {
+				return 0, err
+			}
+			args = append(args, v)
+			if p.peek().kind != tokenComma {
+				break
+			}
+			p.next()
+		}
+	}
+	if r := p.next(); r.kind != tokenRParen {
+		return 0, fmt.Errorf("missing closing parenthesis for %s, got %s", name, r)
+	}
+
+	if len(args) < fn.minArgs || (fn.maxArgs >= 0 && len(args) > fn.maxArgs) {
+		switch {
+		case fn.maxArgs < 0:
+			return 0, fmt.Errorf("function %s expects at least %d arguments, got %d", name.text, fn.minArgs, len(args))
+		case fn.minArgs == fn.maxArgs:
+			return 0, fmt.Errorf("function %s expects %d arguments, got %d", name.text, fn.minArgs, len(args))
+		default:
+			return 0, fmt.Errorf("function %s expects %d to %d arguments, got %d", name.text, fn.minArgs, fn.maxArgs, len(args))
+		}
+	}
+	if p.degrees && fn.angleArg {
+		args[0] = args[0] * math.Pi / 180
+	}
+	v := fn.fn(args)
+	if fn.angleArg && math.Abs(v) < trigEpsilon {
+		v = 0
+	}
+	if p.degrees && fn.angleResult {
+		v = v * 180 / math.Pi
+	}
+	return v, nil
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package calculator
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		expr    string
+		degrees bool
+		want    float64
+	}{
+		{expr: "1 + 2 * 3", want: 7},
+		{expr: "(1 + 2) * 3", want: 9},
+		{expr: "10 - 4 - 3", want: 3},
+		{expr: "2 ^ 3 ^ 2", want: 512},
+		{expr: "2 ** 10", want: 1024},
+		{expr: "-2 ^ 2", want: -4},
+		{expr: "2 ^ -1", want: 0.5},
+		{expr: "--3", want: 3},
+		{expr: "7 % 3", want: 1},
+		{expr: "1.5e3 + .5", want: 1500.5},
+		{expr: "2.5E-1", want: 0.25},
+		{expr: "5!", want: 120},
+		{expr: "3!!", want: 720},
+		{expr: "0!", want: 1},
+		{expr: "6 × 7 ÷ 2 − 1", want: 20},
+		{expr: "PI", want: math.Pi},
+		{expr: "tau / 2", want: math.Pi},
+		{expr: "sqrt(16) + cbrt(27)", want: 7},
+		{expr: "abs(-3)", want: 3},
+		{expr: "ln(e)", want: 1},
+		{expr: "log(1000)", want: 3},
+		{expr: "log(8, 2)", want: 3},
+		{expr: "log2(1024) + log10(100)", want: 12},
+		{expr: "min(3, 1, 2) + max(3, 1, 2)", want: 4},
+		{expr: "pow(2, 8)", want: 256},
+		{expr: "hypot(3, 4)", want: 5},
+		{expr: "round(2.5) + floor(-1.5) + ceil(1.2) + trunc(-1.7)", want: 2},
+		{expr: "round(3.14159, 2)", want: 3.14},
+		{expr: "sin(pi)", want: 0},
+		{expr: "sin(90)", degrees: true, want: 1},
+		{expr: "cos(180)", degrees: true, want: -1},
+		{expr: "asin(1)", degrees: true, want: 90},
+		{expr: "atan2(1, 1)", want: math.Pi / 4},
+	}
+	for _, tt := range tests {
+		got, err := evaluate(tt.expr, tt.degrees)
+		assert.NoError(t, err, tt.expr)
+		assert.InDelta(t, tt.want, got, 1e-12, tt.expr)
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		err  string
+	}{
+		{expr: " ", err: "expression is empty"},
+		{expr: "1 + ", err: "unexpected end of expression"},
+		{expr: "2 3", err: `unexpected "3" at position 3`},
+		// implicit multiplication is not supported
+		{expr: "2π", err: `unexpected "π" at position 2`},
+		{expr: "1 & 2", err: `unexpected character '&' at position 3`},
+		{expr: "(1 + 2", err: `missing closing parenthesis for "(" at position 1, got end of expression`},
+		{expr: "1 / (2 - 2)", err: "division by zero at position 3"},
+		{expr: "5 % 0", err: "modulo by zero at position 3"},
+		{expr: "1.2.3", err: `invalid number "1.2.3" at position 1`},
+		{expr: "x + 1", err: `unknown constant "x" at position 1`},
+		{expr: "foo(1)", err: `unknown function "foo" at position 1`},
+		{expr: "sqrt(1, 2)", err: "function sqrt expects 1 arguments, got 2"},
+		{expr: "log()", err: "function log expects 1 to 2 arguments, got 0"},
+		{expr: "max()", err: "function max expects at least 1 arguments, got 0"},
+		{expr: "sqrt(4", err: `missing closing parenthesis for "sqrt" at position 1, got end of expression`},
+		{expr: "(-1)!", err: "factorial of -1 at position 5, must be a non-negative integer"},
+		{expr: "2.5!", err: "factorial of 2.5 at position 4, must be a non-negative integer"},
+		{expr: "171!", err: "factorial of 171 at position 4 is too large, must be at most 170"},
+		{expr: "sqrt(-1)", err: "result is not a real number"},
+		{expr: "10 ^ 400", err: "result is infinite"},
+	}
+	for _, tt := range tests {
+		_, err := evaluate(tt.expr, false)
+		assert.EqualError(t, err, tt.err, tt.expr)
+	}
+
+	deep := ""
+	for i := 0; i < 200; i++ {
+		deep += "("
+	}
+	_, err := evaluate(deep+"1", false)
+	assert.EqualError(t, err, "expression is nested too deeply, must be at most 100 levels")
+}
@@ -0,0 +1,265 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package calculator
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	dimensionLength      = "length"
+	dimensionMass        = "mass"
+	dimensionTime        = "time"
+	dimensionTemperature = "temperature"
+	dimensionArea        = "area"
+	dimensionVolume      = "volume"
+	dimensionSpeed       = "speed"
+	dimensionData        = "data"
+	dimensionEnergy      = "energy"
+	dimensionPower       = "power"
+	dimensionPressure    = "pressure"
+	dimensionAngle       = "angle"
+)
+
+// unit converts a value to the base unit of its dimension: base = (value + offset) * factor.
+// The offset is only used by the temperatures, whose base unit is the degree Celsius,
+// so that e.g. 98.6 °F is 37 °C without floating point errors.
+type unit struct {
+	dimension string
+	factor    float64
+	offset    float64
+}
+
+type unitDef struct {
+	names []string
+	unit  unit
+}
+
+func defs(dimension string, list ...unitDef) []unitDef {
+	for i := range list {
+		list[i].unit.dimension = dimension
+	}
+	return list
+}
+
+func u(factor float64, names ...string) unitDef {
+	return unitDef{names: names, unit: unit{factor: factor}}
+}
+
+// unitDefs are the supported units, the first name of each unit is its symbol.
+// The base units are the meter, kilogram, second, degree Celsius, square meter, cubic meter, meter per second, byte,
+// joule, watt, pascal and radian.
+var unitDefs = concat(
+	defs(dimensionLength,
+		u(1, "m", "meter", "meters", "metre", "metres"),
+		u(1e3, "km", "kilometer", "kilometers", "kilometre", "kilometres"),
+		u(1e-2, "cm", "centimeter", "centimeters", "centimetre", "centimetres"),
+		u(1e-3, "mm", "millimeter", "millimeters", "millimetre", "millimetres"),
+		u(1e-6, "um", "µm", "micrometer", "micrometers", "micron", "microns"),
+		u(1e-9, "nm", "nanometer", "nanometers"),
+		u(0.0254, "in", "inch", "inches"),
+		u(0.3048, "ft", "foot", "feet"),
+		u(0.9144, "yd", "yard", "yards"),
+		u(1609.344, "mi", "mile", "miles"),
+		u(1852, "nmi", "nautical_mile", "nautical_miles"),
+		u(1.495978707e11, "au", "astronomical_unit", "astronomical_units"),
+		u(9.4607304725808e15, "ly", "light_year", "light_years"),
+	),
+	defs(dimensionMass,
+		u(1, "kg", "kilogram", "kilograms"),
+		u(1e-3, "g", "gram", "grams"),
+		u(1e-6, "mg", "milligram", "milligrams"),
+		u(1e-9, "ug", "µg", "microgram", "micrograms"),
+		u(1e3, "t", "tonne", "tonnes", "metric_ton", "metric_tons"),
+		u(0.028349523125, "oz", "ounce", "ounces"),
+		u(0.45359237, "lb", "lbs", "pound", "pounds"),
+		u(6.35029318, "st", "stone", "stones"),
+		u(907.18474, "short_ton", "short_tons", "us_ton", "us_tons"),
+		u(1016.0469088, "long_ton", "long_tons", "imperial_ton", "imperial_tons"),
+	),
+	defs(dimensionTime,
+		u(1e-9, "ns", "nanosecond", "nanoseconds"),
+		u(1e-6, "us", "µs", "microsecond", "microseconds"),
+		u(1e-3, "ms", "millisecond", "milliseconds"),
+		u(1, "s", "sec", "secs", "second", "seconds"),
+		u(60, "min", "mins", "minute", "minutes"),
+		u(3600, "h", "hr", "hrs", "hour", "hours"),
+		u(86400, "d", "day", "days"),
+		u(604800, "wk", "week", "weeks"),
+		// the average month and year of the Gregorian calendar
+		u(2629746, "mo", "month", "months"),
+		u(31556952, "yr", "year", "years"),
+	),
+	defs(dimensionTemperature,
+		unitDef{names: []string{"C", "°C", "celsius"}, unit: unit{factor: 1}},
+		unitDef{names: []string{"K", "kelvin"}, unit: unit{factor: 1, offset: -273.15}},
+		unitDef{names: []string{"F", "°F", "fahrenheit"}, unit: unit{factor: 5.0 / 9, offset: -32}},
+		unitDef{names: []string{"R", "°R", "rankine"}, unit: unit{factor: 5.0 / 9, offset: -491.67}},
+	),
+	defs(dimensionArea,
+		u(1, "m2", "sq_m", "square_meter", "square_meters", "square_metre", "square_metres"),
+		u(1e6, "km2", "sq_km", "square_kilometer", "square_kilometers"),
+		u(1e-4, "cm2", "sq_cm", "square_centimeter", "square_centimeters"),
+		u(1e-6, "mm2", "sq_mm", "square_millimeter", "square_millimeters"),
+		u(1e4, "ha", "hectare", "hectares"),
+		u(4046.8564224, "ac", "acre", "acres"),
+		u(6.4516e-4, "in2", "sq_in", "square_inch", "square_inches"),
+		u(0.09290304, "ft2", "sq_ft", "square_foot", "square_feet"),
+		u(0.83612736, "yd2", "sq_yd", "square_yard", "square_yards"),
+		u(2589988.110336, "mi2", "sq_mi", "square_mile", "square_miles"),
+	),
+	defs(dimensionVolume,
+		u(1, "m3", "cubic_meter", "cubic_meters", "cubic_metre", "cubic_metres"),
+		u(1e-3, "L", "l", "liter", "liters", "litre", "litres"),
+		u(1e-4, "dL", "deciliter", "deciliters", "decilitre", "decilitres"),
+		u(1e-5, "cL", "centiliter", "centiliters", "centilitre", "centilitres"),
+		u(1e-6, "mL", "ml", "cm3", "cc", "milliliter", "milliliters", "millilitre", "millilitres"),
+		u(1.6387064e-5, "in3", "cubic_inch", "cubic_inches"),
+		u(0.028316846592, "ft3", "cubic_foot", "cubic_feet"),
+		// the US customary units
+		u(4.92892159375e-6, "tsp", "teaspoon", "teaspoons"),
+		u(1.478676478125e-5, "tbsp", "tablespoon", "tablespoons"),
+		u(2.95735295625e-5, "fl_oz", "fluid_ounce", "fluid_ounces"),
+		u(2.365882365e-4, "cup", "cups"),
+		u(4.73176473e-4, "pt", "pint", "pints"),
+		u(9.46352946e-4, "qt", "quart", "quarts"),
+		u(3.785411784e-3, "gal", "gallon", "gallons"),
+		u(4.54609e-3, "imp_gal", "imperial_gallon", "imperial_gallons"),
+	),
+	defs(dimensionSpeed,
+		u(1, "m/s", "mps", "meter_per_second", "meters_per_second"),
+		u(1/3.6, "km/h", "kph", "kmh", "kilometer_per_hour", "kilometers_per_hour"),
+		u(0.44704, "mph", "mi/h", "mile_per_hour", "miles_per_hour"),
+		u(0.3048, "ft/s", "fps", "foot_per_second", "feet_per_second"),
+		u(1852.0/3600, "kn", "kt", "knot", "knots"),
+	),
+	defs(dimensionData,
+		u(0.125, "bit", "b", "bits"),
+		u(125, "kbit", "kb", "kilobit", "kilobits"),
+		u(125e3, "Mbit", "Mb", "megabit", "megabits"),
+		u(125e6, "Gbit", "Gb", "gigabit", "gigabits"),
+		u(1, "B", "byte", "bytes"),
+		u(1e3, "kB", "KB", "kilobyte", "kilobytes"),
+		u(1e6, "MB", "megabyte", "megabytes"),
+		u(1e9, "GB", "gigabyte", "gigabytes"),
+		u(1e12, "TB", "terabyte", "terabytes"),
+		u(1e15, "PB", "petabyte", "petabytes"),
+		u(1<<10, "KiB", "kibibyte", "kibibytes"),
+		u(1<<20, "MiB", "mebibyte", "mebibytes"),
+		u(1<<30, "GiB", "gibibyte", "gibibytes"),
+		u(1<<40, "TiB", "tebibyte", "tebibytes"),
+		u(1<<50, "PiB", "pebibyte", "pebibytes"),
+	),
+	defs(dimensionEnergy,
+		u(1, "J", "joule", "joules"),
+		u(1e3, "kJ", "kilojoule", "kilojoules"),
+		u(1e6, "MJ", "megajoule", "megajoules"),
+		u(4.184, "cal", "calorie", "calories"),
+		u(4184, "kcal", "kilocalorie", "kilocalories"),
+		u(3600, "Wh", "watt_hour", "watt_hours"),
+		u(3.6e6, "kWh", "kilowatt_hour", "kilowatt_hours"),
+		u(1.602176634e-19, "eV", "electronvolt", "electronvolts"),
+		u(1055.05585262, "BTU", "btu"),
+	),
+	defs(dimensionPower,
+		u(1, "W", "watt", "watts"),
+		u(1e3, "kW", "kilowatt", "kilowatts"),
+		u(1e6, "MW", "megawatt", "megawatts"),
+		u(1e9, "GW", "gigawatt", "gigawatts"),
+		u(745.69987158227022, "hp", "horsepower"),
+	),
+	defs(dimensionPressure,
+		u(1, "Pa", "pascal", "pascals"),
+		u(100, "hPa", "hectopascal", "hectopascals"),
+		u(1e3, "kPa", "kilopascal", "kilopascals"),
+		u(1e6, "MPa", "megapascal", "megapascals"),
+		u(1e5, "bar", "bars"),
+		u(100, "mbar", "millibar", "millibars"),
+		u(101325, "atm", "atmosphere", "atmospheres"),
+		u(6894.757293168361, "psi"),
+		u(133.322387415, "mmHg"),
+	),
+	defs(dimensionAngle,
+		u(1, "rad", "radian", "radians"),
+		u(math.Pi/180, "deg", "°", "degree", "degrees"),
+		u(math.Pi/200, "grad", "gradian", "gradians"),
+		u(math.Pi/10800, "arcmin", "arcminute", "arcminutes"),
+		u(math.Pi/648000, "arcsec", "arcsecond", "arcseconds"),
+		u(2*math.Pi, "turn", "turns", "revolution", "revolutions"),
+	),
+)
+
+func concat(lists ...[]unitDef) []unitDef {
+	var result []unitDef
+	for _, list := range lists {
+		result = append(result, list...)
+	}
+	return result
+}
+
+var (
+	// units are the units by name.
+	units = map[string]unit{}
+	// foldedUnits are the units by lower case name, to accept e.g. "kwh" and "Km",
+	// nil for the names shared by several units, e.g. "mb" is either "MB" or "Mb".
+	foldedUnits = map[string]*unit{}
+)
+
+func init() {
+	for _, def := range unitDefs {
+		for _, name := range def.names {
+			if _, ok := units[name]; ok {
+				panic(fmt.Sprintf("duplicated unit: %s", name))
+			}
+			units[name] = def.unit
+		}
+	}
+	for _, def := range unitDefs {
+		for _, name := range def.names {
+			folded := strings.ToLower(name)
+			if prev, ok := foldedUnits[folded]; ok && (prev == nil || *prev != def.unit) {
+				foldedUnits[folded] = nil
+				continue
+			}
+			un := def.unit
+			foldedUnits[folded] = &un
+		}
+	}
+}
+
+// unitReplacer normalizes the spellings of the units, e.g. "square meters" or "m²".
+var unitReplacer = strings.NewReplacer(" ", "_", "-", "_", "²", "2", "³", "3", "μ", "µ")
+
+func lookupUnit(name string) (unit, error) {
+	normalized := unitReplacer.Replace(strings.Join(strings.Fields(name), " "))
+	if un, ok := units[normalized]; ok {
+		return un, nil
+	}
+	if un, ok := foldedUnits[strings.ToLower(normalized)]; ok {
+		if un == nil {
+			return unit{}, fmt.Errorf("ambiguous unit: %q, mind the case, e.g. MB is megabytes and Mb is megabits", name)
+		}
+		return *un, nil
+	}
+	return unit{}, fmt.Errorf("unknown unit: %q", name)
+}
+
+// convert converts the value between two units of the same dimension.
+func convert(v float64, from, to unit) float64 {
+	return (v+from.offset)*from.factor/to.factor - to.offset
+}
@@ -0,0 +1,374 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commandline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eino-contrib/jsonschema"
+	orderedmap "github.com/wk8/go-ordered-map/v2"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/tool/commandline/sandbox"
+)
+
+// Language is a programming language supported by the code interpreter.
+type Language string
+
+const (
+	LanguagePython     Language = "python"
+	LanguageJavaScript Language = "javascript"
+	LanguageGo         Language = "go"
+)
+
+// Runtime describes how the code of a language is run.
+type Runtime struct {
+	// Image is the docker image the code runs in.
+	Image string
+	// FileName is the name of the file the code is written to, in the working directory.
+	FileName string
+	// Command runs the file, e.g. "python3 main.py".
+	Command string
+}
+
+// DefaultRuntimes are the runtimes of the languages supported out of the box.
+// The python image only has the standard library, use an image with the data analysis packages,
+// e.g. jupyter/scipy-notebook, for pandas or matplotlib.
+var DefaultRuntimes = map[Language]*Runtime{
+	LanguagePython:     {Image: "python:3.12-slim", FileName: "main.py", Command: "python3 main.py"},
+	LanguageJavaScript: {Image: "node:20-slim", FileName: "main.js", Command: "node main.js"},
+	LanguageGo:         {Image: "golang:1.23", FileName: "main.go", Command: "go run main.go"},
+}
+
+const (
+	defaultCodeInterpreterName = "code_interpreter"
+	defaultCodeInterpreterDesc = `Executes code in an isolated container and returns its stdout, stderr, exit code and the files it produced.
+* Each call runs in a new container, nothing is kept from the previous calls
+* Only printed outputs are visible, print the results you need
+* Save plots and other outputs as files in the current directory, e.g. plt.savefig("plot.png"), they are returned in "files"`
+
+	defaultInterpreterTimeout       = 60 * time.Second
+	defaultInterpreterMaxOutputSize = 16 * 1024
+	containerWorkDir                = "/workspace"
+)
+
+// CodeInterpreterConfig is the configuration of the code interpreter.
+type CodeInterpreterConfig struct {
+	// ToolName is the name of the tool.
+	// Optional. Default "code_interpreter".
+	ToolName string
+	// ToolDesc is the description of the tool.
+	// Optional. Default a description telling the model how to get outputs and plots.
+	ToolDesc string
+	// Languages are the languages the model can use, the first one being the default.
+	// Optional. Default python only.
+	Languages []Language
+	// Runtimes overrides or adds runtimes of languages, e.g. a python image with the data analysis packages.
+	// Optional. Default DefaultRuntimes.
+	Runtimes map[Language]*Runtime
+	// ScratchDir is the host directory in which each run gets its own directory, mounted as the working directory
+	// of the container. The run directories are kept so that the produced files can be used, cleaning them is up to the caller.
+	// Optional. Default the temporary directory of the system.
+	ScratchDir string
+	// Env is the environment of the container, as KEY=value entries.
+	// Optional.
+	Env []string
+	// MemoryLimit is the memory limit in bytes of each run.
+	// Optional. Default 512MB.
+	MemoryLimit int64
+	// CPULimit is the CPU limit in cores of each run.
+	// Optional. Default 1.
+	CPULimit float64
+	// Timeout is the maximum duration of each run.
+	// Optional. Default 60s.
+	Timeout time.Duration
+	// NetworkEnabled gives the container access to the network, e.g. to install packages.
+	// Optional. Default false.
+	NetworkEnabled bool
+	// MaxOutputSize is the maximum size in bytes of the stdout and of the stderr returned to the model.
+	// Optional. Default 16KB.
+	MaxOutputSize int
+}
+
+// CodeInterpreterInput is the input of the code interpreter.
+type CodeInterpreterInput struct {
+	Language Language `json:"language,omitempty"`
+	Code     string   `json:"code"`
+}
+
+// CodeInterpreterResult is the result of a run.
+type CodeInterpreterResult struct {
+	Stdout   string        `json:"stdout"`
+	Stderr   string        `json:"stderr,omitempty"`
+	ExitCode int           `json:"exit_code"`
+	Files    []*OutputFile `json:"files,omitempty"`
+	// Dir is the host directory of the run, containing the code and the produced files.
+	Dir string `json:"-"`
+}
+
+// OutputFile is a file produced by a run.
+type OutputFile struct {
+	// Name is the path of the file relative to the working directory.
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Size     int64  `json:"size"`
+	MimeType string `json:"mime_type,omitempty"`
+}
+
+// CodeInterpreter is a tool running model-generated code in ephemeral docker containers.
+type CodeInterpreter struct {
+	info      *schema.ToolInfo
+	languages []Language
+	runtimes  map[Language]*Runtime
+	config    CodeInterpreterConfig
+}
+
+// NewCodeInterpreter creates a code interpreter tool. Docker must be available when the tool runs.
+func NewCodeInterpreter(_ context.Context, cfg *CodeInterpreterConfig) (*CodeInterpreter, error) {
+	if cfg == nil {
+		cfg = &CodeInterpreterConfig{}
+	}
+	config := *cfg
+	if config.ToolName == "" {
+		config.ToolName = defaultCodeInterpreterName
+	}
+	if config.ToolDesc == "" {
+		config.ToolDesc = defaultCodeInterpreterDesc
+	}
+	if config.Timeout == 0 {
+		config.Timeout = defaultInterpreterTimeout
+	}
+	if config.MaxOutputSize == 0 {
+		config.MaxOutputSize = defaultInterpreterMaxOutputSize
+	}
+	if config.ScratchDir == "" {
+		config.ScratchDir = os.TempDir()
+	}
+
+	languages := config.Languages
+	if len(languages) == 0 {
+		languages = []Language{LanguagePython}
+	}
+	runtimes := make(map[Language]*Runtime, len(languages))
+	enum := make([]any, 0, len(languages))
+	for _, lang := range languages {
+		rt, ok := config.Runtimes[lang]
+		if !ok {
+			rt, ok = DefaultRuntimes[lang]
+		}
+		if !ok || rt == nil {
+			return nil, fmt.Errorf("no runtime for language: %s", lang)
+		}
+		if rt.Image == "" || rt.FileName == "" || rt.Command == "" {
+			return nil, fmt.Errorf("runtime image, file name and command are required, language= %s", lang)
+		}
+		runtimes[lang] = rt
+		enum = append(enum, string(lang))
+	}
+
+	return &CodeInterpreter{
+		info: &schema.ToolInfo{
+			Name: config.ToolName,
+			Desc: config.ToolDesc,
+			ParamsOneOf: schema.NewParamsOneOfByJSONSchema(
+				&jsonschema.Schema{
+					Type:     string(schema.Object),
+					Required: []string{"code"},
+					Properties: orderedmap.New[string, *jsonschema.Schema](
+						orderedmap.WithInitialData[string, *jsonschema.Schema](
+							orderedmap.Pair[string, *jsonschema.Schema]{
+								Key: "language",
+								Value: &jsonschema.Schema{
+									Type:        string(schema.String),
+									Description: fmt.Sprintf("The language of the code. Default %s.", languages[0]),
+									Enum:        enum,
+								},
+							},
+							orderedmap.Pair[string, *jsonschema.Schema]{
+								Key: "code",
+								Value: &jsonschema.Schema{
+									Type:        string(schema.String),
+									Description: "The code to execute.",
+								},
+							},
+						),
+					),
+				},
+			),
+		},
+		languages: languages,
+		runtimes:  runtimes,
+		config:    config,
+	}, nil
+}
+
+func (c *CodeInterpreter) Info(_ context.Context) (*schema.ToolInfo, error) {
+	return c.info, nil
+}
+
+// Execute runs the code in a new container, with a new run directory of ScratchDir mounted as its working directory.
+// A non-zero exit code is not an error, the stderr being returned to the model so that it can fix the code.
+func (c *CodeInterpreter) Execute(ctx context.Context, input *CodeInterpreterInput) (*CodeInterpreterResult, error) {
+	lang := input.Language
+	if lang == "" {
+		lang = c.languages[0]
+	}
+	rt, ok := c.runtimes[lang]
+	if !ok {
+		return nil, fmt.Errorf("unsupported language: %s", lang)
+	}
+	if strings.TrimSpace(input.Code) == "" {
+		return nil, errors.New("code is required")
+	}
+
+	if err := os.MkdirAll(c.config.ScratchDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create scratch dir: %w", err)
+	}
+	dir, err := os.MkdirTemp(c.config.ScratchDir, "run-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create run dir: %w", err)
+	}
+	// the container may run as another user than the host process
+	if err = os.Chmod(dir, 0777); err != nil {
+		return nil, fmt.Errorf("failed to create run dir: %w", err)
+	}
+	if err = os.WriteFile(filepath.Join(dir, rt.FileName), []byte(input.Code), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write code file: %w", err)
+	}
+
+	sb, err := sandbox.NewDockerSandbox(ctx, &sandbox.Config{
+		Image:          rt.Image,
+		WorkDir:        containerWorkDir,
+		HostWorkDir:    dir,
+		Env:            append([]string{"MPLBACKEND=Agg"}, c.config.Env...),
+		MemoryLimit:    c.config.MemoryLimit,
+		CPULimit:       c.config.CPULimit,
+		NetworkEnabled: c.config.NetworkEnabled,
+		Timeout:        c.config.Timeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = sb.Create(ctx); err != nil {
+		return nil, err
+	}
+	defer sb.Cleanup(context.WithoutCancel(ctx))
+
+	// the outputs are redirected out of the working directory, so that they are not taken as produced files
+	output, err := sb.RunCommand(ctx, rt.Command+" >/tmp/.stdout 2>/tmp/.stderr; echo $?")
+	if err != nil {
+		return nil, err
+	}
+	exitCode, err := strconv.Atoi(strings.TrimSpace(output))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exit code: %w, output= %s", err, output)
+	}
+	stdout, err := sb.ReadFile(ctx, "/tmp/.stdout")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stdout: %w", err)
+	}
+	stderr, err := sb.ReadFile(ctx, "/tmp/.stderr")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stderr: %w", err)
+	}
+
+	files, err := outputFiles(dir, rt.FileName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CodeInterpreterResult{
+		Stdout:   truncateOutput(stdout, c.config.MaxOutputSize),
+		Stderr:   truncateOutput(stderr, c.config.MaxOutputSize),
+		ExitCode: exitCode,
+		Files:    files,
+		Dir:      dir,
+	}, nil
+}
+
+func (c *CodeInterpreter) InvokableRun(ctx context.Context, argumentsInJSON string, _ ...tool.Option) (string, error) {
+	input := &CodeInterpreterInput{}
+	if err := json.Unmarshal([]byte(argumentsInJSON), input); err != nil {
+		return "", fmt.Errorf("extract argument fail: %w", err)
+	}
+
+	result, err := c.Execute(ctx, input)
+	if err != nil {
+		return "", fmt.Errorf("execute error: %w", err)
+	}
+
+	b, err := json.Marshal(result)
+	if err != nil {
+		return "", fmt.Errorf("marshal result fail: %w", err)
+	}
+	return string(b), nil
+}
+
+// outputFiles lists the files of the run directory, except the code file.
+func outputFiles(dir, codeFile string) ([]*OutputFile, error) {
+	var files []*OutputFile
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if name == codeFile {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		files = append(files, &OutputFile{
+			Name:     filepath.ToSlash(name),
+			Path:     path,
+			Size:     info.Size(),
+			MimeType: mime.TypeByExtension(filepath.Ext(name)),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list output files: %w", err)
+	}
+
+	return files, nil
+}
+
+func truncateOutput(s string, size int) string {
+	if size <= 0 || len(s) <= size {
+		return s
+	}
+	return fmt.Sprintf("%s\n[output truncated, %d bytes dropped]", s[:size], len(s)-size)
+}
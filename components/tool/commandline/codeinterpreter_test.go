@@ -0,0 +1,115 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commandline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bytedance/mockey"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-ext/components/tool/commandline/sandbox"
+)
+
+func TestNewCodeInterpreter(t *testing.T) {
+	ctx := context.Background()
+
+	ci, err := NewCodeInterpreter(ctx, nil)
+	assert.NoError(t, err)
+	info, err := ci.Info(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "code_interpreter", info.Name)
+	js, err := info.ParamsOneOf.ToJSONSchema()
+	assert.NoError(t, err)
+	lang, _ := js.Properties.Get("language")
+	assert.Equal(t, []any{"python"}, lang.Enum)
+
+	_, err = NewCodeInterpreter(ctx, &CodeInterpreterConfig{Languages: []Language{"rust"}})
+	assert.EqualError(t, err, "no runtime for language: rust")
+	_, err = NewCodeInterpreter(ctx, &CodeInterpreterConfig{
+		Languages: []Language{"rust"},
+		Runtimes:  map[Language]*Runtime{"rust": {Image: "rust:1"}},
+	})
+	assert.EqualError(t, err, "runtime image, file name and command are required, language= rust")
+
+	ci, err = NewCodeInterpreter(ctx, &CodeInterpreterConfig{Languages: []Language{LanguageGo, LanguagePython}})
+	assert.NoError(t, err)
+	_, err = ci.Execute(ctx, &CodeInterpreterInput{Language: LanguageJavaScript, Code: "console.log(1)"})
+	assert.EqualError(t, err, "unsupported language: javascript")
+	_, err = ci.Execute(ctx, &CodeInterpreterInput{Code: " "})
+	assert.EqualError(t, err, "code is required")
+}
+
+func TestCodeInterpreter_InvokableRun(t *testing.T) {
+	ctx := context.Background()
+	scratch := t.TempDir()
+
+	var commands []string
+	cleaned := false
+	defer mockey.Mock((*sandbox.DockerSandbox).Create).Return(nil).Build().UnPatch()
+	defer mockey.Mock((*sandbox.DockerSandbox).Cleanup).To(func(s *sandbox.DockerSandbox, ctx context.Context) {
+		cleaned = true
+	}).Build().UnPatch()
+	defer mockey.Mock((*sandbox.DockerSandbox).RunCommand).To(func(s *sandbox.DockerSandbox, ctx context.Context, cmd string) (string, error) {
+		commands = append(commands, cmd)
+		dirs, _ := filepath.Glob(filepath.Join(scratch, "run-*"))
+		if len(dirs) != 1 {
+			return "", errors.New("run dir not found")
+		}
+		code, _ := os.ReadFile(filepath.Join(dirs[0], "main.py"))
+		if string(code) != "print(1)" {
+			return "", errors.New("code file not found")
+		}
+		_ = os.MkdirAll(filepath.Join(dirs[0], "out"), 0755)
+		_ = os.WriteFile(filepath.Join(dirs[0], "out", "plot.png"), []byte("png"), 0644)
+		return "1\n", nil
+	}).Build().UnPatch()
+	defer mockey.Mock((*sandbox.DockerSandbox).ReadFile).To(func(s *sandbox.DockerSandbox, ctx context.Context, path string) (string, error) {
+		if path == "/tmp/.stdout" {
+			return "0123456789", nil
+		}
+		return "Traceback", nil
+	}).Build().UnPatch()
+
+	ci, err := NewCodeInterpreter(ctx, &CodeInterpreterConfig{ScratchDir: scratch, MaxOutputSize: 4})
+	assert.NoError(t, err)
+
+	output, err := ci.InvokableRun(ctx, `{"code": "print(1)"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"python3 main.py >/tmp/.stdout 2>/tmp/.stderr; echo $?"}, commands)
+	assert.True(t, cleaned)
+
+	dirs, _ := filepath.Glob(filepath.Join(scratch, "run-*"))
+	result := &CodeInterpreterResult{}
+	assert.NoError(t, json.Unmarshal([]byte(output), result))
+	assert.Equal(t, &CodeInterpreterResult{
+		Stdout:   "0123\n[output truncated, 6 bytes dropped]",
+		Stderr:   "Trac\n[output truncated, 5 bytes dropped]",
+		ExitCode: 1,
+		Files: []*OutputFile{{
+			Name:     "out/plot.png",
+			Path:     filepath.Join(dirs[0], "out", "plot.png"),
+			Size:     3,
+			MimeType: "image/png",
+		}},
+	}, result)
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/cloudwego/eino-ext/components/tool/commandline"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// you should ensure that docker has been started, each run creates a new container
+	ci, err := commandline.NewCodeInterpreter(ctx, &commandline.CodeInterpreterConfig{
+		Languages: []commandline.Language{commandline.LanguagePython, commandline.LanguageJavaScript},
+		// an image with pandas and matplotlib for data analysis
+		Runtimes: map[commandline.Language]*commandline.Runtime{
+			commandline.LanguagePython: {Image: "jupyter/scipy-notebook", FileName: "main.py", Command: "python3 main.py"},
+		},
+		ScratchDir: "./scratch",
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	code := "import matplotlib.pyplot as plt\n\nplt.plot([1, 2, 3], [2, 4, 1])\nplt.savefig(\"plot.png\")\nprint(\"saved\")"
+	result, err := ci.Execute(ctx, &commandline.CodeInterpreterInput{Code: code})
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("exit code: %d, stdout: %s, stderr: %s", result.ExitCode, result.Stdout, result.Stderr)
+	for _, f := range result.Files {
+		log.Printf("produced file: %s (%s, %d bytes)", f.Path, f.MimeType, f.Size)
+	}
+}
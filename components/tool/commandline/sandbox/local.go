@@ -0,0 +1,275 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sandbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino-ext/components/tool/commandline/sandbox/internal/safepath"
+)
+
+//go:generate sh ../../../../libs/acl/bundle.sh safepath internal/safepath
+
+// LocalConfig configures the local sandbox.
+type LocalConfig struct {
+	// RootDir is the directory the sandbox is jailed in: commands run in it, and files can only be read or written under it.
+	// Note that the commands themselves are not confined to it, use the DockerSandbox for a full isolation.
+	// Required.
+	RootDir string
+	// AllowedCommands are regular expressions, each simple command of a command line must match one of them,
+	// e.g. `^(ls|cat|grep|find|python3)\b`. The command lines nesting commands, with substitutions,
+	// subshells or groups, are rejected.
+	// Optional. Default all the commands are allowed.
+	AllowedCommands []string
+	// DeniedCommands are regular expressions of rejected commands, checked before AllowedCommands,
+	// e.g. DefaultDeniedCommands.
+	// Optional.
+	DeniedCommands []string
+	// Timeout is the maximum duration of a command, the whole process group is killed when it expires.
+	// Optional. Default 30s.
+	Timeout time.Duration
+	// CPUTime is the maximum CPU time of a command, applied with the RLIMIT_CPU resource limit.
+	// Optional. Default 0, no limit.
+	CPUTime time.Duration
+	// MemoryLimit is the maximum virtual memory in bytes of each process, applied with the RLIMIT_AS resource limit.
+	// Runtimes reserving large address spaces, such as the JVM or Go, need a limit well above their actual usage.
+	// Optional. Default 0, no limit.
+	MemoryLimit int64
+	// Env is the environment of the commands, as KEY=value entries.
+	// The environment of the host is not inherited, the commands only get PATH, HOME set to RootDir, and these entries.
+	// Optional.
+	Env []string
+	// InheritEnv are names of the host environment variables passed to the commands, e.g. LANG.
+	// Optional.
+	InheritEnv []string
+	// MaxOutputSize is the maximum size in bytes kept of the stdout and of the stderr of a command,
+	// the rest is dropped, and a note about the truncation appended.
+	// Optional. Default 64KB, negative for no limit.
+	MaxOutputSize int
+}
+
+const (
+	defaultMaxOutputSize = 64 * 1024
+	defaultPath          = "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"
+)
+
+// LocalSandbox runs the commands on the host, in a working directory jail, with resource limits,
+// a scrubbed environment and capped outputs.
+// It implements the commandline.Operator interface, and suits trusted agents or machines which are disposable,
+// such as CI runners or containers. Use the DockerSandbox to run untrusted code.
+type LocalSandbox struct {
+	config LocalConfig
+	root   string
+	policy *commandPolicy
+	env    []string
+}
+
+// NewLocalSandbox creates a new local sandbox with the given configuration.
+func NewLocalSandbox(ctx context.Context, config *LocalConfig) (*LocalSandbox, error) {
+	if config == nil {
+		return nil, errors.New("config is required")
+	}
+	if config.RootDir == "" {
+		return nil, errors.New("root dir is required")
+	}
+	if config.CPUTime < 0 || config.MemoryLimit < 0 {
+		return nil, errors.New("resource limits must not be negative")
+	}
+
+	root, err := filepath.Abs(config.RootDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve root dir: %w", err)
+	}
+	if root, err = filepath.EvalSymlinks(root); err != nil {
+		return nil, fmt.Errorf("failed to resolve root dir: %w", err)
+	}
+	if info, err := os.Stat(root); err != nil {
+		return nil, fmt.Errorf("failed to stat root dir: %w", err)
+	} else if !info.IsDir() {
+		return nil, fmt.Errorf("root dir is not a directory: %s", root)
+	}
+
+	policy, err := newCommandPolicy(config.AllowedCommands, config.DeniedCommands)
+	if err != nil {
+		return nil, err
+	}
+
+	nConfig := *config
+	if nConfig.Timeout == 0 {
+		nConfig.Timeout = defaultTimeout
+	}
+	if nConfig.MaxOutputSize == 0 {
+		nConfig.MaxOutputSize = defaultMaxOutputSize
+	}
+
+	env := []string{"PATH=" + defaultPath, "HOME=" + root}
+	for _, name := range config.InheritEnv {
+		if v, ok := os.LookupEnv(name); ok {
+			env = append(env, name+"="+v)
+		}
+	}
+	env = append(env, config.Env...)
+
+	return &LocalSandbox{
+		config: nConfig,
+		root:   root,
+		policy: policy,
+		env:    env,
+	}, nil
+}
+
+// RunCommand executes a command with /bin/sh in the root dir.
+func (s *LocalSandbox) RunCommand(ctx context.Context, command string) (string, error) {
+	if err := s.policy.check(command); err != nil {
+		return "", err
+	}
+
+	timeout := s.config.Timeout
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// the limits are set by the shell before running the command, as both the soft and hard limits,
+	// so that the command can't raise them
+	var limits []string
+	if s.config.CPUTime > 0 {
+		limits = append(limits, fmt.Sprintf("ulimit -t %d", int64((s.config.CPUTime+time.Second-1)/time.Second)))
+	}
+	if s.config.MemoryLimit > 0 {
+		limits = append(limits, fmt.Sprintf("ulimit -v %d", (s.config.MemoryLimit+1023)/1024))
+	}
+	script := command
+	if len(limits) > 0 {
+		script = strings.Join(limits, " && ") + " || exit 125\n" + command
+	}
+
+	stdout := &cappedBuffer{max: s.config.MaxOutputSize}
+	stderr := &cappedBuffer{max: s.config.MaxOutputSize}
+	cmd := exec.CommandContext(ctx, "/bin/sh", "-c", script)
+	cmd.Dir = s.root
+	cmd.Env = s.env
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	// background processes keeping the outputs open must not block the command
+	cmd.WaitDelay = time.Second
+	setProcessGroup(cmd)
+
+	err := cmd.Run()
+	if ctx.Err() != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return "", fmt.Errorf("command execution timed out after %v", timeout)
+		}
+		return "", ctx.Err()
+	}
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", fmt.Errorf("command execution failed with exit code %d: %s", exitErr.ExitCode(), stderr.String())
+		}
+		return "", fmt.Errorf("failed to run command: %w", err)
+	}
+
+	return stdout.String(), nil
+}
+
+// ReadFile reads a file under the root dir.
+func (s *LocalSandbox) ReadFile(ctx context.Context, path string) (string, error) {
+	resolvedPath, err := s.safeResolvePath(path)
+	if err != nil {
+		return "", err
+	}
+
+	content, err := os.ReadFile(resolvedPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	return string(content), nil
+}
+
+// WriteFile writes content to a file under the root dir, creating the parent directories.
+func (s *LocalSandbox) WriteFile(ctx context.Context, path string, content string) error {
+	resolvedPath, err := s.safeResolvePath(path)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(resolvedPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err = os.WriteFile(resolvedPath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
+// IsDirectory checks if a path under the root dir is a directory.
+func (s *LocalSandbox) IsDirectory(ctx context.Context, path string) (bool, error) {
+	resolvedPath, err := s.safeResolvePath(path)
+	if err != nil {
+		return false, err
+	}
+
+	info, err := os.Stat(resolvedPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check path type: %w", err)
+	}
+
+	return info.IsDir(), nil
+}
+
+// Exists checks if a path exists under the root dir.
+func (s *LocalSandbox) Exists(ctx context.Context, path string) (bool, error) {
+	resolvedPath, err := s.safeResolvePath(path)
+	if err != nil {
+		return false, err
+	}
+
+	if _, err = os.Stat(resolvedPath); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check path existence: %w", err)
+	}
+
+	return true, nil
+}
+
+// safeResolvePath resolves a path relative to the root dir, and rejects the paths leaving it,
+// including through symbolic links.
+func (s *LocalSandbox) safeResolvePath(path string) (string, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(s.root, path)
+	}
+	path = filepath.Clean(path)
+
+	resolved, err := safepath.Resolve(s.root, path)
+	if err != nil && !errors.Is(err, safepath.ErrOutsideRoot) {
+		return "", fmt.Errorf("failed to resolve path: %w", err)
+	}
+	return resolved, err
+}
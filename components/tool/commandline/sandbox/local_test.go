@@ -0,0 +1,229 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sandbox
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLocalSandbox(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewLocalSandbox(ctx, nil)
+	assert.EqualError(t, err, "config is required")
+	_, err = NewLocalSandbox(ctx, &LocalConfig{})
+	assert.EqualError(t, err, "root dir is required")
+	_, err = NewLocalSandbox(ctx, &LocalConfig{RootDir: filepath.Join(t.TempDir(), "missing")})
+	assert.ErrorContains(t, err, "failed to resolve root dir")
+	_, err = NewLocalSandbox(ctx, &LocalConfig{RootDir: t.TempDir(), DeniedCommands: []string{"("}})
+	assert.ErrorContains(t, err, "invalid denied command pattern")
+
+	s, err := NewLocalSandbox(ctx, &LocalConfig{RootDir: t.TempDir()})
+	assert.NoError(t, err)
+	assert.Equal(t, defaultTimeout, s.config.Timeout)
+	assert.Equal(t, defaultMaxOutputSize, s.config.MaxOutputSize)
+}
+
+func TestLocalSandbox_Files(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symbolic links require privileges on windows")
+	}
+	ctx := context.Background()
+	root := t.TempDir()
+	outside := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(outside, "secret.txt"), []byte("secret"), 0644))
+	assert.NoError(t, os.Symlink(outside, filepath.Join(root, "link")))
+	assert.NoError(t, os.Symlink(filepath.Join(outside, "pwned.txt"), filepath.Join(root, "dangling")))
+
+	s, err := NewLocalSandbox(ctx, &LocalConfig{RootDir: root})
+	assert.NoError(t, err)
+
+	assert.NoError(t, s.WriteFile(ctx, "a/b/test.txt", "hello"))
+	content, err := s.ReadFile(ctx, filepath.Join(s.root, "a/b/test.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", content)
+
+	ok, err := s.IsDirectory(ctx, "a/b")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = s.IsDirectory(ctx, "a/b/test.txt")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	ok, err = s.Exists(ctx, "a/b/test.txt")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = s.Exists(ctx, "a/c")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	for _, path := range []string{"../x.txt", "a/../../x.txt", filepath.Join(outside, "secret.txt"), "link/secret.txt", "link/new/x.txt", "dangling"} {
+		_, err = s.ReadFile(ctx, path)
+		assert.ErrorContains(t, err, "path is outside of the root dir", path)
+		err = s.WriteFile(ctx, path, "x")
+		assert.ErrorContains(t, err, "path is outside of the root dir", path)
+	}
+	_, err = os.Stat(filepath.Join(outside, "new"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(outside, "pwned.txt"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestLocalSandbox_RunCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the local sandbox runs commands with /bin/sh")
+	}
+	ctx := context.Background()
+
+	t.Run("working dir and environment", func(t *testing.T) {
+		t.Setenv("SANDBOX_TEST_SECRET", "secret")
+		t.Setenv("SANDBOX_TEST_LANG", "C")
+		s, err := NewLocalSandbox(ctx, &LocalConfig{
+			RootDir:    t.TempDir(),
+			Env:        []string{"FOO=bar"},
+			InheritEnv: []string{"SANDBOX_TEST_LANG"},
+		})
+		assert.NoError(t, err)
+
+		output, err := s.RunCommand(ctx, `pwd; echo "$HOME|$FOO|$SANDBOX_TEST_LANG|$SANDBOX_TEST_SECRET"`)
+		assert.NoError(t, err)
+		assert.Equal(t, s.root+"\n"+s.root+"|bar|C|\n", output)
+
+		_, err = s.RunCommand(ctx, "echo oops >&2; exit 3")
+		assert.EqualError(t, err, "command execution failed with exit code 3: oops\n")
+	})
+
+	t.Run("command policy", func(t *testing.T) {
+		s, err := NewLocalSandbox(ctx, &LocalConfig{
+			RootDir:         t.TempDir(),
+			AllowedCommands: []string{`^(echo|ls)\b`},
+			DeniedCommands:  DefaultDeniedCommands,
+		})
+		assert.NoError(t, err)
+
+		output, err := s.RunCommand(ctx, "echo a && echo b | ls")
+		assert.NoError(t, err)
+		assert.Equal(t, "a\n", output)
+
+		for _, cmd := range []string{"cat /etc/passwd", "echo a; cat /etc/passwd", "echo $(cat /etc/passwd)", "echo `id`", "sudo ls", "echo a\nrm -rf /",
+			"ls <(cat /etc/passwd)", "ls >(sh -c id)", "(cat /etc/passwd)", "{ cat /etc/passwd; }"} {
+			_, err = s.RunCommand(ctx, cmd)
+			assert.True(t, errors.Is(err, ErrCommandNotAllowed), cmd)
+		}
+
+		output, err = s.RunCommand(ctx, `echo '(a) $(b)' "{ c }" \(d\)`)
+		assert.NoError(t, err)
+		assert.Equal(t, "(a) $(b) { c } (d)\n", output)
+	})
+
+	t.Run("output size", func(t *testing.T) {
+		s, err := NewLocalSandbox(ctx, &LocalConfig{RootDir: t.TempDir(), MaxOutputSize: 10})
+		assert.NoError(t, err)
+
+		output, err := s.RunCommand(ctx, "printf 0123456789abcdef")
+		assert.NoError(t, err)
+		assert.Equal(t, "0123456789\n[output truncated, 6 bytes dropped]", output)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		s, err := NewLocalSandbox(ctx, &LocalConfig{RootDir: t.TempDir(), Timeout: 200 * time.Millisecond})
+		assert.NoError(t, err)
+
+		start := time.Now()
+		_, err = s.RunCommand(ctx, "sleep 10 & sleep 10")
+		assert.EqualError(t, err, "command execution timed out after 200ms")
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+
+	t.Run("resource limits", func(t *testing.T) {
+		if runtime.GOOS != "linux" {
+			t.Skip("RLIMIT_AS is not enforced on all platforms")
+		}
+		s, err := NewLocalSandbox(ctx, &LocalConfig{RootDir: t.TempDir(), CPUTime: time.Second, MemoryLimit: 64 * 1024 * 1024})
+		assert.NoError(t, err)
+
+		output, err := s.RunCommand(ctx, "ulimit -t; ulimit -v")
+		assert.NoError(t, err)
+		assert.Equal(t, "1\n65536\n", output)
+
+		_, err = s.RunCommand(ctx, "ulimit -v 1000000")
+		assert.Error(t, err)
+	})
+}
+
+func TestCommandPolicy_NestedCommands(t *testing.T) {
+	p, err := newCommandPolicy([]string{`^(echo|ls)\b`}, nil)
+	assert.NoError(t, err)
+
+	for cmd, syntax := range map[string]string{
+		"echo `id`":                "command substitution",
+		"echo $(id)":               "command substitution",
+		`echo "$(id)"`:             "command substitution",
+		"echo \"`id`\"":            "command substitution",
+		"echo $((1 + 2))":          "command substitution",
+		"ls <(rm -rf ~)":           "process substitution",
+		"ls >(sh -c id)":           "process substitution",
+		"ls 2>(sh -c id)":          "process substitution",
+		"(rm -rf ~)":               "subshell",
+		"ls; (rm -rf ~)":           "subshell",
+		"ls && (rm -rf ~)":         "subshell",
+		"{ rm -rf ~; }":            "command group",
+		"ls; { rm -rf ~; }":        "command group",
+		"ls & {\nrm -rf ~\n}":      "command group",
+		"echo a; ls\n(rm -rf ~)\n": "subshell",
+	} {
+		err = p.check(cmd)
+		assert.ErrorIs(t, err, ErrCommandNotAllowed, cmd)
+		assert.ErrorContains(t, err, syntax+" is not allowed", cmd)
+	}
+
+	for _, cmd := range []string{
+		`echo '$(id) <(id) (a) { b }'`,
+		`echo "(a) <(b) { c }"`,
+		`echo \$\(id\) \{ a`,
+		`echo {a,b} ${HOME} a{}b`,
+	} {
+		assert.NoError(t, p.check(cmd), cmd)
+	}
+
+	// without allowed patterns, the denied patterns are matched on the whole command line
+	p, err = newCommandPolicy(nil, DefaultDeniedCommands)
+	assert.NoError(t, err)
+	assert.NoError(t, p.check("echo $(date) (a)"))
+}
+
+func TestCappedBuffer(t *testing.T) {
+	b := &cappedBuffer{max: 4}
+	for _, s := range []string{"ab", "cde", "fgh"} {
+		n, err := b.Write([]byte(s))
+		assert.NoError(t, err)
+		assert.Equal(t, len(s), n)
+	}
+	assert.Equal(t, "abcd\n[output truncated, 4 bytes dropped]", b.String())
+
+	b = &cappedBuffer{}
+	_, _ = b.Write([]byte(strings.Repeat("a", 100)))
+	assert.Equal(t, strings.Repeat("a", 100), b.String())
+}
@@ -0,0 +1,199 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sandbox
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrCommandNotAllowed is returned by RunCommand when the command is rejected by the allowed or denied patterns.
+var ErrCommandNotAllowed = errors.New("command not allowed")
+
+// DefaultDeniedCommands are patterns of commands which are rarely needed by an agent and dangerous on a host,
+// e.g. privilege escalation, recursive removal of the root, disk formatting or piping downloads to a shell.
+// They are not applied by default, set them as DeniedCommands, optionally with patterns of your own.
+var DefaultDeniedCommands = []string{
+	`^(sudo|su|doas)\b`,
+	`\brm\s+(-[a-zA-Z]*\s+)*/(\s|$)`,
+	`^(mkfs|mkfs\.\w+|fdisk|parted|dd|shutdown|reboot|halt|poweroff|mount|umount|chroot)\b`,
+	`\b(curl|wget)\b.*\|\s*(ba|z|da)?sh\b`,
+	`:\(\)\s*\{`,
+}
+
+// commandPolicy checks the commands against the allowed and denied patterns.
+type commandPolicy struct {
+	allowed []*regexp.Regexp
+	denied  []*regexp.Regexp
+}
+
+func newCommandPolicy(allowed, denied []string) (*commandPolicy, error) {
+	p := &commandPolicy{}
+	for _, pattern := range allowed {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed command pattern %q: %w", pattern, err)
+		}
+		p.allowed = append(p.allowed, re)
+	}
+	for _, pattern := range denied {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid denied command pattern %q: %w", pattern, err)
+		}
+		p.denied = append(p.denied, re)
+	}
+	return p, nil
+}
+
+// check matches each simple command of a command line, i.e. the parts separated by ;, &, | or new lines,
+// so that an allowed command can't be chained with another one.
+// When allowed patterns are set, the syntax running commands nested in another one is rejected as they can't be checked:
+// command and process substitutions, subshells and groups.
+func (p *commandPolicy) check(command string) error {
+	if p == nil || (len(p.allowed) == 0 && len(p.denied) == 0) {
+		return nil
+	}
+
+	for _, re := range p.denied {
+		if re.MatchString(command) {
+			return fmt.Errorf("%w: %s", ErrCommandNotAllowed, command)
+		}
+	}
+
+	if len(p.allowed) > 0 {
+		if syntax := nestedCommandSyntax(command); syntax != "" {
+			return fmt.Errorf("%w: %s is not allowed: %s", ErrCommandNotAllowed, syntax, command)
+		}
+	}
+
+	for _, part := range splitCommand(command) {
+		for _, re := range p.denied {
+			if re.MatchString(part) {
+				return fmt.Errorf("%w: %s", ErrCommandNotAllowed, part)
+			}
+		}
+		if len(p.allowed) == 0 {
+			continue
+		}
+		allowed := false
+		for _, re := range p.allowed {
+			if re.MatchString(part) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("%w: %s", ErrCommandNotAllowed, part)
+		}
+	}
+
+	return nil
+}
+
+// nestedCommandSyntax returns the name of the first syntax running nested commands in the command line, empty if none:
+// `...` and $(...) outside single quotes, and outside quotes, <(...) and >(...), (...) subshells and { ...; } groups.
+// Parentheses are rejected altogether outside quotes, they are only literal within quotes or escaped.
+func nestedCommandSyntax(command string) string {
+	var (
+		quote    rune // the current quote, ' or ", 0 outside quotes
+		escaped  bool
+		wordHead = true // at the start of a word
+	)
+	runes := []rune(command)
+	for i, r := range runes {
+		head := wordHead
+		wordHead = false
+		switch {
+		case escaped:
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			}
+		case r == '\\':
+			escaped = true
+		case r == '`':
+			return "command substitution"
+		case r == '$' && i+1 < len(runes) && runes[i+1] == '(':
+			return "command substitution"
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case (r == '<' || r == '>') && i+1 < len(runes) && runes[i+1] == '(':
+			return "process substitution"
+		case r == '(' || r == ')':
+			return "subshell"
+		case (r == '{' || r == '}') && head && (i+1 == len(runes) || isCommandSeparator(runes[i+1])):
+			return "command group"
+		case isCommandSeparator(r):
+			wordHead = true
+		}
+	}
+	return ""
+}
+
+// isCommandSeparator reports whether r ends a word, or a simple command, of a command line.
+func isCommandSeparator(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == ';' || r == '&' || r == '|'
+}
+
+func splitCommand(command string) []string {
+	parts := strings.FieldsFunc(command, func(r rune) bool {
+		return r == ';' || r == '&' || r == '|' || r == '\n'
+	})
+	ret := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			ret = append(ret, part)
+		}
+	}
+	return ret
+}
+
+// cappedBuffer keeps the first max bytes written to it, and counts the dropped ones.
+type cappedBuffer struct {
+	buf     bytes.Buffer
+	max     int
+	dropped int64
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if b.max <= 0 {
+		return b.buf.Write(p)
+	}
+	n := len(p)
+	if remain := b.max - b.buf.Len(); remain < n {
+		b.dropped += int64(n - max(remain, 0))
+		p = p[:max(remain, 0)]
+	}
+	b.buf.Write(p)
+	return n, nil
+}
+
+func (b *cappedBuffer) String() string {
+	if b.dropped == 0 {
+		return b.buf.String()
+	}
+	return fmt.Sprintf("%s\n[output truncated, %d bytes dropped]", b.buf.String(), b.dropped)
+}
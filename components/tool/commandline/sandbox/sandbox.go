@@ -37,6 +37,7 @@ import (
 // Config configures the sandbox environment
 type Config struct {
 	VolumeBindings map[string]string
+	HostWorkDir    string // Host directory mounted as WorkDir, a new temporary directory by default
 	Image          string
 	HostName       string
 	WorkDir        string
@@ -45,12 +46,18 @@ type Config struct {
 	CPULimit       float64 // CPU limit in cores
 	NetworkEnabled bool
 	Timeout        time.Duration // Command execution timeout in seconds
+	// AllowedCommands are regular expressions, each simple command passed to RunCommand must match one of them
+	AllowedCommands []string
+	// DeniedCommands are regular expressions of the commands rejected by RunCommand, e.g. DefaultDeniedCommands
+	DeniedCommands []string
+	MaxOutputSize  int // Maximum size in bytes kept of stdout and stderr, 0 for no limit
 }
 
 // DockerSandbox provides a containerized execution environment
 type DockerSandbox struct {
 	config      Config
 	client      *client.Client
+	policy      *commandPolicy
 	containerID string
 }
 
@@ -94,6 +101,11 @@ func NewDockerSandbox(ctx context.Context, config *Config) (*DockerSandbox, erro
 		config.HostName = defaultHostName
 	}
 
+	policy, err := newCommandPolicy(config.AllowedCommands, config.DeniedCommands)
+	if err != nil {
+		return nil, err
+	}
+
 	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Docker client: %w", err)
@@ -102,6 +114,7 @@ func NewDockerSandbox(ctx context.Context, config *Config) (*DockerSandbox, erro
 	return &DockerSandbox{
 		config: *config,
 		client: cli,
+		policy: policy,
 	}, nil
 }
 
@@ -165,6 +178,15 @@ func (s *DockerSandbox) Create(ctx context.Context) error {
 
 // RunCommand executes a command in the sandbox
 func (s *DockerSandbox) RunCommand(ctx context.Context, cmd string) (string, error) {
+	if err := s.policy.check(cmd); err != nil {
+		return "", err
+	}
+	return s.exec(ctx, cmd)
+}
+
+// exec executes a command in the sandbox without checking the command policy,
+// for the commands issued by the sandbox itself
+func (s *DockerSandbox) exec(ctx context.Context, cmd string) (string, error) {
 	if s.containerID == "" {
 		return "", fmt.Errorf("sandbox not initialized")
 	}
@@ -201,11 +223,12 @@ func (s *DockerSandbox) RunCommand(ctx context.Context, cmd string) (string, err
 	defer resp.Close()
 
 	// Read output
-	var outBuf, errBuf bytes.Buffer
+	outBuf := &cappedBuffer{max: s.config.MaxOutputSize}
+	errBuf := &cappedBuffer{max: s.config.MaxOutputSize}
 	outputDone := make(chan error)
 
 	go func() {
-		_, err := stdcopy.StdCopy(&outBuf, &errBuf, resp.Reader)
+		_, err := stdcopy.StdCopy(outBuf, errBuf, resp.Reader)
 		outputDone <- err
 	}()
 
@@ -275,7 +298,7 @@ func (s *DockerSandbox) WriteFile(ctx context.Context, path string, content stri
 	// Create parent directory
 	parentDir := filepath.Dir(resolvedPath)
 	if parentDir != "" && parentDir != "/" {
-		_, err := s.RunCommand(ctx, fmt.Sprintf("mkdir -p %s", parentDir))
+		_, err := s.exec(ctx, fmt.Sprintf("mkdir -p %s", parentDir))
 		if err != nil {
 			return fmt.Errorf("failed to create directory: %w", err)
 		}
@@ -335,7 +358,7 @@ func (s *DockerSandbox) IsDirectory(ctx context.Context, path string) (bool, err
 
 	// Use stat command to check path type
 	cmd := fmt.Sprintf("test -d %s && echo 'true' || echo 'false'", resolvedPath)
-	output, err := s.RunCommand(ctx, cmd)
+	output, err := s.exec(ctx, cmd)
 	if err != nil {
 		return false, fmt.Errorf("failed to check path type: %w", err)
 	}
@@ -357,7 +380,7 @@ func (s *DockerSandbox) Exists(ctx context.Context, path string) (bool, error) {
 
 	// Use stat command to check if path exists
 	cmd := fmt.Sprintf("test -e %s && echo 'true' || echo 'false'", resolvedPath)
-	output, err := s.RunCommand(ctx, cmd)
+	output, err := s.exec(ctx, cmd)
 	if err != nil {
 		return false, fmt.Errorf("failed to check path existence: %w", err)
 	}
@@ -403,9 +426,13 @@ func (s *DockerSandbox) prepareVolumeBindings() ([]string, error) {
 	binds := []string{}
 
 	// Create and add working directory mapping
-	workDir, err := s.ensureHostDir(s.config.WorkDir)
-	if err != nil {
-		return nil, fmt.Errorf("failed to ensure host directory exists: %w", err)
+	workDir := s.config.HostWorkDir
+	if workDir == "" {
+		var err error
+		workDir, err = s.ensureHostDir(s.config.WorkDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to ensure host directory exists: %w", err)
+		}
 	}
 
 	binds = append(binds, fmt.Sprintf("%s:%s:rw", workDir, s.config.WorkDir))
@@ -0,0 +1,142 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package duckduckgo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+type rawImageResponse struct {
+	Results []struct {
+		Title     string `json:"title"`
+		Image     string `json:"image"`
+		Thumbnail string `json:"thumbnail"`
+		URL       string `json:"url"`
+		Height    int    `json:"height"`
+		Width     int    `json:"width"`
+		Source    string `json:"source"`
+	} `json:"results"`
+	Next string `json:"next"`
+}
+
+func (c *client) ImageSearch(ctx context.Context, input *ImageSearchRequest) (*ImageSearchResponse, error) {
+	if input.Query == "" {
+		return nil, fmt.Errorf("search query is required")
+	}
+
+	safeSearch, err := c.resolveSafeSearch(input.SafeSearch)
+	if err != nil {
+		return nil, err
+	}
+
+	vqd, err := c.getVQD(ctx, input.Query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vqd: %w", err)
+	}
+
+	params := input.buildImageRequestParams(c.resolveRegion(input.Region), safeSearch, vqd)
+
+	results := make([]*ImageSearchResult, 0, c.maxResults)
+	imageCache := make(map[string]bool)
+
+	for {
+		body, err := c.doJSONSearch(ctx, searchImagesURL, params)
+		if err != nil {
+			return nil, err
+		}
+
+		var raw rawImageResponse
+		if err = json.Unmarshal(body, &raw); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		for _, r := range raw.Results {
+			if r.Image == "" || imageCache[r.Image] {
+				continue
+			}
+			imageCache[r.Image] = true
+
+			results = append(results, &ImageSearchResult{
+				Title:        r.Title,
+				ImageURL:     normalizeURL(r.Image),
+				ThumbnailURL: normalizeURL(r.Thumbnail),
+				URL:          normalizeURL(r.URL),
+				Source:       r.Source,
+				Width:        r.Width,
+				Height:       r.Height,
+			})
+		}
+
+		if len(results) >= c.maxResults {
+			results = results[:c.maxResults]
+			break
+		}
+
+		offset := nextOffset(raw.Next)
+		if len(raw.Results) == 0 || offset == "" {
+			break
+		}
+		params.Set("s", offset)
+	}
+
+	if len(results) == 0 {
+		return &ImageSearchResponse{
+			Message: "No good results were found.",
+		}, nil
+	}
+
+	return &ImageSearchResponse{
+		Message: fmt.Sprintf("Found %d results successfully.", len(results)),
+		Results: results,
+	}, nil
+}
+
+func (i *ImageSearchRequest) buildImageRequestParams(region Region, safeSearch SafeSearch, vqd string) url.Values {
+	// l (str): Region code, e.g. 'us-en'
+	// o (str): Output format, 'json'
+	// q (str): Search query string
+	// vqd (str): Validation query digest
+	// f (str): Filters, 'time,size,color,type,layout,license', e.g. 'time:Week,,,,,'
+	// p (int): Safe search, 1 (on), -1 (off), the endpoint has no moderate level
+
+	params := url.Values{
+		"l":   {string(region)},
+		"o":   {"json"},
+		"q":   {i.Query},
+		"vqd": {vqd},
+		"f":   {",,,,,"},
+		"p":   {"1"},
+	}
+
+	if safeSearch == SafeSearchOff {
+		params.Set("p", "-1")
+	}
+
+	timeFilters := map[TimeRange]string{
+		TimeRangeDay:   "time:Day",
+		TimeRangeWeek:  "time:Week",
+		TimeRangeMonth: "time:Month",
+		TimeRangeYear:  "time:Year",
+	}
+	if f, ok := timeFilters[i.TimeRange]; ok {
+		params.Set("f", f+",,,,,")
+	}
+
+	return params
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package duckduckgo
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	. "github.com/bytedance/mockey"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientImageSearch(t *testing.T) {
+	PatchConvey("", t, func() {
+		ctx := context.Background()
+
+		_, queries := newFakeDDG(t, func(_ string, q url.Values) string {
+			if q.Get("s") == "" {
+				return `{"results":[
+					{"title":"i1","image":"https://img.com/1.jpg","thumbnail":"https://tse.com/1","url":"https://a.com/1","width":640,"height":480,"source":"Bing"},
+					{"title":"i2","image":"https://img.com/2.jpg","url":"https://a.com/2"}
+				],"next":"i.js?q=eino&o=json&p=1&s=100&u=bing"}`
+			}
+			return `{"results":[
+				{"title":"i1","image":"https://img.com/1.jpg","url":"https://a.com/1"},
+				{"title":"i3","image":"https://img.com/3.jpg","url":"https://a.com/3"}
+			]}`
+		})
+
+		cli := &client{httpCli: &http.Client{}, maxResults: 10, region: RegionJP, safeSearch: SafeSearchModerate}
+		resp, err := cli.ImageSearch(ctx, &ImageSearchRequest{
+			Query:      "eino",
+			TimeRange:  TimeRangeMonth,
+			SafeSearch: SafeSearchOff,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "Found 3 results successfully.", resp.Message)
+		assert.Equal(t, &ImageSearchResult{
+			Title:        "i1",
+			ImageURL:     "https://img.com/1.jpg",
+			ThumbnailURL: "https://tse.com/1",
+			URL:          "https://a.com/1",
+			Source:       "Bing",
+			Width:        640,
+			Height:       480,
+		}, resp.Results[0])
+		assert.Equal(t, "i3", resp.Results[2].Title)
+
+		assert.Len(t, *queries, 2)
+		first := (*queries)[0]
+		assert.Equal(t, "jp-jp", first.Get("l"))
+		assert.Equal(t, "-1", first.Get("p"))
+		assert.Equal(t, "time:Month,,,,,", first.Get("f"))
+		assert.Equal(t, "100", (*queries)[1].Get("s"))
+	})
+}
+
+func TestBuildImageRequestParams(t *testing.T) {
+	req := &ImageSearchRequest{Query: "eino"}
+
+	params := req.buildImageRequestParams(RegionWT, SafeSearchModerate, "vqd")
+	assert.Equal(t, "1", params.Get("p"))
+	assert.Equal(t, ",,,,,", params.Get("f"))
+
+	params = req.buildImageRequestParams(RegionWT, SafeSearchStrict, "vqd")
+	assert.Equal(t, "1", params.Get("p"))
+}
@@ -23,15 +23,33 @@ import (
 
 // Common constants
 var (
-	searchHTMLURL = "https://html.duckduckgo.com/html/"
+	searchHTMLURL   = "https://html.duckduckgo.com/html/"
+	searchVQDURL    = "https://duckduckgo.com/"
+	searchNewsURL   = "https://duckduckgo.com/news.js"
+	searchImagesURL = "https://duckduckgo.com/i.js"
 
 	defaultTextSearchToolName = "duckduckgo_text_search"
 	defaultTextSearchToolDesc = `This is a duckduckgo plain text information search tool. 
 It can be useful to help you get information within a certain time range.`
+
+	defaultNewsSearchToolName = "duckduckgo_news_search"
+	defaultNewsSearchToolDesc = `This is a duckduckgo news search tool.
+It can be useful to help you get the latest news articles about a topic, optionally within a certain time range.`
+
+	defaultImageSearchToolName = "duckduckgo_image_search"
+	defaultImageSearchToolDesc = `This is a duckduckgo image search tool.
+It can be useful to help you find images about a topic, including their source page and thumbnail.`
+
+	defaultSearchToolName = "duckduckgo_search"
+	defaultSearchToolDesc = `This is a duckduckgo search tool supporting web text, news and image search.
+Choose the search type, and optionally narrow results by region, time range and safe search level.`
 )
 
 type Search interface {
 	TextSearch(ctx context.Context, req *TextSearchRequest) (*TextSearchResponse, error)
+	NewsSearch(ctx context.Context, req *NewsSearchRequest) (*NewsSearchResponse, error)
+	ImageSearch(ctx context.Context, req *ImageSearchRequest) (*ImageSearchResponse, error)
+	MultiSearch(ctx context.Context, req *SearchRequest) (*SearchResponse, error)
 }
 
 // client represents the DuckDuckGo search client.
@@ -41,6 +59,7 @@ type client struct {
 	httpCli    *http.Client
 	maxResults int
 	region     Region
+	safeSearch SafeSearch
 }
 
 // Region represents a geographical region for search results.
@@ -84,12 +103,42 @@ const (
 	TimeRangeAny TimeRange = ""
 )
 
+// SafeSearch represents the safe search level applied to search results.
+type SafeSearch string
+
+const (
+	// SafeSearchStrict filters out all adult content
+	SafeSearchStrict SafeSearch = "strict"
+	// SafeSearchModerate filters out explicit content (default)
+	SafeSearchModerate SafeSearch = "moderate"
+	// SafeSearchOff disables safe search filtering
+	SafeSearchOff SafeSearch = "off"
+)
+
+// SearchType represents the vertical to search in.
+type SearchType string
+
+const (
+	// SearchTypeText searches web pages (default)
+	SearchTypeText SearchType = "text"
+	// SearchTypeNews searches news articles
+	SearchTypeNews SearchType = "news"
+	// SearchTypeImages searches images
+	SearchTypeImages SearchType = "images"
+)
+
 type TextSearchRequest struct {
 	// Query is the user's search query
 	Query string `json:"query"`
 	// TimeRange is the search time range
 	// Default: TimeRangeAny
 	TimeRange TimeRange `json:"time_range"`
+	// Region overrides Config.Region for this request
+	// Optional. Default: Config.Region
+	Region Region `json:"region,omitempty"`
+	// SafeSearch overrides Config.SafeSearch for this request
+	// Optional. Default: Config.SafeSearch
+	SafeSearch SafeSearch `json:"safe_search,omitempty"`
 }
 
 // TextSearchResult represents a single search result.
@@ -110,3 +159,113 @@ type TextSearchResponse struct {
 	// Results contains the list of search results
 	Results []*TextSearchResult `json:"results,omitempty"`
 }
+
+type NewsSearchRequest struct {
+	// Query is the user's search query
+	Query string `json:"query"`
+	// TimeRange is the search time range
+	// Default: TimeRangeAny
+	TimeRange TimeRange `json:"time_range"`
+	// Region overrides Config.Region for this request
+	// Optional. Default: Config.Region
+	Region Region `json:"region,omitempty"`
+	// SafeSearch overrides Config.SafeSearch for this request
+	// Optional. Default: Config.SafeSearch
+	SafeSearch SafeSearch `json:"safe_search,omitempty"`
+}
+
+// NewsSearchResult represents a single news article.
+type NewsSearchResult struct {
+	// Title is the title of the article
+	Title string `json:"title"`
+	// URL is the web address of the article
+	URL string `json:"url"`
+	// Summary is the excerpt of the article
+	Summary string `json:"summary"`
+	// Source is the publisher of the article
+	Source string `json:"source,omitempty"`
+	// Date is the publish time of the article in RFC3339 format
+	Date string `json:"date,omitempty"`
+	// Image is the cover image of the article
+	Image string `json:"image,omitempty"`
+}
+
+// NewsSearchResponse represents the complete response from a news search request.
+type NewsSearchResponse struct {
+	// Message is a brief status message for the model
+	Message string `json:"message"`
+	// Results contains the list of news articles
+	Results []*NewsSearchResult `json:"results,omitempty"`
+}
+
+type ImageSearchRequest struct {
+	// Query is the user's search query
+	Query string `json:"query"`
+	// TimeRange is the search time range
+	// Default: TimeRangeAny
+	TimeRange TimeRange `json:"time_range"`
+	// Region overrides Config.Region for this request
+	// Optional. Default: Config.Region
+	Region Region `json:"region,omitempty"`
+	// SafeSearch overrides Config.SafeSearch for this request
+	// Optional. Default: Config.SafeSearch
+	SafeSearch SafeSearch `json:"safe_search,omitempty"`
+}
+
+// ImageSearchResult represents a single image.
+type ImageSearchResult struct {
+	// Title is the title of the image
+	Title string `json:"title"`
+	// ImageURL is the address of the full size image
+	ImageURL string `json:"image_url"`
+	// ThumbnailURL is the address of the image thumbnail
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+	// URL is the web page the image comes from
+	URL string `json:"url"`
+	// Source is the search engine the image was indexed by
+	Source string `json:"source,omitempty"`
+	// Width is the width of the image in pixels
+	Width int `json:"width,omitempty"`
+	// Height is the height of the image in pixels
+	Height int `json:"height,omitempty"`
+}
+
+// ImageSearchResponse represents the complete response from an image search request.
+type ImageSearchResponse struct {
+	// Message is a brief status message for the model
+	Message string `json:"message"`
+	// Results contains the list of images
+	Results []*ImageSearchResult `json:"results,omitempty"`
+}
+
+// SearchRequest is the request of the multi-vertical search tool,
+// the model chooses the vertical with Type.
+type SearchRequest struct {
+	// Query is the user's search query
+	Query string `json:"query"`
+	// Type is the vertical to search in
+	// Default: SearchTypeText
+	Type SearchType `json:"type,omitempty"`
+	// TimeRange is the search time range
+	// Default: TimeRangeAny
+	TimeRange TimeRange `json:"time_range"`
+	// Region overrides Config.Region for this request
+	// Optional. Default: Config.Region
+	Region Region `json:"region,omitempty"`
+	// SafeSearch overrides Config.SafeSearch for this request
+	// Optional. Default: Config.SafeSearch
+	SafeSearch SafeSearch `json:"safe_search,omitempty"`
+}
+
+// SearchResponse is the response of the multi-vertical search tool,
+// only the results matching SearchRequest.Type are set.
+type SearchResponse struct {
+	// Message is a brief status message for the model
+	Message string `json:"message"`
+	// TextResults contains the list of web page results
+	TextResults []*TextSearchResult `json:"text_results,omitempty"`
+	// NewsResults contains the list of news articles
+	NewsResults []*NewsSearchResult `json:"news_results,omitempty"`
+	// ImageResults contains the list of images
+	ImageResults []*ImageSearchResult `json:"image_results,omitempty"`
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package duckduckgo
+
+import (
+	"context"
+	"fmt"
+)
+
+// MultiSearch dispatches the request to the text, news or image search according to its type.
+func (c *client) MultiSearch(ctx context.Context, input *SearchRequest) (*SearchResponse, error) {
+	switch input.Type {
+	case "", SearchTypeText:
+		resp, err := c.TextSearch(ctx, &TextSearchRequest{
+			Query:      input.Query,
+			TimeRange:  input.TimeRange,
+			Region:     input.Region,
+			SafeSearch: input.SafeSearch,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &SearchResponse{Message: resp.Message, TextResults: resp.Results}, nil
+
+	case SearchTypeNews:
+		resp, err := c.NewsSearch(ctx, &NewsSearchRequest{
+			Query:      input.Query,
+			TimeRange:  input.TimeRange,
+			Region:     input.Region,
+			SafeSearch: input.SafeSearch,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &SearchResponse{Message: resp.Message, NewsResults: resp.Results}, nil
+
+	case SearchTypeImages:
+		resp, err := c.ImageSearch(ctx, &ImageSearchRequest{
+			Query:      input.Query,
+			TimeRange:  input.TimeRange,
+			Region:     input.Region,
+			SafeSearch: input.SafeSearch,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &SearchResponse{Message: resp.Message, ImageResults: resp.Results}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported search type: %s", input.Type)
+	}
+}
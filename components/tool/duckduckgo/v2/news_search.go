@@ -0,0 +1,143 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package duckduckgo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+type rawNewsResponse struct {
+	Results []struct {
+		Date    int64  `json:"date"`
+		Title   string `json:"title"`
+		Excerpt string `json:"excerpt"`
+		URL     string `json:"url"`
+		Image   string `json:"image"`
+		Source  string `json:"source"`
+	} `json:"results"`
+	Next string `json:"next"`
+}
+
+func (c *client) NewsSearch(ctx context.Context, input *NewsSearchRequest) (*NewsSearchResponse, error) {
+	if input.Query == "" {
+		return nil, fmt.Errorf("search query is required")
+	}
+
+	safeSearch, err := c.resolveSafeSearch(input.SafeSearch)
+	if err != nil {
+		return nil, err
+	}
+
+	vqd, err := c.getVQD(ctx, input.Query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vqd: %w", err)
+	}
+
+	params := input.buildNewsRequestParams(c.resolveRegion(input.Region), safeSearch, vqd)
+
+	results := make([]*NewsSearchResult, 0, c.maxResults)
+	urlCache := make(map[string]bool)
+
+	for {
+		body, err := c.doJSONSearch(ctx, searchNewsURL, params)
+		if err != nil {
+			return nil, err
+		}
+
+		var raw rawNewsResponse
+		if err = json.Unmarshal(body, &raw); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		for _, r := range raw.Results {
+			if r.URL == "" || urlCache[r.URL] {
+				continue
+			}
+			urlCache[r.URL] = true
+
+			result := &NewsSearchResult{
+				Title:   r.Title,
+				URL:     normalizeURL(r.URL),
+				Summary: r.Excerpt,
+				Source:  r.Source,
+				Image:   normalizeURL(r.Image),
+			}
+			if r.Date > 0 {
+				result.Date = time.Unix(r.Date, 0).UTC().Format(time.RFC3339)
+			}
+			results = append(results, result)
+		}
+
+		if len(results) >= c.maxResults {
+			results = results[:c.maxResults]
+			break
+		}
+
+		offset := nextOffset(raw.Next)
+		if len(raw.Results) == 0 || offset == "" {
+			break
+		}
+		params.Set("s", offset)
+	}
+
+	if len(results) == 0 {
+		return &NewsSearchResponse{
+			Message: "No good results were found.",
+		}, nil
+	}
+
+	return &NewsSearchResponse{
+		Message: fmt.Sprintf("Found %d results successfully.", len(results)),
+		Results: results,
+	}, nil
+}
+
+func (n *NewsSearchRequest) buildNewsRequestParams(region Region, safeSearch SafeSearch, vqd string) url.Values {
+	// l (str): Region code, e.g. 'us-en'
+	// o (str): Output format, 'json'
+	// noamp (int): Prefer non-AMP article urls
+	// q (str): Search query string
+	// vqd (str): Validation query digest
+	// p (int): Safe search, 1 (strict), -1 (moderate), -2 (off)
+	// df (str): Time filter, 'd' (day), 'w' (week), 'm' (month), 'y' (year)
+
+	params := url.Values{
+		"l":     {string(region)},
+		"o":     {"json"},
+		"noamp": {"1"},
+		"q":     {n.Query},
+		"vqd":   {vqd},
+		"p":     {"-1"},
+	}
+
+	switch safeSearch {
+	case SafeSearchStrict:
+		params.Set("p", "1")
+	case SafeSearchOff:
+		params.Set("p", "-2")
+	}
+
+	switch n.TimeRange {
+	case TimeRangeDay, TimeRangeWeek, TimeRangeMonth, TimeRangeYear:
+		params.Set("df", string(n.TimeRange))
+	}
+
+	return params
+}
@@ -0,0 +1,139 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package duckduckgo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	. "github.com/bytedance/mockey"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeDDG serves the vqd page and routes json endpoints to handler, recording received queries.
+func newFakeDDG(t *testing.T, handler func(path string, q url.Values) string) (*httptest.Server, *[]url.Values) {
+	var (
+		mu      sync.Mutex
+		queries []url.Values
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/" {
+			_, _ = fmt.Fprintf(w, `<html><script>DDG.deep.initialize('/d.js?q=%s&vqd="4-123456"&kl=wt-wt');</script></html>`, r.URL.Query().Get("q"))
+			return
+		}
+		mu.Lock()
+		queries = append(queries, r.URL.Query())
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(handler(r.URL.Path, r.URL.Query())))
+	}))
+	t.Cleanup(srv.Close)
+
+	oldVQD, oldNews, oldImages := searchVQDURL, searchNewsURL, searchImagesURL
+	searchVQDURL, searchNewsURL, searchImagesURL = srv.URL+"/", srv.URL+"/news.js", srv.URL+"/i.js"
+	t.Cleanup(func() {
+		searchVQDURL, searchNewsURL, searchImagesURL = oldVQD, oldNews, oldImages
+	})
+
+	return srv, &queries
+}
+
+func TestClientNewsSearch(t *testing.T) {
+	PatchConvey("", t, func() {
+		ctx := context.Background()
+
+		PatchConvey("paginate and dedup", func() {
+			_, queries := newFakeDDG(t, func(_ string, q url.Values) string {
+				if q.Get("s") == "" {
+					return `{"results":[
+						{"date":1700000000,"title":"t1","excerpt":"e1","url":"https://a.com/1","image":"//img.com/1.jpg","source":"A"},
+						{"date":1700000001,"title":"t2","excerpt":"e2","url":"https://a.com/2","source":"B"}
+					],"next":"news.js?q=eino&s=2"}`
+				}
+				return `{"results":[
+					{"date":1700000002,"title":"t2","excerpt":"e2","url":"https://a.com/2"},
+					{"date":1700000003,"title":"t3","excerpt":"e3","url":"https://a.com/3"},
+					{"date":1700000004,"title":"t4","excerpt":"e4","url":"https://a.com/4"}
+				]}`
+			})
+
+			cli := &client{httpCli: &http.Client{}, maxResults: 3, region: RegionWT, safeSearch: SafeSearchModerate}
+			resp, err := cli.NewsSearch(ctx, &NewsSearchRequest{
+				Query:      "eino",
+				TimeRange:  TimeRangeWeek,
+				Region:     RegionUS,
+				SafeSearch: SafeSearchStrict,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, "Found 3 results successfully.", resp.Message)
+			assert.Len(t, resp.Results, 3)
+			assert.Equal(t, &NewsSearchResult{
+				Title:   "t1",
+				URL:     "https://a.com/1",
+				Summary: "e1",
+				Source:  "A",
+				Date:    "2023-11-14T22:13:20Z",
+				Image:   "https://img.com/1.jpg",
+			}, resp.Results[0])
+			assert.Equal(t, "https://a.com/3", resp.Results[2].URL)
+
+			assert.Len(t, *queries, 2)
+			first := (*queries)[0]
+			assert.Equal(t, "4-123456", first.Get("vqd"))
+			assert.Equal(t, "us-en", first.Get("l"))
+			assert.Equal(t, "1", first.Get("p"))
+			assert.Equal(t, "w", first.Get("df"))
+			assert.Equal(t, "2", (*queries)[1].Get("s"))
+		})
+
+		PatchConvey("no results", func() {
+			newFakeDDG(t, func(string, url.Values) string { return `{"results":[]}` })
+
+			cli := &client{httpCli: &http.Client{}, maxResults: 3, region: RegionWT, safeSearch: SafeSearchModerate}
+			resp, err := cli.NewsSearch(ctx, &NewsSearchRequest{Query: "eino"})
+			assert.NoError(t, err)
+			assert.Equal(t, "No good results were found.", resp.Message)
+			assert.Empty(t, resp.Results)
+		})
+
+		PatchConvey("invalid request", func() {
+			cli := &client{httpCli: &http.Client{}, maxResults: 3, region: RegionWT, safeSearch: SafeSearchModerate}
+			_, err := cli.NewsSearch(ctx, &NewsSearchRequest{})
+			assert.Error(t, err)
+
+			_, err = cli.NewsSearch(ctx, &NewsSearchRequest{Query: "eino", SafeSearch: "unknown"})
+			assert.ErrorContains(t, err, "invalid safe search level")
+		})
+	})
+}
+
+func TestBuildNewsRequestParams(t *testing.T) {
+	req := &NewsSearchRequest{Query: "eino", TimeRange: "invalid"}
+
+	params := req.buildNewsRequestParams(RegionWT, SafeSearchModerate, "vqd")
+	assert.Equal(t, "-1", params.Get("p"))
+	assert.Equal(t, "wt-wt", params.Get("l"))
+	assert.False(t, params.Has("df"))
+
+	params = req.buildNewsRequestParams(RegionDE, SafeSearchOff, "vqd")
+	assert.Equal(t, "-2", params.Get("p"))
+	assert.Equal(t, "de-de", params.Get("l"))
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package duckduckgo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/corpix/uarand"
+)
+
+var vqdPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`vqd=["']([^"']+)["']`),
+	regexp.MustCompile(`vqd=([\d-]+)&`),
+}
+
+// resolveRegion returns the region of the request, falling back to the client's region.
+func (c *client) resolveRegion(region Region) Region {
+	region = Region(strings.ToLower(strings.TrimSpace(string(region))))
+	if region == "" {
+		return c.region
+	}
+	return region
+}
+
+// resolveSafeSearch returns the safe search level of the request, falling back to the client's level.
+func (c *client) resolveSafeSearch(safeSearch SafeSearch) (SafeSearch, error) {
+	switch safeSearch {
+	case "":
+		return c.safeSearch, nil
+	case SafeSearchStrict, SafeSearchModerate, SafeSearchOff:
+		return safeSearch, nil
+	default:
+		return "", fmt.Errorf("invalid safe search level: %s", safeSearch)
+	}
+}
+
+// getVQD fetches the validation query digest required by the news and image endpoints.
+func (c *client) getVQD(ctx context.Context, query string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, searchVQDURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.URL.RawQuery = url.Values{"q": {query}}.Encode()
+	req.Header.Set("User-Agent", uarand.GetRandom())
+
+	resp, err := c.httpCli.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	for _, re := range vqdPatterns {
+		if m := re.FindSubmatch(body); len(m) == 2 {
+			return string(m[1]), nil
+		}
+	}
+
+	return "", fmt.Errorf("failed to extract vqd for query: %s", query)
+}
+
+// doJSONSearch sends a GET request to a duckduckgo json endpoint and returns the response body.
+func (c *client) doJSONSearch(ctx context.Context, endpoint string, params url.Values) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.URL.RawQuery = params.Encode()
+	req.Header = http.Header{
+		"Accept":         {"application/json, text/javascript, */*; q=0.01"},
+		"Referer":        {"https://duckduckgo.com/"},
+		"Sec-Fetch-Site": {"same-origin"},
+		"Sec-Fetch-Dest": {"empty"},
+		"Sec-Fetch-Mode": {"cors"},
+		"User-Agent":     {uarand.GetRandom()},
+	}
+
+	resp, err := c.httpCli.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return body, nil
+}
+
+// nextOffset extracts the "s" offset from the "next" field of a json search response.
+func nextOffset(next string) string {
+	if next == "" {
+		return ""
+	}
+	u, err := url.Parse(next)
+	if err != nil {
+		return ""
+	}
+	return u.Query().Get("s")
+}
+
+func normalizeURL(u string) string {
+	if strings.HasPrefix(u, "//") {
+		return "https:" + u
+	}
+	return u
+}
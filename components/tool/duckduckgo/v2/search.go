@@ -55,86 +55,133 @@ type Config struct {
 	// Default: RegionWT, means all regions
 	// Reference: https://duckduckgo.com/duckduckgo-help-pages/settings/params
 	Region Region `json:"region"`
+
+	// SafeSearch is the safe search level for results
+	// Default: SafeSearchModerate
+	SafeSearch SafeSearch `json:"safe_search"`
 }
 
 func NewTextSearchTool(ctx context.Context, config *Config) (tool.InvokableTool, error) {
-	if config == nil {
-		config = &Config{}
-	}
+	name, desc := toolNameAndDesc(config, defaultTextSearchToolName, defaultTextSearchToolDesc)
 
-	name := config.ToolName
-	if name == "" {
-		name = defaultTextSearchToolName
+	cli, err := buildClient(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create duckduckgo client: %w", err)
 	}
-	desc := config.ToolDesc
-	if desc == "" {
-		desc = defaultTextSearchToolDesc
+
+	searchTool := utils.NewTool(getVerticalSearchSchema(name, desc), cli.TextSearch)
+
+	return searchTool, nil
+}
+
+// NewNewsSearchTool creates a tool searching news articles.
+func NewNewsSearchTool(ctx context.Context, config *Config) (tool.InvokableTool, error) {
+	name, desc := toolNameAndDesc(config, defaultNewsSearchToolName, defaultNewsSearchToolDesc)
+
+	cli, err := buildClient(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create duckduckgo client: %w", err)
 	}
 
+	return utils.NewTool(getVerticalSearchSchema(name, desc), cli.NewsSearch), nil
+}
+
+// NewImageSearchTool creates a tool searching images.
+func NewImageSearchTool(ctx context.Context, config *Config) (tool.InvokableTool, error) {
+	name, desc := toolNameAndDesc(config, defaultImageSearchToolName, defaultImageSearchToolDesc)
+
 	cli, err := buildClient(ctx, config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create duckduckgo client: %w", err)
 	}
 
-	searchTool := utils.NewTool(getTextSearchSchema(name, desc), cli.TextSearch)
+	return utils.NewTool(getVerticalSearchSchema(name, desc), cli.ImageSearch), nil
+}
 
-	return searchTool, nil
+// NewSearchTool creates a single tool covering text, news and image search,
+// the model picks the vertical with the "type" parameter.
+func NewSearchTool(ctx context.Context, config *Config) (tool.InvokableTool, error) {
+	name, desc := toolNameAndDesc(config, defaultSearchToolName, defaultSearchToolDesc)
+
+	cli, err := buildClient(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create duckduckgo client: %w", err)
+	}
+
+	return utils.NewTool(getSearchSchema(name, desc), cli.MultiSearch), nil
 }
 
 func NewSearch(ctx context.Context, config *Config) (Search, error) {
 	return buildClient(ctx, config)
 }
 
-func getTextSearchSchema(toolName, toolDesc string) *schema.ToolInfo {
-	sc := &jsonschema.Schema{
-		Type:     string(schema.Object),
-		Required: []string{"query"},
-		Properties: orderedmap.New[string, *jsonschema.Schema](
-			orderedmap.WithInitialData[string, *jsonschema.Schema](
-				orderedmap.Pair[string, *jsonschema.Schema]{
-					Key: "query",
-					Value: &jsonschema.Schema{
+func toolNameAndDesc(config *Config, defaultName, defaultDesc string) (string, string) {
+	if config == nil {
+		config = &Config{}
+	}
+
+	name := config.ToolName
+	if name == "" {
+		name = defaultName
+	}
+	desc := config.ToolDesc
+	if desc == "" {
+		desc = defaultDesc
+	}
+
+	return name, desc
+}
+
+// getVerticalSearchSchema describes the parameters shared by the text, news and image search tools.
+func getVerticalSearchSchema(toolName, toolDesc string) *schema.ToolInfo {
+	return buildToolInfo(toolName, toolDesc,
+		queryProperty(),
+		timeRangeProperty(),
+		regionProperty(),
+		safeSearchProperty(),
+	)
+}
+
+func getSearchSchema(toolName, toolDesc string) *schema.ToolInfo {
+	return buildToolInfo(toolName, toolDesc,
+		queryProperty(),
+		orderedmap.Pair[string, *jsonschema.Schema]{
+			Key: "type",
+			Value: &jsonschema.Schema{
+				Type:        string(schema.String),
+				Description: "The type of search to perform",
+				Default:     string(SearchTypeText),
+				OneOf: []*jsonschema.Schema{
+					{
 						Type:        string(schema.String),
-						Description: "The user's search query. The query is required.",
+						Enum:        []any{string(SearchTypeText)},
+						Description: "Search web pages",
 					},
-				},
-				orderedmap.Pair[string, *jsonschema.Schema]{
-					Key: "time_range",
-					Value: &jsonschema.Schema{
+					{
 						Type:        string(schema.String),
-						Description: "The time range of search results",
-						Default:     "",
-						OneOf: []*jsonschema.Schema{
-							{
-
-								Type:        string(schema.String),
-								Enum:        []any{"d"},
-								Description: "Search information from the past day",
-							},
-							{
-								Type:        string(schema.String),
-								Enum:        []any{"w"},
-								Description: "Search information from the past week",
-							},
-							{
-								Type:        string(schema.String),
-								Enum:        []any{"m"},
-								Description: "Search information from the past month",
-							},
-							{
-								Type:        string(schema.String),
-								Enum:        []any{"y"},
-								Description: "Search information from the past year",
-							},
-							{
-								Type:        string(schema.String),
-								Enum:        []any{""},
-								Description: "Search information at any time",
-							},
-						},
+						Enum:        []any{string(SearchTypeNews)},
+						Description: "Search news articles",
+					},
+					{
+						Type:        string(schema.String),
+						Enum:        []any{string(SearchTypeImages)},
+						Description: "Search images",
 					},
 				},
-			),
+			},
+		},
+		timeRangeProperty(),
+		regionProperty(),
+		safeSearchProperty(),
+	)
+}
+
+func buildToolInfo(toolName, toolDesc string, props ...orderedmap.Pair[string, *jsonschema.Schema]) *schema.ToolInfo {
+	sc := &jsonschema.Schema{
+		Type:     string(schema.Object),
+		Required: []string{"query"},
+		Properties: orderedmap.New[string, *jsonschema.Schema](
+			orderedmap.WithInitialData[string, *jsonschema.Schema](props...),
 		),
 	}
 
@@ -147,6 +194,98 @@ func getTextSearchSchema(toolName, toolDesc string) *schema.ToolInfo {
 	return info
 }
 
+func queryProperty() orderedmap.Pair[string, *jsonschema.Schema] {
+	return orderedmap.Pair[string, *jsonschema.Schema]{
+		Key: "query",
+		Value: &jsonschema.Schema{
+			Type:        string(schema.String),
+			Description: "The user's search query. The query is required.",
+		},
+	}
+}
+
+func timeRangeProperty() orderedmap.Pair[string, *jsonschema.Schema] {
+	return orderedmap.Pair[string, *jsonschema.Schema]{
+		Key: "time_range",
+		Value: &jsonschema.Schema{
+			Type:        string(schema.String),
+			Description: "The time range of search results",
+			Default:     "",
+			OneOf: []*jsonschema.Schema{
+				{
+					Type:        string(schema.String),
+					Enum:        []any{"d"},
+					Description: "Search information from the past day",
+				},
+				{
+					Type:        string(schema.String),
+					Enum:        []any{"w"},
+					Description: "Search information from the past week",
+				},
+				{
+					Type:        string(schema.String),
+					Enum:        []any{"m"},
+					Description: "Search information from the past month",
+				},
+				{
+					Type:        string(schema.String),
+					Enum:        []any{"y"},
+					Description: "Search information from the past year",
+				},
+				{
+					Type:        string(schema.String),
+					Enum:        []any{""},
+					Description: "Search information at any time",
+				},
+			},
+		},
+	}
+}
+
+func regionProperty() orderedmap.Pair[string, *jsonschema.Schema] {
+	return orderedmap.Pair[string, *jsonschema.Schema]{
+		Key: "region",
+		Value: &jsonschema.Schema{
+			Type: string(schema.String),
+			Description: "The region of search results, formatted as '<country>-<language>', " +
+				"e.g. 'us-en', 'uk-en', 'de-de', 'fr-fr', 'jp-jp', 'cn-zh'. " +
+				"Use 'wt-wt' for no specific region. Leave empty to use the default region.",
+		},
+	}
+}
+
+func safeSearchProperty() orderedmap.Pair[string, *jsonschema.Schema] {
+	return orderedmap.Pair[string, *jsonschema.Schema]{
+		Key: "safe_search",
+		Value: &jsonschema.Schema{
+			Type:        string(schema.String),
+			Description: "The safe search level of search results. Leave empty to use the default level.",
+			OneOf: []*jsonschema.Schema{
+				{
+					Type:        string(schema.String),
+					Enum:        []any{string(SafeSearchStrict)},
+					Description: "Filter out all adult content",
+				},
+				{
+					Type:        string(schema.String),
+					Enum:        []any{string(SafeSearchModerate)},
+					Description: "Filter out explicit content",
+				},
+				{
+					Type:        string(schema.String),
+					Enum:        []any{string(SafeSearchOff)},
+					Description: "Do not filter results",
+				},
+				{
+					Type:        string(schema.String),
+					Enum:        []any{""},
+					Description: "Use the default level",
+				},
+			},
+		},
+	}
+}
+
 func buildClient(_ context.Context, config *Config) (Search, error) {
 	if config == nil {
 		config = &Config{}
@@ -157,6 +296,11 @@ func buildClient(_ context.Context, config *Config) (Search, error) {
 		region = RegionWT
 	}
 
+	safeSearch := config.SafeSearch
+	if safeSearch == "" {
+		safeSearch = SafeSearchModerate
+	}
+
 	maxResults := config.MaxResults
 	if maxResults <= 0 {
 		maxResults = 10
@@ -180,5 +324,6 @@ func buildClient(_ context.Context, config *Config) (Search, error) {
 		httpCli:    httpCli,
 		maxResults: maxResults,
 		region:     region,
+		safeSearch: safeSearch,
 	}, nil
 }
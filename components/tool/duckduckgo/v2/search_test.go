@@ -18,10 +18,13 @@ package duckduckgo
 
 import (
 	"context"
+	"encoding/json"
+	"net/url"
 	"testing"
 	"time"
 
 	"github.com/bytedance/mockey"
+	"github.com/cloudwego/eino/components/tool"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -37,6 +40,7 @@ func Test_buildClient(t *testing.T) {
 			assert.True(t, ok)
 
 			assert.Equal(t, RegionWT, cli.region)
+			assert.Equal(t, SafeSearchModerate, cli.safeSearch)
 			assert.Equal(t, 10, cli.maxResults)
 
 			assert.NotNil(t, cli.httpCli)
@@ -47,6 +51,7 @@ func Test_buildClient(t *testing.T) {
 			Timeout:    15 * time.Second,
 			MaxResults: 20,
 			Region:     RegionUS,
+			SafeSearch: SafeSearchStrict,
 		}
 
 		search, err := buildClient(ctx, customConfig)
@@ -56,9 +61,71 @@ func Test_buildClient(t *testing.T) {
 		assert.True(t, ok)
 
 		assert.Equal(t, RegionUS, cli.region)
+		assert.Equal(t, SafeSearchStrict, cli.safeSearch)
 		assert.Equal(t, 20, cli.maxResults)
 
 		assert.NotNil(t, cli.httpCli)
 		assert.Equal(t, 15*time.Second, cli.httpCli.Timeout)
 	})
 }
+
+func TestNewSearchTool(t *testing.T) {
+	mockey.PatchConvey("Test NewSearchTool", t, func() {
+		ctx := context.Background()
+
+		mockey.PatchConvey("tool info", func() {
+			for _, tc := range []struct {
+				newTool func(context.Context, *Config) (tool.InvokableTool, error)
+				name    string
+				params  []string
+			}{
+				{NewTextSearchTool, defaultTextSearchToolName, []string{"query", "time_range", "region", "safe_search"}},
+				{NewNewsSearchTool, defaultNewsSearchToolName, []string{"query", "time_range", "region", "safe_search"}},
+				{NewImageSearchTool, defaultImageSearchToolName, []string{"query", "time_range", "region", "safe_search"}},
+				{NewSearchTool, defaultSearchToolName, []string{"query", "type", "time_range", "region", "safe_search"}},
+			} {
+				st, err := tc.newTool(ctx, nil)
+				assert.NoError(t, err)
+
+				info, err := st.Info(ctx)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.name, info.Name)
+
+				js, err := info.ParamsOneOf.ToJSONSchema()
+				assert.NoError(t, err)
+				var keys []string
+				for pair := js.Properties.Oldest(); pair != nil; pair = pair.Next() {
+					keys = append(keys, pair.Key)
+				}
+				assert.Equal(t, tc.params, keys)
+			}
+		})
+
+		mockey.PatchConvey("news type", func() {
+			newFakeDDG(t, func(path string, _ url.Values) string {
+				assert.Equal(t, "/news.js", path)
+				return `{"results":[{"title":"t1","excerpt":"e1","url":"https://a.com/1"}]}`
+			})
+
+			st, err := NewSearchTool(ctx, &Config{ToolName: "web_search"})
+			assert.NoError(t, err)
+
+			out, err := st.InvokableRun(ctx, `{"query":"eino","type":"news","region":"US-EN"}`)
+			assert.NoError(t, err)
+
+			var resp SearchResponse
+			assert.NoError(t, json.Unmarshal([]byte(out), &resp))
+			assert.Equal(t, []*NewsSearchResult{{Title: "t1", URL: "https://a.com/1", Summary: "e1"}}, resp.NewsResults)
+			assert.Empty(t, resp.TextResults)
+			assert.Empty(t, resp.ImageResults)
+		})
+
+		mockey.PatchConvey("unsupported type", func() {
+			st, err := NewSearchTool(ctx, nil)
+			assert.NoError(t, err)
+
+			_, err = st.InvokableRun(ctx, `{"query":"eino","type":"videos"}`)
+			assert.ErrorContains(t, err, "unsupported search type")
+		})
+	})
+}
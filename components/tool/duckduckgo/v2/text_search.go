@@ -38,10 +38,15 @@ func (c *client) TextSearch(ctx context.Context, input *TextSearchRequest) (*Tex
 		return nil, fmt.Errorf("search query is required")
 	}
 
+	safeSearch, err := c.resolveSafeSearch(input.SafeSearch)
+	if err != nil {
+		return nil, err
+	}
+
 	results := make([]*TextSearchResult, 0, c.maxResults)
 
 	header := buildTextHTMLRequestHeader()
-	reqBody := input.buildTextHTMLRequestBody(c.region)
+	reqBody := input.buildTextHTMLRequestBody(c.resolveRegion(input.Region), safeSearch)
 
 	for {
 		var req *http.Request
@@ -102,7 +107,7 @@ func buildTextHTMLRequestHeader() http.Header {
 	}
 }
 
-func (t *TextSearchRequest) buildTextHTMLRequestBody(region Region) url.Values {
+func (t *TextSearchRequest) buildTextHTMLRequestBody(region Region, safeSearch SafeSearch) url.Values {
 	// q (str): Search query string
 	// s (int): Search offset for pagination
 	// nextParams (str): Continuation parameters from previous page response, typically empty
@@ -113,18 +118,27 @@ func (t *TextSearchRequest) buildTextHTMLRequestBody(region Region) url.Values {
 	// vqd (str): Validation query digest
 	// kl (str): Keyboard language/region code (e.g., 'en-us')
 	// df (str): Time filter, maps to values like 'd' (day), 'w' (week), 'm' (month), 'y' (year)
+	// kp (int): Safe search, 1 (strict), -1 (moderate), -2 (off)
 
 	body := url.Values{
 		"q":  {t.Query},
 		"b":  {""},
 		"kl": {""},
 		"df": {string(TimeRangeAny)},
+		"kp": {"-1"},
 	}
 
 	if region != RegionWT {
 		body["kl"] = []string{string(region)}
 	}
 
+	switch safeSearch {
+	case SafeSearchStrict:
+		body["kp"] = []string{"1"}
+	case SafeSearchOff:
+		body["kp"] = []string{"-2"}
+	}
+
 	switch t.TimeRange {
 	case TimeRangeDay, TimeRangeWeek, TimeRangeMonth, TimeRangeYear:
 		body["df"] = []string{string(t.TimeRange)}
@@ -54,6 +54,26 @@ func TestClientTextSearch(t *testing.T) {
 	})
 }
 
+func TestBuildTextHTMLRequestBody(t *testing.T) {
+	req := &TextSearchRequest{Query: "eino", TimeRange: TimeRangeDay}
+
+	body := req.buildTextHTMLRequestBody(RegionWT, SafeSearchModerate)
+	assert.Equal(t, url.Values{
+		"q":  {"eino"},
+		"b":  {""},
+		"kl": {""},
+		"df": {"d"},
+		"kp": {"-1"},
+	}, body)
+
+	body = req.buildTextHTMLRequestBody(RegionCN, SafeSearchStrict)
+	assert.Equal(t, "cn-zh", body.Get("kl"))
+	assert.Equal(t, "1", body.Get("kp"))
+
+	body = req.buildTextHTMLRequestBody(RegionCN, SafeSearchOff)
+	assert.Equal(t, "-2", body.Get("kp"))
+}
+
 func TestParseSearchResponse(t *testing.T) {
 	PatchConvey("found results", t, func() {
 		respBody := `<!DOCTYPE html PUBLIC "-//W3C//DTD XHTML 1.0 Transitional//EN" "http://www.w3.org/TR/xhtml1/DTD/xhtml1-transitional.dtd">
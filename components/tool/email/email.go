@@ -0,0 +1,301 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package email provides a tool sending emails with SMTP or the SendGrid API,
+// restricted to an allowlist of recipients, with templates, attachments and a dry-run mode.
+package email
+
+import (
+	"context"
+	"fmt"
+	htmltemplate "html/template"
+	"net/http"
+	"net/mail"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+)
+
+// Provider is the backend sending the emails.
+type Provider string
+
+const (
+	ProviderSMTP     Provider = "smtp"
+	ProviderSendGrid Provider = "sendgrid"
+)
+
+const (
+	defaultMaxRecipients     = 10
+	defaultMaxAttachmentSize = 10 << 20
+)
+
+// Config is the configuration for the email tool.
+type Config struct {
+	// Provider is the backend sending the emails.
+	// Required.
+	Provider Provider
+	// SMTP is the configuration of the SMTP server.
+	// Required with ProviderSMTP.
+	SMTP *SMTPConfig
+	// SendGrid is the configuration of the SendGrid API.
+	// Required with ProviderSendGrid.
+	SendGrid *SendGridConfig
+	// Timeout is the maximum duration of sending an email.
+	// Optional. Default 30s.
+	Timeout time.Duration
+
+	// From is the address of the sender, e.g. "Support <support@example.com>".
+	// Required.
+	From string
+	// ReplyTo is the address the replies are sent to.
+	// Optional. Default From.
+	ReplyTo string
+
+	// AllowedRecipients are the addresses, e.g. "ops@example.com", or the domains, e.g. "@example.com",
+	// the emails can be sent to, checked for all the recipients including cc and bcc.
+	// Optional. Default any recipient.
+	AllowedRecipients []string
+	// MaxRecipients is the maximum number of recipients of an email, including cc and bcc.
+	// Optional. Default 10.
+	MaxRecipients int
+
+	// Templates are the templates the model can fill with data instead of writing the whole email, by name.
+	// Optional.
+	Templates map[string]*Template
+
+	// AttachmentDir is the directory of the files the model can attach by path.
+	// Optional. Default the files can't be attached by path, only the text contents of the model.
+	AttachmentDir string
+	// MaxAttachmentSize is the maximum total size of the attachments of an email in bytes.
+	// Optional. Default 10MB.
+	MaxAttachmentSize int64
+
+	// DryRun renders and validates the emails without sending them, the rendered email being returned to the model.
+	// Optional. Default false.
+	DryRun bool
+
+	ToolName string // Optional. Default "send_email".
+	ToolDesc string // Optional. Default "send an email".
+}
+
+// SMTPConfig is the configuration of the SMTP server.
+type SMTPConfig struct {
+	// Host is the host name of the SMTP server, e.g. "smtp.gmail.com".
+	// Required.
+	Host string
+	// Port is the port of the SMTP server.
+	// Optional. Default 587.
+	Port int
+	// Username and Password authenticate with PLAIN auth, which requires TLS except on localhost.
+	// Optional. Default no authentication.
+	Username string
+	Password string
+	// ImplicitTLS connects with TLS from the start, instead of upgrading the connection with STARTTLS.
+	// Optional. Default true on the port 465.
+	ImplicitTLS bool
+	// DisableStartTLS sends the email without upgrading the connection with STARTTLS, e.g. for a local relay.
+	// Optional. Default false.
+	DisableStartTLS bool
+	// LocalName is the host name sent in the HELO command.
+	// Optional. Default "localhost".
+	LocalName string
+}
+
+// SendGridConfig is the configuration of the SendGrid API.
+type SendGridConfig struct {
+	// APIKey is the API key with the Mail Send permission.
+	// Required.
+	APIKey string
+	// BaseURL is the base url of the API, e.g. "https://api.eu.sendgrid.com" for the EU region.
+	// Optional. Default "https://api.sendgrid.com".
+	BaseURL string
+	// HTTPClient is the http client sending the requests.
+	// Optional. Default a client with Timeout.
+	HTTPClient *http.Client
+}
+
+// Template is an email template rendered with the data of the model, with the Go template syntax, e.g. "Hello {{.name}}".
+// A missing key of the data is an error.
+type Template struct {
+	// Description tells the model when to use the template and which data to fill.
+	Description string
+	// Subject is the template of the subject, used unless the model sets the subject.
+	Subject string
+	// Text is the template of the plain text body.
+	Text string
+	// HTML is the template of the HTML body, whose values are escaped.
+	HTML string
+
+	subject *template.Template
+	text    *template.Template
+	html    *htmltemplate.Template
+}
+
+// NewTool creates a new email tool.
+func NewTool(ctx context.Context, conf *Config) (tool.InvokableTool, error) {
+	s, err := newSender(conf)
+	if err != nil {
+		return nil, err
+	}
+	t, err := utils.InferTool(conf.ToolName, conf.ToolDesc, s.Send)
+	if err != nil {
+		return nil, fmt.Errorf("failed to infer tool: %w", err)
+	}
+	return t, nil
+}
+
+// validate validates the configuration and sets default values if not provided.
+func (conf *Config) validate() error {
+	if conf == nil {
+		return fmt.Errorf("config is nil")
+	}
+	if conf.Timeout <= 0 {
+		conf.Timeout = 30 * time.Second
+	}
+	switch conf.Provider {
+	case ProviderSMTP:
+		if conf.SMTP == nil || conf.SMTP.Host == "" {
+			return fmt.Errorf("smtp host is required")
+		}
+		if conf.SMTP.Port == 0 {
+			conf.SMTP.Port = 587
+		}
+		if conf.SMTP.Port == 465 {
+			conf.SMTP.ImplicitTLS = true
+		}
+		if conf.SMTP.LocalName == "" {
+			conf.SMTP.LocalName = "localhost"
+		}
+	case ProviderSendGrid:
+		if conf.SendGrid == nil || conf.SendGrid.APIKey == "" {
+			return fmt.Errorf("sendgrid api key is required")
+		}
+		if conf.SendGrid.BaseURL == "" {
+			conf.SendGrid.BaseURL = "https://api.sendgrid.com"
+		}
+		conf.SendGrid.BaseURL = strings.TrimRight(conf.SendGrid.BaseURL, "/")
+		if conf.SendGrid.HTTPClient == nil {
+			conf.SendGrid.HTTPClient = &http.Client{Timeout: conf.Timeout}
+		}
+	case "":
+		return fmt.Errorf("provider is required")
+	default:
+		return fmt.Errorf("unsupported provider: %s", conf.Provider)
+	}
+
+	if conf.From == "" {
+		return fmt.Errorf("from is required")
+	}
+	if _, err := mail.ParseAddress(conf.From); err != nil {
+		return fmt.Errorf("invalid from address %q: %w", conf.From, err)
+	}
+	if conf.ReplyTo != "" {
+		if _, err := mail.ParseAddress(conf.ReplyTo); err != nil {
+			return fmt.Errorf("invalid reply to address %q: %w", conf.ReplyTo, err)
+		}
+	}
+	for _, r := range conf.AllowedRecipients {
+		if !strings.Contains(r, "@") {
+			return fmt.Errorf("invalid allowed recipient %q, must be an address or a domain like @example.com", r)
+		}
+	}
+	if conf.MaxRecipients <= 0 {
+		conf.MaxRecipients = defaultMaxRecipients
+	}
+	if conf.MaxAttachmentSize <= 0 {
+		conf.MaxAttachmentSize = defaultMaxAttachmentSize
+	}
+	if conf.AttachmentDir != "" {
+		info, err := os.Stat(conf.AttachmentDir)
+		if err != nil {
+			return fmt.Errorf("invalid attachment dir: %w", err)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("attachment dir %s is not a directory", conf.AttachmentDir)
+		}
+	}
+
+	for name, tpl := range conf.Templates {
+		if err := tpl.parse(name); err != nil {
+			return err
+		}
+	}
+
+	if conf.ToolName == "" {
+		conf.ToolName = "send_email"
+	}
+	if conf.ToolDesc == "" {
+		conf.ToolDesc = conf.defaultToolDesc()
+	}
+	return nil
+}
+
+func (conf *Config) defaultToolDesc() string {
+	var sb strings.Builder
+	sb.WriteString("send an email with a subject and a body, or with a template filled with data.")
+	if len(conf.AllowedRecipients) > 0 {
+		sb.WriteString(" The allowed recipients are: ")
+		sb.WriteString(strings.Join(conf.AllowedRecipients, ", "))
+		sb.WriteString(".")
+	}
+	if len(conf.Templates) > 0 {
+		names := make([]string, 0, len(conf.Templates))
+		for name := range conf.Templates {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		sb.WriteString(" The templates are:")
+		for _, name := range names {
+			sb.WriteString("\n- ")
+			sb.WriteString(name)
+			if desc := conf.Templates[name].Description; desc != "" {
+				sb.WriteString(": ")
+				sb.WriteString(desc)
+			}
+		}
+	}
+	return sb.String()
+}
+
+func (t *Template) parse(name string) error {
+	if t == nil {
+		return fmt.Errorf("template %s is nil", name)
+	}
+	if t.Text == "" && t.HTML == "" {
+		return fmt.Errorf("template %s has no body, text or html is required", name)
+	}
+	var err error
+	if t.Subject != "" {
+		if t.subject, err = template.New(name).Option("missingkey=error").Parse(t.Subject); err != nil {
+			return fmt.Errorf("failed to parse subject of template %s: %w", name, err)
+		}
+	}
+	if t.Text != "" {
+		if t.text, err = template.New(name).Option("missingkey=error").Parse(t.Text); err != nil {
+			return fmt.Errorf("failed to parse text of template %s: %w", name, err)
+		}
+	}
+	if t.HTML != "" {
+		if t.html, err = htmltemplate.New(name).Option("missingkey=error").Parse(t.HTML); err != nil {
+			return fmt.Errorf("failed to parse html of template %s: %w", name, err)
+		}
+	}
+	return nil
+}
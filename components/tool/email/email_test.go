@@ -0,0 +1,395 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package email
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTool(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewTool(ctx, nil)
+	assert.EqualError(t, err, "config is nil")
+	_, err = NewTool(ctx, &Config{})
+	assert.EqualError(t, err, "provider is required")
+	_, err = NewTool(ctx, &Config{Provider: "ses"})
+	assert.EqualError(t, err, "unsupported provider: ses")
+	_, err = NewTool(ctx, &Config{Provider: ProviderSMTP})
+	assert.EqualError(t, err, "smtp host is required")
+	_, err = NewTool(ctx, &Config{Provider: ProviderSendGrid, SendGrid: &SendGridConfig{}})
+	assert.EqualError(t, err, "sendgrid api key is required")
+	_, err = NewTool(ctx, &Config{Provider: ProviderSendGrid, SendGrid: &SendGridConfig{APIKey: "key"}})
+	assert.EqualError(t, err, "from is required")
+	_, err = NewTool(ctx, &Config{Provider: ProviderSendGrid, SendGrid: &SendGridConfig{APIKey: "key"},
+		From: "bot@example.com", AllowedRecipients: []string{"example.com"}})
+	assert.EqualError(t, err, `invalid allowed recipient "example.com", must be an address or a domain like @example.com`)
+	_, err = NewTool(ctx, &Config{Provider: ProviderSendGrid, SendGrid: &SendGridConfig{APIKey: "key"},
+		From: "bot@example.com", Templates: map[string]*Template{"empty": {Subject: "hi"}}})
+	assert.EqualError(t, err, "template empty has no body, text or html is required")
+	_, err = NewTool(ctx, &Config{Provider: ProviderSendGrid, SendGrid: &SendGridConfig{APIKey: "key"},
+		From: "bot@example.com", Templates: map[string]*Template{"bad": {Text: "{{.name"}}})
+	assert.ErrorContains(t, err, "failed to parse text of template bad")
+
+	tl, err := NewTool(ctx, &Config{
+		Provider:          ProviderSendGrid,
+		SendGrid:          &SendGridConfig{APIKey: "key"},
+		From:              "bot@example.com",
+		AllowedRecipients: []string{"@example.com"},
+		Templates: map[string]*Template{
+			"welcome": {Description: "welcome a new user, data: name", Text: "Hello {{.name}}"},
+			"alert":   {Text: "{{.message}}"},
+		},
+	})
+	assert.NoError(t, err)
+	info, err := tl.Info(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "send_email", info.Name)
+	assert.Equal(t, "send an email with a subject and a body, or with a template filled with data. "+
+		"The allowed recipients are: @example.com. The templates are:\n- alert\n- welcome: welcome a new user, data: name", info.Desc)
+}
+
+func newDryRunSender(t *testing.T, conf *Config) *sender {
+	conf.Provider = ProviderSendGrid
+	conf.SendGrid = &SendGridConfig{APIKey: "key"}
+	conf.DryRun = true
+	if conf.From == "" {
+		conf.From = "Bot <bot@example.com>"
+	}
+	s, err := newSender(conf)
+	assert.NoError(t, err)
+	return s
+}
+
+func TestSendDryRun(t *testing.T) {
+	ctx := context.Background()
+	s := newDryRunSender(t, &Config{
+		AllowedRecipients: []string{"@example.com", "partner@other.org"},
+		MaxRecipients:     3,
+		Templates: map[string]*Template{
+			"report": {
+				Subject: "Report of {{.day}}",
+				Text:    "Hello {{.name}}, {{.count}} incidents.",
+				HTML:    "<p>Hello {{.name}}</p>",
+			},
+		},
+	})
+
+	resp, err := s.Send(ctx, &SendRequest{
+		To:       []string{"Alice <alice@example.com>"},
+		Cc:       []string{"PARTNER@other.org"},
+		Template: "report",
+		Data:     map[string]any{"day": "Monday", "name": "<Alice>", "count": 2},
+		Attachments: []*Attachment{
+			{Filename: "incidents.csv", Content: "id,severity\n1,high\n"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &SendResponse{
+		Status:      StatusDryRun,
+		To:          []string{"alice@example.com"},
+		Cc:          []string{"PARTNER@other.org"},
+		Subject:     "Report of Monday",
+		Attachments: []string{"incidents.csv"},
+		Text:        "Hello <Alice>, 2 incidents.",
+		HTML:        "<p>Hello &lt;Alice&gt;</p>",
+	}, resp)
+
+	// the subject of the request overrides the subject of the template
+	resp, err = s.Send(ctx, &SendRequest{
+		To:       []string{"alice@example.com"},
+		Subject:  "Urgent report",
+		Template: "report",
+		Data:     map[string]any{"name": "Alice", "count": 2},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Urgent report", resp.Subject)
+
+	tests := []struct {
+		req *SendRequest
+		err string
+	}{
+		{req: &SendRequest{Subject: "s", Body: "b"}, err: "to is required"},
+		{req: &SendRequest{To: []string{"not an address"}, Subject: "s", Body: "b"}, err: `invalid to address "not an address": mail: no angle-addr`},
+		{req: &SendRequest{To: []string{"eve@evil.com"}, Subject: "s", Body: "b"}, err: "recipient eve@evil.com is not allowed"},
+		{req: &SendRequest{To: []string{"eve@notexample.com"}, Subject: "s", Body: "b"}, err: "recipient eve@notexample.com is not allowed"},
+		{req: &SendRequest{To: []string{"a@example.com"}, Bcc: []string{"eve@evil.com"}, Subject: "s", Body: "b"}, err: "recipient eve@evil.com is not allowed"},
+		{req: &SendRequest{To: []string{"a@example.com", "b@example.com"}, Cc: []string{"c@example.com", "d@example.com"}, Subject: "s", Body: "b"},
+			err: "too many recipients: 4, at most 3 are allowed"},
+		{req: &SendRequest{To: []string{"a@example.com"}, Body: "b"}, err: "subject is required"},
+		{req: &SendRequest{To: []string{"a@example.com"}, Subject: "s\r\nBcc: eve@evil.com", Body: "b"}, err: "subject must be a single line"},
+		{req: &SendRequest{To: []string{"a@example.com"}, Subject: "s"}, err: "body or html is required"},
+		{req: &SendRequest{To: []string{"a@example.com"}, Template: "missing"}, err: "unknown template: missing"},
+		{req: &SendRequest{To: []string{"a@example.com"}, Template: "report", Body: "b"}, err: "set either template, or body and html"},
+		{req: &SendRequest{To: []string{"a@example.com"}, Template: "report", Data: map[string]any{"day": "Monday"}},
+			err: `failed to render text of template report: template: report:1:8: executing "report" at <.name>: map has no entry for key "name"`},
+		{req: &SendRequest{To: []string{"a@example.com"}, Subject: "s", Body: "b", Attachments: []*Attachment{{Filename: "a.txt", Path: "a.txt"}}},
+			err: "attachment a.txt: attaching files by path is not enabled"},
+	}
+	for _, tt := range tests {
+		_, err = s.Send(ctx, tt.req)
+		assert.EqualError(t, err, tt.err)
+	}
+}
+
+func TestAttachments(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "report.pdf"), []byte("%PDF-1.4"), 0o644))
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "sub"), 0o755))
+	s := newDryRunSender(t, &Config{AttachmentDir: dir, MaxAttachmentSize: 20})
+
+	msg, err := s.render(&SendRequest{
+		To:      []string{"a@example.com"},
+		Subject: "s",
+		Body:    "b",
+		Attachments: []*Attachment{
+			{Filename: "report.pdf", Path: "../../report.pdf"},
+			{Filename: "notes.md", Content: "# notes"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, msg.attachments, 2)
+	assert.Equal(t, &attachment{filename: "report.pdf", contentType: "application/pdf", data: []byte("%PDF-1.4")}, msg.attachments[0])
+	assert.Equal(t, "notes.md", msg.attachments[1].filename)
+
+	tests := []struct {
+		attachment *Attachment
+		err        string
+	}{
+		{attachment: &Attachment{Content: "x"}, err: "attachment filename is required"},
+		{attachment: &Attachment{Filename: "a.txt", Content: "x", Path: "report.pdf"}, err: "attachment a.txt: set either content or path"},
+		{attachment: &Attachment{Filename: "a.txt", Path: "missing.txt"}, err: "attachment a.txt: file missing.txt not found"},
+		{attachment: &Attachment{Filename: "a.txt", Path: "sub"}, err: "attachment a.txt: sub is a directory"},
+		{attachment: &Attachment{Filename: "a.txt", Content: strings.Repeat("x", 21)}, err: "attachments are too large, at most 20 bytes are allowed"},
+	}
+	for _, tt := range tests {
+		_, err = s.render(&SendRequest{To: []string{"a@example.com"}, Subject: "s", Body: "b", Attachments: []*Attachment{tt.attachment}})
+		assert.EqualError(t, err, tt.err)
+	}
+}
+
+func TestSendGrid(t *testing.T) {
+	ctx := context.Background()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v3/mail/send", r.URL.Path)
+		assert.Equal(t, "Bearer sg-key", r.Header.Get("Authorization"))
+		var body sendGridRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		if body.Subject == "fail" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":[{"message":"The from address does not match a verified Sender Identity.","field":"from"}]}`))
+			return
+		}
+		assert.Equal(t, &sendGridRequest{
+			Personalizations: []*sendGridPersonalization{{
+				To:  []*sendGridAddress{{Email: "alice@example.com", Name: "Alice"}},
+				Bcc: []*sendGridAddress{{Email: "audit@example.com"}},
+			}},
+			From:    &sendGridAddress{Email: "bot@example.com", Name: "Bot"},
+			ReplyTo: &sendGridAddress{Email: "support@example.com"},
+			Subject: "Hello",
+			Content: []*sendGridContent{{Type: "text/plain", Value: "Hi"}, {Type: "text/html", Value: "<b>Hi</b>"}},
+			Attachments: []*sendGridAttachment{{
+				Content:     base64.StdEncoding.EncodeToString([]byte("a,b")),
+				Type:        "text/csv; charset=utf-8",
+				Filename:    "data.csv",
+				Disposition: "attachment",
+			}},
+		}, &body)
+		w.Header().Set("X-Message-Id", "sg-message-id")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	s, err := newSender(&Config{
+		Provider: ProviderSendGrid,
+		SendGrid: &SendGridConfig{APIKey: "sg-key", BaseURL: srv.URL},
+		From:     "Bot <bot@example.com>",
+		ReplyTo:  "support@example.com",
+	})
+	assert.NoError(t, err)
+
+	resp, err := s.Send(ctx, &SendRequest{
+		To:          []string{"Alice <alice@example.com>"},
+		Bcc:         []string{"audit@example.com"},
+		Subject:     "Hello",
+		Body:        "Hi",
+		HTML:        "<b>Hi</b>",
+		Attachments: []*Attachment{{Filename: "data.csv", Content: "a,b"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, StatusSent, resp.Status)
+	assert.Equal(t, "sg-message-id", resp.MessageID)
+	assert.Empty(t, resp.Text)
+
+	_, err = s.Send(ctx, &SendRequest{To: []string{"alice@example.com"}, Subject: "fail", Body: "Hi"})
+	assert.EqualError(t, err, "failed to send email: sendgrid api error, status 403: from: The from address does not match a verified Sender Identity.")
+}
+
+// fakeSMTPServer accepts a single session and records its commands and data.
+type fakeSMTPServer struct {
+	ln       net.Listener
+	commands []string
+	data     string
+	done     chan struct{}
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	s := &fakeSMTPServer{ln: ln, done: make(chan struct{})}
+	t.Cleanup(func() { _ = ln.Close() })
+	go s.serve()
+	return s
+}
+
+func (s *fakeSMTPServer) port() int {
+	return s.ln.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeSMTPServer) serve() {
+	defer close(s.done)
+	conn, err := s.ln.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = io.WriteString(conn, line+"\r\n") }
+
+	reply("220 localhost ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		s.commands = append(s.commands, line)
+		switch cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0]); cmd {
+		case "EHLO":
+			reply("250-localhost")
+			reply("250 AUTH PLAIN")
+		case "AUTH":
+			reply("235 2.7.0 Authentication successful")
+		case "MAIL", "RCPT":
+			reply("250 OK")
+		case "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var sb strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				sb.WriteString(l)
+			}
+			s.data = sb.String()
+			reply("250 OK: queued")
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func TestSMTP(t *testing.T) {
+	ctx := context.Background()
+	srv := newFakeSMTPServer(t)
+
+	s, err := newSender(&Config{
+		Provider: ProviderSMTP,
+		SMTP: &SMTPConfig{
+			Host:     "127.0.0.1",
+			Port:     srv.port(),
+			Username: "user",
+			Password: "pass",
+		},
+		From: "Bot <bot@example.com>",
+	})
+	assert.NoError(t, err)
+
+	resp, err := s.Send(ctx, &SendRequest{
+		To:          []string{"Alice <alice@example.com>"},
+		Cc:          []string{"bob@example.com"},
+		Bcc:         []string{"audit@example.com"},
+		Subject:     "Rapport journalier é",
+		Body:        "Hello",
+		HTML:        "<p>Hello</p>",
+		Attachments: []*Attachment{{Filename: "data.csv", Content: "a,b"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, StatusSent, resp.Status)
+	assert.True(t, strings.HasPrefix(resp.MessageID, "<") && strings.HasSuffix(resp.MessageID, "@example.com>"))
+	<-srv.done
+
+	assert.Equal(t, []string{
+		"EHLO localhost",
+		"AUTH PLAIN " + base64.StdEncoding.EncodeToString([]byte("\x00user\x00pass")),
+		"MAIL FROM:<bot@example.com>",
+		"RCPT TO:<alice@example.com>",
+		"RCPT TO:<bob@example.com>",
+		"RCPT TO:<audit@example.com>",
+		"DATA",
+		"QUIT",
+	}, srv.commands)
+	assert.Contains(t, srv.data, "From: \"Bot\" <bot@example.com>\r\n")
+	assert.Contains(t, srv.data, "To: \"Alice\" <alice@example.com>\r\n")
+	assert.Contains(t, srv.data, "Cc: <bob@example.com>\r\n")
+	assert.NotContains(t, srv.data, "audit@example.com")
+	assert.Contains(t, srv.data, "Subject: =?utf-8?q?Rapport_journalier_=C3=A9?=\r\n")
+	assert.Contains(t, srv.data, "Message-ID: "+resp.MessageID+"\r\n")
+	assert.Contains(t, srv.data, "Content-Type: multipart/mixed; boundary=")
+	assert.Contains(t, srv.data, "Content-Type: multipart/alternative; boundary=")
+	assert.Contains(t, srv.data, "Content-Type: text/html; charset=utf-8\r\n")
+	assert.Contains(t, srv.data, "Content-Disposition: attachment; filename=data.csv\r\n")
+	assert.Contains(t, srv.data, base64.StdEncoding.EncodeToString([]byte("a,b"))+"\r\n")
+}
+
+func TestSMTPConnectionError(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	port := ln.Addr().(*net.TCPAddr).Port
+	assert.NoError(t, ln.Close())
+
+	s, err := newSender(&Config{
+		Provider: ProviderSMTP,
+		SMTP:     &SMTPConfig{Host: "127.0.0.1", Port: port},
+		From:     "bot@example.com",
+	})
+	assert.NoError(t, err)
+	_, err = s.Send(context.Background(), &SendRequest{To: []string{"a@example.com"}, Subject: "s", Body: "b"})
+	assert.ErrorContains(t, err, "failed to send email: failed to connect to smtp server")
+	assert.ErrorContains(t, err, strconv.Itoa(port))
+}
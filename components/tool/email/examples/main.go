@@ -0,0 +1,59 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino-ext/components/tool/email"
+)
+
+func main() {
+	ctx := context.Background()
+
+	t, err := email.NewTool(ctx, &email.Config{
+		Provider:          email.ProviderSendGrid,
+		SendGrid:          &email.SendGridConfig{APIKey: os.Getenv("SENDGRID_API_KEY")},
+		From:              os.Getenv("EMAIL_FROM"),
+		AllowedRecipients: []string{"@example.com"},
+		Templates: map[string]*email.Template{
+			"incident": {
+				Description: "notify an incident, data: service, severity, summary",
+				Subject:     "[{{.severity}}] Incident on {{.service}}",
+				Text:        "An incident was detected on {{.service}}.\n\n{{.summary}}",
+			},
+		},
+		// render the emails without sending them
+		DryRun: os.Getenv("EMAIL_SEND") != "true",
+	})
+	if err != nil {
+		log.Fatalf("NewTool failed, err=%v", err)
+	}
+
+	out, err := t.InvokableRun(ctx, `{
+		"to": ["oncall@example.com"],
+		"template": "incident",
+		"data": {"service": "checkout", "severity": "P1", "summary": "The error rate is above 5%."},
+		"attachments": [{"filename": "errors.csv", "content": "time,errors\n10:00,120\n10:05,340\n"}]
+	}`)
+	if err != nil {
+		log.Fatalf("send_email failed, err=%v", err)
+	}
+	fmt.Println(out)
+}
@@ -0,0 +1,178 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package email
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"sort"
+	"strings"
+	"time"
+)
+
+// base64LineLength is the maximum length of the base64 lines, as required by RFC 2045.
+const base64LineLength = 76
+
+// mimePart is a part of the message, written after its header.
+type mimePart struct {
+	header textproto.MIMEHeader
+	write  func(w io.Writer) error
+}
+
+// bytes formats the message as a MIME message for SMTP, without the bcc recipients.
+func (m *message) bytes() ([]byte, error) {
+	var body mimePart
+	switch {
+	case m.text != "" && m.html != "":
+		body = multipartPart("alternative", textPart("text/plain", m.text), textPart("text/html", m.html))
+	case m.html != "":
+		body = textPart("text/html", m.html)
+	default:
+		body = textPart("text/plain", m.text)
+	}
+	if len(m.attachments) > 0 {
+		parts := []mimePart{body}
+		for _, a := range m.attachments {
+			parts = append(parts, attachmentPart(a))
+		}
+		body = multipartPart("mixed", parts...)
+	}
+
+	var buf bytes.Buffer
+	writeHeader(&buf, "From", m.from.String())
+	writeHeader(&buf, "To", joinAddresses(m.to))
+	if len(m.cc) > 0 {
+		writeHeader(&buf, "Cc", joinAddresses(m.cc))
+	}
+	if m.replyTo != nil {
+		writeHeader(&buf, "Reply-To", m.replyTo.String())
+	}
+	writeHeader(&buf, "Subject", mime.QEncoding.Encode("utf-8", m.subject))
+	writeHeader(&buf, "Date", time.Now().Format(time.RFC1123Z))
+	writeHeader(&buf, "Message-ID", m.messageID)
+	writeHeader(&buf, "MIME-Version", "1.0")
+	writeMIMEHeader(&buf, body.header)
+	buf.WriteString("\r\n")
+	if err := body.write(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeHeader(buf *bytes.Buffer, key, value string) {
+	buf.WriteString(key)
+	buf.WriteString(": ")
+	buf.WriteString(value)
+	buf.WriteString("\r\n")
+}
+
+func writeMIMEHeader(buf *bytes.Buffer, header textproto.MIMEHeader) {
+	keys := make([]string, 0, len(header))
+	for k := range header {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, v := range header[k] {
+			writeHeader(buf, k, v)
+		}
+	}
+}
+
+func joinAddresses(list []*mail.Address) string {
+	parts := make([]string, 0, len(list))
+	for _, addr := range list {
+		parts = append(parts, addr.String())
+	}
+	return strings.Join(parts, ", ")
+}
+
+func textPart(contentType, content string) mimePart {
+	return mimePart{
+		header: textproto.MIMEHeader{
+			"Content-Type":              {contentType + "; charset=utf-8"},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		},
+		write: func(w io.Writer) error {
+			qp := quotedprintable.NewWriter(w)
+			if _, err := qp.Write([]byte(content)); err != nil {
+				return err
+			}
+			return qp.Close()
+		},
+	}
+}
+
+func attachmentPart(a *attachment) mimePart {
+	return mimePart{
+		header: textproto.MIMEHeader{
+			"Content-Type":              {mime.FormatMediaType(a.contentType, map[string]string{"name": a.filename})},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": a.filename})},
+			"Content-Transfer-Encoding": {"base64"},
+		},
+		write: func(w io.Writer) error {
+			encoded := base64.StdEncoding.EncodeToString(a.data)
+			for len(encoded) > 0 {
+				n := min(len(encoded), base64LineLength)
+				if _, err := io.WriteString(w, encoded[:n]+"\r\n"); err != nil {
+					return err
+				}
+				encoded = encoded[n:]
+			}
+			return nil
+		},
+	}
+}
+
+func multipartPart(subtype string, parts ...mimePart) mimePart {
+	boundary := randomBoundary()
+	return mimePart{
+		header: textproto.MIMEHeader{
+			"Content-Type": {mime.FormatMediaType("multipart/"+subtype, map[string]string{"boundary": boundary})},
+		},
+		write: func(w io.Writer) error {
+			mw := multipart.NewWriter(w)
+			if err := mw.SetBoundary(boundary); err != nil {
+				return err
+			}
+			for _, p := range parts {
+				pw, err := mw.CreatePart(p.header)
+				if err != nil {
+					return err
+				}
+				if err = p.write(pw); err != nil {
+					return err
+				}
+			}
+			return mw.Close()
+		},
+	}
+}
+
+func randomBoundary() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return fmt.Sprintf("eino-%s", hex.EncodeToString(b))
+}
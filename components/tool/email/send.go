@@ -0,0 +1,362 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SendRequest is the request of the email tool.
+type SendRequest struct {
+	To       []string       `json:"to" jsonschema:"required,description=The addresses of the recipients"`
+	Cc       []string       `json:"cc,omitempty" jsonschema:"description=The addresses of the carbon copy recipients"`
+	Bcc      []string       `json:"bcc,omitempty" jsonschema:"description=The addresses of the blind carbon copy recipients"`
+	Subject  string         `json:"subject,omitempty" jsonschema:"description=The subject of the email. Required unless the template has a subject"`
+	Body     string         `json:"body,omitempty" jsonschema:"description=The plain text body of the email. Required unless html or template is set"`
+	HTML     string         `json:"html,omitempty" jsonschema:"description=The HTML body of the email"`
+	Template string         `json:"template,omitempty" jsonschema:"description=The name of the template rendering the body instead of body and html"`
+	Data     map[string]any `json:"data,omitempty" jsonschema:"description=The data filling the template"`
+
+	Attachments []*Attachment `json:"attachments,omitempty" jsonschema:"description=The files attached to the email"`
+}
+
+// Attachment is a file attached to the email, with either the text content or the path of a file.
+type Attachment struct {
+	Filename string `json:"filename" jsonschema:"required,description=The file name of the attachment with its extension e.g. report.csv"`
+	Content  string `json:"content,omitempty" jsonschema:"description=The text content of the attachment"`
+	Path     string `json:"path,omitempty" jsonschema:"description=The path of the file to attach in the attachment directory"`
+}
+
+// SendResponse is the email sent, or rendered in dry-run mode.
+type SendResponse struct {
+	// Status is "sent", or "dry_run" when the email is not sent.
+	Status    string `json:"status"`
+	MessageID string `json:"message_id,omitempty"`
+
+	To          []string `json:"to"`
+	Cc          []string `json:"cc,omitempty"`
+	Bcc         []string `json:"bcc,omitempty"`
+	Subject     string   `json:"subject"`
+	Attachments []string `json:"attachments,omitempty"`
+	// Text and HTML are the rendered bodies, only returned in dry-run mode for the review.
+	Text string `json:"text,omitempty"`
+	HTML string `json:"html,omitempty"`
+}
+
+const (
+	StatusSent   = "sent"
+	StatusDryRun = "dry_run"
+)
+
+// backend sends a rendered message, and returns its message id.
+type backend interface {
+	send(ctx context.Context, msg *message) (string, error)
+}
+
+type sender struct {
+	conf    *Config
+	from    *mail.Address
+	replyTo *mail.Address
+	backend backend
+}
+
+func newSender(conf *Config) (*sender, error) {
+	if err := conf.validate(); err != nil {
+		return nil, err
+	}
+	s := &sender{conf: conf}
+	s.from, _ = mail.ParseAddress(conf.From)
+	if conf.ReplyTo != "" {
+		s.replyTo, _ = mail.ParseAddress(conf.ReplyTo)
+	}
+	switch conf.Provider {
+	case ProviderSMTP:
+		s.backend = &smtpBackend{conf: conf.SMTP, timeout: conf.Timeout}
+	case ProviderSendGrid:
+		s.backend = &sendGridBackend{conf: conf.SendGrid}
+	}
+	return s, nil
+}
+
+type message struct {
+	messageID   string
+	from        *mail.Address
+	replyTo     *mail.Address
+	to          []*mail.Address
+	cc          []*mail.Address
+	bcc         []*mail.Address
+	subject     string
+	text        string
+	html        string
+	attachments []*attachment
+}
+
+type attachment struct {
+	filename    string
+	contentType string
+	data        []byte
+}
+
+// recipients are all the addresses the email is delivered to.
+func (m *message) recipients() []*mail.Address {
+	result := make([]*mail.Address, 0, len(m.to)+len(m.cc)+len(m.bcc))
+	result = append(result, m.to...)
+	result = append(result, m.cc...)
+	return append(result, m.bcc...)
+}
+
+// Send renders the email and sends it, unless in dry-run mode.
+func (s *sender) Send(ctx context.Context, req *SendRequest) (*SendResponse, error) {
+	if req == nil {
+		return nil, errors.New("request is nil")
+	}
+	msg, err := s.render(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &SendResponse{
+		Status:  StatusDryRun,
+		To:      addressList(msg.to),
+		Cc:      addressList(msg.cc),
+		Bcc:     addressList(msg.bcc),
+		Subject: msg.subject,
+	}
+	for _, a := range msg.attachments {
+		resp.Attachments = append(resp.Attachments, a.filename)
+	}
+	if s.conf.DryRun {
+		resp.Text = msg.text
+		resp.HTML = msg.html
+		return resp, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.conf.Timeout)
+	defer cancel()
+	id, err := s.backend.send(ctx, msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send email: %w", err)
+	}
+	resp.Status = StatusSent
+	resp.MessageID = id
+	return resp, nil
+}
+
+// render validates the request and renders the message.
+func (s *sender) render(req *SendRequest) (*message, error) {
+	msg := &message{from: s.from, replyTo: s.replyTo}
+	var err error
+	if msg.to, err = s.parseRecipients("to", req.To); err != nil {
+		return nil, err
+	}
+	if len(msg.to) == 0 {
+		return nil, errors.New("to is required")
+	}
+	if msg.cc, err = s.parseRecipients("cc", req.Cc); err != nil {
+		return nil, err
+	}
+	if msg.bcc, err = s.parseRecipients("bcc", req.Bcc); err != nil {
+		return nil, err
+	}
+	if n := len(msg.recipients()); n > s.conf.MaxRecipients {
+		return nil, fmt.Errorf("too many recipients: %d, at most %d are allowed", n, s.conf.MaxRecipients)
+	}
+
+	msg.subject, msg.text, msg.html = req.Subject, req.Body, req.HTML
+	if req.Template != "" {
+		if req.Body != "" || req.HTML != "" {
+			return nil, errors.New("set either template, or body and html")
+		}
+		if err = s.renderTemplate(msg, req.Template, req.Data); err != nil {
+			return nil, err
+		}
+	}
+	msg.subject = strings.TrimSpace(msg.subject)
+	if msg.subject == "" {
+		return nil, errors.New("subject is required")
+	}
+	// a line break in a header would inject other headers
+	if strings.ContainsAny(msg.subject, "\r\n") {
+		return nil, errors.New("subject must be a single line")
+	}
+	if strings.TrimSpace(msg.text) == "" && strings.TrimSpace(msg.html) == "" {
+		return nil, errors.New("body or html is required")
+	}
+
+	if msg.attachments, err = s.loadAttachments(req.Attachments); err != nil {
+		return nil, err
+	}
+	if msg.messageID, err = newMessageID(s.from.Address); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+func (s *sender) parseRecipients(field string, list []string) ([]*mail.Address, error) {
+	result := make([]*mail.Address, 0, len(list))
+	for _, r := range list {
+		addr, err := mail.ParseAddress(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s address %q: %w", field, r, err)
+		}
+		if !s.allowed(addr.Address) {
+			return nil, fmt.Errorf("recipient %s is not allowed", addr.Address)
+		}
+		result = append(result, addr)
+	}
+	return result, nil
+}
+
+// allowed checks the address against the allowlist of addresses and domains.
+func (s *sender) allowed(address string) bool {
+	if len(s.conf.AllowedRecipients) == 0 {
+		return true
+	}
+	address = strings.ToLower(address)
+	for _, r := range s.conf.AllowedRecipients {
+		r = strings.ToLower(r)
+		if strings.HasPrefix(r, "@") {
+			if strings.HasSuffix(address, r) {
+				return true
+			}
+		} else if address == r {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *sender) renderTemplate(msg *message, name string, data map[string]any) error {
+	tpl, ok := s.conf.Templates[name]
+	if !ok {
+		return fmt.Errorf("unknown template: %s", name)
+	}
+	var buf bytes.Buffer
+	if msg.subject == "" && tpl.subject != nil {
+		if err := tpl.subject.Execute(&buf, data); err != nil {
+			return fmt.Errorf("failed to render subject of template %s: %w", name, err)
+		}
+		msg.subject = buf.String()
+	}
+	if tpl.text != nil {
+		buf.Reset()
+		if err := tpl.text.Execute(&buf, data); err != nil {
+			return fmt.Errorf("failed to render text of template %s: %w", name, err)
+		}
+		msg.text = buf.String()
+	}
+	if tpl.html != nil {
+		buf.Reset()
+		if err := tpl.html.Execute(&buf, data); err != nil {
+			return fmt.Errorf("failed to render html of template %s: %w", name, err)
+		}
+		msg.html = buf.String()
+	}
+	return nil
+}
+
+func (s *sender) loadAttachments(list []*Attachment) ([]*attachment, error) {
+	var (
+		result []*attachment
+		total  int64
+	)
+	for _, a := range list {
+		if a == nil {
+			continue
+		}
+		filename := filepath.Base(strings.TrimSpace(a.Filename))
+		if filename == "." || filename == string(filepath.Separator) {
+			return nil, errors.New("attachment filename is required")
+		}
+		var data []byte
+		switch {
+		case a.Path != "" && a.Content != "":
+			return nil, fmt.Errorf("attachment %s: set either content or path", filename)
+		case a.Path != "":
+			if s.conf.AttachmentDir == "" {
+				return nil, fmt.Errorf("attachment %s: attaching files by path is not enabled", filename)
+			}
+			// the path is cleaned as an absolute path to stay in the attachment dir
+			path := filepath.Join(s.conf.AttachmentDir, filepath.Clean("/"+a.Path))
+			info, err := os.Stat(path)
+			if err != nil {
+				return nil, fmt.Errorf("attachment %s: file %s not found", filename, a.Path)
+			}
+			if info.IsDir() {
+				return nil, fmt.Errorf("attachment %s: %s is a directory", filename, a.Path)
+			}
+			if total+info.Size() > s.conf.MaxAttachmentSize {
+				return nil, fmt.Errorf("attachments are too large, at most %d bytes are allowed", s.conf.MaxAttachmentSize)
+			}
+			if data, err = os.ReadFile(path); err != nil {
+				return nil, fmt.Errorf("attachment %s: failed to read file: %w", filename, err)
+			}
+		default:
+			data = []byte(a.Content)
+		}
+		total += int64(len(data))
+		if total > s.conf.MaxAttachmentSize {
+			return nil, fmt.Errorf("attachments are too large, at most %d bytes are allowed", s.conf.MaxAttachmentSize)
+		}
+		result = append(result, &attachment{
+			filename:    filename,
+			contentType: contentType(filename),
+			data:        data,
+		})
+	}
+	return result, nil
+}
+
+func contentType(filename string) string {
+	if ct := mime.TypeByExtension(filepath.Ext(filename)); ct != "" {
+		return ct
+	}
+	return "application/octet-stream"
+}
+
+func addressList(list []*mail.Address) []string {
+	if len(list) == 0 {
+		return nil
+	}
+	result := make([]string, 0, len(list))
+	for _, addr := range list {
+		result = append(result, addr.Address)
+	}
+	return result
+}
+
+// newMessageID generates a unique message id in the domain of the sender.
+func newMessageID(from string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate message id: %w", err)
+	}
+	domain := "localhost"
+	if i := strings.LastIndex(from, "@"); i >= 0 {
+		domain = from[i+1:]
+	}
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(b), domain), nil
+}
@@ -0,0 +1,146 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package email
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"strings"
+)
+
+type sendGridBackend struct {
+	conf *SendGridConfig
+}
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type sendGridPersonalization struct {
+	To  []*sendGridAddress `json:"to"`
+	Cc  []*sendGridAddress `json:"cc,omitempty"`
+	Bcc []*sendGridAddress `json:"bcc,omitempty"`
+}
+
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendGridAttachment struct {
+	Content     string `json:"content"`
+	Type        string `json:"type"`
+	Filename    string `json:"filename"`
+	Disposition string `json:"disposition"`
+}
+
+type sendGridRequest struct {
+	Personalizations []*sendGridPersonalization `json:"personalizations"`
+	From             *sendGridAddress           `json:"from"`
+	ReplyTo          *sendGridAddress           `json:"reply_to,omitempty"`
+	Subject          string                     `json:"subject"`
+	Content          []*sendGridContent         `json:"content"`
+	Attachments      []*sendGridAttachment      `json:"attachments,omitempty"`
+}
+
+func (b *sendGridBackend) send(ctx context.Context, msg *message) (string, error) {
+	body := &sendGridRequest{
+		Personalizations: []*sendGridPersonalization{{
+			To:  sendGridAddresses(msg.to),
+			Cc:  sendGridAddresses(msg.cc),
+			Bcc: sendGridAddresses(msg.bcc),
+		}},
+		From:    sendGridAddresses([]*mail.Address{msg.from})[0],
+		Subject: msg.subject,
+	}
+	if msg.replyTo != nil {
+		body.ReplyTo = sendGridAddresses([]*mail.Address{msg.replyTo})[0]
+	}
+	// the plain text must come before the html
+	if msg.text != "" {
+		body.Content = append(body.Content, &sendGridContent{Type: "text/plain", Value: msg.text})
+	}
+	if msg.html != "" {
+		body.Content = append(body.Content, &sendGridContent{Type: "text/html", Value: msg.html})
+	}
+	for _, a := range msg.attachments {
+		body.Attachments = append(body.Attachments, &sendGridAttachment{
+			Content:     base64.StdEncoding.EncodeToString(a.data),
+			Type:        a.contentType,
+			Filename:    a.filename,
+			Disposition: "attachment",
+		})
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.conf.BaseURL+"/v3/mail/send", bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+b.conf.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.conf.HTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		var apiErr struct {
+			Errors []struct {
+				Message string `json:"message"`
+				Field   string `json:"field"`
+			} `json:"errors"`
+		}
+		if json.Unmarshal(respBody, &apiErr) == nil && len(apiErr.Errors) > 0 {
+			msgs := make([]string, 0, len(apiErr.Errors))
+			for _, e := range apiErr.Errors {
+				if e.Field != "" {
+					msgs = append(msgs, e.Field+": "+e.Message)
+				} else {
+					msgs = append(msgs, e.Message)
+				}
+			}
+			return "", fmt.Errorf("sendgrid api error, status %d: %s", resp.StatusCode, strings.Join(msgs, "; "))
+		}
+		return "", fmt.Errorf("sendgrid api error, status %d: %s", resp.StatusCode, string(respBody))
+	}
+	// SendGrid identifies the message with its own id, used to track it in the activity feed
+	return resp.Header.Get("X-Message-Id"), nil
+}
+
+func sendGridAddresses(list []*mail.Address) []*sendGridAddress {
+	if len(list) == 0 {
+		return nil
+	}
+	result := make([]*sendGridAddress, 0, len(list))
+	for _, addr := range list {
+		result = append(result, &sendGridAddress{Email: addr.Address, Name: addr.Name})
+	}
+	return result
+}
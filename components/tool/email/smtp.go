@@ -0,0 +1,98 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package email
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+type smtpBackend struct {
+	conf    *SMTPConfig
+	timeout time.Duration
+}
+
+func (b *smtpBackend) send(ctx context.Context, msg *message) (string, error) {
+	data, err := msg.bytes()
+	if err != nil {
+		return "", fmt.Errorf("failed to format message: %w", err)
+	}
+
+	addr := net.JoinHostPort(b.conf.Host, strconv.Itoa(b.conf.Port))
+	dialer := &net.Dialer{Timeout: b.timeout}
+	var conn net.Conn
+	if b.conf.ImplicitTLS {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: b.conf.Host}}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to smtp server: %w", err)
+	}
+	// the smtp client has no context, the deadline of the context bounds the whole session
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	c, err := smtp.NewClient(conn, b.conf.Host)
+	if err != nil {
+		_ = conn.Close()
+		return "", fmt.Errorf("failed to create smtp client: %w", err)
+	}
+	defer c.Close()
+
+	if err = c.Hello(b.conf.LocalName); err != nil {
+		return "", fmt.Errorf("smtp hello failed: %w", err)
+	}
+	if !b.conf.ImplicitTLS && !b.conf.DisableStartTLS {
+		if ok, _ := c.Extension("STARTTLS"); ok {
+			if err = c.StartTLS(&tls.Config{ServerName: b.conf.Host}); err != nil {
+				return "", fmt.Errorf("smtp starttls failed: %w", err)
+			}
+		}
+	}
+	if b.conf.Username != "" {
+		if err = c.Auth(smtp.PlainAuth("", b.conf.Username, b.conf.Password, b.conf.Host)); err != nil {
+			return "", fmt.Errorf("smtp auth failed: %w", err)
+		}
+	}
+
+	if err = c.Mail(msg.from.Address); err != nil {
+		return "", fmt.Errorf("smtp mail from failed: %w", err)
+	}
+	for _, rcpt := range msg.recipients() {
+		if err = c.Rcpt(rcpt.Address); err != nil {
+			return "", fmt.Errorf("smtp rcpt to %s failed: %w", rcpt.Address, err)
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return "", fmt.Errorf("smtp data failed: %w", err)
+	}
+	if _, err = w.Write(data); err != nil {
+		return "", fmt.Errorf("failed to write message: %w", err)
+	}
+	if err = w.Close(); err != nil {
+		return "", fmt.Errorf("smtp data failed: %w", err)
+	}
+	_ = c.Quit()
+	return msg.messageID, nil
+}
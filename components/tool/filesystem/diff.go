@@ -0,0 +1,157 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package filesystem
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	diffContext = 3
+	// maxDiffCells bounds the memory of the longest common subsequence of the changed lines,
+	// larger changes are shown as the removal of all the old lines and the addition of all the new ones.
+	maxDiffCells = 4 << 20
+)
+
+type diffOp struct {
+	kind byte // ' ', '-' or '+'
+	line string
+}
+
+// unifiedDiff returns the unified diff of two versions of a file.
+func unifiedDiff(name, old, new string) string {
+	a, b := splitLines(old), splitLines(new)
+	ops := diffLines(a, b)
+
+	var sb strings.Builder
+	sb.WriteString("--- a/" + name + "\n+++ b/" + name + "\n")
+
+	// group the changes with their context lines into hunks
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+		start := max(i-diffContext, 0)
+		end := i
+		for j := i; j < len(ops); j++ {
+			if ops[j].kind != ' ' {
+				end = j + 1
+			} else if j-end >= 2*diffContext {
+				break
+			}
+		}
+		end = min(end+diffContext, len(ops))
+
+		aStart, bStart := 1, 1
+		for _, op := range ops[:start] {
+			if op.kind != '+' {
+				aStart++
+			}
+			if op.kind != '-' {
+				bStart++
+			}
+		}
+		aLen, bLen := 0, 0
+		for _, op := range ops[start:end] {
+			if op.kind != '+' {
+				aLen++
+			}
+			if op.kind != '-' {
+				bLen++
+			}
+		}
+		sb.WriteString(fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", aStart, aLen, bStart, bLen))
+		for _, op := range ops[start:end] {
+			sb.WriteByte(op.kind)
+			sb.WriteString(op.line + "\n")
+		}
+		i = end
+	}
+	return sb.String()
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffLines returns the operations turning a into b, from the longest common subsequence of their lines
+// once the common prefix and suffix are trimmed.
+func diffLines(a, b []string) []diffOp {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]diffOp, 0, len(a)+len(b))
+	for _, l := range a[:prefix] {
+		ops = append(ops, diffOp{' ', l})
+	}
+
+	ma, mb := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	if (len(ma)+1)*(len(mb)+1) > maxDiffCells {
+		for _, l := range ma {
+			ops = append(ops, diffOp{'-', l})
+		}
+		for _, l := range mb {
+			ops = append(ops, diffOp{'+', l})
+		}
+	} else {
+		// lcs[i][j] is the length of the longest common subsequence of ma[i:] and mb[j:]
+		lcs := make([][]int, len(ma)+1)
+		for i := range lcs {
+			lcs[i] = make([]int, len(mb)+1)
+		}
+		for i := len(ma) - 1; i >= 0; i-- {
+			for j := len(mb) - 1; j >= 0; j-- {
+				if ma[i] == mb[j] {
+					lcs[i][j] = lcs[i+1][j+1] + 1
+				} else {
+					lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+				}
+			}
+		}
+		i, j := 0, 0
+		for i < len(ma) || j < len(mb) {
+			switch {
+			case i < len(ma) && j < len(mb) && ma[i] == mb[j]:
+				ops = append(ops, diffOp{' ', ma[i]})
+				i++
+				j++
+			case j < len(mb) && (i == len(ma) || lcs[i][j+1] > lcs[i+1][j]):
+				ops = append(ops, diffOp{'+', mb[j]})
+				j++
+			default:
+				ops = append(ops, diffOp{'-', ma[i]})
+				i++
+			}
+		}
+	}
+
+	for _, l := range a[len(a)-suffix:] {
+		ops = append(ops, diffOp{' ', l})
+	}
+	return ops
+}
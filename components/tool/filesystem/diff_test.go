@@ -0,0 +1,65 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package filesystem
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	var lines []string
+	for i := 1; i <= 20; i++ {
+		lines = append(lines, fmt.Sprintf("line %d", i))
+	}
+	old := strings.Join(lines, "\n") + "\n"
+	lines[1] = "line two"
+	lines[17] = "line eighteen"
+	lines = append(lines[:10], lines[11:]...)
+	new := strings.Join(lines, "\n") + "\n"
+
+	assert.Equal(t, `--- a/f.txt
++++ b/f.txt
+@@ -1,5 +1,5 @@
+ line 1
+-line 2
++line two
+ line 3
+ line 4
+ line 5
+@@ -8,13 +8,12 @@
+ line 8
+ line 9
+ line 10
+-line 11
+ line 12
+ line 13
+ line 14
+ line 15
+ line 16
+ line 17
+-line 18
++line eighteen
+ line 19
+ line 20
+`, unifiedDiff("f.txt", old, new))
+
+	assert.Equal(t, "--- a/f.txt\n+++ b/f.txt\n@@ -1,0 +1,1 @@\n+a\n", unifiedDiff("f.txt", "", "a\n"))
+}
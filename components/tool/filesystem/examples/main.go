@@ -0,0 +1,63 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/cloudwego/eino/components/tool"
+
+	"github.com/cloudwego/eino-ext/components/tool/filesystem"
+)
+
+func main() {
+	ctx := context.Background()
+
+	tools, err := filesystem.NewToolKit(ctx, &filesystem.Config{
+		RootDir:    "./workspace",
+		IgnoreDirs: []string{".git", "node_modules"},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	byName := make(map[string]tool.InvokableTool, len(tools))
+	for _, t := range tools {
+		info, err := t.Info(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		byName[info.Name] = t.(tool.InvokableTool)
+	}
+
+	// the calls a coding agent would make
+	calls := [][2]string{
+		{"write_file", `{"path":"hello.py","content":"print('hello')\n"}`},
+		{"edit_file", `{"path":"hello.py","edits":[{"old_text":"hello","new_text":"hello world"}]}`},
+		{"glob_search", `{"pattern":"**/*.py"}`},
+		{"grep_search", `{"pattern":"print\\(","glob":"**/*.py"}`},
+		{"read_file", `{"path":"hello.py"}`},
+	}
+	for _, c := range calls {
+		out, err := byName[c[0]].InvokableRun(ctx, c[1])
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("%s:\n%s", c[0], out)
+	}
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package filesystem provides tools reading, writing, editing, listing and searching the files of a workspace directory,
+// for coding agents. The paths leaving the workspace, including through symbolic links, are rejected.
+package filesystem
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+)
+
+// Config is the configuration for the filesystem tools.
+type Config struct {
+	// RootDir is the workspace directory, the tools can only access the files under it.
+	// Required.
+	RootDir string
+	// ReadOnly only creates the tools reading the workspace, without write_file and edit_file.
+	// Optional. Default false.
+	ReadOnly bool
+	// MaxFileSize is the maximum size in bytes of the files read, edited or searched.
+	// Optional. Default 1MB.
+	MaxFileSize int64
+	// MaxResults is the maximum number of entries returned by list_directory, glob_search and grep_search.
+	// Optional. Default 200.
+	MaxResults int
+	// IgnoreDirs are names of directories skipped when listing and searching, e.g. "node_modules".
+	// They can still be accessed with explicit paths.
+	// Optional. Default ".git".
+	IgnoreDirs []string
+}
+
+// NewToolKit creates the filesystem tools: read_file, list_directory, glob_search and grep_search,
+// plus write_file and edit_file unless ReadOnly is set.
+func NewToolKit(ctx context.Context, conf *Config) ([]tool.BaseTool, error) {
+	fs, err := newFileSystem(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	tools := make([]tool.BaseTool, 0, 6)
+	add := func(t tool.InvokableTool, err error) error {
+		if err != nil {
+			return fmt.Errorf("failed to infer tool: %w", err)
+		}
+		tools = append(tools, t)
+		return nil
+	}
+
+	if err = add(utils.InferTool("read_file", readFileDesc, fs.ReadFile)); err != nil {
+		return nil, err
+	}
+	if err = add(utils.InferTool("list_directory", listDirectoryDesc, fs.ListDirectory)); err != nil {
+		return nil, err
+	}
+	if err = add(utils.InferTool("glob_search", globSearchDesc, fs.GlobSearch)); err != nil {
+		return nil, err
+	}
+	if err = add(utils.InferTool("grep_search", grepSearchDesc, fs.GrepSearch)); err != nil {
+		return nil, err
+	}
+	if conf.ReadOnly {
+		return tools, nil
+	}
+	if err = add(utils.InferTool("write_file", writeFileDesc, fs.WriteFile)); err != nil {
+		return nil, err
+	}
+	if err = add(utils.InferTool("edit_file", editFileDesc, fs.EditFile)); err != nil {
+		return nil, err
+	}
+
+	return tools, nil
+}
+
+// validate validates the configuration and sets default values if not provided.
+func (conf *Config) validate() error {
+	if conf == nil {
+		return fmt.Errorf("config is nil")
+	}
+	if conf.RootDir == "" {
+		return fmt.Errorf("root dir is required")
+	}
+	if conf.MaxFileSize <= 0 {
+		conf.MaxFileSize = 1 << 20
+	}
+	if conf.MaxResults <= 0 {
+		conf.MaxResults = 200
+	}
+	if conf.IgnoreDirs == nil {
+		conf.IgnoreDirs = []string{".git"}
+	}
+	return nil
+}
+
+type fileSystem struct {
+	conf   *Config
+	root   string
+	ignore map[string]bool
+}
+
+func newFileSystem(conf *Config) (*fileSystem, error) {
+	if err := conf.validate(); err != nil {
+		return nil, err
+	}
+
+	root, err := filepath.Abs(conf.RootDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve root dir: %w", err)
+	}
+	if root, err = filepath.EvalSymlinks(root); err != nil {
+		return nil, fmt.Errorf("failed to resolve root dir: %w", err)
+	}
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat root dir: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("root dir is not a directory: %s", root)
+	}
+
+	ignore := make(map[string]bool, len(conf.IgnoreDirs))
+	for _, d := range conf.IgnoreDirs {
+		ignore[d] = true
+	}
+
+	return &fileSystem{conf: conf, root: root, ignore: ignore}, nil
+}
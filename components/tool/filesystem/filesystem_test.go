@@ -0,0 +1,245 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package filesystem
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestFileSystem(t *testing.T, conf *Config) *fileSystem {
+	root := t.TempDir()
+	files := map[string]string{
+		"main.go":               "package main\n\nfunc main() {\n\tprintln(\"hello\")\n}\n",
+		"pkg/util/util.go":      "package util\n\n// TODO: remove\nfunc Util() {}\n",
+		"pkg/util/util_test.go": "package util\n",
+		"docs/README.md":        "# Docs\ntodo list\n",
+		".git/config":           "[core]\n",
+		"image.png":             "\x89PNG\x00\x00",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	if conf == nil {
+		conf = &Config{}
+	}
+	conf.RootDir = root
+	f, err := newFileSystem(conf)
+	assert.NoError(t, err)
+	return f
+}
+
+func TestNewToolKit(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewToolKit(ctx, nil)
+	assert.EqualError(t, err, "config is nil")
+	_, err = NewToolKit(ctx, &Config{})
+	assert.EqualError(t, err, "root dir is required")
+	_, err = NewToolKit(ctx, &Config{RootDir: filepath.Join(t.TempDir(), "missing")})
+	assert.ErrorContains(t, err, "failed to resolve root dir")
+
+	names := func(conf *Config) []string {
+		tools, err := NewToolKit(ctx, conf)
+		assert.NoError(t, err)
+		var ret []string
+		for _, tl := range tools {
+			info, err := tl.Info(ctx)
+			assert.NoError(t, err)
+			ret = append(ret, info.Name)
+		}
+		return ret
+	}
+	assert.Equal(t, []string{"read_file", "list_directory", "glob_search", "grep_search", "write_file", "edit_file"}, names(&Config{RootDir: t.TempDir()}))
+	assert.Equal(t, []string{"read_file", "list_directory", "glob_search", "grep_search"}, names(&Config{RootDir: t.TempDir(), ReadOnly: true}))
+}
+
+func TestFileSystem_Resolve(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symbolic links require privileges on windows")
+	}
+	f := newTestFileSystem(t, nil)
+	outside := t.TempDir()
+	assert.NoError(t, os.Symlink(outside, filepath.Join(f.root, "link")))
+	assert.NoError(t, os.Symlink(filepath.Join(outside, "pwned.txt"), filepath.Join(f.root, "dangling")))
+
+	for path, want := range map[string]string{
+		"":                 f.root,
+		"main.go":          filepath.Join(f.root, "main.go"),
+		"/pkg/util":        filepath.Join(f.root, "pkg/util"),
+		f.root + "/docs":   filepath.Join(f.root, "docs"),
+		"pkg/../main.go":   filepath.Join(f.root, "main.go"),
+		"new/dir/file.txt": filepath.Join(f.root, "new/dir/file.txt"),
+	} {
+		got, err := f.resolve(path)
+		assert.NoError(t, err, path)
+		assert.Equal(t, want, got, path)
+	}
+
+	for _, path := range []string{"..", "../x", "pkg/../../x", "link", "link/x/y", "dangling"} {
+		_, err := f.resolve(path)
+		assert.ErrorContains(t, err, "path is outside of the workspace", path)
+	}
+
+	_, err := f.WriteFile(context.Background(), &WriteFileRequest{Path: "dangling", Content: "pwned"})
+	assert.ErrorContains(t, err, "path is outside of the workspace")
+	_, err = os.Stat(filepath.Join(outside, "pwned.txt"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestFileSystem_ReadWrite(t *testing.T) {
+	ctx := context.Background()
+	f := newTestFileSystem(t, &Config{MaxFileSize: 64})
+
+	out, err := f.ReadFile(ctx, &ReadFileRequest{Path: "main.go"})
+	assert.NoError(t, err)
+	assert.Equal(t, "     1\tpackage main\n     2\t\n     3\tfunc main() {\n     4\t\tprintln(\"hello\")\n     5\t}\n", out)
+
+	out, err = f.ReadFile(ctx, &ReadFileRequest{Path: "main.go", Offset: 3, Limit: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "     3\tfunc main() {\n[2 more lines, read them with offset 4]\n", out)
+
+	_, err = f.ReadFile(ctx, &ReadFileRequest{Path: "main.go", Offset: 9})
+	assert.EqualError(t, err, "offset 9 is beyond the end of the file, which has 5 lines")
+	_, err = f.ReadFile(ctx, &ReadFileRequest{Path: "image.png"})
+	assert.EqualError(t, err, "file is binary: image.png")
+	_, err = f.ReadFile(ctx, &ReadFileRequest{Path: "pkg"})
+	assert.EqualError(t, err, "path is a directory: pkg")
+
+	out, err = f.WriteFile(ctx, &WriteFileRequest{Path: "a/b/c.txt", Content: strings.Repeat("x", 65)})
+	assert.NoError(t, err)
+	assert.Equal(t, "wrote 65 bytes to a/b/c.txt", out)
+	_, err = f.ReadFile(ctx, &ReadFileRequest{Path: "a/b/c.txt"})
+	assert.EqualError(t, err, "file is larger than 64 bytes: a/b/c.txt")
+
+	_, err = f.WriteFile(ctx, &WriteFileRequest{Path: "../escape.txt", Content: "x"})
+	assert.ErrorContains(t, err, "path is outside of the workspace")
+}
+
+func TestFileSystem_EditFile(t *testing.T) {
+	ctx := context.Background()
+	f := newTestFileSystem(t, nil)
+
+	out, err := f.EditFile(ctx, &EditFileRequest{Path: "main.go", Edits: []*Edit{
+		{OldText: `println("hello")`, NewText: "fmt.Println(\"hello\")\n\tfmt.Println(\"world\")"},
+		{OldText: "package main\n", NewText: "package main\n\nimport \"fmt\"\n"},
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, `--- a/main.go
++++ b/main.go
+@@ -1,5 +1,8 @@
+ package main
+ 
++import "fmt"
++
+ func main() {
+-	println("hello")
++	fmt.Println("hello")
++	fmt.Println("world")
+ }
+`, out)
+
+	content, err := os.ReadFile(filepath.Join(f.root, "main.go"))
+	assert.NoError(t, err)
+	assert.Equal(t, "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"hello\")\n\tfmt.Println(\"world\")\n}\n", string(content))
+
+	_, err = f.EditFile(ctx, &EditFileRequest{Path: "main.go", Edits: []*Edit{{OldText: "fmt.Println", NewText: "log.Println"}}})
+	assert.EqualError(t, err, "edit 1: old_text found 2 times in the file, add lines around it to make it unique, or set replace_all")
+	_, err = f.EditFile(ctx, &EditFileRequest{Path: "main.go", Edits: []*Edit{
+		{OldText: "fmt.Println", NewText: "log.Println", ReplaceAll: true},
+		{OldText: "missing", NewText: ""},
+	}})
+	assert.EqualError(t, err, "edit 2: old_text not found in the file")
+	// nothing is written when an edit fails
+	unchanged, err := os.ReadFile(filepath.Join(f.root, "main.go"))
+	assert.NoError(t, err)
+	assert.Equal(t, content, unchanged)
+
+	_, err = f.EditFile(ctx, &EditFileRequest{Path: "main.go", Edits: []*Edit{{OldText: "main", NewText: "main", ReplaceAll: true}}})
+	assert.EqualError(t, err, "the edits do not change the file")
+	_, err = f.EditFile(ctx, &EditFileRequest{Path: "missing.go", Edits: []*Edit{{OldText: "a", NewText: "b"}}})
+	assert.ErrorContains(t, err, "failed to stat file")
+}
+
+func TestFileSystem_ListAndSearch(t *testing.T) {
+	ctx := context.Background()
+	f := newTestFileSystem(t, nil)
+
+	out, err := f.ListDirectory(ctx, &ListDirectoryRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, ".git/\ndocs/\nimage.png\nmain.go\npkg/", out)
+	out, err = f.ListDirectory(ctx, &ListDirectoryRequest{Path: "pkg", Depth: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, "util/\nutil/util.go\nutil/util_test.go", out)
+	_, err = f.ListDirectory(ctx, &ListDirectoryRequest{Path: "main.go"})
+	assert.EqualError(t, err, "path is not a directory: main.go")
+
+	out, err = f.GlobSearch(ctx, &GlobSearchRequest{Pattern: "**/*.go"})
+	assert.NoError(t, err)
+	assert.Equal(t, "main.go\npkg/util/util.go\npkg/util/util_test.go", out)
+	out, err = f.GlobSearch(ctx, &GlobSearchRequest{Pattern: "*_test.go", Path: "pkg/util"})
+	assert.NoError(t, err)
+	assert.Equal(t, "pkg/util/util_test.go", out)
+	out, err = f.GlobSearch(ctx, &GlobSearchRequest{Pattern: "**/config"})
+	assert.NoError(t, err)
+	assert.Equal(t, "no files found", out)
+
+	out, err = f.GrepSearch(ctx, &GrepSearchRequest{Pattern: "todo", IgnoreCase: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "docs/README.md:2: todo list\npkg/util/util.go:3: // TODO: remove", out)
+	out, err = f.GrepSearch(ctx, &GrepSearchRequest{Pattern: "^package", Glob: "**/*.go"})
+	assert.NoError(t, err)
+	assert.Equal(t, "main.go:1: package main\npkg/util/util.go:1: package util\npkg/util/util_test.go:1: package util", out)
+	_, err = f.GrepSearch(ctx, &GrepSearchRequest{Pattern: "("})
+	assert.ErrorContains(t, err, "invalid pattern")
+
+	f.conf.MaxResults = 2
+	out, err = f.GlobSearch(ctx, &GlobSearchRequest{Pattern: "**"})
+	assert.NoError(t, err)
+	assert.Equal(t, "docs/README.md\nimage.png\n[only the first 2 files are shown, use a more specific pattern]", out)
+}
+
+func TestGlob(t *testing.T) {
+	for _, c := range []struct {
+		pattern string
+		name    string
+		match   bool
+	}{
+		{"*.go", "main.go", true},
+		{"*.go", "pkg/main.go", false},
+		{"**/*.go", "main.go", true},
+		{"**/*.go", "a/b/c.go", true},
+		{"a/**", "a/b/c", true},
+		{"a/**/c", "a/c", true},
+		{"a/**/c", "a/b/d", false},
+		{"./src/*.[jt]s", "src/app.ts", true},
+	} {
+		g, err := compileGlob(c.pattern)
+		assert.NoError(t, err)
+		assert.Equal(t, c.match, g.match(c.name), c.pattern+" "+c.name)
+	}
+	_, err := compileGlob("[")
+	assert.EqualError(t, err, "invalid pattern: [")
+}
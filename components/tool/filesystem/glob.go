@@ -0,0 +1,67 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package filesystem
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// glob matches slash separated paths against a pattern of path.Match segments, "**" matching any number of segments.
+type glob struct {
+	segments []string
+}
+
+func compileGlob(pattern string) (*glob, error) {
+	pattern = strings.Trim(strings.TrimPrefix(pattern, "./"), "/")
+	if pattern == "" {
+		return nil, fmt.Errorf("pattern is required")
+	}
+	segments := strings.Split(pattern, "/")
+	for _, s := range segments {
+		if _, err := path.Match(s, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern: %s", pattern)
+		}
+	}
+	return &glob{segments: segments}, nil
+}
+
+func (g *glob) match(name string) bool {
+	return matchSegments(g.segments, strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
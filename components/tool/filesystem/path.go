@@ -0,0 +1,59 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package filesystem
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/cloudwego/eino-ext/components/tool/filesystem/internal/safepath"
+)
+
+//go:generate sh ../../../libs/acl/bundle.sh safepath internal/safepath
+
+// resolve resolves a path relative to the root dir, and rejects the paths leaving it, including through symbolic links.
+// The model sees the root dir as "/", so absolute paths are taken as relative to it too,
+// unless they already are under the root dir.
+func (f *fileSystem) resolve(path string) (string, error) {
+	if path == "" {
+		path = "."
+	}
+	path = filepath.FromSlash(path)
+	if !filepath.IsAbs(path) || !safepath.IsUnder(f.root, filepath.Clean(path)) {
+		path = filepath.Join(f.root, path)
+	}
+	path = filepath.Clean(path)
+
+	resolved, err := safepath.Resolve(f.root, path)
+	if errors.Is(err, safepath.ErrOutsideRoot) {
+		return "", fmt.Errorf("path is outside of the workspace: %s", f.rel(path))
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve path: %w", err)
+	}
+	return resolved, nil
+}
+
+// rel returns the slash separated path of a resolved path relative to the root dir, as shown to the model.
+func (f *fileSystem) rel(path string) string {
+	rel, err := filepath.Rel(f.root, path)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
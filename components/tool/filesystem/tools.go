@@ -0,0 +1,403 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package filesystem
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	readFileDesc = `Read a file of the workspace. The lines are prefixed with their number and a tab, which are not part of the file.
+Use offset and limit to read a part of a large file.`
+	listDirectoryDesc = `List the files and directories of a directory of the workspace, directories ending with "/".`
+	globSearchDesc    = `Find the files of the workspace matching a glob pattern, e.g. "**/*.go" or "src/*.ts". "**" matches any number of directories.`
+	grepSearchDesc    = `Search the lines of the files of the workspace matching a regular expression (RE2 syntax), returned as "path:line: text".`
+	writeFileDesc     = `Write a file of the workspace, creating it and its parent directories or overwriting it.
+Prefer edit_file to change an existing file.`
+	editFileDesc = `Edit a file of the workspace by replacing texts, and return the diff of the changes.
+Each old_text must match the file exactly, including white spaces and indentation, and only once unless replace_all is set:
+include enough lines around the change to make it unique. The edits are applied in order, and none is applied if one fails.`
+)
+
+const maxLineLength = 500
+
+// ReadFileRequest is the request of read_file.
+type ReadFileRequest struct {
+	Path   string `json:"path" jsonschema:"description=The path of the file, relative to the workspace"`
+	Offset int    `json:"offset,omitempty" jsonschema:"description=The line number to start reading from, 1 for the first line. Optional"`
+	Limit  int    `json:"limit,omitempty" jsonschema:"description=The maximum number of lines to read. Optional, default all the lines"`
+}
+
+// WriteFileRequest is the request of write_file.
+type WriteFileRequest struct {
+	Path    string `json:"path" jsonschema:"description=The path of the file, relative to the workspace"`
+	Content string `json:"content" jsonschema:"description=The content of the file"`
+}
+
+// Edit replaces a text of a file.
+type Edit struct {
+	OldText    string `json:"old_text" jsonschema:"description=The text to replace, matching the file exactly"`
+	NewText    string `json:"new_text" jsonschema:"description=The replacement text"`
+	ReplaceAll bool   `json:"replace_all,omitempty" jsonschema:"description=Replace all the occurrences of old_text instead of requiring a single one"`
+}
+
+// EditFileRequest is the request of edit_file.
+type EditFileRequest struct {
+	Path  string  `json:"path" jsonschema:"description=The path of the file, relative to the workspace"`
+	Edits []*Edit `json:"edits" jsonschema:"description=The edits, applied in order"`
+}
+
+// ListDirectoryRequest is the request of list_directory.
+type ListDirectoryRequest struct {
+	Path  string `json:"path,omitempty" jsonschema:"description=The path of the directory, relative to the workspace. Optional, default the workspace"`
+	Depth int    `json:"depth,omitempty" jsonschema:"description=The number of levels listed. Optional, default 1"`
+}
+
+// GlobSearchRequest is the request of glob_search.
+type GlobSearchRequest struct {
+	Pattern string `json:"pattern" jsonschema:"description=The glob pattern, relative to path"`
+	Path    string `json:"path,omitempty" jsonschema:"description=The directory to search in, relative to the workspace. Optional, default the workspace"`
+}
+
+// GrepSearchRequest is the request of grep_search.
+type GrepSearchRequest struct {
+	Pattern    string `json:"pattern" jsonschema:"description=The regular expression searched in the lines"`
+	Path       string `json:"path,omitempty" jsonschema:"description=The directory or file to search in, relative to the workspace. Optional, default the workspace"`
+	Glob       string `json:"glob,omitempty" jsonschema:"description=Only search the files matching this glob pattern, e.g. **/*.go. Optional"`
+	IgnoreCase bool   `json:"ignore_case,omitempty" jsonschema:"description=Match case insensitively. Optional"`
+}
+
+// ReadFile returns the lines of a file prefixed with their number.
+func (f *fileSystem) ReadFile(_ context.Context, req *ReadFileRequest) (string, error) {
+	path, err := f.resolve(req.Path)
+	if err != nil {
+		return "", err
+	}
+	content, err := f.readFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	start := max(req.Offset, 1) - 1
+	if start >= len(lines) && len(lines) > 0 {
+		return "", fmt.Errorf("offset %d is beyond the end of the file, which has %d lines", req.Offset, len(lines))
+	}
+	end := len(lines)
+	if req.Limit > 0 {
+		end = min(start+req.Limit, end)
+	}
+
+	var sb strings.Builder
+	for i := start; i < end; i++ {
+		sb.WriteString(fmt.Sprintf("%6d\t%s", i+1, strings.TrimSuffix(lines[i], "\n")))
+		sb.WriteString("\n")
+	}
+	if end < len(lines) {
+		sb.WriteString(fmt.Sprintf("[%d more lines, read them with offset %d]\n", len(lines)-end, end+1))
+	}
+	return sb.String(), nil
+}
+
+// WriteFile writes a file, creating its parent directories.
+func (f *fileSystem) WriteFile(_ context.Context, req *WriteFileRequest) (string, error) {
+	path, err := f.resolve(req.Path)
+	if err != nil {
+		return "", err
+	}
+	if path == f.root {
+		return "", fmt.Errorf("path is a directory: %s", req.Path)
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err = os.WriteFile(path, []byte(req.Content), 0644); err != nil {
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+	return fmt.Sprintf("wrote %d bytes to %s", len(req.Content), f.rel(path)), nil
+}
+
+// EditFile applies the edits to a file, and returns the unified diff of the changes.
+func (f *fileSystem) EditFile(_ context.Context, req *EditFileRequest) (string, error) {
+	if len(req.Edits) == 0 {
+		return "", fmt.Errorf("edits are required")
+	}
+	path, err := f.resolve(req.Path)
+	if err != nil {
+		return "", err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to stat file: %w", err)
+	}
+	old, err := f.readFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	content := old
+	for i, e := range req.Edits {
+		if e.OldText == "" {
+			return "", fmt.Errorf("edit %d: old_text is required", i+1)
+		}
+		n := strings.Count(content, e.OldText)
+		switch {
+		case n == 0:
+			return "", fmt.Errorf("edit %d: old_text not found in the file", i+1)
+		case n > 1 && !e.ReplaceAll:
+			return "", fmt.Errorf("edit %d: old_text found %d times in the file, add lines around it to make it unique, or set replace_all", i+1, n)
+		}
+		content = strings.ReplaceAll(content, e.OldText, e.NewText)
+	}
+	if content == old {
+		return "", fmt.Errorf("the edits do not change the file")
+	}
+
+	if err = os.WriteFile(path, []byte(content), info.Mode().Perm()); err != nil {
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+	return unifiedDiff(f.rel(path), old, content), nil
+}
+
+// ListDirectory lists a directory up to the requested depth.
+func (f *fileSystem) ListDirectory(_ context.Context, req *ListDirectoryRequest) (string, error) {
+	dir, err := f.resolve(req.Path)
+	if err != nil {
+		return "", err
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to stat directory: %w", err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("path is not a directory: %s", req.Path)
+	}
+	depth := max(req.Depth, 1)
+
+	var entries []string
+	truncated := false
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dir {
+			return nil
+		}
+		rel, _ := filepath.Rel(dir, path)
+		level := strings.Count(rel, string(filepath.Separator)) + 1
+		if len(entries) >= f.conf.MaxResults {
+			truncated = true
+			return filepath.SkipAll
+		}
+		if d.IsDir() {
+			entries = append(entries, filepath.ToSlash(rel)+"/")
+			if level >= depth || f.ignore[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		entries = append(entries, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list directory: %w", err)
+	}
+
+	if len(entries) == 0 {
+		return "(empty directory)", nil
+	}
+	out := strings.Join(entries, "\n")
+	if truncated {
+		out += fmt.Sprintf("\n[only the first %d entries are shown]", f.conf.MaxResults)
+	}
+	return out, nil
+}
+
+// GlobSearch returns the files matching the pattern, sorted by path.
+func (f *fileSystem) GlobSearch(_ context.Context, req *GlobSearchRequest) (string, error) {
+	g, err := compileGlob(req.Pattern)
+	if err != nil {
+		return "", err
+	}
+	dir, err := f.resolve(req.Path)
+	if err != nil {
+		return "", err
+	}
+
+	var matches []string
+	truncated := false
+	err = f.walkFiles(dir, func(path string, _ fs.DirEntry) error {
+		rel, _ := filepath.Rel(dir, path)
+		if !g.match(filepath.ToSlash(rel)) {
+			return nil
+		}
+		if len(matches) >= f.conf.MaxResults {
+			truncated = true
+			return filepath.SkipAll
+		}
+		matches = append(matches, f.rel(path))
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to search files: %w", err)
+	}
+
+	if len(matches) == 0 {
+		return "no files found", nil
+	}
+	sort.Strings(matches)
+	out := strings.Join(matches, "\n")
+	if truncated {
+		out += fmt.Sprintf("\n[only the first %d files are shown, use a more specific pattern]", f.conf.MaxResults)
+	}
+	return out, nil
+}
+
+// GrepSearch returns the lines matching the regular expression, as path:line: text.
+func (f *fileSystem) GrepSearch(_ context.Context, req *GrepSearchRequest) (string, error) {
+	if req.Pattern == "" {
+		return "", fmt.Errorf("pattern is required")
+	}
+	pattern := req.Pattern
+	if req.IgnoreCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", fmt.Errorf("invalid pattern: %w", err)
+	}
+	var g *glob
+	if req.Glob != "" {
+		if g, err = compileGlob(req.Glob); err != nil {
+			return "", err
+		}
+	}
+	dir, err := f.resolve(req.Path)
+	if err != nil {
+		return "", err
+	}
+
+	var results []string
+	truncated := false
+	err = f.walkFiles(dir, func(path string, d fs.DirEntry) error {
+		if g != nil {
+			rel, _ := filepath.Rel(dir, path)
+			if !g.match(filepath.ToSlash(rel)) {
+				return nil
+			}
+		}
+		info, err := d.Info()
+		if err != nil || info.Size() > f.conf.MaxFileSize {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil || isBinary(content) {
+			return nil
+		}
+
+		scanner := bufio.NewScanner(bytes.NewReader(content))
+		scanner.Buffer(make([]byte, 0, 64*1024), int(f.conf.MaxFileSize)+1)
+		for n := 1; scanner.Scan(); n++ {
+			line := scanner.Text()
+			if !re.MatchString(line) {
+				continue
+			}
+			if len(results) >= f.conf.MaxResults {
+				truncated = true
+				return filepath.SkipAll
+			}
+			results = append(results, fmt.Sprintf("%s:%d: %s", f.rel(path), n, truncateLine(line)))
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to search files: %w", err)
+	}
+
+	if len(results) == 0 {
+		return "no matches found", nil
+	}
+	out := strings.Join(results, "\n")
+	if truncated {
+		out += fmt.Sprintf("\n[only the first %d matches are shown, use a more specific pattern]", f.conf.MaxResults)
+	}
+	return out, nil
+}
+
+// walkFiles calls fn with the regular files under dir, or dir itself when it is a file, skipping the ignored directories.
+func (f *fileSystem) walkFiles(dir string, fn func(path string, d fs.DirEntry) error) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && f.ignore[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		return fn(path, d)
+	})
+}
+
+func (f *fileSystem) readFile(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to stat file: %w", err)
+	}
+	if info.IsDir() {
+		return "", fmt.Errorf("path is a directory: %s", f.rel(path))
+	}
+	if info.Size() > f.conf.MaxFileSize {
+		return "", fmt.Errorf("file is larger than %d bytes: %s", f.conf.MaxFileSize, f.rel(path))
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+	if isBinary(content) {
+		return "", fmt.Errorf("file is binary: %s", f.rel(path))
+	}
+	return string(content), nil
+}
+
+// isBinary reports whether the content has a NUL byte in its first 8KB, as git does.
+func isBinary(content []byte) bool {
+	return bytes.IndexByte(content[:min(len(content), 8000)], 0) >= 0
+}
+
+func truncateLine(line string) string {
+	if runes := []rune(line); len(runes) > maxLineLength {
+		return string(runes[:maxLineLength]) + "..."
+	}
+	return line
+}
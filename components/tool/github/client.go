@@ -0,0 +1,165 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// maxPerPage is the maximum number of results of a page allowed by the API.
+const maxPerPage = 100
+
+var repoPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+$`)
+
+type client struct {
+	conf *Config
+}
+
+func newClient(conf *Config) (*client, error) {
+	if err := conf.validate(); err != nil {
+		return nil, err
+	}
+	return &client{conf: conf}, nil
+}
+
+// checkRepo checks the repository is well-formed and allowed.
+func (c *client) checkRepo(repo string) error {
+	owner, name, _ := strings.Cut(repo, "/")
+	if !repoPattern.MatchString(repo) || strings.Trim(owner, ".") == "" || strings.Trim(name, ".") == "" {
+		return fmt.Errorf("invalid repo: %q, expected owner/repo", repo)
+	}
+	if !c.allowed(repo) {
+		return fmt.Errorf("repo is not allowed: %s", repo)
+	}
+	return nil
+}
+
+// allowed reports whether the repository is in the allowlist, case-insensitively as GitHub.
+func (c *client) allowed(repo string) bool {
+	if len(c.conf.Repos) == 0 {
+		return true
+	}
+	repo = strings.ToLower(repo)
+	for _, pattern := range c.conf.Repos {
+		if ok, _ := path.Match(strings.ToLower(pattern), repo); ok {
+			return true
+		}
+	}
+	return false
+}
+
+type apiError struct {
+	Message string `json:"message"`
+	Errors  []struct {
+		Message string `json:"message"`
+		Field   string `json:"field"`
+		Code    string `json:"code"`
+	} `json:"errors"`
+}
+
+// do sends the request to the API and decodes the json response into out.
+func (c *client) do(ctx context.Context, method, apiPath string, query url.Values, body, out any) error {
+	return c.doAccept(ctx, method, apiPath, query, body, out, "application/vnd.github+json")
+}
+
+// doAccept is do with another media type, e.g. to get the text matches of the searches.
+func (c *client) doAccept(ctx context.Context, method, apiPath string, query url.Values, body, out any, accept string) error {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	u := c.conf.BaseURL + apiPath
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", accept)
+	req.Header.Set("Authorization", "Bearer "+c.conf.Token)
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	req.Header.Set("User-Agent", "eino-ext-github-tool")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.conf.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("github api error, status code: %d, message: %s", resp.StatusCode, errorMessage(respBody))
+	}
+	if out == nil {
+		return nil
+	}
+	if err = json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return nil
+}
+
+func errorMessage(body []byte) string {
+	var e apiError
+	if err := json.Unmarshal(body, &e); err != nil || e.Message == "" {
+		return strings.TrimSpace(string(body))
+	}
+	msg := e.Message
+	for _, fe := range e.Errors {
+		switch {
+		case fe.Message != "":
+			msg += "; " + fe.Message
+		case fe.Field != "":
+			msg += fmt.Sprintf("; %s %s", fe.Field, fe.Code)
+		}
+	}
+	return msg
+}
+
+// repoPath returns the api path of the repository.
+func repoPath(repo string) string {
+	owner, name, _ := strings.Cut(repo, "/")
+	return "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(name)
+}
+
+func truncate(s string, maxLen int) (string, bool) {
+	r := []rune(s)
+	if len(r) <= maxLen {
+		return s, false
+	}
+	return string(r[:maxLen]) + "\n[content truncated]", true
+}
@@ -0,0 +1,252 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// maxFilePages is the maximum number of pages of the changed files of a pull request, 3000 files being listed at most by the API.
+const maxFilePages = 30
+
+// GetFileRequest is the request of the file tool.
+type GetFileRequest struct {
+	Repo string `json:"repo" jsonschema:"required,description=The repository, as owner/repo"`
+	Path string `json:"path" jsonschema:"required,description=The path of the file or the directory in the repository, empty or / for the root directory"`
+	Ref  string `json:"ref,omitempty" jsonschema:"description=The branch, tag or commit sha. Leave empty for the default branch"`
+}
+
+// GetFileResponse is the content of a file, or the entries of a directory.
+type GetFileResponse struct {
+	Repo      string       `json:"repo"`
+	Path      string       `json:"path"`
+	Type      string       `json:"type"`
+	Size      int64        `json:"size,omitempty"`
+	URL       string       `json:"url,omitempty"`
+	Content   string       `json:"content,omitempty"`
+	Truncated bool         `json:"truncated,omitempty"`
+	Binary    bool         `json:"binary,omitempty"`
+	Entries   []*FileEntry `json:"entries,omitempty"`
+}
+
+// FileEntry is an entry of a directory.
+type FileEntry struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	// Type is "file", "dir", "symlink" or "submodule".
+	Type string `json:"type"`
+	Size int64  `json:"size,omitempty"`
+}
+
+type apiContent struct {
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Size     int64  `json:"size"`
+	HTMLURL  string `json:"html_url"`
+	Encoding string `json:"encoding"`
+	Content  string `json:"content"`
+}
+
+// GetFile reads a file of the repository, or lists the entries of a directory.
+func (c *client) GetFile(ctx context.Context, req *GetFileRequest) (*GetFileResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request is nil")
+	}
+	if err := c.checkRepo(req.Repo); err != nil {
+		return nil, err
+	}
+	p := strings.Trim(req.Path, "/")
+	segments := make([]string, 0, strings.Count(p, "/")+1)
+	if p != "" {
+		for _, s := range strings.Split(p, "/") {
+			if s == "" || s == "." || s == ".." {
+				return nil, fmt.Errorf("invalid path: %s", req.Path)
+			}
+			segments = append(segments, url.PathEscape(s))
+		}
+	}
+	var query url.Values
+	if req.Ref != "" {
+		query = url.Values{"ref": {req.Ref}}
+	}
+
+	var raw json.RawMessage
+	if err := c.do(ctx, http.MethodGet, repoPath(req.Repo)+"/contents/"+strings.Join(segments, "/"), query, nil, &raw); err != nil {
+		return nil, err
+	}
+
+	// a directory is an array of entries
+	if bytes.HasPrefix(bytes.TrimSpace(raw), []byte("[")) {
+		var entries []*apiContent
+		if err := json.Unmarshal(raw, &entries); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		resp := &GetFileResponse{Repo: req.Repo, Path: p, Type: "dir", Entries: make([]*FileEntry, 0, len(entries))}
+		for _, e := range entries {
+			resp.Entries = append(resp.Entries, &FileEntry{Name: e.Name, Path: e.Path, Type: e.Type, Size: e.Size})
+		}
+		return resp, nil
+	}
+
+	var content apiContent
+	if err := json.Unmarshal(raw, &content); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	resp := &GetFileResponse{Repo: req.Repo, Path: content.Path, Type: content.Type, Size: content.Size, URL: content.HTMLURL}
+	if content.Type != "file" {
+		return resp, nil
+	}
+	if content.Encoding != "base64" {
+		// the api does not return the content of the files larger than 1 MB
+		return nil, fmt.Errorf("file is too large to be read: %d bytes", content.Size)
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(content.Content, "\n", ""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode file content: %w", err)
+	}
+	if bytes.IndexByte(data, 0) >= 0 {
+		resp.Binary = true
+		return resp, nil
+	}
+	resp.Content, resp.Truncated = truncate(string(data), c.conf.MaxContentLength)
+	return resp, nil
+}
+
+// GetPullRequestRequest is the request of the pull request tool.
+type GetPullRequestRequest struct {
+	Repo   string `json:"repo" jsonschema:"required,description=The repository, as owner/repo"`
+	Number int    `json:"number" jsonschema:"required,description=The number of the pull request"`
+}
+
+// PullRequest is a pull request with the diff of its changed files.
+type PullRequest struct {
+	Repo         string      `json:"repo"`
+	Number       int         `json:"number"`
+	Title        string      `json:"title"`
+	State        string      `json:"state"`
+	Draft        bool        `json:"draft,omitempty"`
+	Merged       bool        `json:"merged,omitempty"`
+	Author       string      `json:"author"`
+	Base         string      `json:"base"`
+	Head         string      `json:"head"`
+	URL          string      `json:"url"`
+	Body         string      `json:"body,omitempty"`
+	Additions    int         `json:"additions"`
+	Deletions    int         `json:"deletions"`
+	ChangedFiles int         `json:"changed_files"`
+	Files        []*FileDiff `json:"files"`
+	// Truncated is true when the patches of some files were left out, to keep within MaxContentLength.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// FileDiff is a changed file of a pull request.
+type FileDiff struct {
+	Filename string `json:"filename"`
+	// Status is "added", "removed", "modified", "renamed", "copied", "changed" or "unchanged".
+	Status           string `json:"status"`
+	PreviousFilename string `json:"previous_filename,omitempty"`
+	Additions        int    `json:"additions"`
+	Deletions        int    `json:"deletions"`
+	// Patch is the unified diff of the file, missing for the binary and the very large files.
+	Patch string `json:"patch,omitempty"`
+}
+
+// GetPullRequest gets the pull request with the diff of its changed files.
+func (c *client) GetPullRequest(ctx context.Context, req *GetPullRequestRequest) (*PullRequest, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request is nil")
+	}
+	if err := c.checkRepo(req.Repo); err != nil {
+		return nil, err
+	}
+	if req.Number <= 0 {
+		return nil, fmt.Errorf("number is required")
+	}
+	prPath := repoPath(req.Repo) + "/pulls/" + strconv.Itoa(req.Number)
+
+	var pr struct {
+		Number  int    `json:"number"`
+		Title   string `json:"title"`
+		State   string `json:"state"`
+		Draft   bool   `json:"draft"`
+		Merged  bool   `json:"merged"`
+		HTMLURL string `json:"html_url"`
+		Body    string `json:"body"`
+		User    struct {
+			Login string `json:"login"`
+		} `json:"user"`
+		Base struct {
+			Ref string `json:"ref"`
+		} `json:"base"`
+		Head struct {
+			Label string `json:"label"`
+		} `json:"head"`
+		Additions    int `json:"additions"`
+		Deletions    int `json:"deletions"`
+		ChangedFiles int `json:"changed_files"`
+	}
+	if err := c.do(ctx, http.MethodGet, prPath, nil, nil, &pr); err != nil {
+		return nil, err
+	}
+
+	result := &PullRequest{
+		Repo:         req.Repo,
+		Number:       pr.Number,
+		Title:        pr.Title,
+		State:        pr.State,
+		Draft:        pr.Draft,
+		Merged:       pr.Merged,
+		Author:       pr.User.Login,
+		Base:         pr.Base.Ref,
+		Head:         pr.Head.Label,
+		URL:          pr.HTMLURL,
+		Additions:    pr.Additions,
+		Deletions:    pr.Deletions,
+		ChangedFiles: pr.ChangedFiles,
+	}
+	result.Body, _ = truncate(strings.TrimSpace(pr.Body), maxIssueBodyLength)
+
+	budget := c.conf.MaxContentLength
+	for page := 1; page <= maxFilePages; page++ {
+		var files []*FileDiff
+		query := url.Values{"per_page": {strconv.Itoa(maxPerPage)}, "page": {strconv.Itoa(page)}}
+		if err := c.do(ctx, http.MethodGet, prPath+"/files", query, nil, &files); err != nil {
+			return nil, err
+		}
+		for _, f := range files {
+			if n := len([]rune(f.Patch)); n > budget {
+				f.Patch = ""
+				result.Truncated = true
+			} else {
+				budget -= n
+			}
+			result.Files = append(result.Files, f)
+		}
+		if len(files) < maxPerPage {
+			break
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/components/tool"
+
+	"github.com/cloudwego/eino-ext/components/tool/github"
+)
+
+func main() {
+	ctx := context.Background()
+
+	tools, err := github.NewToolKit(ctx, &github.Config{
+		Token: os.Getenv("GITHUB_TOKEN"),
+		Repos: []string{"cloudwego/eino", "cloudwego/eino-ext"},
+	})
+	if err != nil {
+		log.Fatalf("NewToolKit failed, err=%v", err)
+	}
+
+	calls := map[string]string{
+		"github_search_code":      `{"query":"InferTool language:go","max_results":3}`,
+		"github_search_issues":    `{"query":"tool call","type":"issue","state":"open","max_results":3}`,
+		"github_get_file":         `{"repo":"cloudwego/eino","path":"README.md"}`,
+		"github_get_pull_request": `{"repo":"cloudwego/eino-ext","number":1}`,
+	}
+	for _, t := range tools {
+		info, err := t.Info(ctx)
+		if err != nil {
+			log.Fatalf("Info failed, err=%v", err)
+		}
+		out, err := t.(tool.InvokableTool).InvokableRun(ctx, calls[info.Name])
+		if err != nil {
+			log.Printf("%s failed, err=%v", info.Name, err)
+			continue
+		}
+		fmt.Printf("%s:\n%s\n\n", info.Name, out)
+	}
+}
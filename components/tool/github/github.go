@@ -0,0 +1,140 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package github provides tools for GitHub: searching code and issues, reading files and pull request diffs,
+// and creating issues and comments, restricted to an allowlist of repositories.
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+)
+
+// Config is the configuration for the GitHub tools.
+type Config struct {
+	// Token is the GitHub token, preferably a fine-grained personal access token limited to the repositories
+	// and the permissions the agent needs, e.g. read-only "Contents", "Issues" and "Pull requests" for a review agent.
+	// Required.
+	Token string
+	// BaseURL is the base url of the GitHub REST API, e.g. "https://github.example.com/api/v3" for GitHub Enterprise Server.
+	// Optional. Default "https://api.github.com".
+	BaseURL string
+	// HTTPClient is the http client sending the requests.
+	// Optional. Default a client with Timeout.
+	HTTPClient *http.Client
+	// Timeout is the maximum duration of each request.
+	// Optional. Default 30s.
+	Timeout time.Duration
+
+	// Repos is the allowlist of the repositories the tools can access, as "owner/repo", or "owner/*" for all
+	// the repositories of an owner. The searches are restricted to these repositories.
+	// Optional. Default all the repositories the token can access.
+	Repos []string
+	// EnableWrite adds the tools creating issues and comments.
+	// Optional. Default false, the tools are read-only.
+	EnableWrite bool
+
+	// MaxResults is the default number of results of a search, between 1 and 100.
+	// Optional. Default 10.
+	MaxResults int
+	// MaxContentLength is the maximum number of characters of a file, or of the patches of a pull request,
+	// longer content is truncated.
+	// Optional. Default 20000.
+	MaxContentLength int
+}
+
+// NewToolKit creates the GitHub tools: github_search_code, github_search_issues, github_get_file
+// and github_get_pull_request, plus github_create_issue and github_create_comment when EnableWrite is set.
+func NewToolKit(ctx context.Context, conf *Config) ([]tool.BaseTool, error) {
+	c, err := newClient(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		tools    []tool.BaseTool
+		inferErr error
+	)
+	add := func(t tool.InvokableTool, err error) {
+		if err != nil {
+			inferErr = errors.Join(inferErr, err)
+			return
+		}
+		tools = append(tools, t)
+	}
+
+	add(utils.InferTool("github_search_code", "Search code in GitHub repositories. "+
+		"The query uses the GitHub code search syntax, e.g. \"NewToolKit language:go path:components/tool\".", c.SearchCode))
+	add(utils.InferTool("github_search_issues", "Search issues and pull requests in GitHub repositories. "+
+		"The query uses the GitHub issue search syntax, e.g. \"timeout label:bug\".", c.SearchIssues))
+	add(utils.InferTool("github_get_file", "Read a file of a GitHub repository, or list the entries of a directory.", c.GetFile))
+	add(utils.InferTool("github_get_pull_request", "Get a pull request of a GitHub repository, "+
+		"with the diff of its changed files.", c.GetPullRequest))
+	if conf.EnableWrite {
+		add(utils.InferTool("github_create_issue", "Create an issue in a GitHub repository.", c.CreateIssue))
+		add(utils.InferTool("github_create_comment", "Comment on an issue or a pull request of a GitHub repository.", c.CreateComment))
+	}
+	if inferErr != nil {
+		return nil, fmt.Errorf("failed to infer tool: %w", inferErr)
+	}
+	return tools, nil
+}
+
+// validate validates the configuration and sets default values if not provided.
+func (conf *Config) validate() error {
+	if conf == nil {
+		return fmt.Errorf("config is nil")
+	}
+	if conf.Token == "" {
+		return fmt.Errorf("token is required")
+	}
+	if conf.BaseURL == "" {
+		conf.BaseURL = "https://api.github.com"
+	}
+	conf.BaseURL = strings.TrimRight(conf.BaseURL, "/")
+	if conf.Timeout <= 0 {
+		conf.Timeout = 30 * time.Second
+	}
+	if conf.HTTPClient == nil {
+		conf.HTTPClient = &http.Client{Timeout: conf.Timeout}
+	}
+	for _, r := range conf.Repos {
+		owner, name, ok := strings.Cut(r, "/")
+		if !ok || owner == "" || name == "" || owner == "*" || strings.Contains(name, "/") {
+			return fmt.Errorf("invalid repo in allowlist: %q, expected owner/repo or owner/*", r)
+		}
+		if _, err := path.Match(name, ""); err != nil {
+			return fmt.Errorf("invalid repo in allowlist: %q: %w", r, err)
+		}
+	}
+	if conf.MaxResults <= 0 {
+		conf.MaxResults = 10
+	}
+	if conf.MaxResults > maxPerPage {
+		return fmt.Errorf("max results must be at most %d", maxPerPage)
+	}
+	if conf.MaxContentLength <= 0 {
+		conf.MaxContentLength = 20000
+	}
+	return nil
+}
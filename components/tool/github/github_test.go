@@ -0,0 +1,304 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClient(t *testing.T, conf *Config, mux *http.ServeMux) *client {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.Equal(t, "2022-11-28", r.Header.Get("X-GitHub-Api-Version"))
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	conf.Token = "token"
+	conf.BaseURL = srv.URL
+	c, err := newClient(conf)
+	assert.NoError(t, err)
+	return c
+}
+
+func TestNewToolKit(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewToolKit(ctx, nil)
+	assert.EqualError(t, err, "config is nil")
+	_, err = NewToolKit(ctx, &Config{})
+	assert.EqualError(t, err, "token is required")
+	_, err = NewToolKit(ctx, &Config{Token: "token", Repos: []string{"cloudwego"}})
+	assert.EqualError(t, err, `invalid repo in allowlist: "cloudwego", expected owner/repo or owner/*`)
+	_, err = NewToolKit(ctx, &Config{Token: "token", MaxResults: 101})
+	assert.EqualError(t, err, "max results must be at most 100")
+
+	names := func(tools []tool.BaseTool) []string {
+		var result []string
+		for _, tl := range tools {
+			info, err := tl.Info(ctx)
+			assert.NoError(t, err)
+			result = append(result, info.Name)
+		}
+		return result
+	}
+	tools, err := NewToolKit(ctx, &Config{Token: "token"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"github_search_code", "github_search_issues", "github_get_file", "github_get_pull_request"}, names(tools))
+
+	tools, err = NewToolKit(ctx, &Config{Token: "token", EnableWrite: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"github_search_code", "github_search_issues", "github_get_file", "github_get_pull_request",
+		"github_create_issue", "github_create_comment"}, names(tools))
+}
+
+func TestScopeQuery(t *testing.T) {
+	c, err := newClient(&Config{Token: "token", Repos: []string{"cloudwego/eino", "cloudwego/eino-*", "CloudWeGo/Kitex", "golang/*"}})
+	assert.NoError(t, err)
+
+	q, err := c.scopeQuery("NewToolKit", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "NewToolKit repo:cloudwego/eino user:cloudwego repo:CloudWeGo/Kitex user:golang", q)
+	q, err = c.scopeQuery("NewToolKit", "cloudwego/eino-ext")
+	assert.NoError(t, err)
+	assert.Equal(t, "NewToolKit repo:cloudwego/eino-ext", q)
+	q, err = c.scopeQuery("client", "cloudwego/kitex")
+	assert.NoError(t, err)
+	assert.Equal(t, "client repo:cloudwego/kitex", q)
+
+	_, err = c.scopeQuery("NewToolKit", "cloudwego/hertz")
+	assert.EqualError(t, err, "repo is not allowed: cloudwego/hertz")
+	_, err = c.scopeQuery("NewToolKit", "../etc")
+	assert.EqualError(t, err, `invalid repo: "../etc", expected owner/repo`)
+	_, err = c.scopeQuery("NewToolKit repo:cloudwego/hertz", "")
+	assert.EqualError(t, err, "use the repo parameter instead of the repo, org or user qualifiers")
+	_, err = c.scopeQuery("NewToolKit org:other", "cloudwego/eino")
+	assert.EqualError(t, err, "the query cannot have repo, org or user qualifiers with the repo parameter")
+
+	open, err := newClient(&Config{Token: "token"})
+	assert.NoError(t, err)
+	q, err = open.scopeQuery(" NewToolKit org:cloudwego ", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "NewToolKit org:cloudwego", q)
+}
+
+func TestSearchCode(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/search/code", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/vnd.github.text-match+json", r.Header.Get("Accept"))
+		assert.Equal(t, "InferTool user:cloudwego", r.URL.Query().Get("q"))
+		assert.Equal(t, "5", r.URL.Query().Get("per_page"))
+		assert.Equal(t, "2", r.URL.Query().Get("page"))
+		_, _ = w.Write([]byte(`{"total_count":2,"items":[
+			{"path":"components/tool/tavily/tavily.go","html_url":"https://github.com/cloudwego/eino-ext/blob/main/components/tool/tavily/tavily.go",
+			 "repository":{"full_name":"cloudwego/eino-ext"},"text_matches":[{"fragment":"utils.InferTool(conf.SearchToolName"}]},
+			{"path":"main.go","html_url":"https://github.com/cloudwego/hertz/blob/main/main.go","repository":{"full_name":"cloudwego/hertz"}}
+		]}`))
+	})
+	c := newTestClient(t, &Config{Repos: []string{"cloudwego/eino-*"}, MaxResults: 5}, mux)
+
+	resp, err := c.SearchCode(context.Background(), &SearchCodeRequest{Query: "InferTool", Page: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, &SearchCodeResponse{TotalCount: 2, Results: []*CodeResult{{
+		Repo:      "cloudwego/eino-ext",
+		Path:      "components/tool/tavily/tavily.go",
+		URL:       "https://github.com/cloudwego/eino-ext/blob/main/components/tool/tavily/tavily.go",
+		Fragments: []string{"utils.InferTool(conf.SearchToolName"},
+	}}}, resp)
+}
+
+func TestSearchIssues(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/search/issues", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/vnd.github+json", r.Header.Get("Accept"))
+		assert.Equal(t, "timeout label:bug repo:cloudwego/eino is:pr state:open", r.URL.Query().Get("q"))
+		assert.Equal(t, "10", r.URL.Query().Get("per_page"))
+		assert.Empty(t, r.URL.Query().Get("page"))
+		_, _ = w.Write([]byte(`{"total_count":1,"items":[{"number":42,"title":"Fix timeout","state":"open",
+			"html_url":"https://github.com/cloudwego/eino/pull/42","user":{"login":"octocat"},"labels":[{"name":"bug"}],
+			"comments":3,"created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-02T00:00:00Z","body":" Fixes #41 \n",
+			"pull_request":{"url":"https://api.github.com/repos/cloudwego/eino/pulls/42"},
+			"repository_url":"https://api.github.com/repos/cloudwego/eino"}]}`))
+	})
+	c := newTestClient(t, &Config{}, mux)
+
+	resp, err := c.SearchIssues(context.Background(), &SearchIssuesRequest{Query: "timeout label:bug", Repo: "cloudwego/eino", Type: "pr", State: "open"})
+	assert.NoError(t, err)
+	assert.Equal(t, &SearchIssuesResponse{TotalCount: 1, Results: []*Issue{{
+		Repo:        "cloudwego/eino",
+		Number:      42,
+		Title:       "Fix timeout",
+		State:       "open",
+		PullRequest: true,
+		Author:      "octocat",
+		Labels:      []string{"bug"},
+		Comments:    3,
+		URL:         "https://github.com/cloudwego/eino/pull/42",
+		CreatedAt:   "2025-01-01T00:00:00Z",
+		UpdatedAt:   "2025-01-02T00:00:00Z",
+		Body:        "Fixes #41",
+	}}}, resp)
+
+	_, err = c.SearchIssues(context.Background(), &SearchIssuesRequest{Query: "timeout", Type: "discussion"})
+	assert.EqualError(t, err, "unsupported type: discussion")
+}
+
+func TestGetFile(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/cloudwego/eino/contents/docs/my%20notes.md", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "v0.4.7", r.URL.Query().Get("ref"))
+		content := base64.StdEncoding.EncodeToString([]byte("# Notes\n\nHello, world!"))
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"type": "file", "name": "my notes.md", "path": "docs/my notes.md", "size": 22, "encoding": "base64",
+			"html_url": "https://github.com/cloudwego/eino/blob/v0.4.7/docs/my%20notes.md",
+			// the api wraps the base64 content in lines
+			"content": content[:10] + "\n" + content[10:] + "\n",
+		})
+	})
+	mux.HandleFunc("/repos/cloudwego/eino/contents/logo.png", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"type":"file","path":"logo.png","size":4,"encoding":"base64","content":"%s"}`,
+			base64.StdEncoding.EncodeToString([]byte("\x89PNG\x00")))
+	})
+	mux.HandleFunc("/repos/cloudwego/eino/contents/big.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"type":"file","path":"big.json","size":2000000,"encoding":"none","content":""}`))
+	})
+	mux.HandleFunc("/repos/cloudwego/eino/contents/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"type":"dir","name":"docs","path":"docs","size":0},{"type":"file","name":"go.mod","path":"go.mod","size":120}]`))
+	})
+	mux.HandleFunc("/repos/cloudwego/eino/contents/missing.go", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Not Found","documentation_url":"https://docs.github.com/rest"}`))
+	})
+	c := newTestClient(t, &Config{Repos: []string{"cloudwego/eino"}, MaxContentLength: 10}, mux)
+	ctx := context.Background()
+
+	resp, err := c.GetFile(ctx, &GetFileRequest{Repo: "cloudwego/eino", Path: "/docs/my notes.md", Ref: "v0.4.7"})
+	assert.NoError(t, err)
+	assert.Equal(t, &GetFileResponse{
+		Repo:      "cloudwego/eino",
+		Path:      "docs/my notes.md",
+		Type:      "file",
+		Size:      22,
+		URL:       "https://github.com/cloudwego/eino/blob/v0.4.7/docs/my%20notes.md",
+		Content:   "# Notes\n\nH\n[content truncated]",
+		Truncated: true,
+	}, resp)
+
+	resp, err = c.GetFile(ctx, &GetFileRequest{Repo: "cloudwego/eino", Path: "logo.png"})
+	assert.NoError(t, err)
+	assert.True(t, resp.Binary)
+	assert.Empty(t, resp.Content)
+
+	resp, err = c.GetFile(ctx, &GetFileRequest{Repo: "cloudwego/eino", Path: "/"})
+	assert.NoError(t, err)
+	assert.Equal(t, &GetFileResponse{Repo: "cloudwego/eino", Type: "dir", Entries: []*FileEntry{
+		{Name: "docs", Path: "docs", Type: "dir"},
+		{Name: "go.mod", Path: "go.mod", Type: "file", Size: 120},
+	}}, resp)
+
+	_, err = c.GetFile(ctx, &GetFileRequest{Repo: "cloudwego/eino", Path: "big.json"})
+	assert.EqualError(t, err, "file is too large to be read: 2000000 bytes")
+	_, err = c.GetFile(ctx, &GetFileRequest{Repo: "cloudwego/eino", Path: "missing.go"})
+	assert.EqualError(t, err, "github api error, status code: 404, message: Not Found")
+	_, err = c.GetFile(ctx, &GetFileRequest{Repo: "cloudwego/eino", Path: "docs/../../secrets"})
+	assert.EqualError(t, err, "invalid path: docs/../../secrets")
+	_, err = c.GetFile(ctx, &GetFileRequest{Repo: "cloudwego/kitex", Path: "go.mod"})
+	assert.EqualError(t, err, "repo is not allowed: cloudwego/kitex")
+}
+
+func TestGetPullRequest(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/cloudwego/eino/pulls/7", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"number":7,"title":"Add tool","state":"closed","merged":true,"html_url":"https://github.com/cloudwego/eino/pull/7",
+			"body":"Adds a tool.","user":{"login":"octocat"},"base":{"ref":"main"},"head":{"label":"octocat:feat/tool"},
+			"additions":120,"deletions":2,"changed_files":101}`))
+	})
+	mux.HandleFunc("/repos/cloudwego/eino/pulls/7/files", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+		var files []map[string]any
+		if r.URL.Query().Get("page") == "1" {
+			for i := 0; i < 100; i++ {
+				files = append(files, map[string]any{"filename": fmt.Sprintf("f%d.go", i), "status": "added", "additions": 1, "patch": "@@ +1 @@\n+a"})
+			}
+		} else {
+			assert.Equal(t, "2", r.URL.Query().Get("page"))
+			files = append(files, map[string]any{"filename": "big.go", "status": "modified", "additions": 20, "deletions": 2, "patch": strings.Repeat("+x\n", 20)})
+		}
+		_ = json.NewEncoder(w).Encode(files)
+	})
+	c := newTestClient(t, &Config{MaxContentLength: 1150}, mux)
+
+	pr, err := c.GetPullRequest(context.Background(), &GetPullRequestRequest{Repo: "cloudwego/eino", Number: 7})
+	assert.NoError(t, err)
+	assert.Equal(t, "Add tool", pr.Title)
+	assert.True(t, pr.Merged)
+	assert.Equal(t, "octocat", pr.Author)
+	assert.Equal(t, "main", pr.Base)
+	assert.Equal(t, "octocat:feat/tool", pr.Head)
+	assert.Equal(t, 101, pr.ChangedFiles)
+	assert.Len(t, pr.Files, 101)
+	assert.Equal(t, "@@ +1 @@\n+a", pr.Files[99].Patch)
+	// the patch of the last file does not fit in the remaining 50 characters
+	assert.Equal(t, &FileDiff{Filename: "big.go", Status: "modified", Additions: 20, Deletions: 2}, pr.Files[100])
+	assert.True(t, pr.Truncated)
+}
+
+func TestCreateIssueAndComment(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /repos/cloudwego/eino/issues", func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"title":"Crash on nil config","body":"Steps to reproduce...","labels":["bug"]}`, string(data))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"number":43,"html_url":"https://github.com/cloudwego/eino/issues/43"}`))
+	})
+	mux.HandleFunc("POST /repos/cloudwego/eino/issues/42/comments", func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"body":"LGTM"}`, string(data))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":1001,"html_url":"https://github.com/cloudwego/eino/pull/42#issuecomment-1001"}`))
+	})
+	mux.HandleFunc("POST /repos/cloudwego/eino/issues/44/comments", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message":"Validation Failed","errors":[{"resource":"IssueComment","field":"body","code":"too_long"}]}`))
+	})
+	c := newTestClient(t, &Config{Repos: []string{"cloudwego/eino"}, EnableWrite: true}, mux)
+	ctx := context.Background()
+
+	issue, err := c.CreateIssue(ctx, &CreateIssueRequest{Repo: "cloudwego/eino", Title: "Crash on nil config", Body: "Steps to reproduce...", Labels: []string{"bug"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &CreateIssueResponse{Number: 43, URL: "https://github.com/cloudwego/eino/issues/43"}, issue)
+
+	comment, err := c.CreateComment(ctx, &CreateCommentRequest{Repo: "cloudwego/eino", Number: 42, Body: "LGTM"})
+	assert.NoError(t, err)
+	assert.Equal(t, &CreateCommentResponse{ID: 1001, URL: "https://github.com/cloudwego/eino/pull/42#issuecomment-1001"}, comment)
+
+	_, err = c.CreateComment(ctx, &CreateCommentRequest{Repo: "cloudwego/eino", Number: 44, Body: "LGTM"})
+	assert.EqualError(t, err, "github api error, status code: 422, message: Validation Failed; body too_long")
+	_, err = c.CreateIssue(ctx, &CreateIssueRequest{Repo: "other/repo", Title: "spam"})
+	assert.EqualError(t, err, "repo is not allowed: other/repo")
+	_, err = c.CreateIssue(ctx, &CreateIssueRequest{Repo: "cloudwego/eino", Title: " "})
+	assert.EqualError(t, err, "title is required")
+}
@@ -0,0 +1,264 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// scopeQualifier matches the qualifiers choosing the repositories of a search.
+var scopeQualifier = regexp.MustCompile(`(?i)(^|\s)-?(repo|org|user):`)
+
+// SearchCodeRequest is the request of the code search tool.
+type SearchCodeRequest struct {
+	Query      string `json:"query" jsonschema:"required,description=The search query in the GitHub code search syntax, e.g. NewToolKit language:go path:components"`
+	Repo       string `json:"repo,omitempty" jsonschema:"description=Only search this repository, as owner/repo"`
+	MaxResults int    `json:"max_results,omitempty" jsonschema:"description=The number of results to return, between 1 and 100"`
+	Page       int    `json:"page,omitempty" jsonschema:"description=The page of the results, starting from 1"`
+}
+
+// SearchCodeResponse is the response of the code search tool.
+type SearchCodeResponse struct {
+	TotalCount int           `json:"total_count"`
+	Results    []*CodeResult `json:"results"`
+}
+
+// CodeResult is a file found by a code search.
+type CodeResult struct {
+	Repo string `json:"repo"`
+	Path string `json:"path"`
+	URL  string `json:"url"`
+	// Fragments are the parts of the file matching the query.
+	Fragments []string `json:"fragments,omitempty"`
+}
+
+// SearchIssuesRequest is the request of the issue search tool.
+type SearchIssuesRequest struct {
+	Query      string `json:"query" jsonschema:"required,description=The search query in the GitHub issue search syntax, e.g. timeout label:bug"`
+	Repo       string `json:"repo,omitempty" jsonschema:"description=Only search this repository, as owner/repo"`
+	Type       string `json:"type,omitempty" jsonschema:"description=Only return issues or pull requests,enum=issue,enum=pr"`
+	State      string `json:"state,omitempty" jsonschema:"description=Only return open or closed ones,enum=open,enum=closed"`
+	MaxResults int    `json:"max_results,omitempty" jsonschema:"description=The number of results to return, between 1 and 100"`
+	Page       int    `json:"page,omitempty" jsonschema:"description=The page of the results, starting from 1"`
+}
+
+// SearchIssuesResponse is the response of the issue search tool.
+type SearchIssuesResponse struct {
+	TotalCount int      `json:"total_count"`
+	Results    []*Issue `json:"results"`
+}
+
+// Issue is an issue or a pull request.
+type Issue struct {
+	Repo        string   `json:"repo"`
+	Number      int      `json:"number"`
+	Title       string   `json:"title"`
+	State       string   `json:"state"`
+	PullRequest bool     `json:"pull_request,omitempty"`
+	Author      string   `json:"author"`
+	Labels      []string `json:"labels,omitempty"`
+	Comments    int      `json:"comments"`
+	URL         string   `json:"url"`
+	CreatedAt   string   `json:"created_at"`
+	UpdatedAt   string   `json:"updated_at"`
+	// Body is the beginning of the description.
+	Body string `json:"body,omitempty"`
+}
+
+// maxIssueBodyLength is the maximum number of characters of the description of a search result.
+const maxIssueBodyLength = 1000
+
+// SearchCode searches code in the allowed repositories.
+func (c *client) SearchCode(ctx context.Context, req *SearchCodeRequest) (*SearchCodeResponse, error) {
+	if req == nil || strings.TrimSpace(req.Query) == "" {
+		return nil, fmt.Errorf("query is required")
+	}
+	q, err := c.scopeQuery(req.Query, req.Repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		TotalCount int `json:"total_count"`
+		Items      []struct {
+			Path       string `json:"path"`
+			HTMLURL    string `json:"html_url"`
+			Repository struct {
+				FullName string `json:"full_name"`
+			} `json:"repository"`
+			TextMatches []struct {
+				Fragment string `json:"fragment"`
+			} `json:"text_matches"`
+		} `json:"items"`
+	}
+	err = c.doAccept(ctx, http.MethodGet, "/search/code", c.searchParams(q, req.MaxResults, req.Page), nil, &resp,
+		"application/vnd.github.text-match+json")
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SearchCodeResponse{TotalCount: resp.TotalCount, Results: make([]*CodeResult, 0, len(resp.Items))}
+	for _, item := range resp.Items {
+		if !c.allowed(item.Repository.FullName) {
+			continue
+		}
+		r := &CodeResult{Repo: item.Repository.FullName, Path: item.Path, URL: item.HTMLURL}
+		for _, m := range item.TextMatches {
+			r.Fragments = append(r.Fragments, m.Fragment)
+		}
+		result.Results = append(result.Results, r)
+	}
+	return result, nil
+}
+
+type apiIssue struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	State   string `json:"state"`
+	HTMLURL string `json:"html_url"`
+	User    struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Comments      int       `json:"comments"`
+	CreatedAt     string    `json:"created_at"`
+	UpdatedAt     string    `json:"updated_at"`
+	Body          string    `json:"body"`
+	PullRequest   *struct{} `json:"pull_request"`
+	RepositoryURL string    `json:"repository_url"`
+}
+
+// SearchIssues searches issues and pull requests in the allowed repositories.
+func (c *client) SearchIssues(ctx context.Context, req *SearchIssuesRequest) (*SearchIssuesResponse, error) {
+	if req == nil || strings.TrimSpace(req.Query) == "" {
+		return nil, fmt.Errorf("query is required")
+	}
+	q, err := c.scopeQuery(req.Query, req.Repo)
+	if err != nil {
+		return nil, err
+	}
+	switch req.Type {
+	case "":
+	case "issue", "pr":
+		q += " is:" + req.Type
+	default:
+		return nil, fmt.Errorf("unsupported type: %s", req.Type)
+	}
+	switch req.State {
+	case "":
+	case "open", "closed":
+		q += " state:" + req.State
+	default:
+		return nil, fmt.Errorf("unsupported state: %s", req.State)
+	}
+
+	var resp struct {
+		TotalCount int         `json:"total_count"`
+		Items      []*apiIssue `json:"items"`
+	}
+	if err = c.do(ctx, http.MethodGet, "/search/issues", c.searchParams(q, req.MaxResults, req.Page), nil, &resp); err != nil {
+		return nil, err
+	}
+
+	result := &SearchIssuesResponse{TotalCount: resp.TotalCount, Results: make([]*Issue, 0, len(resp.Items))}
+	for _, item := range resp.Items {
+		// the repository url is https://api.github.com/repos/owner/repo
+		parts := strings.Split(item.RepositoryURL, "/")
+		repo := ""
+		if len(parts) >= 2 {
+			repo = parts[len(parts)-2] + "/" + parts[len(parts)-1]
+		}
+		if !c.allowed(repo) {
+			continue
+		}
+		body, _ := truncate(strings.TrimSpace(item.Body), maxIssueBodyLength)
+		issue := &Issue{
+			Repo:        repo,
+			Number:      item.Number,
+			Title:       item.Title,
+			State:       item.State,
+			PullRequest: item.PullRequest != nil,
+			Author:      item.User.Login,
+			Comments:    item.Comments,
+			URL:         item.HTMLURL,
+			CreatedAt:   item.CreatedAt,
+			UpdatedAt:   item.UpdatedAt,
+			Body:        body,
+		}
+		for _, l := range item.Labels {
+			issue.Labels = append(issue.Labels, l.Name)
+		}
+		result.Results = append(result.Results, issue)
+	}
+	return result, nil
+}
+
+// scopeQuery restricts the query to the repository, or to the allowlist.
+// The results are filtered by the allowlist as well, as the owner qualifiers of the patterns are wider.
+func (c *client) scopeQuery(query, repo string) (string, error) {
+	query = strings.TrimSpace(query)
+	if repo != "" {
+		if err := c.checkRepo(repo); err != nil {
+			return "", err
+		}
+		if scopeQualifier.MatchString(query) {
+			return "", fmt.Errorf("the query cannot have repo, org or user qualifiers with the repo parameter")
+		}
+		return query + " repo:" + repo, nil
+	}
+	if len(c.conf.Repos) == 0 {
+		return query, nil
+	}
+	if scopeQualifier.MatchString(query) {
+		return "", fmt.Errorf("use the repo parameter instead of the repo, org or user qualifiers")
+	}
+
+	seen := make(map[string]bool, len(c.conf.Repos))
+	for _, pattern := range c.conf.Repos {
+		owner, name, _ := strings.Cut(pattern, "/")
+		qualifier := "repo:" + pattern
+		if strings.ContainsAny(name, "*?[") {
+			qualifier = "user:" + owner
+		}
+		if !seen[qualifier] {
+			seen[qualifier] = true
+			query += " " + qualifier
+		}
+	}
+	return query, nil
+}
+
+func (c *client) searchParams(q string, maxResults, page int) url.Values {
+	if maxResults <= 0 {
+		maxResults = c.conf.MaxResults
+	}
+	params := url.Values{
+		"q":        {q},
+		"per_page": {strconv.Itoa(min(maxResults, maxPerPage))},
+	}
+	if page > 1 {
+		params.Set("page", strconv.Itoa(page))
+	}
+	return params
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// CreateIssueRequest is the request of the issue creation tool.
+type CreateIssueRequest struct {
+	Repo   string   `json:"repo" jsonschema:"required,description=The repository, as owner/repo"`
+	Title  string   `json:"title" jsonschema:"required,description=The title of the issue"`
+	Body   string   `json:"body,omitempty" jsonschema:"description=The description of the issue, in markdown"`
+	Labels []string `json:"labels,omitempty" jsonschema:"description=The labels of the issue, which must exist in the repository"`
+}
+
+// CreateIssueResponse is the created issue.
+type CreateIssueResponse struct {
+	Number int    `json:"number"`
+	URL    string `json:"url"`
+}
+
+// CreateCommentRequest is the request of the comment creation tool.
+type CreateCommentRequest struct {
+	Repo   string `json:"repo" jsonschema:"required,description=The repository, as owner/repo"`
+	Number int    `json:"number" jsonschema:"required,description=The number of the issue or the pull request"`
+	Body   string `json:"body" jsonschema:"required,description=The comment, in markdown"`
+}
+
+// CreateCommentResponse is the created comment.
+type CreateCommentResponse struct {
+	ID  int64  `json:"id"`
+	URL string `json:"url"`
+}
+
+// CreateIssue creates an issue in the repository.
+func (c *client) CreateIssue(ctx context.Context, req *CreateIssueRequest) (*CreateIssueResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request is nil")
+	}
+	if err := c.checkRepo(req.Repo); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(req.Title) == "" {
+		return nil, fmt.Errorf("title is required")
+	}
+
+	body := map[string]any{"title": req.Title}
+	if req.Body != "" {
+		body["body"] = req.Body
+	}
+	if len(req.Labels) > 0 {
+		body["labels"] = req.Labels
+	}
+	var resp struct {
+		Number  int    `json:"number"`
+		HTMLURL string `json:"html_url"`
+	}
+	if err := c.do(ctx, http.MethodPost, repoPath(req.Repo)+"/issues", nil, body, &resp); err != nil {
+		return nil, err
+	}
+	return &CreateIssueResponse{Number: resp.Number, URL: resp.HTMLURL}, nil
+}
+
+// CreateComment comments on an issue or a pull request of the repository.
+func (c *client) CreateComment(ctx context.Context, req *CreateCommentRequest) (*CreateCommentResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request is nil")
+	}
+	if err := c.checkRepo(req.Repo); err != nil {
+		return nil, err
+	}
+	if req.Number <= 0 {
+		return nil, fmt.Errorf("number is required")
+	}
+	if strings.TrimSpace(req.Body) == "" {
+		return nil, fmt.Errorf("body is required")
+	}
+
+	var resp struct {
+		ID      int64  `json:"id"`
+		HTMLURL string `json:"html_url"`
+	}
+	err := c.do(ctx, http.MethodPost, repoPath(req.Repo)+"/issues/"+strconv.Itoa(req.Number)+"/comments", nil,
+		map[string]any{"body": req.Body}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &CreateCommentResponse{ID: resp.ID, URL: resp.HTMLURL}, nil
+}
@@ -18,7 +18,9 @@ package googlesearch
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"google.golang.org/api/customsearch/v1"
@@ -86,7 +88,16 @@ type googleSearch struct {
 	cseSvr *customsearch.Service
 }
 
-func (gs *googleSearch) search(ctx context.Context, req *SearchRequest) (*customsearch.Search, error) {
+const (
+	// maxNumPerCall is the maximum number of results a single custom search call returns.
+	maxNumPerCall = 10
+	// maxResultIndex is the index of the last result the custom search json api is able to return.
+	maxResultIndex = 100
+
+	quotaExceededMessage = "google search quota exceeded, retry later or use another search tool"
+)
+
+func (gs *googleSearch) search(ctx context.Context, req *SearchRequest) (*SearchResult, error) {
 
 	num := req.Num
 	if num <= 0 {
@@ -100,30 +111,108 @@ func (gs *googleSearch) search(ctx context.Context, req *SearchRequest) (*custom
 	}
 
 	cseCall := gs.cseSvr.Cse.List().Context(ctx).Cx(gs.conf.SearchEngineID).Q(req.Query)
-	if num > 0 {
-		cseCall = cseCall.Num(int64(num))
-	}
 	if lang != "" {
 		cseCall = cseCall.Gl(lang)
 	}
-	if offset > 0 {
-		cseCall = cseCall.Start(int64(offset))
+	if req.SiteSearch != "" {
+		cseCall = cseCall.SiteSearch(req.SiteSearch)
+		switch req.SiteSearchFilter {
+		case "", "include":
+			cseCall = cseCall.SiteSearchFilter("i")
+		case "exclude":
+			cseCall = cseCall.SiteSearchFilter("e")
+		default:
+			return nil, fmt.Errorf("invalid site_search_filter: %s, expect include or exclude", req.SiteSearchFilter)
+		}
+	}
+	if req.SortByDate {
+		cseCall = cseCall.Sort("date")
+	}
+	switch req.SearchType {
+	case "", "web":
+	case "image":
+		cseCall = cseCall.SearchType("image")
+	default:
+		return nil, fmt.Errorf("invalid search_type: %s, expect web or image", req.SearchType)
 	}
 
-	sc, err := cseCall.Do()
-	if err != nil {
-		return nil, fmt.Errorf("search.cse.list failed: %w", err)
+	if num <= maxNumPerCall {
+		if num > 0 {
+			cseCall = cseCall.Num(int64(num))
+		}
+		if offset > 0 {
+			cseCall = cseCall.Start(int64(offset))
+		}
+
+		sc, err := cseCall.Do()
+		if err != nil {
+			if isQuotaExceeded(err) {
+				return &SearchResult{Query: req.Query, Items: []*SimplifiedSearchItem{}, Error: quotaExceededMessage}, nil
+			}
+			return nil, fmt.Errorf("search.cse.list failed: %w", err)
+		}
+
+		return toSearchResult(sc)
+	}
+
+	// the api returns at most 10 results per call, larger num is fetched page by page
+	start := offset
+	if start <= 0 {
+		start = 1
 	}
 
-	return sc, nil
+	result := &SearchResult{Items: make([]*SimplifiedSearchItem, 0, num)}
+	for remaining := num; remaining > 0 && start <= maxResultIndex; {
+		pageNum := min(remaining, maxNumPerCall, maxResultIndex-start+1)
+
+		sc, err := cseCall.Num(int64(pageNum)).Start(int64(start)).Do()
+		if err != nil {
+			if !isQuotaExceeded(err) {
+				return nil, fmt.Errorf("search.cse.list failed: %w", err)
+			}
+			if result.Query == "" {
+				result.Query = req.Query
+			}
+			// keep the results fetched so far, and let the agent know where to continue
+			result.Error = quotaExceededMessage
+			result.NextOffset = start
+			return result, nil
+		}
+
+		page, err := toSearchResult(sc)
+		if err != nil {
+			return nil, err
+		}
+		if result.Query == "" {
+			result.Query = page.Query
+		}
+		result.Items = append(result.Items, page.Items...)
+		result.NextOffset = page.NextOffset
+
+		if len(page.Items) == 0 || page.NextOffset == 0 {
+			break
+		}
+		remaining -= len(page.Items)
+		start = page.NextOffset
+	}
+
+	if result.NextOffset > maxResultIndex {
+		result.NextOffset = 0
+	}
+
+	return result, nil
 }
 
 func (gs *googleSearch) marshalOutput(_ context.Context, output any) (string, error) {
-	gsr, ok := output.(*customsearch.Search)
+	sr, ok := output.(*SearchResult)
 	if !ok {
-		return "", fmt.Errorf("unexpected google search response, expect %T but given %T", gsr, output)
+		return "", fmt.Errorf("unexpected google search response, expect %T but given %T", sr, output)
 	}
 
+	return sonic.MarshalString(sr)
+}
+
+func toSearchResult(gsr *customsearch.Search) (*SearchResult, error) {
 	simpleItems := make([]*SimplifiedSearchItem, 0, len(gsr.Items))
 	for _, item := range gsr.Items {
 		ssi := &SimplifiedSearchItem{
@@ -131,23 +220,56 @@ func (gs *googleSearch) marshalOutput(_ context.Context, output any) (string, er
 			Title:   item.Title,
 			Snippet: item.Snippet,
 		}
-		desc, okk, err := getDescFromPageMap(item.Pagemap)
-		if err != nil {
-			return "", err
+		if len(item.Pagemap) > 0 {
+			desc, okk, err := getDescFromPageMap(item.Pagemap)
+			if err != nil {
+				return nil, err
+			}
+			if okk {
+				ssi.Desc = desc
+			}
 		}
-		if okk {
-			ssi.Desc = desc
+		if item.Image != nil {
+			ssi.Image = &SimplifiedImage{
+				ContextLink:   item.Image.ContextLink,
+				ThumbnailLink: item.Image.ThumbnailLink,
+				Width:         item.Image.Width,
+				Height:        item.Image.Height,
+			}
 		}
 
 		simpleItems = append(simpleItems, ssi)
 	}
 
-	sr := SearchResult{
-		Query: getQuery(gsr.Queries.Request),
+	sr := &SearchResult{
 		Items: simpleItems,
 	}
+	if gsr.Queries != nil {
+		sr.Query = getQuery(gsr.Queries.Request)
+		if len(gsr.Queries.NextPage) > 0 {
+			sr.NextOffset = int(gsr.Queries.NextPage[0].StartIndex)
+		}
+	}
 
-	return sonic.MarshalString(sr)
+	return sr, nil
+}
+
+// isQuotaExceeded reports whether err is caused by the rate limit or the daily quota of the custom search api.
+func isQuotaExceeded(err error) bool {
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	if apiErr.Code == http.StatusTooManyRequests {
+		return true
+	}
+	for _, e := range apiErr.Errors {
+		switch e.Reason {
+		case "rateLimitExceeded", "userRateLimitExceeded", "dailyLimitExceeded", "quotaExceeded":
+			return true
+		}
+	}
+	return false
 }
 
 func getQuery(reqs []*customsearch.SearchQueriesRequest) string {
@@ -215,19 +337,37 @@ func getDescFromPageMap(pageMap googleapi.RawMessage) (string, bool, error) {
 
 type SearchRequest struct {
 	Query  string `json:"query" jsonschema:"description=queried string to the search engine"`
-	Num    int    `json:"num,omitempty" jsonschema:"description=number of search results to return, valid values are between 1 and 10, inclusive"`
-	Offset int    `json:"offset,omitempty" jsonschema:"description=the index of the first result to return."`
+	Num    int    `json:"num,omitempty" jsonschema:"description=number of search results to return. values above 10 are fetched page by page and only the first 100 results are available"`
+	Offset int    `json:"offset,omitempty" jsonschema:"description=the index of the first result to return. use next_offset of the previous result to get the next page"`
 	Lang   string `json:"lang,omitempty" jsonschema:"description=sets the user interface language, default english. usually represented by a 2-4 letter code in ISO 639-1. e.g. en, ja, zh-CN"`
+
+	SiteSearch       string `json:"site_search,omitempty" jsonschema:"description=restricts results to the given site or excludes it according to site_search_filter. e.g. go.dev"`
+	SiteSearchFilter string `json:"site_search_filter,omitempty" jsonschema:"description=whether to include only or exclude results from site_search. default include,enum=include,enum=exclude"`
+	SortByDate       bool   `json:"sort_by_date,omitempty" jsonschema:"description=sorts results by date with the newest first instead of by relevance"`
+	SearchType       string `json:"search_type,omitempty" jsonschema:"description=web searches web pages and image searches images. default web,enum=web,enum=image"`
 }
 
 type SearchResult struct {
 	Query string                  `json:"query,omitempty"`
 	Items []*SimplifiedSearchItem `json:"items"`
+	// NextOffset is the offset of the next page, absent when there are no more results.
+	NextOffset int `json:"next_offset,omitempty"`
+	// Error tells the agent why the search stopped early, e.g. the api quota is exceeded.
+	Error string `json:"error,omitempty"`
 }
 
 type SimplifiedSearchItem struct {
-	Link    string `json:"link"`
-	Title   string `json:"title,omitempty"`
-	Snippet string `json:"snippet,omitempty"`
-	Desc    string `json:"desc,omitempty"`
+	Link    string           `json:"link"`
+	Title   string           `json:"title,omitempty"`
+	Snippet string           `json:"snippet,omitempty"`
+	Desc    string           `json:"desc,omitempty"`
+	Image   *SimplifiedImage `json:"image,omitempty"`
+}
+
+// SimplifiedImage is set on items of image search, Link of the item is the image itself.
+type SimplifiedImage struct {
+	ContextLink   string `json:"context_link,omitempty"`
+	ThumbnailLink string `json:"thumbnail_link,omitempty"`
+	Width         int64  `json:"width,omitempty"`
+	Height        int64  `json:"height,omitempty"`
 }
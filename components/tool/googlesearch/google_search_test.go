@@ -18,6 +18,12 @@ package googlesearch
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
 	"testing"
 
 	"github.com/bytedance/mockey"
@@ -220,16 +226,34 @@ func TestGooGleSearchTool(t *testing.T) {
       "type" : "string"
     },
     "num" : {
-      "description" : "number of search results to return",
+      "description" : "number of search results to return. values above 10 are fetched page by page and only the first 100 results are available",
       "type" : "integer"
     },
     "offset" : {
-      "description" : "the index of the first result to return.",
+      "description" : "the index of the first result to return. use next_offset of the previous result to get the next page",
       "type" : "integer"
     },
     "lang" : {
       "description" : "sets the user interface language",
       "type" : "string"
+    },
+    "site_search" : {
+      "description" : "restricts results to the given site or excludes it according to site_search_filter. e.g. go.dev",
+      "type" : "string"
+    },
+    "site_search_filter" : {
+      "description" : "whether to include only or exclude results from site_search. default include",
+      "enum" : [ "include", "exclude" ],
+      "type" : "string"
+    },
+    "sort_by_date" : {
+      "description" : "sorts results by date with the newest first instead of by relevance",
+      "type" : "boolean"
+    },
+    "search_type" : {
+      "description" : "web searches web pages and image searches images. default web",
+      "enum" : [ "web", "image" ],
+      "type" : "string"
     }
   },
   "additionalProperties" : false,
@@ -259,7 +283,8 @@ func TestGooGleSearchTool(t *testing.T) {
 		  "snippet": "Feb 21, 2022 ... 一Spark与hadoop Hadoop有两个核心模块，分布式存储模块HDFS和分布式计算模块Mapreduce Spark 支持多种编程语言，包括Java、Python、R 和Scala， ...",
 		  "desc": "一 Spark与hadoop Hadoop有两个核心模块，分布式存储模块HDFS和分布式计算模块Mapreduce Spark 支持多种编程语言，包括 Java、Python、R 和 Scala，同时 Spark 也支持 Hadoop 的底层存储系统 HDFS，但 Spark 不依赖 Hadoop。"
 	  }
-	]
+	],
+	"next_offset": 4
 }
 `
 	searchResult := &customsearch.Search{}
@@ -305,3 +330,143 @@ func TestGooGleSearchTool(t *testing.T) {
 	})
 
 }
+
+func TestGoogleSearchPaging(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		mu      sync.Mutex
+		queries []url.Values
+	)
+	quotaAfter := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.Query())
+		calls := len(queries)
+		mu.Unlock()
+
+		if quotaAfter != 0 && calls > quotaAfter {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error":{"code":429,"message":"Quota exceeded","errors":[{"reason":"rateLimitExceeded","message":"Quota exceeded"}]}}`))
+			return
+		}
+
+		start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+		if start == 0 {
+			start = 1
+		}
+		num, _ := strconv.Atoi(r.URL.Query().Get("num"))
+
+		resp := &customsearch.Search{
+			Queries: &customsearch.SearchQueries{
+				Request:  []*customsearch.SearchQueriesRequest{{SearchTerms: r.URL.Query().Get("q")}},
+				NextPage: []*customsearch.SearchQueriesNextPage{{StartIndex: int64(start + num)}},
+			},
+		}
+		for i := start; i < start+num; i++ {
+			item := &customsearch.Result{Link: fmt.Sprintf("https://go.dev/%d", i), Title: strconv.Itoa(i)}
+			if r.URL.Query().Get("searchType") == "image" {
+				item.Image = &customsearch.ResultImage{ContextLink: "https://go.dev/blog", ThumbnailLink: "https://thumb/1", Width: 64, Height: 32}
+			}
+			resp.Items = append(resp.Items, item)
+		}
+		body, _ := sonic.Marshal(resp)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}))
+	defer srv.Close()
+
+	reset := func(quota int) {
+		queries = nil
+		quotaAfter = quota
+	}
+
+	st, err := NewTool(ctx, &Config{
+		APIKey:         "{mock_api_key}",
+		SearchEngineID: "{mock_search_engine_id}",
+		BaseURL:        srv.URL + "/",
+	})
+	assert.NoError(t, err)
+
+	run := func(req *SearchRequest) (*SearchResult, error) {
+		args, err := sonic.MarshalString(req)
+		assert.NoError(t, err)
+		out, err := st.InvokableRun(ctx, args)
+		if err != nil {
+			return nil, err
+		}
+		sr := &SearchResult{}
+		assert.NoError(t, sonic.UnmarshalString(out, sr))
+		return sr, nil
+	}
+
+	t.Run("num_beyond_one_page", func(t *testing.T) {
+		reset(0)
+		sr, err := run(&SearchRequest{
+			Query:            "eino",
+			Num:              15,
+			SiteSearch:       "go.dev",
+			SiteSearchFilter: "exclude",
+			SortByDate:       true,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, sr.Items, 15)
+		assert.Equal(t, "https://go.dev/15", sr.Items[14].Link)
+		assert.Equal(t, 16, sr.NextOffset)
+		assert.Equal(t, "eino", sr.Query)
+
+		assert.Len(t, queries, 2)
+		assert.Equal(t, "10", queries[0].Get("num"))
+		assert.Equal(t, "1", queries[0].Get("start"))
+		assert.Equal(t, "5", queries[1].Get("num"))
+		assert.Equal(t, "11", queries[1].Get("start"))
+		assert.Equal(t, "go.dev", queries[1].Get("siteSearch"))
+		assert.Equal(t, "e", queries[1].Get("siteSearchFilter"))
+		assert.Equal(t, "date", queries[1].Get("sort"))
+	})
+
+	t.Run("stop_at_result_limit", func(t *testing.T) {
+		reset(0)
+		sr, err := run(&SearchRequest{Query: "eino", Num: 30, Offset: 85})
+		assert.NoError(t, err)
+		assert.Len(t, sr.Items, 16)
+		assert.Equal(t, 0, sr.NextOffset)
+		assert.Equal(t, "6", queries[1].Get("num"))
+	})
+
+	t.Run("image_search", func(t *testing.T) {
+		reset(0)
+		sr, err := run(&SearchRequest{Query: "gopher", Num: 1, SearchType: "image", SiteSearch: "go.dev"})
+		assert.NoError(t, err)
+		assert.Equal(t, &SimplifiedImage{ContextLink: "https://go.dev/blog", ThumbnailLink: "https://thumb/1", Width: 64, Height: 32}, sr.Items[0].Image)
+		assert.Equal(t, "image", queries[0].Get("searchType"))
+		assert.Equal(t, "i", queries[0].Get("siteSearchFilter"))
+	})
+
+	t.Run("quota_exceeded", func(t *testing.T) {
+		reset(1)
+		sr, err := run(&SearchRequest{Query: "eino", Num: 20})
+		assert.NoError(t, err)
+		assert.Len(t, sr.Items, 10)
+		assert.Equal(t, 11, sr.NextOffset)
+		assert.Equal(t, quotaExceededMessage, sr.Error)
+
+		// every call fails
+		reset(-1)
+		sr, err = run(&SearchRequest{Query: "eino"})
+		assert.NoError(t, err)
+		assert.Empty(t, sr.Items)
+		assert.Equal(t, "eino", sr.Query)
+		assert.Equal(t, quotaExceededMessage, sr.Error)
+	})
+
+	t.Run("invalid_request", func(t *testing.T) {
+		reset(0)
+		_, err := run(&SearchRequest{Query: "eino", SiteSearch: "go.dev", SiteSearchFilter: "only"})
+		assert.ErrorContains(t, err, "invalid site_search_filter")
+
+		_, err = run(&SearchRequest{Query: "eino", SearchType: "video"})
+		assert.ErrorContains(t, err, "invalid search_type")
+	})
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package common holds the request options shared by the HTTP request tools:
+// authentication, host policy and response limits.
+package common
+
+import (
+	"encoding/base64"
+	"net/http"
+)
+
+type AuthType string
+
+const (
+	// AuthTypeBearer sends "Authorization: Bearer <Token>".
+	AuthTypeBearer AuthType = "bearer"
+	// AuthTypeBasic sends "Authorization: Basic <base64(Username:Password)>".
+	AuthTypeBasic AuthType = "basic"
+	// AuthTypeAPIKey sends "<HeaderName>: <Token>".
+	AuthTypeAPIKey AuthType = "api_key"
+)
+
+// Auth is the authentication added to every request. The model can neither see nor override its header.
+type Auth struct {
+	Type AuthType `json:"type"`
+	// Token is the bearer token, or the API key.
+	Token string `json:"token"`
+	// Username and Password are the credentials of the basic authentication.
+	Username string `json:"username"`
+	Password string `json:"password"`
+	// HeaderName is the header carrying the API key.
+	// Optional. Default: "X-API-Key".
+	HeaderName string `json:"header_name"`
+}
+
+// header returns the name and the value of the authentication header.
+func (a *Auth) header() (string, string) {
+	switch a.Type {
+	case AuthTypeBearer:
+		return "Authorization", "Bearer " + a.Token
+	case AuthTypeBasic:
+		return "Authorization", "Basic " + base64.StdEncoding.EncodeToString([]byte(a.Username+":"+a.Password))
+	case AuthTypeAPIKey:
+		name := a.HeaderName
+		if name == "" {
+			name = "X-API-Key"
+		}
+		return name, a.Token
+	default:
+		return "", ""
+	}
+}
+
+func (a *Auth) apply(req *http.Request) {
+	if a == nil {
+		return
+	}
+	if name, value := a.header(); name != "" {
+		req.Header.Set(name, value)
+	}
+}
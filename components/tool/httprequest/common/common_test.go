@@ -0,0 +1,255 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuth(t *testing.T) {
+	for _, tt := range []struct {
+		auth  *Auth
+		name  string
+		value string
+	}{
+		{&Auth{Type: AuthTypeBearer, Token: "t"}, "Authorization", "Bearer t"},
+		{&Auth{Type: AuthTypeBasic, Username: "u", Password: "p"}, "Authorization", "Basic dTpw"},
+		{&Auth{Type: AuthTypeAPIKey, Token: "k"}, "X-API-Key", "k"},
+		{&Auth{Type: AuthTypeAPIKey, Token: "k", HeaderName: "Api-Key"}, "Api-Key", "k"},
+	} {
+		name, value := tt.auth.header()
+		assert.Equal(t, tt.name, name)
+		assert.Equal(t, tt.value, value)
+	}
+}
+
+func TestHostPolicy_Check(t *testing.T) {
+	ctx := context.Background()
+	check := func(p *HostPolicy, rawURL string) error {
+		u, err := url.Parse(rawURL)
+		assert.NoError(t, err)
+		return p.Check(ctx, u)
+	}
+
+	var p *HostPolicy
+	assert.NoError(t, check(p, "http://127.0.0.1"))
+
+	p = &HostPolicy{AllowedHosts: []string{"example.com", "*.example.org"}, DeniedHosts: []string{"admin.example.org"}}
+	assert.NoError(t, check(p, "https://example.com/a"))
+	assert.NoError(t, check(p, "https://API.example.org./a"))
+	assert.ErrorIs(t, check(p, "https://www.example.com"), ErrHostNotAllowed)
+	assert.ErrorIs(t, check(p, "https://admin.example.org"), ErrHostNotAllowed)
+	assert.ErrorIs(t, check(p, "file:///etc/passwd"), ErrHostNotAllowed)
+
+	p = &HostPolicy{DenyPrivateNetworks: true}
+	for _, u := range []string{"http://127.0.0.1:8080", "http://10.0.0.1", "http://169.254.169.254/latest/meta-data", "http://[::1]", "http://0.0.0.0", "http://localhost"} {
+		assert.ErrorIs(t, check(p, u), ErrHostNotAllowed, u)
+	}
+	assert.NoError(t, check(p, "http://93.184.215.14"))
+}
+
+func TestDo(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redirect":
+			http.Redirect(w, r, "http://10.0.0.1/", http.StatusFound)
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+		default:
+			fmt.Fprintf(w, "%s %s", r.Header.Get("X-API-Key"), r.Header.Get("X-Trace"))
+		}
+	}))
+	defer server.Close()
+
+	newReq := func(path string) *http.Request {
+		req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		assert.NoError(t, err)
+		return req
+	}
+
+	opts := &Options{
+		Headers: map[string]string{"X-Trace": "config"},
+		Auth:    &Auth{Type: AuthTypeAPIKey, Token: "key"},
+	}
+	body, err := Do(ctx, NewClient(nil, nil), newReq("/"), map[string]string{"X-Trace": "model", "X-API-Key": "forged"}, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "key model", body)
+
+	_, err = Do(ctx, NewClient(nil, nil), newReq("/slow"), nil, &Options{Timeout: 50 * time.Millisecond})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// the redirects are checked against the host policy
+	policy := &HostPolicy{DeniedHosts: []string{"10.0.0.1"}}
+	_, err = Do(ctx, NewClient(nil, policy), newReq("/redirect"), nil, &Options{HostPolicy: policy})
+	assert.ErrorIs(t, err, ErrHostNotAllowed)
+
+	// the test server listens on a loopback address
+	policy = &HostPolicy{DenyPrivateNetworks: true}
+	_, err = Do(ctx, NewClient(nil, policy), newReq("/"), nil, &Options{HostPolicy: policy})
+	assert.ErrorIs(t, err, ErrHostNotAllowed)
+	// also when connecting, e.g. after the DNS record changed
+	_, err = Do(ctx, NewClient(nil, policy), newReq("/"), nil, nil)
+	assert.ErrorIs(t, err, ErrHostNotAllowed)
+}
+
+func TestDo_Download(t *testing.T) {
+	ctx := context.Background()
+	png := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 100)...)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/logo":
+			// the content type is sniffed
+			w.Write(png)
+		case "/report.pdf":
+			w.Header().Set("Content-Type", "application/pdf")
+			w.Write([]byte("%PDF-1.7"))
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"items":[1,2,3]}`)
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	do := func(path string, opts *Options) (string, error) {
+		req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		assert.NoError(t, err)
+		return Do(ctx, NewClient(nil, nil), req, nil, opts)
+	}
+	downloaded := func(result string) *Download {
+		d := &Download{}
+		assert.NoError(t, json.Unmarshal([]byte(result), d))
+		return d
+	}
+
+	result, err := do("/items", &Options{DownloadDir: dir})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"items":[1,2,3]}`, result)
+
+	// text longer than the max response size
+	result, err = do("/items", &Options{DownloadDir: dir, MaxResponseSize: 5})
+	assert.NoError(t, err)
+	d := downloaded(result)
+	assert.Equal(t, "application/json", d.ContentType)
+	assert.Equal(t, int64(17), d.Size)
+	assert.Equal(t, ".json", filepath.Ext(d.FilePath))
+	content, err := os.ReadFile(d.FilePath)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"items":[1,2,3]}`, string(content))
+
+	result, err = do("/logo", &Options{DownloadDir: dir})
+	assert.NoError(t, err)
+	d = downloaded(result)
+	assert.Equal(t, http.StatusOK, d.StatusCode)
+	assert.Equal(t, "image/png", d.ContentType)
+	assert.Equal(t, ".png", filepath.Ext(d.FilePath))
+	assert.Equal(t, dir, filepath.Dir(d.FilePath))
+	content, err = os.ReadFile(d.FilePath)
+	assert.NoError(t, err)
+	assert.Equal(t, png, content)
+
+	result, err = do("/report.pdf", &Options{DownloadDir: dir})
+	assert.NoError(t, err)
+	assert.Equal(t, ".pdf", filepath.Ext(downloaded(result).FilePath))
+
+	_, err = do("/logo", &Options{DownloadDir: dir, MaxDownloadSize: 10})
+	assert.EqualError(t, err, "response body exceeds the max download size of 10 bytes")
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(entries))
+}
+
+func TestDo_ResponseTransform(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/text":
+			w.Header().Set("Content-Type", "text/plain")
+			fmt.Fprint(w, `{"items": "not transformed"}`)
+		case "/invalid":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"items": [`)
+		default:
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			fmt.Fprint(w, `{"total": 2, "items": [{"name": "a", "url": "https://a.com/?x=1&y=2", "size": 1}, {"name": "b", "url": "https://b.com", "size": 2}]}`)
+		}
+	}))
+	defer server.Close()
+
+	newReq := func(path string) *http.Request {
+		req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		assert.NoError(t, err)
+		return req
+	}
+	client := NewClient(nil, nil)
+
+	body, err := Do(ctx, client, newReq("/"), nil, &Options{ResponseTransform: &ResponseTransform{JQ: `.items[] | {name, url}`}})
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"name\":\"a\",\"url\":\"https://a.com/?x=1&y=2\"}\n{\"name\":\"b\",\"url\":\"https://b.com\"}", body)
+
+	body, err = Do(ctx, client, newReq("/"), nil, &Options{ResponseTransform: &ResponseTransform{JSONPath: `$.items[*].name`}})
+	assert.NoError(t, err)
+	assert.Equal(t, `["a","b"]`, body)
+
+	// the transformed body is truncated, not the original one
+	body, err = Do(ctx, client, newReq("/"), nil, &Options{
+		ResponseTransform: &ResponseTransform{JQ: `[.items[].size]`},
+		MaxResponseSize:   3,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "[1,\n[response truncated to 3 bytes]", body)
+
+	// the transform is applied before the downloads
+	body, err = Do(ctx, client, newReq("/"), nil, &Options{
+		ResponseTransform: &ResponseTransform{JQ: `.total`},
+		DownloadDir:       t.TempDir(),
+		MaxResponseSize:   10,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "2", body)
+
+	body, err = Do(ctx, client, newReq("/text"), nil, &Options{ResponseTransform: &ResponseTransform{JQ: `.items`}})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"items": "not transformed"}`, body)
+
+	_, err = Do(ctx, client, newReq("/invalid"), nil, &Options{ResponseTransform: &ResponseTransform{JQ: `.items`}})
+	assert.ErrorContains(t, err, "failed to transform response body")
+
+	_, err = Do(ctx, client, newReq("/"), nil, &Options{ResponseTransform: &ResponseTransform{JQ: `.items | error("boom")`}})
+	assert.ErrorContains(t, err, "boom")
+}
+
+func TestResponseTransform_Validate(t *testing.T) {
+	assert.NoError(t, (*ResponseTransform)(nil).Validate())
+	assert.NoError(t, (&ResponseTransform{JQ: `.items[0]`}).Validate())
+	assert.NoError(t, (&ResponseTransform{JSONPath: `$.items[0]`}).Validate())
+	assert.Error(t, (&ResponseTransform{}).Validate())
+	assert.Error(t, (&ResponseTransform{JQ: `.a`, JSONPath: `$.a`}).Validate())
+	assert.ErrorContains(t, (&ResponseTransform{JQ: `.items[`}).Validate(), "invalid jq expression")
+	assert.ErrorContains(t, (&ResponseTransform{JSONPath: `$.items[`}).Validate(), "invalid json path expression")
+}
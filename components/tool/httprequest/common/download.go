@@ -0,0 +1,150 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package common
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+)
+
+// Download is returned to the model instead of the response body when the body is saved to a file.
+type Download struct {
+	StatusCode  int    `json:"status_code"`
+	FilePath    string `json:"file_path"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+}
+
+// download returns the text response bodies shorter than MaxResponseSize, and streams the others to a file.
+func download(resp *http.Response, opts *Options) (string, error) {
+	br := bufio.NewReader(resp.Body)
+	head, err := br.Peek(512)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(head)
+	}
+
+	var body []byte
+	if isText(contentType) {
+		limit := opts.MaxResponseSize
+		if limit <= 0 {
+			limit = 1<<63 - 2
+		}
+		body, err = io.ReadAll(io.LimitReader(br, limit+1))
+		if err != nil {
+			return "", fmt.Errorf("failed to read response body: %w", err)
+		}
+		if int64(len(body)) <= limit {
+			return string(body), nil
+		}
+	}
+
+	f, err := os.CreateTemp(opts.DownloadDir, "download-*"+extension(resp, contentType))
+	if err != nil {
+		return "", fmt.Errorf("failed to create download file: %w", err)
+	}
+	size, err := copyLimited(f, io.MultiReader(bytes.NewReader(body), br), opts.MaxDownloadSize)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return "", err
+	}
+
+	result, err := json.Marshal(&Download{
+		StatusCode:  resp.StatusCode,
+		FilePath:    f.Name(),
+		ContentType: contentType,
+		Size:        size,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(result), nil
+}
+
+func copyLimited(dst io.Writer, src io.Reader, limit int64) (int64, error) {
+	if limit <= 0 {
+		n, err := io.Copy(dst, src)
+		if err != nil {
+			return n, fmt.Errorf("failed to download response body: %w", err)
+		}
+		return n, nil
+	}
+
+	n, err := io.Copy(dst, io.LimitReader(src, limit+1))
+	if err != nil {
+		return n, fmt.Errorf("failed to download response body: %w", err)
+	}
+	if n > limit {
+		return n, fmt.Errorf("response body exceeds the max download size of %d bytes", limit)
+	}
+	return n, nil
+}
+
+func isText(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	if strings.HasPrefix(mediaType, "text/") {
+		return true
+	}
+	for _, s := range []string{"json", "xml", "javascript", "yaml", "x-www-form-urlencoded"} {
+		if strings.Contains(mediaType, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// extension returns the extension of the file name in the URL, or of the content type.
+func extension(resp *http.Response, contentType string) string {
+	if resp.Request != nil {
+		if ext := path.Ext(resp.Request.URL.Path); len(ext) > 1 && len(ext) <= 8 && isAlphanumeric(ext[1:]) {
+			return ext
+		}
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if exts, _ := mime.ExtensionsByType(mediaType); len(exts) > 0 {
+		return exts[0]
+	}
+	return ""
+}
+
+func isAlphanumeric(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// HostPolicy restricts the hosts the tools can send requests to, which prevents server-side request forgery
+// when the model chooses the URL, e.g. requests to the internal services or to the cloud metadata endpoint.
+type HostPolicy struct {
+	// AllowedHosts are the only hosts the requests can be sent to, e.g. "api.example.com",
+	// or "*.example.com" for the subdomains of example.com.
+	// Optional. Default: all the hosts are allowed.
+	AllowedHosts []string `json:"allowed_hosts"`
+	// DeniedHosts are the hosts the requests can't be sent to, with the same patterns as AllowedHosts.
+	// Optional.
+	DeniedHosts []string `json:"denied_hosts"`
+	// DenyPrivateNetworks denies the hosts resolving to loopback, private, link-local or unspecified addresses.
+	// With the default HttpClient, the addresses are checked when connecting, which also prevents DNS rebinding.
+	// Optional. Default: false.
+	DenyPrivateNetworks bool `json:"deny_private_networks"`
+}
+
+// ErrHostNotAllowed is returned for the requests to the hosts denied by the host policy.
+var ErrHostNotAllowed = errors.New("host not allowed")
+
+// Check returns ErrHostNotAllowed if the policy denies the host of the URL.
+func (p *HostPolicy) Check(ctx context.Context, u *url.URL) error {
+	if p == nil {
+		return nil
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%w: unsupported scheme %q", ErrHostNotAllowed, u.Scheme)
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if len(p.AllowedHosts) > 0 && !matchHost(p.AllowedHosts, host) {
+		return fmt.Errorf("%w: %s", ErrHostNotAllowed, host)
+	}
+	if matchHost(p.DeniedHosts, host) {
+		return fmt.Errorf("%w: %s", ErrHostNotAllowed, host)
+	}
+
+	if !p.DenyPrivateNetworks {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return checkIP(ip)
+	}
+	ips, err := net.DefaultResolver.LookupIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("failed to resolve host: %w", err)
+	}
+	for _, ip := range ips {
+		if err = checkIP(ip); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func matchHost(patterns []string, host string) bool {
+	for _, p := range patterns {
+		p = strings.TrimSuffix(strings.ToLower(p), ".")
+		if p == host {
+			return true
+		}
+		if strings.HasPrefix(p, "*.") && strings.HasSuffix(host, p[1:]) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkIP(ip net.IP) error {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() {
+		return fmt.Errorf("%w: %s is a private network address", ErrHostNotAllowed, ip)
+	}
+	return nil
+}
+
+// NewClient returns the client used by the tools: a default client with a 30-second timeout when client is nil,
+// with the host policy applied to the redirects, and for the default client, to the connected addresses.
+func NewClient(client *http.Client, policy *HostPolicy) *http.Client {
+	if client == nil {
+		transport := &http.Transport{}
+		if policy != nil && policy.DenyPrivateNetworks {
+			dialer := &net.Dialer{
+				Timeout: 30 * time.Second,
+				Control: func(network, address string, c syscall.RawConn) error {
+					host, _, err := net.SplitHostPort(address)
+					if err != nil {
+						return err
+					}
+					if ip := net.ParseIP(host); ip != nil {
+						return checkIP(ip)
+					}
+					return nil
+				},
+			}
+			transport.DialContext = dialer.DialContext
+		}
+		client = &http.Client{
+			Timeout:   30 * time.Second,
+			Transport: transport,
+		}
+	}
+	if policy == nil {
+		return client
+	}
+
+	copied := *client
+	checkRedirect := client.CheckRedirect
+	copied.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if err := policy.Check(req.Context(), req.URL); err != nil {
+			return err
+		}
+		if checkRedirect != nil {
+			return checkRedirect(req, via)
+		}
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+
+	return &copied
+}
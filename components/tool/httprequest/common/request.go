@@ -0,0 +1,125 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package common
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Options are the options of a request, from the config of the tool.
+type Options struct {
+	// Headers are the headers of the config, sent with every request.
+	Headers map[string]string
+	Auth    *Auth
+	// HostPolicy restricts the hosts of the requests.
+	HostPolicy *HostPolicy
+	// MaxResponseSize is the maximum size in bytes of the response body returned to the model,
+	// the longer bodies being truncated. 0 means no limit.
+	MaxResponseSize int64
+	// Timeout bounds the duration of the request, including reading the body. 0 means the client's timeout.
+	Timeout time.Duration
+	// DownloadDir enables the downloads: the binary response bodies, and the ones longer than MaxResponseSize,
+	// are streamed to a file in DownloadDir, and the model gets its path and content type instead of the body.
+	DownloadDir string
+	// MaxDownloadSize is the maximum size in bytes of the downloaded files. 0 means no limit.
+	MaxDownloadSize int64
+	// ResponseTransform transforms the JSON response bodies before MaxResponseSize and DownloadDir apply.
+	ResponseTransform *ResponseTransform
+}
+
+// maxTransformBodySize is the maximum size in bytes of the JSON response bodies read for ResponseTransform.
+const maxTransformBodySize = 64 << 20
+
+// Do sends the request and returns its response body.
+// The headers chosen by the model override the headers of the config, except the authentication header.
+func Do(ctx context.Context, client *http.Client, req *http.Request, headers map[string]string, opts *Options) (string, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	if err := opts.HostPolicy.Check(ctx, req.URL); err != nil {
+		return "", err
+	}
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	for key, value := range opts.Headers {
+		req.Header.Set(key, value)
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	opts.Auth.apply(req)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if opts.ResponseTransform != nil && isJSON(resp.Header.Get("Content-Type")) {
+		return transform(resp, opts)
+	}
+
+	if opts.DownloadDir != "" {
+		return download(resp, opts)
+	}
+
+	var reader io.Reader = resp.Body
+	if opts.MaxResponseSize > 0 {
+		reader = io.LimitReader(resp.Body, opts.MaxResponseSize+1)
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return truncate(body, opts.MaxResponseSize), nil
+}
+
+// transform reads the whole JSON response body and returns it transformed by the ResponseTransform.
+func transform(resp *http.Response, opts *Options) (string, error) {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxTransformBodySize+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+	if len(body) > maxTransformBodySize {
+		return "", fmt.Errorf("response body exceeds the max size of %d bytes to transform", maxTransformBodySize)
+	}
+
+	body, err = opts.ResponseTransform.apply(body)
+	if err != nil {
+		return "", fmt.Errorf("failed to transform response body: %w", err)
+	}
+
+	return truncate(body, opts.MaxResponseSize), nil
+}
+
+func truncate(body []byte, maxSize int64) string {
+	if maxSize > 0 && int64(len(body)) > maxSize {
+		return fmt.Sprintf("%s\n[response truncated to %d bytes]", body[:maxSize], maxSize)
+	}
+	return string(body)
+}
@@ -0,0 +1,141 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+	"sync"
+
+	"github.com/itchyny/gojq"
+	"github.com/ohler55/ojg/jp"
+)
+
+// ResponseTransform extracts the fields the model needs from the JSON response bodies before they are returned,
+// which shrinks the huge API payloads and saves context tokens.
+// Only the responses with a JSON content type are transformed, the others are returned unchanged.
+// Exactly one of JQ and JSONPath must be set.
+type ResponseTransform struct {
+	// JQ is a jq expression, e.g. `.items[] | {name, html_url}`, see https://jqlang.org/manual/.
+	// Multiple outputs are returned as one compact JSON value per line, like `jq -c`.
+	JQ string `json:"jq"`
+	// JSONPath is a JSONPath expression, e.g. `$.items[*].name`, the matches are returned as a JSON array.
+	JSONPath string `json:"json_path"`
+
+	once sync.Once
+	err  error
+	code *gojq.Code
+	path jp.Expr
+}
+
+// Validate compiles the expression, it's called when creating the tools.
+func (t *ResponseTransform) Validate() error {
+	if t == nil {
+		return nil
+	}
+	t.once.Do(func() {
+		t.err = t.compile()
+	})
+	return t.err
+}
+
+func (t *ResponseTransform) compile() error {
+	switch {
+	case t.JQ != "" && t.JSONPath != "":
+		return errors.New("response transform must set only one of jq and json path")
+	case t.JQ != "":
+		query, err := gojq.Parse(t.JQ)
+		if err != nil {
+			return fmt.Errorf("invalid jq expression: %w", err)
+		}
+		t.code, err = gojq.Compile(query)
+		if err != nil {
+			return fmt.Errorf("invalid jq expression: %w", err)
+		}
+	case t.JSONPath != "":
+		path, err := jp.ParseString(t.JSONPath)
+		if err != nil {
+			return fmt.Errorf("invalid json path expression: %w", err)
+		}
+		t.path = path
+	default:
+		return errors.New("response transform must set one of jq and json path")
+	}
+	return nil
+}
+
+// apply transforms the JSON body with the expression.
+func (t *ResponseTransform) apply(body []byte) ([]byte, error) {
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+
+	var input any
+	if err := json.Unmarshal(body, &input); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON response body: %w", err)
+	}
+
+	if t.path != nil {
+		return marshalCompact(t.path.Get(input))
+	}
+
+	var buf bytes.Buffer
+	iter := t.code.Run(input)
+	for {
+		v, ok := iter.Next()
+		if !ok {
+			break
+		}
+		if err, ok := v.(error); ok {
+			var haltErr *gojq.HaltError
+			if errors.As(err, &haltErr) && haltErr.Value() == nil {
+				break
+			}
+			return nil, fmt.Errorf("failed to run jq expression: %w", err)
+		}
+		out, err := marshalCompact(v)
+		if err != nil {
+			return nil, err
+		}
+		if buf.Len() > 0 {
+			buf.WriteByte('\n')
+		}
+		buf.Write(out)
+	}
+	return buf.Bytes(), nil
+}
+
+func marshalCompact(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, fmt.Errorf("failed to marshal transformed response: %w", err)
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
@@ -25,6 +25,8 @@ import (
 
 	"github.com/cloudwego/eino/components/tool"
 	"github.com/cloudwego/eino/components/tool/utils"
+
+	"github.com/cloudwego/eino-ext/components/tool/httprequest/common"
 )
 
 type Config struct {
@@ -49,6 +51,39 @@ type Config struct {
 	// If not provided, a default client with a 30-second timeout and a standard transport
 	// will be initialized and used.
 	HttpClient *http.Client
+
+	// Optional.
+	// Auth is the authentication added to every request: a bearer token, basic credentials or an API key header.
+	// The model can't override its header.
+	Auth *common.Auth `json:"auth"`
+
+	// Optional.
+	// HostPolicy restricts the hosts the requests can be sent to, which prevents server-side request forgery
+	// when the model chooses the URL.
+	HostPolicy *common.HostPolicy `json:"host_policy"`
+
+	// Optional. Default: 0, no limit.
+	// MaxResponseSize is the maximum size in bytes of the response body returned to the model.
+	// Longer bodies are truncated.
+	MaxResponseSize int64 `json:"max_response_size"`
+
+	// Optional. Default: 0, the timeout of the HttpClient.
+	// Timeout bounds the duration of each request, including reading the response body.
+	Timeout time.Duration `json:"timeout"`
+
+	// Optional. Default: "", the response bodies are returned to the model.
+	// DownloadDir enables the downloads: the binary response bodies, and the ones longer than MaxResponseSize,
+	// are streamed to a file in DownloadDir, and the model gets the file path and the content type instead.
+	DownloadDir string `json:"download_dir"`
+
+	// Optional. Default: 0, no limit.
+	// MaxDownloadSize is the maximum size in bytes of the downloaded files.
+	MaxDownloadSize int64 `json:"max_download_size"`
+
+	// Optional. Default: nil, the response bodies are returned as is.
+	// ResponseTransform extracts the fields the model needs from the JSON response bodies with a jq or JSONPath
+	// expression, e.g. &common.ResponseTransform{JQ: `.items[] | {name, html_url}`}.
+	ResponseTransform *common.ResponseTransform `json:"response_transform"`
 }
 
 func (c *Config) validate() error {
@@ -64,12 +99,13 @@ func (c *Config) validate() error {
 	if c.Headers == nil {
 		c.Headers = make(map[string]string)
 	}
-	if c.HttpClient == nil {
-		c.HttpClient = &http.Client{
-			Timeout:   30 * time.Second,
-			Transport: &http.Transport{},
-		}
+	if c.MaxResponseSize < 0 {
+		return errors.New("max response size must not be negative")
+	}
+	if err := c.ResponseTransform.Validate(); err != nil {
+		return err
 	}
+	c.HttpClient = common.NewClient(c.HttpClient, c.HostPolicy)
 	return nil
 }
 
@@ -105,3 +141,16 @@ func newRequestTool(config *Config) (*DeleteRequestTool, error) {
 		client: config.HttpClient,
 	}, nil
 }
+
+func (r *DeleteRequestTool) options() *common.Options {
+	return &common.Options{
+		Headers:           r.config.Headers,
+		Auth:              r.config.Auth,
+		HostPolicy:        r.config.HostPolicy,
+		MaxResponseSize:   r.config.MaxResponseSize,
+		Timeout:           r.config.Timeout,
+		DownloadDir:       r.config.DownloadDir,
+		MaxDownloadSize:   r.config.MaxDownloadSize,
+		ResponseTransform: r.config.ResponseTransform,
+	}
+}
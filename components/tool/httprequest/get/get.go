@@ -19,12 +19,14 @@ package get
 import (
 	"context"
 	"fmt"
-	"io"
 	"net/http"
+
+	"github.com/cloudwego/eino-ext/components/tool/httprequest/common"
 )
 
 type GetRequest struct {
-	URL string `json:"url" jsonschema:"description=The URL to make the GET request"`
+	URL     string            `json:"url" jsonschema:"description=The URL to make the GET request"`
+	Headers map[string]string `json:"headers,omitempty" jsonschema:"description=Optional HTTP headers to send with the request"`
 }
 
 func (r *GetRequestTool) Get(ctx context.Context, req *GetRequest) (string, error) {
@@ -34,20 +36,5 @@ func (r *GetRequestTool) Get(ctx context.Context, req *GetRequest) (string, erro
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
-	for key, value := range r.config.Headers {
-		httpReq.Header.Set(key, value)
-	}
-
-	resp, err := r.client.Do(httpReq)
-	if err != nil {
-		return "", fmt.Errorf("failed to execute request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	return string(body), nil
+	return common.Do(ctx, r.client, httpReq, req.Headers, r.options())
 }
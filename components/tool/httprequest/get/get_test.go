@@ -26,6 +26,8 @@ import (
 
 	"github.com/bytedance/mockey"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-ext/components/tool/httprequest/common"
 )
 
 type mockTransport struct {
@@ -176,7 +178,7 @@ func TestNewTool_Config(t *testing.T) {
 
 		doc, err := info.ParamsOneOf.ToJSONSchema()
 		assert.Nil(t, err)
-		assert.Equal(t, 1, doc.Properties.Len())
+		assert.Equal(t, 2, doc.Properties.Len())
 		for pair := doc.Properties.Oldest(); pair != nil; pair = pair.Next() {
 			assert.NotEqual(t, "", pair.Value.Description)
 		}
@@ -211,3 +213,62 @@ func TestGet_WithHeaders(t *testing.T) {
 	assert.Equal(t, "Bearer token", receivedHeaders.Get("Authorization"))
 	assert.Equal(t, "test-agent", receivedHeaders.Get("User-Agent"))
 }
+
+func TestGet_WithAuthAndLimits(t *testing.T) {
+	var receivedHeaders http.Header
+	mockTransport := &mockTransport{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			receivedHeaders = req.Header
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader("0123456789")),
+			}, nil
+		},
+	}
+	tool := &GetRequestTool{
+		config: &Config{
+			Headers:         map[string]string{"Accept": "text/plain"},
+			Auth:            &common.Auth{Type: common.AuthTypeBearer, Token: "secret"},
+			HostPolicy:      &common.HostPolicy{AllowedHosts: []string{"*.example.com"}},
+			MaxResponseSize: 4,
+		},
+		client: &http.Client{Transport: mockTransport},
+	}
+
+	result, err := tool.Get(context.Background(), &GetRequest{
+		URL:     "https://api.example.com/items",
+		Headers: map[string]string{"Accept": "application/json", "Authorization": "Bearer forged"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "0123\n[response truncated to 4 bytes]", result)
+	assert.Equal(t, "application/json", receivedHeaders.Get("Accept"))
+	assert.Equal(t, "Bearer secret", receivedHeaders.Get("Authorization"))
+
+	_, err = tool.Get(context.Background(), &GetRequest{URL: "https://internal.corp/admin"})
+	assert.ErrorIs(t, err, common.ErrHostNotAllowed)
+}
+
+func TestGet_ResponseTransform(t *testing.T) {
+	mockTransport := &mockTransport{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(`{"data": {"user": {"id": 1, "name": "eino", "bio": "long text"}}}`)),
+			}, nil
+		},
+	}
+
+	_, err := newRequestTool(&Config{ResponseTransform: &common.ResponseTransform{JQ: `.data[`}})
+	assert.ErrorContains(t, err, "invalid jq expression")
+
+	tool, err := newRequestTool(&Config{
+		HttpClient:        &http.Client{Transport: mockTransport},
+		ResponseTransform: &common.ResponseTransform{JQ: `.data.user | {id, name}`},
+	})
+	assert.NoError(t, err)
+
+	result, err := tool.Get(context.Background(), &GetRequest{URL: "https://example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"id":1,"name":"eino"}`, result)
+}
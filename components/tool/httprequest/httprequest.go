@@ -20,11 +20,15 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/cloudwego/eino-ext/components/tool/httprequest/common"
 	"github.com/cloudwego/eino-ext/components/tool/httprequest/delete"
 	"github.com/cloudwego/eino-ext/components/tool/httprequest/get"
+	"github.com/cloudwego/eino-ext/components/tool/httprequest/patch"
 	"github.com/cloudwego/eino-ext/components/tool/httprequest/post"
 	"github.com/cloudwego/eino-ext/components/tool/httprequest/put"
+	"github.com/cloudwego/eino-ext/components/tool/httprequest/upload"
 
 	"github.com/cloudwego/eino/components/tool"
 )
@@ -40,6 +44,40 @@ type Config struct {
 	// If not provided, a default client with a 30-second timeout and a standard transport
 	// will be initialized and used.
 	HttpClient *http.Client
+
+	// Optional.
+	// Auth is the authentication added to every request: a bearer token, basic credentials or an API key header.
+	Auth *common.Auth `json:"auth"`
+
+	// Optional.
+	// HostPolicy restricts the hosts the requests can be sent to, which prevents server-side request forgery
+	// when the model chooses the URL.
+	HostPolicy *common.HostPolicy `json:"host_policy"`
+
+	// Optional. Default: 0, no limit.
+	// MaxResponseSize is the maximum size in bytes of the response body returned to the model.
+	MaxResponseSize int64 `json:"max_response_size"`
+
+	// Optional. Default: 0, the timeout of the HttpClient.
+	// Timeout bounds the duration of each request, including reading the response body.
+	Timeout time.Duration `json:"timeout"`
+
+	// Optional. Default: "", the response bodies are returned to the model.
+	// DownloadDir enables the downloads: the binary response bodies, and the ones longer than MaxResponseSize,
+	// are streamed to a file in DownloadDir, and the model gets the file path and the content type instead.
+	DownloadDir string `json:"download_dir"`
+
+	// Optional. Default: 0, no limit.
+	// MaxDownloadSize is the maximum size in bytes of the downloaded files.
+	MaxDownloadSize int64 `json:"max_download_size"`
+
+	// Optional. Default: nil, the response bodies are returned as is.
+	// ResponseTransform extracts the fields the model needs from the JSON response bodies with a jq or JSONPath expression.
+	ResponseTransform *common.ResponseTransform `json:"response_transform"`
+
+	// Optional. Default: "", no upload tool.
+	// UploadDir adds the upload tool, which uploads the files of UploadDir as multipart/form-data requests.
+	UploadDir string `json:"upload_dir"`
 }
 
 func NewToolKit(ctx context.Context, conf *Config) ([]tool.BaseTool, error) {
@@ -47,6 +85,13 @@ func NewToolKit(ctx context.Context, conf *Config) ([]tool.BaseTool, error) {
 	if conf != nil {
 		getConf.Headers = conf.Headers
 		getConf.HttpClient = conf.HttpClient
+		getConf.Auth = conf.Auth
+		getConf.HostPolicy = conf.HostPolicy
+		getConf.MaxResponseSize = conf.MaxResponseSize
+		getConf.Timeout = conf.Timeout
+		getConf.DownloadDir = conf.DownloadDir
+		getConf.MaxDownloadSize = conf.MaxDownloadSize
+		getConf.ResponseTransform = conf.ResponseTransform
 	}
 
 	getTool, err := get.NewTool(ctx, getConf)
@@ -58,6 +103,13 @@ func NewToolKit(ctx context.Context, conf *Config) ([]tool.BaseTool, error) {
 	if conf != nil {
 		postConf.Headers = conf.Headers
 		postConf.HttpClient = conf.HttpClient
+		postConf.Auth = conf.Auth
+		postConf.HostPolicy = conf.HostPolicy
+		postConf.MaxResponseSize = conf.MaxResponseSize
+		postConf.Timeout = conf.Timeout
+		postConf.DownloadDir = conf.DownloadDir
+		postConf.MaxDownloadSize = conf.MaxDownloadSize
+		postConf.ResponseTransform = conf.ResponseTransform
 	}
 	postTool, err := post.NewTool(ctx, postConf)
 	if err != nil {
@@ -68,21 +120,71 @@ func NewToolKit(ctx context.Context, conf *Config) ([]tool.BaseTool, error) {
 	if conf != nil {
 		putConf.Headers = conf.Headers
 		putConf.HttpClient = conf.HttpClient
+		putConf.Auth = conf.Auth
+		putConf.HostPolicy = conf.HostPolicy
+		putConf.MaxResponseSize = conf.MaxResponseSize
+		putConf.Timeout = conf.Timeout
+		putConf.DownloadDir = conf.DownloadDir
+		putConf.MaxDownloadSize = conf.MaxDownloadSize
+		putConf.ResponseTransform = conf.ResponseTransform
 	}
 	putTool, err := put.NewTool(ctx, putConf)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create tool PUT: %w", err)
 	}
 
+	patchConf := &patch.Config{}
+	if conf != nil {
+		patchConf.Headers = conf.Headers
+		patchConf.HttpClient = conf.HttpClient
+		patchConf.Auth = conf.Auth
+		patchConf.HostPolicy = conf.HostPolicy
+		patchConf.MaxResponseSize = conf.MaxResponseSize
+		patchConf.Timeout = conf.Timeout
+		patchConf.DownloadDir = conf.DownloadDir
+		patchConf.MaxDownloadSize = conf.MaxDownloadSize
+		patchConf.ResponseTransform = conf.ResponseTransform
+	}
+	patchTool, err := patch.NewTool(ctx, patchConf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tool PATCH: %w", err)
+	}
+
 	deleteConf := &delete.Config{}
 	if conf != nil {
 		deleteConf.Headers = conf.Headers
 		deleteConf.HttpClient = conf.HttpClient
+		deleteConf.Auth = conf.Auth
+		deleteConf.HostPolicy = conf.HostPolicy
+		deleteConf.MaxResponseSize = conf.MaxResponseSize
+		deleteConf.Timeout = conf.Timeout
+		deleteConf.DownloadDir = conf.DownloadDir
+		deleteConf.MaxDownloadSize = conf.MaxDownloadSize
+		deleteConf.ResponseTransform = conf.ResponseTransform
 	}
 	deleteTool, err := delete.NewTool(ctx, deleteConf)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create tool DELETE: %w", err)
 	}
 
-	return []tool.BaseTool{getTool, postTool, putTool, deleteTool}, nil
+	tools := []tool.BaseTool{getTool, postTool, putTool, patchTool, deleteTool}
+
+	if conf != nil && conf.UploadDir != "" {
+		uploadTool, err := upload.NewTool(ctx, &upload.Config{
+			UploadDir:         conf.UploadDir,
+			Headers:           conf.Headers,
+			HttpClient:        conf.HttpClient,
+			Auth:              conf.Auth,
+			HostPolicy:        conf.HostPolicy,
+			MaxResponseSize:   conf.MaxResponseSize,
+			Timeout:           conf.Timeout,
+			ResponseTransform: conf.ResponseTransform,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create tool UPLOAD: %w", err)
+		}
+		tools = append(tools, uploadTool)
+	}
+
+	return tools, nil
 }
@@ -21,7 +21,10 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/cloudwego/eino/components/tool"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-ext/components/tool/httprequest/common"
 )
 
 func TestNewToolKit_Success(t *testing.T) {
@@ -35,7 +38,7 @@ func TestNewToolKit_Success(t *testing.T) {
 
 	tools, err := NewToolKit(ctx, conf)
 	assert.NoError(t, err)
-	assert.Len(t, tools, 4)
+	assert.Len(t, tools, 5)
 
 	var toolNames []string
 	for _, tool := range tools {
@@ -45,6 +48,7 @@ func TestNewToolKit_Success(t *testing.T) {
 	assert.Contains(t, toolNames, "request_get")
 	assert.Contains(t, toolNames, "requests_post")
 	assert.Contains(t, toolNames, "requests_put")
+	assert.Contains(t, toolNames, "requests_patch")
 	assert.Contains(t, toolNames, "requests_delete")
 }
 
@@ -52,7 +56,7 @@ func TestNewToolKit_NilConfig(t *testing.T) {
 	ctx := context.Background()
 	tools, err := NewToolKit(ctx, nil)
 	assert.NoError(t, err)
-	assert.Len(t, tools, 4)
+	assert.Len(t, tools, 5)
 
 	var toolNames []string
 	for _, tool := range tools {
@@ -62,5 +66,30 @@ func TestNewToolKit_NilConfig(t *testing.T) {
 	assert.Contains(t, toolNames, "request_get")
 	assert.Contains(t, toolNames, "requests_post")
 	assert.Contains(t, toolNames, "requests_put")
+	assert.Contains(t, toolNames, "requests_patch")
 	assert.Contains(t, toolNames, "requests_delete")
 }
+
+func TestNewToolKit_HostPolicy(t *testing.T) {
+	ctx := context.Background()
+	tools, err := NewToolKit(ctx, &Config{
+		HostPolicy: &common.HostPolicy{AllowedHosts: []string{"api.example.com"}},
+	})
+	assert.NoError(t, err)
+
+	for _, bt := range tools {
+		_, err = bt.(tool.InvokableTool).InvokableRun(ctx, `{"url": "http://169.254.169.254/latest/meta-data"}`)
+		assert.ErrorIs(t, err, common.ErrHostNotAllowed)
+	}
+}
+
+func TestNewToolKit_Upload(t *testing.T) {
+	ctx := context.Background()
+	tools, err := NewToolKit(ctx, &Config{UploadDir: t.TempDir()})
+	assert.NoError(t, err)
+	assert.Len(t, tools, 6)
+
+	info, err := tools[5].Info(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "requests_upload", info.Name)
+}
@@ -0,0 +1,42 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package patch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cloudwego/eino-ext/components/tool/httprequest/common"
+)
+
+type PatchRequest struct {
+	URL     string            `json:"url" jsonschema:"description=The URL to make the PATCH request"`
+	Body    string            `json:"body" jsonschema:"description=The body to send in the PATCH request"`
+	Headers map[string]string `json:"headers,omitempty" jsonschema:"description=Optional HTTP headers to send with the request"`
+}
+
+func (r *PatchRequestTool) Patch(ctx context.Context, req *PatchRequest) (string, error) {
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPatch, req.URL, strings.NewReader(req.Body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	return common.Do(ctx, r.client, httpReq, req.Headers, r.options())
+}
@@ -19,14 +19,16 @@ package post
 import (
 	"context"
 	"fmt"
-	"io"
 	"net/http"
 	"strings"
+
+	"github.com/cloudwego/eino-ext/components/tool/httprequest/common"
 )
 
 type PostRequest struct {
-	URL  string `json:"url" jsonschema:"description=The URL to make the POST request"`
-	Body string `json:"body" jsonschema:"description=The body to send in the POST request"`
+	URL     string            `json:"url" jsonschema:"description=The URL to make the POST request"`
+	Body    string            `json:"body" jsonschema:"description=The body to send in the POST request"`
+	Headers map[string]string `json:"headers,omitempty" jsonschema:"description=Optional HTTP headers to send with the request"`
 }
 
 func (r *PostRequestTool) Post(ctx context.Context, req *PostRequest) (string, error) {
@@ -36,20 +38,5 @@ func (r *PostRequestTool) Post(ctx context.Context, req *PostRequest) (string, e
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
-	for key, value := range r.config.Headers {
-		httpReq.Header.Set(key, value)
-	}
-
-	resp, err := r.client.Do(httpReq)
-	if err != nil {
-		return "", fmt.Errorf("failed to execute request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	return string(body), nil
+	return common.Do(ctx, r.client, httpReq, req.Headers, r.options())
 }
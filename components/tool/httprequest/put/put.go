@@ -19,14 +19,16 @@ package put
 import (
 	"context"
 	"fmt"
-	"io"
 	"net/http"
 	"strings"
+
+	"github.com/cloudwego/eino-ext/components/tool/httprequest/common"
 )
 
 type PutRequest struct {
-	URL  string `json:"url" jsonschema:"description=The URL to make the PUT request"`
-	Body string `json:"body" jsonschema:"description=The body to send in the PUT request"`
+	URL     string            `json:"url" jsonschema:"description=The URL to make the PUT request"`
+	Body    string            `json:"body" jsonschema:"description=The body to send in the PUT request"`
+	Headers map[string]string `json:"headers,omitempty" jsonschema:"description=Optional HTTP headers to send with the request"`
 }
 
 func (r *PutRequestTool) Put(ctx context.Context, req *PutRequest) (string, error) {
@@ -36,20 +38,5 @@ func (r *PutRequestTool) Put(ctx context.Context, req *PutRequest) (string, erro
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
-	for key, value := range r.config.Headers {
-		httpReq.Header.Set(key, value)
-	}
-
-	resp, err := r.client.Do(httpReq)
-	if err != nil {
-		return "", fmt.Errorf("failed to execute request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	return string(body), nil
+	return common.Do(ctx, r.client, httpReq, req.Headers, r.options())
 }
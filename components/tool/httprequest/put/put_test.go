@@ -175,7 +175,7 @@ func TestNewTool_Config(t *testing.T) {
 
 		doc, err := info.ParamsOneOf.ToJSONSchema()
 		assert.Nil(t, err)
-		assert.Equal(t, 2, doc.Properties.Len())
+		assert.Equal(t, 3, doc.Properties.Len())
 		for pair := doc.Properties.Oldest(); pair != nil; pair = pair.Next() {
 			assert.NotEqual(t, "", pair.Value.Description)
 		}
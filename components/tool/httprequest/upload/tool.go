@@ -0,0 +1,156 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package upload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+
+	"github.com/cloudwego/eino-ext/components/tool/httprequest/common"
+)
+
+type Config struct {
+	// Optional. Default: "requests_upload".
+	ToolName string `json:"tool_name"`
+
+	// Optional. Default: Use this when you want to upload a file to a website, as a multipart/form-data request.
+	// The output will be the text response of the request.
+	ToolDesc string `json:"tool_desc"`
+
+	// Required.
+	// UploadDir is the directory of the files which can be uploaded. The file paths chosen by the model are
+	// relative to it, and the files outside of it can't be uploaded.
+	UploadDir string `json:"upload_dir"`
+
+	// Optional.
+	// Headers is a map of HTTP header names to their corresponding values.
+	// These headers will be included in every request made by the tool.
+	Headers map[string]string `json:"headers"`
+
+	// Optional.
+	// HttpClient is the HTTP client used to perform the requests.
+	// If not provided, a default client with a 30-second timeout and a standard transport
+	// will be initialized and used.
+	HttpClient *http.Client
+
+	// Optional.
+	// Auth is the authentication added to every request: a bearer token, basic credentials or an API key header.
+	// The model can't override its header.
+	Auth *common.Auth `json:"auth"`
+
+	// Optional.
+	// HostPolicy restricts the hosts the requests can be sent to, which prevents server-side request forgery
+	// when the model chooses the URL.
+	HostPolicy *common.HostPolicy `json:"host_policy"`
+
+	// Optional. Default: 0, no limit.
+	// MaxResponseSize is the maximum size in bytes of the response body returned to the model.
+	// Longer bodies are truncated.
+	MaxResponseSize int64 `json:"max_response_size"`
+
+	// Optional. Default: 0, the timeout of the HttpClient.
+	// Timeout bounds the duration of each request, including reading the response body.
+	Timeout time.Duration `json:"timeout"`
+
+	// Optional. Default: nil, the response bodies are returned as is.
+	// ResponseTransform extracts the fields the model needs from the JSON response bodies with a jq or JSONPath
+	// expression, e.g. &common.ResponseTransform{JQ: `.items[] | {name, html_url}`}.
+	ResponseTransform *common.ResponseTransform `json:"response_transform"`
+}
+
+func (c *Config) validate() error {
+	if c.UploadDir == "" {
+		return errors.New("upload dir is required")
+	}
+	dir, err := filepath.Abs(c.UploadDir)
+	if err != nil {
+		return fmt.Errorf("invalid upload dir: %w", err)
+	}
+	if dir, err = filepath.EvalSymlinks(dir); err != nil {
+		return fmt.Errorf("invalid upload dir: %w", err)
+	}
+	c.UploadDir = dir
+
+	if c.ToolName == "" {
+		c.ToolName = "requests_upload"
+	}
+	if c.ToolDesc == "" {
+		c.ToolDesc = `Use this when you want to upload a file to a website, as a multipart/form-data request.
+		The output will be the text response of the request.`
+	}
+	if c.Headers == nil {
+		c.Headers = make(map[string]string)
+	}
+	if c.MaxResponseSize < 0 {
+		return errors.New("max response size must not be negative")
+	}
+	if err := c.ResponseTransform.Validate(); err != nil {
+		return err
+	}
+	c.HttpClient = common.NewClient(c.HttpClient, c.HostPolicy)
+	return nil
+}
+
+func NewTool(ctx context.Context, config *Config) (tool.InvokableTool, error) {
+	reqTool, err := newRequestTool(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request tool: %w", err)
+	}
+
+	invokableTool, err := utils.InferTool(config.ToolName, config.ToolDesc, reqTool.Upload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to infer the tool: %w", err)
+	}
+
+	return invokableTool, nil
+}
+
+type UploadRequestTool struct {
+	config *Config
+	client *http.Client
+}
+
+func newRequestTool(config *Config) (*UploadRequestTool, error) {
+	if config == nil {
+		return nil, errors.New("request tool configuration is required")
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	return &UploadRequestTool{
+		config: config,
+		client: config.HttpClient,
+	}, nil
+}
+
+func (r *UploadRequestTool) options() *common.Options {
+	return &common.Options{
+		Headers:           r.config.Headers,
+		Auth:              r.config.Auth,
+		HostPolicy:        r.config.HostPolicy,
+		MaxResponseSize:   r.config.MaxResponseSize,
+		Timeout:           r.config.Timeout,
+		ResponseTransform: r.config.ResponseTransform,
+	}
+}
@@ -0,0 +1,116 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package upload
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudwego/eino-ext/components/tool/httprequest/common"
+)
+
+type UploadRequest struct {
+	URL       string            `json:"url" jsonschema:"description=The URL to upload the file to"`
+	Method    string            `json:"method,omitempty" jsonschema:"description=The HTTP method of the request,enum=POST,enum=PUT,enum=PATCH"`
+	FilePath  string            `json:"file_path" jsonschema:"description=The path of the file to upload"`
+	FieldName string            `json:"field_name,omitempty" jsonschema:"description=The form field name of the file. Default: file"`
+	Fields    map[string]string `json:"fields,omitempty" jsonschema:"description=Optional form fields to send with the file"`
+	Headers   map[string]string `json:"headers,omitempty" jsonschema:"description=Optional HTTP headers to send with the request"`
+}
+
+func (r *UploadRequestTool) Upload(ctx context.Context, req *UploadRequest) (string, error) {
+	method := strings.ToUpper(req.Method)
+	switch method {
+	case "":
+		method = http.MethodPost
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+	default:
+		return "", fmt.Errorf("unsupported upload method: %s", req.Method)
+	}
+	fieldName := req.FieldName
+	if fieldName == "" {
+		fieldName = "file"
+	}
+
+	path, err := r.resolve(req.FilePath)
+	if err != nil {
+		return "", err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	// stream the form, so that the large files are not loaded in memory
+	pr, pw := io.Pipe()
+	form := multipart.NewWriter(pw)
+	go func() {
+		pw.CloseWithError(writeForm(form, fieldName, filepath.Base(path), file, req.Fields))
+	}()
+
+	// stops the writer when the request failed before sending the body
+	defer pr.Close()
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, req.URL, pr)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", form.FormDataContentType())
+
+	return common.Do(ctx, r.client, httpReq, req.Headers, r.options())
+}
+
+// resolve returns the absolute path of the file, which must be in the upload dir.
+func (r *UploadRequestTool) resolve(name string) (string, error) {
+	path := name
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(r.config.UploadDir, path)
+	}
+	path, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+
+	rel, err := filepath.Rel(r.config.UploadDir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("file is outside of the upload dir: %s", name)
+	}
+
+	return path, nil
+}
+
+func writeForm(form *multipart.Writer, fieldName, fileName string, file io.Reader, fields map[string]string) error {
+	for k, v := range fields {
+		if err := form.WriteField(k, v); err != nil {
+			return err
+		}
+	}
+	part, err := form.CreateFormFile(fieldName, fileName)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(part, file); err != nil {
+		return err
+	}
+	return form.Close()
+}
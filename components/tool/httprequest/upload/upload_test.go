@@ -0,0 +1,105 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package upload
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-ext/components/tool/httprequest/common"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	err := (&Config{}).validate()
+	assert.EqualError(t, err, "upload dir is required")
+
+	dir := t.TempDir()
+	config := &Config{UploadDir: dir}
+	assert.NoError(t, config.validate())
+	assert.Equal(t, "requests_upload", config.ToolName)
+	assert.NotEmpty(t, config.ToolDesc)
+	assert.NotNil(t, config.HttpClient)
+	assert.True(t, filepath.IsAbs(config.UploadDir))
+}
+
+func TestUpload(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("attachment")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		content, _ := io.ReadAll(file)
+		fmt.Fprintf(w, "%s %s %s %s %s", r.Method, header.Filename, content, r.FormValue("title"), r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "reports"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "reports", "q2.csv"), []byte("a,b\n1,2"), 0o644))
+	outside := filepath.Join(t.TempDir(), "secret.txt")
+	assert.NoError(t, os.WriteFile(outside, []byte("secret"), 0o644))
+	assert.NoError(t, os.Symlink(outside, filepath.Join(dir, "link.txt")))
+
+	tool, err := newRequestTool(&Config{UploadDir: dir, Auth: &common.Auth{Type: common.AuthTypeBearer, Token: "t"}})
+	assert.NoError(t, err)
+
+	result, err := tool.Upload(ctx, &UploadRequest{
+		URL:       server.URL,
+		Method:    "put",
+		FilePath:  "reports/q2.csv",
+		FieldName: "attachment",
+		Fields:    map[string]string{"title": "Q2"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "PUT q2.csv a,b\n1,2 Q2 Bearer t", result)
+
+	for _, path := range []string{"../secret.txt", outside, "link.txt"} {
+		_, err = tool.Upload(ctx, &UploadRequest{URL: server.URL, FilePath: path})
+		assert.Error(t, err, path)
+	}
+
+	_, err = tool.Upload(ctx, &UploadRequest{URL: server.URL, Method: "GET", FilePath: "reports/q2.csv"})
+	assert.EqualError(t, err, "unsupported upload method: GET")
+
+	tool, err = newRequestTool(&Config{UploadDir: dir, HostPolicy: &common.HostPolicy{DenyPrivateNetworks: true}})
+	assert.NoError(t, err)
+	_, err = tool.Upload(ctx, &UploadRequest{URL: server.URL, FilePath: "reports/q2.csv"})
+	assert.ErrorIs(t, err, common.ErrHostNotAllowed)
+}
+
+func TestNewTool(t *testing.T) {
+	tool, err := NewTool(context.Background(), &Config{UploadDir: t.TempDir()})
+	assert.NoError(t, err)
+
+	info, err := tool.Info(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "requests_upload", info.Name)
+
+	doc, err := info.ParamsOneOf.ToJSONSchema()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"url", "file_path"}, doc.Required)
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino-ext/components/tool/imagegen"
+)
+
+func main() {
+	ctx := context.Background()
+
+	imageTool, err := imagegen.NewTool(ctx, &imagegen.Config{
+		Provider: imagegen.ProviderOpenAI,
+		APIKey:   os.Getenv("OPENAI_API_KEY"),
+		Model:    "gpt-image-1",
+		Sizes:    []string{"1024x1024", "1536x1024", "1024x1536"},
+		Quality:  "medium",
+		// gpt-image-1 returns base64 images, save them instead of returning them to the model
+		SaveDir: "./images",
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	out, err := imageTool.InvokableRun(ctx, `{"prompt":"A flat illustration of the Go gopher reading a book, pastel colors","size":"1024x1024"}`)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Println(out)
+}
@@ -0,0 +1,225 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package imagegen
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// GenerateRequest is the request of the image generation tool.
+type GenerateRequest struct {
+	Prompt  string `json:"prompt" jsonschema:"required,description=The detailed description of the image to generate"`
+	Size    string `json:"size,omitempty" jsonschema:"description=The size of the image in pixels, e.g. 1024x1024. Leave empty for the default size"`
+	Quality string `json:"quality,omitempty" jsonschema:"description=The quality of the image, e.g. standard or hd, low, medium or high depending on the model. Leave empty for the default quality"`
+	Style   string `json:"style,omitempty" jsonschema:"description=The style of the image, vivid or natural, only supported by some models"`
+	N       int    `json:"n,omitempty" jsonschema:"description=The number of images to generate, default 1"`
+}
+
+// GenerateResponse is the response of the image generation tool.
+type GenerateResponse struct {
+	Images []*Image `json:"images"`
+}
+
+// Image is a generated image, returned as a url, a saved file, or base64 data.
+type Image struct {
+	URL     string `json:"url,omitempty"`
+	Path    string `json:"path,omitempty"`
+	B64JSON string `json:"b64_json,omitempty"`
+	// RevisedPrompt is the prompt the model rewrote and used, returned by dall-e-3.
+	RevisedPrompt string `json:"revised_prompt,omitempty"`
+}
+
+type generator struct {
+	conf *Config
+}
+
+func newGenerator(conf *Config) (*generator, error) {
+	if err := conf.validate(); err != nil {
+		return nil, err
+	}
+	return &generator{conf: conf}, nil
+}
+
+type apiResponse struct {
+	Data []struct {
+		URL           string `json:"url"`
+		B64JSON       string `json:"b64_json"`
+		RevisedPrompt string `json:"revised_prompt"`
+	} `json:"data"`
+	Error *struct {
+		Code    any    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Generate generates images from the prompt.
+func (g *generator) Generate(ctx context.Context, req *GenerateRequest) (*GenerateResponse, error) {
+	if strings.TrimSpace(req.Prompt) == "" {
+		return nil, errors.New("prompt is required")
+	}
+	n := max(req.N, 1)
+	if n > g.conf.MaxImages {
+		return nil, fmt.Errorf("at most %d images can be generated at once", g.conf.MaxImages)
+	}
+	size := req.Size
+	if size == "" {
+		size = g.conf.Size
+	}
+	if size != "" && len(g.conf.Sizes) > 0 && !slices.Contains(g.conf.Sizes, size) {
+		return nil, fmt.Errorf("unsupported size: %s, supported sizes: %s", size, strings.Join(g.conf.Sizes, ", "))
+	}
+
+	body := make(map[string]any, len(g.conf.ExtraParams)+7)
+	for k, v := range g.conf.ExtraParams {
+		body[k] = v
+	}
+	body["model"] = g.conf.Model
+	body["prompt"] = req.Prompt
+	if size != "" {
+		body["size"] = size
+	}
+	// the gpt-image models reject the response_format parameter, and always return base64
+	if !strings.HasPrefix(g.conf.Model, "gpt-image") {
+		body["response_format"] = string(g.conf.ResponseFormat)
+	}
+
+	calls := 1
+	switch g.conf.Provider {
+	case ProviderOpenAI:
+		body["n"] = n
+		if quality := firstNonEmpty(req.Quality, g.conf.Quality); quality != "" {
+			body["quality"] = quality
+		}
+		if style := firstNonEmpty(req.Style, g.conf.Style); style != "" {
+			body["style"] = style
+		}
+	case ProviderArk:
+		// the text to image models of ark generate a single image per request
+		calls = n
+	}
+
+	resp := &GenerateResponse{}
+	for i := 0; i < calls; i++ {
+		images, err := g.generate(ctx, body)
+		if err != nil {
+			return nil, err
+		}
+		resp.Images = append(resp.Images, images...)
+	}
+	return resp, nil
+}
+
+func (g *generator) generate(ctx context.Context, body map[string]any) ([]*Image, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(g.conf.BaseURL, "/")+"/images/generations", bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+g.conf.APIKey)
+
+	httpResp, err := g.conf.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var res apiResponse
+	if err = json.Unmarshal(respBody, &res); err != nil {
+		if httpResp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("image generation failed, status code: %d, body: %s", httpResp.StatusCode, respBody)
+		}
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if res.Error != nil {
+		return nil, fmt.Errorf("image generation failed, status code: %d, code: %v, message: %s", httpResp.StatusCode, res.Error.Code, res.Error.Message)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("image generation failed, status code: %d", httpResp.StatusCode)
+	}
+	if len(res.Data) == 0 {
+		return nil, errors.New("image generation failed, no image returned")
+	}
+
+	images := make([]*Image, 0, len(res.Data))
+	for _, d := range res.Data {
+		img := &Image{URL: d.URL, B64JSON: d.B64JSON, RevisedPrompt: d.RevisedPrompt}
+		if img.B64JSON != "" && g.conf.SaveDir != "" {
+			if img.Path, err = g.save(img.B64JSON); err != nil {
+				return nil, err
+			}
+			img.B64JSON = ""
+		}
+		images = append(images, img)
+	}
+	return images, nil
+}
+
+// save decodes the base64 image and saves it to SaveDir, with the extension of its format.
+func (g *generator) save(b64 string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(b64)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	ext := ".png"
+	switch http.DetectContentType(data) {
+	case "image/jpeg":
+		ext = ".jpg"
+	case "image/webp":
+		ext = ".webp"
+	}
+
+	f, err := os.CreateTemp(g.conf.SaveDir, fmt.Sprintf("image-%s-*%s", time.Now().Format("20060102-150405"), ext))
+	if err != nil {
+		return "", fmt.Errorf("failed to create image file: %w", err)
+	}
+	defer f.Close()
+	if _, err = f.Write(data); err != nil {
+		return "", fmt.Errorf("failed to write image file: %w", err)
+	}
+	return filepath.Abs(f.Name())
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
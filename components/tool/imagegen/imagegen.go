@@ -0,0 +1,171 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package imagegen provides a tool generating images with the OpenAI Images API (gpt-image, DALL-E)
+// or the Ark image generation API (Doubao Seedream), which share the same interface.
+package imagegen
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+)
+
+// Provider is the image generation API.
+type Provider string
+
+const (
+	ProviderOpenAI Provider = "openai"
+	ProviderArk    Provider = "ark"
+)
+
+// ResponseFormat is how the API returns the images.
+type ResponseFormat string
+
+const (
+	// ResponseFormatURL returns temporary urls of the images, valid for about an hour with OpenAI and 24 hours with Ark.
+	ResponseFormatURL ResponseFormat = "url"
+	// ResponseFormatB64JSON returns the images encoded in base64. The gpt-image models always return base64.
+	ResponseFormatB64JSON ResponseFormat = "b64_json"
+)
+
+// Config is the configuration for the image generation tool.
+type Config struct {
+	// Provider is the image generation API.
+	// Required.
+	Provider Provider
+	// APIKey is the API key of the provider.
+	// Required.
+	APIKey string
+	// Model is the image model, e.g. "gpt-image-1" or "dall-e-3" for OpenAI, or the model or endpoint id for Ark,
+	// e.g. "doubao-seedream-3-0-t2i-250415".
+	// Required.
+	Model string
+	// BaseURL is the base url of the API.
+	// Optional. Default "https://api.openai.com/v1" for OpenAI, "https://ark.cn-beijing.volces.com/api/v3" for Ark.
+	BaseURL string
+	// HTTPClient is the http client sending the requests.
+	// Optional. Default a client with Timeout.
+	HTTPClient *http.Client
+	// Timeout is the maximum duration of each request, generating images taking up to a minute.
+	// Optional. Default 2 minutes.
+	Timeout time.Duration
+
+	// ResponseFormat is how the API returns the images. Ignored by the gpt-image models which always return base64.
+	// Optional. Default ResponseFormatURL.
+	ResponseFormat ResponseFormat
+	// SaveDir is the directory the base64 images are saved to, their paths being returned instead of the base64 data,
+	// which would fill the context window of the model.
+	// Optional. Default the base64 data is returned.
+	SaveDir string
+
+	// Size is the default size of the images, e.g. "1024x1024", or "2K" for Ark.
+	// Optional. Default the default of the model.
+	Size string
+	// Sizes are the sizes the model can choose, e.g. []string{"1024x1024", "1536x1024", "1024x1536"}.
+	// Optional. Default any size is sent to the API.
+	Sizes []string
+	// Quality is the default quality of the images, e.g. "standard" or "hd" for dall-e-3, "low", "medium" or "high" for gpt-image-1.
+	// Not supported by Ark.
+	// Optional. Default the default of the model.
+	Quality string
+	// Style is the default style of the images, "vivid" or "natural", only supported by dall-e-3.
+	// Optional. Default the default of the model.
+	Style string
+	// MaxImages is the maximum number of images of a call.
+	// Optional. Default 1.
+	MaxImages int
+	// ExtraParams are added to the body of the requests, e.g. {"watermark": false} or {"seed": 42} for Ark,
+	// {"background": "transparent"} or {"output_format": "webp"} for gpt-image-1.
+	// Optional.
+	ExtraParams map[string]any
+
+	ToolName string // Optional. Default "image_generation".
+	ToolDesc string // Optional. Default "generate images from a text description".
+}
+
+// NewTool creates a new image generation tool.
+func NewTool(ctx context.Context, conf *Config) (tool.InvokableTool, error) {
+	g, err := newGenerator(conf)
+	if err != nil {
+		return nil, err
+	}
+	t, err := utils.InferTool(conf.ToolName, conf.ToolDesc, g.Generate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to infer tool: %w", err)
+	}
+	return t, nil
+}
+
+// validate validates the configuration and sets default values if not provided.
+func (conf *Config) validate() error {
+	if conf == nil {
+		return fmt.Errorf("config is nil")
+	}
+	switch conf.Provider {
+	case ProviderOpenAI:
+		if conf.BaseURL == "" {
+			conf.BaseURL = "https://api.openai.com/v1"
+		}
+	case ProviderArk:
+		if conf.BaseURL == "" {
+			conf.BaseURL = "https://ark.cn-beijing.volces.com/api/v3"
+		}
+	case "":
+		return fmt.Errorf("provider is required")
+	default:
+		return fmt.Errorf("unsupported provider: %s", conf.Provider)
+	}
+	if conf.APIKey == "" {
+		return fmt.Errorf("api key is required")
+	}
+	if conf.Model == "" {
+		return fmt.Errorf("model is required")
+	}
+	if conf.Timeout <= 0 {
+		conf.Timeout = 2 * time.Minute
+	}
+	if conf.HTTPClient == nil {
+		conf.HTTPClient = &http.Client{Timeout: conf.Timeout}
+	}
+	switch conf.ResponseFormat {
+	case "":
+		conf.ResponseFormat = ResponseFormatURL
+	case ResponseFormatURL, ResponseFormatB64JSON:
+	default:
+		return fmt.Errorf("unsupported response format: %s", conf.ResponseFormat)
+	}
+	if conf.SaveDir != "" {
+		if err := os.MkdirAll(conf.SaveDir, 0o755); err != nil {
+			return fmt.Errorf("failed to create save dir: %w", err)
+		}
+	}
+	if conf.MaxImages <= 0 {
+		conf.MaxImages = 1
+	}
+	if conf.ToolName == "" {
+		conf.ToolName = "image_generation"
+	}
+	if conf.ToolDesc == "" {
+		conf.ToolDesc = "generate images from a text description. Describe the subject, style, composition and colors in detail."
+	}
+	return nil
+}
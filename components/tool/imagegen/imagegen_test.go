@@ -0,0 +1,195 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package imagegen
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pngHeader is the signature of the png files, enough for the content type detection.
+var pngHeader = []byte("\x89PNG\r\n\x1a\n")
+
+func newTestServer(t *testing.T, handler func(body map[string]any) (int, string)) (*httptest.Server, *[]map[string]any) {
+	var bodies []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/images/generations", r.URL.Path)
+		assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+		data, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		var body map[string]any
+		assert.NoError(t, json.Unmarshal(data, &body))
+		bodies = append(bodies, body)
+
+		code, resp := handler(body)
+		w.WriteHeader(code)
+		_, _ = w.Write([]byte(resp))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &bodies
+}
+
+func TestNewTool(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewTool(ctx, nil)
+	assert.EqualError(t, err, "config is nil")
+	_, err = NewTool(ctx, &Config{APIKey: "key", Model: "dall-e-3"})
+	assert.EqualError(t, err, "provider is required")
+	_, err = NewTool(ctx, &Config{Provider: "midjourney", APIKey: "key", Model: "v6"})
+	assert.EqualError(t, err, "unsupported provider: midjourney")
+	_, err = NewTool(ctx, &Config{Provider: ProviderOpenAI, Model: "dall-e-3"})
+	assert.EqualError(t, err, "api key is required")
+	_, err = NewTool(ctx, &Config{Provider: ProviderOpenAI, APIKey: "key"})
+	assert.EqualError(t, err, "model is required")
+	_, err = NewTool(ctx, &Config{Provider: ProviderOpenAI, APIKey: "key", Model: "dall-e-3", ResponseFormat: "png"})
+	assert.EqualError(t, err, "unsupported response format: png")
+
+	tl, err := NewTool(ctx, &Config{Provider: ProviderArk, APIKey: "key", Model: "doubao-seedream-3-0-t2i-250415"})
+	assert.NoError(t, err)
+	info, err := tl.Info(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "image_generation", info.Name)
+}
+
+func TestGenerate_OpenAI(t *testing.T) {
+	ctx := context.Background()
+	srv, bodies := newTestServer(t, func(body map[string]any) (int, string) {
+		if body["prompt"] == "forbidden" {
+			return http.StatusBadRequest, `{"error":{"code":"content_policy_violation","message":"Your request was rejected by the safety system.","type":"invalid_request_error"}}`
+		}
+		return http.StatusOK, `{"created":1700000000,"data":[{"url":"https://images.example.com/1.png","revised_prompt":"A watercolor painting of a gopher."}]}`
+	})
+
+	tl, err := NewTool(ctx, &Config{
+		Provider:    ProviderOpenAI,
+		APIKey:      "key",
+		Model:       "dall-e-3",
+		BaseURL:     srv.URL,
+		Size:        "1024x1024",
+		Sizes:       []string{"1024x1024", "1792x1024"},
+		Quality:     "standard",
+		ExtraParams: map[string]any{"user": "user-1", "model": "ignored"},
+	})
+	assert.NoError(t, err)
+
+	out, err := tl.InvokableRun(ctx, `{"prompt":"a gopher","size":"1792x1024","quality":"hd","style":"natural"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"model":           "dall-e-3",
+		"prompt":          "a gopher",
+		"size":            "1792x1024",
+		"quality":         "hd",
+		"style":           "natural",
+		"response_format": "url",
+		"n":               float64(1),
+		"user":            "user-1",
+	}, (*bodies)[0])
+
+	var resp GenerateResponse
+	assert.NoError(t, json.Unmarshal([]byte(out), &resp))
+	assert.Equal(t, []*Image{{URL: "https://images.example.com/1.png", RevisedPrompt: "A watercolor painting of a gopher."}}, resp.Images)
+
+	_, err = tl.InvokableRun(ctx, `{"prompt":"a gopher"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "1024x1024", (*bodies)[1]["size"])
+	assert.Equal(t, "standard", (*bodies)[1]["quality"])
+	assert.NotContains(t, (*bodies)[1], "style")
+
+	_, err = tl.InvokableRun(ctx, `{"prompt":"a gopher","size":"512x512"}`)
+	assert.ErrorContains(t, err, "unsupported size: 512x512, supported sizes: 1024x1024, 1792x1024")
+	_, err = tl.InvokableRun(ctx, `{"prompt":"a gopher","n":2}`)
+	assert.ErrorContains(t, err, "at most 1 images can be generated at once")
+	_, err = tl.InvokableRun(ctx, `{"prompt":""}`)
+	assert.ErrorContains(t, err, "prompt is required")
+	_, err = tl.InvokableRun(ctx, `{"prompt":"forbidden"}`)
+	assert.ErrorContains(t, err, "image generation failed, status code: 400, code: content_policy_violation, message: Your request was rejected by the safety system.")
+}
+
+func TestGenerate_GPTImageSaved(t *testing.T) {
+	ctx := context.Background()
+	b64 := base64.StdEncoding.EncodeToString(append(pngHeader, "data"...))
+	srv, bodies := newTestServer(t, func(body map[string]any) (int, string) {
+		return http.StatusOK, `{"created":1700000000,"data":[{"b64_json":"` + b64 + `"},{"b64_json":"` + b64 + `"}]}`
+	})
+
+	dir := filepath.Join(t.TempDir(), "images")
+	g, err := newGenerator(&Config{
+		Provider:  ProviderOpenAI,
+		APIKey:    "key",
+		Model:     "gpt-image-1",
+		BaseURL:   srv.URL,
+		SaveDir:   dir,
+		MaxImages: 4,
+	})
+	assert.NoError(t, err)
+
+	resp, err := g.Generate(ctx, &GenerateRequest{Prompt: "a gopher", N: 2})
+	assert.NoError(t, err)
+	assert.NotContains(t, (*bodies)[0], "response_format")
+	assert.Equal(t, float64(2), (*bodies)[0]["n"])
+
+	assert.Equal(t, 2, len(resp.Images))
+	for _, img := range resp.Images {
+		assert.Empty(t, img.B64JSON)
+		assert.Equal(t, dir, filepath.Dir(img.Path))
+		assert.Equal(t, ".png", filepath.Ext(img.Path))
+		data, err := os.ReadFile(img.Path)
+		assert.NoError(t, err)
+		assert.Equal(t, append(pngHeader, "data"...), data)
+	}
+	assert.NotEqual(t, resp.Images[0].Path, resp.Images[1].Path)
+}
+
+func TestGenerate_Ark(t *testing.T) {
+	ctx := context.Background()
+	srv, bodies := newTestServer(t, func(body map[string]any) (int, string) {
+		return http.StatusOK, `{"model":"doubao-seedream-3-0-t2i-250415","created":1700000000,"data":[{"b64_json":"aW1hZ2U="}],"usage":{"generated_images":1}}`
+	})
+
+	g, err := newGenerator(&Config{
+		Provider:       ProviderArk,
+		APIKey:         "key",
+		Model:          "doubao-seedream-3-0-t2i-250415",
+		BaseURL:        srv.URL,
+		ResponseFormat: ResponseFormatB64JSON,
+		MaxImages:      2,
+		ExtraParams:    map[string]any{"watermark": false},
+	})
+	assert.NoError(t, err)
+
+	resp, err := g.Generate(ctx, &GenerateRequest{Prompt: "a gopher", Quality: "hd", N: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []*Image{{B64JSON: "aW1hZ2U="}, {B64JSON: "aW1hZ2U="}}, resp.Images)
+	// a request per image, without the parameters ark does not support
+	assert.Equal(t, 2, len(*bodies))
+	assert.Equal(t, map[string]any{
+		"model":           "doubao-seedream-3-0-t2i-250415",
+		"prompt":          "a gopher",
+		"response_format": "b64_json",
+		"watermark":       false,
+	}, (*bodies)[0])
+}
@@ -0,0 +1,157 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// maxResults is the maximum number of issues of a search page allowed by the API.
+const maxResults = 100
+
+var issueKeyPattern = regexp.MustCompile(`^([A-Z][A-Z0-9_]+)-[1-9][0-9]*$`)
+
+type client struct {
+	conf *Config
+}
+
+func newClient(conf *Config) (*client, error) {
+	if err := conf.validate(); err != nil {
+		return nil, err
+	}
+	return &client{conf: conf}, nil
+}
+
+// checkProject checks the project is in the allowlist.
+func (c *client) checkProject(project string) error {
+	if len(c.conf.Projects) > 0 && !slices.Contains(c.conf.Projects, project) {
+		return fmt.Errorf("project is not allowed: %s", project)
+	}
+	return nil
+}
+
+// checkIssueKey checks the issue key is well-formed, e.g. "PROJ-123", and its project is allowed.
+func (c *client) checkIssueKey(key string) error {
+	m := issueKeyPattern.FindStringSubmatch(key)
+	if m == nil {
+		return fmt.Errorf("invalid issue key: %q, expected e.g. PROJ-123", key)
+	}
+	return c.checkProject(m[1])
+}
+
+func (c *client) issueURL(key string) string {
+	return c.conf.BaseURL + "/browse/" + key
+}
+
+// assignee returns the assignee field, the account id for Jira Cloud, the user name for Jira Data Center.
+func (c *client) assignee(user string) map[string]any {
+	if c.conf.cloud() {
+		return map[string]any{"accountId": user}
+	}
+	return map[string]any{"name": user}
+}
+
+type apiError struct {
+	ErrorMessages []string          `json:"errorMessages"`
+	Errors        map[string]string `json:"errors"`
+}
+
+// do sends the request to the REST API and decodes the json response into out.
+func (c *client) do(ctx context.Context, method, apiPath string, query url.Values, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	u := c.conf.BaseURL + apiPath
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.conf.PersonalAccessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.conf.PersonalAccessToken)
+	} else {
+		req.SetBasicAuth(c.conf.Email, c.conf.APIToken)
+	}
+
+	resp, err := c.conf.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("jira api error, status code: %d, message: %s", resp.StatusCode, errorMessage(respBody))
+	}
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+	if err = json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return nil
+}
+
+// errorMessage joins the messages and the field errors of the response.
+func errorMessage(body []byte) string {
+	var e apiError
+	if err := json.Unmarshal(body, &e); err != nil || (len(e.ErrorMessages) == 0 && len(e.Errors) == 0) {
+		return strings.TrimSpace(string(body))
+	}
+	msgs := append([]string(nil), e.ErrorMessages...)
+	fields := make([]string, 0, len(e.Errors))
+	for f := range e.Errors {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	for _, f := range fields {
+		msgs = append(msgs, f+": "+e.Errors[f])
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func truncate(s string, maxLen int) string {
+	r := []rune(s)
+	if len(r) <= maxLen {
+		return s
+	}
+	return string(r[:maxLen]) + "\n[content truncated]"
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/components/tool"
+
+	"github.com/cloudwego/eino-ext/components/tool/jira"
+)
+
+func main() {
+	ctx := context.Background()
+
+	tools, err := jira.NewToolKit(ctx, &jira.Config{
+		BaseURL:     os.Getenv("JIRA_BASE_URL"),
+		Email:       os.Getenv("JIRA_EMAIL"),
+		APIToken:    os.Getenv("JIRA_API_TOKEN"),
+		Projects:    []string{os.Getenv("JIRA_PROJECT")},
+		EnableWrite: true,
+	})
+	if err != nil {
+		log.Fatalf("NewToolKit failed, err=%v", err)
+	}
+
+	search := tools[0].(tool.InvokableTool)
+	out, err := search.InvokableRun(ctx, `{"jql":"status != Done ORDER BY updated DESC","max_results":5}`)
+	if err != nil {
+		log.Fatalf("jira_search failed, err=%v", err)
+	}
+	fmt.Println(out)
+
+	create := tools[1].(tool.InvokableTool)
+	out, err = create.InvokableRun(ctx, fmt.Sprintf(`{"project":%q,"summary":"Created by the Eino Jira tool","issue_type":"Task","labels":["eino"]}`,
+		os.Getenv("JIRA_PROJECT")))
+	if err != nil {
+		log.Fatalf("jira_create_issue failed, err=%v", err)
+	}
+	fmt.Println(out)
+}
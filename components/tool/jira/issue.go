@@ -0,0 +1,215 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// CreateIssueRequest is the request of the issue creation tool.
+type CreateIssueRequest struct {
+	Project     string   `json:"project" jsonschema:"required,description=The key of the project, e.g. PROJ"`
+	Summary     string   `json:"summary" jsonschema:"required,description=The summary of the issue"`
+	IssueType   string   `json:"issue_type,omitempty" jsonschema:"description=The type of the issue, e.g. Task, Bug or Story. Default Task"`
+	Description string   `json:"description,omitempty" jsonschema:"description=The description of the issue, in Jira wiki markup"`
+	Priority    string   `json:"priority,omitempty" jsonschema:"description=The priority of the issue, e.g. High, Medium or Low"`
+	Labels      []string `json:"labels,omitempty" jsonschema:"description=The labels of the issue, without spaces"`
+	Assignee    string   `json:"assignee,omitempty" jsonschema:"description=The account id of the assignee on Jira Cloud, the user name on Jira Data Center"`
+}
+
+// UpdateIssueRequest is the request of the issue update tool.
+type UpdateIssueRequest struct {
+	Key         string   `json:"key" jsonschema:"required,description=The key of the issue, e.g. PROJ-123"`
+	Summary     string   `json:"summary,omitempty" jsonschema:"description=The new summary. Leave empty to keep it"`
+	Description string   `json:"description,omitempty" jsonschema:"description=The new description, in Jira wiki markup. Leave empty to keep it"`
+	Priority    string   `json:"priority,omitempty" jsonschema:"description=The new priority. Leave empty to keep it"`
+	Labels      []string `json:"labels,omitempty" jsonschema:"description=The new labels, replacing the current ones. Leave empty to keep them"`
+	Assignee    string   `json:"assignee,omitempty" jsonschema:"description=The account id of the new assignee on Jira Cloud, the user name on Jira Data Center. Leave empty to keep it"`
+	Comment     string   `json:"comment,omitempty" jsonschema:"description=A comment to add to the issue"`
+}
+
+// TransitionIssueRequest is the request of the issue transition tool.
+type TransitionIssueRequest struct {
+	Key        string `json:"key" jsonschema:"required,description=The key of the issue, e.g. PROJ-123"`
+	Transition string `json:"transition" jsonschema:"required,description=The name of the transition or of the target status, e.g. Start Progress or Done"`
+	Comment    string `json:"comment,omitempty" jsonschema:"description=A comment to add to the issue"`
+}
+
+// IssueResponse is the created, updated or transitioned issue.
+type IssueResponse struct {
+	Key string `json:"key"`
+	URL string `json:"url"`
+	// Status is the status of the issue after a transition.
+	Status string `json:"status,omitempty"`
+}
+
+// CreateIssue creates an issue in the project.
+func (c *client) CreateIssue(ctx context.Context, req *CreateIssueRequest) (*IssueResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request is nil")
+	}
+	if !projectKeyPattern.MatchString(req.Project) {
+		return nil, fmt.Errorf("invalid project key: %q", req.Project)
+	}
+	if err := c.checkProject(req.Project); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(req.Summary) == "" {
+		return nil, fmt.Errorf("summary is required")
+	}
+	if err := checkLabels(req.Labels); err != nil {
+		return nil, err
+	}
+
+	issueType := req.IssueType
+	if issueType == "" {
+		issueType = "Task"
+	}
+	fields := map[string]any{
+		"project":   map[string]any{"key": req.Project},
+		"summary":   req.Summary,
+		"issuetype": map[string]any{"name": issueType},
+	}
+	if req.Description != "" {
+		fields["description"] = req.Description
+	}
+	if req.Priority != "" {
+		fields["priority"] = map[string]any{"name": req.Priority}
+	}
+	if len(req.Labels) > 0 {
+		fields["labels"] = req.Labels
+	}
+	if req.Assignee != "" {
+		fields["assignee"] = c.assignee(req.Assignee)
+	}
+
+	var resp struct {
+		Key string `json:"key"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/rest/api/2/issue", nil, map[string]any{"fields": fields}, &resp); err != nil {
+		return nil, err
+	}
+	return &IssueResponse{Key: resp.Key, URL: c.issueURL(resp.Key)}, nil
+}
+
+// UpdateIssue updates the given fields of the issue, and adds the comment.
+func (c *client) UpdateIssue(ctx context.Context, req *UpdateIssueRequest) (*IssueResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request is nil")
+	}
+	if err := c.checkIssueKey(req.Key); err != nil {
+		return nil, err
+	}
+	if err := checkLabels(req.Labels); err != nil {
+		return nil, err
+	}
+
+	fields := map[string]any{}
+	if strings.TrimSpace(req.Summary) != "" {
+		fields["summary"] = req.Summary
+	}
+	if req.Description != "" {
+		fields["description"] = req.Description
+	}
+	if req.Priority != "" {
+		fields["priority"] = map[string]any{"name": req.Priority}
+	}
+	if len(req.Labels) > 0 {
+		fields["labels"] = req.Labels
+	}
+	if req.Assignee != "" {
+		fields["assignee"] = c.assignee(req.Assignee)
+	}
+	if len(fields) == 0 && req.Comment == "" {
+		return nil, fmt.Errorf("nothing to update, set at least one field or a comment")
+	}
+
+	if len(fields) > 0 {
+		if err := c.do(ctx, http.MethodPut, "/rest/api/2/issue/"+req.Key, nil, map[string]any{"fields": fields}, nil); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.addComment(ctx, req.Key, req.Comment); err != nil {
+		return nil, err
+	}
+	return &IssueResponse{Key: req.Key, URL: c.issueURL(req.Key)}, nil
+}
+
+// TransitionIssue moves the issue with the transition matching the name of the transition or of its target status.
+func (c *client) TransitionIssue(ctx context.Context, req *TransitionIssueRequest) (*IssueResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request is nil")
+	}
+	if err := c.checkIssueKey(req.Key); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(req.Transition) == "" {
+		return nil, fmt.Errorf("transition is required")
+	}
+
+	var resp struct {
+		Transitions []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+			To   named  `json:"to"`
+		} `json:"transitions"`
+	}
+	path := "/rest/api/2/issue/" + req.Key + "/transitions"
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	id, status := "", ""
+	available := make([]string, 0, len(resp.Transitions))
+	for _, t := range resp.Transitions {
+		if strings.EqualFold(t.Name, req.Transition) || strings.EqualFold(t.To.Name, req.Transition) {
+			id, status = t.ID, t.To.Name
+			break
+		}
+		available = append(available, fmt.Sprintf("%s (to %s)", t.Name, t.To.Name))
+	}
+	if id == "" {
+		return nil, fmt.Errorf("transition not available: %s, available transitions: %s", req.Transition, strings.Join(available, ", "))
+	}
+
+	if err := c.do(ctx, http.MethodPost, path, nil, map[string]any{"transition": map[string]any{"id": id}}, nil); err != nil {
+		return nil, err
+	}
+	if err := c.addComment(ctx, req.Key, req.Comment); err != nil {
+		return nil, err
+	}
+	return &IssueResponse{Key: req.Key, URL: c.issueURL(req.Key), Status: status}, nil
+}
+
+func (c *client) addComment(ctx context.Context, key, comment string) error {
+	if strings.TrimSpace(comment) == "" {
+		return nil
+	}
+	return c.do(ctx, http.MethodPost, "/rest/api/2/issue/"+key+"/comment", nil, map[string]any{"body": comment}, nil)
+}
+
+// checkLabels checks the labels have no spaces, which Jira rejects.
+func checkLabels(labels []string) error {
+	for _, l := range labels {
+		if l == "" || strings.ContainsAny(l, " \t\n") {
+			return fmt.Errorf("invalid label: %q, labels cannot be empty or have spaces", l)
+		}
+	}
+	return nil
+}
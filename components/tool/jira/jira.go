@@ -0,0 +1,144 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package jira provides tools for Jira: searching issues with JQL, and creating, updating and transitioning issues,
+// with Jira Cloud and Jira Data Center.
+package jira
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+)
+
+var projectKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]+$`)
+
+// Config is the configuration for the Jira tools.
+type Config struct {
+	// BaseURL is the url of the Jira site, e.g. "https://your-domain.atlassian.net" for Jira Cloud.
+	// Required.
+	BaseURL string
+	// Email and APIToken authenticate with Jira Cloud, the API token being created in the Atlassian account settings.
+	// Either Email and APIToken, or PersonalAccessToken, is required.
+	Email    string
+	APIToken string
+	// PersonalAccessToken authenticates with Jira Data Center and Server.
+	PersonalAccessToken string
+	// HTTPClient is the http client sending the requests.
+	// Optional. Default a client with Timeout.
+	HTTPClient *http.Client
+	// Timeout is the maximum duration of each request.
+	// Optional. Default 30s.
+	Timeout time.Duration
+
+	// Projects is the allowlist of the keys of the projects the tools can access, e.g. "PROJ".
+	// The searches are restricted to these projects.
+	// Optional. Default all the projects the user can access.
+	Projects []string
+	// EnableWrite adds the tools creating, updating and transitioning issues.
+	// Optional. Default false, the tools are read-only.
+	EnableWrite bool
+
+	// MaxResults is the default number of issues of a search, between 1 and 100.
+	// Optional. Default 20.
+	MaxResults int
+	// MaxDescriptionLength is the maximum number of characters of the description of an issue in the search results.
+	// Optional. Default 1000.
+	MaxDescriptionLength int
+}
+
+// NewToolKit creates the Jira tools: jira_search, plus jira_create_issue, jira_update_issue and jira_transition_issue
+// when EnableWrite is set.
+func NewToolKit(ctx context.Context, conf *Config) ([]tool.BaseTool, error) {
+	c, err := newClient(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		tools    []tool.BaseTool
+		inferErr error
+	)
+	add := func(t tool.InvokableTool, err error) {
+		if err != nil {
+			inferErr = errors.Join(inferErr, err)
+			return
+		}
+		tools = append(tools, t)
+	}
+
+	add(utils.InferTool("jira_search", "Search Jira issues with JQL, e.g. "+
+		"\"project = PROJ AND status = 'In Progress' AND assignee = currentUser() ORDER BY updated DESC\".", c.Search))
+	if conf.EnableWrite {
+		add(utils.InferTool("jira_create_issue", "Create a Jira issue.", c.CreateIssue))
+		add(utils.InferTool("jira_update_issue", "Update the fields of a Jira issue, and optionally add a comment.", c.UpdateIssue))
+		add(utils.InferTool("jira_transition_issue", "Move a Jira issue to another status, e.g. \"In Progress\" or \"Done\", "+
+			"by the name of the transition or of the target status.", c.TransitionIssue))
+	}
+	if inferErr != nil {
+		return nil, fmt.Errorf("failed to infer tool: %w", inferErr)
+	}
+	return tools, nil
+}
+
+// validate validates the configuration and sets default values if not provided.
+func (conf *Config) validate() error {
+	if conf == nil {
+		return fmt.Errorf("config is nil")
+	}
+	if conf.BaseURL == "" {
+		return fmt.Errorf("base url is required")
+	}
+	conf.BaseURL = strings.TrimRight(conf.BaseURL, "/")
+	switch {
+	case conf.PersonalAccessToken != "" && (conf.Email != "" || conf.APIToken != ""):
+		return fmt.Errorf("either email and api token, or personal access token, must be set, not both")
+	case conf.PersonalAccessToken == "" && (conf.Email == "" || conf.APIToken == ""):
+		return fmt.Errorf("email and api token, or personal access token, are required")
+	}
+	if conf.Timeout <= 0 {
+		conf.Timeout = 30 * time.Second
+	}
+	if conf.HTTPClient == nil {
+		conf.HTTPClient = &http.Client{Timeout: conf.Timeout}
+	}
+	for _, p := range conf.Projects {
+		if !projectKeyPattern.MatchString(p) {
+			return fmt.Errorf("invalid project key in allowlist: %q", p)
+		}
+	}
+	if conf.MaxResults <= 0 {
+		conf.MaxResults = 20
+	}
+	if conf.MaxResults > maxResults {
+		return fmt.Errorf("max results must be at most %d", maxResults)
+	}
+	if conf.MaxDescriptionLength <= 0 {
+		conf.MaxDescriptionLength = 1000
+	}
+	return nil
+}
+
+// cloud reports whether the site is Jira Cloud, which is authenticated with an email and an api token.
+func (conf *Config) cloud() bool {
+	return conf.Email != ""
+}
@@ -0,0 +1,262 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package jira
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClient(t *testing.T, conf *Config, mux *http.ServeMux) *client {
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	conf.BaseURL = srv.URL + "/"
+	c, err := newClient(conf)
+	assert.NoError(t, err)
+	return c
+}
+
+func TestNewToolKit(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewToolKit(ctx, nil)
+	assert.EqualError(t, err, "config is nil")
+	_, err = NewToolKit(ctx, &Config{})
+	assert.EqualError(t, err, "base url is required")
+	_, err = NewToolKit(ctx, &Config{BaseURL: "https://example.atlassian.net", Email: "a@example.com"})
+	assert.EqualError(t, err, "email and api token, or personal access token, are required")
+	_, err = NewToolKit(ctx, &Config{BaseURL: "https://example.atlassian.net", Email: "a@example.com", APIToken: "t", PersonalAccessToken: "p"})
+	assert.EqualError(t, err, "either email and api token, or personal access token, must be set, not both")
+	_, err = NewToolKit(ctx, &Config{BaseURL: "https://jira.example.com", PersonalAccessToken: "p", Projects: []string{"proj"}})
+	assert.EqualError(t, err, `invalid project key in allowlist: "proj"`)
+
+	names := func(tools []tool.BaseTool) []string {
+		var result []string
+		for _, tl := range tools {
+			info, err := tl.Info(ctx)
+			assert.NoError(t, err)
+			result = append(result, info.Name)
+		}
+		return result
+	}
+	tools, err := NewToolKit(ctx, &Config{BaseURL: "https://jira.example.com", PersonalAccessToken: "p"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"jira_search"}, names(tools))
+	tools, err = NewToolKit(ctx, &Config{BaseURL: "https://jira.example.com", PersonalAccessToken: "p", EnableWrite: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"jira_search", "jira_create_issue", "jira_update_issue", "jira_transition_issue"}, names(tools))
+}
+
+func TestScopeJQL(t *testing.T) {
+	c, err := newClient(&Config{BaseURL: "https://jira.example.com", PersonalAccessToken: "p", Projects: []string{"PROJ", "OPS"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "project in (PROJ, OPS) AND (status = Open OR project = OTHER) order by updated DESC",
+		c.scopeJQL(" status = Open OR project = OTHER order by updated DESC "))
+	assert.Equal(t, "project in (PROJ, OPS) ORDER BY created", c.scopeJQL("ORDER BY created"))
+	assert.Equal(t, "project in (PROJ, OPS) AND (assignee = currentUser())", c.scopeJQL("assignee = currentUser()"))
+
+	open, err := newClient(&Config{BaseURL: "https://jira.example.com", PersonalAccessToken: "p"})
+	assert.NoError(t, err)
+	assert.Equal(t, "status = Open", open.scopeJQL("status = Open"))
+}
+
+const searchResponse = `"issues":[
+	{"key":"PROJ-1","fields":{"summary":"Login fails","status":{"name":"Open"},"issuetype":{"name":"Bug"},"priority":{"name":"High"},
+	 "assignee":{"displayName":"Alice"},"reporter":{"displayName":"Bob"},"labels":["auth"],"created":"2025-01-01T10:00:00.000+0000",
+	 "updated":"2025-01-02T10:00:00.000+0000","description":"Steps to reproduce: open the login page and submit."}},
+	{"key":"OTHER-2","fields":{"summary":"Hidden","status":{"name":"Open"},"issuetype":{"name":"Task"}}}
+]`
+
+func TestSearch_Cloud(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /rest/api/2/search/jql", func(w http.ResponseWriter, r *http.Request) {
+		email, token, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "a@example.com", email)
+		assert.Equal(t, "token", token)
+		q := r.URL.Query()
+		assert.Equal(t, "project in (PROJ) AND (status = Open)", q.Get("jql"))
+		assert.Equal(t, "20", q.Get("maxResults"))
+		assert.Equal(t, searchFields, q.Get("fields"))
+		if q.Get("nextPageToken") == "" {
+			_, _ = w.Write([]byte(`{` + searchResponse + `,"nextPageToken":"abc","isLast":false}`))
+			return
+		}
+		assert.Equal(t, "abc", q.Get("nextPageToken"))
+		_, _ = w.Write([]byte(`{"issues":[],"isLast":true}`))
+	})
+	c := newTestClient(t, &Config{Email: "a@example.com", APIToken: "token", Projects: []string{"PROJ"}, MaxDescriptionLength: 18}, mux)
+	ctx := context.Background()
+
+	resp, err := c.Search(ctx, &SearchRequest{JQL: "status = Open"})
+	assert.NoError(t, err)
+	assert.Equal(t, &SearchResponse{
+		Issues: []*Issue{{
+			Key:         "PROJ-1",
+			Summary:     "Login fails",
+			Status:      "Open",
+			Type:        "Bug",
+			Priority:    "High",
+			Assignee:    "Alice",
+			Reporter:    "Bob",
+			Labels:      []string{"auth"},
+			Created:     "2025-01-01T10:00:00.000+0000",
+			Updated:     "2025-01-02T10:00:00.000+0000",
+			URL:         c.conf.BaseURL + "/browse/PROJ-1",
+			Description: "Steps to reproduce\n[content truncated]",
+		}},
+		NextPageToken: "abc",
+	}, resp)
+
+	resp, err = c.Search(ctx, &SearchRequest{JQL: "status = Open", PageToken: "abc"})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Issues)
+	assert.Empty(t, resp.NextPageToken)
+}
+
+func TestSearch_DataCenter(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /rest/api/2/search", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer pat", r.Header.Get("Authorization"))
+		q := r.URL.Query()
+		assert.Equal(t, "2", q.Get("maxResults"))
+		switch q.Get("startAt") {
+		case "0":
+			_, _ = w.Write([]byte(`{"startAt":0,"total":3,` + searchResponse + `}`))
+		case "2":
+			_, _ = w.Write([]byte(`{"startAt":2,"total":3,"issues":[{"key":"PROJ-3","fields":{"summary":"Last"}}]}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errorMessages":["Error in the JQL Query: Expecting operator"],"errors":{}}`))
+		}
+	})
+	c := newTestClient(t, &Config{PersonalAccessToken: "pat"}, mux)
+	ctx := context.Background()
+
+	resp, err := c.Search(ctx, &SearchRequest{JQL: "text ~ login", MaxResults: 2})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Issues, 2)
+	assert.Equal(t, "2", resp.NextPageToken)
+
+	resp, err = c.Search(ctx, &SearchRequest{JQL: "text ~ login", MaxResults: 2, PageToken: resp.NextPageToken})
+	assert.NoError(t, err)
+	assert.Equal(t, "PROJ-3", resp.Issues[0].Key)
+	assert.Empty(t, resp.NextPageToken)
+
+	_, err = c.Search(ctx, &SearchRequest{JQL: "text ~", MaxResults: 2, PageToken: "5"})
+	assert.EqualError(t, err, "jira api error, status code: 400, message: Error in the JQL Query: Expecting operator")
+	_, err = c.Search(ctx, &SearchRequest{JQL: "text ~ login", PageToken: "abc"})
+	assert.EqualError(t, err, "invalid page token: abc")
+}
+
+func TestCreateAndUpdateIssue(t *testing.T) {
+	var bodies []string
+	record := func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, r.Method+" "+r.URL.Path+" "+string(data))
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /rest/api/2/issue", func(w http.ResponseWriter, r *http.Request) {
+		record(w, r)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"10001","key":"PROJ-7","self":"https://example.atlassian.net/rest/api/2/issue/10001"}`))
+	})
+	mux.HandleFunc("PUT /rest/api/2/issue/PROJ-7", func(w http.ResponseWriter, r *http.Request) {
+		record(w, r)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("POST /rest/api/2/issue/PROJ-7/comment", func(w http.ResponseWriter, r *http.Request) {
+		record(w, r)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"1"}`))
+	})
+	mux.HandleFunc("PUT /rest/api/2/issue/PROJ-8", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"errorMessages":[],"errors":{"priority":"Priority name 'Urgent' is not valid"}}`))
+	})
+	c := newTestClient(t, &Config{Email: "a@example.com", APIToken: "token", Projects: []string{"PROJ"}, EnableWrite: true}, mux)
+	ctx := context.Background()
+
+	issue, err := c.CreateIssue(ctx, &CreateIssueRequest{Project: "PROJ", Summary: "Login fails", IssueType: "Bug",
+		Description: "Steps...", Priority: "High", Labels: []string{"auth"}, Assignee: "5b10ac8d82e05b22cc7d4ef5"})
+	assert.NoError(t, err)
+	assert.Equal(t, &IssueResponse{Key: "PROJ-7", URL: c.conf.BaseURL + "/browse/PROJ-7"}, issue)
+
+	issue, err = c.UpdateIssue(ctx, &UpdateIssueRequest{Key: "PROJ-7", Priority: "Low", Comment: "Lowered, a workaround exists."})
+	assert.NoError(t, err)
+	assert.Equal(t, "PROJ-7", issue.Key)
+
+	_, err = c.UpdateIssue(ctx, &UpdateIssueRequest{Key: "PROJ-7", Comment: "Only a comment."})
+	assert.NoError(t, err)
+
+	assert.Len(t, bodies, 4)
+	assert.Equal(t, "POST /rest/api/2/issue ", bodies[0][:23])
+	assert.JSONEq(t, `{"fields":{"project":{"key":"PROJ"},"summary":"Login fails","issuetype":{"name":"Bug"},"description":"Steps...",
+		"priority":{"name":"High"},"labels":["auth"],"assignee":{"accountId":"5b10ac8d82e05b22cc7d4ef5"}}}`, bodies[0][23:])
+	assert.Equal(t, `PUT /rest/api/2/issue/PROJ-7 {"fields":{"priority":{"name":"Low"}}}`, bodies[1])
+	assert.Equal(t, `POST /rest/api/2/issue/PROJ-7/comment {"body":"Lowered, a workaround exists."}`, bodies[2])
+	assert.Equal(t, `POST /rest/api/2/issue/PROJ-7/comment {"body":"Only a comment."}`, bodies[3])
+
+	_, err = c.UpdateIssue(ctx, &UpdateIssueRequest{Key: "PROJ-8", Priority: "Urgent"})
+	assert.EqualError(t, err, "jira api error, status code: 400, message: priority: Priority name 'Urgent' is not valid")
+	_, err = c.UpdateIssue(ctx, &UpdateIssueRequest{Key: "PROJ-7"})
+	assert.EqualError(t, err, "nothing to update, set at least one field or a comment")
+	_, err = c.CreateIssue(ctx, &CreateIssueRequest{Project: "OPS", Summary: "x"})
+	assert.EqualError(t, err, "project is not allowed: OPS")
+	_, err = c.CreateIssue(ctx, &CreateIssueRequest{Project: "PROJ", Summary: "x", Labels: []string{"two words"}})
+	assert.EqualError(t, err, `invalid label: "two words", labels cannot be empty or have spaces`)
+	_, err = c.UpdateIssue(ctx, &UpdateIssueRequest{Key: "PROJ-7/../../x", Summary: "x"})
+	assert.EqualError(t, err, `invalid issue key: "PROJ-7/../../x", expected e.g. PROJ-123`)
+}
+
+func TestTransitionIssue(t *testing.T) {
+	var posted []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /rest/api/2/issue/PROJ-7/transitions", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"transitions":[{"id":"11","name":"Start Progress","to":{"name":"In Progress"}},
+			{"id":"31","name":"Resolve","to":{"name":"Done"}}]}`))
+	})
+	mux.HandleFunc("POST /rest/api/2/issue/PROJ-7/transitions", func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		posted = append(posted, string(data))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("POST /rest/api/2/issue/PROJ-7/comment", func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		posted = append(posted, string(data))
+		w.WriteHeader(http.StatusCreated)
+	})
+	c := newTestClient(t, &Config{PersonalAccessToken: "pat", EnableWrite: true}, mux)
+	ctx := context.Background()
+
+	resp, err := c.TransitionIssue(ctx, &TransitionIssueRequest{Key: "PROJ-7", Transition: "done", Comment: "Fixed in 1.2.0."})
+	assert.NoError(t, err)
+	assert.Equal(t, "Done", resp.Status)
+	assert.Equal(t, []string{`{"transition":{"id":"31"}}`, `{"body":"Fixed in 1.2.0."}`}, posted)
+
+	resp, err = c.TransitionIssue(ctx, &TransitionIssueRequest{Key: "PROJ-7", Transition: "Start Progress"})
+	assert.NoError(t, err)
+	assert.Equal(t, "In Progress", resp.Status)
+
+	_, err = c.TransitionIssue(ctx, &TransitionIssueRequest{Key: "PROJ-7", Transition: "Closed"})
+	assert.EqualError(t, err, "transition not available: Closed, available transitions: Start Progress (to In Progress), Resolve (to Done)")
+}
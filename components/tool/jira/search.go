@@ -0,0 +1,205 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// orderBy matches the ORDER BY clause of a JQL query.
+var orderBy = regexp.MustCompile(`(?i)\border\s+by\b`)
+
+// searchFields are the fields of the issues returned by the searches.
+const searchFields = "summary,status,issuetype,priority,assignee,reporter,labels,created,updated,description"
+
+// SearchRequest is the request of the search tool.
+type SearchRequest struct {
+	JQL        string `json:"jql" jsonschema:"required,description=The JQL query, e.g. project = PROJ AND status = 'In Progress' ORDER BY updated DESC"`
+	MaxResults int    `json:"max_results,omitempty" jsonschema:"description=The number of issues to return, between 1 and 100"`
+	PageToken  string `json:"page_token,omitempty" jsonschema:"description=The next_page_token of the previous search, to get the next page of issues"`
+}
+
+// SearchResponse is the response of the search tool.
+type SearchResponse struct {
+	Issues []*Issue `json:"issues"`
+	// NextPageToken is set when there are more issues.
+	NextPageToken string `json:"next_page_token,omitempty"`
+}
+
+// Issue is a Jira issue.
+type Issue struct {
+	Key      string   `json:"key"`
+	Summary  string   `json:"summary"`
+	Status   string   `json:"status"`
+	Type     string   `json:"type"`
+	Priority string   `json:"priority,omitempty"`
+	Assignee string   `json:"assignee,omitempty"`
+	Reporter string   `json:"reporter,omitempty"`
+	Labels   []string `json:"labels,omitempty"`
+	Created  string   `json:"created"`
+	Updated  string   `json:"updated"`
+	URL      string   `json:"url"`
+	// Description is the beginning of the description.
+	Description string `json:"description,omitempty"`
+}
+
+type named struct {
+	Name string `json:"name"`
+}
+
+type user struct {
+	DisplayName string `json:"displayName"`
+}
+
+type apiIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary     string   `json:"summary"`
+		Status      *named   `json:"status"`
+		IssueType   *named   `json:"issuetype"`
+		Priority    *named   `json:"priority"`
+		Assignee    *user    `json:"assignee"`
+		Reporter    *user    `json:"reporter"`
+		Labels      []string `json:"labels"`
+		Created     string   `json:"created"`
+		Updated     string   `json:"updated"`
+		Description string   `json:"description"`
+	} `json:"fields"`
+}
+
+// Search searches the issues of the allowed projects with JQL.
+// Jira Cloud pages with the tokens of the enhanced search, Jira Data Center with the offsets, given as tokens as well.
+func (c *client) Search(ctx context.Context, req *SearchRequest) (*SearchResponse, error) {
+	if req == nil || strings.TrimSpace(req.JQL) == "" {
+		return nil, fmt.Errorf("jql is required")
+	}
+	limit := req.MaxResults
+	if limit <= 0 {
+		limit = c.conf.MaxResults
+	}
+	query := url.Values{
+		"jql":        {c.scopeJQL(req.JQL)},
+		"maxResults": {strconv.Itoa(min(limit, maxResults))},
+		"fields":     {searchFields},
+	}
+
+	var (
+		issues []*apiIssue
+		next   string
+	)
+	if c.conf.cloud() {
+		if req.PageToken != "" {
+			query.Set("nextPageToken", req.PageToken)
+		}
+		var resp struct {
+			Issues        []*apiIssue `json:"issues"`
+			NextPageToken string      `json:"nextPageToken"`
+			IsLast        bool        `json:"isLast"`
+		}
+		if err := c.do(ctx, http.MethodGet, "/rest/api/2/search/jql", query, nil, &resp); err != nil {
+			return nil, err
+		}
+		issues = resp.Issues
+		if !resp.IsLast {
+			next = resp.NextPageToken
+		}
+	} else {
+		startAt := 0
+		if req.PageToken != "" {
+			n, err := strconv.Atoi(req.PageToken)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("invalid page token: %s", req.PageToken)
+			}
+			startAt = n
+		}
+		query.Set("startAt", strconv.Itoa(startAt))
+		var resp struct {
+			StartAt int         `json:"startAt"`
+			Total   int         `json:"total"`
+			Issues  []*apiIssue `json:"issues"`
+		}
+		if err := c.do(ctx, http.MethodGet, "/rest/api/2/search", query, nil, &resp); err != nil {
+			return nil, err
+		}
+		issues = resp.Issues
+		if end := resp.StartAt + len(resp.Issues); len(resp.Issues) > 0 && end < resp.Total {
+			next = strconv.Itoa(end)
+		}
+	}
+
+	result := &SearchResponse{Issues: make([]*Issue, 0, len(issues)), NextPageToken: next}
+	for _, is := range issues {
+		if c.checkIssueKey(is.Key) != nil {
+			continue
+		}
+		f := is.Fields
+		issue := &Issue{
+			Key:         is.Key,
+			Summary:     f.Summary,
+			Labels:      f.Labels,
+			Created:     f.Created,
+			Updated:     f.Updated,
+			URL:         c.issueURL(is.Key),
+			Description: truncate(strings.TrimSpace(f.Description), c.conf.MaxDescriptionLength),
+		}
+		if f.Status != nil {
+			issue.Status = f.Status.Name
+		}
+		if f.IssueType != nil {
+			issue.Type = f.IssueType.Name
+		}
+		if f.Priority != nil {
+			issue.Priority = f.Priority.Name
+		}
+		if f.Assignee != nil {
+			issue.Assignee = f.Assignee.DisplayName
+		}
+		if f.Reporter != nil {
+			issue.Reporter = f.Reporter.DisplayName
+		}
+		result.Issues = append(result.Issues, issue)
+	}
+	return result, nil
+}
+
+// scopeJQL restricts the query to the allowed projects, keeping its ORDER BY clause at the end.
+// The results are filtered by the allowlist as well.
+func (c *client) scopeJQL(jql string) string {
+	jql = strings.TrimSpace(jql)
+	if len(c.conf.Projects) == 0 {
+		return jql
+	}
+
+	where, order := jql, ""
+	if locs := orderBy.FindAllStringIndex(jql, -1); len(locs) > 0 {
+		last := locs[len(locs)-1]
+		where, order = strings.TrimSpace(jql[:last[0]]), jql[last[0]:]
+	}
+	scoped := "project in (" + strings.Join(c.conf.Projects, ", ") + ")"
+	if where != "" {
+		scoped += " AND (" + where + ")"
+	}
+	if order != "" {
+		scoped += " " + order
+	}
+	return scoped
+}
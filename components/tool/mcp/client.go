@@ -0,0 +1,414 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Transport is the transport connecting to the MCP server.
+type Transport string
+
+const (
+	// TransportStdio runs the server as a subprocess, talking over its stdin and stdout.
+	TransportStdio Transport = "stdio"
+	// TransportSSE is the HTTP with Server-Sent Events transport of the protocol version 2024-11-05.
+	TransportSSE Transport = "sse"
+	// TransportStreamableHTTP is the Streamable HTTP transport of the protocol version 2025-03-26,
+	// used by most hosted MCP servers.
+	TransportStreamableHTTP Transport = "streamable_http"
+)
+
+// ClientConfig is the configuration for connecting to an MCP server with NewClient.
+type ClientConfig struct {
+	// Transport is the transport connecting to the server.
+	// Optional. Default TransportStreamableHTTP when URL is set, TransportStdio when Command is set.
+	Transport Transport
+
+	// URL is the endpoint of the server, e.g. "https://example.com/mcp" for Streamable HTTP,
+	// or "https://example.com/sse" for SSE.
+	// Required for the HTTP transports.
+	URL string
+	// Headers are added to the HTTP requests, e.g. an API key of the server.
+	// Optional.
+	Headers map[string]string
+	// HTTPClient is the http client sending the requests of the HTTP transports.
+	// Optional. Default a client with Timeout for Streamable HTTP, http.DefaultClient for SSE, as its stream is long-lived.
+	HTTPClient *http.Client
+	// Timeout is the maximum duration of each request of the Streamable HTTP transport, ignored with HTTPClient.
+	// Optional. Default 0, no timeout.
+	Timeout time.Duration
+	// OAuth authorizes the HTTP transports with OAuth 2.1, for the servers requiring it.
+	// Optional.
+	OAuth *OAuthConfig
+	// SessionID resumes a session of the Streamable HTTP transport, e.g. saved with Client.GetSessionId
+	// before a restart, instead of initializing a new one. A new session is initialized when the server
+	// has terminated it.
+	// Optional.
+	SessionID string
+	// ContinuousListening keeps a stream of the Streamable HTTP transport open to receive the notifications
+	// of the server while no request is in flight, e.g. the resource updates and the prompt list changes.
+	// Optional.
+	ContinuousListening bool
+
+	// Command is the command running the server of the stdio transport, e.g. "npx".
+	// Required for the stdio transport.
+	Command string
+	// Args are the arguments of the command, e.g. []string{"-y", "@modelcontextprotocol/server-everything"}.
+	// Optional.
+	Args []string
+	// Env is the environment of the command, as "KEY=value", added to the environment of the current process.
+	// Optional.
+	Env []string
+
+	// ClientInfo is the name and the version of the client sent to the server.
+	// Optional. Default "eino" "1.0.0".
+	ClientInfo mcp.Implementation
+	// Capabilities are the capabilities of the client sent to the server.
+	// Optional.
+	Capabilities mcp.ClientCapabilities
+}
+
+// OAuthConfig is the OAuth 2.1 authorization code flow with PKCE, the authorization server being discovered
+// from the MCP server.
+type OAuthConfig struct {
+	// ClientID is the id of the client registered with the authorization server.
+	// Optional. Default the client is registered dynamically, as ClientName.
+	ClientID string
+	// ClientSecret is the secret of the confidential clients.
+	// Optional.
+	ClientSecret string
+	// ClientName is the name of the client registered dynamically, shown to the user by the authorization server.
+	// Optional. Default "eino".
+	ClientName string
+	// RedirectURI is the uri the authorization server redirects the user to with the code, e.g. "http://localhost:8085/callback".
+	// Required.
+	RedirectURI string
+	// Scopes are the requested scopes.
+	// Optional.
+	Scopes []string
+	// TokenStore keeps the tokens, e.g. in a file or a database to authorize the next runs without the user.
+	// The tokens are refreshed when they expire.
+	// Optional. Default the tokens are kept in memory.
+	TokenStore client.TokenStore
+	// AuthServerMetadataURL is the url of the metadata of the authorization server.
+	// Optional. Default discovered from the protected resource metadata of the MCP server.
+	AuthServerMetadataURL string
+	// Authorize runs the interactive step of the flow: it directs the user to authURL, and returns the code
+	// and the state the authorization server sent to RedirectURI. NewLocalAuthorizer implements it for local apps.
+	// Required when there is no valid token in TokenStore.
+	Authorize func(ctx context.Context, authURL string) (code, state string, err error)
+}
+
+// Client is an MCP client connected by NewClient, to be given to GetTools as Config.Cli.
+// When the server terminates the session, or the authorization expires, the session is initialized again,
+// and the authorization flow is run again, before retrying the request once.
+type Client struct {
+	*client.Client
+
+	conf *ClientConfig
+	mu   sync.Mutex
+}
+
+// NewClient connects to the MCP server with the transport of the configuration, runs the OAuth flow when
+// the server requires it, and initializes the session, or resumes it with SessionID.
+func NewClient(ctx context.Context, conf *ClientConfig) (*Client, error) {
+	if err := conf.validate(); err != nil {
+		return nil, err
+	}
+
+	t, err := newTransport(conf)
+	if err != nil {
+		return nil, err
+	}
+	opts := []client.ClientOption{client.WithClientCapabilities(conf.Capabilities)}
+	if conf.SessionID != "" {
+		opts = append(opts, client.WithSession())
+	}
+	c := &Client{Client: client.NewClient(t, opts...), conf: conf}
+
+	err = c.withAuthorization(ctx, func() error {
+		return c.Client.Start(ctx)
+	})
+	if err != nil {
+		_ = c.Client.Close()
+		return nil, fmt.Errorf("failed to start mcp client: %w", err)
+	}
+	if conf.SessionID == "" {
+		if err = c.withAuthorization(ctx, func() error { return c.initialize(ctx) }); err != nil {
+			_ = c.Client.Close()
+			return nil, fmt.Errorf("failed to initialize mcp client: %w", err)
+		}
+	}
+	return c, nil
+}
+
+// validate validates the configuration and sets default values if not provided.
+func (conf *ClientConfig) validate() error {
+	if conf == nil {
+		return fmt.Errorf("config is nil")
+	}
+	if conf.Transport == "" {
+		switch {
+		case conf.URL != "":
+			conf.Transport = TransportStreamableHTTP
+		case conf.Command != "":
+			conf.Transport = TransportStdio
+		default:
+			return fmt.Errorf("url or command is required")
+		}
+	}
+	switch conf.Transport {
+	case TransportStdio:
+		if conf.Command == "" {
+			return fmt.Errorf("command is required for the stdio transport")
+		}
+		if conf.OAuth != nil {
+			return fmt.Errorf("oauth is only supported by the http transports")
+		}
+	case TransportSSE, TransportStreamableHTTP:
+		if conf.URL == "" {
+			return fmt.Errorf("url is required for the %s transport", conf.Transport)
+		}
+	default:
+		return fmt.Errorf("unsupported transport: %s", conf.Transport)
+	}
+	if conf.SessionID != "" && conf.Transport != TransportStreamableHTTP {
+		return fmt.Errorf("session id is only supported by the streamable http transport")
+	}
+	if conf.OAuth != nil {
+		if conf.OAuth.RedirectURI == "" {
+			return fmt.Errorf("oauth redirect uri is required")
+		}
+		if conf.OAuth.ClientName == "" {
+			conf.OAuth.ClientName = "eino"
+		}
+	}
+	if conf.ClientInfo.Name == "" {
+		conf.ClientInfo = mcp.Implementation{Name: "eino", Version: "1.0.0"}
+	}
+	return nil
+}
+
+func newTransport(conf *ClientConfig) (transport.Interface, error) {
+	var oauth *transport.OAuthConfig
+	if conf.OAuth != nil {
+		oauth = &transport.OAuthConfig{
+			ClientID:              conf.OAuth.ClientID,
+			ClientSecret:          conf.OAuth.ClientSecret,
+			RedirectURI:           conf.OAuth.RedirectURI,
+			Scopes:                conf.OAuth.Scopes,
+			TokenStore:            conf.OAuth.TokenStore,
+			AuthServerMetadataURL: conf.OAuth.AuthServerMetadataURL,
+			// PKCE is mandatory with OAuth 2.1
+			PKCEEnabled: true,
+		}
+	}
+
+	switch conf.Transport {
+	case TransportStdio:
+		return transport.NewStdio(conf.Command, conf.Env, conf.Args...), nil
+	case TransportSSE:
+		var opts []transport.ClientOption
+		if len(conf.Headers) > 0 {
+			opts = append(opts, transport.WithHeaders(conf.Headers))
+		}
+		if conf.HTTPClient != nil {
+			opts = append(opts, transport.WithHTTPClient(conf.HTTPClient))
+		}
+		if oauth != nil {
+			opts = append(opts, transport.WithOAuth(*oauth))
+		}
+		t, err := transport.NewSSE(conf.URL, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create sse transport: %w", err)
+		}
+		return t, nil
+	default:
+		var opts []transport.StreamableHTTPCOption
+		if len(conf.Headers) > 0 {
+			opts = append(opts, transport.WithHTTPHeaders(conf.Headers))
+		}
+		if conf.HTTPClient != nil {
+			opts = append(opts, transport.WithHTTPBasicClient(conf.HTTPClient))
+		} else if conf.Timeout > 0 {
+			opts = append(opts, transport.WithHTTPTimeout(conf.Timeout))
+		}
+		if oauth != nil {
+			opts = append(opts, transport.WithHTTPOAuth(*oauth))
+		}
+		if conf.SessionID != "" {
+			opts = append(opts, transport.WithSession(conf.SessionID))
+		}
+		if conf.ContinuousListening {
+			opts = append(opts, transport.WithContinuousListening())
+		}
+		t, err := transport.NewStreamableHTTP(conf.URL, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create streamable http transport: %w", err)
+		}
+		return t, nil
+	}
+}
+
+func (c *Client) initialize(ctx context.Context) error {
+	req := mcp.InitializeRequest{}
+	req.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	req.Params.ClientInfo = c.conf.ClientInfo
+	req.Params.Capabilities = c.conf.Capabilities
+	_, err := c.Client.Initialize(ctx, req)
+	return err
+}
+
+// withAuthorization runs fn, and runs the OAuth flow before retrying it once when the server requires an authorization.
+func (c *Client) withAuthorization(ctx context.Context, fn func() error) error {
+	err := fn()
+	if err == nil || !client.IsOAuthAuthorizationRequiredError(err) {
+		return err
+	}
+	if err = c.authorize(ctx, client.GetOAuthHandler(err)); err != nil {
+		return err
+	}
+	return fn()
+}
+
+// authorize runs the authorization code flow with PKCE, registering the client first when it has no id.
+func (c *Client) authorize(ctx context.Context, handler *transport.OAuthHandler) error {
+	if c.conf.OAuth == nil || handler == nil {
+		return fmt.Errorf("mcp server requires an authorization, but oauth is not configured")
+	}
+	if c.conf.OAuth.Authorize == nil {
+		return fmt.Errorf("mcp server requires an authorization, but oauth authorize is not configured")
+	}
+
+	// the handler keeps the expected state between the authorization url and the response
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if handler.GetClientID() == "" {
+		if err := handler.RegisterClient(ctx, c.conf.OAuth.ClientName); err != nil {
+			return fmt.Errorf("failed to register oauth client: %w", err)
+		}
+	}
+	verifier, err := client.GenerateCodeVerifier()
+	if err != nil {
+		return fmt.Errorf("failed to generate oauth code verifier: %w", err)
+	}
+	state, err := client.GenerateState()
+	if err != nil {
+		return fmt.Errorf("failed to generate oauth state: %w", err)
+	}
+	authURL, err := handler.GetAuthorizationURL(ctx, state, client.GenerateCodeChallenge(verifier))
+	if err != nil {
+		return fmt.Errorf("failed to get oauth authorization url: %w", err)
+	}
+
+	code, gotState, err := c.conf.OAuth.Authorize(ctx, authURL)
+	if err != nil {
+		return fmt.Errorf("oauth authorization failed: %w", err)
+	}
+	if err = handler.ProcessAuthorizationResponse(ctx, code, gotState, verifier); err != nil {
+		return fmt.Errorf("failed to exchange oauth code: %w", err)
+	}
+	return nil
+}
+
+// retry runs the request, and retries it once after initializing a new session when the server terminated it,
+// or after authorizing again when the authorization expired.
+func retry[T any](ctx context.Context, c *Client, fn func() (T, error)) (T, error) {
+	result, err := fn()
+	switch {
+	case err == nil:
+		return result, nil
+	case errors.Is(err, transport.ErrSessionTerminated):
+		if iErr := c.withAuthorization(ctx, func() error { return c.initialize(ctx) }); iErr != nil {
+			return result, fmt.Errorf("%w, and failed to initialize a new session: %v", err, iErr)
+		}
+		return fn()
+	case client.IsOAuthAuthorizationRequiredError(err):
+		if aErr := c.authorize(ctx, client.GetOAuthHandler(err)); aErr != nil {
+			return result, aErr
+		}
+		return fn()
+	default:
+		return result, err
+	}
+}
+
+// Ping checks the server is alive.
+func (c *Client) Ping(ctx context.Context) error {
+	_, err := retry(ctx, c, func() (struct{}, error) { return struct{}{}, c.Client.Ping(ctx) })
+	return err
+}
+
+// ListTools lists the tools of the server.
+func (c *Client) ListTools(ctx context.Context, request mcp.ListToolsRequest) (*mcp.ListToolsResult, error) {
+	return retry(ctx, c, func() (*mcp.ListToolsResult, error) { return c.Client.ListTools(ctx, request) })
+}
+
+// ListToolsByPage lists a page of the tools of the server.
+func (c *Client) ListToolsByPage(ctx context.Context, request mcp.ListToolsRequest) (*mcp.ListToolsResult, error) {
+	return retry(ctx, c, func() (*mcp.ListToolsResult, error) { return c.Client.ListToolsByPage(ctx, request) })
+}
+
+// CallTool calls a tool of the server.
+func (c *Client) CallTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return retry(ctx, c, func() (*mcp.CallToolResult, error) { return c.Client.CallTool(ctx, request) })
+}
+
+// ListResources lists the resources of the server.
+func (c *Client) ListResources(ctx context.Context, request mcp.ListResourcesRequest) (*mcp.ListResourcesResult, error) {
+	return retry(ctx, c, func() (*mcp.ListResourcesResult, error) { return c.Client.ListResources(ctx, request) })
+}
+
+// ListResourcesByPage lists a page of the resources of the server.
+func (c *Client) ListResourcesByPage(ctx context.Context, request mcp.ListResourcesRequest) (*mcp.ListResourcesResult, error) {
+	return retry(ctx, c, func() (*mcp.ListResourcesResult, error) { return c.Client.ListResourcesByPage(ctx, request) })
+}
+
+// ListResourceTemplates lists the resource templates of the server.
+func (c *Client) ListResourceTemplates(ctx context.Context, request mcp.ListResourceTemplatesRequest) (*mcp.ListResourceTemplatesResult, error) {
+	return retry(ctx, c, func() (*mcp.ListResourceTemplatesResult, error) { return c.Client.ListResourceTemplates(ctx, request) })
+}
+
+// ReadResource reads a resource of the server.
+func (c *Client) ReadResource(ctx context.Context, request mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	return retry(ctx, c, func() (*mcp.ReadResourceResult, error) { return c.Client.ReadResource(ctx, request) })
+}
+
+// ListPrompts lists the prompts of the server.
+func (c *Client) ListPrompts(ctx context.Context, request mcp.ListPromptsRequest) (*mcp.ListPromptsResult, error) {
+	return retry(ctx, c, func() (*mcp.ListPromptsResult, error) { return c.Client.ListPrompts(ctx, request) })
+}
+
+// ListPromptsByPage lists a page of the prompts of the server.
+func (c *Client) ListPromptsByPage(ctx context.Context, request mcp.ListPromptsRequest) (*mcp.ListPromptsResult, error) {
+	return retry(ctx, c, func() (*mcp.ListPromptsResult, error) { return c.Client.ListPromptsByPage(ctx, request) })
+}
+
+// GetPrompt gets a prompt of the server.
+func (c *Client) GetPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	return retry(ctx, c, func() (*mcp.GetPromptResult, error) { return c.Client.GetPrompt(ctx, request) })
+}
@@ -0,0 +1,283 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestMCPServer() *server.MCPServer {
+	svr := server.NewMCPServer("test", "1.0.0")
+	svr.AddTool(mcp.NewTool("echo",
+		mcp.WithDescription("echo the input"),
+		mcp.WithString("input", mcp.Required()),
+	), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText(request.GetString("input", "")), nil
+	})
+	return svr
+}
+
+func runEcho(t *testing.T, ctx context.Context, cli *Client) {
+	tools, err := GetTools(ctx, &Config{Cli: cli})
+	assert.NoError(t, err)
+	if !assert.Len(t, tools, 1) {
+		return
+	}
+	result, err := tools[0].(tool.InvokableTool).InvokableRun(ctx, `{"input": "hello"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"content":[{"type":"text","text":"hello"}]}`, result)
+}
+
+func TestNewClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("streamable http", func(t *testing.T) {
+		srv := server.NewTestStreamableHTTPServer(newTestMCPServer())
+		defer srv.Close()
+
+		cli, err := NewClient(ctx, &ClientConfig{URL: srv.URL + "/mcp"})
+		assert.NoError(t, err)
+		defer cli.Close()
+		assert.Equal(t, TransportStreamableHTTP, cli.conf.Transport)
+		assert.NotEmpty(t, cli.GetSessionId())
+		runEcho(t, ctx, cli)
+	})
+
+	t.Run("sse", func(t *testing.T) {
+		srv := server.NewTestServer(newTestMCPServer())
+		defer srv.Close()
+
+		cli, err := NewClient(ctx, &ClientConfig{Transport: TransportSSE, URL: srv.URL + "/sse"})
+		assert.NoError(t, err)
+		defer cli.Close()
+		runEcho(t, ctx, cli)
+	})
+
+	t.Run("headers", func(t *testing.T) {
+		h := server.NewStreamableHTTPServer(newTestMCPServer())
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-Api-Key") != "key" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			h.ServeHTTP(w, r)
+		}))
+		defer srv.Close()
+
+		_, err := NewClient(ctx, &ClientConfig{URL: srv.URL + "/mcp"})
+		assert.Error(t, err)
+
+		cli, err := NewClient(ctx, &ClientConfig{URL: srv.URL + "/mcp", Headers: map[string]string{"X-Api-Key": "key"}})
+		assert.NoError(t, err)
+		defer cli.Close()
+		runEcho(t, ctx, cli)
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		_, err := NewClient(ctx, nil)
+		assert.EqualError(t, err, "config is nil")
+		_, err = NewClient(ctx, &ClientConfig{})
+		assert.EqualError(t, err, "url or command is required")
+		_, err = NewClient(ctx, &ClientConfig{Transport: TransportSSE})
+		assert.EqualError(t, err, "url is required for the sse transport")
+		_, err = NewClient(ctx, &ClientConfig{Transport: "ws", URL: "http://localhost"})
+		assert.EqualError(t, err, "unsupported transport: ws")
+		_, err = NewClient(ctx, &ClientConfig{Transport: TransportSSE, URL: "http://localhost", SessionID: "id"})
+		assert.EqualError(t, err, "session id is only supported by the streamable http transport")
+		_, err = NewClient(ctx, &ClientConfig{Command: "server", OAuth: &OAuthConfig{RedirectURI: "http://localhost"}})
+		assert.EqualError(t, err, "oauth is only supported by the http transports")
+		_, err = NewClient(ctx, &ClientConfig{URL: "http://localhost", OAuth: &OAuthConfig{}})
+		assert.EqualError(t, err, "oauth redirect uri is required")
+	})
+}
+
+// sessionManager terminates the sessions on demand, as a server restart would.
+type sessionManager struct {
+	mu         sync.Mutex
+	terminated map[string]bool
+}
+
+func (m *sessionManager) Generate() string {
+	return "mcp-session-" + uuid.New().String()
+}
+
+func (m *sessionManager) Validate(sessionID string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.terminated[sessionID], nil
+}
+
+func (m *sessionManager) Terminate(sessionID string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.terminated[sessionID] = true
+	return false, nil
+}
+
+func TestClientSession(t *testing.T) {
+	ctx := context.Background()
+	sm := &sessionManager{terminated: map[string]bool{}}
+	srv := server.NewTestStreamableHTTPServer(newTestMCPServer(), server.WithSessionIdManager(sm))
+	defer srv.Close()
+
+	cli, err := NewClient(ctx, &ClientConfig{URL: srv.URL + "/mcp"})
+	assert.NoError(t, err)
+	sessionID := cli.GetSessionId()
+
+	t.Run("resume", func(t *testing.T) {
+		resumed, err := NewClient(ctx, &ClientConfig{URL: srv.URL + "/mcp", SessionID: sessionID})
+		assert.NoError(t, err)
+		assert.Equal(t, sessionID, resumed.GetSessionId())
+		runEcho(t, ctx, resumed)
+	})
+
+	t.Run("terminated", func(t *testing.T) {
+		_, _ = sm.Terminate(sessionID)
+		runEcho(t, ctx, cli)
+		assert.NotEmpty(t, cli.GetSessionId())
+		assert.NotEqual(t, sessionID, cli.GetSessionId())
+	})
+}
+
+func TestClientOAuth(t *testing.T) {
+	ctx := context.Background()
+	h := server.NewStreamableHTTPServer(newTestMCPServer())
+
+	var (
+		mu        sync.Mutex
+		token     = "token-1"
+		exchanged int
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /register", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"client_id": "registered"}`))
+	})
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.PostForm.Get("code") != "code" || r.PostForm.Get("code_verifier") == "" || r.PostForm.Get("client_id") != "registered" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": "invalid_grant"}`))
+			return
+		}
+		mu.Lock()
+		exchanged++
+		tok := token
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"access_token": "` + tok + `", "token_type": "bearer", "expires_in": 3600}`))
+	})
+	mux.HandleFunc("/mcp", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		tok := token
+		mu.Unlock()
+		if r.Header.Get("Authorization") != "Bearer "+tok {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	var authorized int
+	authorize := func(ctx context.Context, authURL string) (string, string, error) {
+		authorized++
+		u, err := url.Parse(authURL)
+		assert.NoError(t, err)
+		assert.Equal(t, "/authorize", u.Path)
+		q := u.Query()
+		assert.Equal(t, "registered", q.Get("client_id"))
+		assert.Equal(t, "http://localhost:8085/callback", q.Get("redirect_uri"))
+		assert.Equal(t, "S256", q.Get("code_challenge_method"))
+		assert.NotEmpty(t, q.Get("code_challenge"))
+		return "code", q.Get("state"), nil
+	}
+
+	cli, err := NewClient(ctx, &ClientConfig{
+		URL: srv.URL + "/mcp",
+		OAuth: &OAuthConfig{
+			RedirectURI: "http://localhost:8085/callback",
+			Authorize:   authorize,
+		},
+	})
+	assert.NoError(t, err)
+	defer cli.Close()
+	assert.Equal(t, 1, authorized)
+	runEcho(t, ctx, cli)
+
+	// the token is revoked by the server
+	mu.Lock()
+	token = "token-2"
+	mu.Unlock()
+	runEcho(t, ctx, cli)
+	assert.Equal(t, 2, authorized)
+	assert.Equal(t, 2, exchanged)
+
+	t.Run("not configured", func(t *testing.T) {
+		_, err := NewClient(ctx, &ClientConfig{
+			URL:   srv.URL + "/mcp",
+			OAuth: &OAuthConfig{RedirectURI: "http://localhost:8085/callback"},
+		})
+		assert.ErrorContains(t, err, "oauth authorize is not configured")
+	})
+}
+
+func TestNewLocalAuthorizer(t *testing.T) {
+	ctx := context.Background()
+	redirectURI := "http://127.0.0.1:18085/callback"
+
+	authorize := NewLocalAuthorizer(redirectURI, func(authURL string) error {
+		assert.Equal(t, "https://auth.example.com/authorize?state=s", authURL)
+		go func() {
+			resp, err := http.Get(redirectURI + "?code=c&state=s")
+			if assert.NoError(t, err) {
+				_ = resp.Body.Close()
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+			}
+		}()
+		return nil
+	})
+	code, state, err := authorize(ctx, "https://auth.example.com/authorize?state=s")
+	assert.NoError(t, err)
+	assert.Equal(t, "c", code)
+	assert.Equal(t, "s", state)
+
+	authorize = NewLocalAuthorizer(redirectURI, func(authURL string) error {
+		go func() {
+			resp, err := http.Get(redirectURI + "?error=access_denied&error_description=denied")
+			if assert.NoError(t, err) {
+				_ = resp.Body.Close()
+			}
+		}()
+		return nil
+	})
+	_, _, err = authorize(ctx, "https://auth.example.com/authorize")
+	assert.EqualError(t, err, "authorization server error: access_denied, description: denied")
+
+	_, _, err = NewLocalAuthorizer("https://example.com/callback", nil)(ctx, "")
+	assert.EqualError(t, err, "redirect uri must be a local http url, got: https://example.com/callback")
+}
@@ -6,6 +6,7 @@ require (
 	github.com/bytedance/sonic v1.13.2
 	github.com/cloudwego/eino v0.4.7
 	github.com/eino-contrib/jsonschema v1.0.0
+	github.com/google/uuid v1.6.0
 	github.com/mark3labs/mcp-go v0.33.0
 	github.com/stretchr/testify v1.9.0
 )
@@ -20,7 +21,6 @@ require (
 	github.com/getkin/kin-openapi v0.118.0 // indirect
 	github.com/go-openapi/jsonpointer v0.19.5 // indirect
 	github.com/go-openapi/swag v0.19.5 // indirect
-	github.com/google/uuid v1.6.0 // indirect
 	github.com/goph/emperror v0.17.2 // indirect
 	github.com/invopop/yaml v0.1.0 // indirect
 	github.com/josharian/intern v1.0.0 // indirect
@@ -0,0 +1,98 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+type authorizationResponse struct {
+	code  string
+	state string
+	err   error
+}
+
+// NewLocalAuthorizer returns an OAuthConfig.Authorize for the apps running on the machine of the user:
+// it serves redirectURI, e.g. "http://localhost:8085/callback", calls open with the authorization url,
+// e.g. to open it in the browser or to print it, and waits for the authorization server to redirect
+// the user back with the code.
+func NewLocalAuthorizer(redirectURI string, open func(authURL string) error) func(ctx context.Context, authURL string) (code, state string, err error) {
+	return func(ctx context.Context, authURL string) (string, string, error) {
+		u, err := url.Parse(redirectURI)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid redirect uri: %w", err)
+		}
+		if u.Scheme != "http" || u.Host == "" {
+			return "", "", fmt.Errorf("redirect uri must be a local http url, got: %s", redirectURI)
+		}
+		path := u.Path
+		if path == "" {
+			path = "/"
+		}
+
+		ln, err := net.Listen("tcp", u.Host)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to listen on redirect uri: %w", err)
+		}
+
+		respCh := make(chan authorizationResponse, 1)
+		mux := http.NewServeMux()
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			resp := authorizationResponse{code: q.Get("code"), state: q.Get("state")}
+			if e := q.Get("error"); e != "" {
+				resp.err = fmt.Errorf("authorization server error: %s, description: %s", e, q.Get("error_description"))
+			} else if resp.code == "" {
+				resp.err = fmt.Errorf("authorization server returned no code")
+			}
+			select {
+			case respCh <- resp:
+			default:
+			}
+			if resp.err != nil {
+				http.Error(w, "Authorization failed, you can close this window.", http.StatusBadRequest)
+				return
+			}
+			_, _ = fmt.Fprint(w, "Authorization completed, you can close this window.")
+		})
+		srv := &http.Server{Handler: mux}
+		go func() {
+			if sErr := srv.Serve(ln); sErr != nil && !errors.Is(sErr, http.ErrServerClosed) {
+				select {
+				case respCh <- authorizationResponse{err: fmt.Errorf("redirect server failed: %w", sErr)}:
+				default:
+				}
+			}
+		}()
+		defer srv.Close()
+
+		if err = open(authURL); err != nil {
+			return "", "", fmt.Errorf("failed to open authorization url: %w", err)
+		}
+
+		select {
+		case resp := <-respCh:
+			return resp.code, resp.state, resp.err
+		case <-ctx.Done():
+			return "", "", ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,186 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package arxiv provides tools searching the papers of arXiv by query, category and submission date,
+// and reading their full text with a PDF parser.
+package arxiv
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+
+	"github.com/cloudwego/eino-ext/components/tool/research/internal/paper"
+)
+
+// SortBy is the order of the search results.
+type SortBy string
+
+const (
+	SortByRelevance       SortBy = "relevance"
+	SortByLastUpdatedDate SortBy = "lastUpdatedDate"
+	SortBySubmittedDate   SortBy = "submittedDate"
+)
+
+// maxResults is the maximum number of results of a search allowed by the tool, the API allowing more.
+const maxResults = 50
+
+// Config is the configuration for the arxiv tools.
+type Config struct {
+	// BaseURL is the url of the query endpoint of the arXiv API.
+	// Optional. Default "https://export.arxiv.org/api/query".
+	BaseURL string
+	// PDFBaseURL is the base url of the PDFs, the id of the paper being appended.
+	// Optional. Default "https://arxiv.org/pdf/".
+	PDFBaseURL string
+	// HTTPClient is the http client sending the requests.
+	// Optional. Default a client with Timeout.
+	HTTPClient *http.Client
+	// Timeout is the maximum duration of each request.
+	// Optional. Default 30s.
+	Timeout time.Duration
+	// UserAgent is the user agent of the requests.
+	// Optional. Default "eino (https://github.com/cloudwego/eino)".
+	UserAgent string
+
+	// MaxResults is the default number of results of a search, at most 50.
+	// Optional. Default 10.
+	MaxResults int
+	// SortBy is the order of the search results.
+	// Optional. Default SortByRelevance.
+	SortBy SortBy
+
+	// PDFParser parses the PDF of the papers, e.g. the pdf parser of github.com/cloudwego/eino-ext/components/document/parser/pdf.
+	// The read tool is only created when it is set.
+	// Optional.
+	PDFParser parser.Parser
+	// MaxPDFSize is the maximum size in bytes of a PDF downloaded by the read tool.
+	// Optional. Default 50MB.
+	MaxPDFSize int64
+	// MaxContentLength is the maximum number of characters of the text returned by the read tool.
+	// Optional. Default 50000.
+	MaxContentLength int
+
+	SearchToolName string // Optional. Default "arxiv_search".
+	SearchToolDesc string // Optional. Default a description of the search tool.
+	ReadToolName   string // Optional. Default "arxiv_read_paper".
+	ReadToolDesc   string // Optional. Default a description of the read tool.
+}
+
+// NewToolKit creates the search tool, and the read tool when Config.PDFParser is set.
+func NewToolKit(ctx context.Context, conf *Config) ([]tool.BaseTool, error) {
+	a, err := newArxiv(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	search, err := utils.InferTool(conf.SearchToolName, conf.SearchToolDesc, a.Search)
+	if err != nil {
+		return nil, fmt.Errorf("failed to infer tool: %w", err)
+	}
+	tools := []tool.BaseTool{search}
+
+	if conf.PDFParser != nil {
+		read, err := utils.InferTool(conf.ReadToolName, conf.ReadToolDesc, a.Read)
+		if err != nil {
+			return nil, fmt.Errorf("failed to infer tool: %w", err)
+		}
+		tools = append(tools, read)
+	}
+
+	return tools, nil
+}
+
+// validate validates the configuration and sets default values if not provided.
+func (conf *Config) validate() error {
+	if conf == nil {
+		return fmt.Errorf("config is nil")
+	}
+	if conf.BaseURL == "" {
+		conf.BaseURL = "https://export.arxiv.org/api/query"
+	}
+	if conf.PDFBaseURL == "" {
+		conf.PDFBaseURL = "https://arxiv.org/pdf/"
+	}
+	if conf.Timeout <= 0 {
+		conf.Timeout = 30 * time.Second
+	}
+	if conf.HTTPClient == nil {
+		conf.HTTPClient = &http.Client{Timeout: conf.Timeout}
+	}
+	if conf.UserAgent == "" {
+		conf.UserAgent = "eino (https://github.com/cloudwego/eino)"
+	}
+	if conf.MaxResults <= 0 {
+		conf.MaxResults = 10
+	}
+	if conf.MaxResults > maxResults {
+		return fmt.Errorf("max results must be at most %d", maxResults)
+	}
+	switch conf.SortBy {
+	case "":
+		conf.SortBy = SortByRelevance
+	case SortByRelevance, SortByLastUpdatedDate, SortBySubmittedDate:
+	default:
+		return fmt.Errorf("unsupported sort by: %s", conf.SortBy)
+	}
+	if conf.MaxPDFSize <= 0 {
+		conf.MaxPDFSize = 50 << 20
+	}
+	if conf.MaxContentLength <= 0 {
+		conf.MaxContentLength = 50000
+	}
+	if conf.SearchToolName == "" {
+		conf.SearchToolName = "arxiv_search"
+	}
+	if conf.SearchToolDesc == "" {
+		conf.SearchToolDesc = "Search the preprints of arXiv in physics, mathematics, computer science and other fields. " +
+			"Returns the metadata and abstract of the papers, filtered by category and submission date."
+	}
+	if conf.ReadToolName == "" {
+		conf.ReadToolName = "arxiv_read_paper"
+	}
+	if conf.ReadToolDesc == "" {
+		conf.ReadToolDesc = "Read the full text of an arXiv paper by its id, e.g. 1706.03762, as returned by the search tool."
+	}
+	return nil
+}
+
+type arxiv struct {
+	conf   *Config
+	reader *paper.Reader
+}
+
+func newArxiv(conf *Config) (*arxiv, error) {
+	if err := conf.validate(); err != nil {
+		return nil, err
+	}
+	return &arxiv{
+		conf: conf,
+		reader: &paper.Reader{
+			HTTPClient:       conf.HTTPClient,
+			Parser:           conf.PDFParser,
+			UserAgent:        conf.UserAgent,
+			MaxSize:          conf.MaxPDFSize,
+			MaxContentLength: conf.MaxContentLength,
+		},
+	}, nil
+}
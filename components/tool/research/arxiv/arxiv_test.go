@@ -0,0 +1,196 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package arxiv
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+const mockFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom" xmlns:opensearch="http://a9.com/-/spec/opensearch/1.1/" xmlns:arxiv="http://arxiv.org/schemas/atom">
+  <title type="html">ArXiv Query</title>
+  <opensearch:totalResults>1342</opensearch:totalResults>
+  <entry>
+    <id>http://arxiv.org/abs/1706.03762v7</id>
+    <updated>2023-08-02T00:41:18Z</updated>
+    <published>2017-06-12T17:57:34Z</published>
+    <title>Attention Is All You
+  Need</title>
+    <summary>  The dominant sequence transduction models are based on complex recurrent or
+convolutional neural networks.
+</summary>
+    <author><name>Ashish Vaswani</name></author>
+    <author><name>Noam Shazeer</name></author>
+    <arxiv:comment>15 pages, 5 figures</arxiv:comment>
+    <link href="http://arxiv.org/abs/1706.03762v7" rel="alternate" type="text/html"/>
+    <link title="pdf" href="http://arxiv.org/pdf/1706.03762v7" rel="related" type="application/pdf"/>
+    <arxiv:primary_category term="cs.CL" scheme="http://arxiv.org/schemas/atom"/>
+    <category term="cs.CL" scheme="http://arxiv.org/schemas/atom"/>
+    <category term="cs.LG" scheme="http://arxiv.org/schemas/atom"/>
+  </entry>
+</feed>`
+
+const mockError = `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom" xmlns:opensearch="http://a9.com/-/spec/opensearch/1.1/">
+  <opensearch:totalResults>1</opensearch:totalResults>
+  <entry>
+    <id>http://arxiv.org/api/errors#max_results_must_be_non-negative</id>
+    <title>Error</title>
+    <summary>max_results must be non-negative</summary>
+  </entry>
+</feed>`
+
+type mockParser struct{}
+
+func (m *mockParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	return []*schema.Document{{Content: "text of " + string(data)}}, nil
+}
+
+func TestNewToolKit(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewToolKit(ctx, nil)
+	assert.EqualError(t, err, "config is nil")
+	_, err = NewToolKit(ctx, &Config{MaxResults: 100})
+	assert.EqualError(t, err, "max results must be at most 50")
+	_, err = NewToolKit(ctx, &Config{SortBy: "popularity"})
+	assert.EqualError(t, err, "unsupported sort by: popularity")
+
+	tools, err := NewToolKit(ctx, &Config{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(tools))
+
+	tools, err = NewToolKit(ctx, &Config{PDFParser: &mockParser{}})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(tools))
+	info, err := tools[1].Info(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "arxiv_read_paper", info.Name)
+}
+
+func TestSearch(t *testing.T) {
+	ctx := context.Background()
+
+	var query url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		if query.Get("search_query") == "all:error" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(mockError))
+			return
+		}
+		_, _ = w.Write([]byte(mockFeed))
+	}))
+	defer srv.Close()
+
+	tools, err := NewToolKit(ctx, &Config{BaseURL: srv.URL, MaxResults: 5, SortBy: SortBySubmittedDate})
+	assert.NoError(t, err)
+	search := tools[0].(tool.InvokableTool)
+
+	out, err := search.InvokableRun(ctx, `{"query":"attention transformer","category":"cs.CL","from":"2017-01-01","to":"2017-12-31","max_results":20}`)
+	assert.NoError(t, err)
+	assert.Equal(t, url.Values{
+		"search_query": {"all:attention AND all:transformer AND cat:cs.CL AND submittedDate:[201701010000 TO 201712312359]"},
+		"start":        {"0"},
+		"max_results":  {"5"},
+		"sortBy":       {"submittedDate"},
+		"sortOrder":    {"descending"},
+	}, query)
+
+	var resp SearchResponse
+	assert.NoError(t, json.Unmarshal([]byte(out), &resp))
+	assert.Equal(t, SearchResponse{
+		TotalResults: 1342,
+		Papers: []*Paper{{
+			ID:              "1706.03762v7",
+			Title:           "Attention Is All You Need",
+			Authors:         []string{"Ashish Vaswani", "Noam Shazeer"},
+			Abstract:        "The dominant sequence transduction models are based on complex recurrent or convolutional neural networks.",
+			PrimaryCategory: "cs.CL",
+			Categories:      []string{"cs.CL", "cs.LG"},
+			Published:       "2017-06-12T17:57:34Z",
+			Updated:         "2023-08-02T00:41:18Z",
+			URL:             "http://arxiv.org/abs/1706.03762v7",
+			PDFURL:          "http://arxiv.org/pdf/1706.03762v7",
+			Comment:         "15 pages, 5 figures",
+		}},
+	}, resp)
+
+	_, err = search.InvokableRun(ctx, `{"query":"error"}`)
+	assert.ErrorContains(t, err, "arxiv api error: max_results must be non-negative")
+	_, err = search.InvokableRun(ctx, `{"query":"llm","from":"2024/01/01"}`)
+	assert.ErrorContains(t, err, "invalid from date: 2024/01/01, the format is YYYY-MM-DD")
+}
+
+func TestBuildQuery(t *testing.T) {
+	q, err := buildQuery(&SearchRequest{Query: "ti:transformer AND au:vaswani"})
+	assert.NoError(t, err)
+	assert.Equal(t, "(ti:transformer AND au:vaswani)", q)
+
+	q, err = buildQuery(&SearchRequest{Query: "diffusion", To: "2020-06-30"})
+	assert.NoError(t, err)
+	assert.Equal(t, "all:diffusion AND submittedDate:[199101010000 TO 202006302359]", q)
+
+	_, err = buildQuery(&SearchRequest{Query: " "})
+	assert.EqualError(t, err, "query is required")
+}
+
+func TestNormalizeID(t *testing.T) {
+	for _, id := range []string{"1706.03762", "arXiv:1706.03762", "https://arxiv.org/abs/1706.03762", "http://arxiv.org/pdf/1706.03762.pdf"} {
+		got, err := normalizeID(id)
+		assert.NoError(t, err)
+		assert.Equal(t, "1706.03762", got)
+	}
+	got, err := normalizeID("hep-th/9901001v2")
+	assert.NoError(t, err)
+	assert.Equal(t, "hep-th/9901001v2", got)
+
+	_, err = normalizeID("../etc/passwd")
+	assert.EqualError(t, err, "invalid arxiv id: ../etc/passwd")
+}
+
+func TestRead(t *testing.T) {
+	ctx := context.Background()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/pdf/1706.03762v7", r.URL.Path)
+		_, _ = w.Write([]byte("%PDF-1.5"))
+	}))
+	defer srv.Close()
+
+	a, err := newArxiv(&Config{PDFBaseURL: srv.URL + "/pdf/", PDFParser: &mockParser{}})
+	assert.NoError(t, err)
+
+	resp, err := a.Read(ctx, &ReadRequest{ID: "arXiv:1706.03762v7"})
+	assert.NoError(t, err)
+	assert.Equal(t, &ReadResponse{ID: "1706.03762v7", Content: "text of %PDF-1.5"}, resp)
+}
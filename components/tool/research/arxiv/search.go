@@ -0,0 +1,277 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package arxiv
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SearchRequest is the request of the search tool.
+type SearchRequest struct {
+	Query      string `json:"query" jsonschema:"required,description=The search query. Words are matched in all the fields, the arXiv query syntax such as ti:transformer AND au:vaswani is also supported"`
+	Category   string `json:"category,omitempty" jsonschema:"description=Only return the papers of this arXiv category, e.g. cs.CL or math.PR"`
+	From       string `json:"from,omitempty" jsonschema:"description=Only return the papers submitted on or after this date, format YYYY-MM-DD"`
+	To         string `json:"to,omitempty" jsonschema:"description=Only return the papers submitted on or before this date, format YYYY-MM-DD"`
+	MaxResults int    `json:"max_results,omitempty" jsonschema:"description=The number of papers to return, at most 50"`
+}
+
+// SearchResponse is the response of the search tool.
+type SearchResponse struct {
+	TotalResults int      `json:"total_results"`
+	Papers       []*Paper `json:"papers"`
+}
+
+// Paper is the metadata of an arXiv paper.
+type Paper struct {
+	// ID is the arXiv id of the paper with its version, e.g. 1706.03762v7.
+	ID              string   `json:"id"`
+	Title           string   `json:"title"`
+	Authors         []string `json:"authors"`
+	Abstract        string   `json:"abstract"`
+	PrimaryCategory string   `json:"primary_category,omitempty"`
+	Categories      []string `json:"categories,omitempty"`
+	Published       string   `json:"published"`
+	Updated         string   `json:"updated,omitempty"`
+	URL             string   `json:"url"`
+	PDFURL          string   `json:"pdf_url"`
+	DOI             string   `json:"doi,omitempty"`
+	JournalRef      string   `json:"journal_ref,omitempty"`
+	Comment         string   `json:"comment,omitempty"`
+}
+
+// ReadRequest is the request of the read tool.
+type ReadRequest struct {
+	ID string `json:"id" jsonschema:"required,description=The arXiv id of the paper, e.g. 1706.03762 or 1706.03762v7"`
+}
+
+// ReadResponse is the response of the read tool.
+type ReadResponse struct {
+	ID        string `json:"id"`
+	Content   string `json:"content"`
+	Truncated bool   `json:"truncated,omitempty"`
+}
+
+type feed struct {
+	TotalResults int     `xml:"http://a9.com/-/spec/opensearch/1.1/ totalResults"`
+	Entries      []entry `xml:"http://www.w3.org/2005/Atom entry"`
+}
+
+type entry struct {
+	ID        string `xml:"http://www.w3.org/2005/Atom id"`
+	Title     string `xml:"http://www.w3.org/2005/Atom title"`
+	Summary   string `xml:"http://www.w3.org/2005/Atom summary"`
+	Published string `xml:"http://www.w3.org/2005/Atom published"`
+	Updated   string `xml:"http://www.w3.org/2005/Atom updated"`
+	Authors   []struct {
+		Name string `xml:"http://www.w3.org/2005/Atom name"`
+	} `xml:"http://www.w3.org/2005/Atom author"`
+	Links []struct {
+		Href  string `xml:"href,attr"`
+		Title string `xml:"title,attr"`
+		Rel   string `xml:"rel,attr"`
+	} `xml:"http://www.w3.org/2005/Atom link"`
+	Categories []struct {
+		Term string `xml:"term,attr"`
+	} `xml:"http://www.w3.org/2005/Atom category"`
+	PrimaryCategory struct {
+		Term string `xml:"term,attr"`
+	} `xml:"http://arxiv.org/schemas/atom primary_category"`
+	DOI        string `xml:"http://arxiv.org/schemas/atom doi"`
+	JournalRef string `xml:"http://arxiv.org/schemas/atom journal_ref"`
+	Comment    string `xml:"http://arxiv.org/schemas/atom comment"`
+}
+
+// fieldPrefixRegexp matches the field prefixes of the arXiv query syntax.
+var fieldPrefixRegexp = regexp.MustCompile(`\b(ti|au|abs|co|jr|cat|rn|id|all):`)
+
+// idRegexp matches the new style ids, e.g. 1706.03762v7, and the old style ids, e.g. hep-th/9901001.
+var idRegexp = regexp.MustCompile(`^(\d{4}\.\d{4,5}|[a-z\-]+(\.[A-Z]{2})?/\d{7})(v\d+)?$`)
+
+// Search searches the papers of arXiv.
+func (a *arxiv) Search(ctx context.Context, req *SearchRequest) (*SearchResponse, error) {
+	query, err := buildQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	maxResults := a.conf.MaxResults
+	if req.MaxResults > 0 {
+		maxResults = min(req.MaxResults, maxResults)
+	}
+
+	params := url.Values{}
+	params.Set("search_query", query)
+	params.Set("start", "0")
+	params.Set("max_results", strconv.Itoa(maxResults))
+	params.Set("sortBy", string(a.conf.SortBy))
+	params.Set("sortOrder", "descending")
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, a.conf.BaseURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("User-Agent", a.conf.UserAgent)
+
+	resp, err := a.conf.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var f feed
+	if err = xml.Unmarshal(body, &f); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("arxiv api error, status code: %d", resp.StatusCode)
+		}
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	// errors are returned as a feed with a single entry whose summary is the message
+	if len(f.Entries) == 1 && strings.Contains(f.Entries[0].ID, "/api/errors") {
+		return nil, fmt.Errorf("arxiv api error: %s", normalizeSpace(f.Entries[0].Summary))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("arxiv api error, status code: %d", resp.StatusCode)
+	}
+
+	ret := &SearchResponse{
+		TotalResults: f.TotalResults,
+		Papers:       make([]*Paper, 0, len(f.Entries)),
+	}
+	for _, e := range f.Entries {
+		ret.Papers = append(ret.Papers, e.paper())
+	}
+	return ret, nil
+}
+
+// Read downloads the PDF of the paper and returns its text.
+func (a *arxiv) Read(ctx context.Context, req *ReadRequest) (*ReadResponse, error) {
+	id, err := normalizeID(req.ID)
+	if err != nil {
+		return nil, err
+	}
+	content, truncated, err := a.reader.Read(ctx, a.conf.PDFBaseURL+id)
+	if err != nil {
+		return nil, err
+	}
+	return &ReadResponse{ID: id, Content: content, Truncated: truncated}, nil
+}
+
+func (e *entry) paper() *Paper {
+	p := &Paper{
+		ID:              strings.TrimPrefix(strings.TrimPrefix(e.ID, "http://arxiv.org/abs/"), "https://arxiv.org/abs/"),
+		Title:           normalizeSpace(e.Title),
+		Abstract:        normalizeSpace(e.Summary),
+		PrimaryCategory: e.PrimaryCategory.Term,
+		Published:       e.Published,
+		Updated:         e.Updated,
+		URL:             e.ID,
+		DOI:             e.DOI,
+		JournalRef:      normalizeSpace(e.JournalRef),
+		Comment:         normalizeSpace(e.Comment),
+	}
+	if p.Updated == p.Published {
+		p.Updated = ""
+	}
+	for _, author := range e.Authors {
+		p.Authors = append(p.Authors, author.Name)
+	}
+	for _, c := range e.Categories {
+		p.Categories = append(p.Categories, c.Term)
+	}
+	for _, l := range e.Links {
+		if l.Title == "pdf" {
+			p.PDFURL = l.Href
+		} else if l.Rel == "alternate" {
+			p.URL = l.Href
+		}
+	}
+	return p
+}
+
+// buildQuery builds the search_query parameter of the request.
+func buildQuery(req *SearchRequest) (string, error) {
+	query := strings.TrimSpace(req.Query)
+	if query == "" {
+		return "", errors.New("query is required")
+	}
+
+	var parts []string
+	if fieldPrefixRegexp.MatchString(query) {
+		parts = append(parts, "("+query+")")
+	} else {
+		for _, word := range strings.Fields(query) {
+			parts = append(parts, "all:"+word)
+		}
+	}
+	if req.Category != "" {
+		parts = append(parts, "cat:"+strings.TrimSpace(req.Category))
+	}
+	if req.From != "" || req.To != "" {
+		from, to := "199101010000", "999912312359"
+		if req.From != "" {
+			d, err := time.Parse(time.DateOnly, req.From)
+			if err != nil {
+				return "", fmt.Errorf("invalid from date: %s, the format is YYYY-MM-DD", req.From)
+			}
+			from = d.Format("20060102") + "0000"
+		}
+		if req.To != "" {
+			d, err := time.Parse(time.DateOnly, req.To)
+			if err != nil {
+				return "", fmt.Errorf("invalid to date: %s, the format is YYYY-MM-DD", req.To)
+			}
+			to = d.Format("20060102") + "2359"
+		}
+		parts = append(parts, "submittedDate:["+from+" TO "+to+"]")
+	}
+
+	return strings.Join(parts, " AND "), nil
+}
+
+// normalizeID returns the arXiv id of a paper given by its id, e.g. arXiv:1706.03762, or its url.
+func normalizeID(id string) (string, error) {
+	ret := strings.TrimSpace(id)
+	for _, prefix := range []string{"https://", "http://", "export.arxiv.org/", "arxiv.org/", "abs/", "pdf/"} {
+		ret = strings.TrimPrefix(ret, prefix)
+	}
+	if len(ret) > 6 && strings.EqualFold(ret[:6], "arxiv:") {
+		ret = ret[6:]
+	}
+	ret = strings.TrimSuffix(ret, ".pdf")
+	if !idRegexp.MatchString(ret) {
+		return "", fmt.Errorf("invalid arxiv id: %s", id)
+	}
+	return ret, nil
+}
+
+func normalizeSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,47 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/cloudwego/eino/components/tool"
+
+	"github.com/cloudwego/eino-ext/components/tool/research/arxiv"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// set PDFParser, e.g. the pdf parser of github.com/cloudwego/eino-ext/components/document/parser/pdf,
+	// to also create the arxiv_read_paper tool
+	tools, err := arxiv.NewToolKit(ctx, &arxiv.Config{
+		MaxResults: 5,
+		SortBy:     arxiv.SortBySubmittedDate,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	out, err := tools[0].(tool.InvokableTool).InvokableRun(ctx,
+		`{"query":"retrieval augmented generation","category":"cs.CL","from":"2024-01-01"}`)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Println(out)
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/components/tool"
+
+	"github.com/cloudwego/eino-ext/components/tool/research/semanticscholar"
+)
+
+func main() {
+	ctx := context.Background()
+
+	tools, err := semanticscholar.NewToolKit(ctx, &semanticscholar.Config{
+		APIKey:     os.Getenv("SEMANTIC_SCHOLAR_API_KEY"),
+		MaxResults: 5,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// get a paper with its TLDR, then the papers citing it, as an agent would
+	calls := []struct {
+		tool int
+		args string
+	}{
+		{1, `{"paper_id":"arXiv:1706.03762"}`},
+		{2, `{"paper_id":"arXiv:1706.03762","max_results":3}`},
+	}
+	for _, c := range calls {
+		out, err := tools[c.tool].(tool.InvokableTool).InvokableRun(ctx, c.args)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Println(out)
+	}
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package paper downloads the PDF of papers and hands them to a document parser, for the read tools.
+package paper
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document/parser"
+)
+
+// ErrTooLarge is returned when the PDF is larger than the maximum size.
+var ErrTooLarge = errors.New("pdf is too large")
+
+// Reader downloads the PDF of papers and extracts their text with a parser.
+type Reader struct {
+	HTTPClient *http.Client
+	Parser     parser.Parser
+	UserAgent  string
+	// MaxSize is the maximum size in bytes of a PDF.
+	MaxSize int64
+	// MaxContentLength is the maximum number of characters of the text, longer text is truncated.
+	MaxContentLength int
+}
+
+// Read downloads the PDF at the url and returns its text, and whether it was truncated.
+func (r *Reader) Read(ctx context.Context, url string) (string, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to create request: %w", err)
+	}
+	if r.UserAgent != "" {
+		req.Header.Set("User-Agent", r.UserAgent)
+	}
+	req.Header.Set("Accept", "application/pdf")
+
+	resp, err := r.HTTPClient.Do(req)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to download pdf: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", false, fmt.Errorf("failed to download pdf, status code: %d, url: %s", resp.StatusCode, url)
+	}
+	if resp.ContentLength > r.MaxSize {
+		return "", false, fmt.Errorf("%w: %d bytes", ErrTooLarge, resp.ContentLength)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, r.MaxSize+1))
+	if err != nil {
+		return "", false, fmt.Errorf("failed to download pdf: %w", err)
+	}
+	if int64(len(data)) > r.MaxSize {
+		return "", false, fmt.Errorf("%w: more than %d bytes", ErrTooLarge, r.MaxSize)
+	}
+
+	docs, err := r.Parser.Parse(ctx, bytes.NewReader(data), parser.WithURI(url))
+	if err != nil {
+		return "", false, fmt.Errorf("failed to parse pdf: %w", err)
+	}
+
+	var sb strings.Builder
+	for _, doc := range docs {
+		if doc == nil || strings.TrimSpace(doc.Content) == "" {
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteString("\n\n")
+		}
+		sb.WriteString(strings.TrimSpace(doc.Content))
+	}
+
+	content := sb.String()
+	if runes := []rune(content); len(runes) > r.MaxContentLength {
+		return string(runes[:r.MaxContentLength]), true, nil
+	}
+	return content, false, nil
+}
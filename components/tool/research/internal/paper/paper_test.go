@@ -0,0 +1,86 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package paper
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockParser struct {
+	uri string
+}
+
+func (m *mockParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	m.uri = parser.GetCommonOptions(nil, opts...).URI
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	if string(data) == "broken" {
+		return nil, errors.New("invalid pdf")
+	}
+	return []*schema.Document{{Content: "page 1: " + string(data)}, {Content: " "}, {Content: "page 2\n"}}, nil
+}
+
+func TestReader_Read(t *testing.T) {
+	ctx := context.Background()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "eino", r.Header.Get("User-Agent"))
+		switch r.URL.Path {
+		case "/paper.pdf":
+			_, _ = w.Write([]byte("%PDF"))
+		case "/broken.pdf":
+			_, _ = w.Write([]byte("broken"))
+		case "/large.pdf":
+			_, _ = w.Write(make([]byte, 100))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	p := &mockParser{}
+	r := &Reader{HTTPClient: srv.Client(), Parser: p, UserAgent: "eino", MaxSize: 10, MaxContentLength: 100}
+
+	content, truncated, err := r.Read(ctx, srv.URL+"/paper.pdf")
+	assert.NoError(t, err)
+	assert.Equal(t, "page 1: %PDF\n\npage 2", content)
+	assert.False(t, truncated)
+	assert.Equal(t, srv.URL+"/paper.pdf", p.uri)
+
+	r.MaxContentLength = 6
+	content, truncated, err = r.Read(ctx, srv.URL+"/paper.pdf")
+	assert.NoError(t, err)
+	assert.Equal(t, "page 1", content)
+	assert.True(t, truncated)
+
+	_, _, err = r.Read(ctx, srv.URL+"/broken.pdf")
+	assert.EqualError(t, err, "failed to parse pdf: invalid pdf")
+	_, _, err = r.Read(ctx, srv.URL+"/large.pdf")
+	assert.ErrorIs(t, err, ErrTooLarge)
+	_, _, err = r.Read(ctx, srv.URL+"/missing.pdf")
+	assert.EqualError(t, err, "failed to download pdf, status code: 404, url: "+srv.URL+"/missing.pdf")
+}
@@ -0,0 +1,200 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package semanticscholar provides tools searching the papers of Semantic Scholar, getting their details
+// with TLDRs, their citations and references, and reading their open access PDF with a PDF parser.
+package semanticscholar
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+
+	"github.com/cloudwego/eino-ext/components/tool/research/internal/paper"
+)
+
+// maxResults is the maximum number of results of a search or a list of citations allowed by the API.
+const maxResults = 100
+
+// Config is the configuration for the semantic scholar tools.
+type Config struct {
+	// APIKey is the key of the Semantic Scholar API, sent with the x-api-key header.
+	// Optional but recommended, the requests without key sharing a low rate limit.
+	APIKey string
+	// BaseURL is the base url of the Academic Graph API.
+	// Optional. Default "https://api.semanticscholar.org/graph/v1".
+	BaseURL string
+	// HTTPClient is the http client sending the requests.
+	// Optional. Default a client with Timeout.
+	HTTPClient *http.Client
+	// Timeout is the maximum duration of each request.
+	// Optional. Default 30s.
+	Timeout time.Duration
+	// UserAgent is the user agent of the requests.
+	// Optional. Default "eino (https://github.com/cloudwego/eino)".
+	UserAgent string
+
+	// MaxResults is the default number of papers of a search or a list of citations or references, at most 100.
+	// Optional. Default 10.
+	MaxResults int
+
+	// PDFParser parses the open access PDF of the papers, e.g. the pdf parser of github.com/cloudwego/eino-ext/components/document/parser/pdf.
+	// The read tool is only created when it is set.
+	// Optional.
+	PDFParser parser.Parser
+	// MaxPDFSize is the maximum size in bytes of a PDF downloaded by the read tool.
+	// Optional. Default 50MB.
+	MaxPDFSize int64
+	// MaxContentLength is the maximum number of characters of the text returned by the read tool.
+	// Optional. Default 50000.
+	MaxContentLength int
+
+	SearchToolName     string // Optional. Default "semantic_scholar_search".
+	SearchToolDesc     string // Optional. Default a description of the search tool.
+	PaperToolName      string // Optional. Default "semantic_scholar_paper".
+	PaperToolDesc      string // Optional. Default a description of the paper tool.
+	CitationsToolName  string // Optional. Default "semantic_scholar_citations".
+	CitationsToolDesc  string // Optional. Default a description of the citations tool.
+	ReferencesToolName string // Optional. Default "semantic_scholar_references".
+	ReferencesToolDesc string // Optional. Default a description of the references tool.
+	ReadToolName       string // Optional. Default "semantic_scholar_read_paper".
+	ReadToolDesc       string // Optional. Default a description of the read tool.
+}
+
+// NewToolKit creates the search, paper, citations and references tools, and the read tool when Config.PDFParser is set.
+func NewToolKit(ctx context.Context, conf *Config) ([]tool.BaseTool, error) {
+	s, err := newScholar(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	search, err := utils.InferTool(conf.SearchToolName, conf.SearchToolDesc, s.Search)
+	if err != nil {
+		return nil, fmt.Errorf("failed to infer tool: %w", err)
+	}
+	paperTool, err := utils.InferTool(conf.PaperToolName, conf.PaperToolDesc, s.Paper)
+	if err != nil {
+		return nil, fmt.Errorf("failed to infer tool: %w", err)
+	}
+	citations, err := utils.InferTool(conf.CitationsToolName, conf.CitationsToolDesc, s.Citations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to infer tool: %w", err)
+	}
+	references, err := utils.InferTool(conf.ReferencesToolName, conf.ReferencesToolDesc, s.References)
+	if err != nil {
+		return nil, fmt.Errorf("failed to infer tool: %w", err)
+	}
+	tools := []tool.BaseTool{search, paperTool, citations, references}
+
+	if conf.PDFParser != nil {
+		read, err := utils.InferTool(conf.ReadToolName, conf.ReadToolDesc, s.Read)
+		if err != nil {
+			return nil, fmt.Errorf("failed to infer tool: %w", err)
+		}
+		tools = append(tools, read)
+	}
+
+	return tools, nil
+}
+
+// validate validates the configuration and sets default values if not provided.
+func (conf *Config) validate() error {
+	if conf == nil {
+		return fmt.Errorf("config is nil")
+	}
+	if conf.BaseURL == "" {
+		conf.BaseURL = "https://api.semanticscholar.org/graph/v1"
+	}
+	if conf.Timeout <= 0 {
+		conf.Timeout = 30 * time.Second
+	}
+	if conf.HTTPClient == nil {
+		conf.HTTPClient = &http.Client{Timeout: conf.Timeout}
+	}
+	if conf.UserAgent == "" {
+		conf.UserAgent = "eino (https://github.com/cloudwego/eino)"
+	}
+	if conf.MaxResults <= 0 {
+		conf.MaxResults = 10
+	}
+	if conf.MaxResults > maxResults {
+		return fmt.Errorf("max results must be at most %d", maxResults)
+	}
+	if conf.MaxPDFSize <= 0 {
+		conf.MaxPDFSize = 50 << 20
+	}
+	if conf.MaxContentLength <= 0 {
+		conf.MaxContentLength = 50000
+	}
+	if conf.SearchToolName == "" {
+		conf.SearchToolName = "semantic_scholar_search"
+	}
+	if conf.SearchToolDesc == "" {
+		conf.SearchToolDesc = "Search the scientific papers of all fields indexed by Semantic Scholar. " +
+			"Returns the metadata, abstract and citation count of the papers, filtered by year and field of study."
+	}
+	if conf.PaperToolName == "" {
+		conf.PaperToolName = "semantic_scholar_paper"
+	}
+	if conf.PaperToolDesc == "" {
+		conf.PaperToolDesc = "Get the details of a paper with a TLDR summary, by its Semantic Scholar id or an external id such as arXiv:1706.03762 or DOI:10.18653/v1/N19-1423."
+	}
+	if conf.CitationsToolName == "" {
+		conf.CitationsToolName = "semantic_scholar_citations"
+	}
+	if conf.CitationsToolDesc == "" {
+		conf.CitationsToolDesc = "List the papers citing a paper, most recent first."
+	}
+	if conf.ReferencesToolName == "" {
+		conf.ReferencesToolName = "semantic_scholar_references"
+	}
+	if conf.ReferencesToolDesc == "" {
+		conf.ReferencesToolDesc = "List the papers a paper cites."
+	}
+	if conf.ReadToolName == "" {
+		conf.ReadToolName = "semantic_scholar_read_paper"
+	}
+	if conf.ReadToolDesc == "" {
+		conf.ReadToolDesc = "Read the full text of a paper which has an open access PDF, by its Semantic Scholar id or an external id."
+	}
+	return nil
+}
+
+type scholar struct {
+	conf   *Config
+	reader *paper.Reader
+}
+
+func newScholar(conf *Config) (*scholar, error) {
+	if err := conf.validate(); err != nil {
+		return nil, err
+	}
+	return &scholar{
+		conf: conf,
+		reader: &paper.Reader{
+			HTTPClient:       conf.HTTPClient,
+			Parser:           conf.PDFParser,
+			UserAgent:        conf.UserAgent,
+			MaxSize:          conf.MaxPDFSize,
+			MaxContentLength: conf.MaxContentLength,
+		},
+	}, nil
+}
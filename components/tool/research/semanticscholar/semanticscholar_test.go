@@ -0,0 +1,216 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package semanticscholar
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+const mockPaper = `{
+	"paperId": "204e3073870fae3d05bcbc2f6a8e263d9b72e776",
+	"externalIds": {"ArXiv": "1706.03762", "DBLP": "conf/nips/VaswaniSPUJGKP17", "CorpusId": 13756489},
+	"url": "https://www.semanticscholar.org/paper/204e3073870fae3d05bcbc2f6a8e263d9b72e776",
+	"title": "Attention is All you Need",
+	"abstract": "The dominant sequence transduction models...",
+	"venue": "Neural Information Processing Systems",
+	"year": 2017,
+	"referenceCount": 41,
+	"citationCount": 120000,
+	"influentialCitationCount": 15000,
+	"openAccessPdf": null,
+	"fieldsOfStudy": ["Computer Science"],
+	"publicationDate": "2017-06-12",
+	"authors": [{"authorId": "40348417", "name": "Ashish Vaswani"}, {"authorId": "1846258", "name": "Noam M. Shazeer"}],
+	"tldr": {"model": "tldr@v2.0.0", "text": "A new simple network architecture, the Transformer, based solely on attention mechanisms."}
+}`
+
+type mockParser struct{}
+
+func (m *mockParser) Parse(ctx context.Context, reader io.Reader, opts ...parser.Option) ([]*schema.Document, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	return []*schema.Document{{Content: "text of " + string(data)}}, nil
+}
+
+func newTestServer(t *testing.T) (*httptest.Server, *url.Values) {
+	query := &url.Values{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/bert.pdf" {
+			assert.Equal(t, "key", r.Header.Get("x-api-key"))
+		} else {
+			// the key is not sent to the hosts of the pdfs
+			assert.Empty(t, r.Header.Get("x-api-key"))
+		}
+		*query = r.URL.Query()
+		switch r.URL.EscapedPath() {
+		case "/paper/search":
+			_, _ = w.Write([]byte(`{"total": 2, "offset": 0, "data": [` + mockPaper + `]}`))
+		case "/paper/arXiv:1706.03762":
+			_, _ = w.Write([]byte(mockPaper))
+		case "/paper/DOI:10.18653/v1/N19-1423":
+			_, _ = w.Write([]byte(`{"paperId": "df2b0e26", "title": "BERT", "openAccessPdf": {"url": "` + "http://" + r.Host + `/bert.pdf", "status": "GREEN"}}`))
+		case "/paper/arXiv:1706.03762/citations":
+			_, _ = w.Write([]byte(`{"offset": 0, "data": [{"citingPaper": {"paperId": "a1", "title": "Citing paper", "year": 2024, "citationCount": 3}}, {"citingPaper": {"paperId": null, "title": "Not in the corpus"}}]}`))
+		case "/paper/arXiv:1706.03762/references":
+			_, _ = w.Write([]byte(`{"offset": 0, "data": [{"citedPaper": {"paperId": "b1", "title": "Cited paper", "authors": [{"name": "Yoshua Bengio"}]}}]}`))
+		case "/paper/unknown":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": "Paper with id unknown not found"}`))
+		case "/bert.pdf":
+			_, _ = w.Write([]byte("%PDF-bert"))
+		default:
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"message": "Too Many Requests. Please wait and try again or apply for a key for higher rate limits."}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, query
+}
+
+func TestNewToolKit(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewToolKit(ctx, nil)
+	assert.EqualError(t, err, "config is nil")
+	_, err = NewToolKit(ctx, &Config{MaxResults: 101})
+	assert.EqualError(t, err, "max results must be at most 100")
+
+	tools, err := NewToolKit(ctx, &Config{})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(tools))
+
+	tools, err = NewToolKit(ctx, &Config{PDFParser: &mockParser{}})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, len(tools))
+	info, err := tools[4].Info(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "semantic_scholar_read_paper", info.Name)
+}
+
+func TestSearch(t *testing.T) {
+	ctx := context.Background()
+	srv, query := newTestServer(t)
+
+	tools, err := NewToolKit(ctx, &Config{APIKey: "key", BaseURL: srv.URL})
+	assert.NoError(t, err)
+	search := tools[0].(tool.InvokableTool)
+
+	out, err := search.InvokableRun(ctx, `{"query":"attention","year":"2016-2018","fields_of_study":["Computer Science","Linguistics"],"open_access_only":true,"min_citation_count":100,"max_results":500}`)
+	assert.NoError(t, err)
+	assert.Equal(t, url.Values{
+		"query":            {"attention"},
+		"limit":            {"100"},
+		"fields":           {listFields},
+		"year":             {"2016-2018"},
+		"fieldsOfStudy":    {"Computer Science,Linguistics"},
+		"openAccessPdf":    {""},
+		"minCitationCount": {"100"},
+	}, *query)
+
+	var resp SearchResponse
+	assert.NoError(t, json.Unmarshal([]byte(out), &resp))
+	assert.Equal(t, 2, resp.Total)
+	assert.Equal(t, 1, len(resp.Papers))
+	assert.Equal(t, "Attention is All you Need", resp.Papers[0].Title)
+
+	_, err = search.InvokableRun(ctx, `{"query":""}`)
+	assert.ErrorContains(t, err, "query is required")
+}
+
+func TestPaper(t *testing.T) {
+	ctx := context.Background()
+	srv, query := newTestServer(t)
+
+	s, err := newScholar(&Config{APIKey: "key", BaseURL: srv.URL})
+	assert.NoError(t, err)
+
+	p, err := s.Paper(ctx, &PaperRequest{PaperID: "arXiv:1706.03762"})
+	assert.NoError(t, err)
+	assert.Equal(t, detailFields, query.Get("fields"))
+	assert.Equal(t, &Paper{
+		PaperID:                  "204e3073870fae3d05bcbc2f6a8e263d9b72e776",
+		Title:                    "Attention is All you Need",
+		Authors:                  []string{"Ashish Vaswani", "Noam M. Shazeer"},
+		Year:                     2017,
+		Venue:                    "Neural Information Processing Systems",
+		PublicationDate:          "2017-06-12",
+		Abstract:                 "The dominant sequence transduction models...",
+		TLDR:                     "A new simple network architecture, the Transformer, based solely on attention mechanisms.",
+		FieldsOfStudy:            []string{"Computer Science"},
+		CitationCount:            120000,
+		ReferenceCount:           41,
+		InfluentialCitationCount: 15000,
+		URL:                      "https://www.semanticscholar.org/paper/204e3073870fae3d05bcbc2f6a8e263d9b72e776",
+		ExternalIDs:              map[string]string{"ArXiv": "1706.03762", "DBLP": "conf/nips/VaswaniSPUJGKP17", "CorpusId": "13756489"},
+	}, p)
+
+	_, err = s.Paper(ctx, &PaperRequest{PaperID: "unknown"})
+	assert.EqualError(t, err, "semantic scholar api error, status code: 404, message: Paper with id unknown not found")
+	_, err = s.Paper(ctx, &PaperRequest{PaperID: "rate-limited"})
+	assert.ErrorContains(t, err, "status code: 429, message: Too Many Requests.")
+	_, err = s.Paper(ctx, &PaperRequest{})
+	assert.EqualError(t, err, "paper id is required")
+}
+
+func TestCitationsAndReferences(t *testing.T) {
+	ctx := context.Background()
+	srv, query := newTestServer(t)
+
+	s, err := newScholar(&Config{APIKey: "key", BaseURL: srv.URL, MaxResults: 20})
+	assert.NoError(t, err)
+
+	resp, err := s.Citations(ctx, &ListRequest{PaperID: "arXiv:1706.03762"})
+	assert.NoError(t, err)
+	assert.Equal(t, "20", query.Get("limit"))
+	assert.Equal(t, linkFields, query.Get("fields"))
+	assert.Equal(t, []*Paper{{PaperID: "a1", Title: "Citing paper", Year: 2024, CitationCount: 3}}, resp.Papers)
+
+	resp, err = s.References(ctx, &ListRequest{PaperID: "arXiv:1706.03762", MaxResults: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, "5", query.Get("limit"))
+	assert.Equal(t, []*Paper{{PaperID: "b1", Title: "Cited paper", Authors: []string{"Yoshua Bengio"}}}, resp.Papers)
+}
+
+func TestRead(t *testing.T) {
+	ctx := context.Background()
+	srv, _ := newTestServer(t)
+
+	s, err := newScholar(&Config{APIKey: "key", BaseURL: srv.URL, PDFParser: &mockParser{}})
+	assert.NoError(t, err)
+
+	resp, err := s.Read(ctx, &PaperRequest{PaperID: "DOI:10.18653/v1/N19-1423"})
+	assert.NoError(t, err)
+	assert.Equal(t, &ReadResponse{
+		PaperID: "df2b0e26",
+		Title:   "BERT",
+		PDFURL:  srv.URL + "/bert.pdf",
+		Content: "text of %PDF-bert",
+	}, resp)
+}
@@ -0,0 +1,357 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package semanticscholar
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// listFields are the fields of the papers of the search results.
+	listFields = "paperId,title,abstract,authors,year,venue,publicationDate,citationCount,referenceCount,url,openAccessPdf,externalIds"
+	// detailFields are the fields of the paper tool, with the TLDR which is not available in lists.
+	detailFields = listFields + ",tldr,fieldsOfStudy,influentialCitationCount"
+	// linkFields are the fields of the citing and cited papers, without the abstracts which would fill the context window.
+	linkFields = "paperId,title,authors,year,venue,citationCount,url,externalIds"
+)
+
+// SearchRequest is the request of the search tool.
+type SearchRequest struct {
+	Query            string   `json:"query" jsonschema:"required,description=The search query, plain text without special syntax"`
+	Year             string   `json:"year,omitempty" jsonschema:"description=Only return the papers published in this year or range of years, e.g. 2019, 2016-2020, 2010- or -2015"`
+	FieldsOfStudy    []string `json:"fields_of_study,omitempty" jsonschema:"description=Only return the papers of these fields of study, e.g. Computer Science, Medicine, Biology"`
+	OpenAccessOnly   bool     `json:"open_access_only,omitempty" jsonschema:"description=Only return the papers with an open access PDF"`
+	MinCitationCount int      `json:"min_citation_count,omitempty" jsonschema:"description=Only return the papers cited at least this number of times"`
+	MaxResults       int      `json:"max_results,omitempty" jsonschema:"description=The number of papers to return, at most 100"`
+}
+
+// SearchResponse is the response of the search tool.
+type SearchResponse struct {
+	Total  int      `json:"total"`
+	Papers []*Paper `json:"papers"`
+}
+
+// PaperRequest is the request of the paper and read tools.
+type PaperRequest struct {
+	PaperID string `json:"paper_id" jsonschema:"required,description=The Semantic Scholar id of the paper, or an external id prefixed by its type, e.g. arXiv:1706.03762, DOI:10.18653/v1/N19-1423, PMID:19872477 or CorpusId:215416146"`
+}
+
+// ListRequest is the request of the citations and references tools.
+type ListRequest struct {
+	PaperID    string `json:"paper_id" jsonschema:"required,description=The Semantic Scholar id of the paper, or an external id prefixed by its type, e.g. arXiv:1706.03762"`
+	MaxResults int    `json:"max_results,omitempty" jsonschema:"description=The number of papers to return, at most 100"`
+}
+
+// ListResponse is the response of the citations and references tools.
+type ListResponse struct {
+	Papers []*Paper `json:"papers"`
+}
+
+// ReadResponse is the response of the read tool.
+type ReadResponse struct {
+	PaperID   string `json:"paper_id"`
+	Title     string `json:"title"`
+	PDFURL    string `json:"pdf_url"`
+	Content   string `json:"content"`
+	Truncated bool   `json:"truncated,omitempty"`
+}
+
+// Paper is the metadata of a paper.
+type Paper struct {
+	PaperID                  string   `json:"paper_id"`
+	Title                    string   `json:"title"`
+	Authors                  []string `json:"authors,omitempty"`
+	Year                     int      `json:"year,omitempty"`
+	Venue                    string   `json:"venue,omitempty"`
+	PublicationDate          string   `json:"publication_date,omitempty"`
+	Abstract                 string   `json:"abstract,omitempty"`
+	TLDR                     string   `json:"tldr,omitempty"`
+	FieldsOfStudy            []string `json:"fields_of_study,omitempty"`
+	CitationCount            int      `json:"citation_count"`
+	ReferenceCount           int      `json:"reference_count,omitempty"`
+	InfluentialCitationCount int      `json:"influential_citation_count,omitempty"`
+	URL                      string   `json:"url"`
+	PDFURL                   string   `json:"pdf_url,omitempty"`
+	// ExternalIDs are the ids of the paper in other databases, e.g. ArXiv, DOI or PubMed.
+	ExternalIDs map[string]string `json:"external_ids,omitempty"`
+}
+
+type apiPaper struct {
+	PaperID         string                     `json:"paperId"`
+	Title           string                     `json:"title"`
+	Abstract        string                     `json:"abstract"`
+	Venue           string                     `json:"venue"`
+	Year            int                        `json:"year"`
+	PublicationDate string                     `json:"publicationDate"`
+	URL             string                     `json:"url"`
+	ExternalIDs     map[string]json.RawMessage `json:"externalIds"`
+	FieldsOfStudy   []string                   `json:"fieldsOfStudy"`
+	Authors         []struct {
+		Name string `json:"name"`
+	} `json:"authors"`
+	CitationCount            int `json:"citationCount"`
+	ReferenceCount           int `json:"referenceCount"`
+	InfluentialCitationCount int `json:"influentialCitationCount"`
+	OpenAccessPDF            *struct {
+		URL string `json:"url"`
+	} `json:"openAccessPdf"`
+	TLDR *struct {
+		Text string `json:"text"`
+	} `json:"tldr"`
+}
+
+func (p *apiPaper) paper() *Paper {
+	ret := &Paper{
+		PaperID:                  p.PaperID,
+		Title:                    p.Title,
+		Year:                     p.Year,
+		Venue:                    p.Venue,
+		PublicationDate:          p.PublicationDate,
+		Abstract:                 p.Abstract,
+		FieldsOfStudy:            p.FieldsOfStudy,
+		CitationCount:            p.CitationCount,
+		ReferenceCount:           p.ReferenceCount,
+		InfluentialCitationCount: p.InfluentialCitationCount,
+		URL:                      p.URL,
+	}
+	for _, a := range p.Authors {
+		ret.Authors = append(ret.Authors, a.Name)
+	}
+	if p.TLDR != nil {
+		ret.TLDR = p.TLDR.Text
+	}
+	if p.OpenAccessPDF != nil {
+		ret.PDFURL = p.OpenAccessPDF.URL
+	}
+	if len(p.ExternalIDs) > 0 {
+		ret.ExternalIDs = make(map[string]string, len(p.ExternalIDs))
+		for k := range p.ExternalIDs {
+			if v := p.externalID(k); v != "" {
+				ret.ExternalIDs[k] = v
+			}
+		}
+	}
+	return ret
+}
+
+// externalID returns the external id of the type, which is a string, or a number for the CorpusId.
+func (p *apiPaper) externalID(typ string) string {
+	raw := p.ExternalIDs[typ]
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	return string(raw)
+}
+
+// Search searches the papers.
+func (s *scholar) Search(ctx context.Context, req *SearchRequest) (*SearchResponse, error) {
+	if strings.TrimSpace(req.Query) == "" {
+		return nil, errors.New("query is required")
+	}
+
+	params := url.Values{}
+	params.Set("query", req.Query)
+	params.Set("limit", strconv.Itoa(s.limit(req.MaxResults)))
+	params.Set("fields", listFields)
+	if req.Year != "" {
+		params.Set("year", req.Year)
+	}
+	if len(req.FieldsOfStudy) > 0 {
+		params.Set("fieldsOfStudy", strings.Join(req.FieldsOfStudy, ","))
+	}
+	if req.OpenAccessOnly {
+		params.Set("openAccessPdf", "")
+	}
+	if req.MinCitationCount > 0 {
+		params.Set("minCitationCount", strconv.Itoa(req.MinCitationCount))
+	}
+
+	var res struct {
+		Total int         `json:"total"`
+		Data  []*apiPaper `json:"data"`
+	}
+	if err := s.get(ctx, "/paper/search", params, &res); err != nil {
+		return nil, err
+	}
+
+	ret := &SearchResponse{Total: res.Total, Papers: make([]*Paper, 0, len(res.Data))}
+	for _, p := range res.Data {
+		ret.Papers = append(ret.Papers, p.paper())
+	}
+	return ret, nil
+}
+
+// Paper gets the details of a paper.
+func (s *scholar) Paper(ctx context.Context, req *PaperRequest) (*Paper, error) {
+	p, err := s.getPaper(ctx, req.PaperID, detailFields)
+	if err != nil {
+		return nil, err
+	}
+	return p.paper(), nil
+}
+
+// Citations lists the papers citing a paper.
+func (s *scholar) Citations(ctx context.Context, req *ListRequest) (*ListResponse, error) {
+	return s.list(ctx, req, "citations", "citingPaper")
+}
+
+// References lists the papers a paper cites.
+func (s *scholar) References(ctx context.Context, req *ListRequest) (*ListResponse, error) {
+	return s.list(ctx, req, "references", "citedPaper")
+}
+
+// Read downloads the open access PDF of a paper, or its arXiv PDF, and returns its text.
+func (s *scholar) Read(ctx context.Context, req *PaperRequest) (*ReadResponse, error) {
+	p, err := s.getPaper(ctx, req.PaperID, "paperId,title,openAccessPdf,externalIds")
+	if err != nil {
+		return nil, err
+	}
+
+	var pdfURL string
+	if p.OpenAccessPDF != nil && p.OpenAccessPDF.URL != "" {
+		pdfURL = p.OpenAccessPDF.URL
+	} else if arxivID := p.externalID("ArXiv"); arxivID != "" {
+		pdfURL = "https://arxiv.org/pdf/" + arxivID
+	} else {
+		return nil, fmt.Errorf("paper has no open access pdf: %s", req.PaperID)
+	}
+
+	content, truncated, err := s.reader.Read(ctx, pdfURL)
+	if err != nil {
+		return nil, err
+	}
+	return &ReadResponse{
+		PaperID:   p.PaperID,
+		Title:     p.Title,
+		PDFURL:    pdfURL,
+		Content:   content,
+		Truncated: truncated,
+	}, nil
+}
+
+func (s *scholar) getPaper(ctx context.Context, paperID, fields string) (*apiPaper, error) {
+	path, err := paperPath(paperID)
+	if err != nil {
+		return nil, err
+	}
+	var p apiPaper
+	if err = s.get(ctx, path, url.Values{"fields": []string{fields}}, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (s *scholar) list(ctx context.Context, req *ListRequest, endpoint, key string) (*ListResponse, error) {
+	path, err := paperPath(req.PaperID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("limit", strconv.Itoa(s.limit(req.MaxResults)))
+	params.Set("fields", linkFields)
+
+	var res struct {
+		Data []map[string]*apiPaper `json:"data"`
+	}
+	if err = s.get(ctx, path+"/"+endpoint, params, &res); err != nil {
+		return nil, err
+	}
+
+	ret := &ListResponse{Papers: make([]*Paper, 0, len(res.Data))}
+	for _, item := range res.Data {
+		// the papers which are not in the corpus have no id, and only a title at best
+		if p := item[key]; p != nil && p.PaperID != "" {
+			ret.Papers = append(ret.Papers, p.paper())
+		}
+	}
+	return ret, nil
+}
+
+func (s *scholar) limit(n int) int {
+	if n <= 0 {
+		return s.conf.MaxResults
+	}
+	return min(n, maxResults)
+}
+
+func (s *scholar) get(ctx context.Context, path string, params url.Values, result any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(s.conf.BaseURL, "/")+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", s.conf.UserAgent)
+	if s.conf.APIKey != "" {
+		req.Header.Set("x-api-key", s.conf.APIKey)
+	}
+
+	resp, err := s.conf.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("semantic scholar api error, status code: %d, message: %s", resp.StatusCode, errorMessage(body))
+	}
+	if err = json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return nil
+}
+
+// paperPath returns the path of a paper, keeping the slashes of the DOIs which the API expects unescaped.
+func paperPath(paperID string) (string, error) {
+	paperID = strings.TrimSpace(paperID)
+	if paperID == "" {
+		return "", errors.New("paper id is required")
+	}
+	return "/paper/" + strings.ReplaceAll(url.PathEscape(paperID), "%2F", "/"), nil
+}
+
+// errorMessage returns the message of an error response, {"error":"..."} or {"message":"..."}, or the body if it has another format.
+func errorMessage(body []byte) string {
+	var e struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &e); err == nil {
+		if e.Error != "" {
+			return e.Error
+		}
+		if e.Message != "" {
+			return e.Message
+		}
+	}
+	return string(body)
+}
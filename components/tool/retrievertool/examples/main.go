@@ -0,0 +1,76 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/tool/retrievertool"
+)
+
+// keywordRetriever is a retriever for the example, use a vector store retriever instead, e.g. milvus or es8.
+type keywordRetriever struct {
+	docs []*schema.Document
+}
+
+func (r *keywordRetriever) Retrieve(_ context.Context, query string, opts ...retriever.Option) ([]*schema.Document, error) {
+	options := retriever.GetCommonOptions(&retriever.Options{TopK: of(10)}, opts...)
+	var result []*schema.Document
+	for _, doc := range r.docs {
+		for _, word := range strings.Fields(strings.ToLower(query)) {
+			if strings.Contains(strings.ToLower(doc.Content), word) {
+				result = append(result, doc)
+				break
+			}
+		}
+	}
+	return result[:min(len(result), *options.TopK)], nil
+}
+
+func main() {
+	ctx := context.Background()
+
+	t, err := retrievertool.NewTool(ctx, &retrievertool.Config{
+		Retriever: &keywordRetriever{docs: []*schema.Document{
+			{ID: "1", Content: "Reset your password in Settings > Security.", MetaData: map[string]any{"source": "faq.md", "category": "faq"}},
+			{ID: "2", Content: "The router supports WPA3 and a guest network.", MetaData: map[string]any{"source": "router.pdf", "category": "manual"}},
+		}},
+		FilterFields: map[string]*schema.ParameterInfo{
+			"category": {Type: schema.String, Enum: []string{"faq", "manual"}},
+		},
+		ToolName: "search_help_center",
+		ToolDesc: "search the help center articles about the account and the devices",
+	})
+	if err != nil {
+		log.Fatalf("NewTool failed, err=%v", err)
+	}
+
+	out, err := t.InvokableRun(ctx, `{"query":"password network","top_k":3,"filter":{"category":"faq"}}`)
+	if err != nil {
+		log.Fatalf("search failed, err=%v", err)
+	}
+	fmt.Println(out)
+}
+
+func of[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,150 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package retrievertool wraps a Retriever as a tool, so that an agent decides when and what to search,
+// instead of retrieving the documents before every call of the model.
+package retrievertool
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	defaultTopK    = 5
+	defaultMaxTopK = 20
+)
+
+// FilterOptionsFn translates the filter of the model into the options of the retriever,
+// e.g. milvus.WithFilter with a boolean expression built from the filter.
+type FilterOptionsFn func(ctx context.Context, filter map[string]any) ([]retriever.Option, error)
+
+// Config is the configuration for the retriever tool.
+type Config struct {
+	// Retriever is the retriever searching the documents.
+	// Required.
+	Retriever retriever.Retriever
+
+	// DefaultTopK is the number of documents returned when the model doesn't set top_k.
+	// Optional. Default 5.
+	DefaultTopK int
+	// MaxTopK is the maximum number of documents the model can ask for.
+	// Optional. Default 20.
+	MaxTopK int
+
+	// FilterFields are the metadata fields the model can filter the documents on, by name,
+	// e.g. {"category": {Type: schema.String, Enum: []string{"faq", "manual"}}}.
+	// Optional. Default the tool has no filter parameter.
+	FilterFields map[string]*schema.ParameterInfo
+	// FilterOptions translates the filter into the options of the retriever, to filter in the store.
+	// Optional. Default MaxTopK documents are retrieved and filtered on their metadata, a value matching
+	// an equal value, or any of the values of a list.
+	FilterOptions FilterOptionsFn
+	// Options are the options of the retriever added to each call, e.g. retriever.WithScoreThreshold.
+	// Optional.
+	Options []retriever.Option
+
+	// MetadataKeys are the metadata keys of the documents returned to the model, e.g. the source, the title or the url.
+	// Optional. Default all the metadata, except the internal keys starting with "_", e.g. "_score" or "_dense_vector".
+	MetadataKeys []string
+	// MaxContentLength is the maximum number of characters of the content of each document, the rest being cut.
+	// Optional. Default no limit.
+	MaxContentLength int
+
+	ToolName string // Optional. Default "search_knowledge".
+	ToolDesc string // Optional. Default "search the knowledge base for the documents relevant to a query".
+}
+
+// NewTool creates a tool searching the documents with the retriever.
+func NewTool(ctx context.Context, conf *Config) (tool.InvokableTool, error) {
+	if err := conf.validate(); err != nil {
+		return nil, err
+	}
+	s := &searcher{conf: conf}
+	return utils.NewTool(conf.toolInfo(), s.Search), nil
+}
+
+// validate validates the configuration and sets default values if not provided.
+func (conf *Config) validate() error {
+	if conf == nil {
+		return fmt.Errorf("config is nil")
+	}
+	if conf.Retriever == nil {
+		return fmt.Errorf("retriever is required")
+	}
+	if conf.MaxTopK <= 0 {
+		conf.MaxTopK = defaultMaxTopK
+	}
+	if conf.DefaultTopK <= 0 {
+		conf.DefaultTopK = min(defaultTopK, conf.MaxTopK)
+	}
+	if conf.DefaultTopK > conf.MaxTopK {
+		return fmt.Errorf("default top k %d is greater than max top k %d", conf.DefaultTopK, conf.MaxTopK)
+	}
+	for name, field := range conf.FilterFields {
+		if field == nil {
+			return fmt.Errorf("filter field %s is nil", name)
+		}
+	}
+	if conf.MaxContentLength < 0 {
+		return fmt.Errorf("max content length must be positive")
+	}
+	if conf.ToolName == "" {
+		conf.ToolName = "search_knowledge"
+	}
+	if conf.ToolDesc == "" {
+		conf.ToolDesc = "search the knowledge base for the documents relevant to a query. " +
+			"Search when the answer needs facts from the knowledge base, with a specific query, and search again with another query if the results are not relevant."
+	}
+	return nil
+}
+
+func (conf *Config) toolInfo() *schema.ToolInfo {
+	params := map[string]*schema.ParameterInfo{
+		"query": {
+			Type:     schema.String,
+			Desc:     "The search query, a specific question or keywords",
+			Required: true,
+		},
+		"top_k": {
+			Type: schema.Integer,
+			Desc: fmt.Sprintf("The number of documents to return, between 1 and %d, default %d", conf.MaxTopK, conf.DefaultTopK),
+		},
+	}
+	if len(conf.FilterFields) > 0 {
+		names := make([]string, 0, len(conf.FilterFields))
+		for name := range conf.FilterFields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		params["filter"] = &schema.ParameterInfo{
+			Type:      schema.Object,
+			Desc:      "Only return the documents whose metadata match the filter, on the fields " + strings.Join(names, ", "),
+			SubParams: conf.FilterFields,
+		}
+	}
+	return &schema.ToolInfo{
+		Name:        conf.ToolName,
+		Desc:        conf.ToolDesc,
+		ParamsOneOf: schema.NewParamsOneOfByParams(params),
+	}
+}
@@ -0,0 +1,221 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package retrievertool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeRetriever struct {
+	docs  []*schema.Document
+	err   error
+	query string
+	opts  *retriever.Options
+	calls int
+}
+
+func (f *fakeRetriever) Retrieve(_ context.Context, query string, opts ...retriever.Option) ([]*schema.Document, error) {
+	f.calls++
+	f.query = query
+	f.opts = retriever.GetCommonOptions(nil, opts...)
+	if f.err != nil {
+		return nil, f.err
+	}
+	n := len(f.docs)
+	if f.opts.TopK != nil {
+		n = min(n, *f.opts.TopK)
+	}
+	return f.docs[:n], nil
+}
+
+func testDocs() []*schema.Document {
+	return []*schema.Document{
+		(&schema.Document{ID: "1", Content: "Reset the password in the settings.", MetaData: map[string]any{
+			"title": "Password", "category": "faq", "year": int64(2024), "_dense_vector": []float64{0.1},
+		}}).WithScore(0.92),
+		(&schema.Document{ID: "2", Content: "The manual of the router.", MetaData: map[string]any{
+			"title": "Router manual", "category": "manual", "tags": []string{"network", "hardware"},
+		}}).WithScore(0.81),
+		{ID: "3", Content: "Contact the support by email.", MetaData: map[string]any{
+			"title": "Support", "category": "faq", "year": 2023,
+		}},
+	}
+}
+
+func TestNewTool(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewTool(ctx, nil)
+	assert.EqualError(t, err, "config is nil")
+	_, err = NewTool(ctx, &Config{})
+	assert.EqualError(t, err, "retriever is required")
+	_, err = NewTool(ctx, &Config{Retriever: &fakeRetriever{}, DefaultTopK: 10, MaxTopK: 5})
+	assert.EqualError(t, err, "default top k 10 is greater than max top k 5")
+
+	tl, err := NewTool(ctx, &Config{Retriever: &fakeRetriever{}})
+	assert.NoError(t, err)
+	info, err := tl.Info(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "search_knowledge", info.Name)
+	s, err := info.ParamsOneOf.ToJSONSchema()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"query"}, s.Required)
+	_, ok := s.Properties.Get("top_k")
+	assert.True(t, ok)
+	_, ok = s.Properties.Get("filter")
+	assert.False(t, ok)
+
+	tl, err = NewTool(ctx, &Config{
+		Retriever: &fakeRetriever{},
+		FilterFields: map[string]*schema.ParameterInfo{
+			"category": {Type: schema.String, Enum: []string{"faq", "manual"}},
+			"year":     {Type: schema.Integer},
+		},
+	})
+	assert.NoError(t, err)
+	info, err = tl.Info(ctx)
+	assert.NoError(t, err)
+	s, err = info.ParamsOneOf.ToJSONSchema()
+	assert.NoError(t, err)
+	filter, ok := s.Properties.Get("filter")
+	assert.True(t, ok)
+	assert.Equal(t, "Only return the documents whose metadata match the filter, on the fields category, year", filter.Description)
+	assert.Equal(t, 2, filter.Properties.Len())
+}
+
+func TestSearch(t *testing.T) {
+	ctx := context.Background()
+	r := &fakeRetriever{docs: testDocs()}
+	tl, err := NewTool(ctx, &Config{
+		Retriever:        r,
+		DefaultTopK:      2,
+		MaxContentLength: 20,
+		Options:          []retriever.Option{retriever.WithScoreThreshold(0.5)},
+	})
+	assert.NoError(t, err)
+
+	out, err := tl.InvokableRun(ctx, `{"query":" reset password "}`)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"results":[
+		{"id":"1","score":0.92,"content":"Reset the password i...","metadata":{"title":"Password","category":"faq","year":2024}},
+		{"id":"2","score":0.81,"content":"The manual of the ro...","metadata":{"title":"Router manual","category":"manual","tags":["network","hardware"]}}
+	]}`, out)
+	assert.Equal(t, "reset password", r.query)
+	assert.Equal(t, 2, *r.opts.TopK)
+	assert.Equal(t, 0.5, *r.opts.ScoreThreshold)
+
+	out, err = tl.InvokableRun(ctx, `{"query":"support","top_k":3}`)
+	assert.NoError(t, err)
+	var resp SearchResponse
+	assert.NoError(t, json.Unmarshal([]byte(out), &resp))
+	assert.Len(t, resp.Results, 3)
+	// no score without the score of the retriever
+	assert.Nil(t, resp.Results[2].Score)
+
+	_, err = tl.InvokableRun(ctx, `{"query":" "}`)
+	assert.ErrorContains(t, err, "query is required")
+	_, err = tl.InvokableRun(ctx, `{"query":"q","top_k":21}`)
+	assert.ErrorContains(t, err, "top_k must be at most 20")
+	_, err = tl.InvokableRun(ctx, `{"query":"q","filter":{"category":"faq"}}`)
+	assert.ErrorContains(t, err, "filter is not supported")
+
+	r.err = errors.New("connection refused")
+	_, err = tl.InvokableRun(ctx, `{"query":"q"}`)
+	assert.ErrorContains(t, err, "failed to retrieve documents: connection refused")
+}
+
+func TestSearchMetadataKeys(t *testing.T) {
+	ctx := context.Background()
+	s := &searcher{conf: &Config{Retriever: &fakeRetriever{docs: testDocs()}, MetadataKeys: []string{"title", "_dense_vector"}}}
+	assert.NoError(t, s.conf.validate())
+
+	resp, err := s.Search(ctx, &SearchRequest{Query: "q", TopK: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"title": "Password", "_dense_vector": []float64{0.1}}, resp.Results[0].Metadata)
+
+	s.conf.MetadataKeys = []string{}
+	resp, err = s.Search(ctx, &SearchRequest{Query: "q", TopK: 1})
+	assert.NoError(t, err)
+	assert.Nil(t, resp.Results[0].Metadata)
+}
+
+func TestSearchFilter(t *testing.T) {
+	ctx := context.Background()
+	fields := map[string]*schema.ParameterInfo{
+		"category": {Type: schema.String},
+		"year":     {Type: schema.Integer},
+		"tags":     {Type: schema.Array, ElemInfo: &schema.ParameterInfo{Type: schema.String}},
+	}
+
+	// filtered on the metadata
+	r := &fakeRetriever{docs: testDocs()}
+	s := &searcher{conf: &Config{Retriever: r, FilterFields: fields, DefaultTopK: 1}}
+	assert.NoError(t, s.conf.validate())
+
+	ids := func(resp *SearchResponse) []string {
+		var result []string
+		for _, doc := range resp.Results {
+			result = append(result, doc.ID)
+		}
+		return result
+	}
+	resp, err := s.Search(ctx, &SearchRequest{Query: "q", Filter: map[string]any{"category": "faq", "year": float64(2023)}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"3"}, ids(resp))
+	assert.Equal(t, 20, *r.opts.TopK)
+
+	resp, err = s.Search(ctx, &SearchRequest{Query: "q", TopK: 5, Filter: map[string]any{"year": []any{float64(2023), float64(2024)}}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "3"}, ids(resp))
+
+	resp, err = s.Search(ctx, &SearchRequest{Query: "q", Filter: map[string]any{"tags": "network"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2"}, ids(resp))
+
+	resp, err = s.Search(ctx, &SearchRequest{Query: "q", Filter: map[string]any{"category": "blog"}})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Results)
+
+	_, err = s.Search(ctx, &SearchRequest{Query: "q", Filter: map[string]any{"author": "bob"}})
+	assert.EqualError(t, err, "unknown filter field: author")
+
+	// filtered by the retriever
+	var gotFilter map[string]any
+	s.conf.FilterOptions = func(ctx context.Context, filter map[string]any) ([]retriever.Option, error) {
+		gotFilter = filter
+		if filter["category"] == "invalid" {
+			return nil, errors.New("unsupported category")
+		}
+		return []retriever.Option{retriever.WithDSLInfo(map[string]any{"category": filter["category"]})}, nil
+	}
+	resp, err = s.Search(ctx, &SearchRequest{Query: "q", TopK: 2, Filter: map[string]any{"category": "manual"}})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"category": "manual"}, gotFilter)
+	assert.Equal(t, map[string]any{"category": "manual"}, r.opts.DSLInfo)
+	assert.Equal(t, 2, *r.opts.TopK)
+	// the documents are not filtered again
+	assert.Equal(t, []string{"1", "2"}, ids(resp))
+
+	_, err = s.Search(ctx, &SearchRequest{Query: "q", Filter: map[string]any{"category": "invalid"}})
+	assert.EqualError(t, err, "invalid filter: unsupported category")
+}
@@ -0,0 +1,230 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package retrievertool
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+)
+
+// SearchRequest is the request of the retriever tool.
+type SearchRequest struct {
+	Query  string         `json:"query"`
+	TopK   int            `json:"top_k,omitempty"`
+	Filter map[string]any `json:"filter,omitempty"`
+}
+
+// SearchResponse are the documents found, the most relevant first.
+type SearchResponse struct {
+	Results []*Result `json:"results"`
+}
+
+// Result is a document found.
+type Result struct {
+	ID      string   `json:"id,omitempty"`
+	Score   *float64 `json:"score,omitempty"`
+	Content string   `json:"content"`
+	// Metadata is the source metadata of the document, e.g. its title or its url.
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// scoreKey is the metadata key of the score of the documents, set by schema.Document.WithScore.
+const scoreKey = "_score"
+
+type searcher struct {
+	conf *Config
+}
+
+// Search retrieves the documents relevant to the query.
+func (s *searcher) Search(ctx context.Context, req *SearchRequest) (*SearchResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request is nil")
+	}
+	query := strings.TrimSpace(req.Query)
+	if query == "" {
+		return nil, fmt.Errorf("query is required")
+	}
+	topK := req.TopK
+	if topK <= 0 {
+		topK = s.conf.DefaultTopK
+	}
+	if topK > s.conf.MaxTopK {
+		return nil, fmt.Errorf("top_k must be at most %d", s.conf.MaxTopK)
+	}
+	if err := s.checkFilter(req.Filter); err != nil {
+		return nil, err
+	}
+
+	opts := append([]retriever.Option{}, s.conf.Options...)
+	postFilter := len(req.Filter) > 0 && s.conf.FilterOptions == nil
+	switch {
+	case postFilter:
+		// retrieve more candidates, as some of them are filtered out
+		opts = append(opts, retriever.WithTopK(s.conf.MaxTopK))
+	case len(req.Filter) > 0:
+		filterOpts, err := s.conf.FilterOptions(ctx, req.Filter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter: %w", err)
+		}
+		opts = append(append(opts, filterOpts...), retriever.WithTopK(topK))
+	default:
+		opts = append(opts, retriever.WithTopK(topK))
+	}
+
+	docs, err := s.conf.Retriever.Retrieve(ctx, query, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve documents: %w", err)
+	}
+
+	resp := &SearchResponse{Results: make([]*Result, 0, min(len(docs), topK))}
+	for _, doc := range docs {
+		if len(resp.Results) == topK {
+			break
+		}
+		if doc == nil || (postFilter && !matchFilter(doc.MetaData, req.Filter)) {
+			continue
+		}
+		resp.Results = append(resp.Results, s.result(doc))
+	}
+	return resp, nil
+}
+
+// checkFilter checks the fields of the filter are the fields of the configuration.
+func (s *searcher) checkFilter(filter map[string]any) error {
+	if len(filter) == 0 {
+		return nil
+	}
+	if len(s.conf.FilterFields) == 0 {
+		return fmt.Errorf("filter is not supported")
+	}
+	for name := range filter {
+		if _, ok := s.conf.FilterFields[name]; !ok {
+			return fmt.Errorf("unknown filter field: %s", name)
+		}
+	}
+	return nil
+}
+
+func (s *searcher) result(doc *schema.Document) *Result {
+	r := &Result{ID: doc.ID, Content: doc.Content}
+	if _, ok := doc.MetaData[scoreKey]; ok {
+		score := doc.Score()
+		r.Score = &score
+	}
+	if n := s.conf.MaxContentLength; n > 0 {
+		if runes := []rune(r.Content); len(runes) > n {
+			r.Content = string(runes[:n]) + "..."
+		}
+	}
+
+	if s.conf.MetadataKeys != nil {
+		for _, key := range s.conf.MetadataKeys {
+			if v, ok := doc.MetaData[key]; ok {
+				r.setMetadata(key, v)
+			}
+		}
+		return r
+	}
+	for key, v := range doc.MetaData {
+		if !strings.HasPrefix(key, "_") {
+			r.setMetadata(key, v)
+		}
+	}
+	return r
+}
+
+func (r *Result) setMetadata(key string, v any) {
+	if r.Metadata == nil {
+		r.Metadata = make(map[string]any)
+	}
+	r.Metadata[key] = v
+}
+
+// matchFilter checks the metadata match all the fields of the filter.
+func matchFilter(metadata map[string]any, filter map[string]any) bool {
+	for key, want := range filter {
+		got, ok := metadata[key]
+		if !ok || !matchValue(got, want) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchValue checks the value of the metadata is the value of the filter, or any of its values if it's a list.
+// A list in the metadata, e.g. tags, matches if any of its values matches.
+func matchValue(got, want any) bool {
+	if values, ok := asList(want); ok {
+		for _, w := range values {
+			if matchValue(got, w) {
+				return true
+			}
+		}
+		return false
+	}
+	if values, ok := asList(got); ok {
+		for _, g := range values {
+			if matchValue(g, want) {
+				return true
+			}
+		}
+		return false
+	}
+	// the numbers of the model are float64, and the numbers of the metadata of any type
+	if g, ok := asFloat(got); ok {
+		w, ok := asFloat(want)
+		return ok && g == w
+	}
+	return reflect.DeepEqual(got, want)
+}
+
+func asList(v any) ([]any, bool) {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() || (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) {
+		return nil, false
+	}
+	// bytes are a value, not a list
+	if rv.Type().Elem().Kind() == reflect.Uint8 {
+		return nil, false
+	}
+	result := make([]any, rv.Len())
+	for i := range result {
+		result[i] = rv.Index(i).Interface()
+	}
+	return result, true
+}
+
+func asFloat(v any) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return 0, false
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
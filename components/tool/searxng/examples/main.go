@@ -93,7 +93,7 @@ func main() {
 	for i, result := range searchResp.Results {
 		fmt.Printf("%d. Title: %s\n", i+1, result.Title)
 		fmt.Printf("   URL: %s\n", result.URL)
-		fmt.Printf("   Description: %s\n\n", result.Content)
+		fmt.Printf("   Description: %s\n\n", result.Snippet)
 	}
 	fmt.Println("==============")
 
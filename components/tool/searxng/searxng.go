@@ -228,10 +228,38 @@ func validateEngines(engines []Engine) error {
 }
 
 type SearchResult struct {
-	Title   string `json:"title" jsonschema:"description=The title of the search result"`
+	Title string `json:"title" jsonschema:"description=The title of the search result"`
+	// Snippet is the content of the result, named like the snippet of the other search tools.
+	Snippet string `json:"snippet" jsonschema:"description=The snippet of the search result"`
+	// Content is the same as Snippet, kept for compatibility.
 	Content string `json:"content" jsonschema:"description=The content of the search result"`
 	URL     string `json:"url" jsonschema:"description=The URL of the search result"`
 	Engine  string `json:"engine" jsonschema:"description=The engine of the search result"`
+	// PublishedDate is the publication date of the page, when the engine provides it, e.g. for news.
+	PublishedDate string `json:"published_date,omitempty" jsonschema:"description=The publication date of the search result"`
+}
+
+// UnmarshalJSON reads the snippet from the content field, and the publication date from the publishedDate field
+// of the SearXNG API, the result being marshaled with the snippet and published_date fields shared with
+// the other search tools.
+func (r *SearchResult) UnmarshalJSON(data []byte) error {
+	type alias SearchResult
+	aux := struct {
+		*alias
+		APIPublishedDate *string `json:"publishedDate"`
+	}{alias: (*alias)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if r.PublishedDate == "" && aux.APIPublishedDate != nil {
+		r.PublishedDate = *aux.APIPublishedDate
+	}
+	if r.Snippet == "" {
+		r.Snippet = r.Content
+	} else if r.Content == "" {
+		r.Content = r.Snippet
+	}
+	return nil
 }
 
 type SearchResponse struct {
@@ -18,6 +18,7 @@ package searxng
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -427,6 +428,7 @@ func TestParseSearchResponse(t *testing.T) {
 				Results: []*SearchResult{
 					{
 						Title:   "title",
+						Snippet: "content",
 						Content: "content",
 						URL:     "url",
 						Engine:  "engine",
@@ -454,6 +456,7 @@ func TestParseSearchResponse(t *testing.T) {
 				Results: []*SearchResult{
 					{
 						Title:   "title",
+						Snippet: "content",
 						Content: "content",
 						URL:     "url",
 						Engine:  "engine",
@@ -1021,6 +1024,44 @@ func TestSearchRequest_validate_params(t *testing.T) {
 }
 
 func Test_parseSearchResponse(t *testing.T) {
+	t.Run("published date", func(t *testing.T) {
+		body := []byte(`{"results": [{"title": "t1", "publishedDate": "2025-02-11T00:00:00"}, {"title": "t2", "publishedDate": null}]}`)
+		res, err := parseSearchResponse(body)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if res.Results[0].PublishedDate != "2025-02-11T00:00:00" || res.Results[1].PublishedDate != "" {
+			t.Errorf("unexpected published dates: %q, %q", res.Results[0].PublishedDate, res.Results[1].PublishedDate)
+		}
+		out, err := json.Marshal(res.Results[0])
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(string(out), `"published_date":"2025-02-11T00:00:00"`) {
+			t.Errorf("unexpected marshaled result: %s", out)
+		}
+	})
+
+	t.Run("snippet", func(t *testing.T) {
+		body := []byte(`{"results": [{"title": "t1", "content": "c1"}, {"title": "t2", "snippet": "s2"}]}`)
+		res, err := parseSearchResponse(body)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if res.Results[0].Snippet != "c1" || res.Results[0].Content != "c1" {
+			t.Errorf("unexpected first result: %+v", res.Results[0])
+		}
+		if res.Results[1].Snippet != "s2" || res.Results[1].Content != "s2" {
+			t.Errorf("unexpected second result: %+v", res.Results[1])
+		}
+		out, err := json.Marshal(res.Results[0])
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(string(out), `"snippet":"c1","content":"c1"`) {
+			t.Errorf("unexpected marshaled result: %s", out)
+		}
+	})
 	t.Run("no number of results", func(t *testing.T) {
 		body := []byte(`{"results": [{"title": "t1"}, {"title": "t2"}]}`)
 		res, err := parseSearchResponse(body)
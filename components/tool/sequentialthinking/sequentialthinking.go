@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	
 	"github.com/cloudwego/eino/components/tool"
 	"github.com/cloudwego/eino/components/tool/utils"
@@ -113,18 +114,22 @@ type ThoughtResult struct {
 }
 
 // thinkingServer maintains the state of the sequential thinking process.
-// It stores the history of thoughts and manages branches.
+// It stores the history of thoughts of each session, the branches being derived from it.
 type thinkingServer struct {
-	thoughtHistory []*ThoughtRequest
-	branches       map[string][]*ThoughtRequest
+	store          Store
+	sessionID      string
+	maxThoughts    int
+	maxBranchDepth int
+	// mu serializes the thoughts, each being checked against the history loaded before it
+	mu sync.Mutex
 }
 
-// newThinkingServer creates a new instance of thinkingServer with initialized fields.
+// newThinkingServer creates a new instance of thinkingServer keeping the thoughts in memory.
 // Returns: A pointer to the newly created thinkingServer
 func newThinkingServer() *thinkingServer {
 	return &thinkingServer{
-		thoughtHistory: make([]*ThoughtRequest, 0),
-		branches:       make(map[string][]*ThoughtRequest),
+		store:     NewMemoryStore(),
+		sessionID: defaultSessionID,
 	}
 }
 
@@ -194,18 +199,39 @@ func (t *thinkingServer) formatThought(td *ThoughtRequest) string {
 // Returns:
 //   - result: The processed thought result
 //   - err: An error if processing fails
-func (t *thinkingServer) processThought(_ context.Context, td *ThoughtRequest) (*ThoughtResult, error) {
+func (t *thinkingServer) processThought(ctx context.Context, td *ThoughtRequest) (*ThoughtResult, error) {
 	validated := t.validate(td)
 	if validated.ThoughtNumber > validated.TotalThoughts {
 		validated.TotalThoughts = validated.ThoughtNumber
 	}
 	
-	t.thoughtHistory = append(t.thoughtHistory, validated)
+	sessionID := t.session(ctx)
 	
-	if validated.BranchID != "" {
-		t.branches[validated.BranchID] = append(t.branches[validated.BranchID], validated)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	
+	history, err := t.store.Load(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load thought history: %w", err)
+	}
+	
+	if msg := t.checkLimits(history, validated); msg != "" {
+		// the thought is not recorded, and the model is asked to conclude
+		return &ThoughtResult{
+			Content:              msg,
+			ThoughtNumber:        validated.ThoughtNumber,
+			TotalThoughts:        validated.TotalThoughts,
+			NextThoughtNeeded:    false,
+			Branches:             getKeys(groupBranches(history)),
+			ThoughtHistoryLength: len(history),
+		}, nil
 	}
 	
+	if err = t.store.Append(ctx, sessionID, validated); err != nil {
+		return nil, fmt.Errorf("failed to save thought: %w", err)
+	}
+	history = append(history, validated)
+	
 	thought := t.formatThought(validated)
 	
 	return &ThoughtResult{
@@ -213,11 +239,35 @@ func (t *thinkingServer) processThought(_ context.Context, td *ThoughtRequest) (
 		ThoughtNumber:        validated.ThoughtNumber,
 		TotalThoughts:        validated.TotalThoughts,
 		NextThoughtNeeded:    validated.NextThoughtNeeded,
-		Branches:             getKeys(t.branches),
-		ThoughtHistoryLength: len(t.thoughtHistory),
+		Branches:             getKeys(groupBranches(history)),
+		ThoughtHistoryLength: len(history),
 	}, nil
 }
 
+// checkLimits checks the thought against the max thoughts and the max branch depth,
+// returning the message to the model when the thought exceeds them.
+func (t *thinkingServer) checkLimits(history []*ThoughtRequest, td *ThoughtRequest) string {
+	if t.maxThoughts > 0 && len(history) >= t.maxThoughts {
+		return fmt.Sprintf("Maximum of %d thoughts reached, this thought is not recorded. "+
+			"Stop thinking and give the final answer based on the previous thoughts.", t.maxThoughts)
+	}
+	if t.maxBranchDepth > 0 && td.BranchID != "" {
+		if depth := branchDepth(history, td); depth > t.maxBranchDepth {
+			return fmt.Sprintf("Maximum branch depth of %d reached, this branch is not recorded. "+
+				"Continue on an existing branch, or give the final answer.", t.maxBranchDepth)
+		}
+	}
+	return ""
+}
+
+// session returns the session of the thought, from the context or the default one.
+func (t *thinkingServer) session(ctx context.Context) string {
+	if id, ok := ctx.Value(sessionIDKey{}).(string); ok && id != "" {
+		return id
+	}
+	return t.sessionID
+}
+
 // NewTool creates a new sequential thinking tool instance.
 // Returns:
 //   - tool: An invokable tool interface
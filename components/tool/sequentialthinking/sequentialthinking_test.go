@@ -28,10 +28,11 @@ func TestNewThinkingServer(t *testing.T) {
 	convey.Convey("Test NewThinkingServer", t, func() {
 		server := newThinkingServer()
 		convey.So(server, convey.ShouldNotBeNil)
-		convey.So(server.thoughtHistory, convey.ShouldNotBeNil)
-		convey.So(server.branches, convey.ShouldNotBeNil)
-		convey.So(len(server.thoughtHistory), convey.ShouldEqual, 0)
-		convey.So(len(server.branches), convey.ShouldEqual, 0)
+		convey.So(server.store, convey.ShouldNotBeNil)
+		convey.So(server.sessionID, convey.ShouldEqual, defaultSessionID)
+		history, err := server.store.Load(context.Background(), defaultSessionID)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(len(history), convey.ShouldEqual, 0)
 	})
 }
 
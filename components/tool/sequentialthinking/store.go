@@ -0,0 +1,156 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sequentialthinking
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/bytedance/sonic"
+)
+
+// Store persists the thought history of the thinking sessions, so the chains survive the restarts.
+// The implementations must be safe for concurrent use.
+type Store interface {
+	// Append appends the thought to the history of the session.
+	Append(ctx context.Context, sessionID string, thought *ThoughtRequest) error
+	// Load loads the history of the session in order, empty for an unknown session.
+	Load(ctx context.Context, sessionID string) ([]*ThoughtRequest, error)
+	// Clear deletes the history of the session.
+	Clear(ctx context.Context, sessionID string) error
+}
+
+// memoryStore keeps the history in memory, lost on restart.
+type memoryStore struct {
+	mu       sync.RWMutex
+	sessions map[string][]*ThoughtRequest
+}
+
+// NewMemoryStore creates a Store keeping the history in memory.
+func NewMemoryStore() Store {
+	return &memoryStore{sessions: make(map[string][]*ThoughtRequest)}
+}
+
+func (m *memoryStore) Append(_ context.Context, sessionID string, thought *ThoughtRequest) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sessions[sessionID] = append(m.sessions[sessionID], thought)
+	return nil
+}
+
+func (m *memoryStore) Load(_ context.Context, sessionID string) ([]*ThoughtRequest, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	history := m.sessions[sessionID]
+	return append(make([]*ThoughtRequest, 0, len(history)), history...), nil
+}
+
+func (m *memoryStore) Clear(_ context.Context, sessionID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.sessions, sessionID)
+	return nil
+}
+
+// FileStore keeps the history of each session in a JSON lines file of its directory.
+type FileStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewFileStore creates a FileStore in the directory, created if missing.
+func NewFileStore(dir string) (*FileStore, error) {
+	if dir == "" {
+		return nil, errors.New("dir is required")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create dir: %w", err)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+// path returns the file of the session, its id being encoded to be a safe file name.
+func (f *FileStore) path(sessionID string) string {
+	return filepath.Join(f.dir, hex.EncodeToString([]byte(sessionID))+".jsonl")
+}
+
+func (f *FileStore) Append(_ context.Context, sessionID string, thought *ThoughtRequest) error {
+	line, err := sonic.Marshal(thought)
+	if err != nil {
+		return fmt.Errorf("failed to marshal thought: %w", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, err := os.OpenFile(f.path(sessionID), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open session file: %w", err)
+	}
+	defer file.Close()
+	if _, err = file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write thought: %w", err)
+	}
+	return nil
+}
+
+func (f *FileStore) Load(_ context.Context, sessionID string) ([]*ThoughtRequest, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, err := os.Open(f.path(sessionID))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open session file: %w", err)
+	}
+	defer file.Close()
+
+	var history []*ThoughtRequest
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		thought := &ThoughtRequest{}
+		if err = sonic.Unmarshal(scanner.Bytes(), thought); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal thought: %w", err)
+		}
+		history = append(history, thought)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read session file: %w", err)
+	}
+	return history, nil
+}
+
+func (f *FileStore) Clear(_ context.Context, sessionID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := os.Remove(f.path(sessionID)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove session file: %w", err)
+	}
+	return nil
+}
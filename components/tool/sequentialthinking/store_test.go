@@ -0,0 +1,90 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sequentialthinking
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestMemoryStore(t *testing.T) {
+	convey.Convey("Test memory store", t, func() {
+		testStore(NewMemoryStore())
+	})
+}
+
+func TestFileStore(t *testing.T) {
+	convey.Convey("Test file store", t, func() {
+		_, err := NewFileStore("")
+		convey.So(err, convey.ShouldNotBeNil)
+
+		dir := filepath.Join(t.TempDir(), "thoughts")
+		store, err := NewFileStore(dir)
+		convey.So(err, convey.ShouldBeNil)
+		testStore(store)
+
+		// the history survives a new store on the same directory
+		ctx := context.Background()
+		convey.So(store.Append(ctx, "../session", &ThoughtRequest{Thought: "persisted", ThoughtNumber: 1, TotalThoughts: 1}), convey.ShouldBeNil)
+		reopened, err := NewFileStore(dir)
+		convey.So(err, convey.ShouldBeNil)
+		history, err := reopened.Load(ctx, "../session")
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(len(history), convey.ShouldEqual, 1)
+		convey.So(history[0].Thought, convey.ShouldEqual, "persisted")
+
+		// the session ids are encoded as file names inside the directory
+		entries, err := os.ReadDir(dir)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(len(entries), convey.ShouldEqual, 2)
+		for _, e := range entries {
+			convey.So(e.Name(), convey.ShouldEndWith, ".jsonl")
+			convey.So(e.Name(), convey.ShouldNotContainSubstring, "..")
+		}
+	})
+}
+
+func testStore(store Store) {
+	ctx := context.Background()
+
+	history, err := store.Load(ctx, "s1")
+	convey.So(err, convey.ShouldBeNil)
+	convey.So(len(history), convey.ShouldEqual, 0)
+
+	convey.So(store.Append(ctx, "s1", &ThoughtRequest{Thought: "first", ThoughtNumber: 1, TotalThoughts: 2}), convey.ShouldBeNil)
+	convey.So(store.Append(ctx, "s1", &ThoughtRequest{Thought: "second", ThoughtNumber: 2, TotalThoughts: 2, BranchID: "b", BranchFromThought: 1}), convey.ShouldBeNil)
+	convey.So(store.Append(ctx, "s2", &ThoughtRequest{Thought: "other", ThoughtNumber: 1, TotalThoughts: 1}), convey.ShouldBeNil)
+
+	history, err = store.Load(ctx, "s1")
+	convey.So(err, convey.ShouldBeNil)
+	convey.So(len(history), convey.ShouldEqual, 2)
+	convey.So(history[0].Thought, convey.ShouldEqual, "first")
+	convey.So(history[1].BranchID, convey.ShouldEqual, "b")
+	convey.So(history[1].BranchFromThought, convey.ShouldEqual, 1)
+
+	convey.So(store.Clear(ctx, "s1"), convey.ShouldBeNil)
+	convey.So(store.Clear(ctx, "unknown"), convey.ShouldBeNil)
+	history, err = store.Load(ctx, "s1")
+	convey.So(err, convey.ShouldBeNil)
+	convey.So(len(history), convey.ShouldEqual, 0)
+	history, err = store.Load(ctx, "s2")
+	convey.So(err, convey.ShouldBeNil)
+	convey.So(len(history), convey.ShouldEqual, 1)
+}
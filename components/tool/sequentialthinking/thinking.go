@@ -0,0 +1,226 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sequentialthinking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+)
+
+const defaultSessionID = "default"
+
+type sessionIDKey struct{}
+
+// WithSessionID sets the thinking session of the tool calls in the context, e.g. the id of the conversation,
+// so the thoughts of the conversations sharing the tool and its store are kept apart.
+func WithSessionID(ctx context.Context, sessionID string) context.Context {
+	return context.WithValue(ctx, sessionIDKey{}, sessionID)
+}
+
+// Config is the configuration of the sequential thinking tool.
+type Config struct {
+	// Store persists the thought history, e.g. NewFileStore, so the chains survive the restarts.
+	// Optional. Default NewMemoryStore().
+	Store Store
+	// SessionID is the session of the thoughts when the context has none, see WithSessionID.
+	// Optional. Default "default".
+	SessionID string
+	// MaxThoughts bounds the thoughts of a session, the model being asked to conclude once reached.
+	// Optional. Default 0, unlimited.
+	MaxThoughts int
+	// MaxBranchDepth bounds the nesting of the branches, a branch from the main thoughts being at depth 1,
+	// and a branch from a thought of a branch being one level deeper than it.
+	// Optional. Default 0, unlimited.
+	MaxBranchDepth int
+}
+
+// Thinking is the sequential thinking tool, which also inspects and exports the thoughts of its sessions.
+type Thinking struct {
+	tool.InvokableTool
+	server *thinkingServer
+}
+
+// NewThinking creates the sequential thinking tool with the config.
+func NewThinking(_ context.Context, conf *Config) (*Thinking, error) {
+	if conf == nil {
+		return nil, errors.New("config is nil")
+	}
+	if conf.MaxThoughts < 0 || conf.MaxBranchDepth < 0 {
+		return nil, errors.New("max thoughts and max branch depth must not be negative")
+	}
+
+	server := &thinkingServer{
+		store:          conf.Store,
+		sessionID:      conf.SessionID,
+		maxThoughts:    conf.MaxThoughts,
+		maxBranchDepth: conf.MaxBranchDepth,
+	}
+	if server.store == nil {
+		server.store = NewMemoryStore()
+	}
+	if server.sessionID == "" {
+		server.sessionID = defaultSessionID
+	}
+
+	thinkingTool, err := utils.InferTool(toolName, toolDesc, server.processThought)
+	if err != nil {
+		return nil, fmt.Errorf("failed to infer tool: %w", err)
+	}
+	return &Thinking{InvokableTool: thinkingTool, server: server}, nil
+}
+
+// History returns the thoughts of the session in order.
+func (t *Thinking) History(ctx context.Context, sessionID string) ([]*ThoughtRequest, error) {
+	return t.server.store.Load(ctx, sessionID)
+}
+
+// Chain returns the thoughts of the session, as the main thoughts and the branches.
+func (t *Thinking) Chain(ctx context.Context, sessionID string) (*Chain, error) {
+	history, err := t.server.store.Load(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load thought history: %w", err)
+	}
+	return buildChain(sessionID, history), nil
+}
+
+// Branch returns the branch of the session, or nil if it does not exist.
+func (t *Thinking) Branch(ctx context.Context, sessionID, branchID string) (*Branch, error) {
+	chain, err := t.Chain(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range chain.Branches {
+		if b.ID == branchID {
+			return b, nil
+		}
+	}
+	return nil, nil
+}
+
+// Reset deletes the thoughts of the session, e.g. once the conversation ends.
+func (t *Thinking) Reset(ctx context.Context, sessionID string) error {
+	return t.server.store.Clear(ctx, sessionID)
+}
+
+// Chain is the thoughts of a session, to be inspected or exported, e.g. as JSON or with Markdown.
+type Chain struct {
+	SessionID string `json:"session_id"`
+	// Main is the thoughts outside the branches.
+	Main []*ThoughtRequest `json:"main"`
+	// Branches are in the order of their first thought.
+	Branches []*Branch `json:"branches,omitempty"`
+}
+
+// Branch is a branch of the thoughts.
+type Branch struct {
+	ID string `json:"id"`
+	// FromThought is the thought number the branch starts from.
+	FromThought int `json:"from_thought"`
+	// ParentID is the branch of the thought the branch starts from, empty for the main thoughts.
+	ParentID string `json:"parent_id,omitempty"`
+	// Depth is 1 for the branches from the main thoughts, one more than the parent for the others.
+	Depth    int               `json:"depth"`
+	Thoughts []*ThoughtRequest `json:"thoughts"`
+}
+
+// Markdown renders the chain as Markdown, e.g. to review the reasoning of the model.
+func (c *Chain) Markdown() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# Thinking session %s\n\n## Main\n\n", c.SessionID))
+	writeThoughts(&sb, c.Main)
+	for _, b := range c.Branches {
+		parent := "main"
+		if b.ParentID != "" {
+			parent = "branch " + b.ParentID
+		}
+		sb.WriteString(fmt.Sprintf("\n## Branch %s\n\nFrom thought %d of %s, depth %d.\n\n", b.ID, b.FromThought, parent, b.Depth))
+		writeThoughts(&sb, b.Thoughts)
+	}
+	return sb.String()
+}
+
+func writeThoughts(sb *strings.Builder, thoughts []*ThoughtRequest) {
+	for _, td := range thoughts {
+		sb.WriteString(fmt.Sprintf("%d. ", td.ThoughtNumber))
+		if td.IsRevision {
+			sb.WriteString(fmt.Sprintf("(revises thought %d) ", td.RevisesThought))
+		}
+		sb.WriteString(strings.ReplaceAll(td.Thought, "\n", "\n   "))
+		sb.WriteString("\n")
+	}
+}
+
+// buildChain splits the history into the main thoughts and the branches.
+func buildChain(sessionID string, history []*ThoughtRequest) *Chain {
+	chain := &Chain{SessionID: sessionID, Main: make([]*ThoughtRequest, 0)}
+	branches := make(map[string]*Branch)
+	for i, td := range history {
+		if td.BranchID == "" {
+			chain.Main = append(chain.Main, td)
+			continue
+		}
+		b, ok := branches[td.BranchID]
+		if !ok {
+			b = &Branch{ID: td.BranchID, FromThought: td.BranchFromThought}
+			b.ParentID = parentBranch(history[:i], td.BranchFromThought)
+			b.Depth = 1
+			if parent, ok := branches[b.ParentID]; ok {
+				b.Depth = parent.Depth + 1
+			}
+			branches[td.BranchID] = b
+			chain.Branches = append(chain.Branches, b)
+		}
+		b.Thoughts = append(b.Thoughts, td)
+	}
+	return chain
+}
+
+// parentBranch returns the branch of the latest thought with the number, empty for the main thoughts.
+func parentBranch(history []*ThoughtRequest, thoughtNumber int) string {
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].ThoughtNumber == thoughtNumber {
+			return history[i].BranchID
+		}
+	}
+	return ""
+}
+
+// branchDepth returns the depth of the branch of the thought, given the history before it.
+func branchDepth(history []*ThoughtRequest, td *ThoughtRequest) int {
+	chain := buildChain("", append(history[:len(history):len(history)], td))
+	for _, b := range chain.Branches {
+		if b.ID == td.BranchID {
+			return b.Depth
+		}
+	}
+	return 0
+}
+
+// groupBranches groups the thoughts of the branches by branch id.
+func groupBranches(history []*ThoughtRequest) map[string][]*ThoughtRequest {
+	branches := make(map[string][]*ThoughtRequest)
+	for _, td := range history {
+		if td.BranchID != "" {
+			branches[td.BranchID] = append(branches[td.BranchID], td)
+		}
+	}
+	return branches
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sequentialthinking
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bytedance/sonic"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func think(ctx context.Context, thinking *Thinking, req *ThoughtRequest) *ThoughtResult {
+	args, err := sonic.MarshalString(req)
+	convey.So(err, convey.ShouldBeNil)
+	output, err := thinking.InvokableRun(ctx, args)
+	convey.So(err, convey.ShouldBeNil)
+	result := &ThoughtResult{}
+	convey.So(sonic.UnmarshalString(output, result), convey.ShouldBeNil)
+	return result
+}
+
+func TestNewThinking(t *testing.T) {
+	convey.Convey("Test NewThinking", t, func() {
+		ctx := context.Background()
+
+		_, err := NewThinking(ctx, nil)
+		convey.So(err, convey.ShouldNotBeNil)
+		_, err = NewThinking(ctx, &Config{MaxThoughts: -1})
+		convey.So(err, convey.ShouldNotBeNil)
+
+		thinking, err := NewThinking(ctx, &Config{})
+		convey.So(err, convey.ShouldBeNil)
+		info, err := thinking.Info(ctx)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(info.Name, convey.ShouldEqual, toolName)
+	})
+}
+
+func TestThinkingSessions(t *testing.T) {
+	convey.Convey("Test thinking sessions", t, func() {
+		ctx := context.Background()
+		store, err := NewFileStore(t.TempDir())
+		convey.So(err, convey.ShouldBeNil)
+
+		thinking, err := NewThinking(ctx, &Config{Store: store})
+		convey.So(err, convey.ShouldBeNil)
+
+		think(ctx, thinking, &ThoughtRequest{Thought: "default session", ThoughtNumber: 1, TotalThoughts: 2, NextThoughtNeeded: true})
+		result := think(WithSessionID(ctx, "conv-1"), thinking, &ThoughtRequest{Thought: "conversation 1", ThoughtNumber: 1, TotalThoughts: 2, NextThoughtNeeded: true})
+		convey.So(result.ThoughtHistoryLength, convey.ShouldEqual, 1)
+
+		// a new tool on the same store continues the chains after a restart
+		restarted, err := NewThinking(ctx, &Config{Store: store})
+		convey.So(err, convey.ShouldBeNil)
+		result = think(WithSessionID(ctx, "conv-1"), restarted, &ThoughtRequest{Thought: "conversation 1 again", ThoughtNumber: 2, TotalThoughts: 2})
+		convey.So(result.ThoughtHistoryLength, convey.ShouldEqual, 2)
+
+		history, err := restarted.History(ctx, defaultSessionID)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(len(history), convey.ShouldEqual, 1)
+
+		convey.So(restarted.Reset(ctx, "conv-1"), convey.ShouldBeNil)
+		history, err = restarted.History(ctx, "conv-1")
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(len(history), convey.ShouldEqual, 0)
+	})
+}
+
+func TestThinkingBranches(t *testing.T) {
+	convey.Convey("Test thinking branches", t, func() {
+		ctx := WithSessionID(context.Background(), "s")
+		thinking, err := NewThinking(ctx, &Config{MaxBranchDepth: 2})
+		convey.So(err, convey.ShouldBeNil)
+
+		think(ctx, thinking, &ThoughtRequest{Thought: "understand the problem", ThoughtNumber: 1, TotalThoughts: 4, NextThoughtNeeded: true})
+		think(ctx, thinking, &ThoughtRequest{Thought: "try a cache", ThoughtNumber: 2, TotalThoughts: 4, BranchFromThought: 1, BranchID: "cache", NextThoughtNeeded: true})
+		think(ctx, thinking, &ThoughtRequest{Thought: "try an index", ThoughtNumber: 2, TotalThoughts: 4, BranchFromThought: 1, BranchID: "index", NextThoughtNeeded: true})
+		think(ctx, thinking, &ThoughtRequest{Thought: "index on two columns", ThoughtNumber: 3, TotalThoughts: 4, BranchFromThought: 2, BranchID: "index-2", NextThoughtNeeded: true})
+
+		// depth 3 is beyond the limit
+		result := think(ctx, thinking, &ThoughtRequest{Thought: "too deep", ThoughtNumber: 4, TotalThoughts: 4, BranchFromThought: 3, BranchID: "index-3", NextThoughtNeeded: true})
+		convey.So(result.Content, convey.ShouldContainSubstring, "Maximum branch depth of 2 reached")
+		convey.So(result.NextThoughtNeeded, convey.ShouldBeFalse)
+		convey.So(result.ThoughtHistoryLength, convey.ShouldEqual, 4)
+
+		// continuing an existing branch is not deeper
+		result = think(ctx, thinking, &ThoughtRequest{Thought: "index wins", ThoughtNumber: 4, TotalThoughts: 4, BranchID: "index-2"})
+		convey.So(result.ThoughtHistoryLength, convey.ShouldEqual, 5)
+		convey.So(len(result.Branches), convey.ShouldEqual, 3)
+
+		chain, err := thinking.Chain(ctx, "s")
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(len(chain.Main), convey.ShouldEqual, 1)
+		convey.So(len(chain.Branches), convey.ShouldEqual, 3)
+		convey.So(chain.Branches[0].ID, convey.ShouldEqual, "cache")
+		convey.So(chain.Branches[0].Depth, convey.ShouldEqual, 1)
+		convey.So(chain.Branches[2].ID, convey.ShouldEqual, "index-2")
+		convey.So(chain.Branches[2].ParentID, convey.ShouldEqual, "index")
+		convey.So(chain.Branches[2].Depth, convey.ShouldEqual, 2)
+		convey.So(len(chain.Branches[2].Thoughts), convey.ShouldEqual, 2)
+
+		branch, err := thinking.Branch(ctx, "s", "index")
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(branch.Thoughts[0].Thought, convey.ShouldEqual, "try an index")
+		branch, err = thinking.Branch(ctx, "s", "unknown")
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(branch, convey.ShouldBeNil)
+
+		md := chain.Markdown()
+		convey.So(md, convey.ShouldContainSubstring, "# Thinking session s")
+		convey.So(md, convey.ShouldContainSubstring, "1. understand the problem")
+		convey.So(md, convey.ShouldContainSubstring, "## Branch index-2\n\nFrom thought 2 of branch index, depth 2.")
+	})
+}
+
+func TestThinkingMaxThoughts(t *testing.T) {
+	convey.Convey("Test thinking max thoughts", t, func() {
+		ctx := context.Background()
+		thinking, err := NewThinking(ctx, &Config{MaxThoughts: 2})
+		convey.So(err, convey.ShouldBeNil)
+
+		for i := 1; i <= 2; i++ {
+			result := think(ctx, thinking, &ThoughtRequest{Thought: "thinking", ThoughtNumber: i, TotalThoughts: 10, NextThoughtNeeded: true})
+			convey.So(result.NextThoughtNeeded, convey.ShouldBeTrue)
+		}
+		result := think(ctx, thinking, &ThoughtRequest{Thought: "runaway", ThoughtNumber: 3, TotalThoughts: 10, NextThoughtNeeded: true})
+		convey.So(result.Content, convey.ShouldContainSubstring, "Maximum of 2 thoughts reached")
+		convey.So(result.NextThoughtNeeded, convey.ShouldBeFalse)
+		convey.So(result.ThoughtHistoryLength, convey.ShouldEqual, 2)
+	})
+}
@@ -0,0 +1,260 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// maxMessages is the maximum number of messages of a page, as recommended by Slack.
+const maxMessages = 200
+
+// maxTextLength is the maximum length of the text of a message accepted by Slack.
+const maxTextLength = 40000
+
+var tsPattern = regexp.MustCompile(`^[0-9]+\.[0-9]+$`)
+
+// ReadHistoryRequest is the request of the history tool.
+type ReadHistoryRequest struct {
+	Channel  string `json:"channel" jsonschema:"required,description=The id of the channel, e.g. C0123456789"`
+	ThreadTS string `json:"thread_ts,omitempty" jsonschema:"description=The ts of the parent message, to read the replies of its thread"`
+	Limit    int    `json:"limit,omitempty" jsonschema:"description=The number of messages to return, between 1 and 200"`
+	Cursor   string `json:"cursor,omitempty" jsonschema:"description=The next_cursor of the previous call, to get the next page of messages"`
+	Oldest   string `json:"oldest,omitempty" jsonschema:"description=Only return the messages after this ts, e.g. 1700000000.000000"`
+	Latest   string `json:"latest,omitempty" jsonschema:"description=Only return the messages before this ts, e.g. 1700000000.000000"`
+}
+
+// ReadHistoryResponse is the response of the history tool.
+type ReadHistoryResponse struct {
+	Messages []*Message `json:"messages"`
+	// NextCursor is set when there are more messages.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// Message is a message of a channel or a thread.
+type Message struct {
+	TS   string `json:"ts"`
+	User string `json:"user,omitempty"`
+	// BotID is set for the messages of bots, which may have no user.
+	BotID      string `json:"bot_id,omitempty"`
+	Text       string `json:"text"`
+	ThreadTS   string `json:"thread_ts,omitempty"`
+	ReplyCount int    `json:"reply_count,omitempty"`
+}
+
+// PostMessageRequest is the request of the post tool.
+type PostMessageRequest struct {
+	Channel  string `json:"channel" jsonschema:"required,description=The id of the channel, e.g. C0123456789"`
+	Text     string `json:"text" jsonschema:"required,description=The text of the message, in the Slack mrkdwn format"`
+	ThreadTS string `json:"thread_ts,omitempty" jsonschema:"description=The ts of the parent message, to reply in its thread"`
+}
+
+// PostMessageResponse is the posted message.
+type PostMessageResponse struct {
+	Channel string `json:"channel"`
+	TS      string `json:"ts"`
+}
+
+type client struct {
+	conf *Config
+}
+
+func newClient(conf *Config) (*client, error) {
+	if err := conf.validate(); err != nil {
+		return nil, err
+	}
+	return &client{conf: conf}, nil
+}
+
+// checkChannel checks the channel id is well-formed and allowed.
+func (c *client) checkChannel(channel string) error {
+	if !channelIDPattern.MatchString(channel) {
+		return fmt.Errorf("invalid channel id: %q, expected e.g. C0123456789", channel)
+	}
+	if len(c.conf.Channels) > 0 && !slices.Contains(c.conf.Channels, channel) {
+		return fmt.Errorf("channel is not allowed: %s", channel)
+	}
+	return nil
+}
+
+func checkTS(name, ts string) error {
+	if ts != "" && !tsPattern.MatchString(ts) {
+		return fmt.Errorf("invalid %s: %q, expected e.g. 1700000000.000000", name, ts)
+	}
+	return nil
+}
+
+// ReadHistory reads the messages of the channel with conversations.history,
+// or the replies of the thread with conversations.replies.
+func (c *client) ReadHistory(ctx context.Context, req *ReadHistoryRequest) (*ReadHistoryResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request is nil")
+	}
+	if err := c.checkChannel(req.Channel); err != nil {
+		return nil, err
+	}
+	for _, ts := range [][2]string{{"thread_ts", req.ThreadTS}, {"oldest", req.Oldest}, {"latest", req.Latest}} {
+		if err := checkTS(ts[0], ts[1]); err != nil {
+			return nil, err
+		}
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = c.conf.MaxMessages
+	}
+
+	params := url.Values{
+		"channel": {req.Channel},
+		"limit":   {strconv.Itoa(min(limit, maxMessages))},
+	}
+	for k, v := range map[string]string{"cursor": req.Cursor, "oldest": req.Oldest, "latest": req.Latest} {
+		if v != "" {
+			params.Set(k, v)
+		}
+	}
+	method := "conversations.history"
+	if req.ThreadTS != "" {
+		method = "conversations.replies"
+		params.Set("ts", req.ThreadTS)
+	}
+
+	var resp struct {
+		Messages         []*Message `json:"messages"`
+		HasMore          bool       `json:"has_more"`
+		ResponseMetadata struct {
+			NextCursor string `json:"next_cursor"`
+		} `json:"response_metadata"`
+	}
+	if err := c.call(ctx, http.MethodGet, method, params, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	result := &ReadHistoryResponse{Messages: resp.Messages}
+	if result.Messages == nil {
+		result.Messages = []*Message{}
+	}
+	if resp.HasMore || resp.ResponseMetadata.NextCursor != "" {
+		result.NextCursor = resp.ResponseMetadata.NextCursor
+	}
+	return result, nil
+}
+
+// PostMessage posts the message to the channel with chat.postMessage.
+func (c *client) PostMessage(ctx context.Context, req *PostMessageRequest) (*PostMessageResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request is nil")
+	}
+	if err := c.checkChannel(req.Channel); err != nil {
+		return nil, err
+	}
+	if err := checkTS("thread_ts", req.ThreadTS); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(req.Text) == "" {
+		return nil, fmt.Errorf("text is required")
+	}
+	if n := len([]rune(req.Text)); n > maxTextLength {
+		return nil, fmt.Errorf("text is too long: %d characters, max %d", n, maxTextLength)
+	}
+
+	body := map[string]any{"channel": req.Channel, "text": req.Text}
+	if req.ThreadTS != "" {
+		body["thread_ts"] = req.ThreadTS
+	}
+	var resp PostMessageResponse
+	if err := c.call(ctx, http.MethodPost, "chat.postMessage", nil, body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// call calls the Web API method. Slack answers the errors with the status code 200 and ok false,
+// except the rate limits answered with the status code 429 and a Retry-After header.
+func (c *client) call(ctx context.Context, httpMethod, method string, params url.Values, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(b)
+	}
+	u := c.conf.BaseURL + "/" + method
+	if len(params) > 0 {
+		u += "?" + params.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, httpMethod, u, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.conf.Token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	}
+
+	resp, err := c.conf.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return fmt.Errorf("slack api error, method: %s, rate limited, retry after %ss", method, resp.Header.Get("Retry-After"))
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("slack api error, method: %s, status code: %d, message: %s", method, resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	var status struct {
+		OK               bool   `json:"ok"`
+		Error            string `json:"error"`
+		Needed           string `json:"needed"`
+		ResponseMetadata struct {
+			Messages []string `json:"messages"`
+		} `json:"response_metadata"`
+	}
+	if err = json.Unmarshal(respBody, &status); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if !status.OK {
+		msg := status.Error
+		if status.Needed != "" {
+			msg += ", needed scope: " + status.Needed
+		}
+		if len(status.ResponseMetadata.Messages) > 0 {
+			msg += ", " + strings.Join(status.ResponseMetadata.Messages, "; ")
+		}
+		return fmt.Errorf("slack api error, method: %s, error: %s", method, msg)
+	}
+	if err = json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return nil
+}
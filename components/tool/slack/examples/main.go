@@ -0,0 +1,55 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/components/tool"
+
+	"github.com/cloudwego/eino-ext/components/tool/slack"
+)
+
+func main() {
+	ctx := context.Background()
+	channel := os.Getenv("SLACK_CHANNEL_ID")
+
+	tools, err := slack.NewToolKit(ctx, &slack.Config{
+		Token:       os.Getenv("SLACK_BOT_TOKEN"),
+		Channels:    []string{channel},
+		EnableWrite: true,
+	})
+	if err != nil {
+		log.Fatalf("NewToolKit failed, err=%v", err)
+	}
+
+	history := tools[0].(tool.InvokableTool)
+	out, err := history.InvokableRun(ctx, fmt.Sprintf(`{"channel":%q,"limit":10}`, channel))
+	if err != nil {
+		log.Fatalf("slack_read_history failed, err=%v", err)
+	}
+	fmt.Println(out)
+
+	post := tools[1].(tool.InvokableTool)
+	out, err = post.InvokableRun(ctx, fmt.Sprintf(`{"channel":%q,"text":"Hello from the *Eino* Slack tool!"}`, channel))
+	if err != nil {
+		log.Fatalf("slack_post_message failed, err=%v", err)
+	}
+	fmt.Println(out)
+}
@@ -0,0 +1,124 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package slack provides tools for Slack: reading the history of channels and threads with pagination,
+// and posting messages, restricted to an allowlist of channels.
+package slack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+)
+
+var channelIDPattern = regexp.MustCompile(`^[CGD][A-Z0-9]{6,}$`)
+
+// Config is the configuration for the Slack tools.
+type Config struct {
+	// Token is the bot token of the Slack app, starting with "xoxb-", with the channels:history and groups:history scopes
+	// to read the history, and the chat:write scope to post messages.
+	// Required.
+	Token string
+	// BaseURL is the base url of the Slack Web API.
+	// Optional. Default "https://slack.com/api".
+	BaseURL string
+	// HTTPClient is the http client sending the requests.
+	// Optional. Default a client with Timeout.
+	HTTPClient *http.Client
+	// Timeout is the maximum duration of each request.
+	// Optional. Default 30s.
+	Timeout time.Duration
+
+	// Channels is the allowlist of the ids of the channels the tools can access, e.g. "C0123456789".
+	// Optional. Default all the channels the bot is a member of.
+	Channels []string
+	// EnableWrite adds the tool posting messages.
+	// Optional. Default false, the tools are read-only.
+	EnableWrite bool
+
+	// MaxMessages is the default number of messages of a history page, between 1 and 200.
+	// Optional. Default 50.
+	MaxMessages int
+}
+
+// NewToolKit creates the Slack tools: slack_read_history, plus slack_post_message when EnableWrite is set.
+func NewToolKit(ctx context.Context, conf *Config) ([]tool.BaseTool, error) {
+	c, err := newClient(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		tools    []tool.BaseTool
+		inferErr error
+	)
+	add := func(t tool.InvokableTool, err error) {
+		if err != nil {
+			inferErr = errors.Join(inferErr, err)
+			return
+		}
+		tools = append(tools, t)
+	}
+
+	add(utils.InferTool("slack_read_history", "Read the messages of a Slack channel, newest first, "+
+		"or the replies of a thread when thread_ts is set.", c.ReadHistory))
+	if conf.EnableWrite {
+		add(utils.InferTool("slack_post_message", "Post a message to a Slack channel, "+
+			"or reply in a thread when thread_ts is set. The text uses the Slack mrkdwn format.", c.PostMessage))
+	}
+	if inferErr != nil {
+		return nil, fmt.Errorf("failed to infer tool: %w", inferErr)
+	}
+	return tools, nil
+}
+
+// validate validates the configuration and sets default values if not provided.
+func (conf *Config) validate() error {
+	if conf == nil {
+		return fmt.Errorf("config is nil")
+	}
+	if conf.Token == "" {
+		return fmt.Errorf("token is required")
+	}
+	if conf.BaseURL == "" {
+		conf.BaseURL = "https://slack.com/api"
+	}
+	conf.BaseURL = strings.TrimRight(conf.BaseURL, "/")
+	if conf.Timeout <= 0 {
+		conf.Timeout = 30 * time.Second
+	}
+	if conf.HTTPClient == nil {
+		conf.HTTPClient = &http.Client{Timeout: conf.Timeout}
+	}
+	for _, ch := range conf.Channels {
+		if !channelIDPattern.MatchString(ch) {
+			return fmt.Errorf("invalid channel id in allowlist: %q", ch)
+		}
+	}
+	if conf.MaxMessages <= 0 {
+		conf.MaxMessages = 50
+	}
+	if conf.MaxMessages > maxMessages {
+		return fmt.Errorf("max messages must be at most %d", maxMessages)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package slack
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClient(t *testing.T, conf *Config, mux *http.ServeMux) *client {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer xoxb-token", r.Header.Get("Authorization"))
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	conf.Token = "xoxb-token"
+	conf.BaseURL = srv.URL
+	c, err := newClient(conf)
+	assert.NoError(t, err)
+	return c
+}
+
+func TestNewToolKit(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewToolKit(ctx, nil)
+	assert.EqualError(t, err, "config is nil")
+	_, err = NewToolKit(ctx, &Config{})
+	assert.EqualError(t, err, "token is required")
+	_, err = NewToolKit(ctx, &Config{Token: "xoxb-token", Channels: []string{"#general"}})
+	assert.EqualError(t, err, `invalid channel id in allowlist: "#general"`)
+	_, err = NewToolKit(ctx, &Config{Token: "xoxb-token", MaxMessages: 500})
+	assert.EqualError(t, err, "max messages must be at most 200")
+
+	names := func(tools []tool.BaseTool) []string {
+		var result []string
+		for _, tl := range tools {
+			info, err := tl.Info(ctx)
+			assert.NoError(t, err)
+			result = append(result, info.Name)
+		}
+		return result
+	}
+	tools, err := NewToolKit(ctx, &Config{Token: "xoxb-token"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"slack_read_history"}, names(tools))
+	tools, err = NewToolKit(ctx, &Config{Token: "xoxb-token", EnableWrite: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"slack_read_history", "slack_post_message"}, names(tools))
+}
+
+func TestReadHistory(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /conversations.history", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		assert.Equal(t, "C0123456789", q.Get("channel"))
+		assert.Equal(t, "1700000000.000000", q.Get("oldest"))
+		switch q.Get("cursor") {
+		case "":
+			assert.Equal(t, "2", q.Get("limit"))
+			_, _ = w.Write([]byte(`{"ok":true,"messages":[
+				{"type":"message","user":"U1","text":"Deploy done","ts":"1700000300.000200","thread_ts":"1700000300.000200","reply_count":2},
+				{"type":"message","bot_id":"B1","text":"Build passed","ts":"1700000200.000100"}
+			],"has_more":true,"response_metadata":{"next_cursor":"bmV4dA=="}}`))
+		case "bmV4dA==":
+			_, _ = w.Write([]byte(`{"ok":true,"messages":[{"type":"message","user":"U2","text":"Hi","ts":"1700000100.000100"}],"has_more":false,"response_metadata":{"next_cursor":""}}`))
+		}
+	})
+	mux.HandleFunc("GET /conversations.replies", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "1700000300.000200", r.URL.Query().Get("ts"))
+		assert.Equal(t, "50", r.URL.Query().Get("limit"))
+		_, _ = w.Write([]byte(`{"ok":true,"messages":[{"user":"U1","text":"Deploy done","ts":"1700000300.000200","thread_ts":"1700000300.000200"},
+			{"user":"U3","text":"Thanks!","ts":"1700000400.000100","thread_ts":"1700000300.000200"}],"has_more":false}`))
+	})
+	c := newTestClient(t, &Config{Channels: []string{"C0123456789"}}, mux)
+	ctx := context.Background()
+
+	resp, err := c.ReadHistory(ctx, &ReadHistoryRequest{Channel: "C0123456789", Limit: 2, Oldest: "1700000000.000000"})
+	assert.NoError(t, err)
+	assert.Equal(t, &ReadHistoryResponse{
+		Messages: []*Message{
+			{TS: "1700000300.000200", User: "U1", Text: "Deploy done", ThreadTS: "1700000300.000200", ReplyCount: 2},
+			{TS: "1700000200.000100", BotID: "B1", Text: "Build passed"},
+		},
+		NextCursor: "bmV4dA==",
+	}, resp)
+
+	resp, err = c.ReadHistory(ctx, &ReadHistoryRequest{Channel: "C0123456789", Cursor: resp.NextCursor, Oldest: "1700000000.000000"})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Messages, 1)
+	assert.Empty(t, resp.NextCursor)
+
+	resp, err = c.ReadHistory(ctx, &ReadHistoryRequest{Channel: "C0123456789", ThreadTS: "1700000300.000200"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Thanks!", resp.Messages[1].Text)
+
+	_, err = c.ReadHistory(ctx, &ReadHistoryRequest{Channel: "C9999999999"})
+	assert.EqualError(t, err, "channel is not allowed: C9999999999")
+	_, err = c.ReadHistory(ctx, &ReadHistoryRequest{Channel: "general"})
+	assert.EqualError(t, err, `invalid channel id: "general", expected e.g. C0123456789`)
+	_, err = c.ReadHistory(ctx, &ReadHistoryRequest{Channel: "C0123456789", Oldest: "yesterday"})
+	assert.EqualError(t, err, `invalid oldest: "yesterday", expected e.g. 1700000000.000000`)
+}
+
+func TestPostMessage(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /chat.postMessage", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json; charset=utf-8", r.Header.Get("Content-Type"))
+		data, _ := io.ReadAll(r.Body)
+		switch string(data) {
+		case `{"channel":"C0123456789","text":"Deployed *v1.2.0*","thread_ts":"1700000300.000200"}`:
+			_, _ = w.Write([]byte(`{"ok":true,"channel":"C0123456789","ts":"1700000500.000100","message":{"text":"Deployed *v1.2.0*"}}`))
+		case `{"channel":"C0123456789","text":"no scope"}`:
+			_, _ = w.Write([]byte(`{"ok":false,"error":"missing_scope","needed":"chat:write","provided":"channels:history"}`))
+		default:
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	})
+	c := newTestClient(t, &Config{EnableWrite: true}, mux)
+	ctx := context.Background()
+
+	resp, err := c.PostMessage(ctx, &PostMessageRequest{Channel: "C0123456789", Text: "Deployed *v1.2.0*", ThreadTS: "1700000300.000200"})
+	assert.NoError(t, err)
+	assert.Equal(t, &PostMessageResponse{Channel: "C0123456789", TS: "1700000500.000100"}, resp)
+
+	_, err = c.PostMessage(ctx, &PostMessageRequest{Channel: "C0123456789", Text: "no scope"})
+	assert.EqualError(t, err, "slack api error, method: chat.postMessage, error: missing_scope, needed scope: chat:write")
+	_, err = c.PostMessage(ctx, &PostMessageRequest{Channel: "C0123456789", Text: "too fast"})
+	assert.EqualError(t, err, "slack api error, method: chat.postMessage, rate limited, retry after 30s")
+	_, err = c.PostMessage(ctx, &PostMessageRequest{Channel: "C0123456789", Text: " "})
+	assert.EqualError(t, err, "text is required")
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/components/tool/speech"
+)
+
+func main() {
+	ctx := context.Background()
+	apiKey := os.Getenv("OPENAI_API_KEY")
+
+	// text-to-speech tool, saving the audio to a directory
+	ttsTool, err := speech.NewTTSTool(ctx, &speech.TTSConfig{
+		Provider: speech.ProviderOpenAI,
+		APIKey:   apiKey,
+		Voices:   []string{"alloy", "nova", "onyx"},
+		SaveDir:  "./audio",
+	})
+	if err != nil {
+		log.Fatalf("NewTTSTool failed, err=%v", err)
+	}
+	out, err := ttsTool.InvokableRun(ctx, `{"text":"Hello from Eino! How can I help you today?","voice":"nova"}`)
+	if err != nil {
+		log.Fatalf("text to speech failed, err=%v", err)
+	}
+	fmt.Println(out)
+
+	// speech-to-text tool, reading the files of the same directory
+	sttTool, err := speech.NewSTTTool(ctx, &speech.STTConfig{
+		Provider: speech.ProviderOpenAI,
+		APIKey:   apiKey,
+		AudioDir: "./audio",
+	})
+	if err != nil {
+		log.Fatalf("NewSTTTool failed, err=%v", err)
+	}
+	var resp speech.SpeakResponse
+	if err = json.Unmarshal([]byte(out), &resp); err != nil {
+		log.Fatalf("unmarshal failed, err=%v", err)
+	}
+	out, err = sttTool.InvokableRun(ctx, fmt.Sprintf(`{"audio":%q}`, resp.Path))
+	if err != nil {
+		log.Fatalf("speech to text failed, err=%v", err)
+	}
+	fmt.Println(out)
+
+	// streaming synthesis of a text stream, e.g. the streamed answer of a chat model
+	synthesizer, err := speech.NewSynthesizer(ctx, &speech.TTSConfig{
+		Provider: speech.ProviderOpenAI,
+		APIKey:   apiKey,
+		Format:   "pcm",
+	})
+	if err != nil {
+		log.Fatalf("NewSynthesizer failed, err=%v", err)
+	}
+	answer := schema.StreamReaderFromArray([]string{"Sure. The weather in Paris ", "is sunny today, ", "with a high of 24 degrees. ", "Enjoy your day!"})
+	audio, err := synthesizer.StreamText(ctx, answer)
+	if err != nil {
+		log.Fatalf("StreamText failed, err=%v", err)
+	}
+	defer audio.Close()
+
+	f, err := os.Create("./audio/answer.pcm")
+	if err != nil {
+		log.Fatalf("create file failed, err=%v", err)
+	}
+	defer f.Close()
+	for {
+		chunk, err := audio.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			log.Fatalf("receive audio failed, err=%v", err)
+		}
+		// play the chunk, or send it to the client, as soon as it is received
+		if _, err = f.Write(chunk); err != nil {
+			log.Fatalf("write audio failed, err=%v", err)
+		}
+	}
+	fmt.Println("saved the streamed answer to ./audio/answer.pcm, 24kHz 16-bit mono")
+}
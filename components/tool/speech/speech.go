@@ -0,0 +1,64 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package speech provides text-to-speech and speech-to-text tools, with the OpenAI audio API
+// and the Volcengine (Doubao) speech API, and streaming synthesis for voice agent pipelines.
+package speech
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Provider is the speech API.
+type Provider string
+
+const (
+	ProviderOpenAI     Provider = "openai"
+	ProviderVolcengine Provider = "volcengine"
+)
+
+const (
+	defaultOpenAIBaseURL     = "https://api.openai.com/v1"
+	defaultVolcengineBaseURL = "https://openspeech.bytedance.com"
+
+	volcengineStatusOK = 20000000
+)
+
+func newHTTPClient(client *http.Client, timeout time.Duration) *http.Client {
+	if client != nil {
+		return client
+	}
+	return &http.Client{Timeout: timeout}
+}
+
+func checkProvider(p Provider) error {
+	switch p {
+	case ProviderOpenAI, ProviderVolcengine:
+		return nil
+	case "":
+		return fmt.Errorf("provider is required")
+	default:
+		return fmt.Errorf("unsupported provider: %s", p)
+	}
+}
+
+type openaiError struct {
+	Error *struct {
+		Message string `json:"message"`
+		Type    string `json:"type"`
+	} `json:"error"`
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package speech
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// StreamText speaks a stream of text, e.g. the streamed answer of a chat model, sentence by sentence,
+// so that the audio of the first sentence is played while the model is still writing the next ones.
+// The audio chunks of the sentences are returned in order, in a single stream the caller must close.
+// The text stream is closed when it is consumed, or when the returned stream is closed.
+func (s *Synthesizer) StreamText(ctx context.Context, text *schema.StreamReader[string], opts ...StreamOption) (*schema.StreamReader[[]byte], error) {
+	o := &streamOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	sr, sw := schema.Pipe[[]byte](4)
+	go func() {
+		defer sw.Close()
+		defer text.Close()
+
+		speak := func(sentence string) bool {
+			if strings.TrimSpace(sentence) == "" {
+				return true
+			}
+			audio, err := s.open(ctx, &SpeakRequest{Text: sentence, Voice: o.voice, Speed: o.speed})
+			if err != nil {
+				sw.Send(nil, err)
+				return false
+			}
+			defer audio.Close()
+			return copyChunks(sw, audio)
+		}
+
+		var buf strings.Builder
+		for {
+			chunk, err := text.Recv()
+			if errors.Is(err, io.EOF) {
+				speak(buf.String())
+				return
+			}
+			if err != nil {
+				sw.Send(nil, err)
+				return
+			}
+			buf.WriteString(chunk)
+
+			rest := buf.String()
+			for {
+				sentence, remaining, ok := cutSentence(rest)
+				if !ok {
+					break
+				}
+				if !speak(sentence) {
+					return
+				}
+				rest = remaining
+			}
+			buf.Reset()
+			buf.WriteString(rest)
+		}
+	}()
+	return sr, nil
+}
+
+// StreamOption is the option of StreamText.
+type StreamOption func(o *streamOptions)
+
+type streamOptions struct {
+	voice string
+	speed float64
+}
+
+// WithVoice sets the voice of the speech, instead of the configured voice.
+func WithVoice(voice string) StreamOption {
+	return func(o *streamOptions) {
+		o.voice = voice
+	}
+}
+
+// WithSpeed sets the speed of the speech, instead of the configured speed.
+func WithSpeed(speed float64) StreamOption {
+	return func(o *streamOptions) {
+		o.speed = speed
+	}
+}
+
+// cutSentence cuts the first complete sentence of the text. A sentence ends with a CJK punctuation mark or a new line,
+// or with an ASCII punctuation mark followed by a space, so that the dots of numbers and urls do not end sentences.
+func cutSentence(text string) (sentence, rest string, ok bool) {
+	for i, r := range text {
+		switch r {
+		case '。', '！', '？', '；', '\n':
+			end := i + utf8.RuneLen(r)
+			return text[:end], text[end:], true
+		case '.', '!', '?', ';':
+			next, size := utf8.DecodeRuneInString(text[i+1:])
+			if size > 0 && unicode.IsSpace(next) {
+				return text[:i+1], text[i+1:], true
+			}
+		}
+	}
+	return "", text, false
+}
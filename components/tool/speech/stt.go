@@ -0,0 +1,468 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package speech
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+	"github.com/google/uuid"
+)
+
+// STTConfig is the configuration for the speech-to-text transcriber and tool.
+type STTConfig struct {
+	// Provider is the speech recognition API.
+	// Required.
+	Provider Provider
+	// APIKey is the OpenAI API key.
+	// Required for ProviderOpenAI.
+	APIKey string
+	// AppKey is the app id of the Volcengine speech application, in the speech console.
+	// Required for ProviderVolcengine.
+	AppKey string
+	// AccessKey is the access token of the Volcengine speech application.
+	// Required for ProviderVolcengine.
+	AccessKey string
+	// ResourceID is the resource id of the Volcengine recognition service.
+	// Optional. Default "volc.bigasr.auc_turbo", the flash recognition of the big model.
+	ResourceID string
+	// BaseURL is the base url of the API.
+	// Optional. Default "https://api.openai.com/v1" for OpenAI, "https://openspeech.bytedance.com" for Volcengine.
+	BaseURL string
+	// HTTPClient is the http client sending the requests and downloading the audio.
+	// Optional. Default a client with Timeout.
+	HTTPClient *http.Client
+	// Timeout is the maximum duration of each request.
+	// Optional. Default 2 minutes.
+	Timeout time.Duration
+
+	// Model is the OpenAI transcription model, e.g. "whisper-1", "gpt-4o-transcribe" or "gpt-4o-mini-transcribe".
+	// The language and the duration of the audio are only returned by whisper-1. Ignored by Volcengine.
+	// Optional. Default "whisper-1".
+	Model string
+	// Language is the default language of the audio, as an ISO-639-1 code, e.g. "en", improving accuracy and latency.
+	// Optional. Default automatic language detection.
+	Language string
+	// Prompt guides the style of the transcript, or spells the names and terms of the audio, only supported by OpenAI.
+	// Optional.
+	Prompt string
+	// MaxFileSize is the maximum size in bytes of the audio.
+	// Optional. Default 25 MB, the limit of OpenAI.
+	MaxFileSize int64
+
+	// AudioDir is the directory the tool reads local audio files from, e.g. the SaveDir of the text-to-speech tool.
+	// The paths given by the model are relative to it, and cannot leave it.
+	// Optional. Default the tool only accepts http(s) urls.
+	AudioDir string
+
+	ToolName string // Optional. Default "speech_to_text".
+	ToolDesc string // Optional. Default "transcribe the speech of an audio file or url to text".
+}
+
+// TranscribeRequest is the request of the speech-to-text tool.
+type TranscribeRequest struct {
+	Audio    string `json:"audio" jsonschema:"required,description=The http(s) url or the file path of the audio, e.g. mp3, wav, m4a or ogg"`
+	Language string `json:"language,omitempty" jsonschema:"description=The ISO-639-1 code of the language of the audio, e.g. en. Leave empty to detect the language"`
+}
+
+// Transcript is the text of the audio.
+type Transcript struct {
+	Text string `json:"text"`
+	// Language is the detected or the requested language of the audio, if known.
+	Language string `json:"language,omitempty"`
+	// Duration is the duration of the audio in seconds, if known.
+	Duration float64 `json:"duration,omitempty"`
+}
+
+// Transcriber converts speech to text, e.g. the recorded question of the user at the start of a voice pipeline.
+type Transcriber struct {
+	conf *STTConfig
+}
+
+// NewTranscriber creates a new speech-to-text transcriber.
+func NewTranscriber(ctx context.Context, conf *STTConfig) (*Transcriber, error) {
+	if err := conf.validate(); err != nil {
+		return nil, err
+	}
+	return &Transcriber{conf: conf}, nil
+}
+
+// NewSTTTool creates a new speech-to-text tool.
+func NewSTTTool(ctx context.Context, conf *STTConfig) (tool.InvokableTool, error) {
+	t, err := NewTranscriber(ctx, conf)
+	if err != nil {
+		return nil, err
+	}
+	it, err := utils.InferTool(conf.ToolName, conf.ToolDesc, t.transcribeRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to infer tool: %w", err)
+	}
+	return it, nil
+}
+
+// validate validates the configuration and sets default values if not provided.
+func (conf *STTConfig) validate() error {
+	if conf == nil {
+		return fmt.Errorf("config is nil")
+	}
+	if err := checkProvider(conf.Provider); err != nil {
+		return err
+	}
+	switch conf.Provider {
+	case ProviderOpenAI:
+		if conf.APIKey == "" {
+			return fmt.Errorf("api key is required")
+		}
+		if conf.BaseURL == "" {
+			conf.BaseURL = defaultOpenAIBaseURL
+		}
+		if conf.Model == "" {
+			conf.Model = "whisper-1"
+		}
+	case ProviderVolcengine:
+		if conf.AppKey == "" || conf.AccessKey == "" {
+			return fmt.Errorf("app key and access key are required")
+		}
+		if conf.BaseURL == "" {
+			conf.BaseURL = defaultVolcengineBaseURL
+		}
+		if conf.ResourceID == "" {
+			conf.ResourceID = "volc.bigasr.auc_turbo"
+		}
+	}
+	conf.BaseURL = strings.TrimRight(conf.BaseURL, "/")
+	if conf.Timeout <= 0 {
+		conf.Timeout = 2 * time.Minute
+	}
+	conf.HTTPClient = newHTTPClient(conf.HTTPClient, conf.Timeout)
+	if conf.MaxFileSize <= 0 {
+		conf.MaxFileSize = 25 << 20
+	}
+	if conf.ToolName == "" {
+		conf.ToolName = "speech_to_text"
+	}
+	if conf.ToolDesc == "" {
+		conf.ToolDesc = "transcribe the speech of an audio file or url to text"
+	}
+	return nil
+}
+
+// Transcribe transcribes the audio, whose file name gives its format to the API.
+func (t *Transcriber) Transcribe(ctx context.Context, audio []byte, fileName string) (*Transcript, error) {
+	return t.transcribe(ctx, audio, fileName, t.conf.Language)
+}
+
+func (t *Transcriber) transcribeRequest(ctx context.Context, req *TranscribeRequest) (*Transcript, error) {
+	if req == nil || strings.TrimSpace(req.Audio) == "" {
+		return nil, fmt.Errorf("audio is required")
+	}
+	audio, fileName, err := t.load(ctx, strings.TrimSpace(req.Audio))
+	if err != nil {
+		return nil, err
+	}
+	language := t.conf.Language
+	if req.Language != "" {
+		language = req.Language
+	}
+	return t.transcribe(ctx, audio, fileName, language)
+}
+
+func (t *Transcriber) transcribe(ctx context.Context, audio []byte, fileName, language string) (*Transcript, error) {
+	if len(audio) == 0 {
+		return nil, fmt.Errorf("audio is empty")
+	}
+	if int64(len(audio)) > t.conf.MaxFileSize {
+		return nil, fmt.Errorf("audio is too large: %d bytes, max %d", len(audio), t.conf.MaxFileSize)
+	}
+	if t.conf.Provider == ProviderVolcengine {
+		return t.transcribeVolcengine(ctx, audio, language)
+	}
+	return t.transcribeOpenAI(ctx, audio, fileName, language)
+}
+
+// load reads the audio from the url, or from the file in AudioDir.
+func (t *Transcriber) load(ctx context.Context, audio string) ([]byte, string, error) {
+	if strings.HasPrefix(audio, "http://") || strings.HasPrefix(audio, "https://") {
+		return t.download(ctx, audio)
+	}
+	if t.conf.AudioDir == "" {
+		return nil, "", fmt.Errorf("local files are not allowed, the audio must be an http(s) url")
+	}
+
+	p, err := resolvePath(t.conf.AudioDir, audio)
+	if err != nil {
+		return nil, "", err
+	}
+	info, err := os.Stat(p)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to stat audio file: %w", err)
+	}
+	if info.Size() > t.conf.MaxFileSize {
+		return nil, "", fmt.Errorf("audio is too large: %d bytes, max %d", info.Size(), t.conf.MaxFileSize)
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read audio file: %w", err)
+	}
+	return data, filepath.Base(p), nil
+}
+
+// resolvePath resolves the path in dir, rejecting the paths leaving it.
+func resolvePath(dir, p string) (string, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve audio dir: %w", err)
+	}
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(absDir, p)
+	}
+	rel, err := filepath.Rel(absDir, filepath.Clean(p))
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("audio file is outside of the audio dir: %s", p)
+	}
+	return filepath.Join(absDir, rel), nil
+}
+
+func (t *Transcriber) download(ctx context.Context, rawURL string) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create download request: %w", err)
+	}
+	resp, err := t.conf.HTTPClient.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to download audio: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("failed to download audio, status code: %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, t.conf.MaxFileSize+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to download audio: %w", err)
+	}
+	if int64(len(data)) > t.conf.MaxFileSize {
+		return nil, "", fmt.Errorf("audio is too large, max %d bytes", t.conf.MaxFileSize)
+	}
+	return data, downloadFileName(rawURL, resp.Header.Get("Content-Type")), nil
+}
+
+var audioExts = map[string]string{
+	"audio/mpeg":   ".mp3",
+	"audio/mp3":    ".mp3",
+	"audio/wav":    ".wav",
+	"audio/x-wav":  ".wav",
+	"audio/wave":   ".wav",
+	"audio/ogg":    ".ogg",
+	"audio/opus":   ".ogg",
+	"audio/webm":   ".webm",
+	"audio/mp4":    ".m4a",
+	"audio/m4a":    ".m4a",
+	"audio/x-m4a":  ".m4a",
+	"audio/flac":   ".flac",
+	"audio/x-flac": ".flac",
+	"video/mp4":    ".mp4",
+	"video/webm":   ".webm",
+}
+
+// downloadFileName names the downloaded audio after the url, or after its content type
+// when the url has no extension, since OpenAI guesses the format from the file name.
+func downloadFileName(rawURL, contentType string) string {
+	name := ""
+	if u, err := url.Parse(rawURL); err == nil {
+		name = path.Base(u.Path)
+	}
+	if name != "" && name != "/" && name != "." && path.Ext(name) != "" {
+		return name
+	}
+	if name == "" || name == "/" || name == "." {
+		name = "audio"
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if ext, ok := audioExts[mediaType]; ok {
+		return name + ext
+	}
+	return name + ".mp3"
+}
+
+type openaiTranscription struct {
+	openaiError
+	Text     string  `json:"text"`
+	Language string  `json:"language"`
+	Duration float64 `json:"duration"`
+}
+
+func (t *Transcriber) transcribeOpenAI(ctx context.Context, audio []byte, fileName, language string) (*Transcript, error) {
+	// only whisper-1 returns the language and the duration, with the verbose_json format
+	format := "json"
+	if strings.HasPrefix(t.conf.Model, "whisper") {
+		format = "verbose_json"
+	}
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	fw, err := w.CreateFormFile("file", fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if _, err = fw.Write(audio); err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	fields := [][2]string{
+		{"model", t.conf.Model},
+		{"response_format", format},
+		{"language", language},
+		{"prompt", t.conf.Prompt},
+	}
+	for _, f := range fields {
+		if f[1] == "" {
+			continue
+		}
+		if err = w.WriteField(f[0], f[1]); err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+	}
+	if err = w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.conf.BaseURL+"/audio/transcriptions", &body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+t.conf.APIKey)
+
+	resp, err := t.conf.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var tr openaiTranscription
+	if err = json.Unmarshal(respBody, &tr); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("openai transcription api error, status code: %d, message: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+		}
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if tr.Error != nil {
+		return nil, fmt.Errorf("openai transcription api error, status code: %d, message: %s", resp.StatusCode, tr.Error.Message)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("openai transcription api error, status code: %d, message: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	return &Transcript{
+		Text:     strings.TrimSpace(tr.Text),
+		Language: firstNonEmpty(tr.Language, language),
+		Duration: tr.Duration,
+	}, nil
+}
+
+type volcengineRecognition struct {
+	AudioInfo struct {
+		Duration int64 `json:"duration"`
+	} `json:"audio_info"`
+	Result struct {
+		Text string `json:"text"`
+	} `json:"result"`
+}
+
+// transcribeVolcengine sends the audio to the flash recognition endpoint, which answers synchronously.
+func (t *Transcriber) transcribeVolcengine(ctx context.Context, audio []byte, language string) (*Transcript, error) {
+	request := map[string]any{
+		"model_name":  "bigmodel",
+		"enable_itn":  true,
+		"enable_punc": true,
+	}
+	if language != "" {
+		request["language"] = language
+	}
+	b, err := json.Marshal(map[string]any{
+		"user":    map[string]any{"uid": t.conf.AppKey},
+		"audio":   map[string]any{"data": base64.StdEncoding.EncodeToString(audio)},
+		"request": request,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.conf.BaseURL+"/api/v3/auc/bigmodel/recognize/flash", bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Api-App-Key", t.conf.AppKey)
+	req.Header.Set("X-Api-Access-Key", t.conf.AccessKey)
+	req.Header.Set("X-Api-Resource-Id", t.conf.ResourceID)
+	req.Header.Set("X-Api-Request-Id", uuid.NewString())
+	req.Header.Set("X-Api-Sequence", "-1")
+
+	resp, err := t.conf.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// the status of the recognition is in the response headers
+	if code := resp.Header.Get("X-Api-Status-Code"); code != fmt.Sprint(volcengineStatusOK) {
+		return nil, fmt.Errorf("volcengine recognition api error, status code: %d, code: %s, message: %s, logid: %s",
+			resp.StatusCode, code, resp.Header.Get("X-Api-Message"), resp.Header.Get("X-Tt-Logid"))
+	}
+
+	var vr volcengineRecognition
+	if err = json.Unmarshal(respBody, &vr); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return &Transcript{
+		Text:     strings.TrimSpace(vr.Result.Text),
+		Language: language,
+		Duration: float64(vr.AudioInfo.Duration) / 1000,
+	}, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,159 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package speech
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSTTTool(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewSTTTool(ctx, nil)
+	assert.EqualError(t, err, "config is nil")
+	_, err = NewSTTTool(ctx, &STTConfig{Provider: ProviderOpenAI})
+	assert.EqualError(t, err, "api key is required")
+	_, err = NewSTTTool(ctx, &STTConfig{Provider: ProviderVolcengine, AppKey: "app"})
+	assert.EqualError(t, err, "app key and access key are required")
+
+	tl, err := NewSTTTool(ctx, &STTConfig{Provider: ProviderOpenAI, APIKey: "key"})
+	assert.NoError(t, err)
+	info, err := tl.Info(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "speech_to_text", info.Name)
+}
+
+func TestTranscribe_OpenAI(t *testing.T) {
+	ctx := context.Background()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/files/hello":
+			w.Header().Set("Content-Type", "audio/wav")
+			_, _ = w.Write([]byte("RIFF-wav"))
+		case "/audio/transcriptions":
+			assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+			assert.NoError(t, r.ParseMultipartForm(1<<20))
+			assert.Equal(t, "whisper-1", r.FormValue("model"))
+			assert.Equal(t, "verbose_json", r.FormValue("response_format"))
+			f, h, err := r.FormFile("file")
+			assert.NoError(t, err)
+			data, _ := io.ReadAll(f)
+			if string(data) == "bad" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":{"message":"Invalid file format.","type":"invalid_request_error"}}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"text":     " " + h.Filename + ":" + string(data) + ":" + r.FormValue("language"),
+				"language": "english",
+				"duration": 1.5,
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "question.mp3"), []byte("ID3"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "bad.mp3"), []byte("bad"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(dir), "secret.mp3"), []byte("secret"), 0o644))
+
+	tl, err := NewSTTTool(ctx, &STTConfig{Provider: ProviderOpenAI, APIKey: "key", BaseURL: srv.URL, AudioDir: dir})
+	assert.NoError(t, err)
+
+	out, err := tl.InvokableRun(ctx, `{"audio":"question.mp3","language":"en"}`)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"text":"question.mp3:ID3:en","language":"english","duration":1.5}`, out)
+
+	out, err = tl.InvokableRun(ctx, `{"audio":"`+filepath.Join(dir, "question.mp3")+`"}`)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"text":"question.mp3:ID3:","language":"english","duration":1.5}`, out)
+
+	out, err = tl.InvokableRun(ctx, `{"audio":"`+srv.URL+`/files/hello"}`)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"text":"hello.wav:RIFF-wav:","language":"english","duration":1.5}`, out)
+
+	_, err = tl.InvokableRun(ctx, `{"audio":"../secret.mp3"}`)
+	assert.ErrorContains(t, err, "audio file is outside of the audio dir")
+	_, err = tl.InvokableRun(ctx, `{"audio":"bad.mp3"}`)
+	assert.ErrorContains(t, err, "openai transcription api error, status code: 400, message: Invalid file format.")
+	_, err = tl.InvokableRun(ctx, `{"audio":"`+srv.URL+`/missing.mp3"}`)
+	assert.ErrorContains(t, err, "failed to download audio, status code: 404")
+
+	noDir, err := NewSTTTool(ctx, &STTConfig{Provider: ProviderOpenAI, APIKey: "key", BaseURL: srv.URL})
+	assert.NoError(t, err)
+	_, err = noDir.InvokableRun(ctx, `{"audio":"question.mp3"}`)
+	assert.ErrorContains(t, err, "local files are not allowed")
+
+	small, err := NewTranscriber(ctx, &STTConfig{Provider: ProviderOpenAI, APIKey: "key", BaseURL: srv.URL, MaxFileSize: 2})
+	assert.NoError(t, err)
+	_, err = small.Transcribe(ctx, []byte("ID3"), "question.mp3")
+	assert.EqualError(t, err, "audio is too large: 3 bytes, max 2")
+}
+
+func TestTranscribe_Volcengine(t *testing.T) {
+	ctx := context.Background()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v3/auc/bigmodel/recognize/flash", r.URL.Path)
+		assert.Equal(t, "app", r.Header.Get("X-Api-App-Key"))
+		assert.Equal(t, "token", r.Header.Get("X-Api-Access-Key"))
+		assert.Equal(t, "volc.bigasr.auc_turbo", r.Header.Get("X-Api-Resource-Id"))
+		var body struct {
+			Audio struct {
+				Data string `json:"data"`
+			} `json:"audio"`
+		}
+		data, _ := io.ReadAll(r.Body)
+		assert.NoError(t, json.Unmarshal(data, &body))
+		audio, _ := base64.StdEncoding.DecodeString(body.Audio.Data)
+		if string(audio) == "silence" {
+			w.Header().Set("X-Api-Status-Code", "20000003")
+			w.Header().Set("X-Api-Message", "silent audio")
+			return
+		}
+		w.Header().Set("X-Api-Status-Code", "20000000")
+		_, _ = w.Write([]byte(`{"audio_info":{"duration":2500},"result":{"text":" 你好，世界。 "}}`))
+	}))
+	defer srv.Close()
+
+	tr, err := NewTranscriber(ctx, &STTConfig{Provider: ProviderVolcengine, AppKey: "app", AccessKey: "token", BaseURL: srv.URL, Language: "zh-CN"})
+	assert.NoError(t, err)
+
+	transcript, err := tr.Transcribe(ctx, []byte("audio"), "question.wav")
+	assert.NoError(t, err)
+	assert.Equal(t, &Transcript{Text: "你好，世界。", Language: "zh-CN", Duration: 2.5}, transcript)
+
+	_, err = tr.Transcribe(ctx, []byte("silence"), "silence.wav")
+	assert.ErrorContains(t, err, "volcengine recognition api error, status code: 200, code: 20000003, message: silent audio")
+}
+
+func TestDownloadFileName(t *testing.T) {
+	assert.Equal(t, "a.mp3", downloadFileName("https://example.com/x/a.mp3?sig=1", "application/octet-stream"))
+	assert.Equal(t, "voice.m4a", downloadFileName("https://example.com/voice", "audio/x-m4a"))
+	assert.Equal(t, "audio.ogg", downloadFileName("https://example.com/", "audio/ogg; codecs=opus"))
+	assert.Equal(t, "audio.mp3", downloadFileName("https://example.com", ""))
+}
@@ -0,0 +1,450 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package speech
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+	"github.com/cloudwego/eino/schema"
+	"github.com/google/uuid"
+)
+
+// TTSConfig is the configuration for the text-to-speech synthesizer and tool.
+type TTSConfig struct {
+	// Provider is the speech synthesis API.
+	// Required.
+	Provider Provider
+	// APIKey is the OpenAI API key.
+	// Required for ProviderOpenAI.
+	APIKey string
+	// AppID is the app id of the Volcengine speech application, in the speech console.
+	// Required for ProviderVolcengine.
+	AppID string
+	// AccessKey is the access token of the Volcengine speech application.
+	// Required for ProviderVolcengine.
+	AccessKey string
+	// ResourceID is the resource id of the Volcengine synthesis service.
+	// Optional. Default "seed-tts-1.0", the Doubao big model synthesis.
+	ResourceID string
+	// BaseURL is the base url of the API.
+	// Optional. Default "https://api.openai.com/v1" for OpenAI, "https://openspeech.bytedance.com" for Volcengine.
+	BaseURL string
+	// HTTPClient is the http client sending the requests.
+	// Optional. Default a client with Timeout.
+	HTTPClient *http.Client
+	// Timeout is the maximum duration of each request, including reading the audio.
+	// Optional. Default 2 minutes.
+	Timeout time.Duration
+
+	// Model is the OpenAI speech model, e.g. "gpt-4o-mini-tts", "tts-1" or "tts-1-hd". Ignored by Volcengine.
+	// Optional. Default "gpt-4o-mini-tts".
+	Model string
+	// Voice is the default voice, e.g. "alloy" for OpenAI, or the speaker for Volcengine,
+	// e.g. "zh_female_shuangkuaisisi_moon_bigtts".
+	// Optional for OpenAI, default "alloy". Required for Volcengine.
+	Voice string
+	// Voices are the voices the model can choose with the tool.
+	// Optional. Default the model cannot choose the voice.
+	Voices []string
+	// Instructions control the tone and the style of the speech, only supported by gpt-4o-mini-tts.
+	// Optional.
+	Instructions string
+	// Format is the audio format, "mp3", "opus", "aac", "flac", "wav" or "pcm" for OpenAI,
+	// "mp3", "ogg_opus" or "pcm" for Volcengine. Prefer "mp3" or "pcm" when streaming sentences with StreamText,
+	// as their streams can be concatenated.
+	// Optional. Default "mp3".
+	Format string
+	// SampleRate is the sample rate of the audio in Hz, only supported by Volcengine. OpenAI pcm is 24kHz.
+	// Optional. Default 24000.
+	SampleRate int
+	// Speed is the default speed of the speech, from 0.25 to 4 for OpenAI, from 0.5 to 2 for Volcengine.
+	// Optional. Default 1.
+	Speed float64
+	// MaxTextLength is the maximum length in runes of the text of a request.
+	// Optional. Default 4096, the limit of OpenAI.
+	MaxTextLength int
+
+	// SaveDir is the directory the audio files of the tool are saved to, their paths being returned to the model.
+	// Required by NewTTSTool.
+	SaveDir string
+
+	ToolName string // Optional. Default "text_to_speech".
+	ToolDesc string // Optional. Default "convert text to spoken audio, saved to a file whose path is returned".
+}
+
+// SpeakRequest is the request of the text-to-speech tool.
+type SpeakRequest struct {
+	Text  string  `json:"text" jsonschema:"required,description=The text to speak"`
+	Voice string  `json:"voice,omitempty" jsonschema:"description=The voice of the speech. Leave empty for the default voice"`
+	Speed float64 `json:"speed,omitempty" jsonschema:"description=The speed of the speech, 1 being the normal speed. Leave empty for the default speed"`
+}
+
+// SpeakResponse is the response of the text-to-speech tool.
+type SpeakResponse struct {
+	Path   string `json:"path"`
+	Format string `json:"format"`
+	Size   int64  `json:"size"`
+}
+
+// Synthesizer converts text to speech. Besides the tool, it streams audio for voice pipelines,
+// e.g. speaking the streamed answer of a chat model sentence by sentence with StreamText.
+type Synthesizer struct {
+	conf *TTSConfig
+}
+
+// NewSynthesizer creates a new text-to-speech synthesizer.
+func NewSynthesizer(ctx context.Context, conf *TTSConfig) (*Synthesizer, error) {
+	if err := conf.validate(); err != nil {
+		return nil, err
+	}
+	return &Synthesizer{conf: conf}, nil
+}
+
+// NewTTSTool creates a new text-to-speech tool, saving the audio to SaveDir.
+func NewTTSTool(ctx context.Context, conf *TTSConfig) (tool.InvokableTool, error) {
+	s, err := NewSynthesizer(ctx, conf)
+	if err != nil {
+		return nil, err
+	}
+	if conf.SaveDir == "" {
+		return nil, fmt.Errorf("save dir is required")
+	}
+	desc := conf.ToolDesc
+	if len(conf.Voices) > 0 {
+		desc += ". Available voices: " + strings.Join(conf.Voices, ", ")
+	}
+	t, err := utils.InferTool(conf.ToolName, desc, s.Speak)
+	if err != nil {
+		return nil, fmt.Errorf("failed to infer tool: %w", err)
+	}
+	return t, nil
+}
+
+// validate validates the configuration and sets default values if not provided.
+func (conf *TTSConfig) validate() error {
+	if conf == nil {
+		return fmt.Errorf("config is nil")
+	}
+	if err := checkProvider(conf.Provider); err != nil {
+		return err
+	}
+	switch conf.Provider {
+	case ProviderOpenAI:
+		if conf.APIKey == "" {
+			return fmt.Errorf("api key is required")
+		}
+		if conf.BaseURL == "" {
+			conf.BaseURL = defaultOpenAIBaseURL
+		}
+		if conf.Model == "" {
+			conf.Model = "gpt-4o-mini-tts"
+		}
+		if conf.Voice == "" {
+			conf.Voice = "alloy"
+		}
+	case ProviderVolcengine:
+		if conf.AppID == "" || conf.AccessKey == "" {
+			return fmt.Errorf("app id and access key are required")
+		}
+		if conf.Voice == "" {
+			return fmt.Errorf("voice is required")
+		}
+		if conf.BaseURL == "" {
+			conf.BaseURL = defaultVolcengineBaseURL
+		}
+		if conf.ResourceID == "" {
+			conf.ResourceID = "seed-tts-1.0"
+		}
+		if conf.SampleRate <= 0 {
+			conf.SampleRate = 24000
+		}
+	}
+	conf.BaseURL = strings.TrimRight(conf.BaseURL, "/")
+	if conf.Timeout <= 0 {
+		conf.Timeout = 2 * time.Minute
+	}
+	conf.HTTPClient = newHTTPClient(conf.HTTPClient, conf.Timeout)
+	if conf.Format == "" {
+		conf.Format = "mp3"
+	}
+	if conf.MaxTextLength <= 0 {
+		conf.MaxTextLength = 4096
+	}
+	if conf.SaveDir != "" {
+		if err := os.MkdirAll(conf.SaveDir, 0o755); err != nil {
+			return fmt.Errorf("failed to create save dir: %w", err)
+		}
+	}
+	if conf.ToolName == "" {
+		conf.ToolName = "text_to_speech"
+	}
+	if conf.ToolDesc == "" {
+		conf.ToolDesc = "convert text to spoken audio, saved to a file whose path is returned"
+	}
+	return nil
+}
+
+// Speak synthesizes the speech and saves it to SaveDir.
+func (s *Synthesizer) Speak(ctx context.Context, req *SpeakRequest) (*SpeakResponse, error) {
+	if s.conf.SaveDir == "" {
+		return nil, fmt.Errorf("save dir is not configured")
+	}
+	audio, err := s.open(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer audio.Close()
+
+	f, err := os.CreateTemp(s.conf.SaveDir, fmt.Sprintf("speech-%s-*.%s", time.Now().Format("20060102-150405"), fileExt(s.conf.Format)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create audio file: %w", err)
+	}
+	n, err := io.Copy(f, audio)
+	if cErr := f.Close(); err == nil {
+		err = cErr
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return nil, fmt.Errorf("failed to save audio: %w", err)
+	}
+
+	return &SpeakResponse{Path: f.Name(), Format: s.conf.Format, Size: n}, nil
+}
+
+// Stream synthesizes the speech, returning the audio chunks as soon as the API sends them.
+// The caller must close the returned stream.
+func (s *Synthesizer) Stream(ctx context.Context, req *SpeakRequest) (*schema.StreamReader[[]byte], error) {
+	audio, err := s.open(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	sr, sw := schema.Pipe[[]byte](4)
+	go func() {
+		defer sw.Close()
+		defer audio.Close()
+		_ = copyChunks(sw, audio)
+	}()
+	return sr, nil
+}
+
+// copyChunks sends the audio to the stream, returning false when the reader of the stream is closed.
+func copyChunks(sw *schema.StreamWriter[[]byte], audio io.Reader) bool {
+	buf := make([]byte, 16*1024)
+	for {
+		n, err := audio.Read(buf)
+		if n > 0 {
+			if closed := sw.Send(bytes.Clone(buf[:n]), nil); closed {
+				return false
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return true
+		}
+		if err != nil {
+			sw.Send(nil, fmt.Errorf("failed to read audio: %w", err))
+			return false
+		}
+	}
+}
+
+// open sends the synthesis request, returning the audio while it is being received.
+func (s *Synthesizer) open(ctx context.Context, req *SpeakRequest) (io.ReadCloser, error) {
+	if req == nil || strings.TrimSpace(req.Text) == "" {
+		return nil, fmt.Errorf("text is required")
+	}
+	if n := len([]rune(req.Text)); n > s.conf.MaxTextLength {
+		return nil, fmt.Errorf("text is too long: %d runes, max %d", n, s.conf.MaxTextLength)
+	}
+	voice := s.conf.Voice
+	if req.Voice != "" {
+		if len(s.conf.Voices) > 0 && !slices.Contains(s.conf.Voices, req.Voice) {
+			return nil, fmt.Errorf("unsupported voice: %s, available voices: %s", req.Voice, strings.Join(s.conf.Voices, ", "))
+		}
+		voice = req.Voice
+	}
+	speed := s.conf.Speed
+	if req.Speed > 0 {
+		speed = req.Speed
+	}
+
+	if s.conf.Provider == ProviderVolcengine {
+		return s.openVolcengine(ctx, req.Text, voice, speed)
+	}
+	return s.openOpenAI(ctx, req.Text, voice, speed)
+}
+
+func (s *Synthesizer) openOpenAI(ctx context.Context, text, voice string, speed float64) (io.ReadCloser, error) {
+	body := map[string]any{
+		"model":           s.conf.Model,
+		"input":           text,
+		"voice":           voice,
+		"response_format": s.conf.Format,
+	}
+	if s.conf.Instructions != "" {
+		body["instructions"] = s.conf.Instructions
+	}
+	if speed > 0 {
+		body["speed"] = math.Min(math.Max(speed, 0.25), 4)
+	}
+
+	resp, err := s.post(ctx, s.conf.BaseURL+"/audio/speech", body, map[string]string{
+		"Authorization": "Bearer " + s.conf.APIKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+		var oe openaiError
+		if json.Unmarshal(msg, &oe) == nil && oe.Error != nil {
+			return nil, fmt.Errorf("openai speech api error, status code: %d, message: %s", resp.StatusCode, oe.Error.Message)
+		}
+		return nil, fmt.Errorf("openai speech api error, status code: %d, message: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return resp.Body, nil
+}
+
+func (s *Synthesizer) openVolcengine(ctx context.Context, text, voice string, speed float64) (io.ReadCloser, error) {
+	audioParams := map[string]any{
+		"format":      s.conf.Format,
+		"sample_rate": s.conf.SampleRate,
+	}
+	if speed > 0 {
+		// speech_rate ranges from -50, half the speed, to 100, twice the speed
+		audioParams["speech_rate"] = int(math.Round(math.Min(math.Max((speed-1)*100, -50), 100)))
+	}
+	body := map[string]any{
+		"user": map[string]any{"uid": s.conf.AppID},
+		"req_params": map[string]any{
+			"text":         text,
+			"speaker":      voice,
+			"audio_params": audioParams,
+		},
+	}
+
+	resp, err := s.post(ctx, s.conf.BaseURL+"/api/v3/tts/unidirectional", body, map[string]string{
+		"X-Api-App-Id":      s.conf.AppID,
+		"X-Api-Access-Key":  s.conf.AccessKey,
+		"X-Api-Resource-Id": s.conf.ResourceID,
+		"X-Api-Request-Id":  uuid.NewString(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+		return nil, fmt.Errorf("volcengine speech api error, status code: %d, message: %s, logid: %s",
+			resp.StatusCode, strings.TrimSpace(string(msg)), resp.Header.Get("X-Tt-Logid"))
+	}
+	return &volcengineAudio{body: resp.Body, dec: json.NewDecoder(resp.Body)}, nil
+}
+
+func (s *Synthesizer) post(ctx context.Context, url string, body map[string]any, headers map[string]string) (*http.Response, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := s.conf.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	return resp, nil
+}
+
+// volcengineAudio reads the audio of the Volcengine unidirectional stream, a sequence of json messages
+// carrying base64 audio, ended by a message with the status code 20000000.
+type volcengineAudio struct {
+	body io.Closer
+	dec  *json.Decoder
+	buf  []byte
+	done bool
+}
+
+type volcengineMessage struct {
+	Code    int     `json:"code"`
+	Message string  `json:"message"`
+	Data    *string `json:"data"`
+}
+
+func (v *volcengineAudio) Read(p []byte) (int, error) {
+	for len(v.buf) == 0 {
+		if v.done {
+			return 0, io.EOF
+		}
+		var msg volcengineMessage
+		if err := v.dec.Decode(&msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				return 0, io.ErrUnexpectedEOF
+			}
+			return 0, fmt.Errorf("failed to decode volcengine message: %w", err)
+		}
+		switch msg.Code {
+		case 0:
+		case volcengineStatusOK:
+			v.done = true
+		default:
+			return 0, fmt.Errorf("volcengine speech api error, code: %d, message: %s", msg.Code, msg.Message)
+		}
+		if msg.Data != nil && *msg.Data != "" {
+			data, err := base64.StdEncoding.DecodeString(*msg.Data)
+			if err != nil {
+				return 0, fmt.Errorf("failed to decode volcengine audio: %w", err)
+			}
+			v.buf = data
+		}
+	}
+	n := copy(p, v.buf)
+	v.buf = v.buf[n:]
+	return n, nil
+}
+
+func (v *volcengineAudio) Close() error {
+	return v.body.Close()
+}
+
+func fileExt(format string) string {
+	switch format {
+	case "ogg_opus", "opus":
+		return "ogg"
+	default:
+		return format
+	}
+}
@@ -0,0 +1,244 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package speech
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func readAll(t *testing.T, sr *schema.StreamReader[[]byte]) ([]byte, error) {
+	defer sr.Close()
+	var out []byte
+	for {
+		chunk, err := sr.Recv()
+		if errors.Is(err, io.EOF) {
+			return out, nil
+		}
+		if err != nil {
+			return out, err
+		}
+		out = append(out, chunk...)
+	}
+}
+
+func TestNewTTSTool(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewTTSTool(ctx, nil)
+	assert.EqualError(t, err, "config is nil")
+	_, err = NewTTSTool(ctx, &TTSConfig{APIKey: "key"})
+	assert.EqualError(t, err, "provider is required")
+	_, err = NewTTSTool(ctx, &TTSConfig{Provider: "azure"})
+	assert.EqualError(t, err, "unsupported provider: azure")
+	_, err = NewTTSTool(ctx, &TTSConfig{Provider: ProviderOpenAI})
+	assert.EqualError(t, err, "api key is required")
+	_, err = NewTTSTool(ctx, &TTSConfig{Provider: ProviderVolcengine, AppID: "app"})
+	assert.EqualError(t, err, "app id and access key are required")
+	_, err = NewTTSTool(ctx, &TTSConfig{Provider: ProviderVolcengine, AppID: "app", AccessKey: "token"})
+	assert.EqualError(t, err, "voice is required")
+	_, err = NewTTSTool(ctx, &TTSConfig{Provider: ProviderOpenAI, APIKey: "key"})
+	assert.EqualError(t, err, "save dir is required")
+
+	tl, err := NewTTSTool(ctx, &TTSConfig{Provider: ProviderOpenAI, APIKey: "key", SaveDir: t.TempDir(), Voices: []string{"alloy", "nova"}})
+	assert.NoError(t, err)
+	info, err := tl.Info(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "text_to_speech", info.Name)
+	assert.Contains(t, info.Desc, "Available voices: alloy, nova")
+}
+
+func TestSpeak_OpenAI(t *testing.T) {
+	ctx := context.Background()
+	var body map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/audio/speech", r.URL.Path)
+		assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+		data, _ := io.ReadAll(r.Body)
+		assert.NoError(t, json.Unmarshal(data, &body))
+		if body["voice"] == "bad" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":{"message":"Invalid voice","type":"invalid_request_error"}}`))
+			return
+		}
+		_, _ = w.Write([]byte("ID3-audio"))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	tl, err := NewTTSTool(ctx, &TTSConfig{
+		Provider:     ProviderOpenAI,
+		APIKey:       "key",
+		BaseURL:      srv.URL,
+		Instructions: "Speak cheerfully.",
+		SaveDir:      dir,
+	})
+	assert.NoError(t, err)
+
+	out, err := tl.InvokableRun(ctx, `{"text":"Hello world.","speed":1.5}`)
+	assert.NoError(t, err)
+	var resp SpeakResponse
+	assert.NoError(t, json.Unmarshal([]byte(out), &resp))
+	assert.Equal(t, "mp3", resp.Format)
+	assert.Equal(t, int64(9), resp.Size)
+	assert.True(t, strings.HasPrefix(resp.Path, dir))
+	assert.True(t, strings.HasSuffix(resp.Path, ".mp3"))
+	data, err := os.ReadFile(resp.Path)
+	assert.NoError(t, err)
+	assert.Equal(t, "ID3-audio", string(data))
+
+	assert.Equal(t, map[string]any{
+		"model":           "gpt-4o-mini-tts",
+		"input":           "Hello world.",
+		"voice":           "alloy",
+		"response_format": "mp3",
+		"instructions":    "Speak cheerfully.",
+		"speed":           1.5,
+	}, body)
+
+	_, err = tl.InvokableRun(ctx, `{"text":"Hello","voice":"bad"}`)
+	assert.ErrorContains(t, err, "openai speech api error, status code: 400, message: Invalid voice")
+	_, err = tl.InvokableRun(ctx, `{"text":" "}`)
+	assert.ErrorContains(t, err, "text is required")
+}
+
+func TestSpeak_Voices(t *testing.T) {
+	s, err := NewSynthesizer(context.Background(), &TTSConfig{Provider: ProviderOpenAI, APIKey: "key", SaveDir: t.TempDir(), Voices: []string{"alloy"}, MaxTextLength: 5})
+	assert.NoError(t, err)
+	_, err = s.Speak(context.Background(), &SpeakRequest{Text: "Hi", Voice: "nova"})
+	assert.EqualError(t, err, "unsupported voice: nova, available voices: alloy")
+	_, err = s.Speak(context.Background(), &SpeakRequest{Text: "Hello world"})
+	assert.EqualError(t, err, "text is too long: 11 runes, max 5")
+}
+
+func newVolcengineServer(t *testing.T, texts *[]string) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v3/tts/unidirectional", r.URL.Path)
+		assert.Equal(t, "app", r.Header.Get("X-Api-App-Id"))
+		assert.Equal(t, "token", r.Header.Get("X-Api-Access-Key"))
+		assert.Equal(t, "seed-tts-1.0", r.Header.Get("X-Api-Resource-Id"))
+		var body struct {
+			ReqParams struct {
+				Text        string         `json:"text"`
+				Speaker     string         `json:"speaker"`
+				AudioParams map[string]any `json:"audio_params"`
+			} `json:"req_params"`
+		}
+		data, _ := io.ReadAll(r.Body)
+		assert.NoError(t, json.Unmarshal(data, &body))
+		assert.Equal(t, "zh_female_shuangkuaisisi_moon_bigtts", body.ReqParams.Speaker)
+		assert.Equal(t, map[string]any{"format": "pcm", "sample_rate": float64(24000), "speech_rate": float64(50)}, body.ReqParams.AudioParams)
+		*texts = append(*texts, body.ReqParams.Text)
+
+		if body.ReqParams.Text == "fail" {
+			_, _ = fmt.Fprintln(w, `{"code":45000000,"message":"quota exceeded","data":null}`)
+			return
+		}
+		// the audio of the text is sent in two messages
+		half := len(body.ReqParams.Text) / 2
+		for _, part := range []string{body.ReqParams.Text[:half], body.ReqParams.Text[half:]} {
+			_, _ = fmt.Fprintf(w, `{"code":0,"message":"","data":"%s"}`+"\n", base64.StdEncoding.EncodeToString([]byte(part)))
+			w.(http.Flusher).Flush()
+		}
+		_, _ = fmt.Fprintln(w, `{"code":20000000,"message":"OK","data":null,"usage":{"text_words":4}}`)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestStream_Volcengine(t *testing.T) {
+	ctx := context.Background()
+	var texts []string
+	srv := newVolcengineServer(t, &texts)
+
+	s, err := NewSynthesizer(ctx, &TTSConfig{
+		Provider:  ProviderVolcengine,
+		AppID:     "app",
+		AccessKey: "token",
+		BaseURL:   srv.URL,
+		Voice:     "zh_female_shuangkuaisisi_moon_bigtts",
+		Format:    "pcm",
+		Speed:     1.5,
+	})
+	assert.NoError(t, err)
+
+	sr, err := s.Stream(ctx, &SpeakRequest{Text: "你好，世界"})
+	assert.NoError(t, err)
+	audio, err := readAll(t, sr)
+	assert.NoError(t, err)
+	assert.Equal(t, "你好，世界", string(audio))
+
+	sr, err = s.Stream(ctx, &SpeakRequest{Text: "fail"})
+	assert.NoError(t, err)
+	_, err = readAll(t, sr)
+	assert.EqualError(t, err, "failed to read audio: volcengine speech api error, code: 45000000, message: quota exceeded")
+}
+
+func TestStreamText(t *testing.T) {
+	ctx := context.Background()
+	var texts []string
+	srv := newVolcengineServer(t, &texts)
+
+	s, err := NewSynthesizer(ctx, &TTSConfig{
+		Provider:  ProviderVolcengine,
+		AppID:     "app",
+		AccessKey: "token",
+		BaseURL:   srv.URL,
+		Voice:     "zh_female_shuangkuaisisi_moon_bigtts",
+		Format:    "pcm",
+	})
+	assert.NoError(t, err)
+
+	text := schema.StreamReaderFromArray([]string{"It costs 3", ".5 dollars. Go to exam", "ple.com now", "! 好的。再见"})
+	sr, err := s.StreamText(ctx, text, WithSpeed(1.5))
+	assert.NoError(t, err)
+	audio, err := readAll(t, sr)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"It costs 3.5 dollars.", " Go to example.com now!", " 好的。", "再见"}, texts)
+	assert.Equal(t, strings.Join(texts, ""), string(audio))
+}
+
+func TestCutSentence(t *testing.T) {
+	cases := []struct {
+		text, sentence, rest string
+		ok                   bool
+	}{
+		{"Hello. World", "Hello.", " World", true},
+		{"Hello.", "", "Hello.", false},
+		{"Pi is 3.14", "", "Pi is 3.14", false},
+		{"你好！世界", "你好！", "世界", true},
+		{"line\nnext", "line\n", "next", true},
+		{"Really? Yes", "Really?", " Yes", true},
+	}
+	for _, c := range cases {
+		sentence, rest, ok := cutSentence(c.text)
+		assert.Equal(t, c.ok, ok, c.text)
+		assert.Equal(t, c.sentence, sentence, c.text)
+		assert.Equal(t, c.rest, rest, c.text)
+	}
+}
@@ -0,0 +1,59 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/cloudwego/eino/components/tool"
+
+	"github.com/cloudwego/eino-ext/components/tool/sqldatabase"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// open the database read-only, the tools only run read-only statements anyway
+	db, err := sql.Open("sqlite3", "file:./testdata/shop.db?mode=ro")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	tools, err := sqldatabase.NewToolKit(ctx, &sqldatabase.Config{
+		DB:      db,
+		Dialect: sqldatabase.DialectSQLite,
+		MaxRows: 20,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// list the tables, describe one and query it, as an agent would
+	calls := []string{`{}`, `{"tables":["orders"]}`, `{"query":"SELECT count(*) AS orders FROM orders"}`}
+	for i, args := range calls {
+		out, err := tools[i].(tool.InvokableTool).InvokableRun(ctx, args)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Println(out)
+	}
+}
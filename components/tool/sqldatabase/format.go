@@ -0,0 +1,192 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqldatabase
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// forbiddenKeywords are the keywords of statements writing data, rejected anywhere in a query,
+// e.g. in a data-modifying CTE, or a SELECT INTO creating a table.
+// The keywords also naming common functions or columns, e.g. REPLACE, SET, COMMENT and LOAD, are left out:
+// their statements can only start a query, which the allowed statements already reject.
+// The queries also run in a read-only transaction, on a query_only connection for SQLite whose drivers ignore
+// the read-only option of the transactions, though the database user should be the one enforcing it.
+var forbiddenKeywords = map[string]bool{
+	"INSERT": true, "UPDATE": true, "DELETE": true, "MERGE": true, "UPSERT": true, "INTO": true,
+	"CREATE": true, "ALTER": true, "DROP": true, "TRUNCATE": true, "RENAME": true,
+	"GRANT": true, "REVOKE": true, "ATTACH": true, "DETACH": true, "PRAGMA": true, "VACUUM": true,
+	"COPY": true, "CALL": true, "EXEC": true, "EXECUTE": true, "LOCK": true,
+}
+
+// checkStatement checks that the query is a single statement starting with an allowed keyword,
+// without any keyword of the statements writing data, and returns it without its trailing semicolons.
+func checkStatement(query string, allowed []string) (string, error) {
+	query = strings.TrimSpace(query)
+	for strings.HasSuffix(query, ";") {
+		query = strings.TrimSpace(strings.TrimSuffix(query, ";"))
+	}
+	if query == "" {
+		return "", fmt.Errorf("query is required")
+	}
+
+	words, multiple := keywords(query)
+	if multiple {
+		return "", fmt.Errorf("multiple statements are not allowed")
+	}
+	if len(words) == 0 {
+		return "", fmt.Errorf("query is required")
+	}
+
+	ok := false
+	for _, a := range allowed {
+		if strings.EqualFold(words[0], a) {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return "", fmt.Errorf("statement not allowed: %s, allowed statements: %s", words[0], strings.Join(allowed, ", "))
+	}
+	for _, w := range words {
+		if forbiddenKeywords[w] {
+			return "", fmt.Errorf("keyword not allowed in a read-only query: %s", w)
+		}
+	}
+
+	return query, nil
+}
+
+// keywords returns the upper-cased words of the query out of its strings, quoted identifiers and comments,
+// and whether it has several statements.
+func keywords(query string) (words []string, multiple bool) {
+	runes := []rune(query)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\'' || r == '"' || r == '`':
+			// a doubled quote is an escaped quote, which the loop handles as the end and the start of a string.
+			// Backslashes are not taken as escapes, so that a MySQL string like 'a\';' is rejected as several statements
+			// rather than a standard one like 'a\' hiding the rest of the query.
+			for i++; i < len(runes) && runes[i] != r; i++ {
+			}
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			for i += 2; i < len(runes) && !(runes[i] == '*' && i+1 < len(runes) && runes[i+1] == '/'); i++ {
+			}
+			i++
+		case r == ';':
+			multiple = true
+		case unicode.IsLetter(r) || r == '_':
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_' || runes[j] == '$') {
+				j++
+			}
+			words = append(words, strings.ToUpper(string(runes[i:j])))
+			i = j - 1
+		}
+	}
+	return words, multiple
+}
+
+// markdownTable renders the rows as a markdown table, with at most maxRows rows, negative for no limit,
+// and at most MaxResultSize bytes.
+func (d *database) markdownTable(rows *sql.Rows, maxRows int) (string, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("|")
+	for _, c := range columns {
+		sb.WriteString(" " + d.formatValue(c) + " |")
+	}
+	sb.WriteString("\n|")
+	for range columns {
+		sb.WriteString(" --- |")
+	}
+	sb.WriteString("\n")
+
+	values := make([]any, len(columns))
+	ptrs := make([]any, len(columns))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+
+	count, truncated := 0, false
+	for rows.Next() {
+		if maxRows >= 0 && count >= maxRows {
+			truncated = true
+			break
+		}
+		if err = rows.Scan(ptrs...); err != nil {
+			return "", err
+		}
+		var line strings.Builder
+		line.WriteString("|")
+		for _, v := range values {
+			line.WriteString(" " + d.formatValue(v) + " |")
+		}
+		line.WriteString("\n")
+		if sb.Len()+line.Len() > d.conf.MaxResultSize {
+			truncated = true
+			break
+		}
+		sb.WriteString(line.String())
+		count++
+	}
+	if err = rows.Err(); err != nil {
+		return "", err
+	}
+
+	if truncated {
+		sb.WriteString(fmt.Sprintf("\n[only the first %d rows are shown]\n", count))
+	} else if count == 0 {
+		sb.WriteString("\n(0 rows)\n")
+	}
+	return sb.String(), nil
+}
+
+func (d *database) formatValue(v any) string {
+	var s string
+	switch t := v.(type) {
+	case nil:
+		return "NULL"
+	case []byte:
+		s = string(t)
+	case time.Time:
+		s = t.Format(time.RFC3339Nano)
+	default:
+		s = fmt.Sprint(t)
+	}
+
+	if runes := []rune(s); len(runes) > d.conf.MaxCellLength {
+		s = string(runes[:d.conf.MaxCellLength]) + "..."
+	}
+	s = strings.ReplaceAll(s, "|", `\|`)
+	s = strings.ReplaceAll(s, "\r\n", " ")
+	s = strings.ReplaceAll(s, "\n", " ")
+	return s
+}
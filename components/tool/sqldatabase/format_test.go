@@ -0,0 +1,60 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqldatabase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckStatement(t *testing.T) {
+	allowed := []string{"SELECT", "WITH", "EXPLAIN"}
+
+	for _, c := range []struct {
+		query string
+		want  string
+		err   string
+	}{
+		{query: " select 1 ;; ", want: "select 1"},
+		{query: "-- count\nSELECT count(*) FROM t", want: "-- count\nSELECT count(*) FROM t"},
+		{query: "/* a; b */ SELECT 'delete; drop', \"update\" FROM t", want: "/* a; b */ SELECT 'delete; drop', \"update\" FROM t"},
+		{query: "SELECT 'it''s'", want: "SELECT 'it''s'"},
+		// the functions and columns named like statements writing data
+		{query: "SELECT REPLACE(name, 'a', 'b') FROM t", want: "SELECT REPLACE(name, 'a', 'b') FROM t"},
+		{query: "SELECT comment, set, load FROM t WHERE comment <> ''", want: "SELECT comment, set, load FROM t WHERE comment <> ''"},
+		{query: "WITH c AS (SELECT replace(a, 'x', 'y') AS a FROM t) SELECT a FROM c", want: "WITH c AS (SELECT replace(a, 'x', 'y') AS a FROM t) SELECT a FROM c"},
+		{query: "REPLACE INTO t VALUES (1)", err: "statement not allowed: REPLACE, allowed statements: SELECT, WITH, EXPLAIN"},
+		{query: "SET ROLE admin", err: "statement not allowed: SET, allowed statements: SELECT, WITH, EXPLAIN"},
+		{query: "WITH d AS (DELETE FROM t RETURNING *) SELECT * FROM d", err: "keyword not allowed in a read-only query: DELETE"},
+		{query: "", err: "query is required"},
+		{query: "-- nothing", err: "query is required"},
+		{query: "SELECT 1; SELECT 2", err: "multiple statements are not allowed"},
+		{query: "SELECT 'a\\'; DROP TABLE t; --'", err: "multiple statements are not allowed"},
+		{query: "UPDATE t SET a = 1", err: "statement not allowed: UPDATE, allowed statements: SELECT, WITH, EXPLAIN"},
+		{query: "SELECT * INTO t2 FROM t", err: "keyword not allowed in a read-only query: INTO"},
+		{query: "EXPLAIN ANALYZE DELETE FROM t", err: "keyword not allowed in a read-only query: DELETE"},
+	} {
+		got, err := checkStatement(c.query, allowed)
+		if c.err != "" {
+			assert.EqualError(t, err, c.err, c.query)
+			continue
+		}
+		assert.NoError(t, err, c.query)
+		assert.Equal(t, c.want, got)
+	}
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqldatabase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// listTables returns the tables and views of the database, the postgres tables out of the public schema being prefixed with their schema.
+func (d *database) listTables(ctx context.Context) ([]string, error) {
+	var query string
+	switch d.conf.Dialect {
+	case DialectPostgres:
+		query = `SELECT CASE WHEN table_schema = 'public' THEN table_name ELSE table_schema || '.' || table_name END
+FROM information_schema.tables
+WHERE table_schema NOT IN ('pg_catalog', 'information_schema') AND table_type IN ('BASE TABLE', 'VIEW')
+ORDER BY table_schema, table_name`
+	case DialectMySQL:
+		query = `SELECT table_name FROM information_schema.tables WHERE table_schema = DATABASE() ORDER BY table_name`
+	case DialectSQLite:
+		query = `SELECT name FROM sqlite_master WHERE type IN ('table', 'view') AND name NOT LIKE 'sqlite_%' ORDER BY name`
+	}
+
+	rows, err := d.conf.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+	defer rows.Close()
+
+	tables := make([]string, 0)
+	for rows.Next() {
+		var name string
+		if err = rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to list tables: %w", err)
+		}
+		if d.tables != nil && !d.tables[strings.ToLower(name)] {
+			continue
+		}
+		tables = append(tables, name)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+	return tables, nil
+}
+
+// describeTable writes the columns and the sample rows of a table returned by listTables.
+func (d *database) describeTable(ctx context.Context, sb *strings.Builder, table string) error {
+	var (
+		query string
+		args  []any
+	)
+	switch d.conf.Dialect {
+	case DialectPostgres:
+		schema, name := "public", table
+		if i := strings.Index(table, "."); i >= 0 {
+			schema, name = table[:i], table[i+1:]
+		}
+		query = `SELECT column_name AS "column", data_type AS "type", is_nullable AS "nullable", COALESCE(column_default, '') AS "default"
+FROM information_schema.columns WHERE table_schema = $1 AND table_name = $2 ORDER BY ordinal_position`
+		args = []any{schema, name}
+	case DialectMySQL:
+		query = "SELECT column_name AS `column`, column_type AS `type`, is_nullable AS `nullable`, COALESCE(column_default, '') AS `default`, column_key AS `key`\n" +
+			"FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = ? ORDER BY ordinal_position"
+		args = []any{table}
+	case DialectSQLite:
+		query = `SELECT name AS "column", type AS "type", CASE WHEN "notnull" = 1 THEN 'NO' ELSE 'YES' END AS "nullable", COALESCE(dflt_value, '') AS "default"
+FROM pragma_table_info(?) ORDER BY cid`
+		args = []any{table}
+	}
+
+	rows, err := d.conf.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to describe table %s: %w", table, err)
+	}
+	columns, err := d.markdownTable(rows, -1)
+	rows.Close()
+	if err != nil {
+		return fmt.Errorf("failed to describe table %s: %w", table, err)
+	}
+
+	sb.WriteString("## " + table + "\n\n")
+	sb.WriteString(columns)
+
+	if d.conf.SampleRows < 0 {
+		return nil
+	}
+	rows, err = d.conf.DB.QueryContext(ctx, fmt.Sprintf("SELECT * FROM %s LIMIT %d", d.quoteTable(table), d.conf.SampleRows))
+	if err != nil {
+		return fmt.Errorf("failed to get sample rows of table %s: %w", table, err)
+	}
+	samples, err := d.markdownTable(rows, d.conf.SampleRows)
+	rows.Close()
+	if err != nil {
+		return fmt.Errorf("failed to get sample rows of table %s: %w", table, err)
+	}
+
+	sb.WriteString("\nSample rows:\n\n")
+	sb.WriteString(samples)
+	return nil
+}
+
+func (d *database) quoteTable(table string) string {
+	quote := `"`
+	if d.conf.Dialect == DialectMySQL {
+		quote = "`"
+	}
+	parts := []string{table}
+	if d.conf.Dialect == DialectPostgres {
+		parts = strings.SplitN(table, ".", 2)
+	}
+	for i, p := range parts {
+		parts[i] = quote + strings.ReplaceAll(p, quote, quote+quote) + quote
+	}
+	return strings.Join(parts, ".")
+}
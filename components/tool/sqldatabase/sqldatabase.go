@@ -0,0 +1,305 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package sqldatabase provides tools listing the tables of a database, describing their schema,
+// and running read-only queries whose results are returned as markdown tables.
+package sqldatabase
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+)
+
+// Dialect is the SQL dialect of the database, used to introspect its schema.
+type Dialect string
+
+const (
+	DialectPostgres Dialect = "postgres"
+	DialectMySQL    Dialect = "mysql"
+	DialectSQLite   Dialect = "sqlite"
+)
+
+// Config is the configuration for the sql database tools.
+type Config struct {
+	// DB is the database, opened with the driver of your choice, e.g. github.com/jackc/pgx/v5/stdlib,
+	// github.com/go-sql-driver/mysql or github.com/mattn/go-sqlite3.
+	// Connecting with a read-only database user is HIGHLY RECOMMENDED, the statement checks of the tools being a second line of defense.
+	// Required.
+	DB *sql.DB
+	// Dialect is the SQL dialect of the database.
+	// Required.
+	Dialect Dialect
+	// Tables restricts the tables listed and described to these names, e.g. "orders" or "public.orders".
+	// The queries are not restricted, use the permissions of the database user for that.
+	// Optional. Default all the tables.
+	Tables []string
+	// AllowedStatements are the first keywords of the statements the query tool runs.
+	// Optional. Default "SELECT", "WITH", "EXPLAIN".
+	AllowedStatements []string
+	// MaxRows is the maximum number of rows returned by a query.
+	// Optional. Default 50.
+	MaxRows int
+	// MaxCellLength is the maximum number of characters of a value, longer values are truncated.
+	// Optional. Default 200.
+	MaxCellLength int
+	// MaxResultSize is the maximum size in bytes of a result, the rows over it are left out.
+	// Optional. Default 16KB.
+	MaxResultSize int
+	// SampleRows is the number of rows shown by the describe tool with the columns of a table.
+	// Optional. Default 3, negative for none.
+	SampleRows int
+	// Timeout is the maximum duration of each call.
+	// Optional. Default 30s.
+	Timeout time.Duration
+
+	ListTablesToolName    string // Optional. Default "sql_list_tables".
+	ListTablesToolDesc    string // Optional. Default "List the tables of the database."
+	DescribeTableToolName string // Optional. Default "sql_describe_table".
+	DescribeTableToolDesc string // Optional. Default "Describe the columns of tables, with sample rows."
+	QueryToolName         string // Optional. Default "sql_query".
+	QueryToolDesc         string // Optional. Default a description telling the model the dialect and the allowed statements.
+}
+
+// NewToolKit creates the tools listing the tables, describing tables and running queries.
+func NewToolKit(ctx context.Context, conf *Config) ([]tool.BaseTool, error) {
+	db, err := newDatabase(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	listTables, err := utils.InferTool(db.conf.ListTablesToolName, db.conf.ListTablesToolDesc, db.ListTables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to infer tool: %w", err)
+	}
+	describeTable, err := utils.InferTool(db.conf.DescribeTableToolName, db.conf.DescribeTableToolDesc, db.DescribeTable)
+	if err != nil {
+		return nil, fmt.Errorf("failed to infer tool: %w", err)
+	}
+	query, err := utils.InferTool(db.conf.QueryToolName, db.conf.QueryToolDesc, db.Query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to infer tool: %w", err)
+	}
+
+	return []tool.BaseTool{listTables, describeTable, query}, nil
+}
+
+// validate validates the configuration and sets default values if not provided.
+func (conf *Config) validate() error {
+	if conf == nil {
+		return fmt.Errorf("config is nil")
+	}
+	if conf.DB == nil {
+		return fmt.Errorf("db is required")
+	}
+	switch conf.Dialect {
+	case DialectPostgres, DialectMySQL, DialectSQLite:
+	case "":
+		return fmt.Errorf("dialect is required")
+	default:
+		return fmt.Errorf("unsupported dialect: %s", conf.Dialect)
+	}
+	if len(conf.AllowedStatements) == 0 {
+		conf.AllowedStatements = []string{"SELECT", "WITH", "EXPLAIN"}
+	}
+	if conf.MaxRows <= 0 {
+		conf.MaxRows = 50
+	}
+	if conf.MaxCellLength <= 0 {
+		conf.MaxCellLength = 200
+	}
+	if conf.MaxResultSize <= 0 {
+		conf.MaxResultSize = 16 * 1024
+	}
+	if conf.SampleRows == 0 {
+		conf.SampleRows = 3
+	}
+	if conf.Timeout <= 0 {
+		conf.Timeout = 30 * time.Second
+	}
+	if conf.ListTablesToolName == "" {
+		conf.ListTablesToolName = "sql_list_tables"
+	}
+	if conf.ListTablesToolDesc == "" {
+		conf.ListTablesToolDesc = "List the tables of the database."
+	}
+	if conf.DescribeTableToolName == "" {
+		conf.DescribeTableToolName = "sql_describe_table"
+	}
+	if conf.DescribeTableToolDesc == "" {
+		conf.DescribeTableToolDesc = "Describe the columns of tables, with sample rows. Call it before querying a table."
+	}
+	if conf.QueryToolName == "" {
+		conf.QueryToolName = "sql_query"
+	}
+	if conf.QueryToolDesc == "" {
+		conf.QueryToolDesc = fmt.Sprintf("Run a single read-only %s query and return the rows as a markdown table. "+
+			"Allowed statements: %s. At most %d rows are returned, aggregate or filter the data instead of reading whole tables.",
+			conf.Dialect, strings.Join(conf.AllowedStatements, ", "), conf.MaxRows)
+	}
+	return nil
+}
+
+type database struct {
+	conf   *Config
+	tables map[string]bool
+}
+
+func newDatabase(conf *Config) (*database, error) {
+	if err := conf.validate(); err != nil {
+		return nil, err
+	}
+	db := &database{conf: conf}
+	if len(conf.Tables) > 0 {
+		db.tables = make(map[string]bool, len(conf.Tables))
+		for _, t := range conf.Tables {
+			db.tables[strings.ToLower(t)] = true
+		}
+	}
+	return db, nil
+}
+
+// ListTablesRequest is the request of the list tables tool.
+type ListTablesRequest struct{}
+
+// ListTablesResponse is the response of the list tables tool.
+type ListTablesResponse struct {
+	Tables []string `json:"tables" jsonschema:"description=The names of the tables"`
+}
+
+// DescribeTableRequest is the request of the describe table tool.
+type DescribeTableRequest struct {
+	Tables []string `json:"tables" jsonschema:"description=The names of the tables to describe, as returned by the list tables tool"`
+}
+
+// QueryRequest is the request of the query tool.
+type QueryRequest struct {
+	Query string `json:"query" jsonschema:"description=The SQL query to run"`
+}
+
+// ListTables lists the tables and views of the database, restricted to Config.Tables.
+func (d *database) ListTables(ctx context.Context, _ *ListTablesRequest) (*ListTablesResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.conf.Timeout)
+	defer cancel()
+
+	tables, err := d.listTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ListTablesResponse{Tables: tables}, nil
+}
+
+// DescribeTable returns the columns and sample rows of the tables, as markdown.
+func (d *database) DescribeTable(ctx context.Context, req *DescribeTableRequest) (string, error) {
+	if len(req.Tables) == 0 {
+		return "", fmt.Errorf("tables are required")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.conf.Timeout)
+	defer cancel()
+
+	tables, err := d.listTables(ctx)
+	if err != nil {
+		return "", err
+	}
+	known := make(map[string]string, len(tables))
+	for _, t := range tables {
+		known[strings.ToLower(t)] = t
+	}
+
+	var sb strings.Builder
+	for i, name := range req.Tables {
+		table, ok := known[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return "", fmt.Errorf("table not found: %s", name)
+		}
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		if err = d.describeTable(ctx, &sb, table); err != nil {
+			return "", err
+		}
+	}
+	return sb.String(), nil
+}
+
+// Query runs a read-only query in a read-only transaction, and returns the rows as a markdown table.
+func (d *database) Query(ctx context.Context, req *QueryRequest) (string, error) {
+	query, err := checkStatement(req.Query, d.conf.AllowedStatements)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.conf.Timeout)
+	defer cancel()
+
+	var tx *sql.Tx
+	if d.conf.Dialect == DialectSQLite {
+		conn, err := queryOnlyConn(ctx, d.conf.DB)
+		if err != nil {
+			return "", err
+		}
+		defer releaseQueryOnlyConn(conn)
+		tx, err = conn.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+		if err != nil {
+			return "", fmt.Errorf("failed to begin transaction: %w", err)
+		}
+	} else {
+		tx, err = d.conf.DB.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+		if err != nil {
+			return "", fmt.Errorf("failed to begin transaction: %w", err)
+		}
+	}
+	// nothing is ever committed
+	defer func() { _ = tx.Rollback() }()
+
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return "", fmt.Errorf("query failed: %w", err)
+	}
+	defer rows.Close()
+
+	return d.markdownTable(rows, d.conf.MaxRows)
+}
+
+// queryOnlyConn returns a connection of the SQLite database which cannot write, the SQLite drivers,
+// e.g. github.com/mattn/go-sqlite3, ignoring the ReadOnly option of the transactions.
+func queryOnlyConn(ctx context.Context, db *sql.DB) (*sql.Conn, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection: %w", err)
+	}
+	if _, err = conn.ExecContext(ctx, "PRAGMA query_only = ON"); err != nil {
+		releaseQueryOnlyConn(conn)
+		return nil, fmt.Errorf("failed to make connection query only: %w", err)
+	}
+	return conn, nil
+}
+
+// releaseQueryOnlyConn returns the connection to the pool of the database writable again,
+// or discards it if it cannot be reset.
+func releaseQueryOnlyConn(conn *sql.Conn) {
+	if _, err := conn.ExecContext(context.Background(), "PRAGMA query_only = OFF"); err != nil {
+		_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+	}
+	_ = conn.Close()
+}
@@ -0,0 +1,170 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqldatabase
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino/components/tool"
+)
+
+func newTestDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared&_test="+t.Name())
+	assert.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+
+	_, err = db.Exec(`CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL, bio TEXT);
+CREATE TABLE orders (id INTEGER PRIMARY KEY, user_id INTEGER, amount REAL DEFAULT 0);
+CREATE VIEW big_orders AS SELECT * FROM orders WHERE amount > 100;
+INSERT INTO users (name, bio) VALUES ('alice', 'likes | pipes'), ('bob', NULL), ('carol', 'line1
+line2');
+INSERT INTO orders (user_id, amount) VALUES (1, 50), (1, 150), (2, 300);`)
+	assert.NoError(t, err)
+	return db
+}
+
+func TestNewToolKit(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewToolKit(ctx, nil)
+	assert.EqualError(t, err, "config is nil")
+	_, err = NewToolKit(ctx, &Config{})
+	assert.EqualError(t, err, "db is required")
+	_, err = NewToolKit(ctx, &Config{DB: &sql.DB{}})
+	assert.EqualError(t, err, "dialect is required")
+	_, err = NewToolKit(ctx, &Config{DB: &sql.DB{}, Dialect: "oracle"})
+	assert.EqualError(t, err, "unsupported dialect: oracle")
+
+	tools, err := NewToolKit(ctx, &Config{DB: newTestDB(t), Dialect: DialectSQLite})
+	assert.NoError(t, err)
+	assert.Len(t, tools, 3)
+	var names []string
+	for _, tl := range tools {
+		info, err := tl.Info(ctx)
+		assert.NoError(t, err)
+		names = append(names, info.Name)
+	}
+	assert.Equal(t, []string{"sql_list_tables", "sql_describe_table", "sql_query"}, names)
+
+	out, err := tools[0].(tool.InvokableTool).InvokableRun(ctx, `{}`)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"tables":["big_orders","orders","users"]}`, out)
+}
+
+func TestDatabase_DescribeTable(t *testing.T) {
+	ctx := context.Background()
+	db, err := newDatabase(&Config{DB: newTestDB(t), Dialect: DialectSQLite, Tables: []string{"users", "orders"}, SampleRows: 2})
+	assert.NoError(t, err)
+
+	tables, err := db.ListTables(ctx, &ListTablesRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"orders", "users"}, tables.Tables)
+
+	out, err := db.DescribeTable(ctx, &DescribeTableRequest{Tables: []string{"Users"}})
+	assert.NoError(t, err)
+	assert.Equal(t, `## users
+
+| column | type | nullable | default |
+| --- | --- | --- | --- |
+| id | INTEGER | YES |  |
+| name | TEXT | NO |  |
+| bio | TEXT | YES |  |
+
+Sample rows:
+
+| id | name | bio |
+| --- | --- | --- |
+| 1 | alice | likes \| pipes |
+| 2 | bob | NULL |
+`, out)
+
+	_, err = db.DescribeTable(ctx, &DescribeTableRequest{Tables: []string{"big_orders"}})
+	assert.EqualError(t, err, "table not found: big_orders")
+	_, err = db.DescribeTable(ctx, &DescribeTableRequest{})
+	assert.EqualError(t, err, "tables are required")
+}
+
+func TestDatabase_Query(t *testing.T) {
+	ctx := context.Background()
+	db, err := newDatabase(&Config{DB: newTestDB(t), Dialect: DialectSQLite, MaxRows: 2, MaxCellLength: 8})
+	assert.NoError(t, err)
+
+	out, err := db.Query(ctx, &QueryRequest{Query: "SELECT name, bio FROM users ORDER BY id;"})
+	assert.NoError(t, err)
+	assert.Equal(t, `| name | bio |
+| --- | --- |
+| alice | likes \| ... |
+| bob | NULL |
+
+[only the first 2 rows are shown]
+`, out)
+
+	out, err = db.Query(ctx, &QueryRequest{Query: "with t as (select sum(amount) as total from orders) select * from t"})
+	assert.NoError(t, err)
+	assert.Equal(t, "| total |\n| --- |\n| 500 |\n", out)
+
+	out, err = db.Query(ctx, &QueryRequest{Query: "SELECT * FROM orders WHERE amount > 1000"})
+	assert.NoError(t, err)
+	assert.Equal(t, "| id | user_id | amount |\n| --- | --- | --- |\n\n(0 rows)\n", out)
+
+	_, err = db.Query(ctx, &QueryRequest{Query: "SELECT * FROM missing"})
+	assert.ErrorContains(t, err, "query failed: no such table: missing")
+
+	_, err = db.Query(ctx, &QueryRequest{Query: "DELETE FROM users"})
+	assert.EqualError(t, err, "statement not allowed: DELETE, allowed statements: SELECT, WITH, EXPLAIN")
+	_, err = db.Query(ctx, &QueryRequest{Query: "WITH d AS (DELETE FROM users RETURNING id) SELECT * FROM d"})
+	assert.EqualError(t, err, "keyword not allowed in a read-only query: DELETE")
+
+	db.conf.MaxRows = 10
+	db.conf.MaxResultSize = 40
+	out, err = db.Query(ctx, &QueryRequest{Query: "SELECT id FROM users"})
+	assert.NoError(t, err)
+	assert.Equal(t, "| id |\n| --- |\n| 1 |\n| 2 |\n| 3 |\n", out)
+	db.conf.MaxResultSize = 30
+	out, err = db.Query(ctx, &QueryRequest{Query: "SELECT id FROM users"})
+	assert.NoError(t, err)
+	assert.Equal(t, "| id |\n| --- |\n| 1 |\n| 2 |\n\n[only the first 2 rows are shown]\n", out)
+
+	var count int
+	assert.NoError(t, db.conf.DB.QueryRow("SELECT count(*) FROM users").Scan(&count))
+	assert.Equal(t, 3, count)
+}
+
+func TestQueryOnlyConn(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	// go-sqlite3 ignores the ReadOnly option of the transactions
+	conn, err := queryOnlyConn(ctx, db)
+	assert.NoError(t, err)
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	assert.NoError(t, err)
+	_, err = tx.ExecContext(ctx, `INSERT INTO users (name) VALUES ('mallory')`)
+	assert.ErrorContains(t, err, "readonly")
+	assert.NoError(t, tx.Rollback())
+	releaseQueryOnlyConn(conn)
+
+	// the connection is writable again once released
+	_, err = db.ExecContext(ctx, `INSERT INTO users (name) VALUES ('dave')`)
+	assert.NoError(t, err)
+}
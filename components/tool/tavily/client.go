@@ -0,0 +1,310 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tavily
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxResults is the maximum number of results of a search allowed by the API.
+const maxResults = 20
+
+// maxExtractURLs is the maximum number of urls of an extraction allowed by the API.
+const maxExtractURLs = 20
+
+// SearchRequest is the request of the search tool.
+type SearchRequest struct {
+	Query          string   `json:"query" jsonschema:"required,description=The search query"`
+	Topic          Topic    `json:"topic,omitempty" jsonschema:"description=The category of the search: general for most queries, news for current events, finance for markets and companies,enum=general,enum=news,enum=finance"`
+	TimeRange      string   `json:"time_range,omitempty" jsonschema:"description=Only return pages published or updated within this time range,enum=day,enum=week,enum=month,enum=year"`
+	Days           int      `json:"days,omitempty" jsonschema:"description=Only return news published within this number of days, for the news topic only"`
+	MaxResults     int      `json:"max_results,omitempty" jsonschema:"description=The number of results to return, between 1 and 20"`
+	IncludeDomains []string `json:"include_domains,omitempty" jsonschema:"description=Only search these domains, e.g. go.dev"`
+	ExcludeDomains []string `json:"exclude_domains,omitempty" jsonschema:"description=Leave these domains out of the search"`
+}
+
+// SearchResponse is the response of the search tool.
+type SearchResponse struct {
+	Query   string          `json:"query"`
+	Answer  string          `json:"answer,omitempty"`
+	Results []*SearchResult `json:"results"`
+}
+
+// SearchResult is a page found by a search.
+type SearchResult struct {
+	Title         string  `json:"title"`
+	URL           string  `json:"url"`
+	Content       string  `json:"content"`
+	RawContent    string  `json:"raw_content,omitempty"`
+	Score         float64 `json:"score"`
+	PublishedDate string  `json:"published_date,omitempty"`
+}
+
+// ExtractRequest is the request of the extract tool.
+type ExtractRequest struct {
+	URLs []string `json:"urls" jsonschema:"required,description=The urls of the pages to extract, at most 20"`
+}
+
+// ExtractResponse is the response of the extract tool.
+type ExtractResponse struct {
+	Results       []*ExtractResult `json:"results"`
+	FailedResults []*FailedResult  `json:"failed_results,omitempty"`
+}
+
+// ExtractResult is the content of a page.
+type ExtractResult struct {
+	URL     string `json:"url"`
+	Content string `json:"content"`
+}
+
+// FailedResult is a page which could not be extracted.
+type FailedResult struct {
+	URL   string `json:"url"`
+	Error string `json:"error"`
+}
+
+type client struct {
+	conf *Config
+}
+
+func newClient(conf *Config) (*client, error) {
+	if err := conf.validate(); err != nil {
+		return nil, err
+	}
+	return &client{conf: conf}, nil
+}
+
+type searchBody struct {
+	Query             string   `json:"query"`
+	Topic             Topic    `json:"topic"`
+	SearchDepth       string   `json:"search_depth"`
+	MaxResults        int      `json:"max_results"`
+	TimeRange         string   `json:"time_range,omitempty"`
+	Days              int      `json:"days,omitempty"`
+	IncludeAnswer     bool     `json:"include_answer"`
+	IncludeRawContent bool     `json:"include_raw_content"`
+	IncludeDomains    []string `json:"include_domains,omitempty"`
+	ExcludeDomains    []string `json:"exclude_domains,omitempty"`
+}
+
+type searchResult struct {
+	Query   string `json:"query"`
+	Answer  string `json:"answer"`
+	Results []struct {
+		Title         string  `json:"title"`
+		URL           string  `json:"url"`
+		Content       string  `json:"content"`
+		RawContent    string  `json:"raw_content"`
+		Score         float64 `json:"score"`
+		PublishedDate string  `json:"published_date"`
+	} `json:"results"`
+}
+
+type extractBody struct {
+	URLs         []string `json:"urls"`
+	ExtractDepth string   `json:"extract_depth"`
+	Format       string   `json:"format"`
+}
+
+type extractResult struct {
+	Results []struct {
+		URL        string `json:"url"`
+		RawContent string `json:"raw_content"`
+	} `json:"results"`
+	FailedResults []struct {
+		URL   string `json:"url"`
+		Error string `json:"error"`
+	} `json:"failed_results"`
+}
+
+// Search searches the web. The topic, time range and number of results of the request override the configuration,
+// while the domains of the request can only narrow the domains of the configuration.
+func (c *client) Search(ctx context.Context, req *SearchRequest) (*SearchResponse, error) {
+	if strings.TrimSpace(req.Query) == "" {
+		return nil, errors.New("query is required")
+	}
+
+	body := &searchBody{
+		Query:             req.Query,
+		Topic:             c.conf.Topic,
+		SearchDepth:       string(c.conf.SearchDepth),
+		MaxResults:        c.conf.MaxResults,
+		TimeRange:         req.TimeRange,
+		IncludeAnswer:     c.conf.IncludeAnswer,
+		IncludeRawContent: c.conf.IncludeRawContent,
+		IncludeDomains:    c.conf.IncludeDomains,
+		ExcludeDomains:    append(append([]string{}, c.conf.ExcludeDomains...), req.ExcludeDomains...),
+	}
+	switch req.Topic {
+	case "":
+	case TopicGeneral, TopicNews, TopicFinance:
+		body.Topic = req.Topic
+	default:
+		return nil, fmt.Errorf("unsupported topic: %s", req.Topic)
+	}
+	switch req.TimeRange {
+	case "", "day", "week", "month", "year":
+	default:
+		return nil, fmt.Errorf("unsupported time range: %s", req.TimeRange)
+	}
+	// days is only supported by the news topic
+	if req.Days > 0 && body.Topic == TopicNews {
+		body.Days = req.Days
+	}
+	if req.MaxResults > 0 {
+		body.MaxResults = min(req.MaxResults, maxResults)
+	}
+	if len(req.IncludeDomains) > 0 {
+		body.IncludeDomains = narrowDomains(c.conf.IncludeDomains, req.IncludeDomains)
+	}
+
+	var res searchResult
+	if err := c.post(ctx, "/search", body, &res); err != nil {
+		return nil, err
+	}
+
+	resp := &SearchResponse{
+		Query:   res.Query,
+		Answer:  res.Answer,
+		Results: make([]*SearchResult, 0, len(res.Results)),
+	}
+	for _, r := range res.Results {
+		resp.Results = append(resp.Results, &SearchResult{
+			Title:         r.Title,
+			URL:           r.URL,
+			Content:       r.Content,
+			RawContent:    truncate(r.RawContent, c.conf.MaxContentLength),
+			Score:         r.Score,
+			PublishedDate: r.PublishedDate,
+		})
+	}
+	return resp, nil
+}
+
+// Extract extracts the content of web pages as markdown.
+func (c *client) Extract(ctx context.Context, req *ExtractRequest) (*ExtractResponse, error) {
+	if len(req.URLs) == 0 {
+		return nil, errors.New("urls is required")
+	}
+	if len(req.URLs) > maxExtractURLs {
+		return nil, fmt.Errorf("at most %d urls can be extracted at once", maxExtractURLs)
+	}
+
+	var res extractResult
+	err := c.post(ctx, "/extract", &extractBody{
+		URLs:         req.URLs,
+		ExtractDepth: string(c.conf.ExtractDepth),
+		Format:       "markdown",
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ExtractResponse{Results: make([]*ExtractResult, 0, len(res.Results))}
+	for _, r := range res.Results {
+		resp.Results = append(resp.Results, &ExtractResult{
+			URL:     r.URL,
+			Content: truncate(r.RawContent, c.conf.MaxContentLength),
+		})
+	}
+	for _, r := range res.FailedResults {
+		resp.FailedResults = append(resp.FailedResults, &FailedResult{URL: r.URL, Error: r.Error})
+	}
+	return resp, nil
+}
+
+func (c *client) post(ctx context.Context, path string, body, result any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(c.conf.BaseURL, "/")+path, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.conf.APIKey)
+
+	resp, err := c.conf.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("tavily api error, status code: %d, message: %s", resp.StatusCode, errorMessage(respBody))
+	}
+	if err = json.Unmarshal(respBody, result); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return nil
+}
+
+// errorMessage returns the message of an error response, {"detail":{"error":"..."}}, or the body if it has another format.
+func errorMessage(body []byte) string {
+	var e struct {
+		Detail struct {
+			Error string `json:"error"`
+		} `json:"detail"`
+	}
+	if err := json.Unmarshal(body, &e); err == nil && e.Detail.Error != "" {
+		return e.Detail.Error
+	}
+	return string(body)
+}
+
+// narrowDomains returns the requested domains allowed by the configured ones, which are returned if none is.
+func narrowDomains(allowed, requested []string) []string {
+	if len(allowed) == 0 {
+		return requested
+	}
+	var ret []string
+	for _, r := range requested {
+		for _, a := range allowed {
+			if r == a || strings.HasSuffix(r, "."+a) {
+				ret = append(ret, r)
+				break
+			}
+		}
+	}
+	if len(ret) == 0 {
+		return allowed
+	}
+	return ret
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n]) + "\n[content truncated]"
+}
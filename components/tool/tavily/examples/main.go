@@ -0,0 +1,55 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/cloudwego/eino/components/tool"
+
+	"github.com/cloudwego/eino-ext/components/tool/tavily"
+)
+
+func main() {
+	ctx := context.Background()
+
+	tools, err := tavily.NewToolKit(ctx, &tavily.Config{
+		APIKey:        os.Getenv("TAVILY_API_KEY"),
+		IncludeAnswer: true,
+		MaxResults:    3,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// search recent news, then read the first page found, as an agent would
+	search, extract := tools[0].(tool.InvokableTool), tools[1].(tool.InvokableTool)
+
+	out, err := search.InvokableRun(ctx, `{"query":"latest Go release","topic":"news","time_range":"month"}`)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Println(out)
+
+	out, err = extract.InvokableRun(ctx, `{"urls":["https://go.dev/doc/devel/release"]}`)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Println(out)
+}
@@ -0,0 +1,209 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package tavily provides tools searching the web and extracting the content of web pages with the Tavily API,
+// which is built for LLM agents and returns content ready to be used in a prompt.
+package tavily
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+)
+
+// Topic is the category of the search, which selects the sources Tavily searches.
+type Topic string
+
+const (
+	TopicGeneral Topic = "general"
+	TopicNews    Topic = "news"
+	TopicFinance Topic = "finance"
+)
+
+// SearchDepth is the depth of the search. Advanced searches return more relevant content, and cost 2 credits.
+type SearchDepth string
+
+const (
+	SearchDepthBasic    SearchDepth = "basic"
+	SearchDepthAdvanced SearchDepth = "advanced"
+)
+
+// ExtractDepth is the depth of the extraction. Advanced extraction also gets tables and embedded content, and costs more credits.
+type ExtractDepth string
+
+const (
+	ExtractDepthBasic    ExtractDepth = "basic"
+	ExtractDepthAdvanced ExtractDepth = "advanced"
+)
+
+// Config is the configuration for the tavily tools.
+type Config struct {
+	// APIKey is the Tavily API key, e.g. "tvly-...".
+	// Required.
+	APIKey string
+	// BaseURL is the base url of the Tavily API.
+	// Optional. Default "https://api.tavily.com".
+	BaseURL string
+	// HTTPClient is the http client sending the requests.
+	// Optional. Default a client with Timeout.
+	HTTPClient *http.Client
+	// Timeout is the maximum duration of each request.
+	// Optional. Default 30s.
+	Timeout time.Duration
+
+	// Topic is the default topic of the searches, the model can override it per search.
+	// Optional. Default TopicGeneral.
+	Topic Topic
+	// SearchDepth is the depth of the searches.
+	// Optional. Default SearchDepthBasic.
+	SearchDepth SearchDepth
+	// MaxResults is the default number of results of a search, between 1 and 20.
+	// Optional. Default 5.
+	MaxResults int
+	// IncludeAnswer asks Tavily to synthesize a short answer to the query from the results,
+	// returned in the answer field of the search response.
+	// Optional. Default false.
+	IncludeAnswer bool
+	// IncludeRawContent returns the cleaned content of each page with the results, instead of the snippets only.
+	// Mind the context window, the content is truncated to MaxContentLength.
+	// Optional. Default false.
+	IncludeRawContent bool
+	// IncludeDomains restricts all the searches to these domains, e.g. "go.dev".
+	// Optional.
+	IncludeDomains []string
+	// ExcludeDomains leaves these domains out of all the searches.
+	// Optional.
+	ExcludeDomains []string
+
+	// ExtractDepth is the depth of the extractions.
+	// Optional. Default ExtractDepthBasic.
+	ExtractDepth ExtractDepth
+	// MaxContentLength is the maximum number of characters of the content of a page, longer content is truncated.
+	// Optional. Default 20000.
+	MaxContentLength int
+
+	SearchToolName  string // Optional. Default "tavily_search".
+	SearchToolDesc  string // Optional. Default a description of the search tool.
+	ExtractToolName string // Optional. Default "tavily_extract".
+	ExtractToolDesc string // Optional. Default a description of the extract tool.
+}
+
+// NewToolKit creates the search and extract tools.
+func NewToolKit(ctx context.Context, conf *Config) ([]tool.BaseTool, error) {
+	search, err := NewSearchTool(ctx, conf)
+	if err != nil {
+		return nil, err
+	}
+	extract, err := NewExtractTool(ctx, conf)
+	if err != nil {
+		return nil, err
+	}
+	return []tool.BaseTool{search, extract}, nil
+}
+
+// NewSearchTool creates the tool searching the web.
+func NewSearchTool(ctx context.Context, conf *Config) (tool.InvokableTool, error) {
+	c, err := newClient(conf)
+	if err != nil {
+		return nil, err
+	}
+	t, err := utils.InferTool(conf.SearchToolName, conf.SearchToolDesc, c.Search)
+	if err != nil {
+		return nil, fmt.Errorf("failed to infer tool: %w", err)
+	}
+	return t, nil
+}
+
+// NewExtractTool creates the tool extracting the content of web pages.
+func NewExtractTool(ctx context.Context, conf *Config) (tool.InvokableTool, error) {
+	c, err := newClient(conf)
+	if err != nil {
+		return nil, err
+	}
+	t, err := utils.InferTool(conf.ExtractToolName, conf.ExtractToolDesc, c.Extract)
+	if err != nil {
+		return nil, fmt.Errorf("failed to infer tool: %w", err)
+	}
+	return t, nil
+}
+
+// validate validates the configuration and sets default values if not provided.
+func (conf *Config) validate() error {
+	if conf == nil {
+		return fmt.Errorf("config is nil")
+	}
+	if conf.APIKey == "" {
+		return fmt.Errorf("api key is required")
+	}
+	if conf.BaseURL == "" {
+		conf.BaseURL = "https://api.tavily.com"
+	}
+	if conf.Timeout <= 0 {
+		conf.Timeout = 30 * time.Second
+	}
+	if conf.HTTPClient == nil {
+		conf.HTTPClient = &http.Client{Timeout: conf.Timeout}
+	}
+	switch conf.Topic {
+	case "":
+		conf.Topic = TopicGeneral
+	case TopicGeneral, TopicNews, TopicFinance:
+	default:
+		return fmt.Errorf("unsupported topic: %s", conf.Topic)
+	}
+	switch conf.SearchDepth {
+	case "":
+		conf.SearchDepth = SearchDepthBasic
+	case SearchDepthBasic, SearchDepthAdvanced:
+	default:
+		return fmt.Errorf("unsupported search depth: %s", conf.SearchDepth)
+	}
+	switch conf.ExtractDepth {
+	case "":
+		conf.ExtractDepth = ExtractDepthBasic
+	case ExtractDepthBasic, ExtractDepthAdvanced:
+	default:
+		return fmt.Errorf("unsupported extract depth: %s", conf.ExtractDepth)
+	}
+	if conf.MaxResults <= 0 {
+		conf.MaxResults = 5
+	}
+	if conf.MaxResults > maxResults {
+		return fmt.Errorf("max results must be at most %d", maxResults)
+	}
+	if conf.MaxContentLength <= 0 {
+		conf.MaxContentLength = 20000
+	}
+	if conf.SearchToolName == "" {
+		conf.SearchToolName = "tavily_search"
+	}
+	if conf.SearchToolDesc == "" {
+		conf.SearchToolDesc = "Search the web with Tavily, a search engine built for AI agents. " +
+			"Returns the most relevant pages with their content, and optionally a short answer to the query. " +
+			"Use the news topic with a time range for recent events."
+	}
+	if conf.ExtractToolName == "" {
+		conf.ExtractToolName = "tavily_extract"
+	}
+	if conf.ExtractToolDesc == "" {
+		conf.ExtractToolDesc = "Extract the content of web pages as markdown, e.g. to read a page found with the search tool."
+	}
+	return nil
+}
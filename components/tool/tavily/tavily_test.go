@@ -0,0 +1,192 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tavily
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestServer(t *testing.T, handler func(path string, body map[string]any) (int, string)) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "Bearer tvly-test", r.Header.Get("Authorization"))
+		data, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		var body map[string]any
+		assert.NoError(t, json.Unmarshal(data, &body))
+
+		code, resp := handler(r.URL.Path, body)
+		w.WriteHeader(code)
+		_, _ = w.Write([]byte(resp))
+	}))
+}
+
+func TestNewToolKit(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewToolKit(ctx, nil)
+	assert.EqualError(t, err, "config is nil")
+	_, err = NewToolKit(ctx, &Config{})
+	assert.EqualError(t, err, "api key is required")
+	_, err = NewToolKit(ctx, &Config{APIKey: "tvly-test", Topic: "sports"})
+	assert.EqualError(t, err, "unsupported topic: sports")
+	_, err = NewToolKit(ctx, &Config{APIKey: "tvly-test", MaxResults: 50})
+	assert.EqualError(t, err, "max results must be at most 20")
+
+	tools, err := NewToolKit(ctx, &Config{APIKey: "tvly-test"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(tools))
+	info, err := tools[0].Info(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "tavily_search", info.Name)
+	info, err = tools[1].Info(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "tavily_extract", info.Name)
+}
+
+func TestSearch(t *testing.T) {
+	ctx := context.Background()
+
+	var got map[string]any
+	srv := newTestServer(t, func(path string, body map[string]any) (int, string) {
+		assert.Equal(t, "/search", path)
+		got = body
+		return http.StatusOK, `{
+			"query": "go 1.24 release",
+			"answer": "Go 1.24 was released in February 2025.",
+			"results": [
+				{"title": "Go 1.24 is released", "url": "https://go.dev/blog/go1.24", "content": "Today the Go team is happy to release Go 1.24", "raw_content": "# Go 1.24 is released\n\nToday the Go team...", "score": 0.92, "published_date": "Tue, 11 Feb 2025 00:00:00 GMT"}
+			],
+			"response_time": 1.2
+		}`
+	})
+	defer srv.Close()
+
+	search, err := NewSearchTool(ctx, &Config{
+		APIKey:            "tvly-test",
+		BaseURL:           srv.URL,
+		IncludeAnswer:     true,
+		IncludeRawContent: true,
+		MaxContentLength:  10,
+		IncludeDomains:    []string{"go.dev"},
+		ExcludeDomains:    []string{"pkg.go.dev"},
+	})
+	assert.NoError(t, err)
+
+	out, err := search.InvokableRun(ctx, `{"query":"go 1.24 release","topic":"news","time_range":"month","days":7,"max_results":30,"include_domains":["blog.go.dev","example.com"],"exclude_domains":["reddit.com"]}`)
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"query":               "go 1.24 release",
+		"topic":               "news",
+		"search_depth":        "basic",
+		"max_results":         float64(20),
+		"time_range":          "month",
+		"days":                float64(7),
+		"include_answer":      true,
+		"include_raw_content": true,
+		"include_domains":     []any{"blog.go.dev"},
+		"exclude_domains":     []any{"pkg.go.dev", "reddit.com"},
+	}, got)
+
+	var resp SearchResponse
+	assert.NoError(t, json.Unmarshal([]byte(out), &resp))
+	assert.Equal(t, "Go 1.24 was released in February 2025.", resp.Answer)
+	assert.Equal(t, 1, len(resp.Results))
+	assert.Equal(t, "https://go.dev/blog/go1.24", resp.Results[0].URL)
+	assert.Equal(t, 0.92, resp.Results[0].Score)
+	assert.Equal(t, "# Go 1.24 \n[content truncated]", resp.Results[0].RawContent)
+
+	t.Run("defaults", func(t *testing.T) {
+		_, err = search.InvokableRun(ctx, `{"query":"eino","days":3}`)
+		assert.NoError(t, err)
+		assert.Equal(t, "general", got["topic"])
+		assert.Equal(t, float64(5), got["max_results"])
+		assert.Nil(t, got["days"])
+		assert.Equal(t, []any{"go.dev"}, got["include_domains"])
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		_, err = search.InvokableRun(ctx, `{"query":" "}`)
+		assert.ErrorContains(t, err, "query is required")
+		_, err = search.InvokableRun(ctx, `{"query":"eino","topic":"sports"}`)
+		assert.ErrorContains(t, err, "unsupported topic: sports")
+		_, err = search.InvokableRun(ctx, `{"query":"eino","time_range":"decade"}`)
+		assert.ErrorContains(t, err, "unsupported time range: decade")
+	})
+}
+
+func TestExtract(t *testing.T) {
+	ctx := context.Background()
+
+	srv := newTestServer(t, func(path string, body map[string]any) (int, string) {
+		assert.Equal(t, "/extract", path)
+		assert.Equal(t, "advanced", body["extract_depth"])
+		assert.Equal(t, "markdown", body["format"])
+		assert.Equal(t, []any{"https://go.dev/doc", "https://example.invalid"}, body["urls"])
+		return http.StatusOK, `{
+			"results": [{"url": "https://go.dev/doc", "raw_content": "# Documentation"}],
+			"failed_results": [{"url": "https://example.invalid", "error": "failed to fetch"}]
+		}`
+	})
+	defer srv.Close()
+
+	tools, err := NewToolKit(ctx, &Config{APIKey: "tvly-test", BaseURL: srv.URL, ExtractDepth: ExtractDepthAdvanced})
+	assert.NoError(t, err)
+	extract := tools[1].(tool.InvokableTool)
+
+	out, err := extract.InvokableRun(ctx, `{"urls":["https://go.dev/doc","https://example.invalid"]}`)
+	assert.NoError(t, err)
+	var resp ExtractResponse
+	assert.NoError(t, json.Unmarshal([]byte(out), &resp))
+	assert.Equal(t, []*ExtractResult{{URL: "https://go.dev/doc", Content: "# Documentation"}}, resp.Results)
+	assert.Equal(t, []*FailedResult{{URL: "https://example.invalid", Error: "failed to fetch"}}, resp.FailedResults)
+
+	_, err = extract.InvokableRun(ctx, `{"urls":[]}`)
+	assert.ErrorContains(t, err, "urls is required")
+	_, err = extract.InvokableRun(ctx, `{"urls":["`+strings.Repeat(`a","`, 20)+`a"]}`)
+	assert.ErrorContains(t, err, "at most 20 urls can be extracted at once")
+}
+
+func TestAPIError(t *testing.T) {
+	ctx := context.Background()
+
+	srv := newTestServer(t, func(path string, body map[string]any) (int, string) {
+		if path == "/search" {
+			return http.StatusUnauthorized, `{"detail":{"error":"Unauthorized: missing or invalid API key."}}`
+		}
+		return http.StatusBadGateway, `bad gateway`
+	})
+	defer srv.Close()
+
+	c, err := newClient(&Config{APIKey: "tvly-test", BaseURL: srv.URL})
+	assert.NoError(t, err)
+
+	_, err = c.Search(ctx, &SearchRequest{Query: "eino"})
+	assert.EqualError(t, err, "tavily api error, status code: 401, message: Unauthorized: missing or invalid API key.")
+	_, err = c.Extract(ctx, &ExtractRequest{URLs: []string{"https://go.dev"}})
+	assert.EqualError(t, err, "tavily api error, status code: 502, message: bad gateway")
+}
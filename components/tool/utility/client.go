@@ -0,0 +1,106 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package utility
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// nominatimInterval is the minimum interval between two requests to Nominatim, as required by its usage policy.
+const nominatimInterval = time.Second
+
+type client struct {
+	conf *Config
+
+	// nominatimMu serializes the requests to Nominatim, and nominatimLast is the time of the last one.
+	nominatimMu   sync.Mutex
+	nominatimLast time.Time
+}
+
+func newClient(conf *Config) (*client, error) {
+	if err := conf.validate(); err != nil {
+		return nil, err
+	}
+	return &client{conf: conf}, nil
+}
+
+// getJSON sends a GET request and decodes the JSON response into out.
+func (c *client) getJSON(ctx context.Context, u string, params url.Values, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u+"?"+params.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.conf.UserAgent)
+	req.Header.Set("Accept", "application/json")
+	if c.conf.Language != "" {
+		req.Header.Set("Accept-Language", c.conf.Language)
+	}
+
+	resp, err := c.conf.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		// Open-Meteo answers the errors with a reason
+		var apiErr struct {
+			Reason string `json:"reason"`
+		}
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Reason != "" {
+			return fmt.Errorf("request failed with status %d: %s", resp.StatusCode, apiErr.Reason)
+		}
+		return fmt.Errorf("request failed with status %d: %s", resp.StatusCode, truncate(string(body), 200))
+	}
+	if err = json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return nil
+}
+
+// waitNominatim waits until a request can be sent to Nominatim, the caller must hold nominatimMu.
+func (c *client) waitNominatim(ctx context.Context) error {
+	wait := time.Until(c.nominatimLast.Add(nominatimInterval))
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	c.nominatimLast = time.Now()
+	return nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
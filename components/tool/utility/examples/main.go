@@ -0,0 +1,59 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/cloudwego/eino/components/tool"
+
+	"github.com/cloudwego/eino-ext/components/tool/utility"
+)
+
+func main() {
+	ctx := context.Background()
+
+	tools, err := utility.NewToolKit(ctx, &utility.Config{
+		UserAgent: "eino-utility-example",
+		Language:  "en",
+	})
+	if err != nil {
+		log.Fatalf("NewToolKit failed, err=%v", err)
+	}
+
+	geocode := tools[1].(tool.InvokableTool)
+	out, err := geocode.InvokableRun(ctx, `{"query":"Eiffel Tower","limit":1}`)
+	if err != nil {
+		log.Fatalf("geocode failed, err=%v", err)
+	}
+	fmt.Println(out)
+
+	forecast := tools[0].(tool.InvokableTool)
+	out, err = forecast.InvokableRun(ctx, `{"latitude":48.8584,"longitude":2.2945,"days":2}`)
+	if err != nil {
+		log.Fatalf("weather_forecast failed, err=%v", err)
+	}
+	fmt.Println(out)
+
+	convert := tools[2].(tool.InvokableTool)
+	out, err = convert.InvokableRun(ctx, `{"time":"2025-07-01 09:00","from_timezone":"Europe/Paris","to_timezones":["America/New_York","Asia/Tokyo"]}`)
+	if err != nil {
+		log.Fatalf("convert_time failed, err=%v", err)
+	}
+	fmt.Println(out)
+}
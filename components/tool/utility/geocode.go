@@ -0,0 +1,156 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package utility
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultGeocodeLimit = 5
+	maxGeocodeLimit     = 20
+)
+
+// GeocodeRequest is the request of the geocoding tool, either a query, or a latitude and a longitude.
+type GeocodeRequest struct {
+	Query        string   `json:"query,omitempty" jsonschema:"description=The name or the address of the place to find, e.g. Eiffel Tower or 10 Downing Street London"`
+	Latitude     *float64 `json:"latitude,omitempty" jsonschema:"description=The latitude to find the address at, with longitude and without query"`
+	Longitude    *float64 `json:"longitude,omitempty" jsonschema:"description=The longitude to find the address at, with latitude and without query"`
+	CountryCodes []string `json:"country_codes,omitempty" jsonschema:"description=Only return the places in these countries, as ISO 3166-1 alpha-2 codes, e.g. fr"`
+	Limit        int      `json:"limit,omitempty" jsonschema:"description=The maximum number of places to return for a query between 1 and 20. Default 5"`
+}
+
+// GeocodeResponse are the places found, the most relevant first.
+type GeocodeResponse struct {
+	Places []*Place `json:"places"`
+}
+
+// Place is a place of OpenStreetMap.
+type Place struct {
+	Name      string  `json:"name"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	// Category and Type are the OpenStreetMap tag of the place, e.g. "tourism" and "attraction".
+	Category string `json:"category,omitempty"`
+	Type     string `json:"type,omitempty"`
+	// Address are the parts of the address, e.g. "road", "city", "postcode", "country" and "country_code".
+	Address map[string]string `json:"address,omitempty"`
+}
+
+type nominatimPlace struct {
+	Lat         string            `json:"lat"`
+	Lon         string            `json:"lon"`
+	DisplayName string            `json:"display_name"`
+	Category    string            `json:"category"`
+	Type        string            `json:"type"`
+	Address     map[string]string `json:"address"`
+	Error       string            `json:"error"`
+}
+
+// Geocode searches the places with the Nominatim search API, or the address at the coordinates with the reverse API.
+func (c *client) Geocode(ctx context.Context, req *GeocodeRequest) (*GeocodeResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request is nil")
+	}
+
+	params := url.Values{
+		"format":         {"jsonv2"},
+		"addressdetails": {"1"},
+	}
+	if c.conf.Language != "" {
+		params.Set("accept-language", c.conf.Language)
+	}
+
+	var (
+		endpoint string
+		places   []*nominatimPlace
+	)
+	switch {
+	case strings.TrimSpace(req.Query) != "":
+		if req.Latitude != nil || req.Longitude != nil {
+			return nil, fmt.Errorf("set either query, or latitude and longitude")
+		}
+		limit := req.Limit
+		if limit <= 0 {
+			limit = defaultGeocodeLimit
+		}
+		if limit > maxGeocodeLimit {
+			return nil, fmt.Errorf("invalid limit: %d, must be between 1 and %d", limit, maxGeocodeLimit)
+		}
+		params.Set("q", req.Query)
+		params.Set("limit", strconv.Itoa(limit))
+		if len(req.CountryCodes) > 0 {
+			params.Set("countrycodes", strings.ToLower(strings.Join(req.CountryCodes, ",")))
+		}
+		endpoint = "/search"
+	case req.Latitude != nil && req.Longitude != nil:
+		if *req.Latitude < -90 || *req.Latitude > 90 || *req.Longitude < -180 || *req.Longitude > 180 {
+			return nil, fmt.Errorf("invalid coordinates: %v, %v", *req.Latitude, *req.Longitude)
+		}
+		params.Set("lat", strconv.FormatFloat(*req.Latitude, 'f', -1, 64))
+		params.Set("lon", strconv.FormatFloat(*req.Longitude, 'f', -1, 64))
+		endpoint = "/reverse"
+	default:
+		return nil, fmt.Errorf("query, or latitude and longitude, are required")
+	}
+
+	c.nominatimMu.Lock()
+	defer c.nominatimMu.Unlock()
+	if err := c.waitNominatim(ctx); err != nil {
+		return nil, err
+	}
+
+	u := c.conf.NominatimBaseURL + endpoint
+	if endpoint == "/search" {
+		if err := c.getJSON(ctx, u, params, &places); err != nil {
+			return nil, err
+		}
+	} else {
+		var place nominatimPlace
+		if err := c.getJSON(ctx, u, params, &place); err != nil {
+			return nil, err
+		}
+		// no address at the coordinates, e.g. in the sea
+		if place.Error == "" {
+			places = append(places, &place)
+		}
+	}
+
+	result := &GeocodeResponse{Places: make([]*Place, 0, len(places))}
+	for _, p := range places {
+		lat, err := strconv.ParseFloat(p.Lat, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid latitude in response: %q", p.Lat)
+		}
+		lon, err := strconv.ParseFloat(p.Lon, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid longitude in response: %q", p.Lon)
+		}
+		result.Places = append(result.Places, &Place{
+			Name:      p.DisplayName,
+			Latitude:  lat,
+			Longitude: lon,
+			Category:  p.Category,
+			Type:      p.Type,
+			Address:   p.Address,
+		})
+	}
+	return result, nil
+}
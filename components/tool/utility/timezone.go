@@ -0,0 +1,150 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package utility
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	// embed the time zone database, for the systems without one, e.g. Windows or distroless images
+	_ "time/tzdata"
+)
+
+// now is replaced in the tests.
+var now = time.Now
+
+// localTimeLayouts are the layouts of the times without a UTC offset, interpreted in the source time zone.
+var localTimeLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// clockLayouts are the layouts of the times of the current day in the source time zone.
+var clockLayouts = []string{
+	"15:04:05",
+	"15:04",
+}
+
+// ConvertTimeRequest is the request of the time zone tool.
+type ConvertTimeRequest struct {
+	Time         string   `json:"time,omitempty" jsonschema:"description=The date and time to convert: 2006-01-02 15:04 or 15:04 for today or RFC 3339 with a UTC offset. Default now"`
+	FromTimezone string   `json:"from_timezone,omitempty" jsonschema:"description=The IANA time zone of the time without a UTC offset, e.g. America/New_York. Default UTC"`
+	ToTimezones  []string `json:"to_timezones" jsonschema:"required,description=The IANA time zones to convert to, e.g. Europe/Paris and Asia/Tokyo"`
+}
+
+// ConvertTimeResponse is the time in the source time zone and in the target time zones.
+type ConvertTimeResponse struct {
+	Source  *ZonedTime   `json:"source"`
+	Results []*ZonedTime `json:"results"`
+}
+
+// ZonedTime is a time in a time zone.
+type ZonedTime struct {
+	Timezone string `json:"timezone"`
+	// Time is the time in RFC 3339, with the UTC offset of the time zone.
+	Time    string `json:"time"`
+	Weekday string `json:"weekday"`
+	// Abbreviation is the abbreviation of the time zone at the time, e.g. "CEST".
+	Abbreviation string `json:"abbreviation"`
+	UTCOffset    string `json:"utc_offset"`
+	IsDST        bool   `json:"is_dst"`
+}
+
+// ConvertTime converts the time from the source time zone to the target time zones.
+func (c *client) ConvertTime(_ context.Context, req *ConvertTimeRequest) (*ConvertTimeResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request is nil")
+	}
+	if len(req.ToTimezones) == 0 {
+		return nil, fmt.Errorf("to_timezones is required")
+	}
+
+	fromName := req.FromTimezone
+	if fromName == "" {
+		fromName = "UTC"
+	}
+	from, err := loadLocation(fromName)
+	if err != nil {
+		return nil, err
+	}
+	t, err := parseTime(req.Time, from)
+	if err != nil {
+		return nil, err
+	}
+	if req.Time != "" && t.Location() != from {
+		// the UTC offset of the time takes precedence over the source time zone
+		fromName = t.Location().String()
+	}
+
+	result := &ConvertTimeResponse{
+		Source:  zonedTime(fromName, t),
+		Results: make([]*ZonedTime, 0, len(req.ToTimezones)),
+	}
+	for _, name := range req.ToTimezones {
+		loc, err := loadLocation(name)
+		if err != nil {
+			return nil, err
+		}
+		result.Results = append(result.Results, zonedTime(name, t.In(loc)))
+	}
+	return result, nil
+}
+
+func loadLocation(name string) (*time.Location, error) {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone: %q, expected an IANA name like Europe/Paris", name)
+	}
+	return loc, nil
+}
+
+// parseTime parses the time in the location, unless it has a UTC offset. An empty time is now.
+func parseTime(s string, loc *time.Location) (time.Time, error) {
+	if s == "" {
+		return now().In(loc), nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	for _, layout := range localTimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	for _, layout := range clockLayouts {
+		if clock, err := time.Parse(layout, s); err == nil {
+			y, m, d := now().In(loc).Date()
+			return time.Date(y, m, d, clock.Hour(), clock.Minute(), clock.Second(), 0, loc), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time: %q, expected e.g. 2006-01-02 15:04, 15:04 or 2006-01-02T15:04:05+08:00", s)
+}
+
+func zonedTime(name string, t time.Time) *ZonedTime {
+	abbr, _ := t.Zone()
+	return &ZonedTime{
+		Timezone:     name,
+		Time:         t.Format(time.RFC3339),
+		Weekday:      t.Weekday().String(),
+		Abbreviation: abbr,
+		UTCOffset:    t.Format("-07:00"),
+		IsDST:        t.IsDST(),
+	}
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package utility provides tools without API keys for the common needs of assistants:
+// the weather forecast from Open-Meteo, geocoding from Nominatim, and the conversion of times between time zones.
+package utility
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+)
+
+// Config is the configuration for the utility tools.
+type Config struct {
+	// OpenMeteoBaseURL is the base url of the Open-Meteo forecast API.
+	// Optional. Default "https://api.open-meteo.com/v1".
+	OpenMeteoBaseURL string
+	// NominatimBaseURL is the base url of the Nominatim API, set it to a self-hosted instance for heavy usage.
+	// Optional. Default "https://nominatim.openstreetmap.org".
+	NominatimBaseURL string
+	// UserAgent identifies the application to Nominatim, as required by its usage policy.
+	// Optional. Default "eino-ext-utility-tool".
+	UserAgent string
+	// Language is the preferred language of the place names, e.g. "en" or "zh-CN".
+	// Optional. Default the local language of each place.
+	Language string
+	// HTTPClient is the http client sending the requests.
+	// Optional. Default a client with Timeout.
+	HTTPClient *http.Client
+	// Timeout is the maximum duration of each request.
+	// Optional. Default 30s.
+	Timeout time.Duration
+}
+
+// NewToolKit creates the utility tools: weather_forecast, geocode and convert_time.
+func NewToolKit(ctx context.Context, conf *Config) ([]tool.BaseTool, error) {
+	c, err := newClient(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		tools    []tool.BaseTool
+		inferErr error
+	)
+	add := func(t tool.InvokableTool, err error) {
+		if err != nil {
+			inferErr = errors.Join(inferErr, err)
+			return
+		}
+		tools = append(tools, t)
+	}
+
+	add(utils.InferTool("weather_forecast", "Get the current weather and the daily forecast at a latitude and longitude. "+
+		"Use geocode first to find the coordinates of a place.", c.Forecast))
+	add(utils.InferTool("geocode", "Find the coordinates and the address of a place by its name or address, "+
+		"or the address at a latitude and longitude.", c.Geocode))
+	add(utils.InferTool("convert_time", "Convert a date and time from a time zone to other time zones, "+
+		"or get the current time in time zones when no time is given. Time zones are IANA names like Europe/Paris.", c.ConvertTime))
+	if inferErr != nil {
+		return nil, fmt.Errorf("failed to infer tool: %w", inferErr)
+	}
+	return tools, nil
+}
+
+// validate validates the configuration and sets default values if not provided.
+func (conf *Config) validate() error {
+	if conf == nil {
+		return fmt.Errorf("config is nil")
+	}
+	if conf.OpenMeteoBaseURL == "" {
+		conf.OpenMeteoBaseURL = "https://api.open-meteo.com/v1"
+	}
+	conf.OpenMeteoBaseURL = strings.TrimRight(conf.OpenMeteoBaseURL, "/")
+	if conf.NominatimBaseURL == "" {
+		conf.NominatimBaseURL = "https://nominatim.openstreetmap.org"
+	}
+	conf.NominatimBaseURL = strings.TrimRight(conf.NominatimBaseURL, "/")
+	if conf.UserAgent == "" {
+		conf.UserAgent = "eino-ext-utility-tool"
+	}
+	if conf.Timeout <= 0 {
+		conf.Timeout = 30 * time.Second
+	}
+	if conf.HTTPClient == nil {
+		conf.HTTPClient = &http.Client{Timeout: conf.Timeout}
+	}
+	return nil
+}
@@ -0,0 +1,279 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package utility
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClient(t *testing.T, mux *http.ServeMux) *client {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "test-agent", r.Header.Get("User-Agent"))
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	c, err := newClient(&Config{
+		OpenMeteoBaseURL: srv.URL + "/v1/",
+		NominatimBaseURL: srv.URL,
+		UserAgent:        "test-agent",
+	})
+	assert.NoError(t, err)
+	return c
+}
+
+func TestNewToolKit(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewToolKit(ctx, nil)
+	assert.EqualError(t, err, "config is nil")
+
+	tools, err := NewToolKit(ctx, &Config{})
+	assert.NoError(t, err)
+	var names []string
+	for _, tl := range tools {
+		_, ok := tl.(tool.InvokableTool)
+		assert.True(t, ok)
+		info, err := tl.Info(ctx)
+		assert.NoError(t, err)
+		names = append(names, info.Name)
+	}
+	assert.Equal(t, []string{"weather_forecast", "geocode", "convert_time"}, names)
+}
+
+func TestForecast(t *testing.T) {
+	ctx := context.Background()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/forecast", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		assert.Equal(t, "48.8566", q.Get("latitude"))
+		assert.Equal(t, "2.3522", q.Get("longitude"))
+		assert.Equal(t, "auto", q.Get("timezone"))
+		if q.Get("forecast_days") == "2" {
+			assert.Equal(t, "fahrenheit", q.Get("temperature_unit"))
+			assert.Equal(t, "mph", q.Get("wind_speed_unit"))
+			assert.Equal(t, "inch", q.Get("precipitation_unit"))
+		} else {
+			assert.Equal(t, "3", q.Get("forecast_days"))
+			assert.Empty(t, q.Get("temperature_unit"))
+		}
+		_, _ = w.Write([]byte(`{
+			"latitude": 48.86, "longitude": 2.35, "timezone": "Europe/Paris",
+			"current_units": {"temperature_2m": "°C", "wind_speed_10m": "km/h", "precipitation": "mm"},
+			"current": {"time": "2025-06-01T12:00", "temperature_2m": 21.5, "relative_humidity_2m": 55,
+				"apparent_temperature": 20.9, "precipitation": 0, "weather_code": 2,
+				"wind_speed_10m": 12.3, "wind_direction_10m": 250},
+			"daily": {"time": ["2025-06-01", "2025-06-02"], "weather_code": [3, null],
+				"temperature_2m_max": [24.1, 22], "temperature_2m_min": [14.2, null],
+				"precipitation_sum": [0, 1.2], "precipitation_probability_max": [10, 60],
+				"wind_speed_10m_max": [18, 25], "sunrise": ["2025-06-01T05:50", "2025-06-02T05:49"],
+				"sunset": ["2025-06-01T21:45", "2025-06-02T21:46"]}
+		}`))
+	})
+	c := newTestClient(t, mux)
+
+	resp, err := c.Forecast(ctx, &ForecastRequest{Latitude: 48.8566, Longitude: 2.3522})
+	assert.NoError(t, err)
+	assert.Equal(t, "Europe/Paris", resp.Timezone)
+	assert.Equal(t, &WeatherUnits{Temperature: "°C", WindSpeed: "km/h", Precipitation: "mm"}, resp.Units)
+	assert.Equal(t, &CurrentWeather{
+		Time:                "2025-06-01T12:00",
+		Weather:             "partly cloudy",
+		Temperature:         21.5,
+		ApparentTemperature: 20.9,
+		Humidity:            55,
+		WindSpeed:           12.3,
+		WindDirection:       250,
+	}, resp.Current)
+	assert.Len(t, resp.Daily, 2)
+	assert.Equal(t, "overcast", resp.Daily[0].Weather)
+	assert.Equal(t, 24.1, *resp.Daily[0].TemperatureMax)
+	assert.Equal(t, "2025-06-01T21:45", resp.Daily[0].Sunset)
+	assert.Empty(t, resp.Daily[1].Weather)
+	assert.Nil(t, resp.Daily[1].TemperatureMin)
+	assert.Equal(t, 60.0, *resp.Daily[1].PrecipitationProbability)
+
+	_, err = c.Forecast(ctx, &ForecastRequest{Latitude: 48.8566, Longitude: 2.3522, Days: 2, Units: UnitsImperial})
+	assert.NoError(t, err)
+
+	_, err = c.Forecast(ctx, &ForecastRequest{Latitude: 91})
+	assert.EqualError(t, err, "invalid latitude: 91, must be between -90 and 90")
+	_, err = c.Forecast(ctx, &ForecastRequest{Longitude: -181})
+	assert.EqualError(t, err, "invalid longitude: -181, must be between -180 and 180")
+	_, err = c.Forecast(ctx, &ForecastRequest{Days: 17})
+	assert.EqualError(t, err, "invalid days: 17, must be between 1 and 16")
+	_, err = c.Forecast(ctx, &ForecastRequest{Units: "kelvin"})
+	assert.EqualError(t, err, `invalid units: "kelvin", must be metric or imperial`)
+}
+
+func TestForecastAPIError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/forecast", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":true,"reason":"Latitude must be in range of -90 to 90°."}`))
+	})
+	c := newTestClient(t, mux)
+
+	_, err := c.Forecast(context.Background(), &ForecastRequest{})
+	assert.EqualError(t, err, "request failed with status 400: Latitude must be in range of -90 to 90°.")
+}
+
+func TestGeocode(t *testing.T) {
+	ctx := context.Background()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /search", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		assert.Equal(t, "Eiffel Tower", q.Get("q"))
+		assert.Equal(t, "jsonv2", q.Get("format"))
+		assert.Equal(t, "1", q.Get("addressdetails"))
+		assert.Equal(t, "2", q.Get("limit"))
+		assert.Equal(t, "fr,be", q.Get("countrycodes"))
+		_, _ = w.Write([]byte(`[{"lat": "48.8582599", "lon": "2.2945006", "category": "tourism", "type": "attraction",
+			"display_name": "Tour Eiffel, Paris, France",
+			"address": {"tourism": "Tour Eiffel", "city": "Paris", "country": "France", "country_code": "fr"}}]`))
+	})
+	mux.HandleFunc("GET /reverse", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("lat") == "0" {
+			_, _ = w.Write([]byte(`{"error": "Unable to geocode"}`))
+			return
+		}
+		assert.Equal(t, "48.8584", q.Get("lat"))
+		assert.Equal(t, "2.2945", q.Get("lon"))
+		_, _ = w.Write([]byte(`{"lat": "48.8582599", "lon": "2.2945006", "category": "tourism", "type": "attraction",
+			"display_name": "Tour Eiffel, Paris, France", "address": {"city": "Paris"}}`))
+	})
+	c := newTestClient(t, mux)
+	lat, lon, zero := 48.8584, 2.2945, 0.0
+
+	resp, err := c.Geocode(ctx, &GeocodeRequest{Query: "Eiffel Tower", CountryCodes: []string{"FR", "be"}, Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []*Place{{
+		Name:      "Tour Eiffel, Paris, France",
+		Latitude:  48.8582599,
+		Longitude: 2.2945006,
+		Category:  "tourism",
+		Type:      "attraction",
+		Address:   map[string]string{"tourism": "Tour Eiffel", "city": "Paris", "country": "France", "country_code": "fr"},
+	}}, resp.Places)
+
+	start := time.Now()
+	resp, err = c.Geocode(ctx, &GeocodeRequest{Latitude: &lat, Longitude: &lon})
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), nominatimInterval/2)
+	assert.Len(t, resp.Places, 1)
+	assert.Equal(t, map[string]string{"city": "Paris"}, resp.Places[0].Address)
+
+	c.nominatimLast = time.Time{}
+	resp, err = c.Geocode(ctx, &GeocodeRequest{Latitude: &zero, Longitude: &zero})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Places)
+
+	_, err = c.Geocode(ctx, &GeocodeRequest{})
+	assert.EqualError(t, err, "query, or latitude and longitude, are required")
+	_, err = c.Geocode(ctx, &GeocodeRequest{Query: "Paris", Latitude: &lat})
+	assert.EqualError(t, err, "set either query, or latitude and longitude")
+	_, err = c.Geocode(ctx, &GeocodeRequest{Query: "Paris", Limit: 21})
+	assert.EqualError(t, err, "invalid limit: 21, must be between 1 and 20")
+
+	cancelCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	c.nominatimLast = time.Now()
+	_, err = c.Geocode(cancelCtx, &GeocodeRequest{Query: "Paris"})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestConvertTime(t *testing.T) {
+	ctx := context.Background()
+	c, err := newClient(&Config{})
+	assert.NoError(t, err)
+
+	now = func() time.Time { return time.Date(2025, 1, 15, 8, 30, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	resp, err := c.ConvertTime(ctx, &ConvertTimeRequest{
+		Time:         "2025-07-01 09:00",
+		FromTimezone: "America/New_York",
+		ToTimezones:  []string{"Europe/Paris", "Asia/Tokyo"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &ZonedTime{
+		Timezone:     "America/New_York",
+		Time:         "2025-07-01T09:00:00-04:00",
+		Weekday:      "Tuesday",
+		Abbreviation: "EDT",
+		UTCOffset:    "-04:00",
+		IsDST:        true,
+	}, resp.Source)
+	assert.Equal(t, []*ZonedTime{{
+		Timezone:     "Europe/Paris",
+		Time:         "2025-07-01T15:00:00+02:00",
+		Weekday:      "Tuesday",
+		Abbreviation: "CEST",
+		UTCOffset:    "+02:00",
+		IsDST:        true,
+	}, {
+		Timezone:     "Asia/Tokyo",
+		Time:         "2025-07-01T22:00:00+09:00",
+		Weekday:      "Tuesday",
+		Abbreviation: "JST",
+		UTCOffset:    "+09:00",
+	}}, resp.Results)
+
+	// the current time in UTC by default
+	resp, err = c.ConvertTime(ctx, &ConvertTimeRequest{ToTimezones: []string{"Asia/Kolkata"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "UTC", resp.Source.Timezone)
+	assert.Equal(t, "2025-01-15T08:30:00Z", resp.Source.Time)
+	assert.Equal(t, "2025-01-15T14:00:00+05:30", resp.Results[0].Time)
+
+	// a clock time is today in the source time zone
+	resp, err = c.ConvertTime(ctx, &ConvertTimeRequest{
+		Time:         "23:15",
+		FromTimezone: "America/Los_Angeles",
+		ToTimezones:  []string{"UTC"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-01-15T23:15:00-08:00", resp.Source.Time)
+	assert.Equal(t, "2025-01-16T07:15:00Z", resp.Results[0].Time)
+	assert.Equal(t, "Thursday", resp.Results[0].Weekday)
+
+	// the UTC offset of the time takes precedence over the source time zone
+	resp, err = c.ConvertTime(ctx, &ConvertTimeRequest{
+		Time:         "2025-01-15T10:00:00+08:00",
+		FromTimezone: "Europe/London",
+		ToTimezones:  []string{"Europe/London"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-01-15T10:00:00+08:00", resp.Source.Time)
+	assert.Equal(t, "2025-01-15T02:00:00Z", resp.Results[0].Time)
+	assert.Equal(t, "GMT", resp.Results[0].Abbreviation)
+
+	_, err = c.ConvertTime(ctx, &ConvertTimeRequest{})
+	assert.EqualError(t, err, "to_timezones is required")
+	_, err = c.ConvertTime(ctx, &ConvertTimeRequest{FromTimezone: "Mars/Olympus", ToTimezones: []string{"UTC"}})
+	assert.EqualError(t, err, `unknown time zone: "Mars/Olympus", expected an IANA name like Europe/Paris`)
+	_, err = c.ConvertTime(ctx, &ConvertTimeRequest{ToTimezones: []string{"UTC", "PST8"}})
+	assert.Error(t, err)
+	_, err = c.ConvertTime(ctx, &ConvertTimeRequest{Time: "tomorrow", ToTimezones: []string{"UTC"}})
+	assert.EqualError(t, err, `invalid time: "tomorrow", expected e.g. 2006-01-02 15:04, 15:04 or 2006-01-02T15:04:05+08:00`)
+}
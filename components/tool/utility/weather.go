@@ -0,0 +1,253 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package utility
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+const (
+	defaultForecastDays = 3
+	maxForecastDays     = 16
+)
+
+const (
+	UnitsMetric   = "metric"
+	UnitsImperial = "imperial"
+)
+
+// ForecastRequest is the request of the weather tool.
+type ForecastRequest struct {
+	Latitude  float64 `json:"latitude" jsonschema:"required,description=The latitude of the location in degrees, e.g. 48.8566"`
+	Longitude float64 `json:"longitude" jsonschema:"required,description=The longitude of the location in degrees, e.g. 2.3522"`
+	Days      int     `json:"days,omitempty" jsonschema:"description=The number of days of the daily forecast between 1 and 16. Default 3"`
+	Units     string  `json:"units,omitempty" jsonschema:"description=The units of the values. Default metric,enum=metric,enum=imperial"`
+}
+
+// ForecastResponse is the current weather and the daily forecast of a location.
+type ForecastResponse struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	// Timezone is the time zone of the location, the times are local times.
+	Timezone string           `json:"timezone"`
+	Units    *WeatherUnits    `json:"units"`
+	Current  *CurrentWeather  `json:"current"`
+	Daily    []*DailyForecast `json:"daily"`
+}
+
+// WeatherUnits are the units of the values of the forecast.
+type WeatherUnits struct {
+	Temperature   string `json:"temperature"`
+	WindSpeed     string `json:"wind_speed"`
+	Precipitation string `json:"precipitation"`
+}
+
+// CurrentWeather is the weather at the time of the request.
+type CurrentWeather struct {
+	Time                string  `json:"time"`
+	Weather             string  `json:"weather"`
+	Temperature         float64 `json:"temperature"`
+	ApparentTemperature float64 `json:"apparent_temperature"`
+	// Humidity is the relative humidity in percent.
+	Humidity      float64 `json:"humidity"`
+	Precipitation float64 `json:"precipitation"`
+	WindSpeed     float64 `json:"wind_speed"`
+	// WindDirection is the direction the wind comes from, in degrees.
+	WindDirection float64 `json:"wind_direction"`
+}
+
+// DailyForecast is the forecast of a day.
+type DailyForecast struct {
+	Date           string   `json:"date"`
+	Weather        string   `json:"weather"`
+	TemperatureMax *float64 `json:"temperature_max,omitempty"`
+	TemperatureMin *float64 `json:"temperature_min,omitempty"`
+	Precipitation  *float64 `json:"precipitation,omitempty"`
+	// PrecipitationProbability is the maximum probability of precipitation of the day, in percent.
+	PrecipitationProbability *float64 `json:"precipitation_probability,omitempty"`
+	WindSpeedMax             *float64 `json:"wind_speed_max,omitempty"`
+	Sunrise                  string   `json:"sunrise,omitempty"`
+	Sunset                   string   `json:"sunset,omitempty"`
+}
+
+// Forecast gets the current weather and the daily forecast from the Open-Meteo forecast API.
+func (c *client) Forecast(ctx context.Context, req *ForecastRequest) (*ForecastResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request is nil")
+	}
+	if req.Latitude < -90 || req.Latitude > 90 {
+		return nil, fmt.Errorf("invalid latitude: %v, must be between -90 and 90", req.Latitude)
+	}
+	if req.Longitude < -180 || req.Longitude > 180 {
+		return nil, fmt.Errorf("invalid longitude: %v, must be between -180 and 180", req.Longitude)
+	}
+	days := req.Days
+	if days <= 0 {
+		days = defaultForecastDays
+	}
+	if days > maxForecastDays {
+		return nil, fmt.Errorf("invalid days: %d, must be between 1 and %d", days, maxForecastDays)
+	}
+
+	params := url.Values{
+		"latitude":      {strconv.FormatFloat(req.Latitude, 'f', -1, 64)},
+		"longitude":     {strconv.FormatFloat(req.Longitude, 'f', -1, 64)},
+		"forecast_days": {strconv.Itoa(days)},
+		"timezone":      {"auto"},
+		"current": {"temperature_2m,relative_humidity_2m,apparent_temperature,precipitation,weather_code," +
+			"wind_speed_10m,wind_direction_10m"},
+		"daily": {"weather_code,temperature_2m_max,temperature_2m_min,precipitation_sum," +
+			"precipitation_probability_max,wind_speed_10m_max,sunrise,sunset"},
+	}
+	switch req.Units {
+	case "", UnitsMetric:
+	case UnitsImperial:
+		params.Set("temperature_unit", "fahrenheit")
+		params.Set("wind_speed_unit", "mph")
+		params.Set("precipitation_unit", "inch")
+	default:
+		return nil, fmt.Errorf("invalid units: %q, must be metric or imperial", req.Units)
+	}
+
+	var resp struct {
+		Latitude     float64 `json:"latitude"`
+		Longitude    float64 `json:"longitude"`
+		Timezone     string  `json:"timezone"`
+		CurrentUnits struct {
+			Temperature   string `json:"temperature_2m"`
+			WindSpeed     string `json:"wind_speed_10m"`
+			Precipitation string `json:"precipitation"`
+		} `json:"current_units"`
+		Current struct {
+			Time                string  `json:"time"`
+			Temperature         float64 `json:"temperature_2m"`
+			Humidity            float64 `json:"relative_humidity_2m"`
+			ApparentTemperature float64 `json:"apparent_temperature"`
+			Precipitation       float64 `json:"precipitation"`
+			WeatherCode         int     `json:"weather_code"`
+			WindSpeed           float64 `json:"wind_speed_10m"`
+			WindDirection       float64 `json:"wind_direction_10m"`
+		} `json:"current"`
+		Daily struct {
+			Time                     []string   `json:"time"`
+			WeatherCode              []*int     `json:"weather_code"`
+			TemperatureMax           []*float64 `json:"temperature_2m_max"`
+			TemperatureMin           []*float64 `json:"temperature_2m_min"`
+			Precipitation            []*float64 `json:"precipitation_sum"`
+			PrecipitationProbability []*float64 `json:"precipitation_probability_max"`
+			WindSpeedMax             []*float64 `json:"wind_speed_10m_max"`
+			Sunrise                  []string   `json:"sunrise"`
+			Sunset                   []string   `json:"sunset"`
+		} `json:"daily"`
+	}
+	if err := c.getJSON(ctx, c.conf.OpenMeteoBaseURL+"/forecast", params, &resp); err != nil {
+		return nil, err
+	}
+
+	result := &ForecastResponse{
+		Latitude:  resp.Latitude,
+		Longitude: resp.Longitude,
+		Timezone:  resp.Timezone,
+		Units: &WeatherUnits{
+			Temperature:   resp.CurrentUnits.Temperature,
+			WindSpeed:     resp.CurrentUnits.WindSpeed,
+			Precipitation: resp.CurrentUnits.Precipitation,
+		},
+		Current: &CurrentWeather{
+			Time:                resp.Current.Time,
+			Weather:             weatherDescription(resp.Current.WeatherCode),
+			Temperature:         resp.Current.Temperature,
+			ApparentTemperature: resp.Current.ApparentTemperature,
+			Humidity:            resp.Current.Humidity,
+			Precipitation:       resp.Current.Precipitation,
+			WindSpeed:           resp.Current.WindSpeed,
+			WindDirection:       resp.Current.WindDirection,
+		},
+		Daily: make([]*DailyForecast, 0, len(resp.Daily.Time)),
+	}
+	d := resp.Daily
+	for i, date := range d.Time {
+		day := &DailyForecast{
+			Date:                     date,
+			TemperatureMax:           at(d.TemperatureMax, i),
+			TemperatureMin:           at(d.TemperatureMin, i),
+			Precipitation:            at(d.Precipitation, i),
+			PrecipitationProbability: at(d.PrecipitationProbability, i),
+			WindSpeedMax:             at(d.WindSpeedMax, i),
+		}
+		if code := at(d.WeatherCode, i); code != nil {
+			day.Weather = weatherDescription(*code)
+		}
+		if i < len(d.Sunrise) {
+			day.Sunrise = d.Sunrise[i]
+		}
+		if i < len(d.Sunset) {
+			day.Sunset = d.Sunset[i]
+		}
+		result.Daily = append(result.Daily, day)
+	}
+	return result, nil
+}
+
+// at returns the i-th value of the daily variable, nil when it's missing.
+func at[T any](values []*T, i int) *T {
+	if i < len(values) {
+		return values[i]
+	}
+	return nil
+}
+
+// weatherCodes are the descriptions of the WMO weather interpretation codes used by Open-Meteo.
+var weatherCodes = map[int]string{
+	0:  "clear sky",
+	1:  "mainly clear",
+	2:  "partly cloudy",
+	3:  "overcast",
+	45: "fog",
+	48: "depositing rime fog",
+	51: "light drizzle",
+	53: "moderate drizzle",
+	55: "dense drizzle",
+	56: "light freezing drizzle",
+	57: "dense freezing drizzle",
+	61: "slight rain",
+	63: "moderate rain",
+	65: "heavy rain",
+	66: "light freezing rain",
+	67: "heavy freezing rain",
+	71: "slight snow fall",
+	73: "moderate snow fall",
+	75: "heavy snow fall",
+	77: "snow grains",
+	80: "slight rain showers",
+	81: "moderate rain showers",
+	82: "violent rain showers",
+	85: "slight snow showers",
+	86: "heavy snow showers",
+	95: "thunderstorm",
+	96: "thunderstorm with slight hail",
+	99: "thunderstorm with heavy hail",
+}
+
+func weatherDescription(code int) string {
+	if desc, ok := weatherCodes[code]; ok {
+		return desc
+	}
+	return fmt.Sprintf("weather code %d", code)
+}
@@ -0,0 +1,495 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxDepth bounds the recursion of the references.
+const maxDepth = 64
+
+// checker checks the value against the JSON schema, coercing it to the types of the schema when enabled.
+// The keywords of the schemas of the tool parameters are supported, the others being ignored.
+type checker struct {
+	root    any
+	coerce  bool
+	changed bool
+	errs    []*FieldError
+}
+
+func (c *checker) fail(path, format string, args ...any) {
+	c.errs = append(c.errs, &FieldError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// check checks the value against the schema, returning the value coerced when needed.
+func (c *checker) check(path string, v any, s any, depth int) any {
+	if depth > maxDepth {
+		return v
+	}
+	switch sc := s.(type) {
+	case bool:
+		if !sc {
+			c.fail(path, "is not allowed")
+		}
+		return v
+	case map[string]any:
+		return c.checkSchema(path, v, sc, depth)
+	default:
+		return v
+	}
+}
+
+func (c *checker) checkSchema(path string, v any, s map[string]any, depth int) any {
+	if ref, ok := s["$ref"].(string); ok {
+		target, err := c.resolve(ref)
+		if err != nil {
+			c.fail(path, "invalid schema: %v", err)
+			return v
+		}
+		v = c.check(path, v, target, depth+1)
+	}
+
+	if types := schemaTypes(s); len(types) > 0 {
+		var ok bool
+		if v, ok = c.checkType(path, v, types); !ok {
+			return v
+		}
+	}
+
+	if enum, ok := s["enum"].([]any); ok {
+		v = c.checkEnum(path, v, enum)
+	}
+	if constant, ok := s["const"]; ok && !equal(v, constant) {
+		c.fail(path, "must be %s", encode(constant))
+	}
+
+	switch val := v.(type) {
+	case json.Number:
+		c.checkNumber(path, val, s)
+	case string:
+		c.checkString(path, val, s)
+	case []any:
+		v = c.checkArray(path, val, s, depth)
+	case map[string]any:
+		v = c.checkObject(path, val, s, depth)
+	}
+
+	if all, ok := s["allOf"].([]any); ok {
+		for _, sub := range all {
+			v = c.check(path, v, sub, depth+1)
+		}
+	}
+	for _, key := range []string{"anyOf", "oneOf"} {
+		if subs, ok := s[key].([]any); ok {
+			v = c.checkAnyOf(path, v, subs, depth)
+		}
+	}
+	return v
+}
+
+// checkAnyOf checks the value matches one of the schemas, coerced as the first schema it matches.
+func (c *checker) checkAnyOf(path string, v any, subs []any, depth int) any {
+	for _, coerce := range []bool{false, c.coerce} {
+		for _, sub := range subs {
+			sc := &checker{root: c.root, coerce: coerce}
+			// the coercion of a schema not matched must not leak to the next one
+			coerced := sc.check(path, clone(v), sub, depth+1)
+			if len(sc.errs) == 0 {
+				c.changed = c.changed || sc.changed
+				return coerced
+			}
+		}
+		if !c.coerce {
+			break
+		}
+	}
+	c.fail(path, "does not match any of the allowed schemas")
+	return v
+}
+
+func (c *checker) checkType(path string, v any, types []string) (any, bool) {
+	for _, t := range types {
+		if isType(v, t) {
+			return v, true
+		}
+	}
+	if c.coerce {
+		for _, t := range types {
+			if coerced, ok := coerceType(v, t); ok {
+				c.changed = true
+				return coerced, true
+			}
+		}
+	}
+	c.fail(path, "expected %s, got %s", strings.Join(types, " or "), typeOf(v))
+	return v, false
+}
+
+func (c *checker) checkEnum(path string, v any, enum []any) any {
+	for _, e := range enum {
+		if equal(v, e) {
+			return v
+		}
+	}
+	if c.coerce {
+		// the case of the enums is often mistaken
+		if str, ok := v.(string); ok {
+			for _, e := range enum {
+				if es, ok := e.(string); ok && strings.EqualFold(es, str) {
+					c.changed = true
+					return es
+				}
+			}
+		}
+	}
+	c.fail(path, "must be one of %s", encode(enum))
+	return v
+}
+
+func (c *checker) checkNumber(path string, n json.Number, s map[string]any) {
+	f, err := n.Float64()
+	if err != nil {
+		return
+	}
+	if m, ok := number(s["minimum"]); ok && f < m {
+		c.fail(path, "must be >= %v", s["minimum"])
+	}
+	if m, ok := number(s["maximum"]); ok && f > m {
+		c.fail(path, "must be <= %v", s["maximum"])
+	}
+	if m, ok := number(s["exclusiveMinimum"]); ok && f <= m {
+		c.fail(path, "must be > %v", s["exclusiveMinimum"])
+	}
+	if m, ok := number(s["exclusiveMaximum"]); ok && f >= m {
+		c.fail(path, "must be < %v", s["exclusiveMaximum"])
+	}
+	if m, ok := number(s["multipleOf"]); ok && m > 0 {
+		if q := f / m; math.Abs(q-math.Round(q)) > 1e-9 {
+			c.fail(path, "must be a multiple of %v", s["multipleOf"])
+		}
+	}
+}
+
+func (c *checker) checkString(path string, str string, s map[string]any) {
+	length := utf8.RuneCountInString(str)
+	if m, ok := number(s["minLength"]); ok && float64(length) < m {
+		c.fail(path, "length must be >= %v", s["minLength"])
+	}
+	if m, ok := number(s["maxLength"]); ok && float64(length) > m {
+		c.fail(path, "length must be <= %v", s["maxLength"])
+	}
+	if pattern, ok := s["pattern"].(string); ok {
+		re, err := regexp.Compile(pattern)
+		if err == nil && !re.MatchString(str) {
+			c.fail(path, "must match pattern %s", pattern)
+		}
+	}
+}
+
+func (c *checker) checkArray(path string, arr []any, s map[string]any, depth int) any {
+	if m, ok := number(s["minItems"]); ok && float64(len(arr)) < m {
+		c.fail(path, "must have at least %v items", s["minItems"])
+	}
+	if m, ok := number(s["maxItems"]); ok && float64(len(arr)) > m {
+		c.fail(path, "must have at most %v items", s["maxItems"])
+	}
+	if unique, _ := s["uniqueItems"].(bool); unique {
+		for i := range arr {
+			for j := 0; j < i; j++ {
+				if equal(arr[i], arr[j]) {
+					c.fail(fmt.Sprintf("%s[%d]", path, i), "duplicates item %d", j)
+				}
+			}
+		}
+	}
+
+	prefix, _ := s["prefixItems"].([]any)
+	items, hasItems := s["items"]
+	for i, item := range arr {
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i < len(prefix):
+			arr[i] = c.check(itemPath, item, prefix[i], depth+1)
+		case hasItems:
+			arr[i] = c.check(itemPath, item, items, depth+1)
+		}
+	}
+	return arr
+}
+
+func (c *checker) checkObject(path string, obj map[string]any, s map[string]any, depth int) any {
+	props, _ := s["properties"].(map[string]any)
+
+	if required, ok := s["required"].([]any); ok {
+		for _, r := range required {
+			if name, ok := r.(string); ok {
+				if _, exists := obj[name]; !exists {
+					c.fail(path+"."+name, "is required")
+				}
+			}
+		}
+	}
+	if m, ok := number(s["minProperties"]); ok && float64(len(obj)) < m {
+		c.fail(path, "must have at least %v properties", s["minProperties"])
+	}
+	if m, ok := number(s["maxProperties"]); ok && float64(len(obj)) > m {
+		c.fail(path, "must have at most %v properties", s["maxProperties"])
+	}
+
+	additional, hasAdditional := s["additionalProperties"]
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		propPath := path + "." + name
+		if ps, ok := props[name]; ok {
+			obj[name] = c.check(propPath, obj[name], ps, depth+1)
+			continue
+		}
+		if !hasAdditional {
+			continue
+		}
+		if allowed, ok := additional.(bool); ok && !allowed {
+			c.fail(propPath, "is not allowed, the allowed properties are: %s", strings.Join(sortedKeys(props), ", "))
+			continue
+		}
+		obj[name] = c.check(propPath, obj[name], additional, depth+1)
+	}
+	return obj
+}
+
+// resolve resolves the local references, e.g. "#/$defs/address".
+func (c *checker) resolve(ref string) (any, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("unsupported reference %s", ref)
+	}
+	var cur any = c.root
+	for _, token := range strings.Split(strings.TrimPrefix(ref, "#"), "/") {
+		if token == "" {
+			continue
+		}
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unresolved reference %s", ref)
+		}
+		if cur, ok = m[token]; !ok {
+			return nil, fmt.Errorf("unresolved reference %s", ref)
+		}
+	}
+	return cur, nil
+}
+
+func schemaTypes(s map[string]any) []string {
+	switch t := s["type"].(type) {
+	case string:
+		return []string{t}
+	case []any:
+		types := make([]string, 0, len(t))
+		for _, e := range t {
+			if str, ok := e.(string); ok {
+				types = append(types, str)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+func isType(v any, t string) bool {
+	switch t {
+	case "object":
+		_, ok := v.(map[string]any)
+		return ok
+	case "array":
+		_, ok := v.([]any)
+		return ok
+	case "string":
+		_, ok := v.(string)
+		return ok
+	case "boolean":
+		_, ok := v.(bool)
+		return ok
+	case "null":
+		return v == nil
+	case "number":
+		_, ok := v.(json.Number)
+		return ok
+	case "integer":
+		n, ok := v.(json.Number)
+		if !ok {
+			return false
+		}
+		if _, err := n.Int64(); err == nil {
+			return true
+		}
+		f, err := n.Float64()
+		return err == nil && f == math.Trunc(f)
+	default:
+		return true
+	}
+}
+
+// coerceType converts the value to the type, as the models often quote the numbers and the booleans,
+// or encode the objects and the arrays as strings.
+func coerceType(v any, t string) (any, bool) {
+	switch t {
+	case "number", "integer":
+		switch val := v.(type) {
+		case string:
+			n := json.Number(strings.TrimSpace(val))
+			if _, err := strconv.ParseFloat(string(n), 64); err != nil {
+				return nil, false
+			}
+			if t == "integer" && !isType(n, "integer") {
+				return nil, false
+			}
+			return n, true
+		case bool:
+			if val {
+				return json.Number("1"), true
+			}
+			return json.Number("0"), true
+		}
+	case "boolean":
+		if str, ok := v.(string); ok {
+			switch strings.ToLower(strings.TrimSpace(str)) {
+			case "true":
+				return true, true
+			case "false":
+				return false, true
+			}
+		}
+	case "string":
+		switch val := v.(type) {
+		case json.Number:
+			return val.String(), true
+		case bool:
+			return strconv.FormatBool(val), true
+		}
+	case "array":
+		if str, ok := v.(string); ok {
+			if arr, err := decode(str); err == nil {
+				if a, ok := arr.([]any); ok {
+					return a, true
+				}
+			}
+		}
+		if v != nil {
+			// a single item for an array of items
+			return []any{v}, true
+		}
+	case "object":
+		if str, ok := v.(string); ok {
+			if obj, err := decode(str); err == nil {
+				if o, ok := obj.(map[string]any); ok {
+					return o, true
+				}
+			}
+		}
+	case "null":
+		if str, ok := v.(string); ok && (str == "" || str == "null") {
+			return nil, true
+		}
+	}
+	return nil, false
+}
+
+func typeOf(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+func number(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// equal compares the JSON values, the numbers by value.
+func equal(a, b any) bool {
+	if fa, ok := number(a); ok {
+		fb, ok := number(b)
+		return ok && fa == fb
+	}
+	return encode(a) == encode(b)
+}
+
+func encode(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+func clone(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(val))
+		for k, e := range val {
+			m[k] = clone(e)
+		}
+		return m
+	case []any:
+		a := make([]any, len(val))
+		for i, e := range val {
+			a[i] = clone(e)
+		}
+		return a
+	default:
+		return v
+	}
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/cloudwego/eino/components/tool/utils"
+
+	"github.com/cloudwego/eino-ext/components/tool/validation"
+)
+
+type weatherRequest struct {
+	City string `json:"city" jsonschema:"description=the city"`
+	Days int    `json:"days,omitempty" jsonschema:"minimum=1,maximum=7"`
+	Unit string `json:"unit,omitempty" jsonschema:"enum=celsius,enum=fahrenheit"`
+}
+
+func main() {
+	ctx := context.Background()
+
+	weather, err := utils.InferTool("weather", "get the weather forecast of a city", func(ctx context.Context, req *weatherRequest) (string, error) {
+		return fmt.Sprintf("%d days in %s: sunny, 25 %s", req.Days, req.City, req.Unit), nil
+	})
+	if err != nil {
+		log.Fatalf("InferTool failed, err=%v", err)
+	}
+
+	wrapped, err := validation.Wrap(ctx, weather, &validation.Config{})
+	if err != nil {
+		log.Fatalf("Wrap failed, err=%v", err)
+	}
+
+	// repaired and coerced: unquoted keys, trailing comma, quoted integer, enum case
+	result, err := wrapped.InvokableRun(ctx, `{city: "Paris", days: "3", unit: "Celsius",}`)
+	if err != nil {
+		log.Fatalf("InvokableRun failed, err=%v", err)
+	}
+	fmt.Println(result)
+
+	// the validation errors are returned to the model to correct the arguments
+	result, err = wrapped.InvokableRun(ctx, `{"days": 10, "unit": "kelvin"}`)
+	if err != nil {
+		log.Fatalf("InvokableRun failed, err=%v", err)
+	}
+	fmt.Println(result)
+}
@@ -0,0 +1,196 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package validation
+
+import (
+	"strings"
+	"unicode"
+)
+
+// repairJSON repairs the common mistakes of the JSON produced by the models: the code fences and the text
+// around the JSON, the comments, the single quoted strings, the unquoted keys, the literals of Python and
+// JavaScript, the trailing commas, and the unclosed strings and brackets of truncated output.
+func repairJSON(s string) string {
+	s = strings.TrimSpace(stripCodeFence(strings.TrimSpace(s)))
+	if s == "" {
+		return "{}"
+	}
+	if s[0] != '{' && s[0] != '[' {
+		// text before the JSON
+		if i := strings.IndexAny(s, "{["); i >= 0 {
+			s = s[i:]
+		}
+	}
+
+	var (
+		out   strings.Builder
+		stack []byte
+		rs    = []rune(s)
+	)
+	out.Grow(len(s) + 8)
+
+	for i := 0; i < len(rs); i++ {
+		r := rs[i]
+		switch {
+		case r == '"' || r == '\'':
+			i = copyString(&out, rs, i)
+		case r == '/' && i+1 < len(rs) && rs[i+1] == '/':
+			for i < len(rs) && rs[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(rs) && rs[i+1] == '*':
+			for i += 2; i+1 < len(rs) && (rs[i] != '*' || rs[i+1] != '/'); i++ {
+			}
+			i++
+		case r == '{' || r == '[':
+			stack = append(stack, closer(r))
+			out.WriteRune(r)
+		case r == '}' || r == ']':
+			if len(stack) == 0 {
+				// text after the JSON
+				return finish(out.String(), stack)
+			}
+			trimTrailingComma(&out)
+			out.WriteByte(stack[len(stack)-1])
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				return out.String()
+			}
+		case isIdentStart(r):
+			j := i
+			for j < len(rs) && isIdentPart(rs[j]) {
+				j++
+			}
+			word := string(rs[i:j])
+			k := j
+			for k < len(rs) && unicode.IsSpace(rs[k]) {
+				k++
+			}
+			if k < len(rs) && rs[k] == ':' {
+				out.WriteString(quote(word))
+			} else {
+				out.WriteString(literal(word))
+			}
+			i = j - 1
+		default:
+			out.WriteRune(r)
+		}
+	}
+	return finish(out.String(), stack)
+}
+
+// copyString copies the string starting at rs[i] as a double quoted string, and returns the index of its end.
+func copyString(out *strings.Builder, rs []rune, i int) int {
+	q := rs[i]
+	out.WriteByte('"')
+	for i++; i < len(rs); i++ {
+		r := rs[i]
+		switch {
+		case r == '\\' && i+1 < len(rs):
+			if rs[i+1] == '\'' {
+				// \' is not a valid escape in JSON
+				out.WriteRune('\'')
+			} else {
+				out.WriteRune(r)
+				out.WriteRune(rs[i+1])
+			}
+			i++
+		case r == q:
+			out.WriteByte('"')
+			return i
+		case r == '"':
+			out.WriteString(`\"`)
+		case r == '\n':
+			out.WriteString(`\n`)
+		case r == '\r':
+			out.WriteString(`\r`)
+		case r == '\t':
+			out.WriteString(`\t`)
+		default:
+			out.WriteRune(r)
+		}
+	}
+	// unclosed string of truncated output
+	out.WriteByte('"')
+	return i
+}
+
+func finish(s string, stack []byte) string {
+	var out strings.Builder
+	out.WriteString(s)
+	for i := len(stack) - 1; i >= 0; i-- {
+		trimTrailingComma(&out)
+		out.WriteByte(stack[i])
+	}
+	return out.String()
+}
+
+func trimTrailingComma(out *strings.Builder) {
+	s := strings.TrimRightFunc(out.String(), unicode.IsSpace)
+	if strings.HasSuffix(s, ",") {
+		s = s[:len(s)-1]
+		out.Reset()
+		out.WriteString(s)
+	}
+}
+
+func closer(r rune) byte {
+	if r == '{' {
+		return '}'
+	}
+	return ']'
+}
+
+func quote(word string) string {
+	return `"` + word + `"`
+}
+
+func literal(word string) string {
+	switch word {
+	case "True":
+		return "true"
+	case "False":
+		return "false"
+	case "None", "nil", "undefined":
+		return "null"
+	default:
+		return word
+	}
+}
+
+func isIdentStart(r rune) bool {
+	return r == '_' || r == '$' || unicode.IsLetter(r)
+}
+
+func isIdentPart(r rune) bool {
+	return isIdentStart(r) || unicode.IsDigit(r) || r == '-'
+}
+
+func stripCodeFence(s string) string {
+	if !strings.HasPrefix(s, "```") {
+		return s
+	}
+	// the language of the fence, e.g. ```json
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[i+1:]
+	} else {
+		s = strings.TrimPrefix(s, "```")
+	}
+	if i := strings.LastIndex(s, "```"); i >= 0 {
+		s = s[:i]
+	}
+	return s
+}
@@ -0,0 +1,46 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepairJSON(t *testing.T) {
+	cases := []struct {
+		name, input, expected string
+	}{
+		{"empty", "  ", `{}`},
+		{"trailing commas", `{"a": [1, 2,], "b": 1,}`, `{"a": [1, 2], "b": 1}`},
+		{"unquoted keys", `{city: "Paris", max_results: 3}`, `{"city": "Paris", "max_results": 3}`},
+		{"single quotes", `{'q': 'it\'s "fine"'}`, `{"q": "it's \"fine\""}`},
+		{"python literals", `{"a": True, "b": False, "c": None}`, `{"a": true, "b": false, "c": null}`},
+		{"code fence", "```json\n{\"a\": 1}\n```", `{"a": 1}`},
+		{"surrounding text", `Arguments: {"a": 1} hope it helps`, `{"a": 1}`},
+		{"comments", "{\"a\": 1, // the a\n /* b */ \"b\": 2}", `{"a": 1,   "b": 2}`},
+		{"truncated", `{"a": {"b": [1, 2`, `{"a": {"b": [1, 2]}}`},
+		{"unclosed string", `{"a": "hel`, `{"a": "hel"}`},
+		{"newline in string", "{\"a\": \"x\ny\"}", `{"a": "x\ny"}`},
+		{"literal in string", `{"a": "True"}`, `{"a": "True"}`},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.expected, repairJSON(c.input))
+		})
+	}
+}
@@ -0,0 +1,191 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package validation wraps invokable tools to validate the arguments produced by the model
+// against the schema of the tool, repairing the common mistakes before running the tool.
+package validation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+)
+
+// Config is the configuration of the validation of the arguments.
+type Config struct {
+	// DisableRepair disables repairing the malformed JSON, e.g. the trailing commas, the unquoted keys,
+	// the single quoted strings, the comments, the code fences, or the unclosed brackets of truncated output.
+	// Optional. Default false.
+	DisableRepair bool
+	// DisableCoercion disables converting the arguments to the types of the schema, e.g. "42" to 42 for
+	// an integer, "true" to true for a boolean, a JSON encoded object to the object, or "Red" to "red" for an enum.
+	// Optional. Default false.
+	DisableCoercion bool
+	// ReturnError returns the *ValidationError as the error of InvokableRun, instead of as the tool result.
+	// Notice: the error of a tool aborts the agent loop, while the result lets the model correct the arguments.
+	// Optional. Default false.
+	ReturnError bool
+}
+
+// FieldError is an error of an argument.
+type FieldError struct {
+	// Path is the path of the argument, e.g. "$.items[0].name", "$" being the arguments.
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// ValidationError is the error of the arguments which can not be repaired.
+type ValidationError struct {
+	Tool   string        `json:"tool"`
+	Errors []*FieldError `json:"errors"`
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, fe := range e.Errors {
+		msgs = append(msgs, fe.Path+": "+fe.Message)
+	}
+	return fmt.Sprintf("invalid arguments of tool %s: %s", e.Tool, strings.Join(msgs, "; "))
+}
+
+// result is the tool result telling the model what to correct.
+func (e *ValidationError) result() string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	// the messages quote the expected values, e.g. "must be <= 7"
+	enc.SetEscapeHTML(false)
+	err := enc.Encode(struct {
+		Error  string        `json:"error"`
+		Errors []*FieldError `json:"errors"`
+	}{
+		Error:  fmt.Sprintf("invalid arguments of tool %s, correct them and call the tool again", e.Tool),
+		Errors: e.Errors,
+	})
+	if err != nil {
+		return e.Error()
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// Wrap wraps the tool to validate its arguments against the parameters of its info before running it,
+// the repaired and coerced arguments being passed to the tool instead of the original ones.
+// The arguments violating the schema are not passed to the tool, the model being asked to correct them.
+func Wrap(ctx context.Context, t tool.InvokableTool, conf *Config) (tool.InvokableTool, error) {
+	if t == nil {
+		return nil, errors.New("tool is nil")
+	}
+	if conf == nil {
+		conf = &Config{}
+	}
+
+	info, err := t.Info(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tool info: %w", err)
+	}
+
+	var root any
+	if info.ParamsOneOf != nil {
+		js, err := info.ParamsOneOf.ToJSONSchema()
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert parameters of tool %s to json schema: %w", info.Name, err)
+		}
+		// the schema is checked as plain JSON, to support the boolean schemas and the references alike
+		b, err := json.Marshal(js)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal json schema of tool %s: %w", info.Name, err)
+		}
+		if err = json.Unmarshal(b, &root); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal json schema of tool %s: %w", info.Name, err)
+		}
+	}
+
+	return &validatedTool{tool: t, info: info, schema: root, conf: conf}, nil
+}
+
+type validatedTool struct {
+	tool   tool.InvokableTool
+	info   *schema.ToolInfo
+	schema any
+	conf   *Config
+}
+
+func (v *validatedTool) Info(ctx context.Context) (*schema.ToolInfo, error) {
+	return v.tool.Info(ctx)
+}
+
+func (v *validatedTool) InvokableRun(ctx context.Context, argumentsInJSON string, opts ...tool.Option) (string, error) {
+	args, vErr := v.prepare(argumentsInJSON)
+	if vErr != nil {
+		if v.conf.ReturnError {
+			return "", vErr
+		}
+		return vErr.result(), nil
+	}
+	return v.tool.InvokableRun(ctx, args, opts...)
+}
+
+// prepare returns the arguments to pass to the tool, repaired and coerced when needed.
+func (v *validatedTool) prepare(argumentsInJSON string) (string, *ValidationError) {
+	value, err := decode(argumentsInJSON)
+	changed := false
+	if err != nil && !v.conf.DisableRepair {
+		if repaired := repairJSON(argumentsInJSON); repaired != argumentsInJSON {
+			if value, err = decode(repaired); err == nil {
+				changed = true
+			}
+		}
+	}
+	if err != nil {
+		return "", &ValidationError{Tool: v.info.Name, Errors: []*FieldError{{Path: "$", Message: "invalid JSON: " + err.Error()}}}
+	}
+
+	if v.schema != nil {
+		c := &checker{root: v.schema, coerce: !v.conf.DisableCoercion}
+		value = c.check("$", value, v.schema, 0)
+		if len(c.errs) > 0 {
+			return "", &ValidationError{Tool: v.info.Name, Errors: c.errs}
+		}
+		changed = changed || c.changed
+	}
+
+	if !changed {
+		return argumentsInJSON, nil
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "", &ValidationError{Tool: v.info.Name, Errors: []*FieldError{{Path: "$", Message: "invalid value: " + err.Error()}}}
+	}
+	return string(b), nil
+}
+
+// decode decodes the arguments keeping the numbers as they are.
+func decode(s string) (any, error) {
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if rest := strings.TrimSpace(s[dec.InputOffset():]); rest != "" {
+		return nil, fmt.Errorf("unexpected content after the JSON value: %s", rest)
+	}
+	return v, nil
+}
@@ -0,0 +1,168 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package validation
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+	"github.com/cloudwego/eino/schema"
+	"github.com/eino-contrib/jsonschema"
+	"github.com/stretchr/testify/assert"
+)
+
+type weatherRequest struct {
+	City  string   `json:"city" jsonschema:"description=the city"`
+	Days  int      `json:"days,omitempty" jsonschema:"minimum=1,maximum=7"`
+	Unit  string   `json:"unit,omitempty" jsonschema:"enum=celsius,enum=fahrenheit"`
+	Alert bool     `json:"alert,omitempty"`
+	Tags  []string `json:"tags,omitempty"`
+}
+
+func newWeatherTool(t *testing.T, got *string) tool.InvokableTool {
+	wt, err := utils.InferTool("weather", "get the weather", func(ctx context.Context, req *weatherRequest) (string, error) {
+		b, _ := json.Marshal(req)
+		*got = string(b)
+		return "sunny", nil
+	})
+	assert.NoError(t, err)
+	return wt
+}
+
+func TestWrap(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := Wrap(ctx, nil, nil)
+	assert.EqualError(t, err, "tool is nil")
+
+	var got string
+	wrapped, err := Wrap(ctx, newWeatherTool(t, &got), nil)
+	assert.NoError(t, err)
+
+	info, err := wrapped.Info(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "weather", info.Name)
+
+	t.Run("valid", func(t *testing.T) {
+		result, err := wrapped.InvokableRun(ctx, `{"city": "Paris", "days": 3}`)
+		assert.NoError(t, err)
+		assert.Equal(t, "sunny", result)
+		assert.Equal(t, `{"city":"Paris","days":3}`, got)
+	})
+
+	t.Run("repaired and coerced", func(t *testing.T) {
+		result, err := wrapped.InvokableRun(ctx, `{city: 'Paris', days: "3", unit: "Celsius", alert: "true", tags: "rain",}`)
+		assert.NoError(t, err)
+		assert.Equal(t, "sunny", result)
+		assert.Equal(t, `{"city":"Paris","days":3,"unit":"celsius","alert":true,"tags":["rain"]}`, got)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		result, err := wrapped.InvokableRun(ctx, `{"days": 10, "unit": "kelvin", "country": "FR"}`)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{
+			"error": "invalid arguments of tool weather, correct them and call the tool again",
+			"errors": [
+				{"path": "$.city", "message": "is required"},
+				{"path": "$.country", "message": "is not allowed, the allowed properties are: alert, city, days, tags, unit"},
+				{"path": "$.days", "message": "must be <= 7"},
+				{"path": "$.unit", "message": "must be one of [\"celsius\",\"fahrenheit\"]"}
+			]
+		}`, result)
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		result, err := wrapped.InvokableRun(ctx, `{"city": "Paris", "days": 3 4}`)
+		assert.NoError(t, err)
+		assert.Contains(t, result, `"path":"$","message":"invalid JSON: `)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		strict, err := Wrap(ctx, newWeatherTool(t, &got), &Config{DisableRepair: true, DisableCoercion: true, ReturnError: true})
+		assert.NoError(t, err)
+
+		_, err = strict.InvokableRun(ctx, `{city: "Paris"}`)
+		var vErr *ValidationError
+		if assert.ErrorAs(t, err, &vErr) {
+			assert.Equal(t, "weather", vErr.Tool)
+			assert.Equal(t, "$", vErr.Errors[0].Path)
+		}
+
+		_, err = strict.InvokableRun(ctx, `{"city": "Paris", "days": "3"}`)
+		assert.EqualError(t, err, "invalid arguments of tool weather: $.days: expected integer, got string")
+	})
+}
+
+type schemaTool struct {
+	params *schema.ParamsOneOf
+	got    string
+}
+
+func (s *schemaTool) Info(ctx context.Context) (*schema.ToolInfo, error) {
+	return &schema.ToolInfo{Name: "schema_tool", ParamsOneOf: s.params}, nil
+}
+
+func (s *schemaTool) InvokableRun(ctx context.Context, argumentsInJSON string, opts ...tool.Option) (string, error) {
+	s.got = argumentsInJSON
+	return "ok", nil
+}
+
+func TestWrapJSONSchema(t *testing.T) {
+	ctx := context.Background()
+
+	var js *jsonschema.Schema
+	assert.NoError(t, json.Unmarshal([]byte(`{
+		"type": "object",
+		"$defs": {
+			"point": {"type": "object", "properties": {"x": {"type": "number"}, "y": {"type": "number"}}, "required": ["x", "y"]}
+		},
+		"properties": {
+			"points": {"type": "array", "items": {"$ref": "#/$defs/point"}, "minItems": 1},
+			"label": {"anyOf": [{"type": "integer"}, {"type": "string", "pattern": "^[a-z]+$"}]},
+			"note": {"type": ["string", "null"], "maxLength": 5}
+		},
+		"required": ["points"]
+	}`), &js))
+	st := &schemaTool{params: schema.NewParamsOneOfByJSONSchema(js)}
+
+	wrapped, err := Wrap(ctx, st, &Config{ReturnError: true})
+	assert.NoError(t, err)
+
+	_, err = wrapped.InvokableRun(ctx, `{"points": "[{\"x\": 1, \"y\": \"2\"}]", "label": "abc", "note": null}`)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"label":"abc","note":null,"points":[{"x":1,"y":2}]}`, st.got)
+
+	_, err = wrapped.InvokableRun(ctx, `{"points": [{"x": 1}], "label": "ABC!", "note": "too long"}`)
+	assert.EqualError(t, err, "invalid arguments of tool schema_tool: "+
+		"$.label: does not match any of the allowed schemas; "+
+		"$.note: length must be <= 5; "+
+		"$.points[0].y: is required")
+
+	// the arguments are passed as they are when nothing is changed
+	_, err = wrapped.InvokableRun(ctx, `{"points": [{"x": 1.50, "y": 2}]}`)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"points": [{"x": 1.50, "y": 2}]}`, st.got)
+
+	// no parameters
+	wrapped, err = Wrap(ctx, &schemaTool{}, nil)
+	assert.NoError(t, err)
+	result, err := wrapped.InvokableRun(ctx, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", result)
+}
@@ -153,6 +153,123 @@ func (c *WikipediaClient) GetPage(ctx context.Context, title string) (*Page, err
 	return nil, ErrPageNotFound
 }
 
+// GetArticle retrieves the full article of the Wikipedia page by title, following the redirects.
+func (c *WikipediaClient) GetArticle(ctx context.Context, title string) (*Article, error) {
+	if strings.TrimSpace(title) == "" {
+		return nil, ErrInvalidParameters
+	}
+
+	params := url.Values{
+		"action":          []string{"query"},
+		"prop":            []string{"extracts|revisions|pageprops"},
+		"titles":          []string{title},
+		"redirects":       []string{"1"},
+		"explaintext":     []string{"1"},
+		"exsectionformat": []string{"wiki"},
+		"rvprop":          []string{"timestamp"},
+		"ppprop":          []string{"disambiguation"},
+		"format":          []string{"json"},
+	}
+
+	var response struct {
+		Query struct {
+			Redirects []struct {
+				From string `json:"from"`
+				To   string `json:"to"`
+			} `json:"redirects"`
+			Pages map[string]struct {
+				PageID    int    `json:"pageid"`
+				Title     string `json:"title"`
+				Extract   string `json:"extract"`
+				Revisions []struct {
+					Timestamp time.Time `json:"timestamp"`
+				} `json:"revisions"`
+				PageProps map[string]string `json:"pageprops"`
+			} `json:"pages"`
+		} `json:"query"`
+		Error *APIError `json:"error"`
+	}
+
+	if err := c.makeRequest(ctx, params, &response); err != nil {
+		return nil, err
+	}
+
+	if response.Error != nil {
+		return nil, response.Error
+	}
+
+	for _, page := range response.Query.Pages {
+		if page.PageID == 0 {
+			return nil, ErrPageNotFound
+		}
+
+		article := &Article{
+			Title:    page.Title,
+			PageID:   page.PageID,
+			URL:      c.buildPageURL(page.Title),
+			Sections: splitSections(page.Extract),
+		}
+		if len(response.Query.Redirects) > 0 {
+			article.RedirectedFrom = response.Query.Redirects[0].From
+		}
+		if _, ok := page.PageProps["disambiguation"]; ok {
+			article.Disambiguation = true
+		}
+		if len(page.Revisions) > 0 {
+			article.LastUpdated = page.Revisions[0].Timestamp
+		}
+		return article, nil
+	}
+
+	return nil, ErrPageNotFound
+}
+
+// GetLinks retrieves the titles of the articles the Wikipedia page links to, e.g. the candidates of a disambiguation page.
+// API documentation: https://www.mediawiki.org/wiki/API:Links
+func (c *WikipediaClient) GetLinks(ctx context.Context, title string, limit int) ([]string, error) {
+	params := url.Values{
+		"action":      []string{"query"},
+		"prop":        []string{"links"},
+		"titles":      []string{title},
+		"plnamespace": []string{"0"},
+		"pllimit":     []string{fmt.Sprintf("%d", limit)},
+		"format":      []string{"json"},
+	}
+
+	var response struct {
+		Query struct {
+			Pages map[string]struct {
+				Links []struct {
+					Title string `json:"title"`
+				} `json:"links"`
+			} `json:"pages"`
+		} `json:"query"`
+		Error *APIError `json:"error"`
+	}
+
+	if err := c.makeRequest(ctx, params, &response); err != nil {
+		return nil, err
+	}
+
+	if response.Error != nil {
+		return nil, response.Error
+	}
+
+	var links []string
+	for _, page := range response.Query.Pages {
+		for _, link := range page.Links {
+			links = append(links, link.Title)
+		}
+	}
+
+	return links, nil
+}
+
+// Language returns the language of the Wikipedia the client requests.
+func (c *WikipediaClient) Language() string {
+	return c.language
+}
+
 // buildPageURL builds the URL for the Wikipedia page.
 func (c *WikipediaClient) buildPageURL(title string) string {
 	return fmt.Sprintf("https://%s.wikipedia.org/wiki/%s",
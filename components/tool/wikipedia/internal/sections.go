@@ -0,0 +1,54 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"regexp"
+	"strings"
+)
+
+// headingRegexp matches the section headings of the plain text extracts with the wiki section format, e.g. "== History ==".
+var headingRegexp = regexp.MustCompile(`^(={2,6})\s*(.*?)\s*={2,6}$`)
+
+// splitSections splits a plain text extract into its sections. The first section is the introduction,
+// with an empty title and level 1, and is omitted when empty. Sections with no content are kept,
+// as their subsections follow them.
+func splitSections(extract string) []*Section {
+	sections := []*Section{{Level: 1}}
+	var content strings.Builder
+	flush := func() {
+		sections[len(sections)-1].Content = strings.TrimSpace(content.String())
+		content.Reset()
+	}
+
+	for _, line := range strings.Split(extract, "\n") {
+		m := headingRegexp.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			content.WriteString(line)
+			content.WriteString("\n")
+			continue
+		}
+		flush()
+		sections = append(sections, &Section{Title: m[2], Level: len(m[1])})
+	}
+	flush()
+
+	if sections[0].Content == "" {
+		sections = sections[1:]
+	}
+	return sections
+}
@@ -0,0 +1,36 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitSections(t *testing.T) {
+	extract := "Go is a programming language.\n\n\n== History ==\nGo was designed at Google.\n\n=== Versions ===\nGo 1 was released in 2012.\n\n== See also ==\n"
+	assert.Equal(t, []*Section{
+		{Level: 1, Content: "Go is a programming language."},
+		{Title: "History", Level: 2, Content: "Go was designed at Google."},
+		{Title: "Versions", Level: 3, Content: "Go 1 was released in 2012."},
+		{Title: "See also", Level: 2},
+	}, splitSections(extract))
+
+	assert.Equal(t, []*Section{{Title: "History", Level: 2, Content: "a = b"}}, splitSections("== History ==\na = b"))
+	assert.Equal(t, []*Section{}, splitSections(""))
+}
@@ -36,3 +36,25 @@ type Page struct {
 	URL         string    `json:"url"`
 	LastUpdated time.Time `json:"last_updated"`
 }
+
+// Article represents the full article of a Wikipedia page, split into sections.
+type Article struct {
+	Title  string `json:"title"`
+	PageID int    `json:"pageid"`
+	URL    string `json:"url"`
+	// RedirectedFrom is the requested title when it redirected to this page.
+	RedirectedFrom string `json:"redirected_from,omitempty"`
+	// Disambiguation is true when the page lists the articles the title may refer to.
+	Disambiguation bool `json:"disambiguation,omitempty"`
+	// Sections are the sections of the article in order, the first one being the introduction without title.
+	Sections    []*Section `json:"sections"`
+	LastUpdated time.Time  `json:"last_updated"`
+}
+
+// Section is a section of an article. The content does not include the subsections, which follow it.
+type Section struct {
+	Title string `json:"title"`
+	// Level is the heading level of the section, 2 for the top level sections, 1 for the introduction.
+	Level   int    `json:"level"`
+	Content string `json:"content"`
+}
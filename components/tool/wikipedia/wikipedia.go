@@ -18,8 +18,10 @@ package wikipedia
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/cloudwego/eino-ext/components/tool/wikipedia/internal"
@@ -58,11 +60,26 @@ type Config struct {
 	// Language is the language to use for the wikipedia search.
 	// Optional. Default: "en".
 	Language string `json:"language"`
+	// FallbackLanguages are the languages tried in order when nothing is found in Language,
+	// e.g. []string{"en"} to fall back to the English Wikipedia, which has the most articles.
+	// Their api is https://<language>.wikipedia.org/w/api.php.
+	// Optional. Default: none.
+	FallbackLanguages []string `json:"fallback_languages"`
+	// PageMaxChars is the maximum number of characters of the content returned by the page tool.
+	// If the content is longer than this, it will be truncated, the model can request a single section instead.
+	// Optional. Default: 20000.
+	PageMaxChars int `json:"page_max_chars"`
 
 	ToolName string `json:"tool_name"` // Optional. Default: "wikipedia_search".
 	ToolDesc string `json:"tool_desc"` // Optional. Default: "this tool provides quick and efficient access to information from the Wikipedia"
+
+	PageToolName string `json:"page_tool_name"` // Optional. Default: "wikipedia_page".
+	PageToolDesc string `json:"page_tool_desc"` // Optional. Default: a description of the page tool.
 }
 
+// maxDisambiguationLinks is the maximum number of candidate titles returned for a disambiguation page.
+const maxDisambiguationLinks = 50
+
 // NewTool creates a new wikipedia search tool.
 func NewTool(ctx context.Context, conf *Config) (tool.InvokableTool, error) {
 	err := conf.validate()
@@ -80,6 +97,36 @@ func NewTool(ctx context.Context, conf *Config) (tool.InvokableTool, error) {
 	return t, nil
 }
 
+// NewPageTool creates a new wikipedia page tool, which fetches the full article of a page or one of its sections.
+func NewPageTool(ctx context.Context, conf *Config) (tool.InvokableTool, error) {
+	err := conf.validate()
+	if err != nil {
+		return nil, err
+	}
+	w, err := newWikipedia(ctx, conf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create wikipedia page tool: %w", err)
+	}
+	t, err := utils.InferTool(conf.PageToolName, conf.PageToolDesc, w.Page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to infer tool: %w", err)
+	}
+	return t, nil
+}
+
+// NewToolKit creates the wikipedia search and page tools.
+func NewToolKit(ctx context.Context, conf *Config) ([]tool.BaseTool, error) {
+	search, err := NewTool(ctx, conf)
+	if err != nil {
+		return nil, err
+	}
+	page, err := NewPageTool(ctx, conf)
+	if err != nil {
+		return nil, err
+	}
+	return []tool.BaseTool{search, page}, nil
+}
+
 // validate validates the configuration and sets default values if not provided.
 func (conf *Config) validate() error {
 	if conf == nil {
@@ -110,46 +157,79 @@ func (conf *Config) validate() error {
 		conf.Language = "en"
 	}
 	if conf.BaseURL == "" {
-		conf.BaseURL = fmt.Sprintf("https://%s.wikipedia.org/w/api.php", conf.Language)
+		conf.BaseURL = baseURL(conf.Language)
+	}
+	if conf.PageMaxChars <= 0 {
+		conf.PageMaxChars = 20000
+	}
+	if conf.PageToolName == "" {
+		conf.PageToolName = "wikipedia_page"
+	}
+	if conf.PageToolDesc == "" {
+		conf.PageToolDesc = "this tool fetches the full article of a Wikipedia page by its title, or one of its sections"
 	}
 	return nil
 }
 
+func baseURL(language string) string {
+	return fmt.Sprintf("https://%s.wikipedia.org/w/api.php", language)
+}
+
 // newWikipedia creates a new wikipedia search tool.
 func newWikipedia(_ context.Context, conf *Config) (*wikipedia, error) {
-	c := internal.NewClient(
-		internal.WithBaseURL(conf.BaseURL),
-		internal.WithUserAgent(conf.UserAgent),
-		internal.WithTopK(conf.TopK),
-		internal.WithLanguage(conf.Language),
-		internal.WithHTTPClient(
-			&http.Client{
-				Timeout: conf.Timeout,
-				CheckRedirect: func(req *http.Request, via []*http.Request) error {
-					if len(via) >= conf.MaxRedirect {
-						return internal.ErrTooManyRedirects
-					}
-					return nil
-				}}),
-	)
+	httpClient := &http.Client{
+		Timeout: conf.Timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= conf.MaxRedirect {
+				return internal.ErrTooManyRedirects
+			}
+			return nil
+		}}
+	newClient := func(baseURL, language string) *internal.WikipediaClient {
+		return internal.NewClient(
+			internal.WithBaseURL(baseURL),
+			internal.WithUserAgent(conf.UserAgent),
+			internal.WithTopK(conf.TopK),
+			internal.WithLanguage(language),
+			internal.WithHTTPClient(httpClient),
+		)
+	}
+
+	clients := []*internal.WikipediaClient{newClient(conf.BaseURL, conf.Language)}
+	for _, lang := range conf.FallbackLanguages {
+		if lang != conf.Language {
+			clients = append(clients, newClient(baseURL(lang), lang))
+		}
+	}
 	return &wikipedia{
-		conf:   conf,
-		client: c,
+		conf:    conf,
+		clients: clients,
 	}, nil
 }
 
-// Search searches the web for the query and returns the search results.
+// Search searches the web for the query and returns the search results,
+// from the first language of Language and FallbackLanguages with results.
 func (w *wikipedia) Search(ctx context.Context, query SearchRequest) (*SearchResponse, error) {
-	sr, err := w.client.Search(ctx, query.Query)
-	if err != nil {
-		return nil, err
+	var (
+		client *internal.WikipediaClient
+		sr     []internal.SearchResult
+		err    error
+	)
+	for _, client = range w.clients {
+		sr, err = client.Search(ctx, query.Query)
+		if err != nil {
+			return nil, err
+		}
+		if len(sr) > 0 {
+			break
+		}
 	}
 	if len(sr) == 0 {
 		return nil, internal.ErrPageNotFound
 	}
 	res := make([]*Result, 0, len(sr))
 	for _, search := range sr {
-		pr, err := w.client.GetPage(ctx, search.Title)
+		pr, err := client.GetPage(ctx, search.Title)
 		if err != nil {
 			return nil, err
 		}
@@ -160,18 +240,110 @@ func (w *wikipedia) Search(ctx context.Context, query SearchRequest) (*SearchRes
 			extract = pr.Content
 		}
 		res = append(res, &Result{
-			Title:   pr.Title,
-			URL:     pr.URL,
-			Extract: extract,
-			Snippet: search.Snippet,
+			Title:    pr.Title,
+			URL:      pr.URL,
+			Extract:  extract,
+			Snippet:  search.Snippet,
+			Language: client.Language(),
 		})
 	}
 	return &SearchResponse{Results: res}, nil
 }
 
+// Page fetches the full article of a page, or one of its sections with its subsections,
+// from the first language of Language and FallbackLanguages having the page.
+// Redirects are followed, and the candidate articles are listed for disambiguation pages.
+func (w *wikipedia) Page(ctx context.Context, req *PageRequest) (*PageResponse, error) {
+	var (
+		client  *internal.WikipediaClient
+		article *internal.Article
+		err     error
+	)
+	for _, client = range w.clients {
+		article, err = client.GetArticle(ctx, req.Title)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, internal.ErrPageNotFound) {
+			return nil, err
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &PageResponse{
+		Title:          article.Title,
+		URL:            article.URL,
+		Language:       client.Language(),
+		RedirectedFrom: article.RedirectedFrom,
+		Sections:       make([]string, 0, len(article.Sections)),
+	}
+	for _, sec := range article.Sections {
+		if sec.Title != "" {
+			resp.Sections = append(resp.Sections, sec.Title)
+		}
+	}
+	if article.Disambiguation {
+		resp.Disambiguation, err = client.GetLinks(ctx, article.Title, maxDisambiguationLinks)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sections := article.Sections
+	if req.Section != "" {
+		sections, err = findSection(sections, req.Section)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	content := renderSections(sections)
+	if runes := []rune(content); len(runes) > w.conf.PageMaxChars {
+		content = string(runes[:w.conf.PageMaxChars])
+		resp.Truncated = true
+	}
+	resp.Content = content
+	return resp, nil
+}
+
+// findSection returns the section with the title, followed by its subsections.
+func findSection(sections []*internal.Section, title string) ([]*internal.Section, error) {
+	for i, sec := range sections {
+		if sec.Title == "" || !strings.EqualFold(sec.Title, strings.TrimSpace(title)) {
+			continue
+		}
+		end := i + 1
+		for end < len(sections) && sections[end].Level > sec.Level {
+			end++
+		}
+		return sections[i:end], nil
+	}
+	return nil, fmt.Errorf("section not found: %s", title)
+}
+
+// renderSections renders the sections as markdown, with a heading for each titled section.
+func renderSections(sections []*internal.Section) string {
+	var sb strings.Builder
+	for _, sec := range sections {
+		if sec.Title != "" {
+			sb.WriteString(strings.Repeat("#", sec.Level))
+			sb.WriteString(" ")
+			sb.WriteString(sec.Title)
+			sb.WriteString("\n\n")
+		}
+		if sec.Content != "" {
+			sb.WriteString(sec.Content)
+			sb.WriteString("\n\n")
+		}
+	}
+	return strings.TrimSpace(sb.String())
+}
+
 type wikipedia struct {
-	conf   *Config
-	client *internal.WikipediaClient
+	conf    *Config
+	clients []*internal.WikipediaClient
 }
 
 // Result is the page search result.
@@ -180,6 +352,8 @@ type Result struct {
 	URL     string `json:"url" jsonschema:"description=The url of the search result"`
 	Extract string `json:"extract" jsonschema:"description=The extract of the search result"`
 	Snippet string `json:"snippet" jsonschema:"description=The snippet of the search result"`
+	// Language is the language of the Wikipedia the result comes from.
+	Language string `json:"language,omitempty" jsonschema:"description=The language of the search result"`
 }
 
 // SearchRequest is the search request.
@@ -191,3 +365,27 @@ type SearchRequest struct {
 type SearchResponse struct {
 	Results []*Result `json:"results" jsonschema:"description=The results of the search"`
 }
+
+// PageRequest is the page request.
+type PageRequest struct {
+	Title   string `json:"title" jsonschema:"required,description=The title of the Wikipedia page, e.g. as returned by the search tool"`
+	Section string `json:"section,omitempty" jsonschema:"description=The title of a section to return with its subsections instead of the whole article"`
+}
+
+// PageResponse is the page response.
+type PageResponse struct {
+	Title string `json:"title" jsonschema:"description=The title of the page"`
+	URL   string `json:"url" jsonschema:"description=The url of the page"`
+	// Language is the language of the Wikipedia the page comes from.
+	Language string `json:"language" jsonschema:"description=The language of the page"`
+	// RedirectedFrom is the requested title, when it redirected to the page.
+	RedirectedFrom string `json:"redirected_from,omitempty" jsonschema:"description=The requested title which redirected to the page"`
+	// Sections are the titles of all the sections of the page, to request one of them.
+	Sections []string `json:"sections" jsonschema:"description=The titles of the sections of the page"`
+	// Disambiguation are the titles of the articles a disambiguation page lists, to request one of them.
+	Disambiguation []string `json:"disambiguation,omitempty" jsonschema:"description=The titles of the articles the requested title may refer to"`
+	// Content is the article, or the requested section, as markdown.
+	Content string `json:"content" jsonschema:"description=The content of the page or of the requested section"`
+	// Truncated is true when the content was longer than PageMaxChars.
+	Truncated bool `json:"truncated,omitempty" jsonschema:"description=Whether the content was truncated"`
+}
@@ -19,6 +19,8 @@ package wikipedia
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/bytedance/sonic"
@@ -68,3 +70,120 @@ func TestWikipedia_Search(t *testing.T) {
 		})
 	}
 }
+
+func newTestWikipedia(t *testing.T, conf *Config, pages map[string]map[string]string) *wikipedia {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lang := r.URL.Path[1:]
+		q := r.URL.Query()
+		title := q.Get("titles")
+		if q.Get("list") == "search" {
+			title = q.Get("srsearch")
+		}
+		if q.Get("prop") == "links" {
+			title += "#links"
+		}
+		resp, ok := pages[lang][title]
+		if !ok {
+			resp = `{"query":{"search":[],"pages":{"-1":{"title":"` + title + `","missing":""}}}}`
+		}
+		_, _ = w.Write([]byte(resp))
+	}))
+	t.Cleanup(srv.Close)
+
+	assert.NoError(t, conf.validate())
+	w, err := newWikipedia(context.Background(), conf)
+	assert.NoError(t, err)
+	w.clients = nil
+	for _, lang := range append([]string{conf.Language}, conf.FallbackLanguages...) {
+		w.clients = append(w.clients, internal.NewClient(
+			internal.WithBaseURL(srv.URL+"/"+lang),
+			internal.WithLanguage(lang),
+			internal.WithTopK(conf.TopK),
+			internal.WithHTTPClient(srv.Client()),
+		))
+	}
+	return w
+}
+
+func TestWikipedia_Page(t *testing.T) {
+	ctx := context.Background()
+	const article = `{"query":{"redirects":[{"from":"Golang","to":"Go (programming language)"}],"pages":{"25039021":{"pageid":25039021,"title":"Go (programming language)",` +
+		`"extract":"Go is a programming language.\n\n== History ==\nGo was designed at Google.\n\n=== Versions ===\nGo 1 was released in 2012.\n\n== Design ==\nGo is statically typed.",` +
+		`"revisions":[{"timestamp":"2025-01-02T03:04:05Z"}]}}}}`
+	w := newTestWikipedia(t, &Config{Language: "fr", FallbackLanguages: []string{"en"}, PageMaxChars: 80}, map[string]map[string]string{
+		"en": {
+			"Golang":        article,
+			"Mercury":       `{"query":{"pages":{"1":{"pageid":1,"title":"Mercury","extract":"Mercury may refer to:","pageprops":{"disambiguation":""}}}}}`,
+			"Mercury#links": `{"query":{"pages":{"1":{"links":[{"title":"Mercury (planet)"},{"title":"Mercury (element)"}]}}}}`,
+		},
+		"fr": {
+			"Mercure": `{"query":{"pages":{"2":{"pageid":2,"title":"Mercure","extract":"Mercure est une planète."}}}}`,
+		},
+	})
+
+	resp, err := w.Page(ctx, &PageRequest{Title: "Golang"})
+	assert.NoError(t, err)
+	assert.Equal(t, &PageResponse{
+		Title:          "Go (programming language)",
+		URL:            "https://en.wikipedia.org/wiki/Go%20%28programming%20language%29",
+		Language:       "en",
+		RedirectedFrom: "Golang",
+		Sections:       []string{"History", "Versions", "Design"},
+		Content:        "Go is a programming language.\n\n## History\n\nGo was designed at Google.\n\n### Versi",
+		Truncated:      true,
+	}, resp)
+
+	resp, err = w.Page(ctx, &PageRequest{Title: "Golang", Section: "history"})
+	assert.NoError(t, err)
+	assert.Equal(t, "## History\n\nGo was designed at Google.\n\n### Versions\n\nGo 1 was released in 2012.", resp.Content)
+	assert.False(t, resp.Truncated)
+
+	_, err = w.Page(ctx, &PageRequest{Title: "Golang", Section: "Reception"})
+	assert.EqualError(t, err, "section not found: Reception")
+
+	resp, err = w.Page(ctx, &PageRequest{Title: "Mercure"})
+	assert.NoError(t, err)
+	assert.Equal(t, "fr", resp.Language)
+	assert.Equal(t, "Mercure est une planète.", resp.Content)
+	assert.Empty(t, resp.Disambiguation)
+
+	resp, err = w.Page(ctx, &PageRequest{Title: "Mercury"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Mercury (planet)", "Mercury (element)"}, resp.Disambiguation)
+
+	_, err = w.Page(ctx, &PageRequest{Title: "Nothing"})
+	assert.ErrorIs(t, err, internal.ErrPageNotFound)
+}
+
+func TestWikipedia_SearchFallback(t *testing.T) {
+	ctx := context.Background()
+	w := newTestWikipedia(t, &Config{Language: "fr", FallbackLanguages: []string{"en"}, DocMaxChars: 10}, map[string]map[string]string{
+		"en": {
+			"eino": `{"query":{"search":[{"title":"Eino","pageid":3,"snippet":"<span class=\"searchmatch\">Eino</span> is a name"}]}}`,
+			"Eino": `{"query":{"pages":{"3":{"pageid":3,"title":"Eino","extract":"Eino is a Finnish given name."}}}}`,
+		},
+	})
+
+	resp, err := w.Search(ctx, SearchRequest{Query: "eino"})
+	assert.NoError(t, err)
+	assert.Equal(t, []*Result{{
+		Title:    "Eino",
+		URL:      "https://en.wikipedia.org/wiki/Eino",
+		Extract:  "Eino is a ",
+		Snippet:  "Eino is a name",
+		Language: "en",
+	}}, resp.Results)
+
+	_, err = w.Search(ctx, SearchRequest{Query: "nothing"})
+	assert.ErrorIs(t, err, internal.ErrPageNotFound)
+}
+
+func TestNewToolKit(t *testing.T) {
+	ctx := context.Background()
+	tools, err := NewToolKit(ctx, &Config{FallbackLanguages: []string{"en", "de"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(tools))
+	info, err := tools[1].Info(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "wikipedia_page", info.Name)
+}
@@ -18,9 +18,11 @@ package apihandler
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/cloudwego/eino-ext/devops/internal/apihandler/types"
 	"github.com/cloudwego/eino-ext/devops/internal/model"
@@ -159,6 +161,9 @@ func StreamDebugRun(res http.ResponseWriter, req *http.Request) {
 				}
 
 				evt := types.DebugRunDataEVT(debugID, state)
+				if state.Paused {
+					evt = types.DebugRunPausedEVT(debugID, state)
+				}
 				if err != nil {
 					errEvt := types.DebugRunErrEVT(debugID, err.Error())
 					sseStreamResponseChan <- NewStreamResponse(string(errEvt.Type), string(evt.JsonBytes()))
@@ -204,6 +209,144 @@ func validateDebugRunRequest(req *http.Request) (*types.DebugRunRequest, error)
 	return r, nil
 }
 
+// GetBreakpoints returns the nodes of a graph its debug runs pause before.
+func GetBreakpoints(res http.ResponseWriter, req *http.Request) {
+	graphID := getPathParam(req, "graph_id")
+	if graphID == "" {
+		newHTTPResp(newBizError(http.StatusBadRequest, fmt.Errorf("graph_id is empty")), newBaseResp(http.StatusBadRequest, "")).doResp(res)
+		return
+	}
+
+	resp := &types.BreakpointsResponse{
+		NodeKeys: service.DebugSVC.GetBreakpoints(graphID),
+	}
+
+	newHTTPResp(resp).doResp(res)
+}
+
+// SetBreakpoints sets the nodes of a graph its debug runs pause before, an empty list clearing them.
+func SetBreakpoints(res http.ResponseWriter, req *http.Request) {
+	graphID := getPathParam(req, "graph_id")
+	if graphID == "" {
+		newHTTPResp(newBizError(http.StatusBadRequest, fmt.Errorf("graph_id is empty")), newBaseResp(http.StatusBadRequest, "")).doResp(res)
+		return
+	}
+
+	r, err := getReqFromBody[types.BreakpointsRequest](req)
+	if err != nil {
+		newHTTPResp(newBizError(http.StatusBadRequest, err), newBaseResp(http.StatusBadRequest, "")).doResp(res)
+		return
+	}
+
+	service.DebugSVC.SetBreakpoints(graphID, r.NodeKeys)
+
+	resp := &types.BreakpointsResponse{
+		NodeKeys: service.DebugSVC.GetBreakpoints(graphID),
+	}
+
+	newHTTPResp(resp).doResp(res)
+}
+
+// ResumeDebugRun resumes a paused debug run, optionally editing the input of the paused node.
+func ResumeDebugRun(res http.ResponseWriter, req *http.Request) {
+	graphID := getPathParam(req, "graph_id")
+	debugID := getPathParam(req, "debug_id")
+	if graphID == "" || debugID == "" {
+		newHTTPResp(newBizError(http.StatusBadRequest, fmt.Errorf("graph_id or debug_id is empty")), newBaseResp(http.StatusBadRequest, "")).doResp(res)
+		return
+	}
+
+	r, err := getReqFromBody[types.ResumeDebugRunRequest](req)
+	if err != nil {
+		newHTTPResp(newBizError(http.StatusBadRequest, err), newBaseResp(http.StatusBadRequest, "")).doResp(res)
+		return
+	}
+
+	err = service.DebugSVC.ResumeDebugRun(graphID, debugID, &model.ResumeCommand{
+		Action: model.ResumeAction(r.Action),
+		Input:  r.Input,
+	})
+	if err != nil {
+		newHTTPResp(newBizError(http.StatusBadRequest, err), newBaseResp(http.StatusBadRequest, "")).doResp(res)
+		return
+	}
+
+	newHTTPResp(nil).doResp(res)
+}
+
+// ListDebugRuns lists the recorded runs of a graph, the latest first.
+func ListDebugRuns(res http.ResponseWriter, req *http.Request) {
+	graphID := getPathParam(req, "graph_id")
+	if graphID == "" {
+		newHTTPResp(newBizError(http.StatusBadRequest, fmt.Errorf("graph_id is empty")), newBaseResp(http.StatusBadRequest, "")).doResp(res)
+		return
+	}
+
+	runs := service.DebugSVC.ListDebugRuns(graphID)
+	resp := &types.ListDebugRunsResponse{
+		Runs: make([]*types.DebugRunSummary, 0, len(runs)),
+	}
+	for _, run := range runs {
+		resp.Runs = append(resp.Runs, types.NewDebugRunSummary(run))
+	}
+
+	newHTTPResp(resp).doResp(res)
+}
+
+// GetDebugRun returns a recorded run with its node timeline, inputs, outputs and token usage.
+func GetDebugRun(res http.ResponseWriter, req *http.Request) {
+	run, err := getDebugRun(req)
+	if err != nil {
+		newHTTPResp(newBizError(http.StatusNotFound, err), newBaseResp(http.StatusNotFound, "")).doResp(res)
+		return
+	}
+
+	resp := &types.GetDebugRunResponse{
+		Run: types.NewDebugRunDetail(run),
+	}
+
+	newHTTPResp(resp).doResp(res)
+}
+
+// ExportDebugRun downloads a recorded run as a json file, to share problematic runs.
+func ExportDebugRun(res http.ResponseWriter, req *http.Request) {
+	run, err := getDebugRun(req)
+	if err != nil {
+		newHTTPResp(newBizError(http.StatusNotFound, err), newBaseResp(http.StatusNotFound, "")).doResp(res)
+		return
+	}
+
+	out, err := json.MarshalIndent(&types.ExportDebugRunResponse{
+		Version:      types.Version,
+		ExportTimeMS: time.Now().UnixMilli(),
+		Run:          types.NewDebugRunDetail(run),
+	}, "", "  ")
+	if err != nil {
+		newHTTPResp(newBizError(http.StatusInternalServerError, err)).doResp(res)
+		return
+	}
+
+	res.Header().Set("content-type", "application/json")
+	res.Header().Set("content-disposition", fmt.Sprintf(`attachment; filename="eino_debug_run_%s.json"`, run.DebugID))
+	if _, err = res.Write(out); err != nil {
+		log.Errorf("write exported debug run failed, err=%v", err)
+	}
+}
+
+func getDebugRun(req *http.Request) (*model.DebugRun, error) {
+	graphID := getPathParam(req, "graph_id")
+	debugID := getPathParam(req, "debug_id")
+	if graphID == "" || debugID == "" {
+		return nil, fmt.Errorf("graph_id or debug_id is empty")
+	}
+
+	run, ok := service.DebugSVC.GetDebugRun(graphID, debugID)
+	if !ok {
+		return nil, fmt.Errorf("debug run=%s of graph=%s not exist", debugID, graphID)
+	}
+	return run, nil
+}
+
 func ListInputTypes(res http.ResponseWriter, req *http.Request) {
 	resp := &types.ListInputTypesResponse{
 		Types: model.GetRegisteredTypeJsonSchema(),
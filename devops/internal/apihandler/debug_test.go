@@ -19,7 +19,9 @@ package apihandler
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
@@ -223,3 +225,175 @@ func (d *debugTestSuite) Test_ListInputTypes() {
 	assert.Nil(d.t, err)
 	assert.Greater(d.t, len(data.Types), 0)
 }
+
+func (d *debugTestSuite) newDebugRun() *model.DebugRun {
+	run := model.NewDebugRun("mock_debug_id", model.DebugRunMeta{
+		GraphID:  "mock_graph",
+		ThreadID: "mock_thread_id",
+		FromNode: "start",
+	}, `"hello"`, 1000)
+	run.AddNodeState(&model.NodeDebugState{
+		NodeKey:   "model",
+		Component: "ChatModel",
+		Output:    `{"content":"hi"}`,
+		Metrics: model.NodeDebugMetrics{
+			PromptTokens:     10,
+			CompletionTokens: 5,
+			InvokeTimeMS:     1020,
+			CompletionTimeMS: 1200,
+		},
+	})
+	run.AddNodeState(&model.NodeDebugState{
+		NodeKey:   "prompt",
+		Component: "ChatTemplate",
+		Metrics: model.NodeDebugMetrics{
+			InvokeTimeMS:     1001,
+			CompletionTimeMS: 1010,
+		},
+	})
+	run.Finish(nil, 1250)
+	return run.Snapshot()
+}
+
+func (d *debugTestSuite) Test_ListDebugRuns() {
+	d.mockDebugSVC.EXPECT().ListDebugRuns("mock_graph").Return([]*model.DebugRun{d.newDebugRun()}).Times(1)
+
+	req, err := http.NewRequest(http.MethodGet, "", nil)
+	assert.Nil(d.t, err)
+	req = mux.SetURLVars(req, map[string]string{"graph_id": "mock_graph"})
+	res := &mockResponseWriter{}
+	ListDebugRuns(res, req)
+
+	resp := &HTTPResp{}
+	err = json.Unmarshal(res.body, &resp)
+	assert.Nil(d.t, err)
+	b, err := json.Marshal(resp.Data)
+	assert.Nil(d.t, err)
+	var data *types.ListDebugRunsResponse
+	err = json.Unmarshal(b, &data)
+	assert.Nil(d.t, err)
+	assert.Len(d.t, data.Runs, 1)
+	assert.Equal(d.t, "success", data.Runs[0].Status)
+	assert.Equal(d.t, int64(250), data.Runs[0].DurationMS)
+	assert.Equal(d.t, 2, data.Runs[0].NodeCount)
+	assert.Equal(d.t, types.TokenUsage{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15}, data.Runs[0].TokenUsage)
+}
+
+func (d *debugTestSuite) Test_GetDebugRun() {
+	d.mockDebugSVC.EXPECT().GetDebugRun("mock_graph", "mock_debug_id").Return(d.newDebugRun(), true).Times(1)
+	d.mockDebugSVC.EXPECT().GetDebugRun("mock_graph", "unknown").Return(nil, false).Times(1)
+
+	req, err := http.NewRequest(http.MethodGet, "", nil)
+	assert.Nil(d.t, err)
+	req = mux.SetURLVars(req, map[string]string{"graph_id": "mock_graph", "debug_id": "mock_debug_id"})
+	res := &mockResponseWriter{}
+	GetDebugRun(res, req)
+
+	resp := &HTTPResp{}
+	err = json.Unmarshal(res.body, &resp)
+	assert.Nil(d.t, err)
+	b, err := json.Marshal(resp.Data)
+	assert.Nil(d.t, err)
+	var data *types.GetDebugRunResponse
+	err = json.Unmarshal(b, &data)
+	assert.Nil(d.t, err)
+	assert.Equal(d.t, `"hello"`, data.Run.Input)
+	assert.Len(d.t, data.Run.Timeline, 2)
+	assert.Equal(d.t, "prompt", data.Run.Timeline[0].NodeKey)
+	assert.Equal(d.t, int64(1), data.Run.Timeline[0].StartOffsetMS)
+	assert.Equal(d.t, "model", data.Run.Timeline[1].NodeKey)
+	assert.Equal(d.t, "ChatModel", data.Run.Timeline[1].Component)
+	assert.Equal(d.t, int64(20), data.Run.Timeline[1].StartOffsetMS)
+	assert.Equal(d.t, int64(180), data.Run.Timeline[1].DurationMS)
+	assert.Equal(d.t, int64(5), data.Run.Timeline[1].Metrics.CompletionTokens)
+	assert.Equal(d.t, map[string]types.TokenUsage{"model": {PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15}}, data.Run.NodeTokenUsage)
+
+	req = mux.SetURLVars(req, map[string]string{"graph_id": "mock_graph", "debug_id": "unknown"})
+	res = &mockResponseWriter{}
+	GetDebugRun(res, req)
+	resp = &HTTPResp{}
+	err = json.Unmarshal(res.body, &resp)
+	assert.Nil(d.t, err)
+	assert.Equal(d.t, http.StatusNotFound, resp.Code)
+}
+
+func (d *debugTestSuite) Test_ExportDebugRun() {
+	d.mockDebugSVC.EXPECT().GetDebugRun("mock_graph", "mock_debug_id").Return(d.newDebugRun(), true).Times(1)
+
+	req, err := http.NewRequest(http.MethodGet, "", nil)
+	assert.Nil(d.t, err)
+	req = mux.SetURLVars(req, map[string]string{"graph_id": "mock_graph", "debug_id": "mock_debug_id"})
+	res := httptest.NewRecorder()
+	ExportDebugRun(res, req)
+
+	assert.Equal(d.t, `attachment; filename="eino_debug_run_mock_debug_id.json"`, res.Header().Get("content-disposition"))
+	var data *types.ExportDebugRunResponse
+	err = json.Unmarshal(res.Body.Bytes(), &data)
+	assert.Nil(d.t, err)
+	assert.Equal(d.t, types.Version, data.Version)
+	assert.Equal(d.t, "mock_debug_id", data.Run.DebugID)
+	assert.Len(d.t, data.Run.Timeline, 2)
+}
+
+func (d *debugTestSuite) Test_Breakpoints() {
+	d.mockDebugSVC.EXPECT().SetBreakpoints("mock_graph", []string{"model"}).Times(1)
+	d.mockDebugSVC.EXPECT().GetBreakpoints("mock_graph").Return([]string{"model"}).Times(2)
+
+	req, err := http.NewRequest(http.MethodPut, "", strings.NewReader(`{"node_keys":["model"]}`))
+	assert.Nil(d.t, err)
+	req = mux.SetURLVars(req, map[string]string{"graph_id": "mock_graph"})
+	res := &mockResponseWriter{}
+	SetBreakpoints(res, req)
+
+	resp := &HTTPResp{}
+	err = json.Unmarshal(res.body, &resp)
+	assert.Nil(d.t, err)
+	assert.Equal(d.t, 0, resp.Code)
+
+	req, err = http.NewRequest(http.MethodGet, "", nil)
+	assert.Nil(d.t, err)
+	req = mux.SetURLVars(req, map[string]string{"graph_id": "mock_graph"})
+	res = &mockResponseWriter{}
+	GetBreakpoints(res, req)
+
+	resp = &HTTPResp{}
+	err = json.Unmarshal(res.body, &resp)
+	assert.Nil(d.t, err)
+	b, err := json.Marshal(resp.Data)
+	assert.Nil(d.t, err)
+	var data *types.BreakpointsResponse
+	err = json.Unmarshal(b, &data)
+	assert.Nil(d.t, err)
+	assert.Equal(d.t, []string{"model"}, data.NodeKeys)
+}
+
+func (d *debugTestSuite) Test_ResumeDebugRun() {
+	d.mockDebugSVC.EXPECT().ResumeDebugRun("mock_graph", "mock_debug_id", &model.ResumeCommand{
+		Action: model.ResumeStep,
+		Input:  `[{"role":"user","content":"hi"}]`,
+	}).Return(nil).Times(1)
+	d.mockDebugSVC.EXPECT().ResumeDebugRun("mock_graph", "unknown", gomock.Any()).
+		Return(fmt.Errorf("debug run=unknown of graph=mock_graph not running")).Times(1)
+
+	req, err := http.NewRequest(http.MethodPost, "", strings.NewReader(`{"action":"step","input":"[{\"role\":\"user\",\"content\":\"hi\"}]"}`))
+	assert.Nil(d.t, err)
+	req = mux.SetURLVars(req, map[string]string{"graph_id": "mock_graph", "debug_id": "mock_debug_id"})
+	res := &mockResponseWriter{}
+	ResumeDebugRun(res, req)
+
+	resp := &HTTPResp{}
+	err = json.Unmarshal(res.body, &resp)
+	assert.Nil(d.t, err)
+	assert.Equal(d.t, 0, resp.Code)
+
+	req, err = http.NewRequest(http.MethodPost, "", strings.NewReader(`{"action":"continue"}`))
+	assert.Nil(d.t, err)
+	req = mux.SetURLVars(req, map[string]string{"graph_id": "mock_graph", "debug_id": "unknown"})
+	res = &mockResponseWriter{}
+	ResumeDebugRun(res, req)
+
+	resp = &HTTPResp{}
+	err = json.Unmarshal(res.body, &resp)
+	assert.Nil(d.t, err)
+	assert.Equal(d.t, http.StatusBadRequest, resp.Code)
+}
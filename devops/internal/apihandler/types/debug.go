@@ -18,6 +18,7 @@ package types
 
 import (
 	"encoding/json"
+	"sort"
 
 	"github.com/cloudwego/eino-ext/devops/internal/model"
 	devmodel "github.com/cloudwego/eino-ext/devops/model"
@@ -43,6 +44,8 @@ const (
 	debugRunEventOfData   DebugRunEventType = "data"
 	debugRunEventOfFinish DebugRunEventType = "finish"
 	debugRunEventOfError  DebugRunEventType = "error"
+	// debugRunEventOfPaused: the run is paused before the node of the content, waiting to be resumed.
+	debugRunEventOfPaused DebugRunEventType = "paused"
 )
 
 type DebugRunEventMsg struct {
@@ -53,7 +56,8 @@ type DebugRunEventMsg struct {
 }
 
 type NodeDebugState struct {
-	NodeKey string `json:"node_key,omitempty"`
+	NodeKey   string `json:"node_key,omitempty"`
+	Component string `json:"component,omitempty"`
 
 	Input     string `json:"input,omitempty"`
 	Output    string `json:"output,omitempty"`
@@ -77,6 +81,7 @@ func DebugRunDataEVT(debugID string, state *model.NodeDebugState) (s DebugRunEve
 		DebugID: debugID,
 		Content: &NodeDebugState{
 			NodeKey:   state.NodeKey,
+			Component: state.Component,
 			Input:     state.Input,
 			Output:    state.Output,
 			Error:     state.Error,
@@ -91,6 +96,12 @@ func DebugRunDataEVT(debugID string, state *model.NodeDebugState) (s DebugRunEve
 	}
 }
 
+func DebugRunPausedEVT(debugID string, state *model.NodeDebugState) (s DebugRunEventMsg) {
+	s = DebugRunDataEVT(debugID, state)
+	s.Type = debugRunEventOfPaused
+	return s
+}
+
 func DebugRunErrEVT(debugID string, errStr string) (s DebugRunEventMsg) {
 	return DebugRunEventMsg{
 		Type:    debugRunEventOfError,
@@ -114,3 +125,135 @@ func (d DebugRunEventMsg) JsonBytes() []byte {
 type ListInputTypesResponse struct {
 	Types []*devmodel.JsonSchema `json:"types,omitempty"`
 }
+
+type BreakpointsRequest struct {
+	NodeKeys []string `json:"node_keys"`
+}
+
+type BreakpointsResponse struct {
+	NodeKeys []string `json:"node_keys"`
+}
+
+type ResumeDebugRunRequest struct {
+	// Action: continue, step or abort.
+	Action string `json:"action"`
+	// Input: the edited input of the paused node after json marshal, empty to keep the input.
+	Input string `json:"input,omitempty"`
+}
+
+type ListDebugRunsResponse struct {
+	Runs []*DebugRunSummary `json:"runs"`
+}
+
+type GetDebugRunResponse struct {
+	Run *DebugRunDetail `json:"run,omitempty"`
+}
+
+// ExportDebugRunResponse is the json file exported to share a run.
+type ExportDebugRunResponse struct {
+	Version      string          `json:"version"`
+	ExportTimeMS int64           `json:"export_time_ms"`
+	Run          *DebugRunDetail `json:"run"`
+}
+
+type DebugRunSummary struct {
+	DebugID  string `json:"debug_id"`
+	GraphID  string `json:"graph_id"`
+	ThreadID string `json:"thread_id"`
+	FromNode string `json:"from_node"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	// PausedNode: the node the run is paused before.
+	PausedNode string `json:"paused_node,omitempty"`
+
+	StartTimeMS int64 `json:"start_time_ms"`
+	EndTimeMS   int64 `json:"end_time_ms,omitempty"`
+	DurationMS  int64 `json:"duration_ms,omitempty"`
+
+	NodeCount  int        `json:"node_count"`
+	TokenUsage TokenUsage `json:"token_usage"`
+}
+
+type TokenUsage struct {
+	PromptTokens     int64 `json:"prompt_tokens"`
+	CompletionTokens int64 `json:"completion_tokens"`
+	TotalTokens      int64 `json:"total_tokens"`
+}
+
+type DebugRunDetail struct {
+	DebugRunSummary
+
+	Input string `json:"input,omitempty"`
+	// Timeline: the node executions, ordered by start time.
+	Timeline []*TimelineEntry `json:"timeline"`
+	// NodeTokenUsage: the token usage of each node reporting some.
+	NodeTokenUsage map[string]TokenUsage `json:"node_token_usage,omitempty"`
+}
+
+type TimelineEntry struct {
+	*NodeDebugState
+
+	// StartOffsetMS: the start of the node, relative to the start of the run.
+	StartOffsetMS int64 `json:"start_offset_ms"`
+	DurationMS    int64 `json:"duration_ms"`
+}
+
+func NewDebugRunSummary(run *model.DebugRun) *DebugRunSummary {
+	s := &DebugRunSummary{
+		DebugID:     run.DebugID,
+		GraphID:     run.Meta.GraphID,
+		ThreadID:    run.Meta.ThreadID,
+		FromNode:    run.Meta.FromNode,
+		Status:      string(run.Status),
+		Error:       run.Error,
+		PausedNode:  run.PausedNode,
+		StartTimeMS: run.StartTimeMS,
+		EndTimeMS:   run.EndTimeMS,
+		NodeCount:   len(run.NodeStates),
+	}
+	if run.EndTimeMS > 0 {
+		s.DurationMS = run.EndTimeMS - run.StartTimeMS
+	}
+	for _, state := range run.NodeStates {
+		s.TokenUsage.add(state.Metrics)
+	}
+	return s
+}
+
+func NewDebugRunDetail(run *model.DebugRun) *DebugRunDetail {
+	d := &DebugRunDetail{
+		DebugRunSummary: *NewDebugRunSummary(run),
+		Input:           run.Input,
+		Timeline:        make([]*TimelineEntry, 0, len(run.NodeStates)),
+	}
+
+	for _, state := range run.NodeStates {
+		entry := &TimelineEntry{NodeDebugState: DebugRunDataEVT(run.DebugID, state).Content}
+		if state.Metrics.InvokeTimeMS > 0 {
+			entry.StartOffsetMS = state.Metrics.InvokeTimeMS - run.StartTimeMS
+			if state.Metrics.CompletionTimeMS >= state.Metrics.InvokeTimeMS {
+				entry.DurationMS = state.Metrics.CompletionTimeMS - state.Metrics.InvokeTimeMS
+			}
+		}
+		d.Timeline = append(d.Timeline, entry)
+
+		if state.Metrics.PromptTokens > 0 || state.Metrics.CompletionTokens > 0 {
+			if d.NodeTokenUsage == nil {
+				d.NodeTokenUsage = make(map[string]TokenUsage)
+			}
+			usage := d.NodeTokenUsage[state.NodeKey]
+			usage.add(state.Metrics)
+			d.NodeTokenUsage[state.NodeKey] = usage
+		}
+	}
+	sort.SliceStable(d.Timeline, func(i, j int) bool {
+		return d.Timeline[i].StartOffsetMS < d.Timeline[j].StartOffsetMS
+	})
+	return d
+}
+
+func (t *TokenUsage) add(m model.NodeDebugMetrics) {
+	t.PromptTokens += m.PromptTokens
+	t.CompletionTokens += m.CompletionTokens
+	t.TotalTokens += m.PromptTokens + m.CompletionTokens
+}
@@ -87,3 +87,72 @@ func (mr *MockDebugServiceMockRecorder) DebugRun(ctx, m, userInput any) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DebugRun", reflect.TypeOf((*MockDebugService)(nil).DebugRun), ctx, m, userInput)
 }
+
+// GetBreakpoints mocks base method.
+func (m *MockDebugService) GetBreakpoints(graphID string) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBreakpoints", graphID)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetBreakpoints indicates an expected call of GetBreakpoints.
+func (mr *MockDebugServiceMockRecorder) GetBreakpoints(graphID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBreakpoints", reflect.TypeOf((*MockDebugService)(nil).GetBreakpoints), graphID)
+}
+
+// GetDebugRun mocks base method.
+func (m *MockDebugService) GetDebugRun(graphID, debugID string) (*model.DebugRun, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDebugRun", graphID, debugID)
+	ret0, _ := ret[0].(*model.DebugRun)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetDebugRun indicates an expected call of GetDebugRun.
+func (mr *MockDebugServiceMockRecorder) GetDebugRun(graphID, debugID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDebugRun", reflect.TypeOf((*MockDebugService)(nil).GetDebugRun), graphID, debugID)
+}
+
+// ListDebugRuns mocks base method.
+func (m *MockDebugService) ListDebugRuns(graphID string) []*model.DebugRun {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDebugRuns", graphID)
+	ret0, _ := ret[0].([]*model.DebugRun)
+	return ret0
+}
+
+// ListDebugRuns indicates an expected call of ListDebugRuns.
+func (mr *MockDebugServiceMockRecorder) ListDebugRuns(graphID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDebugRuns", reflect.TypeOf((*MockDebugService)(nil).ListDebugRuns), graphID)
+}
+
+// ResumeDebugRun mocks base method.
+func (m *MockDebugService) ResumeDebugRun(graphID, debugID string, cmd *model.ResumeCommand) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeDebugRun", graphID, debugID, cmd)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResumeDebugRun indicates an expected call of ResumeDebugRun.
+func (mr *MockDebugServiceMockRecorder) ResumeDebugRun(graphID, debugID, cmd any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeDebugRun", reflect.TypeOf((*MockDebugService)(nil).ResumeDebugRun), graphID, debugID, cmd)
+}
+
+// SetBreakpoints mocks base method.
+func (m *MockDebugService) SetBreakpoints(graphID string, nodeKeys []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetBreakpoints", graphID, nodeKeys)
+}
+
+// SetBreakpoints indicates an expected call of SetBreakpoints.
+func (mr *MockDebugServiceMockRecorder) SetBreakpoints(graphID, nodeKeys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBreakpoints", reflect.TypeOf((*MockDebugService)(nil).SetBreakpoints), graphID, nodeKeys)
+}
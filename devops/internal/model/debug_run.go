@@ -16,6 +16,10 @@
 
 package model
 
+import (
+	"sync"
+)
+
 type DebugGraph struct {
 	DT []*DebugThread
 }
@@ -37,6 +41,8 @@ type DebugThread struct {
 type NodeDebugState struct {
 	// NodeKey: from graph compile callback.
 	NodeKey string
+	// Component: the component of the node, e.g. ChatModel, from callback run info.
+	Component string
 
 	// Input: the input of the node, json marshal string.
 	Input string
@@ -46,6 +52,8 @@ type NodeDebugState struct {
 	Error string
 	// ErrorType: the type of error.
 	ErrorType ErrorType
+	// Paused: the run is paused before the node, which waits to be resumed.
+	Paused bool
 
 	Metrics NodeDebugMetrics
 }
@@ -70,3 +78,123 @@ const (
 	NodeError   ErrorType = "NodeError"
 	SystemError ErrorType = "SystemError"
 )
+
+type DebugRunStatus string
+
+const (
+	DebugRunRunning DebugRunStatus = "running"
+	DebugRunPaused  DebugRunStatus = "paused"
+	DebugRunSuccess DebugRunStatus = "success"
+	DebugRunFailed  DebugRunStatus = "failed"
+)
+
+// DebugRun records a debug run: its input, and the debug state of each node, in the order they completed.
+// It is safe for concurrent use, Snapshot returning a copy to read.
+type DebugRun struct {
+	mu sync.RWMutex
+
+	DebugID string
+	Meta    DebugRunMeta
+	// Input: the mock input of the run, json marshal string.
+	Input string
+
+	Status DebugRunStatus
+	// Error: the error of the run, plain text.
+	Error string
+
+	StartTimeMS int64
+	EndTimeMS   int64
+
+	// PausedNode: the node the run is paused before, empty if not paused.
+	PausedNode string
+
+	NodeStates []*NodeDebugState
+}
+
+func NewDebugRun(debugID string, meta DebugRunMeta, input string, startTimeMS int64) *DebugRun {
+	return &DebugRun{
+		DebugID:     debugID,
+		Meta:        meta,
+		Input:       input,
+		Status:      DebugRunRunning,
+		StartTimeMS: startTimeMS,
+	}
+}
+
+func (r *DebugRun) AddNodeState(state *NodeDebugState) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.NodeStates = append(r.NodeStates, state)
+}
+
+// Pause marks the run as paused before the node.
+func (r *DebugRun) Pause(nodeKey string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Status = DebugRunPaused
+	r.PausedNode = nodeKey
+}
+
+// Resume marks the paused run as running again.
+func (r *DebugRun) Resume() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.Status != DebugRunPaused {
+		return
+	}
+	r.Status = DebugRunRunning
+	r.PausedNode = ""
+}
+
+// Finish ends the run, failed if err is not nil.
+func (r *DebugRun) Finish(err error, endTimeMS int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.EndTimeMS = endTimeMS
+	r.PausedNode = ""
+	if err != nil {
+		r.Status = DebugRunFailed
+		r.Error = err.Error()
+		return
+	}
+	r.Status = DebugRunSuccess
+}
+
+// Snapshot returns a copy of the run as recorded so far.
+func (r *DebugRun) Snapshot() *DebugRun {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	states := make([]*NodeDebugState, 0, len(r.NodeStates))
+	for _, s := range r.NodeStates {
+		cp := *s
+		states = append(states, &cp)
+	}
+	return &DebugRun{
+		DebugID:     r.DebugID,
+		Meta:        r.Meta,
+		Input:       r.Input,
+		Status:      r.Status,
+		Error:       r.Error,
+		StartTimeMS: r.StartTimeMS,
+		EndTimeMS:   r.EndTimeMS,
+		PausedNode:  r.PausedNode,
+		NodeStates:  states,
+	}
+}
+
+type ResumeAction string
+
+const (
+	// ResumeContinue runs until the next breakpoint.
+	ResumeContinue ResumeAction = "continue"
+	// ResumeStep pauses again before the next node.
+	ResumeStep ResumeAction = "step"
+	// ResumeAbort cancels the run.
+	ResumeAbort ResumeAction = "abort"
+)
+
+type ResumeCommand struct {
+	Action ResumeAction
+	// Input: the edited input of the paused node, json marshal string, empty to keep the input.
+	Input string
+}
@@ -0,0 +1,210 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/cloudwego/eino-ext/devops/internal/model"
+)
+
+// debugSession controls the execution of a debug run: nodes with a breakpoint, or every node when stepping,
+// pause before they run until the run is resumed.
+// Nodes of parallel branches pause one after the other, the other branches going on meanwhile.
+type debugSession struct {
+	run          *model.DebugRun
+	isBreakpoint func(nodeKey string) bool
+	cancel       context.CancelFunc
+	stateCh      chan *model.NodeDebugState
+
+	// pauseMu serializes the pauses of parallel nodes.
+	pauseMu sync.Mutex
+
+	mu     sync.Mutex
+	step   bool
+	paused *pausedNode
+}
+
+type pausedNode struct {
+	nodeKey  string
+	edit     func(input string) error
+	resumeCh chan *model.ResumeCommand
+}
+
+func newDebugSession(run *model.DebugRun, isBreakpoint func(nodeKey string) bool, cancel context.CancelFunc,
+	stateCh chan *model.NodeDebugState) *debugSession {
+	return &debugSession{
+		run:          run,
+		isBreakpoint: isBreakpoint,
+		cancel:       cancel,
+		stateCh:      stateCh,
+	}
+}
+
+func (s *debugSession) shouldPause(nodeKey string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.step || s.isBreakpoint(nodeKey)
+}
+
+// pause blocks the node until the run is resumed, if the node has a breakpoint or the run is stepping.
+// edit applies the input edited while paused to the node. It returns the edited input, empty if not edited.
+func (s *debugSession) pause(ctx context.Context, state *model.NodeDebugState, edit func(input string) error) (input string, paused bool) {
+	if s == nil || !s.shouldPause(state.NodeKey) {
+		return "", false
+	}
+
+	s.pauseMu.Lock()
+	defer s.pauseMu.Unlock()
+
+	p := &pausedNode{
+		nodeKey:  state.NodeKey,
+		edit:     edit,
+		resumeCh: make(chan *model.ResumeCommand, 1),
+	}
+	s.mu.Lock()
+	s.paused = p
+	s.mu.Unlock()
+
+	s.run.Pause(state.NodeKey)
+	state.Paused = true
+	s.stateCh <- state
+
+	select {
+	case cmd := <-p.resumeCh:
+		return cmd.Input, true
+	case <-ctx.Done():
+		s.mu.Lock()
+		if s.paused == p {
+			s.paused = nil
+		}
+		s.mu.Unlock()
+		s.run.Resume()
+		return "", true
+	}
+}
+
+// resume resumes the paused run, the edited input being applied to the paused node first.
+// The run keeps paused if the input can not be applied.
+func (s *debugSession) resume(cmd *model.ResumeCommand) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.paused
+	if p == nil {
+		return fmt.Errorf("debug run=%s is not paused", s.run.DebugID)
+	}
+
+	switch cmd.Action {
+	case model.ResumeContinue, model.ResumeStep, model.ResumeAbort:
+	default:
+		return fmt.Errorf("unknown resume action=%s", cmd.Action)
+	}
+
+	if cmd.Input != "" && cmd.Action != model.ResumeAbort {
+		if err := p.edit(cmd.Input); err != nil {
+			return fmt.Errorf("edit input of node=%s failed, err=%w", p.nodeKey, err)
+		}
+	}
+
+	s.step = cmd.Action == model.ResumeStep
+	if cmd.Action == model.ResumeAbort {
+		// the graph stops before its next step, the paused node being run with a canceled context
+		s.cancel()
+	}
+
+	s.paused = nil
+	s.run.Resume()
+	p.resumeCh <- cmd
+	return nil
+}
+
+// editInput replaces the value of the node input in place with the edited json.
+// Only inputs which are pointers, maps, or slices of pointers of the same length can be edited,
+// the node holding the same references.
+func editInput(target any, inputKey string, input string) error {
+	data := []byte(input)
+	if len(inputKey) > 0 {
+		var wrapped map[string]json.RawMessage
+		if err := json.Unmarshal(data, &wrapped); err != nil {
+			return err
+		}
+		raw, ok := wrapped[inputKey]
+		if !ok {
+			return fmt.Errorf("input key=%s not found", inputKey)
+		}
+		data = raw
+	}
+
+	tv := reflect.ValueOf(target)
+	switch tv.Kind() {
+	case reflect.Ptr:
+		if tv.IsNil() {
+			return fmt.Errorf("input is nil")
+		}
+		nv := reflect.New(tv.Type().Elem())
+		if err := json.Unmarshal(data, nv.Interface()); err != nil {
+			return err
+		}
+		tv.Elem().Set(nv.Elem())
+
+	case reflect.Map:
+		if tv.IsNil() {
+			return fmt.Errorf("input is nil")
+		}
+		nv := reflect.New(tv.Type())
+		if err := json.Unmarshal(data, nv.Interface()); err != nil {
+			return err
+		}
+		for _, k := range tv.MapKeys() {
+			tv.SetMapIndex(k, reflect.Value{})
+		}
+		iter := nv.Elem().MapRange()
+		for iter.Next() {
+			tv.SetMapIndex(iter.Key(), iter.Value())
+		}
+
+	case reflect.Slice:
+		if tv.Type().Elem().Kind() != reflect.Ptr {
+			return fmt.Errorf("input of type=%s can not be edited", tv.Type())
+		}
+		nv := reflect.New(tv.Type())
+		if err := json.Unmarshal(data, nv.Interface()); err != nil {
+			return err
+		}
+		if nv.Elem().Len() != tv.Len() {
+			return fmt.Errorf("input length can not be changed, expected=%d, got=%d", tv.Len(), nv.Elem().Len())
+		}
+		for i := 0; i < tv.Len(); i++ {
+			if tv.Index(i).IsNil() || nv.Elem().Index(i).IsNil() {
+				return fmt.Errorf("input element=%d is nil", i)
+			}
+		}
+		for i := 0; i < tv.Len(); i++ {
+			tv.Index(i).Elem().Set(nv.Elem().Index(i).Elem())
+		}
+
+	default:
+		return fmt.Errorf("input of type=%s can not be edited", tv.Type())
+	}
+
+	return nil
+}
@@ -0,0 +1,199 @@
+/*
+ * Copyright 2024 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-ext/devops/internal/model"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+)
+
+func Test_debugServiceImpl_Breakpoints(t *testing.T) {
+	svc := newDebugService()
+	svc.SetBreakpoints("g1", []string{"n2", "n1", "n2"})
+	assert.Equal(t, []string{"n1", "n2"}, svc.GetBreakpoints("g1"))
+	assert.Len(t, svc.GetBreakpoints("g2"), 0)
+
+	impl := svc.(*debugServiceImpl)
+	assert.True(t, impl.isBreakpoint("g1", "n1"))
+	assert.False(t, impl.isBreakpoint("g2", "n1"))
+
+	svc.SetBreakpoints("g1", nil)
+	assert.Len(t, svc.GetBreakpoints("g1"), 0)
+
+	err := svc.ResumeDebugRun("g1", "unknown", &model.ResumeCommand{Action: model.ResumeContinue})
+	assert.Error(t, err)
+}
+
+func Test_debugSession(t *testing.T) {
+	run := model.NewDebugRun("d1", model.DebugRunMeta{GraphID: "g1"}, "{}", 100)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stateCh := make(chan *model.NodeDebugState, 10)
+	session := newDebugSession(run, func(nodeKey string) bool {
+		return nodeKey == "n1"
+	}, cancel, stateCh)
+
+	t.Run("no breakpoint", func(t *testing.T) {
+		_, paused := session.pause(ctx, &model.NodeDebugState{NodeKey: "n0"}, nil)
+		assert.False(t, paused)
+		assert.Error(t, session.resume(&model.ResumeCommand{Action: model.ResumeContinue}))
+	})
+
+	t.Run("step then continue", func(t *testing.T) {
+		msg := &schema.Message{Role: schema.User, Content: "hello"}
+		done := make(chan string, 1)
+		go func() {
+			input, _ := session.pause(ctx, &model.NodeDebugState{NodeKey: "n1"}, func(in string) error {
+				return editInput(msg, "", in)
+			})
+			done <- input
+		}()
+
+		state := <-stateCh
+		assert.True(t, state.Paused)
+		assert.Equal(t, "n1", state.NodeKey)
+		assert.Equal(t, model.DebugRunPaused, run.Snapshot().Status)
+		assert.Equal(t, "n1", run.Snapshot().PausedNode)
+
+		assert.Error(t, session.resume(&model.ResumeCommand{Action: "unknown"}))
+		assert.Error(t, session.resume(&model.ResumeCommand{Action: model.ResumeStep, Input: "{"}))
+		assert.Nil(t, session.resume(&model.ResumeCommand{Action: model.ResumeStep, Input: `{"role":"user","content":"hi"}`}))
+		assert.Equal(t, `{"role":"user","content":"hi"}`, <-done)
+		assert.Equal(t, "hi", msg.Content)
+		assert.Equal(t, model.DebugRunRunning, run.Snapshot().Status)
+
+		// stepping pauses before any node
+		go func() {
+			input, _ := session.pause(ctx, &model.NodeDebugState{NodeKey: "n2"}, nil)
+			done <- input
+		}()
+		state = <-stateCh
+		assert.Equal(t, "n2", state.NodeKey)
+		assert.Nil(t, session.resume(&model.ResumeCommand{Action: model.ResumeContinue}))
+		assert.Equal(t, "", <-done)
+
+		_, paused := session.pause(ctx, &model.NodeDebugState{NodeKey: "n2"}, nil)
+		assert.False(t, paused)
+	})
+
+	t.Run("abort", func(t *testing.T) {
+		done := make(chan bool, 1)
+		go func() {
+			_, paused := session.pause(ctx, &model.NodeDebugState{NodeKey: "n1"}, nil)
+			done <- paused
+		}()
+		<-stateCh
+		assert.Nil(t, session.resume(&model.ResumeCommand{Action: model.ResumeAbort, Input: "ignored"}))
+		assert.True(t, <-done)
+		assert.ErrorIs(t, ctx.Err(), context.Canceled)
+
+		// pausing ends with the run
+		go func() {
+			_, paused := session.pause(ctx, &model.NodeDebugState{NodeKey: "n1"}, nil)
+			done <- paused
+		}()
+		<-stateCh
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("pause not ended by the canceled run")
+		}
+		assert.Error(t, session.resume(&model.ResumeCommand{Action: model.ResumeContinue}))
+	})
+}
+
+func Test_debugSession_graph(t *testing.T) {
+	g := compose.NewGraph[map[string]any, map[string]any]()
+	echo := compose.InvokableLambda(func(ctx context.Context, in map[string]any) (map[string]any, error) {
+		return in, nil
+	})
+	assert.Nil(t, g.AddLambdaNode("n1", echo))
+	assert.Nil(t, g.AddLambdaNode("n2", echo))
+	assert.Nil(t, g.AddEdge(compose.START, "n1"))
+	assert.Nil(t, g.AddEdge("n1", "n2"))
+	assert.Nil(t, g.AddEdge("n2", compose.END))
+	r, err := g.Compile(context.Background())
+	assert.Nil(t, err)
+
+	gi := &model.GraphInfo{
+		GraphInfo: &compose.GraphInfo{
+			Nodes: map[string]compose.GraphNodeInfo{
+				"n1": {Component: compose.ComponentOfLambda},
+				"n2": {Component: compose.ComponentOfLambda},
+			},
+		},
+	}
+	run := model.NewDebugRun("d1", model.DebugRunMeta{GraphID: "g1"}, "{}", 100)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stateCh := make(chan *model.NodeDebugState, 10)
+	session := newDebugSession(run, func(nodeKey string) bool {
+		return nodeKey == "n2"
+	}, cancel, stateCh)
+	opts, err := newDebugService().(*debugServiceImpl).getInvokeOptions(gi, "t1", session, stateCh)
+	assert.Nil(t, err)
+
+	outCh := make(chan map[string]any, 1)
+	go func() {
+		out, _ := r.Invoke(ctx, map[string]any{"q": "hello"}, opts...)
+		outCh <- out
+	}()
+
+	state := <-stateCh
+	assert.Equal(t, "n1", state.NodeKey)
+	assert.False(t, state.Paused)
+	state = <-stateCh
+	assert.Equal(t, "n2", state.NodeKey)
+	assert.True(t, state.Paused)
+	assert.Equal(t, `{"q":"hello"}`, state.Input)
+
+	assert.Nil(t, session.resume(&model.ResumeCommand{Action: model.ResumeContinue, Input: `{"q":"edited"}`}))
+	assert.Equal(t, map[string]any{"q": "edited"}, <-outCh)
+	state = <-stateCh
+	assert.Equal(t, "n2", state.NodeKey)
+	assert.Equal(t, `{"q":"edited"}`, state.Input)
+}
+
+func Test_editInput(t *testing.T) {
+	msg := &schema.Message{Role: schema.User, Content: "hello"}
+	assert.Nil(t, editInput(msg, "", `{"role":"user","content":"hi"}`))
+	assert.Equal(t, "hi", msg.Content)
+
+	vs := map[string]any{"a": 1, "b": 2}
+	assert.Nil(t, editInput(vs, "", `{"a":"x"}`))
+	assert.Equal(t, map[string]any{"a": "x"}, vs)
+
+	msgs := []*schema.Message{{Role: schema.System, Content: "s"}, {Role: schema.User, Content: "u"}}
+	assert.Nil(t, editInput(msgs, "", `[{"role":"system","content":"s2"},{"role":"user","content":"u2"}]`))
+	assert.Equal(t, "s2", msgs[0].Content)
+	assert.Equal(t, "u2", msgs[1].Content)
+	assert.Error(t, editInput(msgs, "", `[{"role":"user","content":"u3"}]`))
+
+	assert.Nil(t, editInput(msg, "query", `{"query":{"role":"user","content":"wrapped"}}`))
+	assert.Equal(t, "wrapped", msg.Content)
+	assert.Error(t, editInput(msg, "query", `{"other":{}}`))
+
+	assert.Error(t, editInput("query", "", `"new query"`))
+	assert.Error(t, editInput([]string{"a"}, "", `["b"]`))
+}
@@ -36,12 +36,13 @@ import (
 	"github.com/cloudwego/eino/schema"
 )
 
-func newCallbackOption(nodeKey, threadID string, node compose.GraphNodeInfo, stateCh chan *model.NodeDebugState) compose.Option {
+func newCallbackOption(nodeKey, threadID string, node compose.GraphNodeInfo, session *debugSession, stateCh chan *model.NodeDebugState) compose.Option {
 	cb := &callbackHandler{
 		nodeKey:  nodeKey,
 		threadID: threadID,
 		stateCh:  stateCh,
 		node:     node,
+		session:  session,
 	}
 	op := compose.WithCallbacks(cb).DesignateNode(nodeKey)
 	return op
@@ -52,6 +53,8 @@ type callbackHandler struct {
 	stateCh  chan *model.NodeDebugState
 	threadID string
 	node     compose.GraphNodeInfo
+	// session pauses the node on breakpoints, nil if the run is not controlled.
+	session *debugSession
 }
 
 func (c *callbackHandler) OnStart(ctx context.Context, info *callbacks.RunInfo, input callbacks.CallbackInput) context.Context {
@@ -82,6 +85,17 @@ func (c *callbackHandler) OnStart(ctx context.Context, info *callbacks.RunInfo,
 		return ctx
 	}
 
+	edited, paused := c.session.pause(ctx, c.pausedState(info, string(jsonInput), invokeTime), func(in string) error {
+		return editInput(c.convCallbackInput(input), c.node.InputKey, in)
+	})
+	if paused {
+		// the node runs once resumed
+		invokeTime = time.Now().UnixMilli()
+	}
+	if edited != "" {
+		jsonInput = []byte(edited)
+	}
+
 	return setNodeDebugStateCtx(ctx, &nodeDebugStateCtxValue{
 		invokeTimeMS:  invokeTime,
 		callbackInput: string(jsonInput),
@@ -126,9 +140,10 @@ func (c *callbackHandler) OnEnd(ctx context.Context, info *callbacks.RunInfo, ou
 	}
 
 	state := &model.NodeDebugState{
-		NodeKey: c.nodeKey,
-		Input:   jsonInput,
-		Output:  string(jsonOutput),
+		NodeKey:   c.nodeKey,
+		Component: componentOf(info),
+		Input:     jsonInput,
+		Output:    string(jsonOutput),
 		Metrics: model.NodeDebugMetrics{
 			InvokeTimeMS:     invokeTime,
 			CompletionTimeMS: completionTime,
@@ -139,7 +154,7 @@ func (c *callbackHandler) OnEnd(ctx context.Context, info *callbacks.RunInfo, ou
 	ext := c.ConvCallbackOutput(output)
 	if ext != nil && ext.TokenUsage != nil {
 		state.Metrics.PromptTokens = int64(ext.TokenUsage.PromptTokens)
-		state.Metrics.CompletionTokens = int64(ext.TokenUsage.PromptTokens)
+		state.Metrics.CompletionTokens = int64(ext.TokenUsage.CompletionTokens)
 	}
 
 	// append result
@@ -160,6 +175,7 @@ func (c *callbackHandler) OnError(ctx context.Context, info *callbacks.RunInfo,
 
 	state := &model.NodeDebugState{
 		NodeKey:   c.nodeKey,
+		Component: componentOf(info),
 		Input:     jsonInput,
 		Error:     err.Error(),
 		ErrorType: model.NodeError,
@@ -196,6 +212,13 @@ func (c *callbackHandler) OnStartWithStreamInput(ctx context.Context, info *call
 		return ctx
 	}
 
+	_, paused := c.session.pause(ctx, c.pausedState(info, string(jsonData), invokeTime), func(string) error {
+		return fmt.Errorf("stream input can not be edited")
+	})
+	if paused {
+		invokeTime = time.Now().UnixMilli()
+	}
+
 	return setNodeDebugStateCtx(ctx, &nodeDebugStateCtxValue{
 		invokeTimeMS:  invokeTime,
 		callbackInput: string(jsonData),
@@ -227,6 +250,7 @@ func (c *callbackHandler) OnEndWithStreamOutput(ctx context.Context, info *callb
 	}
 
 	state.NodeKey = c.nodeKey
+	state.Component = componentOf(info)
 	state.Input = jsonInput
 	state.Metrics.InvokeTimeMS = startTime
 	state.Metrics.CompletionTimeMS = time.Now().UnixMilli()
@@ -288,6 +312,17 @@ func (c *callbackHandler) parseDefaultStreamOutput(ctx context.Context, output *
 	return state, nil
 }
 
+func (c *callbackHandler) pausedState(info *callbacks.RunInfo, jsonInput string, invokeTime int64) *model.NodeDebugState {
+	return &model.NodeDebugState{
+		NodeKey:   c.nodeKey,
+		Component: componentOf(info),
+		Input:     jsonInput,
+		Metrics: model.NodeDebugMetrics{
+			InvokeTimeMS: invokeTime,
+		},
+	}
+}
+
 func (c *callbackHandler) ConvCallbackOutput(src callbacks.CallbackOutput) *einomodel.CallbackOutput {
 	switch t := src.(type) {
 	case *einomodel.CallbackOutput:
@@ -315,6 +350,14 @@ func (c *callbackHandler) systemErrorProcess(errorStr string, invokeTime, comple
 	c.stateCh <- state
 }
 
+// componentOf returns the component of the node from its run info, e.g. ChatModel or Lambda.
+func componentOf(info *callbacks.RunInfo) string {
+	if info == nil {
+		return ""
+	}
+	return string(info.Component)
+}
+
 type nodeDebugStateCtxKey struct{}
 
 type nodeDebugStateCtxValue struct {
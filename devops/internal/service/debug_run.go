@@ -19,7 +19,9 @@ package service
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/matoous/go-nanoid"
 
@@ -37,18 +39,43 @@ var _ DebugService = &debugServiceImpl{}
 type DebugService interface {
 	CreateDebugThread(ctx context.Context, graphID string) (threadID string, err error)
 	DebugRun(ctx context.Context, m *model.DebugRunMeta, userInput string) (debugID string, stateCh chan *model.NodeDebugState, errCh chan error, err error)
+	// ListDebugRuns returns the snapshots of the recorded runs of a graph, the latest first.
+	ListDebugRuns(graphID string) []*model.DebugRun
+	// GetDebugRun returns the snapshot of a recorded run of a graph.
+	GetDebugRun(graphID, debugID string) (run *model.DebugRun, exist bool)
+	// SetBreakpoints sets the nodes of a graph its debug runs pause before, replacing the previous ones.
+	SetBreakpoints(graphID string, nodeKeys []string)
+	// GetBreakpoints returns the nodes of a graph its debug runs pause before.
+	GetBreakpoints(graphID string) (nodeKeys []string)
+	// ResumeDebugRun resumes a paused debug run of a graph.
+	ResumeDebugRun(graphID, debugID string, cmd *model.ResumeCommand) error
 }
 
+// maxDebugRuns is the number of runs recorded, the oldest being evicted.
+const maxDebugRuns = 100
+
 type debugServiceImpl struct {
 	mu sync.RWMutex
 	// debugGraphs: graphID vs DebugGraph
 	debugGraphs map[string]*model.DebugGraph
+
+	// breakpoints: graphID vs the node keys with a breakpoint
+	breakpoints map[string]map[string]bool
+
+	runsMu sync.RWMutex
+	// runs: the recorded runs, in start order
+	runs []*model.DebugRun
+	// sessions: debugID vs the session of the ongoing runs
+	sessions map[string]*debugSession
 }
 
 func newDebugService() DebugService {
 	return &debugServiceImpl{
 		mu:          sync.RWMutex{},
 		debugGraphs: make(map[string]*model.DebugGraph, 10),
+		breakpoints: make(map[string]map[string]bool, 10),
+		runs:        make([]*model.DebugRun, 0, maxDebugRuns),
+		sessions:    make(map[string]*debugSession, 10),
 	}
 }
 
@@ -110,29 +137,59 @@ func (d *debugServiceImpl) DebugRun(ctx context.Context, rm *model.DebugRunMeta,
 		return "", nil, nil, err
 	}
 
-	stateCh = make(chan *model.NodeDebugState, 100)
+	run := model.NewDebugRun(debugID, *rm, userInput, time.Now().UnixMilli())
+
+	// the node states are recorded in the run before being forwarded to stateCh
+	nodeStateCh := make(chan *model.NodeDebugState, 100)
+	ctx, cancel := context.WithCancel(ctx)
+	session := newDebugSession(run, func(nodeKey string) bool {
+		return d.isBreakpoint(rm.GraphID, nodeKey)
+	}, cancel, nodeStateCh)
 
-	opts, err := d.getInvokeOptions(devGraph.GraphInfo, rm.ThreadID, stateCh)
+	opts, err := d.getInvokeOptions(devGraph.GraphInfo, rm.ThreadID, session, nodeStateCh)
 	if err != nil {
-		close(stateCh)
+		cancel()
 		return "", nil, nil, fmt.Errorf("get invoke option failed, err=%w", err)
 	}
 
-	errCh = make(chan error, 1)
+	d.addDebugRun(run, session)
+
+	// runErr is set before nodeStateCh is closed, the run being finished once all the node states are recorded
+	var runErr error
+	stateCh = make(chan *model.NodeDebugState, 100)
 	safego.Go(ctx, func() {
 		defer close(stateCh)
-		defer close(errCh)
+		for state := range nodeStateCh {
+			if !state.Paused {
+				run.AddNodeState(state)
+			}
+			stateCh <- state
+		}
+		d.removeDebugSession(debugID)
+		cancel()
+		run.Finish(runErr, time.Now().UnixMilli())
+	})
+
+	errCh = make(chan error, 1)
+	safego.Go(ctx, func() {
+		var e error
+		defer func() {
+			runErr = e
+			close(nodeStateCh)
+			if e != nil {
+				errCh <- e
+			}
+			close(errCh)
+		}()
 
 		r, e := devGraph.Compile()
 		if e != nil {
-			errCh <- e
 			log.Errorf("Compile failed, fromNode=%s\nerr=%s", rm.FromNode, e)
 			return
 		}
 
 		_, e = r.Invoke(ctx, input, opts...)
 		if e != nil {
-			errCh <- e
 			log.Errorf("invoke failed, userInput=%s\nerr=%s", userInput, e)
 			return
 		}
@@ -141,10 +198,94 @@ func (d *debugServiceImpl) DebugRun(ctx context.Context, rm *model.DebugRunMeta,
 	return debugID, stateCh, errCh, nil
 }
 
-func (d *debugServiceImpl) getInvokeOptions(gi *model.GraphInfo, threadID string, stateCh chan *model.NodeDebugState) (opts []compose.Option, err error) {
+func (d *debugServiceImpl) addDebugRun(run *model.DebugRun, session *debugSession) {
+	d.runsMu.Lock()
+	defer d.runsMu.Unlock()
+	if len(d.runs) >= maxDebugRuns {
+		d.runs[0] = nil
+		d.runs = d.runs[1:]
+	}
+	d.runs = append(d.runs, run)
+	if session != nil {
+		d.sessions[run.DebugID] = session
+	}
+}
+
+func (d *debugServiceImpl) removeDebugSession(debugID string) {
+	d.runsMu.Lock()
+	defer d.runsMu.Unlock()
+	delete(d.sessions, debugID)
+}
+
+func (d *debugServiceImpl) ListDebugRuns(graphID string) []*model.DebugRun {
+	d.runsMu.RLock()
+	defer d.runsMu.RUnlock()
+	runs := make([]*model.DebugRun, 0, len(d.runs))
+	for i := len(d.runs) - 1; i >= 0; i-- {
+		if d.runs[i].Meta.GraphID == graphID {
+			runs = append(runs, d.runs[i].Snapshot())
+		}
+	}
+	return runs
+}
+
+func (d *debugServiceImpl) GetDebugRun(graphID, debugID string) (*model.DebugRun, bool) {
+	d.runsMu.RLock()
+	defer d.runsMu.RUnlock()
+	for _, run := range d.runs {
+		if run.DebugID == debugID && run.Meta.GraphID == graphID {
+			return run.Snapshot(), true
+		}
+	}
+	return nil, false
+}
+
+func (d *debugServiceImpl) SetBreakpoints(graphID string, nodeKeys []string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(nodeKeys) == 0 {
+		delete(d.breakpoints, graphID)
+		return
+	}
+	bps := make(map[string]bool, len(nodeKeys))
+	for _, key := range nodeKeys {
+		bps[key] = true
+	}
+	d.breakpoints[graphID] = bps
+}
+
+func (d *debugServiceImpl) GetBreakpoints(graphID string) []string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	nodeKeys := make([]string, 0, len(d.breakpoints[graphID]))
+	for key := range d.breakpoints[graphID] {
+		nodeKeys = append(nodeKeys, key)
+	}
+	sort.Strings(nodeKeys)
+	return nodeKeys
+}
+
+func (d *debugServiceImpl) isBreakpoint(graphID, nodeKey string) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.breakpoints[graphID][nodeKey]
+}
+
+func (d *debugServiceImpl) ResumeDebugRun(graphID, debugID string, cmd *model.ResumeCommand) error {
+	d.runsMu.RLock()
+	session := d.sessions[debugID]
+	d.runsMu.RUnlock()
+	if session == nil || session.run.Meta.GraphID != graphID {
+		return fmt.Errorf("debug run=%s of graph=%s not running", debugID, graphID)
+	}
+	return session.resume(cmd)
+}
+
+func (d *debugServiceImpl) getInvokeOptions(gi *model.GraphInfo, threadID string, session *debugSession,
+	stateCh chan *model.NodeDebugState) (opts []compose.Option, err error) {
 	opts = make([]compose.Option, 0, len(gi.Nodes))
 	for key, node := range gi.Nodes {
-		opts = append(opts, newCallbackOption(key, threadID, node, stateCh))
+		opts = append(opts, newCallbackOption(key, threadID, node, session, stateCh))
 	}
 
 	return opts, nil
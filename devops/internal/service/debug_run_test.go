@@ -17,6 +17,8 @@
 package service
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/cloudwego/eino-ext/devops/internal/model"
@@ -45,7 +47,47 @@ func Test_debugServiceImpl_getInvokeOptions(t *testing.T) {
 	svc := newDebugService()
 	impl, ok := svc.(*debugServiceImpl)
 	assert.True(t, ok)
-	opts, err := impl.getInvokeOptions(gi, "t1", nil)
+	opts, err := impl.getInvokeOptions(gi, "t1", nil, nil)
 	assert.Nil(t, err)
 	assert.NotNil(t, opts)
 }
+
+func Test_debugServiceImpl_DebugRuns(t *testing.T) {
+	svc := newDebugService()
+	impl, ok := svc.(*debugServiceImpl)
+	assert.True(t, ok)
+
+	run := model.NewDebugRun("d1", model.DebugRunMeta{GraphID: "g1", ThreadID: "t1", FromNode: compose.START}, "{}", 100)
+	impl.addDebugRun(run, nil)
+	impl.addDebugRun(model.NewDebugRun("d2", model.DebugRunMeta{GraphID: "g2"}, "{}", 101), nil)
+	impl.addDebugRun(model.NewDebugRun("d3", model.DebugRunMeta{GraphID: "g1"}, "{}", 102), nil)
+
+	run.AddNodeState(&model.NodeDebugState{NodeKey: "n1"})
+	run.Finish(errors.New("boom"), 200)
+
+	runs := svc.ListDebugRuns("g1")
+	assert.Len(t, runs, 2)
+	assert.Equal(t, "d3", runs[0].DebugID)
+	assert.Equal(t, model.DebugRunRunning, runs[0].Status)
+	assert.Equal(t, "d1", runs[1].DebugID)
+	assert.Equal(t, model.DebugRunFailed, runs[1].Status)
+	assert.Equal(t, "boom", runs[1].Error)
+	assert.Equal(t, int64(200), runs[1].EndTimeMS)
+	assert.Len(t, runs[1].NodeStates, 1)
+
+	// snapshots are not updated by the run
+	run.AddNodeState(&model.NodeDebugState{NodeKey: "n2"})
+	assert.Len(t, runs[1].NodeStates, 1)
+
+	got, ok := svc.GetDebugRun("g1", "d1")
+	assert.True(t, ok)
+	assert.Len(t, got.NodeStates, 2)
+	_, ok = svc.GetDebugRun("g2", "d1")
+	assert.False(t, ok)
+
+	for i := 0; i < maxDebugRuns; i++ {
+		impl.addDebugRun(model.NewDebugRun(fmt.Sprintf("e%d", i), model.DebugRunMeta{GraphID: "g3"}, "{}", 300), nil)
+	}
+	assert.Len(t, svc.ListDebugRuns("g3"), maxDebugRuns)
+	assert.Len(t, svc.ListDebugRuns("g1"), 0)
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checkpoint
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodec(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("round trip", func(t *testing.T) {
+		c := &Codec{Version: "v1"}
+		got, err := c.Decode(ctx, c.Encode([]byte("state")))
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("state"), got)
+
+		var nilCodec *Codec
+		got, err = nilCodec.Decode(ctx, nilCodec.Encode([]byte("state")))
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("state"), got)
+
+		got, err = c.Decode(ctx, c.Encode(nil))
+		assert.NoError(t, err)
+		assert.Len(t, got, 0)
+	})
+
+	t.Run("version mismatch", func(t *testing.T) {
+		record := (&Codec{Version: "v1"}).Encode([]byte("state"))
+		_, err := (&Codec{Version: "v2"}).Decode(ctx, record)
+		assert.ErrorIs(t, err, ErrVersionMismatch)
+		var nilCodec *Codec
+		_, err = nilCodec.Decode(ctx, record)
+		assert.ErrorIs(t, err, ErrVersionMismatch)
+	})
+
+	t.Run("migrate", func(t *testing.T) {
+		record := (&Codec{Version: "v1"}).Encode([]byte("state"))
+		c := &Codec{
+			Version: "v2",
+			Migrate: func(ctx context.Context, version string, checkPoint []byte) ([]byte, error) {
+				if version != "v1" {
+					return nil, errors.New("unknown version")
+				}
+				return append(checkPoint, "-v2"...), nil
+			},
+		}
+		got, err := c.Decode(ctx, record)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("state-v2"), got)
+
+		_, err = c.Decode(ctx, (&Codec{Version: "v0"}).Encode([]byte("state")))
+		assert.ErrorContains(t, err, "unknown version")
+	})
+
+	t.Run("invalid record", func(t *testing.T) {
+		c := &Codec{}
+		for _, record := range [][]byte{nil, []byte("state"), []byte("ECKP"), []byte("ECKP\x02"), []byte("ECKP\x01\x05v1")} {
+			_, err := c.Decode(ctx, record)
+			assert.ErrorIs(t, err, ErrInvalidRecord)
+		}
+	})
+}
+
+type cleanerFunc func(ctx context.Context) (int64, error)
+
+func (f cleanerFunc) Cleanup(ctx context.Context) (int64, error) {
+	return f(ctx)
+}
+
+func TestStartCleanup(t *testing.T) {
+	var calls, errs atomic.Int32
+	stop := StartCleanup(context.Background(), cleanerFunc(func(ctx context.Context) (int64, error) {
+		calls.Add(1)
+		return 0, errors.New("boom")
+	}), 5*time.Millisecond, func(err error) {
+		errs.Add(1)
+	})
+
+	assert.Eventually(t, func() bool {
+		return errs.Load() >= 2
+	}, time.Second, time.Millisecond)
+	stop()
+	time.Sleep(20 * time.Millisecond)
+	n := calls.Load()
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, n, calls.Load())
+}
@@ -0,0 +1,49 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checkpoint
+
+import (
+	"context"
+	"time"
+)
+
+// Cleaner is a store which deletes its expired checkpoints on demand, having no native expiration.
+type Cleaner interface {
+	// Cleanup deletes the expired checkpoints, returning the number deleted.
+	Cleanup(ctx context.Context) (deleted int64, err error)
+}
+
+// StartCleanup cleans up the expired checkpoints of the store every interval, until ctx is done or stop is called.
+// onError is called with the errors of the cleanups, optional.
+func StartCleanup(ctx context.Context, c Cleaner, interval time.Duration, onError func(err error)) (stop func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := c.Cleanup(ctx); err != nil && onError != nil && ctx.Err() == nil {
+					onError(err)
+				}
+			}
+		}
+	}()
+	return cancel
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package checkpoint provides the versioned record format and the ttl cleanup shared by the checkpoint stores
+// of eino graphs, the stores themselves being in the sub packages, e.g. redis, postgres and s3.
+package checkpoint
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrVersionMismatch is returned when reading a checkpoint written by another version with no Migrate.
+	ErrVersionMismatch = errors.New("checkpoint version mismatch")
+	// ErrInvalidRecord is returned when reading data which is not a checkpoint record.
+	ErrInvalidRecord = errors.New("invalid checkpoint record")
+)
+
+// recordMagic starts each record, followed by the format version of the record.
+var recordMagic = []byte("ECKP")
+
+const formatVersion byte = 1
+
+// Codec stamps the checkpoints with a version when written, those written by another version being
+// migrated or rejected when read.
+// A nil *Codec is valid, the checkpoints being stamped with an empty version.
+type Codec struct {
+	// Version of the checkpoints, to change when the graph or its state change incompatibly, e.g. "v2".
+	// Optional.
+	Version string
+	// Migrate converts a checkpoint written by another version to the current one.
+	// Optional. Default: such checkpoints are rejected with ErrVersionMismatch.
+	Migrate func(ctx context.Context, version string, checkPoint []byte) ([]byte, error)
+}
+
+// Encode wraps the checkpoint serialized by eino in a record stamped with the version.
+func (c *Codec) Encode(checkPoint []byte) []byte {
+	version := c.version()
+	buf := bytes.NewBuffer(make([]byte, 0, len(recordMagic)+1+binary.MaxVarintLen64+len(version)+len(checkPoint)))
+	buf.Write(recordMagic)
+	buf.WriteByte(formatVersion)
+	buf.Write(binary.AppendUvarint(nil, uint64(len(version))))
+	buf.WriteString(version)
+	buf.Write(checkPoint)
+	return buf.Bytes()
+}
+
+// Decode unwraps the checkpoint of a record, migrating it if written by another version.
+func (c *Codec) Decode(ctx context.Context, record []byte) ([]byte, error) {
+	version, checkPoint, err := parseRecord(record)
+	if err != nil {
+		return nil, err
+	}
+	if version == c.version() {
+		return checkPoint, nil
+	}
+	if c == nil || c.Migrate == nil {
+		return nil, fmt.Errorf("%w: written by %q, expected %q", ErrVersionMismatch, version, c.version())
+	}
+	checkPoint, err = c.Migrate(ctx, version, checkPoint)
+	if err != nil {
+		return nil, fmt.Errorf("migrate checkpoint of version %q fail: %w", version, err)
+	}
+	return checkPoint, nil
+}
+
+func (c *Codec) version() string {
+	if c == nil {
+		return ""
+	}
+	return c.Version
+}
+
+func parseRecord(record []byte) (version string, checkPoint []byte, err error) {
+	if !bytes.HasPrefix(record, recordMagic) || len(record) < len(recordMagic)+1 {
+		return "", nil, ErrInvalidRecord
+	}
+	record = record[len(recordMagic):]
+	if record[0] != formatVersion {
+		return "", nil, fmt.Errorf("%w: unsupported format version %d", ErrInvalidRecord, record[0])
+	}
+	record = record[1:]
+
+	n, size := binary.Uvarint(record)
+	if size <= 0 || uint64(len(record)-size) < n {
+		return "", nil, ErrInvalidRecord
+	}
+	record = record[size:]
+	return string(record[:n]), record[n:], nil
+}
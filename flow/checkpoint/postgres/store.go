@@ -0,0 +1,163 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/cloudwego/eino/compose"
+
+	"github.com/cloudwego/eino-ext/flow/checkpoint"
+)
+
+type Config struct {
+	// DB is the database, opened with a postgres driver, e.g. sql.Open("pgx", dsn) with github.com/jackc/pgx/v5/stdlib.
+	// Required
+	DB *sql.DB
+	// Table keeping the checkpoints, created by CreateTable.
+	// Optional. Default: "eino_checkpoints"
+	Table string
+	// TTL expires the checkpoints ttl after their last write, expired checkpoints being deleted by Cleanup.
+	// Optional. Default: no expiration
+	TTL time.Duration
+	// Codec stamps the checkpoints with a version, migrating or rejecting those of other versions when read.
+	// Optional. Default: checkpoints stamped with an empty version
+	Codec *checkpoint.Codec
+}
+
+// Store is a compose.CheckPointStore keeping each checkpoint in a row of a postgres table.
+type Store struct {
+	db    *sql.DB
+	table string
+	ttl   time.Duration
+	codec *checkpoint.Codec
+	now   func() time.Time
+}
+
+var (
+	_ compose.CheckPointStore = (*Store)(nil)
+	_ checkpoint.Cleaner      = (*Store)(nil)
+)
+
+var tableNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+func NewStore(config *Config) (*Store, error) {
+	if config == nil || config.DB == nil {
+		return nil, errors.New("db is required")
+	}
+	table := config.Table
+	if table == "" {
+		table = "eino_checkpoints"
+	}
+	if !tableNameRegexp.MatchString(table) {
+		return nil, fmt.Errorf("invalid table name: %s", table)
+	}
+	return &Store{
+		db:    config.DB,
+		table: table,
+		ttl:   config.TTL,
+		codec: config.Codec,
+		now:   time.Now,
+	}, nil
+}
+
+// CreateTable creates the table of the checkpoints and the index of their expiration if not existing.
+func (s *Store) CreateTable(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	id TEXT PRIMARY KEY,
+	data BYTEA NOT NULL,
+	updated_at TIMESTAMPTZ NOT NULL,
+	expires_at TIMESTAMPTZ
+)`, s.table))
+	if err != nil {
+		return fmt.Errorf("create checkpoint table fail: %w", err)
+	}
+	_, err = s.db.ExecContext(ctx, fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %s_expires_at_idx ON %s (expires_at)`,
+		indexPrefix(s.table), s.table))
+	if err != nil {
+		return fmt.Errorf("create checkpoint index fail: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) Get(ctx context.Context, checkPointID string) ([]byte, bool, error) {
+	var record []byte
+	err := s.db.QueryRowContext(ctx,
+		fmt.Sprintf(`SELECT data FROM %s WHERE id = $1 AND (expires_at IS NULL OR expires_at > $2)`, s.table),
+		checkPointID, s.now()).Scan(&record)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("get checkpoint fail: %w", err)
+	}
+
+	checkPoint, err := s.codec.Decode(ctx, record)
+	if err != nil {
+		return nil, false, fmt.Errorf("decode checkpoint %s fail: %w", checkPointID, err)
+	}
+	return checkPoint, true, nil
+}
+
+func (s *Store) Set(ctx context.Context, checkPointID string, checkPoint []byte) error {
+	now := s.now()
+	var expiresAt sql.NullTime
+	if s.ttl > 0 {
+		expiresAt = sql.NullTime{Time: now.Add(s.ttl), Valid: true}
+	}
+
+	_, err := s.db.ExecContext(ctx, fmt.Sprintf(`INSERT INTO %s (id, data, updated_at, expires_at) VALUES ($1, $2, $3, $4)
+ON CONFLICT (id) DO UPDATE SET data = EXCLUDED.data, updated_at = EXCLUDED.updated_at, expires_at = EXCLUDED.expires_at`,
+		s.table), checkPointID, s.codec.Encode(checkPoint), now, expiresAt)
+	if err != nil {
+		return fmt.Errorf("set checkpoint fail: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes a checkpoint, e.g. once the interrupted run completed.
+func (s *Store) Delete(ctx context.Context, checkPointID string) error {
+	if _, err := s.db.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, s.table), checkPointID); err != nil {
+		return fmt.Errorf("delete checkpoint fail: %w", err)
+	}
+	return nil
+}
+
+// Cleanup deletes the expired checkpoints, to be called periodically, e.g. with checkpoint.StartCleanup.
+func (s *Store) Cleanup(ctx context.Context) (int64, error) {
+	res, err := s.db.ExecContext(ctx,
+		fmt.Sprintf(`DELETE FROM %s WHERE expires_at IS NOT NULL AND expires_at <= $1`, s.table), s.now())
+	if err != nil {
+		return 0, fmt.Errorf("cleanup checkpoints fail: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+// indexPrefix returns the table name without its schema, index names being unqualified.
+func indexPrefix(table string) string {
+	for i := len(table) - 1; i >= 0; i-- {
+		if table[i] == '.' {
+			return table[i+1:]
+		}
+	}
+	return table
+}
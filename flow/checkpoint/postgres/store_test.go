@@ -0,0 +1,213 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/compose"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-ext/flow/checkpoint"
+)
+
+// fakeDB interprets the few statements of the store, to test it with no postgres.
+type fakeDB struct {
+	mu      sync.Mutex
+	rows    map[string]fakeRow
+	queries []string
+}
+
+type fakeRow struct {
+	data      []byte
+	expiresAt any
+}
+
+func newFakeDB() (*fakeDB, *sql.DB) {
+	f := &fakeDB{rows: map[string]fakeRow{}}
+	return f, sql.OpenDB(f)
+}
+
+func (f *fakeDB) Connect(context.Context) (driver.Conn, error) { return &fakeConn{db: f}, nil }
+func (f *fakeDB) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *fakeConn) Close() error                        { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	f := c.db
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.queries = append(f.queries, query)
+
+	switch {
+	case strings.HasPrefix(query, "CREATE"):
+		return driver.RowsAffected(0), nil
+	case strings.HasPrefix(query, "INSERT"):
+		f.rows[args[0].Value.(string)] = fakeRow{data: args[1].Value.([]byte), expiresAt: args[3].Value}
+		return driver.RowsAffected(1), nil
+	case strings.Contains(query, "WHERE id = $1"):
+		if _, ok := f.rows[args[0].Value.(string)]; !ok {
+			return driver.RowsAffected(0), nil
+		}
+		delete(f.rows, args[0].Value.(string))
+		return driver.RowsAffected(1), nil
+	case strings.Contains(query, "expires_at <= $1"):
+		var n int64
+		for id, row := range f.rows {
+			if row.expiresAt != nil && !row.expiresAt.(time.Time).After(args[0].Value.(time.Time)) {
+				delete(f.rows, id)
+				n++
+			}
+		}
+		return driver.RowsAffected(n), nil
+	}
+	return nil, errors.New("unexpected query: " + query)
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	f := c.db
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.queries = append(f.queries, query)
+
+	row, ok := f.rows[args[0].Value.(string)]
+	if !ok || (row.expiresAt != nil && !row.expiresAt.(time.Time).After(args[1].Value.(time.Time))) {
+		return &fakeRows{}, nil
+	}
+	return &fakeRows{data: [][]byte{row.data}}, nil
+}
+
+type fakeRows struct {
+	data [][]byte
+}
+
+func (r *fakeRows) Columns() []string { return []string{"data"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.data) == 0 {
+		return io.EOF
+	}
+	dest[0], r.data = r.data[0], r.data[1:]
+	return nil
+}
+
+func TestNewStore(t *testing.T) {
+	_, err := NewStore(nil)
+	assert.Error(t, err)
+
+	_, db := newFakeDB()
+	_, err = NewStore(&Config{DB: db, Table: "checkpoints; DROP TABLE users"})
+	assert.Error(t, err)
+
+	s, err := NewStore(&Config{DB: db, Table: "agent.checkpoints"})
+	assert.NoError(t, err)
+	assert.Equal(t, "checkpoints", indexPrefix(s.table))
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	f, db := newFakeDB()
+	s, err := NewStore(&Config{DB: db, TTL: time.Hour, Codec: &checkpoint.Codec{Version: "v1"}})
+	assert.NoError(t, err)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	assert.NoError(t, s.CreateTable(ctx))
+	assert.Contains(t, f.queries[0], "CREATE TABLE IF NOT EXISTS eino_checkpoints")
+	assert.Contains(t, f.queries[1], "eino_checkpoints_expires_at_idx")
+
+	_, ok, err := s.Get(ctx, "c1")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.NoError(t, s.Set(ctx, "c1", []byte("state")))
+	assert.NoError(t, s.Set(ctx, "c2", []byte("other")))
+	got, ok, err := s.Get(ctx, "c1")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("state"), got)
+
+	assert.NoError(t, s.Delete(ctx, "c2"))
+	_, ok, err = s.Get(ctx, "c2")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// a checkpoint of another version is rejected
+	s2, err := NewStore(&Config{DB: db, Codec: &checkpoint.Codec{Version: "v2"}})
+	assert.NoError(t, err)
+	s2.now = s.now
+	_, _, err = s2.Get(ctx, "c1")
+	assert.ErrorIs(t, err, checkpoint.ErrVersionMismatch)
+
+	// expired checkpoints are hidden, then cleaned up
+	now = now.Add(2 * time.Hour)
+	_, ok, err = s.Get(ctx, "c1")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.NoError(t, s2.Set(ctx, "c3", []byte("no ttl")))
+	n, err := s.Cleanup(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.Len(t, f.rows, 1)
+}
+
+func TestStore_interruptResume(t *testing.T) {
+	ctx := context.Background()
+	_, db := newFakeDB()
+	s, err := NewStore(&Config{DB: db})
+	assert.NoError(t, err)
+
+	newRunnable := func() compose.Runnable[string, string] {
+		g := compose.NewGraph[string, string]()
+		assert.NoError(t, g.AddLambdaNode("draft", compose.InvokableLambda(func(ctx context.Context, in string) (string, error) {
+			return "draft: " + in, nil
+		})))
+		assert.NoError(t, g.AddLambdaNode("send", compose.InvokableLambda(func(ctx context.Context, in string) (string, error) {
+			return "sent " + in, nil
+		})))
+		assert.NoError(t, g.AddEdge(compose.START, "draft"))
+		assert.NoError(t, g.AddEdge("draft", "send"))
+		assert.NoError(t, g.AddEdge("send", compose.END))
+		r, err := g.Compile(ctx, compose.WithCheckPointStore(s), compose.WithInterruptBeforeNodes([]string{"send"}))
+		assert.NoError(t, err)
+		return r
+	}
+
+	_, err = newRunnable().Invoke(ctx, "hello", compose.WithCheckPointID("c1"))
+	info, ok := compose.ExtractInterruptInfo(err)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"send"}, info.BeforeNodes)
+
+	out, err := newRunnable().Invoke(ctx, "", compose.WithCheckPointID("c1"))
+	assert.NoError(t, err)
+	assert.Equal(t, "sent draft: hello", out)
+}
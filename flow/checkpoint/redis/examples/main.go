@@ -0,0 +1,92 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/compose"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/cloudwego/eino-ext/flow/checkpoint"
+	redisstore "github.com/cloudwego/eino-ext/flow/checkpoint/redis"
+)
+
+// newGraph drafts a reply then sends it, the run being interrupted before sending for a human to approve.
+func newGraph(ctx context.Context, store compose.CheckPointStore) (compose.Runnable[string, string], error) {
+	g := compose.NewGraph[string, string]()
+	_ = g.AddLambdaNode("draft", compose.InvokableLambda(func(ctx context.Context, in string) (string, error) {
+		return "Dear customer, " + in, nil
+	}))
+	_ = g.AddLambdaNode("send", compose.InvokableLambda(func(ctx context.Context, in string) (string, error) {
+		return "sent: " + strings.ToUpper(in), nil
+	}))
+	_ = g.AddEdge(compose.START, "draft")
+	_ = g.AddEdge("draft", "send")
+	_ = g.AddEdge("send", compose.END)
+
+	return g.Compile(ctx,
+		compose.WithCheckPointStore(store),
+		compose.WithInterruptBeforeNodes([]string{"send"}),
+	)
+}
+
+func main() {
+	ctx := context.Background()
+	rdb := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+
+	store, err := redisstore.NewStore(&redisstore.Config{
+		Client: rdb,
+		Prefix: "myapp:checkpoint",
+		TTL:    24 * time.Hour,
+		Codec:  &checkpoint.Codec{Version: "v1"},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	r, err := newGraph(ctx, store)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	_, err = r.Invoke(ctx, "your order has shipped", compose.WithCheckPointID("ticket-42"))
+	info, ok := compose.ExtractInterruptInfo(err)
+	if !ok {
+		log.Fatalf("expected an interrupt, got: %v", err)
+	}
+	fmt.Printf("interrupted before: %v\n", info.BeforeNodes)
+
+	// the checkpoint being in redis, the run can be resumed by another process once approved
+	r, err = newGraph(ctx, store)
+	if err != nil {
+		log.Fatal(err)
+	}
+	out, err := r.Invoke(ctx, "", compose.WithCheckPointID("ticket-42"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(out)
+
+	if err = store.Delete(ctx, "ticket-42"); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/compose"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/cloudwego/eino-ext/flow/checkpoint"
+)
+
+type Config struct {
+	// Client is the redis client, e.g. redis.NewClient(&redis.Options{Addr: "localhost:6379"}).
+	// Required
+	Client redis.UniversalClient
+	// Prefix of the checkpoint keys.
+	// Optional. Default: "eino:checkpoint:"
+	Prefix string
+	// TTL expires the checkpoints ttl after their last write, with the native expiration of redis.
+	// Optional. Default: no expiration
+	TTL time.Duration
+	// Codec stamps the checkpoints with a version, migrating or rejecting those of other versions when read.
+	// Optional. Default: checkpoints stamped with an empty version
+	Codec *checkpoint.Codec
+}
+
+// Store is a compose.CheckPointStore keeping each checkpoint in a redis string.
+type Store struct {
+	rdb    redis.UniversalClient
+	prefix string
+	ttl    time.Duration
+	codec  *checkpoint.Codec
+}
+
+var _ compose.CheckPointStore = (*Store)(nil)
+
+func NewStore(config *Config) (*Store, error) {
+	if config == nil || config.Client == nil {
+		return nil, errors.New("redis client is required")
+	}
+	prefix := "eino:checkpoint:"
+	if config.Prefix != "" {
+		prefix = strings.TrimSuffix(config.Prefix, ":") + ":"
+	}
+	return &Store{
+		rdb:    config.Client,
+		prefix: prefix,
+		ttl:    config.TTL,
+		codec:  config.Codec,
+	}, nil
+}
+
+func (s *Store) Get(ctx context.Context, checkPointID string) ([]byte, bool, error) {
+	record, err := s.rdb.Get(ctx, s.prefix+checkPointID).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("get checkpoint fail: %w", err)
+	}
+
+	checkPoint, err := s.codec.Decode(ctx, record)
+	if err != nil {
+		return nil, false, fmt.Errorf("decode checkpoint %s fail: %w", checkPointID, err)
+	}
+	return checkPoint, true, nil
+}
+
+func (s *Store) Set(ctx context.Context, checkPointID string, checkPoint []byte) error {
+	if err := s.rdb.Set(ctx, s.prefix+checkPointID, s.codec.Encode(checkPoint), s.ttl).Err(); err != nil {
+		return fmt.Errorf("set checkpoint fail: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes a checkpoint, e.g. once the interrupted run completed.
+func (s *Store) Delete(ctx context.Context, checkPointID string) error {
+	if err := s.rdb.Del(ctx, s.prefix+checkPointID).Err(); err != nil {
+		return fmt.Errorf("delete checkpoint fail: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudwego/eino-ext/flow/checkpoint"
+)
+
+// fakeRedis implements the string commands used by Store on an in-process map.
+type fakeRedis struct {
+	redis.UniversalClient
+	values  map[string][]byte
+	expires map[string]time.Duration
+	err     error
+}
+
+func newFakeRedis() *fakeRedis {
+	return &fakeRedis{values: map[string][]byte{}, expires: map[string]time.Duration{}}
+}
+
+func (f *fakeRedis) Get(ctx context.Context, key string) *redis.StringCmd {
+	cmd := redis.NewStringCmd(ctx)
+	if f.err != nil {
+		cmd.SetErr(f.err)
+		return cmd
+	}
+	v, ok := f.values[key]
+	if !ok {
+		cmd.SetErr(redis.Nil)
+		return cmd
+	}
+	cmd.SetVal(string(v))
+	return cmd
+}
+
+func (f *fakeRedis) Set(ctx context.Context, key string, value any, expiration time.Duration) *redis.StatusCmd {
+	cmd := redis.NewStatusCmd(ctx)
+	if f.err != nil {
+		cmd.SetErr(f.err)
+		return cmd
+	}
+	f.values[key] = value.([]byte)
+	f.expires[key] = expiration
+	return cmd
+}
+
+func (f *fakeRedis) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	cmd := redis.NewIntCmd(ctx)
+	for _, k := range keys {
+		delete(f.values, k)
+		delete(f.expires, k)
+	}
+	cmd.SetErr(f.err)
+	return cmd
+}
+
+func TestNewStore(t *testing.T) {
+	_, err := NewStore(nil)
+	assert.Error(t, err)
+	_, err = NewStore(&Config{})
+	assert.Error(t, err)
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	rdb := newFakeRedis()
+	s, err := NewStore(&Config{Client: rdb, Prefix: "cp:", TTL: time.Hour})
+	require.NoError(t, err)
+
+	t.Run("missing key", func(t *testing.T) {
+		cp, ok, err := s.Get(ctx, "none")
+		require.NoError(t, err)
+		assert.False(t, ok)
+		assert.Nil(t, cp)
+	})
+
+	t.Run("overwrite", func(t *testing.T) {
+		require.NoError(t, s.Set(ctx, "1", []byte("first")))
+		require.NoError(t, s.Set(ctx, "1", []byte("second")))
+		cp, ok, err := s.Get(ctx, "1")
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, []byte("second"), cp)
+		assert.Len(t, rdb.values, 1)
+		assert.Contains(t, rdb.values, "cp:1")
+
+		require.NoError(t, s.Delete(ctx, "1"))
+		_, ok, err = s.Get(ctx, "1")
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("ttl", func(t *testing.T) {
+		require.NoError(t, s.Set(ctx, "2", []byte("state")))
+		assert.Equal(t, time.Hour, rdb.expires["cp:2"])
+
+		noTTL, err := NewStore(&Config{Client: rdb})
+		require.NoError(t, err)
+		require.NoError(t, noTTL.Set(ctx, "2", []byte("state")))
+		assert.Equal(t, time.Duration(0), rdb.expires["eino:checkpoint:2"])
+	})
+
+	t.Run("error", func(t *testing.T) {
+		rdb.values["cp:bad"] = []byte("not a record")
+		_, _, err := s.Get(ctx, "bad")
+		assert.ErrorIs(t, err, checkpoint.ErrInvalidRecord)
+
+		rdb.err = errors.New("connection refused")
+		defer func() { rdb.err = nil }()
+		_, _, err = s.Get(ctx, "2")
+		assert.Error(t, err)
+		assert.Error(t, s.Set(ctx, "2", []byte("state")))
+		assert.Error(t, s.Delete(ctx, "2"))
+	})
+}
+
+func TestStore_Codec(t *testing.T) {
+	ctx := context.Background()
+	rdb := newFakeRedis()
+	v1, err := NewStore(&Config{Client: rdb, Codec: &checkpoint.Codec{Version: "v1"}})
+	require.NoError(t, err)
+	require.NoError(t, v1.Set(ctx, "1", []byte("state")))
+
+	cp, ok, err := v1.Get(ctx, "1")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("state"), cp)
+
+	v2, err := NewStore(&Config{Client: rdb, Codec: &checkpoint.Codec{Version: "v2"}})
+	require.NoError(t, err)
+	_, _, err = v2.Get(ctx, "1")
+	assert.ErrorIs(t, err, checkpoint.ErrVersionMismatch)
+
+	migrated, err := NewStore(&Config{Client: rdb, Codec: &checkpoint.Codec{
+		Version: "v2",
+		Migrate: func(_ context.Context, version string, checkPoint []byte) ([]byte, error) {
+			return append([]byte(version+":"), checkPoint...), nil
+		},
+	}})
+	require.NoError(t, err)
+	cp, ok, err = migrated.Get(ctx, "1")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("v1:state"), cp)
+}
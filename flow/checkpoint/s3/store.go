@@ -0,0 +1,194 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/cloudwego/eino/compose"
+
+	"github.com/cloudwego/eino-ext/flow/checkpoint"
+)
+
+// Client is the subset of *s3.Client used by the store.
+type Client interface {
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+	DeleteObject(ctx context.Context, params *s3.DeleteObjectInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectOutput, error)
+	ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error)
+}
+
+type Config struct {
+	// Client is the s3 client, e.g. s3.NewFromConfig(cfg).
+	// Required
+	Client Client
+	// Bucket keeping the checkpoints.
+	// Required
+	Bucket string
+	// Prefix of the checkpoint object keys.
+	// Optional. Default: "eino/checkpoints/"
+	Prefix string
+	// TTL expires the checkpoints ttl after their last write, expired checkpoints being deleted by Cleanup,
+	// or by a lifecycle rule of the bucket on the prefix.
+	// Optional. Default: no expiration
+	TTL time.Duration
+	// Codec stamps the checkpoints with a version, migrating or rejecting those of other versions when read.
+	// Optional. Default: checkpoints stamped with an empty version
+	Codec *checkpoint.Codec
+}
+
+// Store is a compose.CheckPointStore keeping each checkpoint in an s3 object.
+type Store struct {
+	cli    Client
+	bucket string
+	prefix string
+	ttl    time.Duration
+	codec  *checkpoint.Codec
+	now    func() time.Time
+}
+
+var (
+	_ compose.CheckPointStore = (*Store)(nil)
+	_ checkpoint.Cleaner      = (*Store)(nil)
+)
+
+func NewStore(config *Config) (*Store, error) {
+	if config == nil || config.Client == nil {
+		return nil, errors.New("s3 client is required")
+	}
+	if config.Bucket == "" {
+		return nil, errors.New("bucket is required")
+	}
+	prefix := "eino/checkpoints/"
+	if config.Prefix != "" {
+		prefix = strings.TrimSuffix(config.Prefix, "/") + "/"
+	}
+	return &Store{
+		cli:    config.Client,
+		bucket: config.Bucket,
+		prefix: prefix,
+		ttl:    config.TTL,
+		codec:  config.Codec,
+		now:    time.Now,
+	}, nil
+}
+
+func (s *Store) Get(ctx context.Context, checkPointID string) ([]byte, bool, error) {
+	key := s.prefix + checkPointID
+	out, err := s.cli.GetObject(ctx, &s3.GetObjectInput{Bucket: &s.bucket, Key: &key})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("get checkpoint fail: %w", err)
+	}
+	defer out.Body.Close()
+
+	if out.LastModified != nil && s.expired(*out.LastModified) {
+		return nil, false, nil
+	}
+
+	record, err := io.ReadAll(out.Body)
+	if err != nil {
+		return nil, false, fmt.Errorf("read checkpoint fail: %w", err)
+	}
+	checkPoint, err := s.codec.Decode(ctx, record)
+	if err != nil {
+		return nil, false, fmt.Errorf("decode checkpoint %s fail: %w", checkPointID, err)
+	}
+	return checkPoint, true, nil
+}
+
+func (s *Store) Set(ctx context.Context, checkPointID string, checkPoint []byte) error {
+	key := s.prefix + checkPointID
+	contentType := "application/octet-stream"
+	_, err := s.cli.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      &s.bucket,
+		Key:         &key,
+		Body:        bytes.NewReader(s.codec.Encode(checkPoint)),
+		ContentType: &contentType,
+	})
+	if err != nil {
+		return fmt.Errorf("set checkpoint fail: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes a checkpoint, e.g. once the interrupted run completed.
+func (s *Store) Delete(ctx context.Context, checkPointID string) error {
+	key := s.prefix + checkPointID
+	if _, err := s.cli.DeleteObject(ctx, &s3.DeleteObjectInput{Bucket: &s.bucket, Key: &key}); err != nil {
+		return fmt.Errorf("delete checkpoint fail: %w", err)
+	}
+	return nil
+}
+
+// Cleanup deletes the expired checkpoints under the prefix, to be called periodically,
+// e.g. with checkpoint.StartCleanup. Nothing expires with no TTL.
+func (s *Store) Cleanup(ctx context.Context) (int64, error) {
+	if s.ttl <= 0 {
+		return 0, nil
+	}
+
+	var (
+		deleted int64
+		token   *string
+	)
+	for {
+		out, err := s.cli.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+			Bucket:            &s.bucket,
+			Prefix:            &s.prefix,
+			ContinuationToken: token,
+		})
+		if err != nil {
+			return deleted, fmt.Errorf("list checkpoints fail: %w", err)
+		}
+
+		for _, obj := range out.Contents {
+			if obj.Key == nil || obj.LastModified == nil || !s.expired(*obj.LastModified) {
+				continue
+			}
+			if _, err = s.cli.DeleteObject(ctx, &s3.DeleteObjectInput{Bucket: &s.bucket, Key: obj.Key}); err != nil {
+				return deleted, fmt.Errorf("delete checkpoint fail: %w", err)
+			}
+			deleted++
+		}
+
+		if out.IsTruncated == nil || !*out.IsTruncated || out.NextContinuationToken == nil {
+			return deleted, nil
+		}
+		token = out.NextContinuationToken
+	}
+}
+
+func (s *Store) expired(lastModified time.Time) bool {
+	return s.ttl > 0 && !lastModified.Add(s.ttl).After(s.now())
+}
+
+func isNotFound(err error) bool {
+	var noSuchKey *types.NoSuchKey
+	var notFound *types.NotFound
+	return errors.As(err, &noSuchKey) || errors.As(err, &notFound)
+}
@@ -0,0 +1,160 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-ext/flow/checkpoint"
+)
+
+type fakeObject struct {
+	data         []byte
+	lastModified time.Time
+}
+
+// fakeClient keeps the objects in memory, listing them one per page.
+type fakeClient struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	objects map[string]fakeObject
+}
+
+func (c *fakeClient) GetObject(_ context.Context, in *s3.GetObjectInput, _ ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	obj, ok := c.objects[*in.Bucket+"/"+*in.Key]
+	if !ok {
+		return nil, &types.NoSuchKey{}
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(obj.data)), LastModified: &obj.lastModified}, nil
+}
+
+func (c *fakeClient) PutObject(_ context.Context, in *s3.PutObjectInput, _ ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	data, err := io.ReadAll(in.Body)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.objects[*in.Bucket+"/"+*in.Key] = fakeObject{data: data, lastModified: c.now()}
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (c *fakeClient) DeleteObject(_ context.Context, in *s3.DeleteObjectInput, _ ...func(*s3.Options)) (*s3.DeleteObjectOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.objects, *in.Bucket+"/"+*in.Key)
+	return &s3.DeleteObjectOutput{}, nil
+}
+
+func (c *fakeClient) ListObjectsV2(_ context.Context, in *s3.ListObjectsV2Input, _ ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var keys []string
+	for k := range c.objects {
+		key := strings.TrimPrefix(k, *in.Bucket+"/")
+		if strings.HasPrefix(key, *in.Prefix) && (in.ContinuationToken == nil || key > *in.ContinuationToken) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	if len(keys) == 0 {
+		return &s3.ListObjectsV2Output{}, nil
+	}
+	obj := c.objects[*in.Bucket+"/"+keys[0]]
+	truncated := len(keys) > 1
+	return &s3.ListObjectsV2Output{
+		Contents:              []types.Object{{Key: &keys[0], LastModified: &obj.lastModified}},
+		IsTruncated:           &truncated,
+		NextContinuationToken: &keys[0],
+	}, nil
+}
+
+func TestNewStore(t *testing.T) {
+	_, err := NewStore(nil)
+	assert.Error(t, err)
+	_, err = NewStore(&Config{Client: &fakeClient{}})
+	assert.Error(t, err)
+
+	s, err := NewStore(&Config{Client: &fakeClient{}, Bucket: "b", Prefix: "agents"})
+	assert.NoError(t, err)
+	assert.Equal(t, "agents/", s.prefix)
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	cli := &fakeClient{now: clock, objects: map[string]fakeObject{}}
+
+	s, err := NewStore(&Config{Client: cli, Bucket: "b", TTL: time.Hour, Codec: &checkpoint.Codec{Version: "v1"}})
+	assert.NoError(t, err)
+	s.now = clock
+
+	_, ok, err := s.Get(ctx, "c1")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.NoError(t, s.Set(ctx, "c1", []byte("state")))
+	assert.Contains(t, cli.objects, "b/eino/checkpoints/c1")
+	got, ok, err := s.Get(ctx, "c1")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("state"), got)
+
+	s2, err := NewStore(&Config{Client: cli, Bucket: "b", Codec: &checkpoint.Codec{Version: "v2"}})
+	assert.NoError(t, err)
+	_, _, err = s2.Get(ctx, "c1")
+	assert.ErrorIs(t, err, checkpoint.ErrVersionMismatch)
+
+	assert.NoError(t, s.Set(ctx, "c2", []byte("state")))
+	assert.NoError(t, s.Delete(ctx, "c2"))
+	_, ok, err = s.Get(ctx, "c2")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// expired checkpoints are hidden, then cleaned up across the pages of the listing
+	assert.NoError(t, s.Set(ctx, "c3", []byte("state")))
+	now = now.Add(30 * time.Minute)
+	assert.NoError(t, s.Set(ctx, "c4", []byte("state")))
+	now = now.Add(45 * time.Minute)
+	_, ok, err = s.Get(ctx, "c1")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	n, err := s.Cleanup(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	assert.Len(t, cli.objects, 1)
+	assert.Contains(t, cli.objects, "b/eino/checkpoints/c4")
+
+	n, err = s2.Cleanup(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+}
@@ -0,0 +1,381 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package citation attaches the sources to the answers of RAG applications: the claims of the answer are cited with
+// inline markers, e.g. [1], numbered in a source list with the URL, title and chunk offsets of the retrieved documents,
+// and the support of each claim by its sources is optionally verified by embedding similarity.
+package citation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+)
+
+// Instruction asks the model to cite the documents numbered by FormatContext, to be added to the system prompt.
+const Instruction = `Cite the documents supporting each sentence of your answer with their numbers in brackets at the end of the sentence, e.g. [1] or [1][3]. Do not cite documents which do not support the sentence.`
+
+const (
+	defaultURLKey         = "url"
+	defaultTitleKey       = "title"
+	defaultThreshold      = 0.75
+	defaultMaxCitations   = 2
+	defaultMinClaimLength = 20
+
+	// the metadata keys of the offsets of the chunks set by the splitters
+	metaKeyStartOffset = "_start_offset"
+	metaKeyEndOffset   = "_end_offset"
+)
+
+// Source is a cited document.
+type Source struct {
+	// Number is the number of the markers citing the source, starting at 1 in the order of the first citation.
+	Number     int    `json:"number"`
+	DocumentID string `json:"document_id"`
+	URL        string `json:"url,omitempty"`
+	Title      string `json:"title,omitempty"`
+	// StartOffset and EndOffset locate the chunk in its original document, when set by the splitter.
+	StartOffset *int `json:"start_offset,omitempty"`
+	EndOffset   *int `json:"end_offset,omitempty"`
+	// Document is the retrieved document.
+	Document *schema.Document `json:"-"`
+}
+
+// Claim is a sentence of the answer.
+type Claim struct {
+	// Text is the sentence, without its markers.
+	Text string `json:"text"`
+	// Sources are the numbers of the sources cited by the sentence.
+	Sources []int `json:"sources,omitempty"`
+	// Score is the best similarity of the sentence to its sources, 0 without verification.
+	Score float64 `json:"score,omitempty"`
+	// Unsupported reports the verified sentences not supported by any source, whose citations are removed.
+	Unsupported bool `json:"unsupported,omitempty"`
+}
+
+// Result is the answer with its citations.
+type Result struct {
+	// Text is the answer with the markers of the sources.
+	Text string `json:"text"`
+	// Sources are the cited documents, in the order of their numbers.
+	Sources []*Source `json:"sources"`
+	Claims  []*Claim  `json:"claims"`
+}
+
+// FormatSources formats the list of the sources, e.g. "[1] [ACME Q2 report](https://acme.com/q2)".
+func (r *Result) FormatSources() string {
+	var sb strings.Builder
+	for i, s := range r.Sources {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		title := s.Title
+		if title == "" {
+			title = s.DocumentID
+		}
+		if s.URL != "" {
+			fmt.Fprintf(&sb, "[%d] [%s](%s)", s.Number, title, s.URL)
+		} else {
+			fmt.Fprintf(&sb, "[%d] %s", s.Number, title)
+		}
+	}
+	return sb.String()
+}
+
+type Config struct {
+	// Embedding verifies the support of the claims by their sources, and cites the claims without markers
+	// with the most similar documents.
+	// Optional. Default the markers of the answer are kept as they are.
+	Embedding embedding.Embedder
+	// Threshold is the minimum cosine similarity of a claim to a document supporting it.
+	// Optional. Default 0.75.
+	Threshold float64
+	// MaxCitations is the maximum number of documents cited by a claim without markers.
+	// Optional. Default 2.
+	MaxCitations int
+	// MinClaimLength is the minimum length in characters of the claims verified or cited, shorter sentences such as
+	// the titles of the answer being left as they are.
+	// Optional. Default 20.
+	MinClaimLength int
+	// URLKey and TitleKey are the metadata keys of the URL and the title of the documents.
+	// Optional. Default "url" and "title".
+	URLKey   string
+	TitleKey string
+	// Marker formats the marker of the source numbers.
+	// Optional. Default "[1][3]".
+	Marker func(numbers []int) string
+}
+
+// Builder builds the citations of the answers.
+type Builder struct {
+	embedding      embedding.Embedder
+	threshold      float64
+	maxCitations   int
+	minClaimLength int
+	urlKey         string
+	titleKey       string
+	marker         func(numbers []int) string
+}
+
+func NewBuilder(ctx context.Context, config *Config) (*Builder, error) {
+	if config == nil {
+		return nil, errors.New("config is required")
+	}
+	if config.Threshold < 0 || config.Threshold > 1 {
+		return nil, errors.New("threshold must be between 0 and 1")
+	}
+	b := &Builder{
+		embedding:      config.Embedding,
+		threshold:      config.Threshold,
+		maxCitations:   config.MaxCitations,
+		minClaimLength: config.MinClaimLength,
+		urlKey:         config.URLKey,
+		titleKey:       config.TitleKey,
+		marker:         config.Marker,
+	}
+	if b.threshold == 0 {
+		b.threshold = defaultThreshold
+	}
+	if b.maxCitations <= 0 {
+		b.maxCitations = defaultMaxCitations
+	}
+	if b.minClaimLength <= 0 {
+		b.minClaimLength = defaultMinClaimLength
+	}
+	if b.urlKey == "" {
+		b.urlKey = defaultURLKey
+	}
+	if b.titleKey == "" {
+		b.titleKey = defaultTitleKey
+	}
+	if b.marker == nil {
+		b.marker = defaultMarker
+	}
+	return b, nil
+}
+
+// FormatContext numbers the documents for the prompt, e.g. "[1] ACME Q2 report\nRevenue grew by 3%.",
+// the answer citing them with Instruction being passed to Build with the same documents.
+func (b *Builder) FormatContext(docs []*schema.Document) string {
+	var sb strings.Builder
+	for i, doc := range docs {
+		if i > 0 {
+			sb.WriteString("\n\n")
+		}
+		fmt.Fprintf(&sb, "[%d]", i+1)
+		if title, _ := doc.MetaData[b.titleKey].(string); title != "" {
+			sb.WriteString(" ")
+			sb.WriteString(title)
+		}
+		sb.WriteString("\n")
+		sb.WriteString(doc.Content)
+	}
+	return sb.String()
+}
+
+// claim is a claim of the answer, with its range and the indexes of the documents it cites.
+type claim struct {
+	*Claim
+	start, end int
+	docs       []int
+}
+
+// Build cites the claims of the answer with the documents, the markers of the answer, e.g. [2], being the numbers
+// of the documents in FormatContext. The sources are renumbered in the order of their first citation.
+// The bracketed numbers out of range, e.g. a year, and the code, both inline and fenced, are left as they are.
+func (b *Builder) Build(ctx context.Context, answer string, docs []*schema.Document) (*Result, error) {
+	var claims []*claim
+	blocks := fencedBlocks(answer)
+	for _, s := range splitSentences(answer) {
+		if overlaps(blocks, s.start, s.end) {
+			continue
+		}
+		text, leading, numbers := extractMarkers(answer[s.start:s.end], len(docs))
+		if len(leading) > 0 && len(claims) > 0 {
+			prev := claims[len(claims)-1]
+			prev.docs = append(prev.docs, validDocs(leading, len(docs))...)
+			if text == "" {
+				// only markers, part of the previous claim
+				prev.end = s.end
+				continue
+			}
+		}
+		if text == "" {
+			continue
+		}
+		claims = append(claims, &claim{
+			Claim: &Claim{Text: text},
+			start: s.start,
+			end:   s.end,
+			docs:  validDocs(append(leading, numbers...), len(docs)),
+		})
+	}
+
+	if b.embedding != nil && len(claims) > 0 && len(docs) > 0 {
+		if err := b.verify(ctx, claims, docs); err != nil {
+			return nil, err
+		}
+	}
+
+	// the sources numbered in the order of their first citation
+	result := &Result{Sources: []*Source{}, Claims: make([]*Claim, 0, len(claims))}
+	numbers := make(map[int]int)
+	var sb strings.Builder
+	prev := 0
+	for _, c := range claims {
+		for _, d := range c.docs {
+			if _, found := numbers[d]; !found {
+				numbers[d] = len(numbers) + 1
+				result.Sources = append(result.Sources, b.source(numbers[d], docs[d]))
+			}
+			c.Sources = append(c.Sources, numbers[d])
+		}
+		sort.Ints(c.Sources)
+		result.Claims = append(result.Claims, c.Claim)
+
+		sb.WriteString(answer[prev:c.start])
+		marker := ""
+		if len(c.Sources) > 0 {
+			marker = b.marker(c.Sources)
+		}
+		sb.WriteString(insertMarker(c.Text, marker))
+		prev = c.end
+	}
+	sb.WriteString(answer[prev:])
+	result.Text = sb.String()
+
+	return result, nil
+}
+
+// verify keeps the citations of the claims supported by the documents, and cites the claims without markers
+// with the most similar documents.
+func (b *Builder) verify(ctx context.Context, claims []*claim, docs []*schema.Document) error {
+	var verified []*claim
+	for _, c := range claims {
+		if len([]rune(c.Text)) >= b.minClaimLength {
+			verified = append(verified, c)
+		}
+	}
+	if len(verified) == 0 {
+		return nil
+	}
+
+	texts := make([]string, 0, len(verified)+len(docs))
+	for _, c := range verified {
+		texts = append(texts, c.Text)
+	}
+	for _, doc := range docs {
+		texts = append(texts, doc.Content)
+	}
+	vectors, err := b.embedding.EmbedStrings(ctx, texts)
+	if err != nil {
+		return fmt.Errorf("embed claims and documents failed: %w", err)
+	}
+	if len(vectors) != len(texts) {
+		return fmt.Errorf("embedding returned %d vectors for %d texts", len(vectors), len(texts))
+	}
+	docVectors := vectors[len(verified):]
+
+	for i, c := range verified {
+		similarities := make([]float64, len(docs))
+		for d := range docs {
+			similarities[d] = cosine(vectors[i], docVectors[d])
+		}
+
+		candidates := c.docs
+		limit := len(candidates)
+		if len(candidates) == 0 {
+			// no marker, the most similar documents
+			for d := range docs {
+				candidates = append(candidates, d)
+			}
+			sort.SliceStable(candidates, func(x, y int) bool {
+				return similarities[candidates[x]] > similarities[candidates[y]]
+			})
+			limit = b.maxCitations
+		}
+
+		var supported []int
+		for _, d := range candidates {
+			c.Score = math.Max(c.Score, similarities[d])
+			if similarities[d] >= b.threshold && len(supported) < limit {
+				supported = append(supported, d)
+			}
+		}
+		c.docs = supported
+		c.Unsupported = len(supported) == 0
+	}
+	return nil
+}
+
+func (b *Builder) source(number int, doc *schema.Document) *Source {
+	s := &Source{
+		Number:     number,
+		DocumentID: doc.ID,
+		Document:   doc,
+	}
+	s.URL, _ = doc.MetaData[b.urlKey].(string)
+	s.Title, _ = doc.MetaData[b.titleKey].(string)
+	if start, ok := doc.MetaData[metaKeyStartOffset].(int); ok {
+		s.StartOffset = &start
+	}
+	if end, ok := doc.MetaData[metaKeyEndOffset].(int); ok {
+		s.EndOffset = &end
+	}
+	return s
+}
+
+// validDocs returns the indexes of the documents of the 1-based numbers, without the duplicates and the ones out of range.
+func validDocs(numbers []int, count int) []int {
+	var ret []int
+	seen := make(map[int]bool, len(numbers))
+	for _, n := range numbers {
+		if n >= 1 && n <= count && !seen[n] {
+			seen[n] = true
+			ret = append(ret, n-1)
+		}
+	}
+	return ret
+}
+
+func defaultMarker(numbers []int) string {
+	var sb strings.Builder
+	for _, n := range numbers {
+		fmt.Fprintf(&sb, "[%d]", n)
+	}
+	return sb.String()
+}
+
+func cosine(a, b []float64) float64 {
+	var dot, na, nb float64
+	for i := range a {
+		if i >= len(b) {
+			break
+		}
+		dot += a[i] * b[i]
+		na += a[i] * a[i]
+		nb += b[i] * b[i]
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(na*nb)
+}
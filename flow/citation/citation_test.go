@@ -0,0 +1,218 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package citation
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockEmbedding embeds the texts by the topics they mention.
+type mockEmbedding struct {
+	err error
+}
+
+var topics = []string{"revenue", "margin", "office"}
+
+func (m *mockEmbedding) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	vectors := make([][]float64, 0, len(texts))
+	for _, text := range texts {
+		vec := make([]float64, len(topics)+1)
+		vec[len(topics)] = 0.1
+		for i, topic := range topics {
+			if strings.Contains(strings.ToLower(text), topic) {
+				vec[i] = 1
+			}
+		}
+		vectors = append(vectors, vec)
+	}
+	return vectors, nil
+}
+
+var docs = []*schema.Document{
+	{ID: "report#1", Content: "Revenue grew by 3% in Q2.", MetaData: map[string]any{"url": "https://acme.com/q2", "title": "ACME Q2 report", "_start_offset": 120, "_end_offset": 145}},
+	{ID: "report#2", Content: "The margin was stable at 12%.", MetaData: map[string]any{"url": "https://acme.com/q2", "title": "ACME Q2 report"}},
+	{ID: "faq", Content: "The office is open from 9am."},
+}
+
+func TestSplitSentences(t *testing.T) {
+	text := "## Summary\nRevenue grew by 3% [1]. The margin was stable. [2]\n- Open at 9am [3]"
+	var got []string
+	for _, s := range splitSentences(text) {
+		got = append(got, text[s.start:s.end])
+	}
+	assert.Equal(t, []string{"## Summary", "Revenue grew by 3% [1].", "The margin was stable.", "[2]", "- Open at 9am [3]"}, got)
+}
+
+func TestExtractMarkers(t *testing.T) {
+	text, leading, numbers := extractMarkers("Revenue grew [1, 2] by 3%[3].", 4)
+	assert.Equal(t, "Revenue grew by 3%.", text)
+	assert.Empty(t, leading)
+	assert.Equal(t, []int{1, 2, 3}, numbers)
+
+	text, leading, numbers = extractMarkers("[2][4] The margin was stable.", 4)
+	assert.Equal(t, "The margin was stable.", text)
+	assert.Equal(t, []int{2, 4}, leading)
+	assert.Empty(t, numbers)
+
+	text, _, numbers = extractMarkers("The array a[x] is not cited.", 4)
+	assert.Equal(t, "The array a[x] is not cited.", text)
+	assert.Empty(t, numbers)
+
+	// out of range: indexes and years
+	text, _, numbers = extractMarkers("Read items[0] and items[5], founded in [2019] [1, 7] [2].", 4)
+	assert.Equal(t, "Read items[0] and items[5], founded in [2019] [1, 7].", text)
+	assert.Equal(t, []int{2}, numbers)
+
+	// code spans
+	text, _, numbers = extractMarkers("Use `items[1]` or ``a[2]`` to get it [3].", 4)
+	assert.Equal(t, "Use `items[1]` or ``a[2]`` to get it.", text)
+	assert.Equal(t, []int{3}, numbers)
+}
+
+func TestFencedBlocks(t *testing.T) {
+	text := "Run it:\n```go\nx := items[1]\n```\nDone [1].\n~~~\nunclosed [2]"
+	var got []string
+	for _, b := range fencedBlocks(text) {
+		got = append(got, text[b[0]:b[1]])
+	}
+	assert.Equal(t, []string{"```go\nx := items[1]\n```\n", "~~~\nunclosed [2]"}, got)
+}
+
+func TestInsertMarker(t *testing.T) {
+	assert.Equal(t, "Revenue grew by 3% [1].", insertMarker("Revenue grew by 3%.", "[1]"))
+	assert.Equal(t, "Is it open [2]?!", insertMarker("Is it open?!", "[2]"))
+	assert.Equal(t, "- Open at 9am [3]", insertMarker("- Open at 9am", "[3]"))
+	assert.Equal(t, "...[1]", insertMarker("...", "[1]"))
+	assert.Equal(t, "unchanged.", insertMarker("unchanged.", ""))
+}
+
+func TestNewBuilder(t *testing.T) {
+	ctx := context.Background()
+	_, err := NewBuilder(ctx, nil)
+	assert.EqualError(t, err, "config is required")
+	_, err = NewBuilder(ctx, &Config{Threshold: 2})
+	assert.EqualError(t, err, "threshold must be between 0 and 1")
+
+	b, err := NewBuilder(ctx, &Config{})
+	assert.NoError(t, err)
+	assert.Equal(t, 0.75, b.threshold)
+	assert.Equal(t, 2, b.maxCitations)
+	assert.Equal(t, 20, b.minClaimLength)
+	assert.Equal(t, "url", b.urlKey)
+	assert.Equal(t, "title", b.titleKey)
+}
+
+func TestFormatContext(t *testing.T) {
+	b, err := NewBuilder(context.Background(), &Config{})
+	assert.NoError(t, err)
+	assert.Equal(t, "[1] ACME Q2 report\nRevenue grew by 3% in Q2.\n\n[2] ACME Q2 report\nThe margin was stable at 12%.\n\n[3]\nThe office is open from 9am.",
+		b.FormatContext(docs))
+}
+
+func TestBuild_Markers(t *testing.T) {
+	ctx := context.Background()
+	b, err := NewBuilder(ctx, &Config{})
+	assert.NoError(t, err)
+
+	answer := "## Summary\nThe margin was stable [2]. Revenue grew by 3%. [1]\nThe office opens at 9am [3, 1]."
+	result, err := b.Build(ctx, answer, docs)
+	assert.NoError(t, err)
+
+	// renumbered in the order of the first citation
+	assert.Equal(t, "## Summary\nThe margin was stable [1]. Revenue grew by 3% [2].\nThe office opens at 9am [2][3].", result.Text)
+	assert.Len(t, result.Sources, 3)
+	assert.Equal(t, 1, result.Sources[0].Number)
+	assert.Equal(t, "report#2", result.Sources[0].DocumentID)
+	assert.Nil(t, result.Sources[0].StartOffset)
+	assert.Equal(t, "report#1", result.Sources[1].DocumentID)
+	assert.Equal(t, "https://acme.com/q2", result.Sources[1].URL)
+	assert.Equal(t, "ACME Q2 report", result.Sources[1].Title)
+	assert.Equal(t, 120, *result.Sources[1].StartOffset)
+	assert.Equal(t, 145, *result.Sources[1].EndOffset)
+	assert.Same(t, docs[0], result.Sources[1].Document)
+	assert.Equal(t, "faq", result.Sources[2].DocumentID)
+
+	assert.Equal(t, []*Claim{
+		{Text: "## Summary"},
+		{Text: "The margin was stable.", Sources: []int{1}},
+		{Text: "Revenue grew by 3%.", Sources: []int{2}},
+		{Text: "The office opens at 9am.", Sources: []int{2, 3}},
+	}, result.Claims)
+
+	assert.Equal(t, "[1] [ACME Q2 report](https://acme.com/q2)\n[2] [ACME Q2 report](https://acme.com/q2)\n[3] faq", result.FormatSources())
+
+	// the years, the indexes and the code are not citations
+	answer = "Founded in [2019], it lists items[0] [1].\n```python\nprint(items[2])\n```\nUse `items[3]` [3]."
+	result, err = b.Build(ctx, answer, docs)
+	assert.NoError(t, err)
+	assert.Equal(t, "Founded in [2019], it lists items[0] [1].\n```python\nprint(items[2])\n```\nUse `items[3]` [2].", result.Text)
+	assert.Len(t, result.Sources, 2)
+	assert.Len(t, result.Claims, 2)
+
+	result, err = b.Build(ctx, "No document was cited.", docs)
+	assert.NoError(t, err)
+	assert.Equal(t, "No document was cited.", result.Text)
+	assert.Empty(t, result.Sources)
+	assert.Empty(t, result.FormatSources())
+}
+
+func TestBuild_Verification(t *testing.T) {
+	ctx := context.Background()
+	b, err := NewBuilder(ctx, &Config{
+		Embedding: &mockEmbedding{},
+		Marker: func(numbers []int) string {
+			parts := make([]string, 0, len(numbers))
+			for _, n := range numbers {
+				parts = append(parts, "^"+string(rune('0'+n)))
+			}
+			return strings.Join(parts, "")
+		},
+	})
+	assert.NoError(t, err)
+
+	answer := "Short [3]. Revenue grew by 3% in the quarter [2]. The margin was stable over the period. The weather was sunny all week [1]."
+	result, err := b.Build(ctx, answer, docs)
+	assert.NoError(t, err)
+
+	// the wrong citation is replaced by no citation, the claim without marker is cited with the similar document,
+	// the short claim is not verified
+	assert.Equal(t, "Short ^1. Revenue grew by 3% in the quarter. The margin was stable over the period ^2. The weather was sunny all week.", result.Text)
+	assert.Equal(t, "faq", result.Sources[0].DocumentID)
+	assert.Equal(t, "report#2", result.Sources[1].DocumentID)
+
+	assert.False(t, result.Claims[0].Unsupported)
+	assert.True(t, result.Claims[1].Unsupported)
+	assert.Empty(t, result.Claims[1].Sources)
+	assert.Less(t, result.Claims[1].Score, 0.75)
+	assert.False(t, result.Claims[2].Unsupported)
+	assert.Equal(t, []int{2}, result.Claims[2].Sources)
+	assert.True(t, result.Claims[3].Unsupported)
+
+	b, err = NewBuilder(ctx, &Config{Embedding: &mockEmbedding{err: errors.New("timeout")}})
+	assert.NoError(t, err)
+	_, err = b.Build(ctx, answer, docs)
+	assert.EqualError(t, err, "embed claims and documents failed: timeout")
+}
@@ -0,0 +1,59 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/flow/citation"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// with an embedding, the citations are verified: Embedding: embedder
+	b, err := citation.NewBuilder(ctx, &citation.Config{})
+	if err != nil {
+		log.Fatalf("Failed to create builder: %v", err)
+	}
+
+	// the retrieved documents
+	docs := []*schema.Document{
+		{ID: "q2#1", Content: "Revenue grew by 3% in Q2 2025.", MetaData: map[string]any{"url": "https://acme.com/reports/q2", "title": "ACME Q2 2025 report"}},
+		{ID: "q2#2", Content: "The operating margin was stable at 12%.", MetaData: map[string]any{"url": "https://acme.com/reports/q2", "title": "ACME Q2 2025 report"}},
+		{ID: "faq", Content: "The headquarters are in Berlin.", MetaData: map[string]any{"title": "FAQ"}},
+	}
+
+	// the system prompt of the chat model
+	prompt := schema.SystemMessage("Answer with the documents below.\n" + citation.Instruction + "\n\n" + b.FormatContext(docs))
+	fmt.Println(prompt.Content)
+	fmt.Println()
+
+	// the answer of the chat model
+	answer := "ACME's revenue grew by 3% [1]. Its margin stayed at 12% [2][1]."
+
+	result, err := b.Build(ctx, answer, docs)
+	if err != nil {
+		log.Fatalf("Failed to build citations: %v", err)
+	}
+	fmt.Println(result.Text)
+	fmt.Println()
+	fmt.Println(result.FormatSources())
+}
@@ -0,0 +1,233 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package citation
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// markerRegexp matches the citation markers written by the model, e.g. [1], [1, 3] or [1][3].
+var markerRegexp = regexp.MustCompile(`\s*\[\d+(?:\s*,\s*\d+)*\]`)
+
+// sentence is a sentence of a text, [start, end) being its byte range without the surrounding spaces.
+type sentence struct {
+	start, end int
+}
+
+// splitSentences splits the text after the sentence terminators followed by a space, the CJK terminators, and the line breaks.
+func splitSentences(text string) []sentence {
+	var (
+		ret   []sentence
+		start int
+	)
+	add := func(end int) {
+		s, e := start, end
+		for s < e {
+			r, size := utf8.DecodeRuneInString(text[s:])
+			if !unicode.IsSpace(r) {
+				break
+			}
+			s += size
+		}
+		for e > s {
+			r, size := utf8.DecodeLastRuneInString(text[:e])
+			if !unicode.IsSpace(r) {
+				break
+			}
+			e -= size
+		}
+		if s < e {
+			ret = append(ret, sentence{start: s, end: e})
+		}
+		start = end
+	}
+
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+		switch r {
+		case '\n', '。', '！', '？':
+			add(i)
+		case '.', '!', '?':
+			for i < len(text) {
+				next, nextSize := utf8.DecodeRuneInString(text[i:])
+				if next != '.' && next != '!' && next != '?' && next != '"' && next != '\'' && next != ')' {
+					break
+				}
+				i += nextSize
+			}
+			if i == len(text) {
+				add(i)
+				break
+			}
+			if next, _ := utf8.DecodeRuneInString(text[i:]); unicode.IsSpace(next) {
+				add(i)
+			}
+		}
+	}
+	add(len(text))
+	return ret
+}
+
+// extractMarkers removes the markers of the sentence, returning the sentence and the numbers of the markers,
+// and the numbers of the markers starting the sentence, which cite the previous sentence, e.g. "It grew. [1]".
+// Only the markers of documents, numbered 1 to count, out of code spans are citations: "items[0]" or "[2019]" are kept.
+func extractMarkers(s string, count int) (text string, leading, numbers []int) {
+	locs := markerRegexp.FindAllStringIndex(s, -1)
+	if len(locs) == 0 {
+		return s, nil, nil
+	}
+	code := codeSpans(s)
+	var sb strings.Builder
+	prev := 0
+	for _, loc := range locs {
+		nums := parseMarker(s[loc[0]:loc[1]])
+		if !inRange(nums, count) || overlaps(code, loc[0], loc[1]) {
+			continue
+		}
+		if strings.TrimSpace(s[prev:loc[0]]) == "" && sb.Len() == 0 {
+			leading = append(leading, nums...)
+		} else {
+			numbers = append(numbers, nums...)
+		}
+		sb.WriteString(s[prev:loc[0]])
+		prev = loc[1]
+	}
+	sb.WriteString(s[prev:])
+	return strings.TrimSpace(sb.String()), leading, numbers
+}
+
+func inRange(numbers []int, count int) bool {
+	for _, n := range numbers {
+		if n < 1 || n > count {
+			return false
+		}
+	}
+	return len(numbers) > 0
+}
+
+func overlaps(spans [][2]int, start, end int) bool {
+	for _, span := range spans {
+		if start < span[1] && span[0] < end {
+			return true
+		}
+	}
+	return false
+}
+
+// codeSpans returns the byte ranges of the inline code spans of the text, delimited by backtick strings of the same length.
+func codeSpans(text string) [][2]int {
+	var ret [][2]int
+	for i := 0; i < len(text); {
+		if text[i] != '`' {
+			i++
+			continue
+		}
+		start := i
+		for i < len(text) && text[i] == '`' {
+			i++
+		}
+		delim := text[start:i]
+		// the closing backtick string must have the same length, not be part of a longer one
+		for j := i; j < len(text); {
+			k := strings.Index(text[j:], delim)
+			if k < 0 {
+				break
+			}
+			k += j
+			end := k + len(delim)
+			if end < len(text) && text[end] == '`' {
+				for end < len(text) && text[end] == '`' {
+					end++
+				}
+				j = end
+				continue
+			}
+			ret = append(ret, [2]int{start, end})
+			i = end
+			break
+		}
+	}
+	return ret
+}
+
+// fencedBlocks returns the byte ranges of the fenced code blocks of the text, from their opening fence line
+// to the end of their closing one, or to the end of the text when unclosed.
+func fencedBlocks(text string) [][2]int {
+	var (
+		ret   [][2]int
+		fence string
+		start int
+	)
+	for pos := 0; pos < len(text); {
+		end := strings.IndexByte(text[pos:], '\n')
+		if end < 0 {
+			end = len(text)
+		} else {
+			end += pos + 1
+		}
+		line := strings.TrimLeft(text[pos:end], " ")
+		switch {
+		case fence == "":
+			if strings.HasPrefix(line, "```") || strings.HasPrefix(line, "~~~") {
+				fence = line[:3]
+				start = pos
+			}
+		case strings.HasPrefix(line, fence) && strings.TrimSpace(strings.TrimLeft(line, fence[:1])) == "":
+			ret = append(ret, [2]int{start, end})
+			fence = ""
+		}
+		pos = end
+	}
+	if fence != "" {
+		ret = append(ret, [2]int{start, len(text)})
+	}
+	return ret
+}
+
+func parseMarker(marker string) []int {
+	marker = strings.Trim(strings.TrimSpace(marker), "[]")
+	var ret []int
+	for _, part := range strings.Split(marker, ",") {
+		if n, err := strconv.Atoi(strings.TrimSpace(part)); err == nil {
+			ret = append(ret, n)
+		}
+	}
+	return ret
+}
+
+// insertMarker inserts the marker before the final punctuation of the sentence, e.g. "Revenue grew by 3% [1].".
+func insertMarker(s, marker string) string {
+	if marker == "" {
+		return s
+	}
+	end := len(s)
+	for end > 0 {
+		r, size := utf8.DecodeLastRuneInString(s[:end])
+		if !strings.ContainsRune(".!?。！？:;", r) {
+			break
+		}
+		end -= size
+	}
+	if end == 0 {
+		return s + marker
+	}
+	return s[:end] + " " + marker + s[end:]
+}
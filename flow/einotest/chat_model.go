@@ -0,0 +1,134 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package einotest
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+type ChatModelConfig struct {
+	Faults
+	// Responses are returned in order, one per call.
+	// Optional
+	Responses []*schema.Message
+	// Handler computes the responses once the scripted ones are all returned, e.g. to echo the input.
+	// Optional. Default: failing with ErrNoResponse
+	Handler func(ctx context.Context, input []*schema.Message) (*schema.Message, error)
+	// ChunkSize is the number of runes of the content chunks streamed by Stream, the tool calls being in the last chunk.
+	// Optional. Default: the whole content in one chunk
+	ChunkSize int
+}
+
+// ChatModelCall is a call to a fake ChatModel.
+type ChatModelCall struct {
+	Input   []*schema.Message
+	Options *model.Options
+	// Tools are the tools bound with WithTools.
+	Tools  []*schema.ToolInfo
+	Stream bool
+}
+
+// NewChatModel returns a fake ChatModel. The chat models returned by WithTools share its script and its calls.
+func NewChatModel(config *ChatModelConfig) *ChatModel {
+	if config == nil {
+		config = &ChatModelConfig{}
+	}
+	return &ChatModel{
+		chatModelState: &chatModelState{
+			config: config,
+			script: &script[*schema.Message]{faults: config.Faults, responses: config.Responses},
+		},
+	}
+}
+
+var _ model.ToolCallingChatModel = (*ChatModel)(nil)
+
+// ChatModel is a fake model.ToolCallingChatModel.
+type ChatModel struct {
+	tools []*schema.ToolInfo
+	*chatModelState
+}
+
+type chatModelState struct {
+	calls[*ChatModelCall]
+	config *ChatModelConfig
+	script *script[*schema.Message]
+}
+
+func (c *ChatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	return c.respond(ctx, input, opts, false)
+}
+
+func (c *ChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	out, err := c.respond(ctx, input, opts, true)
+	if err != nil {
+		return nil, err
+	}
+	return schema.StreamReaderFromArray(splitMessage(out, c.config.ChunkSize)), nil
+}
+
+func (c *ChatModel) WithTools(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	return &ChatModel{tools: tools, chatModelState: c.chatModelState}, nil
+}
+
+func (c *ChatModel) GetType() string {
+	return "Fake"
+}
+
+func (c *ChatModel) respond(ctx context.Context, input []*schema.Message, opts []model.Option, stream bool) (*schema.Message, error) {
+	c.add(&ChatModelCall{
+		Input:   input,
+		Options: model.GetCommonOptions(&model.Options{Tools: c.tools}, opts...),
+		Tools:   c.tools,
+		Stream:  stream,
+	})
+
+	out, ok, err := c.script.take(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		cp := *out
+		return &cp, nil
+	}
+	if c.config.Handler != nil {
+		return c.config.Handler(ctx, input)
+	}
+	return nil, noResponse("chat model")
+}
+
+// splitMessage splits the content of msg into chunks of size runes.
+func splitMessage(msg *schema.Message, size int) []*schema.Message {
+	content := []rune(msg.Content)
+	if size <= 0 || len(content) <= size {
+		cp := *msg
+		return []*schema.Message{&cp}
+	}
+
+	var chunks []*schema.Message
+	for start := 0; start < len(content); start += size {
+		end := min(start+size, len(content))
+		chunks = append(chunks, &schema.Message{Role: msg.Role, Content: string(content[start:end])})
+	}
+	last := chunks[len(chunks)-1]
+	last.ToolCalls = msg.ToolCalls
+	last.ResponseMeta = msg.ResponseMeta
+	last.Extra = msg.Extra
+	return chunks
+}
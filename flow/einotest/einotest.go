@@ -0,0 +1,146 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package einotest provides fake ChatModel, Embedder, Retriever, Indexer and Tool components for the unit tests
+// of eino applications, with scripted responses, latency and error injection, and recorded calls to assert on.
+package einotest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrNoResponse is returned by a fake component called more times than it has scripted responses.
+var ErrNoResponse = errors.New("no scripted response left")
+
+// Faults injects latency and errors in the calls to a fake component.
+type Faults struct {
+	// Latency delays each call, a canceled context ending the wait with its error.
+	Latency time.Duration
+	// Errors fail the calls in order, a nil error letting its call through. The calls after the last one succeed,
+	// and a failed call consumes no scripted response.
+	Errors []error
+}
+
+// TestingT is the subset of testing.TB used by the assertions.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// script hands out the scripted responses and the faults of the successive calls.
+type script[T any] struct {
+	mu        sync.Mutex
+	faults    Faults
+	responses []T
+	call      int
+	next      int
+}
+
+// take returns the response of the next call, ok being false if no scripted response is left.
+func (s *script[T]) take(ctx context.Context) (resp T, ok bool, err error) {
+	s.mu.Lock()
+	call := s.call
+	s.call++
+	var fault error
+	if call < len(s.faults.Errors) {
+		fault = s.faults.Errors[call]
+	}
+	if fault == nil && s.next < len(s.responses) {
+		resp, ok = s.responses[s.next], true
+		s.next++
+	}
+	s.mu.Unlock()
+
+	if s.faults.Latency > 0 {
+		timer := time.NewTimer(s.faults.Latency)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return resp, false, ctx.Err()
+		case <-timer.C:
+		}
+	}
+	if fault != nil {
+		return resp, false, fault
+	}
+	return resp, ok, nil
+}
+
+// calls records the calls to a fake component.
+type calls[C any] struct {
+	mu   sync.Mutex
+	list []C
+}
+
+func (c *calls[C]) add(call C) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.list = append(c.list, call)
+}
+
+// Calls returns the calls received, in order.
+func (c *calls[C]) Calls() []C {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]C(nil), c.list...)
+}
+
+// CallCount returns the number of calls received.
+func (c *calls[C]) CallCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.list)
+}
+
+// LastCall returns the last call received, the zero value if none.
+func (c *calls[C]) LastCall() C {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var last C
+	if len(c.list) > 0 {
+		last = c.list[len(c.list)-1]
+	}
+	return last
+}
+
+// AssertCallCount reports a test error if the number of calls received is not n.
+func (c *calls[C]) AssertCallCount(t TestingT, n int) bool {
+	t.Helper()
+	if count := c.CallCount(); count != n {
+		t.Errorf("expected %d calls, got %d", n, count)
+		return false
+	}
+	return true
+}
+
+// AssertCalled reports a test error if no call received satisfies match.
+func (c *calls[C]) AssertCalled(t TestingT, match func(call C) bool) bool {
+	t.Helper()
+	for _, call := range c.Calls() {
+		if match(call) {
+			return true
+		}
+	}
+	t.Errorf("no call matched among %d calls", c.CallCount())
+	return false
+}
+
+func noResponse(component string) error {
+	return fmt.Errorf("fake %s: %w", component, ErrNoResponse)
+}
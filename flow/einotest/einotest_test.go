@@ -0,0 +1,192 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package einotest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeT records the errors of the assertions.
+type fakeT struct {
+	errors []string
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, _ ...any) {
+	f.errors = append(f.errors, format)
+}
+
+func TestChatModel(t *testing.T) {
+	ctx := context.Background()
+	errQuota := errors.New("quota exceeded")
+
+	cm := NewChatModel(&ChatModelConfig{
+		Faults:    Faults{Errors: []error{errQuota}},
+		Responses: []*schema.Message{schema.AssistantMessage("hello world", nil)},
+		ChunkSize: 4,
+	})
+
+	_, err := cm.Generate(ctx, []*schema.Message{schema.UserMessage("hi")})
+	assert.ErrorIs(t, err, errQuota)
+
+	sr, err := cm.Stream(ctx, []*schema.Message{schema.UserMessage("hi")}, model.WithTemperature(0.5))
+	require.NoError(t, err)
+	var chunks []string
+	for {
+		chunk, err := sr.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		chunks = append(chunks, chunk.Content)
+	}
+	assert.Equal(t, []string{"hell", "o wo", "rld"}, chunks)
+
+	_, err = cm.Generate(ctx, []*schema.Message{schema.UserMessage("hi")})
+	assert.ErrorIs(t, err, ErrNoResponse)
+
+	withTools, err := cm.WithTools([]*schema.ToolInfo{{Name: "search"}})
+	require.NoError(t, err)
+	_, _ = withTools.Generate(ctx, []*schema.Message{schema.UserMessage("search")})
+
+	assert.True(t, cm.AssertCallCount(t, 4))
+	assert.True(t, cm.AssertCalled(t, func(call *ChatModelCall) bool {
+		return call.Stream && *call.Options.Temperature == 0.5
+	}))
+	assert.Equal(t, "search", cm.LastCall().Tools[0].Name)
+	assert.Equal(t, "search", cm.LastCall().Options.Tools[0].Name)
+
+	ft := &fakeT{}
+	assert.False(t, cm.AssertCallCount(ft, 1))
+	assert.False(t, cm.AssertCalled(ft, func(*ChatModelCall) bool { return false }))
+	assert.Len(t, ft.errors, 2)
+
+	t.Run("handler", func(t *testing.T) {
+		echo := NewChatModel(&ChatModelConfig{
+			Handler: func(_ context.Context, input []*schema.Message) (*schema.Message, error) {
+				return schema.AssistantMessage(input[0].Content, nil), nil
+			},
+		})
+		out, err := echo.Generate(ctx, []*schema.Message{schema.UserMessage("ping")})
+		require.NoError(t, err)
+		assert.Equal(t, "ping", out.Content)
+	})
+
+	t.Run("latency", func(t *testing.T) {
+		slow := NewChatModel(&ChatModelConfig{
+			Faults:    Faults{Latency: time.Hour},
+			Responses: []*schema.Message{schema.AssistantMessage("late", nil)},
+		})
+		timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		_, err := slow.Generate(timeout, nil)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestEmbedder(t *testing.T) {
+	ctx := context.Background()
+	e := NewEmbedder(&EmbedderConfig{Responses: [][][]float64{{{1, 0}}}, Dimensions: 4})
+
+	out, err := e.EmbedStrings(ctx, []string{"a"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]float64{{1, 0}}, out)
+
+	out, err = e.EmbedStrings(ctx, []string{"a", "b", "a"})
+	require.NoError(t, err)
+	require.Len(t, out, 3)
+	assert.Len(t, out[0], 4)
+	assert.Equal(t, out[0], out[2])
+	assert.NotEqual(t, out[0], out[1])
+	var norm float64
+	for _, v := range out[0] {
+		norm += v * v
+	}
+	assert.InDelta(t, 1, norm, 1e-9)
+
+	assert.Equal(t, []string{"a", "b", "a"}, e.LastCall().Texts)
+}
+
+func TestRetrieverIndexerTool(t *testing.T) {
+	ctx := context.Background()
+
+	r := NewRetriever(&RetrieverConfig{
+		Responses: [][]*schema.Document{{{ID: "1"}, {ID: "2"}, {ID: "3"}}},
+	})
+	docs, err := r.Retrieve(ctx, "q", retriever.WithTopK(2))
+	require.NoError(t, err)
+	assert.Len(t, docs, 2)
+	docs, err = r.Retrieve(ctx, "q")
+	require.NoError(t, err)
+	assert.Empty(t, docs)
+	assert.Equal(t, 2, *r.Calls()[0].Options.TopK)
+
+	idx := NewIndexer(&IndexerConfig{Faults: Faults{Errors: []error{nil, errors.New("unavailable")}}})
+	ids, err := idx.Store(ctx, []*schema.Document{{Content: "a"}, {ID: "x", Content: "b"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"doc-1", "x"}, ids)
+	_, err = idx.Store(ctx, []*schema.Document{{Content: "c"}})
+	assert.Error(t, err)
+	assert.Len(t, idx.Documents(), 2)
+	idx.AssertCallCount(t, 2)
+
+	tl := NewTool(&ToolConfig{Responses: []string{"sunny"}})
+	info, err := tl.Info(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "fake_tool", info.Name)
+	out, err := tl.InvokableRun(ctx, `{"city": "Beijing"}`)
+	require.NoError(t, err)
+	assert.Equal(t, "sunny", out)
+	_, err = tl.InvokableRun(ctx, `{}`)
+	assert.ErrorIs(t, err, ErrNoResponse)
+	assert.Equal(t, `{"city": "Beijing"}`, tl.Calls()[0].Arguments)
+}
+
+func TestInGraph(t *testing.T) {
+	ctx := context.Background()
+	weather := NewTool(&ToolConfig{Info: &schema.ToolInfo{Name: "get_weather"}, Responses: []string{"sunny"}})
+	cm := NewChatModel(&ChatModelConfig{
+		Responses: []*schema.Message{
+			schema.AssistantMessage("", []schema.ToolCall{{ID: "1", Function: schema.FunctionCall{Name: "get_weather", Arguments: "{}"}}}),
+		},
+	})
+
+	toolsNode, err := compose.NewToolNode(ctx, &compose.ToolsNodeConfig{Tools: []tool.BaseTool{weather}})
+	require.NoError(t, err)
+	chain := compose.NewChain[[]*schema.Message, []*schema.Message]()
+	chain.AppendChatModel(cm).AppendToolsNode(toolsNode)
+	runnable, err := chain.Compile(ctx)
+	require.NoError(t, err)
+
+	out, err := runnable.Invoke(ctx, []*schema.Message{schema.UserMessage("weather?")})
+	require.NoError(t, err)
+	require.Len(t, out, 1)
+	assert.Equal(t, "sunny", out[0].Content)
+	weather.AssertCallCount(t, 1)
+}
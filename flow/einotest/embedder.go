@@ -0,0 +1,112 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package einotest
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+
+	"github.com/cloudwego/eino/components/embedding"
+)
+
+type EmbedderConfig struct {
+	Faults
+	// Responses are returned in order, one per call.
+	// Optional
+	Responses [][][]float64
+	// Handler computes the responses once the scripted ones are all returned.
+	// Optional. Default: HashEmbedding of Dimensions
+	Handler func(ctx context.Context, texts []string) ([][]float64, error)
+	// Dimensions of the vectors of the default Handler.
+	// Optional. Default: 8
+	Dimensions int
+}
+
+// EmbedderCall is a call to a fake Embedder.
+type EmbedderCall struct {
+	Texts   []string
+	Options *embedding.Options
+}
+
+// NewEmbedder returns a fake Embedder.
+func NewEmbedder(config *EmbedderConfig) *Embedder {
+	if config == nil {
+		config = &EmbedderConfig{}
+	}
+	return &Embedder{
+		config: config,
+		script: &script[[][]float64]{faults: config.Faults, responses: config.Responses},
+	}
+}
+
+var _ embedding.Embedder = (*Embedder)(nil)
+
+// Embedder is a fake embedding.Embedder.
+type Embedder struct {
+	calls[*EmbedderCall]
+	config *EmbedderConfig
+	script *script[[][]float64]
+}
+
+func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	e.add(&EmbedderCall{Texts: texts, Options: embedding.GetCommonOptions(nil, opts...)})
+
+	out, ok, err := e.script.take(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return out, nil
+	}
+	if e.config.Handler != nil {
+		return e.config.Handler(ctx, texts)
+	}
+
+	dims := e.config.Dimensions
+	if dims <= 0 {
+		dims = 8
+	}
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		vectors[i] = HashEmbedding(text, dims)
+	}
+	return vectors, nil
+}
+
+func (e *Embedder) GetType() string {
+	return "Fake"
+}
+
+// HashEmbedding returns a deterministic unit vector of text, the same text always getting the same vector.
+func HashEmbedding(text string, dims int) []float64 {
+	vector := make([]float64, dims)
+	var norm float64
+	for i := range vector {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte{byte(i), byte(i >> 8)})
+		_, _ = h.Write([]byte(text))
+		vector[i] = float64(h.Sum64()%2000)/1000 - 1
+		norm += vector[i] * vector[i]
+	}
+	norm = math.Sqrt(norm)
+	if norm > 0 {
+		for i := range vector {
+			vector[i] /= norm
+		}
+	}
+	return vector
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/flow/einotest"
+)
+
+func main() {
+	ctx := context.Background()
+
+	weather := einotest.NewTool(&einotest.ToolConfig{
+		Info:      &schema.ToolInfo{Name: "get_weather", Desc: "get the weather of a city"},
+		Responses: []string{`{"weather": "sunny"}`},
+	})
+	cm := einotest.NewChatModel(&einotest.ChatModelConfig{
+		Responses: []*schema.Message{
+			schema.AssistantMessage("", []schema.ToolCall{{
+				ID:       "call_1",
+				Function: schema.FunctionCall{Name: "get_weather", Arguments: `{"city": "Beijing"}`},
+			}}),
+		},
+	})
+
+	toolsNode, err := compose.NewToolNode(ctx, &compose.ToolsNodeConfig{Tools: []tool.BaseTool{weather}})
+	if err != nil {
+		log.Fatal(err)
+	}
+	chain := compose.NewChain[[]*schema.Message, []*schema.Message]()
+	chain.AppendChatModel(cm).AppendToolsNode(toolsNode)
+	runnable, err := chain.Compile(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	out, err := runnable.Invoke(ctx, []*schema.Message{schema.UserMessage("What is the weather in Beijing?")})
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("tool result: %s", out[0].Content)
+	log.Printf("chat model calls: %d, tool arguments: %s", cm.CallCount(), weather.LastCall().Arguments)
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package einotest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+)
+
+type IndexerConfig struct {
+	Faults
+}
+
+// IndexerCall is a call to a fake Indexer.
+type IndexerCall struct {
+	Docs    []*schema.Document
+	Options *indexer.Options
+}
+
+// NewIndexer returns a fake Indexer keeping the documents stored in memory.
+// The documents without id get the id "doc-N", N counting the stored documents from 1.
+func NewIndexer(config *IndexerConfig) *Indexer {
+	if config == nil {
+		config = &IndexerConfig{}
+	}
+	return &Indexer{
+		script: &script[struct{}]{faults: config.Faults},
+	}
+}
+
+var _ indexer.Indexer = (*Indexer)(nil)
+
+// Indexer is a fake indexer.Indexer.
+type Indexer struct {
+	calls[*IndexerCall]
+	script *script[struct{}]
+
+	mu   sync.Mutex
+	docs []*schema.Document
+}
+
+func (i *Indexer) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) ([]string, error) {
+	i.add(&IndexerCall{Docs: docs, Options: indexer.GetCommonOptions(nil, opts...)})
+
+	if _, _, err := i.script.take(ctx); err != nil {
+		return nil, err
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	ids := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		cp := *doc
+		if cp.ID == "" {
+			cp.ID = fmt.Sprintf("doc-%d", len(i.docs)+1)
+		}
+		i.docs = append(i.docs, &cp)
+		ids = append(ids, cp.ID)
+	}
+	return ids, nil
+}
+
+// Documents returns the documents stored, in order.
+func (i *Indexer) Documents() []*schema.Document {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return append([]*schema.Document(nil), i.docs...)
+}
+
+func (i *Indexer) GetType() string {
+	return "Fake"
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package einotest
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+)
+
+type RetrieverConfig struct {
+	Faults
+	// Responses are returned in order, one per call.
+	// Optional
+	Responses [][]*schema.Document
+	// Handler computes the responses once the scripted ones are all returned.
+	// Optional. Default: no document
+	Handler func(ctx context.Context, query string) ([]*schema.Document, error)
+}
+
+// RetrieverCall is a call to a fake Retriever.
+type RetrieverCall struct {
+	Query   string
+	Options *retriever.Options
+}
+
+// NewRetriever returns a fake Retriever. The documents returned are truncated to the TopK option when set.
+func NewRetriever(config *RetrieverConfig) *Retriever {
+	if config == nil {
+		config = &RetrieverConfig{}
+	}
+	return &Retriever{
+		config: config,
+		script: &script[[]*schema.Document]{faults: config.Faults, responses: config.Responses},
+	}
+}
+
+var _ retriever.Retriever = (*Retriever)(nil)
+
+// Retriever is a fake retriever.Retriever.
+type Retriever struct {
+	calls[*RetrieverCall]
+	config *RetrieverConfig
+	script *script[[]*schema.Document]
+}
+
+func (r *Retriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) ([]*schema.Document, error) {
+	options := retriever.GetCommonOptions(nil, opts...)
+	r.add(&RetrieverCall{Query: query, Options: options})
+
+	docs, ok, err := r.script.take(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !ok && r.config.Handler != nil {
+		if docs, err = r.config.Handler(ctx, query); err != nil {
+			return nil, err
+		}
+	}
+	if options.TopK != nil && *options.TopK >= 0 && len(docs) > *options.TopK {
+		docs = docs[:*options.TopK]
+	}
+	return docs, nil
+}
+
+func (r *Retriever) GetType() string {
+	return "Fake"
+}
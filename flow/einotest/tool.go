@@ -0,0 +1,92 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package einotest
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+)
+
+type ToolConfig struct {
+	Faults
+	// Info describes the tool to the chat models.
+	// Optional. Default: a tool named "fake_tool" without parameters
+	Info *schema.ToolInfo
+	// Responses are returned in order, one per call.
+	// Optional
+	Responses []string
+	// Handler computes the responses once the scripted ones are all returned.
+	// Optional. Default: failing with ErrNoResponse
+	Handler func(ctx context.Context, arguments string) (string, error)
+}
+
+// ToolCall is a call to a fake Tool.
+type ToolCall struct {
+	Arguments string
+	Options   []tool.Option
+}
+
+// NewTool returns a fake Tool.
+func NewTool(config *ToolConfig) *Tool {
+	if config == nil {
+		config = &ToolConfig{}
+	}
+	info := config.Info
+	if info == nil {
+		info = &schema.ToolInfo{Name: "fake_tool", Desc: "a fake tool"}
+	}
+	return &Tool{
+		config: config,
+		info:   info,
+		script: &script[string]{faults: config.Faults, responses: config.Responses},
+	}
+}
+
+var _ tool.InvokableTool = (*Tool)(nil)
+
+// Tool is a fake tool.InvokableTool.
+type Tool struct {
+	calls[*ToolCall]
+	config *ToolConfig
+	info   *schema.ToolInfo
+	script *script[string]
+}
+
+func (t *Tool) Info(_ context.Context) (*schema.ToolInfo, error) {
+	return t.info, nil
+}
+
+func (t *Tool) InvokableRun(ctx context.Context, argumentsInJSON string, opts ...tool.Option) (string, error) {
+	t.add(&ToolCall{Arguments: argumentsInJSON, Options: opts})
+
+	out, ok, err := t.script.take(ctx)
+	if err != nil {
+		return "", err
+	}
+	if ok {
+		return out, nil
+	}
+	if t.config.Handler != nil {
+		return t.config.Handler(ctx, argumentsInJSON)
+	}
+	return "", noResponse("tool " + t.info.Name)
+}
+
+func (t *Tool) GetType() string {
+	return "Fake"
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package evals scores the quality of question answering and RAG applications with a judge chat model:
+// faithfulness, answer relevance, context precision and context recall, over datasets or recorded runs,
+// with JSON and CSV reports and thresholds failing the run on a regression.
+package evals
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ErrNotApplicable is returned by the metrics which cannot score a sample, e.g. context recall without ground truth.
+// The sample is reported as skipped for the metric.
+var ErrNotApplicable = errors.New("metric not applicable to sample")
+
+// Sample is a question answered by the application.
+type Sample struct {
+	ID       string `json:"id"`
+	Question string `json:"question"`
+	// Answer is the answer of the application.
+	Answer string `json:"answer"`
+	// Contexts are the retrieved passages the answer is based on, in ranking order.
+	Contexts []string `json:"contexts,omitempty"`
+	// GroundTruth is the reference answer, required by context recall.
+	GroundTruth string         `json:"ground_truth,omitempty"`
+	Metadata    map[string]any `json:"metadata,omitempty"`
+}
+
+// Score is the score of a sample for a metric.
+type Score struct {
+	// Value is between 0 and 1, the higher the better.
+	Value float64 `json:"value"`
+	// Reason is the explanation of the judge.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Metric scores samples.
+type Metric interface {
+	Name() string
+	Score(ctx context.Context, sample *Sample) (*Score, error)
+}
+
+// ReadJSONL reads the samples of a dataset with a json sample per line.
+func ReadJSONL(r io.Reader) ([]*Sample, error) {
+	var samples []*Sample
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		s := &Sample{}
+		if err := json.Unmarshal([]byte(text), s); err != nil {
+			return nil, fmt.Errorf("decode sample of line %d fail: %w", line, err)
+		}
+		if s.ID == "" {
+			s.ID = fmt.Sprint(line)
+		}
+		samples = append(samples, s)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read dataset fail: %w", err)
+	}
+	return samples, nil
+}
+
+// LoadDataset reads the samples of a .jsonl file, or of a .json file holding an array of samples.
+func LoadDataset(path string) ([]*Sample, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open dataset fail: %w", err)
+	}
+	defer f.Close()
+
+	if !strings.HasSuffix(path, ".json") {
+		return ReadJSONL(f)
+	}
+
+	var samples []*Sample
+	if err = json.NewDecoder(f).Decode(&samples); err != nil {
+		return nil, fmt.Errorf("decode dataset fail: %w", err)
+	}
+	for i, s := range samples {
+		if s.ID == "" {
+			s.ID = fmt.Sprint(i + 1)
+		}
+	}
+	return samples, nil
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/flow/evals"
+)
+
+func main() {
+	dataset := flag.String("dataset", "", "dataset file, json or jsonl")
+	out := flag.String("out", "", "report file, stdout if empty")
+	format := flag.String("format", "json", "report format, json or csv")
+	flag.Parse()
+
+	ctx := context.Background()
+
+	samples := []*evals.Sample{
+		{
+			ID:          "1",
+			Question:    "What is Eino?",
+			Answer:      "Eino is an LLM application framework written in Go.",
+			Contexts:    []string{"Eino is the ultimate LLM application development framework in Golang."},
+			GroundTruth: "Eino is a Go framework for LLM applications.",
+		},
+	}
+	if *dataset != "" {
+		var err error
+		samples, err = evals.LoadDataset(*dataset)
+		if err != nil {
+			log.Fatalf("load dataset fail: %v", err)
+		}
+	}
+
+	// replace with a real judge model, e.g. openai or ark chat model
+	judge := &fakeJudge{}
+
+	report, err := evals.Run(ctx, &evals.Config{
+		Metrics:     evals.RAGMetrics(judge),
+		Concurrency: 4,
+		Thresholds: map[string]float64{
+			evals.MetricFaithfulness:    0.8,
+			evals.MetricAnswerRelevance: 0.7,
+		},
+	}, samples)
+	if err != nil {
+		log.Fatalf("run evals fail: %v", err)
+	}
+
+	w := os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			log.Fatalf("create report fail: %v", err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if *format == "csv" {
+		err = report.WriteCSV(w)
+	} else {
+		err = report.WriteJSON(w)
+	}
+	if err != nil {
+		log.Fatalf("write report fail: %v", err)
+	}
+
+	if !report.Passed {
+		fmt.Fprintf(os.Stderr, "metrics below threshold: %v\n", report.Failures())
+		os.Exit(1)
+	}
+}
+
+// fakeJudge gives canned verdicts, approving everything.
+type fakeJudge struct{}
+
+func (f *fakeJudge) Generate(_ context.Context, input []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	prompt := input[0].Content
+	switch {
+	case strings.Contains(prompt, "faithful"):
+		return schema.AssistantMessage(`{"claims": [{"claim": "Eino is a Go framework", "supported": true}], "reason": "supported by the context"}`, nil), nil
+	case strings.Contains(prompt, "addresses"):
+		return schema.AssistantMessage(`{"rating": 5, "reason": "answers the question"}`, nil), nil
+	case strings.Contains(prompt, "cover the reference answer"):
+		return schema.AssistantMessage(`{"statements": [{"statement": "Eino is a Go framework", "attributed": true}], "reason": "covered"}`, nil), nil
+	default:
+		return schema.AssistantMessage(`{"verdicts": [true], "reason": "the context is useful"}`, nil), nil
+	}
+}
+
+func (f *fakeJudge) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	msg, err := f.Generate(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return schema.StreamReaderFromArray([]*schema.Message{msg}), nil
+}
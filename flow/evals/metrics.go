@@ -0,0 +1,238 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package evals
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	MetricFaithfulness     = "faithfulness"
+	MetricAnswerRelevance  = "answer_relevance"
+	MetricContextPrecision = "context_precision"
+	MetricContextRecall    = "context_recall"
+)
+
+const faithfulnessPrompt = `You evaluate whether an answer is faithful to its context.
+Break the answer down into its factual claims, and decide for each claim whether it can be inferred from the context alone.
+Reply with json only: {"claims": [{"claim": "...", "supported": true}], "reason": "one sentence"}`
+
+const answerRelevancePrompt = `You evaluate whether an answer addresses the question, regardless of its correctness.
+Rate from 1 (off-topic or evasive) to 5 (directly and completely addresses the question), penalizing incomplete or redundant answers.
+Reply with json only: {"rating": 5, "reason": "one sentence"}`
+
+const contextPrecisionPrompt = `You evaluate the retrieved contexts of a question.
+For each context, in order, decide whether it is useful to answer the question, in light of the reference answer if given.
+Reply with json only: {"verdicts": [true, false], "reason": "one sentence"}, with a verdict per context.`
+
+const contextRecallPrompt = `You evaluate whether the retrieved contexts cover the reference answer.
+Break the reference answer down into its statements, and decide for each statement whether it can be attributed to the contexts.
+Reply with json only: {"statements": [{"statement": "...", "attributed": true}], "reason": "one sentence"}`
+
+// NewFaithfulness returns the metric of the fraction of the claims of the answer supported by the contexts.
+func NewFaithfulness(judge model.BaseChatModel) Metric {
+	return &judgeMetric{name: MetricFaithfulness, judge: judge, score: scoreFaithfulness}
+}
+
+// NewAnswerRelevance returns the metric of how well the answer addresses the question, rated by the judge.
+func NewAnswerRelevance(judge model.BaseChatModel) Metric {
+	return &judgeMetric{name: MetricAnswerRelevance, judge: judge, score: scoreAnswerRelevance}
+}
+
+// NewContextPrecision returns the metric of the ranking of the useful contexts, as the mean precision at
+// the rank of each useful context, so that the useful contexts ranked first score higher.
+func NewContextPrecision(judge model.BaseChatModel) Metric {
+	return &judgeMetric{name: MetricContextPrecision, judge: judge, score: scoreContextPrecision}
+}
+
+// NewContextRecall returns the metric of the fraction of the statements of the ground truth attributable to the contexts.
+// It requires the ground truth.
+func NewContextRecall(judge model.BaseChatModel) Metric {
+	return &judgeMetric{name: MetricContextRecall, judge: judge, score: scoreContextRecall}
+}
+
+// RAGMetrics returns the four metrics with the same judge.
+func RAGMetrics(judge model.BaseChatModel) []Metric {
+	return []Metric{
+		NewFaithfulness(judge),
+		NewAnswerRelevance(judge),
+		NewContextPrecision(judge),
+		NewContextRecall(judge),
+	}
+}
+
+type judgeMetric struct {
+	name  string
+	judge model.BaseChatModel
+	score func(ctx context.Context, m *judgeMetric, s *Sample) (*Score, error)
+}
+
+func (j *judgeMetric) Name() string {
+	return j.name
+}
+
+func (j *judgeMetric) Score(ctx context.Context, sample *Sample) (*Score, error) {
+	if j.judge == nil {
+		return nil, errors.New("judge is required")
+	}
+	return j.score(ctx, j, sample)
+}
+
+// ask sends the prompt and the sample fields to the judge, and decodes its json reply into out.
+func (j *judgeMetric) ask(ctx context.Context, prompt, input string, out any) error {
+	msg, err := j.judge.Generate(ctx, []*schema.Message{
+		schema.SystemMessage(prompt),
+		schema.UserMessage(input),
+	})
+	if err != nil {
+		return fmt.Errorf("judge %s fail: %w", j.name, err)
+	}
+
+	content := msg.Content
+	start, end := strings.Index(content, "{"), strings.LastIndex(content, "}")
+	if start < 0 || end < start {
+		return fmt.Errorf("judge %s replied without json: %s", j.name, content)
+	}
+	if err = json.Unmarshal([]byte(content[start:end+1]), out); err != nil {
+		return fmt.Errorf("decode judge %s reply fail: %w", j.name, err)
+	}
+	return nil
+}
+
+func scoreFaithfulness(ctx context.Context, m *judgeMetric, s *Sample) (*Score, error) {
+	if len(s.Contexts) == 0 || s.Answer == "" {
+		return nil, ErrNotApplicable
+	}
+
+	var reply struct {
+		Claims []struct {
+			Claim     string `json:"claim"`
+			Supported bool   `json:"supported"`
+		} `json:"claims"`
+		Reason string `json:"reason"`
+	}
+	input := formatContexts(s.Contexts) + "\n<answer>\n" + s.Answer + "\n</answer>"
+	if err := m.ask(ctx, faithfulnessPrompt, input, &reply); err != nil {
+		return nil, err
+	}
+	if len(reply.Claims) == 0 {
+		return &Score{Value: 1, Reason: reply.Reason}, nil
+	}
+
+	supported := 0
+	for _, c := range reply.Claims {
+		if c.Supported {
+			supported++
+		}
+	}
+	return &Score{Value: float64(supported) / float64(len(reply.Claims)), Reason: reply.Reason}, nil
+}
+
+func scoreAnswerRelevance(ctx context.Context, m *judgeMetric, s *Sample) (*Score, error) {
+	if s.Answer == "" {
+		return nil, ErrNotApplicable
+	}
+
+	var reply struct {
+		Rating float64 `json:"rating"`
+		Reason string  `json:"reason"`
+	}
+	input := "<question>\n" + s.Question + "\n</question>\n<answer>\n" + s.Answer + "\n</answer>"
+	if err := m.ask(ctx, answerRelevancePrompt, input, &reply); err != nil {
+		return nil, err
+	}
+	if reply.Rating < 1 || reply.Rating > 5 {
+		return nil, fmt.Errorf("judge %s replied an invalid rating: %v", m.name, reply.Rating)
+	}
+	return &Score{Value: (reply.Rating - 1) / 4, Reason: reply.Reason}, nil
+}
+
+func scoreContextPrecision(ctx context.Context, m *judgeMetric, s *Sample) (*Score, error) {
+	if len(s.Contexts) == 0 {
+		return nil, ErrNotApplicable
+	}
+
+	var reply struct {
+		Verdicts []bool `json:"verdicts"`
+		Reason   string `json:"reason"`
+	}
+	input := "<question>\n" + s.Question + "\n</question>\n" + formatContexts(s.Contexts)
+	if s.GroundTruth != "" {
+		input += "\n<reference>\n" + s.GroundTruth + "\n</reference>"
+	}
+	if err := m.ask(ctx, contextPrecisionPrompt, input, &reply); err != nil {
+		return nil, err
+	}
+	if len(reply.Verdicts) != len(s.Contexts) {
+		return nil, fmt.Errorf("judge %s replied %d verdicts for %d contexts", m.name, len(reply.Verdicts), len(s.Contexts))
+	}
+
+	var useful, sum float64
+	for k, v := range reply.Verdicts {
+		if v {
+			useful++
+			sum += useful / float64(k+1)
+		}
+	}
+	if useful == 0 {
+		return &Score{Value: 0, Reason: reply.Reason}, nil
+	}
+	return &Score{Value: sum / useful, Reason: reply.Reason}, nil
+}
+
+func scoreContextRecall(ctx context.Context, m *judgeMetric, s *Sample) (*Score, error) {
+	if len(s.Contexts) == 0 || s.GroundTruth == "" {
+		return nil, ErrNotApplicable
+	}
+
+	var reply struct {
+		Statements []struct {
+			Statement  string `json:"statement"`
+			Attributed bool   `json:"attributed"`
+		} `json:"statements"`
+		Reason string `json:"reason"`
+	}
+	input := formatContexts(s.Contexts) + "\n<reference>\n" + s.GroundTruth + "\n</reference>"
+	if err := m.ask(ctx, contextRecallPrompt, input, &reply); err != nil {
+		return nil, err
+	}
+	if len(reply.Statements) == 0 {
+		return nil, fmt.Errorf("judge %s replied no statement", m.name)
+	}
+
+	attributed := 0
+	for _, st := range reply.Statements {
+		if st.Attributed {
+			attributed++
+		}
+	}
+	return &Score{Value: float64(attributed) / float64(len(reply.Statements)), Reason: reply.Reason}, nil
+}
+
+func formatContexts(contexts []string) string {
+	sb := strings.Builder{}
+	for i, c := range contexts {
+		fmt.Fprintf(&sb, "<context index=\"%d\">\n%s\n</context>\n", i+1, c)
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
@@ -0,0 +1,104 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package evals
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeJudge replies with the reply of the first prompt prefix matching its system prompt.
+type fakeJudge struct {
+	replies map[string]string
+	inputs  []string
+}
+
+func (f *fakeJudge) Generate(_ context.Context, input []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	f.inputs = append(f.inputs, input[1].Content)
+	for prefix, reply := range f.replies {
+		if strings.HasPrefix(input[0].Content, prefix) {
+			return schema.AssistantMessage(reply, nil), nil
+		}
+	}
+	return nil, errors.New("unexpected prompt")
+}
+
+func (f *fakeJudge) Stream(context.Context, []*schema.Message, ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return nil, errors.New("not implemented")
+}
+
+var testSample = &Sample{
+	ID:          "1",
+	Question:    "When was eino released?",
+	Answer:      "Eino was released in 2024 by ByteDance.",
+	Contexts:    []string{"The weather is nice.", "Eino was open sourced by CloudWeGo in 2024."},
+	GroundTruth: "Eino was released in 2024.",
+}
+
+func TestMetrics(t *testing.T) {
+	ctx := context.Background()
+	judge := &fakeJudge{replies: map[string]string{
+		"You evaluate whether an answer is faithful": "```json\n" +
+			`{"claims": [{"claim": "released in 2024", "supported": true}, {"claim": "by ByteDance", "supported": false}], "reason": "half"}` + "\n```",
+		"You evaluate whether an answer addresses": `{"rating": 4, "reason": "mostly"}`,
+		"You evaluate the retrieved contexts":      `{"verdicts": [false, true], "reason": "second one"}`,
+		"You evaluate whether the retrieved":       `{"statements": [{"statement": "released in 2024", "attributed": true}], "reason": "all"}`,
+	}}
+
+	expected := map[string]*Score{
+		MetricFaithfulness:     {Value: 0.5, Reason: "half"},
+		MetricAnswerRelevance:  {Value: 0.75, Reason: "mostly"},
+		MetricContextPrecision: {Value: 0.5, Reason: "second one"},
+		MetricContextRecall:    {Value: 1, Reason: "all"},
+	}
+	for _, m := range RAGMetrics(judge) {
+		score, err := m.Score(ctx, testSample)
+		require.NoError(t, err, m.Name())
+		assert.Equal(t, expected[m.Name()], score, m.Name())
+	}
+	assert.Contains(t, judge.inputs[0], "<context index=\"2\">\nEino was open sourced by CloudWeGo in 2024.\n</context>")
+	assert.Contains(t, judge.inputs[2], "<reference>\nEino was released in 2024.\n</reference>")
+
+	t.Run("not applicable", func(t *testing.T) {
+		_, err := NewContextRecall(judge).Score(ctx, &Sample{Question: "q", Answer: "a", Contexts: []string{"c"}})
+		assert.ErrorIs(t, err, ErrNotApplicable)
+		_, err = NewFaithfulness(judge).Score(ctx, &Sample{Question: "q", Answer: "a"})
+		assert.ErrorIs(t, err, ErrNotApplicable)
+	})
+
+	t.Run("invalid replies", func(t *testing.T) {
+		bad := &fakeJudge{replies: map[string]string{
+			"You evaluate whether an answer addresses":   `{"rating": 9}`,
+			"You evaluate the retrieved contexts":        `{"verdicts": [true]}`,
+			"You evaluate whether an answer is faithful": "no json",
+		}}
+		_, err := NewAnswerRelevance(bad).Score(ctx, testSample)
+		assert.ErrorContains(t, err, "invalid rating")
+		_, err = NewContextPrecision(bad).Score(ctx, testSample)
+		assert.ErrorContains(t, err, "1 verdicts for 2 contexts")
+		_, err = NewFaithfulness(bad).Score(ctx, testSample)
+		assert.ErrorContains(t, err, "without json")
+		_, err = NewFaithfulness(nil).Score(ctx, testSample)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,163 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package evals
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+)
+
+type recordKey struct{}
+
+// Recorder records samples from the runs of an application through callbacks: the documents of the retrievers
+// become the contexts, and the output of the last chat model call the answer.
+//
+//	ctx = recorder.Start(ctx, "q1", question)
+//	_, err = runnable.Invoke(ctx, question, compose.WithCallbacks(recorder.Handler()))
+//	...
+//	report, err := evals.Run(ctx, config, recorder.Samples())
+type Recorder struct {
+	mu      sync.Mutex
+	wg      sync.WaitGroup
+	samples []*Sample
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Start returns a context recording the sample of id and question, to pass to the run of the application.
+func (r *Recorder) Start(ctx context.Context, id, question string) context.Context {
+	s := &Sample{ID: id, Question: question}
+
+	r.mu.Lock()
+	r.samples = append(r.samples, s)
+	r.mu.Unlock()
+
+	return context.WithValue(ctx, recordKey{}, s)
+}
+
+// SetGroundTruth sets the ground truth of the sample of id.
+func (r *Recorder) SetGroundTruth(id, groundTruth string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, s := range r.samples {
+		if s.ID == id {
+			s.GroundTruth = groundTruth
+		}
+	}
+}
+
+// Samples returns the recorded samples, once the streamed outputs being recorded are fully received.
+func (r *Recorder) Samples() []*Sample {
+	r.wg.Wait()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ret := make([]*Sample, 0, len(r.samples))
+	for _, s := range r.samples {
+		c := *s
+		c.Contexts = append([]string(nil), s.Contexts...)
+		ret = append(ret, &c)
+	}
+	return ret
+}
+
+// Handler returns the callback handler recording the samples.
+func (r *Recorder) Handler() callbacks.Handler {
+	return callbacks.NewHandlerBuilder().
+		OnEndFn(r.onEnd).
+		OnEndWithStreamOutputFn(r.onEndWithStreamOutput).
+		Build()
+}
+
+func (r *Recorder) onEnd(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+	s, ok := ctx.Value(recordKey{}).(*Sample)
+	if !ok || info == nil {
+		return ctx
+	}
+
+	switch info.Component {
+	case components.ComponentOfRetriever:
+		if out := retriever.ConvCallbackOutput(output); out != nil {
+			r.mu.Lock()
+			for _, doc := range out.Docs {
+				s.Contexts = append(s.Contexts, doc.Content)
+			}
+			r.mu.Unlock()
+		}
+	case components.ComponentOfChatModel:
+		if out := model.ConvCallbackOutput(output); out != nil && out.Message != nil {
+			r.setAnswer(s, out.Message)
+		}
+	}
+	return ctx
+}
+
+func (r *Recorder) onEndWithStreamOutput(ctx context.Context, info *callbacks.RunInfo, output *schema.StreamReader[callbacks.CallbackOutput]) context.Context {
+	s, ok := ctx.Value(recordKey{}).(*Sample)
+	if !ok || info == nil || info.Component != components.ComponentOfChatModel {
+		output.Close()
+		return ctx
+	}
+
+	r.wg.Add(1)
+	go func() {
+		defer func() {
+			recover()
+			output.Close()
+			r.wg.Done()
+		}()
+
+		var chunks []*schema.Message
+		for {
+			chunk, err := output.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return
+			}
+			if out := model.ConvCallbackOutput(chunk); out != nil && out.Message != nil {
+				chunks = append(chunks, out.Message)
+			}
+		}
+		if msg, err := schema.ConcatMessages(chunks); err == nil {
+			r.setAnswer(s, msg)
+		}
+	}()
+	return ctx
+}
+
+// setAnswer records the final answer, the chat model outputs calling tools being intermediate steps.
+func (r *Recorder) setAnswer(s *Sample, msg *schema.Message) {
+	if len(msg.ToolCalls) > 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s.Answer = msg.Content
+}
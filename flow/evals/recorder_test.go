@@ -0,0 +1,79 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package evals
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRetriever struct{}
+
+func (fakeRetriever) Retrieve(_ context.Context, query string, _ ...retriever.Option) ([]*schema.Document, error) {
+	return []*schema.Document{{Content: "doc about " + query}}, nil
+}
+
+type fakeModel struct{}
+
+func (fakeModel) Generate(_ context.Context, input []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	return schema.AssistantMessage("answer to "+input[len(input)-1].Content, nil), nil
+}
+
+func (fakeModel) Stream(_ context.Context, input []*schema.Message, _ ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return schema.StreamReaderFromArray([]*schema.Message{
+		schema.AssistantMessage("answer to ", nil),
+		schema.AssistantMessage(input[len(input)-1].Content, nil),
+	}), nil
+}
+
+func TestRecorder(t *testing.T) {
+	ctx := context.Background()
+
+	chain := compose.NewChain[string, *schema.Message]()
+	chain.AppendRetriever(fakeRetriever{}).
+		AppendLambda(compose.InvokableLambda(func(_ context.Context, docs []*schema.Document) ([]*schema.Message, error) {
+			return []*schema.Message{schema.UserMessage(docs[0].Content)}, nil
+		})).
+		AppendChatModel(fakeModel{})
+	runnable, err := chain.Compile(ctx)
+	require.NoError(t, err)
+
+	recorder := NewRecorder()
+	_, err = runnable.Invoke(recorder.Start(ctx, "1", "eino"), "eino", compose.WithCallbacks(recorder.Handler()))
+	require.NoError(t, err)
+
+	sr, err := runnable.Stream(recorder.Start(ctx, "2", "graphs"), "graphs", compose.WithCallbacks(recorder.Handler()))
+	require.NoError(t, err)
+	sr.Close()
+
+	// runs without a started sample are ignored
+	_, err = runnable.Invoke(ctx, "other", compose.WithCallbacks(recorder.Handler()))
+	require.NoError(t, err)
+
+	recorder.SetGroundTruth("1", "truth")
+	samples := recorder.Samples()
+	assert.Equal(t, []*Sample{
+		{ID: "1", Question: "eino", Answer: "answer to doc about eino", Contexts: []string{"doc about eino"}, GroundTruth: "truth"},
+		{ID: "2", Question: "graphs", Answer: "answer to doc about graphs", Contexts: []string{"doc about graphs"}},
+	}, samples)
+}
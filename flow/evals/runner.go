@@ -0,0 +1,251 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package evals
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Config struct {
+	// Metrics score the samples.
+	// Required
+	Metrics []Metric
+	// Concurrency is the number of samples scored at the same time.
+	// Optional. Default: 1
+	Concurrency int
+	// Thresholds are the minimum mean scores of the metrics, by metric name, a lower mean failing the report.
+	// Optional
+	Thresholds map[string]float64
+}
+
+// SampleResult is the scores of a sample.
+type SampleResult struct {
+	SampleID string            `json:"sample_id"`
+	Scores   map[string]*Score `json:"scores"`
+	// Skipped are the metrics not applicable to the sample.
+	Skipped []string `json:"skipped,omitempty"`
+	// Errors are the errors of the metrics which failed, by metric name.
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// MetricSummary aggregates the scores of a metric over the samples.
+type MetricSummary struct {
+	Mean    float64 `json:"mean"`
+	Min     float64 `json:"min"`
+	Max     float64 `json:"max"`
+	Count   int     `json:"count"`
+	Skipped int     `json:"skipped"`
+	Failed  int     `json:"failed"`
+	// Threshold is the minimum mean of the metric, if any.
+	Threshold *float64 `json:"threshold,omitempty"`
+	// Passed reports whether the mean reaches the threshold, true without threshold.
+	Passed bool `json:"passed"`
+}
+
+// Report is the result of an evaluation.
+type Report struct {
+	StartedAt time.Time                 `json:"started_at"`
+	Duration  time.Duration             `json:"duration"`
+	Metrics   []string                  `json:"metrics"`
+	Summary   map[string]*MetricSummary `json:"summary"`
+	Results   []*SampleResult           `json:"results"`
+	// Passed reports whether all the metrics reach their threshold.
+	Passed bool `json:"passed"`
+}
+
+// Run scores the samples with the metrics. The errors of the metrics are reported in the results,
+// the returned error being for an invalid config or a canceled context.
+func Run(ctx context.Context, config *Config, samples []*Sample) (*Report, error) {
+	if config == nil || len(config.Metrics) == 0 {
+		return nil, errors.New("metrics are required")
+	}
+	names := make([]string, 0, len(config.Metrics))
+	seen := make(map[string]bool, len(config.Metrics))
+	for _, m := range config.Metrics {
+		if seen[m.Name()] {
+			return nil, fmt.Errorf("duplicated metric: %s", m.Name())
+		}
+		seen[m.Name()] = true
+		names = append(names, m.Name())
+	}
+	for name := range config.Thresholds {
+		if !seen[name] {
+			return nil, fmt.Errorf("threshold of unknown metric: %s", name)
+		}
+	}
+
+	report := &Report{
+		StartedAt: time.Now(),
+		Metrics:   names,
+		Results:   make([]*SampleResult, len(samples)),
+	}
+
+	sem := make(chan struct{}, max(config.Concurrency, 1))
+	var wg sync.WaitGroup
+	for i, s := range samples {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return nil, ctx.Err()
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(i int, s *Sample) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			report.Results[i] = scoreSample(ctx, config.Metrics, s)
+		}(i, s)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	report.Duration = time.Since(report.StartedAt)
+	report.summarize(config.Thresholds)
+	return report, nil
+}
+
+func scoreSample(ctx context.Context, metrics []Metric, s *Sample) (res *SampleResult) {
+	res = &SampleResult{SampleID: s.ID, Scores: make(map[string]*Score, len(metrics))}
+	for _, m := range metrics {
+		score, err := safeScore(ctx, m, s)
+		switch {
+		case errors.Is(err, ErrNotApplicable):
+			res.Skipped = append(res.Skipped, m.Name())
+		case err != nil:
+			if res.Errors == nil {
+				res.Errors = make(map[string]string)
+			}
+			res.Errors[m.Name()] = err.Error()
+		default:
+			score.Value = math.Max(0, math.Min(1, score.Value))
+			res.Scores[m.Name()] = score
+		}
+	}
+	return res
+}
+
+func safeScore(ctx context.Context, m Metric, s *Sample) (score *Score, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic in metric %s: %v", m.Name(), p)
+		}
+	}()
+	score, err = m.Score(ctx, s)
+	if err == nil && score == nil {
+		err = fmt.Errorf("metric %s returned no score", m.Name())
+	}
+	return score, err
+}
+
+func (r *Report) summarize(thresholds map[string]float64) {
+	r.Summary = make(map[string]*MetricSummary, len(r.Metrics))
+	r.Passed = true
+	for _, name := range r.Metrics {
+		sum := &MetricSummary{Min: 1, Passed: true}
+		total := 0.0
+		for _, res := range r.Results {
+			if score, ok := res.Scores[name]; ok {
+				sum.Count++
+				total += score.Value
+				sum.Min = math.Min(sum.Min, score.Value)
+				sum.Max = math.Max(sum.Max, score.Value)
+			} else if _, ok = res.Errors[name]; ok {
+				sum.Failed++
+			} else {
+				sum.Skipped++
+			}
+		}
+		if sum.Count > 0 {
+			sum.Mean = total / float64(sum.Count)
+		} else {
+			sum.Min = 0
+		}
+		if t, ok := thresholds[name]; ok {
+			sum.Threshold = &t
+			sum.Passed = sum.Count > 0 && sum.Mean >= t
+		}
+		r.Passed = r.Passed && sum.Passed
+		r.Summary[name] = sum
+	}
+}
+
+// Failures returns the names of the metrics whose mean is below their threshold.
+func (r *Report) Failures() []string {
+	var failures []string
+	for _, name := range r.Metrics {
+		if !r.Summary[name].Passed {
+			failures = append(failures, name)
+		}
+	}
+	return failures
+}
+
+// WriteJSON writes the report as indented json.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteCSV writes a row per sample, with a column per metric holding its score, empty when skipped or failed,
+// and a last column with the errors.
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	header := append([]string{"sample_id"}, r.Metrics...)
+	if err := cw.Write(append(header, "errors")); err != nil {
+		return err
+	}
+
+	for _, res := range r.Results {
+		row := make([]string, 0, len(r.Metrics)+2)
+		row = append(row, res.SampleID)
+		for _, name := range r.Metrics {
+			if score, ok := res.Scores[name]; ok {
+				row = append(row, strconv.FormatFloat(score.Value, 'f', 4, 64))
+			} else {
+				row = append(row, "")
+			}
+		}
+		errs := make([]string, 0, len(res.Errors))
+		for name, e := range res.Errors {
+			errs = append(errs, name+": "+e)
+		}
+		sort.Strings(errs)
+		row = append(row, strings.Join(errs, "; "))
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package evals
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lengthMetric scores the answers by their length, failing on empty questions.
+type lengthMetric struct{}
+
+func (lengthMetric) Name() string { return "length" }
+
+func (lengthMetric) Score(_ context.Context, s *Sample) (*Score, error) {
+	switch {
+	case s.Question == "":
+		return nil, errors.New("empty question")
+	case s.Answer == "":
+		return nil, ErrNotApplicable
+	}
+	return &Score{Value: float64(len(s.Answer)) / 10}, nil
+}
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+	samples := []*Sample{
+		{ID: "a", Question: "q", Answer: "12345"},
+		{ID: "b", Question: "q", Answer: "1234567890123"},
+		{ID: "c", Question: "q"},
+		{ID: "d", Answer: "x"},
+	}
+
+	report, err := Run(ctx, &Config{
+		Metrics:     []Metric{lengthMetric{}},
+		Concurrency: 2,
+		Thresholds:  map[string]float64{"length": 0.8},
+	}, samples)
+	require.NoError(t, err)
+
+	require.Len(t, report.Results, 4)
+	assert.Equal(t, 0.5, report.Results[0].Scores["length"].Value)
+	assert.Equal(t, 1.0, report.Results[1].Scores["length"].Value, "clamped")
+	assert.Equal(t, []string{"length"}, report.Results[2].Skipped)
+	assert.Equal(t, "empty question", report.Results[3].Errors["length"])
+
+	sum := report.Summary["length"]
+	assert.Equal(t, 0.75, sum.Mean)
+	assert.Equal(t, 0.5, sum.Min)
+	assert.Equal(t, 1.0, sum.Max)
+	assert.Equal(t, 2, sum.Count)
+	assert.Equal(t, 1, sum.Skipped)
+	assert.Equal(t, 1, sum.Failed)
+	assert.False(t, sum.Passed)
+	assert.False(t, report.Passed)
+	assert.Equal(t, []string{"length"}, report.Failures())
+
+	t.Run("csv", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		require.NoError(t, report.WriteCSV(buf))
+		assert.Equal(t, "sample_id,length,errors\na,0.5000,\nb,1.0000,\nc,,\nd,,length: empty question\n", buf.String())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		require.NoError(t, report.WriteJSON(buf))
+		decoded := &Report{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), decoded))
+		assert.Equal(t, report.Summary, decoded.Summary)
+		assert.Equal(t, report.Results, decoded.Results)
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		_, err = Run(ctx, &Config{}, samples)
+		assert.Error(t, err)
+		_, err = Run(ctx, &Config{Metrics: []Metric{lengthMetric{}, lengthMetric{}}}, samples)
+		assert.Error(t, err)
+		_, err = Run(ctx, &Config{Metrics: []Metric{lengthMetric{}}, Thresholds: map[string]float64{"x": 1}}, samples)
+		assert.Error(t, err)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err = Run(canceled, &Config{Metrics: []Metric{lengthMetric{}}}, samples)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestLoadDataset(t *testing.T) {
+	dir := t.TempDir()
+	jsonl := filepath.Join(dir, "set.jsonl")
+	require.NoError(t, os.WriteFile(jsonl, []byte(`{"id": "x", "question": "q1", "contexts": ["c"]}
+
+{"question": "q2", "ground_truth": "g"}
+`), 0o644))
+
+	samples, err := LoadDataset(jsonl)
+	require.NoError(t, err)
+	require.Len(t, samples, 2)
+	assert.Equal(t, &Sample{ID: "x", Question: "q1", Contexts: []string{"c"}}, samples[0])
+	assert.Equal(t, &Sample{ID: "3", Question: "q2", GroundTruth: "g"}, samples[1])
+
+	arr := filepath.Join(dir, "set.json")
+	require.NoError(t, os.WriteFile(arr, []byte(`[{"question": "q1"}, {"id": "b", "question": "q2"}]`), 0o644))
+	samples, err = LoadDataset(arr)
+	require.NoError(t, err)
+	assert.Equal(t, "1", samples[0].ID)
+	assert.Equal(t, "b", samples[1].ID)
+
+	_, err = ReadJSONL(strings.NewReader("{"))
+	assert.Error(t, err)
+	_, err = LoadDataset(filepath.Join(dir, "missing.jsonl"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,166 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package builtin registers the eino-ext components in factory.Default when imported:
+//
+//	import _ "github.com/cloudwego/eino-ext/flow/factory/builtin"
+//
+// The params of the chat models and the embedders are their eino-ext configs by json names, e.g. the
+// openai.ChatModelConfig of the "openai" chat model. The retrievers and the indexers are the eino flows
+// composing other components, referenced by name, e.g. the "multi_query" retriever rewriting the queries
+// of a retriever with a chat model.
+package builtin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/flow/indexer/parent"
+	"github.com/cloudwego/eino/flow/retriever/multiquery"
+	"github.com/cloudwego/eino/flow/retriever/router"
+
+	arkembedding "github.com/cloudwego/eino-ext/components/embedding/ark"
+	"github.com/cloudwego/eino-ext/components/model/ark"
+	"github.com/cloudwego/eino-ext/components/model/openai"
+	"github.com/cloudwego/eino-ext/flow/factory"
+)
+
+func init() {
+	Register(factory.Default)
+}
+
+// MultiQueryConfig is the config of the "multi_query" retriever.
+type MultiQueryConfig struct {
+	// Retriever names the retriever searching each query.
+	Retriever string `json:"retriever"`
+	// Model names the chat model rewriting the query into several ones.
+	Model string `json:"model"`
+	// MaxQueries limits the number of queries, default 5.
+	MaxQueries int `json:"max_queries"`
+}
+
+// RouterConfig is the config of the "router" retriever, which searches all its retrievers, the documents being
+// fused by reciprocal rank.
+type RouterConfig struct {
+	// Retrievers names the retrievers.
+	Retrievers []string `json:"retrievers"`
+}
+
+// ParentConfig is the config of the "parent" indexer, which splits the documents into chunks before indexing
+// them, each chunk keeping the ID of its document.
+type ParentConfig struct {
+	// Indexer names the indexer storing the chunks.
+	Indexer string `json:"indexer"`
+	// Transformer names the transformer splitting the documents.
+	Transformer string `json:"transformer"`
+	// ParentIDKey is the metadata key of the ID of the document of a chunk, default "parent_id".
+	ParentIDKey string `json:"parent_id_key"`
+}
+
+// Register registers the eino-ext components in r.
+func Register(r *factory.Registry) {
+	factory.RegisterChatModel(r, "openai", func(ctx context.Context, config *openai.ChatModelConfig, _ *factory.Components) (model.BaseChatModel, error) {
+		return openai.NewChatModel(ctx, config)
+	}, factory.WithRequired("api_key", "model"))
+	factory.RegisterChatModel(r, "ark", func(ctx context.Context, config *ark.ChatModelConfig, _ *factory.Components) (model.BaseChatModel, error) {
+		return ark.NewChatModel(ctx, config)
+	}, factory.WithRequired("model"))
+
+	factory.RegisterEmbedding(r, "ark", func(ctx context.Context, config *arkembedding.EmbeddingConfig, _ *factory.Components) (embedding.Embedder, error) {
+		return arkembedding.NewEmbedder(ctx, config)
+	}, factory.WithRequired("model"))
+
+	factory.RegisterRetriever(r, "multi_query", buildMultiQuery, factory.WithRequired("retriever", "model"))
+	factory.RegisterRetriever(r, "router", buildRouter, factory.WithRequired("retrievers"))
+
+	factory.RegisterIndexer(r, "parent", buildParent, factory.WithRequired("indexer", "transformer"))
+}
+
+func buildMultiQuery(ctx context.Context, config *MultiQueryConfig, deps *factory.Components) (retriever.Retriever, error) {
+	rtr, err := deps.Retriever(config.Retriever)
+	if err != nil {
+		return nil, err
+	}
+	cm, err := deps.ChatModel(config.Model)
+	if err != nil {
+		return nil, err
+	}
+	rewriter, ok := cm.(model.ChatModel)
+	if !ok {
+		return nil, fmt.Errorf("chat model %q can not rewrite the queries", config.Model)
+	}
+	return multiquery.NewRetriever(ctx, &multiquery.Config{
+		RewriteLLM:    rewriter,
+		MaxQueriesNum: config.MaxQueries,
+		OrigRetriever: rtr,
+	})
+}
+
+func buildRouter(ctx context.Context, config *RouterConfig, deps *factory.Components) (retriever.Retriever, error) {
+	retrievers := make(map[string]retriever.Retriever, len(config.Retrievers))
+	for _, name := range config.Retrievers {
+		rtr, err := deps.Retriever(name)
+		if err != nil {
+			return nil, err
+		}
+		retrievers[name] = rtr
+	}
+	names := append([]string(nil), config.Retrievers...)
+	return router.NewRetriever(ctx, &router.Config{
+		Retrievers: retrievers,
+		// all the retrievers, set as the router of eino v0.4 drops its default one
+		Router: func(context.Context, string) ([]string, error) {
+			return names, nil
+		},
+	})
+}
+
+func buildParent(ctx context.Context, config *ParentConfig, deps *factory.Components) (indexer.Indexer, error) {
+	idx, err := deps.Indexer(config.Indexer)
+	if err != nil {
+		return nil, err
+	}
+	transformer, err := deps.Transformer(config.Transformer)
+	if err != nil {
+		return nil, err
+	}
+	parentIDKey := config.ParentIDKey
+	if parentIDKey == "" {
+		parentIDKey = "parent_id"
+	}
+	return parent.NewIndexer(ctx, &parent.Config{
+		Indexer:        idx,
+		Transformer:    transformer,
+		ParentIDKey:    parentIDKey,
+		SubIDGenerator: subIDs,
+	})
+}
+
+// subIDs numbers the chunks of a document, e.g. "doc_1#0".
+func subIDs(_ context.Context, parentID string, num int) ([]string, error) {
+	if parentID == "" {
+		return nil, errors.New("document ID is required")
+	}
+	ids := make([]string, num)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("%s#%d", parentID, i)
+	}
+	return ids, nil
+}
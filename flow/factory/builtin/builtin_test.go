@@ -0,0 +1,173 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package builtin
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudwego/eino-ext/flow/factory"
+)
+
+func TestBuiltin(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("BUILTIN_TEST_API_KEY", "sk-test")
+
+	config, err := factory.ParseConfig([]byte(`
+model:
+  type: openai
+  params:
+    api_key: ${BUILTIN_TEST_API_KEY}
+    model: gpt-4o
+    timeout: 30s
+local:
+  kind: model
+  type: ark
+  params:
+    api_key: ${BUILTIN_TEST_API_KEY}
+    model: doubao-pro
+embedding:
+  type: ark
+  params:
+    api_key: ${BUILTIN_TEST_API_KEY}
+    model: doubao-embedding
+`))
+	require.NoError(t, err)
+
+	components, err := factory.Build(ctx, config)
+	require.NoError(t, err)
+	_, err = components.ToolCallingChatModel("model")
+	assert.NoError(t, err)
+	_, err = components.ChatModel("local")
+	assert.NoError(t, err)
+	_, err = components.Embedder("embedding")
+	assert.NoError(t, err)
+
+	config, err = factory.ParseConfig([]byte(`{"model": {"type": "openai", "params": {"model": "gpt-4o"}}}`))
+	require.NoError(t, err)
+	_, err = factory.Build(ctx, config)
+	assert.EqualError(t, err, "model.params.api_key: required")
+}
+
+func TestBuiltin_Flows(t *testing.T) {
+	ctx := context.Background()
+	r := factory.NewRegistry()
+	Register(r)
+	factory.RegisterRetriever(r, "fake", func(_ context.Context, _ *struct{}, _ *factory.Components) (retriever.Retriever, error) {
+		return &fakeRetriever{}, nil
+	})
+	factory.RegisterIndexer(r, "fake", func(_ context.Context, _ *struct{}, _ *factory.Components) (indexer.Indexer, error) {
+		return &fakeIndexer{}, nil
+	})
+	factory.RegisterTransformer(r, "fake", func(_ context.Context, _ *struct{}, _ *factory.Components) (document.Transformer, error) {
+		return &fakeTransformer{}, nil
+	})
+
+	config, err := factory.ParseConfig([]byte(`
+model:
+  type: openai
+  params:
+    api_key: sk-test
+    model: gpt-4o
+docs:
+  kind: retriever
+  type: fake
+faq:
+  kind: retriever
+  type: fake
+retriever:
+  type: multi_query
+  params:
+    retriever: router
+    model: model
+    max_queries: 3
+router:
+  kind: retriever
+  type: router
+  params:
+    retrievers: [docs, faq]
+store:
+  kind: indexer
+  type: fake
+splitter:
+  kind: transformer
+  type: fake
+indexer:
+  type: parent
+  params:
+    indexer: store
+    transformer: splitter
+`))
+	require.NoError(t, err)
+	components, err := r.Build(ctx, config)
+	require.NoError(t, err)
+
+	rtr, err := components.Retriever("router")
+	require.NoError(t, err)
+	docs, err := rtr.Retrieve(ctx, "query")
+	require.NoError(t, err)
+	assert.Len(t, docs, 1)
+	_, err = components.Retriever("retriever")
+	assert.NoError(t, err)
+
+	idx, err := components.Indexer("indexer")
+	require.NoError(t, err)
+	ids, err := idx.Store(ctx, []*schema.Document{{ID: "doc", Content: "a b"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"doc#0", "doc#1"}, ids)
+
+	config, err = factory.ParseConfig([]byte(`{"indexer": {"type": "parent", "params": {"indexer": "store"}}}`))
+	require.NoError(t, err)
+	_, err = r.Build(ctx, config)
+	assert.EqualError(t, err, "indexer.params.transformer: required")
+}
+
+type fakeRetriever struct{}
+
+func (f *fakeRetriever) Retrieve(_ context.Context, query string, _ ...retriever.Option) ([]*schema.Document, error) {
+	return []*schema.Document{{ID: "1", Content: query}}, nil
+}
+
+type fakeIndexer struct{}
+
+func (f *fakeIndexer) Store(_ context.Context, docs []*schema.Document, _ ...indexer.Option) ([]string, error) {
+	ids := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+	}
+	return ids, nil
+}
+
+// fakeTransformer splits the documents by word.
+type fakeTransformer struct{}
+
+func (f *fakeTransformer) Transform(_ context.Context, docs []*schema.Document, _ ...document.TransformerOption) ([]*schema.Document, error) {
+	var ret []*schema.Document
+	for _, doc := range docs {
+		for _, word := range strings.Fields(doc.Content) {
+			ret = append(ret, &schema.Document{ID: doc.ID, Content: word})
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,194 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package factory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/components/tool"
+)
+
+// Components is the components built from a config, by component name.
+type Components struct {
+	config   Config
+	registry *Registry
+	built    map[string]any
+	// building is the names of the components being built, to detect the dependency cycles
+	building []string
+	ctx      context.Context
+	secrets  SecretResolver
+}
+
+type buildOptions struct {
+	secrets SecretResolver
+}
+
+// BuildOption configures the build of the components.
+type BuildOption func(o *buildOptions)
+
+// WithSecretResolver resolves the ${secret:ref} references of the params with resolver when the components are
+// built, e.g. api_key: ${secret:openai_api_key}. Write $${secret:ref} for a literal ${secret:ref}.
+func WithSecretResolver(resolver SecretResolver) BuildOption {
+	return func(o *buildOptions) {
+		o.secrets = resolver
+	}
+}
+
+// Build builds the components of config with the types registered in r. A component depending on another one
+// resolves it from the deps of its BuildFunc, the components being built in dependency order.
+func (r *Registry) Build(ctx context.Context, config Config, opts ...BuildOption) (*Components, error) {
+	o := &buildOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	c := &Components{
+		config:   config,
+		registry: r,
+		built:    make(map[string]any, len(config)),
+		ctx:      ctx,
+		secrets:  o.secrets,
+	}
+	for _, name := range sortedNames(config) {
+		if _, err := c.get(name); err != nil {
+			return nil, err
+		}
+	}
+	c.ctx, c.secrets = nil, nil
+	return c, nil
+}
+
+// Build builds the components of config with the types registered in Default.
+func Build(ctx context.Context, config Config, opts ...BuildOption) (*Components, error) {
+	return Default.Build(ctx, config, opts...)
+}
+
+// BuildFile loads the config file at path and builds its components with the types registered in Default.
+func BuildFile(ctx context.Context, path string, opts ...BuildOption) (*Components, error) {
+	config, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	return Default.Build(ctx, config, opts...)
+}
+
+func (c *Components) get(name string) (any, error) {
+	if component, ok := c.built[name]; ok {
+		return component, nil
+	}
+	spec, ok := c.config[name]
+	if !ok {
+		return nil, fmt.Errorf("component %q not found", name)
+	}
+	if c.ctx == nil {
+		return nil, fmt.Errorf("component %q not built", name)
+	}
+	for _, building := range c.building {
+		if building == name {
+			return nil, fmt.Errorf("dependency cycle: %s -> %s", strings.Join(c.building, " -> "), name)
+		}
+	}
+
+	b, err := c.registry.builder(name, spec)
+	if err != nil {
+		return nil, err
+	}
+	params, err := resolveSecrets(c.ctx, name+".params", spec.Params, c.secrets)
+	if err != nil {
+		return nil, err
+	}
+	c.building = append(c.building, name)
+	component, err := b(c.ctx, name, params.(map[string]any), c)
+	c.building = c.building[:len(c.building)-1]
+	if err != nil {
+		return nil, err
+	}
+	c.built[name] = component
+	return component, nil
+}
+
+func getAs[T any](c *Components, name string, kind Kind) (T, error) {
+	var zero T
+	if spec, ok := c.config[name]; ok && spec.Kind != kind {
+		return zero, fmt.Errorf("component %q is a %s, not a %s", name, spec.Kind, kind)
+	}
+	component, err := c.get(name)
+	if err != nil {
+		return zero, err
+	}
+	t, ok := component.(T)
+	if !ok {
+		return zero, fmt.Errorf("component %q is a %T", name, component)
+	}
+	return t, nil
+}
+
+// ChatModel returns the chat model named name.
+func (c *Components) ChatModel(name string) (model.BaseChatModel, error) {
+	return getAs[model.BaseChatModel](c, name, KindChatModel)
+}
+
+// ToolCallingChatModel returns the chat model named name, failing if it does not implement ToolCallingChatModel.
+func (c *Components) ToolCallingChatModel(name string) (model.ToolCallingChatModel, error) {
+	return getAs[model.ToolCallingChatModel](c, name, KindChatModel)
+}
+
+// Embedder returns the embedder named name.
+func (c *Components) Embedder(name string) (embedding.Embedder, error) {
+	return getAs[embedding.Embedder](c, name, KindEmbedding)
+}
+
+// Retriever returns the retriever named name.
+func (c *Components) Retriever(name string) (retriever.Retriever, error) {
+	return getAs[retriever.Retriever](c, name, KindRetriever)
+}
+
+// Indexer returns the indexer named name.
+func (c *Components) Indexer(name string) (indexer.Indexer, error) {
+	return getAs[indexer.Indexer](c, name, KindIndexer)
+}
+
+// Tool returns the tool named name.
+func (c *Components) Tool(name string) (tool.BaseTool, error) {
+	return getAs[tool.BaseTool](c, name, KindTool)
+}
+
+// Transformer returns the document transformer named name.
+func (c *Components) Transformer(name string) (document.Transformer, error) {
+	return getAs[document.Transformer](c, name, KindTransformer)
+}
+
+// Loader returns the document loader named name.
+func (c *Components) Loader(name string) (document.Loader, error) {
+	return getAs[document.Loader](c, name, KindLoader)
+}
+
+// Names returns the names of the components of a kind, sorted.
+func (c *Components) Names(kind Kind) []string {
+	var names []string
+	for _, name := range sortedNames(c.config) {
+		if c.config[name].Kind == kind {
+			names = append(names, name)
+		}
+	}
+	return names
+}
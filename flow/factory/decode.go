@@ -0,0 +1,199 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package factory
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// decodeParams decodes the params into a new config of type C by the json names of its fields, path naming the
+// params in the errors. The unknown fields are rejected, the durations are parsed from strings like "30s", and the
+// numbers and booleans from strings, as the interpolated environment variables are.
+func decodeParams[C any](path string, params map[string]any) (*C, error) {
+	config := new(C)
+	if params == nil {
+		return config, nil
+	}
+	converted, err := convert(path, reflect.TypeOf(config).Elem(), params)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(converted)
+	if err != nil {
+		return nil, fieldError(path, "marshal fail: %v", err)
+	}
+	if err = json.Unmarshal(data, config); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			return nil, fieldError(path+"."+typeErr.Field, "cannot use %s as %s", typeErr.Value, typeErr.Type)
+		}
+		return nil, fieldError(path, "%v", err)
+	}
+	return config, nil
+}
+
+// convert checks and converts a decoded YAML value v to be unmarshalled into type t.
+func convert(path string, t reflect.Type, v any) (any, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if v == nil {
+		return nil, nil
+	}
+
+	if t == durationType {
+		if s, ok := v.(string); ok {
+			d, err := time.ParseDuration(s)
+			if err != nil {
+				return nil, fieldError(path, "invalid duration %q", s)
+			}
+			return int64(d), nil
+		}
+		return v, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		m, ok := v.(map[string]any)
+		if !ok {
+			return v, nil
+		}
+		fields := jsonFields(t)
+		out := make(map[string]any, len(m))
+		for _, key := range sortedNames(m) {
+			field, ok := lookupField(fields, key)
+			if !ok {
+				return nil, fieldError(path+"."+key, "unknown field")
+			}
+			value, err := convert(path+"."+key, field.Type, m[key])
+			if err != nil {
+				return nil, err
+			}
+			out[key] = value
+		}
+		return out, nil
+	case reflect.Slice, reflect.Array:
+		items, ok := v.([]any)
+		if !ok {
+			return v, nil
+		}
+		out := make([]any, len(items))
+		for i, item := range items {
+			value, err := convert(path+"["+strconv.Itoa(i)+"]", t.Elem(), item)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = value
+		}
+		return out, nil
+	case reflect.Map:
+		m, ok := v.(map[string]any)
+		if !ok {
+			return v, nil
+		}
+		out := make(map[string]any, len(m))
+		for key, value := range m {
+			converted, err := convert(path+"."+key, t.Elem(), value)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = converted
+		}
+		return out, nil
+	}
+
+	s, ok := v.(string)
+	if !ok {
+		return v, nil
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, fieldError(path, "invalid bool %q", s)
+		}
+		return b, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, fieldError(path, "invalid integer %q", s)
+		}
+		return i, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return nil, fieldError(path, "invalid unsigned integer %q", s)
+		}
+		return u, nil
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, fieldError(path, "invalid number %q", s)
+		}
+		return f, nil
+	}
+	return v, nil
+}
+
+// jsonFields returns the fields of a struct by json name, the fields of the embedded structs included.
+func jsonFields(t reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" || (!f.IsExported() && !f.Anonymous) {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			for embeddedName, embedded := range jsonFields(ft) {
+				if _, ok := fields[embeddedName]; !ok {
+					fields[embeddedName] = embedded
+				}
+			}
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f
+	}
+	return fields
+}
+
+// lookupField finds the field of a json name, case-insensitively as encoding/json does.
+func lookupField(fields map[string]reflect.StructField, key string) (reflect.StructField, bool) {
+	if f, ok := fields[key]; ok {
+		return f, true
+	}
+	for name, f := range fields {
+		if strings.EqualFold(name, key) {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
@@ -0,0 +1,139 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package factory
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+)
+
+// envPattern matches the secret references first, kept as is, escaped or not, until they are resolved by Build.
+var envPattern = regexp.MustCompile(`\$?\$\{secret:[^}]*\}|\$\$|\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+var secretPattern = regexp.MustCompile(`\$?\$\{secret:([^}]*)\}`)
+
+// SecretResolver resolves the secrets referenced by ${secret:ref} in the params, e.g. a resolver of the
+// github.com/cloudwego/eino-ext/flow/secrets package.
+type SecretResolver interface {
+	Resolve(ctx context.Context, ref string) (string, error)
+}
+
+// interpolate replaces the environment variables of s, path naming the field in the errors.
+func interpolate(path, s string) (string, error) {
+	var err error
+	out := envPattern.ReplaceAllStringFunc(s, func(match string) string {
+		if match == "$$" {
+			return "$"
+		}
+		if secretPattern.MatchString(match) {
+			return match
+		}
+		groups := envPattern.FindStringSubmatch(match)
+		value, ok := os.LookupEnv(groups[1])
+		if groups[2] != "" {
+			if value == "" {
+				return groups[3]
+			}
+			return value
+		}
+		if !ok && err == nil {
+			err = fieldError(path, "environment variable %s is not set", groups[1])
+		}
+		return value
+	})
+	return out, err
+}
+
+// interpolateValue interpolates the strings of a decoded YAML value.
+func interpolateValue(path string, v any) (any, error) {
+	switch v := v.(type) {
+	case string:
+		return interpolate(path, v)
+	case map[string]any:
+		for key, value := range v {
+			interpolated, err := interpolateValue(path+"."+key, value)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = interpolated
+		}
+		return v, nil
+	case []any:
+		for i, value := range v {
+			interpolated, err := interpolateValue(path+"["+strconv.Itoa(i)+"]", value)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = interpolated
+		}
+		return v, nil
+	default:
+		return v, nil
+	}
+}
+
+// resolveSecrets returns a copy of a decoded YAML value v, its secret references being resolved by resolver,
+// and $${secret:ref} being replaced by ${secret:ref}.
+func resolveSecrets(ctx context.Context, path string, v any, resolver SecretResolver) (any, error) {
+	switch v := v.(type) {
+	case string:
+		var err error
+		out := secretPattern.ReplaceAllStringFunc(v, func(match string) string {
+			if match[1] == '$' {
+				return match[1:]
+			}
+			if err != nil {
+				return ""
+			}
+			if resolver == nil {
+				err = fieldError(path, "secret resolver not configured")
+				return ""
+			}
+			ref := secretPattern.FindStringSubmatch(match)[1]
+			value, resolveErr := resolver.Resolve(ctx, ref)
+			if resolveErr != nil {
+				err = &FieldError{Path: path, Err: fmt.Errorf("resolve secret %s fail: %w", ref, resolveErr)}
+			}
+			return value
+		})
+		return out, err
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, value := range v {
+			resolved, err := resolveSecrets(ctx, path+"."+key, value, resolver)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = resolved
+		}
+		return out, nil
+	case []any:
+		out := make([]any, len(v))
+		for i, value := range v {
+			resolved, err := resolveSecrets(ctx, path+"["+strconv.Itoa(i)+"]", value, resolver)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = resolved
+		}
+		return out, nil
+	default:
+		return v, nil
+	}
+}
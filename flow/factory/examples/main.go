@@ -0,0 +1,95 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/flow/factory"
+)
+
+type echoConfig struct {
+	Prefix string `json:"prefix"`
+	Upper  bool   `json:"upper"`
+}
+
+type echoModel struct {
+	config *echoConfig
+}
+
+func (e *echoModel) Generate(_ context.Context, input []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	content := e.config.Prefix + input[len(input)-1].Content
+	if e.config.Upper {
+		content = strings.ToUpper(content)
+	}
+	return schema.AssistantMessage(content, nil), nil
+}
+
+func (e *echoModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	out, err := e.Generate(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return schema.StreamReaderFromArray([]*schema.Message{out}), nil
+}
+
+func main() {
+	ctx := context.Background()
+
+	// import _ "github.com/cloudwego/eino-ext/flow/factory/builtin" to register the eino-ext components instead
+	factory.RegisterChatModel(factory.Default, "echo",
+		func(_ context.Context, config *echoConfig, _ *factory.Components) (model.BaseChatModel, error) {
+			return &echoModel{config: config}, nil
+		},
+		factory.WithRequired("prefix"),
+	)
+
+	config, err := factory.ParseConfig([]byte(`
+model:
+  type: echo
+  params:
+    prefix: "echo: "
+    upper: ${ECHO_UPPER:-false}
+`))
+	if err != nil {
+		log.Fatal(err)
+	}
+	components, err := factory.Build(ctx, config)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cm, err := components.ChatModel("model")
+	if err != nil {
+		log.Fatal(err)
+	}
+	out, err := cm.Generate(ctx, []*schema.Message{schema.UserMessage("hello")})
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("output: %s", out.Content)
+
+	// a misconfigured component names the offending field
+	config, _ = factory.ParseConfig([]byte(`{"model": {"type": "echo", "params": {"prefix": "> ", "uper": true}}}`))
+	if _, err = factory.Build(ctx, config); err != nil {
+		log.Printf("invalid config: %v", err)
+	}
+}
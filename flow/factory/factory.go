@@ -0,0 +1,169 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package factory builds eino components by name from YAML or JSON config files, so that pipelines are assembled
+// from config and their components swapped without code changes:
+//
+//	model:
+//	  type: openai
+//	  params:
+//	    api_key: ${OPENAI_API_KEY}
+//	    model: ${MODEL:-gpt-4o}
+//	retriever:
+//	  type: es8
+//	  params:
+//	    addresses: [http://localhost:9200]
+//	    index: docs
+//	    embedding: embedding
+//
+// The component types are registered in a Registry, the builtin package registering the eino-ext ones.
+package factory
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Kind is the kind of component, e.g. chat model or retriever.
+type Kind string
+
+const (
+	KindChatModel   Kind = "model"
+	KindEmbedding   Kind = "embedding"
+	KindRetriever   Kind = "retriever"
+	KindIndexer     Kind = "indexer"
+	KindTool        Kind = "tool"
+	KindTransformer Kind = "transformer"
+	KindLoader      Kind = "loader"
+)
+
+var kinds = []Kind{KindChatModel, KindEmbedding, KindRetriever, KindIndexer, KindTool, KindTransformer, KindLoader}
+
+func (k Kind) valid() bool {
+	for _, kind := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Spec describes a component.
+type Spec struct {
+	// Kind is the kind of the component, the name of the component when empty, e.g. a component named "model".
+	Kind Kind `json:"kind,omitempty" yaml:"kind,omitempty"`
+	// Type is the registered type of the component, e.g. "openai".
+	Type string `json:"type" yaml:"type"`
+	// Params is the config of the component, decoded into the config struct of its type by their json names.
+	Params map[string]any `json:"params,omitempty" yaml:"params,omitempty"`
+}
+
+// Config is the specs of the components by component name.
+type Config map[string]*Spec
+
+// FieldError is an invalid config field, Path being the dotted path of the field, e.g. "model.params.api_key".
+type FieldError struct {
+	Path string
+	Err  error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+func fieldError(path string, format string, args ...any) error {
+	return &FieldError{Path: path, Err: fmt.Errorf(format, args...)}
+}
+
+// LoadConfig reads and parses the YAML or JSON config file at path.
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config fail: %w", err)
+	}
+	return ParseConfig(data)
+}
+
+// ParseConfig parses a YAML or JSON config, interpolating the environment variables of its string values:
+// ${VAR} is replaced by the value of VAR, failing if it is unset, ${VAR:-default} by default if VAR is unset or empty,
+// and $$ by $.
+func ParseConfig(data []byte) (Config, error) {
+	var raw map[string]*Spec
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("unmarshal config fail: %w", err)
+	}
+	if len(raw) == 0 {
+		return nil, errors.New("config has no component")
+	}
+
+	config := make(Config, len(raw))
+	for _, name := range sortedNames(raw) {
+		spec := raw[name]
+		if spec == nil {
+			return nil, fieldError(name, "spec is empty")
+		}
+		if err := spec.interpolate(name); err != nil {
+			return nil, err
+		}
+		if spec.Kind == "" {
+			spec.Kind = Kind(name)
+		}
+		if !spec.Kind.valid() {
+			if spec.Kind == Kind(name) {
+				return nil, fieldError(name+".kind", "required for a component not named after its kind")
+			}
+			return nil, fieldError(name+".kind", "unknown kind %q", spec.Kind)
+		}
+		if spec.Type == "" {
+			return nil, fieldError(name+".type", "required")
+		}
+		config[name] = spec
+	}
+	return config, nil
+}
+
+func (s *Spec) interpolate(name string) error {
+	kind, err := interpolate(name+".kind", string(s.Kind))
+	if err != nil {
+		return err
+	}
+	s.Kind = Kind(kind)
+	if s.Type, err = interpolate(name+".type", s.Type); err != nil {
+		return err
+	}
+	params, err := interpolateValue(name+".params", s.Params)
+	if err != nil {
+		return err
+	}
+	s.Params, _ = params.(map[string]any)
+	return nil
+}
+
+func sortedNames[V any](m map[string]V) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,288 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package factory
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeModelConfig struct {
+	APIKey      string         `json:"api_key"`
+	Model       string         `json:"model"`
+	Temperature *float32       `json:"temperature"`
+	Timeout     time.Duration  `json:"timeout"`
+	Stream      bool           `json:"stream"`
+	Options     *fakeOptions   `json:"options"`
+	Extra       map[string]any `json:"extra"`
+}
+
+type fakeOptions struct {
+	Stop  []string `json:"stop"`
+	Retry int      `json:"retry"`
+}
+
+type fakeModel struct {
+	model.BaseChatModel
+	config *fakeModelConfig
+}
+
+type fakeEmbedder struct {
+	embedding.Embedder
+	dims int
+}
+
+type fakeRetrieverConfig struct {
+	Embedding string `json:"embedding"`
+	TopK      int    `json:"top_k"`
+}
+
+type fakeRetriever struct {
+	retriever.Retriever
+	embedder embedding.Embedder
+	config   *fakeRetrieverConfig
+}
+
+func testRegistry() *Registry {
+	r := NewRegistry()
+	RegisterChatModel(r, "fake", func(_ context.Context, config *fakeModelConfig, _ *Components) (model.BaseChatModel, error) {
+		return &fakeModel{config: config}, nil
+	}, WithRequired("api_key"))
+	RegisterEmbedding(r, "fake", func(_ context.Context, config *struct {
+		Dims int `json:"dims"`
+	}, _ *Components) (embedding.Embedder, error) {
+		if config.Dims <= 0 {
+			return nil, errors.New("dims must be positive")
+		}
+		return &fakeEmbedder{dims: config.Dims}, nil
+	})
+	RegisterRetriever(r, "fake", func(_ context.Context, config *fakeRetrieverConfig, deps *Components) (retriever.Retriever, error) {
+		emb, err := deps.Embedder(config.Embedding)
+		if err != nil {
+			return nil, err
+		}
+		return &fakeRetriever{embedder: emb, config: config}, nil
+	})
+	return r
+}
+
+func TestBuild(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("FAKE_API_KEY", "secret")
+	t.Setenv("FAKE_TEMPERATURE", "0.5")
+
+	config, err := ParseConfig([]byte(`
+model:
+  type: fake
+  params:
+    api_key: ${FAKE_API_KEY}
+    model: ${FAKE_MODEL:-gpt-4o}
+    temperature: ${FAKE_TEMPERATURE}
+    timeout: 30s
+    stream: "true"
+    options:
+      stop: ["$$END"]
+      retry: 3
+    extra:
+      anything: goes
+judge:
+  kind: model
+  type: fake
+  params:
+    api_key: other
+retriever:
+  type: fake
+  params:
+    embedding: embedder
+    top_k: 5
+embedder:
+  kind: embedding
+  type: fake
+  params:
+    dims: 8
+`))
+	require.NoError(t, err)
+
+	components, err := testRegistry().Build(ctx, config)
+	require.NoError(t, err)
+
+	cm, err := components.ChatModel("model")
+	require.NoError(t, err)
+	fm := cm.(*fakeModel)
+	assert.Equal(t, "secret", fm.config.APIKey)
+	assert.Equal(t, "gpt-4o", fm.config.Model)
+	assert.Equal(t, float32(0.5), *fm.config.Temperature)
+	assert.Equal(t, 30*time.Second, fm.config.Timeout)
+	assert.True(t, fm.config.Stream)
+	assert.Equal(t, &fakeOptions{Stop: []string{"$END"}, Retry: 3}, fm.config.Options)
+
+	r, err := components.Retriever("retriever")
+	require.NoError(t, err)
+	assert.Equal(t, 8, r.(*fakeRetriever).embedder.(*fakeEmbedder).dims)
+
+	assert.Equal(t, []string{"judge", "model"}, components.Names(KindChatModel))
+
+	_, err = components.Retriever("model")
+	assert.ErrorContains(t, err, `component "model" is a model, not a retriever`)
+	_, err = components.ToolCallingChatModel("judge")
+	assert.Error(t, err)
+	_, err = components.Embedder("missing")
+	assert.Error(t, err)
+}
+
+func TestBuildErrors(t *testing.T) {
+	ctx := context.Background()
+
+	cases := []struct {
+		name   string
+		config string
+		path   string
+		err    string
+	}{
+		{"unset env", "model: {type: fake, params: {api_key: '${FAKE_UNSET}'}}", "model.params.api_key", "environment variable FAKE_UNSET is not set"},
+		{"no kind", "chat: {type: fake}", "chat.kind", "required for a component not named after its kind"},
+		{"unknown kind", "chat: {kind: llm, type: fake}", "chat.kind", `unknown kind "llm"`},
+		{"no type", "model: {params: {}}", "model.type", "required"},
+		{"unknown type", "model: {type: opnai}", "model.type", `unknown model type "opnai", registered: fake`},
+		{"required", "model: {type: fake, params: {model: x}}", "model.params.api_key", "required"},
+		{"unknown field", "model: {type: fake, params: {api_key: k, modle: x}}", "model.params.modle", "unknown field"},
+		{"nested unknown field", "model: {type: fake, params: {api_key: k, options: {retries: 1}}}", "model.params.options.retries", "unknown field"},
+		{"duration", "model: {type: fake, params: {api_key: k, timeout: 30 seconds}}", "model.params.timeout", `invalid duration "30 seconds"`},
+		{"number", "model: {type: fake, params: {api_key: k, temperature: hot}}", "model.params.temperature", `invalid number "hot"`},
+		{"type", "model: {type: fake, params: {api_key: k, options: {stop: 1}}}", "model.params.options.stop", "cannot use number as []string"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			config, err := ParseConfig([]byte(c.config))
+			if err == nil {
+				_, err = testRegistry().Build(ctx, config)
+			}
+			var fieldErr *FieldError
+			require.ErrorAs(t, err, &fieldErr)
+			assert.Equal(t, c.path, fieldErr.Path)
+			assert.Equal(t, c.err, fieldErr.Err.Error())
+		})
+	}
+
+	t.Run("build fail", func(t *testing.T) {
+		config, err := ParseConfig([]byte(`{"embedding": {"type": "fake", "params": {"dims": 0}}}`))
+		require.NoError(t, err)
+		_, err = testRegistry().Build(ctx, config)
+		assert.ErrorContains(t, err, "build embedding fake fail: dims must be positive")
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		r := testRegistry()
+		RegisterEmbedding(r, "wrap", func(_ context.Context, config *struct {
+			Inner string `json:"inner"`
+		}, deps *Components) (embedding.Embedder, error) {
+			return deps.Embedder(config.Inner)
+		})
+		config, err := ParseConfig([]byte(`
+a: {kind: embedding, type: wrap, params: {inner: b}}
+b: {kind: embedding, type: wrap, params: {inner: a}}
+`))
+		require.NoError(t, err)
+		_, err = r.Build(ctx, config)
+		assert.ErrorContains(t, err, "dependency cycle: a -> b -> a")
+	})
+}
+
+func TestBuildFile(t *testing.T) {
+	ctx := context.Background()
+	RegisterTool(Default, "factory_test", func(_ context.Context, config *struct {
+		Name string `json:"name"`
+	}, _ *Components) (tool.BaseTool, error) {
+		return &fakeTool{name: config.Name}, nil
+	})
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"tool": {"type": "factory_test", "params": {"name": "search"}}}`), 0o644))
+	components, err := BuildFile(ctx, path)
+	require.NoError(t, err)
+	tl, err := components.Tool("tool")
+	require.NoError(t, err)
+	info, err := tl.Info(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "search", info.Name)
+
+	_, err = BuildFile(ctx, filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
+
+type fakeTool struct {
+	name string
+}
+
+func (f *fakeTool) Info(context.Context) (*schema.ToolInfo, error) {
+	return &schema.ToolInfo{Name: f.name}, nil
+}
+
+func TestSecrets(t *testing.T) {
+	ctx := context.Background()
+	resolver := secretResolverFunc(func(_ context.Context, ref string) (string, error) {
+		if ref == "openai_api_key" {
+			return "sk-secret", nil
+		}
+		return "", errors.New("not found")
+	})
+
+	config, err := ParseConfig([]byte(`
+model:
+  type: fake
+  params:
+    api_key: ${secret:openai_api_key}
+    model: "$${secret:literal}"
+`))
+	require.NoError(t, err)
+	assert.Equal(t, "${secret:openai_api_key}", config["model"].Params["api_key"], "resolved at build")
+
+	components, err := testRegistry().Build(ctx, config, WithSecretResolver(resolver))
+	require.NoError(t, err)
+	cm, err := components.ChatModel("model")
+	require.NoError(t, err)
+	assert.Equal(t, "sk-secret", cm.(*fakeModel).config.APIKey)
+	assert.Equal(t, "${secret:literal}", cm.(*fakeModel).config.Model)
+	assert.Equal(t, "${secret:openai_api_key}", config["model"].Params["api_key"], "config is not modified")
+
+	var fieldErr *FieldError
+	_, err = testRegistry().Build(ctx, config)
+	require.ErrorAs(t, err, &fieldErr)
+	assert.Equal(t, "model.params.api_key: secret resolver not configured", err.Error())
+
+	config, err = ParseConfig([]byte(`model: {type: fake, params: {api_key: "${secret:missing}"}}`))
+	require.NoError(t, err)
+	_, err = testRegistry().Build(ctx, config, WithSecretResolver(resolver))
+	assert.EqualError(t, err, "model.params.api_key: resolve secret missing fail: not found")
+}
+
+type secretResolverFunc func(ctx context.Context, ref string) (string, error)
+
+func (f secretResolverFunc) Resolve(ctx context.Context, ref string) (string, error) {
+	return f(ctx, ref)
+}
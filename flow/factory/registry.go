@@ -0,0 +1,156 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package factory
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/components/tool"
+)
+
+// BuildFunc builds a component of type T from its config, deps resolving the other components it depends on.
+type BuildFunc[C, T any] func(ctx context.Context, config *C, deps *Components) (T, error)
+
+// builder builds a component from its spec, path naming the spec in the errors.
+type builder func(ctx context.Context, path string, params map[string]any, deps *Components) (any, error)
+
+// Registry is the registered component types by kind.
+type Registry struct {
+	mu       sync.RWMutex
+	builders map[Kind]map[string]builder
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{builders: make(map[Kind]map[string]builder)}
+}
+
+// Default is the registry of the package level functions, where the builtin package registers.
+var Default = NewRegistry()
+
+type registerOptions struct {
+	required []string
+}
+
+// RegisterOption configures the registration of a component type.
+type RegisterOption func(o *registerOptions)
+
+// WithRequired names, by json name, the params required by the component type, missing or empty params failing
+// with a FieldError.
+func WithRequired(params ...string) RegisterOption {
+	return func(o *registerOptions) {
+		o.required = append(o.required, params...)
+	}
+}
+
+// RegisterChatModel registers a chat model type in r, its params being decoded into a config of type C.
+func RegisterChatModel[C any](r *Registry, typ string, build BuildFunc[C, model.BaseChatModel], opts ...RegisterOption) {
+	register(r, KindChatModel, typ, build, opts)
+}
+
+// RegisterEmbedding registers an embedder type in r, its params being decoded into a config of type C.
+func RegisterEmbedding[C any](r *Registry, typ string, build BuildFunc[C, embedding.Embedder], opts ...RegisterOption) {
+	register(r, KindEmbedding, typ, build, opts)
+}
+
+// RegisterRetriever registers a retriever type in r, its params being decoded into a config of type C.
+func RegisterRetriever[C any](r *Registry, typ string, build BuildFunc[C, retriever.Retriever], opts ...RegisterOption) {
+	register(r, KindRetriever, typ, build, opts)
+}
+
+// RegisterIndexer registers an indexer type in r, its params being decoded into a config of type C.
+func RegisterIndexer[C any](r *Registry, typ string, build BuildFunc[C, indexer.Indexer], opts ...RegisterOption) {
+	register(r, KindIndexer, typ, build, opts)
+}
+
+// RegisterTool registers a tool type in r, its params being decoded into a config of type C.
+func RegisterTool[C any](r *Registry, typ string, build BuildFunc[C, tool.BaseTool], opts ...RegisterOption) {
+	register(r, KindTool, typ, build, opts)
+}
+
+// RegisterTransformer registers a document transformer type in r, its params being decoded into a config of type C.
+func RegisterTransformer[C any](r *Registry, typ string, build BuildFunc[C, document.Transformer], opts ...RegisterOption) {
+	register(r, KindTransformer, typ, build, opts)
+}
+
+// RegisterLoader registers a document loader type in r, its params being decoded into a config of type C.
+func RegisterLoader[C any](r *Registry, typ string, build BuildFunc[C, document.Loader], opts ...RegisterOption) {
+	register(r, KindLoader, typ, build, opts)
+}
+
+func register[C, T any](r *Registry, kind Kind, typ string, build BuildFunc[C, T], opts []RegisterOption) {
+	o := &registerOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	b := func(ctx context.Context, path string, params map[string]any, deps *Components) (any, error) {
+		for _, name := range o.required {
+			if v, ok := params[name]; !ok || v == nil || reflect.ValueOf(v).IsZero() {
+				return nil, fieldError(path+".params."+name, "required")
+			}
+		}
+		config, err := decodeParams[C](path+".params", params)
+		if err != nil {
+			return nil, err
+		}
+		component, err := build(ctx, config, deps)
+		if err != nil {
+			return nil, fmt.Errorf("build %s %s fail: %w", path, typ, err)
+		}
+		return component, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.builders[kind] == nil {
+		r.builders[kind] = make(map[string]builder)
+	}
+	r.builders[kind][typ] = b
+}
+
+// Types returns the registered types of a kind, sorted.
+func (r *Registry) Types(kind Kind) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	types := make([]string, 0, len(r.builders[kind]))
+	for typ := range r.builders[kind] {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func (r *Registry) builder(name string, spec *Spec) (builder, error) {
+	r.mu.RLock()
+	b, ok := r.builders[spec.Kind][spec.Type]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fieldError(name+".type", "unknown %s type %q, registered: %s",
+			spec.Kind, spec.Type, strings.Join(r.Types(spec.Kind), ", "))
+	}
+	return b, nil
+}
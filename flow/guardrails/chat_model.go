@@ -0,0 +1,260 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package guardrails
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+const typ = "Guardrails"
+
+// ComponentOfGuardrails is the component of the run info of the guardrails callbacks.
+const ComponentOfGuardrails components.Component = "Guardrails"
+
+// Action is what was done with the screened messages.
+type Action string
+
+const (
+	ActionPass     Action = "pass"
+	ActionBlock    Action = "block"
+	ActionRedact   Action = "redact"
+	ActionAnnotate Action = "annotate"
+)
+
+// CallbackInput is the input of the guardrails callbacks, emitted for each screened stage.
+type CallbackInput struct {
+	Stage    Stage
+	Messages []*schema.Message
+}
+
+// CallbackOutput is the output of the guardrails callbacks.
+type CallbackOutput struct {
+	Stage Stage
+	// Result is the moderation result merged over the screened messages.
+	Result *Result
+	Action Action
+	// Messages are the messages passed on, nil when blocked.
+	Messages []*schema.Message
+}
+
+// GuardrailsBlock returns the stage and the flagged categories when the content is blocked, see BlockedError.GuardrailsBlock.
+func (o *CallbackOutput) GuardrailsBlock() (stage string, categories []string, blocked bool) {
+	if o.Action != ActionBlock {
+		return "", nil, false
+	}
+	if o.Result != nil {
+		categories = o.Result.Categories
+	}
+	return string(o.Stage), categories, true
+}
+
+type Config struct {
+	// Input screens the user messages of the new turn, those after the last assistant message of the input.
+	// Optional. Default: nil, the input is not screened
+	Input *Guard
+	// Output screens the output of the chat model, a streamed output being buffered until it is screened.
+	// Optional. Default: nil, the output is not screened
+	Output *Guard
+}
+
+// NewChatModel wraps cm so that its input and output are screened by the guards of the config.
+// The screenings emit callbacks with the Guardrails component, their input and output being
+// *CallbackInput and *CallbackOutput.
+func NewChatModel(cm model.BaseChatModel, config *Config) (model.ToolCallingChatModel, error) {
+	if cm == nil {
+		return nil, errors.New("chat model is required")
+	}
+	if config == nil || (config.Input == nil && config.Output == nil) {
+		return nil, errors.New("input or output guard is required")
+	}
+	for _, g := range []*Guard{config.Input, config.Output} {
+		if g == nil {
+			continue
+		}
+		if err := g.validate(); err != nil {
+			return nil, err
+		}
+	}
+	return &chatModel{cm: cm, input: config.Input, output: config.Output}, nil
+}
+
+type chatModel struct {
+	cm     model.BaseChatModel
+	input  *Guard
+	output *Guard
+}
+
+func (c *chatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	input, err := c.screenInput(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := c.cm.Generate(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.screenOutput(ctx, out)
+}
+
+func (c *chatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	input, err := c.screenInput(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	sr, err := c.cm.Stream(ctx, input, opts...)
+	if err != nil || c.output == nil {
+		return sr, err
+	}
+	defer sr.Close()
+
+	var chunks []*schema.Message
+	for {
+		chunk, err := sr.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		chunks = append(chunks, chunk)
+	}
+	out, err := schema.ConcatMessages(chunks)
+	if err != nil {
+		return nil, fmt.Errorf("concat stream output fail: %w", err)
+	}
+
+	out, err = c.screenOutput(ctx, out)
+	if err != nil {
+		return nil, err
+	}
+	return schema.StreamReaderFromArray([]*schema.Message{out}), nil
+}
+
+func (c *chatModel) WithTools(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	tcm, ok := c.cm.(model.ToolCallingChatModel)
+	if !ok {
+		return nil, errors.New("chat model does not implement ToolCallingChatModel")
+	}
+	withTools, err := tcm.WithTools(tools)
+	if err != nil {
+		return nil, err
+	}
+	return &chatModel{cm: withTools, input: c.input, output: c.output}, nil
+}
+
+// IsCallbacksEnabled avoids duplicated callbacks, those of the wrapped chat model being kept.
+func (c *chatModel) IsCallbacksEnabled() bool {
+	return true
+}
+
+func (c *chatModel) screenInput(ctx context.Context, input []*schema.Message) ([]*schema.Message, error) {
+	if c.input == nil {
+		return input, nil
+	}
+
+	start := 0
+	for i := len(input) - 1; i >= 0; i-- {
+		if input[i].Role == schema.Assistant {
+			start = i + 1
+			break
+		}
+	}
+	var idx []int
+	for i := start; i < len(input); i++ {
+		if input[i].Role == schema.User {
+			idx = append(idx, i)
+		}
+	}
+	if len(idx) == 0 {
+		return input, nil
+	}
+
+	return run(ctx, StageInput, c.input, input, idx)
+}
+
+func (c *chatModel) screenOutput(ctx context.Context, out *schema.Message) (*schema.Message, error) {
+	if c.output == nil {
+		return out, nil
+	}
+
+	msgs, err := run(ctx, StageOutput, c.output, []*schema.Message{out}, []int{0})
+	if err != nil {
+		return nil, err
+	}
+	return msgs[0], nil
+}
+
+// run screens the messages of msgs at idx, returning msgs with the screened messages replaced.
+func run(ctx context.Context, stage Stage, g *Guard, msgs []*schema.Message, idx []int) (result []*schema.Message, err error) {
+	screened := make([]*schema.Message, 0, len(idx))
+	for _, i := range idx {
+		screened = append(screened, msgs[i])
+	}
+
+	ctx = callbacks.ReuseHandlers(ctx, &callbacks.RunInfo{Name: typ, Type: typ, Component: ComponentOfGuardrails})
+	ctx = callbacks.OnStart(ctx, &CallbackInput{Stage: stage, Messages: screened})
+	defer func() {
+		if err != nil && !errors.Is(err, ErrBlocked) {
+			callbacks.OnError(ctx, err)
+		}
+	}()
+
+	total := &Result{}
+	result, copied := msgs, false
+	for _, i := range idx {
+		out, res, err := g.screen(ctx, msgs[i])
+		if err != nil {
+			if g.FailOpen {
+				continue
+			}
+			return nil, err
+		}
+		total.merge(res)
+		if out != msgs[i] {
+			if !copied {
+				result, copied = append([]*schema.Message(nil), msgs...), true
+			}
+			result[i] = out
+		}
+	}
+
+	action := ActionPass
+	if total.Flagged {
+		action = Action(g.policy())
+	}
+	output := &CallbackOutput{Stage: stage, Result: total, Action: action}
+	if action == ActionBlock {
+		callbacks.OnEnd(ctx, output)
+		return nil, &BlockedError{Stage: stage, Result: total}
+	}
+
+	for _, i := range idx {
+		output.Messages = append(output.Messages, result[i])
+	}
+	callbacks.OnEnd(ctx, output)
+	return result, nil
+}
@@ -0,0 +1,155 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package guardrails
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeChatModel struct {
+	input []*schema.Message
+	reply string
+}
+
+func (f *fakeChatModel) Generate(_ context.Context, input []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	f.input = input
+	return schema.AssistantMessage(f.reply, nil), nil
+}
+
+func (f *fakeChatModel) Stream(_ context.Context, input []*schema.Message, _ ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	f.input = input
+	half := len(f.reply) / 2
+	return schema.StreamReaderFromArray([]*schema.Message{
+		schema.AssistantMessage(f.reply[:half], nil),
+		schema.AssistantMessage(f.reply[half:], nil),
+	}), nil
+}
+
+func TestChatModel(t *testing.T) {
+	ctx := context.Background()
+	rules := newTestRules(t)
+	inner := &fakeChatModel{reply: "try the casino"}
+
+	_, err := NewChatModel(inner, &Config{})
+	assert.Error(t, err)
+	_, err = NewChatModel(inner, &Config{Input: &Guard{}})
+	assert.Error(t, err)
+	_, err = NewChatModel(inner, &Config{Input: &Guard{Moderators: []Moderator{rules}, Policy: "drop"}})
+	assert.Error(t, err)
+
+	var outputs []*CallbackOutput
+	handler := callbacks.NewHandlerBuilder().OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+		if info.Component == ComponentOfGuardrails {
+			outputs = append(outputs, output.(*CallbackOutput))
+		}
+		return ctx
+	}).Build()
+	ctx = callbacks.InitCallbacks(ctx, &callbacks.RunInfo{}, handler)
+
+	t.Run("redact input and block output", func(t *testing.T) {
+		cm, err := NewChatModel(inner, &Config{
+			Input:  &Guard{Moderators: []Moderator{rules}, Policy: PolicyRedact},
+			Output: &Guard{Moderators: []Moderator{rules}},
+		})
+		require.NoError(t, err)
+
+		history := schema.UserMessage("call 555-0000")
+		_, err = cm.Generate(ctx, []*schema.Message{
+			schema.SystemMessage("call 555-1111"),
+			history,
+			schema.AssistantMessage("ok", nil),
+			schema.UserMessage("my phone is 555-1234"),
+		})
+		assert.ErrorIs(t, err, ErrBlocked)
+		var blocked *BlockedError
+		require.ErrorAs(t, err, &blocked)
+		assert.Equal(t, StageOutput, blocked.Stage)
+		assert.Equal(t, []string{"banned"}, blocked.Result.Categories)
+		stage, categories, ok := blocked.GuardrailsBlock()
+		assert.True(t, ok)
+		assert.Equal(t, "output", stage)
+		assert.Equal(t, []string{"banned"}, categories)
+
+		assert.Equal(t, "call 555-1111", inner.input[0].Content, "system messages are not screened")
+		assert.Same(t, history, inner.input[1], "messages before the last assistant message are not screened")
+		assert.Equal(t, "my phone is [REDACTED]", inner.input[3].Content)
+
+		require.Len(t, outputs, 2)
+		assert.Equal(t, ActionRedact, outputs[0].Action)
+		assert.Equal(t, StageInput, outputs[0].Stage)
+		assert.Equal(t, ActionBlock, outputs[1].Action)
+		assert.Nil(t, outputs[1].Messages)
+		_, _, ok = outputs[0].GuardrailsBlock()
+		assert.False(t, ok)
+		stage, categories, ok = outputs[1].GuardrailsBlock()
+		assert.True(t, ok)
+		assert.Equal(t, "output", stage)
+		assert.Equal(t, []string{"banned"}, categories)
+	})
+
+	t.Run("annotate stream output", func(t *testing.T) {
+		outputs = nil
+		cm, err := NewChatModel(inner, &Config{Output: &Guard{Moderators: []Moderator{rules}, Policy: PolicyAnnotate}})
+		require.NoError(t, err)
+
+		sr, err := cm.Stream(ctx, []*schema.Message{schema.UserMessage("what to do tonight?")})
+		require.NoError(t, err)
+		msg, err := sr.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, "try the casino", msg.Content)
+		assert.Equal(t, []string{"banned"}, msg.Extra[ExtraKeyModeration].(*Result).Categories)
+		_, err = sr.Recv()
+		assert.ErrorIs(t, err, io.EOF)
+
+		require.Len(t, outputs, 1)
+		assert.Equal(t, ActionAnnotate, outputs[0].Action)
+	})
+
+	t.Run("block input", func(t *testing.T) {
+		cm, err := NewChatModel(inner, &Config{Input: &Guard{Moderators: []Moderator{rules}}})
+		require.NoError(t, err)
+		inner.input = nil
+		_, err = cm.Generate(ctx, []*schema.Message{schema.UserMessage("casino?")})
+		assert.ErrorIs(t, err, ErrBlocked)
+		assert.Nil(t, inner.input)
+
+		out, err := cm.Generate(ctx, []*schema.Message{schema.UserMessage("hello")})
+		require.NoError(t, err)
+		assert.Equal(t, "try the casino", out.Content)
+	})
+
+	t.Run("moderator error", func(t *testing.T) {
+		cm, err := NewChatModel(inner, &Config{Input: &Guard{Moderators: []Moderator{failing{}}}})
+		require.NoError(t, err)
+		_, err = cm.Generate(ctx, []*schema.Message{schema.UserMessage("hello")})
+		assert.Error(t, err)
+		assert.False(t, errors.Is(err, ErrBlocked))
+
+		cm, err = NewChatModel(inner, &Config{Input: &Guard{Moderators: []Moderator{failing{}}, FailOpen: true}})
+		require.NoError(t, err)
+		_, err = cm.Generate(ctx, []*schema.Message{schema.UserMessage("hello")})
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/flow/guardrails"
+)
+
+func main() {
+	ctx := context.Background()
+
+	rules, err := guardrails.NewRuleModerator(&guardrails.RuleConfig{Rules: []guardrails.Rule{
+		{Category: "pii", Patterns: []string{`[\w.+-]+@[\w-]+\.[\w.]+`}},
+		{Category: "banned", Keywords: []string{"password"}},
+	}})
+	if err != nil {
+		log.Fatalf("Failed to create moderator: %v", err)
+	}
+
+	// replace with any chat model, e.g. openai or ark
+	cm, err := guardrails.NewChatModel(&echoModel{}, &guardrails.Config{
+		Input:  &guardrails.Guard{Moderators: []guardrails.Moderator{rules}, Policy: guardrails.PolicyRedact},
+		Output: &guardrails.Guard{Moderators: []guardrails.Moderator{rules}, Policy: guardrails.PolicyBlock},
+	})
+	if err != nil {
+		log.Fatalf("Failed to create chat model: %v", err)
+	}
+
+	for _, question := range []string{"my email is bob@example.com", "tell me the admin password"} {
+		out, err := cm.Generate(ctx, []*schema.Message{schema.UserMessage(question)})
+		var blocked *guardrails.BlockedError
+		switch {
+		case errors.As(err, &blocked):
+			fmt.Printf("blocked %s: %v\n", blocked.Stage, blocked.Result.Categories)
+		case err != nil:
+			log.Fatalf("Failed to generate: %v", err)
+		default:
+			fmt.Println(out.Content)
+		}
+	}
+}
+
+// echoModel repeats the user message.
+type echoModel struct{}
+
+func (e *echoModel) Generate(_ context.Context, input []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	return schema.AssistantMessage("you said: "+input[len(input)-1].Content, nil), nil
+}
+
+func (e *echoModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	out, err := e.Generate(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return schema.StreamReaderFromArray([]*schema.Message{out}), nil
+}
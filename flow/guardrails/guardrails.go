@@ -0,0 +1,281 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package guardrails screens the inputs and the outputs of chat models with moderation backends,
+// blocking, redacting or annotating the flagged content.
+package guardrails
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// Moderator classifies a text, see NewRuleModerator, NewOpenAIModerator and NewHTTPModerator.
+type Moderator interface {
+	Moderate(ctx context.Context, text string) (*Result, error)
+}
+
+// Result is the moderation result of a text.
+type Result struct {
+	// Flagged reports whether the text violates a policy.
+	Flagged bool `json:"flagged"`
+	// Categories are the categories the text is flagged for, e.g. "hate" or "pii".
+	Categories []string `json:"categories,omitempty"`
+	// Scores are the scores of the categories, if the moderator has scores.
+	Scores map[string]float64 `json:"scores,omitempty"`
+	// Matches are the flagged spans of the text, if the moderator locates them, used to redact the text.
+	Matches []Match `json:"matches,omitempty"`
+}
+
+// Match is a flagged span of a text, Start and End being byte offsets.
+type Match struct {
+	Category string `json:"category"`
+	Start    int    `json:"start"`
+	End      int    `json:"end"`
+}
+
+func (r *Result) merge(o *Result) {
+	if o == nil {
+		return
+	}
+	r.Flagged = r.Flagged || o.Flagged
+	for _, c := range o.Categories {
+		if !slices.Contains(r.Categories, c) {
+			r.Categories = append(r.Categories, c)
+		}
+	}
+	for k, v := range o.Scores {
+		if r.Scores == nil {
+			r.Scores = make(map[string]float64, len(o.Scores))
+		}
+		if v > r.Scores[k] {
+			r.Scores[k] = v
+		}
+	}
+	r.Matches = append(r.Matches, o.Matches...)
+}
+
+// Policy is what is done with flagged content.
+type Policy string
+
+const (
+	// PolicyBlock fails the call with a *BlockedError.
+	PolicyBlock Policy = "block"
+	// PolicyRedact replaces the flagged spans, or the whole text when the moderator does not locate them, with the redact text.
+	PolicyRedact Policy = "redact"
+	// PolicyAnnotate keeps the content, adding the moderation result to the Extra of the message under ExtraKeyModeration.
+	PolicyAnnotate Policy = "annotate"
+)
+
+// ExtraKeyModeration is the key of the *Result in the Extra of the messages annotated by PolicyAnnotate.
+const ExtraKeyModeration = "_eino_guardrails_moderation"
+
+const defaultRedactText = "[REDACTED]"
+
+// Stage is the side of the chat model call being screened.
+type Stage string
+
+const (
+	StageInput  Stage = "input"
+	StageOutput Stage = "output"
+)
+
+// ErrBlocked is matched by the *BlockedError returned when a flagged content is blocked.
+var ErrBlocked = errors.New("content blocked by guardrails")
+
+// BlockedError is returned when a flagged content is blocked.
+type BlockedError struct {
+	Stage  Stage
+	Result *Result
+}
+
+func (e *BlockedError) Error() string {
+	return fmt.Sprintf("%s %s, categories: [%s]", e.Stage, ErrBlocked.Error(), strings.Join(e.Result.Categories, ", "))
+}
+
+func (e *BlockedError) Is(target error) bool {
+	return target == ErrBlocked
+}
+
+// GuardrailsBlock returns the stage and the flagged categories of the blocked content. Along with
+// CallbackOutput.GuardrailsBlock, it lets the callbacks recognize the blocks without importing this module.
+func (e *BlockedError) GuardrailsBlock() (stage string, categories []string, blocked bool) {
+	if e.Result != nil {
+		categories = e.Result.Categories
+	}
+	return string(e.Stage), categories, true
+}
+
+// Guard screens the messages of a stage.
+type Guard struct {
+	// Moderators classify the texts in order, a text being flagged if any of them flags it.
+	// Required
+	Moderators []Moderator
+	// Policy is what is done with the flagged content.
+	// Optional. Default: PolicyBlock
+	Policy Policy
+	// RedactText replaces the flagged content with PolicyRedact.
+	// Optional. Default: "[REDACTED]"
+	RedactText string
+	// FailOpen passes the content when a moderator fails, instead of failing the call.
+	// Optional. Default: false
+	FailOpen bool
+}
+
+func (g *Guard) validate() error {
+	if len(g.Moderators) == 0 {
+		return errors.New("moderators are required")
+	}
+	switch g.Policy {
+	case "", PolicyBlock, PolicyRedact, PolicyAnnotate:
+		return nil
+	default:
+		return fmt.Errorf("unknown policy: %s", g.Policy)
+	}
+}
+
+func (g *Guard) policy() Policy {
+	if g.Policy == "" {
+		return PolicyBlock
+	}
+	return g.Policy
+}
+
+func (g *Guard) redactText() string {
+	if g.RedactText == "" {
+		return defaultRedactText
+	}
+	return g.RedactText
+}
+
+// moderate classifies text with all the moderators.
+func (g *Guard) moderate(ctx context.Context, text string) (*Result, error) {
+	res := &Result{}
+	for _, m := range g.Moderators {
+		r, err := m.Moderate(ctx, text)
+		if err != nil {
+			return nil, fmt.Errorf("moderate fail: %w", err)
+		}
+		res.merge(r)
+	}
+	return res, nil
+}
+
+// screen moderates the texts of msg, returning the message to pass on, copied if changed, and the merged result.
+func (g *Guard) screen(ctx context.Context, msg *schema.Message) (*schema.Message, *Result, error) {
+	total := &Result{}
+	out := msg
+
+	apply := func(text string) (string, error) {
+		if strings.TrimSpace(text) == "" {
+			return text, nil
+		}
+		res, err := g.moderate(ctx, text)
+		if err != nil {
+			return "", err
+		}
+		total.merge(res)
+		if !res.Flagged || g.policy() != PolicyRedact {
+			return text, nil
+		}
+		return redact(text, res.Matches, g.redactText()), nil
+	}
+
+	content, err := apply(msg.Content)
+	if err != nil {
+		return nil, nil, err
+	}
+	if content != msg.Content {
+		out = copyMessage(out)
+		out.Content = content
+	}
+	partsCloned := false
+	for i, part := range msg.MultiContent {
+		if part.Type != schema.ChatMessagePartTypeText {
+			continue
+		}
+		text, err := apply(part.Text)
+		if err != nil {
+			return nil, nil, err
+		}
+		if text != part.Text {
+			if out == msg {
+				out = copyMessage(out)
+			}
+			if !partsCloned {
+				out.MultiContent, partsCloned = slices.Clone(msg.MultiContent), true
+			}
+			out.MultiContent[i].Text = text
+		}
+	}
+
+	if total.Flagged && g.policy() == PolicyAnnotate {
+		if out == msg {
+			out = copyMessage(out)
+		}
+		extra := make(map[string]any, len(msg.Extra)+1)
+		for k, v := range msg.Extra {
+			extra[k] = v
+		}
+		extra[ExtraKeyModeration] = total
+		out.Extra = extra
+	}
+
+	return out, total, nil
+}
+
+// redact replaces the matched spans of text, or the whole text without matches, with replacement.
+func redact(text string, matches []Match, replacement string) string {
+	if len(matches) == 0 {
+		return replacement
+	}
+
+	spans := slices.Clone(matches)
+	slices.SortFunc(spans, func(a, b Match) int { return a.Start - b.Start })
+
+	// overlapping spans are replaced once
+	merged := make([]Match, 0, len(spans))
+	for _, m := range spans {
+		m.Start, m.End = max(m.Start, 0), min(m.End, len(text))
+		if m.Start >= m.End {
+			continue
+		}
+		if n := len(merged); n > 0 && m.Start <= merged[n-1].End {
+			merged[n-1].End = max(merged[n-1].End, m.End)
+			continue
+		}
+		merged = append(merged, m)
+	}
+
+	sb := strings.Builder{}
+	last := 0
+	for _, m := range merged {
+		sb.WriteString(text[last:m.Start])
+		sb.WriteString(replacement)
+		last = m.End
+	}
+	sb.WriteString(text[last:])
+	return sb.String()
+}
+
+func copyMessage(msg *schema.Message) *schema.Message {
+	c := *msg
+	return &c
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package guardrails
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRules(t *testing.T) Moderator {
+	m, err := NewRuleModerator(&RuleConfig{Rules: []Rule{
+		{Category: "pii", Patterns: []string{`\b\d{3}-\d{4}\b`}},
+		{Category: "banned", Keywords: []string{"Casino"}},
+	}})
+	require.NoError(t, err)
+	return m
+}
+
+func TestRuleModerator(t *testing.T) {
+	ctx := context.Background()
+	m := newTestRules(t)
+
+	res, err := m.Moderate(ctx, "call 555-1234 about the casino")
+	require.NoError(t, err)
+	assert.True(t, res.Flagged)
+	assert.Equal(t, []string{"pii", "banned"}, res.Categories)
+	assert.Equal(t, []Match{{Category: "pii", Start: 5, End: 13}, {Category: "banned", Start: 24, End: 30}}, res.Matches)
+
+	res, err = m.Moderate(ctx, "hello")
+	require.NoError(t, err)
+	assert.False(t, res.Flagged)
+
+	_, err = NewRuleModerator(&RuleConfig{})
+	assert.Error(t, err)
+	_, err = NewRuleModerator(&RuleConfig{Rules: []Rule{{Category: "x", Patterns: []string{"("}}}})
+	assert.Error(t, err)
+	_, err = NewRuleModerator(&RuleConfig{Rules: []Rule{{Keywords: []string{"x"}}}})
+	assert.Error(t, err)
+}
+
+func TestRedact(t *testing.T) {
+	assert.Equal(t, "[R]", redact("anything", nil, "[R]"))
+	assert.Equal(t, "a * c *", redact("a bb c dd", []Match{{Start: 7, End: 9}, {Start: 2, End: 4}}, "*"))
+	assert.Equal(t, "a *", redact("a bbcc", []Match{{Start: 2, End: 5}, {Start: 4, End: 6}}, "*"), "overlapping matches")
+}
+
+func TestGuardScreen(t *testing.T) {
+	ctx := context.Background()
+	msg := &schema.Message{
+		Role:    schema.User,
+		Content: "my number is 555-1234",
+		MultiContent: []schema.ChatMessagePart{
+			{Type: schema.ChatMessagePartTypeText, Text: "casino night"},
+			{Type: schema.ChatMessagePartTypeImageURL, ImageURL: &schema.ChatMessageImageURL{URL: "http://img"}},
+		},
+		Extra: map[string]any{"k": "v"},
+	}
+
+	g := &Guard{Moderators: []Moderator{newTestRules(t)}, Policy: PolicyRedact}
+	out, res, err := g.screen(ctx, msg)
+	require.NoError(t, err)
+	assert.True(t, res.Flagged)
+	assert.Equal(t, "my number is [REDACTED]", out.Content)
+	assert.Equal(t, "[REDACTED] night", out.MultiContent[0].Text)
+	assert.Equal(t, "my number is 555-1234", msg.Content, "the input message is not modified")
+	assert.Equal(t, "casino night", msg.MultiContent[0].Text)
+
+	g = &Guard{Moderators: []Moderator{newTestRules(t)}, Policy: PolicyAnnotate}
+	out, _, err = g.screen(ctx, msg)
+	require.NoError(t, err)
+	assert.Equal(t, msg.Content, out.Content)
+	assert.Equal(t, "v", out.Extra["k"])
+	assert.Equal(t, []string{"pii", "banned"}, out.Extra[ExtraKeyModeration].(*Result).Categories)
+	assert.NotContains(t, msg.Extra, ExtraKeyModeration)
+
+	clean := schema.UserMessage("hello")
+	out, res, err = g.screen(ctx, clean)
+	require.NoError(t, err)
+	assert.False(t, res.Flagged)
+	assert.Same(t, clean, out)
+
+	g = &Guard{Moderators: []Moderator{failing{}}}
+	_, _, err = g.screen(ctx, clean)
+	assert.Error(t, err)
+}
+
+type failing struct{}
+
+func (failing) Moderate(context.Context, string) (*Result, error) {
+	return nil, errors.New("unavailable")
+}
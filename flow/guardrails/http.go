@@ -0,0 +1,195 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package guardrails
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	defaultOpenAIBaseURL = "https://api.openai.com/v1"
+	defaultOpenAIModel   = "omni-moderation-latest"
+)
+
+type OpenAIConfig struct {
+	// APIKey is the openai api key.
+	// Required
+	APIKey string
+	// BaseURL is the base url of the openai compatible api.
+	// Optional. Default: "https://api.openai.com/v1"
+	BaseURL string
+	// Model is the moderation model.
+	// Optional. Default: "omni-moderation-latest"
+	Model string
+	// Threshold additionally flags the categories whose score is at least Threshold, 0 relying on the flags of the api.
+	// Optional
+	Threshold float64
+	// HTTPClient is used to request the api.
+	// Optional. Default: a client with a 30s timeout
+	HTTPClient *http.Client
+}
+
+// NewOpenAIModerator returns a Moderator using the openai moderation endpoint,
+// ref: https://platform.openai.com/docs/api-reference/moderations
+func NewOpenAIModerator(config *OpenAIConfig) (Moderator, error) {
+	if config == nil || config.APIKey == "" {
+		return nil, errors.New("api key is required")
+	}
+
+	m := &openAIModerator{
+		apiKey:    config.APIKey,
+		url:       trimURL(config.BaseURL) + "/moderations",
+		model:     config.Model,
+		threshold: config.Threshold,
+		client:    config.HTTPClient,
+	}
+	if config.BaseURL == "" {
+		m.url = defaultOpenAIBaseURL + "/moderations"
+	}
+	if m.model == "" {
+		m.model = defaultOpenAIModel
+	}
+	if m.client == nil {
+		m.client = &http.Client{Timeout: 30 * time.Second}
+	}
+	return m, nil
+}
+
+type openAIModerator struct {
+	apiKey    string
+	url       string
+	model     string
+	threshold float64
+	client    *http.Client
+}
+
+type openAIModerationResponse struct {
+	Results []struct {
+		Flagged        bool               `json:"flagged"`
+		Categories     map[string]bool    `json:"categories"`
+		CategoryScores map[string]float64 `json:"category_scores"`
+	} `json:"results"`
+}
+
+func (o *openAIModerator) Moderate(ctx context.Context, text string) (*Result, error) {
+	resp := &openAIModerationResponse{}
+	err := postJSON(ctx, o.client, o.url, map[string]string{"Authorization": "Bearer " + o.apiKey},
+		map[string]any{"model": o.model, "input": text}, resp)
+	if err != nil {
+		return nil, fmt.Errorf("openai moderation fail: %w", err)
+	}
+	if len(resp.Results) == 0 {
+		return nil, errors.New("openai moderation fail: empty results")
+	}
+
+	r := resp.Results[0]
+	res := &Result{Flagged: r.Flagged, Scores: r.CategoryScores}
+	for category, flagged := range r.Categories {
+		if flagged || (o.threshold > 0 && r.CategoryScores[category] >= o.threshold) {
+			res.Categories = append(res.Categories, category)
+		}
+	}
+	sort.Strings(res.Categories)
+	res.Flagged = res.Flagged || len(res.Categories) > 0
+	return res, nil
+}
+
+type HTTPConfig struct {
+	// URL of the classifier, which receives a POST of {"text": "..."} and replies with a json Result:
+	// {"flagged": true, "categories": ["toxic"], "scores": {"toxic": 0.93}, "matches": [{"category": "toxic", "start": 0, "end": 5}]}
+	// Required
+	URL string
+	// Headers are added to the requests, e.g. for authentication.
+	// Optional
+	Headers map[string]string
+	// HTTPClient is used to request the classifier.
+	// Optional. Default: a client with a 10s timeout
+	HTTPClient *http.Client
+}
+
+// NewHTTPModerator returns a Moderator calling a custom http classifier.
+func NewHTTPModerator(config *HTTPConfig) (Moderator, error) {
+	if config == nil || config.URL == "" {
+		return nil, errors.New("url is required")
+	}
+
+	client := config.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &httpModerator{url: config.URL, headers: config.Headers, client: client}, nil
+}
+
+type httpModerator struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+func (h *httpModerator) Moderate(ctx context.Context, text string) (*Result, error) {
+	res := &Result{}
+	if err := postJSON(ctx, h.client, h.url, h.headers, map[string]string{"text": text}, res); err != nil {
+		return nil, fmt.Errorf("http moderation fail: %w", err)
+	}
+	return res, nil
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, body, out any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("marshal request fail: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("create request fail: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send request fail: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response fail: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(respBody))
+	}
+	if err = json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("unmarshal response fail: %w", err)
+	}
+	return nil
+}
+
+// trimURL trims the trailing slashes of a base url.
+func trimURL(u string) string {
+	return strings.TrimRight(u, "/")
+}
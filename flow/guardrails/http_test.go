@@ -0,0 +1,101 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package guardrails
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAIModerator(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/moderations", r.URL.Path)
+		assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+		body := map[string]string{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "omni-moderation-latest", body["model"])
+
+		if body["input"] == "error" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		flagged := body["input"] == "I hate you"
+		_ = json.NewEncoder(w).Encode(map[string]any{"results": []map[string]any{{
+			"flagged":         flagged,
+			"categories":      map[string]bool{"hate": flagged, "violence": false},
+			"category_scores": map[string]float64{"hate": 0.9, "violence": 0.4},
+		}}})
+	}))
+	defer server.Close()
+
+	m, err := NewOpenAIModerator(&OpenAIConfig{APIKey: "key", BaseURL: server.URL + "/v1/"})
+	require.NoError(t, err)
+
+	res, err := m.Moderate(ctx, "I hate you")
+	require.NoError(t, err)
+	assert.True(t, res.Flagged)
+	assert.Equal(t, []string{"hate"}, res.Categories)
+	assert.Equal(t, 0.9, res.Scores["hate"])
+
+	res, err = m.Moderate(ctx, "hello")
+	require.NoError(t, err)
+	assert.False(t, res.Flagged)
+
+	m, err = NewOpenAIModerator(&OpenAIConfig{APIKey: "key", BaseURL: server.URL + "/v1", Threshold: 0.3})
+	require.NoError(t, err)
+	res, err = m.Moderate(ctx, "hello")
+	require.NoError(t, err)
+	assert.True(t, res.Flagged)
+	assert.Equal(t, []string{"hate", "violence"}, res.Categories)
+
+	_, err = m.Moderate(ctx, "error")
+	assert.ErrorContains(t, err, "unexpected status code 500")
+
+	_, err = NewOpenAIModerator(&OpenAIConfig{})
+	assert.Error(t, err)
+}
+
+func TestHTTPModerator(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("X-Token"))
+		body := map[string]string{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		_, _ = w.Write([]byte(`{"flagged": true, "categories": ["toxic"], "scores": {"toxic": 0.93}, "matches": [{"category": "toxic", "start": 0, "end": 5}]}`))
+	}))
+	defer server.Close()
+
+	m, err := NewHTTPModerator(&HTTPConfig{URL: server.URL, Headers: map[string]string{"X-Token": "secret"}})
+	require.NoError(t, err)
+	res, err := m.Moderate(ctx, "idiot!")
+	require.NoError(t, err)
+	assert.Equal(t, &Result{
+		Flagged:    true,
+		Categories: []string{"toxic"},
+		Scores:     map[string]float64{"toxic": 0.93},
+		Matches:    []Match{{Category: "toxic", Start: 0, End: 5}},
+	}, res)
+
+	_, err = NewHTTPModerator(&HTTPConfig{})
+	assert.Error(t, err)
+}
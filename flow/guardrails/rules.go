@@ -0,0 +1,94 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package guardrails
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// Rule flags the texts containing any of its keywords or matching any of its patterns.
+type Rule struct {
+	// Category is the category of the flagged texts.
+	Category string
+	// Keywords are matched case-insensitively.
+	Keywords []string
+	// Patterns are regular expressions, in the syntax of the regexp package.
+	Patterns []string
+}
+
+type RuleConfig struct {
+	// Rules are checked in order, all of them being checked.
+	// Required
+	Rules []Rule
+}
+
+// NewRuleModerator returns a Moderator applying local keyword and regex rules, e.g. for pii or banned topics.
+// It locates the matches, so the flagged spans can be redacted.
+func NewRuleModerator(config *RuleConfig) (Moderator, error) {
+	if config == nil || len(config.Rules) == 0 {
+		return nil, errors.New("rules are required")
+	}
+
+	m := &ruleModerator{}
+	for _, r := range config.Rules {
+		if r.Category == "" {
+			return nil, errors.New("rule category is required")
+		}
+		exprs := make([]string, 0, len(r.Keywords)+len(r.Patterns))
+		for _, k := range r.Keywords {
+			if k != "" {
+				exprs = append(exprs, "(?i)"+regexp.QuoteMeta(k))
+			}
+		}
+		exprs = append(exprs, r.Patterns...)
+		for _, expr := range exprs {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return nil, fmt.Errorf("compile pattern of rule %s fail: %w", r.Category, err)
+			}
+			m.rules = append(m.rules, compiledRule{category: r.Category, re: re})
+		}
+	}
+
+	return m, nil
+}
+
+type compiledRule struct {
+	category string
+	re       *regexp.Regexp
+}
+
+type ruleModerator struct {
+	rules []compiledRule
+}
+
+func (r *ruleModerator) Moderate(_ context.Context, text string) (*Result, error) {
+	res := &Result{}
+	for _, rule := range r.rules {
+		locs := rule.re.FindAllStringIndex(text, -1)
+		if len(locs) == 0 {
+			continue
+		}
+		res.merge(&Result{Flagged: true, Categories: []string{rule.category}})
+		for _, loc := range locs {
+			res.Matches = append(res.Matches, Match{Category: rule.category, Start: loc[0], End: loc[1]})
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/gorilla/websocket"
+
+	"github.com/cloudwego/eino-ext/flow/httpstream"
+)
+
+// try with:
+//
+//	curl -N 'localhost:8080/sse?q=hello+from+eino'
+//	curl -N 'localhost:8080/sse?q=hello+from+eino&format=openai'
+//	websocat ws://localhost:8080/ws
+func main() {
+	// replace with any chat model, e.g. openai or ark
+	cm := &echoModel{}
+	upgrader := websocket.Upgrader{}
+
+	http.HandleFunc("/sse", func(w http.ResponseWriter, r *http.Request) {
+		// the request context cancels the model call when the client disconnects
+		sr, err := cm.Stream(r.Context(), []*schema.Message{schema.UserMessage(r.URL.Query().Get("q"))})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		config := &httpstream.Config{Format: httpstream.Format(r.URL.Query().Get("format")), Model: "echo"}
+		if err = httpstream.ServeSSE(w, r, sr, config); err != nil {
+			log.Printf("sse stream ended: %v", err)
+		}
+	})
+
+	http.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			log.Printf("upgrade fail: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		// each text message is a question, answered with a stream of frames
+		for {
+			_, question, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			sr, err := cm.Stream(r.Context(), []*schema.Message{schema.UserMessage(string(question))})
+			if err != nil {
+				log.Printf("stream fail: %v", err)
+				return
+			}
+			if err = httpstream.StreamWebSocket(r.Context(), conn, sr, nil); err != nil {
+				log.Printf("websocket stream ended: %v", err)
+				return
+			}
+		}
+	})
+
+	log.Println("listening on :8080")
+	log.Fatal(http.ListenAndServe(":8080", nil))
+}
+
+// echoModel streams the words of the last message back, replace with a real chat model.
+type echoModel struct{}
+
+func (e *echoModel) Generate(_ context.Context, input []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	return schema.AssistantMessage(input[len(input)-1].Content, nil), nil
+}
+
+func (e *echoModel) Stream(ctx context.Context, input []*schema.Message, _ ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	sr, sw := schema.Pipe[*schema.Message](0)
+	go func() {
+		defer sw.Close()
+		for i, word := range strings.Fields(input[len(input)-1].Content) {
+			if i > 0 {
+				word = " " + word
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(200 * time.Millisecond):
+			}
+			if closed := sw.Send(schema.AssistantMessage(word, nil), nil); closed {
+				return
+			}
+		}
+		sw.Send(&schema.Message{Role: schema.Assistant, ResponseMeta: &schema.ResponseMeta{FinishReason: "stop"}}, nil)
+	}()
+	return sr, nil
+}
@@ -0,0 +1,47 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package hertz writes eino message streams to Hertz responses as Server-Sent Events.
+// For WebSocket, *websocket.Conn of github.com/hertz-contrib/websocket is an httpstream.Conn.
+package hertz
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+	"github.com/cloudwego/hertz/pkg/protocol/http1/resp"
+
+	"github.com/cloudwego/eino-ext/flow/httpstream"
+)
+
+// ServeSSE writes the chunks of sr to the response of c as Server-Sent Events, each flushed as soon as received,
+// as httpstream.ServeSSE does.
+// It returns when the stream ends, or when a write fails, the client being gone: sr is closed, which stops the chat
+// model producing it. Run the server with server.WithSenseClientDisconnection(true) for ctx to be canceled
+// on client disconnections, so that they are noticed while the model is thinking.
+func ServeSSE(ctx context.Context, c *app.RequestContext, sr *schema.StreamReader[*schema.Message], config *httpstream.Config) error {
+	c.SetStatusCode(consts.StatusOK)
+	c.Response.Header.Set(consts.HeaderContentType, "text/event-stream")
+	c.Response.Header.Set("Cache-Control", "no-cache")
+	// disables the response buffering of nginx
+	c.Response.Header.Set("X-Accel-Buffering", "no")
+
+	// the chunked writer sends the headers with the first flush, and is finalized by hertz after the handler
+	w := resp.NewChunkedBodyWriter(&c.Response, c.GetWriter())
+	c.Response.HijackWriter(w)
+	return httpstream.StreamSSE(ctx, w, sr, config)
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package hertz
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/app/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudwego/eino-ext/flow/httpstream"
+)
+
+func TestServeSSE(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	h := server.New(server.WithListener(ln), server.WithDisablePrintRoute(true), server.WithExitWaitTime(0))
+	h.GET("/chat", func(ctx context.Context, c *app.RequestContext) {
+		sr, sw := schema.Pipe[*schema.Message](0)
+		go func() {
+			defer sw.Close()
+			sw.Send(schema.AssistantMessage("Hello", nil), nil)
+			time.Sleep(30 * time.Millisecond)
+			sw.Send(schema.AssistantMessage(" world", nil), nil)
+		}()
+		assert.NoError(t, ServeSSE(ctx, c, sr, &httpstream.Config{Format: httpstream.FormatOpenAI}))
+	})
+	h.GET("/error", func(ctx context.Context, c *app.RequestContext) {
+		sr, sw := schema.Pipe[*schema.Message](1)
+		sw.Send(nil, errors.New("boom"))
+		sw.Close()
+		assert.EqualError(t, ServeSSE(ctx, c, sr, nil), "boom")
+	})
+	go h.Spin()
+	defer h.Close()
+
+	url := "http://" + ln.Addr().String()
+	var resp *http.Response
+	require.Eventually(t, func() bool {
+		resp, err = http.Get(url + "/chat")
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	var data []string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		if line := scanner.Text(); strings.HasPrefix(line, "data: ") {
+			data = append(data, strings.TrimPrefix(line, "data: "))
+		}
+	}
+	resp.Body.Close()
+	require.Len(t, data, 3)
+	assert.Contains(t, data[0], `"object":"chat.completion.chunk"`)
+	assert.Contains(t, data[1], `"content":" world"`)
+	assert.Equal(t, httpstream.DoneData, data[2])
+
+	resp, err = http.Get(url + "/error")
+	require.NoError(t, err)
+	scanner = bufio.NewScanner(resp.Body)
+	var lines []string
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	resp.Body.Close()
+	assert.Equal(t, []string{"event: error", `data: {"error":{"message":"boom","type":"server_error"}}`}, lines)
+}
@@ -0,0 +1,114 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package httpstream writes eino message streams to http responses, as Server-Sent Events or WebSocket frames,
+// with the chunks formatted as eino messages or as openai chat completion chunks.
+package httpstream
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// Format is the format of the chunks written.
+type Format string
+
+const (
+	// FormatEino writes the chunks as json schema.Message.
+	FormatEino Format = "eino"
+	// FormatOpenAI writes the chunks as openai chat completion chunks, ref: https://platform.openai.com/docs/api-reference/chat/streaming
+	FormatOpenAI Format = "openai"
+)
+
+const (
+	defaultHeartbeatInterval = 15 * time.Second
+	defaultOpenAIModel       = "eino"
+)
+
+// DoneData is the data of the last event or frame, written once the stream was fully received, as with openai.
+const DoneData = "[DONE]"
+
+type Config struct {
+	// Format of the chunks.
+	// Optional. Default: FormatEino
+	Format Format
+	// Model is the model name of the openai chunks.
+	// Optional. Default: "eino"
+	Model string
+	// ID is the id of the openai chunks.
+	// Optional. Default: a random "chatcmpl-" id
+	ID string
+	// HeartbeatInterval is the interval of the heartbeats keeping the connection alive through proxies
+	// while the model is thinking: SSE comments, or WebSocket pings. Negative disables them.
+	// Optional. Default: 15s
+	HeartbeatInterval time.Duration
+}
+
+func (c *Config) heartbeatInterval() time.Duration {
+	if c.HeartbeatInterval == 0 {
+		return defaultHeartbeatInterval
+	}
+	return c.HeartbeatInterval
+}
+
+// encoder encodes the chunks of a stream.
+type encoder interface {
+	// encode returns the data of a chunk, nil to skip it.
+	encode(chunk *schema.Message) ([]byte, error)
+}
+
+func newEncoder(config *Config) (encoder, error) {
+	switch config.Format {
+	case "", FormatEino:
+		return einoEncoder{}, nil
+	case FormatOpenAI:
+		e := &openAIEncoder{id: config.ID, model: config.Model, created: time.Now().Unix()}
+		if e.id == "" {
+			e.id = NewCompletionID()
+		}
+		if e.model == "" {
+			e.model = defaultOpenAIModel
+		}
+		return e, nil
+	default:
+		return nil, fmt.Errorf("unknown format: %s", config.Format)
+	}
+}
+
+type einoEncoder struct{}
+
+func (einoEncoder) encode(chunk *schema.Message) ([]byte, error) {
+	return json.Marshal(chunk)
+}
+
+// errorData is the data of the error event or frame, in the openai error format for both formats.
+func errorData(err error) []byte {
+	data, _ := json.Marshal(map[string]any{
+		"error": map[string]string{"message": err.Error(), "type": "server_error"},
+	})
+	return data
+}
+
+// NewCompletionID returns a random openai chat completion id.
+func NewCompletionID() string {
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+	return "chatcmpl-" + hex.EncodeToString(b)
+}
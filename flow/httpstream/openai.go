@@ -0,0 +1,134 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package httpstream
+
+import (
+	"encoding/json"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// OpenAIChunk is an openai chat completion chunk.
+type OpenAIChunk struct {
+	ID      string          `json:"id"`
+	Object  string          `json:"object"`
+	Created int64           `json:"created"`
+	Model   string          `json:"model"`
+	Choices []*OpenAIChoice `json:"choices"`
+	Usage   *OpenAIUsage    `json:"usage,omitempty"`
+}
+
+type OpenAIChoice struct {
+	Index        int          `json:"index"`
+	Delta        *OpenAIDelta `json:"delta"`
+	FinishReason *string      `json:"finish_reason"`
+}
+
+type OpenAIDelta struct {
+	Role             string            `json:"role,omitempty"`
+	Content          string            `json:"content,omitempty"`
+	ReasoningContent string            `json:"reasoning_content,omitempty"`
+	ToolCalls        []*OpenAIToolCall `json:"tool_calls,omitempty"`
+}
+
+type OpenAIToolCall struct {
+	Index    int                `json:"index"`
+	ID       string             `json:"id,omitempty"`
+	Type     string             `json:"type,omitempty"`
+	Function OpenAIFunctionCall `json:"function"`
+}
+
+type OpenAIFunctionCall struct {
+	Name      string `json:"name,omitempty"`
+	Arguments string `json:"arguments"`
+}
+
+type OpenAIUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// NewOpenAIUsage converts the token usage of a message, nil if none.
+func NewOpenAIUsage(u *schema.TokenUsage) *OpenAIUsage {
+	if u == nil {
+		return nil
+	}
+	return &OpenAIUsage{PromptTokens: u.PromptTokens, CompletionTokens: u.CompletionTokens, TotalTokens: u.TotalTokens}
+}
+
+// NewOpenAIToolCalls converts the tool calls of a message, their index defaulting to their position.
+func NewOpenAIToolCalls(toolCalls []schema.ToolCall) []*OpenAIToolCall {
+	if len(toolCalls) == 0 {
+		return nil
+	}
+	ret := make([]*OpenAIToolCall, 0, len(toolCalls))
+	for i, tc := range toolCalls {
+		index := i
+		if tc.Index != nil {
+			index = *tc.Index
+		}
+		ret = append(ret, &OpenAIToolCall{
+			Index: index,
+			ID:    tc.ID,
+			Type:  tc.Type,
+			Function: OpenAIFunctionCall{
+				Name:      tc.Function.Name,
+				Arguments: tc.Function.Arguments,
+			},
+		})
+	}
+	return ret
+}
+
+type openAIEncoder struct {
+	id       string
+	model    string
+	created  int64
+	roleSent bool
+}
+
+func (o *openAIEncoder) encode(chunk *schema.Message) ([]byte, error) {
+	delta := &OpenAIDelta{
+		Content:          chunk.Content,
+		ReasoningContent: chunk.ReasoningContent,
+		ToolCalls:        NewOpenAIToolCalls(chunk.ToolCalls),
+	}
+	// the role is only sent in the first chunk
+	if !o.roleSent {
+		delta.Role = string(schema.Assistant)
+		o.roleSent = true
+	}
+
+	choice := &OpenAIChoice{Delta: delta}
+	var usage *OpenAIUsage
+	if chunk.ResponseMeta != nil {
+		if chunk.ResponseMeta.FinishReason != "" {
+			reason := chunk.ResponseMeta.FinishReason
+			choice.FinishReason = &reason
+		}
+		usage = NewOpenAIUsage(chunk.ResponseMeta.Usage)
+	}
+
+	return json.Marshal(&OpenAIChunk{
+		ID:      o.id,
+		Object:  "chat.completion.chunk",
+		Created: o.created,
+		Model:   o.model,
+		Choices: []*OpenAIChoice{choice},
+		Usage:   usage,
+	})
+}
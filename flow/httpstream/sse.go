@@ -0,0 +1,108 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package httpstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// Flusher is a streamed response body, Flush sending the bytes written so far to the client.
+type Flusher interface {
+	io.Writer
+	Flush() error
+}
+
+// ServeSSE writes the chunks of sr to w as Server-Sent Events, each flushed as soon as received:
+//
+//	data: {"role":"assistant","content":"Hello"}
+//
+// followed by "data: [DONE]" once the stream was fully received, or by an "error" event with an openai error
+// if the stream fails. Heartbeats are sent as SSE comments.
+// It returns when the stream ends, or when the client disconnects, the request context being canceled:
+// sr is closed, which stops the chat model producing it. Use the request context to call the chat model so that
+// the call is canceled as well.
+func ServeSSE(w http.ResponseWriter, r *http.Request, sr *schema.StreamReader[*schema.Message], config *Config) error {
+	rc := http.NewResponseController(w)
+	setSSEHeaders(w.Header())
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		sr.Close()
+		if errors.Is(err, http.ErrNotSupported) {
+			return errors.New("response writer does not support flushing")
+		}
+		return fmt.Errorf("flush headers fail: %w", err)
+	}
+	return StreamSSE(r.Context(), &responseFlusher{w: w, rc: rc}, sr, config)
+}
+
+// StreamSSE writes the chunks of sr to w as Server-Sent Events, as ServeSSE does, for the http frameworks other than
+// net/http, the response headers being already written.
+func StreamSSE(ctx context.Context, w Flusher, sr *schema.StreamReader[*schema.Message], config *Config) error {
+	return stream(ctx, &sseSink{w: w}, sr, config)
+}
+
+func setSSEHeaders(h http.Header) {
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	// disables the response buffering of nginx
+	h.Set("X-Accel-Buffering", "no")
+}
+
+type responseFlusher struct {
+	w  http.ResponseWriter
+	rc *http.ResponseController
+}
+
+func (r *responseFlusher) Write(p []byte) (int, error) {
+	return r.w.Write(p)
+}
+
+func (r *responseFlusher) Flush() error {
+	return r.rc.Flush()
+}
+
+type sseSink struct {
+	w Flusher
+}
+
+func (s *sseSink) data(data []byte) error {
+	return s.write("data: ", data, "\n\n")
+}
+
+func (s *sseSink) error(data []byte) error {
+	return s.write("event: error\ndata: ", data, "\n\n")
+}
+
+func (s *sseSink) heartbeat() error {
+	return s.write(": ping", nil, "\n\n")
+}
+
+func (s *sseSink) write(prefix string, data []byte, suffix string) error {
+	buf := make([]byte, 0, len(prefix)+len(data)+len(suffix))
+	buf = append(buf, prefix...)
+	buf = append(buf, data...)
+	buf = append(buf, suffix...)
+	if _, err := s.w.Write(buf); err != nil {
+		return err
+	}
+	return s.w.Flush()
+}
@@ -0,0 +1,193 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package httpstream
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func chunks() []*schema.Message {
+	index := 0
+	return []*schema.Message{
+		schema.AssistantMessage("Hello", nil),
+		schema.AssistantMessage(" world", nil),
+		{Role: schema.Assistant, ToolCalls: []schema.ToolCall{
+			{Index: &index, ID: "call_1", Type: "function", Function: schema.FunctionCall{Name: "search", Arguments: `{"q":`}},
+		}},
+		{Role: schema.Assistant, ResponseMeta: &schema.ResponseMeta{
+			FinishReason: "tool_calls",
+			Usage:        &schema.TokenUsage{PromptTokens: 3, CompletionTokens: 4, TotalTokens: 7},
+		}},
+	}
+}
+
+// readEvents reads the events of an SSE response, as "event: data" or "data" lines.
+func readEvents(t *testing.T, resp *http.Response) []string {
+	t.Helper()
+	defer resp.Body.Close()
+	var events []string
+	var event string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ") + ": "
+		case strings.HasPrefix(line, "data: "):
+			events = append(events, event+strings.TrimPrefix(line, "data: "))
+			event = ""
+		case strings.HasPrefix(line, ":"):
+			events = append(events, line)
+		}
+	}
+	return events
+}
+
+func TestServeSSE(t *testing.T) {
+	t.Run("eino", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.NoError(t, ServeSSE(w, r, schema.StreamReaderFromArray(chunks()), nil))
+		}))
+		defer srv.Close()
+
+		resp, err := http.Get(srv.URL)
+		require.NoError(t, err)
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+		events := readEvents(t, resp)
+		require.Len(t, events, 5)
+		assert.Equal(t, DoneData, events[4])
+
+		msg := &schema.Message{}
+		require.NoError(t, json.Unmarshal([]byte(events[0]), msg))
+		assert.Equal(t, "Hello", msg.Content)
+	})
+
+	t.Run("openai", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.NoError(t, ServeSSE(w, r, schema.StreamReaderFromArray(chunks()),
+				&Config{Format: FormatOpenAI, Model: "gpt-4o", ID: "chatcmpl-1"}))
+		}))
+		defer srv.Close()
+
+		resp, err := http.Get(srv.URL)
+		require.NoError(t, err)
+		events := readEvents(t, resp)
+		require.Len(t, events, 5)
+
+		var got []*OpenAIChunk
+		for _, e := range events[:4] {
+			c := &OpenAIChunk{}
+			require.NoError(t, json.Unmarshal([]byte(e), c))
+			assert.Equal(t, "chatcmpl-1", c.ID)
+			assert.Equal(t, "chat.completion.chunk", c.Object)
+			assert.Equal(t, "gpt-4o", c.Model)
+			require.Len(t, c.Choices, 1)
+			got = append(got, c)
+		}
+		assert.Equal(t, "assistant", got[0].Choices[0].Delta.Role)
+		assert.Equal(t, "Hello", got[0].Choices[0].Delta.Content)
+		assert.Empty(t, got[1].Choices[0].Delta.Role)
+		assert.Nil(t, got[1].Choices[0].FinishReason)
+		assert.Equal(t, []*OpenAIToolCall{{Index: 0, ID: "call_1", Type: "function",
+			Function: OpenAIFunctionCall{Name: "search", Arguments: `{"q":`}}}, got[2].Choices[0].Delta.ToolCalls)
+		require.NotNil(t, got[3].Choices[0].FinishReason)
+		assert.Equal(t, "tool_calls", *got[3].Choices[0].FinishReason)
+		assert.Equal(t, &OpenAIUsage{PromptTokens: 3, CompletionTokens: 4, TotalTokens: 7}, got[3].Usage)
+		assert.Equal(t, DoneData, events[4])
+	})
+
+	t.Run("error and heartbeat", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			sr, sw := schema.Pipe[*schema.Message](0)
+			go func() {
+				defer sw.Close()
+				sw.Send(schema.AssistantMessage("Hello", nil), nil)
+				time.Sleep(50 * time.Millisecond)
+				sw.Send(nil, errors.New("boom"))
+			}()
+			assert.EqualError(t, ServeSSE(w, r, sr, &Config{HeartbeatInterval: 20 * time.Millisecond}), "boom")
+		}))
+		defer srv.Close()
+
+		resp, err := http.Get(srv.URL)
+		require.NoError(t, err)
+		events := readEvents(t, resp)
+		require.GreaterOrEqual(t, len(events), 3)
+		assert.Contains(t, events, ": ping")
+		assert.Equal(t, `error: {"error":{"message":"boom","type":"server_error"}}`, events[len(events)-1])
+	})
+
+	t.Run("client disconnect", func(t *testing.T) {
+		closed := make(chan struct{})
+		returned := make(chan error, 1)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			sr, sw := schema.Pipe[*schema.Message](0)
+			go func() {
+				defer close(closed)
+				defer sw.Close()
+				for {
+					if sw.Send(schema.AssistantMessage("x", nil), nil) {
+						return
+					}
+					time.Sleep(5 * time.Millisecond)
+				}
+			}()
+			returned <- ServeSSE(w, r, sr, nil)
+		}))
+		defer srv.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		_, err = bufio.NewReader(resp.Body).ReadString('\n')
+		require.NoError(t, err)
+		cancel()
+		resp.Body.Close()
+
+		select {
+		case err = <-returned:
+			assert.Error(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("ServeSSE not returned")
+		}
+		select {
+		case <-closed:
+		case <-time.After(5 * time.Second):
+			t.Fatal("stream not closed")
+		}
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		err := ServeSSE(rec, httptest.NewRequest(http.MethodGet, "/", nil),
+			schema.StreamReaderFromArray(chunks()), &Config{Format: "xml"})
+		assert.EqualError(t, err, "unknown format: xml")
+	})
+}
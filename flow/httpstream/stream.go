@@ -0,0 +1,114 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package httpstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// sink writes the events of a stream to the client.
+type sink interface {
+	data(data []byte) error
+	error(data []byte) error
+	heartbeat() error
+}
+
+type received struct {
+	chunk *schema.Message
+	err   error
+}
+
+// stream writes the chunks of sr to s until the stream ends, the context is done or a write fails,
+// the client being gone. sr is closed, which stops the chat model producing it.
+func stream(ctx context.Context, s sink, sr *schema.StreamReader[*schema.Message], config *Config) error {
+	defer sr.Close()
+	if config == nil {
+		config = &Config{}
+	}
+	enc, err := newEncoder(config)
+	if err != nil {
+		return err
+	}
+
+	// the chunks are received in a goroutine so that heartbeats and disconnections are handled while waiting
+	items := make(chan received)
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		for {
+			chunk, err := sr.Recv()
+			select {
+			case items <- received{chunk: chunk, err: err}:
+			case <-stop:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	var heartbeat <-chan time.Time
+	if interval := config.heartbeatInterval(); interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		heartbeat = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-heartbeat:
+			if err = s.heartbeat(); err != nil {
+				return fmt.Errorf("write heartbeat fail: %w", err)
+			}
+		case item := <-items:
+			if errors.Is(item.err, io.EOF) {
+				if err = s.data([]byte(DoneData)); err != nil {
+					return fmt.Errorf("write done fail: %w", err)
+				}
+				return nil
+			}
+			if item.err != nil {
+				_ = s.error(errorData(item.err))
+				return item.err
+			}
+			if item.chunk == nil {
+				continue
+			}
+
+			data, err := enc.encode(item.chunk)
+			if err != nil {
+				err = fmt.Errorf("encode chunk fail: %w", err)
+				_ = s.error(errorData(err))
+				return err
+			}
+			if data == nil {
+				continue
+			}
+			if err = s.data(data); err != nil {
+				return fmt.Errorf("write chunk fail: %w", err)
+			}
+		}
+	}
+}
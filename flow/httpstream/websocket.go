@@ -0,0 +1,68 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package httpstream
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// the websocket message types, ref: https://www.rfc-editor.org/rfc/rfc6455#section-11.8
+const (
+	textMessage = 1
+	pingMessage = 9
+)
+
+const defaultWriteTimeout = 10 * time.Second
+
+// Conn is a websocket connection, such as *websocket.Conn of github.com/gorilla/websocket
+// or of github.com/hertz-contrib/websocket.
+type Conn interface {
+	WriteMessage(messageType int, data []byte) error
+	WriteControl(messageType int, data []byte, deadline time.Time) error
+	SetWriteDeadline(t time.Time) error
+}
+
+// StreamWebSocket writes the chunks of sr to conn as text frames, each holding a chunk in the format of config,
+// followed by a "[DONE]" frame once the stream was fully received, or by an openai error frame if the stream fails.
+// Heartbeats are sent as pings, and each write is bounded by a 10s deadline.
+// It returns when the stream ends, when ctx is done or when a write fails, the client being gone: sr is closed,
+// which stops the chat model producing it. The connection is neither read nor closed, and must not be written
+// concurrently, so that it can serve several streams in turn.
+func StreamWebSocket(ctx context.Context, conn Conn, sr *schema.StreamReader[*schema.Message], config *Config) error {
+	return stream(ctx, &wsSink{conn: conn}, sr, config)
+}
+
+type wsSink struct {
+	conn Conn
+}
+
+func (w *wsSink) data(data []byte) error {
+	if err := w.conn.SetWriteDeadline(time.Now().Add(defaultWriteTimeout)); err != nil {
+		return err
+	}
+	return w.conn.WriteMessage(textMessage, data)
+}
+
+func (w *wsSink) error(data []byte) error {
+	return w.data(data)
+}
+
+func (w *wsSink) heartbeat() error {
+	return w.conn.WriteControl(pingMessage, nil, time.Now().Add(defaultWriteTimeout))
+}
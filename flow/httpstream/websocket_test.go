@@ -0,0 +1,79 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package httpstream
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamWebSocket(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer conn.Close()
+
+		sr, sw := schema.Pipe[*schema.Message](0)
+		go func() {
+			defer sw.Close()
+			for _, c := range chunks() {
+				time.Sleep(20 * time.Millisecond)
+				sw.Send(c, nil)
+			}
+		}()
+		assert.NoError(t, StreamWebSocket(context.Background(), conn, sr,
+			&Config{Format: FormatOpenAI, HeartbeatInterval: 10 * time.Millisecond}))
+	}))
+	defer srv.Close()
+
+	pings := 0
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	conn.SetPingHandler(func(string) error {
+		pings++
+		return nil
+	})
+
+	var frames []string
+	for {
+		typ, data, err := conn.ReadMessage()
+		require.NoError(t, err)
+		assert.Equal(t, websocket.TextMessage, typ)
+		frames = append(frames, string(data))
+		if string(data) == DoneData {
+			break
+		}
+	}
+	require.Len(t, frames, 5)
+	c := &OpenAIChunk{}
+	require.NoError(t, json.Unmarshal([]byte(frames[0]), c))
+	assert.Equal(t, "Hello", c.Choices[0].Delta.Content)
+	assert.Greater(t, pings, 0)
+}
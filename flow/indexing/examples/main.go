@@ -0,0 +1,76 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/indexer"
+
+	"github.com/cloudwego/eino-ext/flow/indexing"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// the components of the pipeline
+	var (
+		loader   document.Loader
+		splitter document.Transformer
+		idx      indexer.Indexer
+	)
+	// loader, err := file.NewFileLoader(ctx, &file.FileLoaderConfig{})
+	// splitter, err := recursive.NewSplitter(ctx, &recursive.Config{ChunkSize: 1000, OverlapSize: 100})
+	// idx, err := redis.NewIndexer(ctx, &redis.IndexerConfig{...})
+	// ...
+
+	records, err := indexing.NewFileRecordStore("./records.json")
+	if err != nil {
+		log.Fatalf("Failed to open records: %v", err)
+	}
+
+	p, err := indexing.NewPipeline(ctx, &indexing.Config{
+		Loader:          loader,
+		Transformers:    []document.Transformer{splitter},
+		Indexer:         idx,
+		Concurrency:     4,
+		BatchSize:       64,
+		RecordStore:     records,
+		ContinueOnError: true,
+	})
+	if err != nil {
+		log.Fatalf("Failed to create pipeline: %v", err)
+	}
+
+	res, err := p.Run(ctx, []document.Source{
+		{URI: "./docs/install.md"},
+		{URI: "./docs/faq.md"},
+	})
+	if err != nil {
+		log.Fatalf("Failed to index: %v", err)
+	}
+
+	fmt.Printf("indexed: %d, skipped: %d, failed: %d\n", res.Indexed, res.Skipped, res.Failed)
+	for stage, m := range res.Stages {
+		fmt.Printf("%s: %d calls, %d documents, %v\n", stage, m.Calls, m.Documents, m.Duration)
+	}
+	for _, err := range res.Errors {
+		fmt.Println(err)
+	}
+}
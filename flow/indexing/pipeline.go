@@ -0,0 +1,332 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package indexing provides a pipeline wiring a loader, transformers and an indexer together,
+// which loads the sources concurrently, and skips the sources unchanged since they were last indexed.
+package indexing
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+)
+
+// Stage is a step of the pipeline.
+type Stage string
+
+const (
+	StageLoad      Stage = "load"
+	StageTransform Stage = "transform"
+	StageStore     Stage = "store"
+	StageDelete    Stage = "delete"
+)
+
+type Config struct {
+	// Loader loads the documents of a source, parsing them with its parser. Required.
+	Loader document.Loader
+	// Transformers transform the loaded documents in order, e.g. a splitter then a metadata extractor.
+	// Optional.
+	Transformers []document.Transformer
+	// Embedding is passed to the indexer with indexer.WithEmbedding, for the indexers embedding the documents themselves.
+	// Optional. Default the embedding of the indexer's config.
+	Embedding embedding.Embedder
+	// Indexer stores the transformed documents. Required.
+	Indexer indexer.Indexer
+	// Concurrency is the number of sources processed at the same time.
+	// Optional. Default 1.
+	Concurrency int
+	// BatchSize is the maximum number of documents stored by each call to the indexer.
+	// Optional. Default 0, all the documents of a source are stored at once.
+	BatchSize int
+	// RecordStore enables the incremental mode: the sources whose loaded documents did not change since
+	// they were last indexed are skipped, and the records are saved as soon as each source is indexed.
+	// Optional. Default nil, all the sources are indexed.
+	RecordStore RecordStore
+	// Delete deletes the documents of the previous version of a changed source which are not stored anymore,
+	// e.g. with the delete API of the vector store. It is only used in incremental mode.
+	// Optional. Default nil, the stale documents are kept.
+	Delete func(ctx context.Context, ids []string) error
+	// ContinueOnError continues with the other sources when a source fails, the errors being collected in the result.
+	// Optional. Default false, the first error stops the run.
+	ContinueOnError bool
+}
+
+// StageMetrics are the metrics of a stage, summed over the sources.
+type StageMetrics struct {
+	// Calls is the number of calls of the stage's components.
+	Calls int
+	// Errors is the number of failed calls.
+	Errors int
+	// Documents is the number of documents the stage output: loaded, transformed, stored or deleted.
+	Documents int
+	// Duration is the total duration of the calls, which exceeds the wall time when the sources are processed concurrently.
+	Duration time.Duration
+}
+
+// Result is the result of a run of the pipeline.
+type Result struct {
+	// Indexed is the number of sources indexed.
+	Indexed int
+	// Skipped is the number of sources skipped as unchanged.
+	Skipped int
+	// Failed is the number of sources which failed.
+	Failed int
+	// Stages are the metrics of each stage.
+	Stages map[Stage]*StageMetrics
+	// Errors are the errors of the failed sources, when ContinueOnError is set.
+	Errors []error
+}
+
+// Pipeline loads, transforms and indexes sources.
+type Pipeline struct {
+	conf *Config
+}
+
+// NewPipeline creates a new indexing pipeline.
+func NewPipeline(ctx context.Context, config *Config) (*Pipeline, error) {
+	if config == nil {
+		return nil, errors.New("config is required")
+	}
+	if config.Loader == nil {
+		return nil, errors.New("loader is required")
+	}
+	if config.Indexer == nil {
+		return nil, errors.New("indexer is required")
+	}
+	if config.BatchSize < 0 {
+		return nil, errors.New("batch size must not be negative")
+	}
+
+	return &Pipeline{conf: config}, nil
+}
+
+// Run indexes the sources. The returned result has the metrics of the run even when an error is returned.
+func (p *Pipeline) Run(ctx context.Context, sources []document.Source) (*Result, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	r := &run{
+		Pipeline: p,
+		result: &Result{Stages: map[Stage]*StageMetrics{
+			StageLoad:      {},
+			StageTransform: {},
+			StageStore:     {},
+			StageDelete:    {},
+		}},
+	}
+
+	var (
+		wg       sync.WaitGroup
+		firstErr error
+		once     sync.Once
+	)
+	sem := make(chan struct{}, max(p.conf.Concurrency, 1))
+	for _, src := range sources {
+		sem <- struct{}{}
+		if ctx.Err() != nil {
+			<-sem
+			break
+		}
+
+		wg.Add(1)
+		go func(src document.Source) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			skipped, err := r.index(ctx, src)
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			switch {
+			case err != nil:
+				r.result.Failed++
+				err = fmt.Errorf("index source failed: %w, source= %s", err, src.URI)
+				if p.conf.ContinueOnError {
+					r.result.Errors = append(r.result.Errors, err)
+					return
+				}
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			case skipped:
+				r.result.Skipped++
+			default:
+				r.result.Indexed++
+			}
+		}(src)
+	}
+	wg.Wait()
+
+	if firstErr == nil {
+		// the parent context was canceled
+		firstErr = ctx.Err()
+	}
+
+	return r.result, firstErr
+}
+
+// run is a run of the pipeline, collecting the metrics.
+type run struct {
+	*Pipeline
+	mu     sync.Mutex
+	result *Result
+}
+
+// index indexes the source, and returns whether it was skipped as unchanged.
+func (r *run) index(ctx context.Context, src document.Source) (bool, error) {
+	var docs []*schema.Document
+	err := r.measure(StageLoad, func() (n int, err error) {
+		docs, err = r.conf.Loader.Load(ctx, src)
+		return len(docs), err
+	})
+	if err != nil {
+		return false, fmt.Errorf("load failed: %w", err)
+	}
+
+	var (
+		prev *Record
+		hash string
+	)
+	if r.conf.RecordStore != nil {
+		hash = contentHash(docs)
+		if prev, err = r.conf.RecordStore.Get(ctx, src.URI); err != nil {
+			return false, fmt.Errorf("get record failed: %w", err)
+		}
+		if prev != nil && prev.ContentHash == hash {
+			return true, nil
+		}
+	}
+
+	for _, t := range r.conf.Transformers {
+		err = r.measure(StageTransform, func() (n int, err error) {
+			docs, err = t.Transform(ctx, docs)
+			return len(docs), err
+		})
+		if err != nil {
+			return false, fmt.Errorf("transform failed: %w", err)
+		}
+	}
+
+	var opts []indexer.Option
+	if r.conf.Embedding != nil {
+		opts = append(opts, indexer.WithEmbedding(r.conf.Embedding))
+	}
+	ids := make([]string, 0, len(docs))
+	for _, batch := range batches(docs, r.conf.BatchSize) {
+		err = r.measure(StageStore, func() (n int, err error) {
+			stored, err := r.conf.Indexer.Store(ctx, batch, opts...)
+			ids = append(ids, stored...)
+			return len(stored), err
+		})
+		if err != nil {
+			return false, fmt.Errorf("store failed: %w", err)
+		}
+	}
+
+	if r.conf.RecordStore == nil {
+		return false, nil
+	}
+
+	if prev != nil && r.conf.Delete != nil {
+		stored := make(map[string]bool, len(ids))
+		for _, id := range ids {
+			stored[id] = true
+		}
+		var stale []string
+		for _, id := range prev.IDs {
+			if !stored[id] {
+				stale = append(stale, id)
+			}
+		}
+		if len(stale) > 0 {
+			err = r.measure(StageDelete, func() (int, error) {
+				return len(stale), r.conf.Delete(ctx, stale)
+			})
+			if err != nil {
+				return false, fmt.Errorf("delete stale documents failed: %w", err)
+			}
+		}
+	}
+
+	err = r.conf.RecordStore.Put(ctx, &Record{
+		Source:      src.URI,
+		ContentHash: hash,
+		IDs:         ids,
+		IndexedAt:   time.Now(),
+	})
+	if err != nil {
+		return false, fmt.Errorf("put record failed: %w", err)
+	}
+
+	return false, nil
+}
+
+// measure calls fn and adds its duration, result and error to the metrics of the stage.
+func (r *run) measure(stage Stage, fn func() (int, error)) error {
+	start := time.Now()
+	n, err := fn()
+	duration := time.Since(start)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m := r.result.Stages[stage]
+	m.Calls++
+	m.Duration += duration
+	if err != nil {
+		m.Errors++
+		return err
+	}
+	m.Documents += n
+
+	return nil
+}
+
+// contentHash is the hash of the IDs and contents of the loaded documents.
+func contentHash(docs []*schema.Document) string {
+	h := sha256.New()
+	for _, doc := range docs {
+		h.Write([]byte(doc.ID))
+		h.Write([]byte{0})
+		h.Write([]byte(doc.Content))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func batches(docs []*schema.Document, size int) [][]*schema.Document {
+	if len(docs) == 0 {
+		return nil
+	}
+	if size <= 0 || len(docs) <= size {
+		return [][]*schema.Document{docs}
+	}
+
+	ret := make([][]*schema.Document, 0, (len(docs)+size-1)/size)
+	for i := 0; i < len(docs); i += size {
+		ret = append(ret, docs[i:min(i+size, len(docs))])
+	}
+	return ret
+}
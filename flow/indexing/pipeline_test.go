@@ -0,0 +1,226 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package indexing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockLoader struct {
+	mu    sync.Mutex
+	files map[string]string
+}
+
+func (m *mockLoader) Load(ctx context.Context, src document.Source, opts ...document.LoaderOption) ([]*schema.Document, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	content, ok := m.files[src.URI]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return []*schema.Document{{ID: src.URI, Content: content}}, nil
+}
+
+// lineSplitter splits the documents into lines, with the line number in the ID.
+type lineSplitter struct{}
+
+func (lineSplitter) Transform(ctx context.Context, src []*schema.Document, opts ...document.TransformerOption) ([]*schema.Document, error) {
+	var ret []*schema.Document
+	for _, doc := range src {
+		for i, line := range strings.Split(doc.Content, "\n") {
+			ret = append(ret, &schema.Document{ID: fmt.Sprintf("%s#%d", doc.ID, i), Content: line})
+		}
+	}
+	return ret, nil
+}
+
+type mockIndexer struct {
+	mu     sync.Mutex
+	docs   map[string]string
+	calls  int
+	hasEmb bool
+}
+
+func (m *mockIndexer) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls++
+	m.hasEmb = indexer.GetCommonOptions(nil, opts...).Embedding != nil
+	var ids []string
+	for _, doc := range docs {
+		m.docs[doc.ID] = doc.Content
+		ids = append(ids, doc.ID)
+	}
+	return ids, nil
+}
+
+func (m *mockIndexer) delete(ctx context.Context, ids []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, id := range ids {
+		delete(m.docs, id)
+	}
+	return nil
+}
+
+type mockEmbedder struct{}
+
+func (mockEmbedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	return nil, nil
+}
+
+func sources(uris ...string) []document.Source {
+	var ret []document.Source
+	for _, uri := range uris {
+		ret = append(ret, document.Source{URI: uri})
+	}
+	return ret
+}
+
+func TestNewPipeline(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewPipeline(ctx, nil)
+	assert.EqualError(t, err, "config is required")
+	_, err = NewPipeline(ctx, &Config{Indexer: &mockIndexer{}})
+	assert.EqualError(t, err, "loader is required")
+	_, err = NewPipeline(ctx, &Config{Loader: &mockLoader{}})
+	assert.EqualError(t, err, "indexer is required")
+}
+
+func TestPipeline_Run(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("full", func(t *testing.T) {
+		loader := &mockLoader{files: map[string]string{"a.md": "a1\na2\na3", "b.md": "b1"}}
+		idx := &mockIndexer{docs: map[string]string{}}
+		p, err := NewPipeline(ctx, &Config{
+			Loader:       loader,
+			Transformers: []document.Transformer{lineSplitter{}},
+			Embedding:    mockEmbedder{},
+			Indexer:      idx,
+			Concurrency:  2,
+			BatchSize:    2,
+		})
+		assert.NoError(t, err)
+
+		res, err := p.Run(ctx, sources("a.md", "b.md"))
+		assert.NoError(t, err)
+		assert.Equal(t, 2, res.Indexed)
+		assert.Equal(t, 0, res.Skipped)
+		assert.Equal(t, map[string]string{"a.md#0": "a1", "a.md#1": "a2", "a.md#2": "a3", "b.md#0": "b1"}, idx.docs)
+		assert.Equal(t, 3, idx.calls)
+		assert.True(t, idx.hasEmb)
+
+		assert.Equal(t, 2, res.Stages[StageLoad].Calls)
+		assert.Equal(t, 2, res.Stages[StageLoad].Documents)
+		assert.Equal(t, 2, res.Stages[StageTransform].Calls)
+		assert.Equal(t, 4, res.Stages[StageTransform].Documents)
+		assert.Equal(t, 3, res.Stages[StageStore].Calls)
+		assert.Equal(t, 4, res.Stages[StageStore].Documents)
+		assert.Equal(t, 0, res.Stages[StageDelete].Calls)
+
+		// without a record store, the sources are indexed again
+		res, err = p.Run(ctx, sources("a.md", "b.md"))
+		assert.NoError(t, err)
+		assert.Equal(t, 2, res.Indexed)
+	})
+
+	t.Run("incremental", func(t *testing.T) {
+		loader := &mockLoader{files: map[string]string{"a.md": "a1\na2\na3", "b.md": "b1"}}
+		idx := &mockIndexer{docs: map[string]string{}}
+		store, err := NewFileRecordStore(filepath.Join(t.TempDir(), "records.json"))
+		assert.NoError(t, err)
+		p, err := NewPipeline(ctx, &Config{
+			Loader:       loader,
+			Transformers: []document.Transformer{lineSplitter{}},
+			Indexer:      idx,
+			RecordStore:  store,
+			Delete:       idx.delete,
+		})
+		assert.NoError(t, err)
+
+		res, err := p.Run(ctx, sources("a.md", "b.md"))
+		assert.NoError(t, err)
+		assert.Equal(t, 2, res.Indexed)
+		record, err := store.Get(ctx, "a.md")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a.md#0", "a.md#1", "a.md#2"}, record.IDs)
+
+		loader.files["a.md"] = "a1\nA2"
+		res, err = p.Run(ctx, sources("a.md", "b.md"))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, res.Indexed)
+		assert.Equal(t, 1, res.Skipped)
+		assert.Equal(t, 1, res.Stages[StageDelete].Calls)
+		assert.Equal(t, 1, res.Stages[StageDelete].Documents)
+		assert.Equal(t, map[string]string{"a.md#0": "a1", "a.md#1": "A2", "b.md#0": "b1"}, idx.docs)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		loader := &mockLoader{files: map[string]string{"a.md": "a1"}}
+		idx := &mockIndexer{docs: map[string]string{}}
+		p, err := NewPipeline(ctx, &Config{Loader: loader, Indexer: idx})
+		assert.NoError(t, err)
+
+		res, err := p.Run(ctx, sources("missing.md", "a.md"))
+		assert.EqualError(t, err, "index source failed: load failed: not found, source= missing.md")
+		assert.Equal(t, 1, res.Failed)
+		assert.Equal(t, 0, res.Indexed)
+		assert.Equal(t, 1, res.Stages[StageLoad].Errors)
+
+		p, err = NewPipeline(ctx, &Config{Loader: loader, Indexer: idx, ContinueOnError: true})
+		assert.NoError(t, err)
+
+		res, err = p.Run(ctx, sources("missing.md", "a.md"))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, res.Failed)
+		assert.Equal(t, 1, res.Indexed)
+		assert.Equal(t, 1, len(res.Errors))
+	})
+}
+
+func TestFileRecordStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "records.json")
+
+	store, err := NewFileRecordStore(path)
+	assert.NoError(t, err)
+	record, err := store.Get(ctx, "a.md")
+	assert.NoError(t, err)
+	assert.Nil(t, record)
+	assert.NoError(t, store.Put(ctx, &Record{Source: "a.md", ContentHash: "h", IDs: []string{"1"}}))
+
+	// reopened, e.g. after the process was interrupted
+	store, err = NewFileRecordStore(path)
+	assert.NoError(t, err)
+	record, err = store.Get(ctx, "a.md")
+	assert.NoError(t, err)
+	assert.Equal(t, "h", record.ContentHash)
+	assert.Equal(t, []string{"1"}, record.IDs)
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package indexing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Record is what the pipeline remembers of an indexed source, to skip it while it is unchanged.
+type Record struct {
+	// Source is the URI of the source.
+	Source string `json:"source"`
+	// ContentHash is the hash of the documents loaded from the source.
+	ContentHash string `json:"content_hash"`
+	// IDs are the IDs of the documents stored by the indexer.
+	IDs []string `json:"ids"`
+	// IndexedAt is when the source was indexed.
+	IndexedAt time.Time `json:"indexed_at"`
+}
+
+// RecordStore stores the records of the indexed sources, e.g. in a database table or a Redis hash.
+// Its implementations must be safe for concurrent use.
+type RecordStore interface {
+	// Get returns the record of the source, or nil if the source was never indexed.
+	Get(ctx context.Context, source string) (*Record, error)
+	// Put creates or replaces the record of the source.
+	Put(ctx context.Context, record *Record) error
+}
+
+// NewMemoryRecordStore creates a record store keeping the records in memory, e.g. for a long-running process
+// re-indexing the same sources periodically.
+func NewMemoryRecordStore() RecordStore {
+	return &memoryRecordStore{records: map[string]*Record{}}
+}
+
+type memoryRecordStore struct {
+	mu      sync.RWMutex
+	records map[string]*Record
+}
+
+func (m *memoryRecordStore) Get(ctx context.Context, source string) (*Record, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.records[source], nil
+}
+
+func (m *memoryRecordStore) Put(ctx context.Context, record *Record) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.records[record.Source] = record
+	return nil
+}
+
+// NewFileRecordStore creates a record store keeping the records in a JSON file, which is rewritten after each put.
+// It checkpoints the progress of a pipeline: when a run is interrupted, the next run skips the sources already indexed.
+func NewFileRecordStore(path string) (RecordStore, error) {
+	s := &fileRecordStore{path: path, records: map[string]*Record{}}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read record file failed: %w", err)
+	}
+	if err = json.Unmarshal(data, &s.records); err != nil {
+		return nil, fmt.Errorf("unmarshal record file failed: %w", err)
+	}
+
+	return s, nil
+}
+
+type fileRecordStore struct {
+	mu      sync.RWMutex
+	path    string
+	records map[string]*Record
+}
+
+func (f *fileRecordStore) Get(ctx context.Context, source string) (*Record, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.records[source], nil
+}
+
+func (f *fileRecordStore) Put(ctx context.Context, record *Record) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.records[record.Source] = record
+	data, err := json.MarshalIndent(f.records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal records failed: %w", err)
+	}
+
+	// write to a temporary file first, so that an interrupted write does not lose the previous records
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*")
+	if err != nil {
+		return fmt.Errorf("create record file failed: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write record file failed: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("write record file failed: %w", err)
+	}
+	if err = os.Rename(tmp.Name(), f.path); err != nil {
+		return fmt.Errorf("replace record file failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+type options struct {
+	SessionID string
+}
+
+// WithSessionID sets the session of the call to a chat model wrapped with NewChatModel,
+// the calls without session ID are passed through without memory.
+func WithSessionID(sessionID string) model.Option {
+	return model.WrapImplSpecificOptFn(func(o *options) {
+		o.SessionID = sessionID
+	})
+}
+
+// NewChatModel wraps cm so that the history of the session is put ahead of the input,
+// after its leading system messages, and the input and output of each call are saved to the memory.
+// The system messages of the input are not saved.
+func NewChatModel(cm model.BaseChatModel, m Memory) (model.ToolCallingChatModel, error) {
+	if cm == nil {
+		return nil, errors.New("chat model is required")
+	}
+	if m == nil {
+		return nil, errors.New("memory is required")
+	}
+	return &chatModel{cm: cm, memory: m}, nil
+}
+
+type chatModel struct {
+	cm     model.BaseChatModel
+	memory Memory
+}
+
+func (c *chatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	sessionID := model.GetImplSpecificOptions(&options{}, opts...).SessionID
+	if sessionID == "" {
+		return c.cm.Generate(ctx, input, opts...)
+	}
+
+	full, turn, err := c.withHistory(ctx, sessionID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := c.cm.Generate(ctx, full, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.memory.Save(ctx, sessionID, append(turn, out)...); err != nil {
+		return nil, fmt.Errorf("save memory fail: %w", err)
+	}
+	return out, nil
+}
+
+func (c *chatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	sessionID := model.GetImplSpecificOptions(&options{}, opts...).SessionID
+	if sessionID == "" {
+		return c.cm.Stream(ctx, input, opts...)
+	}
+
+	full, turn, err := c.withHistory(ctx, sessionID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	sr, err := c.cm.Stream(ctx, full, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// the turn is saved once the output is fully received, before the reader gets io.EOF
+	outSR, outSW := schema.Pipe[*schema.Message](1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				outSW.Send(nil, fmt.Errorf("panic in memory chat model stream: %v", p))
+			}
+			sr.Close()
+			outSW.Close()
+		}()
+
+		var chunks []*schema.Message
+		for {
+			chunk, err := sr.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				outSW.Send(nil, err)
+				return
+			}
+			chunks = append(chunks, chunk)
+			if closed := outSW.Send(chunk, nil); closed {
+				return
+			}
+		}
+
+		out, err := schema.ConcatMessages(chunks)
+		if err != nil {
+			outSW.Send(nil, fmt.Errorf("concat stream output fail: %w", err))
+			return
+		}
+		if err = c.memory.Save(ctx, sessionID, append(turn, out)...); err != nil {
+			outSW.Send(nil, fmt.Errorf("save memory fail: %w", err))
+		}
+	}()
+
+	return outSR, nil
+}
+
+func (c *chatModel) WithTools(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	tcm, ok := c.cm.(model.ToolCallingChatModel)
+	if !ok {
+		return nil, errors.New("chat model does not implement ToolCallingChatModel")
+	}
+	withTools, err := tcm.WithTools(tools)
+	if err != nil {
+		return nil, err
+	}
+	return &chatModel{cm: withTools, memory: c.memory}, nil
+}
+
+// IsCallbacksEnabled avoids duplicated callbacks, those of the wrapped chat model being kept.
+func (c *chatModel) IsCallbacksEnabled() bool {
+	return true
+}
+
+// withHistory returns the input with the history inserted after its leading system messages,
+// and the messages of the turn to save.
+func (c *chatModel) withHistory(ctx context.Context, sessionID string, input []*schema.Message) ([]*schema.Message, []*schema.Message, error) {
+	history, err := c.memory.Load(ctx, sessionID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load memory fail: %w", err)
+	}
+
+	i := 0
+	for i < len(input) && input[i].Role == schema.System {
+		i++
+	}
+
+	full := make([]*schema.Message, 0, len(input)+len(history))
+	full = append(full, input[:i]...)
+	full = append(full, history...)
+	full = append(full, input[i:]...)
+
+	turn := make([]*schema.Message, 0, len(input)-i+1)
+	turn = append(turn, input[i:]...)
+	return full, turn, nil
+}
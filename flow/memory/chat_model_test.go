@@ -0,0 +1,84 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChatModel(t *testing.T) {
+	ctx := context.Background()
+	inner := &fakeChatModel{reply: "nice to meet you"}
+	mem, err := NewWindowMemory(&WindowConfig{Size: 10})
+	require.NoError(t, err)
+
+	_, err = NewChatModel(nil, mem)
+	assert.Error(t, err)
+	cm, err := NewChatModel(inner, mem)
+	require.NoError(t, err)
+
+	system := schema.SystemMessage("be kind")
+	out, err := cm.Generate(ctx, []*schema.Message{system, schema.UserMessage("I am bob")}, WithSessionID("s"))
+	require.NoError(t, err)
+	assert.Equal(t, "nice to meet you", out.Content)
+
+	t.Run("history is put after the system messages", func(t *testing.T) {
+		_, err = cm.Generate(ctx, []*schema.Message{system, schema.UserMessage("who am I?")}, WithSessionID("s"))
+		require.NoError(t, err)
+		assert.Equal(t, []*schema.Message{
+			system,
+			schema.UserMessage("I am bob"),
+			schema.AssistantMessage("nice to meet you", nil),
+			schema.UserMessage("who am I?"),
+		}, inner.lastInput())
+	})
+
+	t.Run("stream saves the concatenated output", func(t *testing.T) {
+		inner.reply = "you are bob"
+		sr, err := cm.Stream(ctx, []*schema.Message{schema.UserMessage("again?")}, WithSessionID("s"))
+		require.NoError(t, err)
+		chunks, err := readAll(sr)
+		require.NoError(t, err)
+		assert.Len(t, chunks, 3)
+
+		msgs, err := mem.Load(ctx, "s")
+		require.NoError(t, err)
+		require.Len(t, msgs, 6)
+		assert.Equal(t, "you are bob", msgs[5].Content)
+	})
+
+	t.Run("without session id", func(t *testing.T) {
+		_, err = cm.Generate(ctx, []*schema.Message{schema.UserMessage("hi")})
+		require.NoError(t, err)
+		assert.Len(t, inner.lastInput(), 1)
+		msgs, _ := mem.Load(ctx, "s")
+		assert.Len(t, msgs, 6)
+	})
+
+	t.Run("with tools", func(t *testing.T) {
+		withTools, err := cm.WithTools([]*schema.ToolInfo{{Name: "weather"}})
+		require.NoError(t, err)
+		_, err = withTools.Generate(ctx, []*schema.Message{schema.UserMessage("hi")}, WithSessionID("s"))
+		require.NoError(t, err)
+		msgs, _ := mem.Load(ctx, "s")
+		assert.Len(t, msgs, 8)
+	})
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/flow/memory"
+)
+
+func main() {
+	ctx := context.Background()
+
+	mem, err := memory.NewWindowMemory(&memory.WindowConfig{Size: 10})
+	if err != nil {
+		log.Fatalf("Failed to create memory: %v", err)
+	}
+
+	// replace with any chat model, e.g. openai or ark
+	cm, err := memory.NewChatModel(&echoModel{}, mem)
+	if err != nil {
+		log.Fatalf("Failed to create chat model: %v", err)
+	}
+
+	for _, question := range []string{"My name is Bob.", "What is my name?"} {
+		out, err := cm.Generate(ctx, []*schema.Message{
+			schema.SystemMessage("You are a helpful assistant."),
+			schema.UserMessage(question),
+		}, memory.WithSessionID("bob"))
+		if err != nil {
+			log.Fatalf("Failed to generate: %v", err)
+		}
+		fmt.Println(out.Content)
+	}
+}
+
+// echoModel replies with the number of messages it received.
+type echoModel struct{}
+
+func (e *echoModel) Generate(_ context.Context, input []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	return schema.AssistantMessage(fmt.Sprintf("received %d messages", len(input)), nil), nil
+}
+
+func (e *echoModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	out, err := e.Generate(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return schema.StreamReaderFromArray([]*schema.Message{out}), nil
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package memory
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+// fakeChatModel replies with reply, recording its inputs, and runs onGenerate if set before replying.
+type fakeChatModel struct {
+	mu         sync.Mutex
+	inputs     [][]*schema.Message
+	reply      string
+	err        error
+	tools      []*schema.ToolInfo
+	onGenerate func()
+}
+
+func (f *fakeChatModel) Generate(_ context.Context, input []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.inputs = append(f.inputs, input)
+	if f.onGenerate != nil {
+		f.onGenerate()
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	return schema.AssistantMessage(f.reply, nil), nil
+}
+
+func (f *fakeChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	out, err := f.Generate(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	words := strings.SplitAfter(out.Content, " ")
+	chunks := make([]*schema.Message, 0, len(words))
+	for _, w := range words {
+		chunks = append(chunks, schema.AssistantMessage(w, nil))
+	}
+	return schema.StreamReaderFromArray(chunks), nil
+}
+
+func (f *fakeChatModel) WithTools(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	return &fakeChatModel{reply: f.reply, err: f.err, tools: tools}, nil
+}
+
+func (f *fakeChatModel) lastInput() []*schema.Message {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.inputs[len(f.inputs)-1]
+}
+
+func readAll(sr *schema.StreamReader[*schema.Message]) ([]*schema.Message, error) {
+	defer sr.Close()
+	var msgs []*schema.Message
+	for {
+		msg, err := sr.Recv()
+		if errors.Is(err, io.EOF) {
+			return msgs, nil
+		}
+		if err != nil {
+			return msgs, err
+		}
+		msgs = append(msgs, msg)
+	}
+}
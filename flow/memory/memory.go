@@ -0,0 +1,59 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package memory provides conversation memories, which keep the chat history of sessions and
+// load the part of it fitting the context of the next chat model call.
+package memory
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// Memory keeps the chat history of sessions.
+type Memory interface {
+	// Load returns the history of the session to put ahead of the new input, oldest first.
+	Load(ctx context.Context, sessionID string) ([]*schema.Message, error)
+	// Save appends the messages of a turn, e.g. the user input and the model output, to the history of the session.
+	Save(ctx context.Context, sessionID string, msgs ...*schema.Message) error
+	// Clear deletes the history of the session.
+	Clear(ctx context.Context, sessionID string) error
+}
+
+// Store persists the messages of sessions, see NewInMemoryStore and the redis sub package.
+type Store interface {
+	// Get returns the messages of the session, oldest first, or an empty slice if the session does not exist.
+	Get(ctx context.Context, sessionID string) ([]*schema.Message, error)
+	// Append adds the messages to the end of the session.
+	Append(ctx context.Context, sessionID string, msgs ...*schema.Message) error
+	// Set replaces the messages of the session.
+	Set(ctx context.Context, sessionID string, msgs []*schema.Message) error
+	// Replace atomically replaces the first len(old) messages of the session with msgs if the session still starts
+	// with old, keeping the messages appended since old was read. It returns false, leaving the session unchanged,
+	// if the session no longer starts with old.
+	Replace(ctx context.Context, sessionID string, old, msgs []*schema.Message) (bool, error)
+	// Clear deletes the session.
+	Clear(ctx context.Context, sessionID string) error
+}
+
+// trimStart drops the leading tool messages of msgs, whose assistant message calling the tools was cut off,
+// chat models rejecting tool messages without the matching tool call.
+func trimStart(msgs []*schema.Message) []*schema.Message {
+	for len(msgs) > 0 && msgs[0].Role == schema.Tool {
+		msgs = msgs[1:]
+	}
+	return msgs
+}
@@ -0,0 +1,135 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func toolTurn() []*schema.Message {
+	return []*schema.Message{
+		schema.UserMessage("weather?"),
+		schema.AssistantMessage("", []schema.ToolCall{{ID: "1", Function: schema.FunctionCall{Name: "weather", Arguments: "{}"}}}),
+		schema.ToolMessage("sunny", "1", schema.WithToolName("weather")),
+		schema.AssistantMessage("It is sunny.", nil),
+	}
+}
+
+func TestInMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	s := NewInMemoryStore()
+
+	msgs, err := s.Get(ctx, "s1")
+	require.NoError(t, err)
+	assert.Empty(t, msgs)
+
+	require.NoError(t, s.Append(ctx, "s1", schema.UserMessage("a"), schema.AssistantMessage("b", nil)))
+	require.NoError(t, s.Append(ctx, "s2", schema.UserMessage("c")))
+	msgs, err = s.Get(ctx, "s1")
+	require.NoError(t, err)
+	assert.Equal(t, []*schema.Message{schema.UserMessage("a"), schema.AssistantMessage("b", nil)}, msgs)
+
+	// the returned slice is a copy
+	msgs[0] = nil
+	msgs, _ = s.Get(ctx, "s1")
+	assert.NotNil(t, msgs[0])
+
+	require.NoError(t, s.Set(ctx, "s1", []*schema.Message{schema.UserMessage("d")}))
+	msgs, _ = s.Get(ctx, "s1")
+	assert.Equal(t, []*schema.Message{schema.UserMessage("d")}, msgs)
+
+	require.NoError(t, s.Append(ctx, "s1", schema.AssistantMessage("e", nil)))
+	ok, err := s.Replace(ctx, "s1", []*schema.Message{schema.UserMessage("d")}, []*schema.Message{schema.SystemMessage("f")})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	msgs, _ = s.Get(ctx, "s1")
+	assert.Equal(t, []*schema.Message{schema.SystemMessage("f"), schema.AssistantMessage("e", nil)}, msgs)
+	ok, err = s.Replace(ctx, "s1", []*schema.Message{schema.UserMessage("d")}, nil)
+	require.NoError(t, err)
+	assert.False(t, ok, "the session no longer starts with old")
+
+	require.NoError(t, s.Clear(ctx, "s1"))
+	msgs, _ = s.Get(ctx, "s1")
+	assert.Empty(t, msgs)
+	msgs, _ = s.Get(ctx, "s2")
+	assert.Len(t, msgs, 1)
+}
+
+func TestWindowMemory(t *testing.T) {
+	ctx := context.Background()
+	_, err := NewWindowMemory(&WindowConfig{})
+	assert.Error(t, err)
+
+	store := NewInMemoryStore()
+	m, err := NewWindowMemory(&WindowConfig{Store: store, Size: 2})
+	require.NoError(t, err)
+
+	require.NoError(t, m.Save(ctx, "s", toolTurn()...))
+	msgs, err := m.Load(ctx, "s")
+	require.NoError(t, err)
+	assert.Equal(t, toolTurn()[3:], msgs, "the tool message whose call is out of the window is dropped")
+
+	all, _ := store.Get(ctx, "s")
+	assert.Len(t, all, 4)
+
+	t.Run("trim", func(t *testing.T) {
+		m, err = NewWindowMemory(&WindowConfig{Store: store, Size: 3, Trim: true})
+		require.NoError(t, err)
+		require.NoError(t, m.Save(ctx, "s", schema.UserMessage("thanks")))
+		all, _ = store.Get(ctx, "s")
+		assert.Equal(t, []*schema.Message{toolTurn()[3], schema.UserMessage("thanks")}, all)
+		msgs, _ = m.Load(ctx, "s")
+		assert.Len(t, msgs, 2)
+	})
+
+	require.NoError(t, m.Clear(ctx, "s"))
+	msgs, _ = m.Load(ctx, "s")
+	assert.Empty(t, msgs)
+}
+
+func TestTokenBufferMemory(t *testing.T) {
+	ctx := context.Background()
+	_, err := NewTokenBufferMemory(&TokenBufferConfig{})
+	assert.Error(t, err)
+
+	countWords := func(_ context.Context, msg *schema.Message) (int, error) {
+		return len(msg.Content), nil
+	}
+	m, err := NewTokenBufferMemory(&TokenBufferConfig{MaxTokens: 5, TokenCounter: countWords})
+	require.NoError(t, err)
+
+	require.NoError(t, m.Save(ctx, "s", schema.UserMessage("aaa"), schema.AssistantMessage("bb", nil)))
+	msgs, err := m.Load(ctx, "s")
+	require.NoError(t, err)
+	assert.Len(t, msgs, 2)
+
+	require.NoError(t, m.Save(ctx, "s", schema.UserMessage("c")))
+	msgs, err = m.Load(ctx, "s")
+	require.NoError(t, err)
+	assert.Equal(t, []*schema.Message{schema.AssistantMessage("bb", nil), schema.UserMessage("c")}, msgs)
+
+	m, err = NewTokenBufferMemory(&TokenBufferConfig{MaxTokens: 1})
+	require.NoError(t, err)
+	require.NoError(t, m.Save(ctx, "s", schema.UserMessage("hello")))
+	msgs, err = m.Load(ctx, "s")
+	require.NoError(t, err)
+	assert.Empty(t, msgs)
+}
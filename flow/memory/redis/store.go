@@ -0,0 +1,217 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/cloudwego/eino/schema"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/cloudwego/eino-ext/flow/memory"
+)
+
+// maxReplaceAttempts is the number of attempts of Replace when the session is written concurrently.
+const maxReplaceAttempts = 3
+
+// Store is a memory.Store keeping each session in a redis list of json encoded messages.
+type Store struct {
+	rdb    redis.UniversalClient
+	prefix string
+	ttl    time.Duration
+	maxLen int64
+}
+
+type Option interface {
+	apply(*Store)
+}
+
+type optionFunc func(*Store)
+
+func (f optionFunc) apply(s *Store) {
+	f(s)
+}
+
+// WithPrefix sets the prefix of the session keys, default "eino:memory:".
+func WithPrefix(prefix string) Option {
+	return optionFunc(func(s *Store) {
+		s.prefix = strings.TrimSuffix(prefix, ":") + ":"
+	})
+}
+
+// WithTTL expires the sessions ttl after their last write, default no expiration.
+func WithTTL(ttl time.Duration) Option {
+	return optionFunc(func(s *Store) {
+		s.ttl = ttl
+	})
+}
+
+// WithMaxLen keeps at most maxLen messages of each session, the oldest ones being dropped, default no limit.
+// Not for the summary memory of the memory package, whose summary is the oldest message.
+func WithMaxLen(maxLen int) Option {
+	return optionFunc(func(s *Store) {
+		s.maxLen = int64(maxLen)
+	})
+}
+
+var _ memory.Store = (*Store)(nil)
+
+func NewStore(rdb redis.UniversalClient, opts ...Option) *Store {
+	s := &Store{
+		rdb:    rdb,
+		prefix: "eino:memory:",
+	}
+	for _, opt := range opts {
+		opt.apply(s)
+	}
+	return s
+}
+
+func (s *Store) Get(ctx context.Context, sessionID string) ([]*schema.Message, error) {
+	values, err := s.rdb.LRange(ctx, s.prefix+sessionID, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	return decode(values)
+}
+
+func (s *Store) Append(ctx context.Context, sessionID string, msgs ...*schema.Message) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	values, err := encode(msgs)
+	if err != nil {
+		return err
+	}
+
+	key := s.prefix + sessionID
+	_, err = s.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.RPush(ctx, key, values...)
+		s.limit(ctx, pipe, key)
+		return nil
+	})
+	return err
+}
+
+func (s *Store) Set(ctx context.Context, sessionID string, msgs []*schema.Message) error {
+	values, err := encode(msgs)
+	if err != nil {
+		return err
+	}
+
+	key := s.prefix + sessionID
+	_, err = s.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		if len(values) > 0 {
+			pipe.RPush(ctx, key, values...)
+			s.limit(ctx, pipe, key)
+		}
+		return nil
+	})
+	return err
+}
+
+// Replace watches the session, so that the messages appended between the comparison with old and the replacement
+// abort the transaction, which is then attempted again.
+func (s *Store) Replace(ctx context.Context, sessionID string, old, msgs []*schema.Message) (bool, error) {
+	values, err := encode(msgs)
+	if err != nil {
+		return false, err
+	}
+	// LPUSH inserts the values one after the other at the head
+	for i, j := 0, len(values)-1; i < j; i, j = i+1, j-1 {
+		values[i], values[j] = values[j], values[i]
+	}
+
+	key := s.prefix + sessionID
+	for i := 0; i < maxReplaceAttempts; i++ {
+		replaced := false
+		err = s.rdb.Watch(ctx, func(tx *redis.Tx) error {
+			if len(old) > 0 {
+				head, err := tx.LRange(ctx, key, 0, int64(len(old))-1).Result()
+				if err != nil {
+					return err
+				}
+				cur, err := decode(head)
+				if err != nil {
+					return err
+				}
+				if !reflect.DeepEqual(cur, old) {
+					return nil
+				}
+			}
+
+			_, err := tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				pipe.LTrim(ctx, key, int64(len(old)), -1)
+				if len(values) > 0 {
+					pipe.LPush(ctx, key, values...)
+				}
+				s.limit(ctx, pipe, key)
+				return nil
+			})
+			replaced = err == nil
+			return err
+		}, key)
+		if !errors.Is(err, redis.TxFailedErr) {
+			return replaced, err
+		}
+	}
+	// written concurrently on every attempt
+	return false, nil
+}
+
+func (s *Store) Clear(ctx context.Context, sessionID string) error {
+	return s.rdb.Del(ctx, s.prefix+sessionID).Err()
+}
+
+func (s *Store) limit(ctx context.Context, pipe redis.Pipeliner, key string) {
+	if s.maxLen > 0 {
+		pipe.LTrim(ctx, key, -s.maxLen, -1)
+	}
+	if s.ttl > 0 {
+		pipe.Expire(ctx, key, s.ttl)
+	}
+}
+
+func decode(values []string) ([]*schema.Message, error) {
+	msgs := make([]*schema.Message, 0, len(values))
+	for _, v := range values {
+		msg := &schema.Message{}
+		if err := sonic.UnmarshalString(v, msg); err != nil {
+			return nil, fmt.Errorf("unmarshal message fail: %w", err)
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+func encode(msgs []*schema.Message) ([]any, error) {
+	values := make([]any, 0, len(msgs))
+	for _, msg := range msgs {
+		data, err := sonic.MarshalString(msg)
+		if err != nil {
+			return nil, fmt.Errorf("marshal message fail: %w", err)
+		}
+		values = append(values, data)
+	}
+	return values, nil
+}
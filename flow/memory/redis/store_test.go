@@ -0,0 +1,181 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/cloudwego/eino/schema"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudwego/eino-ext/flow/memory"
+)
+
+// fakeRedis implements the list commands used by Store on an in-process map.
+type fakeRedis struct {
+	redis.UniversalClient
+	lists   map[string][]string
+	expires map[string]time.Duration
+	err     error
+}
+
+func newFakeRedis() *fakeRedis {
+	return &fakeRedis{lists: map[string][]string{}, expires: map[string]time.Duration{}}
+}
+
+func (f *fakeRedis) LRange(ctx context.Context, key string, _, _ int64) *redis.StringSliceCmd {
+	cmd := redis.NewStringSliceCmd(ctx)
+	cmd.SetVal(f.lists[key])
+	cmd.SetErr(f.err)
+	return cmd
+}
+
+func (f *fakeRedis) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	for _, k := range keys {
+		delete(f.lists, k)
+	}
+	return redis.NewIntCmd(ctx)
+}
+
+func (f *fakeRedis) TxPipelined(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return nil, fn(&fakePipe{f: f})
+}
+
+type fakePipe struct {
+	redis.Pipeliner
+	f *fakeRedis
+}
+
+func (p *fakePipe) RPush(ctx context.Context, key string, values ...any) *redis.IntCmd {
+	for _, v := range values {
+		p.f.lists[key] = append(p.f.lists[key], v.(string))
+	}
+	return redis.NewIntCmd(ctx)
+}
+
+func (p *fakePipe) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	return p.f.Del(ctx, keys...)
+}
+
+func (p *fakePipe) LTrim(ctx context.Context, key string, start, _ int64) *redis.StatusCmd {
+	if l := p.f.lists[key]; int64(len(l)) > -start {
+		p.f.lists[key] = l[int64(len(l))+start:]
+	}
+	return redis.NewStatusCmd(ctx)
+}
+
+func (p *fakePipe) Expire(ctx context.Context, key string, expiration time.Duration) *redis.BoolCmd {
+	p.f.expires[key] = expiration
+	return redis.NewBoolCmd(ctx)
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	rdb := newFakeRedis()
+	s := NewStore(rdb, WithPrefix("chat"), WithTTL(time.Hour), WithMaxLen(3))
+
+	msgs, err := s.Get(ctx, "s")
+	require.NoError(t, err)
+	assert.Empty(t, msgs)
+
+	call := schema.AssistantMessage("", []schema.ToolCall{{ID: "1", Function: schema.FunctionCall{Name: "weather", Arguments: "{}"}}})
+	require.NoError(t, s.Append(ctx, "s", schema.UserMessage("weather?"), call))
+	assert.Len(t, rdb.lists["chat:s"], 2)
+	assert.Equal(t, time.Hour, rdb.expires["chat:s"])
+
+	msgs, err = s.Get(ctx, "s")
+	require.NoError(t, err)
+	assert.Equal(t, []*schema.Message{schema.UserMessage("weather?"), call}, msgs)
+
+	require.NoError(t, s.Append(ctx, "s", schema.ToolMessage("sunny", "1"), schema.AssistantMessage("sunny", nil)))
+	msgs, err = s.Get(ctx, "s")
+	require.NoError(t, err)
+	assert.Len(t, msgs, 3, "trimmed to max len")
+	assert.Equal(t, call, msgs[0])
+
+	require.NoError(t, s.Set(ctx, "s", []*schema.Message{schema.SystemMessage("summary")}))
+	msgs, err = s.Get(ctx, "s")
+	require.NoError(t, err)
+	assert.Equal(t, []*schema.Message{schema.SystemMessage("summary")}, msgs)
+
+	require.NoError(t, s.Clear(ctx, "s"))
+	msgs, err = s.Get(ctx, "s")
+	require.NoError(t, err)
+	assert.Empty(t, msgs)
+
+	t.Run("error", func(t *testing.T) {
+		rdb.err = errors.New("connection refused")
+		_, err = s.Get(ctx, "s")
+		assert.Error(t, err)
+		assert.Error(t, s.Append(ctx, "s", schema.UserMessage("hi")))
+		rdb.err = nil
+
+		rdb.lists["chat:bad"] = []string{"{"}
+		_, err = s.Get(ctx, "bad")
+		assert.Error(t, err)
+	})
+
+	t.Run("with memory", func(t *testing.T) {
+		m, err := memory.NewWindowMemory(&memory.WindowConfig{Store: s, Size: 2})
+		require.NoError(t, err)
+		require.NoError(t, m.Save(ctx, "s2", schema.UserMessage("a"), schema.AssistantMessage("b", nil), schema.UserMessage("c")))
+		msgs, err = m.Load(ctx, "s2")
+		require.NoError(t, err)
+		assert.Equal(t, []*schema.Message{schema.AssistantMessage("b", nil), schema.UserMessage("c")}, msgs)
+	})
+}
+
+func TestStoreReplace(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	s := NewStore(redis.NewClient(&redis.Options{Addr: mr.Addr()}))
+
+	require.NoError(t, s.Append(ctx, "s", schema.UserMessage("a"), schema.AssistantMessage("b", nil)))
+	old, err := s.Get(ctx, "s")
+	require.NoError(t, err)
+	require.NoError(t, s.Append(ctx, "s", schema.UserMessage("c")))
+
+	ok, err := s.Replace(ctx, "s", old, []*schema.Message{schema.SystemMessage("summary"), schema.SystemMessage("more")})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	msgs, err := s.Get(ctx, "s")
+	require.NoError(t, err)
+	assert.Equal(t, []*schema.Message{schema.SystemMessage("summary"), schema.SystemMessage("more"), schema.UserMessage("c")}, msgs,
+		"the messages appended after old are kept")
+
+	ok, err = s.Replace(ctx, "s", old, nil)
+	require.NoError(t, err)
+	assert.False(t, ok, "the session no longer starts with old")
+	msgs, err = s.Get(ctx, "s")
+	require.NoError(t, err)
+	assert.Len(t, msgs, 3)
+
+	ok, err = s.Replace(ctx, "new", nil, []*schema.Message{schema.UserMessage("d")})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	msgs, err = s.Get(ctx, "new")
+	require.NoError(t, err)
+	assert.Equal(t, []*schema.Message{schema.UserMessage("d")}, msgs)
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package memory
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// InMemoryStore is a Store keeping the sessions in process memory, the sessions are lost on restart.
+type InMemoryStore struct {
+	mu       sync.RWMutex
+	sessions map[string][]*schema.Message
+}
+
+var _ Store = (*InMemoryStore)(nil)
+
+func NewInMemoryStore() *InMemoryStore {
+	return &InMemoryStore{sessions: make(map[string][]*schema.Message)}
+}
+
+func (s *InMemoryStore) Get(_ context.Context, sessionID string) ([]*schema.Message, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	msgs := s.sessions[sessionID]
+	return append(make([]*schema.Message, 0, len(msgs)), msgs...), nil
+}
+
+func (s *InMemoryStore) Append(_ context.Context, sessionID string, msgs ...*schema.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions[sessionID] = append(s.sessions[sessionID], msgs...)
+	return nil
+}
+
+func (s *InMemoryStore) Set(_ context.Context, sessionID string, msgs []*schema.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions[sessionID] = append(make([]*schema.Message, 0, len(msgs)), msgs...)
+	return nil
+}
+
+func (s *InMemoryStore) Replace(_ context.Context, sessionID string, old, msgs []*schema.Message) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cur := s.sessions[sessionID]
+	if !hasPrefix(cur, old) {
+		return false, nil
+	}
+	replaced := make([]*schema.Message, 0, len(msgs)+len(cur)-len(old))
+	replaced = append(replaced, msgs...)
+	s.sessions[sessionID] = append(replaced, cur[len(old):]...)
+	return true, nil
+}
+
+func (s *InMemoryStore) Clear(_ context.Context, sessionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.sessions, sessionID)
+	return nil
+}
+
+// hasPrefix reports whether msgs starts with prefix, comparing the messages by value.
+func hasPrefix(msgs, prefix []*schema.Message) bool {
+	if len(msgs) < len(prefix) {
+		return false
+	}
+	for i, msg := range prefix {
+		if !reflect.DeepEqual(msgs[i], msg) {
+			return false
+		}
+	}
+	return true
+}
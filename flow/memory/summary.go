@@ -0,0 +1,228 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+// ExtraKeySummary marks, in the Extra of a message, the message holding the summary of the earlier conversation.
+const ExtraKeySummary = "_eino_memory_summary"
+
+const (
+	defaultSummaryInstruction = "Progressively summarize the conversation, adding to the previous summary and returning a new summary. " +
+		"Keep the facts, decisions and open questions which may be needed to continue the conversation. Reply with the summary only."
+	summaryPrefix = "Summary of the earlier conversation:\n"
+)
+
+type SummaryConfig struct {
+	// Store persists the history.
+	// Optional. Default: NewInMemoryStore()
+	Store Store
+	// ChatModel summarizes the earlier conversation.
+	// Required
+	ChatModel model.BaseChatModel
+	// MaxTokens is the token budget of the history, the earlier messages being summarized once it is exceeded.
+	// Required
+	MaxTokens int
+	// KeepTokens is the token budget of the latest messages kept as they are when summarizing.
+	// Optional. Default: MaxTokens / 2
+	KeepTokens int
+	// Instruction is the system prompt of the summarization.
+	// Optional
+	Instruction string
+	// TokenCounter counts the tokens of a message, e.g. with the tokenizer of the chat model.
+	// Optional. Default: an approximation of 4 bytes per token
+	TokenCounter TokenCounter
+}
+
+// NewSummaryMemory returns a Memory which summarizes the earlier conversation with a chat model once the history
+// exceeds MaxTokens, the history becoming a system message holding the summary followed by the latest messages.
+// The summarization runs in Save. The summary being the first message of the session, the store must not drop
+// the oldest messages itself, e.g. the redis store WithMaxLen.
+func NewSummaryMemory(config *SummaryConfig) (Memory, error) {
+	if config == nil {
+		return nil, errors.New("config is required")
+	}
+	if config.ChatModel == nil {
+		return nil, errors.New("chat model is required")
+	}
+	if config.MaxTokens <= 0 {
+		return nil, errors.New("max tokens must be positive")
+	}
+	if config.KeepTokens < 0 || config.KeepTokens >= config.MaxTokens {
+		return nil, errors.New("keep tokens must be less than max tokens")
+	}
+
+	s := &summaryMemory{
+		store:       config.Store,
+		cm:          config.ChatModel,
+		maxTokens:   config.MaxTokens,
+		keepTokens:  config.KeepTokens,
+		instruction: config.Instruction,
+		counter:     config.TokenCounter,
+	}
+	if s.store == nil {
+		s.store = NewInMemoryStore()
+	}
+	if s.keepTokens == 0 {
+		s.keepTokens = s.maxTokens / 2
+	}
+	if s.instruction == "" {
+		s.instruction = defaultSummaryInstruction
+	}
+	if s.counter == nil {
+		s.counter = defaultTokenCounter
+	}
+
+	return s, nil
+}
+
+type summaryMemory struct {
+	store       Store
+	cm          model.BaseChatModel
+	maxTokens   int
+	keepTokens  int
+	instruction string
+	counter     TokenCounter
+}
+
+func (s *summaryMemory) Load(ctx context.Context, sessionID string) ([]*schema.Message, error) {
+	return s.store.Get(ctx, sessionID)
+}
+
+func (s *summaryMemory) Save(ctx context.Context, sessionID string, msgs ...*schema.Message) error {
+	if err := s.store.Append(ctx, sessionID, msgs...); err != nil {
+		return err
+	}
+
+	all, err := s.store.Get(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	total := 0
+	for _, msg := range all {
+		n, err := s.counter(ctx, msg)
+		if err != nil {
+			return fmt.Errorf("count tokens fail: %w", err)
+		}
+		total += n
+	}
+	if total <= s.maxTokens {
+		return nil
+	}
+
+	var summary string
+	history := all
+	if len(all) > 0 && isSummary(all[0]) {
+		summary = strings.TrimPrefix(all[0].Content, summaryPrefix)
+		all = all[1:]
+	}
+
+	start, err := latestWithin(ctx, all, s.keepTokens, s.counter)
+	if err != nil {
+		return err
+	}
+	// never split the tool calls of an assistant message from their results
+	for start < len(all) && all[start].Role == schema.Tool {
+		start++
+	}
+	if start == 0 {
+		return nil
+	}
+
+	summary, err = s.summarize(ctx, summary, all[:start])
+	if err != nil {
+		return err
+	}
+
+	// only the summarized messages are replaced, those appended meanwhile by a concurrent Save are kept.
+	// The history having been summarized meanwhile, this summary is dropped and the next Save summarizes again.
+	summarized := history[:len(history)-len(all)+start]
+	_, err = s.store.Replace(ctx, sessionID, summarized, []*schema.Message{{
+		Role:    schema.System,
+		Content: summaryPrefix + summary,
+		Extra:   map[string]any{ExtraKeySummary: true},
+	}})
+	return err
+}
+
+func (s *summaryMemory) Clear(ctx context.Context, sessionID string) error {
+	return s.store.Clear(ctx, sessionID)
+}
+
+func (s *summaryMemory) summarize(ctx context.Context, summary string, msgs []*schema.Message) (string, error) {
+	sb := strings.Builder{}
+	if summary != "" {
+		sb.WriteString("Previous summary:\n")
+		sb.WriteString(summary)
+		sb.WriteString("\n\n")
+	}
+	sb.WriteString("New lines of conversation:\n")
+	for _, msg := range msgs {
+		sb.WriteString(formatLine(msg))
+		sb.WriteString("\n")
+	}
+
+	out, err := s.cm.Generate(ctx, []*schema.Message{
+		schema.SystemMessage(s.instruction),
+		schema.UserMessage(sb.String()),
+	})
+	if err != nil {
+		return "", fmt.Errorf("summarize conversation fail: %w", err)
+	}
+	if strings.TrimSpace(out.Content) == "" {
+		return "", errors.New("summarize conversation fail: empty summary")
+	}
+	return strings.TrimSpace(out.Content), nil
+}
+
+func formatLine(msg *schema.Message) string {
+	content := msg.Content
+	if content == "" {
+		texts := make([]string, 0, len(msg.MultiContent))
+		for _, part := range msg.MultiContent {
+			if part.Type == schema.ChatMessagePartTypeText {
+				texts = append(texts, part.Text)
+			}
+		}
+		content = strings.Join(texts, " ")
+	}
+
+	switch {
+	case len(msg.ToolCalls) > 0:
+		calls := make([]string, 0, len(msg.ToolCalls))
+		for _, tc := range msg.ToolCalls {
+			calls = append(calls, fmt.Sprintf("%s(%s)", tc.Function.Name, tc.Function.Arguments))
+		}
+		return fmt.Sprintf("%s: %s [called %s]", msg.Role, content, strings.Join(calls, ", "))
+	case msg.Role == schema.Tool:
+		return fmt.Sprintf("tool %s: %s", msg.ToolName, content)
+	default:
+		return fmt.Sprintf("%s: %s", msg.Role, content)
+	}
+}
+
+func isSummary(msg *schema.Message) bool {
+	v, _ := msg.Extra[ExtraKeySummary].(bool)
+	return v
+}
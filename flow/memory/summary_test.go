@@ -0,0 +1,114 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package memory
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummaryMemory(t *testing.T) {
+	ctx := context.Background()
+	cm := &fakeChatModel{reply: "the user greeted"}
+	countBytes := func(_ context.Context, msg *schema.Message) (int, error) {
+		return len(msg.Content), nil
+	}
+
+	_, err := NewSummaryMemory(&SummaryConfig{MaxTokens: 10})
+	assert.Error(t, err)
+	_, err = NewSummaryMemory(&SummaryConfig{ChatModel: cm, MaxTokens: 10, KeepTokens: 10})
+	assert.Error(t, err)
+
+	m, err := NewSummaryMemory(&SummaryConfig{ChatModel: cm, MaxTokens: 10, KeepTokens: 4, TokenCounter: countBytes})
+	require.NoError(t, err)
+
+	require.NoError(t, m.Save(ctx, "s", schema.UserMessage("hello"), schema.AssistantMessage("hi", nil)))
+	assert.Empty(t, cm.inputs, "under budget")
+
+	require.NoError(t, m.Save(ctx, "s", schema.UserMessage("how"), schema.AssistantMessage("fine", nil)))
+	require.Len(t, cm.inputs, 1)
+	assert.Equal(t, "New lines of conversation:\nuser: hello\nassistant: hi\nuser: how\n", cm.lastInput()[1].Content)
+
+	msgs, err := m.Load(ctx, "s")
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+	assert.Equal(t, schema.System, msgs[0].Role)
+	assert.Equal(t, summaryPrefix+"the user greeted", msgs[0].Content)
+	assert.True(t, isSummary(msgs[0]))
+	assert.Equal(t, schema.AssistantMessage("fine", nil), msgs[1])
+
+	t.Run("the previous summary is extended", func(t *testing.T) {
+		cm.reply = "the user greeted and asked"
+		require.NoError(t, m.Save(ctx, "s", schema.UserMessage("bye"), schema.AssistantMessage("ciao", nil)))
+		require.Len(t, cm.inputs, 2)
+		assert.True(t, strings.HasPrefix(cm.lastInput()[1].Content, "Previous summary:\nthe user greeted\n\n"))
+		msgs, err = m.Load(ctx, "s")
+		require.NoError(t, err)
+		assert.Equal(t, summaryPrefix+"the user greeted and asked", msgs[0].Content)
+		assert.Equal(t, schema.AssistantMessage("ciao", nil), msgs[len(msgs)-1])
+	})
+
+	t.Run("concurrent writes", func(t *testing.T) {
+		store := NewInMemoryStore()
+		cm := &fakeChatModel{reply: "the user greeted"}
+		m, err := NewSummaryMemory(&SummaryConfig{Store: store, ChatModel: cm, MaxTokens: 10, KeepTokens: 4, TokenCounter: countBytes})
+		require.NoError(t, err)
+
+		// the messages appended while summarizing are kept after the summary
+		cm.onGenerate = func() {
+			require.NoError(t, store.Append(ctx, "s", schema.UserMessage("late")))
+		}
+		require.NoError(t, m.Save(ctx, "s", schema.UserMessage("hello"), schema.AssistantMessage("hi"+strings.Repeat("!", 10), nil)))
+		msgs, err := m.Load(ctx, "s")
+		require.NoError(t, err)
+		require.Len(t, msgs, 2)
+		assert.True(t, isSummary(msgs[0]))
+		assert.Equal(t, schema.UserMessage("late"), msgs[1])
+
+		// the summary of a history rewritten meanwhile is dropped
+		rewritten := []*schema.Message{schema.UserMessage("reset")}
+		cm.onGenerate = func() {
+			require.NoError(t, store.Set(ctx, "s", rewritten))
+		}
+		require.NoError(t, m.Save(ctx, "s", schema.UserMessage("more"+strings.Repeat("?", 10))))
+		msgs, err = m.Load(ctx, "s")
+		require.NoError(t, err)
+		assert.Equal(t, rewritten, msgs)
+	})
+
+	t.Run("summarize error", func(t *testing.T) {
+		cm.err = errors.New("unavailable")
+		err = m.Save(ctx, "s", schema.UserMessage("once more please"))
+		assert.ErrorContains(t, err, "summarize conversation fail")
+	})
+}
+
+func TestFormatLine(t *testing.T) {
+	turn := toolTurn()
+	assert.Equal(t, "user: weather?", formatLine(turn[0]))
+	assert.Equal(t, "assistant:  [called weather({})]", formatLine(turn[1]))
+	assert.Equal(t, "tool weather: sunny", formatLine(turn[2]))
+	assert.Equal(t, "user: look", formatLine(&schema.Message{
+		Role:         schema.User,
+		MultiContent: []schema.ChatMessagePart{{Type: schema.ChatMessagePartTypeText, Text: "look"}},
+	}))
+}
@@ -0,0 +1,116 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// TokenCounter returns the number of tokens of msg.
+type TokenCounter func(ctx context.Context, msg *schema.Message) (int, error)
+
+// defaultTokenCounter approximates the tokens of a message with one token every 4 bytes of its text,
+// plus 4 tokens of message overhead.
+func defaultTokenCounter(_ context.Context, msg *schema.Message) (int, error) {
+	n := len(msg.Content) + len(msg.ReasoningContent)
+	for _, part := range msg.MultiContent {
+		n += len(part.Text)
+	}
+	for _, tc := range msg.ToolCalls {
+		n += len(tc.Function.Name) + len(tc.Function.Arguments)
+	}
+	return n/4 + 4, nil
+}
+
+type TokenBufferConfig struct {
+	// Store persists the history.
+	// Optional. Default: NewInMemoryStore()
+	Store Store
+	// MaxTokens is the token budget of the loaded history, the latest messages fitting in it being kept.
+	// Required
+	MaxTokens int
+	// TokenCounter counts the tokens of a message, e.g. with the tokenizer of the chat model.
+	// Optional. Default: an approximation of 4 bytes per token
+	TokenCounter TokenCounter
+}
+
+// NewTokenBufferMemory returns a Memory loading the latest messages of the history whose tokens fit in MaxTokens.
+func NewTokenBufferMemory(config *TokenBufferConfig) (Memory, error) {
+	if config == nil {
+		return nil, errors.New("config is required")
+	}
+	if config.MaxTokens <= 0 {
+		return nil, errors.New("max tokens must be positive")
+	}
+
+	store := config.Store
+	if store == nil {
+		store = NewInMemoryStore()
+	}
+	counter := config.TokenCounter
+	if counter == nil {
+		counter = defaultTokenCounter
+	}
+
+	return &tokenBufferMemory{store: store, maxTokens: config.MaxTokens, counter: counter}, nil
+}
+
+type tokenBufferMemory struct {
+	store     Store
+	maxTokens int
+	counter   TokenCounter
+}
+
+func (t *tokenBufferMemory) Load(ctx context.Context, sessionID string) ([]*schema.Message, error) {
+	msgs, err := t.store.Get(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	start, err := latestWithin(ctx, msgs, t.maxTokens, t.counter)
+	if err != nil {
+		return nil, err
+	}
+	return trimStart(msgs[start:]), nil
+}
+
+func (t *tokenBufferMemory) Save(ctx context.Context, sessionID string, msgs ...*schema.Message) error {
+	return t.store.Append(ctx, sessionID, msgs...)
+}
+
+func (t *tokenBufferMemory) Clear(ctx context.Context, sessionID string) error {
+	return t.store.Clear(ctx, sessionID)
+}
+
+// latestWithin returns the index of the first message of the longest suffix of msgs whose tokens fit in maxTokens.
+func latestWithin(ctx context.Context, msgs []*schema.Message, maxTokens int, counter TokenCounter) (int, error) {
+	total := 0
+	for i := len(msgs) - 1; i >= 0; i-- {
+		n, err := counter(ctx, msgs[i])
+		if err != nil {
+			return 0, fmt.Errorf("count tokens fail: %w", err)
+		}
+		if total+n > maxTokens {
+			return i + 1, nil
+		}
+		total += n
+	}
+	return 0, nil
+}
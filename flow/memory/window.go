@@ -0,0 +1,96 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package memory
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+type WindowConfig struct {
+	// Store persists the history.
+	// Optional. Default: NewInMemoryStore()
+	Store Store
+	// Size is the maximum number of messages loaded, the latest ones being kept.
+	// Required
+	Size int
+	// Trim also deletes the messages out of the window from the store when saving,
+	// instead of keeping the full history in the store.
+	// Optional. Default: false
+	Trim bool
+}
+
+// NewWindowMemory returns a Memory loading the latest Size messages of the history.
+func NewWindowMemory(config *WindowConfig) (Memory, error) {
+	if config == nil {
+		return nil, errors.New("config is required")
+	}
+	if config.Size <= 0 {
+		return nil, errors.New("size must be positive")
+	}
+
+	store := config.Store
+	if store == nil {
+		store = NewInMemoryStore()
+	}
+
+	return &windowMemory{store: store, size: config.Size, trim: config.Trim}, nil
+}
+
+type windowMemory struct {
+	store Store
+	size  int
+	trim  bool
+}
+
+func (w *windowMemory) Load(ctx context.Context, sessionID string) ([]*schema.Message, error) {
+	msgs, err := w.store.Get(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return w.window(msgs), nil
+}
+
+func (w *windowMemory) Save(ctx context.Context, sessionID string, msgs ...*schema.Message) error {
+	if err := w.store.Append(ctx, sessionID, msgs...); err != nil {
+		return err
+	}
+	if !w.trim {
+		return nil
+	}
+
+	all, err := w.store.Get(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if kept := w.window(all); len(kept) < len(all) {
+		return w.store.Set(ctx, sessionID, kept)
+	}
+	return nil
+}
+
+func (w *windowMemory) Clear(ctx context.Context, sessionID string) error {
+	return w.store.Clear(ctx, sessionID)
+}
+
+func (w *windowMemory) window(msgs []*schema.Message) []*schema.Message {
+	if len(msgs) > w.size {
+		msgs = msgs[len(msgs)-w.size:]
+	}
+	return trimStart(msgs)
+}
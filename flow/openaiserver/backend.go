@@ -0,0 +1,107 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package openaiserver
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+)
+
+// Backend answers the chat completions of a model served.
+// tools are the tools of the request, to pass through to the chat model, whose tool calls are returned to the client.
+type Backend interface {
+	Generate(ctx context.Context, input []*schema.Message, tools []*schema.ToolInfo, opts ...model.Option) (*schema.Message, error)
+	Stream(ctx context.Context, input []*schema.Message, tools []*schema.ToolInfo, opts ...model.Option) (*schema.StreamReader[*schema.Message], error)
+}
+
+// NewChatModelBackend serves a chat model, the tools of the requests being bound with WithTools
+// for a ToolCallingChatModel, passed with model.WithTools otherwise.
+func NewChatModelBackend(cm model.BaseChatModel) (Backend, error) {
+	if cm == nil {
+		return nil, errors.New("chat model is required")
+	}
+	return &chatModelBackend{cm: cm}, nil
+}
+
+type chatModelBackend struct {
+	cm model.BaseChatModel
+}
+
+func (c *chatModelBackend) Generate(ctx context.Context, input []*schema.Message, tools []*schema.ToolInfo, opts ...model.Option) (*schema.Message, error) {
+	cm, opts, err := c.withTools(tools, opts)
+	if err != nil {
+		return nil, err
+	}
+	return cm.Generate(ctx, input, opts...)
+}
+
+func (c *chatModelBackend) Stream(ctx context.Context, input []*schema.Message, tools []*schema.ToolInfo, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	cm, opts, err := c.withTools(tools, opts)
+	if err != nil {
+		return nil, err
+	}
+	return cm.Stream(ctx, input, opts...)
+}
+
+func (c *chatModelBackend) withTools(tools []*schema.ToolInfo, opts []model.Option) (model.BaseChatModel, []model.Option, error) {
+	if len(tools) == 0 {
+		return c.cm, opts, nil
+	}
+	if tcm, ok := c.cm.(model.ToolCallingChatModel); ok {
+		withTools, err := tcm.WithTools(tools)
+		if err != nil {
+			return nil, nil, err
+		}
+		return withTools, opts, nil
+	}
+	return c.cm, append(opts, model.WithTools(tools)), nil
+}
+
+// NewRunnableBackend serves a compiled graph, chain or workflow, the options of the requests, including their tools,
+// being passed to all its chat model nodes with compose.WithChatModelOption. opts are added to every call.
+func NewRunnableBackend(r compose.Runnable[[]*schema.Message, *schema.Message], opts ...compose.Option) (Backend, error) {
+	if r == nil {
+		return nil, errors.New("runnable is required")
+	}
+	return &runnableBackend{r: r, opts: opts}, nil
+}
+
+type runnableBackend struct {
+	r    compose.Runnable[[]*schema.Message, *schema.Message]
+	opts []compose.Option
+}
+
+func (r *runnableBackend) Generate(ctx context.Context, input []*schema.Message, tools []*schema.ToolInfo, opts ...model.Option) (*schema.Message, error) {
+	return r.r.Invoke(ctx, input, r.options(tools, opts)...)
+}
+
+func (r *runnableBackend) Stream(ctx context.Context, input []*schema.Message, tools []*schema.ToolInfo, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return r.r.Stream(ctx, input, r.options(tools, opts)...)
+}
+
+func (r *runnableBackend) options(tools []*schema.ToolInfo, opts []model.Option) []compose.Option {
+	if len(tools) > 0 {
+		opts = append(opts, model.WithTools(tools))
+	}
+	if len(opts) == 0 {
+		return r.opts
+	}
+	return append(r.opts[:len(r.opts):len(r.opts)], compose.WithChatModelOption(opts...))
+}
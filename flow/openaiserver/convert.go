@@ -0,0 +1,266 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package openaiserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/eino-contrib/jsonschema"
+
+	"github.com/cloudwego/eino-ext/flow/openaiserver/internal/httpstream"
+)
+
+// toMessages converts the messages of a request.
+func toMessages(msgs []*requestMessage) ([]*schema.Message, error) {
+	if len(msgs) == 0 {
+		return nil, fmt.Errorf("messages is required")
+	}
+	ret := make([]*schema.Message, 0, len(msgs))
+	for i, m := range msgs {
+		if m == nil {
+			return nil, fmt.Errorf("messages[%d] is null", i)
+		}
+		msg := &schema.Message{Name: m.Name}
+		switch m.Role {
+		case "system", "developer":
+			msg.Role = schema.System
+		case "user":
+			msg.Role = schema.User
+		case "assistant":
+			msg.Role = schema.Assistant
+			msg.ToolCalls = toToolCalls(m.ToolCalls)
+		case "tool":
+			msg.Role = schema.Tool
+			msg.ToolCallID = m.ToolCallID
+		default:
+			return nil, fmt.Errorf("messages[%d]: unknown role %q", i, m.Role)
+		}
+
+		if m.Content != nil {
+			msg.Content = m.Content.Text
+			parts, err := toParts(m.Content.Parts)
+			if err != nil {
+				return nil, fmt.Errorf("messages[%d]: %w", i, err)
+			}
+			if msg.Role == schema.User && hasImage(parts) {
+				msg.MultiContent = parts
+			} else {
+				// the text parts are joined, the other roles supporting no images
+				for _, p := range parts {
+					msg.Content += p.Text
+				}
+			}
+		}
+		ret = append(ret, msg)
+	}
+	return ret, nil
+}
+
+func toParts(parts []*contentPart) ([]schema.ChatMessagePart, error) {
+	ret := make([]schema.ChatMessagePart, 0, len(parts))
+	for _, p := range parts {
+		if p == nil {
+			continue
+		}
+		switch p.Type {
+		case "text":
+			ret = append(ret, schema.ChatMessagePart{Type: schema.ChatMessagePartTypeText, Text: p.Text})
+		case "image_url":
+			if p.ImageURL == nil {
+				return nil, fmt.Errorf("image_url is required")
+			}
+			ret = append(ret, schema.ChatMessagePart{
+				Type: schema.ChatMessagePartTypeImageURL,
+				ImageURL: &schema.ChatMessageImageURL{
+					URL:    p.ImageURL.URL,
+					Detail: schema.ImageURLDetail(p.ImageURL.Detail),
+				},
+			})
+		default:
+			return nil, fmt.Errorf("unsupported content part type %q", p.Type)
+		}
+	}
+	return ret, nil
+}
+
+func hasImage(parts []schema.ChatMessagePart) bool {
+	for _, p := range parts {
+		if p.Type != schema.ChatMessagePartTypeText {
+			return true
+		}
+	}
+	return false
+}
+
+func toToolCalls(calls []*httpstream.OpenAIToolCall) []schema.ToolCall {
+	if len(calls) == 0 {
+		return nil
+	}
+	ret := make([]schema.ToolCall, 0, len(calls))
+	for _, c := range calls {
+		if c == nil {
+			continue
+		}
+		typ := c.Type
+		if typ == "" {
+			typ = "function"
+		}
+		ret = append(ret, schema.ToolCall{
+			ID:   c.ID,
+			Type: typ,
+			Function: schema.FunctionCall{
+				Name:      c.Function.Name,
+				Arguments: c.Function.Arguments,
+			},
+		})
+	}
+	return ret
+}
+
+// toTools converts the tools of a request, only keeping the one named by a function tool choice.
+func toTools(tools []*requestTool, choice string) ([]*schema.ToolInfo, error) {
+	ret := make([]*schema.ToolInfo, 0, len(tools))
+	for i, t := range tools {
+		if t == nil || t.Function == nil || t.Function.Name == "" {
+			return nil, fmt.Errorf("tools[%d]: function name is required", i)
+		}
+		if t.Type != "" && t.Type != "function" {
+			return nil, fmt.Errorf("tools[%d]: unsupported tool type %q", i, t.Type)
+		}
+		if choice != "" && t.Function.Name != choice {
+			continue
+		}
+
+		info := &schema.ToolInfo{Name: t.Function.Name, Desc: t.Function.Description}
+		if len(t.Function.Parameters) > 0 && string(t.Function.Parameters) != "null" {
+			s := &jsonschema.Schema{}
+			if err := json.Unmarshal(t.Function.Parameters, s); err != nil {
+				return nil, fmt.Errorf("tools[%d]: invalid parameters: %w", i, err)
+			}
+			info.ParamsOneOf = schema.NewParamsOneOfByJSONSchema(s)
+		}
+		ret = append(ret, info)
+	}
+	if choice != "" && len(ret) == 0 {
+		return nil, fmt.Errorf("tool_choice: unknown function %q", choice)
+	}
+	return ret, nil
+}
+
+// toToolChoice converts the tool choice of a request, returning the name of the function chosen if any.
+func toToolChoice(raw json.RawMessage) (*schema.ToolChoice, string, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, "", nil
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		var choice schema.ToolChoice
+		switch s {
+		case "none":
+			choice = schema.ToolChoiceForbidden
+		case "auto":
+			choice = schema.ToolChoiceAllowed
+		case "required":
+			choice = schema.ToolChoiceForced
+		default:
+			return nil, "", fmt.Errorf("tool_choice: unknown value %q", s)
+		}
+		return &choice, "", nil
+	}
+
+	var named struct {
+		Function struct {
+			Name string `json:"name"`
+		} `json:"function"`
+	}
+	if err := json.Unmarshal(raw, &named); err != nil || named.Function.Name == "" {
+		return nil, "", fmt.Errorf("tool_choice: must be a string or a function")
+	}
+	choice := schema.ToolChoiceForced
+	return &choice, named.Function.Name, nil
+}
+
+// toOptions converts the sampling parameters of a request.
+func toOptions(req *chatCompletionRequest, choice *schema.ToolChoice) []model.Option {
+	var opts []model.Option
+	if req.Temperature != nil {
+		opts = append(opts, model.WithTemperature(*req.Temperature))
+	}
+	if req.TopP != nil {
+		opts = append(opts, model.WithTopP(*req.TopP))
+	}
+	if req.MaxCompletionTokens != nil {
+		opts = append(opts, model.WithMaxTokens(*req.MaxCompletionTokens))
+	} else if req.MaxTokens != nil {
+		opts = append(opts, model.WithMaxTokens(*req.MaxTokens))
+	}
+	if len(req.Stop) > 0 {
+		opts = append(opts, model.WithStop(req.Stop))
+	}
+	if choice != nil {
+		opts = append(opts, model.WithToolChoice(*choice))
+	}
+	return opts
+}
+
+// toCompletion converts the answer of a backend.
+func toCompletion(id, modelName string, created int64, out *schema.Message) *chatCompletion {
+	finishReason := finishReasonOf(out)
+	var usage *httpstream.OpenAIUsage
+	if out.ResponseMeta != nil {
+		usage = httpstream.NewOpenAIUsage(out.ResponseMeta.Usage)
+	}
+	toolCalls := httpstream.NewOpenAIToolCalls(out.ToolCalls)
+	for i, tc := range toolCalls {
+		tc.Index = i
+		if tc.Type == "" {
+			tc.Type = "function"
+		}
+	}
+
+	return &chatCompletion{
+		ID:      id,
+		Object:  "chat.completion",
+		Created: created,
+		Model:   modelName,
+		Choices: []*chatCompletionChoice{{
+			Message: &responseMessage{
+				Role:             string(schema.Assistant),
+				Content:          out.Content,
+				ReasoningContent: out.ReasoningContent,
+				ToolCalls:        toolCalls,
+			},
+			FinishReason: finishReason,
+		}},
+		Usage: usage,
+	}
+}
+
+// finishReasonOf returns the finish reason of an answer, defaulting to "tool_calls" or "stop".
+func finishReasonOf(out *schema.Message) string {
+	if out.ResponseMeta != nil && out.ResponseMeta.FinishReason != "" {
+		return strings.ToLower(out.ResponseMeta.FinishReason)
+	}
+	if len(out.ToolCalls) > 0 {
+		return "tool_calls"
+	}
+	return "stop"
+}
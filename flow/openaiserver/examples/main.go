@@ -0,0 +1,101 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/flow/openaiserver"
+)
+
+// try with any openai client, or:
+//
+//	curl localhost:8080/v1/chat/completions -H 'Authorization: Bearer sk-local' \
+//	  -d '{"model": "support-agent", "stream": true, "messages": [{"role": "user", "content": "hello"}]}'
+func main() {
+	ctx := context.Background()
+
+	// replace with any chat model, e.g. openai or ark
+	cm := &echoModel{}
+
+	// a pipeline served as a model: a chain adding a system prompt before the chat model
+	chain, err := compose.NewChain[[]*schema.Message, *schema.Message]().
+		AppendLambda(compose.InvokableLambda(func(_ context.Context, in []*schema.Message) ([]*schema.Message, error) {
+			return append([]*schema.Message{schema.SystemMessage("You are a support agent.")}, in...), nil
+		})).
+		AppendChatModel(cm).
+		Compile(ctx)
+	if err != nil {
+		log.Fatalf("Failed to compile chain: %v", err)
+	}
+
+	agent, err := openaiserver.NewRunnableBackend(chain)
+	if err != nil {
+		log.Fatalf("Failed to create backend: %v", err)
+	}
+	echo, err := openaiserver.NewChatModelBackend(cm)
+	if err != nil {
+		log.Fatalf("Failed to create backend: %v", err)
+	}
+
+	handler, err := openaiserver.NewHandler(&openaiserver.Config{
+		Models: map[string]openaiserver.Backend{
+			"support-agent": agent,
+			"echo":          echo,
+		},
+		DefaultModel: "support-agent",
+		APIKeys:      []string{"sk-local"},
+		OnError: func(_ context.Context, modelName string, err error) {
+			log.Printf("model %s failed: %v", modelName, err)
+		},
+	})
+	if err != nil {
+		log.Fatalf("Failed to create handler: %v", err)
+	}
+
+	log.Println("listening on :8080")
+	log.Fatal(http.ListenAndServe(":8080", handler))
+}
+
+// echoModel answers with the messages it received, replace with a real chat model.
+type echoModel struct{}
+
+func (e *echoModel) Generate(_ context.Context, input []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	var sb strings.Builder
+	for _, m := range input {
+		sb.WriteString(string(m.Role) + ": " + m.Content + "\n")
+	}
+	return schema.AssistantMessage(sb.String(), nil), nil
+}
+
+func (e *echoModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	out, err := e.Generate(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	var chunks []*schema.Message
+	for _, line := range strings.SplitAfter(out.Content, "\n") {
+		chunks = append(chunks, schema.AssistantMessage(line, nil))
+	}
+	return schema.StreamReaderFromArray(chunks), nil
+}
@@ -0,0 +1,274 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package openaiserver exposes eino chat models and compiled graphs as an openai compatible api,
+// so that the openai clients and frontends can talk to eino pipelines directly.
+package openaiserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/flow/openaiserver/internal/httpstream"
+)
+
+//go:generate sh ../../libs/acl/bundle.sh flow/httpstream internal/httpstream
+
+const defaultMaxBodyBytes = 10 << 20
+
+type Config struct {
+	// Models are the backends served, by the model name requested.
+	// Required
+	Models map[string]Backend
+	// DefaultModel is the model answering the requests of an unknown model, which get a 404 error otherwise.
+	// Optional
+	DefaultModel string
+	// APIKeys are the keys accepted as bearer tokens, the api being open if empty.
+	// Optional
+	APIKeys []string
+	// MaxBodyBytes limits the size of the requests.
+	// Optional. Default: 10MB
+	MaxBodyBytes int64
+	// HeartbeatInterval is the interval of the heartbeats of the streams, negative disables them.
+	// Optional. Default: 15s
+	HeartbeatInterval time.Duration
+	// OnError is called with the errors of the backends and of the streams, e.g. to log them.
+	// Optional
+	OnError func(ctx context.Context, modelName string, err error)
+}
+
+// NewHandler returns the http handler of the openai compatible api, serving:
+//
+//	POST /v1/chat/completions
+//	GET  /v1/models
+//
+// Mount it under a prefix with http.StripPrefix.
+func NewHandler(config *Config) (http.Handler, error) {
+	if config == nil || len(config.Models) == 0 {
+		return nil, errors.New("models are required")
+	}
+	for name, b := range config.Models {
+		if b == nil {
+			return nil, fmt.Errorf("backend of model %q is nil", name)
+		}
+	}
+	if config.DefaultModel != "" && config.Models[config.DefaultModel] == nil {
+		return nil, fmt.Errorf("default model %q is not served", config.DefaultModel)
+	}
+
+	s := &server{config: config, maxBodyBytes: config.MaxBodyBytes}
+	if s.maxBodyBytes <= 0 {
+		s.maxBodyBytes = defaultMaxBodyBytes
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/chat/completions", s.chatCompletions)
+	mux.HandleFunc("GET /v1/models", s.models)
+	return s.authenticate(mux), nil
+}
+
+type server struct {
+	config       *Config
+	maxBodyBytes int64
+}
+
+func (s *server) authenticate(next http.Handler) http.Handler {
+	if len(s.config.APIKeys) == 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if ok {
+			for _, key := range s.config.APIKeys {
+				if subtle.ConstantTimeCompare([]byte(token), []byte(key)) == 1 {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+		}
+		writeError(w, http.StatusUnauthorized, "invalid_request_error", "invalid_api_key", "Incorrect API key provided.")
+	})
+}
+
+func (s *server) chatCompletions(w http.ResponseWriter, r *http.Request) {
+	req := &chatCompletionRequest{}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, s.maxBodyBytes))
+	if err != nil {
+		status := http.StatusBadRequest
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		writeError(w, status, "invalid_request_error", "", fmt.Sprintf("read request fail: %v", err))
+		return
+	}
+	if err = json.Unmarshal(body, req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "", fmt.Sprintf("invalid request: %v", err))
+		return
+	}
+
+	modelName := req.Model
+	backend := s.config.Models[modelName]
+	if backend == nil {
+		if s.config.DefaultModel == "" {
+			writeError(w, http.StatusNotFound, "invalid_request_error", "model_not_found",
+				fmt.Sprintf("The model `%s` does not exist.", req.Model))
+			return
+		}
+		backend = s.config.Models[s.config.DefaultModel]
+	}
+
+	input, err := toMessages(req.Messages)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "", err.Error())
+		return
+	}
+	choice, chosen, err := toToolChoice(req.ToolChoice)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "", err.Error())
+		return
+	}
+	tools, err := toTools(req.Tools, chosen)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "", err.Error())
+		return
+	}
+	opts := toOptions(req, choice)
+
+	ctx := r.Context()
+	id, created := httpstream.NewCompletionID(), time.Now().Unix()
+	if !req.Stream {
+		out, err := backend.Generate(ctx, input, tools, opts...)
+		if err != nil {
+			s.onError(ctx, modelName, err)
+			writeError(w, http.StatusInternalServerError, "server_error", "", err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, toCompletion(id, modelName, created, out))
+		return
+	}
+
+	sr, err := backend.Stream(ctx, input, tools, opts...)
+	if err != nil {
+		s.onError(ctx, modelName, err)
+		writeError(w, http.StatusInternalServerError, "server_error", "", err.Error())
+		return
+	}
+	includeUsage := req.StreamOptions != nil && req.StreamOptions.IncludeUsage
+	err = httpstream.ServeSSE(w, r, completeStream(sr, includeUsage), &httpstream.Config{
+		Format:            httpstream.FormatOpenAI,
+		Model:             modelName,
+		ID:                id,
+		HeartbeatInterval: s.config.HeartbeatInterval,
+	})
+	if err != nil && ctx.Err() == nil {
+		s.onError(ctx, modelName, err)
+	}
+}
+
+// completeStream ends the chunks with a finish reason as openai does, and removes their usage unless included.
+func completeStream(sr *schema.StreamReader[*schema.Message], includeUsage bool) *schema.StreamReader[*schema.Message] {
+	outSR, outSW := schema.Pipe[*schema.Message](1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				outSW.Send(nil, fmt.Errorf("panic in openai server stream: %v", p))
+			}
+			sr.Close()
+			outSW.Close()
+		}()
+
+		finished, toolCalls := false, false
+		for {
+			chunk, err := sr.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				outSW.Send(nil, err)
+				return
+			}
+			if chunk == nil {
+				continue
+			}
+			toolCalls = toolCalls || len(chunk.ToolCalls) > 0
+			if meta := chunk.ResponseMeta; meta != nil {
+				finished = finished || meta.FinishReason != ""
+				if meta.Usage != nil && !includeUsage {
+					cp := *chunk
+					cpMeta := *meta
+					cpMeta.Usage = nil
+					cp.ResponseMeta = &cpMeta
+					chunk = &cp
+				}
+			}
+			if closed := outSW.Send(chunk, nil); closed {
+				return
+			}
+		}
+
+		if !finished {
+			reason := "stop"
+			if toolCalls {
+				reason = "tool_calls"
+			}
+			outSW.Send(&schema.Message{Role: schema.Assistant, ResponseMeta: &schema.ResponseMeta{FinishReason: reason}}, nil)
+		}
+	}()
+	return outSR
+}
+
+func (s *server) models(w http.ResponseWriter, _ *http.Request) {
+	names := make([]string, 0, len(s.config.Models))
+	for name := range s.config.Models {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	list := &modelList{Object: "list", Data: make([]*modelInfo, 0, len(names))}
+	for _, name := range names {
+		list.Data = append(list.Data, &modelInfo{ID: name, Object: "model", OwnedBy: "eino"})
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+func (s *server) onError(ctx context.Context, modelName string, err error) {
+	if s.config.OnError != nil {
+		s.config.OnError(ctx, modelName, err)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, typ, code, message string) {
+	e := &apiError{Message: message, Type: typ}
+	if code != "" {
+		e.Code = &code
+	}
+	writeJSON(w, status, &errorResponse{Error: e})
+}
@@ -0,0 +1,274 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package openaiserver
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudwego/eino-ext/flow/openaiserver/internal/httpstream"
+)
+
+type fakeChatModel struct {
+	tools  []*schema.ToolInfo
+	input  []*schema.Message
+	opts   *model.Options
+	chunks []*schema.Message
+}
+
+func (f *fakeChatModel) Generate(_ context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	f.input, f.opts = input, model.GetCommonOptions(&model.Options{}, opts...)
+	return schema.ConcatMessages(f.chunks)
+}
+
+func (f *fakeChatModel) Stream(_ context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	f.input, f.opts = input, model.GetCommonOptions(&model.Options{}, opts...)
+	return schema.StreamReaderFromArray(f.chunks), nil
+}
+
+type fakeToolCallingChatModel struct {
+	*fakeChatModel
+}
+
+func (f *fakeToolCallingChatModel) WithTools(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	f.tools = tools
+	return f, nil
+}
+
+func post(t *testing.T, h http.Handler, body string, header ...string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(body))
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestChatCompletions(t *testing.T) {
+	index := 0
+	cm := &fakeToolCallingChatModel{&fakeChatModel{chunks: []*schema.Message{
+		schema.AssistantMessage("Let me check", nil),
+		{Role: schema.Assistant, ToolCalls: []schema.ToolCall{{Index: &index, ID: "call_1", Type: "function",
+			Function: schema.FunctionCall{Name: "weather", Arguments: `{"city":"Paris"}`}}}},
+		{Role: schema.Assistant, ResponseMeta: &schema.ResponseMeta{Usage: &schema.TokenUsage{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15}}},
+	}}}
+	backend, err := NewChatModelBackend(cm)
+	require.NoError(t, err)
+	h, err := NewHandler(&Config{Models: map[string]Backend{"agent": backend}})
+	require.NoError(t, err)
+
+	reqBody := `{
+		"model": "agent",
+		"messages": [
+			{"role": "system", "content": "be brief"},
+			{"role": "user", "content": [{"type": "text", "text": "what is this? "}, {"type": "image_url", "image_url": {"url": "https://example.com/a.png", "detail": "low"}}]},
+			{"role": "assistant", "content": null, "tool_calls": [{"id": "call_0", "type": "function", "function": {"name": "weather", "arguments": "{}"}}]},
+			{"role": "tool", "tool_call_id": "call_0", "content": [{"type": "text", "text": "sunny"}]}
+		],
+		"tools": [{"type": "function", "function": {"name": "weather", "description": "get the weather", "parameters": {"type": "object", "properties": {"city": {"type": "string"}}}}}],
+		"temperature": 0.2,
+		"max_tokens": 100,
+		"stop": "END"
+	}`
+
+	t.Run("generate", func(t *testing.T) {
+		rec := post(t, h, reqBody)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		require.Len(t, cm.input, 4)
+		assert.Equal(t, schema.System, cm.input[0].Role)
+		require.Len(t, cm.input[1].MultiContent, 2)
+		assert.Equal(t, "https://example.com/a.png", cm.input[1].MultiContent[1].ImageURL.URL)
+		assert.Equal(t, schema.ImageURLDetailLow, cm.input[1].MultiContent[1].ImageURL.Detail)
+		assert.Equal(t, "call_0", cm.input[2].ToolCalls[0].ID)
+		assert.Equal(t, schema.Tool, cm.input[3].Role)
+		assert.Equal(t, "call_0", cm.input[3].ToolCallID)
+		assert.Equal(t, "sunny", cm.input[3].Content)
+
+		require.Len(t, cm.tools, 1)
+		assert.Equal(t, "weather", cm.tools[0].Name)
+		js, err := cm.tools[0].ToJSONSchema()
+		require.NoError(t, err)
+		assert.Equal(t, "string", js.Properties.Value("city").Type)
+		assert.Equal(t, float32(0.2), *cm.opts.Temperature)
+		assert.Equal(t, 100, *cm.opts.MaxTokens)
+		assert.Equal(t, []string{"END"}, cm.opts.Stop)
+
+		resp := &chatCompletion{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), resp))
+		assert.Equal(t, "chat.completion", resp.Object)
+		assert.Equal(t, "agent", resp.Model)
+		require.Len(t, resp.Choices, 1)
+		assert.Equal(t, "tool_calls", resp.Choices[0].FinishReason)
+		assert.Equal(t, "Let me check", resp.Choices[0].Message.Content)
+		assert.Equal(t, []*httpstream.OpenAIToolCall{{ID: "call_1", Type: "function",
+			Function: httpstream.OpenAIFunctionCall{Name: "weather", Arguments: `{"city":"Paris"}`}}}, resp.Choices[0].Message.ToolCalls)
+		assert.Equal(t, 15, resp.Usage.TotalTokens)
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		for _, includeUsage := range []bool{false, true} {
+			body := strings.Replace(reqBody, `"model": "agent",`,
+				`"model": "agent", "stream": true, "stream_options": {"include_usage": `+map[bool]string{true: "true", false: "false"}[includeUsage]+`},`, 1)
+			rec := post(t, h, body)
+			require.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+
+			var chunks []*httpstream.OpenAIChunk
+			scanner := bufio.NewScanner(bytes.NewReader(rec.Body.Bytes()))
+			var done bool
+			for scanner.Scan() {
+				data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+				if !ok {
+					continue
+				}
+				if data == httpstream.DoneData {
+					done = true
+					continue
+				}
+				c := &httpstream.OpenAIChunk{}
+				require.NoError(t, json.Unmarshal([]byte(data), c))
+				chunks = append(chunks, c)
+			}
+			assert.True(t, done)
+			require.Len(t, chunks, 4)
+			assert.Equal(t, "assistant", chunks[0].Choices[0].Delta.Role)
+			assert.Equal(t, "weather", chunks[1].Choices[0].Delta.ToolCalls[0].Function.Name)
+			assert.Equal(t, includeUsage, chunks[2].Usage != nil)
+			require.NotNil(t, chunks[3].Choices[0].FinishReason)
+			assert.Equal(t, "tool_calls", *chunks[3].Choices[0].FinishReason)
+		}
+	})
+
+	t.Run("tool choice", func(t *testing.T) {
+		body := strings.Replace(reqBody, `"model": "agent",`,
+			`"model": "agent", "tool_choice": {"type": "function", "function": {"name": "weather"}},`, 1)
+		rec := post(t, h, body)
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, schema.ToolChoiceForced, *cm.opts.ToolChoice)
+
+		body = strings.Replace(reqBody, `"model": "agent",`,
+			`"model": "agent", "tool_choice": {"type": "function", "function": {"name": "search"}},`, 1)
+		rec = post(t, h, body)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), `tool_choice: unknown function \"search\"`)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		rec := post(t, h, `{"model": "gpt-4o", "messages": [{"role": "user", "content": "hi"}]}`)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"code":"model_not_found"`)
+
+		rec = post(t, h, `{"model": "agent", "messages": [{"role": "robot", "content": "hi"}]}`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), `unknown role \"robot\"`)
+
+		rec = post(t, h, `{"model": "agent", "messages": []}`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		rec = post(t, h, `{"model": `)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"type":"invalid_request_error"`)
+	})
+}
+
+func TestHandler(t *testing.T) {
+	_, err := NewHandler(&Config{})
+	assert.Error(t, err)
+	_, err = NewHandler(&Config{Models: map[string]Backend{"a": nil}})
+	assert.Error(t, err)
+
+	a, err := NewChatModelBackend(&fakeChatModel{chunks: []*schema.Message{schema.AssistantMessage("from a", nil)}})
+	require.NoError(t, err)
+	b, err := NewChatModelBackend(&fakeChatModel{chunks: []*schema.Message{schema.AssistantMessage("from b", nil)}})
+	require.NoError(t, err)
+	_, err = NewHandler(&Config{Models: map[string]Backend{"a": a}, DefaultModel: "c"})
+	assert.Error(t, err)
+
+	h, err := NewHandler(&Config{Models: map[string]Backend{"b": b, "a": a}, DefaultModel: "b", APIKeys: []string{"sk-1"}})
+	require.NoError(t, err)
+
+	rec := post(t, h, `{"model": "a", "messages": [{"role": "user", "content": "hi"}]}`)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), "invalid_api_key")
+
+	rec = post(t, h, `{"model": "a", "messages": [{"role": "user", "content": "hi"}]}`, "Authorization", "Bearer sk-1")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"content":"from a"`)
+	assert.Contains(t, rec.Body.String(), `"finish_reason":"stop"`)
+
+	rec = post(t, h, `{"model": "gpt-4o", "messages": [{"role": "user", "content": "hi"}]}`, "Authorization", "Bearer sk-1")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"content":"from b"`)
+	assert.Contains(t, rec.Body.String(), `"model":"gpt-4o"`)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/models", nil)
+	req.Header.Set("Authorization", "Bearer sk-1")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	list := &modelList{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), list))
+	require.Len(t, list.Data, 2)
+	assert.Equal(t, "a", list.Data[0].ID)
+	assert.Equal(t, "b", list.Data[1].ID)
+}
+
+func TestRunnableBackend(t *testing.T) {
+	ctx := context.Background()
+	cm := &fakeChatModel{chunks: []*schema.Message{schema.AssistantMessage("from graph", nil)}}
+	r, err := compose.NewChain[[]*schema.Message, *schema.Message]().
+		AppendLambda(compose.InvokableLambda(func(_ context.Context, in []*schema.Message) ([]*schema.Message, error) {
+			return append([]*schema.Message{schema.SystemMessage("graph prompt")}, in...), nil
+		})).
+		AppendChatModel(cm).
+		Compile(ctx)
+	require.NoError(t, err)
+
+	backend, err := NewRunnableBackend(r)
+	require.NoError(t, err)
+	h, err := NewHandler(&Config{Models: map[string]Backend{"graph": backend}})
+	require.NoError(t, err)
+
+	rec := post(t, h, `{"model": "graph", "messages": [{"role": "user", "content": "hi"}],
+		"tools": [{"type": "function", "function": {"name": "search"}}], "temperature": 0.5}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"content":"from graph"`)
+	require.Len(t, cm.input, 2)
+	assert.Equal(t, "graph prompt", cm.input[0].Content)
+	require.Len(t, cm.opts.Tools, 1)
+	assert.Equal(t, "search", cm.opts.Tools[0].Name)
+	assert.Equal(t, float32(0.5), *cm.opts.Temperature)
+
+	rec = post(t, h, `{"model": "graph", "stream": true, "messages": [{"role": "user", "content": "hi"}]}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"content":"from graph"`)
+	assert.Contains(t, rec.Body.String(), "data: [DONE]")
+}
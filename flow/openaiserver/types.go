@@ -0,0 +1,146 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package openaiserver
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/cloudwego/eino-ext/flow/openaiserver/internal/httpstream"
+)
+
+// the openai chat completion api, ref: https://platform.openai.com/docs/api-reference/chat
+
+type chatCompletionRequest struct {
+	Model               string            `json:"model"`
+	Messages            []*requestMessage `json:"messages"`
+	Tools               []*requestTool    `json:"tools,omitempty"`
+	ToolChoice          json.RawMessage   `json:"tool_choice,omitempty"`
+	Stream              bool              `json:"stream,omitempty"`
+	StreamOptions       *streamOptions    `json:"stream_options,omitempty"`
+	Temperature         *float32          `json:"temperature,omitempty"`
+	TopP                *float32          `json:"top_p,omitempty"`
+	MaxTokens           *int              `json:"max_tokens,omitempty"`
+	MaxCompletionTokens *int              `json:"max_completion_tokens,omitempty"`
+	Stop                stringOrList      `json:"stop,omitempty"`
+}
+
+type streamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+type requestMessage struct {
+	Role       string                       `json:"role"`
+	Content    *messageContent              `json:"content,omitempty"`
+	Name       string                       `json:"name,omitempty"`
+	ToolCalls  []*httpstream.OpenAIToolCall `json:"tool_calls,omitempty"`
+	ToolCallID string                       `json:"tool_call_id,omitempty"`
+}
+
+// messageContent is either a string or a list of parts.
+type messageContent struct {
+	Text  string
+	Parts []*contentPart
+}
+
+func (m *messageContent) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &m.Text)
+	}
+	return json.Unmarshal(data, &m.Parts)
+}
+
+type contentPart struct {
+	Type     string `json:"type"`
+	Text     string `json:"text,omitempty"`
+	ImageURL *struct {
+		URL    string `json:"url"`
+		Detail string `json:"detail,omitempty"`
+	} `json:"image_url,omitempty"`
+}
+
+type requestTool struct {
+	Type     string `json:"type"`
+	Function *struct {
+		Name        string          `json:"name"`
+		Description string          `json:"description,omitempty"`
+		Parameters  json.RawMessage `json:"parameters,omitempty"`
+	} `json:"function"`
+}
+
+type stringOrList []string
+
+func (s *stringOrList) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var v string
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		*s = []string{v}
+		return nil
+	}
+	var v []string
+	if err := json.Unmarshal(data, &v); err != nil {
+		return errors.New("must be a string or a list of strings")
+	}
+	*s = v
+	return nil
+}
+
+type chatCompletion struct {
+	ID      string                  `json:"id"`
+	Object  string                  `json:"object"`
+	Created int64                   `json:"created"`
+	Model   string                  `json:"model"`
+	Choices []*chatCompletionChoice `json:"choices"`
+	Usage   *httpstream.OpenAIUsage `json:"usage,omitempty"`
+}
+
+type chatCompletionChoice struct {
+	Index        int              `json:"index"`
+	Message      *responseMessage `json:"message"`
+	FinishReason string           `json:"finish_reason"`
+}
+
+type responseMessage struct {
+	Role             string                       `json:"role"`
+	Content          string                       `json:"content"`
+	ReasoningContent string                       `json:"reasoning_content,omitempty"`
+	ToolCalls        []*httpstream.OpenAIToolCall `json:"tool_calls,omitempty"`
+}
+
+type modelList struct {
+	Object string       `json:"object"`
+	Data   []*modelInfo `json:"data"`
+}
+
+type modelInfo struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Created int64  `json:"created"`
+	OwnedBy string `json:"owned_by"`
+}
+
+type errorResponse struct {
+	Error *apiError `json:"error"`
+}
+
+type apiError struct {
+	Message string  `json:"message"`
+	Type    string  `json:"type"`
+	Param   *string `json:"param"`
+	Code    *string `json:"code"`
+}
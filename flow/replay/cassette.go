@@ -0,0 +1,121 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package replay records the requests and the responses of chat models to cassette files, and serves them back
+// deterministically, so that the integration tests of eino graphs run hermetically, without network nor API keys.
+package replay
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// ErrNoInteraction is returned when a cassette has no recorded interaction matching a request.
+var ErrNoInteraction = errors.New("no recorded interaction matches the request")
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	// Key is the hash of the request, the exact match of a replayed request.
+	Key   string            `json:"key"`
+	Input []*schema.Message `json:"input"`
+	// Tools are the names of the tools bound to the chat model.
+	Tools  []string        `json:"tools,omitempty"`
+	Output *schema.Message `json:"output"`
+	// Chunks are the chunks of a streamed output, replayed as is by Stream.
+	Chunks []*schema.Message `json:"chunks,omitempty"`
+}
+
+// Cassette is the content of a cassette file.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// LoadCassette reads the cassette file at path.
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read cassette fail: %w", err)
+	}
+	c := &Cassette{}
+	if err = json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("unmarshal cassette %s fail: %w", path, err)
+	}
+	return c, nil
+}
+
+// Save writes the cassette to path, atomically replacing the existing file.
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal cassette fail: %w", err)
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create cassette dir fail: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err = os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write cassette fail: %w", err)
+	}
+	if err = os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("write cassette fail: %w", err)
+	}
+	return nil
+}
+
+// RequestKey hashes the roles, the contents, the tool calls of the input and the tool names.
+// The tool call ids, the Extra and the response metadata of the messages are left out, as they vary between runs.
+func RequestKey(input []*schema.Message, tools []string) string {
+	h := sha256.New()
+	write := func(s string) {
+		_, _ = h.Write([]byte(s))
+		_, _ = h.Write([]byte{0})
+	}
+	for _, msg := range input {
+		write(string(msg.Role))
+		write(msg.Content)
+		for _, part := range msg.MultiContent {
+			write(string(part.Type))
+			write(part.Text)
+			if part.ImageURL != nil {
+				write(part.ImageURL.URL)
+			}
+		}
+		for _, tc := range msg.ToolCalls {
+			write(tc.Function.Name)
+			write(tc.Function.Arguments)
+		}
+	}
+	write(strings.Join(tools, ","))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func toolNames(tools []*schema.ToolInfo) []string {
+	if len(tools) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(tools))
+	for _, t := range tools {
+		names = append(names, t.Name)
+	}
+	return names
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/flow/replay"
+)
+
+func main() {
+	ctx := context.Background()
+
+	dir, err := os.MkdirTemp("", "replay")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "example.json")
+
+	// record, replace echoModel with a real chat model, e.g. openai or ark chat model
+	recorder, err := replay.NewChatModel(ctx, &echoModel{}, &replay.Config{Path: path, Mode: replay.ModeRecord})
+	if err != nil {
+		log.Fatal(err)
+	}
+	out, err := recorder.Generate(ctx, []*schema.Message{schema.UserMessage("Hello, Eino!")})
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("recorded: %s", out.Content)
+
+	// replay, no chat model needed
+	replayer, err := replay.NewChatModel(ctx, nil, &replay.Config{Path: path, Mode: replay.ModeReplay})
+	if err != nil {
+		log.Fatal(err)
+	}
+	out, err = replayer.Generate(ctx, []*schema.Message{schema.UserMessage("hello,  eino!")})
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("replayed: %s", out.Content)
+}
+
+type echoModel struct{}
+
+func (e *echoModel) Generate(_ context.Context, input []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	return schema.AssistantMessage("echo: "+input[len(input)-1].Content, nil), nil
+}
+
+func (e *echoModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	out, err := e.Generate(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return schema.StreamReaderFromArray([]*schema.Message{out}), nil
+}
@@ -0,0 +1,83 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package replay
+
+import (
+	"strings"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+func defaultNormalize(content string) string {
+	return strings.Join(strings.Fields(strings.ToLower(content)), " ")
+}
+
+// similarity compares the inputs message by message, as the mean of the similarities of their contents.
+// Inputs with different lengths or roles are not similar.
+func similarity(a, b []*schema.Message, normalize func(string) string) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	if len(a) == 0 {
+		return 1
+	}
+	var total float64
+	for i := range a {
+		if a[i].Role != b[i].Role {
+			return 0
+		}
+		total += jaccard(words(messageText(a[i]), normalize), words(messageText(b[i]), normalize))
+	}
+	return total / float64(len(a))
+}
+
+func messageText(msg *schema.Message) string {
+	var sb strings.Builder
+	sb.WriteString(msg.Content)
+	for _, part := range msg.MultiContent {
+		sb.WriteString(" ")
+		sb.WriteString(part.Text)
+	}
+	for _, tc := range msg.ToolCalls {
+		sb.WriteString(" ")
+		sb.WriteString(tc.Function.Name)
+		sb.WriteString(" ")
+		sb.WriteString(tc.Function.Arguments)
+	}
+	return sb.String()
+}
+
+func words(text string, normalize func(string) string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, w := range strings.Fields(normalize(text)) {
+		set[w] = struct{}{}
+	}
+	return set
+}
+
+// jaccard is the size of the intersection of the sets over the size of their union.
+func jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	inter := 0
+	for w := range a {
+		if _, ok := b[w]; ok {
+			inter++
+		}
+	}
+	return float64(inter) / float64(len(a)+len(b)-inter)
+}
@@ -0,0 +1,153 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package replay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+type RecorderConfig struct {
+	// Path is the cassette file, overwritten with the interactions recorded.
+	// Required
+	Path string
+}
+
+// NewRecorder wraps cm so that its requests and responses are recorded to the cassette at config.Path,
+// written after each interaction. The failed requests are not recorded.
+func NewRecorder(cm model.BaseChatModel, config *RecorderConfig) (*Recorder, error) {
+	if cm == nil {
+		return nil, errors.New("chat model is required")
+	}
+	if config == nil || config.Path == "" {
+		return nil, errors.New("cassette path is required")
+	}
+	return &Recorder{
+		cm:   cm,
+		tape: &tape{path: config.Path, cassette: &Cassette{}},
+	}, nil
+}
+
+// Recorder is a chat model recording the interactions with the chat model it wraps.
+type Recorder struct {
+	cm    model.BaseChatModel
+	tools []string
+	tape  *tape
+}
+
+// tape is the cassette being recorded, shared by the recorders returned by WithTools.
+type tape struct {
+	mu       sync.Mutex
+	path     string
+	cassette *Cassette
+}
+
+func (t *tape) record(i *Interaction) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cassette.Interactions = append(t.cassette.Interactions, i)
+	return t.cassette.Save(t.path)
+}
+
+func (r *Recorder) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	out, err := r.cm.Generate(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err = r.tape.record(r.interaction(input, out, nil)); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *Recorder) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	sr, err := r.cm.Stream(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// the interaction is recorded once the output is fully received, before the reader gets io.EOF
+	outSR, outSW := schema.Pipe[*schema.Message](1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				outSW.Send(nil, fmt.Errorf("panic in replay recorder stream: %v", p))
+			}
+			sr.Close()
+			outSW.Close()
+		}()
+
+		var chunks []*schema.Message
+		for {
+			chunk, err := sr.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				outSW.Send(nil, err)
+				return
+			}
+			chunks = append(chunks, chunk)
+			if closed := outSW.Send(chunk, nil); closed {
+				return
+			}
+		}
+
+		out, err := schema.ConcatMessages(chunks)
+		if err != nil {
+			outSW.Send(nil, fmt.Errorf("concat stream output fail: %w", err))
+			return
+		}
+		if err = r.tape.record(r.interaction(input, out, chunks)); err != nil {
+			outSW.Send(nil, err)
+		}
+	}()
+
+	return outSR, nil
+}
+
+func (r *Recorder) WithTools(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	tcm, ok := r.cm.(model.ToolCallingChatModel)
+	if !ok {
+		return nil, errors.New("chat model does not implement ToolCallingChatModel")
+	}
+	withTools, err := tcm.WithTools(tools)
+	if err != nil {
+		return nil, err
+	}
+	return &Recorder{cm: withTools, tools: toolNames(tools), tape: r.tape}, nil
+}
+
+// IsCallbacksEnabled avoids duplicated callbacks, those of the wrapped chat model being kept.
+func (r *Recorder) IsCallbacksEnabled() bool {
+	return true
+}
+
+func (r *Recorder) interaction(input []*schema.Message, out *schema.Message, chunks []*schema.Message) *Interaction {
+	return &Interaction{
+		Key:    RequestKey(input, r.tools),
+		Input:  input,
+		Tools:  r.tools,
+		Output: out,
+		Chunks: chunks,
+	}
+}
@@ -0,0 +1,92 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package replay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/cloudwego/eino/components/model"
+)
+
+// EnvMode is the environment variable selecting the Mode of NewChatModel, e.g. EINO_REPLAY_MODE=record go test ./...
+const EnvMode = "EINO_REPLAY_MODE"
+
+type Mode string
+
+const (
+	// ModeReplay replays the cassette, failing the requests not recorded.
+	ModeReplay Mode = "replay"
+	// ModeRecord records the cassette, calling the chat model.
+	ModeRecord Mode = "record"
+	// ModeAuto replays the cassette when it exists, else records it.
+	ModeAuto Mode = "auto"
+)
+
+// ModeFromEnv returns the mode set by EnvMode, ModeReplay if unset.
+func ModeFromEnv() Mode {
+	if mode := os.Getenv(EnvMode); mode != "" {
+		return Mode(mode)
+	}
+	return ModeReplay
+}
+
+type Config struct {
+	// Path is the cassette file.
+	// Required
+	Path string
+	// Mode selects between recording and replaying.
+	// Optional. Default: ModeFromEnv()
+	Mode Mode
+	// Similarity is the minimum similarity of a fuzzy match when replaying, see ReplayerConfig.
+	// Optional. Default: 0.8
+	Similarity float64
+}
+
+// NewChatModel returns the Recorder of cm or the Replayer of the cassette, depending on the mode.
+// cm is only required when recording, so that the tests replaying the cassettes need no API key, e.g.
+//
+//	var cm model.ToolCallingChatModel // nil when replaying
+//	if replay.ModeFromEnv() != replay.ModeReplay {
+//		cm, _ = openai.NewChatModel(ctx, &openai.ChatModelConfig{APIKey: os.Getenv("OPENAI_API_KEY"), Model: "gpt-4o"})
+//	}
+//	chatModel, err := replay.NewChatModel(ctx, cm, &replay.Config{Path: "testdata/agent.json"})
+func NewChatModel(ctx context.Context, cm model.BaseChatModel, config *Config) (model.ToolCallingChatModel, error) {
+	if config == nil || config.Path == "" {
+		return nil, errors.New("cassette path is required")
+	}
+	mode := config.Mode
+	if mode == "" {
+		mode = ModeFromEnv()
+	}
+	if mode == ModeAuto {
+		mode = ModeRecord
+		if _, err := os.Stat(config.Path); err == nil {
+			mode = ModeReplay
+		}
+	}
+
+	switch mode {
+	case ModeReplay:
+		return NewReplayer(ctx, &ReplayerConfig{Path: config.Path, Similarity: config.Similarity})
+	case ModeRecord:
+		return NewRecorder(cm, &RecorderConfig{Path: config.Path})
+	default:
+		return nil, fmt.Errorf("unknown replay mode: %s", mode)
+	}
+}
@@ -0,0 +1,196 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package replay
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// counterModel answers with the number of calls, streaming the answers word by word.
+type counterModel struct {
+	calls int
+	tools []*schema.ToolInfo
+}
+
+func (c *counterModel) Generate(_ context.Context, input []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	c.calls++
+	if input[len(input)-1].Content == "fail" {
+		return nil, errors.New("model fail")
+	}
+	if len(c.tools) > 0 {
+		return schema.AssistantMessage("", []schema.ToolCall{{ID: "call_1", Function: schema.FunctionCall{Name: c.tools[0].Name, Arguments: "{}"}}}), nil
+	}
+	return schema.AssistantMessage("answer "+string(rune('0'+c.calls)), nil), nil
+}
+
+func (c *counterModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	out, err := c.Generate(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return schema.StreamReaderFromArray([]*schema.Message{
+		schema.AssistantMessage("answer ", nil),
+		schema.AssistantMessage(out.Content[len("answer "):], nil),
+	}), nil
+}
+
+func (c *counterModel) WithTools(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	return &counterModel{calls: c.calls, tools: tools}, nil
+}
+
+func readAll(t *testing.T, sr *schema.StreamReader[*schema.Message]) []string {
+	defer sr.Close()
+	var contents []string
+	for {
+		chunk, err := sr.Recv()
+		if errors.Is(err, io.EOF) {
+			return contents
+		}
+		require.NoError(t, err)
+		contents = append(contents, chunk.Content)
+	}
+}
+
+func TestRecordReplay(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cassettes", "test.json")
+	question := []*schema.Message{
+		schema.SystemMessage("You are a helpful assistant."),
+		schema.UserMessage("What is the weather in Beijing today?"),
+	}
+	weather := &schema.ToolInfo{Name: "get_weather"}
+
+	recorder, err := NewRecorder(&counterModel{}, &RecorderConfig{Path: path})
+	require.NoError(t, err)
+
+	out, err := recorder.Generate(ctx, question)
+	require.NoError(t, err)
+	assert.Equal(t, "answer 1", out.Content)
+	out, err = recorder.Generate(ctx, question)
+	require.NoError(t, err)
+	assert.Equal(t, "answer 2", out.Content)
+
+	sr, err := recorder.Stream(ctx, []*schema.Message{schema.UserMessage("hello")})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"answer ", "3"}, readAll(t, sr))
+
+	withTools, err := recorder.WithTools([]*schema.ToolInfo{weather})
+	require.NoError(t, err)
+	out, err = withTools.Generate(ctx, question)
+	require.NoError(t, err)
+	require.Len(t, out.ToolCalls, 1)
+
+	_, err = recorder.Generate(ctx, []*schema.Message{schema.UserMessage("fail")})
+	assert.Error(t, err)
+
+	cassette, err := LoadCassette(path)
+	require.NoError(t, err)
+	require.Len(t, cassette.Interactions, 4)
+	assert.Equal(t, []string{"get_weather"}, cassette.Interactions[3].Tools)
+	assert.Len(t, cassette.Interactions[2].Chunks, 2)
+	assert.Equal(t, "answer 3", cassette.Interactions[2].Output.Content)
+
+	replayer, err := NewReplayer(ctx, &ReplayerConfig{Path: path})
+	require.NoError(t, err)
+
+	t.Run("in order", func(t *testing.T) {
+		for _, expected := range []string{"answer 1", "answer 2", "answer 2"} {
+			out, err := replayer.Generate(ctx, question)
+			require.NoError(t, err)
+			assert.Equal(t, expected, out.Content)
+		}
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		sr, err := replayer.Stream(ctx, []*schema.Message{schema.UserMessage("hello")})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"answer ", "3"}, readAll(t, sr))
+
+		sr, err = replayer.Stream(ctx, question)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"answer 2"}, readAll(t, sr))
+	})
+
+	t.Run("tools", func(t *testing.T) {
+		withTools, err := replayer.WithTools([]*schema.ToolInfo{weather})
+		require.NoError(t, err)
+		out, err := withTools.Generate(ctx, question)
+		require.NoError(t, err)
+		assert.Equal(t, "get_weather", out.ToolCalls[0].Function.Name)
+	})
+
+	t.Run("fuzzy", func(t *testing.T) {
+		out, err := replayer.Generate(ctx, []*schema.Message{
+			schema.SystemMessage("You are a helpful assistant."),
+			schema.UserMessage("what is the  weather in Beijing today"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "answer 2", out.Content)
+
+		_, err = replayer.Generate(ctx, []*schema.Message{
+			schema.SystemMessage("You are a helpful assistant."),
+			schema.UserMessage("Tell me a joke."),
+		})
+		assert.ErrorIs(t, err, ErrNoInteraction)
+
+		exact, err := NewReplayer(ctx, &ReplayerConfig{Path: path, Similarity: 1})
+		require.NoError(t, err)
+		_, err = exact.Generate(ctx, []*schema.Message{
+			schema.SystemMessage("You are a helpful assistant."),
+			schema.UserMessage("what is the  weather in Beijing today"),
+		})
+		assert.ErrorIs(t, err, ErrNoInteraction)
+	})
+}
+
+func TestNewChatModel(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "auto.json")
+	input := []*schema.Message{schema.UserMessage("hi")}
+
+	cm, err := NewChatModel(ctx, &counterModel{}, &Config{Path: path, Mode: ModeAuto})
+	require.NoError(t, err)
+	assert.IsType(t, &Recorder{}, cm)
+	_, err = cm.Generate(ctx, input)
+	require.NoError(t, err)
+
+	cm, err = NewChatModel(ctx, nil, &Config{Path: path, Mode: ModeAuto})
+	require.NoError(t, err)
+	assert.IsType(t, &Replayer{}, cm)
+	out, err := cm.Generate(ctx, input)
+	require.NoError(t, err)
+	assert.Equal(t, "answer 1", out.Content)
+
+	t.Setenv(EnvMode, "")
+	_, err = NewChatModel(ctx, nil, &Config{Path: filepath.Join(t.TempDir(), "missing.json")})
+	assert.Error(t, err)
+
+	t.Setenv(EnvMode, string(ModeRecord))
+	_, err = NewChatModel(ctx, nil, &Config{Path: path})
+	assert.ErrorContains(t, err, "chat model is required")
+
+	_, err = NewChatModel(ctx, nil, &Config{Path: path, Mode: "unknown"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,177 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package replay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+type ReplayerConfig struct {
+	// Path is the cassette file to replay.
+	// Required
+	Path string
+	// Similarity is the minimum similarity, between 0 and 1, of a request to a recorded one with the same tools
+	// when no recorded request matches exactly. A value of 1 or more disables the fuzzy matching.
+	// Optional. Default: 0.8
+	Similarity float64
+	// Normalize prepares the contents of the messages for the fuzzy matching, e.g. masking the dates or the ids.
+	// Optional. Default: lower case with collapsed white spaces
+	Normalize func(content string) string
+}
+
+// NewReplayer returns a chat model serving the responses recorded in the cassette at config.Path.
+// A request is answered with the first unreplayed interaction recording it exactly, else with the most similar one,
+// so that the same request asked repeatedly, e.g. by an agent loop, gets the recorded responses in order,
+// the last one being repeated once all are replayed.
+// A request matching no interaction fails with ErrNoInteraction.
+func NewReplayer(_ context.Context, config *ReplayerConfig) (*Replayer, error) {
+	if config == nil || config.Path == "" {
+		return nil, errors.New("cassette path is required")
+	}
+	cassette, err := LoadCassette(config.Path)
+	if err != nil {
+		return nil, err
+	}
+	similarity := config.Similarity
+	if similarity == 0 {
+		similarity = 0.8
+	}
+	normalize := config.Normalize
+	if normalize == nil {
+		normalize = defaultNormalize
+	}
+	return &Replayer{
+		player: &player{
+			interactions: cassette.Interactions,
+			replayed:     make([]bool, len(cassette.Interactions)),
+			similarity:   similarity,
+			normalize:    normalize,
+		},
+	}, nil
+}
+
+// Replayer is a chat model replaying a cassette.
+type Replayer struct {
+	tools  []string
+	player *player
+}
+
+// player is the cassette being replayed, shared by the replayers returned by WithTools.
+type player struct {
+	mu           sync.Mutex
+	interactions []*Interaction
+	replayed     []bool
+	similarity   float64
+	normalize    func(string) string
+}
+
+func (r *Replayer) Generate(_ context.Context, input []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	i, err := r.player.match(input, r.tools)
+	if err != nil {
+		return nil, err
+	}
+	return copyMessage(i.Output), nil
+}
+
+func (r *Replayer) Stream(_ context.Context, input []*schema.Message, _ ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	i, err := r.player.match(input, r.tools)
+	if err != nil {
+		return nil, err
+	}
+	if len(i.Chunks) == 0 {
+		return schema.StreamReaderFromArray([]*schema.Message{copyMessage(i.Output)}), nil
+	}
+	chunks := make([]*schema.Message, 0, len(i.Chunks))
+	for _, chunk := range i.Chunks {
+		chunks = append(chunks, copyMessage(chunk))
+	}
+	return schema.StreamReaderFromArray(chunks), nil
+}
+
+func (r *Replayer) WithTools(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	return &Replayer{tools: toolNames(tools), player: r.player}, nil
+}
+
+func (r *Replayer) GetType() string {
+	return "Replay"
+}
+
+func (r *Replayer) IsCallbacksEnabled() bool {
+	return false
+}
+
+func (p *player) match(input []*schema.Message, tools []string) (*Interaction, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := RequestKey(input, tools)
+	best, bestScore := -1, 0.0
+	for idx, i := range p.interactions {
+		score := 0.0
+		if i.Key == key {
+			// an exact match outscores any fuzzy one, replayed or not
+			score = 4
+		} else if p.similarity < 1 && slices.Equal(i.Tools, tools) {
+			score = similarity(input, i.Input, p.normalize)
+			if score < p.similarity {
+				continue
+			}
+		} else {
+			continue
+		}
+		// an unreplayed interaction wins over a replayed one, the first unreplayed and the last replayed being kept
+		if !p.replayed[idx] {
+			score += 2
+		}
+		if score > bestScore || (score == bestScore && p.replayed[idx]) {
+			best, bestScore = idx, score
+		}
+	}
+
+	if best < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoInteraction, describe(input))
+	}
+	p.replayed[best] = true
+	return p.interactions[best], nil
+}
+
+func copyMessage(msg *schema.Message) *schema.Message {
+	if msg == nil {
+		return nil
+	}
+	cp := *msg
+	return &cp
+}
+
+// describe summarizes the last message of the input for the error of an unmatched request.
+func describe(input []*schema.Message) string {
+	if len(input) == 0 {
+		return "empty input"
+	}
+	last := input[len(input)-1]
+	content := []rune(last.Content)
+	if len(content) > 80 {
+		content = append(content[:80], '.', '.', '.')
+	}
+	return fmt.Sprintf("%d messages, last %s message %q", len(input), last.Role, string(content))
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package aws resolves the secrets of AWS Secrets Manager.
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+
+	"github.com/cloudwego/eino-ext/flow/secrets"
+)
+
+// Client is the subset of *secretsmanager.Client used by the resolver.
+type Client interface {
+	GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput,
+		optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error)
+}
+
+type Config struct {
+	// Client reads the secrets, e.g. secretsmanager.NewFromConfig(cfg).
+	// Required
+	Client Client
+	// VersionStage is the staging label of the versions read.
+	// Optional. Default: AWSCURRENT
+	VersionStage string
+}
+
+// NewResolver returns a secrets.Resolver reading the secrets from AWS Secrets Manager, the name of a secret being
+// its name or ARN, e.g. "prod/openai#api_key" for the api_key field of the json secret prod/openai.
+func NewResolver(config *Config) (secrets.Resolver, error) {
+	if config == nil || config.Client == nil {
+		return nil, errors.New("secrets manager client is required")
+	}
+	return &resolver{cli: config.Client, versionStage: config.VersionStage}, nil
+}
+
+type resolver struct {
+	cli          Client
+	versionStage string
+}
+
+func (r *resolver) Resolve(ctx context.Context, ref string) (string, error) {
+	name, field := secrets.SplitRef(ref)
+	if name == "" {
+		return "", errors.New("secret name is empty")
+	}
+
+	input := &secretsmanager.GetSecretValueInput{SecretId: &name}
+	if r.versionStage != "" {
+		input.VersionStage = &r.versionStage
+	}
+	out, err := r.cli.GetSecretValue(ctx, input)
+	if err != nil {
+		var notFound *types.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return "", fmt.Errorf("aws secret %s: %w", name, secrets.ErrNotFound)
+		}
+		return "", fmt.Errorf("get aws secret value fail: %w", err)
+	}
+
+	var secret string
+	switch {
+	case out.SecretString != nil:
+		secret = *out.SecretString
+	case out.SecretBinary != nil:
+		secret = string(out.SecretBinary)
+	default:
+		return "", fmt.Errorf("aws secret %s has no value: %w", name, secrets.ErrNotFound)
+	}
+	return secrets.Field(secret, field)
+}
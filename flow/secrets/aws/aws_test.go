@@ -0,0 +1,74 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudwego/eino-ext/flow/secrets"
+)
+
+type fakeClient struct {
+	secrets map[string]string
+	inputs  []*secretsmanager.GetSecretValueInput
+}
+
+func (f *fakeClient) GetSecretValue(_ context.Context, params *secretsmanager.GetSecretValueInput,
+	_ ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+	f.inputs = append(f.inputs, params)
+	switch *params.SecretId {
+	case "broken":
+		return nil, errors.New("throttled")
+	case "binary":
+		return &secretsmanager.GetSecretValueOutput{SecretBinary: []byte("bytes")}, nil
+	}
+	value, ok := f.secrets[*params.SecretId]
+	if !ok {
+		return nil, &types.ResourceNotFoundException{}
+	}
+	return &secretsmanager.GetSecretValueOutput{SecretString: &value}, nil
+}
+
+func TestResolver(t *testing.T) {
+	ctx := context.Background()
+	cli := &fakeClient{secrets: map[string]string{"prod/openai": `{"api_key": "sk-aws"}`}}
+	r, err := NewResolver(&Config{Client: cli, VersionStage: "AWSPENDING"})
+	require.NoError(t, err)
+
+	value, err := r.Resolve(ctx, "prod/openai#api_key")
+	require.NoError(t, err)
+	assert.Equal(t, "sk-aws", value)
+	assert.Equal(t, "AWSPENDING", *cli.inputs[0].VersionStage)
+
+	value, err = r.Resolve(ctx, "binary")
+	require.NoError(t, err)
+	assert.Equal(t, "bytes", value)
+
+	_, err = r.Resolve(ctx, "missing")
+	assert.ErrorIs(t, err, secrets.ErrNotFound)
+	_, err = r.Resolve(ctx, "broken")
+	assert.ErrorContains(t, err, "throttled")
+
+	_, err = NewResolver(&Config{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package secrets
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// NewCachedResolver returns a Resolver caching the secrets resolved by r for ttl, sparing the remote secret managers
+// a request per resolution. The failures are not cached.
+func NewCachedResolver(r Resolver, ttl time.Duration) *CachedResolver {
+	return &CachedResolver{
+		r:       r,
+		ttl:     ttl,
+		entries: make(map[string]*cacheEntry),
+	}
+}
+
+// CachedResolver is a Resolver caching the secrets of another one.
+type CachedResolver struct {
+	r   Resolver
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+type cacheEntry struct {
+	value     string
+	expiresAt time.Time
+}
+
+func (c *CachedResolver) Resolve(ctx context.Context, ref string) (string, error) {
+	c.mu.Lock()
+	e, ok := c.entries[ref]
+	c.mu.Unlock()
+	if ok && time.Now().Before(e.expiresAt) {
+		return e.value, nil
+	}
+
+	value, err := c.r.Resolve(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+	c.mu.Lock()
+	c.entries[ref] = &cacheEntry{value: value, expiresAt: time.Now().Add(c.ttl)}
+	c.mu.Unlock()
+	return value, nil
+}
+
+// Invalidate drops the cached secrets of refs, all of them if refs is empty.
+func (c *CachedResolver) Invalidate(refs ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(refs) == 0 {
+		c.entries = make(map[string]*cacheEntry)
+		return
+	}
+	for _, ref := range refs {
+		delete(c.entries, ref)
+	}
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package secrets
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+// NewChatModel returns a chat model delegating to the chat model built by build, rebuilt when its secrets rotate, e.g.
+//
+//	cm, err := secrets.NewChatModel(ctx, &secrets.RotationConfig{Resolver: resolver, Refs: []string{"openai_api_key"}},
+//		func(ctx context.Context, s map[string]string) (model.BaseChatModel, error) {
+//			return openai.NewChatModel(ctx, &openai.ChatModelConfig{APIKey: s["openai_api_key"], Model: "gpt-4o"})
+//		})
+func NewChatModel(ctx context.Context, config *RotationConfig, build BuildFunc[model.BaseChatModel]) (model.ToolCallingChatModel, error) {
+	r, err := NewRotating(ctx, config, build)
+	if err != nil {
+		return nil, err
+	}
+	return &chatModel{r: r}, nil
+}
+
+type chatModel struct {
+	r     *Rotating[model.BaseChatModel]
+	tools []*schema.ToolInfo
+}
+
+func (c *chatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	return call(ctx, c.r, func(cm model.BaseChatModel) (*schema.Message, error) {
+		cm, err := c.bind(cm)
+		if err != nil {
+			return nil, err
+		}
+		return cm.Generate(ctx, input, opts...)
+	})
+}
+
+func (c *chatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return call(ctx, c.r, func(cm model.BaseChatModel) (*schema.StreamReader[*schema.Message], error) {
+		cm, err := c.bind(cm)
+		if err != nil {
+			return nil, err
+		}
+		return cm.Stream(ctx, input, opts...)
+	})
+}
+
+// WithTools binds the tools to the current chat model and to those rebuilt later.
+func (c *chatModel) WithTools(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	if _, ok := c.r.Get(context.Background()).(model.ToolCallingChatModel); !ok {
+		return nil, errors.New("chat model does not implement ToolCallingChatModel")
+	}
+	return &chatModel{r: c.r, tools: tools}, nil
+}
+
+// IsCallbacksEnabled avoids duplicated callbacks, those of the wrapped chat model being kept.
+func (c *chatModel) IsCallbacksEnabled() bool {
+	return true
+}
+
+func (c *chatModel) bind(cm model.BaseChatModel) (model.BaseChatModel, error) {
+	if c.tools == nil {
+		return cm, nil
+	}
+	tcm, ok := cm.(model.ToolCallingChatModel)
+	if !ok {
+		return nil, errors.New("chat model does not implement ToolCallingChatModel")
+	}
+	return tcm.WithTools(c.tools)
+}
+
+// NewEmbedder returns an embedder delegating to the embedder built by build, rebuilt when its secrets rotate.
+func NewEmbedder(ctx context.Context, config *RotationConfig, build BuildFunc[embedding.Embedder]) (embedding.Embedder, error) {
+	r, err := NewRotating(ctx, config, build)
+	if err != nil {
+		return nil, err
+	}
+	return &embedder{r: r}, nil
+}
+
+type embedder struct {
+	r *Rotating[embedding.Embedder]
+}
+
+func (e *embedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	return call(ctx, e.r, func(emb embedding.Embedder) ([][]float64, error) {
+		return emb.EmbedStrings(ctx, texts, opts...)
+	})
+}
+
+// IsCallbacksEnabled avoids duplicated callbacks, those of the wrapped embedder being kept.
+func (e *embedder) IsCallbacksEnabled() bool {
+	return true
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// NewEnvResolver returns a Resolver reading the secrets from the environment variables, the name of a secret being
+// upper cased and prefixed with prefix, e.g. "openai_api_key" read from OPENAI_API_KEY with an empty prefix.
+func NewEnvResolver(prefix string) Resolver {
+	return ResolverFunc(func(_ context.Context, ref string) (string, error) {
+		return resolveRef(ref, func(name string) (string, error) {
+			key := prefix + strings.ToUpper(strings.NewReplacer("-", "_", ".", "_", "/", "_").Replace(name))
+			value, ok := os.LookupEnv(key)
+			if !ok {
+				return "", fmt.Errorf("environment variable %s: %w", key, ErrNotFound)
+			}
+			return value, nil
+		})
+	})
+}
+
+// NewFileResolver returns a Resolver reading the secrets from the files of dir, e.g. the secrets mounted by
+// kubernetes or docker, the trailing new lines of the files being trimmed.
+func NewFileResolver(dir string) Resolver {
+	return ResolverFunc(func(_ context.Context, ref string) (string, error) {
+		return resolveRef(ref, func(name string) (string, error) {
+			if !filepath.IsLocal(name) {
+				return "", fmt.Errorf("invalid secret file name: %s", name)
+			}
+			data, err := os.ReadFile(filepath.Join(dir, name))
+			if errors.Is(err, os.ErrNotExist) {
+				return "", fmt.Errorf("secret file %s: %w", name, ErrNotFound)
+			}
+			if err != nil {
+				return "", fmt.Errorf("read secret file fail: %w", err)
+			}
+			return strings.TrimRight(string(data), "\r\n"), nil
+		})
+	})
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/flow/secrets"
+)
+
+var errUnauthorized = errors.New("401 unauthorized")
+
+func main() {
+	ctx := context.Background()
+
+	// a mounted secret file, rotated below
+	dir, err := os.MkdirTemp("", "secrets")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	keyFile := filepath.Join(dir, "api_key")
+	if err = os.WriteFile(keyFile, []byte("sk-1\n"), 0o600); err != nil {
+		log.Fatal(err)
+	}
+	validKey := "sk-1"
+
+	resolver := secrets.NewMux(map[string]secrets.Resolver{
+		"file": secrets.NewFileResolver(dir),
+	}, secrets.NewEnvResolver(""))
+
+	cm, err := secrets.NewChatModel(ctx, &secrets.RotationConfig{
+		Resolver:  resolver,
+		Refs:      []string{"file:api_key"},
+		Interval:  time.Hour,
+		RefreshOn: func(err error) bool { return errors.Is(err, errUnauthorized) },
+	}, func(_ context.Context, s map[string]string) (model.BaseChatModel, error) {
+		// replace with a real chat model, e.g. openai.NewChatModel with APIKey: s["file:api_key"]
+		return &keyModel{key: s["file:api_key"], valid: func() string { return validKey }}, nil
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	out, err := cm.Generate(ctx, []*schema.Message{schema.UserMessage("hello")})
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("answer: %s", out.Content)
+
+	// the key is rotated and the old one revoked, the next call refreshes the secret
+	if err = os.WriteFile(keyFile, []byte("sk-2\n"), 0o600); err != nil {
+		log.Fatal(err)
+	}
+	validKey = "sk-2"
+	out, err = cm.Generate(ctx, []*schema.Message{schema.UserMessage("hello")})
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("answer after rotation: %s", out.Content)
+}
+
+type keyModel struct {
+	key   string
+	valid func() string
+}
+
+func (k *keyModel) Generate(_ context.Context, _ []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	if k.key != k.valid() {
+		return nil, errUnauthorized
+	}
+	return schema.AssistantMessage("answered with "+k.key, nil), nil
+}
+
+func (k *keyModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	out, err := k.Generate(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return schema.StreamReaderFromArray([]*schema.Message{out}), nil
+}
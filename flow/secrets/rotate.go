@@ -0,0 +1,137 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+)
+
+// BuildFunc builds a component from the values of its secrets, by reference.
+type BuildFunc[T any] func(ctx context.Context, secrets map[string]string) (T, error)
+
+type RotationConfig struct {
+	// Resolver resolves the secrets.
+	// Required
+	Resolver Resolver
+	// Refs are the references of the secrets of the component.
+	// Required
+	Refs []string
+	// Interval is the period of the checks of the secrets, the component being rebuilt when they changed.
+	// Optional. Default: 5m
+	Interval time.Duration
+	// RefreshOn tells the errors of the component caused by a rotated secret, e.g. 401 Unauthorized, upon which the
+	// secrets are checked at once and the call retried if they changed.
+	// Optional
+	RefreshOn func(err error) bool
+}
+
+// Rotating holds a component built from secrets, rebuilt when the secrets rotate.
+type Rotating[T any] struct {
+	config *RotationConfig
+	build  BuildFunc[T]
+
+	mu        sync.Mutex
+	current   T
+	secrets   map[string]string
+	checkedAt time.Time
+}
+
+// NewRotating resolves the secrets and builds the component, failing if either fails.
+func NewRotating[T any](ctx context.Context, config *RotationConfig, build BuildFunc[T]) (*Rotating[T], error) {
+	if config == nil || config.Resolver == nil {
+		return nil, errors.New("resolver is required")
+	}
+	if len(config.Refs) == 0 {
+		return nil, errors.New("secret refs are required")
+	}
+	if build == nil {
+		return nil, errors.New("build func is required")
+	}
+	r := &Rotating[T]{config: config, build: build}
+	if r.config.Interval <= 0 {
+		cp := *config
+		cp.Interval = 5 * time.Minute
+		r.config = &cp
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, err := r.refresh(ctx); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Get returns the current component, checking the secrets first if Interval elapsed since the last check.
+// A failed check keeps the current component, retried at the next Interval.
+func (r *Rotating[T]) Get(ctx context.Context) T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if time.Since(r.checkedAt) >= r.config.Interval {
+		_, _ = r.refresh(ctx)
+	}
+	return r.current
+}
+
+// Refresh checks the secrets at once, rebuilding the component if they changed, and tells whether it was rebuilt.
+func (r *Rotating[T]) Refresh(ctx context.Context) (bool, error) {
+	if inv, ok := r.config.Resolver.(interface{ Invalidate(refs ...string) }); ok {
+		inv.Invalidate(r.config.Refs...)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.refresh(ctx)
+}
+
+func (r *Rotating[T]) refresh(ctx context.Context) (bool, error) {
+	r.checkedAt = time.Now()
+	secrets := make(map[string]string, len(r.config.Refs))
+	for _, ref := range r.config.Refs {
+		value, err := r.config.Resolver.Resolve(ctx, ref)
+		if err != nil {
+			return false, fmt.Errorf("resolve secret %s fail: %w", ref, err)
+		}
+		secrets[ref] = value
+	}
+	if r.secrets != nil && maps.Equal(secrets, r.secrets) {
+		return false, nil
+	}
+
+	current, err := r.build(ctx, secrets)
+	if err != nil {
+		return false, fmt.Errorf("build with rotated secrets fail: %w", err)
+	}
+	r.current, r.secrets = current, secrets
+	return true, nil
+}
+
+// call runs fn with the current component, running it again with the rebuilt component if it fails with an error
+// telling a rotated secret.
+func call[T, R any](ctx context.Context, r *Rotating[T], fn func(T) (R, error)) (R, error) {
+	out, err := fn(r.Get(ctx))
+	if err == nil || r.config.RefreshOn == nil || !r.config.RefreshOn(err) {
+		return out, err
+	}
+	if rebuilt, refreshErr := r.Refresh(ctx); refreshErr != nil || !rebuilt {
+		return out, err
+	}
+	return fn(r.Get(ctx))
+}
@@ -0,0 +1,181 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package secrets
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errUnauthorized = errors.New("401 unauthorized")
+
+// rotatingStore is a secret store whose secrets are rotated by the tests.
+type rotatingStore struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func (s *rotatingStore) Resolve(_ context.Context, ref string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, ok := s.values[ref]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return value, nil
+}
+
+func (s *rotatingStore) set(ref, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[ref] = value
+}
+
+// keyModel is a chat model accepting only the valid api key, answering with its key and its tools.
+type keyModel struct {
+	key   string
+	valid func() string
+	tools []*schema.ToolInfo
+}
+
+func (k *keyModel) Generate(_ context.Context, _ []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	if k.key != k.valid() {
+		return nil, errUnauthorized
+	}
+	content := k.key
+	for _, t := range k.tools {
+		content += "+" + t.Name
+	}
+	return schema.AssistantMessage(content, nil), nil
+}
+
+func (k *keyModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	out, err := k.Generate(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return schema.StreamReaderFromArray([]*schema.Message{out}), nil
+}
+
+func (k *keyModel) WithTools(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	return &keyModel{key: k.key, valid: k.valid, tools: tools}, nil
+}
+
+func TestRotating(t *testing.T) {
+	ctx := context.Background()
+	store := &rotatingStore{values: map[string]string{"key": "v1"}}
+	builds := 0
+
+	r, err := NewRotating(ctx, &RotationConfig{Resolver: store, Refs: []string{"key"}, Interval: time.Millisecond},
+		func(_ context.Context, s map[string]string) (string, error) {
+			builds++
+			return "client-" + s["key"], nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, "client-v1", r.Get(ctx))
+
+	time.Sleep(2 * time.Millisecond)
+	assert.Equal(t, "client-v1", r.Get(ctx))
+	assert.Equal(t, 1, builds, "unchanged secrets are not rebuilt")
+
+	store.set("key", "v2")
+	time.Sleep(2 * time.Millisecond)
+	assert.Equal(t, "client-v2", r.Get(ctx))
+	assert.Equal(t, 2, builds)
+
+	store.set("key", "")
+	delete(store.values, "key")
+	time.Sleep(2 * time.Millisecond)
+	assert.Equal(t, "client-v2", r.Get(ctx), "a failed check keeps the current component")
+	_, err = r.Refresh(ctx)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = NewRotating(ctx, &RotationConfig{Resolver: store, Refs: []string{"missing"}},
+		func(context.Context, map[string]string) (string, error) { return "", nil })
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestChatModel(t *testing.T) {
+	ctx := context.Background()
+	store := &rotatingStore{values: map[string]string{"openai_api_key": "sk-1"}}
+	valid := "sk-1"
+	cached := NewCachedResolver(store, time.Hour)
+
+	cm, err := NewChatModel(ctx, &RotationConfig{
+		Resolver:  cached,
+		Refs:      []string{"openai_api_key"},
+		Interval:  time.Hour,
+		RefreshOn: func(err error) bool { return errors.Is(err, errUnauthorized) },
+	}, func(_ context.Context, s map[string]string) (model.BaseChatModel, error) {
+		return &keyModel{key: s["openai_api_key"], valid: func() string { return valid }}, nil
+	})
+	require.NoError(t, err)
+
+	out, err := cm.Generate(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "sk-1", out.Content)
+
+	withTools, err := cm.WithTools([]*schema.ToolInfo{{Name: "search"}})
+	require.NoError(t, err)
+
+	// the key is rotated, the old one being revoked: the unauthorized call refreshes the secret and is retried
+	store.set("openai_api_key", "sk-2")
+	valid = "sk-2"
+	out, err = withTools.Generate(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "sk-2+search", out.Content)
+
+	sr, err := cm.Stream(ctx, nil)
+	require.NoError(t, err)
+	chunk, err := sr.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "sk-2", chunk.Content)
+
+	// the secret did not change, the error is returned
+	valid = "sk-3"
+	_, err = cm.Generate(ctx, nil)
+	assert.ErrorIs(t, err, errUnauthorized)
+}
+
+type keyEmbedder struct {
+	key string
+}
+
+func (k *keyEmbedder) EmbedStrings(_ context.Context, texts []string, _ ...embedding.Option) ([][]float64, error) {
+	return [][]float64{{float64(len(k.key))}}, nil
+}
+
+func TestEmbedder(t *testing.T) {
+	ctx := context.Background()
+	store := &rotatingStore{values: map[string]string{"key": "abc"}}
+	emb, err := NewEmbedder(ctx, &RotationConfig{Resolver: store, Refs: []string{"key"}},
+		func(_ context.Context, s map[string]string) (embedding.Embedder, error) {
+			return &keyEmbedder{key: s["key"]}, nil
+		})
+	require.NoError(t, err)
+	out, err := emb.EmbedStrings(ctx, []string{"x"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]float64{{3}}, out)
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package secrets resolves the secrets of component configs, e.g. API keys, from the environment, files, Vault, or
+// cloud secret managers, and rebuilds long-lived components when their secrets rotate.
+//
+// A secret is referenced by name, "name#field" selecting a field of a secret holding a json object.
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bytedance/sonic"
+)
+
+// ErrNotFound is returned by a Resolver for an unknown secret.
+var ErrNotFound = errors.New("secret not found")
+
+// Resolver resolves the value of a secret by its reference.
+type Resolver interface {
+	Resolve(ctx context.Context, ref string) (string, error)
+}
+
+// ResolverFunc is a function Resolver.
+type ResolverFunc func(ctx context.Context, ref string) (string, error)
+
+func (f ResolverFunc) Resolve(ctx context.Context, ref string) (string, error) {
+	return f(ctx, ref)
+}
+
+// SplitRef splits a reference into the name of a secret and the field selected, empty if none.
+func SplitRef(ref string) (name, field string) {
+	name, field, _ = strings.Cut(ref, "#")
+	return name, field
+}
+
+// Field returns the field of a secret holding a json object, the secret itself if field is empty.
+func Field(secret, field string) (string, error) {
+	if field == "" {
+		return secret, nil
+	}
+	var obj map[string]any
+	if err := sonic.UnmarshalString(secret, &obj); err != nil {
+		return "", fmt.Errorf("secret is not a json object: %w", err)
+	}
+	v, ok := obj[field]
+	if !ok {
+		return "", fmt.Errorf("field %s: %w", field, ErrNotFound)
+	}
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	data, err := sonic.MarshalString(v)
+	if err != nil {
+		return "", fmt.Errorf("marshal secret field fail: %w", err)
+	}
+	return data, nil
+}
+
+// resolveRef resolves the secret of ref with get, selecting its field.
+func resolveRef(ref string, get func(name string) (string, error)) (string, error) {
+	name, field := SplitRef(ref)
+	if name == "" {
+		return "", errors.New("secret name is empty")
+	}
+	secret, err := get(name)
+	if err != nil {
+		return "", err
+	}
+	return Field(secret, field)
+}
+
+// NewMux returns a Resolver dispatching the references by their "scheme:" prefix, e.g. "vault:kv/openai#api_key"
+// to resolvers["vault"], the references without a known prefix going to fallback, if not nil.
+func NewMux(resolvers map[string]Resolver, fallback Resolver) Resolver {
+	return ResolverFunc(func(ctx context.Context, ref string) (string, error) {
+		if scheme, rest, ok := strings.Cut(ref, ":"); ok {
+			if r, ok := resolvers[scheme]; ok {
+				return r.Resolve(ctx, rest)
+			}
+		}
+		if fallback == nil {
+			return "", fmt.Errorf("no resolver of secret %s: %w", ref, ErrNotFound)
+		}
+		return fallback.Resolve(ctx, ref)
+	})
+}
+
+// NewChain returns a Resolver trying the resolvers in order, until one does not fail with ErrNotFound.
+func NewChain(resolvers ...Resolver) Resolver {
+	return ResolverFunc(func(ctx context.Context, ref string) (string, error) {
+		for _, r := range resolvers {
+			value, err := r.Resolve(ctx, ref)
+			if !errors.Is(err, ErrNotFound) {
+				return value, err
+			}
+		}
+		return "", fmt.Errorf("secret %s: %w", ref, ErrNotFound)
+	})
+}
@@ -0,0 +1,152 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package secrets
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvers(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("EINO_OPENAI_API_KEY", "sk-env")
+	t.Setenv("EINO_DB", `{"user": "admin", "port": 5432}`)
+
+	env := NewEnvResolver("EINO_")
+	value, err := env.Resolve(ctx, "openai-api_key")
+	require.NoError(t, err)
+	assert.Equal(t, "sk-env", value)
+	value, err = env.Resolve(ctx, "db#user")
+	require.NoError(t, err)
+	assert.Equal(t, "admin", value)
+	value, err = env.Resolve(ctx, "db#port")
+	require.NoError(t, err)
+	assert.Equal(t, "5432", value)
+	_, err = env.Resolve(ctx, "db#password")
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = env.Resolve(ctx, "missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "openai_api_key"), []byte("sk-file\n"), 0o600))
+	file := NewFileResolver(dir)
+	value, err = file.Resolve(ctx, "openai_api_key")
+	require.NoError(t, err)
+	assert.Equal(t, "sk-file", value)
+	_, err = file.Resolve(ctx, "missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = file.Resolve(ctx, "../etc/passwd")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrNotFound)
+
+	chain := NewChain(file, env)
+	value, err = chain.Resolve(ctx, "openai_api_key")
+	require.NoError(t, err)
+	assert.Equal(t, "sk-file", value)
+	value, err = chain.Resolve(ctx, "db#user")
+	require.NoError(t, err)
+	assert.Equal(t, "admin", value)
+	_, err = chain.Resolve(ctx, "missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	mux := NewMux(map[string]Resolver{"file": file}, env)
+	value, err = mux.Resolve(ctx, "file:openai_api_key")
+	require.NoError(t, err)
+	assert.Equal(t, "sk-file", value)
+	value, err = mux.Resolve(ctx, "openai_api_key")
+	require.NoError(t, err)
+	assert.Equal(t, "sk-env", value)
+	_, err = NewMux(nil, nil).Resolve(ctx, "x")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestCachedResolver(t *testing.T) {
+	ctx := context.Background()
+	calls := 0
+	r := NewCachedResolver(ResolverFunc(func(_ context.Context, ref string) (string, error) {
+		calls++
+		return ref + "-value", nil
+	}), time.Hour)
+
+	for i := 0; i < 3; i++ {
+		value, err := r.Resolve(ctx, "a")
+		require.NoError(t, err)
+		assert.Equal(t, "a-value", value)
+	}
+	assert.Equal(t, 1, calls)
+
+	r.Invalidate("a")
+	_, _ = r.Resolve(ctx, "a")
+	assert.Equal(t, 2, calls)
+	r.Invalidate()
+	_, _ = r.Resolve(ctx, "a")
+	assert.Equal(t, 3, calls)
+}
+
+func TestVaultResolver(t *testing.T) {
+	ctx := context.Background()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" || r.Header.Get("X-Vault-Namespace") != "team" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/kv/data/apps/openai":
+			_, _ = w.Write([]byte(`{"data": {"data": {"api_key": "sk-vault"}, "metadata": {"version": 3}}}`))
+		case "/v1/kv1/apps/openai":
+			_, _ = w.Write([]byte(`{"data": {"api_key": "sk-vault-v1"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors": []}`))
+		}
+	}))
+	defer srv.Close()
+
+	r, err := NewVaultResolver(&VaultConfig{Address: srv.URL + "/", Token: "token", Namespace: "team", Mount: "kv"})
+	require.NoError(t, err)
+	value, err := r.Resolve(ctx, "apps/openai#api_key")
+	require.NoError(t, err)
+	assert.Equal(t, "sk-vault", value)
+	value, err = r.Resolve(ctx, "apps/openai")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"api_key": "sk-vault"}`, value)
+	_, err = r.Resolve(ctx, "apps/missing#api_key")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	v1, err := NewVaultResolver(&VaultConfig{Address: srv.URL, Token: "token", Namespace: "team", Mount: "kv1", KVVersion: 1})
+	require.NoError(t, err)
+	value, err = v1.Resolve(ctx, "apps/openai#api_key")
+	require.NoError(t, err)
+	assert.Equal(t, "sk-vault-v1", value)
+
+	forbidden, err := NewVaultResolver(&VaultConfig{Address: srv.URL, Token: "bad"})
+	require.NoError(t, err)
+	_, err = forbidden.Resolve(ctx, "apps/openai")
+	assert.ErrorContains(t, err, "status: 403")
+
+	_, err = NewVaultResolver(&VaultConfig{Address: srv.URL})
+	assert.Error(t, err)
+	_, err = NewVaultResolver(&VaultConfig{Address: srv.URL, Token: "token", KVVersion: 3})
+	assert.Error(t, err)
+}
@@ -0,0 +1,157 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/bytedance/sonic"
+)
+
+type VaultConfig struct {
+	// Address is the url of the vault server, e.g. https://vault.example.com:8200.
+	// Required
+	Address string
+	// Token authenticates the requests.
+	// Required
+	Token string
+	// Namespace is the vault enterprise namespace.
+	// Optional
+	Namespace string
+	// Mount is the mount path of the KV secrets engine.
+	// Optional. Default: secret
+	Mount string
+	// KVVersion is the version of the KV secrets engine, 1 or 2.
+	// Optional. Default: 2
+	KVVersion int
+	// Timeout of the requests.
+	// Optional. Default: 10s
+	Timeout time.Duration
+	// HTTPClient sends the requests, Timeout being ignored when set.
+	// Optional
+	HTTPClient *http.Client
+}
+
+// NewVaultResolver returns a Resolver reading the secrets from the KV secrets engine of HashiCorp Vault, the name of a
+// secret being its path in the engine, e.g. "openai#api_key". A secret is the json object of the data at its path.
+func NewVaultResolver(config *VaultConfig) (Resolver, error) {
+	if config == nil || config.Address == "" {
+		return nil, errors.New("vault address is required")
+	}
+	if config.Token == "" {
+		return nil, errors.New("vault token is required")
+	}
+	v := &vault{
+		address:   strings.TrimRight(config.Address, "/"),
+		token:     config.Token,
+		namespace: config.Namespace,
+		mount:     strings.Trim(config.Mount, "/"),
+		kvVersion: config.KVVersion,
+		cli:       config.HTTPClient,
+	}
+	if v.mount == "" {
+		v.mount = "secret"
+	}
+	if v.kvVersion == 0 {
+		v.kvVersion = 2
+	}
+	if v.kvVersion != 1 && v.kvVersion != 2 {
+		return nil, fmt.Errorf("unsupported vault kv version: %d", v.kvVersion)
+	}
+	if v.cli == nil {
+		timeout := config.Timeout
+		if timeout == 0 {
+			timeout = 10 * time.Second
+		}
+		v.cli = &http.Client{Timeout: timeout}
+	}
+	return v, nil
+}
+
+type vault struct {
+	address   string
+	token     string
+	namespace string
+	mount     string
+	kvVersion int
+	cli       *http.Client
+}
+
+func (v *vault) Resolve(ctx context.Context, ref string) (string, error) {
+	return resolveRef(ref, func(name string) (string, error) {
+		return v.read(ctx, name)
+	})
+}
+
+func (v *vault) read(ctx context.Context, path string) (string, error) {
+	u := v.address + "/v1/" + v.mount + "/"
+	if v.kvVersion == 2 {
+		u += "data/"
+	}
+	u += (&url.URL{Path: strings.Trim(path, "/")}).EscapedPath()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return "", fmt.Errorf("create vault request fail: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", v.token)
+	if v.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.namespace)
+	}
+
+	resp, err := v.cli.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("vault request fail: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("read vault response fail: %w", err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("vault secret %s: %w", path, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault request fail, status: %d, body: %s", resp.StatusCode, body)
+	}
+
+	var out struct {
+		Data map[string]any `json:"data"`
+	}
+	if err = sonic.Unmarshal(body, &out); err != nil {
+		return "", fmt.Errorf("unmarshal vault response fail: %w", err)
+	}
+	data := out.Data
+	if v.kvVersion == 2 {
+		inner, _ := data["data"].(map[string]any)
+		data = inner
+	}
+	if data == nil {
+		return "", fmt.Errorf("vault secret %s: %w", path, ErrNotFound)
+	}
+	secret, err := sonic.MarshalString(data)
+	if err != nil {
+		return "", fmt.Errorf("marshal vault secret fail: %w", err)
+	}
+	return secret, nil
+}
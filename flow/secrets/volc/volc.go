@@ -0,0 +1,106 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package volc resolves the secrets of the Volcengine KMS secrets manager.
+package volc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/volcengine/volcengine-go-sdk/service/kms"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/credentials"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/request"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/session"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/volcengineerr"
+
+	"github.com/cloudwego/eino-ext/flow/secrets"
+)
+
+// Client is the subset of *kms.KMS used by the resolver.
+type Client interface {
+	GetSecretValueWithContext(ctx volcengine.Context, input *kms.GetSecretValueInput,
+		opts ...request.Option) (*kms.GetSecretValueOutput, error)
+}
+
+type Config struct {
+	// Client reads the secrets, built from Region, AccessKey and SecretKey when nil.
+	// Optional
+	Client Client
+	// Region of the KMS service, e.g. cn-beijing.
+	// Required without Client
+	Region string
+	// AccessKey and SecretKey authenticate the requests.
+	// Required without Client
+	AccessKey string
+	SecretKey string
+}
+
+// NewResolver returns a secrets.Resolver reading the secrets from the Volcengine KMS secrets manager, the name of a
+// secret being its name, e.g. "openai#api_key" for the api_key field of the json secret openai.
+func NewResolver(config *Config) (secrets.Resolver, error) {
+	if config == nil {
+		return nil, errors.New("config is required")
+	}
+	cli := config.Client
+	if cli == nil {
+		if config.Region == "" || config.AccessKey == "" || config.SecretKey == "" {
+			return nil, errors.New("region, access key and secret key are required without client")
+		}
+		sess, err := session.NewSession(volcengine.NewConfig().
+			WithRegion(config.Region).
+			WithCredentials(credentials.NewStaticCredentials(config.AccessKey, config.SecretKey, "")))
+		if err != nil {
+			return nil, fmt.Errorf("create volcengine session fail: %w", err)
+		}
+		cli = kms.New(sess)
+	}
+	return &resolver{cli: cli}, nil
+}
+
+type resolver struct {
+	cli Client
+}
+
+func (r *resolver) Resolve(ctx context.Context, ref string) (string, error) {
+	name, field := secrets.SplitRef(ref)
+	if name == "" {
+		return "", errors.New("secret name is empty")
+	}
+
+	out, err := r.cli.GetSecretValueWithContext(ctx, &kms.GetSecretValueInput{SecretName: &name})
+	if err != nil {
+		if isNotFound(err) {
+			return "", fmt.Errorf("volcengine secret %s: %w", name, secrets.ErrNotFound)
+		}
+		return "", fmt.Errorf("get volcengine secret value fail: %w", err)
+	}
+	if out.SecretValue == nil {
+		return "", fmt.Errorf("volcengine secret %s has no value: %w", name, secrets.ErrNotFound)
+	}
+	return secrets.Field(*out.SecretValue, field)
+}
+
+func isNotFound(err error) bool {
+	var failure volcengineerr.RequestFailure
+	if errors.As(err, &failure) && failure.StatusCode() == 404 {
+		return true
+	}
+	var volcErr volcengineerr.Error
+	return errors.As(err, &volcErr) && strings.Contains(volcErr.Code(), "NotFound")
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package volc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volcengine/volcengine-go-sdk/service/kms"
+	"github.com/volcengine/volcengine-go-sdk/volcengine"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/request"
+	"github.com/volcengine/volcengine-go-sdk/volcengine/volcengineerr"
+
+	"github.com/cloudwego/eino-ext/flow/secrets"
+)
+
+type fakeClient struct {
+	secrets map[string]string
+}
+
+func (f *fakeClient) GetSecretValueWithContext(_ volcengine.Context, input *kms.GetSecretValueInput,
+	_ ...request.Option) (*kms.GetSecretValueOutput, error) {
+	if *input.SecretName == "broken" {
+		return nil, errors.New("throttled")
+	}
+	value, ok := f.secrets[*input.SecretName]
+	if !ok {
+		return nil, volcengineerr.NewRequestFailure(volcengineerr.New("InvalidSecret.NotFound", "not found", nil), 404, "req")
+	}
+	return &kms.GetSecretValueOutput{SecretValue: &value}, nil
+}
+
+func TestResolver(t *testing.T) {
+	ctx := context.Background()
+	r, err := NewResolver(&Config{Client: &fakeClient{secrets: map[string]string{
+		"openai": `{"api_key": "sk-volc"}`,
+		"plain":  "value",
+	}}})
+	require.NoError(t, err)
+
+	value, err := r.Resolve(ctx, "openai#api_key")
+	require.NoError(t, err)
+	assert.Equal(t, "sk-volc", value)
+	value, err = r.Resolve(ctx, "plain")
+	require.NoError(t, err)
+	assert.Equal(t, "value", value)
+
+	_, err = r.Resolve(ctx, "missing")
+	assert.ErrorIs(t, err, secrets.ErrNotFound)
+	_, err = r.Resolve(ctx, "broken")
+	assert.ErrorContains(t, err, "throttled")
+
+	_, err = NewResolver(&Config{Region: "cn-beijing"})
+	assert.Error(t, err)
+	_, err = NewResolver(&Config{Region: "cn-beijing", AccessKey: "ak", SecretKey: "sk"})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,184 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package semcache provides a semantic cache: the answers are cached by the meaning of the queries,
+// so that a query similar enough to a previous one gets its answer without calling the chat model.
+package semcache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cloudwego/eino/components/embedding"
+)
+
+const defaultThreshold = 0.9
+
+// Entry is a cached answer.
+type Entry struct {
+	// ID identifies the entry, derived from the namespace and the query.
+	ID string
+	// Namespace scopes the entry, only the lookups of the same namespace are served its answer, see WithNamespace.
+	Namespace string
+	// Query is the query the answer was given to.
+	Query string
+	// Vector is the embedding of the query, nil when the cache has no embedder.
+	Vector []float64
+	// Answer is the cached answer.
+	Answer string
+	// CreatedAt is when the answer was cached.
+	CreatedAt time.Time
+	// Score is the similarity of the entry to the looked up query, set by Store.Search.
+	Score float64
+}
+
+// Store keeps the entries of the cache, see NewInMemoryStore and NewRetrieverStore.
+type Store interface {
+	// Search returns the entry of the namespace most similar to the query whose score is at least threshold, or nil.
+	// vector is the embedding of the query, nil when the cache has no embedder.
+	Search(ctx context.Context, namespace, query string, vector []float64, threshold float64) (*Entry, error)
+	// Put adds the entry, replacing the entry of the same ID.
+	Put(ctx context.Context, entry *Entry) error
+}
+
+type Config struct {
+	// Embedder embeds the queries.
+	// Optional for the stores embedding the queries themselves, e.g. NewRetrieverStore.
+	Embedder embedding.Embedder
+	// Store keeps the entries.
+	// Optional. Default: NewInMemoryStore(0)
+	Store Store
+	// Threshold is the minimum similarity of a cached query to the looked up one.
+	// Optional. Default: 0.9
+	Threshold float64
+	// TTL is how long an answer can be served, older answers being stale and ignored.
+	// Optional. Default: 0, answers never get stale
+	TTL time.Duration
+}
+
+// Cache looks up the answers of the queries similar to previous ones.
+type Cache struct {
+	embedder  embedding.Embedder
+	store     Store
+	threshold float64
+	ttl       time.Duration
+}
+
+func NewCache(_ context.Context, config *Config) (*Cache, error) {
+	if config == nil {
+		return nil, errors.New("config is required")
+	}
+	if config.Threshold < 0 || config.Threshold > 1 {
+		return nil, errors.New("threshold must be between 0 and 1")
+	}
+
+	c := &Cache{
+		embedder:  config.Embedder,
+		store:     config.Store,
+		threshold: config.Threshold,
+		ttl:       config.TTL,
+	}
+	if c.store == nil {
+		if c.embedder == nil {
+			return nil, errors.New("embedder is required by the in-memory store")
+		}
+		c.store = NewInMemoryStore(0)
+	}
+	if c.threshold == 0 {
+		c.threshold = defaultThreshold
+	}
+
+	return c, nil
+}
+
+// Option is an option of Lookup and Put.
+type Option func(o *cacheOptions)
+
+type cacheOptions struct {
+	Namespace string
+}
+
+// WithNamespace scopes the lookup or the answer cached to namespace, e.g. the instructions and the tools
+// of the chat model answering, so that the same query gets different answers in different namespaces.
+func WithNamespace(namespace string) Option {
+	return func(o *cacheOptions) {
+		o.Namespace = namespace
+	}
+}
+
+func getCacheOptions(opts []Option) *cacheOptions {
+	o := &cacheOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// Lookup returns the fresh entry whose query is the most similar to query, or nil on a miss.
+func (c *Cache) Lookup(ctx context.Context, query string, opts ...Option) (*Entry, error) {
+	o := getCacheOptions(opts)
+	vector, err := c.embed(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := c.store.Search(ctx, o.Namespace, query, vector, c.threshold)
+	if err != nil {
+		return nil, fmt.Errorf("search cache fail: %w", err)
+	}
+	if entry == nil || (c.ttl > 0 && time.Since(entry.CreatedAt) > c.ttl) {
+		return nil, nil
+	}
+	return entry, nil
+}
+
+// Put caches the answer to query.
+func (c *Cache) Put(ctx context.Context, query, answer string, opts ...Option) error {
+	o := getCacheOptions(opts)
+	vector, err := c.embed(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	err = c.store.Put(ctx, &Entry{
+		ID:        entryID(o.Namespace, query),
+		Namespace: o.Namespace,
+		Query:     query,
+		Vector:    vector,
+		Answer:    answer,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("put cache fail: %w", err)
+	}
+	return nil
+}
+
+func (c *Cache) embed(ctx context.Context, query string) ([]float64, error) {
+	if c.embedder == nil {
+		return nil, nil
+	}
+
+	vectors, err := c.embedder.EmbedStrings(ctx, []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("embed query fail: %w", err)
+	}
+	if len(vectors) != 1 {
+		return nil, fmt.Errorf("embed query fail: got %d vectors", len(vectors))
+	}
+	return vectors[0], nil
+}
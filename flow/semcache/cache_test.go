@@ -0,0 +1,218 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package semcache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEmbedder embeds the queries with the vectors of the map, unknown queries being orthogonal to all of them.
+type fakeEmbedder struct {
+	vectors map[string][]float64
+	calls   int
+	err     error
+}
+
+func (f *fakeEmbedder) EmbedStrings(_ context.Context, texts []string, _ ...embedding.Option) ([][]float64, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	res := make([][]float64, 0, len(texts))
+	for _, t := range texts {
+		v, ok := f.vectors[t]
+		if !ok {
+			v = []float64{0, 0, 1}
+		}
+		res = append(res, v)
+	}
+	return res, nil
+}
+
+func newTestEmbedder() *fakeEmbedder {
+	return &fakeEmbedder{vectors: map[string][]float64{
+		"how do I reset my password?":       {1, 0, 0},
+		"how can I reset my password":       {0.99, 0.1, 0},
+		"how do I delete my account?":       {0, 1, 0},
+		"how do I delete my account please": {0.1, 0.99, 0},
+	}}
+}
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+	_, err := NewCache(ctx, &Config{})
+	assert.Error(t, err)
+	_, err = NewCache(ctx, &Config{Embedder: newTestEmbedder(), Threshold: 2})
+	assert.Error(t, err)
+
+	emb := newTestEmbedder()
+	c, err := NewCache(ctx, &Config{Embedder: emb, TTL: time.Hour})
+	require.NoError(t, err)
+
+	entry, err := c.Lookup(ctx, "how do I reset my password?")
+	require.NoError(t, err)
+	assert.Nil(t, entry)
+
+	require.NoError(t, c.Put(ctx, "how do I reset my password?", "Click on forgot password."))
+	require.NoError(t, c.Put(ctx, "how do I delete my account?", "Go to settings."))
+
+	entry, err = c.Lookup(ctx, "how can I reset my password")
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	assert.Equal(t, "Click on forgot password.", entry.Answer)
+	assert.Equal(t, "how do I reset my password?", entry.Query)
+	assert.InDelta(t, 0.99, entry.Score, 0.01)
+
+	entry, err = c.Lookup(ctx, "what is the weather?")
+	require.NoError(t, err)
+	assert.Nil(t, entry)
+
+	t.Run("stale", func(t *testing.T) {
+		store := NewInMemoryStore(0)
+		require.NoError(t, store.Put(ctx, &Entry{
+			ID:        entryID("", "how do I reset my password?"),
+			Vector:    []float64{1, 0, 0},
+			Answer:    "old",
+			CreatedAt: time.Now().Add(-2 * time.Hour),
+		}))
+		c, err := NewCache(ctx, &Config{Embedder: emb, Store: store, TTL: time.Hour})
+		require.NoError(t, err)
+		entry, err := c.Lookup(ctx, "how do I reset my password?")
+		require.NoError(t, err)
+		assert.Nil(t, entry)
+
+		require.NoError(t, c.Put(ctx, "how do I reset my password?", "new"))
+		entry, err = c.Lookup(ctx, "how do I reset my password?")
+		require.NoError(t, err)
+		assert.Equal(t, "new", entry.Answer)
+		assert.Len(t, store.entries, 1, "the entry of the same query is replaced")
+	})
+
+	t.Run("namespace", func(t *testing.T) {
+		require.NoError(t, c.Put(ctx, "how do I reset my password?", "Ask an admin.", WithNamespace("admin")))
+
+		entry, err := c.Lookup(ctx, "how can I reset my password")
+		require.NoError(t, err)
+		assert.Equal(t, "Click on forgot password.", entry.Answer)
+		entry, err = c.Lookup(ctx, "how can I reset my password", WithNamespace("admin"))
+		require.NoError(t, err)
+		assert.Equal(t, "Ask an admin.", entry.Answer)
+		assert.Equal(t, "admin", entry.Namespace)
+		entry, err = c.Lookup(ctx, "how can I reset my password", WithNamespace("guest"))
+		require.NoError(t, err)
+		assert.Nil(t, entry)
+	})
+
+	t.Run("embed error", func(t *testing.T) {
+		emb.err = errors.New("unavailable")
+		defer func() { emb.err = nil }()
+		_, err = c.Lookup(ctx, "how do I reset my password?")
+		assert.ErrorContains(t, err, "embed query fail")
+	})
+}
+
+func TestInMemoryStoreEviction(t *testing.T) {
+	ctx := context.Background()
+	s := NewInMemoryStore(2)
+	for i, v := range [][]float64{{1, 0}, {0, 1}, {1, 1}} {
+		require.NoError(t, s.Put(ctx, &Entry{ID: string(rune('a' + i)), Vector: v}))
+	}
+	assert.Len(t, s.entries, 2)
+	e, err := s.Search(ctx, "", "", []float64{1, 0}, 0.99)
+	require.NoError(t, err)
+	assert.Nil(t, e, "the oldest entry was evicted")
+
+	_, err = s.Search(ctx, "", "", nil, 0.9)
+	assert.Error(t, err)
+	assert.Error(t, s.Put(ctx, &Entry{ID: "x"}))
+	assert.Equal(t, 0.0, cosine([]float64{1}, []float64{1, 0}))
+}
+
+type fakeRetriever struct {
+	docs []*schema.Document
+}
+
+func (f *fakeRetriever) Retrieve(_ context.Context, query string, opts ...retriever.Option) ([]*schema.Document, error) {
+	o := retriever.GetCommonOptions(&retriever.Options{}, opts...)
+	var res []*schema.Document
+	for _, d := range f.docs {
+		if d.Content == query && (o.ScoreThreshold == nil || d.Score() >= *o.ScoreThreshold) {
+			res = append(res, d)
+		}
+	}
+	return res, nil
+}
+
+type fakeIndexer struct {
+	r *fakeRetriever
+}
+
+func (f *fakeIndexer) Store(_ context.Context, docs []*schema.Document, _ ...indexer.Option) ([]string, error) {
+	ids := make([]string, 0, len(docs))
+	for _, d := range docs {
+		f.r.docs = append(f.r.docs, d.WithScore(0.95))
+		ids = append(ids, d.ID)
+	}
+	return ids, nil
+}
+
+func TestRetrieverStore(t *testing.T) {
+	ctx := context.Background()
+	r := &fakeRetriever{}
+	store, err := NewRetrieverStore(&RetrieverStoreConfig{Retriever: r, Indexer: &fakeIndexer{r: r}})
+	require.NoError(t, err)
+	_, err = NewRetrieverStore(&RetrieverStoreConfig{Retriever: r})
+	assert.Error(t, err)
+
+	c, err := NewCache(ctx, &Config{Store: store})
+	require.NoError(t, err)
+
+	require.NoError(t, c.Put(ctx, "hello", "hi"))
+	require.Len(t, r.docs, 1)
+	assert.Equal(t, "hi", r.docs[0].MetaData[MetaKeyAnswer])
+
+	entry, err := c.Lookup(ctx, "hello")
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	assert.Equal(t, "hi", entry.Answer)
+	assert.Equal(t, 0.95, entry.Score)
+	assert.WithinDuration(t, time.Now(), entry.CreatedAt, time.Minute)
+
+	require.NoError(t, c.Put(ctx, "hello", "hi admin", WithNamespace("admin")))
+	assert.Equal(t, "admin", r.docs[1].MetaData[MetaKeyNamespace])
+	entry, err = c.Lookup(ctx, "hello", WithNamespace("admin"))
+	require.NoError(t, err)
+	assert.Equal(t, "hi admin", entry.Answer)
+	entry, err = c.Lookup(ctx, "hello", WithNamespace("guest"))
+	require.NoError(t, err)
+	assert.Nil(t, entry)
+
+	c, err = NewCache(ctx, &Config{Store: store, Threshold: 0.99})
+	require.NoError(t, err)
+	entry, err = c.Lookup(ctx, "hello")
+	require.NoError(t, err)
+	assert.Nil(t, entry)
+}
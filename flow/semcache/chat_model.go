@@ -0,0 +1,269 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package semcache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+// ExtraKeyCacheHit marks, in the Extra of a message, the answers served from the cache.
+const ExtraKeyCacheHit = "_eino_semcache_hit"
+
+type options struct {
+	Bypass bool
+}
+
+// WithBypass skips the cache for the call to a chat model wrapped with NewChatModel, neither reading nor writing it.
+func WithBypass() model.Option {
+	return model.WrapImplSpecificOptFn(func(o *options) {
+		o.Bypass = true
+	})
+}
+
+// QueryFunc returns the query of the input to cache the answer of, false if the answer must not be cached.
+type QueryFunc func(input []*schema.Message) (string, bool)
+
+// LastUserQuery is the default QueryFunc, it caches the answers to single question inputs: the content of the
+// user message when it is the only non-system message of the input.
+// The answers depending on a conversation history are not cached, as the same question can have different answers.
+// The system messages are not part of the query: NewChatModel caches the answers per system messages and tools.
+func LastUserQuery(input []*schema.Message) (string, bool) {
+	var query *schema.Message
+	for _, msg := range input {
+		if msg.Role == schema.System {
+			continue
+		}
+		if query != nil || msg.Role != schema.User {
+			return "", false
+		}
+		query = msg
+	}
+	if query == nil || query.Content == "" || len(query.MultiContent) > 0 {
+		return "", false
+	}
+	return query.Content, true
+}
+
+// NewChatModel wraps cm so that the answers are served from the cache when a similar query was answered before.
+// Only the answers without tool calls are cached, in the namespace of the system messages of the input and of the tools
+// of the chat model, so that the chat models of different instructions or tools sharing the cache do not get the answers
+// of each other. A lookup error fails the call, use WithBypass to skip the cache, while the answer is returned
+// when caching it fails.
+func NewChatModel(cm model.BaseChatModel, cache *Cache, query QueryFunc) (model.ToolCallingChatModel, error) {
+	if cm == nil {
+		return nil, errors.New("chat model is required")
+	}
+	if cache == nil {
+		return nil, errors.New("cache is required")
+	}
+	if query == nil {
+		query = LastUserQuery
+	}
+	return &chatModel{cm: cm, cache: cache, query: query}, nil
+}
+
+type chatModel struct {
+	cm    model.BaseChatModel
+	cache *Cache
+	query QueryFunc
+	tools []*schema.ToolInfo
+}
+
+func (c *chatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	key, hit, err := c.lookup(ctx, input, opts)
+	if err != nil {
+		return nil, err
+	}
+	if hit != nil {
+		return hit, nil
+	}
+
+	out, err := c.cm.Generate(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	c.put(ctx, key, out)
+	return out, nil
+}
+
+func (c *chatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	key, hit, err := c.lookup(ctx, input, opts)
+	if err != nil {
+		return nil, err
+	}
+	if hit != nil {
+		return schema.StreamReaderFromArray([]*schema.Message{hit}), nil
+	}
+
+	sr, err := c.cm.Stream(ctx, input, opts...)
+	if err != nil || key == nil {
+		return sr, err
+	}
+
+	// the answer is cached once the output is fully received, before the reader gets io.EOF
+	outSR, outSW := schema.Pipe[*schema.Message](1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				outSW.Send(nil, fmt.Errorf("panic in semantic cache chat model stream: %v", p))
+			}
+			sr.Close()
+			outSW.Close()
+		}()
+
+		var chunks []*schema.Message
+		for {
+			chunk, err := sr.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				outSW.Send(nil, err)
+				return
+			}
+			chunks = append(chunks, chunk)
+			if closed := outSW.Send(chunk, nil); closed {
+				return
+			}
+		}
+
+		out, err := schema.ConcatMessages(chunks)
+		if err != nil {
+			log.Printf("[semcache] concat stream output fail: %v", err)
+			return
+		}
+		c.put(ctx, key, out)
+	}()
+
+	return outSR, nil
+}
+
+func (c *chatModel) WithTools(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	tcm, ok := c.cm.(model.ToolCallingChatModel)
+	if !ok {
+		return nil, errors.New("chat model does not implement ToolCallingChatModel")
+	}
+	withTools, err := tcm.WithTools(tools)
+	if err != nil {
+		return nil, err
+	}
+	return &chatModel{cm: withTools, cache: c.cache, query: c.query, tools: tools}, nil
+}
+
+// IsCallbacksEnabled avoids duplicated callbacks, those of the wrapped chat model being kept.
+func (c *chatModel) IsCallbacksEnabled() bool {
+	return true
+}
+
+// cacheKey is the query of an input and the namespace of its answer.
+type cacheKey struct {
+	query     string
+	namespace string
+}
+
+// lookup returns the key of the input, nil if it must not be cached, and the cached answer on a hit.
+func (c *chatModel) lookup(ctx context.Context, input []*schema.Message, opts []model.Option) (*cacheKey, *schema.Message, error) {
+	if model.GetImplSpecificOptions(&options{}, opts...).Bypass {
+		return nil, nil, nil
+	}
+	query, ok := c.query(input)
+	if !ok || query == "" {
+		return nil, nil, nil
+	}
+	tools := c.tools
+	if o := model.GetCommonOptions(&model.Options{}, opts...); o.Tools != nil {
+		tools = o.Tools
+	}
+	ns, err := namespace(input, tools)
+	if err != nil {
+		// the answers of an input whose context cannot be identified are not cached
+		return nil, nil, nil
+	}
+	key := &cacheKey{query: query, namespace: ns}
+
+	entry, err := c.cache.Lookup(ctx, query, WithNamespace(ns))
+	if err != nil {
+		return nil, nil, err
+	}
+	if entry == nil {
+		return key, nil, nil
+	}
+
+	return key, &schema.Message{
+		Role:    schema.Assistant,
+		Content: entry.Answer,
+		Extra:   map[string]any{ExtraKeyCacheHit: true},
+	}, nil
+}
+
+// put caches the answer, logging the cache errors as the answer is given anyway.
+func (c *chatModel) put(ctx context.Context, key *cacheKey, out *schema.Message) {
+	if key == nil || len(out.ToolCalls) > 0 || out.Content == "" {
+		return
+	}
+	if err := c.cache.Put(ctx, key.query, out.Content, WithNamespace(key.namespace)); err != nil {
+		log.Printf("[semcache] put answer fail: %v", err)
+	}
+}
+
+// namespace hashes the system messages of the input and the tools, empty when there are none.
+func namespace(input []*schema.Message, tools []*schema.ToolInfo) (string, error) {
+	type tool struct {
+		Name   string `json:"name"`
+		Desc   string `json:"desc"`
+		Params any    `json:"params,omitempty"`
+	}
+	var ctx struct {
+		System []*schema.Message `json:"system,omitempty"`
+		Tools  []*tool           `json:"tools,omitempty"`
+	}
+	for _, msg := range input {
+		if msg.Role == schema.System {
+			ctx.System = append(ctx.System, msg)
+		}
+	}
+	for _, ti := range tools {
+		t := &tool{Name: ti.Name, Desc: ti.Desc}
+		if ti.ParamsOneOf != nil {
+			params, err := ti.ParamsOneOf.ToJSONSchema()
+			if err != nil {
+				return "", err
+			}
+			t.Params = params
+		}
+		ctx.Tools = append(ctx.Tools, t)
+	}
+	if len(ctx.System) == 0 && len(ctx.Tools) == 0 {
+		return "", nil
+	}
+
+	b, err := json.Marshal(ctx)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
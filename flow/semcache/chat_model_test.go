@@ -0,0 +1,193 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package semcache
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeChatModel struct {
+	calls int
+	reply *schema.Message
+}
+
+func (f *fakeChatModel) Generate(_ context.Context, _ []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	f.calls++
+	return f.reply, nil
+}
+
+func (f *fakeChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	out, _ := f.Generate(ctx, input, opts...)
+	half := len(out.Content) / 2
+	return schema.StreamReaderFromArray([]*schema.Message{
+		schema.AssistantMessage(out.Content[:half], out.ToolCalls),
+		schema.AssistantMessage(out.Content[half:], nil),
+	}), nil
+}
+
+func (f *fakeChatModel) WithTools(_ []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	return f, nil
+}
+
+// failingStore fails to put the entries.
+type failingStore struct {
+	*InMemoryStore
+}
+
+func (f *failingStore) Put(_ context.Context, _ *Entry) error {
+	return errors.New("store unavailable")
+}
+
+func readAll(t *testing.T, sr *schema.StreamReader[*schema.Message]) []*schema.Message {
+	defer sr.Close()
+	var msgs []*schema.Message
+	for {
+		msg, err := sr.Recv()
+		if errors.Is(err, io.EOF) {
+			return msgs
+		}
+		require.NoError(t, err)
+		msgs = append(msgs, msg)
+	}
+}
+
+func TestChatModel(t *testing.T) {
+	ctx := context.Background()
+	c, err := NewCache(ctx, &Config{Embedder: newTestEmbedder()})
+	require.NoError(t, err)
+	inner := &fakeChatModel{reply: schema.AssistantMessage("Click on forgot password.", nil)}
+	cm, err := NewChatModel(inner, c, nil)
+	require.NoError(t, err)
+
+	system := schema.SystemMessage("You are a support agent.")
+	out, err := cm.Generate(ctx, []*schema.Message{system, schema.UserMessage("how do I reset my password?")})
+	require.NoError(t, err)
+	assert.Nil(t, out.Extra)
+	assert.Equal(t, 1, inner.calls)
+
+	out, err = cm.Generate(ctx, []*schema.Message{system, schema.UserMessage("how can I reset my password")})
+	require.NoError(t, err)
+	assert.Equal(t, "Click on forgot password.", out.Content)
+	assert.Equal(t, true, out.Extra[ExtraKeyCacheHit])
+	assert.Equal(t, 1, inner.calls)
+
+	t.Run("stream", func(t *testing.T) {
+		sr, err := cm.Stream(ctx, []*schema.Message{system, schema.UserMessage("how can I reset my password")})
+		require.NoError(t, err)
+		msgs := readAll(t, sr)
+		assert.Len(t, msgs, 1)
+		assert.Equal(t, 1, inner.calls)
+
+		inner.reply = schema.AssistantMessage("Go to settings.", nil)
+		sr, err = cm.Stream(ctx, []*schema.Message{system, schema.UserMessage("how do I delete my account?")})
+		require.NoError(t, err)
+		assert.Len(t, readAll(t, sr), 2)
+		assert.Equal(t, 2, inner.calls)
+
+		out, err = cm.Generate(ctx, []*schema.Message{system, schema.UserMessage("how do I delete my account please")})
+		require.NoError(t, err)
+		assert.Equal(t, "Go to settings.", out.Content)
+		assert.Equal(t, 2, inner.calls)
+	})
+
+	t.Run("not cached", func(t *testing.T) {
+		// bypass
+		_, err = cm.Generate(ctx, []*schema.Message{schema.UserMessage("how do I reset my password?")}, WithBypass())
+		require.NoError(t, err)
+		assert.Equal(t, 3, inner.calls)
+
+		// conversation history
+		_, err = cm.Generate(ctx, []*schema.Message{
+			schema.UserMessage("hi"), schema.AssistantMessage("hello", nil), schema.UserMessage("how do I reset my password?"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 4, inner.calls)
+
+		// tool calls
+		inner.reply = schema.AssistantMessage("", []schema.ToolCall{{ID: "1", Function: schema.FunctionCall{Name: "weather"}}})
+		for i := 0; i < 2; i++ {
+			_, err = cm.Generate(ctx, []*schema.Message{schema.UserMessage("what is the weather?")})
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 6, inner.calls)
+	})
+}
+
+func TestChatModelNamespace(t *testing.T) {
+	ctx := context.Background()
+	c, err := NewCache(ctx, &Config{Embedder: newTestEmbedder()})
+	require.NoError(t, err)
+	inner := &fakeChatModel{reply: schema.AssistantMessage("Click on forgot password.", nil)}
+	cm, err := NewChatModel(inner, c, nil)
+	require.NoError(t, err)
+
+	generate := func(cm model.BaseChatModel, system string, opts ...model.Option) *schema.Message {
+		out, err := cm.Generate(ctx, []*schema.Message{schema.SystemMessage(system), schema.UserMessage("how do I reset my password?")}, opts...)
+		require.NoError(t, err)
+		return out
+	}
+
+	generate(cm, "You are a support agent.")
+	generate(cm, "You are an admin assistant.")
+	assert.Equal(t, 2, inner.calls, "the answers of other system messages are not served")
+	assert.Equal(t, true, generate(cm, "You are a support agent.").Extra[ExtraKeyCacheHit])
+	assert.Equal(t, 2, inner.calls)
+
+	withTools, err := cm.WithTools([]*schema.ToolInfo{{Name: "reset_password", Desc: "Resets the password of the user."}})
+	require.NoError(t, err)
+	generate(withTools, "You are a support agent.")
+	assert.Equal(t, 3, inner.calls, "the answers of the chat model without tools are not served")
+	assert.Equal(t, true, generate(withTools, "You are a support agent.").Extra[ExtraKeyCacheHit])
+
+	generate(cm, "You are a support agent.", model.WithTools([]*schema.ToolInfo{{Name: "search"}}))
+	assert.Equal(t, 4, inner.calls, "the tools of the call are part of the namespace")
+
+	t.Run("put error", func(t *testing.T) {
+		c, err := NewCache(ctx, &Config{Embedder: newTestEmbedder(), Store: &failingStore{NewInMemoryStore(0)}})
+		require.NoError(t, err)
+		cm, err := NewChatModel(inner, c, nil)
+		require.NoError(t, err)
+
+		out, err := cm.Generate(ctx, []*schema.Message{schema.UserMessage("how do I reset my password?")})
+		require.NoError(t, err)
+		assert.Equal(t, "Click on forgot password.", out.Content)
+
+		sr, err := cm.Stream(ctx, []*schema.Message{schema.UserMessage("how do I reset my password?")})
+		require.NoError(t, err)
+		assert.Len(t, readAll(t, sr), 2)
+	})
+}
+
+func TestLastUserQuery(t *testing.T) {
+	q, ok := LastUserQuery([]*schema.Message{schema.SystemMessage("s"), schema.UserMessage("q")})
+	assert.True(t, ok)
+	assert.Equal(t, "q", q)
+
+	_, ok = LastUserQuery([]*schema.Message{schema.SystemMessage("s")})
+	assert.False(t, ok)
+	_, ok = LastUserQuery([]*schema.Message{schema.UserMessage("a"), schema.UserMessage("b")})
+	assert.False(t, ok)
+	_, ok = LastUserQuery([]*schema.Message{{Role: schema.User, MultiContent: []schema.ChatMessagePart{{Type: schema.ChatMessagePartTypeText, Text: "x"}}}})
+	assert.False(t, ok)
+}
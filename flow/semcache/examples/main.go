@@ -0,0 +1,88 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/flow/semcache"
+)
+
+func main() {
+	ctx := context.Background()
+
+	cache, err := semcache.NewCache(ctx, &semcache.Config{
+		Embedder:  &wordsEmbedder{},
+		Threshold: 0.8,
+		TTL:       time.Hour,
+	})
+	if err != nil {
+		log.Fatalf("Failed to create cache: %v", err)
+	}
+
+	// replace with any chat model, e.g. openai or ark
+	cm, err := semcache.NewChatModel(&supportModel{}, cache, nil)
+	if err != nil {
+		log.Fatalf("Failed to create chat model: %v", err)
+	}
+
+	for _, question := range []string{"how do I reset my password", "how can I reset my password"} {
+		out, err := cm.Generate(ctx, []*schema.Message{schema.UserMessage(question)})
+		if err != nil {
+			log.Fatalf("Failed to generate: %v", err)
+		}
+		hit, _ := out.Extra[semcache.ExtraKeyCacheHit].(bool)
+		fmt.Printf("%s -> %s (cache hit: %v)\n", question, out.Content, hit)
+	}
+}
+
+// wordsEmbedder embeds a text with the counts of a few words, replace with a real embedder.
+type wordsEmbedder struct{}
+
+func (w *wordsEmbedder) EmbedStrings(_ context.Context, texts []string, _ ...embedding.Option) ([][]float64, error) {
+	vocabulary := []string{"reset", "password", "delete", "account", "how"}
+	res := make([][]float64, 0, len(texts))
+	for _, text := range texts {
+		v := make([]float64, len(vocabulary))
+		for i, word := range vocabulary {
+			v[i] = float64(strings.Count(text, word))
+		}
+		res = append(res, v)
+	}
+	return res, nil
+}
+
+type supportModel struct{}
+
+func (s *supportModel) Generate(_ context.Context, _ []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	return schema.AssistantMessage("Click on 'Forgot password' on the login page.", nil), nil
+}
+
+func (s *supportModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	out, err := s.Generate(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return schema.StreamReaderFromArray([]*schema.Message{out}), nil
+}
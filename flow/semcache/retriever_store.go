@@ -0,0 +1,118 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package semcache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	// MetaKeyAnswer and MetaKeyCreatedAt are the metadata keys of the answer and of its creation time,
+	// in RFC 3339 format, of the documents stored by the store from NewRetrieverStore.
+	MetaKeyAnswer    = "semcache_answer"
+	MetaKeyCreatedAt = "semcache_created_at"
+	// MetaKeyNamespace is the metadata key of the namespace of the documents, absent for the default namespace.
+	MetaKeyNamespace = "semcache_namespace"
+)
+
+// retrieverTopK is the number of documents searched for one of the namespace looked up,
+// as the retrievers do not filter the metadata.
+const retrieverTopK = 5
+
+type RetrieverStoreConfig struct {
+	// Retriever searches the cached queries, its scores must be similarities, the higher the more similar.
+	// Required
+	Retriever retriever.Retriever
+	// Indexer stores the cached queries, as documents whose content is the query, into the store read by Retriever.
+	// Required
+	Indexer indexer.Indexer
+}
+
+// NewRetrieverStore returns a Store backed by a vector store through its eino retriever and indexer,
+// e.g. redis, milvus or elasticsearch, which embed the queries themselves so the cache needs no embedder.
+// The metadata of the documents must be stored and retrieved by the indexer and the retriever.
+func NewRetrieverStore(config *RetrieverStoreConfig) (Store, error) {
+	if config == nil {
+		return nil, errors.New("config is required")
+	}
+	if config.Retriever == nil || config.Indexer == nil {
+		return nil, errors.New("retriever and indexer are required")
+	}
+	return &retrieverStore{retriever: config.Retriever, indexer: config.Indexer}, nil
+}
+
+type retrieverStore struct {
+	retriever retriever.Retriever
+	indexer   indexer.Indexer
+}
+
+func (r *retrieverStore) Search(ctx context.Context, namespace, query string, _ []float64, threshold float64) (*Entry, error) {
+	docs, err := r.retriever.Retrieve(ctx, query, retriever.WithTopK(retrieverTopK), retriever.WithScoreThreshold(threshold))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, doc := range docs {
+		// not every retriever applies the score threshold
+		if doc.Score() < threshold {
+			continue
+		}
+		answer, ok := doc.MetaData[MetaKeyAnswer].(string)
+		if !ok {
+			continue
+		}
+		if ns, _ := doc.MetaData[MetaKeyNamespace].(string); ns != namespace {
+			continue
+		}
+		e := &Entry{
+			ID:        doc.ID,
+			Namespace: namespace,
+			Query:     doc.Content,
+			Answer:    answer,
+			Score:     doc.Score(),
+		}
+		if createdAt, ok := doc.MetaData[MetaKeyCreatedAt].(string); ok {
+			if e.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+				return nil, fmt.Errorf("parse %s fail: %w", MetaKeyCreatedAt, err)
+			}
+		}
+		return e, nil
+	}
+	return nil, nil
+}
+
+func (r *retrieverStore) Put(ctx context.Context, entry *Entry) error {
+	meta := map[string]any{
+		MetaKeyAnswer:    entry.Answer,
+		MetaKeyCreatedAt: entry.CreatedAt.UTC().Format(time.RFC3339Nano),
+	}
+	if entry.Namespace != "" {
+		meta[MetaKeyNamespace] = entry.Namespace
+	}
+	_, err := r.indexer.Store(ctx, []*schema.Document{{
+		ID:       entry.ID,
+		Content:  entry.Query,
+		MetaData: meta,
+	}})
+	return err
+}
@@ -0,0 +1,118 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package semcache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math"
+	"sync"
+)
+
+// InMemoryStore is a Store keeping the entries in process memory, searched by the cosine similarity of their vectors.
+type InMemoryStore struct {
+	maxEntries int
+
+	mu      sync.RWMutex
+	entries []*Entry
+}
+
+var _ Store = (*InMemoryStore)(nil)
+
+// NewInMemoryStore returns an InMemoryStore keeping at most maxEntries entries, the oldest ones being evicted,
+// a non-positive maxEntries meaning no limit.
+func NewInMemoryStore(maxEntries int) *InMemoryStore {
+	return &InMemoryStore{maxEntries: maxEntries}
+}
+
+func (s *InMemoryStore) Search(_ context.Context, namespace, _ string, vector []float64, threshold float64) (*Entry, error) {
+	if len(vector) == 0 {
+		return nil, errors.New("in-memory store requires the query vector")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var (
+		best      *Entry
+		bestScore float64
+	)
+	for _, e := range s.entries {
+		if e.Namespace != namespace {
+			continue
+		}
+		score := cosine(vector, e.Vector)
+		if score >= threshold && (best == nil || score > bestScore) {
+			best, bestScore = e, score
+		}
+	}
+	if best == nil {
+		return nil, nil
+	}
+
+	hit := *best
+	hit.Score = bestScore
+	return &hit, nil
+}
+
+func (s *InMemoryStore) Put(_ context.Context, entry *Entry) error {
+	if len(entry.Vector) == 0 {
+		return errors.New("in-memory store requires the query vector")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, e := range s.entries {
+		if e.ID == entry.ID {
+			s.entries = append(s.entries[:i], s.entries[i+1:]...)
+			break
+		}
+	}
+	s.entries = append(s.entries, entry)
+	if s.maxEntries > 0 && len(s.entries) > s.maxEntries {
+		s.entries = s.entries[len(s.entries)-s.maxEntries:]
+	}
+	return nil
+}
+
+func cosine(a, b []float64) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+func entryID(namespace, query string) string {
+	if namespace == "" {
+		sum := sha256.Sum256([]byte(query))
+		return hex.EncodeToString(sum[:])
+	}
+	sum := sha256.Sum256([]byte(namespace + "\x00" + query))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/cloudwego/eino-ext/flow/state"
+)
+
+// orderState is the state of an ordering agent, shared by the replicas serving the session.
+type orderState struct {
+	Items     []string `json:"items"`
+	Confirmed bool     `json:"confirmed"`
+}
+
+func main() {
+	ctx := context.Background()
+
+	// use the redis or mysql store for multi replica deployments
+	store := state.Namespace(state.NewInMemoryStore(0), "order_agent")
+
+	// concurrent turns of the session, e.g. served by different replicas, are all applied
+	var wg sync.WaitGroup
+	for _, item := range []string{"coffee", "croissant", "juice"} {
+		wg.Add(1)
+		go func(item string) {
+			defer wg.Done()
+			_, err := state.Update(ctx, store, "session-1", func(s *orderState) error {
+				s.Items = append(s.Items, item)
+				return nil
+			})
+			if err != nil {
+				log.Printf("update state failed: %v", err)
+			}
+		}(item)
+	}
+	wg.Wait()
+
+	s, version, err := state.Load[orderState](ctx, store, "session-1")
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("items: %v, version: %d\n", s.Items, version)
+
+	// a write based on a stale read is rejected
+	s.Confirmed = true
+	if _, err = state.Save(ctx, store, "session-1", s, version-1); err != nil {
+		fmt.Printf("stale write: %v\n", err)
+	}
+	if _, err = state.Save(ctx, store, "session-1", s, version); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package state
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// InMemoryStore is a Store keeping the states in process memory, for tests and single replica deployments,
+// the states are lost on restart.
+type InMemoryStore struct {
+	mu     sync.Mutex
+	ttl    time.Duration
+	now    func() time.Time
+	states map[string]*memEntry
+}
+
+type memEntry struct {
+	Entry
+	expiresAt time.Time
+}
+
+var _ Store = (*InMemoryStore)(nil)
+
+// NewInMemoryStore returns an InMemoryStore expiring the states ttl after their last write, 0 for no expiration.
+func NewInMemoryStore(ttl time.Duration) *InMemoryStore {
+	return &InMemoryStore{
+		ttl:    ttl,
+		now:    time.Now,
+		states: make(map[string]*memEntry),
+	}
+}
+
+func (s *InMemoryStore) Get(_ context.Context, key string) (*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := s.get(key)
+	if e == nil {
+		return nil, ErrNotFound
+	}
+	return &Entry{Value: append([]byte(nil), e.Value...), Version: e.Version}, nil
+}
+
+func (s *InMemoryStore) CompareAndSwap(_ context.Context, key string, value []byte, version int64) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var cur int64
+	if e := s.get(key); e != nil {
+		cur = e.Version
+	}
+	if cur != version {
+		return 0, ErrConflict
+	}
+
+	e := &memEntry{Entry: Entry{Value: append([]byte(nil), value...), Version: cur + 1}}
+	if s.ttl > 0 {
+		e.expiresAt = s.now().Add(s.ttl)
+	}
+	s.states[key] = e
+	return e.Version, nil
+}
+
+func (s *InMemoryStore) Delete(_ context.Context, key string, version int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if version != 0 {
+		if e := s.get(key); e == nil || e.Version != version {
+			return ErrConflict
+		}
+	}
+	delete(s.states, key)
+	return nil
+}
+
+// get returns the state of the key, expired states being deleted.
+func (s *InMemoryStore) get(key string) *memEntry {
+	e, ok := s.states[key]
+	if !ok {
+		return nil
+	}
+	if !e.expiresAt.IsZero() && !e.expiresAt.After(s.now()) {
+		delete(s.states, key)
+		return nil
+	}
+	return e
+}
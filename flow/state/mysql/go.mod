@@ -0,0 +1,24 @@
+module github.com/cloudwego/eino-ext/flow/state/mysql
+
+go 1.23.0
+
+replace github.com/cloudwego/eino-ext/flow/state => ../
+
+require (
+	github.com/cloudwego/eino-ext/flow/state v0.0.0-00010101000000-000000000000
+	github.com/go-sql-driver/mysql v1.8.1
+	github.com/stretchr/testify v1.10.0
+)
+
+require (
+	filippo.io/edwards25519 v1.1.0 // indirect
+	github.com/bytedance/sonic v1.13.2 // indirect
+	github.com/bytedance/sonic/loader v0.2.4 // indirect
+	github.com/cloudwego/base64x v0.1.5 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/klauspost/cpuid/v2 v2.0.9 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/twitchyliquid64/golang-asm v0.15.1 // indirect
+	golang.org/x/arch v0.0.0-20210923205945-b76863e36670 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
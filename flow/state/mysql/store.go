@@ -0,0 +1,186 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+
+	"github.com/cloudwego/eino-ext/flow/state"
+)
+
+// errDupEntry is the mysql error of a duplicate key.
+const errDupEntry = 1062
+
+type Config struct {
+	// DB is the database, opened with github.com/go-sql-driver/mysql, e.g. sql.Open("mysql", dsn),
+	// whose duplicate key errors are the conflicts of the new states.
+	// Required
+	DB *sql.DB
+	// Table keeping the states, created by CreateTable.
+	// Optional. Default: "eino_states"
+	Table string
+	// TTL expires the states ttl after their last write, expired states being deleted by Cleanup.
+	// Optional. Default: no expiration
+	TTL time.Duration
+}
+
+// Store is a state.Store keeping each state in a row of a mysql table,
+// the versions being compared and swapped by conditional updates.
+type Store struct {
+	db    *sql.DB
+	table string
+	ttl   time.Duration
+	now   func() time.Time
+}
+
+var _ state.Store = (*Store)(nil)
+
+var tableNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+func NewStore(config *Config) (*Store, error) {
+	if config == nil || config.DB == nil {
+		return nil, errors.New("db is required")
+	}
+	table := config.Table
+	if table == "" {
+		table = "eino_states"
+	}
+	if !tableNameRegexp.MatchString(table) {
+		return nil, fmt.Errorf("invalid table name: %s", table)
+	}
+	return &Store{
+		db:    config.DB,
+		table: table,
+		ttl:   config.TTL,
+		now:   time.Now,
+	}, nil
+}
+
+// CreateTable creates the table of the states if not existing.
+func (s *Store) CreateTable(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	id VARCHAR(255) NOT NULL PRIMARY KEY,
+	value LONGBLOB NOT NULL,
+	version BIGINT NOT NULL,
+	updated_at DATETIME(3) NOT NULL,
+	expires_at DATETIME(3) NULL,
+	INDEX idx_expires_at (expires_at)
+)`, s.table))
+	if err != nil {
+		return fmt.Errorf("create state table fail: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) Get(ctx context.Context, key string) (*state.Entry, error) {
+	e := &state.Entry{}
+	err := s.db.QueryRowContext(ctx,
+		fmt.Sprintf(`SELECT value, version FROM %s WHERE id = ? AND (expires_at IS NULL OR expires_at > ?)`, s.table),
+		key, s.now()).Scan(&e.Value, &e.Version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, state.ErrNotFound
+		}
+		return nil, fmt.Errorf("get state fail: %w", err)
+	}
+	return e, nil
+}
+
+func (s *Store) CompareAndSwap(ctx context.Context, key string, value []byte, version int64) (int64, error) {
+	now := s.now()
+	var expiresAt sql.NullTime
+	if s.ttl > 0 {
+		expiresAt = sql.NullTime{Time: now.Add(s.ttl), Valid: true}
+	}
+
+	if version == 0 {
+		// an expired state is as good as none
+		_, err := s.db.ExecContext(ctx,
+			fmt.Sprintf(`DELETE FROM %s WHERE id = ? AND expires_at IS NOT NULL AND expires_at <= ?`, s.table), key, now)
+		if err != nil {
+			return 0, fmt.Errorf("set state fail: %w", err)
+		}
+		// a plain insert fails with a duplicate key if the state exists, whatever the affected rows reported by the dsn,
+		// e.g. with clientFoundRows=true
+		res, err := s.db.ExecContext(ctx, fmt.Sprintf(`INSERT INTO %s (id, value, version, updated_at, expires_at)
+VALUES (?, ?, 1, ?, ?)`, s.table), key, value, now, expiresAt)
+		if isDupEntry(err) {
+			return 0, state.ErrConflict
+		}
+		if err = checkAffected("set", res, err); err != nil {
+			return 0, err
+		}
+		return 1, nil
+	}
+
+	res, err := s.db.ExecContext(ctx, fmt.Sprintf(`UPDATE %s SET value = ?, version = version + 1, updated_at = ?, expires_at = ?
+WHERE id = ? AND version = ? AND (expires_at IS NULL OR expires_at > ?)`, s.table),
+		value, now, expiresAt, key, version, now)
+	if err = checkAffected("set", res, err); err != nil {
+		return 0, err
+	}
+	return version + 1, nil
+}
+
+func (s *Store) Delete(ctx context.Context, key string, version int64) error {
+	if version == 0 {
+		if _, err := s.db.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE id = ?`, s.table), key); err != nil {
+			return fmt.Errorf("delete state fail: %w", err)
+		}
+		return nil
+	}
+
+	res, err := s.db.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE id = ? AND version = ?`, s.table), key, version)
+	return checkAffected("delete", res, err)
+}
+
+// Cleanup deletes the expired states, to be called periodically.
+func (s *Store) Cleanup(ctx context.Context) (int64, error) {
+	res, err := s.db.ExecContext(ctx,
+		fmt.Sprintf(`DELETE FROM %s WHERE expires_at IS NOT NULL AND expires_at <= ?`, s.table), s.now())
+	if err != nil {
+		return 0, fmt.Errorf("cleanup states fail: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+func isDupEntry(err error) bool {
+	var myErr *mysql.MySQLError
+	return errors.As(err, &myErr) && myErr.Number == errDupEntry
+}
+
+// checkAffected returns ErrConflict if the conditional write affected no row.
+func checkAffected(op string, res sql.Result, err error) error {
+	if err != nil {
+		return fmt.Errorf("%s state fail: %w", op, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s state fail: %w", op, err)
+	}
+	if n == 0 {
+		return state.ErrConflict
+	}
+	return nil
+}
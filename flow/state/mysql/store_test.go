@@ -0,0 +1,242 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-ext/flow/state"
+)
+
+// fakeDB interprets the few statements of the store, to test it with no mysql.
+type fakeDB struct {
+	mu      sync.Mutex
+	rows    map[string]*fakeRow
+	queries []string
+}
+
+type fakeRow struct {
+	value     []byte
+	version   int64
+	expiresAt any
+}
+
+func newFakeDB() (*fakeDB, *sql.DB) {
+	f := &fakeDB{rows: map[string]*fakeRow{}}
+	return f, sql.OpenDB(f)
+}
+
+func (f *fakeDB) Connect(context.Context) (driver.Conn, error) { return &fakeConn{db: f}, nil }
+func (f *fakeDB) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *fakeConn) Close() error                        { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (r *fakeRow) expired(now any) bool {
+	return r.expiresAt != nil && !r.expiresAt.(time.Time).After(now.(time.Time))
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	f := c.db
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.queries = append(f.queries, query)
+
+	switch {
+	case strings.HasPrefix(query, "CREATE"):
+		return driver.RowsAffected(0), nil
+	case strings.HasPrefix(query, "INSERT"):
+		id := args[0].Value.(string)
+		if _, ok := f.rows[id]; ok {
+			return nil, &mysql.MySQLError{Number: 1062, Message: "Duplicate entry '" + id + "' for key 'PRIMARY'"}
+		}
+		f.rows[id] = &fakeRow{value: args[1].Value.([]byte), version: 1, expiresAt: args[3].Value}
+		return driver.RowsAffected(1), nil
+	case strings.HasPrefix(query, "UPDATE"):
+		row, ok := f.rows[args[3].Value.(string)]
+		if !ok || row.version != args[4].Value.(int64) || row.expired(args[5].Value) {
+			return driver.RowsAffected(0), nil
+		}
+		row.value, row.version, row.expiresAt = args[0].Value.([]byte), row.version+1, args[2].Value
+		return driver.RowsAffected(1), nil
+	case strings.Contains(query, "WHERE id = ? AND version = ?"):
+		id := args[0].Value.(string)
+		if row, ok := f.rows[id]; !ok || row.version != args[1].Value.(int64) {
+			return driver.RowsAffected(0), nil
+		}
+		delete(f.rows, id)
+		return driver.RowsAffected(1), nil
+	case strings.Contains(query, "WHERE id = ? AND expires_at"):
+		id := args[0].Value.(string)
+		if row, ok := f.rows[id]; ok && row.expired(args[1].Value) {
+			delete(f.rows, id)
+			return driver.RowsAffected(1), nil
+		}
+		return driver.RowsAffected(0), nil
+	case strings.Contains(query, "WHERE id = ?"):
+		delete(f.rows, args[0].Value.(string))
+		return driver.RowsAffected(1), nil
+	case strings.Contains(query, "expires_at <= ?"):
+		var n int64
+		for id, row := range f.rows {
+			if row.expired(args[0].Value) {
+				delete(f.rows, id)
+				n++
+			}
+		}
+		return driver.RowsAffected(n), nil
+	}
+	return nil, errors.New("unexpected query: " + query)
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	f := c.db
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.queries = append(f.queries, query)
+
+	row, ok := f.rows[args[0].Value.(string)]
+	if !ok || row.expired(args[1].Value) {
+		return &fakeRows{}, nil
+	}
+	return &fakeRows{rows: [][]driver.Value{{row.value, row.version}}}, nil
+}
+
+type fakeRows struct {
+	rows [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return []string{"value", "version"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func TestNewStore(t *testing.T) {
+	_, err := NewStore(nil)
+	assert.Error(t, err)
+
+	_, db := newFakeDB()
+	_, err = NewStore(&Config{DB: db, Table: "states; DROP TABLE users"})
+	assert.Error(t, err)
+	_, err = NewStore(&Config{DB: db, Table: "agent.states"})
+	assert.NoError(t, err)
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	f, db := newFakeDB()
+	s, err := NewStore(&Config{DB: db, TTL: time.Hour})
+	assert.NoError(t, err)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	assert.NoError(t, s.CreateTable(ctx))
+	assert.Contains(t, f.queries[0], "CREATE TABLE IF NOT EXISTS eino_states")
+
+	_, err = s.Get(ctx, "k")
+	assert.ErrorIs(t, err, state.ErrNotFound)
+
+	_, err = s.CompareAndSwap(ctx, "k", []byte("a"), 1)
+	assert.ErrorIs(t, err, state.ErrConflict)
+	v, err := s.CompareAndSwap(ctx, "k", []byte("a"), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), v)
+	_, err = s.CompareAndSwap(ctx, "k", []byte("b"), 0)
+	assert.ErrorIs(t, err, state.ErrConflict)
+	v, err = s.CompareAndSwap(ctx, "k", []byte("b"), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), v)
+
+	e, err := s.Get(ctx, "k")
+	assert.NoError(t, err)
+	assert.Equal(t, &state.Entry{Value: []byte("b"), Version: 2}, e)
+
+	assert.ErrorIs(t, s.Delete(ctx, "k", 1), state.ErrConflict)
+	assert.NoError(t, s.Delete(ctx, "k", 2))
+	assert.NoError(t, s.Delete(ctx, "k", 0))
+
+	// expired states are gone, their version starting again
+	_, err = s.CompareAndSwap(ctx, "k", []byte("a"), 0)
+	assert.NoError(t, err)
+	_, err = s.CompareAndSwap(ctx, "other", []byte("a"), 0)
+	assert.NoError(t, err)
+	now = now.Add(time.Hour)
+	_, err = s.Get(ctx, "k")
+	assert.ErrorIs(t, err, state.ErrNotFound)
+	_, err = s.CompareAndSwap(ctx, "k", []byte("a"), 1)
+	assert.ErrorIs(t, err, state.ErrConflict)
+	v, err = s.CompareAndSwap(ctx, "k", []byte("a"), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), v)
+
+	n, err := s.Cleanup(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.Len(t, f.rows, 1)
+}
+
+func TestStore_Update(t *testing.T) {
+	ctx := context.Background()
+	_, db := newFakeDB()
+	s, err := NewStore(&Config{DB: db})
+	assert.NoError(t, err)
+
+	type session struct {
+		Turns int `json:"turns"`
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := state.Update(ctx, state.Namespace(s, "agent"), "s1", func(st *session) error {
+				st.Turns++
+				return nil
+			}, state.WithMaxRetries(50))
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	got, version, err := state.Load[session](ctx, s, "agent:s1")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, got.Turns)
+	assert.Equal(t, int64(5), version)
+}
@@ -0,0 +1,141 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/cloudwego/eino-ext/flow/state"
+)
+
+type Config struct {
+	// Client is the redis client, e.g. redis.NewClient(&redis.Options{Addr: "localhost:6379"}).
+	// Required
+	Client redis.UniversalClient
+	// Prefix of the state keys, namespacing the states of a deployment.
+	// Optional. Default: "eino:state:"
+	Prefix string
+	// TTL expires the states ttl after their last write, with the native expiration of redis.
+	// Optional. Default: no expiration
+	TTL time.Duration
+}
+
+// Store is a state.Store keeping each state in a redis hash of its value and version,
+// the versions being compared and swapped atomically by lua scripts.
+type Store struct {
+	rdb    redis.UniversalClient
+	prefix string
+	ttl    time.Duration
+}
+
+var _ state.Store = (*Store)(nil)
+
+const (
+	fieldValue   = "value"
+	fieldVersion = "version"
+)
+
+// casScript sets the value of KEYS[1] if its version is ARGV[2], returning the new version, or -1 on conflict.
+var casScript = redis.NewScript(`
+local cur = tonumber(redis.call('HGET', KEYS[1], 'version') or '0')
+if cur ~= tonumber(ARGV[2]) then
+	return -1
+end
+redis.call('HSET', KEYS[1], 'value', ARGV[1], 'version', cur + 1)
+if tonumber(ARGV[3]) > 0 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[3])
+end
+return cur + 1
+`)
+
+// deleteScript deletes KEYS[1] if its version is ARGV[1], returning -1 on conflict.
+var deleteScript = redis.NewScript(`
+local cur = tonumber(redis.call('HGET', KEYS[1], 'version') or '0')
+if cur == 0 or cur ~= tonumber(ARGV[1]) then
+	return -1
+end
+return redis.call('DEL', KEYS[1])
+`)
+
+func NewStore(config *Config) (*Store, error) {
+	if config == nil || config.Client == nil {
+		return nil, errors.New("redis client is required")
+	}
+	prefix := "eino:state:"
+	if config.Prefix != "" {
+		prefix = strings.TrimSuffix(config.Prefix, ":") + ":"
+	}
+	return &Store{
+		rdb:    config.Client,
+		prefix: prefix,
+		ttl:    config.TTL,
+	}, nil
+}
+
+func (s *Store) Get(ctx context.Context, key string) (*state.Entry, error) {
+	values, err := s.rdb.HMGet(ctx, s.prefix+key, fieldValue, fieldVersion).Result()
+	if err != nil {
+		return nil, fmt.Errorf("get state fail: %w", err)
+	}
+	value, ok1 := values[0].(string)
+	version, ok2 := values[1].(string)
+	if !ok1 || !ok2 {
+		return nil, state.ErrNotFound
+	}
+
+	v, err := strconv.ParseInt(version, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parse state version fail: %w", err)
+	}
+	return &state.Entry{Value: []byte(value), Version: v}, nil
+}
+
+func (s *Store) CompareAndSwap(ctx context.Context, key string, value []byte, version int64) (int64, error) {
+	v, err := casScript.Run(ctx, s.rdb, []string{s.prefix + key}, value, version, s.ttl.Milliseconds()).Int64()
+	if err != nil {
+		return 0, fmt.Errorf("set state fail: %w", err)
+	}
+	if v < 0 {
+		return 0, state.ErrConflict
+	}
+	return v, nil
+}
+
+func (s *Store) Delete(ctx context.Context, key string, version int64) error {
+	if version == 0 {
+		if err := s.rdb.Del(ctx, s.prefix+key).Err(); err != nil {
+			return fmt.Errorf("delete state fail: %w", err)
+		}
+		return nil
+	}
+
+	n, err := deleteScript.Run(ctx, s.rdb, []string{s.prefix + key}, version).Int64()
+	if err != nil {
+		return fmt.Errorf("delete state fail: %w", err)
+	}
+	if n < 0 {
+		return state.ErrConflict
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package redis
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-ext/flow/state"
+)
+
+// newTestStore returns a Store on an in-process redis running the lua scripts.
+func newTestStore(t *testing.T, ttl time.Duration) (*Store, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	s, err := NewStore(&Config{Client: rdb, Prefix: "st", TTL: ttl})
+	assert.NoError(t, err)
+	return s, mr
+}
+
+func TestNewStore(t *testing.T) {
+	_, err := NewStore(nil)
+	assert.Error(t, err)
+	_, err = NewStore(&Config{})
+	assert.Error(t, err)
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	s, mr := newTestStore(t, 0)
+
+	t.Run("missing key", func(t *testing.T) {
+		_, err := s.Get(ctx, "none")
+		assert.ErrorIs(t, err, state.ErrNotFound)
+		assert.ErrorIs(t, s.Delete(ctx, "none", 1), state.ErrConflict)
+		assert.NoError(t, s.Delete(ctx, "none", 0))
+	})
+
+	t.Run("version conflict", func(t *testing.T) {
+		_, err := s.CompareAndSwap(ctx, "k", []byte("a"), 1)
+		assert.ErrorIs(t, err, state.ErrConflict)
+		v, err := s.CompareAndSwap(ctx, "k", []byte("a"), 0)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), v)
+		_, err = s.CompareAndSwap(ctx, "k", []byte("b"), 0)
+		assert.ErrorIs(t, err, state.ErrConflict)
+		v, err = s.CompareAndSwap(ctx, "k", []byte("b"), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), v)
+		_, err = s.CompareAndSwap(ctx, "k", []byte("c"), 1)
+		assert.ErrorIs(t, err, state.ErrConflict)
+
+		e, err := s.Get(ctx, "k")
+		assert.NoError(t, err)
+		assert.Equal(t, &state.Entry{Value: []byte("b"), Version: 2}, e)
+		assert.Equal(t, "b", mr.HGet("st:k", fieldValue))
+		assert.False(t, mr.TTL("st:k") > 0, "no expiration by default")
+	})
+
+	t.Run("delete with a stale version", func(t *testing.T) {
+		assert.ErrorIs(t, s.Delete(ctx, "k", 1), state.ErrConflict)
+		assert.True(t, mr.Exists("st:k"))
+		assert.NoError(t, s.Delete(ctx, "k", 2))
+		assert.False(t, mr.Exists("st:k"))
+
+		// the version starts again once deleted
+		_, err := s.CompareAndSwap(ctx, "k", []byte("a"), 2)
+		assert.ErrorIs(t, err, state.ErrConflict)
+		v, err := s.CompareAndSwap(ctx, "k", []byte("a"), 0)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), v)
+		assert.NoError(t, s.Delete(ctx, "k", 0))
+		assert.False(t, mr.Exists("st:k"))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mr.SetError("connection refused")
+		defer mr.SetError("")
+		_, err := s.Get(ctx, "k")
+		assert.Error(t, err)
+		_, err = s.CompareAndSwap(ctx, "k", []byte("a"), 0)
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, state.ErrConflict)
+		assert.Error(t, s.Delete(ctx, "k", 1))
+	})
+}
+
+func TestStore_TTL(t *testing.T) {
+	ctx := context.Background()
+	s, mr := newTestStore(t, time.Hour)
+
+	_, err := s.CompareAndSwap(ctx, "k", []byte("a"), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour, mr.TTL("st:k"))
+
+	// each write refreshes the expiration
+	mr.FastForward(40 * time.Minute)
+	assert.Equal(t, 20*time.Minute, mr.TTL("st:k"))
+	_, err = s.CompareAndSwap(ctx, "k", []byte("b"), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour, mr.TTL("st:k"))
+
+	// a conflicting write does not
+	mr.FastForward(40 * time.Minute)
+	_, err = s.CompareAndSwap(ctx, "k", []byte("c"), 1)
+	assert.ErrorIs(t, err, state.ErrConflict)
+	assert.Equal(t, 20*time.Minute, mr.TTL("st:k"))
+
+	mr.FastForward(20 * time.Minute)
+	_, err = s.Get(ctx, "k")
+	assert.ErrorIs(t, err, state.ErrNotFound)
+	v, err := s.CompareAndSwap(ctx, "k", []byte("a"), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), v)
+}
+
+func TestStore_Update(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestStore(t, 0)
+
+	type session struct {
+		Turns int `json:"turns"`
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := state.Update(ctx, state.Namespace(s, "agent"), "s1", func(st *session) error {
+				st.Turns++
+				return nil
+			}, state.WithMaxRetries(50))
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	got, version, err := state.Load[session](ctx, s, "agent:s1")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, got.Turns)
+	assert.Equal(t, int64(5), version)
+}
@@ -0,0 +1,72 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package state provides a store of session states shared by the replicas of a deployment, e.g. the state of
+// multi-turn agents, written with optimistic concurrency.
+package state
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrNotFound is returned when reading the state of a session which has none.
+	ErrNotFound = errors.New("state not found")
+	// ErrConflict is returned when writing a state which was written by someone else since read.
+	ErrConflict = errors.New("state version conflict")
+)
+
+// Entry is the state of a session with its version, incremented by each write.
+type Entry struct {
+	Value   []byte
+	Version int64
+}
+
+// Store keeps the states of sessions, written with optimistic concurrency: a write succeeds only if the state
+// is still at the version it was read at, ErrConflict being returned otherwise.
+type Store interface {
+	// Get returns the state of the session, ErrNotFound if none.
+	Get(ctx context.Context, key string) (*Entry, error)
+	// CompareAndSwap writes the state of the session if still at version, 0 meaning the session has no state yet,
+	// returning the new version.
+	CompareAndSwap(ctx context.Context, key string, value []byte, version int64) (newVersion int64, err error)
+	// Delete deletes the state of the session if still at version, 0 deleting it whatever its version.
+	Delete(ctx context.Context, key string, version int64) error
+}
+
+// Namespace returns a view of the store whose keys are prefixed with the namespace, e.g. the name of the agent,
+// for components to share a store.
+func Namespace(store Store, namespace string) Store {
+	return &namespaced{store: store, prefix: namespace + ":"}
+}
+
+type namespaced struct {
+	store  Store
+	prefix string
+}
+
+func (n *namespaced) Get(ctx context.Context, key string) (*Entry, error) {
+	return n.store.Get(ctx, n.prefix+key)
+}
+
+func (n *namespaced) CompareAndSwap(ctx context.Context, key string, value []byte, version int64) (int64, error) {
+	return n.store.CompareAndSwap(ctx, n.prefix+key, value, version)
+}
+
+func (n *namespaced) Delete(ctx context.Context, key string, version int64) error {
+	return n.store.Delete(ctx, n.prefix+key, version)
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package state
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	s := NewInMemoryStore(time.Hour)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	_, err := s.Get(ctx, "k")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = s.CompareAndSwap(ctx, "k", []byte("a"), 1)
+	assert.ErrorIs(t, err, ErrConflict)
+	v, err := s.CompareAndSwap(ctx, "k", []byte("a"), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), v)
+	_, err = s.CompareAndSwap(ctx, "k", []byte("b"), 0)
+	assert.ErrorIs(t, err, ErrConflict)
+	v, err = s.CompareAndSwap(ctx, "k", []byte("b"), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), v)
+
+	e, err := s.Get(ctx, "k")
+	assert.NoError(t, err)
+	assert.Equal(t, &Entry{Value: []byte("b"), Version: 2}, e)
+
+	assert.ErrorIs(t, s.Delete(ctx, "k", 1), ErrConflict)
+	assert.NoError(t, s.Delete(ctx, "k", 2))
+	assert.ErrorIs(t, s.Delete(ctx, "k", 2), ErrConflict)
+	assert.NoError(t, s.Delete(ctx, "k", 0))
+
+	// expired states are gone, their version starting again
+	_, err = s.CompareAndSwap(ctx, "k", []byte("a"), 0)
+	assert.NoError(t, err)
+	now = now.Add(time.Hour)
+	_, err = s.Get(ctx, "k")
+	assert.ErrorIs(t, err, ErrNotFound)
+	v, err = s.CompareAndSwap(ctx, "k", []byte("a"), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), v)
+}
+
+func TestNamespace(t *testing.T) {
+	ctx := context.Background()
+	s := NewInMemoryStore(0)
+	a, b := Namespace(s, "agent_a"), Namespace(s, "agent_b")
+
+	_, err := a.CompareAndSwap(ctx, "session", []byte("a"), 0)
+	assert.NoError(t, err)
+	_, err = b.Get(ctx, "session")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	e, err := s.Get(ctx, "agent_a:session")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("a"), e.Value)
+
+	assert.NoError(t, a.Delete(ctx, "session", 1))
+	_, err = s.Get(ctx, "agent_a:session")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+type counter struct {
+	Turns   int      `json:"turns"`
+	Replies []string `json:"replies"`
+}
+
+func TestUpdate(t *testing.T) {
+	ctx := context.Background()
+	s := NewInMemoryStore(0)
+
+	state, version, err := Load[counter](ctx, s, "session")
+	assert.NoError(t, err)
+	assert.Equal(t, counter{}, state)
+	assert.Equal(t, int64(0), version)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := Update(ctx, s, "session", func(c *counter) error {
+				c.Turns++
+				c.Replies = append(c.Replies, "hi")
+				return nil
+			}, WithMaxRetries(100))
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	state, version, err = Load[counter](ctx, s, "session")
+	assert.NoError(t, err)
+	assert.Equal(t, 10, state.Turns)
+	assert.Len(t, state.Replies, 10)
+	assert.Equal(t, int64(10), version)
+
+	_, err = Update(ctx, s, "session", func(c *counter) error {
+		return errors.New("boom")
+	})
+	assert.ErrorContains(t, err, "boom")
+
+	// a state written meanwhile on each attempt exhausts the retries
+	calls := 0
+	_, err = Update(ctx, s, "session", func(c *counter) error {
+		calls++
+		_, _ = Update(ctx, s, "session", func(c *counter) error { return nil })
+		return nil
+	}, WithMaxRetries(2))
+	assert.ErrorIs(t, err, ErrConflict)
+	assert.Equal(t, 3, calls)
+
+	_, err = s.CompareAndSwap(ctx, "broken", []byte("{"), 0)
+	assert.NoError(t, err)
+	_, _, err = Load[counter](ctx, s, "broken")
+	assert.Error(t, err)
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bytedance/sonic"
+)
+
+const defaultMaxRetries = 5
+
+type updateOptions struct {
+	maxRetries int
+}
+
+type UpdateOption func(*updateOptions)
+
+// WithMaxRetries sets how many times Update retries on conflicts, default 5.
+func WithMaxRetries(n int) UpdateOption {
+	return func(o *updateOptions) {
+		o.maxRetries = n
+	}
+}
+
+// Load returns the state of a session decoded from json, the zero T at version 0 if the session has none.
+func Load[T any](ctx context.Context, store Store, key string) (state T, version int64, err error) {
+	e, err := store.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return state, 0, nil
+		}
+		return state, 0, err
+	}
+	if err = sonic.Unmarshal(e.Value, &state); err != nil {
+		return state, 0, fmt.Errorf("unmarshal state fail: %w", err)
+	}
+	return state, e.Version, nil
+}
+
+// Save writes the state of a session encoded to json if still at version, returning the new version.
+func Save[T any](ctx context.Context, store Store, key string, state T, version int64) (newVersion int64, err error) {
+	value, err := sonic.Marshal(state)
+	if err != nil {
+		return 0, fmt.Errorf("marshal state fail: %w", err)
+	}
+	return store.CompareAndSwap(ctx, key, value, version)
+}
+
+// Update reads the state of a session, modifies it with fn and writes it back, reading and modifying it again
+// when written by someone else meanwhile. fn may thus be called several times, and must only modify the state.
+func Update[T any](ctx context.Context, store Store, key string, fn func(state *T) error, opts ...UpdateOption) (T, error) {
+	o := &updateOptions{maxRetries: defaultMaxRetries}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	for i := 0; ; i++ {
+		state, version, err := Load[T](ctx, store, key)
+		if err != nil {
+			return state, err
+		}
+		if err = fn(&state); err != nil {
+			return state, err
+		}
+		_, err = Save(ctx, store, key, state, version)
+		if err == nil {
+			return state, nil
+		}
+		if !errors.Is(err, ErrConflict) || i >= o.maxRetries {
+			return state, err
+		}
+		if err = ctx.Err(); err != nil {
+			return state, err
+		}
+	}
+}
@@ -0,0 +1,129 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package streamtee
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+type Config struct {
+	// Observers receive the output chunks of every call, the whole output being a single chunk for Generate.
+	// They are ended with the error of a failed call.
+	Observers []Observer[*schema.Message]
+	// OnStats is called with the stats of every successful call, once its output is fully received.
+	// Optional
+	OnStats func(ctx context.Context, stats *Stats)
+}
+
+// NewChatModel wraps cm so that its outputs are duplicated to the observers of config and its stats measured.
+func NewChatModel(cm model.BaseChatModel, config *Config) (model.ToolCallingChatModel, error) {
+	if cm == nil {
+		return nil, errors.New("chat model is required")
+	}
+	if config == nil {
+		config = &Config{}
+	}
+	return &chatModel{cm: cm, config: config}, nil
+}
+
+type chatModel struct {
+	cm     model.BaseChatModel
+	config *Config
+}
+
+func (c *chatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	meter := NewMeter(time.Now())
+	out, err := c.cm.Generate(ctx, input, opts...)
+	if err != nil {
+		c.end(ctx, err)
+		return nil, err
+	}
+	meter.Observe(out)
+
+	sr := Tee(ctx, schema.StreamReaderFromArray([]*schema.Message{out}), c.observers(ctx, meter)...)
+	sr.Recv()
+	sr.Close()
+	return out, nil
+}
+
+func (c *chatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	meter := NewMeter(time.Now())
+	sr, err := c.cm.Stream(ctx, input, opts...)
+	if err != nil {
+		c.end(ctx, err)
+		return nil, err
+	}
+
+	// the chunks are measured when received from the chat model, not when delivered to the observers
+	measured := schema.StreamReaderWithConvert(sr, func(chunk *schema.Message) (*schema.Message, error) {
+		meter.Observe(chunk)
+		return chunk, nil
+	})
+	return Tee(ctx, measured, c.observers(ctx, meter)...), nil
+}
+
+func (c *chatModel) WithTools(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	tcm, ok := c.cm.(model.ToolCallingChatModel)
+	if !ok {
+		return nil, errors.New("chat model does not implement ToolCallingChatModel")
+	}
+	withTools, err := tcm.WithTools(tools)
+	if err != nil {
+		return nil, err
+	}
+	return &chatModel{cm: withTools, config: c.config}, nil
+}
+
+// IsCallbacksEnabled avoids duplicated callbacks, those of the wrapped chat model being kept.
+func (c *chatModel) IsCallbacksEnabled() bool {
+	return true
+}
+
+func (c *chatModel) observers(ctx context.Context, meter *Meter) []Observer[*schema.Message] {
+	observers := c.config.Observers
+	if c.config.OnStats != nil {
+		// the meter is read once the tee has received the whole stream
+		observers = append(observers[:len(observers):len(observers)], &ObserverFuncs[*schema.Message]{
+			End: func(ctx context.Context, err error) {
+				if err == nil {
+					c.config.OnStats(ctx, meter.Stats())
+				}
+			},
+		})
+	}
+	return observers
+}
+
+// end ends the observers of a failed call.
+func (c *chatModel) end(ctx context.Context, err error) {
+	for _, o := range c.config.Observers {
+		if o != nil {
+			safeEnd(ctx, o, err)
+		}
+	}
+}
+
+func safeEnd(ctx context.Context, o Observer[*schema.Message], err error) {
+	defer func() {
+		_ = recover()
+	}()
+	o.OnEnd(ctx, err)
+}
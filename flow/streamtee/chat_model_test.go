@@ -0,0 +1,221 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package streamtee
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeChatModel struct {
+	chunks []*schema.Message
+	delay  time.Duration
+	err    error
+}
+
+func (f *fakeChatModel) Generate(_ context.Context, _ []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	time.Sleep(f.delay)
+	return schema.ConcatMessages(f.chunks)
+}
+
+func (f *fakeChatModel) Stream(_ context.Context, _ []*schema.Message, _ ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	sr, sw := schema.Pipe[*schema.Message](0)
+	go func() {
+		defer sw.Close()
+		for _, chunk := range f.chunks {
+			time.Sleep(f.delay)
+			if closed := sw.Send(chunk, nil); closed {
+				return
+			}
+		}
+	}()
+	return sr, nil
+}
+
+type messageCollector struct {
+	mu      sync.Mutex
+	content string
+	err     error
+	done    chan struct{}
+}
+
+func (m *messageCollector) OnChunk(_ context.Context, chunk *schema.Message) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.content += chunk.Content
+}
+
+func (m *messageCollector) OnEnd(_ context.Context, err error) {
+	m.err = err
+	close(m.done)
+}
+
+func TestChatModel(t *testing.T) {
+	ctx := context.Background()
+	chunks := []*schema.Message{
+		schema.AssistantMessage("hello", nil),
+		schema.AssistantMessage(" world", nil),
+		{Role: schema.Assistant, ResponseMeta: &schema.ResponseMeta{Usage: &schema.TokenUsage{CompletionTokens: 2}}},
+	}
+
+	_, err := NewChatModel(nil, nil)
+	assert.Error(t, err)
+
+	t.Run("stream", func(t *testing.T) {
+		obs := &messageCollector{done: make(chan struct{})}
+		statsCh := make(chan *Stats, 1)
+		cm, err := NewChatModel(&fakeChatModel{chunks: chunks, delay: 10 * time.Millisecond}, &Config{
+			Observers: []Observer[*schema.Message]{obs},
+			OnStats:   func(_ context.Context, s *Stats) { statsCh <- s },
+		})
+		require.NoError(t, err)
+
+		sr, err := cm.Stream(ctx, []*schema.Message{schema.UserMessage("hi")})
+		require.NoError(t, err)
+		out, err := drain(t, sr)
+		require.NoError(t, err)
+		assert.Len(t, out, 3)
+
+		<-obs.done
+		assert.Equal(t, "hello world", obs.content)
+		assert.NoError(t, obs.err)
+
+		s := <-statsCh
+		assert.Equal(t, 3, s.Chunks)
+		assert.Equal(t, 2, s.CompletionTokens)
+		assert.False(t, s.TokensEstimated)
+		assert.GreaterOrEqual(t, s.FirstTokenLatency, 10*time.Millisecond)
+		assert.GreaterOrEqual(t, s.Duration, 30*time.Millisecond)
+	})
+
+	t.Run("generate", func(t *testing.T) {
+		obs := &messageCollector{done: make(chan struct{})}
+		statsCh := make(chan *Stats, 1)
+		cm, err := NewChatModel(&fakeChatModel{chunks: chunks, delay: 10 * time.Millisecond}, &Config{
+			Observers: []Observer[*schema.Message]{obs},
+			OnStats:   func(_ context.Context, s *Stats) { statsCh <- s },
+		})
+		require.NoError(t, err)
+
+		out, err := cm.Generate(ctx, []*schema.Message{schema.UserMessage("hi")})
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", out.Content)
+
+		<-obs.done
+		assert.Equal(t, "hello world", obs.content)
+		s := <-statsCh
+		assert.Equal(t, s.Duration, s.FirstTokenLatency)
+		assert.Equal(t, 2, s.CompletionTokens)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		obs := &messageCollector{done: make(chan struct{})}
+		cm, err := NewChatModel(&fakeChatModel{err: errors.New("boom")}, &Config{
+			Observers: []Observer[*schema.Message]{obs},
+		})
+		require.NoError(t, err)
+		_, err = cm.Stream(ctx, nil)
+		assert.EqualError(t, err, "boom")
+		<-obs.done
+		assert.EqualError(t, obs.err, "boom")
+	})
+}
+
+func TestWrapHandler(t *testing.T) {
+	info := &callbacks.RunInfo{Name: "cm", Type: "Fake", Component: components.ComponentOfChatModel}
+
+	t.Run("stream", func(t *testing.T) {
+		outCh := make(chan []*model.CallbackOutput, 1)
+		inner := callbacks.NewHandlerBuilder().OnEndWithStreamOutputFn(
+			func(ctx context.Context, _ *callbacks.RunInfo, output *schema.StreamReader[callbacks.CallbackOutput]) context.Context {
+				chunks, err := drain(t, output)
+				assert.NoError(t, err)
+				var outs []*model.CallbackOutput
+				for _, c := range chunks {
+					outs = append(outs, model.ConvCallbackOutput(c))
+				}
+				outCh <- outs
+				return ctx
+			}).Build()
+		h := WrapHandler(inner)
+		checker := h.(callbacks.TimingChecker)
+		assert.True(t, checker.Needed(context.Background(), info, callbacks.TimingOnStart))
+
+		ctx := h.OnStart(context.Background(), info, &model.CallbackInput{})
+		sr, sw := schema.Pipe[callbacks.CallbackOutput](0)
+		go func() {
+			defer sw.Close()
+			time.Sleep(10 * time.Millisecond)
+			sw.Send(&model.CallbackOutput{Message: schema.AssistantMessage("hello", nil)}, nil)
+			sw.Send(schema.AssistantMessage(" world", nil), nil)
+		}()
+		h.OnEndWithStreamOutput(ctx, info, sr)
+
+		outs := <-outCh
+		require.Len(t, outs, 2)
+		assert.Nil(t, outs[0].Extra)
+		s, ok := outs[1].Extra[ExtraKeyStats].(*Stats)
+		require.True(t, ok)
+		assert.Equal(t, 2, s.Chunks)
+		assert.GreaterOrEqual(t, s.FirstTokenLatency, 10*time.Millisecond)
+	})
+
+	t.Run("generate", func(t *testing.T) {
+		var got *model.CallbackOutput
+		h := WrapHandler(callbacks.NewHandlerBuilder().OnEndFn(
+			func(ctx context.Context, _ *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+				got = model.ConvCallbackOutput(output)
+				return ctx
+			}).Build())
+
+		extra := map[string]any{"k": "v"}
+		ctx := h.OnStart(context.Background(), info, &model.CallbackInput{})
+		h.OnEnd(ctx, info, &model.CallbackOutput{Message: schema.AssistantMessage("hello", nil), Extra: extra})
+		require.NotNil(t, got)
+		assert.Equal(t, "v", got.Extra["k"])
+		assert.IsType(t, &Stats{}, got.Extra[ExtraKeyStats])
+		assert.Len(t, extra, 1)
+	})
+
+	t.Run("other components", func(t *testing.T) {
+		toolInfo := &callbacks.RunInfo{Component: components.ComponentOfTool}
+		var got callbacks.CallbackOutput
+		h := WrapHandler(callbacks.NewHandlerBuilder().OnEndFn(
+			func(ctx context.Context, _ *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+				got = output
+				return ctx
+			}).Build())
+		assert.False(t, h.(callbacks.TimingChecker).Needed(context.Background(), toolInfo, callbacks.TimingOnStart))
+		h.OnEnd(h.OnStart(context.Background(), toolInfo, "args"), toolInfo, "result")
+		assert.Equal(t, "result", got)
+	})
+}
@@ -0,0 +1,134 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/flow/streamtee"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// the observers, e.g. a logger and a websocket pushing the chunks to a browser
+	logger := &streamtee.ObserverFuncs[*schema.Message]{
+		Chunk: func(_ context.Context, chunk *schema.Message) { log.Printf("chunk: %q", chunk.Content) },
+		End:   func(_ context.Context, err error) { log.Printf("stream ended: %v", err) },
+	}
+	done := make(chan struct{})
+
+	// replace with any chat model, e.g. openai or ark
+	cm, err := streamtee.NewChatModel(&echoModel{}, &streamtee.Config{
+		Observers: []streamtee.Observer[*schema.Message]{logger},
+		OnStats: func(_ context.Context, s *streamtee.Stats) {
+			log.Printf("first token after %v, %.1f tokens/s", s.FirstTokenLatency, s.TokensPerSecond)
+			close(done)
+		},
+	})
+	if err != nil {
+		log.Fatalf("Failed to create chat model: %v", err)
+	}
+
+	sr, err := cm.Stream(ctx, []*schema.Message{schema.UserMessage("streams are duplicated without being consumed")})
+	if err != nil {
+		log.Fatalf("Failed to stream: %v", err)
+	}
+	fmt.Println(readAll(sr))
+	<-done
+
+	// the stats can also be added to the outputs received by callback handlers, e.g. langfuse or apmplus
+	handler := streamtee.WrapHandler(callbacks.NewHandlerBuilder().OnEndWithStreamOutputFn(
+		func(ctx context.Context, info *callbacks.RunInfo, output *schema.StreamReader[callbacks.CallbackOutput]) context.Context {
+			defer output.Close()
+			for {
+				chunk, err := output.Recv()
+				if err != nil {
+					return ctx
+				}
+				if out := model.ConvCallbackOutput(chunk); out != nil && out.Extra[streamtee.ExtraKeyStats] != nil {
+					s := out.Extra[streamtee.ExtraKeyStats].(*streamtee.Stats)
+					log.Printf("%s: first token after %v in %d chunks", info.Name, s.FirstTokenLatency, s.Chunks)
+				}
+			}
+		}).Build())
+
+	chain, err := compose.NewChain[[]*schema.Message, *schema.Message]().
+		AppendChatModel(&echoModel{}, compose.WithNodeName("echo")).
+		Compile(ctx)
+	if err != nil {
+		log.Fatalf("Failed to compile chain: %v", err)
+	}
+	sr, err = chain.Stream(ctx, []*schema.Message{schema.UserMessage("callback handlers get the stats")},
+		compose.WithCallbacks(handler))
+	if err != nil {
+		log.Fatalf("Failed to stream: %v", err)
+	}
+	fmt.Println(readAll(sr))
+	// the handlers receive the stream asynchronously
+	time.Sleep(100 * time.Millisecond)
+}
+
+func readAll(sr *schema.StreamReader[*schema.Message]) string {
+	defer sr.Close()
+	var sb strings.Builder
+	for {
+		chunk, err := sr.Recv()
+		if errors.Is(err, io.EOF) {
+			return sb.String()
+		}
+		if err != nil {
+			log.Fatalf("Failed to receive: %v", err)
+		}
+		sb.WriteString(chunk.Content)
+	}
+}
+
+// echoModel streams the words of the last message back, replace with a real chat model.
+type echoModel struct{}
+
+func (e *echoModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	return schema.AssistantMessage(input[len(input)-1].Content, nil), nil
+}
+
+func (e *echoModel) Stream(_ context.Context, input []*schema.Message, _ ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	sr, sw := schema.Pipe[*schema.Message](0)
+	go func() {
+		defer sw.Close()
+		time.Sleep(50 * time.Millisecond)
+		for i, word := range strings.Fields(input[len(input)-1].Content) {
+			if i > 0 {
+				word = " " + word
+			}
+			if closed := sw.Send(schema.AssistantMessage(word, nil), nil); closed {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+	return sr, nil
+}
@@ -0,0 +1,177 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package streamtee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+// ExtraKeyStats is the key of the *Stats added to the Extra of the chat model callback outputs by WrapHandler.
+const ExtraKeyStats = "_eino_stream_stats"
+
+// WrapHandler wraps the callback handler h so that the chat model outputs it receives carry the Stats of the call
+// in their Extra, under ExtraKeyStats: in the last chunk of a stream output, so that the concatenated output has them.
+// The handler receives its own copy of the streams, which are neither delayed nor consumed for the other handlers.
+func WrapHandler(h callbacks.Handler) callbacks.Handler {
+	return &statsHandler{h: h}
+}
+
+type startKey struct{}
+
+type statsHandler struct {
+	h callbacks.Handler
+}
+
+func (s *statsHandler) OnStart(ctx context.Context, info *callbacks.RunInfo, input callbacks.CallbackInput) context.Context {
+	ctx = withStart(ctx, info)
+	if !s.wrappedNeeded(ctx, info, callbacks.TimingOnStart) {
+		return ctx
+	}
+	return s.h.OnStart(ctx, info, input)
+}
+
+func (s *statsHandler) OnEnd(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+	if start, ok := startOf(ctx, info); ok {
+		if out := model.ConvCallbackOutput(output); out != nil {
+			meter := NewMeter(start)
+			meter.Observe(out.Message)
+			output = withStats(out, meter.Stats())
+		}
+	}
+	return s.h.OnEnd(ctx, info, output)
+}
+
+func (s *statsHandler) OnError(ctx context.Context, info *callbacks.RunInfo, err error) context.Context {
+	return s.h.OnError(ctx, info, err)
+}
+
+func (s *statsHandler) OnStartWithStreamInput(ctx context.Context, info *callbacks.RunInfo,
+	input *schema.StreamReader[callbacks.CallbackInput]) context.Context {
+	ctx = withStart(ctx, info)
+	if !s.wrappedNeeded(ctx, info, callbacks.TimingOnStartWithStreamInput) {
+		input.Close()
+		return ctx
+	}
+	return s.h.OnStartWithStreamInput(ctx, info, input)
+}
+
+func (s *statsHandler) OnEndWithStreamOutput(ctx context.Context, info *callbacks.RunInfo,
+	output *schema.StreamReader[callbacks.CallbackOutput]) context.Context {
+	start, ok := startOf(ctx, info)
+	if !ok {
+		return s.h.OnEndWithStreamOutput(ctx, info, output)
+	}
+
+	// the handler receives each chunk once the next one is, to add the stats to the last one
+	sr, sw := schema.Pipe[callbacks.CallbackOutput](1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				sw.Send(nil, fmt.Errorf("panic in stream stats handler: %v", p))
+			}
+			output.Close()
+			sw.Close()
+		}()
+
+		meter := NewMeter(start)
+		var pending callbacks.CallbackOutput
+		for {
+			chunk, err := output.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				if pending != nil {
+					sw.Send(pending, nil)
+				}
+				sw.Send(nil, err)
+				return
+			}
+			if out := model.ConvCallbackOutput(chunk); out != nil {
+				meter.Observe(out.Message)
+			}
+			if pending != nil {
+				if closed := sw.Send(pending, nil); closed {
+					return
+				}
+			}
+			pending = chunk
+		}
+		if pending == nil {
+			return
+		}
+		if out := model.ConvCallbackOutput(pending); out != nil {
+			pending = withStats(out, meter.Stats())
+		}
+		sw.Send(pending, nil)
+	}()
+
+	return s.h.OnEndWithStreamOutput(ctx, info, sr)
+}
+
+// Needed keeps the start timings, required to measure the stats, and delegates the others to the wrapped handler.
+func (s *statsHandler) Needed(ctx context.Context, info *callbacks.RunInfo, timing callbacks.CallbackTiming) bool {
+	if isChatModel(info) && (timing == callbacks.TimingOnStart || timing == callbacks.TimingOnStartWithStreamInput) {
+		return true
+	}
+	return s.wrappedNeeded(ctx, info, timing)
+}
+
+func (s *statsHandler) wrappedNeeded(ctx context.Context, info *callbacks.RunInfo, timing callbacks.CallbackTiming) bool {
+	if checker, ok := s.h.(callbacks.TimingChecker); ok {
+		return checker.Needed(ctx, info, timing)
+	}
+	return true
+}
+
+func isChatModel(info *callbacks.RunInfo) bool {
+	return info != nil && info.Component == components.ComponentOfChatModel
+}
+
+func withStart(ctx context.Context, info *callbacks.RunInfo) context.Context {
+	if !isChatModel(info) {
+		return ctx
+	}
+	return context.WithValue(ctx, startKey{}, time.Now())
+}
+
+func startOf(ctx context.Context, info *callbacks.RunInfo) (time.Time, bool) {
+	if !isChatModel(info) {
+		return time.Time{}, false
+	}
+	start, ok := ctx.Value(startKey{}).(time.Time)
+	return start, ok
+}
+
+// withStats returns a copy of out with the stats in its Extra, out being shared by the handlers.
+func withStats(out *model.CallbackOutput, stats *Stats) *model.CallbackOutput {
+	cp := *out
+	cp.Extra = make(map[string]any, len(out.Extra)+1)
+	for k, v := range out.Extra {
+		cp.Extra[k] = v
+	}
+	cp.Extra[ExtraKeyStats] = stats
+	return &cp
+}
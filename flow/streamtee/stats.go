@@ -0,0 +1,106 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package streamtee
+
+import (
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// Stats are the latency and throughput of a chat model call.
+type Stats struct {
+	// FirstTokenLatency is the time from the call to the first chunk carrying content, reasoning or tool calls,
+	// the whole call when not streamed.
+	FirstTokenLatency time.Duration `json:"first_token_latency"`
+	// Duration is the time from the call to the last chunk.
+	Duration time.Duration `json:"duration"`
+	// Chunks is the number of chunks received.
+	Chunks int `json:"chunks"`
+	// CompletionTokens is the completion token usage reported by the model, or the number of chunks carrying content
+	// when the model reports none.
+	CompletionTokens int `json:"completion_tokens"`
+	// TokensEstimated reports CompletionTokens counted from the chunks.
+	TokensEstimated bool `json:"tokens_estimated,omitempty"`
+	// TokensPerSecond is the generation throughput, the completion tokens over the time after the first token,
+	// or over the whole call when not streamed.
+	TokensPerSecond float64 `json:"tokens_per_second"`
+}
+
+// Meter measures the Stats of a chat model call from its output chunks, observed as they are received.
+// A Meter is not safe for concurrent use.
+type Meter struct {
+	start       time.Time
+	first       time.Time
+	last        time.Time
+	chunks      int
+	tokenChunks int
+	usage       int
+}
+
+// NewMeter returns a Meter of a call started at start.
+func NewMeter(start time.Time) *Meter {
+	return &Meter{start: start}
+}
+
+// Observe records a chunk received now.
+func (m *Meter) Observe(chunk *schema.Message) {
+	now := time.Now()
+	m.last = now
+	m.chunks++
+	if chunk == nil {
+		return
+	}
+	if hasToken(chunk) {
+		if m.first.IsZero() {
+			m.first = now
+		}
+		m.tokenChunks++
+	}
+	if chunk.ResponseMeta != nil && chunk.ResponseMeta.Usage != nil && chunk.ResponseMeta.Usage.CompletionTokens > 0 {
+		// the usage is usually reported once, in the last chunk, some models report it cumulatively
+		m.usage = chunk.ResponseMeta.Usage.CompletionTokens
+	}
+}
+
+// Stats returns the stats of the chunks observed so far.
+func (m *Meter) Stats() *Stats {
+	s := &Stats{Chunks: m.chunks, CompletionTokens: m.usage}
+	if s.CompletionTokens == 0 {
+		s.CompletionTokens = m.tokenChunks
+		s.TokensEstimated = m.tokenChunks > 0
+	}
+	if m.chunks == 0 {
+		return s
+	}
+	s.Duration = m.last.Sub(m.start)
+	if !m.first.IsZero() {
+		s.FirstTokenLatency = m.first.Sub(m.start)
+	}
+
+	generation := s.Duration - s.FirstTokenLatency
+	if generation <= 0 {
+		generation = s.Duration
+	}
+	if generation > 0 {
+		s.TokensPerSecond = float64(s.CompletionTokens) / generation.Seconds()
+	}
+	return s
+}
+
+func hasToken(m *schema.Message) bool {
+	return m.Content != "" || m.ReasoningContent != "" || len(m.ToolCalls) > 0 || len(m.MultiContent) > 0
+}
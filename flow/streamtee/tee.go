@@ -0,0 +1,191 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package streamtee duplicates the output streams of eino components to observers, such as loggers, websockets
+// or callback handlers, without consuming them, and measures the first-token latency and the throughput of
+// chat model streams.
+package streamtee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// ErrClosed ends the observation of a stream closed by its consumer before it was fully received.
+var ErrClosed = errors.New("stream closed by the consumer")
+
+// Observer observes the chunks of a stream duplicated by Tee.
+// Each observer runs in its own goroutine and receives every chunk in order, a slow observer never delaying
+// the consumer of the stream nor the other observers. A panicking observer is dropped.
+type Observer[T any] interface {
+	OnChunk(ctx context.Context, chunk T)
+	// OnEnd is called once after the last chunk, with nil when the stream was fully received,
+	// the error of the stream, or ErrClosed.
+	OnEnd(ctx context.Context, err error)
+}
+
+// ObserverFuncs is an Observer made of functions, nil ones being skipped.
+type ObserverFuncs[T any] struct {
+	Chunk func(ctx context.Context, chunk T)
+	End   func(ctx context.Context, err error)
+}
+
+func (o *ObserverFuncs[T]) OnChunk(ctx context.Context, chunk T) {
+	if o.Chunk != nil {
+		o.Chunk(ctx, chunk)
+	}
+}
+
+func (o *ObserverFuncs[T]) OnEnd(ctx context.Context, err error) {
+	if o.End != nil {
+		o.End(ctx, err)
+	}
+}
+
+// Tee returns a stream yielding the chunks of sr, duplicated to the observers as they are received.
+// Unlike StreamReader.Copy, the observers never have to be drained nor closed: the returned stream is the only one
+// to consume and close, closing it closes sr.
+func Tee[T any](ctx context.Context, sr *schema.StreamReader[T], observers ...Observer[T]) *schema.StreamReader[T] {
+	if len(observers) == 0 {
+		return sr
+	}
+
+	feeds := make([]*feed[T], 0, len(observers))
+	for _, o := range observers {
+		if o == nil {
+			continue
+		}
+		f := newFeed[T]()
+		feeds = append(feeds, f)
+		go f.run(ctx, o)
+	}
+
+	outSR, outSW := schema.Pipe[T](1)
+	go func() {
+		endErr := ErrClosed
+		defer func() {
+			if p := recover(); p != nil {
+				endErr = fmt.Errorf("panic in stream tee: %v", p)
+				var zero T
+				outSW.Send(zero, endErr)
+			}
+			for _, f := range feeds {
+				f.end(endErr)
+			}
+			sr.Close()
+			outSW.Close()
+		}()
+
+		for {
+			chunk, err := sr.Recv()
+			if errors.Is(err, io.EOF) {
+				endErr = nil
+				return
+			}
+			if err != nil {
+				endErr = err
+				var zero T
+				outSW.Send(zero, err)
+				return
+			}
+			for _, f := range feeds {
+				f.push(chunk)
+			}
+			if closed := outSW.Send(chunk, nil); closed {
+				return
+			}
+		}
+	}()
+
+	return outSR
+}
+
+// feed is the unbounded queue of the chunks to deliver to an observer.
+type feed[T any] struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	chunks []T
+	ended  bool
+	err    error
+	// dropped is set when the observer panicked, the chunks being no longer queued
+	dropped bool
+}
+
+func newFeed[T any]() *feed[T] {
+	f := &feed[T]{}
+	f.cond = sync.NewCond(&f.mu)
+	return f
+}
+
+func (f *feed[T]) push(chunk T) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.dropped {
+		return
+	}
+	f.chunks = append(f.chunks, chunk)
+	f.cond.Signal()
+}
+
+func (f *feed[T]) end(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.ended = true
+	f.err = err
+	f.cond.Signal()
+}
+
+// next returns the next chunk, false once the feed is ended and drained.
+func (f *feed[T]) next() (T, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for len(f.chunks) == 0 && !f.ended {
+		f.cond.Wait()
+	}
+	if len(f.chunks) == 0 {
+		var zero T
+		return zero, false
+	}
+	chunk := f.chunks[0]
+	var zero T
+	f.chunks[0] = zero
+	f.chunks = f.chunks[1:]
+	return chunk, true
+}
+
+func (f *feed[T]) run(ctx context.Context, o Observer[T]) {
+	defer func() {
+		if recover() != nil {
+			f.mu.Lock()
+			f.dropped = true
+			f.chunks = nil
+			f.mu.Unlock()
+		}
+	}()
+
+	for {
+		chunk, ok := f.next()
+		if !ok {
+			break
+		}
+		o.OnChunk(ctx, chunk)
+	}
+	o.OnEnd(ctx, f.err)
+}
@@ -0,0 +1,183 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package streamtee
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type collector struct {
+	mu     sync.Mutex
+	chunks []string
+	err    error
+	done   chan struct{}
+	delay  time.Duration
+}
+
+func newCollector() *collector {
+	return &collector{done: make(chan struct{})}
+}
+
+func (c *collector) OnChunk(_ context.Context, chunk string) {
+	time.Sleep(c.delay)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.chunks = append(c.chunks, chunk)
+}
+
+func (c *collector) OnEnd(_ context.Context, err error) {
+	c.err = err
+	close(c.done)
+}
+
+func (c *collector) wait(t *testing.T) {
+	select {
+	case <-c.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("observer not ended")
+	}
+}
+
+func drain[T any](t *testing.T, sr *schema.StreamReader[T]) ([]T, error) {
+	t.Helper()
+	defer sr.Close()
+	var chunks []T
+	for {
+		chunk, err := sr.Recv()
+		if errors.Is(err, io.EOF) {
+			return chunks, nil
+		}
+		if err != nil {
+			return chunks, err
+		}
+		chunks = append(chunks, chunk)
+	}
+}
+
+func TestTee(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("duplicates", func(t *testing.T) {
+		a, b := newCollector(), newCollector()
+		sr := Tee[string](ctx, schema.StreamReaderFromArray([]string{"a", "b", "c"}), a, nil, b)
+		chunks, err := drain(t, sr)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "c"}, chunks)
+
+		for _, c := range []*collector{a, b} {
+			c.wait(t)
+			assert.Equal(t, []string{"a", "b", "c"}, c.chunks)
+			assert.NoError(t, c.err)
+		}
+	})
+
+	t.Run("no observer", func(t *testing.T) {
+		sr := schema.StreamReaderFromArray([]string{"a"})
+		assert.Same(t, sr, Tee(ctx, sr))
+	})
+
+	t.Run("slow observer", func(t *testing.T) {
+		slow := newCollector()
+		slow.delay = 50 * time.Millisecond
+		start := time.Now()
+		chunks, err := drain(t, Tee[string](ctx, schema.StreamReaderFromArray([]string{"a", "b", "c", "d"}), slow))
+		require.NoError(t, err)
+		assert.Len(t, chunks, 4)
+		assert.Less(t, time.Since(start), 150*time.Millisecond)
+
+		slow.wait(t)
+		assert.Equal(t, []string{"a", "b", "c", "d"}, slow.chunks)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		src, sw := schema.Pipe[string](2)
+		sw.Send("a", nil)
+		sw.Send("", errors.New("boom"))
+		sw.Close()
+
+		c := newCollector()
+		chunks, err := drain(t, Tee[string](ctx, src, c))
+		assert.EqualError(t, err, "boom")
+		assert.Equal(t, []string{"a"}, chunks)
+		c.wait(t)
+		assert.EqualError(t, c.err, "boom")
+	})
+
+	t.Run("closed by consumer", func(t *testing.T) {
+		src, sw := schema.Pipe[string](0)
+		go func() {
+			defer sw.Close()
+			for {
+				if closed := sw.Send("x", nil); closed {
+					return
+				}
+			}
+		}()
+
+		c := newCollector()
+		sr := Tee[string](ctx, src, c)
+		_, err := sr.Recv()
+		require.NoError(t, err)
+		sr.Close()
+		c.wait(t)
+		assert.ErrorIs(t, c.err, ErrClosed)
+	})
+
+	t.Run("panicking observer", func(t *testing.T) {
+		c := newCollector()
+		panicking := &ObserverFuncs[string]{Chunk: func(context.Context, string) { panic("observer") }}
+		chunks, err := drain(t, Tee[string](ctx, schema.StreamReaderFromArray([]string{"a", "b"}), panicking, c))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, chunks)
+		c.wait(t)
+		assert.Equal(t, []string{"a", "b"}, c.chunks)
+	})
+}
+
+func TestMeter(t *testing.T) {
+	start := time.Now()
+	m := NewMeter(start)
+	assert.Equal(t, &Stats{}, m.Stats())
+
+	m.Observe(&schema.Message{Role: schema.Assistant})
+	time.Sleep(20 * time.Millisecond)
+	m.Observe(&schema.Message{Content: "hello"})
+	time.Sleep(20 * time.Millisecond)
+	m.Observe(&schema.Message{Content: " world"})
+
+	s := m.Stats()
+	assert.Equal(t, 3, s.Chunks)
+	assert.Equal(t, 2, s.CompletionTokens)
+	assert.True(t, s.TokensEstimated)
+	assert.GreaterOrEqual(t, s.FirstTokenLatency, 20*time.Millisecond)
+	assert.GreaterOrEqual(t, s.Duration, 40*time.Millisecond)
+	assert.Greater(t, s.TokensPerSecond, 0.0)
+
+	m.Observe(&schema.Message{ResponseMeta: &schema.ResponseMeta{Usage: &schema.TokenUsage{CompletionTokens: 5}}})
+	s = m.Stats()
+	assert.Equal(t, 5, s.CompletionTokens)
+	assert.False(t, s.TokensEstimated)
+	assert.InDelta(t, 5/(s.Duration-s.FirstTokenLatency).Seconds(), s.TokensPerSecond, 0.001)
+}
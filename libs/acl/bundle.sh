@@ -0,0 +1,66 @@
+#!/bin/bash
+#
+# Copyright 2025 CloudWeGo Authors
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+#
+
+# bundle.sh copies the package of a libs/acl lib into an internal package of a component using it, so that the
+# component does not require a version of the lib which is not released. The lib is the only source: fix the lib,
+# never its copies, then regenerate all of them. A lib may also be another module of the repo, named by its path
+# from the repo root, e.g. flow/httpstream.
+#
+# Usage:
+#   bundle.sh <lib> <dir>  copies libs/acl/<lib> to <dir>, relative to the current directory, from a go:generate
+#                          directive of the component, e.g. //go:generate sh ../../../libs/acl/bundle.sh vector internal/vector
+#   bundle.sh <lib>        regenerates all the copies of libs/acl/<lib> in the repo
+
+set -e
+
+ACL_DIR=$(cd "$(dirname "$0")" && pwd)
+REPO_DIR=$(cd "$ACL_DIR/../.." && pwd)
+LIB=$1
+DST=$2
+
+case "$LIB" in
+*/*) SRC_PATH=$LIB ;;
+*) SRC_PATH=libs/acl/$LIB ;;
+esac
+SRC_DIR=$REPO_DIR/$SRC_PATH
+
+if [ -z "$LIB" ] || [ ! -f "$SRC_DIR/go.mod" ]; then
+  echo "usage: bundle.sh <lib> [<dir>], unknown lib: $LIB" >&2
+  exit 1
+fi
+
+if [ -z "$DST" ]; then
+  grep -rl --include='*.go' "^//go:generate sh .*libs/acl/bundle.sh $LIB " "$REPO_DIR" | while read -r file; do
+    dir=$(dirname "$file")
+    grep "^//go:generate sh .*libs/acl/bundle.sh $LIB " "$file" | while read -r _ _ _ _ target; do
+      echo "bundle $LIB to ${dir#"$REPO_DIR"/}/$target"
+      (cd "$dir" && bash "$ACL_DIR/bundle.sh" "$LIB" "$target")
+    done
+  done
+  exit 0
+fi
+
+mkdir -p "$DST"
+rm -f "$DST"/*.go
+for src in "$SRC_DIR"/*.go; do
+  case "$src" in
+  *_test.go) continue ;;
+  esac
+  # the generated marker follows the license header
+  awk -v src="$SRC_PATH" '{ print } !done && /^ \*\/$/ { print ""; print "// Code generated by libs/acl/bundle.sh from " src ". DO NOT EDIT."; done = 1 }' \
+    "$src" >"$DST/$(basename "$src")"
+done
@@ -80,6 +80,7 @@ type eventBodyUnion struct {
 	Generation *GenerationEventBody `json:",inline,omitempty"`
 	Event      *EventEventBody      `json:",inline,omitempty"`
 	Log        *SDKLogEventBody     `json:",inline,omitempty"`
+	Score      *ScoreEventBody      `json:",inline,omitempty"`
 }
 
 func (e *eventBodyUnion) MarshalJSON() ([]byte, error) {
@@ -91,6 +92,8 @@ func (e *eventBodyUnion) MarshalJSON() ([]byte, error) {
 		return sonic.Marshal(e.Generation)
 	} else if e.Event != nil {
 		return sonic.Marshal(e.Event)
+	} else if e.Score != nil {
+		return sonic.Marshal(e.Score)
 	}
 	return nil, fmt.Errorf("event body is empty")
 }
@@ -104,6 +107,8 @@ func (e *eventBodyUnion) getTraceID() string {
 		return e.Generation.TraceID
 	} else if e.Event != nil {
 		return e.Event.TraceID
+	} else if e.Score != nil {
+		return e.Score.TraceID
 	}
 	return ""
 }
@@ -115,6 +120,8 @@ func (e *eventBodyUnion) getObservationID() string {
 		return e.Generation.ID
 	} else if e.Event != nil {
 		return e.Event.ID
+	} else if e.Score != nil {
+		return e.Score.ObservationID
 	}
 	return ""
 }
@@ -176,6 +183,8 @@ func (e *eventBodyUnion) getMetadata() any {
 		return e.Generation.MetaData
 	} else if e.Event != nil {
 		return e.Event.MetaData
+	} else if e.Score != nil {
+		return e.Score.MetaData
 	}
 	return nil
 }
@@ -188,6 +197,8 @@ func (e *eventBodyUnion) setMetadata(data any) {
 		e.Generation.MetaData = data
 	} else if e.Event != nil {
 		e.Event.MetaData = data
+	} else if e.Score != nil {
+		e.Score.MetaData = data
 	}
 	return
 }
@@ -256,4 +267,23 @@ type SDKLogEventBody struct {
 	Log string `json:"log"`
 }
 
-// TODO: ScoreEvent
+type ScoreDataType string
+
+const (
+	ScoreDataTypeNumeric     ScoreDataType = "NUMERIC"
+	ScoreDataTypeBoolean     ScoreDataType = "BOOLEAN"
+	ScoreDataTypeCategorical ScoreDataType = "CATEGORICAL"
+)
+
+type ScoreEventBody struct {
+	ID            string `json:"id,omitempty"`
+	TraceID       string `json:"traceId"`
+	ObservationID string `json:"observationId,omitempty"`
+	Name          string `json:"name"`
+	// Value is a float64 for NUMERIC and BOOLEAN(0 or 1) scores, or a string for CATEGORICAL scores
+	Value    any           `json:"value"`
+	DataType ScoreDataType `json:"dataType,omitempty"`
+	Comment  string        `json:"comment,omitempty"`
+	ConfigID string        `json:"configId,omitempty"`
+	MetaData any           `json:"metadata,omitempty"`
+}
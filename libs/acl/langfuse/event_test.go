@@ -93,4 +93,20 @@ func TestEventBodyUnion(t *testing.T) {
 	assert.Equal(t, input, generationUnion.getInput())
 	assert.Equal(t, observationID, generationUnion.getObservationID())
 	assert.Equal(t, map[string]string{"key": "value"}, generationUnion.getMetadata())
+
+	scoreUnion := &eventBodyUnion{Score: &ScoreEventBody{
+		TraceID:       traceID,
+		ObservationID: observationID,
+		Name:          "user-feedback",
+		Value:         1.0,
+		MetaData:      metadata,
+	}}
+	assert.Equal(t, traceID, scoreUnion.getTraceID())
+	assert.Equal(t, "", scoreUnion.getOutput())
+	assert.Equal(t, "", scoreUnion.getInput())
+	assert.Equal(t, observationID, scoreUnion.getObservationID())
+	assert.Equal(t, map[string]string{"key": "value"}, scoreUnion.getMetadata())
+	b, err := scoreUnion.MarshalJSON()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"traceId":"traceID","observationId":"observationID","name":"user-feedback","value":1,"metadata":{"key":"value"}}`, string(b))
 }
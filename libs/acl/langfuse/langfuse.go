@@ -17,6 +17,8 @@
 package langfuse
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"time"
 
@@ -40,6 +42,12 @@ type Langfuse interface {
 	Flush()
 }
 
+// ScoreClient attaches scores to traces, the client returned by NewLangfuse implements it along with Langfuse.
+// It is not part of Langfuse to keep the other implementations of Langfuse compiling.
+type ScoreClient interface {
+	CreateScore(body *ScoreEventBody) (string, error)
+}
+
 // NewLangfuse creates a Langfuse client instance
 //
 // Parameters:
@@ -205,6 +213,63 @@ func (l *langfuseIns) CreateEvent(body *EventEventBody) (string, error) {
 	})
 }
 
+// CreateScore attaches a score, e.g. an evaluation result or user feedback, to a trace or an observation
+//
+// Parameters:
+//   - body: The score details. TraceID, Name and Value are required, if ID is empty, a new UUID will be generated.
+//     Scores with the same ID are updated. If DataType is empty, it is inferred from the type of Value,
+//     bool values are converted to 0 or 1 with the BOOLEAN data type
+//
+// Returns:
+//   - string: The ID of the created score
+//   - error: Any error that occurred during creation
+func (l *langfuseIns) CreateScore(body *ScoreEventBody) (string, error) {
+	if len(body.TraceID) == 0 {
+		return "", errors.New("trace id of score is required")
+	}
+	if len(body.Name) == 0 {
+		return "", errors.New("name of score is required")
+	}
+	if err := normalizeScoreValue(body); err != nil {
+		return "", err
+	}
+	if len(body.ID) == 0 {
+		body.ID = uuid.NewString()
+	}
+	return body.ID, l.tm.push(&event{
+		ID:   uuid.NewString(),
+		Type: EventTypeScoreCreate,
+		Body: eventBodyUnion{Score: body},
+	})
+}
+
+func normalizeScoreValue(body *ScoreEventBody) error {
+	var dataType ScoreDataType
+	switch v := body.Value.(type) {
+	case bool:
+		body.Value, dataType = 0.0, ScoreDataTypeBoolean
+		if v {
+			body.Value = 1.0
+		}
+	case float64:
+		dataType = ScoreDataTypeNumeric
+	case float32:
+		body.Value, dataType = float64(v), ScoreDataTypeNumeric
+	case int:
+		body.Value, dataType = float64(v), ScoreDataTypeNumeric
+	case int64:
+		body.Value, dataType = float64(v), ScoreDataTypeNumeric
+	case string:
+		dataType = ScoreDataTypeCategorical
+	default:
+		return fmt.Errorf("unsupported score value type: %T", body.Value)
+	}
+	if len(body.DataType) == 0 {
+		body.DataType = dataType
+	}
+	return nil
+}
+
 // Flush waits for all queued events to be processed and uploaded to Langfuse
 //
 // This method blocks until all pending events in the queue have been processed
@@ -165,3 +165,51 @@ func TestLangfuse(t *testing.T) {
 		lf.Flush()
 	})
 }
+
+func TestCreateScore(t *testing.T) {
+	mockey.PatchConvey("", t, func() {
+		var scores []map[string]any
+		mockey.Mock((*http.Client).Do).To(func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			ingestion := struct {
+				Batch []struct {
+					Type EventType      `json:"type"`
+					Body map[string]any `json:"body"`
+				} `json:"batch"`
+			}{}
+			_ = sonic.Unmarshal(body, &ingestion)
+			for _, e := range ingestion.Batch {
+				if e.Type == EventTypeScoreCreate {
+					scores = append(scores, e.Body)
+				}
+			}
+			respBody, _ := sonic.Marshal(&batchIngestionResponse{})
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBuffer(respBody))}, nil
+		}).Build()
+		cli := NewLangfuse("https://host", "pk", "sk")
+		lf, ok := cli.(ScoreClient)
+		assert.True(t, ok)
+
+		_, err := lf.CreateScore(&ScoreEventBody{Name: "rating", Value: 1.0})
+		assert.Error(t, err)
+		_, err = lf.CreateScore(&ScoreEventBody{TraceID: "trace", Value: 1.0})
+		assert.Error(t, err)
+		_, err = lf.CreateScore(&ScoreEventBody{TraceID: "trace", Name: "rating", Value: []int{1}})
+		assert.Error(t, err)
+
+		id, err := lf.CreateScore(&ScoreEventBody{TraceID: "trace", ObservationID: "obs", Name: "rating", Value: 4, Comment: "nice"})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, id)
+		_, err = lf.CreateScore(&ScoreEventBody{ID: "feedback-1", TraceID: "trace", Name: "user-feedback", Value: false})
+		assert.NoError(t, err)
+		_, err = lf.CreateScore(&ScoreEventBody{TraceID: "trace", Name: "category", Value: "helpful"})
+		assert.NoError(t, err)
+		cli.Flush()
+
+		assert.Len(t, scores, 3)
+		assert.Equal(t, map[string]any{"id": id, "traceId": "trace", "observationId": "obs", "name": "rating", "value": 4.0, "dataType": "NUMERIC", "comment": "nice"}, scores[0])
+		assert.Equal(t, map[string]any{"id": "feedback-1", "traceId": "trace", "name": "user-feedback", "value": 0.0, "dataType": "BOOLEAN"}, scores[1])
+		assert.Equal(t, "CATEGORICAL", scores[2]["dataType"])
+		assert.Equal(t, "helpful", scores[2]["value"])
+	})
+}
@@ -149,3 +149,41 @@ func (mr *MockLangfuseMockRecorder) Flush() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*MockLangfuse)(nil).Flush))
 }
+
+// MockScoreClient is a mock of ScoreClient interface.
+type MockScoreClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockScoreClientMockRecorder
+}
+
+// MockScoreClientMockRecorder is the mock recorder for MockScoreClient.
+type MockScoreClientMockRecorder struct {
+	mock *MockScoreClient
+}
+
+// NewMockScoreClient creates a new mock instance.
+func NewMockScoreClient(ctrl *gomock.Controller) *MockScoreClient {
+	mock := &MockScoreClient{ctrl: ctrl}
+	mock.recorder = &MockScoreClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScoreClient) EXPECT() *MockScoreClientMockRecorder {
+	return m.recorder
+}
+
+// CreateScore mocks base method.
+func (m *MockScoreClient) CreateScore(body *langfuse.ScoreEventBody) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScore", body)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateScore indicates an expected call of CreateScore.
+func (mr *MockScoreClientMockRecorder) CreateScore(body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScore", reflect.TypeOf((*MockScoreClient)(nil).CreateScore), body)
+}
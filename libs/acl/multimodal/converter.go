@@ -0,0 +1,221 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package multimodal
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Provider identifies a model provider's native content format.
+type Provider string
+
+const (
+	ProviderOpenAI Provider = "openai"
+	ProviderClaude Provider = "claude"
+	ProviderGemini Provider = "gemini"
+	ProviderArk    Provider = "ark"
+)
+
+const mb = 1 << 20
+
+// DefaultLimits are the documented inline size limits of each provider.
+var DefaultLimits = map[Provider]Limits{
+	ProviderOpenAI: {MaxImageBytes: 20 * mb, MaxImageDimension: 2048, MaxMediaBytes: 25 * mb},
+	ProviderClaude: {MaxImageBytes: 5 * mb, MaxImageDimension: 8000, MaxMediaBytes: 32 * mb},
+	ProviderGemini: {MaxImageBytes: 20 * mb, MaxMediaBytes: 20 * mb},
+	ProviderArk:    {MaxImageBytes: 10 * mb, MaxImageDimension: 6000, MaxMediaBytes: 50 * mb},
+}
+
+type Config struct {
+	// Limits overrides DefaultLimits per provider.
+	// Optional.
+	Limits map[Provider]Limits
+	// HTTPClient is used to download remote URLs for providers that only accept inline content of that kind,
+	// eg. Gemini images or OpenAI audio. The downloaded content is checked against the limits like inline content.
+	// Optional. When nil, such parts return ErrUnsupported.
+	HTTPClient *http.Client
+}
+
+// Converter prepares eino message parts for a provider and renders them in the provider's native format.
+type Converter struct {
+	limits map[Provider]Limits
+	cli    *http.Client
+}
+
+func NewConverter(config *Config) *Converter {
+	if config == nil {
+		config = &Config{}
+	}
+	limits := make(map[Provider]Limits, len(DefaultLimits))
+	for p, l := range DefaultLimits {
+		limits[p] = l
+	}
+	for p, l := range config.Limits {
+		limits[p] = l
+	}
+	return &Converter{limits: limits, cli: config.HTTPClient}
+}
+
+// Prepare downloads the part when the provider can't reference its URL and enforces the provider's limits.
+// The part is modified in place, the To* methods call it before rendering.
+func (c *Converter) Prepare(ctx context.Context, provider Provider, p *Part) error {
+	if p.Kind == KindText {
+		return nil
+	}
+	accept, ok := acceptance[provider][p.Kind]
+	if !ok {
+		return fmt.Errorf("%w: %s does not accept %s parts", ErrUnsupported, provider, p.Kind)
+	}
+	if !p.IsInline() && !accept.url(p.URL) {
+		if err := c.download(ctx, p); err != nil {
+			return err
+		}
+	}
+	if err := Enforce(p, c.limits[provider]); err != nil {
+		return err
+	}
+	if p.IsInline() && accept.mimeTypes != nil && !accept.mimeTypes[baseMIMEType(p.MIMEType)] {
+		return fmt.Errorf("%w: %s does not accept %s", ErrUnsupported, provider, p.MIMEType)
+	}
+	return nil
+}
+
+func (c *Converter) download(ctx context.Context, p *Part) error {
+	if c.cli == nil {
+		return fmt.Errorf("%w: %s url must be downloaded but no HTTPClient is configured", ErrUnsupported, p.Kind)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.URL, nil)
+	if err != nil {
+		return fmt.Errorf("create download request fail: %w", err)
+	}
+	resp, err := c.cli.Do(req)
+	if err != nil {
+		return fmt.Errorf("download %s fail: %w", p.URL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("download %s fail: status %d", p.URL, resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read %s fail: %w", p.URL, err)
+	}
+	if p.MIMEType == "" {
+		p.MIMEType, _, _ = strings.Cut(resp.Header.Get("Content-Type"), ";")
+	}
+	if p.Name == "" && p.Kind == KindFile {
+		p.Name = fileName(p.URL)
+	}
+	p.URL, p.Data = "", data
+	if p.MIMEType == "" {
+		p.MIMEType = detectMIMEType(p)
+	}
+	return nil
+}
+
+// acceptSpec describes how a provider accepts a kind of part, inline content is always accepted.
+type acceptSpec struct {
+	// url reports whether the provider fetches the url itself, nil means urls are never accepted.
+	urlFn func(url string) bool
+	// mimeTypes restricts inline content, nil means any.
+	mimeTypes map[string]bool
+}
+
+func (a acceptSpec) url(url string) bool {
+	return a.urlFn != nil && a.urlFn(url)
+}
+
+func anyURL(string) bool { return true }
+
+func set(values ...string) map[string]bool {
+	m := make(map[string]bool, len(values))
+	for _, v := range values {
+		m[v] = true
+	}
+	return m
+}
+
+var commonImageTypes = set("image/jpeg", "image/png", "image/gif", "image/webp")
+
+// openAIAudioFormats maps the audio mime types accepted by OpenAI to their input_audio format.
+var openAIAudioFormats = map[string]string{
+	"audio/wav":      "wav",
+	"audio/x-wav":    "wav",
+	"audio/wave":     "wav",
+	"audio/vnd.wav":  "wav",
+	"audio/vnd.wave": "wav",
+	"audio/x-pn-wav": "wav",
+	"audio/mpeg":     "mp3",
+	"audio/mp3":      "mp3",
+	"audio/mpeg3":    "mp3",
+	"audio/x-mpeg-3": "mp3",
+}
+
+func keys(m map[string]string) map[string]bool {
+	s := make(map[string]bool, len(m))
+	for k := range m {
+		s[k] = true
+	}
+	return s
+}
+
+var acceptance = map[Provider]map[Kind]acceptSpec{
+	ProviderOpenAI: {
+		KindImage: {urlFn: anyURL, mimeTypes: commonImageTypes},
+		KindAudio: {mimeTypes: keys(openAIAudioFormats)},
+		KindFile:  {},
+	},
+	ProviderClaude: {
+		KindImage: {urlFn: anyURL, mimeTypes: commonImageTypes},
+		KindFile:  {urlFn: isPDFURL, mimeTypes: set("application/pdf", "text/plain")},
+	},
+	ProviderGemini: {
+		KindImage: {urlFn: isGeminiFileURI},
+		KindAudio: {urlFn: isGeminiFileURI},
+		KindVideo: {urlFn: isGeminiVideoURI},
+		KindFile:  {urlFn: isGeminiFileURI},
+	},
+	ProviderArk: {
+		KindImage: {urlFn: anyURL},
+		KindVideo: {urlFn: anyURL},
+	},
+}
+
+func baseMIMEType(mimeType string) string {
+	mimeType, _, _ = strings.Cut(mimeType, ";")
+	return strings.TrimSpace(strings.ToLower(mimeType))
+}
+
+func isPDFURL(url string) bool {
+	return strings.HasSuffix(strings.ToLower(urlPath(url)), ".pdf")
+}
+
+// isGeminiFileURI reports whether the url can be used as file_data, which only accepts
+// Files API and Cloud Storage uris.
+func isGeminiFileURI(url string) bool {
+	return strings.HasPrefix(url, "gs://") || strings.HasPrefix(url, "https://generativelanguage.googleapis.com/")
+}
+
+func isGeminiVideoURI(url string) bool {
+	return isGeminiFileURI(url) ||
+		strings.HasPrefix(url, "https://www.youtube.com/") ||
+		strings.HasPrefix(url, "https://youtu.be/")
+}
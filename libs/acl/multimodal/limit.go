@@ -0,0 +1,177 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package multimodal
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+)
+
+// Limits are the size limits a provider enforces on inline parts.
+// A zero value means no limit.
+type Limits struct {
+	// MaxImageBytes is the maximum size of a decoded inline image.
+	// Larger images are downscaled and re-encoded until they fit.
+	MaxImageBytes int
+	// MaxImageDimension is the maximum length of the longest edge of an inline image, in pixels.
+	MaxImageDimension int
+	// MaxMediaBytes is the maximum size of other decoded inline parts (audio, video and file).
+	MaxMediaBytes int
+}
+
+const (
+	jpegQuality = 85
+	// minImageDimension stops the downscaling loop, images this small always fit a sane limit.
+	minImageDimension = 64
+	downscaleFactor   = 0.75
+)
+
+// Enforce checks the part against the limits, downscaling inline png, jpeg and gif images when
+// they exceed MaxImageDimension or MaxImageBytes. Parts that still exceed the limits return ErrTooLarge.
+// Remote URLs are left unchanged, the provider downloads them itself.
+func Enforce(p *Part, limits Limits) error {
+	if !p.IsInline() {
+		return nil
+	}
+	if p.Kind != KindImage {
+		if limits.MaxMediaBytes > 0 && len(p.Data) > limits.MaxMediaBytes {
+			return fmt.Errorf("%w: %s part has %d bytes, limit is %d", ErrTooLarge, p.Kind, len(p.Data), limits.MaxMediaBytes)
+		}
+		return nil
+	}
+	return enforceImage(p, limits)
+}
+
+func enforceImage(p *Part, limits Limits) error {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(p.Data))
+	if err != nil {
+		// formats the standard library can't decode (eg. webp) can only be checked by size
+		if limits.MaxImageBytes > 0 && len(p.Data) > limits.MaxImageBytes {
+			return fmt.Errorf("%w: image has %d bytes, limit is %d", ErrTooLarge, len(p.Data), limits.MaxImageBytes)
+		}
+		return nil
+	}
+
+	tooWide := limits.MaxImageDimension > 0 && maxInt(cfg.Width, cfg.Height) > limits.MaxImageDimension
+	tooHeavy := limits.MaxImageBytes > 0 && len(p.Data) > limits.MaxImageBytes
+	if !tooWide && !tooHeavy {
+		return nil
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(p.Data))
+	if err != nil {
+		return fmt.Errorf("decode %s image fail: %w", format, err)
+	}
+
+	scale := 1.0
+	if tooWide {
+		scale = float64(limits.MaxImageDimension) / float64(maxInt(cfg.Width, cfg.Height))
+	}
+	for {
+		width, height := scaled(cfg.Width, scale), scaled(cfg.Height, scale)
+		data, mimeType, err := encode(resize(src, width, height), format)
+		if err != nil {
+			return fmt.Errorf("encode image fail: %w", err)
+		}
+		if limits.MaxImageBytes <= 0 || len(data) <= limits.MaxImageBytes {
+			p.Data, p.MIMEType = data, mimeType
+			return nil
+		}
+		if maxInt(width, height) <= minImageDimension {
+			return fmt.Errorf("%w: image has %d bytes after downscaling, limit is %d", ErrTooLarge, len(data), limits.MaxImageBytes)
+		}
+		scale *= downscaleFactor
+	}
+}
+
+// maxInt is the max builtin, which the copies of this package can't use in the go 1.18 modules.
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func scaled(n int, scale float64) int {
+	return maxInt(1, int(float64(n)*scale))
+}
+
+// encode keeps png for images with transparency and uses jpeg for everything else, which is much smaller for photos.
+func encode(img image.Image, format string) ([]byte, string, error) {
+	buf := &bytes.Buffer{}
+	if format == "png" && !opaque(img) {
+		if err := png.Encode(buf, img); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "image/png", nil
+	}
+
+	// jpeg has no alpha channel, flatten onto white
+	flat := image.NewRGBA(img.Bounds())
+	draw.Draw(flat, flat.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Bounds(), img, img.Bounds().Min, draw.Over)
+	if err := jpeg.Encode(buf, flat, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), "image/jpeg", nil
+}
+
+func opaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+	return false
+}
+
+// resize scales img to width x height by averaging the source pixels covered by each target pixel.
+func resize(img image.Image, width, height int) image.Image {
+	b := img.Bounds()
+	if b.Dx() == width && b.Dy() == height {
+		return img
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := b.Min.Y + y*b.Dy()/height
+		y1 := maxInt(y0+1, b.Min.Y+(y+1)*b.Dy()/height)
+		for x := 0; x < width; x++ {
+			x0 := b.Min.X + x*b.Dx()/width
+			x1 := maxInt(x0+1, b.Min.X+(x+1)*b.Dx()/width)
+
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := img.At(sx, sy).RGBA()
+					r, g, bl, a = r+uint64(cr), g+uint64(cg), bl+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(bl / n >> 8),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+	return dst
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package multimodal
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func noisyPNG(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	r := rand.New(rand.NewSource(1))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetRGBA(x, y, color.RGBA{R: uint8(r.Intn(256)), G: uint8(r.Intn(256)), B: uint8(r.Intn(256)), A: 255})
+		}
+	}
+	buf := &bytes.Buffer{}
+	assert.NoError(t, png.Encode(buf, img))
+	return buf.Bytes()
+}
+
+func TestEnforce(t *testing.T) {
+	t.Run("within limits", func(t *testing.T) {
+		data := noisyPNG(t, 32, 16)
+		p := &Part{Kind: KindImage, Data: data, MIMEType: "image/png"}
+		assert.NoError(t, Enforce(p, Limits{MaxImageBytes: len(data), MaxImageDimension: 32}))
+		assert.Equal(t, data, p.Data)
+	})
+
+	t.Run("downscale dimension", func(t *testing.T) {
+		p := &Part{Kind: KindImage, Data: noisyPNG(t, 400, 200), MIMEType: "image/png"}
+		assert.NoError(t, Enforce(p, Limits{MaxImageDimension: 100}))
+		cfg, format, err := image.DecodeConfig(bytes.NewReader(p.Data))
+		assert.NoError(t, err)
+		assert.Equal(t, "jpeg", format)
+		assert.Equal(t, "image/jpeg", p.MIMEType)
+		assert.Equal(t, 100, cfg.Width)
+		assert.Equal(t, 50, cfg.Height)
+	})
+
+	t.Run("downscale bytes", func(t *testing.T) {
+		p := &Part{Kind: KindImage, Data: noisyPNG(t, 512, 512), MIMEType: "image/png"}
+		assert.NoError(t, Enforce(p, Limits{MaxImageBytes: 20 << 10}))
+		assert.LessOrEqual(t, len(p.Data), 20<<10)
+		cfg, _, err := image.DecodeConfig(bytes.NewReader(p.Data))
+		assert.NoError(t, err)
+		assert.Less(t, cfg.Width, 512)
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		p := &Part{Kind: KindImage, Data: noisyPNG(t, 128, 128), MIMEType: "image/png"}
+		assert.ErrorIs(t, Enforce(p, Limits{MaxImageBytes: 10}), ErrTooLarge)
+
+		p = &Part{Kind: KindImage, Data: []byte("RIFF....WEBPVP8 not decodable"), MIMEType: "image/webp"}
+		assert.ErrorIs(t, Enforce(p, Limits{MaxImageBytes: 10}), ErrTooLarge)
+
+		p = &Part{Kind: KindAudio, Data: make([]byte, 11), MIMEType: "audio/wav"}
+		assert.ErrorIs(t, Enforce(p, Limits{MaxMediaBytes: 10}), ErrTooLarge)
+	})
+
+	t.Run("remote url", func(t *testing.T) {
+		p := &Part{Kind: KindImage, URL: "https://example.com/a.png"}
+		assert.NoError(t, Enforce(p, Limits{MaxImageBytes: 1}))
+	})
+}
@@ -0,0 +1,255 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package multimodal maps eino multimodal message parts to the native content
+// formats of the model providers, so that every chat model component accepts
+// the same set of parts and enforces the provider's size limits the same way.
+package multimodal
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// Kind is the kind of content carried by a Part.
+type Kind string
+
+const (
+	KindText  Kind = "text"
+	KindImage Kind = "image"
+	KindAudio Kind = "audio"
+	KindVideo Kind = "video"
+	KindFile  Kind = "file"
+)
+
+var (
+	// ErrUnsupported is returned when a provider can not accept a part.
+	ErrUnsupported = errors.New("multimodal part not supported")
+	// ErrTooLarge is returned when a part exceeds the provider's size limit and can not be downscaled.
+	ErrTooLarge = errors.New("multimodal part too large")
+)
+
+// Part is the provider independent form of a message part.
+// Media parts carry either a remote URL or the decoded Data.
+type Part struct {
+	Kind Kind
+	Text string
+
+	URL      string
+	Data     []byte
+	MIMEType string
+
+	// Detail is the image detail hint, only used by providers that support it.
+	Detail schema.ImageURLDetail
+	// Name is the file name, only used by file parts.
+	Name string
+}
+
+// IsInline reports whether the part carries its content inline.
+func (p *Part) IsInline() bool {
+	return len(p.Data) > 0
+}
+
+// Base64 returns the base64 encoded Data.
+func (p *Part) Base64() string {
+	return base64.StdEncoding.EncodeToString(p.Data)
+}
+
+// DataURL returns the content as a RFC-2397 data URL, or the remote URL when the part is not inline.
+func (p *Part) DataURL() string {
+	if !p.IsInline() {
+		return p.URL
+	}
+	return "data:" + p.MIMEType + ";base64," + p.Base64()
+}
+
+// FromInputPart converts an eino user input part.
+// URL may be either a remote URL or a RFC-2397 data URL, Base64Data must be raw base64 with MIMEType set.
+func FromInputPart(part schema.MessageInputPart) (*Part, error) {
+	switch part.Type {
+	case schema.ChatMessagePartTypeText:
+		return &Part{Kind: KindText, Text: part.Text}, nil
+	case schema.ChatMessagePartTypeImageURL:
+		if part.Image == nil {
+			return nil, fmt.Errorf("image field must not be nil when Type is %s", part.Type)
+		}
+		p, err := fromCommon(KindImage, &part.Image.MessagePartCommon)
+		if err != nil {
+			return nil, err
+		}
+		p.Detail = part.Image.Detail
+		return p, nil
+	case schema.ChatMessagePartTypeAudioURL:
+		if part.Audio == nil {
+			return nil, fmt.Errorf("audio field must not be nil when Type is %s", part.Type)
+		}
+		return fromCommon(KindAudio, &part.Audio.MessagePartCommon)
+	case schema.ChatMessagePartTypeVideoURL:
+		if part.Video == nil {
+			return nil, fmt.Errorf("video field must not be nil when Type is %s", part.Type)
+		}
+		return fromCommon(KindVideo, &part.Video.MessagePartCommon)
+	case schema.ChatMessagePartTypeFileURL:
+		if part.File == nil {
+			return nil, fmt.Errorf("file field must not be nil when Type is %s", part.Type)
+		}
+		p, err := fromCommon(KindFile, &part.File.MessagePartCommon)
+		if err != nil {
+			return nil, err
+		}
+		p.Name = fileName(p.URL)
+		return p, nil
+	default:
+		return nil, fmt.Errorf("%w: part type %s", ErrUnsupported, part.Type)
+	}
+}
+
+// FromOutputPart converts an eino model output part, so that content generated by one provider can be sent to another.
+func FromOutputPart(part schema.MessageOutputPart) (*Part, error) {
+	switch part.Type {
+	case schema.ChatMessagePartTypeText:
+		return &Part{Kind: KindText, Text: part.Text}, nil
+	case schema.ChatMessagePartTypeImageURL:
+		if part.Image == nil {
+			return nil, fmt.Errorf("image field must not be nil when Type is %s", part.Type)
+		}
+		return fromCommon(KindImage, &part.Image.MessagePartCommon)
+	case schema.ChatMessagePartTypeAudioURL:
+		if part.Audio == nil {
+			return nil, fmt.Errorf("audio field must not be nil when Type is %s", part.Type)
+		}
+		return fromCommon(KindAudio, &part.Audio.MessagePartCommon)
+	case schema.ChatMessagePartTypeVideoURL:
+		if part.Video == nil {
+			return nil, fmt.Errorf("video field must not be nil when Type is %s", part.Type)
+		}
+		return fromCommon(KindVideo, &part.Video.MessagePartCommon)
+	default:
+		return nil, fmt.Errorf("%w: part type %s", ErrUnsupported, part.Type)
+	}
+}
+
+// ToInputPart converts the part back to an eino user input part.
+func (p *Part) ToInputPart() schema.MessageInputPart {
+	common := p.toCommon()
+	switch p.Kind {
+	case KindImage:
+		return schema.MessageInputPart{Type: schema.ChatMessagePartTypeImageURL, Image: &schema.MessageInputImage{MessagePartCommon: common, Detail: p.Detail}}
+	case KindAudio:
+		return schema.MessageInputPart{Type: schema.ChatMessagePartTypeAudioURL, Audio: &schema.MessageInputAudio{MessagePartCommon: common}}
+	case KindVideo:
+		return schema.MessageInputPart{Type: schema.ChatMessagePartTypeVideoURL, Video: &schema.MessageInputVideo{MessagePartCommon: common}}
+	case KindFile:
+		return schema.MessageInputPart{Type: schema.ChatMessagePartTypeFileURL, File: &schema.MessageInputFile{MessagePartCommon: common}}
+	default:
+		return schema.MessageInputPart{Type: schema.ChatMessagePartTypeText, Text: p.Text}
+	}
+}
+
+func (p *Part) toCommon() schema.MessagePartCommon {
+	common := schema.MessagePartCommon{MIMEType: p.MIMEType}
+	if p.IsInline() {
+		data := p.Base64()
+		common.Base64Data = &data
+	} else if p.URL != "" {
+		url := p.URL
+		common.URL = &url
+	}
+	return common
+}
+
+func fromCommon(kind Kind, common *schema.MessagePartCommon) (*Part, error) {
+	p := &Part{Kind: kind, MIMEType: common.MIMEType}
+	switch {
+	case common.URL != nil && *common.URL != "":
+		if strings.HasPrefix(*common.URL, "data:") {
+			mimeType, data, err := parseDataURL(*common.URL)
+			if err != nil {
+				return nil, fmt.Errorf("parse %s data url fail: %w", kind, err)
+			}
+			p.Data = data
+			if p.MIMEType == "" {
+				p.MIMEType = mimeType
+			}
+		} else {
+			p.URL = *common.URL
+		}
+	case common.Base64Data != nil && *common.Base64Data != "":
+		if p.MIMEType == "" {
+			return nil, fmt.Errorf("%s part must have MIMEType when use Base64Data", kind)
+		}
+		if strings.HasPrefix(*common.Base64Data, "data:") {
+			return nil, fmt.Errorf("Base64Data should be a raw base64 string, but it has a 'data:' prefix")
+		}
+		data, err := base64.StdEncoding.DecodeString(*common.Base64Data)
+		if err != nil {
+			return nil, fmt.Errorf("decode %s base64 data fail: %w", kind, err)
+		}
+		p.Data = data
+	default:
+		return nil, fmt.Errorf("%s part must have either a URL or Base64Data", kind)
+	}
+
+	if p.MIMEType == "" {
+		p.MIMEType = detectMIMEType(p)
+	}
+	return p, nil
+}
+
+// parseDataURL parses a base64 RFC-2397 data URL, eg. "data:image/png;base64,iVBORw0...".
+func parseDataURL(dataURL string) (string, []byte, error) {
+	header, payload, found := strings.Cut(strings.TrimPrefix(dataURL, "data:"), ",")
+	if !found {
+		return "", nil, fmt.Errorf("invalid data url, missing ','")
+	}
+	params := strings.Split(header, ";")
+	if params[len(params)-1] != "base64" {
+		return "", nil, fmt.Errorf("invalid data url, only base64 encoding is supported")
+	}
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return "", nil, err
+	}
+	return params[0], data, nil
+}
+
+func detectMIMEType(p *Part) string {
+	if p.IsInline() {
+		return http.DetectContentType(p.Data)
+	}
+	return mime.TypeByExtension(path.Ext(urlPath(p.URL)))
+}
+
+func fileName(url string) string {
+	if url == "" {
+		return ""
+	}
+	return path.Base(urlPath(url))
+}
+
+func urlPath(url string) string {
+	if i := strings.IndexAny(url, "?#"); i >= 0 {
+		url = url[:i]
+	}
+	return url
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package multimodal
+
+import (
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromInputPart(t *testing.T) {
+	pngBase64 := "iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAQAAAC1HAwCAAAAC0lEQVR42mNkYAAAAAYAAjCB0C8AAAAASUVORK5CYII="
+	dataURL := "data:image/png;base64," + pngBase64
+	httpURL := "https://example.com/docs/report.pdf?sig=1"
+
+	t.Run("base64", func(t *testing.T) {
+		p, err := FromInputPart(schema.MessageInputPart{
+			Type:  schema.ChatMessagePartTypeImageURL,
+			Image: &schema.MessageInputImage{MessagePartCommon: schema.MessagePartCommon{Base64Data: &pngBase64, MIMEType: "image/png"}, Detail: schema.ImageURLDetailHigh},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, KindImage, p.Kind)
+		assert.True(t, p.IsInline())
+		assert.Equal(t, "image/png", p.MIMEType)
+		assert.Equal(t, schema.ImageURLDetailHigh, p.Detail)
+		assert.Equal(t, dataURL, p.DataURL())
+	})
+
+	t.Run("data url", func(t *testing.T) {
+		p, err := FromInputPart(schema.MessageInputPart{
+			Type:  schema.ChatMessagePartTypeImageURL,
+			Image: &schema.MessageInputImage{MessagePartCommon: schema.MessagePartCommon{URL: &dataURL}},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "image/png", p.MIMEType)
+		assert.Equal(t, pngBase64, p.Base64())
+	})
+
+	t.Run("remote url", func(t *testing.T) {
+		p, err := FromInputPart(schema.MessageInputPart{
+			Type: schema.ChatMessagePartTypeFileURL,
+			File: &schema.MessageInputFile{MessagePartCommon: schema.MessagePartCommon{URL: &httpURL}},
+		})
+		assert.NoError(t, err)
+		assert.False(t, p.IsInline())
+		assert.Equal(t, "application/pdf", p.MIMEType)
+		assert.Equal(t, "report.pdf", p.Name)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := FromInputPart(schema.MessageInputPart{Type: schema.ChatMessagePartTypeAudioURL})
+		assert.ErrorContains(t, err, "audio field must not be nil")
+
+		_, err = FromInputPart(schema.MessageInputPart{
+			Type:  schema.ChatMessagePartTypeImageURL,
+			Image: &schema.MessageInputImage{MessagePartCommon: schema.MessagePartCommon{Base64Data: &pngBase64}},
+		})
+		assert.ErrorContains(t, err, "image part must have MIMEType when use Base64Data")
+
+		_, err = FromInputPart(schema.MessageInputPart{
+			Type:  schema.ChatMessagePartTypeImageURL,
+			Image: &schema.MessageInputImage{MessagePartCommon: schema.MessagePartCommon{Base64Data: &dataURL, MIMEType: "image/png"}},
+		})
+		assert.ErrorContains(t, err, "Base64Data should be a raw base64 string")
+
+		_, err = FromInputPart(schema.MessageInputPart{Type: schema.ChatMessagePartTypeVideoURL, Video: &schema.MessageInputVideo{}})
+		assert.ErrorContains(t, err, "video part must have either a URL or Base64Data")
+	})
+}
+
+func TestRoundTrip(t *testing.T) {
+	data := "UklGRiQAAABXQVZF"
+	out := schema.MessageOutputPart{
+		Type:  schema.ChatMessagePartTypeAudioURL,
+		Audio: &schema.MessageOutputAudio{MessagePartCommon: schema.MessagePartCommon{Base64Data: &data, MIMEType: "audio/wav"}},
+	}
+	p, err := FromOutputPart(out)
+	assert.NoError(t, err)
+
+	in := p.ToInputPart()
+	assert.Equal(t, schema.ChatMessagePartTypeAudioURL, in.Type)
+	assert.Equal(t, data, *in.Audio.Base64Data)
+	assert.Equal(t, "audio/wav", in.Audio.MIMEType)
+
+	p2, err := FromInputPart(in)
+	assert.NoError(t, err)
+	assert.Equal(t, p, p2)
+}
@@ -0,0 +1,182 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package multimodal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// OpenAIContentPart is a chat completions content part.
+type OpenAIContentPart struct {
+	Type       string            `json:"type"`
+	Text       string            `json:"text,omitempty"`
+	ImageURL   *OpenAIImageURL   `json:"image_url,omitempty"`
+	InputAudio *OpenAIInputAudio `json:"input_audio,omitempty"`
+	File       *OpenAIFile       `json:"file,omitempty"`
+}
+
+type OpenAIImageURL struct {
+	URL    string `json:"url"`
+	Detail string `json:"detail,omitempty"`
+}
+
+type OpenAIInputAudio struct {
+	// Data is the base64 encoded audio.
+	Data   string `json:"data"`
+	Format string `json:"format"`
+}
+
+type OpenAIFile struct {
+	// FileData is the file content as a data URL.
+	FileData string `json:"file_data,omitempty"`
+	Filename string `json:"filename,omitempty"`
+}
+
+// ClaudeContentBlock is a messages API content block.
+type ClaudeContentBlock struct {
+	Type   string        `json:"type"`
+	Text   string        `json:"text,omitempty"`
+	Source *ClaudeSource `json:"source,omitempty"`
+}
+
+type ClaudeSource struct {
+	// Type is one of "base64", "url" and "text".
+	Type      string `json:"type"`
+	MediaType string `json:"media_type,omitempty"`
+	Data      string `json:"data,omitempty"`
+	URL       string `json:"url,omitempty"`
+}
+
+// GeminiPart is a generateContent part.
+type GeminiPart struct {
+	Text       string          `json:"text,omitempty"`
+	InlineData *GeminiBlob     `json:"inlineData,omitempty"`
+	FileData   *GeminiFileData `json:"fileData,omitempty"`
+}
+
+type GeminiBlob struct {
+	MIMEType string `json:"mimeType"`
+	Data     []byte `json:"data"`
+}
+
+type GeminiFileData struct {
+	MIMEType string `json:"mimeType,omitempty"`
+	FileURI  string `json:"fileUri"`
+}
+
+// ArkContentPart is an Ark chat content part.
+type ArkContentPart struct {
+	Type     string       `json:"type"`
+	Text     string       `json:"text,omitempty"`
+	ImageURL *ArkImageURL `json:"image_url,omitempty"`
+	VideoURL *ArkVideoURL `json:"video_url,omitempty"`
+}
+
+type ArkImageURL struct {
+	URL    string `json:"url"`
+	Detail string `json:"detail,omitempty"`
+}
+
+type ArkVideoURL struct {
+	URL string `json:"url"`
+}
+
+// ToOpenAI prepares the part and renders it for OpenAI.
+func (c *Converter) ToOpenAI(ctx context.Context, part *Part) (*OpenAIContentPart, error) {
+	if err := c.Prepare(ctx, ProviderOpenAI, part); err != nil {
+		return nil, err
+	}
+	switch part.Kind {
+	case KindText:
+		return &OpenAIContentPart{Type: "text", Text: part.Text}, nil
+	case KindImage:
+		return &OpenAIContentPart{Type: "image_url", ImageURL: &OpenAIImageURL{URL: part.DataURL(), Detail: string(part.Detail)}}, nil
+	case KindAudio:
+		return &OpenAIContentPart{Type: "input_audio", InputAudio: &OpenAIInputAudio{Data: part.Base64(), Format: audioFormat(part.MIMEType)}}, nil
+	case KindFile:
+		return &OpenAIContentPart{Type: "file", File: &OpenAIFile{FileData: part.DataURL(), Filename: part.Name}}, nil
+	default:
+		return nil, fmt.Errorf("%w: openai does not accept %s parts", ErrUnsupported, part.Kind)
+	}
+}
+
+// ToClaude prepares the part and renders it for Claude.
+func (c *Converter) ToClaude(ctx context.Context, part *Part) (*ClaudeContentBlock, error) {
+	if err := c.Prepare(ctx, ProviderClaude, part); err != nil {
+		return nil, err
+	}
+	switch part.Kind {
+	case KindText:
+		return &ClaudeContentBlock{Type: "text", Text: part.Text}, nil
+	case KindImage:
+		return &ClaudeContentBlock{Type: "image", Source: claudeSource(part)}, nil
+	case KindFile:
+		if part.IsInline() && baseMIMEType(part.MIMEType) == "text/plain" {
+			return &ClaudeContentBlock{Type: "document", Source: &ClaudeSource{Type: "text", MediaType: "text/plain", Data: string(part.Data)}}, nil
+		}
+		return &ClaudeContentBlock{Type: "document", Source: claudeSource(part)}, nil
+	default:
+		return nil, fmt.Errorf("%w: claude does not accept %s parts", ErrUnsupported, part.Kind)
+	}
+}
+
+func claudeSource(part *Part) *ClaudeSource {
+	if part.IsInline() {
+		return &ClaudeSource{Type: "base64", MediaType: baseMIMEType(part.MIMEType), Data: part.Base64()}
+	}
+	return &ClaudeSource{Type: "url", URL: part.URL}
+}
+
+// ToGemini prepares the part and renders it for Gemini.
+func (c *Converter) ToGemini(ctx context.Context, part *Part) (*GeminiPart, error) {
+	if err := c.Prepare(ctx, ProviderGemini, part); err != nil {
+		return nil, err
+	}
+	if part.Kind == KindText {
+		return &GeminiPart{Text: part.Text}, nil
+	}
+	if part.IsInline() {
+		return &GeminiPart{InlineData: &GeminiBlob{MIMEType: part.MIMEType, Data: part.Data}}, nil
+	}
+	return &GeminiPart{FileData: &GeminiFileData{MIMEType: part.MIMEType, FileURI: part.URL}}, nil
+}
+
+// ToArk prepares the part and renders it for Ark.
+func (c *Converter) ToArk(ctx context.Context, part *Part) (*ArkContentPart, error) {
+	if err := c.Prepare(ctx, ProviderArk, part); err != nil {
+		return nil, err
+	}
+	switch part.Kind {
+	case KindText:
+		return &ArkContentPart{Type: "text", Text: part.Text}, nil
+	case KindImage:
+		return &ArkContentPart{Type: "image_url", ImageURL: &ArkImageURL{URL: part.DataURL(), Detail: string(part.Detail)}}, nil
+	case KindVideo:
+		return &ArkContentPart{Type: "video_url", VideoURL: &ArkVideoURL{URL: part.DataURL()}}, nil
+	default:
+		return nil, fmt.Errorf("%w: ark does not accept %s parts", ErrUnsupported, part.Kind)
+	}
+}
+
+func audioFormat(mimeType string) string {
+	if format, ok := openAIAudioFormats[baseMIMEType(mimeType)]; ok {
+		return format
+	}
+	return strings.TrimPrefix(baseMIMEType(mimeType), "audio/")
+}
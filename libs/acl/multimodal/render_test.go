@@ -0,0 +1,139 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package multimodal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	ctx := context.Background()
+	c := NewConverter(nil)
+	pdf := []byte("%PDF-1.4 test")
+
+	newImage := func() *Part { return &Part{Kind: KindImage, Data: []byte("GIF89a"), MIMEType: "image/gif"} }
+	newURLImage := func() *Part { return &Part{Kind: KindImage, URL: "https://example.com/a.png", MIMEType: "image/png"} }
+
+	t.Run("openai", func(t *testing.T) {
+		p, err := c.ToOpenAI(ctx, newImage())
+		assert.NoError(t, err)
+		assert.Equal(t, "image_url", p.Type)
+		assert.Equal(t, "data:image/gif;base64,R0lGODlh", p.ImageURL.URL)
+
+		p, err = c.ToOpenAI(ctx, &Part{Kind: KindAudio, Data: []byte("RIFF"), MIMEType: "audio/x-wav"})
+		assert.NoError(t, err)
+		assert.Equal(t, &OpenAIInputAudio{Data: "UklGRg==", Format: "wav"}, p.InputAudio)
+		p, err = c.ToOpenAI(ctx, &Part{Kind: KindAudio, Data: []byte("ID3"), MIMEType: "audio/mpeg3"})
+		assert.NoError(t, err)
+		assert.Equal(t, "mp3", p.InputAudio.Format)
+		_, err = c.ToOpenAI(ctx, &Part{Kind: KindAudio, Data: []byte("fLaC"), MIMEType: "audio/flac"})
+		assert.ErrorIs(t, err, ErrUnsupported)
+
+		p, err = c.ToOpenAI(ctx, &Part{Kind: KindFile, Data: pdf, MIMEType: "application/pdf", Name: "a.pdf"})
+		assert.NoError(t, err)
+		assert.Equal(t, "a.pdf", p.File.Filename)
+
+		_, err = c.ToOpenAI(ctx, &Part{Kind: KindAudio, URL: "https://example.com/a.wav"})
+		assert.ErrorIs(t, err, ErrUnsupported)
+		_, err = c.ToOpenAI(ctx, &Part{Kind: KindVideo, URL: "https://example.com/a.mp4"})
+		assert.ErrorIs(t, err, ErrUnsupported)
+	})
+
+	t.Run("claude", func(t *testing.T) {
+		b, err := c.ToClaude(ctx, newImage())
+		assert.NoError(t, err)
+		raw, _ := json.Marshal(b)
+		assert.JSONEq(t, `{"type":"image","source":{"type":"base64","media_type":"image/gif","data":"R0lGODlh"}}`, string(raw))
+
+		b, err = c.ToClaude(ctx, newURLImage())
+		assert.NoError(t, err)
+		assert.Equal(t, &ClaudeSource{Type: "url", URL: "https://example.com/a.png"}, b.Source)
+
+		b, err = c.ToClaude(ctx, &Part{Kind: KindFile, Data: []byte("hello"), MIMEType: "text/plain; charset=utf-8"})
+		assert.NoError(t, err)
+		assert.Equal(t, &ClaudeSource{Type: "text", MediaType: "text/plain", Data: "hello"}, b.Source)
+
+		_, err = c.ToClaude(ctx, &Part{Kind: KindFile, Data: []byte("{}"), MIMEType: "application/json"})
+		assert.ErrorIs(t, err, ErrUnsupported)
+		_, err = c.ToClaude(ctx, &Part{Kind: KindAudio, Data: []byte("RIFF"), MIMEType: "audio/wav"})
+		assert.ErrorIs(t, err, ErrUnsupported)
+	})
+
+	t.Run("gemini", func(t *testing.T) {
+		p, err := c.ToGemini(ctx, newImage())
+		assert.NoError(t, err)
+		raw, _ := json.Marshal(p)
+		assert.JSONEq(t, `{"inlineData":{"mimeType":"image/gif","data":"R0lGODlh"}}`, string(raw))
+
+		p, err = c.ToGemini(ctx, &Part{Kind: KindVideo, URL: "https://youtu.be/abc", MIMEType: "video/mp4"})
+		assert.NoError(t, err)
+		assert.Equal(t, "https://youtu.be/abc", p.FileData.FileURI)
+
+		_, err = c.ToGemini(ctx, newURLImage())
+		assert.ErrorIs(t, err, ErrUnsupported)
+	})
+
+	t.Run("ark", func(t *testing.T) {
+		p, err := c.ToArk(ctx, newURLImage())
+		assert.NoError(t, err)
+		assert.Equal(t, "https://example.com/a.png", p.ImageURL.URL)
+
+		p, err = c.ToArk(ctx, &Part{Kind: KindVideo, Data: []byte("video"), MIMEType: "video/mp4"})
+		assert.NoError(t, err)
+		assert.Equal(t, "data:video/mp4;base64,dmlkZW8=", p.VideoURL.URL)
+
+		_, err = c.ToArk(ctx, &Part{Kind: KindFile, Data: pdf, MIMEType: "application/pdf"})
+		assert.ErrorIs(t, err, ErrUnsupported)
+	})
+
+	t.Run("text", func(t *testing.T) {
+		p, err := c.ToGemini(ctx, &Part{Kind: KindText, Text: "hi"})
+		assert.NoError(t, err)
+		assert.Equal(t, "hi", p.Text)
+	})
+}
+
+func TestDownload(t *testing.T) {
+	ctx := context.Background()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing.png" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "image/gif")
+		_, _ = w.Write([]byte("GIF89a"))
+	}))
+	defer srv.Close()
+
+	c := NewConverter(&Config{HTTPClient: srv.Client()})
+	p, err := c.ToGemini(ctx, &Part{Kind: KindImage, URL: srv.URL + "/a.gif"})
+	assert.NoError(t, err)
+	assert.Equal(t, &GeminiBlob{MIMEType: "image/gif", Data: []byte("GIF89a")}, p.InlineData)
+
+	_, err = c.ToGemini(ctx, &Part{Kind: KindImage, URL: srv.URL + "/missing.png"})
+	assert.ErrorContains(t, err, "status 404")
+
+	c = NewConverter(&Config{HTTPClient: srv.Client(), Limits: map[Provider]Limits{ProviderGemini: {MaxImageBytes: 3}}})
+	_, err = c.ToGemini(ctx, &Part{Kind: KindImage, URL: srv.URL + "/a.gif"})
+	assert.ErrorIs(t, err, ErrTooLarge)
+}
@@ -34,6 +34,8 @@ import (
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components/model"
 	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/libs/acl/openai/internal/multimodal"
 )
 
 type ChatCompletionResponseFormatType string
@@ -181,6 +183,11 @@ type Config struct {
 
 	// Audio parameters for audio output. Required when audio output is requested with modalities: ["audio"]
 	Audio *Audio `json:"audio,omitempty"`
+
+	// StrictToolCallChunks rejects the streamed tool call chunks not conforming to the OpenAI API, e.g. without index,
+	// with an error, instead of repairing them, as done for providers and proxies such as some vLLM versions.
+	// Optional. Default: false
+	StrictToolCallChunks bool `json:"strict_tool_call_chunks,omitempty"`
 }
 
 // Audio specifies the audio output settings
@@ -199,17 +206,9 @@ type Client struct {
 	toolChoice *schema.ToolChoice
 }
 
-var mimeType2AudioFormat = map[string]string{
-	"audio/wav":      "wav",
-	"audio/vnd.wav":  "wav",
-	"audio/vnd.wave": "wav",
-	"audio/wave":     "wav",
-	"audio/x-pn-wav": "wav",
-	"audio/mpeg":     "wav",
-	"audio/x-wav":    "mp3",
-	"audio/mpeg3":    "mp3",
-	"audio/x-mpeg-3": "mp3",
-}
+//go:generate sh ../../../libs/acl/bundle.sh multimodal internal/multimodal
+
+var partConverter = multimodal.NewConverter(nil)
 
 // audioFormat2MimeTypes maps audio file formats to their corresponding MIME types.
 var audioFormat2MimeTypes = map[string]string{
@@ -401,62 +400,15 @@ func buildMessageFromUserInputMultiContent(inMsg *schema.Message) (openai.ChatCo
 	}
 	for _, part := range inMsg.UserInputMultiContent {
 		switch part.Type {
-		case schema.ChatMessagePartTypeText:
-			comMessage.MultiContent = append(comMessage.MultiContent, openai.ChatMessagePart{
-				Type: openai.ChatMessagePartTypeText,
-				Text: part.Text,
-			})
-		case schema.ChatMessagePartTypeAudioURL:
-			if part.Audio == nil {
-				return comMessage, errors.New("the 'audio' field is required for parts of type 'audio_url'")
-			}
-			if part.Audio.Base64Data != nil {
-				format, ok := mimeType2AudioFormat[part.Audio.MIMEType]
-				if !ok {
-					return comMessage, fmt.Errorf("the 'format' field is required when type is audio_url, use SetMessageInputAudioFormat to set it")
-				}
-				comMessage.MultiContent = append(comMessage.MultiContent, openai.ChatMessagePart{
-					Type: openai.ChatMessagePartTypeInputAudio,
-					InputAudio: &openai.ChatMessageInputAudio{
-						Data:   *part.Audio.Base64Data,
-						Format: format,
-					},
-				})
-			} else if part.Audio.URL != nil {
-				return comMessage, errors.New("for user role, audio message part does not accept URL, only base64 data is supported")
-			} else {
-				return comMessage, errors.New("audio message part must have url or base64 data")
-			}
-
-		case schema.ChatMessagePartTypeImageURL:
-			if part.Image == nil {
-				return comMessage, errors.New("the 'image' field is required for parts of type 'image_url'")
-			}
-
-			if part.Image.URL != nil {
-				comMessage.MultiContent = append(comMessage.MultiContent, openai.ChatMessagePart{
-					Type: openai.ChatMessagePartTypeImageURL,
-					ImageURL: &openai.ChatMessageImageURL{
-						URL:    *part.Image.URL,
-						Detail: openai.ImageURLDetail(part.Image.Detail),
-					},
-				})
-			} else if part.Image.Base64Data != nil {
-				if part.Image.MessagePartCommon.MIMEType == "" {
-					return comMessage, fmt.Errorf("mimetype is required when using base64data")
-				}
-				comMessage.MultiContent = append(comMessage.MultiContent, openai.ChatMessagePart{
-					Type: openai.ChatMessagePartTypeImageURL,
-					ImageURL: &openai.ChatMessageImageURL{
-						URL:    fmt.Sprintf("data:%s;base64,%s", part.Image.MIMEType, *part.Image.Base64Data),
-						Detail: openai.ImageURLDetail(part.Image.Detail),
-					},
-				})
-			} else {
-				return comMessage, errors.New("image message part must have url or base64 data")
+		case schema.ChatMessagePartTypeText, schema.ChatMessagePartTypeImageURL, schema.ChatMessagePartTypeAudioURL:
+			mc, err := convInputPart(part)
+			if err != nil {
+				return openai.ChatCompletionMessage{}, err
 			}
+			comMessage.MultiContent = append(comMessage.MultiContent, mc)
 
 		case schema.ChatMessagePartTypeVideoURL:
+			// video is not an openai input, but is accepted by openai compatible apis, e.g. qwen
 			if part.Video == nil {
 				return comMessage, errors.New("the 'video' field is required for parts of type 'video_url'")
 			}
@@ -490,6 +442,44 @@ func buildMessageFromUserInputMultiContent(inMsg *schema.Message) (openai.ChatCo
 	return comMessage, nil
 }
 
+// convInputPart converts a text, image or audio user input part with the shared multimodal converter,
+// which checks the parts against the api limits and downscales the images over them.
+func convInputPart(part schema.MessageInputPart) (openai.ChatMessagePart, error) {
+	p, err := multimodal.FromInputPart(part)
+	if err != nil {
+		return openai.ChatMessagePart{}, err
+	}
+	// no http client is configured, so the converter never downloads, e.g. audio urls are rejected
+	mc, err := partConverter.ToOpenAI(context.Background(), p)
+	if err != nil {
+		return openai.ChatMessagePart{}, fmt.Errorf("convert message part fail: %w", err)
+	}
+
+	switch {
+	case mc.ImageURL != nil:
+		return openai.ChatMessagePart{
+			Type: openai.ChatMessagePartTypeImageURL,
+			ImageURL: &openai.ChatMessageImageURL{
+				URL:    mc.ImageURL.URL,
+				Detail: openai.ImageURLDetail(mc.ImageURL.Detail),
+			},
+		}, nil
+	case mc.InputAudio != nil:
+		return openai.ChatMessagePart{
+			Type: openai.ChatMessagePartTypeInputAudio,
+			InputAudio: &openai.ChatMessageInputAudio{
+				Data:   mc.InputAudio.Data,
+				Format: mc.InputAudio.Format,
+			},
+		}, nil
+	default:
+		return openai.ChatMessagePart{
+			Type: openai.ChatMessagePartTypeText,
+			Text: mc.Text,
+		}, nil
+	}
+}
+
 // buildMessageFromAssistantGenMultiContent builds a ChatCompletionMessage from AssistantGenMultiContent.
 // It processes text parts and a single audio part. If an audio part is found,
 // it creates a message with an audio ID and stops processing further parts.
@@ -858,6 +848,7 @@ func (c *Client) Stream(ctx context.Context, in []*schema.Message,
 	sr, sw := schema.Pipe[*model.CallbackOutput](1)
 
 	builder := newStreamMessageBuilder(c.config.Audio)
+	builder.toolCalls.strict = c.config.StrictToolCallChunks
 	go func() {
 		defer func() {
 			panicErr := recover()
@@ -1041,13 +1032,15 @@ func byteSlice2int64(in []byte) []int64 {
 }
 
 type streamMessageBuilder struct {
-	audioCfg *Audio
-	audioID  string
+	audioCfg  *Audio
+	audioID   string
+	toolCalls *toolCallChunkNormalizer
 }
 
 func newStreamMessageBuilder(audio *Audio) *streamMessageBuilder {
 	return &streamMessageBuilder{
-		audioCfg: audio,
+		audioCfg:  audio,
+		toolCalls: newToolCallChunkNormalizer(false),
 	}
 }
 
@@ -1092,10 +1085,15 @@ func (b *streamMessageBuilder) build(resp openai.ChatCompletionStreamResponse) (
 
 		found = true
 
+		toolCalls, err := b.toolCalls.normalize(toMessageToolCalls(choice.Delta.ToolCalls))
+		if err != nil {
+			return nil, found, err
+		}
+
 		msg = &schema.Message{
 			Role:      toMessageRole(choice.Delta.Role),
 			Content:   choice.Delta.Content,
-			ToolCalls: toMessageToolCalls(choice.Delta.ToolCalls),
+			ToolCalls: toolCalls,
 			ResponseMeta: &schema.ResponseMeta{
 				FinishReason: string(choice.FinishReason),
 				Usage:        toEinoTokenUsage(resp.Usage),
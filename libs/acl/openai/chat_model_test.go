@@ -17,7 +17,12 @@
 package openai
 
 import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/png"
 	"math/rand"
+	"strings"
 	"testing"
 
 	"github.com/bytedance/mockey"
@@ -25,6 +30,9 @@ import (
 	"github.com/cloudwego/eino/schema"
 	openai "github.com/meguminnnnnnnnn/go-openai"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudwego/eino-ext/libs/acl/openai/internal/multimodal"
 )
 
 func TestToXXXUtils(t *testing.T) {
@@ -374,7 +382,7 @@ func TestBuildMessages(t *testing.T) {
 
 func TestBuildMessageFromUserInputMultiContent(t *testing.T) {
 	mockey.PatchConvey("TestBuildMessageFromUserInputMultiContent", t, func() {
-		base64Data := "base64data"
+		base64Data := "aGVsbG8="
 		text := "hello"
 
 		tests := []struct {
@@ -432,7 +440,7 @@ func TestBuildMessageFromUserInputMultiContent(t *testing.T) {
 						{
 							Type: openai.ChatMessagePartTypeImageURL,
 							ImageURL: &openai.ChatMessageImageURL{
-								URL:    "data:image/png;base64,base64data",
+								URL:    "data:image/png;base64,aGVsbG8=",
 								Detail: openai.ImageURLDetailHigh,
 							},
 						},
@@ -446,7 +454,7 @@ func TestBuildMessageFromUserInputMultiContent(t *testing.T) {
 						{
 							Type: openai.ChatMessagePartTypeVideoURL,
 							VideoURL: &openai.ChatMessageVideoURL{
-								URL: "data:video/mp4;base64,base64data",
+								URL: "data:video/mp4;base64,aGVsbG8=",
 							},
 						},
 					},
@@ -479,6 +487,62 @@ func TestBuildMessageFromUserInputMultiContent(t *testing.T) {
 	})
 }
 
+func TestConvInputPart(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+	inputImage := func(common schema.MessagePartCommon) schema.MessageInputPart {
+		return schema.MessageInputPart{Type: schema.ChatMessagePartTypeImageURL, Image: &schema.MessageInputImage{MessagePartCommon: common}}
+	}
+	inputAudio := func(common schema.MessagePartCommon) schema.MessageInputPart {
+		return schema.MessageInputPart{Type: schema.ChatMessagePartTypeAudioURL, Audio: &schema.MessageInputAudio{MessagePartCommon: common}}
+	}
+
+	t.Run("image url", func(t *testing.T) {
+		mc, err := convInputPart(inputImage(schema.MessagePartCommon{URL: strPtr("https://example.com/a.png")}))
+		require.NoError(t, err)
+		assert.Equal(t, openai.ChatMessagePart{
+			Type:     openai.ChatMessagePartTypeImageURL,
+			ImageURL: &openai.ChatMessageImageURL{URL: "https://example.com/a.png"},
+		}, mc)
+	})
+
+	t.Run("image data url", func(t *testing.T) {
+		mc, err := convInputPart(inputImage(schema.MessagePartCommon{URL: strPtr("data:image/png;base64,aGVsbG8=")}))
+		require.NoError(t, err)
+		assert.Equal(t, "data:image/png;base64,aGVsbG8=", mc.ImageURL.URL)
+	})
+
+	t.Run("image over the size limit is downscaled", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		require.NoError(t, png.Encode(buf, image.NewGray(image.Rect(0, 0, 4096, 16))))
+		mc, err := convInputPart(inputImage(schema.MessagePartCommon{
+			Base64Data: strPtr(base64.StdEncoding.EncodeToString(buf.Bytes())),
+			MIMEType:   "image/png",
+		}))
+		require.NoError(t, err)
+		data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(mc.ImageURL.URL, "data:image/jpeg;base64,"))
+		require.NoError(t, err)
+		cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+		require.NoError(t, err)
+		assert.Equal(t, 2048, cfg.Width)
+	})
+
+	t.Run("unsupported image type", func(t *testing.T) {
+		_, err := convInputPart(inputImage(schema.MessagePartCommon{Base64Data: strPtr("Qk0="), MIMEType: "image/bmp"}))
+		assert.ErrorIs(t, err, multimodal.ErrUnsupported)
+	})
+
+	t.Run("audio", func(t *testing.T) {
+		mc, err := convInputPart(inputAudio(schema.MessagePartCommon{Base64Data: strPtr("SUQz"), MIMEType: "audio/mpeg"}))
+		require.NoError(t, err)
+		assert.Equal(t, &openai.ChatMessageInputAudio{Data: "SUQz", Format: "mp3"}, mc.InputAudio)
+
+		_, err = convInputPart(inputAudio(schema.MessagePartCommon{URL: strPtr("https://example.com/a.wav")}))
+		assert.ErrorIs(t, err, multimodal.ErrUnsupported)
+		_, err = convInputPart(inputAudio(schema.MessagePartCommon{Base64Data: strPtr("data:audio/wav;base64,UklGRg=="), MIMEType: "audio/wav"}))
+		assert.Error(t, err)
+	})
+}
+
 func Test_newStreamMessageBuilder(t *testing.T) {
 	audio := &Audio{Format: "mp3"}
 	builder := newStreamMessageBuilder(audio)
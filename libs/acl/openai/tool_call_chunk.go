@@ -0,0 +1,133 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package openai
+
+import (
+	"fmt"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// toolCallChunkNormalizer assigns a consistent index to the tool call chunks of a stream, by which
+// schema.ConcatMessages merges them. Nonconforming providers and proxies, e.g. some vLLM versions,
+// emit chunks without index, reuse an index for distinct tool calls, or move a tool call id to another index,
+// which would otherwise corrupt the merged tool calls and their arguments.
+type toolCallChunkNormalizer struct {
+	// strict rejects the nonconforming chunks instead of repairing them
+	strict bool
+
+	// calls are the tool calls seen in the stream, by normalized index
+	calls []toolCallChunkState
+	// indexes maps the index given by the provider to the normalized index of the tool call streamed at it
+	indexes map[int]int
+	// last is the normalized index of the last tool call chunk, -1 if none
+	last int
+}
+
+type toolCallChunkState struct {
+	id   string
+	name string
+}
+
+func newToolCallChunkNormalizer(strict bool) *toolCallChunkNormalizer {
+	return &toolCallChunkNormalizer{
+		strict:  strict,
+		indexes: make(map[int]int),
+		last:    -1,
+	}
+}
+
+func (n *toolCallChunkNormalizer) normalize(toolCalls []schema.ToolCall) ([]schema.ToolCall, error) {
+	for i := range toolCalls {
+		idx, err := n.resolve(&toolCalls[i])
+		if err != nil {
+			return nil, err
+		}
+
+		call := &n.calls[idx]
+		if call.id == "" {
+			call.id = toolCalls[i].ID
+		}
+		if call.name == "" {
+			call.name = toolCalls[i].Function.Name
+		}
+		n.last = idx
+		toolCalls[i].Index = &idx
+	}
+	return toolCalls, nil
+}
+
+func (n *toolCallChunkNormalizer) resolve(tc *schema.ToolCall) (int, error) {
+	known := n.find(tc.ID)
+
+	if tc.Index != nil {
+		idx, ok := n.indexes[*tc.Index]
+		switch {
+		case ok && (tc.ID == "" || n.calls[idx].id == "" || n.calls[idx].id == tc.ID):
+			return idx, nil
+		case ok && n.strict:
+			return 0, fmt.Errorf("tool call chunk index %d is reused by tool call id '%s' after '%s'",
+				*tc.Index, tc.ID, n.calls[idx].id)
+		case !ok && known >= 0 && n.strict:
+			return 0, fmt.Errorf("tool call id '%s' is streamed at several indexes", tc.ID)
+		case !ok && known >= 0:
+			n.indexes[*tc.Index] = known
+			return known, nil
+		}
+
+		idx = n.add()
+		n.indexes[*tc.Index] = idx
+		return idx, nil
+	}
+
+	if n.strict {
+		return 0, fmt.Errorf("tool call chunk without index, id: '%s', name: '%s'", tc.ID, tc.Function.Name)
+	}
+
+	if tc.ID != "" {
+		if known >= 0 {
+			return known, nil
+		}
+		// the name of the last tool call may have come before its id
+		if n.last >= 0 && n.calls[n.last].id == "" && (tc.Function.Name == "" || tc.Function.Name == n.calls[n.last].name) {
+			return n.last, nil
+		}
+		return n.add(), nil
+	}
+
+	// a chunk without index nor id continues the last tool call, unless it starts another by its name
+	if n.last >= 0 && (tc.Function.Name == "" || n.calls[n.last].name == "") {
+		return n.last, nil
+	}
+	return n.add(), nil
+}
+
+func (n *toolCallChunkNormalizer) find(id string) int {
+	if id == "" {
+		return -1
+	}
+	for i := range n.calls {
+		if n.calls[i].id == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (n *toolCallChunkNormalizer) add() int {
+	n.calls = append(n.calls, toolCallChunkState{})
+	return len(n.calls) - 1
+}
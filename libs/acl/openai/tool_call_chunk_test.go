@@ -0,0 +1,182 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package openai
+
+import (
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	openai "github.com/meguminnnnnnnnn/go-openai"
+	"github.com/stretchr/testify/assert"
+)
+
+func toolCallDelta(index *int, id, name, args string) openai.ChatCompletionStreamResponse {
+	tc := openai.ToolCall{Index: index, ID: id, Function: openai.FunctionCall{Name: name, Arguments: args}}
+	if id != "" {
+		tc.Type = openai.ToolTypeFunction
+	}
+	return openai.ChatCompletionStreamResponse{
+		Choices: []openai.ChatCompletionStreamChoice{
+			{Delta: openai.ChatCompletionStreamChoiceDelta{ToolCalls: []openai.ToolCall{tc}}},
+		},
+	}
+}
+
+func contentDelta(content string) openai.ChatCompletionStreamResponse {
+	return openai.ChatCompletionStreamResponse{
+		Choices: []openai.ChatCompletionStreamChoice{
+			{Delta: openai.ChatCompletionStreamChoiceDelta{Content: content}},
+		},
+	}
+}
+
+func buildAndConcat(strict bool, chunks []openai.ChatCompletionStreamResponse) (*schema.Message, error) {
+	builder := newStreamMessageBuilder(nil)
+	builder.toolCalls.strict = strict
+
+	var msgs []*schema.Message
+	for _, chunk := range chunks {
+		msg, _, err := builder.build(chunk)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msg)
+	}
+	return schema.ConcatMessages(msgs)
+}
+
+func TestToolCallChunkNormalizer(t *testing.T) {
+	idx := func(i int) *int { return &i }
+
+	type expected struct {
+		id, name, args string
+	}
+
+	tests := []struct {
+		name    string
+		chunks  []openai.ChatCompletionStreamResponse
+		want    []expected
+		content string
+	}{
+		{
+			name: "conforming parallel tool calls",
+			chunks: []openai.ChatCompletionStreamResponse{
+				toolCallDelta(idx(0), "call_a", "get_weather", ""),
+				toolCallDelta(idx(1), "call_b", "get_time", ""),
+				toolCallDelta(idx(0), "", "", `{"city":"beijing"}`),
+				toolCallDelta(idx(1), "", "", `{}`),
+			},
+			want: []expected{{"call_a", "get_weather", `{"city":"beijing"}`}, {"call_b", "get_time", `{}`}},
+		},
+		{
+			name: "chunks without index",
+			chunks: []openai.ChatCompletionStreamResponse{
+				toolCallDelta(nil, "call_a", "get_weather", ""),
+				toolCallDelta(nil, "", "", `{"city":`),
+				toolCallDelta(nil, "", "", `"beijing"}`),
+				toolCallDelta(nil, "call_b", "get_time", ""),
+				toolCallDelta(nil, "", "", `{}`),
+			},
+			want: []expected{{"call_a", "get_weather", `{"city":"beijing"}`}, {"call_b", "get_time", `{}`}},
+		},
+		{
+			name: "chunks without index nor id",
+			chunks: []openai.ChatCompletionStreamResponse{
+				toolCallDelta(nil, "", "get_weather", `{"city":`),
+				toolCallDelta(nil, "", "", `"beijing"}`),
+				toolCallDelta(nil, "", "get_time", `{}`),
+			},
+			want: []expected{{"", "get_weather", `{"city":"beijing"}`}, {"", "get_time", `{}`}},
+		},
+		{
+			name: "index reused by distinct tool calls",
+			chunks: []openai.ChatCompletionStreamResponse{
+				toolCallDelta(idx(0), "call_a", "get_weather", `{"city":"beijing"}`),
+				toolCallDelta(idx(0), "call_b", "get_time", ""),
+				toolCallDelta(idx(0), "", "", `{}`),
+			},
+			want: []expected{{"call_a", "get_weather", `{"city":"beijing"}`}, {"call_b", "get_time", `{}`}},
+		},
+		{
+			name: "duplicate id in every chunk",
+			chunks: []openai.ChatCompletionStreamResponse{
+				toolCallDelta(nil, "call_a", "get_weather", `{"city":`),
+				toolCallDelta(nil, "call_a", "", `"beijing"}`),
+			},
+			want: []expected{{"call_a", "get_weather", `{"city":"beijing"}`}},
+		},
+		{
+			name: "id moved to another index",
+			chunks: []openai.ChatCompletionStreamResponse{
+				toolCallDelta(idx(0), "call_a", "get_weather", `{"city":`),
+				toolCallDelta(idx(1), "call_a", "", `"beijing"}`),
+			},
+			want: []expected{{"call_a", "get_weather", `{"city":"beijing"}`}},
+		},
+		{
+			name: "interleaved text and tool chunks",
+			chunks: []openai.ChatCompletionStreamResponse{
+				contentDelta("let me "),
+				toolCallDelta(nil, "call_a", "get_weather", `{"city":`),
+				contentDelta("check"),
+				toolCallDelta(nil, "", "", `"beijing"}`),
+			},
+			want:    []expected{{"call_a", "get_weather", `{"city":"beijing"}`}},
+			content: "let me check",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := buildAndConcat(false, tt.chunks)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.content, msg.Content)
+			assert.Len(t, msg.ToolCalls, len(tt.want))
+			for i, want := range tt.want {
+				assert.Equal(t, i, *msg.ToolCalls[i].Index)
+				assert.Equal(t, want.id, msg.ToolCalls[i].ID)
+				assert.Equal(t, want.name, msg.ToolCalls[i].Function.Name)
+				assert.Equal(t, want.args, msg.ToolCalls[i].Function.Arguments)
+			}
+		})
+	}
+
+	t.Run("strict", func(t *testing.T) {
+		_, err := buildAndConcat(true, []openai.ChatCompletionStreamResponse{
+			toolCallDelta(nil, "call_a", "get_weather", `{}`),
+		})
+		assert.ErrorContains(t, err, "without index")
+
+		_, err = buildAndConcat(true, []openai.ChatCompletionStreamResponse{
+			toolCallDelta(idx(0), "call_a", "get_weather", `{}`),
+			toolCallDelta(idx(0), "call_b", "get_time", `{}`),
+		})
+		assert.ErrorContains(t, err, "reused")
+
+		_, err = buildAndConcat(true, []openai.ChatCompletionStreamResponse{
+			toolCallDelta(idx(0), "call_a", "get_weather", `{"city":`),
+			toolCallDelta(idx(1), "call_a", "", `"beijing"}`),
+		})
+		assert.ErrorContains(t, err, "several indexes")
+
+		msg, err := buildAndConcat(true, []openai.ChatCompletionStreamResponse{
+			toolCallDelta(idx(0), "call_a", "get_weather", `{"city":`),
+			toolCallDelta(idx(0), "call_a", "", `"beijing"}`),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"city":"beijing"}`, msg.ToolCalls[0].Function.Arguments)
+	})
+}
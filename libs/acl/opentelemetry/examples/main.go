@@ -18,6 +18,7 @@ package examples
 
 import (
 	"context"
+	"log"
 
 	"github.com/cloudwego/eino-ext/libs/acl/opentelemetry"
 )
@@ -29,8 +30,32 @@ func main() {
 		opentelemetry.WithServiceName("service"),
 		opentelemetry.WithExportEndpoint("127.0.0.1:4317"),
 		opentelemetry.WithInsecure(),
+		opentelemetry.WithMetricViews(
+			opentelemetry.NewHistogramBucketsView("gen_ai.client.operation.duration", 0.1, 0.5, 1, 2, 5, 10, 30, 60),
+		),
+		opentelemetry.WithPropagators(opentelemetry.PropagatorTraceContext, opentelemetry.PropagatorBaggage, opentelemetry.PropagatorB3),
 	)
 	if err == nil {
 		defer p.Shutdown(ctx)
 	}
+
+	// baggage is visible to all descendant spans and goroutines
+	ctx, err = opentelemetry.WithBaggage(ctx, map[string]string{"session.id": "session-1"})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// run async work within a child span of the current trace
+	errCh := opentelemetry.Go(ctx, "async_tool", func(ctx context.Context) error {
+		log.Printf("session: %s", opentelemetry.BaggageValue(ctx, "session.id"))
+		return nil
+	})
+	if err = <-errCh; err != nil {
+		log.Printf("async tool failed: %v", err)
+	}
+
+	// carry the trace context across a queue and restore it on the consumer side
+	carrier := opentelemetry.Inject(ctx)
+	consumerCtx := opentelemetry.Extract(context.Background(), carrier)
+	_ = opentelemetry.Detach(consumerCtx)
 }
@@ -17,7 +17,10 @@
 package opentelemetry
 
 import (
+	"crypto/tls"
+
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
@@ -44,6 +47,15 @@ type config struct {
 	exportEndpoint    string
 	exportHeaders     map[string]string
 
+	traceExportEndpoint   string
+	metricsExportEndpoint string
+
+	exportCompressor     string
+	exportTLSConfig      *tls.Config
+	exportCACertFiles    []string
+	exportClientCertFile string
+	exportClientKeyFile  string
+
 	resource          *resource.Resource
 	sdkTracerProvider *sdktrace.TracerProvider
 
@@ -53,6 +65,10 @@ type config struct {
 	resourceDetectors  []resource.Detector
 
 	meterProvider *metric.MeterProvider
+	metricViews   []metric.View
+
+	propagators []Propagator
+	propagator  propagation.TextMapPropagator
 }
 
 func newConfig(opts []Option) *config {
@@ -70,6 +86,7 @@ func defaultConfig() *config {
 		enableTracing: true,
 		enableMetrics: true,
 		sampler:       sdktrace.AlwaysSample(),
+		propagators:   []Propagator{PropagatorTraceContext, PropagatorBaggage},
 	}
 }
 
@@ -122,6 +139,20 @@ func WithExportEndpoint(endpoint string) Option {
 	})
 }
 
+// WithTraceExportEndpoint configures export endpoint of traces, it takes precedence over WithExportEndpoint
+func WithTraceExportEndpoint(endpoint string) Option {
+	return option(func(cfg *config) {
+		cfg.traceExportEndpoint = endpoint
+	})
+}
+
+// WithMetricsExportEndpoint configures export endpoint of metrics, it takes precedence over WithExportEndpoint
+func WithMetricsExportEndpoint(endpoint string) Option {
+	return option(func(cfg *config) {
+		cfg.metricsExportEndpoint = endpoint
+	})
+}
+
 // WithEnableTracing enable tracing
 func WithEnableTracing(enableTracing bool) Option {
 	return option(func(cfg *config) {
@@ -164,6 +195,36 @@ func WithTLSInsecure() Option {
 	})
 }
 
+// WithCompressor configures the compressor of the exporter's gRPC requests, e.g. "gzip"
+func WithCompressor(compressor string) Option {
+	return option(func(cfg *config) {
+		cfg.exportCompressor = compressor
+	})
+}
+
+// WithTLSConfig configures client transport security for the exporter's gRPC,
+// WithCACertFile and WithClientCertFile are applied on a clone of it
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return option(func(cfg *config) {
+		cfg.exportTLSConfig = tlsConfig
+	})
+}
+
+// WithCACertFile configures a PEM encoded CA bundle to verify the collector's certificate, replacing the system roots
+func WithCACertFile(caFile string) Option {
+	return option(func(cfg *config) {
+		cfg.exportCACertFiles = append(cfg.exportCACertFiles, caFile)
+	})
+}
+
+// WithClientCertFile configures a PEM encoded client certificate and key for mutual TLS with the collector
+func WithClientCertFile(certFile, keyFile string) Option {
+	return option(func(cfg *config) {
+		cfg.exportClientCertFile = certFile
+		cfg.exportClientKeyFile = keyFile
+	})
+}
+
 // WithSampler configures sampler
 func WithSampler(sampler sdktrace.Sampler) Option {
 	return option(func(cfg *config) {
@@ -184,3 +245,28 @@ func WithMeterProvider(meterProvider *metric.MeterProvider) Option {
 		cfg.meterProvider = meterProvider
 	})
 }
+
+// WithMetricViews configures views applied by the MeterProvider, e.g. to rename instruments,
+// drop attributes or set histogram buckets, see NewRenameView, NewDropAttributesView and NewHistogramBucketsView.
+// An instrument matching several views is exported once per view, use metric.NewView to combine changes.
+// It takes no effect when the MeterProvider is set by WithMeterProvider
+func WithMetricViews(views ...metric.View) Option {
+	return option(func(cfg *config) {
+		cfg.metricViews = append(cfg.metricViews, views...)
+	})
+}
+
+// WithPropagators configures the propagation formats installed as the global text map propagator,
+// defaults to PropagatorTraceContext and PropagatorBaggage
+func WithPropagators(propagators ...Propagator) Option {
+	return option(func(cfg *config) {
+		cfg.propagators = propagators
+	})
+}
+
+// WithTextMapPropagator configures a custom text map propagator, it takes precedence over WithPropagators
+func WithTextMapPropagator(propagator propagation.TextMapPropagator) Option {
+	return option(func(cfg *config) {
+		cfg.propagator = propagator
+	})
+}
@@ -17,6 +17,7 @@
 package opentelemetry
 
 import (
+	"crypto/tls"
 	"testing"
 
 	"github.com/bytedance/mockey"
@@ -322,3 +323,40 @@ func Test_WithMeterProvider(t *testing.T) {
 		convey.So(cfg.meterProvider, convey.ShouldEqual, meterProvider)
 	})
 }
+
+func Test_WithSignalExportEndpoint(t *testing.T) {
+	mockey.PatchConvey("Test WithTraceExportEndpoint and WithMetricsExportEndpoint", t, func() {
+		cfg := newConfig([]Option{
+			WithExportEndpoint("collector:4317"),
+			WithTraceExportEndpoint("traces:4317"),
+			WithMetricsExportEndpoint("metrics:4317"),
+		})
+		convey.So(cfg.exportEndpoint, convey.ShouldEqual, "collector:4317")
+		convey.So(cfg.traceExportEndpoint, convey.ShouldEqual, "traces:4317")
+		convey.So(cfg.metricsExportEndpoint, convey.ShouldEqual, "metrics:4317")
+	})
+}
+
+func Test_WithCompressor(t *testing.T) {
+	mockey.PatchConvey("Test WithCompressor", t, func() {
+		cfg := &config{}
+		WithCompressor("gzip").apply(cfg)
+		convey.So(cfg.exportCompressor, convey.ShouldEqual, "gzip")
+	})
+}
+
+func Test_WithTLSOptions(t *testing.T) {
+	mockey.PatchConvey("Test WithTLSConfig, WithCACertFile and WithClientCertFile", t, func() {
+		tlsConfig := &tls.Config{ServerName: "collector"}
+		cfg := newConfig([]Option{
+			WithTLSConfig(tlsConfig),
+			WithCACertFile("ca1.pem"),
+			WithCACertFile("ca2.pem"),
+			WithClientCertFile("client.pem", "client-key.pem"),
+		})
+		convey.So(cfg.exportTLSConfig, convey.ShouldEqual, tlsConfig)
+		convey.So(cfg.exportCACertFiles, convey.ShouldResemble, []string{"ca1.pem", "ca2.pem"})
+		convey.So(cfg.exportClientCertFile, convey.ShouldEqual, "client.pem")
+		convey.So(cfg.exportClientKeyFile, convey.ShouldEqual, "client-key.pem")
+	})
+}
@@ -0,0 +1,158 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package opentelemetry
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/cloudwego/eino-ext/libs/acl/opentelemetry"
+
+// Propagator is a context propagation format, named after the values of OTEL_PROPAGATORS
+type Propagator string
+
+const (
+	// PropagatorTraceContext is the W3C Trace Context format
+	PropagatorTraceContext Propagator = "tracecontext"
+	// PropagatorBaggage is the W3C Baggage format
+	PropagatorBaggage Propagator = "baggage"
+	// PropagatorB3 is the B3 single header format
+	PropagatorB3 Propagator = "b3"
+	// PropagatorB3Multi is the B3 multiple headers format
+	PropagatorB3Multi Propagator = "b3multi"
+	// PropagatorNone disables propagation
+	PropagatorNone Propagator = "none"
+)
+
+func newPropagator(cfg *config) (propagation.TextMapPropagator, error) {
+	if cfg.propagator != nil {
+		return cfg.propagator, nil
+	}
+
+	var propagators []propagation.TextMapPropagator
+	for _, p := range cfg.propagators {
+		switch p {
+		case PropagatorTraceContext:
+			propagators = append(propagators, propagation.TraceContext{})
+		case PropagatorBaggage:
+			propagators = append(propagators, propagation.Baggage{})
+		case PropagatorB3:
+			propagators = append(propagators, b3.New(b3.WithInjectEncoding(b3.B3SingleHeader)))
+		case PropagatorB3Multi:
+			propagators = append(propagators, b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader)))
+		case PropagatorNone:
+		default:
+			return nil, fmt.Errorf("unsupported propagator: %s", p)
+		}
+	}
+
+	return propagation.NewCompositeTextMapPropagator(propagators...), nil
+}
+
+// Detach returns a context that keeps the values of ctx, including the active span and baggage,
+// but is never canceled and has no deadline.
+// Use it for goroutines that outlive the node or tool which spawned them, e.g. background
+// consumption of a stream, instead of context.Background() which loses the parent trace.
+func Detach(ctx context.Context) context.Context {
+	return context.WithoutCancel(ctx)
+}
+
+// Propagate copies the active span and baggage of src into dst.
+// It is useful when a goroutine has to run with its own context, e.g. one owned by a worker pool.
+func Propagate(dst, src context.Context) context.Context {
+	if span := trace.SpanFromContext(src); span.SpanContext().IsValid() {
+		dst = trace.ContextWithSpan(dst, span)
+	}
+	if bag := baggage.FromContext(src); bag.Len() > 0 {
+		dst = baggage.ContextWithBaggage(dst, bag)
+	}
+	return dst
+}
+
+// Go runs fn in a new goroutine within a child span named name, so that spans created by fn
+// stay attached to the trace of ctx. The span is created by the tracer provider of the parent span.
+// The returned channel receives the error of fn and is closed once fn returns.
+// A panic in fn is recorded on the span before being re-raised.
+func Go(ctx context.Context, name string, fn func(ctx context.Context) error, opts ...trace.SpanStartOption) <-chan error {
+	tracer := trace.SpanFromContext(ctx).TracerProvider().Tracer(instrumentationName)
+	ctx, span := tracer.Start(ctx, name, opts...)
+
+	errCh := make(chan error, 1)
+	go func() {
+		defer close(errCh)
+		defer func() {
+			if r := recover(); r != nil {
+				span.RecordError(fmt.Errorf("panic: %v", r))
+				span.SetStatus(codes.Error, "panic")
+				span.End()
+				panic(r)
+			}
+		}()
+
+		err := fn(ctx)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+		errCh <- err
+	}()
+
+	return errCh
+}
+
+// Inject serializes the trace context and baggage of ctx with the global text map propagator,
+// the result can be carried across process or queue boundaries and restored by Extract.
+func Inject(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	return carrier
+}
+
+// Extract restores the trace context and baggage serialized by Inject into ctx.
+func Extract(ctx context.Context, carrier map[string]string) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(carrier))
+}
+
+// WithBaggage returns a context whose baggage contains the given members in addition to those of ctx.
+// Baggage set on the context of a graph or a node is visible to all descendant spans and goroutines.
+func WithBaggage(ctx context.Context, members map[string]string) (context.Context, error) {
+	bag := baggage.FromContext(ctx)
+	for k, v := range members {
+		member, err := baggage.NewMemberRaw(k, v)
+		if err != nil {
+			return ctx, fmt.Errorf("invalid baggage member %s: %w", k, err)
+		}
+		if bag, err = bag.SetMember(member); err != nil {
+			return ctx, fmt.Errorf("failed to set baggage member %s: %w", k, err)
+		}
+	}
+	return baggage.ContextWithBaggage(ctx, bag), nil
+}
+
+// BaggageValue returns the value of the baggage member key of ctx, or empty string if not present.
+func BaggageValue(ctx context.Context, key string) string {
+	return baggage.FromContext(ctx).Member(key).Value()
+}
@@ -0,0 +1,165 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package opentelemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func Test_newPropagator(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		p, err := newPropagator(defaultConfig())
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"traceparent", "tracestate", "baggage"}, p.Fields())
+	})
+
+	t.Run("b3", func(t *testing.T) {
+		cfg := newConfig([]Option{WithPropagators(PropagatorB3, PropagatorB3Multi)})
+		p, err := newPropagator(cfg)
+		assert.NoError(t, err)
+		assert.Contains(t, p.Fields(), "b3")
+		assert.Contains(t, p.Fields(), "x-b3-traceid")
+	})
+
+	t.Run("none", func(t *testing.T) {
+		cfg := newConfig([]Option{WithPropagators(PropagatorNone)})
+		p, err := newPropagator(cfg)
+		assert.NoError(t, err)
+		assert.Empty(t, p.Fields())
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		cfg := newConfig([]Option{WithPropagators(PropagatorB3), WithTextMapPropagator(propagation.Baggage{})})
+		p, err := newPropagator(cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, propagation.Baggage{}, p)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		cfg := newConfig([]Option{WithPropagators("xray")})
+		_, err := newPropagator(cfg)
+		assert.Error(t, err)
+	})
+}
+
+func TestDetach(t *testing.T) {
+	tp := sdktrace.NewTracerProvider()
+	ctx, span := tp.Tracer("test").Start(context.Background(), "parent")
+	defer span.End()
+	ctx, err := WithBaggage(ctx, map[string]string{"session": "s1"})
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(ctx)
+	detached := Detach(ctx)
+	cancel()
+
+	assert.Error(t, ctx.Err())
+	assert.NoError(t, detached.Err())
+	assert.Equal(t, span.SpanContext(), trace.SpanContextFromContext(detached))
+	assert.Equal(t, "s1", BaggageValue(detached, "session"))
+}
+
+func TestPropagate(t *testing.T) {
+	tp := sdktrace.NewTracerProvider()
+	src, span := tp.Tracer("test").Start(context.Background(), "parent")
+	defer span.End()
+	src, err := WithBaggage(src, map[string]string{"user": "u1"})
+	assert.NoError(t, err)
+
+	type key struct{}
+	dst := context.WithValue(context.Background(), key{}, "v")
+	dst = Propagate(dst, src)
+
+	assert.Equal(t, "v", dst.Value(key{}))
+	assert.Equal(t, span.SpanContext(), trace.SpanContextFromContext(dst))
+	assert.Equal(t, "u1", BaggageValue(dst, "user"))
+
+	empty := Propagate(context.Background(), context.Background())
+	assert.False(t, trace.SpanContextFromContext(empty).IsValid())
+}
+
+func TestGo(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+
+	errCh := Go(ctx, "tool", func(ctx context.Context) error {
+		_, child := trace.SpanFromContext(ctx).TracerProvider().Tracer("test").Start(ctx, "child")
+		child.End()
+		return errors.New("tool failed")
+	})
+	assert.EqualError(t, <-errCh, "tool failed")
+	_, ok := <-errCh
+	assert.False(t, ok)
+	parent.End()
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, s := range recorder.Ended() {
+		spans[s.Name()] = s
+	}
+	assert.Len(t, spans, 3)
+	assert.Equal(t, parent.SpanContext().SpanID(), spans["tool"].Parent().SpanID())
+	assert.Equal(t, spans["tool"].SpanContext().SpanID(), spans["child"].Parent().SpanID())
+	assert.Equal(t, parent.SpanContext().TraceID(), spans["child"].SpanContext().TraceID())
+	assert.Equal(t, codes.Error, spans["tool"].Status().Code)
+}
+
+func TestInjectExtract(t *testing.T) {
+	prev := otel.GetTextMapPropagator()
+	defer otel.SetTextMapPropagator(prev)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	tp := sdktrace.NewTracerProvider()
+	ctx, span := tp.Tracer("test").Start(context.Background(), "parent")
+	defer span.End()
+	ctx, err := WithBaggage(ctx, map[string]string{"tenant": "t1"})
+	assert.NoError(t, err)
+
+	carrier := Inject(ctx)
+	assert.Contains(t, carrier, "traceparent")
+	assert.Contains(t, carrier, "baggage")
+
+	restored := Extract(context.Background(), carrier)
+	sc := trace.SpanContextFromContext(restored)
+	assert.True(t, sc.IsRemote())
+	assert.Equal(t, span.SpanContext().TraceID(), sc.TraceID())
+	assert.Equal(t, span.SpanContext().SpanID(), sc.SpanID())
+	assert.Equal(t, "t1", BaggageValue(restored, "tenant"))
+}
+
+func TestWithBaggage(t *testing.T) {
+	ctx, err := WithBaggage(context.Background(), map[string]string{"a": "1"})
+	assert.NoError(t, err)
+	ctx, err = WithBaggage(ctx, map[string]string{"b": "2"})
+	assert.NoError(t, err)
+	assert.Equal(t, "1", BaggageValue(ctx, "a"))
+	assert.Equal(t, "2", BaggageValue(ctx, "b"))
+	assert.Equal(t, "", BaggageValue(ctx, "c"))
+
+	_, err = WithBaggage(ctx, map[string]string{"": "v"})
+	assert.Error(t, err)
+}
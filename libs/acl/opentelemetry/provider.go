@@ -18,13 +18,18 @@ package opentelemetry
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"os"
 	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
@@ -34,6 +39,32 @@ import (
 type OtelProvider struct {
 	TracerProvider *sdktrace.TracerProvider
 	MeterProvider  *metric.MeterProvider
+	Propagator     propagation.TextMapPropagator
+
+	runtime *runtimeConfig
+}
+
+// RuntimeConfig returns the current runtime config of the provider
+func (p *OtelProvider) RuntimeConfig() RuntimeConfig {
+	if p.runtime == nil {
+		return RuntimeConfig{}
+	}
+	return *p.runtime.load()
+}
+
+// Update atomically updates the runtime config of the provider, e.g.
+//
+//	err := p.Update(func(cfg *opentelemetry.RuntimeConfig) {
+//		cfg.Sampler = sdktrace.AlwaysSample()
+//	})
+//
+// Only the providers created by NewOpenTelemetryProvider are affected: tracing and metrics disabled at creation
+// can not be enabled, and a TracerProvider or MeterProvider set by options is left untouched.
+func (p *OtelProvider) Update(fn func(cfg *RuntimeConfig)) error {
+	if p.runtime == nil {
+		return errors.New("runtime config not supported by provider")
+	}
+	return p.runtime.update(fn)
 }
 
 func (p *OtelProvider) Shutdown(ctx context.Context) error {
@@ -65,6 +96,13 @@ func NewOpenTelemetryProvider(opts ...Option) (*OtelProvider, error) {
 
 	cfg := newConfig(opts)
 
+	// propagator
+	propagator, err := newPropagator(cfg)
+	if err != nil {
+		return nil, err
+	}
+	otel.SetTextMapPropagator(propagator)
+
 	if !cfg.enableTracing && !cfg.enableMetrics {
 		return nil, nil
 	}
@@ -72,18 +110,32 @@ func NewOpenTelemetryProvider(opts ...Option) (*OtelProvider, error) {
 	// resource
 	res := newResource(cfg)
 
+	// runtime config
+	rc := newRuntimeConfig(cfg)
+
+	// transport security
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	// Tracing
 	if cfg.enableTracing {
 		// trace client
 		var traceClientOpts []otlptracegrpc.Option
-		if cfg.exportEndpoint != "" {
-			traceClientOpts = append(traceClientOpts, otlptracegrpc.WithEndpoint(cfg.exportEndpoint))
+		if endpoint := firstNonEmpty(cfg.traceExportEndpoint, cfg.exportEndpoint); endpoint != "" {
+			traceClientOpts = append(traceClientOpts, otlptracegrpc.WithEndpoint(endpoint))
 		}
 		if len(cfg.exportHeaders) > 0 {
 			traceClientOpts = append(traceClientOpts, otlptracegrpc.WithHeaders(cfg.exportHeaders))
 		}
+		if cfg.exportCompressor != "" {
+			traceClientOpts = append(traceClientOpts, otlptracegrpc.WithCompressor(cfg.exportCompressor))
+		}
 		if cfg.exportInsecure {
 			traceClientOpts = append(traceClientOpts, otlptracegrpc.WithInsecure())
+		} else if tlsConfig != nil {
+			traceClientOpts = append(traceClientOpts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsConfig)))
 		} else if cfg.exportTLSInsecure {
 			traceClientOpts = append(traceClientOpts, otlptracegrpc.WithTLSCredentials(credentials.NewClientTLSFromCert(nil, "")))
 		}
@@ -102,7 +154,7 @@ func NewOpenTelemetryProvider(opts ...Option) (*OtelProvider, error) {
 			bsp := sdktrace.NewBatchSpanProcessor(traceExp)
 
 			tracerProvider = sdktrace.NewTracerProvider(
-				sdktrace.WithSampler(cfg.sampler),
+				sdktrace.WithSampler(&dynamicSampler{rc: rc}),
 				sdktrace.WithResource(res),
 				sdktrace.WithSpanProcessor(bsp),
 			)
@@ -114,14 +166,19 @@ func NewOpenTelemetryProvider(opts ...Option) (*OtelProvider, error) {
 		// prometheus only supports CumulativeTemporalitySelector
 
 		var metricsClientOpts []otlpmetricgrpc.Option
-		if cfg.exportEndpoint != "" {
-			metricsClientOpts = append(metricsClientOpts, otlpmetricgrpc.WithEndpoint(cfg.exportEndpoint))
+		if endpoint := firstNonEmpty(cfg.metricsExportEndpoint, cfg.exportEndpoint); endpoint != "" {
+			metricsClientOpts = append(metricsClientOpts, otlpmetricgrpc.WithEndpoint(endpoint))
 		}
 		if len(cfg.exportHeaders) > 0 {
 			metricsClientOpts = append(metricsClientOpts, otlpmetricgrpc.WithHeaders(cfg.exportHeaders))
 		}
+		if cfg.exportCompressor != "" {
+			metricsClientOpts = append(metricsClientOpts, otlpmetricgrpc.WithCompressor(cfg.exportCompressor))
+		}
 		if cfg.exportInsecure {
 			metricsClientOpts = append(metricsClientOpts, otlpmetricgrpc.WithInsecure())
+		} else if tlsConfig != nil {
+			metricsClientOpts = append(metricsClientOpts, otlpmetricgrpc.WithTLSCredentials(credentials.NewTLS(tlsConfig)))
 		} else if cfg.exportTLSInsecure {
 			metricsClientOpts = append(metricsClientOpts, otlpmetricgrpc.WithTLSCredentials(credentials.NewClientTLSFromCert(nil, "")))
 		}
@@ -134,18 +191,65 @@ func NewOpenTelemetryProvider(opts ...Option) (*OtelProvider, error) {
 				return nil, fmt.Errorf("failed to create otlp metric exporter: %v", err)
 			}
 
-			reader := metric.WithReader(metric.NewPeriodicReader(metricExp, metric.WithInterval(15*time.Second)))
+			reader := metric.WithReader(metric.NewPeriodicReader(&switchableMetricExporter{Exporter: metricExp, rc: rc}, metric.WithInterval(15*time.Second)))
 
-			meterProvider = metric.NewMeterProvider(reader, metric.WithResource(res))
+			meterProvider = metric.NewMeterProvider(reader, metric.WithResource(res), metric.WithView(cfg.metricViews...))
 		}
 	}
 
 	return &OtelProvider{
 		TracerProvider: tracerProvider,
 		MeterProvider:  meterProvider,
+		Propagator:     propagator,
+		runtime:        rc,
 	}, nil
 }
 
+// newTLSConfig builds the tls config of the exporters, returns nil if no tls option is configured
+func newTLSConfig(cfg *config) (*tls.Config, error) {
+	if cfg.exportTLSConfig == nil && len(cfg.exportCACertFiles) == 0 && cfg.exportClientCertFile == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{}
+	if cfg.exportTLSConfig != nil {
+		tlsConfig = cfg.exportTLSConfig.Clone()
+	}
+
+	if len(cfg.exportCACertFiles) > 0 {
+		pool := x509.NewCertPool()
+		for _, caFile := range cfg.exportCACertFiles {
+			pem, err := os.ReadFile(caFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read ca cert file: %v", err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no valid certificate found in ca cert file: %s", caFile)
+			}
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.exportClientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.exportClientCertFile, cfg.exportClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client cert: %v", err)
+		}
+		tlsConfig.Certificates = append(tlsConfig.Certificates, cert)
+	}
+
+	return tlsConfig, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
 func newResource(cfg *config) *resource.Resource {
 	if cfg.resource != nil {
 		return cfg.resource
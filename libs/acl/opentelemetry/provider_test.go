@@ -17,7 +17,18 @@
 package opentelemetry
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/otel/attribute"
@@ -79,3 +90,79 @@ func Test_newResource(t *testing.T) {
 		})
 	}
 }
+
+func writeTestCert(t *testing.T, dir string) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "eino"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	assert.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	assert.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	assert.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile
+}
+
+func Test_newTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeTestCert(t, dir)
+	invalidFile := filepath.Join(dir, "invalid.pem")
+	assert.NoError(t, os.WriteFile(invalidFile, []byte("invalid"), 0o600))
+
+	t.Run("not configured", func(t *testing.T) {
+		got, err := newTLSConfig(&config{})
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("mtls", func(t *testing.T) {
+		base := &tls.Config{ServerName: "collector"}
+		got, err := newTLSConfig(&config{
+			exportTLSConfig:      base,
+			exportCACertFiles:    []string{certFile},
+			exportClientCertFile: certFile,
+			exportClientKeyFile:  keyFile,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "collector", got.ServerName)
+		assert.NotNil(t, got.RootCAs)
+		assert.Len(t, got.Certificates, 1)
+		assert.Nil(t, base.RootCAs)
+		assert.Empty(t, base.Certificates)
+	})
+
+	t.Run("missing ca file", func(t *testing.T) {
+		_, err := newTLSConfig(&config{exportCACertFiles: []string{filepath.Join(dir, "missing.pem")}})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid ca file", func(t *testing.T) {
+		_, err := newTLSConfig(&config{exportCACertFiles: []string{invalidFile}})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid client cert", func(t *testing.T) {
+		_, err := newTLSConfig(&config{exportClientCertFile: invalidFile, exportClientKeyFile: keyFile})
+		assert.Error(t, err)
+	})
+
+	t.Run("provider", func(t *testing.T) {
+		_, err := NewOpenTelemetryProvider(WithCACertFile(invalidFile))
+		assert.Error(t, err)
+	})
+}
+
+func Test_firstNonEmpty(t *testing.T) {
+	assert.Equal(t, "a", firstNonEmpty("", "a", "b"))
+	assert.Equal(t, "", firstNonEmpty("", ""))
+}
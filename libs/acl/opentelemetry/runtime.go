@@ -0,0 +1,106 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package opentelemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RuntimeConfig is the part of the provider config which can be updated at runtime by OtelProvider.Update,
+// e.g. to turn up sampling during incidents without restarting services.
+type RuntimeConfig struct {
+	// Sampler samples the spans created by the tracer provider.
+	Sampler sdktrace.Sampler
+	// EnableTracing drops all spans when false.
+	EnableTracing bool
+	// EnableMetrics stops exporting metrics when false.
+	EnableMetrics bool
+}
+
+type runtimeConfig struct {
+	mu sync.Mutex
+	v  atomic.Pointer[RuntimeConfig]
+}
+
+func newRuntimeConfig(cfg *config) *runtimeConfig {
+	rc := &runtimeConfig{}
+	rc.v.Store(&RuntimeConfig{
+		Sampler:       cfg.sampler,
+		EnableTracing: cfg.enableTracing,
+		EnableMetrics: cfg.enableMetrics,
+	})
+	return rc
+}
+
+func (rc *runtimeConfig) load() *RuntimeConfig {
+	return rc.v.Load()
+}
+
+func (rc *runtimeConfig) update(fn func(cfg *RuntimeConfig)) error {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	cfg := *rc.v.Load()
+	fn(&cfg)
+	if cfg.Sampler == nil {
+		return errors.New("sampler is required")
+	}
+	rc.v.Store(&cfg)
+	return nil
+}
+
+// dynamicSampler delegates to the sampler of the current runtime config
+type dynamicSampler struct {
+	rc *runtimeConfig
+}
+
+func (s *dynamicSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	cfg := s.rc.load()
+	if !cfg.EnableTracing {
+		return sdktrace.SamplingResult{
+			Decision:   sdktrace.Drop,
+			Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+		}
+	}
+	return cfg.Sampler.ShouldSample(p)
+}
+
+func (s *dynamicSampler) Description() string {
+	return fmt.Sprintf("DynamicSampler{%s}", s.rc.load().Sampler.Description())
+}
+
+// switchableMetricExporter skips exporting when metrics are disabled by the current runtime config
+type switchableMetricExporter struct {
+	metric.Exporter
+	rc *runtimeConfig
+}
+
+func (e *switchableMetricExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	if !e.rc.load().EnableMetrics {
+		return nil
+	}
+	return e.Exporter.Export(ctx, rm)
+}
@@ -0,0 +1,129 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package opentelemetry
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestDynamicSampler(t *testing.T) {
+	rc := newRuntimeConfig(newConfig([]Option{WithSampler(sdktrace.NeverSample())}))
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(&dynamicSampler{rc: rc}))
+	tracer := tp.Tracer("test")
+
+	_, span := tracer.Start(context.Background(), "never")
+	assert.False(t, span.IsRecording())
+	span.End()
+
+	assert.NoError(t, rc.update(func(cfg *RuntimeConfig) {
+		cfg.Sampler = sdktrace.AlwaysSample()
+	}))
+	_, span = tracer.Start(context.Background(), "always")
+	assert.True(t, span.IsRecording())
+	span.End()
+	assert.Equal(t, "DynamicSampler{AlwaysOnSampler}", (&dynamicSampler{rc: rc}).Description())
+
+	assert.NoError(t, rc.update(func(cfg *RuntimeConfig) {
+		cfg.EnableTracing = false
+	}))
+	_, span = tracer.Start(context.Background(), "disabled")
+	assert.False(t, span.IsRecording())
+	span.End()
+
+	assert.Error(t, rc.update(func(cfg *RuntimeConfig) {
+		cfg.Sampler = nil
+	}))
+	assert.NotNil(t, rc.load().Sampler)
+}
+
+type countingExporter struct {
+	metric.Exporter
+	count int
+}
+
+func (e *countingExporter) Export(_ context.Context, _ *metricdata.ResourceMetrics) error {
+	e.count++
+	return nil
+}
+
+func TestSwitchableMetricExporter(t *testing.T) {
+	rc := newRuntimeConfig(defaultConfig())
+	inner := &countingExporter{}
+	exp := &switchableMetricExporter{Exporter: inner, rc: rc}
+
+	assert.NoError(t, exp.Export(context.Background(), &metricdata.ResourceMetrics{}))
+	assert.Equal(t, 1, inner.count)
+
+	assert.NoError(t, rc.update(func(cfg *RuntimeConfig) {
+		cfg.EnableMetrics = false
+	}))
+	assert.NoError(t, exp.Export(context.Background(), &metricdata.ResourceMetrics{}))
+	assert.Equal(t, 1, inner.count)
+}
+
+func TestOtelProvider_Update(t *testing.T) {
+	assert.Error(t, (&OtelProvider{}).Update(func(cfg *RuntimeConfig) {}))
+	assert.Equal(t, RuntimeConfig{}, (&OtelProvider{}).RuntimeConfig())
+
+	p, err := NewOpenTelemetryProvider(
+		WithExportEndpoint("127.0.0.1:4317"),
+		WithInsecure(),
+		WithEnableMetrics(false),
+		WithSampler(sdktrace.NeverSample()),
+	)
+	assert.NoError(t, err)
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = p.Shutdown(ctx)
+	}()
+
+	_, span := p.TracerProvider.Tracer("test").Start(context.Background(), "never")
+	assert.False(t, span.IsRecording())
+	span.End()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, p.Update(func(cfg *RuntimeConfig) {
+				cfg.Sampler = sdktrace.TraceIDRatioBased(0.5)
+			}))
+		}()
+	}
+	wg.Wait()
+
+	assert.NoError(t, p.Update(func(cfg *RuntimeConfig) {
+		cfg.Sampler = sdktrace.AlwaysSample()
+	}))
+	_, span = p.TracerProvider.Tracer("test").Start(context.Background(), "always")
+	assert.True(t, span.IsRecording())
+	span.End()
+
+	rc := p.RuntimeConfig()
+	assert.True(t, rc.EnableTracing)
+	assert.False(t, rc.EnableMetrics)
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package opentelemetry
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+)
+
+// NewRenameView returns a view renaming the instrument named instrumentName to name.
+// instrumentName accepts the wildcards `*` and `?`.
+func NewRenameView(instrumentName, name string) metric.View {
+	return metric.NewView(
+		metric.Instrument{Name: instrumentName},
+		metric.Stream{Name: name},
+	)
+}
+
+// NewDropAttributesView returns a view dropping the given attributes from the instruments matching instrumentName,
+// e.g. to reduce the cardinality of high-cardinality attributes such as user or session ids.
+// instrumentName accepts the wildcards `*` and `?`.
+func NewDropAttributesView(instrumentName string, keys ...attribute.Key) metric.View {
+	return metric.NewView(
+		metric.Instrument{Name: instrumentName},
+		metric.Stream{AttributeFilter: attribute.NewDenyKeysFilter(keys...)},
+	)
+}
+
+// NewHistogramBucketsView returns a view aggregating the histograms matching instrumentName
+// with explicit bucket boundaries, e.g. for latency or token-count histograms.
+// instrumentName accepts the wildcards `*` and `?`.
+func NewHistogramBucketsView(instrumentName string, boundaries ...float64) metric.View {
+	return metric.NewView(
+		metric.Instrument{Name: instrumentName, Kind: metric.InstrumentKindHistogram},
+		metric.Stream{Aggregation: metric.AggregationExplicitBucketHistogram{Boundaries: boundaries}},
+	)
+}
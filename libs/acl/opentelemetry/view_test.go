@@ -0,0 +1,88 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package opentelemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestWithMetricViews(t *testing.T) {
+	cfg := newConfig([]Option{
+		WithMetricViews(NewRenameView("a", "b")),
+		WithMetricViews(NewDropAttributesView("c"), NewHistogramBucketsView("d", 1, 2)),
+	})
+	assert.Len(t, cfg.metricViews, 3)
+}
+
+func TestViews(t *testing.T) {
+	ctx := context.Background()
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader), metric.WithView(
+		NewRenameView("gen_ai.chat.count", "llm.chat.count"),
+		NewDropAttributesView("gen_ai.client.token.usage", "session.id"),
+		NewHistogramBucketsView("gen_ai.client.operation.duration", 0.5, 1, 5),
+	))
+	meter := mp.Meter("test")
+
+	counter, err := meter.Int64Counter("gen_ai.chat.count")
+	assert.NoError(t, err)
+	counter.Add(ctx, 1)
+
+	histogram, err := meter.Float64Histogram("gen_ai.client.operation.duration")
+	assert.NoError(t, err)
+	histogram.Record(ctx, 2)
+
+	tokenUsage, err := meter.Int64Histogram("gen_ai.client.token.usage")
+	assert.NoError(t, err)
+	tokenUsage.Record(ctx, 100, otelmetric.WithAttributes(
+		attribute.String("session.id", "s1"),
+		attribute.String("gen_ai.request.model", "gpt-4o"),
+	))
+
+	rm := metricdata.ResourceMetrics{}
+	assert.NoError(t, reader.Collect(ctx, &rm))
+	assert.Len(t, rm.ScopeMetrics, 1)
+
+	metrics := map[string]metricdata.Metrics{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m
+	}
+	assert.Contains(t, metrics, "llm.chat.count")
+	assert.NotContains(t, metrics, "gen_ai.chat.count")
+
+	data, ok := metrics["gen_ai.client.operation.duration"].Data.(metricdata.Histogram[float64])
+	assert.True(t, ok)
+	assert.Len(t, data.DataPoints, 1)
+	dp := data.DataPoints[0]
+	assert.Equal(t, []float64{0.5, 1, 5}, dp.Bounds)
+	assert.Equal(t, []uint64{0, 0, 1, 0}, dp.BucketCounts)
+
+	usage, ok := metrics["gen_ai.client.token.usage"].Data.(metricdata.Histogram[int64])
+	assert.True(t, ok)
+	assert.Len(t, usage.DataPoints, 1)
+	_, found := usage.DataPoints[0].Attributes.Value("session.id")
+	assert.False(t, found)
+	_, found = usage.DataPoints[0].Attributes.Value("gen_ai.request.model")
+	assert.True(t, found)
+}
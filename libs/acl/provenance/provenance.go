@@ -0,0 +1,118 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provenance records where the chunks of the document splitters come from: their index among the chunks
+// of their document, their character offsets in the document content, and the IDs of their neighbor chunks.
+package provenance
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	// MetaKeyChunkIndex is the metadata key of the 0-based index of a chunk among the chunks of its document.
+	MetaKeyChunkIndex = "_chunk_index"
+	// MetaKeyStartOffset is the metadata key of the character offset in the original document content where a chunk starts,
+	// absent if the chunk is not located in the content.
+	MetaKeyStartOffset = "_start_offset"
+	// MetaKeyEndOffset is the metadata key of the character offset in the original document content where a chunk ends, exclusive.
+	MetaKeyEndOffset = "_end_offset"
+	// MetaKeyPrevChunkID is the metadata key of the ID of the previous chunk of the same document,
+	// set only if the chunks have distinct IDs.
+	MetaKeyPrevChunkID = "_prev_chunk_id"
+	// MetaKeyNextChunkID is the metadata key of the ID of the next chunk of the same document,
+	// set only if the chunks have distinct IDs.
+	MetaKeyNextChunkID = "_next_chunk_id"
+)
+
+// Locate finds the byte ranges of the chunks in the text. The chunks are substrings of the text in order, possibly overlapping,
+// so each one is searched after the start of the previous one: repeated chunks are located at successive occurrences.
+// The range of a chunk not found is {-1, -1}.
+func Locate(text string, chunks []string) [][2]int {
+	ret := make([][2]int, len(chunks))
+	from := 0
+	for i, chunk := range chunks {
+		idx := -1
+		if from <= len(text) {
+			idx = strings.Index(text[from:], chunk)
+		}
+		if idx < 0 {
+			ret[i] = [2]int{-1, -1}
+			continue
+		}
+		idx += from
+		ret[i] = [2]int{idx, idx + len(chunk)}
+		from = idx + 1
+	}
+	return ret
+}
+
+// Annotate sets the character offsets of the chunks in the text from their byte ranges, {-1, -1} for a chunk
+// not located, and links each chunk to its neighbors, see Link.
+func Annotate(text string, chunks []*schema.Document, ranges [][2]int) {
+	offsets := runeOffsets(text, ranges)
+	for i, chunk := range chunks {
+		if chunk.MetaData == nil {
+			chunk.MetaData = make(map[string]any)
+		}
+		if ranges[i][0] >= 0 {
+			chunk.MetaData[MetaKeyStartOffset] = offsets[ranges[i][0]]
+			chunk.MetaData[MetaKeyEndOffset] = offsets[ranges[i][1]]
+		}
+	}
+	Link(chunks)
+}
+
+// Link sets the index of the chunks of a document and links each chunk to its neighbors,
+// for the splitters whose chunks are not ranges of the document content.
+func Link(chunks []*schema.Document) {
+	for i, chunk := range chunks {
+		if chunk.MetaData == nil {
+			chunk.MetaData = make(map[string]any)
+		}
+		chunk.MetaData[MetaKeyChunkIndex] = i
+		if i > 0 && chunks[i-1].ID != "" && chunks[i-1].ID != chunk.ID {
+			chunk.MetaData[MetaKeyPrevChunkID] = chunks[i-1].ID
+		}
+		if i < len(chunks)-1 && chunks[i+1].ID != "" && chunks[i+1].ID != chunk.ID {
+			chunk.MetaData[MetaKeyNextChunkID] = chunks[i+1].ID
+		}
+	}
+}
+
+// runeOffsets maps the byte offsets of the ranges to character offsets, in a single pass over the text.
+func runeOffsets(text string, ranges [][2]int) map[int]int {
+	bytes := make([]int, 0, 2*len(ranges))
+	for _, r := range ranges {
+		if r[0] >= 0 {
+			bytes = append(bytes, r[0], r[1])
+		}
+	}
+	sort.Ints(bytes)
+
+	ret := make(map[int]int, len(bytes))
+	pos, runes := 0, 0
+	for _, b := range bytes {
+		runes += utf8.RuneCountInString(text[pos:b])
+		pos = b
+		ret[b] = runes
+	}
+	return ret
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provenance
+
+import (
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocate(t *testing.T) {
+	text := "abc def abc def"
+	assert.Equal(t, [][2]int{{0, 7}, {4, 11}, {8, 15}}, Locate(text, []string{"abc def", "def abc", "abc def"}))
+	assert.Equal(t, [][2]int{{0, 3}, {-1, -1}, {4, 7}}, Locate(text, []string{"abc", "xyz", "def"}))
+	assert.Equal(t, [][2]int{}, Locate(text, nil))
+
+	// repeated chunks are located at successive occurrences
+	assert.Equal(t, [][2]int{{0, 3}, {4, 7}, {8, 11}}, Locate("abc abc abc", []string{"abc", "abc", "abc"}))
+	assert.Equal(t, [][2]int{{0, 3}, {1, 4}, {-1, -1}}, Locate("aaaa", []string{"aaa", "aaa", "aaa"}))
+	assert.Equal(t, [][2]int{{0, 3}, {4, 7}, {-1, -1}}, Locate("abc abc", []string{"abc", "abc", "abc"}))
+}
+
+func TestAnnotate(t *testing.T) {
+	text := "你好 world, 你好 eino"
+	chunks := []*schema.Document{
+		{ID: "c0", Content: "你好 world"},
+		{ID: "c1", Content: "missing"},
+		{ID: "c2", Content: "你好 eino", MetaData: map[string]any{"k": "v"}},
+	}
+	Annotate(text, chunks, Locate(text, []string{"你好 world", "missing", "你好 eino"}))
+
+	assert.Equal(t, map[string]any{
+		MetaKeyChunkIndex:  0,
+		MetaKeyStartOffset: 0,
+		MetaKeyEndOffset:   8,
+		MetaKeyNextChunkID: "c1",
+	}, chunks[0].MetaData)
+	assert.Equal(t, map[string]any{
+		MetaKeyChunkIndex:  1,
+		MetaKeyPrevChunkID: "c0",
+		MetaKeyNextChunkID: "c2",
+	}, chunks[1].MetaData)
+	assert.Equal(t, map[string]any{
+		"k":                "v",
+		MetaKeyChunkIndex:  2,
+		MetaKeyStartOffset: 10,
+		MetaKeyEndOffset:   17,
+		MetaKeyPrevChunkID: "c1",
+	}, chunks[2].MetaData)
+}
+
+func TestLink(t *testing.T) {
+	// the chunks without distinct IDs are not linked
+	chunks := []*schema.Document{{ID: "doc"}, {ID: "doc"}, {ID: "doc"}}
+	Link(chunks)
+	for i, chunk := range chunks {
+		assert.Equal(t, map[string]any{MetaKeyChunkIndex: i}, chunk.MetaData)
+	}
+}
@@ -0,0 +1,116 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package safepath resolves the paths given by a model against a root dir, and rejects the paths leaving it,
+// including through symbolic links whose targets don't exist yet.
+package safepath
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrOutsideRoot is returned by Resolve for the paths leaving the root dir.
+var ErrOutsideRoot = errors.New("path is outside of the root dir")
+
+// maxLinks is the number of symbolic links followed before giving up, as the linux ELOOP limit.
+const maxLinks = 255
+
+// Resolve resolves the symbolic links of path and checks that the result is under root.
+// root must be absolute and free of symbolic links, eg. the result of filepath.EvalSymlinks,
+// path must be absolute. Unlike filepath.EvalSymlinks, path doesn't need to exist: the missing
+// components are kept as is, so that they can be created later, and dangling links are resolved
+// to their targets, so that writing through them can't create a file outside of root.
+func Resolve(root, path string) (string, error) {
+	resolved, err := evalSymlinks(path)
+	if err != nil {
+		return "", err
+	}
+	if !IsUnder(root, resolved) {
+		return "", fmt.Errorf("%w: %s", ErrOutsideRoot, resolved)
+	}
+	return resolved, nil
+}
+
+// IsUnder reports whether the clean absolute path is root or under it, without resolving links.
+func IsUnder(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
+
+func evalSymlinks(path string) (string, error) {
+	if !filepath.IsAbs(path) {
+		return "", fmt.Errorf("path is not absolute: %s", path)
+	}
+	vol := filepath.VolumeName(path)
+	resolved := vol + string(filepath.Separator)
+	rest := split(path[len(vol):])
+	links := 0
+	for len(rest) > 0 {
+		name := rest[0]
+		rest = rest[1:]
+		switch name {
+		case ".":
+			continue
+		case "..":
+			resolved = filepath.Dir(resolved)
+			continue
+		}
+
+		next := filepath.Join(resolved, name)
+		info, err := os.Lstat(next)
+		if os.IsNotExist(err) {
+			// nothing exists below a missing component, but the following ".." may leave it
+			resolved = next
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if info.Mode()&os.ModeSymlink == 0 {
+			resolved = next
+			continue
+		}
+
+		if links++; links > maxLinks {
+			return "", fmt.Errorf("too many links in %s", path)
+		}
+		target, err := os.Readlink(next)
+		if err != nil {
+			return "", err
+		}
+		if filepath.IsAbs(target) {
+			vol = filepath.VolumeName(target)
+			resolved = vol + string(filepath.Separator)
+			target = target[len(vol):]
+		}
+		rest = append(split(target), rest...)
+	}
+	return resolved, nil
+}
+
+func split(path string) []string {
+	var names []string
+	for _, name := range strings.Split(filepath.ToSlash(path), "/") {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
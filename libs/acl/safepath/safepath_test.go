@@ -0,0 +1,78 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package safepath
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symbolic links require privileges on windows")
+	}
+	tmp, err := filepath.EvalSymlinks(t.TempDir())
+	assert.NoError(t, err)
+	root, outside := filepath.Join(tmp, "ws"), filepath.Join(tmp, "outside")
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "pkg"), 0755))
+	assert.NoError(t, os.MkdirAll(outside, 0755))
+
+	assert.NoError(t, os.Symlink(outside, filepath.Join(root, "abs")))
+	assert.NoError(t, os.Symlink("../outside/pwned.txt", filepath.Join(root, "dangling")))
+	assert.NoError(t, os.Symlink("..", filepath.Join(root, "pkg", "up")))
+	assert.NoError(t, os.Symlink("../..", filepath.Join(root, "pkg", "out")))
+	assert.NoError(t, os.Symlink("pkg/new.txt", filepath.Join(root, "inside")))
+	assert.NoError(t, os.Symlink("loop", filepath.Join(root, "loop")))
+
+	for path, want := range map[string]string{
+		".":                   root,
+		"pkg":                 filepath.Join(root, "pkg"),
+		"new/dir/file.txt":    filepath.Join(root, "new/dir/file.txt"),
+		"new/../pkg":          filepath.Join(root, "pkg"),
+		"inside":              filepath.Join(root, "pkg/new.txt"),
+		"pkg/up/pkg":          filepath.Join(root, "pkg"),
+		"pkg/up/inside":       filepath.Join(root, "pkg/new.txt"),
+		"pkg/up/new/file.txt": filepath.Join(root, "new/file.txt"),
+	} {
+		got, err := Resolve(root, filepath.Join(root, path))
+		assert.NoError(t, err, path)
+		assert.Equal(t, want, got, path)
+	}
+
+	for _, path := range []string{"abs", "abs/x/y", "dangling", "pkg/out", "pkg/up/pkg/out/outside", "pkg/up/dangling"} {
+		_, err := Resolve(root, filepath.Join(root, path))
+		assert.ErrorIs(t, err, ErrOutsideRoot, path)
+	}
+
+	_, err = Resolve(root, filepath.Join(root, "loop"))
+	assert.ErrorContains(t, err, "too many links")
+	_, err = Resolve(root, "pkg")
+	assert.ErrorContains(t, err, "path is not absolute")
+}
+
+func TestIsUnder(t *testing.T) {
+	root := filepath.FromSlash("/ws")
+	assert.True(t, IsUnder(root, root))
+	assert.True(t, IsUnder(root, filepath.FromSlash("/ws/a/b")))
+	assert.False(t, IsUnder(root, filepath.FromSlash("/")))
+	assert.False(t, IsUnder(root, filepath.FromSlash("/ws2")))
+	assert.False(t, IsUnder(root, filepath.FromSlash("/other/ws")))
+}
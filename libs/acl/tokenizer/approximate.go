@@ -0,0 +1,82 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tokenizer
+
+import (
+	"context"
+	"math"
+	"unicode"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	defaultCharsPerToken = 4.0
+	// approximateMessageOverhead covers the role and the chat template tokens around a message
+	approximateMessageOverhead = 4
+)
+
+type approximateCounter struct {
+	charsPerToken float64
+}
+
+// ApproximateOption configures NewApproximateCounter.
+type ApproximateOption func(*approximateCounter)
+
+// WithCharsPerToken sets how many non CJK characters make one token, default 4, which is close for English text.
+func WithCharsPerToken(n float64) ApproximateOption {
+	return func(c *approximateCounter) {
+		if n > 0 {
+			c.charsPerToken = n
+		}
+	}
+}
+
+// NewApproximateCounter creates a counter for models without a local tokenizer.
+// It counts a token per CJK character and a token per few characters of other text,
+// which is usually within 20% of the real count, so keep a margin when it's used to enforce a hard limit.
+func NewApproximateCounter(opts ...ApproximateOption) Counter {
+	c := &approximateCounter{charsPerToken: defaultCharsPerToken}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (a *approximateCounter) CountTokens(_ context.Context, messages []*schema.Message, _ string) (int, error) {
+	total := 0
+	for _, msg := range messages {
+		total += approximateMessageOverhead + a.count(messageText(msg)) + a.count(msg.Name)
+		for _, tc := range msg.ToolCalls {
+			total += approximateMessageOverhead + a.count(tc.Function.Name) + a.count(tc.Function.Arguments)
+		}
+		total += len(imageParts(msg)) * imageDefaultTokens
+	}
+	return total, nil
+}
+
+func (a *approximateCounter) count(text string) int {
+	cjk, other := 0, 0
+	for _, r := range text {
+		if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) {
+			cjk++
+		} else {
+			other++
+		}
+	}
+	return cjk + int(math.Ceil(float64(other)/a.charsPerToken))
+}
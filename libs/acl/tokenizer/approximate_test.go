@@ -0,0 +1,38 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tokenizer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApproximateCounter(t *testing.T) {
+	ctx := context.Background()
+
+	n, err := NewApproximateCounter().CountTokens(ctx, []*schema.Message{schema.UserMessage("你好, world")}, "")
+	assert.NoError(t, err)
+	// 2 cjk + ceil(7/4)
+	assert.Equal(t, approximateMessageOverhead+4, n)
+
+	n, err = NewApproximateCounter(WithCharsPerToken(1)).CountTokens(ctx, []*schema.Message{schema.UserMessage("abc")}, "")
+	assert.NoError(t, err)
+	assert.Equal(t, approximateMessageOverhead+3, n)
+}
@@ -0,0 +1,204 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tokenizer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-ext/libs/acl/tokenizer/internal/multimodal"
+)
+
+//go:generate sh ../../../libs/acl/bundle.sh multimodal internal/multimodal
+
+const (
+	defaultClaudeBaseURL = "https://api.anthropic.com"
+	claudeAPIVersion     = "2023-06-01"
+)
+
+type ClaudeConfig struct {
+	// APIKey is the Anthropic api key.
+	// Required.
+	APIKey string
+	// BaseURL is the Anthropic api endpoint.
+	// Optional. Default: "https://api.anthropic.com".
+	BaseURL string
+	// HTTPClient sends the count requests.
+	// Optional. Default: http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+type claudeCounter struct {
+	apiKey  string
+	baseURL string
+	cli     *http.Client
+	conv    *multimodal.Converter
+}
+
+// NewClaudeCounter creates a counter that calls the Claude count tokens api, Claude has no public tokenizer.
+// Each count is a request, cache the result if the same messages are counted repeatedly.
+func NewClaudeCounter(config *ClaudeConfig) (Counter, error) {
+	if config == nil || config.APIKey == "" {
+		return nil, fmt.Errorf("claude counter requires an api key")
+	}
+	c := &claudeCounter{
+		apiKey:  config.APIKey,
+		baseURL: strings.TrimSuffix(config.BaseURL, "/"),
+		cli:     config.HTTPClient,
+		conv:    multimodal.NewConverter(nil),
+	}
+	if c.baseURL == "" {
+		c.baseURL = defaultClaudeBaseURL
+	}
+	if c.cli == nil {
+		c.cli = http.DefaultClient
+	}
+	return c, nil
+}
+
+type claudeCountRequest struct {
+	Model    string          `json:"model"`
+	System   string          `json:"system,omitempty"`
+	Messages []claudeMessage `json:"messages"`
+}
+
+type claudeMessage struct {
+	Role    string `json:"role"`
+	Content []any  `json:"content"`
+}
+
+type claudeTextBlock struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type claudeToolUseBlock struct {
+	Type  string          `json:"type"`
+	ID    string          `json:"id"`
+	Name  string          `json:"name"`
+	Input json.RawMessage `json:"input"`
+}
+
+type claudeToolResultBlock struct {
+	Type      string `json:"type"`
+	ToolUseID string `json:"tool_use_id"`
+	Content   string `json:"content"`
+}
+
+type claudeCountResponse struct {
+	InputTokens int `json:"input_tokens"`
+}
+
+func (c *claudeCounter) CountTokens(ctx context.Context, messages []*schema.Message, model string) (int, error) {
+	body, err := c.buildRequest(ctx, messages, model)
+	if err != nil {
+		return 0, err
+	}
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return 0, fmt.Errorf("marshal count tokens request fail: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/v1/messages/count_tokens", bytes.NewReader(raw))
+	if err != nil {
+		return 0, fmt.Errorf("create count tokens request fail: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Api-Key", c.apiKey)
+	req.Header.Set("Anthropic-Version", claudeAPIVersion)
+
+	resp, err := c.cli.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("count tokens fail: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("read count tokens response fail: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("count tokens fail: status %d: %s", resp.StatusCode, respBody)
+	}
+
+	var result claudeCountResponse
+	if err = json.Unmarshal(respBody, &result); err != nil {
+		return 0, fmt.Errorf("unmarshal count tokens response fail: %w", err)
+	}
+	return result.InputTokens, nil
+}
+
+func (c *claudeCounter) buildRequest(ctx context.Context, messages []*schema.Message, model string) (*claudeCountRequest, error) {
+	req := &claudeCountRequest{Model: model}
+	var system []string
+	for _, msg := range messages {
+		if msg.Role == schema.System {
+			system = append(system, msg.Content)
+			continue
+		}
+
+		m := claudeMessage{Role: "user"}
+		switch msg.Role {
+		case schema.Assistant:
+			m.Role = "assistant"
+		case schema.Tool:
+			m.Content = append(m.Content, claudeToolResultBlock{Type: "tool_result", ToolUseID: msg.ToolCallID, Content: msg.Content})
+		}
+
+		if msg.Role != schema.Tool {
+			if msg.Content != "" {
+				m.Content = append(m.Content, claudeTextBlock{Type: "text", Text: msg.Content})
+			} else {
+				for _, part := range msg.UserInputMultiContent {
+					p, err := multimodal.FromInputPart(part)
+					if err != nil {
+						return nil, err
+					}
+					block, err := c.conv.ToClaude(ctx, p)
+					if err != nil {
+						return nil, err
+					}
+					m.Content = append(m.Content, block)
+				}
+				for _, part := range msg.AssistantGenMultiContent {
+					if part.Type == schema.ChatMessagePartTypeText {
+						m.Content = append(m.Content, claudeTextBlock{Type: "text", Text: part.Text})
+					}
+				}
+			}
+		}
+
+		for _, tc := range msg.ToolCalls {
+			args := tc.Function.Arguments
+			if args == "" {
+				args = "{}"
+			}
+			m.Content = append(m.Content, claudeToolUseBlock{Type: "tool_use", ID: tc.ID, Name: tc.Function.Name, Input: json.RawMessage(args)})
+		}
+		if len(m.Content) > 0 {
+			req.Messages = append(req.Messages, m)
+		}
+	}
+	req.System = strings.Join(system, "\n")
+	return req, nil
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tokenizer
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClaudeCounter(t *testing.T) {
+	ctx := context.Background()
+	var body map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/messages/count_tokens", r.URL.Path)
+		assert.Equal(t, "key", r.Header.Get("X-Api-Key"))
+		assert.Equal(t, claudeAPIVersion, r.Header.Get("Anthropic-Version"))
+		raw, _ := io.ReadAll(r.Body)
+		body = nil
+		assert.NoError(t, json.Unmarshal(raw, &body))
+		if body["model"] == "bad" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"type":"error"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"input_tokens":21}`))
+	}))
+	defer srv.Close()
+
+	_, err := NewClaudeCounter(&ClaudeConfig{})
+	assert.Error(t, err)
+
+	c, err := NewClaudeCounter(&ClaudeConfig{APIKey: "key", BaseURL: srv.URL + "/", HTTPClient: srv.Client()})
+	assert.NoError(t, err)
+
+	pdf := "JVBERi0xLjQ="
+	n, err := c.CountTokens(ctx, []*schema.Message{
+		schema.SystemMessage("be brief"),
+		{
+			Role: schema.User,
+			UserInputMultiContent: []schema.MessageInputPart{
+				{Type: schema.ChatMessagePartTypeText, Text: "summarize"},
+				{Type: schema.ChatMessagePartTypeFileURL, File: &schema.MessageInputFile{MessagePartCommon: schema.MessagePartCommon{Base64Data: &pdf, MIMEType: "application/pdf"}}},
+			},
+		},
+		schema.AssistantMessage("", []schema.ToolCall{{ID: "call_1", Function: schema.FunctionCall{Name: "search"}}}),
+		schema.ToolMessage("result", "call_1"),
+	}, "claude-sonnet-4-0")
+	assert.NoError(t, err)
+	assert.Equal(t, 21, n)
+
+	expected := `{
+		"model": "claude-sonnet-4-0",
+		"system": "be brief",
+		"messages": [
+			{"role": "user", "content": [
+				{"type": "text", "text": "summarize"},
+				{"type": "document", "source": {"type": "base64", "media_type": "application/pdf", "data": "JVBERi0xLjQ="}}
+			]},
+			{"role": "assistant", "content": [{"type": "tool_use", "id": "call_1", "name": "search", "input": {}}]},
+			{"role": "user", "content": [{"type": "tool_result", "tool_use_id": "call_1", "content": "result"}]}
+		]
+	}`
+	raw, _ := json.Marshal(body)
+	assert.JSONEq(t, expected, string(raw))
+
+	_, err = c.CountTokens(ctx, []*schema.Message{schema.UserMessage("hi")}, "bad")
+	assert.ErrorContains(t, err, "status 400")
+}
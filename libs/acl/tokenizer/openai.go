@@ -0,0 +1,151 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tokenizer
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/pkoukk/tiktoken-go"
+	tiktokenloader "github.com/pkoukk/tiktoken-go-loader"
+)
+
+// The chat format overhead, see https://cookbook.openai.com/examples/how_to_count_tokens_with_tiktoken
+const (
+	tokensPerMessage  = 3
+	tokensPerName     = 1
+	tokensPerToolCall = 3
+	// every reply is primed with <|start|>assistant<|message|>
+	tokensReplyPrimer = 3
+
+	imageLowDetailTokens = 85
+	imageTileTokens      = 170
+	// imageDefaultTokens is a high detail 1024x1024 image, used when the image size is unknown
+	imageDefaultTokens = imageLowDetailTokens + 4*imageTileTokens
+)
+
+var setLoaderOnce sync.Once
+
+type openAICounter struct {
+	encodings sync.Map // model -> *tiktoken.Tiktoken
+}
+
+// NewOpenAICounter creates a counter of the OpenAI chat models using tiktoken.
+// The ranks files are embedded, so counting needs no network access.
+// Models unknown to tiktoken are counted with o200k_base, the encoding of the recent models.
+// Images are counted with the documented tile formula when their size can be decoded from Base64Data,
+// audio and file parts are not counted.
+func NewOpenAICounter() Counter {
+	return &openAICounter{}
+}
+
+func (o *openAICounter) CountTokens(_ context.Context, messages []*schema.Message, model string) (int, error) {
+	enc, err := o.encoding(model)
+	if err != nil {
+		return 0, err
+	}
+	count := func(text string) int {
+		return len(enc.EncodeOrdinary(text))
+	}
+
+	total := tokensReplyPrimer
+	for _, msg := range messages {
+		total += tokensPerMessage + count(string(msg.Role)) + count(messageText(msg))
+		if msg.Name != "" {
+			total += tokensPerName + count(msg.Name)
+		}
+		for _, tc := range msg.ToolCalls {
+			total += tokensPerToolCall + count(tc.Function.Name) + count(tc.Function.Arguments)
+		}
+		for _, img := range imageParts(msg) {
+			total += imageTokens(img)
+		}
+	}
+	return total, nil
+}
+
+func (o *openAICounter) encoding(model string) (*tiktoken.Tiktoken, error) {
+	if enc, ok := o.encodings.Load(model); ok {
+		return enc.(*tiktoken.Tiktoken), nil
+	}
+
+	setLoaderOnce.Do(func() {
+		tiktoken.SetBpeLoader(tiktokenloader.NewOfflineLoader())
+	})
+	enc, err := tiktoken.EncodingForModel(model)
+	if err != nil {
+		enc, err = tiktoken.GetEncoding(tiktoken.MODEL_O200K_BASE)
+		if err != nil {
+			return nil, fmt.Errorf("get tiktoken encoding of model %s fail: %w", model, err)
+		}
+	}
+	o.encodings.Store(model, enc)
+	return enc, nil
+}
+
+// imageTokens follows the vision pricing of the gpt-4o models: a low detail image costs a fixed amount,
+// a high detail one is fit into 2048x2048, scaled so that its shortest side is 768 and costs per 512px tile.
+func imageTokens(img *schema.MessageInputImage) int {
+	if img.Detail == schema.ImageURLDetailLow {
+		return imageLowDetailTokens
+	}
+	width, height, ok := imageSize(img)
+	if !ok {
+		return imageDefaultTokens
+	}
+
+	w, h := float64(width), float64(height)
+	if scale := 2048 / math.Max(w, h); scale < 1 {
+		w, h = w*scale, h*scale
+	}
+	if scale := 768 / math.Min(w, h); scale < 1 {
+		w, h = w*scale, h*scale
+	}
+	tiles := int(math.Ceil(w/512) * math.Ceil(h/512))
+	return imageLowDetailTokens + tiles*imageTileTokens
+}
+
+func imageSize(img *schema.MessageInputImage) (int, int, bool) {
+	var encoded string
+	switch {
+	case img.Base64Data != nil:
+		encoded = *img.Base64Data
+	case img.URL != nil && strings.HasPrefix(*img.URL, "data:"):
+		_, encoded, _ = strings.Cut(*img.URL, ",")
+	default:
+		return 0, 0, false
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return 0, 0, false
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || cfg.Width == 0 || cfg.Height == 0 {
+		return 0, 0, false
+	}
+	return cfg.Width, cfg.Height, true
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tokenizer
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenAICounter(t *testing.T) {
+	ctx := context.Background()
+	c := NewOpenAICounter()
+
+	n, err := c.CountTokens(ctx, []*schema.Message{
+		schema.SystemMessage("You are a helpful assistant."),
+		schema.UserMessage("hello world"),
+	}, "gpt-4o")
+	assert.NoError(t, err)
+	// 3 + (3+1+6) + (3+1+2)
+	assert.Equal(t, 19, n)
+
+	named := schema.UserMessage("hello world")
+	named.Name = "bob"
+	n2, err := c.CountTokens(ctx, []*schema.Message{named}, "gpt-4o")
+	assert.NoError(t, err)
+	assert.Equal(t, 9+tokensPerName+1, n2)
+
+	call := schema.AssistantMessage("", []schema.ToolCall{{Function: schema.FunctionCall{Name: "get_weather", Arguments: `{"city":"Paris"}`}}})
+	n3, err := c.CountTokens(ctx, []*schema.Message{call}, "unknown-model")
+	assert.NoError(t, err)
+	assert.Greater(t, n3, tokensReplyPrimer+tokensPerMessage+tokensPerToolCall)
+}
+
+func TestImageTokens(t *testing.T) {
+	buf := &bytes.Buffer{}
+	assert.NoError(t, png.Encode(buf, image.NewRGBA(image.Rect(0, 0, 2048, 4096))))
+	data := base64.StdEncoding.EncodeToString(buf.Bytes())
+
+	img := &schema.MessageInputImage{MessagePartCommon: schema.MessagePartCommon{Base64Data: &data, MIMEType: "image/png"}}
+	// 2048x4096 -> 1024x2048 -> 768x1536, 2x3 tiles
+	assert.Equal(t, 85+6*170, imageTokens(img))
+
+	img.Detail = schema.ImageURLDetailLow
+	assert.Equal(t, 85, imageTokens(img))
+
+	url := "https://example.com/a.png"
+	assert.Equal(t, imageDefaultTokens, imageTokens(&schema.MessageInputImage{MessagePartCommon: schema.MessagePartCommon{URL: &url}}))
+}
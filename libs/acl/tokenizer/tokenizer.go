@@ -0,0 +1,144 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package tokenizer counts the tokens of eino messages for a model, using the provider's own
+// tokenizer where one is available, and trims conversations to a token budget.
+package tokenizer
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// Counter counts the input tokens of messages for a model.
+type Counter interface {
+	CountTokens(ctx context.Context, messages []*schema.Message, model string) (int, error)
+}
+
+// CounterFunc adapts a function to Counter.
+type CounterFunc func(ctx context.Context, messages []*schema.Message, model string) (int, error)
+
+func (f CounterFunc) CountTokens(ctx context.Context, messages []*schema.Message, model string) (int, error) {
+	return f(ctx, messages, model)
+}
+
+type registration struct {
+	prefix  string
+	counter Counter
+}
+
+var (
+	mu       sync.RWMutex
+	registry []registration
+	fallback Counter = NewApproximateCounter()
+)
+
+func init() {
+	openai := NewOpenAICounter()
+	for _, prefix := range []string{"gpt-", "chatgpt-", "o1", "o3", "o4", "text-embedding-", "davinci", "babbage"} {
+		Register(prefix, openai)
+	}
+}
+
+// Register sets the counter of the models whose name starts with prefix, the longest matching prefix wins.
+// Claude has no local tokenizer, register a counter of the count tokens api to count exactly:
+//
+//	counter, err := tokenizer.NewClaudeCounter(&tokenizer.ClaudeConfig{APIKey: key})
+//	if err != nil {
+//		return err
+//	}
+//	tokenizer.Register("claude", counter)
+func Register(prefix string, counter Counter) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for i := range registry {
+		if registry[i].prefix == prefix {
+			registry[i].counter = counter
+			return
+		}
+	}
+	registry = append(registry, registration{prefix: prefix, counter: counter})
+	sort.SliceStable(registry, func(i, j int) bool {
+		return len(registry[i].prefix) > len(registry[j].prefix)
+	})
+}
+
+// CounterFor returns the counter registered for the model, or the approximate counter if there is none.
+func CounterFor(model string) Counter {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	for _, r := range registry {
+		if strings.HasPrefix(model, r.prefix) {
+			return r.counter
+		}
+	}
+	return fallback
+}
+
+// CountTokens counts the input tokens of messages with the counter registered for the model.
+func CountTokens(ctx context.Context, messages []*schema.Message, model string) (int, error) {
+	return CounterFor(model).CountTokens(ctx, messages, model)
+}
+
+// messageText returns the text content of the message, including the text parts of multi content.
+func messageText(msg *schema.Message) string {
+	if msg.Content != "" {
+		return msg.Content
+	}
+	sb := &strings.Builder{}
+	for _, part := range msg.UserInputMultiContent {
+		if part.Type == schema.ChatMessagePartTypeText {
+			sb.WriteString(part.Text)
+		}
+	}
+	for _, part := range msg.AssistantGenMultiContent {
+		if part.Type == schema.ChatMessagePartTypeText {
+			sb.WriteString(part.Text)
+		}
+	}
+	for _, part := range msg.MultiContent {
+		if part.Type == schema.ChatMessagePartTypeText {
+			sb.WriteString(part.Text)
+		}
+	}
+	return sb.String()
+}
+
+// imageParts returns the image parts of the message, the deprecated MultiContent included.
+func imageParts(msg *schema.Message) []*schema.MessageInputImage {
+	var images []*schema.MessageInputImage
+	for _, part := range msg.UserInputMultiContent {
+		if part.Type == schema.ChatMessagePartTypeImageURL && part.Image != nil {
+			images = append(images, part.Image)
+		}
+	}
+	for _, part := range msg.MultiContent {
+		if part.Type == schema.ChatMessagePartTypeImageURL && part.ImageURL != nil {
+			url := part.ImageURL.URL
+			images = append(images, &schema.MessageInputImage{
+				MessagePartCommon: schema.MessagePartCommon{URL: &url},
+				Detail:            part.ImageURL.Detail,
+			})
+		}
+	}
+	return images
+}
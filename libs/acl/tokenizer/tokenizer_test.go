@@ -0,0 +1,58 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tokenizer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCounterFor(t *testing.T) {
+	ctx := context.Background()
+	msgs := []*schema.Message{schema.UserMessage("hello world")}
+
+	n, err := CountTokens(ctx, msgs, "gpt-4o-mini")
+	assert.NoError(t, err)
+	assert.Equal(t, 9, n)
+
+	// falls back to the approximate counter
+	n, err = CountTokens(ctx, msgs, "doubao-pro")
+	assert.NoError(t, err)
+	assert.Equal(t, approximateMessageOverhead+3, n)
+
+	Register("doubao", CounterFunc(func(ctx context.Context, messages []*schema.Message, model string) (int, error) {
+		return 42, nil
+	}))
+	Register("doubao-pro", CounterFunc(func(ctx context.Context, messages []*schema.Message, model string) (int, error) {
+		return 7, nil
+	}))
+	defer func() {
+		mu.Lock()
+		defer mu.Unlock()
+		registry = registry[2:]
+	}()
+
+	n, err = CountTokens(ctx, msgs, "doubao-pro-32k")
+	assert.NoError(t, err)
+	assert.Equal(t, 7, n)
+	n, err = CountTokens(ctx, msgs, "doubao-lite")
+	assert.NoError(t, err)
+	assert.Equal(t, 42, n)
+}
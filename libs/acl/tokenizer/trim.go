@@ -0,0 +1,120 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tokenizer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// ErrBudgetExceeded is returned by Trim when the kept messages alone exceed the budget.
+var ErrBudgetExceeded = errors.New("messages exceed the token budget")
+
+type trimOptions struct {
+	counter    Counter
+	keepSystem bool
+}
+
+// TrimOption configures Trim.
+type TrimOption func(*trimOptions)
+
+// WithCounter sets the counter used by Trim, default the one registered for the model.
+func WithCounter(counter Counter) TrimOption {
+	return func(o *trimOptions) {
+		o.counter = counter
+	}
+}
+
+// WithKeepSystem sets whether the leading system messages are always kept, default true.
+func WithKeepSystem(keep bool) TrimOption {
+	return func(o *trimOptions) {
+		o.keepSystem = keep
+	}
+}
+
+// Trim drops the oldest messages until the conversation fits the token budget of the model.
+// The leading system messages and the last message are always kept, and a tool message is never kept
+// without the assistant message that called it. ErrBudgetExceeded is returned together with the shortest
+// conversation when even that doesn't fit.
+// Counting is monotonic in the number of messages, so the cut is found with O(log n) counts,
+// which matters for counters that call an api.
+func Trim(ctx context.Context, messages []*schema.Message, model string, budget int, opts ...TrimOption) ([]*schema.Message, error) {
+	o := &trimOptions{keepSystem: true}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.counter == nil {
+		o.counter = CounterFor(model)
+	}
+	if len(messages) == 0 {
+		return messages, nil
+	}
+
+	var head []*schema.Message
+	if o.keepSystem {
+		for len(head) < len(messages)-1 && messages[len(head)].Role == schema.System {
+			head = append(head, messages[len(head)])
+		}
+	}
+	tail := messages[len(head):]
+
+	// candidate cuts, a cut never starts with a tool message, except the last message itself
+	var cuts []int
+	for i := range tail {
+		if i == len(tail)-1 || tail[i].Role != schema.Tool {
+			cuts = append(cuts, i)
+		}
+	}
+
+	fits := func(cut int) (bool, []*schema.Message, error) {
+		kept := make([]*schema.Message, 0, len(head)+len(tail)-cut)
+		kept = append(append(kept, head...), tail[cut:]...)
+		n, err := o.counter.CountTokens(ctx, kept, model)
+		if err != nil {
+			return false, nil, fmt.Errorf("count tokens fail: %w", err)
+		}
+		return n <= budget, kept, nil
+	}
+
+	// binary search the earliest cut that fits
+	lo, hi := 0, len(cuts)-1
+	var best []*schema.Message
+	for lo <= hi {
+		mid := (lo + hi) / 2
+		ok, kept, err := fits(cuts[mid])
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			best = kept
+			hi = mid - 1
+		} else {
+			lo = mid + 1
+		}
+	}
+	if best == nil {
+		_, kept, err := fits(cuts[len(cuts)-1])
+		if err != nil {
+			return nil, err
+		}
+		return kept, ErrBudgetExceeded
+	}
+	return best, nil
+}
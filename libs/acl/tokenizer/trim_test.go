@@ -0,0 +1,85 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tokenizer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// tokens per message, so that budgets are easy to reason about
+var perMessage = CounterFunc(func(ctx context.Context, messages []*schema.Message, model string) (int, error) {
+	return len(messages) * 10, nil
+})
+
+func TestTrim(t *testing.T) {
+	ctx := context.Background()
+	msgs := []*schema.Message{
+		schema.SystemMessage("sys"),
+		schema.UserMessage("q1"),
+		schema.AssistantMessage("", []schema.ToolCall{{ID: "1"}}),
+		schema.ToolMessage("r1", "1"),
+		schema.AssistantMessage("a1", nil),
+		schema.UserMessage("q2"),
+	}
+
+	t.Run("fits", func(t *testing.T) {
+		kept, err := Trim(ctx, msgs, "", 60, WithCounter(perMessage))
+		assert.NoError(t, err)
+		assert.Equal(t, msgs, kept)
+	})
+
+	t.Run("drops oldest and keeps system", func(t *testing.T) {
+		kept, err := Trim(ctx, msgs, "", 40, WithCounter(perMessage))
+		assert.NoError(t, err)
+		// the tool message can't lead without its call, so a1 starts the kept history
+		assert.Equal(t, []*schema.Message{msgs[0], msgs[4], msgs[5]}, kept)
+
+		kept, err = Trim(ctx, msgs, "", 50, WithCounter(perMessage))
+		assert.NoError(t, err)
+		assert.Equal(t, []*schema.Message{msgs[0], msgs[2], msgs[3], msgs[4], msgs[5]}, kept)
+	})
+
+	t.Run("drop system", func(t *testing.T) {
+		kept, err := Trim(ctx, msgs, "", 20, WithCounter(perMessage), WithKeepSystem(false))
+		assert.NoError(t, err)
+		assert.Equal(t, msgs[4:], kept)
+	})
+
+	t.Run("exceeded", func(t *testing.T) {
+		kept, err := Trim(ctx, msgs, "", 10, WithCounter(perMessage))
+		assert.ErrorIs(t, err, ErrBudgetExceeded)
+		assert.Equal(t, []*schema.Message{msgs[0], msgs[5]}, kept)
+	})
+
+	t.Run("counter error", func(t *testing.T) {
+		_, err := Trim(ctx, msgs, "", 10, WithCounter(CounterFunc(func(ctx context.Context, messages []*schema.Message, model string) (int, error) {
+			return 0, errors.New("boom")
+		})))
+		assert.ErrorContains(t, err, "boom")
+	})
+
+	t.Run("default counter", func(t *testing.T) {
+		kept, err := Trim(ctx, msgs, "gpt-4o", 1000)
+		assert.NoError(t, err)
+		assert.Len(t, kept, len(msgs))
+	})
+}
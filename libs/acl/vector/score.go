@@ -0,0 +1,68 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package vector
+
+import "math"
+
+// Metric is how a vector store scores a stored vector against the query vector.
+type Metric string
+
+const (
+	// MetricCosine is the cosine similarity in [-1,1], eg. milvus COSINE, vikingdb cosine, qdrant Cosine.
+	MetricCosine Metric = "cosine"
+	// MetricCosineDistance is 1 - cosine similarity in [0,2], eg. redis COSINE.
+	MetricCosineDistance Metric = "cosine_distance"
+	// MetricShiftedCosine is the cosine similarity shifted by 1 into [0,2], eg. the es8 cosineSimilarity script.
+	MetricShiftedCosine Metric = "shifted_cosine"
+	// MetricInnerProduct is the unbounded inner product, eg. milvus IP, vikingdb ip, qdrant Dot.
+	MetricInnerProduct Metric = "inner_product"
+	// MetricL2 is the euclidean (or squared euclidean) distance, eg. milvus L2, vikingdb l2, qdrant Euclid.
+	MetricL2 Metric = "l2"
+	// MetricL1 is the manhattan distance, eg. qdrant Manhattan.
+	MetricL1 Metric = "l1"
+	// MetricHamming is the hamming distance between binary vectors.
+	MetricHamming Metric = "hamming"
+	// MetricJaccard is the jaccard (or tanimoto) distance in [0,1] between binary vectors.
+	MetricJaccard Metric = "jaccard"
+	// MetricUnit is a similarity already in [0,1], eg. the es8 knn scores.
+	MetricUnit Metric = "unit"
+	// MetricRelevance is a non-negative unbounded relevance score, eg. BM25.
+	MetricRelevance Metric = "relevance"
+)
+
+// NormalizeScore converts a score of metric to a similarity in [0,1], where higher is more similar.
+// Distances are turned into similarities, unbounded scores are squashed monotonically, so the order
+// of the results of one metric is kept. Unknown metrics return the score clamped to [0,1].
+func NormalizeScore(metric Metric, score float64) float64 {
+	switch metric {
+	case MetricCosine:
+		score = (score + 1) / 2
+	case MetricCosineDistance:
+		score = 1 - score/2
+	case MetricShiftedCosine:
+		score = score / 2
+	case MetricInnerProduct:
+		score = 1 / (1 + math.Exp(-score))
+	case MetricL2, MetricL1, MetricHamming:
+		score = 1 / (1 + math.Max(score, 0))
+	case MetricJaccard:
+		score = 1 - score
+	case MetricRelevance:
+		score = math.Max(score, 0)
+		score = score / (1 + score)
+	}
+	return math.Min(math.Max(score, 0), 1)
+}
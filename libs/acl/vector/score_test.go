@@ -0,0 +1,55 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package vector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeScore(t *testing.T) {
+	cases := []struct {
+		metric Metric
+		score  float64
+		want   float64
+	}{
+		{MetricCosine, 1, 1},
+		{MetricCosine, -1, 0},
+		{MetricCosine, 0, 0.5},
+		{MetricCosineDistance, 0, 1},
+		{MetricCosineDistance, 2, 0},
+		{MetricShiftedCosine, 1.5, 0.75},
+		{MetricInnerProduct, 0, 0.5},
+		{MetricL2, 0, 1},
+		{MetricL2, 3, 0.25},
+		{MetricL1, 1, 0.5},
+		{MetricHamming, 4, 0.2},
+		{MetricJaccard, 0.25, 0.75},
+		{MetricUnit, 0.3, 0.3},
+		{MetricUnit, 1.2, 1},
+		{MetricRelevance, 3, 0.75},
+		{MetricRelevance, -1, 0},
+		{Metric("unknown"), -0.5, 0},
+	}
+	for _, c := range cases {
+		assert.InDelta(t, c.want, NormalizeScore(c.metric, c.score), 1e-9, "%s %v", c.metric, c.score)
+	}
+
+	// the order of the results is kept
+	assert.Greater(t, NormalizeScore(MetricInnerProduct, 5), NormalizeScore(MetricInnerProduct, 2))
+	assert.Greater(t, NormalizeScore(MetricL2, 0.1), NormalizeScore(MetricL2, 0.2))
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package vector post-processes embedding vectors on the client, so that the vectors of different
+// embedding models can be stored in the same fixed dimension schema, and normalizes the scores of
+// vector stores, so that the results of different stores and metrics can be compared.
+package vector
+
+import "math"
+
+// Normalize scales v to unit L2 norm in place and returns it, a zero vector is returned unchanged.
+func Normalize(v []float64) []float64 {
+	var sum float64
+	for _, x := range v {
+		sum += x * x
+	}
+	if sum == 0 {
+		return v
+	}
+	norm := math.Sqrt(sum)
+	for i := range v {
+		v[i] /= norm
+	}
+	return v
+}
+
+// Resize truncates v to dimensions, or pads it with zeros when it is shorter.
+// Truncation keeps the meaning of the vector only for Matryoshka models (eg. text-embedding-3,
+// gemini-embedding-001, jina-embeddings-v3), normalize the result as the truncated vector is no longer unit length.
+func Resize(v []float64, dimensions int) []float64 {
+	if len(v) >= dimensions {
+		return v[:dimensions]
+	}
+	padded := make([]float64, dimensions)
+	copy(padded, v)
+	return padded
+}
+
+// PostProcess resizes every vector to dimensions if it is set, then L2-normalizes it if normalize is true.
+// The vectors are modified in place, nil vectors (eg. failed inputs of a batch) are kept nil.
+func PostProcess(vectors [][]float64, dimensions *int, normalize bool) [][]float64 {
+	if (dimensions == nil || *dimensions <= 0) && !normalize {
+		return vectors
+	}
+	for i := range vectors {
+		if vectors[i] == nil {
+			continue
+		}
+		if dimensions != nil && *dimensions > 0 {
+			vectors[i] = Resize(vectors[i], *dimensions)
+		}
+		if normalize {
+			vectors[i] = Normalize(vectors[i])
+		}
+	}
+	return vectors
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vector
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, []float64{0.6, 0.8}, Normalize([]float64{3, 4}))
+	assert.Equal(t, []float64{0, 0}, Normalize([]float64{0, 0}))
+}
+
+func TestResize(t *testing.T) {
+	assert.Equal(t, []float64{1, 2}, Resize([]float64{1, 2, 3}, 2))
+	assert.Equal(t, []float64{1, 2, 0, 0}, Resize([]float64{1, 2}, 4))
+}
+
+func TestPostProcess(t *testing.T) {
+	dims := 2
+	vectors := PostProcess([][]float64{{3, 4, 12}, {1}}, &dims, true)
+	assert.Equal(t, [][]float64{{0.6, 0.8}, {1, 0}}, vectors)
+
+	for _, v := range vectors {
+		var sum float64
+		for _, x := range v {
+			sum += x * x
+		}
+		assert.InDelta(t, 1, math.Sqrt(sum), 1e-9)
+	}
+
+	assert.Equal(t, [][]float64{nil, {1, 0}}, PostProcess([][]float64{nil, {2}}, &dims, true))
+
+	raw := [][]float64{{3, 4}}
+	assert.Equal(t, [][]float64{{3, 4}}, PostProcess(raw, nil, false))
+}